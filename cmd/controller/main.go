@@ -28,6 +28,7 @@ import (
 	"github.com/tektoncd/pipeline/pkg/reconciler/pipelinerun"
 	"github.com/tektoncd/pipeline/pkg/reconciler/resolutionrequest"
 	"github.com/tektoncd/pipeline/pkg/reconciler/taskrun"
+	"github.com/tektoncd/pipeline/pkg/workspace"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/rest"
 	"k8s.io/utils/clock"
@@ -64,6 +65,7 @@ func main() {
 	flag.StringVar(&opts.Images.ShellImage, "shell-image", "", "The container image containing a shell")
 	flag.StringVar(&opts.Images.ShellImageWin, "shell-image-win", "", "The container image containing a windows shell")
 	flag.StringVar(&opts.Images.WorkingDirInitImage, "workingdirinit-image", "", "The container image containing our working dir init binary.")
+	flag.StringVar(&opts.Images.OCIImageFetchImage, "ociimagefetch-image", "", "The container image containing the binary fetching OCI images into workspaces.")
 	flag.DurationVar(&opts.ResyncPeriod, "resync-period", controller.DefaultResyncPeriod, "The period between two resync run (going through all objects)")
 
 	// This parses flags.
@@ -106,12 +108,15 @@ func main() {
 		log.Fatal(http.ListenAndServe(":"+port, mux)) // #nosec G114 -- see https://github.com/securego/gosec#available-rules
 	}()
 
-	ctx = filteredinformerfactory.WithSelectors(ctx, v1beta1.ManagedByLabelKey)
+	ctx = filteredinformerfactory.WithSelectors(ctx, v1beta1.ManagedByLabelKey, workspace.AffinityAssistantPodSelector)
 	ctx = controller.WithResyncPeriod(ctx, opts.ResyncPeriod)
 
 	sharedmain.MainWithConfig(ctx, ControllerLogKey, cfg,
 		taskrun.NewController(opts, clock.RealClock{}),
 		pipelinerun.NewController(opts, clock.RealClock{}),
+		taskrun.NewFinalStatusController(clock.RealClock{}),
+		taskrun.NewResultsFinalizerController(),
+		pipelinerun.NewFinalStatusController(clock.RealClock{}),
 		resolutionrequest.NewController(clock.RealClock{}),
 	)
 }
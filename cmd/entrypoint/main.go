@@ -46,6 +46,7 @@ var (
 	terminationPath     = flag.String("termination_path", "/tekton/termination", "If specified, file to write upon termination")
 	results             = flag.String("results", "", "If specified, list of file names that might contain task results")
 	stepResults         = flag.String("step_results", "", "step results if specified")
+	sidecarResults      = flag.String("sidecar_results", "", "If specified, list of sidecar results of the form <sidecarName>.<resultName> to report once the step is done")
 	whenExpressions     = flag.String("when_expressions", "", "when expressions if specified")
 	timeout             = flag.Duration("timeout", time.Duration(0), "If specified, sets timeout for step")
 	stdoutPath          = flag.String("stdout_path", "", "If specified, file to copy stdout to")
@@ -54,13 +55,17 @@ var (
 	debugBeforeStep     = flag.Bool("debug_before_step", false, "If specified, wait for a debugger to attach before executing the step")
 	onError             = flag.String("on_error", "", "Set to \"continue\" to ignore an error and continue when a container terminates with a non-zero exit code."+
 		" Set to \"stopAndFail\" to declare a failure with a step error and stop executing the rest of the steps.")
+	retries                    = flag.Int("retries", 0, "If specified, the number of times to run the command again when it exits with a non-zero exit code")
 	stepMetadataDir            = flag.String("step_metadata_dir", "", "If specified, create directory to store the step metadata e.g. /tekton/steps/<step-name>/")
 	resultExtractionMethod     = flag.String("result_from", entrypoint.ResultExtractionMethodTerminationMessage, "The method using which to extract results from tasks. Default is using the termination message.")
 	compressTerminationMessage = flag.Bool("compress_termination_message", false, "If true, compress termination messages with flate to fit more results in the 4KB Kubernetes limit.")
+	scriptSHA256               = flag.String("script_sha256", "", "If specified, the hex-encoded SHA-256 digest the script run by the step must have")
+	failFastFile               = flag.String("fail_fast_file", "", "If specified, file to write when the step fails, whose presence makes the steps waiting to run skip at once")
 )
 
 const (
 	defaultWaitPollingInterval = time.Second
+	defaultRetryBackoff        = time.Second
 	TektonPlatformCommandsEnv  = "TEKTON_PLATFORM_COMMANDS"
 )
 
@@ -133,6 +138,7 @@ func main() {
 		WaitFiles:       strings.Split(*waitFiles, ","),
 		WaitFileContent: *waitFileContent,
 		PostFile:        *postFile,
+		FailFastFile:    *failFastFile,
 		TerminationPath: *terminationPath,
 		Waiter:          &realWaiter{waitPollingInterval: defaultWaitPollingInterval, breakpointOnFailure: *breakpointOnFailure},
 		Runner: &realRunner{
@@ -142,15 +148,19 @@ func main() {
 		PostWriter:                 &realPostWriter{},
 		Results:                    strings.Split(*results, ","),
 		StepResults:                strings.Split(*stepResults, ","),
+		SidecarResults:             strings.Split(*sidecarResults, ","),
 		Timeout:                    timeout,
 		StepWhenExpressions:        when,
 		BreakpointOnFailure:        *breakpointOnFailure,
 		DebugBeforeStep:            *debugBeforeStep,
 		OnError:                    *onError,
+		Retries:                    *retries,
+		RetryBackoff:               defaultRetryBackoff,
 		StepMetadataDir:            *stepMetadataDir,
 		SpireWorkloadAPI:           spireWorkloadAPI,
 		ResultExtractionMethod:     *resultExtractionMethod,
 		CompressTerminationMessage: *compressTerminationMessage,
+		ScriptSHA256:               *scriptSHA256,
 	}
 
 	// Copy any creds injected by the controller into the $HOME directory of the current
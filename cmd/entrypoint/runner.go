@@ -76,10 +76,14 @@ func (rr *realRunner) Run(ctx context.Context, args ...string) error {
 	}
 	name, args := args[0], args[1:]
 
-	// Receive system signals on "rr.signals"
-	if rr.signals == nil {
+	// Receive system signals on "rr.signals", on a new channel when the command of a step
+	// with retries is run again
+	rr.Lock()
+	if rr.signals == nil || rr.signalsClosed {
 		rr.signals = make(chan os.Signal, 1)
+		rr.signalsClosed = false
 	}
+	rr.Unlock()
 	defer rr.close()
 	signal.Notify(rr.signals)
 	defer signal.Reset()
@@ -20,8 +20,12 @@ import (
 	"context"
 	"errors"
 	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
+	"sync"
 	"testing"
+	"testing/synctest"
 	"time"
 
 	"github.com/tektoncd/pipeline/pkg/entrypoint"
@@ -358,3 +362,74 @@ func TestRealWaiterWaitContextMissingFile(t *testing.T) {
 		}
 	}
 }
+
+// failingRunner fails the command of the first step.
+type failingRunner struct{ fail bool }
+
+func (r failingRunner) Run(context.Context, ...string) error {
+	if r.fail {
+		return errors.New("step failed")
+	}
+	return nil
+}
+
+func TestRealWaiterFailFast(t *testing.T) {
+	const steps = 10
+	// runSteps runs the steps of a pod whose first step fails, and returns how long the pod took
+	// until all of its steps exited. The containers of the steps start one after the other, the
+	// later steps first, so that each step polls its wait file just before the previous step
+	// writes its post file, as can happen when their containers start at different times.
+	// The steps run in a bubble, whose fake clock only advances once all of them are waiting, so that the
+	// durations don't depend on how long the goroutines take to be scheduled.
+	runSteps := func(t *testing.T, failFast bool) (elapsed time.Duration) {
+		t.Helper()
+		synctest.Test(t, func(t *testing.T) {
+			dir := t.TempDir()
+			postFile := func(i int) string { return filepath.Join(dir, "run", strconv.Itoa(i), "out") }
+			errs := make([]error, steps)
+			var wg sync.WaitGroup
+			start := time.Now()
+			for i := range steps {
+				e := entrypoint.Entrypointer{
+					Command:         []string{"step"},
+					PostFile:        postFile(i),
+					TerminationPath: filepath.Join(dir, "termination-"+strconv.Itoa(i)),
+					StepMetadataDir: filepath.Join(dir, "run", strconv.Itoa(i), "status"),
+					Waiter:          (&realWaiter{}).setWaitPollingInterval(testWaitPollingInterval),
+					Runner:          failingRunner{fail: i == 0},
+					PostWriter:      &realPostWriter{},
+				}
+				if i > 0 {
+					e.WaitFiles = []string{postFile(i - 1)}
+				}
+				if failFast {
+					e.FailFastFile = filepath.Join(dir, "run", "fail-fast", "failed")
+				}
+				wg.Go(func() {
+					time.Sleep(time.Duration(steps-i) * testWaitPollingInterval / steps)
+					errs[i] = e.Go()
+				})
+			}
+			wg.Wait()
+			elapsed = time.Since(start)
+			for i, err := range errs[1:] {
+				if !errors.Is(err, entrypoint.ErrSkipPreviousStepFailed) {
+					t.Errorf("step %d: got %v, want %v", i+1, err, entrypoint.ErrSkipPreviousStepFailed)
+				}
+			}
+		})
+		return elapsed
+	}
+
+	cascade := runSteps(t, false)
+	failFast := runSteps(t, true)
+	t.Logf("The %d steps took %v to exit with fail-fast, %v without", steps, failFast, cascade)
+	// Without fail-fast each step is skipped once the previous one was, one polling interval after the other,
+	// with fail-fast all the steps are skipped within a polling interval.
+	if failFast >= cascade/2 {
+		t.Errorf("the steps took %v to exit with fail-fast, want less than half of the %v they took without", failFast, cascade)
+	}
+	if failFast >= 4*testWaitPollingInterval {
+		t.Errorf("the steps took %v to exit with fail-fast, want less than %v", failFast, 4*testWaitPollingInterval)
+	}
+}
@@ -0,0 +1,43 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/tektoncd/pipeline/internal/ociimagefetch"
+)
+
+func main() {
+	var image string
+	var dest string
+
+	flag.StringVar(&image, "image", "", "The reference of the OCI image or artifact to fetch.")
+	flag.StringVar(&dest, "dest", "", "The directory the content of the image is unpacked into.")
+	flag.Parse()
+
+	if image == "" || dest == "" {
+		log.Fatal("both -image and -dest must be set")
+	}
+	// The credentials of the pull secret, if any, are read from $DOCKER_CONFIG/config.json.
+	if err := ociimagefetch.Fetch(context.Background(), image, dest, authn.DefaultKeychain); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -17,8 +17,10 @@ limitations under the License.
 package main
 
 import (
+	"bytes"
 	"encoding/json"
 	"flag"
+	"io"
 	"log"
 	"os"
 	"os/signal"
@@ -34,14 +36,18 @@ func main() {
 	var resultsDir string
 	var resultNames string
 	var stepResultsStr string
+	var sidecarResultsStr string
 	var stepNames string
 	var kubernetesNativeSidecar bool
+	var terminationPath string
 
 	flag.StringVar(&resultsDir, "results-dir", pipeline.DefaultResultPath, "Path to the results directory. Default is /tekton/results")
 	flag.StringVar(&resultNames, "result-names", "", "comma separated result names to expect from the steps running in the pod. eg. foo,bar,baz")
 	flag.StringVar(&stepResultsStr, "step-results", "", "json containing a map of step Name as key and list of result Names. eg. {\"stepName\":[\"foo\",\"bar\",\"baz\"]}")
+	flag.StringVar(&sidecarResultsStr, "sidecar-results", "{}", "json containing a map of sidecar Name as key and list of result Names. eg. {\"sidecarName\":[\"foo\",\"bar\",\"baz\"]}")
 	flag.StringVar(&stepNames, "step-names", "", "comma separated step names. eg. foo,bar,baz")
 	flag.BoolVar(&kubernetesNativeSidecar, "kubernetes-sidecar-mode", false, "If true, wait indefinitely after processing results (for Kubernetes native sidecar support)")
+	flag.StringVar(&terminationPath, "termination-path", "", "If set, the results are written to this termination message file instead of stdout.")
 	flag.Parse()
 
 	var done chan bool
@@ -75,7 +81,16 @@ func main() {
 	if err := json.Unmarshal([]byte(stepResultsStr), &expectedStepResults); err != nil {
 		log.Fatal(err)
 	}
-	err := sidecarlogresults.LookForResults(os.Stdout, pod.RunDir, resultsDir, expectedResults, pipeline.StepsDir, expectedStepResults)
+	expectedSidecarResults := map[string][]string{}
+	if err := json.Unmarshal([]byte(sidecarResultsStr), &expectedSidecarResults); err != nil {
+		log.Fatal(err)
+	}
+	var out io.Writer = os.Stdout
+	var terminationMessage bytes.Buffer
+	if terminationPath != "" {
+		out = &terminationMessage
+	}
+	err := sidecarlogresults.LookForResults(out, pod.RunDir, resultsDir, expectedResults, pipeline.StepsDir, expectedStepResults, pipeline.SidecarsDir, expectedSidecarResults)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -84,10 +99,15 @@ func main() {
 	if len(stepNames) > 0 {
 		names = strings.Split(stepNames, ",")
 	}
-	err = sidecarlogresults.LookForArtifacts(os.Stdout, names, pod.RunDir)
+	err = sidecarlogresults.LookForArtifacts(out, names, pod.RunDir)
 	if err != nil {
 		log.Fatal(err)
 	}
+	if terminationPath != "" {
+		if err := sidecarlogresults.WriteTerminationMessage(terminationPath, terminationMessage.Bytes()); err != nil {
+			log.Fatal(err)
+		}
+	}
 
 	if kubernetesNativeSidecar && done != nil {
 		// Wait for a signal to be received.
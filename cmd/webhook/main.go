@@ -31,6 +31,9 @@ import (
 	"github.com/tektoncd/pipeline/pkg/apis/resolution"
 	resolutionv1alpha1 "github.com/tektoncd/pipeline/pkg/apis/resolution/v1alpha1"
 	resolutionv1beta1 "github.com/tektoncd/pipeline/pkg/apis/resolution/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
+	pipelineclient "github.com/tektoncd/pipeline/pkg/client/injection/client"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/controller"
@@ -106,6 +109,7 @@ func newValidationAdmissionController(name string) func(context.Context, configm
 		// Decorate contexts with the current state of the config.
 		store := defaultconfig.NewStore(logging.FromContext(ctx).Named("config-store"))
 		store.WatchConfigs(cmw)
+		resultType := runRefResultType(pipelineclient.Get(ctx))
 		return validation.NewAdmissionController(ctx,
 
 			// Name of the validation webhook, it is based on the value of the environment variable WEBHOOK_ADMISSION_CONTROLLER_NAME
@@ -120,7 +124,7 @@ func newValidationAdmissionController(name string) func(context.Context, configm
 
 			// A function that infuses the context passed to Validate/SetDefaults with custom metadata.
 			func(ctx context.Context) context.Context {
-				return store.ToContext(ctx)
+				return v1.WithRunRefResultType(store.ToContext(ctx), resultType)
 			},
 
 			// Whether to disallow unknown fields.
@@ -129,6 +133,23 @@ func newValidationAdmissionController(name string) func(context.Context, configm
 	}
 }
 
+// runRefResultType returns the type of the result of another PipelineRun that the param of a PipelineRun
+// is taken from with runRef, for it to be checked when the PipelineRun is admitted.
+func runRefResultType(client versioned.Interface) v1.RunRefResultTypeFunc {
+	return func(ctx context.Context, namespace string, ref v1.PipelineRunResultRef) (v1.ParamType, bool) {
+		pr, err := client.TektonV1().PipelineRuns(namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+		if err != nil {
+			return "", false
+		}
+		for _, r := range pr.Status.Results {
+			if r.Name == ref.Result {
+				return r.Value.Type, true
+			}
+		}
+		return "", false
+	}
+}
+
 func newConfigValidationController(name string) func(context.Context, configmap.Watcher) *controller.Impl {
 	return func(ctx context.Context, cmw configmap.Watcher) *controller.Impl {
 		return configmaps.NewAdmissionController(ctx,
@@ -0,0 +1,156 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ociimagefetch fetches the content of OCI images and artifacts into
+// the workspaces bound to them, on clusters without image volumes.
+package ociimagefetch
+
+import (
+	"archive/tar"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+	"os"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+)
+
+// Fetch pulls the image or artifact with the given reference, using the credentials of keychain, and
+// unpacks its flattened filesystem into the dest directory.
+func Fetch(ctx context.Context, reference, dest string, keychain authn.Keychain) error {
+	ref, err := name.ParseReference(reference)
+	if err != nil {
+		return fmt.Errorf("invalid reference %q: %w", reference, err)
+	}
+	img, err := remote.Image(ref, remote.WithContext(ctx), remote.WithAuthFromKeychain(keychain))
+	if err != nil {
+		return fmt.Errorf("failed to pull %s: %w", reference, err)
+	}
+	fsReader := mutate.Extract(img)
+	defer fsReader.Close()
+	if err := Unpack(fsReader, dest); err != nil {
+		return fmt.Errorf("failed to unpack %s into %s: %w", reference, dest, err)
+	}
+	return nil
+}
+
+// Unpack writes the regular files, directories and symbolic links of the tar stream r into the dest
+// directory. The entries are written within dest only: an entry whose path, or the target of a link
+// it goes through, is outside of dest fails the unpacking, and existing files are never overwritten,
+// so that a symbolic link of the stream can't redirect the writing of a later entry to another file.
+func Unpack(r io.Reader, dest string) error {
+	root, err := os.OpenRoot(dest)
+	if err != nil {
+		return err
+	}
+	defer root.Close()
+
+	// The modes of the directories are set once their content is written, which their modes may forbid.
+	dirModes := map[string]fs.FileMode{}
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		p, err := entryPath(hdr.Name)
+		if err != nil {
+			return err
+		}
+		if p == "." {
+			continue
+		}
+		mode := fs.FileMode(hdr.Mode).Perm()
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := root.MkdirAll(p, 0o755); err != nil {
+				return err
+			}
+			dirModes[p] = mode
+		case tar.TypeReg:
+			if err := root.MkdirAll(path.Dir(p), 0o755); err != nil {
+				return err
+			}
+			if err := writeFile(root, p, mode, tr); err != nil {
+				return err
+			}
+		case tar.TypeSymlink:
+			if err := root.MkdirAll(path.Dir(p), 0o755); err != nil {
+				return err
+			}
+			if err := root.Symlink(hdr.Linkname, p); err != nil {
+				return err
+			}
+		case tar.TypeLink:
+			target, err := entryPath(hdr.Linkname)
+			if err != nil {
+				return err
+			}
+			if err := root.MkdirAll(path.Dir(p), 0o755); err != nil {
+				return err
+			}
+			if err := root.Link(target, p); err != nil {
+				return err
+			}
+		default:
+			// Devices, fifos and the like have no use in a workspace.
+			continue
+		}
+	}
+	// The subdirectories come first, in case their parents can't be traversed anymore.
+	dirs := slices.Sorted(maps.Keys(dirModes))
+	slices.Reverse(dirs)
+	for _, p := range dirs {
+		if err := root.Chmod(p, dirModes[p]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// entryPath returns the path of the tar entry with the given name, relative to the directory the
+// stream is unpacked into.
+func entryPath(entryName string) (string, error) {
+	p := path.Clean(strings.TrimPrefix(entryName, "/"))
+	if p == ".." || strings.HasPrefix(p, "../") {
+		return "", fmt.Errorf("entry %q is outside of the destination directory", entryName)
+	}
+	return p, nil
+}
+
+func writeFile(root *os.Root, p string, mode fs.FileMode, r io.Reader) error {
+	f, err := root.OpenFile(p, os.O_WRONLY|os.O_CREATE|os.O_EXCL, mode)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
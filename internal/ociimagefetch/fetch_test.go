@@ -0,0 +1,159 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ociimagefetch
+
+import (
+	"archive/tar"
+	"bytes"
+	"io"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+	"github.com/tektoncd/pipeline/test/diff"
+)
+
+type entry struct {
+	name, linkname, content string
+	typeflag                byte
+	mode                    int64
+}
+
+func layerTar(t *testing.T, entries ...entry) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for _, e := range entries {
+		mode := e.mode
+		if mode == 0 {
+			mode = 0o644
+		}
+		hdr := &tar.Header{Name: e.name, Linkname: e.linkname, Typeflag: e.typeflag, Mode: mode, Size: int64(len(e.content))}
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(e.content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestFetch(t *testing.T) {
+	s := httptest.NewServer(registry.New())
+	defer s.Close()
+	u, err := url.Parse(s.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	content := layerTar(t,
+		entry{name: "config/", typeflag: tar.TypeDir, mode: 0o755},
+		entry{name: "config/app.yaml", typeflag: tar.TypeReg, content: "replicas: 3\n"},
+		entry{name: "current", typeflag: tar.TypeSymlink, linkname: "config/app.yaml"},
+	)
+	layer, err := tarball.LayerFromOpener(func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(content)), nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	img, err := mutate.AppendLayers(empty.Image, layer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reference := u.Host + "/config/app:v1"
+	ref, err := name.ParseReference(reference)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := remote.Write(ref, img); err != nil {
+		t.Fatal(err)
+	}
+
+	dest := t.TempDir()
+	if err := Fetch(t.Context(), reference, dest, authn.DefaultKeychain); err != nil {
+		t.Fatalf("Fetch() = %v", err)
+	}
+	got, err := os.ReadFile(filepath.Join(dest, "current"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := cmp.Diff("replicas: 3\n", string(got)); d != "" {
+		t.Errorf("fetched content diff %s", diff.PrintWantGot(d))
+	}
+
+	if err := Fetch(t.Context(), u.Host+"/config/app:missing", t.TempDir(), authn.DefaultKeychain); err == nil {
+		t.Error("Fetch() of a missing image succeeded, want an error")
+	}
+}
+
+func TestUnpack_Invalid(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		entries []entry
+	}{{
+		name:    "path outside of the destination",
+		entries: []entry{{name: "../escaped", typeflag: tar.TypeReg, content: "x"}},
+	}, {
+		name: "writing through a symbolic link outside of the destination",
+		entries: []entry{
+			{name: "etc", typeflag: tar.TypeSymlink, linkname: "/etc"},
+			{name: "etc/escaped", typeflag: tar.TypeReg, content: "x"},
+		},
+	}, {
+		name: "writing through a symbolic link to another file",
+		entries: []entry{
+			{name: "target", typeflag: tar.TypeReg, content: "original"},
+			{name: "link", typeflag: tar.TypeSymlink, linkname: "target"},
+			{name: "link", typeflag: tar.TypeReg, content: "overwritten"},
+		},
+	}, {
+		name:    "hard link outside of the destination",
+		entries: []entry{{name: "passwd", typeflag: tar.TypeLink, linkname: "../../etc/passwd"}},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			parent := t.TempDir()
+			dest := filepath.Join(parent, "dest")
+			if err := os.Mkdir(dest, 0o755); err != nil {
+				t.Fatal(err)
+			}
+			if err := Unpack(bytes.NewReader(layerTar(t, tc.entries...)), dest); err == nil {
+				t.Error("Unpack() succeeded, want an error")
+			}
+			if _, err := os.Stat(filepath.Join(parent, "escaped")); !os.IsNotExist(err) {
+				t.Errorf("an entry was written outside of the destination: %v", err)
+			}
+			if b, err := os.ReadFile(filepath.Join(dest, "target")); err == nil && string(b) != "original" {
+				t.Errorf("an entry was written through a symbolic link: %q", b)
+			}
+		})
+	}
+}
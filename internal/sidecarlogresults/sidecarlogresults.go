@@ -32,9 +32,11 @@ import (
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"github.com/tektoncd/pipeline/pkg/result"
+	"github.com/tektoncd/pipeline/pkg/termination"
 	"golang.org/x/sync/errgroup"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/kubernetes"
+	"knative.dev/pkg/logging"
 )
 
 // ErrSizeExceeded indicates that the result exceeded its maximum allowed size
@@ -46,8 +48,9 @@ var (
 type SidecarLogResultType string
 
 const (
-	taskResultType SidecarLogResultType = "task"
-	stepResultType SidecarLogResultType = "step"
+	taskResultType    SidecarLogResultType = "task"
+	stepResultType    SidecarLogResultType = "step"
+	sidecarResultType SidecarLogResultType = "sidecar"
 
 	stepArtifactType           SidecarLogResultType = "stepArtifact"
 	taskArtifactType           SidecarLogResultType = "taskArtifact"
@@ -133,7 +136,7 @@ func readResults(resultsDir, resultFile, stepName string, resultType SidecarLogR
 		return SidecarLogResult{}, fmt.Errorf("error reading the results file %w", err)
 	}
 	resultName := resultFile
-	if resultType == stepResultType {
+	if resultType == stepResultType || resultType == sidecarResultType {
 		resultName = createSidecarResultName(stepName, resultFile)
 	}
 	return SidecarLogResult{
@@ -145,8 +148,9 @@ func readResults(resultsDir, resultFile, stepName string, resultType SidecarLogR
 
 // LookForResults waits for results to be written out by the steps
 // in their results path and prints them in a structured way to its
-// stdout so that the reconciler can parse those logs.
-func LookForResults(w io.Writer, runDir string, resultsDir string, resultNames []string, stepResultsDir string, stepResults map[string][]string) error {
+// stdout so that the reconciler can parse those logs, along with the
+// results written by the sidecars by then.
+func LookForResults(w io.Writer, runDir string, resultsDir string, resultNames []string, stepResultsDir string, stepResults map[string][]string, sidecarResultsDir string, sidecarResults map[string][]string) error {
 	interval, err := getSidecarLogPollingInterval()
 	if err != nil {
 		return fmt.Errorf("error getting polling interval: %w", err)
@@ -188,6 +192,24 @@ func LookForResults(w io.Writer, runDir string, resultsDir string, resultNames [
 		}
 	}
 
+	for sName, sresults := range sidecarResults {
+		for _, resultName := range sresults {
+			sidecarResultsDir := filepath.Join(sidecarResultsDir, sName)
+
+			g.Go(func() error {
+				newResult, err := readResults(sidecarResultsDir, resultName, sName, sidecarResultType)
+				if err != nil {
+					return err
+				}
+				if newResult.Name == "" {
+					return nil
+				}
+				results <- newResult
+				return nil
+			})
+		}
+	}
+
 	channelGroup := new(errgroup.Group)
 	channelGroup.Go(func() error {
 		if err := g.Wait(); err != nil {
@@ -243,23 +265,101 @@ func LookForArtifacts(w io.Writer, names []string, runDir string) error {
 	return nil
 }
 
-// GetResultsFromSidecarLogs extracts results from the logs of the results sidecar
-func GetResultsFromSidecarLogs(ctx context.Context, clientset kubernetes.Interface, namespace string, name string, container string, podPhase corev1.PodPhase) ([]result.RunResult, error) {
+// WriteTerminationMessage writes the results printed by LookForResults and LookForArtifacts to the
+// termination message of the results sidecar at path, from which they are extracted when "results-from"
+// is set to "sidecar-volume". The results can't exceed the size of the termination message of a container.
+func WriteTerminationMessage(path string, results []byte) error {
+	if len(results) > termination.MaxContainerTerminationMessageLength {
+		return fmt.Errorf("%d bytes %w of %d bytes of a termination message", len(results), ErrSizeExceeded, termination.MaxContainerTerminationMessageLength)
+	}
+	if err := os.WriteFile(path, results, 0o666); err != nil {
+		return fmt.Errorf("error writing the termination message %w", err)
+	}
+	return nil
+}
+
+// GetResultsFromTerminationMessage extracts results from the termination message of the results sidecar,
+// written by WriteTerminationMessage.
+func GetResultsFromTerminationMessage(ctx context.Context, message string) ([]result.RunResult, error) {
+	maxResultLimit := config.FromContextOrDefaults(ctx).FeatureFlags.MaxResultSize
+	return extractResultsFromLogs(strings.NewReader(message), []result.RunResult{}, maxResultLimit)
+}
+
+// GetResultsFromSidecarLogs extracts results from the logs of the results sidecar. If the container of
+// the results sidecar restarted, the results emitted by its previous instance before the restart are
+// extracted too, the latest occurrence of each result taking precedence.
+func GetResultsFromSidecarLogs(ctx context.Context, clientset kubernetes.Interface, namespace string, name string, container string, podPhase corev1.PodPhase, restartCount int32) ([]result.RunResult, error) {
+	return getResultsFromSidecarLogs(ctx, kubeLogs(clientset), namespace, name, container, podPhase, restartCount)
+}
+
+// logStreamer streams the logs of a container of a pod, or the logs of its previous instance if
+// previous is true.
+type logStreamer func(ctx context.Context, namespace, name, container string, previous bool) (io.ReadCloser, error)
+
+// kubeLogs streams the logs of the containers from the Kubernetes API.
+func kubeLogs(clientset kubernetes.Interface) logStreamer {
+	return func(ctx context.Context, namespace, name, container string, previous bool) (io.ReadCloser, error) {
+		podLogOpts := corev1.PodLogOptions{Container: container, Previous: previous}
+		return clientset.CoreV1().Pods(namespace).GetLogs(name, &podLogOpts).Stream(ctx)
+	}
+}
+
+func getResultsFromSidecarLogs(ctx context.Context, logs logStreamer, namespace string, name string, container string, podPhase corev1.PodPhase, restartCount int32) ([]result.RunResult, error) {
 	sidecarLogResults := []result.RunResult{}
 	if podPhase == corev1.PodPending {
 		return sidecarLogResults, nil
 	}
-	podLogOpts := corev1.PodLogOptions{Container: container}
-	req := clientset.CoreV1().Pods(namespace).GetLogs(name, &podLogOpts)
-	sidecarLogs, err := req.Stream(ctx)
+	maxResultLimit := config.FromContextOrDefaults(ctx).FeatureFlags.MaxResultSize
+	// Only the logs of the last instance before the current one are kept by the kubelet, the
+	// results emitted before the previous restarts are lost. If the logs of the previous instance
+	// can't be read either, e.g. because they were removed with it, only the results of the
+	// current instance are extracted.
+	if restartCount > 0 {
+		previous, err := readResultsFromLogs(ctx, logs, namespace, name, container, true, maxResultLimit)
+		if err != nil {
+			logging.FromContext(ctx).Warnf("Failed to read the logs of the previous instance of container %s of pod %s/%s, only the results of its current instance are extracted: %v", container, namespace, name, err)
+		} else {
+			sidecarLogResults = previous
+		}
+	}
+	current, err := readResultsFromLogs(ctx, logs, namespace, name, container, false, maxResultLimit)
+	if restartCount == 0 {
+		return current, err
+	}
+	return latestResults(append(sidecarLogResults, current...)), err
+}
+
+func readResultsFromLogs(ctx context.Context, logs logStreamer, namespace string, name string, container string, previous bool, maxResultLimit int) ([]result.RunResult, error) {
+	sidecarLogResults := []result.RunResult{}
+	sidecarLogs, err := logs(ctx, namespace, name, container, previous)
 	if err != nil {
 		return sidecarLogResults, err
 	}
 	defer sidecarLogs.Close()
-	maxResultLimit := config.FromContextOrDefaults(ctx).FeatureFlags.MaxResultSize
 	return extractResultsFromLogs(sidecarLogs, sidecarLogResults, maxResultLimit)
 }
 
+// latestResults de-duplicates the results by key and type, keeping the value of the latest occurrence
+// of each result at the position of its first occurrence.
+func latestResults(results []result.RunResult) []result.RunResult {
+	type resultKey struct {
+		key        string
+		resultType result.ResultType
+	}
+	latest := []result.RunResult{}
+	index := map[resultKey]int{}
+	for _, r := range results {
+		k := resultKey{key: r.Key, resultType: r.ResultType}
+		if i, ok := index[k]; ok {
+			latest[i] = r
+			continue
+		}
+		index[k] = len(latest)
+		latest = append(latest, r)
+	}
+	return latest
+}
+
 func extractResultsFromLogs(logs io.Reader, sidecarLogResults []result.RunResult, maxResultLimit int) ([]result.RunResult, error) {
 	reader := bufio.NewReaderSize(logs, maxResultLimit)
 	for {
@@ -322,6 +422,8 @@ func parseResults(resultBytes []byte, maxResultLimit int) (result.RunResult, err
 		resultType = result.TaskRunResultType
 	case stepResultType:
 		resultType = result.StepResultType
+	case sidecarResultType:
+		resultType = result.SidecarResultType
 	case stepArtifactType:
 		resultType = result.StepArtifactsResultType
 	case taskArtifactType:
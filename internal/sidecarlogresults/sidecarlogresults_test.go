@@ -18,9 +18,11 @@ package sidecarlogresults
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"runtime/pprof"
@@ -73,7 +75,7 @@ func TestLookForResults_FanOutAndWait(t *testing.T) {
 			dir2 := t.TempDir()
 			createRun(t, dir2, false)
 			got := new(bytes.Buffer)
-			err := LookForResults(got, dir2, dir, resultNames, "", map[string][]string{})
+			err := LookForResults(got, dir2, dir, resultNames, "", map[string][]string{}, "", map[string][]string{})
 			if err != nil {
 				t.Fatalf("Did not expect any error but got: %v", err)
 			}
@@ -139,7 +141,7 @@ func TestLookForResults(t *testing.T) {
 				want = encodedResult
 			}
 			got := new(bytes.Buffer)
-			err := LookForResults(got, dir2, dir, []string{c.resultName}, "", map[string][]string{})
+			err := LookForResults(got, dir2, dir, []string{c.resultName}, "", map[string][]string{}, "", map[string][]string{})
 			if err != nil {
 				t.Fatalf("Did not expect any error but got: %v", err)
 			}
@@ -209,7 +211,7 @@ func TestLookForStepResults(t *testing.T) {
 			stepResults := map[string][]string{
 				c.stepName: {c.resultName},
 			}
-			err := LookForResults(got, dir2, "", []string{}, dir, stepResults)
+			err := LookForResults(got, dir2, "", []string{}, dir, stepResults, "", map[string][]string{})
 			if err != nil {
 				t.Fatalf("Did not expect any error but got: %v", err)
 			}
@@ -220,6 +222,29 @@ func TestLookForStepResults(t *testing.T) {
 	}
 }
 
+func TestLookForSidecarResults(t *testing.T) {
+	dir := t.TempDir()
+	// Sidecar results are stored directly in the directory of their sidecar.
+	if err := os.MkdirAll(filepath.Join(dir, "server"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	createResult(t, filepath.Join(dir, "server"), "port", "8080")
+	dir2 := t.TempDir()
+	createRun(t, dir2, false)
+
+	got := new(bytes.Buffer)
+	sidecarResults := map[string][]string{
+		"server": {"port", "missing"},
+	}
+	if err := LookForResults(got, dir2, "", []string{}, "", map[string][]string{}, dir, sidecarResults); err != nil {
+		t.Fatalf("Did not expect any error but got: %v", err)
+	}
+	want := mustJSON(SidecarLogResult{Name: "server.port", Value: "8080", Type: "sidecar"}) + "\n"
+	if d := cmp.Diff(want, got.String()); d != "" {
+		t.Error(diff.PrintWantGot(d))
+	}
+}
+
 func TestExtractResultsFromLogs(t *testing.T) {
 	inputResults := []SidecarLogResult{
 		{
@@ -369,6 +394,11 @@ func TestParseResults(t *testing.T) {
 			Value: `["hello","world"]`,
 			Type:  "step",
 		},
+		{
+			Name:  "server.port",
+			Value: "8080",
+			Type:  "sidecar",
+		},
 		{
 			Name: "step-artifacts-result",
 			Value: `{
@@ -465,6 +495,10 @@ func TestParseResults(t *testing.T) {
 		Key:        "step-foo.result3",
 		Value:      `["hello","world"]`,
 		ResultType: result.StepResultType,
+	}, {
+		Key:        "server.port",
+		Value:      "8080",
+		ResultType: result.SidecarResultType,
 	}, {
 		Key: "step-artifacts-result",
 		Value: `{
@@ -631,7 +665,7 @@ func TestGetResultsFromSidecarLogs(t *testing.T) {
 			}
 
 			// Fake logs are not formatted properly so there will be an error
-			_, err = GetResultsFromSidecarLogs(ctx, clientset, "foo", "pod", "container", pod.Status.Phase)
+			_, err = GetResultsFromSidecarLogs(ctx, clientset, "foo", "pod", "container", pod.Status.Phase, 0)
 			if err != nil && !c.wantError {
 				t.Fatalf("did not expect an error but got: %v", err)
 			}
@@ -642,6 +676,138 @@ func TestGetResultsFromSidecarLogs(t *testing.T) {
 	}
 }
 
+// fakeLogs returns the logs of the current and of the previous instance of the results sidecar.
+type fakeLogs struct {
+	current, previous []SidecarLogResult
+}
+
+func (f fakeLogs) stream(_ context.Context, _, _, _ string, previous bool) (io.ReadCloser, error) {
+	results := f.current
+	if previous {
+		if f.previous == nil {
+			return nil, errors.New("previous terminated container not found")
+		}
+		results = f.previous
+	}
+	var logs bytes.Buffer
+	for _, r := range results {
+		if err := json.NewEncoder(&logs).Encode(r); err != nil {
+			return nil, err
+		}
+	}
+	return io.NopCloser(&logs), nil
+}
+
+func TestGetResultsFromSidecarLogs_Restarted(t *testing.T) {
+	logs := fakeLogs{
+		previous: []SidecarLogResult{
+			{Name: "digest", Value: "sha256:1234", Type: taskResultType},
+			{Name: "step-build.image", Value: "registry.example.com/app:1.0", Type: stepResultType},
+			{Name: "url", Value: "https://example.com/v1", Type: taskResultType},
+		},
+		current: []SidecarLogResult{
+			{Name: "url", Value: "https://example.com/v2", Type: taskResultType},
+			{Name: "commit", Value: "abcdef", Type: taskResultType},
+		},
+	}
+	for _, c := range []struct {
+		desc         string
+		restartCount int32
+		want         []result.RunResult
+	}{{
+		desc:         "not restarted",
+		restartCount: 0,
+		want: []result.RunResult{
+			{Key: "url", Value: "https://example.com/v2", ResultType: result.TaskRunResultType},
+			{Key: "commit", Value: "abcdef", ResultType: result.TaskRunResultType},
+		},
+	}, {
+		desc:         "restarted",
+		restartCount: 1,
+		want: []result.RunResult{
+			{Key: "digest", Value: "sha256:1234", ResultType: result.TaskRunResultType},
+			{Key: "step-build.image", Value: "registry.example.com/app:1.0", ResultType: result.StepResultType},
+			{Key: "url", Value: "https://example.com/v2", ResultType: result.TaskRunResultType},
+			{Key: "commit", Value: "abcdef", ResultType: result.TaskRunResultType},
+		},
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			got, err := getResultsFromSidecarLogs(t.Context(), logs.stream, "foo", "pod", "container", corev1.PodRunning, c.restartCount)
+			if err != nil {
+				t.Fatalf("getResultsFromSidecarLogs: %v", err)
+			}
+			if d := cmp.Diff(c.want, got); d != "" {
+				t.Error(diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestGetResultsFromSidecarLogs_PreviousLogsNotFound(t *testing.T) {
+	logs := fakeLogs{current: []SidecarLogResult{{Name: "url", Value: "https://example.com", Type: taskResultType}}}
+	got, err := getResultsFromSidecarLogs(t.Context(), logs.stream, "foo", "pod", "container", corev1.PodRunning, 1)
+	if err != nil {
+		t.Fatalf("getResultsFromSidecarLogs: %v", err)
+	}
+	want := []result.RunResult{{Key: "url", Value: "https://example.com", ResultType: result.TaskRunResultType}}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Error(diff.PrintWantGot(d))
+	}
+}
+
+func TestGetResultsFromTerminationMessage(t *testing.T) {
+	results := []SidecarLogResult{{
+		Name:  "foo",
+		Value: "bar",
+		Type:  "task",
+	}, {
+		Name:  "step-foo.digest",
+		Value: "sha256:1234",
+		Type:  "step",
+	}}
+	var logs bytes.Buffer
+	for _, r := range results {
+		if err := json.NewEncoder(&logs).Encode(r); err != nil {
+			t.Fatal(err)
+		}
+	}
+	path := filepath.Join(t.TempDir(), "termination")
+	if err := WriteTerminationMessage(path, logs.Bytes()); err != nil {
+		t.Fatalf("WriteTerminationMessage: %v", err)
+	}
+	message, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := GetResultsFromTerminationMessage(t.Context(), string(message))
+	if err != nil {
+		t.Fatalf("GetResultsFromTerminationMessage: %v", err)
+	}
+	want := []result.RunResult{{
+		Key:        "foo",
+		Value:      "bar",
+		ResultType: result.TaskRunResultType,
+	}, {
+		Key:        "step-foo.digest",
+		Value:      "sha256:1234",
+		ResultType: result.StepResultType,
+	}}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Error(diff.PrintWantGot(d))
+	}
+}
+
+func TestWriteTerminationMessage_SizeExceeded(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "termination")
+	err := WriteTerminationMessage(path, bytes.Repeat([]byte("a"), 4097))
+	if !errors.Is(err, ErrSizeExceeded) {
+		t.Fatalf("WriteTerminationMessage() = %v, want %v", err, ErrSizeExceeded)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("the termination message was written: %v", err)
+	}
+}
+
 func TestExtractStepAndResultFromSidecarResultName(t *testing.T) {
 	sidecarResultName := "step-foo.resultName"
 	wantResult := "resultName"
@@ -27,7 +27,9 @@ import (
 
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/pod"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"sigs.k8s.io/yaml"
 )
 
@@ -54,11 +56,56 @@ const (
 	// Default maximum resolution timeout used by the resolution controller before timing out when exceeded
 	DefaultMaximumResolutionTimeout = 1 * time.Minute
 
+	// DefaultMaximumTaskRunTimeout is the default maximum duration of a TaskRun, 0 meaning that
+	// there is no maximum.
+	DefaultMaximumTaskRunTimeout = 0 * time.Minute
+
 	DefaultSidecarLogPollingInterval = 100 * time.Millisecond
 
+	// DefaultSidecarLogResultsGracePeriod is the default time the results sidecar is given to complete
+	// once the steps finished, before it is considered hung.
+	DefaultSidecarLogResultsGracePeriod = 5 * time.Minute
+
 	// DefaultStepRefConcurrencyLimit is the default concurrency limit for resolving step references.
 	DefaultStepRefConcurrencyLimit = 5
 
+	// DefaultExpectedDurationMultiplier is the multiple of a Task's expected duration after which
+	// a running TaskRun is reported as running slow.
+	DefaultExpectedDurationMultiplier = 3
+
+	// DefaultPipelineRunStatusUpdateWindow is the default window within which the status updates of
+	// a PipelineRun driven by the status of its children are coalesced.
+	DefaultPipelineRunStatusUpdateWindow = time.Second
+
+	// DefaultMaxStepActionNestingDepth is the default maximum number of StepActions that can be
+	// chained through `uses` below the StepAction referenced by a Step.
+	DefaultMaxStepActionNestingDepth = 1
+
+	// DefaultStartJitter is the default maximum delay added to the start of new PipelineRuns,
+	// 0 meaning that PipelineRuns start right away.
+	DefaultStartJitter = 0 * time.Second
+
+	// DefaultMaxDAGDepth is the default maximum number of tasks in a chain of dependent tasks
+	// of a Pipeline, 0 meaning that there is no maximum.
+	DefaultMaxDAGDepth = 0
+
+	// DefaultMaxDAGTasks is the default maximum number of tasks of a Pipeline, not counting
+	// its finally tasks, 0 meaning that there is no maximum.
+	DefaultMaxDAGTasks = 0
+
+	// DefaultMaxStepRetries is the default maximum number of retries of a Step.
+	DefaultMaxStepRetries = 5
+
+	// PodGroupSizeDAG sizes the pod group of a PipelineRun with all the tasks of its Pipeline,
+	// not counting its finally tasks.
+	PodGroupSizeDAG = "dag"
+	// PodGroupSizeFirstLayer sizes the pod group of a PipelineRun with the tasks of its Pipeline
+	// which don't depend on any other task.
+	PodGroupSizeFirstLayer = "first-layer"
+	// DefaultPodGroupSizeAnnotationKey is the key of the annotation holding the size of the pod group of a
+	// PipelineRun when "default-pod-group-size-annotation-key" isn't set.
+	DefaultPodGroupSizeAnnotationKey = "tekton.dev/pod-group-size"
+
 	defaultTimeoutMinutesKey                = "default-timeout-minutes"
 	defaultServiceAccountKey                = "default-service-account"
 	defaultManagedByLabelValueKey           = "default-managed-by-label-value"
@@ -72,8 +119,26 @@ const (
 	defaultContainerResourceRequirementsKey = "default-container-resource-requirements"
 	defaultImagePullBackOffTimeout          = "default-imagepullbackoff-timeout"
 	defaultMaximumResolutionTimeout         = "default-maximum-resolution-timeout"
+	defaultMaximumTaskRunTimeoutKey         = "default-maximum-taskrun-timeout"
 	defaultSidecarLogPollingIntervalKey     = "default-sidecar-log-polling-interval"
+	defaultSidecarLogResultsGracePeriodKey  = "default-sidecar-log-results-grace-period"
 	DefaultStepRefConcurrencyLimitKey       = "default-step-ref-concurrency-limit"
+	defaultExpectedDurationMultiplierKey    = "default-expected-duration-multiplier"
+	defaultMaxStepActionNestingDepthKey     = "default-max-stepaction-nesting-depth"
+	defaultStartJitterKey                   = "default-start-jitter"
+	DefaultMaxDAGDepthKey                   = "default-max-dag-depth"
+	DefaultMaxDAGTasksKey                   = "default-max-dag-tasks"
+	defaultMaxStepRetriesKey                = "default-max-step-retries"
+	defaultTTLSecondsAfterFinishedKey       = "default-ttl-seconds-after-finished"
+	defaultPodGroupLabelKeyKey              = "default-pod-group-label-key"
+	defaultPodGroupSizeKey                  = "default-pod-group-size"
+	defaultPodGroupSizeAnnotationKeyKey     = "default-pod-group-size-annotation-key"
+	retainFailedPodsKey                     = "retain-failed-pods"
+	helperImageSetsKey                      = "helper-image-sets"
+	pipelineRunStatusUpdateWindowKey        = "pipelinerun-status-update-window"
+	defaultWorkspaceStorageClassKey         = "default-workspace-storage-class"
+	defaultWorkspaceAccessModeKey           = "default-workspace-access-mode"
+	DefaultAllowedStepCapabilitiesKey       = "default-allowed-step-capabilities"
 )
 
 // DefaultConfig holds all the default configurations for the config.
@@ -95,11 +160,102 @@ type Defaults struct {
 	DefaultContainerResourceRequirements map[string]corev1.ResourceRequirements
 	DefaultImagePullBackOffTimeout       time.Duration
 	DefaultMaximumResolutionTimeout      time.Duration
+	// DefaultMaximumTaskRunTimeout is the maximum duration of a TaskRun, whatever its timeout,
+	// including the TaskRuns without timeout, 0 meaning that there is no maximum.
+	DefaultMaximumTaskRunTimeout time.Duration
 	// DefaultSidecarLogPollingInterval specifies how frequently (as a time.Duration) the Tekton sidecar log results container polls for step completion files.
 	// This value is loaded from the 'sidecar-log-polling-interval' key in the config-defaults ConfigMap.
 	// It is used to control the responsiveness and resource usage of the sidecar in both production and test environments.
 	DefaultSidecarLogPollingInterval time.Duration
-	DefaultStepRefConcurrencyLimit   int
+	// DefaultSidecarLogResultsGracePeriod is how long the results sidecar may keep running once all the
+	// steps finished before it is stopped and the results are read from the logs it wrote so far,
+	// 0 meaning that the TaskRun waits for the results sidecar until it times out.
+	DefaultSidecarLogResultsGracePeriod time.Duration
+	DefaultStepRefConcurrencyLimit      int
+	DefaultExpectedDurationMultiplier   int
+	DefaultMaxStepActionNestingDepth    int
+	// DefaultStartJitter is the maximum delay added to the start of new PipelineRuns to spread
+	// the load of many PipelineRuns created at the same time.
+	DefaultStartJitter time.Duration
+	// DefaultMaxDAGDepth is the maximum number of tasks in a chain of dependent tasks of a Pipeline,
+	// 0 meaning that there is no maximum.
+	DefaultMaxDAGDepth int
+	// DefaultMaxDAGTasks is the maximum number of tasks of a Pipeline, not counting its finally tasks,
+	// 0 meaning that there is no maximum.
+	DefaultMaxDAGTasks int
+	// DefaultMaxStepRetries is the maximum number of times the command of a Step can be retried.
+	DefaultMaxStepRetries int
+	// DefaultTTLSecondsAfterFinished is the number of seconds after which the PipelineRuns and TaskRuns
+	// which don't set their ttlSecondsAfterFinished are deleted once they completed, nil meaning that
+	// they aren't deleted.
+	DefaultTTLSecondsAfterFinished *int32
+	// RetainFailedPods is the policy for keeping the pods of failed TaskRuns, nil meaning
+	// that no pod is retained.
+	RetainFailedPods *RetainFailedPods
+	// HelperImageSets are the sets of helper images TaskRuns and PipelineRuns can select with the
+	// "tekton.dev/helper-image-set" annotation, by name.
+	HelperImageSets map[string]HelperImageSet
+	// PipelineRunStatusUpdateWindow is the window within which the status updates of a PipelineRun
+	// driven by the status of its children are coalesced, 0 meaning that they aren't.
+	PipelineRunStatusUpdateWindow time.Duration
+	// DefaultWorkspaceStorageClass is the storage class of the volumeClaimTemplates of the workspaces
+	// of PipelineRuns and TaskRuns which don't set one, empty meaning the default class of the cluster.
+	DefaultWorkspaceStorageClass string
+	// DefaultWorkspaceAccessMode is the access mode of the volumeClaimTemplates of the workspaces of
+	// PipelineRuns and TaskRuns which don't set any.
+	DefaultWorkspaceAccessMode corev1.PersistentVolumeAccessMode
+	// DefaultAllowedStepCapabilities are the Linux capabilities, without the "CAP_" prefix, which Steps
+	// may add with their capabilities, "ALL" allowing any capability. No capability may be added when empty.
+	DefaultAllowedStepCapabilities []string
+	// DefaultPodGroupLabelKey is the key of the label set to the UID of a PipelineRun on the pods of its
+	// TaskRuns and Affinity Assistant, for batch schedulers to schedule them as a group. No label is set when empty.
+	DefaultPodGroupLabelKey string
+	// DefaultPodGroupSize is how the size of the pod group of a PipelineRun is computed, either
+	// PodGroupSizeDAG or PodGroupSizeFirstLayer, empty meaning PodGroupSizeDAG.
+	DefaultPodGroupSize string
+	// DefaultPodGroupSizeAnnotationKey is the key of the annotation holding the size of the pod group of a
+	// PipelineRun, e.g. the one read by Kueue or Volcano, empty meaning DefaultPodGroupSizeAnnotationKey.
+	DefaultPodGroupSizeAnnotationKey string
+}
+
+// RetainFailedPods holds the policy for keeping the pods of the last failed TaskRuns of
+// each Task, configured with the 'retain-failed-pods' key in the config-defaults ConfigMap.
+// +k8s:deepcopy-gen=true
+type RetainFailedPods struct {
+	// Count is the maximum number of pods retained per namespace and Task.
+	Count int `json:"count"`
+	// Selector is a label selector the failed TaskRuns must match for their pods to be retained.
+	// An empty selector matches all TaskRuns.
+	Selector string `json:"selector,omitempty"`
+}
+
+// Matches returns true if a failed TaskRun with the given labels should have its pod retained.
+func (r *RetainFailedPods) Matches(set labels.Set) bool {
+	if r == nil || r.Count < 1 {
+		return false
+	}
+	selector, err := labels.Parse(r.Selector)
+	if err != nil {
+		return false
+	}
+	return selector.Matches(set)
+}
+
+// HelperImageSet holds the helper images of a set configured with the 'helper-image-sets' key in the
+// config-defaults ConfigMap, e.g. for the pods scheduled on the nodes of another architecture. The images
+// which aren't set are the ones the controller is started with.
+// +k8s:deepcopy-gen=true
+type HelperImageSet struct {
+	// Entrypoint is the image containing the entrypoint binary.
+	Entrypoint string `json:"entrypoint,omitempty"`
+	// Nop is the image used to stop the sidecars and run the Affinity Assistants.
+	Nop string `json:"nop,omitempty"`
+	// SidecarLogResults is the image containing the binary logging the results of the steps.
+	SidecarLogResults string `json:"sidecarlogresults,omitempty"`
+	// WorkingDirInit is the image containing the working dir init binary.
+	WorkingDirInit string `json:"workingdirinit,omitempty"`
+	// OCIImageFetch is the image containing the binary fetching OCI images into workspaces.
+	OCIImageFetch string `json:"ociimagefetch,omitempty"`
 }
 
 // GetDefaultsConfigName returns the name of the configmap containing all
@@ -132,24 +288,108 @@ func (cfg *Defaults) Equals(other *Defaults) bool {
 		other.DefaultResolverType == cfg.DefaultResolverType &&
 		other.DefaultImagePullBackOffTimeout == cfg.DefaultImagePullBackOffTimeout &&
 		other.DefaultMaximumResolutionTimeout == cfg.DefaultMaximumResolutionTimeout &&
+		other.DefaultMaximumTaskRunTimeout == cfg.DefaultMaximumTaskRunTimeout &&
 		other.DefaultSidecarLogPollingInterval == cfg.DefaultSidecarLogPollingInterval &&
+		other.DefaultSidecarLogResultsGracePeriod == cfg.DefaultSidecarLogResultsGracePeriod &&
+		other.PipelineRunStatusUpdateWindow == cfg.PipelineRunStatusUpdateWindow &&
 		other.DefaultStepRefConcurrencyLimit == cfg.DefaultStepRefConcurrencyLimit &&
-		reflect.DeepEqual(other.DefaultForbiddenEnv, cfg.DefaultForbiddenEnv)
+		other.DefaultExpectedDurationMultiplier == cfg.DefaultExpectedDurationMultiplier &&
+		other.DefaultMaxStepActionNestingDepth == cfg.DefaultMaxStepActionNestingDepth &&
+		other.DefaultStartJitter == cfg.DefaultStartJitter &&
+		other.DefaultMaxDAGDepth == cfg.DefaultMaxDAGDepth &&
+		other.DefaultMaxDAGTasks == cfg.DefaultMaxDAGTasks &&
+		other.DefaultMaxStepRetries == cfg.DefaultMaxStepRetries &&
+		reflect.DeepEqual(other.DefaultTTLSecondsAfterFinished, cfg.DefaultTTLSecondsAfterFinished) &&
+		other.DefaultWorkspaceStorageClass == cfg.DefaultWorkspaceStorageClass &&
+		other.DefaultWorkspaceAccessMode == cfg.DefaultWorkspaceAccessMode &&
+		other.DefaultPodGroupLabelKey == cfg.DefaultPodGroupLabelKey &&
+		other.DefaultPodGroupSize == cfg.DefaultPodGroupSize &&
+		other.DefaultPodGroupSizeAnnotationKey == cfg.DefaultPodGroupSizeAnnotationKey &&
+		reflect.DeepEqual(other.RetainFailedPods, cfg.RetainFailedPods) &&
+		reflect.DeepEqual(other.HelperImageSets, cfg.HelperImageSets) &&
+		reflect.DeepEqual(other.DefaultForbiddenEnv, cfg.DefaultForbiddenEnv) &&
+		reflect.DeepEqual(other.DefaultAllowedStepCapabilities, cfg.DefaultAllowedStepCapabilities)
+}
+
+// CapTaskRunTimeout returns the given TaskRun timeout capped to the maximum TaskRun duration,
+// if any. A timeout of 0, meaning no timeout, is capped too.
+func (cfg *Defaults) CapTaskRunTimeout(timeout time.Duration) time.Duration {
+	if cfg.DefaultMaximumTaskRunTimeout <= 0 {
+		return timeout
+	}
+	if timeout == NoTimeoutDuration || timeout > cfg.DefaultMaximumTaskRunTimeout {
+		return cfg.DefaultMaximumTaskRunTimeout
+	}
+	return timeout
+}
+
+// PodGroupSizeAnnotationKey returns the key of the annotation holding the size of the pod group of a
+// PipelineRun.
+func (cfg *Defaults) PodGroupSizeAnnotationKey() string {
+	if cfg.DefaultPodGroupSizeAnnotationKey == "" {
+		return DefaultPodGroupSizeAnnotationKey
+	}
+	return cfg.DefaultPodGroupSizeAnnotationKey
+}
+
+// StepCapabilityAllowed returns true if Steps may add the Linux capability c, with or without
+// the "CAP_" prefix.
+func (cfg *Defaults) StepCapabilityAllowed(c corev1.Capability) bool {
+	name := normalizeCapability(string(c))
+	for _, allowed := range cfg.DefaultAllowedStepCapabilities {
+		if allowed == "ALL" || allowed == name {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeCapability returns the name of the Linux capability c in upper case, without the "CAP_" prefix.
+func normalizeCapability(c string) string {
+	return strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(c)), "CAP_")
+}
+
+// SetVolumeClaimTemplateDefaults sets the default storage class and access mode of the workspaces
+// to the volumeClaimTemplate of a workspace which doesn't set them, and returns true if it did.
+func (cfg *Defaults) SetVolumeClaimTemplateDefaults(vct *corev1.PersistentVolumeClaim) bool {
+	if vct == nil {
+		return false
+	}
+	defaulted := false
+	if vct.Spec.StorageClassName == nil && cfg.DefaultWorkspaceStorageClass != "" {
+		storageClass := cfg.DefaultWorkspaceStorageClass
+		vct.Spec.StorageClassName = &storageClass
+		defaulted = true
+	}
+	if len(vct.Spec.AccessModes) == 0 && cfg.DefaultWorkspaceAccessMode != "" {
+		vct.Spec.AccessModes = []corev1.PersistentVolumeAccessMode{cfg.DefaultWorkspaceAccessMode}
+		defaulted = true
+	}
+	return defaulted
 }
 
 // NewDefaultsFromMap returns a Config given a map corresponding to a ConfigMap
 func NewDefaultsFromMap(cfgMap map[string]string) (*Defaults, error) {
 	tc := Defaults{
-		DefaultTimeoutMinutes:             DefaultTimeoutMinutes,
-		DefaultServiceAccount:             DefaultServiceAccountValue,
-		DefaultManagedByLabelValue:        DefaultManagedByLabelValue,
-		DefaultCloudEventsSink:            DefaultCloudEventSinkValue,
-		DefaultMaxMatrixCombinationsCount: DefaultMaxMatrixCombinationsCount,
-		DefaultResolverType:               DefaultResolverTypeValue,
-		DefaultImagePullBackOffTimeout:    DefaultImagePullBackOffTimeout,
-		DefaultMaximumResolutionTimeout:   DefaultMaximumResolutionTimeout,
-		DefaultSidecarLogPollingInterval:  DefaultSidecarLogPollingInterval,
-		DefaultStepRefConcurrencyLimit:    DefaultStepRefConcurrencyLimit,
+		DefaultTimeoutMinutes:               DefaultTimeoutMinutes,
+		DefaultServiceAccount:               DefaultServiceAccountValue,
+		DefaultManagedByLabelValue:          DefaultManagedByLabelValue,
+		DefaultCloudEventsSink:              DefaultCloudEventSinkValue,
+		DefaultMaxMatrixCombinationsCount:   DefaultMaxMatrixCombinationsCount,
+		DefaultResolverType:                 DefaultResolverTypeValue,
+		DefaultImagePullBackOffTimeout:      DefaultImagePullBackOffTimeout,
+		DefaultMaximumResolutionTimeout:     DefaultMaximumResolutionTimeout,
+		DefaultMaximumTaskRunTimeout:        DefaultMaximumTaskRunTimeout,
+		DefaultSidecarLogPollingInterval:    DefaultSidecarLogPollingInterval,
+		DefaultSidecarLogResultsGracePeriod: DefaultSidecarLogResultsGracePeriod,
+		DefaultStepRefConcurrencyLimit:      DefaultStepRefConcurrencyLimit,
+		DefaultExpectedDurationMultiplier:   DefaultExpectedDurationMultiplier,
+		DefaultMaxStepActionNestingDepth:    DefaultMaxStepActionNestingDepth,
+		DefaultStartJitter:                  DefaultStartJitter,
+		DefaultMaxDAGDepth:                  DefaultMaxDAGDepth,
+		DefaultMaxDAGTasks:                  DefaultMaxDAGTasks,
+		DefaultMaxStepRetries:               DefaultMaxStepRetries,
+		PipelineRunStatusUpdateWindow:       DefaultPipelineRunStatusUpdateWindow,
 	}
 
 	if defaultTimeoutMin, ok := cfgMap[defaultTimeoutMinutesKey]; ok {
@@ -173,6 +413,9 @@ func NewDefaultsFromMap(cfgMap map[string]string) (*Defaults, error) {
 		if err := yamlUnmarshal(defaultPodTemplate, defaultPodTemplateKey, &podTemplate); err != nil {
 			return nil, fmt.Errorf("failed to unmarshal %v", defaultPodTemplate)
 		}
+		if err := podTemplate.ValidateTopologySpreadConstraints(); err != nil {
+			return nil, fmt.Errorf("invalid default config %q: %w", defaultPodTemplateKey, err)
+		}
 		tc.DefaultPodTemplate = &podTemplate
 	}
 
@@ -236,6 +479,14 @@ func NewDefaultsFromMap(cfgMap map[string]string) (*Defaults, error) {
 		tc.DefaultMaximumResolutionTimeout = timeout
 	}
 
+	if defaultMaximumTaskRunTimeout, ok := cfgMap[defaultMaximumTaskRunTimeoutKey]; ok {
+		timeout, err := time.ParseDuration(defaultMaximumTaskRunTimeout)
+		if err != nil || timeout < 0 {
+			return nil, fmt.Errorf("failed parsing default config %q", defaultMaximumTaskRunTimeoutKey)
+		}
+		tc.DefaultMaximumTaskRunTimeout = timeout
+	}
+
 	if defaultSidecarPollingInterval, ok := cfgMap[defaultSidecarLogPollingIntervalKey]; ok {
 		interval, err := time.ParseDuration(defaultSidecarPollingInterval)
 		if err != nil {
@@ -244,6 +495,22 @@ func NewDefaultsFromMap(cfgMap map[string]string) (*Defaults, error) {
 		tc.DefaultSidecarLogPollingInterval = interval
 	}
 
+	if gracePeriod, ok := cfgMap[defaultSidecarLogResultsGracePeriodKey]; ok {
+		period, err := time.ParseDuration(gracePeriod)
+		if err != nil || period < 0 {
+			return nil, fmt.Errorf("failed parsing default config %q", defaultSidecarLogResultsGracePeriodKey)
+		}
+		tc.DefaultSidecarLogResultsGracePeriod = period
+	}
+
+	if window, ok := cfgMap[pipelineRunStatusUpdateWindowKey]; ok {
+		w, err := time.ParseDuration(window)
+		if err != nil || w < 0 {
+			return nil, fmt.Errorf("failed parsing default config %q", pipelineRunStatusUpdateWindowKey)
+		}
+		tc.PipelineRunStatusUpdateWindow = w
+	}
+
 	if DefaultStepRefConcurrencyLimit, ok := cfgMap[DefaultStepRefConcurrencyLimitKey]; ok {
 		stepRefConcurrencyLimit, err := strconv.ParseInt(DefaultStepRefConcurrencyLimit, 10, 0)
 		if err != nil {
@@ -252,6 +519,140 @@ func NewDefaultsFromMap(cfgMap map[string]string) (*Defaults, error) {
 		tc.DefaultStepRefConcurrencyLimit = int(stepRefConcurrencyLimit)
 	}
 
+	if defaultExpectedDurationMultiplier, ok := cfgMap[defaultExpectedDurationMultiplierKey]; ok {
+		multiplier, err := strconv.ParseInt(defaultExpectedDurationMultiplier, 10, 0)
+		if err != nil || multiplier < 1 {
+			return nil, fmt.Errorf("failed parsing default config %q", defaultExpectedDurationMultiplierKey)
+		}
+		tc.DefaultExpectedDurationMultiplier = int(multiplier)
+	}
+
+	if defaultMaxStepActionNestingDepth, ok := cfgMap[defaultMaxStepActionNestingDepthKey]; ok {
+		depth, err := strconv.ParseInt(defaultMaxStepActionNestingDepth, 10, 0)
+		if err != nil || depth < 0 {
+			return nil, fmt.Errorf("failed parsing default config %q", defaultMaxStepActionNestingDepthKey)
+		}
+		tc.DefaultMaxStepActionNestingDepth = int(depth)
+	}
+
+	if defaultStartJitter, ok := cfgMap[defaultStartJitterKey]; ok {
+		jitter, err := time.ParseDuration(defaultStartJitter)
+		if err != nil || jitter < 0 {
+			return nil, fmt.Errorf("failed parsing default config %q", defaultStartJitterKey)
+		}
+		tc.DefaultStartJitter = jitter
+	}
+
+	if defaultMaxDAGDepth, ok := cfgMap[DefaultMaxDAGDepthKey]; ok {
+		depth, err := strconv.ParseInt(defaultMaxDAGDepth, 10, 0)
+		if err != nil || depth < 0 {
+			return nil, fmt.Errorf("failed parsing default config %q", DefaultMaxDAGDepthKey)
+		}
+		tc.DefaultMaxDAGDepth = int(depth)
+	}
+
+	if defaultMaxDAGTasks, ok := cfgMap[DefaultMaxDAGTasksKey]; ok {
+		tasks, err := strconv.ParseInt(defaultMaxDAGTasks, 10, 0)
+		if err != nil || tasks < 0 {
+			return nil, fmt.Errorf("failed parsing default config %q", DefaultMaxDAGTasksKey)
+		}
+		tc.DefaultMaxDAGTasks = int(tasks)
+	}
+
+	if defaultMaxStepRetries, ok := cfgMap[defaultMaxStepRetriesKey]; ok {
+		retries, err := strconv.ParseInt(defaultMaxStepRetries, 10, 0)
+		if err != nil || retries < 0 {
+			return nil, fmt.Errorf("failed parsing default config %q", defaultMaxStepRetriesKey)
+		}
+		tc.DefaultMaxStepRetries = int(retries)
+	}
+
+	if defaultTTLSecondsAfterFinished, ok := cfgMap[defaultTTLSecondsAfterFinishedKey]; ok {
+		ttl, err := strconv.ParseInt(defaultTTLSecondsAfterFinished, 10, 32)
+		if err != nil || ttl < 0 {
+			return nil, fmt.Errorf("failed parsing default config %q", defaultTTLSecondsAfterFinishedKey)
+		}
+		seconds := int32(ttl)
+		tc.DefaultTTLSecondsAfterFinished = &seconds
+	}
+
+	if retainFailedPods, ok := cfgMap[retainFailedPodsKey]; ok {
+		var policy RetainFailedPods
+		if err := yaml.UnmarshalStrict([]byte(retainFailedPods), &policy); err != nil || policy.Count < 1 {
+			return nil, fmt.Errorf("failed parsing default config %q", retainFailedPodsKey)
+		}
+		if _, err := labels.Parse(policy.Selector); err != nil {
+			return nil, fmt.Errorf("failed parsing default config %q: %w", retainFailedPodsKey, err)
+		}
+		tc.RetainFailedPods = &policy
+	}
+
+	if helperImageSets, ok := cfgMap[helperImageSetsKey]; ok {
+		var sets map[string]HelperImageSet
+		if err := yaml.UnmarshalStrict([]byte(helperImageSets), &sets); err != nil {
+			return nil, fmt.Errorf("failed parsing default config %q: %w", helperImageSetsKey, err)
+		}
+		for name, set := range sets {
+			if name == "" || set == (HelperImageSet{}) {
+				return nil, fmt.Errorf("failed parsing default config %q: helper image set %q sets no image", helperImageSetsKey, name)
+			}
+		}
+		tc.HelperImageSets = sets
+	}
+
+	if storageClass, ok := cfgMap[defaultWorkspaceStorageClassKey]; ok {
+		tc.DefaultWorkspaceStorageClass = storageClass
+	}
+
+	if accessMode, ok := cfgMap[defaultWorkspaceAccessModeKey]; ok {
+		switch mode := corev1.PersistentVolumeAccessMode(accessMode); mode {
+		case "":
+		case corev1.ReadWriteOnce, corev1.ReadOnlyMany, corev1.ReadWriteMany, corev1.ReadWriteOncePod:
+			tc.DefaultWorkspaceAccessMode = mode
+		default:
+			return nil, fmt.Errorf("failed parsing default config %q: unknown access mode %q", defaultWorkspaceAccessModeKey, accessMode)
+		}
+	}
+
+	if allowedCapabilities, ok := cfgMap[DefaultAllowedStepCapabilitiesKey]; ok {
+		capabilities := sets.NewString()
+		for _, c := range strings.Split(allowedCapabilities, ",") {
+			if c = normalizeCapability(c); c != "" {
+				capabilities.Insert(c)
+			}
+		}
+		if capabilities.Len() > 0 {
+			tc.DefaultAllowedStepCapabilities = capabilities.List()
+		}
+	}
+
+	if labelKey, ok := cfgMap[defaultPodGroupLabelKeyKey]; ok {
+		if labelKey != "" {
+			if errs := validation.IsQualifiedName(labelKey); len(errs) > 0 {
+				return nil, fmt.Errorf("failed parsing default config %q: %s", defaultPodGroupLabelKeyKey, strings.Join(errs, ", "))
+			}
+		}
+		tc.DefaultPodGroupLabelKey = labelKey
+	}
+
+	if size, ok := cfgMap[defaultPodGroupSizeKey]; ok {
+		switch size {
+		case "", PodGroupSizeDAG, PodGroupSizeFirstLayer:
+			tc.DefaultPodGroupSize = size
+		default:
+			return nil, fmt.Errorf("failed parsing default config %q: unknown pod group size %q", defaultPodGroupSizeKey, size)
+		}
+	}
+
+	if annotationKey, ok := cfgMap[defaultPodGroupSizeAnnotationKeyKey]; ok {
+		if annotationKey != "" {
+			if errs := validation.IsQualifiedName(annotationKey); len(errs) > 0 {
+				return nil, fmt.Errorf("failed parsing default config %q: %s", defaultPodGroupSizeAnnotationKeyKey, strings.Join(errs, ", "))
+			}
+		}
+		tc.DefaultPodGroupSizeAnnotationKey = annotationKey
+	}
+
 	return &tc, nil
 }
 
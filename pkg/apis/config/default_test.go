@@ -27,6 +27,7 @@ import (
 	"github.com/tektoncd/pipeline/test/diff"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
 )
 
 func TestNewDefaultsFromConfigMap(t *testing.T) {
@@ -39,15 +40,22 @@ func TestNewDefaultsFromConfigMap(t *testing.T) {
 	testCases := []testCase{
 		{
 			expectedConfig: &config.Defaults{
-				DefaultTimeoutMinutes:             50,
-				DefaultServiceAccount:             "tekton",
-				DefaultManagedByLabelValue:        "something-else",
-				DefaultMaxMatrixCombinationsCount: 256,
-				DefaultResolverType:               "git",
-				DefaultImagePullBackOffTimeout:    time.Duration(5) * time.Second,
-				DefaultMaximumResolutionTimeout:   1 * time.Minute,
-				DefaultSidecarLogPollingInterval:  100 * time.Millisecond,
-				DefaultStepRefConcurrencyLimit:    5,
+				DefaultTimeoutMinutes:               50,
+				DefaultServiceAccount:               "tekton",
+				DefaultManagedByLabelValue:          "something-else",
+				DefaultMaxMatrixCombinationsCount:   256,
+				DefaultResolverType:                 "git",
+				DefaultImagePullBackOffTimeout:      time.Duration(5) * time.Second,
+				DefaultMaximumResolutionTimeout:     1 * time.Minute,
+				DefaultSidecarLogPollingInterval:    100 * time.Millisecond,
+				DefaultStepRefConcurrencyLimit:      5,
+				DefaultExpectedDurationMultiplier:   3,
+				DefaultMaxStepActionNestingDepth:    1,
+				DefaultMaxDAGDepth:                  0,
+				DefaultMaxDAGTasks:                  0,
+				DefaultMaxStepRetries:               5,
+				DefaultSidecarLogResultsGracePeriod: 5 * time.Minute,
+				PipelineRunStatusUpdateWindow:       time.Second,
 			},
 			fileName: config.GetDefaultsConfigName(),
 		},
@@ -66,11 +74,18 @@ func TestNewDefaultsFromConfigMap(t *testing.T) {
 						"label": "value2",
 					},
 				},
-				DefaultMaxMatrixCombinationsCount: 256,
-				DefaultImagePullBackOffTimeout:    0,
-				DefaultMaximumResolutionTimeout:   1 * time.Minute,
-				DefaultSidecarLogPollingInterval:  100 * time.Millisecond,
-				DefaultStepRefConcurrencyLimit:    5,
+				DefaultMaxMatrixCombinationsCount:   256,
+				DefaultImagePullBackOffTimeout:      0,
+				DefaultMaximumResolutionTimeout:     1 * time.Minute,
+				DefaultSidecarLogPollingInterval:    100 * time.Millisecond,
+				DefaultStepRefConcurrencyLimit:      5,
+				DefaultExpectedDurationMultiplier:   3,
+				DefaultMaxStepActionNestingDepth:    1,
+				DefaultMaxDAGDepth:                  0,
+				DefaultMaxDAGTasks:                  0,
+				DefaultMaxStepRetries:               5,
+				DefaultSidecarLogResultsGracePeriod: 5 * time.Minute,
+				PipelineRunStatusUpdateWindow:       time.Second,
 			},
 			fileName: "config-defaults-with-pod-template",
 		},
@@ -88,30 +103,48 @@ func TestNewDefaultsFromConfigMap(t *testing.T) {
 			expectedError: false,
 			fileName:      "config-defaults-pod-template-err",
 			expectedConfig: &config.Defaults{
-				DefaultTimeoutMinutes:             50,
-				DefaultServiceAccount:             "tekton",
-				DefaultManagedByLabelValue:        config.DefaultManagedByLabelValue,
-				DefaultPodTemplate:                &pod.Template{},
-				DefaultMaxMatrixCombinationsCount: 256,
-				DefaultImagePullBackOffTimeout:    0,
-				DefaultMaximumResolutionTimeout:   1 * time.Minute,
-				DefaultSidecarLogPollingInterval:  100 * time.Millisecond,
-				DefaultStepRefConcurrencyLimit:    5,
+				DefaultTimeoutMinutes:               50,
+				DefaultServiceAccount:               "tekton",
+				DefaultManagedByLabelValue:          config.DefaultManagedByLabelValue,
+				DefaultPodTemplate:                  &pod.Template{},
+				DefaultMaxMatrixCombinationsCount:   256,
+				DefaultImagePullBackOffTimeout:      0,
+				DefaultMaximumResolutionTimeout:     1 * time.Minute,
+				DefaultSidecarLogPollingInterval:    100 * time.Millisecond,
+				DefaultStepRefConcurrencyLimit:      5,
+				DefaultExpectedDurationMultiplier:   3,
+				DefaultMaxStepActionNestingDepth:    1,
+				DefaultMaxDAGDepth:                  0,
+				DefaultMaxDAGTasks:                  0,
+				DefaultMaxStepRetries:               5,
+				DefaultSidecarLogResultsGracePeriod: 5 * time.Minute,
+				PipelineRunStatusUpdateWindow:       time.Second,
 			},
 		},
+		{
+			expectedError: true,
+			fileName:      "config-defaults-pod-template-max-skew-err",
+		},
 		{
 			expectedError: false,
 			fileName:      "config-defaults-aa-pod-template-err",
 			expectedConfig: &config.Defaults{
-				DefaultTimeoutMinutes:             50,
-				DefaultServiceAccount:             "tekton",
-				DefaultManagedByLabelValue:        config.DefaultManagedByLabelValue,
-				DefaultAAPodTemplate:              &pod.AffinityAssistantTemplate{},
-				DefaultMaxMatrixCombinationsCount: 256,
-				DefaultImagePullBackOffTimeout:    0,
-				DefaultMaximumResolutionTimeout:   1 * time.Minute,
-				DefaultSidecarLogPollingInterval:  100 * time.Millisecond,
-				DefaultStepRefConcurrencyLimit:    5,
+				DefaultTimeoutMinutes:               50,
+				DefaultServiceAccount:               "tekton",
+				DefaultManagedByLabelValue:          config.DefaultManagedByLabelValue,
+				DefaultAAPodTemplate:                &pod.AffinityAssistantTemplate{},
+				DefaultMaxMatrixCombinationsCount:   256,
+				DefaultImagePullBackOffTimeout:      0,
+				DefaultMaximumResolutionTimeout:     1 * time.Minute,
+				DefaultSidecarLogPollingInterval:    100 * time.Millisecond,
+				DefaultStepRefConcurrencyLimit:      5,
+				DefaultExpectedDurationMultiplier:   3,
+				DefaultMaxStepActionNestingDepth:    1,
+				DefaultMaxDAGDepth:                  0,
+				DefaultMaxDAGTasks:                  0,
+				DefaultMaxStepRetries:               5,
+				DefaultSidecarLogResultsGracePeriod: 5 * time.Minute,
+				PipelineRunStatusUpdateWindow:       time.Second,
 			},
 		},
 		{
@@ -122,29 +155,43 @@ func TestNewDefaultsFromConfigMap(t *testing.T) {
 			expectedError: false,
 			fileName:      "config-defaults-matrix",
 			expectedConfig: &config.Defaults{
-				DefaultMaxMatrixCombinationsCount: 1024,
-				DefaultTimeoutMinutes:             60,
-				DefaultServiceAccount:             "default",
-				DefaultManagedByLabelValue:        config.DefaultManagedByLabelValue,
-				DefaultImagePullBackOffTimeout:    0,
-				DefaultMaximumResolutionTimeout:   1 * time.Minute,
-				DefaultSidecarLogPollingInterval:  100 * time.Millisecond,
-				DefaultStepRefConcurrencyLimit:    5,
+				DefaultMaxMatrixCombinationsCount:   1024,
+				DefaultTimeoutMinutes:               60,
+				DefaultServiceAccount:               "default",
+				DefaultManagedByLabelValue:          config.DefaultManagedByLabelValue,
+				DefaultImagePullBackOffTimeout:      0,
+				DefaultMaximumResolutionTimeout:     1 * time.Minute,
+				DefaultSidecarLogPollingInterval:    100 * time.Millisecond,
+				DefaultStepRefConcurrencyLimit:      5,
+				DefaultExpectedDurationMultiplier:   3,
+				DefaultMaxStepActionNestingDepth:    1,
+				DefaultMaxDAGDepth:                  0,
+				DefaultMaxDAGTasks:                  0,
+				DefaultMaxStepRetries:               5,
+				DefaultSidecarLogResultsGracePeriod: 5 * time.Minute,
+				PipelineRunStatusUpdateWindow:       time.Second,
 			},
 		},
 		{
 			expectedError: false,
 			fileName:      "config-defaults-forbidden-env",
 			expectedConfig: &config.Defaults{
-				DefaultTimeoutMinutes:             50,
-				DefaultServiceAccount:             "tekton",
-				DefaultMaxMatrixCombinationsCount: 256,
-				DefaultManagedByLabelValue:        "tekton-pipelines",
-				DefaultForbiddenEnv:               []string{"TEKTON_POWER_MODE", "TEST_ENV", "TEST_TEKTON"},
-				DefaultImagePullBackOffTimeout:    time.Duration(15) * time.Second,
-				DefaultMaximumResolutionTimeout:   1 * time.Minute,
-				DefaultSidecarLogPollingInterval:  100 * time.Millisecond,
-				DefaultStepRefConcurrencyLimit:    5,
+				DefaultTimeoutMinutes:               50,
+				DefaultServiceAccount:               "tekton",
+				DefaultMaxMatrixCombinationsCount:   256,
+				DefaultManagedByLabelValue:          "tekton-pipelines",
+				DefaultForbiddenEnv:                 []string{"TEKTON_POWER_MODE", "TEST_ENV", "TEST_TEKTON"},
+				DefaultImagePullBackOffTimeout:      time.Duration(15) * time.Second,
+				DefaultMaximumResolutionTimeout:     1 * time.Minute,
+				DefaultSidecarLogPollingInterval:    100 * time.Millisecond,
+				DefaultStepRefConcurrencyLimit:      5,
+				DefaultExpectedDurationMultiplier:   3,
+				DefaultMaxStepActionNestingDepth:    1,
+				DefaultMaxDAGDepth:                  0,
+				DefaultMaxDAGTasks:                  0,
+				DefaultMaxStepRetries:               5,
+				DefaultSidecarLogResultsGracePeriod: 5 * time.Minute,
+				PipelineRunStatusUpdateWindow:       time.Second,
 			},
 		},
 		{
@@ -160,6 +207,13 @@ func TestNewDefaultsFromConfigMap(t *testing.T) {
 				DefaultMaximumResolutionTimeout:      1 * time.Minute,
 				DefaultSidecarLogPollingInterval:     100 * time.Millisecond,
 				DefaultStepRefConcurrencyLimit:       5,
+				DefaultExpectedDurationMultiplier:    3,
+				DefaultMaxStepActionNestingDepth:     1,
+				DefaultMaxDAGDepth:                   0,
+				DefaultMaxDAGTasks:                   0,
+				DefaultMaxStepRetries:                5,
+				DefaultSidecarLogResultsGracePeriod:  5 * time.Minute,
+				PipelineRunStatusUpdateWindow:        time.Second,
 			},
 		},
 		{
@@ -210,25 +264,367 @@ func TestNewDefaultsFromConfigMap(t *testing.T) {
 					},
 					"test": {},
 				},
-				DefaultStepRefConcurrencyLimit: 5,
+				DefaultStepRefConcurrencyLimit:      5,
+				DefaultExpectedDurationMultiplier:   3,
+				DefaultMaxStepActionNestingDepth:    1,
+				DefaultMaxDAGDepth:                  0,
+				DefaultMaxDAGTasks:                  0,
+				DefaultMaxStepRetries:               5,
+				DefaultSidecarLogResultsGracePeriod: 5 * time.Minute,
+				PipelineRunStatusUpdateWindow:       time.Second,
 			},
 		},
 		{
 			expectedError: true,
 			fileName:      "config-defaults-step-ref-concurrency-limit-err",
 		},
+		{
+			expectedError: true,
+			fileName:      "config-defaults-expected-duration-multiplier-err",
+		},
+		{
+			expectedError: false,
+			fileName:      "config-defaults-expected-duration-multiplier",
+			expectedConfig: &config.Defaults{
+				DefaultExpectedDurationMultiplier:   5,
+				DefaultMaxStepActionNestingDepth:    1,
+				DefaultMaxDAGDepth:                  0,
+				DefaultMaxDAGTasks:                  0,
+				DefaultMaxStepRetries:               5,
+				DefaultSidecarLogResultsGracePeriod: 5 * time.Minute,
+				PipelineRunStatusUpdateWindow:       time.Second,
+				DefaultTimeoutMinutes:               60,
+				DefaultServiceAccount:               "default",
+				DefaultManagedByLabelValue:          config.DefaultManagedByLabelValue,
+				DefaultMaxMatrixCombinationsCount:   256,
+				DefaultImagePullBackOffTimeout:      0,
+				DefaultMaximumResolutionTimeout:     1 * time.Minute,
+				DefaultSidecarLogPollingInterval:    100 * time.Millisecond,
+				DefaultStepRefConcurrencyLimit:      5,
+			},
+		},
+		{
+			expectedError: true,
+			fileName:      "config-defaults-max-stepaction-nesting-depth-err",
+		},
+		{
+			expectedError: false,
+			fileName:      "config-defaults-max-stepaction-nesting-depth",
+			expectedConfig: &config.Defaults{
+				DefaultMaxStepActionNestingDepth:    2,
+				DefaultMaxDAGDepth:                  0,
+				DefaultMaxDAGTasks:                  0,
+				DefaultMaxStepRetries:               5,
+				DefaultSidecarLogResultsGracePeriod: 5 * time.Minute,
+				PipelineRunStatusUpdateWindow:       time.Second,
+				DefaultTimeoutMinutes:               60,
+				DefaultServiceAccount:               "default",
+				DefaultManagedByLabelValue:          config.DefaultManagedByLabelValue,
+				DefaultMaxMatrixCombinationsCount:   256,
+				DefaultImagePullBackOffTimeout:      0,
+				DefaultMaximumResolutionTimeout:     1 * time.Minute,
+				DefaultSidecarLogPollingInterval:    100 * time.Millisecond,
+				DefaultStepRefConcurrencyLimit:      5,
+				DefaultExpectedDurationMultiplier:   3,
+			},
+		},
+		{
+			expectedError: true,
+			fileName:      "config-defaults-max-step-retries-err",
+		},
+		{
+			expectedError: false,
+			fileName:      "config-defaults-max-step-retries",
+			expectedConfig: &config.Defaults{
+				DefaultMaxStepRetries:               10,
+				DefaultSidecarLogResultsGracePeriod: 5 * time.Minute,
+				PipelineRunStatusUpdateWindow:       time.Second,
+				DefaultMaxDAGDepth:                  0,
+				DefaultMaxDAGTasks:                  0,
+				DefaultTimeoutMinutes:               60,
+				DefaultServiceAccount:               "default",
+				DefaultManagedByLabelValue:          config.DefaultManagedByLabelValue,
+				DefaultMaxMatrixCombinationsCount:   256,
+				DefaultImagePullBackOffTimeout:      0,
+				DefaultMaximumResolutionTimeout:     1 * time.Minute,
+				DefaultSidecarLogPollingInterval:    100 * time.Millisecond,
+				DefaultStepRefConcurrencyLimit:      5,
+				DefaultExpectedDurationMultiplier:   3,
+				DefaultMaxStepActionNestingDepth:    1,
+			},
+		},
+		{
+			expectedError: true,
+			fileName:      "config-defaults-ttl-seconds-after-finished-err",
+		},
+		{
+			expectedError: false,
+			fileName:      "config-defaults-ttl-seconds-after-finished",
+			expectedConfig: &config.Defaults{
+				DefaultTTLSecondsAfterFinished:      ptr.To(int32(3600)),
+				DefaultMaxStepRetries:               5,
+				DefaultSidecarLogResultsGracePeriod: 5 * time.Minute,
+				PipelineRunStatusUpdateWindow:       time.Second,
+				DefaultMaxDAGDepth:                  0,
+				DefaultMaxDAGTasks:                  0,
+				DefaultTimeoutMinutes:               60,
+				DefaultServiceAccount:               "default",
+				DefaultManagedByLabelValue:          config.DefaultManagedByLabelValue,
+				DefaultMaxMatrixCombinationsCount:   256,
+				DefaultImagePullBackOffTimeout:      0,
+				DefaultMaximumResolutionTimeout:     1 * time.Minute,
+				DefaultSidecarLogPollingInterval:    100 * time.Millisecond,
+				DefaultStepRefConcurrencyLimit:      5,
+				DefaultExpectedDurationMultiplier:   3,
+				DefaultMaxStepActionNestingDepth:    1,
+			},
+		},
+		{
+			expectedError: true,
+			fileName:      "config-defaults-max-dag-depth-err",
+		},
+		{
+			expectedError: true,
+			fileName:      "config-defaults-max-dag-tasks-err",
+		},
+		{
+			expectedError: false,
+			fileName:      "config-defaults-max-dag",
+			expectedConfig: &config.Defaults{
+				DefaultMaxDAGDepth:                  50,
+				DefaultMaxDAGTasks:                  200,
+				DefaultMaxStepRetries:               5,
+				DefaultSidecarLogResultsGracePeriod: 5 * time.Minute,
+				PipelineRunStatusUpdateWindow:       time.Second,
+				DefaultTimeoutMinutes:               60,
+				DefaultServiceAccount:               "default",
+				DefaultManagedByLabelValue:          config.DefaultManagedByLabelValue,
+				DefaultMaxMatrixCombinationsCount:   256,
+				DefaultImagePullBackOffTimeout:      0,
+				DefaultMaximumResolutionTimeout:     1 * time.Minute,
+				DefaultSidecarLogPollingInterval:    100 * time.Millisecond,
+				DefaultStepRefConcurrencyLimit:      5,
+				DefaultExpectedDurationMultiplier:   3,
+				DefaultMaxStepActionNestingDepth:    1,
+			},
+		},
+		{
+			expectedError: true,
+			fileName:      "config-defaults-retain-failed-pods-err",
+		},
+		{
+			expectedError: false,
+			fileName:      "config-defaults-retain-failed-pods",
+			expectedConfig: &config.Defaults{
+				RetainFailedPods:                    &config.RetainFailedPods{Count: 3, Selector: "app=ci"},
+				DefaultMaxDAGDepth:                  0,
+				DefaultMaxDAGTasks:                  0,
+				DefaultMaxStepRetries:               5,
+				DefaultSidecarLogResultsGracePeriod: 5 * time.Minute,
+				PipelineRunStatusUpdateWindow:       time.Second,
+				DefaultTimeoutMinutes:               60,
+				DefaultServiceAccount:               "default",
+				DefaultManagedByLabelValue:          config.DefaultManagedByLabelValue,
+				DefaultMaxMatrixCombinationsCount:   256,
+				DefaultImagePullBackOffTimeout:      0,
+				DefaultMaximumResolutionTimeout:     1 * time.Minute,
+				DefaultSidecarLogPollingInterval:    100 * time.Millisecond,
+				DefaultStepRefConcurrencyLimit:      5,
+				DefaultExpectedDurationMultiplier:   3,
+				DefaultMaxStepActionNestingDepth:    1,
+			},
+		},
+		{
+			expectedError: true,
+			fileName:      "config-defaults-helper-image-sets-err",
+		},
+		{
+			expectedError: false,
+			fileName:      "config-defaults-helper-image-sets",
+			expectedConfig: &config.Defaults{
+				HelperImageSets: map[string]config.HelperImageSet{
+					"arm64": {
+						Entrypoint: "registry.example.com/tekton/entrypoint:arm64",
+						Nop:        "registry.example.com/tekton/nop:arm64",
+					},
+					"amd64": {
+						SidecarLogResults: "registry.example.com/tekton/sidecarlogresults:amd64",
+						WorkingDirInit:    "registry.example.com/tekton/workingdirinit:amd64",
+					},
+				},
+				DefaultMaxDAGDepth:                  0,
+				DefaultMaxDAGTasks:                  0,
+				DefaultMaxStepRetries:               5,
+				DefaultSidecarLogResultsGracePeriod: 5 * time.Minute,
+				PipelineRunStatusUpdateWindow:       time.Second,
+				DefaultTimeoutMinutes:               60,
+				DefaultServiceAccount:               "default",
+				DefaultManagedByLabelValue:          config.DefaultManagedByLabelValue,
+				DefaultMaxMatrixCombinationsCount:   256,
+				DefaultImagePullBackOffTimeout:      0,
+				DefaultMaximumResolutionTimeout:     1 * time.Minute,
+				DefaultSidecarLogPollingInterval:    100 * time.Millisecond,
+				DefaultStepRefConcurrencyLimit:      5,
+				DefaultExpectedDurationMultiplier:   3,
+				DefaultMaxStepActionNestingDepth:    1,
+			},
+		},
 		{
 			expectedError: false,
 			fileName:      "config-defaults-step-ref-concurrency-limit",
 			expectedConfig: &config.Defaults{
-				DefaultStepRefConcurrencyLimit:    10,
-				DefaultTimeoutMinutes:             60,
-				DefaultServiceAccount:             "default",
-				DefaultManagedByLabelValue:        config.DefaultManagedByLabelValue,
-				DefaultMaxMatrixCombinationsCount: 256,
-				DefaultImagePullBackOffTimeout:    0,
-				DefaultMaximumResolutionTimeout:   1 * time.Minute,
-				DefaultSidecarLogPollingInterval:  100 * time.Millisecond,
+				DefaultStepRefConcurrencyLimit:      10,
+				DefaultExpectedDurationMultiplier:   3,
+				DefaultMaxStepActionNestingDepth:    1,
+				DefaultMaxDAGDepth:                  0,
+				DefaultMaxDAGTasks:                  0,
+				DefaultMaxStepRetries:               5,
+				DefaultSidecarLogResultsGracePeriod: 5 * time.Minute,
+				PipelineRunStatusUpdateWindow:       time.Second,
+				DefaultTimeoutMinutes:               60,
+				DefaultServiceAccount:               "default",
+				DefaultManagedByLabelValue:          config.DefaultManagedByLabelValue,
+				DefaultMaxMatrixCombinationsCount:   256,
+				DefaultImagePullBackOffTimeout:      0,
+				DefaultMaximumResolutionTimeout:     1 * time.Minute,
+				DefaultSidecarLogPollingInterval:    100 * time.Millisecond,
+			},
+		},
+		{
+			expectedError: true,
+			fileName:      "config-defaults-start-jitter-err",
+		},
+		{
+			expectedError: true,
+			fileName:      "config-defaults-maximum-taskrun-timeout-err",
+		},
+		{
+			expectedError: false,
+			fileName:      "config-defaults-maximum-taskrun-timeout",
+			expectedConfig: &config.Defaults{
+				DefaultMaximumTaskRunTimeout:        2 * time.Hour,
+				DefaultTimeoutMinutes:               60,
+				DefaultServiceAccount:               "default",
+				DefaultManagedByLabelValue:          config.DefaultManagedByLabelValue,
+				DefaultMaxMatrixCombinationsCount:   256,
+				DefaultImagePullBackOffTimeout:      0,
+				DefaultMaximumResolutionTimeout:     1 * time.Minute,
+				DefaultSidecarLogPollingInterval:    100 * time.Millisecond,
+				DefaultStepRefConcurrencyLimit:      5,
+				DefaultExpectedDurationMultiplier:   3,
+				DefaultMaxStepActionNestingDepth:    1,
+				DefaultMaxDAGDepth:                  0,
+				DefaultMaxDAGTasks:                  0,
+				DefaultMaxStepRetries:               5,
+				DefaultSidecarLogResultsGracePeriod: 5 * time.Minute,
+				PipelineRunStatusUpdateWindow:       time.Second,
+			},
+		},
+		{
+			expectedError: true,
+			fileName:      "config-defaults-workspace-storage-err",
+		},
+		{
+			expectedError: false,
+			fileName:      "config-defaults-workspace-storage",
+			expectedConfig: &config.Defaults{
+				DefaultWorkspaceStorageClass:        "local-wait-for-first-consumer",
+				DefaultWorkspaceAccessMode:          corev1.ReadWriteOnce,
+				DefaultTimeoutMinutes:               60,
+				DefaultServiceAccount:               "default",
+				DefaultManagedByLabelValue:          config.DefaultManagedByLabelValue,
+				DefaultMaxMatrixCombinationsCount:   256,
+				DefaultImagePullBackOffTimeout:      0,
+				DefaultMaximumResolutionTimeout:     1 * time.Minute,
+				DefaultSidecarLogPollingInterval:    100 * time.Millisecond,
+				DefaultStepRefConcurrencyLimit:      5,
+				DefaultExpectedDurationMultiplier:   3,
+				DefaultMaxStepActionNestingDepth:    1,
+				DefaultMaxDAGDepth:                  0,
+				DefaultMaxDAGTasks:                  0,
+				DefaultMaxStepRetries:               5,
+				DefaultSidecarLogResultsGracePeriod: 5 * time.Minute,
+				PipelineRunStatusUpdateWindow:       time.Second,
+			},
+		},
+		{
+			expectedError: false,
+			fileName:      "config-defaults-pod-group",
+			expectedConfig: &config.Defaults{
+				DefaultPodGroupLabelKey:             "scheduling.example.com/group",
+				DefaultPodGroupSize:                 config.PodGroupSizeFirstLayer,
+				DefaultPodGroupSizeAnnotationKey:    "scheduling.example.com/group-size",
+				DefaultTimeoutMinutes:               60,
+				DefaultServiceAccount:               "default",
+				DefaultManagedByLabelValue:          config.DefaultManagedByLabelValue,
+				DefaultMaxMatrixCombinationsCount:   256,
+				DefaultImagePullBackOffTimeout:      0,
+				DefaultMaximumResolutionTimeout:     1 * time.Minute,
+				DefaultSidecarLogPollingInterval:    100 * time.Millisecond,
+				DefaultStepRefConcurrencyLimit:      5,
+				DefaultExpectedDurationMultiplier:   3,
+				DefaultMaxStepActionNestingDepth:    1,
+				DefaultMaxDAGDepth:                  0,
+				DefaultMaxDAGTasks:                  0,
+				DefaultMaxStepRetries:               5,
+				DefaultSidecarLogResultsGracePeriod: 5 * time.Minute,
+				PipelineRunStatusUpdateWindow:       time.Second,
+			},
+		},
+		{
+			expectedError: true,
+			fileName:      "config-defaults-pod-group-label-err",
+		},
+		{
+			expectedError: true,
+			fileName:      "config-defaults-pod-group-size-err",
+		},
+		{
+			expectedError: true,
+			fileName:      "config-defaults-pod-group-size-annotation-err",
+		},
+		{
+			expectedError: false,
+			fileName:      "config-defaults-step-capabilities",
+			expectedConfig: &config.Defaults{
+				DefaultAllowedStepCapabilities:      []string{"NET_RAW", "SETFCAP"},
+				DefaultTimeoutMinutes:               60,
+				DefaultServiceAccount:               "default",
+				DefaultManagedByLabelValue:          config.DefaultManagedByLabelValue,
+				DefaultMaxMatrixCombinationsCount:   256,
+				DefaultImagePullBackOffTimeout:      0,
+				DefaultMaximumResolutionTimeout:     1 * time.Minute,
+				DefaultSidecarLogPollingInterval:    100 * time.Millisecond,
+				DefaultStepRefConcurrencyLimit:      5,
+				DefaultExpectedDurationMultiplier:   3,
+				DefaultMaxStepActionNestingDepth:    1,
+				DefaultMaxDAGDepth:                  0,
+				DefaultMaxDAGTasks:                  0,
+				DefaultMaxStepRetries:               5,
+				DefaultSidecarLogResultsGracePeriod: 5 * time.Minute,
+				PipelineRunStatusUpdateWindow:       time.Second,
+			},
+		},
+		{
+			expectedError: false,
+			fileName:      "config-defaults-start-jitter",
+			expectedConfig: &config.Defaults{
+				DefaultStartJitter:                  30 * time.Second,
+				DefaultTimeoutMinutes:               60,
+				DefaultServiceAccount:               "default",
+				DefaultManagedByLabelValue:          config.DefaultManagedByLabelValue,
+				DefaultMaxMatrixCombinationsCount:   256,
+				DefaultImagePullBackOffTimeout:      0,
+				DefaultMaximumResolutionTimeout:     1 * time.Minute,
+				DefaultSidecarLogPollingInterval:    100 * time.Millisecond,
+				DefaultStepRefConcurrencyLimit:      5,
+				DefaultExpectedDurationMultiplier:   3,
+				DefaultMaxStepActionNestingDepth:    1,
+				DefaultMaxDAGDepth:                  0,
+				DefaultMaxDAGTasks:                  0,
+				DefaultMaxStepRetries:               5,
+				DefaultSidecarLogResultsGracePeriod: 5 * time.Minute,
+				PipelineRunStatusUpdateWindow:       time.Second,
 			},
 		},
 	}
@@ -247,14 +643,21 @@ func TestNewDefaultsFromConfigMap(t *testing.T) {
 func TestNewDefaultsFromEmptyConfigMap(t *testing.T) {
 	DefaultsConfigEmptyName := "config-defaults-empty"
 	expectedConfig := &config.Defaults{
-		DefaultTimeoutMinutes:             60,
-		DefaultManagedByLabelValue:        "tekton-pipelines",
-		DefaultServiceAccount:             "default",
-		DefaultMaxMatrixCombinationsCount: 256,
-		DefaultImagePullBackOffTimeout:    0,
-		DefaultMaximumResolutionTimeout:   1 * time.Minute,
-		DefaultSidecarLogPollingInterval:  100 * time.Millisecond,
-		DefaultStepRefConcurrencyLimit:    5,
+		DefaultTimeoutMinutes:               60,
+		DefaultManagedByLabelValue:          "tekton-pipelines",
+		DefaultServiceAccount:               "default",
+		DefaultMaxMatrixCombinationsCount:   256,
+		DefaultImagePullBackOffTimeout:      0,
+		DefaultMaximumResolutionTimeout:     1 * time.Minute,
+		DefaultSidecarLogPollingInterval:    100 * time.Millisecond,
+		DefaultStepRefConcurrencyLimit:      5,
+		DefaultExpectedDurationMultiplier:   3,
+		DefaultMaxStepActionNestingDepth:    1,
+		DefaultMaxDAGDepth:                  0,
+		DefaultMaxDAGTasks:                  0,
+		DefaultMaxStepRetries:               5,
+		DefaultSidecarLogResultsGracePeriod: 5 * time.Minute,
+		PipelineRunStatusUpdateWindow:       time.Second,
 	}
 	verifyConfigFileWithExpectedConfig(t, DefaultsConfigEmptyName, expectedConfig)
 }
@@ -440,6 +843,15 @@ func TestEquals(t *testing.T) {
 				DefaultMaximumResolutionTimeout: 10 * time.Minute,
 			},
 			expected: true,
+		}, {
+			name: "different default maximum taskrun timeout",
+			left: &config.Defaults{
+				DefaultMaximumTaskRunTimeout: time.Hour,
+			},
+			right: &config.Defaults{
+				DefaultMaximumTaskRunTimeout: 2 * time.Hour,
+			},
+			expected: false,
 		},
 		{
 			name: "different default step ref concurrency limit",
@@ -459,6 +871,33 @@ func TestEquals(t *testing.T) {
 				DefaultStepRefConcurrencyLimit: 5,
 			},
 			expected: true,
+		}, {
+			name: "different default expected duration multiplier",
+			left: &config.Defaults{
+				DefaultExpectedDurationMultiplier: 3,
+			},
+			right: &config.Defaults{
+				DefaultExpectedDurationMultiplier: 5,
+			},
+			expected: false,
+		}, {
+			name: "different default max stepaction nesting depth",
+			left: &config.Defaults{
+				DefaultMaxStepActionNestingDepth: 1,
+			},
+			right: &config.Defaults{
+				DefaultMaxStepActionNestingDepth: 2,
+			},
+			expected: false,
+		}, {
+			name: "different default start jitter",
+			left: &config.Defaults{
+				DefaultStartJitter: 0,
+			},
+			right: &config.Defaults{
+				DefaultStartJitter: 30 * time.Second,
+			},
+			expected: false,
 		},
 	}
 
@@ -536,3 +975,132 @@ func verifyConfigFileWithExpectedError(t *testing.T, fileName string) {
 		t.Errorf("NewDefaultsFromConfigMap(actual) was expected to return an error")
 	}
 }
+
+func TestPipelineRunStatusUpdateWindowParsing(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		data     map[string]string
+		expected time.Duration
+		wantErr  bool
+	}{{
+		name:     "valid window",
+		data:     map[string]string{"pipelinerun-status-update-window": "3s"},
+		expected: 3 * time.Second,
+	}, {
+		name:     "disabled",
+		data:     map[string]string{"pipelinerun-status-update-window": "0s"},
+		expected: 0,
+	}, {
+		name:    "negative window",
+		data:    map[string]string{"pipelinerun-status-update-window": "-1s"},
+		wantErr: true,
+	}, {
+		name:    "invalid window",
+		data:    map[string]string{"pipelinerun-status-update-window": "notaduration"},
+		wantErr: true,
+	}, {
+		name:     "not set (default)",
+		data:     map[string]string{},
+		expected: time.Second,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg, err := config.NewDefaultsFromMap(tc.data)
+			if tc.wantErr {
+				if err == nil {
+					t.Errorf("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if cfg.PipelineRunStatusUpdateWindow != tc.expected {
+				t.Errorf("got %v, want %v", cfg.PipelineRunStatusUpdateWindow, tc.expected)
+			}
+		})
+	}
+}
+
+func TestSidecarLogResultsGracePeriodParsing(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		data     map[string]string
+		expected time.Duration
+		wantErr  bool
+	}{{
+		name:     "valid grace period",
+		data:     map[string]string{"default-sidecar-log-results-grace-period": "30s"},
+		expected: 30 * time.Second,
+	}, {
+		name:     "disabled",
+		data:     map[string]string{"default-sidecar-log-results-grace-period": "0s"},
+		expected: 0,
+	}, {
+		name:    "negative grace period",
+		data:    map[string]string{"default-sidecar-log-results-grace-period": "-1m"},
+		wantErr: true,
+	}, {
+		name:    "invalid grace period",
+		data:    map[string]string{"default-sidecar-log-results-grace-period": "notaduration"},
+		wantErr: true,
+	}, {
+		name:     "not set (default)",
+		data:     map[string]string{},
+		expected: 5 * time.Minute,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg, err := config.NewDefaultsFromMap(tc.data)
+			if tc.wantErr {
+				if err == nil {
+					t.Errorf("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if cfg.DefaultSidecarLogResultsGracePeriod != tc.expected {
+				t.Errorf("got %v, want %v", cfg.DefaultSidecarLogResultsGracePeriod, tc.expected)
+			}
+		})
+	}
+}
+
+func TestCapTaskRunTimeout(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		maximum time.Duration
+		timeout time.Duration
+		want    time.Duration
+	}{{
+		name:    "no maximum",
+		timeout: 2 * time.Hour,
+		want:    2 * time.Hour,
+	}, {
+		name:    "no maximum and no timeout",
+		timeout: config.NoTimeoutDuration,
+		want:    config.NoTimeoutDuration,
+	}, {
+		name:    "timeout below the maximum",
+		maximum: time.Hour,
+		timeout: 30 * time.Minute,
+		want:    30 * time.Minute,
+	}, {
+		name:    "timeout above the maximum",
+		maximum: time.Hour,
+		timeout: 2 * time.Hour,
+		want:    time.Hour,
+	}, {
+		name:    "no timeout",
+		maximum: time.Hour,
+		timeout: config.NoTimeoutDuration,
+		want:    time.Hour,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := &config.Defaults{DefaultMaximumTaskRunTimeout: tc.maximum}
+			if got := cfg.CapTaskRunTimeout(tc.timeout); got != tc.want {
+				t.Errorf("CapTaskRunTimeout(%v) = %v, want %v", tc.timeout, got, tc.want)
+			}
+		})
+	}
+}
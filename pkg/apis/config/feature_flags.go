@@ -19,8 +19,11 @@ package config
 import (
 	"fmt"
 	"os"
+	"slices"
 	"strconv"
 	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
 )
 
 const (
@@ -57,6 +60,9 @@ const (
 	ResultExtractionMethodTerminationMessage = "termination-message"
 	// ResultExtractionMethodSidecarLogs is the value used for "results-from" as a way to extract results from tasks using sidecar logs.
 	ResultExtractionMethodSidecarLogs = "sidecar-logs"
+	// ResultExtractionMethodSidecarVolume is the value used for "results-from" as a way to extract results from tasks using
+	// the termination message of the results sidecar, which collects them from the volumes it shares with the steps.
+	ResultExtractionMethodSidecarVolume = "sidecar-volume"
 	// DefaultDisableCredsInit is the default value for "disable-creds-init".
 	DefaultDisableCredsInit = false
 	// DefaultRunningInEnvWithInjectedSidecars is the default value for "running-in-environment-with-injected-sidecars".
@@ -87,12 +93,22 @@ const (
 	DefaultEnableProvenanceInStatus = true
 	// DefaultResultExtractionMethod is the default value for ResultExtractionMethod
 	DefaultResultExtractionMethod = ResultExtractionMethodTerminationMessage
+	// DefaultAllowedResultExtractionMethods is the default value for "allowed-results-from-overrides"
+	DefaultAllowedResultExtractionMethods = ""
+	// DefaultIsolatedStepsRuntimeClass is the default value for "isolated-steps-runtime-class"
+	DefaultIsolatedStepsRuntimeClass = ""
+	// DefaultPodSecurityExemptNamespaces is the default value for "pod-security-exempt-namespaces"
+	DefaultPodSecurityExemptNamespaces = ""
+	// DefaultPodSecurityExemptRuntimeClasses is the default value for "pod-security-exempt-runtime-classes"
+	DefaultPodSecurityExemptRuntimeClasses = ""
 	// DefaultMaxResultSize is the default value in bytes for the size of a result
 	DefaultMaxResultSize = 4096
 	// DefaultSetSecurityContext is the default value for "set-security-context"
 	DefaultSetSecurityContext = false
 	// DefaultSetSecurityContextReadOnlyRootFilesystem is the default value for "set-security-context-read-only-root-filesystem"
 	DefaultSetSecurityContextReadOnlyRootFilesystem = false
+	// DefaultDisableInlineScripts is the default value for "disable-inline-scripts"
+	DefaultDisableInlineScripts = false
 	// DefaultCoschedule is the default value for coschedule
 	DefaultCoschedule = CoscheduleWorkspaces
 	// KeepPodOnCancel is the flag used to enable cancelling a pod using the entrypoint, and keep pod on cancel
@@ -113,6 +129,33 @@ const (
 	EnableWaitExponentialBackoff = "enable-wait-exponential-backoff"
 	// DefaultEnableWaitExponentialBackoff is the default value for EnableWaitExponentialBackoff
 	DefaultEnableWaitExponentialBackoff = false
+	// EnableLeakedPVCCleanup is the flag to enable the deletion of the PVCs created from the
+	// volumeClaimTemplates of PipelineRuns which outlived the PipelineRun they were created for.
+	EnableLeakedPVCCleanup = "enable-leaked-pvc-cleanup"
+	// DefaultEnableLeakedPVCCleanup is the default value for EnableLeakedPVCCleanup
+	DefaultEnableLeakedPVCCleanup = false
+	// EnablePodSecurityPreflight is the flag to evaluate the pods of the TaskRuns against the Pod Security
+	// Standards enforced in their namespace before creating them, failing the TaskRuns whose pod violates them.
+	EnablePodSecurityPreflight = "enable-pod-security-preflight"
+	// DefaultEnablePodSecurityPreflight is the default value for EnablePodSecurityPreflight
+	DefaultEnablePodSecurityPreflight = false
+	// EnableFinalStatusFinalizer is the flag to keep the PipelineRuns and TaskRuns deleted before they
+	// complete, e.g. with their namespace, until their final status is recorded and their terminal events
+	// and metrics are emitted.
+	EnableFinalStatusFinalizer = "enable-final-status-finalizer"
+	// DefaultEnableFinalStatusFinalizer is the default value for EnableFinalStatusFinalizer
+	DefaultEnableFinalStatusFinalizer = false
+	// SkipAffinityAssistantForReadWriteMany is the flag to create no Affinity Assistant, in the "workspaces"
+	// coschedule mode, for the workspaces bound to a ReadWriteMany PersistentVolumeClaim, which the pods of
+	// the TaskRuns using it can mount from any node, and not to coschedule these pods.
+	SkipAffinityAssistantForReadWriteMany = "skip-affinity-assistant-for-read-write-many"
+	// DefaultSkipAffinityAssistantForReadWriteMany is the default value for SkipAffinityAssistantForReadWriteMany
+	DefaultSkipAffinityAssistantForReadWriteMany = false
+	// EnforceReadOnlyRootFilesystem is the flag to run the containers of the steps and sidecars with a read-only
+	// root filesystem, with an emptyDir volume mounted at /tmp, unless they set readOnlyRootFilesystem themselves.
+	EnforceReadOnlyRootFilesystem = "enforce-read-only-root-filesystem"
+	// DefaultEnforceReadOnlyRootFilesystem is the default value for EnforceReadOnlyRootFilesystem
+	DefaultEnforceReadOnlyRootFilesystem = false
 	// EnableTerminationMessageCompression is the flag to enable compression of
 	// termination messages to fit more results in the 4KB Kubernetes limit.
 	// When enabled, results are compressed with flate and base64-encoded before
@@ -121,6 +164,24 @@ const (
 	EnableTerminationMessageCompression = "enable-termination-message-compression"
 	// DefaultEnableTerminationMessageCompression is the default value for EnableTerminationMessageCompression
 	DefaultEnableTerminationMessageCompression = false
+	// EnableStepTerminationMessageTrimming is the flag to replace the termination message stored in a
+	// TaskRun's step states with a short marker once all of its content has been extracted into
+	// step results, task results and artifacts, so that results are not stored twice in the status.
+	EnableStepTerminationMessageTrimming = "enable-step-termination-message-trimming"
+	// EnableStepDirectoryIsolation is the flag to mount the /tekton/steps/<step> directory of each step
+	// into the steps' containers separately, writable only by its own step, instead of the shared
+	// /tekton/steps tree, and to reject the step results which their step doesn't declare.
+	EnableStepDirectoryIsolation = "enable-step-directory-isolation"
+	// EnableDeletedPodRecreation is the flag to create a replacement pod for the TaskRuns opted in with the
+	// tekton.dev/recreate-deleted-pod annotation whose pod is deleted out-of-band, e.g. when its node is
+	// drained, instead of failing them.
+	EnableDeletedPodRecreation = "enable-deleted-pod-recreation"
+	// EnableRetriesStatusTrimming is the flag to trim the statuses of the previous attempts of TaskRuns,
+	// archived in their retriesStatus, of the fields already recorded in the summaries of the attempts.
+	EnableRetriesStatusTrimming = "enable-retries-status-trimming"
+	// EnableStepFailFast is the flag to skip the steps waiting to run as soon as a step fails the
+	// TaskRun, rather than one after the other, and to stop the sidecars of the TaskRun right away.
+	EnableStepFailFast = "enable-step-fail-fast"
 
 	// EnableStepActions is the flag to enable step actions (no-op since it's stable)
 	EnableStepActions = "enable-step-actions"
@@ -141,10 +202,33 @@ const (
 	verificationNoMatchPolicy                   = "trusted-resources-verification-no-match-policy"
 	enableProvenanceInStatus                    = "enable-provenance-in-status"
 	resultExtractionMethod                      = "results-from"
+	allowedResultExtractionMethods              = "allowed-results-from-overrides"
+	isolatedStepsRuntimeClass                   = "isolated-steps-runtime-class"
+	podSecurityExemptNamespaces                 = "pod-security-exempt-namespaces"
+	podSecurityExemptRuntimeClasses             = "pod-security-exempt-runtime-classes"
 	maxResultSize                               = "max-result-size"
 	setSecurityContextKey                       = "set-security-context"
 	setSecurityContextReadOnlyRootFilesystemKey = "set-security-context-read-only-root-filesystem"
 	coscheduleKey                               = "coschedule"
+
+	// DisableInlineScriptsKey is the name of the "disable-inline-scripts" flag, rejecting the Steps,
+	// Sidecars and StepActions with a script, which must run the command of their image instead.
+	DisableInlineScriptsKey = "disable-inline-scripts"
+
+	// EnableAPIFieldsKey is the name of the "enable-api-fields" flag
+	EnableAPIFieldsKey = enableAPIFields
+	// EnforceNonfalsifiabilityKey is the name of the "enforce-nonfalsifiability" flag
+	EnforceNonfalsifiabilityKey = enforceNonfalsifiability
+	// ResultExtractionMethodKey is the name of the "results-from" flag
+	ResultExtractionMethodKey = resultExtractionMethod
+	// AllowedResultExtractionMethodsKey is the name of the "allowed-results-from-overrides" flag
+	AllowedResultExtractionMethodsKey = allowedResultExtractionMethods
+	// IsolatedStepsRuntimeClassKey is the name of the "isolated-steps-runtime-class" flag
+	IsolatedStepsRuntimeClassKey = isolatedStepsRuntimeClass
+
+	// DebugFeatureUsage is the name the usage of the alpha debug feature, gated by "enable-api-fields",
+	// is recorded under, since the flag alone doesn't tell which of the alpha features a run depends on.
+	DebugFeatureUsage = enableAPIFields + "/debug"
 )
 
 // DefaultFeatureFlags holds all the default configurations for the feature flags configmap.
@@ -193,6 +277,41 @@ var (
 		Enabled:   DefaultAlphaFeatureEnabled,
 	}
 
+	// DefaultEnableStepTerminationMessageTrimmingFlag is the default PerFeatureFlag value for EnableStepTerminationMessageTrimming
+	DefaultEnableStepTerminationMessageTrimmingFlag = PerFeatureFlag{
+		Name:      EnableStepTerminationMessageTrimming,
+		Stability: AlphaAPIFields,
+		Enabled:   DefaultAlphaFeatureEnabled,
+	}
+
+	// DefaultEnableStepDirectoryIsolationFlag is the default PerFeatureFlag value for EnableStepDirectoryIsolation
+	DefaultEnableStepDirectoryIsolationFlag = PerFeatureFlag{
+		Name:      EnableStepDirectoryIsolation,
+		Stability: AlphaAPIFields,
+		Enabled:   DefaultAlphaFeatureEnabled,
+	}
+
+	// DefaultEnableDeletedPodRecreationFlag is the default PerFeatureFlag value for EnableDeletedPodRecreation
+	DefaultEnableDeletedPodRecreationFlag = PerFeatureFlag{
+		Name:      EnableDeletedPodRecreation,
+		Stability: AlphaAPIFields,
+		Enabled:   DefaultAlphaFeatureEnabled,
+	}
+
+	// DefaultEnableRetriesStatusTrimmingFlag is the default PerFeatureFlag value for EnableRetriesStatusTrimming
+	DefaultEnableRetriesStatusTrimmingFlag = PerFeatureFlag{
+		Name:      EnableRetriesStatusTrimming,
+		Stability: AlphaAPIFields,
+		Enabled:   DefaultAlphaFeatureEnabled,
+	}
+
+	// DefaultEnableStepFailFastFlag is the default PerFeatureFlag value for EnableStepFailFast
+	DefaultEnableStepFailFastFlag = PerFeatureFlag{
+		Name:      EnableStepFailFast,
+		Stability: AlphaAPIFields,
+		Enabled:   DefaultAlphaFeatureEnabled,
+	}
+
 	DefaultEnableTektonOCIBundles = PerFeatureFlag{
 		Name:       EnableTektonOCIBundles,
 		Stability:  AlphaAPIFields,
@@ -225,16 +344,38 @@ type FeatureFlags struct {
 	SetSecurityContext                       bool   `json:"setSecurityContext,omitempty"`
 	SetSecurityContextReadOnlyRootFilesystem bool   `json:"setSecurityContextReadOnlyRootFilesystem,omitempty"`
 	Coschedule                               string `json:"coschedule,omitempty"`
+	DisableInlineScripts                     bool   `json:"disableInlineScripts,omitempty"`
 	EnableCELInWhenExpression                bool   `json:"enableCELInWhenExpression,omitempty"`
 	// EnableStepActions is a no-op flag since StepActions are stable
-	EnableStepActions                   bool   `json:"enableStepActions,omitempty"`
-	EnableParamEnum                     bool   `json:"enableParamEnum,omitempty"`
-	EnableArtifacts                     bool   `json:"enableArtifacts,omitempty"`
-	DisableInlineSpec                   string `json:"disableInlineSpec,omitempty"`
-	EnableConciseResolverSyntax         bool   `json:"enableConciseResolverSyntax,omitempty"`
-	EnableKubernetesSidecar             bool   `json:"enableKubernetesSidecar,omitempty"`
-	EnableWaitExponentialBackoff        bool   `json:"enableWaitExponentialBackoff,omitempty"`
-	EnableTerminationMessageCompression bool   `json:"enableTerminationMessageCompression,omitempty"`
+	EnableStepActions                     bool   `json:"enableStepActions,omitempty"`
+	EnableParamEnum                       bool   `json:"enableParamEnum,omitempty"`
+	EnableArtifacts                       bool   `json:"enableArtifacts,omitempty"`
+	DisableInlineSpec                     string `json:"disableInlineSpec,omitempty"`
+	EnableConciseResolverSyntax           bool   `json:"enableConciseResolverSyntax,omitempty"`
+	EnableKubernetesSidecar               bool   `json:"enableKubernetesSidecar,omitempty"`
+	EnableWaitExponentialBackoff          bool   `json:"enableWaitExponentialBackoff,omitempty"`
+	EnableLeakedPVCCleanup                bool   `json:"enableLeakedPVCCleanup,omitempty"`
+	EnablePodSecurityPreflight            bool   `json:"enablePodSecurityPreflight,omitempty"`
+	EnableFinalStatusFinalizer            bool   `json:"enableFinalStatusFinalizer,omitempty"`
+	SkipAffinityAssistantForReadWriteMany bool   `json:"skipAffinityAssistantForReadWriteMany,omitempty"`
+	EnforceReadOnlyRootFilesystem         bool   `json:"enforceReadOnlyRootFilesystem,omitempty"`
+	EnableTerminationMessageCompression   bool   `json:"enableTerminationMessageCompression,omitempty"`
+	EnableStepTerminationMessageTrimming  bool   `json:"enableStepTerminationMessageTrimming,omitempty"`
+	EnableStepDirectoryIsolation          bool   `json:"enableStepDirectoryIsolation,omitempty"`
+	EnableDeletedPodRecreation            bool   `json:"enableDeletedPodRecreation,omitempty"`
+	EnableRetriesStatusTrimming           bool   `json:"enableRetriesStatusTrimming,omitempty"`
+	EnableStepFailFast                    bool   `json:"enableStepFailFast,omitempty"`
+	// AllowedResultExtractionMethods is the comma-separated list of the "results-from" methods
+	// which PipelineRuns and TaskRuns may select instead of ResultExtractionMethod.
+	AllowedResultExtractionMethods string `json:"allowedResultExtractionMethods,omitempty"`
+	// IsolatedStepsRuntimeClass is the name of the RuntimeClass of the second pod running the steps
+	// of a TaskRun with the "isolated" security profile. Such steps are rejected when it is empty.
+	IsolatedStepsRuntimeClass string `json:"isolatedStepsRuntimeClass,omitempty"`
+	// PodSecurityExemptNamespaces and PodSecurityExemptRuntimeClasses are the namespaces and RuntimeClasses
+	// exempted in the configuration of the Pod Security admission controller, which the pods of the TaskRuns
+	// aren't evaluated against the Pod Security Standards for when "enable-pod-security-preflight" is set.
+	PodSecurityExemptNamespaces     []string `json:"podSecurityExemptNamespaces,omitempty"`
+	PodSecurityExemptRuntimeClasses []string `json:"podSecurityExemptRuntimeClasses,omitempty"`
 	// DeprecatedEnableTektonOCIBundles is maintained for backward compatibility
 	// to allow deletion of PipelineRuns created before v0.62.x.
 	// This field is not used and can be removed in a future release
@@ -308,6 +449,18 @@ func NewFeatureFlagsFromMap(cfgMap map[string]string) (*FeatureFlags, error) {
 	if err := setResultExtractionMethod(cfgMap, DefaultResultExtractionMethod, &tc.ResultExtractionMethod); err != nil {
 		return nil, err
 	}
+	if err := setAllowedResultExtractionMethods(cfgMap, DefaultAllowedResultExtractionMethods, &tc.AllowedResultExtractionMethods); err != nil {
+		return nil, err
+	}
+	if err := setIsolatedStepsRuntimeClass(cfgMap, DefaultIsolatedStepsRuntimeClass, &tc.IsolatedStepsRuntimeClass); err != nil {
+		return nil, err
+	}
+	if err := setNames(cfgMap, podSecurityExemptNamespaces, DefaultPodSecurityExemptNamespaces, validation.IsDNS1123Label, &tc.PodSecurityExemptNamespaces); err != nil {
+		return nil, err
+	}
+	if err := setNames(cfgMap, podSecurityExemptRuntimeClasses, DefaultPodSecurityExemptRuntimeClasses, validation.IsDNS1123Subdomain, &tc.PodSecurityExemptRuntimeClasses); err != nil {
+		return nil, err
+	}
 	if err := setMaxResultSize(cfgMap, DefaultMaxResultSize, &tc.MaxResultSize); err != nil {
 		return nil, err
 	}
@@ -323,6 +476,9 @@ func NewFeatureFlagsFromMap(cfgMap map[string]string) (*FeatureFlags, error) {
 	if err := setFeature(setSecurityContextReadOnlyRootFilesystemKey, DefaultSetSecurityContextReadOnlyRootFilesystem, &tc.SetSecurityContextReadOnlyRootFilesystem); err != nil {
 		return nil, err
 	}
+	if err := setFeature(DisableInlineScriptsKey, DefaultDisableInlineScripts, &tc.DisableInlineScripts); err != nil {
+		return nil, err
+	}
 	if err := setCoschedule(cfgMap, DefaultCoschedule, &tc.Coschedule); err != nil {
 		return nil, err
 	}
@@ -348,9 +504,39 @@ func NewFeatureFlagsFromMap(cfgMap map[string]string) (*FeatureFlags, error) {
 	if err := setFeature(EnableWaitExponentialBackoff, DefaultEnableWaitExponentialBackoff, &tc.EnableWaitExponentialBackoff); err != nil {
 		return nil, err
 	}
+	if err := setFeature(EnableLeakedPVCCleanup, DefaultEnableLeakedPVCCleanup, &tc.EnableLeakedPVCCleanup); err != nil {
+		return nil, err
+	}
+	if err := setFeature(EnablePodSecurityPreflight, DefaultEnablePodSecurityPreflight, &tc.EnablePodSecurityPreflight); err != nil {
+		return nil, err
+	}
+	if err := setFeature(EnableFinalStatusFinalizer, DefaultEnableFinalStatusFinalizer, &tc.EnableFinalStatusFinalizer); err != nil {
+		return nil, err
+	}
+	if err := setFeature(SkipAffinityAssistantForReadWriteMany, DefaultSkipAffinityAssistantForReadWriteMany, &tc.SkipAffinityAssistantForReadWriteMany); err != nil {
+		return nil, err
+	}
+	if err := setFeature(EnforceReadOnlyRootFilesystem, DefaultEnforceReadOnlyRootFilesystem, &tc.EnforceReadOnlyRootFilesystem); err != nil {
+		return nil, err
+	}
 	if err := setPerFeatureFlag(EnableTerminationMessageCompression, DefaultEnableTerminationMessageCompressionFlag, &tc.EnableTerminationMessageCompression); err != nil {
 		return nil, err
 	}
+	if err := setPerFeatureFlag(EnableStepTerminationMessageTrimming, DefaultEnableStepTerminationMessageTrimmingFlag, &tc.EnableStepTerminationMessageTrimming); err != nil {
+		return nil, err
+	}
+	if err := setPerFeatureFlag(EnableStepDirectoryIsolation, DefaultEnableStepDirectoryIsolationFlag, &tc.EnableStepDirectoryIsolation); err != nil {
+		return nil, err
+	}
+	if err := setPerFeatureFlag(EnableDeletedPodRecreation, DefaultEnableDeletedPodRecreationFlag, &tc.EnableDeletedPodRecreation); err != nil {
+		return nil, err
+	}
+	if err := setPerFeatureFlag(EnableRetriesStatusTrimming, DefaultEnableRetriesStatusTrimmingFlag, &tc.EnableRetriesStatusTrimming); err != nil {
+		return nil, err
+	}
+	if err := setPerFeatureFlag(EnableStepFailFast, DefaultEnableStepFailFastFlag, &tc.EnableStepFailFast); err != nil {
+		return nil, err
+	}
 
 	return &tc, nil
 }
@@ -423,7 +609,7 @@ func setResultExtractionMethod(cfgMap map[string]string, defaultValue string, fe
 		value = strings.ToLower(cfg)
 	}
 	switch value {
-	case ResultExtractionMethodTerminationMessage, ResultExtractionMethodSidecarLogs:
+	case ResultExtractionMethodTerminationMessage, ResultExtractionMethodSidecarLogs, ResultExtractionMethodSidecarVolume:
 		*feature = value
 	default:
 		return fmt.Errorf("invalid value for feature flag %q: %q", resultExtractionMethod, value)
@@ -431,6 +617,75 @@ func setResultExtractionMethod(cfgMap map[string]string, defaultValue string, fe
 	return nil
 }
 
+// setAllowedResultExtractionMethods sets the "allowed-results-from-overrides" flag based on the content of a given map.
+// If any of the methods it lists is invalid then an error is returned.
+func setAllowedResultExtractionMethods(cfgMap map[string]string, defaultValue string, feature *string) error {
+	value := defaultValue
+	if cfg, ok := cfgMap[allowedResultExtractionMethods]; ok {
+		value = strings.ToLower(strings.ReplaceAll(cfg, " ", ""))
+	}
+	if value != "" {
+		for _, method := range strings.Split(value, ",") {
+			switch method {
+			case ResultExtractionMethodTerminationMessage, ResultExtractionMethodSidecarLogs, ResultExtractionMethodSidecarVolume:
+			default:
+				return fmt.Errorf("invalid value for feature flag %q: %q", allowedResultExtractionMethods, method)
+			}
+		}
+	}
+	*feature = value
+	return nil
+}
+
+// setIsolatedStepsRuntimeClass sets the "isolated-steps-runtime-class" flag based on the content of a given map.
+// If the flag isn't the name of a RuntimeClass then an error is returned.
+func setIsolatedStepsRuntimeClass(cfgMap map[string]string, defaultValue string, feature *string) error {
+	value := defaultValue
+	if cfg, ok := cfgMap[isolatedStepsRuntimeClass]; ok {
+		value = strings.TrimSpace(cfg)
+	}
+	if value != "" {
+		if errs := validation.IsDNS1123Subdomain(value); len(errs) > 0 {
+			return fmt.Errorf("invalid value for feature flag %q: %q", isolatedStepsRuntimeClass, value)
+		}
+	}
+	*feature = value
+	return nil
+}
+
+// setNames sets a flag listing comma separated names based on the content of a given map.
+// If any of the names isn't valid according to validate then an error is returned.
+func setNames(cfgMap map[string]string, key, defaultValue string, validate func(string) []string, feature *[]string) error {
+	value := defaultValue
+	if cfg, ok := cfgMap[key]; ok {
+		value = strings.ReplaceAll(cfg, " ", "")
+	}
+	var names []string
+	for _, name := range strings.Split(value, ",") {
+		if name == "" {
+			continue
+		}
+		if errs := validate(name); len(errs) > 0 {
+			return fmt.Errorf("invalid value for feature flag %q: %q", key, name)
+		}
+		names = append(names, name)
+	}
+	*feature = names
+	return nil
+}
+
+// IsResultExtractionMethodAllowed returns whether PipelineRuns and TaskRuns may select method to
+// extract their results with, i.e. whether it is listed by "allowed-results-from-overrides".
+func (ff *FeatureFlags) IsResultExtractionMethodAllowed(method string) bool {
+	return ff.AllowedResultExtractionMethods != "" && slices.Contains(strings.Split(ff.AllowedResultExtractionMethods, ","), method)
+}
+
+// UsesResultsSidecar returns whether the results are collected by the results sidecar, i.e. whether
+// "results-from" is set to "sidecar-logs" or "sidecar-volume".
+func (ff *FeatureFlags) UsesResultsSidecar() bool {
+	return ff.ResultExtractionMethod == ResultExtractionMethodSidecarLogs || ff.ResultExtractionMethod == ResultExtractionMethodSidecarVolume
+}
+
 // setMaxResultSize sets the "max-result-size" flag based on the content of a given map.
 // If the feature gate is invalid or missing then an error is returned.
 func setMaxResultSize(cfgMap map[string]string, defaultValue int, feature *int) error {
@@ -81,6 +81,21 @@ func TestNewFeatureFlagsFromConfigMap(t *testing.T) {
 				EnableConciseResolverSyntax:              true,
 				EnableKubernetesSidecar:                  true,
 				EnableTerminationMessageCompression:      true,
+				EnableStepTerminationMessageTrimming:     true,
+				EnableStepDirectoryIsolation:             true,
+				EnableDeletedPodRecreation:               true,
+				EnableRetriesStatusTrimming:              true,
+				EnableStepFailFast:                       true,
+				EnableLeakedPVCCleanup:                   true,
+				EnablePodSecurityPreflight:               true,
+				EnableFinalStatusFinalizer:               true,
+				SkipAffinityAssistantForReadWriteMany:    true,
+				EnforceReadOnlyRootFilesystem:            true,
+				AllowedResultExtractionMethods:           "sidecar-logs,termination-message",
+				IsolatedStepsRuntimeClass:                "gvisor",
+				PodSecurityExemptNamespaces:              []string{"kube-system", "ci"},
+				PodSecurityExemptRuntimeClasses:          []string{"gvisor"},
+				DisableInlineScripts:                     true,
 			},
 			fileName: "feature-flags-all-flags-set",
 		},
@@ -189,6 +204,26 @@ func TestNewFeatureFlagsFromConfigMap(t *testing.T) {
 			},
 			fileName: "feature-flags-results-via-sidecar-logs",
 		},
+		{
+			expectedConfig: &config.FeatureFlags{
+				EnableAPIFields:                  config.DefaultEnableAPIFields,
+				SendCloudEventsForRuns:           config.DefaultSendCloudEventsForRuns,
+				EnforceNonfalsifiability:         config.DefaultEnforceNonfalsifiability,
+				VerificationNoMatchPolicy:        config.DefaultNoMatchPolicyConfig,
+				RunningInEnvWithInjectedSidecars: config.DefaultRunningInEnvWithInjectedSidecars,
+				AwaitSidecarReadiness:            config.DefaultAwaitSidecarReadiness,
+				EnableProvenanceInStatus:         config.DefaultEnableProvenanceInStatus,
+				ResultExtractionMethod:           config.ResultExtractionMethodSidecarVolume,
+				MaxResultSize:                    config.DefaultMaxResultSize,
+				SetSecurityContext:               config.DefaultSetSecurityContext,
+				Coschedule:                       config.DefaultCoschedule,
+				EnableKeepPodOnCancel:            config.DefaultEnableKeepPodOnCancel.Enabled,
+				EnableCELInWhenExpression:        config.DefaultEnableCELInWhenExpression.Enabled,
+				EnableParamEnum:                  config.DefaultEnableParamEnum.Enabled,
+				DisableInlineSpec:                config.DefaultDisableInlineSpec,
+			},
+			fileName: "feature-flags-results-via-sidecar-volume",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -267,6 +302,15 @@ func TestNewFeatureFlagsConfigMapErrors(t *testing.T) {
 	}, {
 		fileName: "feature-flags-invalid-results-from",
 		want:     `invalid value for feature flag "results-from": "im-not-a-valid-results-from"`,
+	}, {
+		fileName: "feature-flags-invalid-allowed-results-from-overrides",
+		want:     `invalid value for feature flag "allowed-results-from-overrides": "im-not-a-valid-results-from"`,
+	}, {
+		fileName: "feature-flags-invalid-isolated-steps-runtime-class",
+		want:     `invalid value for feature flag "isolated-steps-runtime-class": "Not_A_RuntimeClass"`,
+	}, {
+		fileName: "feature-flags-invalid-pod-security-exempt-namespaces",
+		want:     `invalid value for feature flag "pod-security-exempt-namespaces": "Not_A_Namespace"`,
 	}, {
 		fileName: "feature-flags-invalid-max-result-size-too-large",
 		want:     `invalid value for feature flag "results-from": "10000000000000". This is exceeding the CRD limit`,
@@ -303,9 +347,42 @@ func TestNewFeatureFlagsConfigMapErrors(t *testing.T) {
 	}, {
 		fileName: "feature-flags-invalid-enable-termination-message-compression",
 		want:     `failed parsing feature flags config "invalid": strconv.ParseBool: parsing "invalid": invalid syntax for feature enable-termination-message-compression`,
+	}, {
+		fileName: "feature-flags-invalid-enable-step-termination-message-trimming",
+		want:     `failed parsing feature flags config "invalid": strconv.ParseBool: parsing "invalid": invalid syntax for feature enable-step-termination-message-trimming`,
+	}, {
+		fileName: "feature-flags-invalid-enable-step-directory-isolation",
+		want:     `failed parsing feature flags config "invalid": strconv.ParseBool: parsing "invalid": invalid syntax for feature enable-step-directory-isolation`,
+	}, {
+		fileName: "feature-flags-invalid-enable-deleted-pod-recreation",
+		want:     `failed parsing feature flags config "invalid": strconv.ParseBool: parsing "invalid": invalid syntax for feature enable-deleted-pod-recreation`,
+	}, {
+		fileName: "feature-flags-invalid-enable-retries-status-trimming",
+		want:     `failed parsing feature flags config "invalid": strconv.ParseBool: parsing "invalid": invalid syntax for feature enable-retries-status-trimming`,
+	}, {
+		fileName: "feature-flags-invalid-enable-step-fail-fast",
+		want:     `failed parsing feature flags config "invalid": strconv.ParseBool: parsing "invalid": invalid syntax for feature enable-step-fail-fast`,
+	}, {
+		fileName: "feature-flags-invalid-enable-leaked-pvc-cleanup",
+		want:     `failed parsing feature flags config "invalid": strconv.ParseBool: parsing "invalid": invalid syntax`,
+	}, {
+		fileName: "feature-flags-invalid-enable-pod-security-preflight",
+		want:     `failed parsing feature flags config "invalid": strconv.ParseBool: parsing "invalid": invalid syntax`,
+	}, {
+		fileName: "feature-flags-invalid-enable-final-status-finalizer",
+		want:     `failed parsing feature flags config "invalid": strconv.ParseBool: parsing "invalid": invalid syntax`,
+	}, {
+		fileName: "feature-flags-invalid-skip-affinity-assistant-for-read-write-many",
+		want:     `failed parsing feature flags config "invalid": strconv.ParseBool: parsing "invalid": invalid syntax`,
+	}, {
+		fileName: "feature-flags-invalid-enforce-read-only-root-filesystem",
+		want:     `failed parsing feature flags config "invalid": strconv.ParseBool: parsing "invalid": invalid syntax`,
 	}, {
 		fileName: "feature-flags-invalid-set_security_context_read_only_root_filesystem",
 		want:     `failed parsing feature flags config "invalid read only root filesystem flag": strconv.ParseBool: parsing "invalid read only root filesystem flag": invalid syntax`,
+	}, {
+		fileName: "feature-flags-invalid-disable-inline-scripts",
+		want:     `failed parsing feature flags config "yes please": strconv.ParseBool: parsing "yes please": invalid syntax`,
 	}} {
 		t.Run(tc.fileName, func(t *testing.T) {
 			cm := test.ConfigMapFromTestFile(t, tc.fileName)
@@ -17,6 +17,9 @@ limitations under the License.
 package config
 
 import (
+	"fmt"
+	"time"
+
 	corev1 "k8s.io/api/core/v1"
 )
 
@@ -44,6 +47,10 @@ const (
 	// throttledWithNamespaceKey sets if the namespace label should be included on the taskrun throttled metrics
 	throttledWithNamespaceKey = "metrics.taskrun.throttle.enable-namespace"
 
+	// slowReconcileThresholdKey sets the duration of the reconciles of PipelineRuns and TaskRuns
+	// above which the time spent in each of their phases is logged
+	slowReconcileThresholdKey = "metrics.reconcile.slow-threshold"
+
 	// DefaultTaskrunLevel determines to what level to aggregate metrics
 	// when it isn't specified in configmap
 	DefaultTaskrunLevel = TaskrunLevelAtTask
@@ -106,6 +113,9 @@ type Metrics struct {
 	DurationPipelinerunType string
 	CountWithReason         bool
 	ThrottleWithNamespace   bool
+	// SlowReconcileThreshold is the duration of the reconciles above which the time spent in each of
+	// their phases is logged, or 0 to never log it.
+	SlowReconcileThreshold time.Duration
 }
 
 // Equals returns true if two Configs are identical
@@ -124,7 +134,8 @@ func (cfg *Metrics) Equals(other *Metrics) bool {
 		other.DurationTaskrunType == cfg.DurationTaskrunType &&
 		other.DurationPipelinerunType == cfg.DurationPipelinerunType &&
 		other.CountWithReason == cfg.CountWithReason &&
-		other.ThrottleWithNamespace == cfg.ThrottleWithNamespace
+		other.ThrottleWithNamespace == cfg.ThrottleWithNamespace &&
+		other.SlowReconcileThreshold == cfg.SlowReconcileThreshold
 }
 
 // newMetricsFromMap returns a Config given a map corresponding to a ConfigMap
@@ -164,6 +175,14 @@ func newMetricsFromMap(cfgMap map[string]string) (*Metrics, error) {
 		tc.ThrottleWithNamespace = true
 	}
 
+	if slowReconcileThreshold, ok := cfgMap[slowReconcileThresholdKey]; ok {
+		threshold, err := time.ParseDuration(slowReconcileThreshold)
+		if err != nil || threshold < 0 {
+			return nil, fmt.Errorf("failed parsing metrics config %q", slowReconcileThresholdKey)
+		}
+		tc.SlowReconcileThreshold = threshold
+	}
+
 	return &tc, nil
 }
 
@@ -18,11 +18,13 @@ package config_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/tektoncd/pipeline/pkg/apis/config"
 	test "github.com/tektoncd/pipeline/pkg/reconciler/testing"
 	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
 )
 
 func TestNewMetricsFromConfigMap(t *testing.T) {
@@ -77,6 +79,7 @@ func TestNewMetricsFromConfigMap(t *testing.T) {
 				DurationPipelinerunType: config.DurationPipelinerunTypeLastValue,
 				CountWithReason:         true,
 				ThrottleWithNamespace:   true,
+				SlowReconcileThreshold:  5 * time.Second,
 			},
 			fileName: "config-observability-throttle",
 		},
@@ -101,6 +104,15 @@ func TestNewMetricsFromEmptyConfigMap(t *testing.T) {
 	verifyConfigFileWithExpectedMetricsConfig(t, MetricsConfigEmptyName, expectedConfig)
 }
 
+func TestNewMetricsFromConfigMap_InvalidSlowReconcileThreshold(t *testing.T) {
+	for _, threshold := range []string{"-1s", "notaduration"} {
+		cm := &corev1.ConfigMap{Data: map[string]string{"metrics.reconcile.slow-threshold": threshold}}
+		if _, err := config.NewMetricsFromConfigMap(cm); err == nil {
+			t.Errorf("NewMetricsFromConfigMap() with slow-threshold %q succeeded, want an error", threshold)
+		}
+	}
+}
+
 func verifyConfigFileWithExpectedMetricsConfig(t *testing.T, fileName string, expectedConfig *config.Metrics) {
 	t.Helper()
 	cm := test.ConfigMapFromTestFile(t, fileName)
@@ -0,0 +1,159 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+)
+
+// NamespaceFeatureFlagsConfigMapName is the name of the ConfigMap overriding the feature flags of
+// the PipelineRuns and TaskRuns of the namespace it is in.
+const NamespaceFeatureFlagsConfigMapName = "tekton-feature-flags"
+
+// namespaceOverridableFeatureFlags maps the feature flags which may be overridden per namespace to
+// the function copying their value. The flags which are relevant to the security of the cluster, such
+// as "disable-creds-init", "set-security-context" or "enable-provenance-in-status", can't be overridden.
+var namespaceOverridableFeatureFlags = map[string]func(dst, src *FeatureFlags){
+	coscheduleKey:               func(dst, src *FeatureFlags) { dst.Coschedule = src.Coschedule },
+	EnableArtifacts:             func(dst, src *FeatureFlags) { dst.EnableArtifacts = src.EnableArtifacts },
+	EnableCELInWhenExpression:   func(dst, src *FeatureFlags) { dst.EnableCELInWhenExpression = src.EnableCELInWhenExpression },
+	EnableConciseResolverSyntax: func(dst, src *FeatureFlags) { dst.EnableConciseResolverSyntax = src.EnableConciseResolverSyntax },
+	EnableDeletedPodRecreation:  func(dst, src *FeatureFlags) { dst.EnableDeletedPodRecreation = src.EnableDeletedPodRecreation },
+	EnableParamEnum:             func(dst, src *FeatureFlags) { dst.EnableParamEnum = src.EnableParamEnum },
+	EnableRetriesStatusTrimming: func(dst, src *FeatureFlags) { dst.EnableRetriesStatusTrimming = src.EnableRetriesStatusTrimming },
+	EnableStepTerminationMessageTrimming: func(dst, src *FeatureFlags) {
+		dst.EnableStepTerminationMessageTrimming = src.EnableStepTerminationMessageTrimming
+	},
+	EnableTerminationMessageCompression: func(dst, src *FeatureFlags) {
+		dst.EnableTerminationMessageCompression = src.EnableTerminationMessageCompression
+	},
+	EnableWaitExponentialBackoff: func(dst, src *FeatureFlags) { dst.EnableWaitExponentialBackoff = src.EnableWaitExponentialBackoff },
+	KeepPodOnCancel:              func(dst, src *FeatureFlags) { dst.EnableKeepPodOnCancel = src.EnableKeepPodOnCancel },
+	// StepActions are stable, the flag is a no-op which is accepted for backward compatibility.
+	EnableStepActions: func(dst, src *FeatureFlags) {},
+}
+
+// NamespaceOverridableFeatureFlags returns the sorted names of the feature flags which may be
+// overridden per namespace.
+func NamespaceOverridableFeatureFlags() []string {
+	names := make([]string, 0, len(namespaceOverridableFeatureFlags))
+	for name := range namespaceOverridableFeatureFlags {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// NamespaceFeatureFlags reads the feature flags overridden in namespaces from their
+// "tekton-feature-flags" ConfigMap. The overrides of a namespace are parsed once per version of its
+// ConfigMap, and parsed again when the ConfigMap changes.
+type NamespaceFeatureFlags struct {
+	lister corev1listers.ConfigMapLister
+
+	mu        sync.Mutex
+	overrides map[string]*namespaceOverrides
+}
+
+// namespaceOverrides holds the feature flags parsed from a version of the ConfigMap of a namespace.
+type namespaceOverrides struct {
+	resourceVersion string
+	keys            []string
+	flags           *FeatureFlags
+	err             error
+}
+
+// NewNamespaceFeatureFlags returns a NamespaceFeatureFlags reading the ConfigMaps of the namespaces
+// with lister.
+func NewNamespaceFeatureFlags(lister corev1listers.ConfigMapLister) *NamespaceFeatureFlags {
+	return &NamespaceFeatureFlags{lister: lister, overrides: map[string]*namespaceOverrides{}}
+}
+
+// ToContext returns ctx with a copy of its Config whose feature flags are overridden by the ones set
+// in namespace, which take precedence over the cluster feature flags. It returns ctx as is if the
+// namespace doesn't override any feature flag, or along with an error if its overrides are invalid,
+// e.g. when they set a feature flag which can't be overridden per namespace.
+func (n *NamespaceFeatureFlags) ToContext(ctx context.Context, namespace string) (context.Context, error) {
+	if n == nil {
+		return ctx, nil
+	}
+	o, err := n.get(namespace)
+	if err != nil {
+		return ctx, err
+	}
+	if o == nil || len(o.keys) == 0 {
+		return ctx, nil
+	}
+	cfg := FromContextOrDefaults(ctx)
+	overridden := *cfg
+	overridden.FeatureFlags = cfg.FeatureFlags.DeepCopy()
+	for _, key := range o.keys {
+		namespaceOverridableFeatureFlags[key](overridden.FeatureFlags, o.flags)
+	}
+	return ToContext(ctx, &overridden), nil
+}
+
+// get returns the overrides of namespace, parsing them again if its ConfigMap changed since they
+// were cached, or nil if the namespace doesn't have a ConfigMap.
+func (n *NamespaceFeatureFlags) get(namespace string) (*namespaceOverrides, error) {
+	cm, err := n.lister.ConfigMaps(namespace).Get(NamespaceFeatureFlagsConfigMapName)
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	switch {
+	case k8serrors.IsNotFound(err):
+		delete(n.overrides, namespace)
+		return nil, nil
+	case err != nil:
+		return nil, err
+	}
+	o, ok := n.overrides[namespace]
+	if !ok || o.resourceVersion != cm.ResourceVersion {
+		o = parseNamespaceOverrides(namespace, cm.ResourceVersion, cm.Data)
+		n.overrides[namespace] = o
+	}
+	return o, o.err
+}
+
+func parseNamespaceOverrides(namespace, resourceVersion string, data map[string]string) *namespaceOverrides {
+	o := &namespaceOverrides{resourceVersion: resourceVersion}
+	var rejected []string
+	for key := range data {
+		if _, ok := namespaceOverridableFeatureFlags[key]; !ok {
+			rejected = append(rejected, key)
+			continue
+		}
+		o.keys = append(o.keys, key)
+	}
+	if len(rejected) > 0 {
+		slices.Sort(rejected)
+		o.err = fmt.Errorf("the feature flags %s set by ConfigMap %s/%s can't be overridden per namespace, only %s can",
+			strings.Join(rejected, ", "), namespace, NamespaceFeatureFlagsConfigMapName, strings.Join(NamespaceOverridableFeatureFlags(), ", "))
+		return o
+	}
+	slices.Sort(o.keys)
+	o.flags, o.err = NewFeatureFlagsFromMap(data)
+	if o.err != nil {
+		o.err = fmt.Errorf("failed to parse ConfigMap %s/%s: %w", namespace, NamespaceFeatureFlagsConfigMapName, o.err)
+	}
+	return o
+}
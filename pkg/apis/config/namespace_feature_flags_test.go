@@ -0,0 +1,194 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+func namespaceFeatureFlagsConfigMap(namespace, resourceVersion string, data map[string]string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: config.NamespaceFeatureFlagsConfigMapName, Namespace: namespace, ResourceVersion: resourceVersion},
+		Data:       data,
+	}
+}
+
+func clusterFeatureFlagsContext(t *testing.T, data map[string]string) context.Context {
+	t.Helper()
+	flags, err := config.NewFeatureFlagsFromMap(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return config.ToContext(t.Context(), &config.Config{FeatureFlags: flags, Defaults: config.DefaultConfig.DeepCopy()})
+}
+
+func TestNamespaceFeatureFlags_ToContext(t *testing.T) {
+	clusterFlags := map[string]string{
+		"keep-pod-on-cancel":             "false",
+		"enable-retries-status-trimming": "true",
+		"enable-artifacts":               "false",
+		"coschedule":                     "workspaces",
+		"disable-creds-init":             "true",
+	}
+	for _, tc := range []struct {
+		name      string
+		namespace string
+		want      map[string]string
+		wantErr   bool
+	}{{
+		name:      "namespace flags take precedence over the cluster flags",
+		namespace: "sandbox",
+		want: map[string]string{
+			"keep-pod-on-cancel":             "true",
+			"enable-retries-status-trimming": "false",
+			"enable-artifacts":               "true",
+			"coschedule":                     "pipelineruns",
+			"disable-creds-init":             "true",
+		},
+	}, {
+		name:      "flags not set by the namespace keep the cluster value",
+		namespace: "partial",
+		want: map[string]string{
+			"keep-pod-on-cancel":             "true",
+			"enable-retries-status-trimming": "true",
+			"enable-artifacts":               "false",
+			"coschedule":                     "workspaces",
+			"disable-creds-init":             "true",
+		},
+	}, {
+		name:      "namespace without a ConfigMap",
+		namespace: "other",
+		want:      clusterFlags,
+	}, {
+		name:      "flag which can't be overridden",
+		namespace: "forbidden",
+		want:      clusterFlags,
+		wantErr:   true,
+	}, {
+		name:      "provenance flag which can't be overridden",
+		namespace: "provenance",
+		want:      clusterFlags,
+		wantErr:   true,
+	}, {
+		name:      "invalid value",
+		namespace: "invalid",
+		want:      clusterFlags,
+		wantErr:   true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+			for _, cm := range []*corev1.ConfigMap{
+				namespaceFeatureFlagsConfigMap("sandbox", "1", map[string]string{
+					"keep-pod-on-cancel":             "true",
+					"enable-retries-status-trimming": "false",
+					"enable-artifacts":               "true",
+					"coschedule":                     "pipelineruns",
+					"enable-step-actions":            "true",
+				}),
+				namespaceFeatureFlagsConfigMap("partial", "1", map[string]string{"keep-pod-on-cancel": "true"}),
+				namespaceFeatureFlagsConfigMap("forbidden", "1", map[string]string{"keep-pod-on-cancel": "true", "disable-creds-init": "false"}),
+				namespaceFeatureFlagsConfigMap("provenance", "1", map[string]string{"keep-pod-on-cancel": "true", "enable-provenance-in-status": "false"}),
+				namespaceFeatureFlagsConfigMap("invalid", "1", map[string]string{"keep-pod-on-cancel": "maybe"}),
+			} {
+				if err := indexer.Add(cm); err != nil {
+					t.Fatal(err)
+				}
+			}
+			nsFlags := config.NewNamespaceFeatureFlags(corev1listers.NewConfigMapLister(indexer))
+
+			ctx, err := nsFlags.ToContext(clusterFeatureFlagsContext(t, clusterFlags), tc.namespace)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ToContext() = %v, wantErr %t", err, tc.wantErr)
+			}
+			want, err := config.NewFeatureFlagsFromMap(tc.want)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if d := cmp.Diff(want, config.FromContextOrDefaults(ctx).FeatureFlags); d != "" {
+				t.Errorf("Unexpected feature flags %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestNamespaceFeatureFlags_ConfigMapChanged(t *testing.T) {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	nsFlags := config.NewNamespaceFeatureFlags(corev1listers.NewConfigMapLister(indexer))
+	keepPodOnCancel := func() bool {
+		t.Helper()
+		ctx, err := nsFlags.ToContext(clusterFeatureFlagsContext(t, map[string]string{}), "sandbox")
+		if err != nil {
+			t.Fatalf("ToContext: %v", err)
+		}
+		return config.FromContextOrDefaults(ctx).FeatureFlags.EnableKeepPodOnCancel
+	}
+
+	if err := indexer.Add(namespaceFeatureFlagsConfigMap("sandbox", "1", map[string]string{"keep-pod-on-cancel": "true"})); err != nil {
+		t.Fatal(err)
+	}
+	if !keepPodOnCancel() {
+		t.Error("keep-pod-on-cancel isn't overridden by the namespace")
+	}
+
+	// The overrides are cached for the version of the ConfigMap.
+	cached := namespaceFeatureFlagsConfigMap("sandbox", "1", map[string]string{"keep-pod-on-cancel": "false"})
+	if err := indexer.Update(cached); err != nil {
+		t.Fatal(err)
+	}
+	if !keepPodOnCancel() {
+		t.Error("the overrides of the same version of the ConfigMap were parsed again")
+	}
+
+	if err := indexer.Update(namespaceFeatureFlagsConfigMap("sandbox", "2", map[string]string{"keep-pod-on-cancel": "false"})); err != nil {
+		t.Fatal(err)
+	}
+	if keepPodOnCancel() {
+		t.Error("the overrides weren't parsed again when the ConfigMap changed")
+	}
+
+	if err := indexer.Update(namespaceFeatureFlagsConfigMap("sandbox", "3", map[string]string{"keep-pod-on-cancel": "true"})); err != nil {
+		t.Fatal(err)
+	}
+	if !keepPodOnCancel() {
+		t.Error("the overrides weren't parsed again when the ConfigMap changed")
+	}
+
+	if err := indexer.Delete(namespaceFeatureFlagsConfigMap("sandbox", "3", nil)); err != nil {
+		t.Fatal(err)
+	}
+	if keepPodOnCancel() {
+		t.Error("the overrides of the namespace are still used after its ConfigMap was deleted")
+	}
+}
+
+func TestNamespaceFeatureFlags_Nil(t *testing.T) {
+	var nsFlags *config.NamespaceFeatureFlags
+	ctx := t.Context()
+	got, err := nsFlags.ToContext(ctx, "sandbox")
+	if err != nil || got != ctx {
+		t.Errorf("ToContext() = %v, %v, want the context as is", got, err)
+	}
+}
@@ -33,6 +33,10 @@ const (
 	// tracingCredentialsSecretKey is the name of the secret which contains credentials for tracing endpoint
 	tracingCredentialsSecretKey = "credentialsSecret"
 
+	// tracingExportRunSpansKey is the configmap key which determines if the spans of the PipelineRuns,
+	// TaskRuns and steps are exported when they complete
+	tracingExportRunSpansKey = "exportRunSpans"
+
 	// DefaultEndpoint is the default destination for sending traces
 	DefaultEndpoint = "http://jaeger-collector.jaeger.svc.cluster.local:4318/v1/traces"
 )
@@ -46,6 +50,7 @@ type Tracing struct {
 	Enabled           bool
 	Endpoint          string
 	CredentialsSecret string
+	ExportRunSpans    bool
 }
 
 // Equals returns true if two Configs are identical
@@ -60,7 +65,8 @@ func (cfg *Tracing) Equals(other *Tracing) bool {
 
 	return other.Enabled == cfg.Enabled &&
 		other.Endpoint == cfg.Endpoint &&
-		other.CredentialsSecret == cfg.CredentialsSecret
+		other.CredentialsSecret == cfg.CredentialsSecret &&
+		other.ExportRunSpans == cfg.ExportRunSpans
 }
 
 // GetTracingConfigName returns the name of the configmap containing all
@@ -94,6 +100,14 @@ func newTracingFromMap(config map[string]string) (*Tracing, error) {
 		}
 		t.Enabled = e
 	}
+
+	if exportRunSpans, ok := config[tracingExportRunSpansKey]; ok {
+		e, err := strconv.ParseBool(exportRunSpans)
+		if err != nil {
+			return nil, fmt.Errorf("failed parsing tracing config %q: %w", exportRunSpans, err)
+		}
+		t.ExportRunSpans = e
+	}
 	return &t, nil
 }
 
@@ -47,6 +47,15 @@ func TestNewTracingFromConfigMap(t *testing.T) {
 			},
 			fileName: "config-tracing-enabled",
 		},
+		{
+			name: "run spans exported",
+			want: &config.Tracing{
+				Enabled:        true,
+				Endpoint:       "http://jaeger-test",
+				ExportRunSpans: true,
+			},
+			fileName: "config-tracing-run-spans",
+		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			cm := test.ConfigMapFromTestFile(t, tc.fileName)
@@ -122,6 +131,16 @@ func TestTracingEquals(t *testing.T) {
 			},
 			expected: false,
 		},
+		{
+			name: "different exportRunSpans",
+			left: &config.Tracing{
+				ExportRunSpans: true,
+			},
+			right: &config.Tracing{
+				ExportRunSpans: false,
+			},
+			expected: false,
+		},
 		{
 			name: "same all fields",
 			left: &config.Tracing{
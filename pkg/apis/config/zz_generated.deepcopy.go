@@ -51,6 +51,28 @@ func (in *Defaults) DeepCopyInto(out *Defaults) {
 			(*out)[key] = *val.DeepCopy()
 		}
 	}
+	if in.DefaultTTLSecondsAfterFinished != nil {
+		in, out := &in.DefaultTTLSecondsAfterFinished, &out.DefaultTTLSecondsAfterFinished
+		*out = new(int32)
+		**out = **in
+	}
+	if in.RetainFailedPods != nil {
+		in, out := &in.RetainFailedPods, &out.RetainFailedPods
+		*out = new(RetainFailedPods)
+		**out = **in
+	}
+	if in.HelperImageSets != nil {
+		in, out := &in.HelperImageSets, &out.HelperImageSets
+		*out = make(map[string]HelperImageSet, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.DefaultAllowedStepCapabilities != nil {
+		in, out := &in.DefaultAllowedStepCapabilities, &out.DefaultAllowedStepCapabilities
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -90,6 +112,16 @@ func (in *Events) DeepCopy() *Events {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FeatureFlags) DeepCopyInto(out *FeatureFlags) {
 	*out = *in
+	if in.PodSecurityExemptNamespaces != nil {
+		in, out := &in.PodSecurityExemptNamespaces, &out.PodSecurityExemptNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PodSecurityExemptRuntimeClasses != nil {
+		in, out := &in.PodSecurityExemptRuntimeClasses, &out.PodSecurityExemptRuntimeClasses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.DeprecatedEnableTektonOCIBundles != nil {
 		in, out := &in.DeprecatedEnableTektonOCIBundles, &out.DeprecatedEnableTektonOCIBundles
 		*out = new(bool)
@@ -108,6 +140,22 @@ func (in *FeatureFlags) DeepCopy() *FeatureFlags {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HelperImageSet) DeepCopyInto(out *HelperImageSet) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HelperImageSet.
+func (in *HelperImageSet) DeepCopy() *HelperImageSet {
+	if in == nil {
+		return nil
+	}
+	out := new(HelperImageSet)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Metrics) DeepCopyInto(out *Metrics) {
 	*out = *in
@@ -124,6 +172,22 @@ func (in *Metrics) DeepCopy() *Metrics {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RetainFailedPods) DeepCopyInto(out *RetainFailedPods) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetainFailedPods.
+func (in *RetainFailedPods) DeepCopy() *RetainFailedPods {
+	if in == nil {
+		return nil
+	}
+	out := new(RetainFailedPods)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Tracing) DeepCopyInto(out *Tracing) {
 	*out = *in
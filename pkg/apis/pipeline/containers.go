@@ -0,0 +1,64 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipeline
+
+import "github.com/tektoncd/pipeline/pkg/names"
+
+const (
+	// StepContainerPrefix is the prefix of the names of the containers running the steps of a Task.
+	StepContainerPrefix = "step-"
+
+	// SidecarContainerPrefix is the prefix of the names of the containers running the sidecars of a Task.
+	SidecarContainerPrefix = "sidecar-"
+
+	// MaxContainerNameLength is the maximum length of the name of a container.
+	MaxContainerNameLength = 63
+
+	// MaxStepNameLength is the maximum length of the name of a step whose container name isn't shortened.
+	MaxStepNameLength = MaxContainerNameLength - len(StepContainerPrefix)
+
+	// MaxSidecarNameLength is the maximum length of the name of a sidecar whose container name isn't shortened.
+	MaxSidecarNameLength = MaxContainerNameLength - len(SidecarContainerPrefix)
+
+	// containerNameHashLength is the length of the hash of the step or sidecar name ending the shortened
+	// container names.
+	containerNameHashLength = 8
+)
+
+// StepContainerName returns the name of the container running the step named name, i.e. the step name
+// prefixed with "step-". See shortenContainerName for the names longer than MaxStepNameLength.
+func StepContainerName(name string) string {
+	return shortenContainerName(StepContainerPrefix, name)
+}
+
+// SidecarContainerName returns the name of the container running the sidecar named name, i.e. the sidecar
+// name prefixed with "sidecar-". See shortenContainerName for the names longer than MaxSidecarNameLength.
+func SidecarContainerName(name string) string {
+	return shortenContainerName(SidecarContainerPrefix, name)
+}
+
+// shortenContainerName returns name prefixed with prefix. When this is longer than the 63 characters
+// allowed for container names, e.g. for the specs which are remotely resolved and so weren't validated
+// at admission, it is truncated and ends with a hash of name instead, so that long names beginning
+// alike are still given distinct container names, the same each time the pod is built.
+func shortenContainerName(prefix, name string) string {
+	if len(prefix)+len(name) <= MaxContainerNameLength {
+		return names.SimpleNameGenerator.RestrictLength(prefix + name)
+	}
+	truncated := (prefix + name)[:MaxContainerNameLength-containerNameHashLength-1]
+	return names.GenerateHashedName(names.SimpleNameGenerator.RestrictLength(truncated), name, containerNameHashLength)
+}
@@ -36,6 +36,9 @@ type Images struct {
 	ShellImageWin string
 	// WorkingDirInitImage is the container image containing our working dir init binary.
 	WorkingDirInitImage string
+	// OCIImageFetchImage is the container image containing the binary fetching the content of
+	// OCI images into workspaces on clusters without image volumes.
+	OCIImageFetchImage string
 
 	// NOTE: Make sure to add any new images to Validate below!
 }
@@ -52,6 +55,7 @@ func (i Images) Validate() error {
 		{i.ShellImage, "shell-image"},
 		{i.ShellImageWin, "shell-image-win"},
 		{i.WorkingDirInitImage, "workingdirinit-image"},
+		{i.OCIImageFetchImage, "ociimagefetch-image"},
 	} {
 		if f.v == "" {
 			unset = append(unset, f.name)
@@ -30,6 +30,7 @@ func TestValidate(t *testing.T) {
 		ShellImage:             "set",
 		ShellImageWin:          "set",
 		WorkingDirInitImage:    "set",
+		OCIImageFetchImage:     "set",
 	}
 	if err := valid.Validate(); err != nil {
 		t.Errorf("valid Images returned error: %v", err)
@@ -42,7 +43,7 @@ func TestValidate(t *testing.T) {
 		ShellImage:             "", // unset!
 		ShellImageWin:          "set",
 	}
-	wantErr := "found unset image flags: [shell-image workingdirinit-image]"
+	wantErr := "found unset image flags: [ociimagefetch-image shell-image workingdirinit-image]"
 	if err := invalid.Validate(); err == nil {
 		t.Error("invalid Images expected error, got nil")
 	} else if err.Error() != wantErr {
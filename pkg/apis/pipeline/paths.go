@@ -28,8 +28,12 @@ const (
 	CredsDir = "/tekton/creds" // #nosec
 	// StepsDir is the directory used for a step to store any metadata related to the step
 	StepsDir = "/tekton/steps"
+	// SidecarsDir is the directory where the steps can read the results of the sidecars
+	SidecarsDir = "/tekton/sidecars"
 
 	ScriptDir = "/tekton/scripts"
 
 	ArtifactsDir = "/tekton/artifacts"
+	// ParamsDir is the directory where the values of the params declared with asFile are placed
+	ParamsDir = "/tekton/params"
 )
@@ -164,7 +164,9 @@ func (tpl *Template) Equals(other *Template) bool {
 	return reflect.DeepEqual(tpl, other)
 }
 
-// ToAffinityAssistantTemplate converts to a affinity assistant pod Template
+// ToAffinityAssistantTemplate converts to a affinity assistant pod Template.
+// TopologySpreadConstraints are left out: the affinity assistant is a singleton,
+// so there is nothing to spread.
 func (tpl *Template) ToAffinityAssistantTemplate() *AffinityAssistantTemplate {
 	if tpl == nil {
 		return nil
@@ -145,6 +145,31 @@ func TestMergePodTemplateWithDefault(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "override default topologySpreadConstraints",
+			tpl: &PodTemplate{
+				TopologySpreadConstraints: []corev1.TopologySpreadConstraint{{MaxSkew: 2, TopologyKey: "topology.kubernetes.io/zone"}},
+			},
+			defaultTpl: &PodTemplate{
+				TopologySpreadConstraints: []corev1.TopologySpreadConstraint{{MaxSkew: 1, TopologyKey: "kubernetes.io/hostname"}},
+			},
+			expected: &PodTemplate{
+				TopologySpreadConstraints: []corev1.TopologySpreadConstraint{{MaxSkew: 2, TopologyKey: "topology.kubernetes.io/zone"}},
+			},
+		},
+		{
+			name: "default topologySpreadConstraints",
+			tpl: &PodTemplate{
+				NodeSelector: map[string]string{"foo": "bar"},
+			},
+			defaultTpl: &PodTemplate{
+				TopologySpreadConstraints: []corev1.TopologySpreadConstraint{{MaxSkew: 1, TopologyKey: "kubernetes.io/hostname"}},
+			},
+			expected: &PodTemplate{
+				NodeSelector:              map[string]string{"foo": "bar"},
+				TopologySpreadConstraints: []corev1.TopologySpreadConstraint{{MaxSkew: 1, TopologyKey: "kubernetes.io/hostname"}},
+			},
+		},
 		{
 			name: "update host network",
 			tpl: &PodTemplate{
@@ -223,3 +248,53 @@ func TestMergeAAPodTemplateWithDefault(t *testing.T) {
 		})
 	}
 }
+
+func TestToAffinityAssistantTemplate_IgnoresTopologySpreadConstraints(t *testing.T) {
+	tpl := &PodTemplate{
+		NodeSelector:              map[string]string{"foo": "bar"},
+		TopologySpreadConstraints: []corev1.TopologySpreadConstraint{{MaxSkew: 1, TopologyKey: "kubernetes.io/hostname"}},
+	}
+	defaultTpl := &AAPodTemplate{
+		NodeSelector: map[string]string{"foo": "baz"},
+	}
+	expected := &AAPodTemplate{
+		NodeSelector: map[string]string{"foo": "bar"},
+	}
+	if result := MergeAAPodTemplateWithDefault(tpl.ToAffinityAssistantTemplate(), defaultTpl); !reflect.DeepEqual(result, expected) {
+		t.Errorf("MergeAAPodTemplateWithDefault(%v, %v) = %v, want %v", tpl.ToAffinityAssistantTemplate(), defaultTpl, result, expected)
+	}
+}
+
+func TestValidateTopologySpreadConstraints(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		tpl     *PodTemplate
+		wantErr string
+	}{{
+		name: "nil template",
+	}, {
+		name: "valid maxSkew",
+		tpl: &PodTemplate{
+			TopologySpreadConstraints: []corev1.TopologySpreadConstraint{{MaxSkew: 1, TopologyKey: "kubernetes.io/hostname"}},
+		},
+	}, {
+		name: "maxSkew lower than 1",
+		tpl: &PodTemplate{
+			TopologySpreadConstraints: []corev1.TopologySpreadConstraint{
+				{MaxSkew: 1, TopologyKey: "kubernetes.io/hostname"},
+				{MaxSkew: 0, TopologyKey: "topology.kubernetes.io/zone"},
+			},
+		},
+		wantErr: "invalid value: 0 should be >= 1: topologySpreadConstraints[1].maxSkew",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := ""
+			if err := tc.tpl.ValidateTopologySpreadConstraints(); err != nil {
+				got = err.Error()
+			}
+			if got != tc.wantErr {
+				t.Errorf("ValidateTopologySpreadConstraints() = %q, want %q", got, tc.wantErr)
+			}
+		})
+	}
+}
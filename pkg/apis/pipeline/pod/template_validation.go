@@ -0,0 +1,38 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"fmt"
+
+	"knative.dev/pkg/apis"
+)
+
+// ValidateTopologySpreadConstraints validates the TopologySpreadConstraints of the Template.
+// Kubernetes rejects Pods with a maxSkew lower than 1, so such constraints are reported
+// before any Pod is created from the Template.
+func (tpl *Template) ValidateTopologySpreadConstraints() (errs *apis.FieldError) {
+	if tpl == nil {
+		return nil
+	}
+	for i, c := range tpl.TopologySpreadConstraints {
+		if c.MaxSkew < 1 {
+			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%d should be >= 1", c.MaxSkew), "maxSkew").ViaFieldIndex("topologySpreadConstraints", i))
+		}
+	}
+	return errs
+}
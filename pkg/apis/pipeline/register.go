@@ -56,6 +56,22 @@ const (
 	// Set to Tasks/Finally depending on the position of the PipelineTask
 	MemberOfLabelKey = GroupName + "/memberOf"
 
+	// RetainedPodLabelKey is used as the label identifier for the pod of a failed TaskRun
+	// that is retained for investigation
+	RetainedPodLabelKey = GroupName + "/retainedPod"
+
+	// ResultsExtractionFinalizer is the finalizer of the pod of a TaskRun whose results are extracted
+	// from the logs of its results sidecar, which keeps the pod until the results are extracted
+	ResultsExtractionFinalizer = GroupName + "/results-extraction"
+
+	// FinalStatusFinalizer is the finalizer of the PipelineRuns and TaskRuns which haven't completed, which
+	// keeps them when they are deleted until their final status is recorded
+	FinalStatusFinalizer = GroupName + "/final-status"
+
+	// KeepAnnotation can be set to "true" on PipelineRuns and TaskRuns so that they aren't deleted
+	// once their ttlSecondsAfterFinished elapsed
+	KeepAnnotation = GroupName + "/keep"
+
 	// ManagedBy is the value of the "managedBy" field for resources
 	// managed by the Tekton Pipeline controller.
 	ManagedBy = GroupName + "/pipeline"
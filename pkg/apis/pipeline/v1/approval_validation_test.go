@@ -0,0 +1,275 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	cfgtesting "github.com/tektoncd/pipeline/pkg/apis/config/testing"
+	"github.com/tektoncd/pipeline/test/diff"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+func TestPipelineSpec_ValidateApprovalGate(t *testing.T) {
+	for _, tc := range []struct {
+		name          string
+		ps            *PipelineSpec
+		wc            func(context.Context) context.Context
+		expectedError *apis.FieldError
+	}{{
+		name: "valid approval gate",
+		ps: &PipelineSpec{
+			Tasks: []PipelineTask{{
+				Name:     "build",
+				TaskRef:  &TaskRef{Name: "build"},
+				RunAfter: []string{},
+			}, {
+				Name:         "approve-release",
+				ApprovalGate: &ApprovalGate{Approvers: []string{"alice", "bob"}, Timeout: &metav1.Duration{Duration: time.Hour}},
+				RunAfter:     []string{"build"},
+				When:         WhenExpressions{{Input: "$(params.release)", Operator: "in", Values: []string{"true"}}},
+			}, {
+				Name:     "release",
+				TaskRef:  &TaskRef{Name: "release"},
+				RunAfter: []string{"approve-release"},
+			}},
+			Params: ParamSpecs{{Name: "release", Type: ParamTypeString}},
+		},
+		wc: cfgtesting.EnableAlphaAPIFields,
+	}, {
+		name: "approval gate without alpha",
+		ps: &PipelineSpec{
+			Tasks: []PipelineTask{{
+				Name:         "approve",
+				ApprovalGate: &ApprovalGate{Approvers: []string{"alice"}},
+			}},
+		},
+		expectedError: apis.ErrGeneric(`approvalGate requires "enable-api-fields" feature gate to be "alpha" but it is "beta"`).ViaFieldIndex("tasks", 0),
+	}, {
+		name: "approval gate with a task",
+		ps: &PipelineSpec{
+			Tasks: []PipelineTask{{
+				Name:         "approve",
+				TaskRef:      &TaskRef{Name: "build"},
+				Retries:      2,
+				Timeout:      &metav1.Duration{Duration: time.Hour},
+				ApprovalGate: &ApprovalGate{Approvers: []string{"alice"}},
+			}},
+		},
+		wc: cfgtesting.EnableAlphaAPIFields,
+		expectedError: (&apis.FieldError{
+			Message: "must not set the field(s) with an approval gate",
+			Paths:   []string{"taskRef", "retries", "timeout"},
+			Details: "approval gates only wait for a decision; their timeout is set with approvalGate.timeout",
+		}).ViaFieldIndex("tasks", 0),
+	}, {
+		name: "approval gate without approvers",
+		ps: &PipelineSpec{
+			Tasks: []PipelineTask{{
+				Name:         "approve",
+				ApprovalGate: &ApprovalGate{},
+			}},
+		},
+		wc:            cfgtesting.EnableAlphaAPIFields,
+		expectedError: apis.ErrMissingField("tasks[0].approvalGate.approvers"),
+	}, {
+		name: "approval gate with duplicated approvers and a negative timeout",
+		ps: &PipelineSpec{
+			Tasks: []PipelineTask{{
+				Name:         "approve",
+				ApprovalGate: &ApprovalGate{Approvers: []string{"alice", "alice", " "}, Timeout: &metav1.Duration{Duration: -time.Minute}},
+			}},
+		},
+		wc: cfgtesting.EnableAlphaAPIFields,
+		expectedError: apis.ErrInvalidValue("alice", "", "approvers must be unique").ViaFieldIndex("approvers", 1).
+			Also(apis.ErrInvalidValue(" ", "", "approver names can't be empty").ViaFieldIndex("approvers", 2)).
+			Also(apis.ErrInvalidValue("-1m0s", "timeout", "should be > 0")).
+			ViaField("approvalGate").ViaFieldIndex("tasks", 0),
+	}, {
+		name: "approval gate in finally",
+		ps: &PipelineSpec{
+			Tasks: []PipelineTask{{
+				Name:    "build",
+				TaskRef: &TaskRef{Name: "build"},
+			}},
+			Finally: []PipelineTask{{
+				Name:         "approve",
+				ApprovalGate: &ApprovalGate{Approvers: []string{"alice"}},
+			}},
+		},
+		wc:            cfgtesting.EnableAlphaAPIFields,
+		expectedError: apis.ErrInvalidValue("final task approve can't be an approval gate", "finally[0].approvalGate"),
+	}, {
+		name: "result reference to an approval gate",
+		ps: &PipelineSpec{
+			Tasks: []PipelineTask{{
+				Name:         "approve",
+				ApprovalGate: &ApprovalGate{Approvers: []string{"alice"}},
+			}, {
+				Name:    "release",
+				TaskRef: &TaskRef{Name: "release"},
+				Params:  Params{{Name: "decision", Value: *NewStructuredValues("$(tasks.approve.results.decision)")}},
+			}},
+		},
+		wc:            cfgtesting.EnableAlphaAPIFields,
+		expectedError: apis.ErrInvalidValue(`result reference to pipelineTask "approve" is not supported: approval gates don't produce results`, "tasks[1]"),
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := t.Context()
+			if tc.wc != nil {
+				ctx = tc.wc(ctx)
+			}
+			err := tc.ps.Validate(ctx)
+			if d := cmp.Diff(tc.expectedError.Error(), err.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
+				t.Errorf("PipelineSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestPipelineRun_ValidateApprovals(t *testing.T) {
+	now := metav1.NewTime(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC))
+	waiting := ApprovalGateStatus{
+		PipelineTaskName: "approve",
+		Approvers:        []string{"alice", "bob"},
+		Reason:           ApprovalGateReasonWaiting,
+		StartTime:        now,
+	}
+	approved := ApprovalGateStatus{
+		PipelineTaskName: "approved",
+		Approvers:        []string{"alice"},
+		Reason:           ApprovalGateReasonApproved,
+		StartTime:        now,
+		CompletionTime:   &now,
+	}
+	pipelineRun := func(gates []ApprovalGateStatus, approvals ...Approval) *PipelineRun {
+		return &PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{Name: "pr"},
+			Spec:       PipelineRunSpec{PipelineRef: &PipelineRef{Name: "release"}},
+			Status: PipelineRunStatus{PipelineRunStatusFields: PipelineRunStatusFields{
+				ApprovalGates: gates,
+				Approvals:     approvals,
+			}},
+		}
+	}
+	approval := func(approver string, decision ApprovalDecision) Approval {
+		return Approval{PipelineTaskName: "approve", Approver: approver, Decision: decision, Time: now}
+	}
+
+	for _, tc := range []struct {
+		name          string
+		baseline      *PipelineRun
+		pr            *PipelineRun
+		user          string
+		expectedError string
+	}{{
+		name:     "approved by an approver",
+		baseline: pipelineRun([]ApprovalGateStatus{waiting}),
+		pr:       pipelineRun([]ApprovalGateStatus{waiting}, approval("bob", ApprovalDecisionApprove)),
+		user:     "bob",
+	}, {
+		name:     "rejected by an approver",
+		baseline: pipelineRun([]ApprovalGateStatus{waiting}),
+		pr:       pipelineRun([]ApprovalGateStatus{waiting}, approval("alice", ApprovalDecisionReject)),
+		user:     "alice",
+	}, {
+		name:     "status updated by the controller",
+		baseline: pipelineRun([]ApprovalGateStatus{waiting}, approval("alice", ApprovalDecisionApprove)),
+		pr:       pipelineRun([]ApprovalGateStatus{approved}, approval("alice", ApprovalDecisionApprove)),
+		user:     "system:serviceaccount:tekton-pipelines:tekton-pipelines-controller",
+	}, {
+		name:          "approval added by another user",
+		baseline:      pipelineRun([]ApprovalGateStatus{waiting}),
+		pr:            pipelineRun([]ApprovalGateStatus{waiting}, approval("bob", ApprovalDecisionApprove)),
+		user:          "alice",
+		expectedError: `invalid value: bob: status.approvals[0].approver` + "\n" + `approvals must be added by the approver, not by "alice"`,
+	}, {
+		name:          "approval added by a user who isn't an approver",
+		baseline:      pipelineRun([]ApprovalGateStatus{waiting}),
+		pr:            pipelineRun([]ApprovalGateStatus{waiting}, approval("mallory", ApprovalDecisionApprove)),
+		user:          "mallory",
+		expectedError: `invalid value: mallory: status.approvals[0].approver` + "\n" + `not one of the approvers of the approval gate: alice, bob`,
+	}, {
+		name:          "approval with an invalid decision",
+		baseline:      pipelineRun([]ApprovalGateStatus{waiting}),
+		pr:            pipelineRun([]ApprovalGateStatus{waiting}, approval("alice", "maybe")),
+		user:          "alice",
+		expectedError: `invalid value: maybe: status.approvals[0].decision` + "\n" + `must be either "approve" or "reject"`,
+	}, {
+		name:          "approval of a gate which isn't waiting",
+		baseline:      pipelineRun([]ApprovalGateStatus{approved}),
+		pr:            pipelineRun([]ApprovalGateStatus{approved}, Approval{PipelineTaskName: "approved", Approver: "alice", Decision: ApprovalDecisionReject, Time: now}),
+		user:          "alice",
+		expectedError: `invalid value: approved: status.approvals[0].pipelineTaskName` + "\n" + `the approval gate isn't waiting for a decision anymore`,
+	}, {
+		name:          "second decision on a gate",
+		baseline:      pipelineRun([]ApprovalGateStatus{waiting}, approval("alice", ApprovalDecisionApprove)),
+		pr:            pipelineRun([]ApprovalGateStatus{waiting}, approval("alice", ApprovalDecisionApprove), approval("bob", ApprovalDecisionReject)),
+		user:          "bob",
+		expectedError: `invalid value: approve: status.approvals[1].pipelineTaskName` + "\n" + `the approval gate isn't waiting for a decision anymore`,
+	}, {
+		name:          "approval of a gate which wasn't reached",
+		baseline:      pipelineRun(nil),
+		pr:            pipelineRun(nil, approval("alice", ApprovalDecisionApprove)),
+		user:          "alice",
+		expectedError: `invalid value: approve: status.approvals[0].pipelineTaskName` + "\n" + `no approval gate with this name was reached`,
+	}, {
+		name:          "approval removed",
+		baseline:      pipelineRun([]ApprovalGateStatus{waiting}, approval("alice", ApprovalDecisionReject)),
+		pr:            pipelineRun([]ApprovalGateStatus{waiting}),
+		user:          "system:serviceaccount:tekton-pipelines:tekton-pipelines-controller",
+		expectedError: `invalid value: approvals can't be changed or removed once added: status.approvals`,
+	}, {
+		name:          "approval changed",
+		baseline:      pipelineRun([]ApprovalGateStatus{waiting}, approval("alice", ApprovalDecisionReject)),
+		pr:            pipelineRun([]ApprovalGateStatus{waiting}, approval("alice", ApprovalDecisionApprove)),
+		user:          "alice",
+		expectedError: `invalid value: approvals can't be changed or removed once added: status.approvals`,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := apis.WithinSubResourceUpdate(t.Context(), tc.baseline, "status")
+			ctx = apis.WithUserInfo(ctx, &authenticationv1.UserInfo{Username: tc.user})
+			var got string
+			if err := tc.pr.Validate(ctx); err != nil {
+				got = err.Error()
+			}
+			if d := cmp.Diff(tc.expectedError, got); d != "" {
+				t.Errorf("PipelineRun.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestPipelineRun_ValidateApprovals_NotInStatusUpdate(t *testing.T) {
+	// Approvals are only validated on updates of the status subresource.
+	pr := &PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "pr"},
+		Spec:       PipelineRunSpec{PipelineRef: &PipelineRef{Name: "release"}},
+		Status: PipelineRunStatus{PipelineRunStatusFields: PipelineRunStatusFields{
+			Approvals: []Approval{{PipelineTaskName: "approve", Approver: "alice", Decision: ApprovalDecisionApprove}},
+		}},
+	}
+	if err := pr.Validate(t.Context()); err != nil {
+		t.Errorf("PipelineRun.Validate() returned error: %v", err)
+	}
+}
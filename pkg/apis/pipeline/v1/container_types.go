@@ -133,6 +133,11 @@ type Step struct {
 	// OnError defines the exiting behavior of a container on error
 	// can be set to [ continue | stopAndFail ]
 	OnError OnErrorType `json:"onError,omitempty"`
+	// Retries is the number of times the command of the Step is run again when it exits with a
+	// non-zero exit code, with a small backoff between the attempts. The exit code of the Step is
+	// the one of its last attempt. It can't exceed the "default-max-step-retries" default.
+	// +optional
+	Retries int `json:"retries,omitempty"`
 	// Stores configuration for the stdout stream of the step.
 	// +optional
 	StdoutConfig *StepOutputConfig `json:"stdoutConfig,omitempty"`
@@ -157,6 +162,23 @@ type Step struct {
 	// When is a list of when expressions that need to be true for the task to run
 	// +optional
 	When StepWhenExpressions `json:"when,omitempty"`
+
+	// SecurityProfile is the security profile of the Step: Steps with the "isolated" security profile,
+	// e.g. running untrusted code, run in a second pod of the TaskRun using the RuntimeClass set by
+	// the "isolated-steps-runtime-class" feature flag.
+	// +optional
+	SecurityProfile StepSecurityProfile `json:"securityProfile,omitempty"`
+
+	// Capabilities are the Linux capabilities added to and dropped from the container of the Step, on
+	// top of the ones of its securityContext. Only the capabilities allowed by the
+	// "default-allowed-step-capabilities" default may be added.
+	// +optional
+	Capabilities *corev1.Capabilities `json:"capabilities,omitempty"`
+
+	// AutomountServiceAccountToken can be set to false to remove the token of the ServiceAccount of the
+	// TaskRun from the container of the Step, when it is mounted in the pod.
+	// +optional
+	AutomountServiceAccountToken *bool `json:"automountServiceAccountToken,omitempty"`
 }
 
 // Ref can be used to refer to a specific instance of a StepAction.
@@ -177,6 +199,17 @@ const (
 	StopAndFail OnErrorType = "stopAndFail"
 	// Continue indicates continue executing the rest of the steps irrespective of the container exit code
 	Continue OnErrorType = "continue"
+	// Ignore indicates the TaskRun isn't affected by a sidecar exiting with non-zero exit code
+	Ignore OnErrorType = "ignore"
+)
+
+// StepSecurityProfile defines the security profiles of a Step
+type StepSecurityProfile string
+
+const (
+	// StepSecurityProfileIsolated indicates that the Step runs in a second pod of the TaskRun, using the
+	// RuntimeClass of the isolated steps rather than the one of the pod running the other Steps
+	StepSecurityProfileIsolated StepSecurityProfile = "isolated"
 )
 
 // StepOutputConfig stores configuration for a step output stream.
@@ -551,6 +584,23 @@ type Sidecar struct {
 	// was introduced.
 	// +optional
 	RestartPolicy *corev1.ContainerRestartPolicy `json:"restartPolicy,omitempty"`
+
+	// This is an alpha field. You must set the "enable-api-fields" feature flag to "alpha"
+	// for this field to be supported.
+	//
+	// Results declares the results produced by the Sidecar, which it writes to
+	// $(sidecar.results.<resultName>.path). Steps can read them from
+	// $(sidecars.<sidecarName>.results.<resultName>.path) and Task results can
+	// take their value from $(sidecars.<sidecarName>.results.<resultName>).
+	// +optional
+	// +listType=atomic
+	Results []StepResult `json:"results,omitempty"`
+
+	// OnError defines whether the TaskRun fails when the Sidecar exits with a non-zero exit code
+	// while the Steps are still running: "stopAndFail" fails the TaskRun with the reason
+	// "TaskRunSidecarFailed", "ignore", the default, lets the Steps run.
+	// +optional
+	OnError OnErrorType `json:"onError,omitempty"`
 }
 
 // ToK8sContainer converts the Sidecar to a Kubernetes Container struct
@@ -30,6 +30,7 @@ import (
 	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	"github.com/tektoncd/pipeline/pkg/internal/resultref"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/validation"
 	"knative.dev/pkg/apis"
 )
@@ -218,11 +219,48 @@ func (s *Step) Validate(ctx context.Context) (errs *apis.FieldError) {
 		}
 	}
 
+	maxRetries := config.DefaultMaxStepRetries
+	if defaults := config.FromContextOrDefaults(ctx).Defaults; defaults != nil {
+		maxRetries = defaults.DefaultMaxStepRetries
+	}
+	if s.Retries < 0 || s.Retries > maxRetries {
+		errs = errs.Also(apis.ErrOutOfBoundsValue(s.Retries, 0, maxRetries, "retries"))
+	}
+
+	// The RuntimeClass of a pod applies to all of its containers, so the steps asking for another
+	// RuntimeClass can only run in a second pod of the TaskRun.
+	switch {
+	case s.SecurityProfile == "":
+	case s.SecurityProfile != StepSecurityProfileIsolated:
+		errs = errs.Also(apis.ErrInvalidValue(s.SecurityProfile, "securityProfile", fmt.Sprintf("Task step securityProfile must be %q", StepSecurityProfileIsolated)))
+	case config.FromContextOrDefaults(ctx).FeatureFlags.IsolatedStepsRuntimeClass == "":
+		errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("steps with the %q securityProfile need the %q feature flag to be set, as the RuntimeClass of a pod applies to all of its steps", StepSecurityProfileIsolated, config.IsolatedStepsRuntimeClassKey), "securityProfile"))
+	}
+
+	defaults := config.FromContextOrDefaults(ctx).Defaults
+	if s.Capabilities != nil {
+		errs = errs.Also(validateAddedCapabilities(defaults, s.Name, s.Capabilities.Add).ViaField("capabilities"))
+	}
+	// The capabilities added by the securityContext are only restricted once the allowed capabilities
+	// are set, as they were allowed before.
+	if s.SecurityContext != nil && s.SecurityContext.Capabilities != nil && defaults != nil && len(defaults.DefaultAllowedStepCapabilities) > 0 {
+		errs = errs.Also(validateAddedCapabilities(defaults, s.Name, s.SecurityContext.Capabilities.Add).ViaField("securityContext.capabilities"))
+	}
+
+	// The legacy credentials helper (aka "creds-init") initializes the credentials of the ServiceAccount
+	// for every step, so removing the token doesn't remove all of its credentials from the step.
+	if s.AutomountServiceAccountToken != nil && !*s.AutomountServiceAccountToken && !config.FromContextOrDefaults(ctx).FeatureFlags.DisableCredsInit {
+		errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("the ServiceAccount token is removed from step %q but the credentials of the ServiceAccount are still initialized in it unless the \"disable-creds-init\" feature flag is set", s.Name), "automountServiceAccountToken").At(apis.WarningLevel))
+	}
+
 	if s.Script != "" {
 		cleaned := strings.TrimSpace(s.Script)
 		if strings.HasPrefix(cleaned, "#!win") {
 			errs = errs.Also(config.ValidateEnabledAPIFields(ctx, "windows script support", config.AlphaAPIFields).ViaField("script"))
 		}
+		if ff := config.FromContextOrDefaults(ctx).FeatureFlags; ff != nil && ff.DisableInlineScripts {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("step %q can't have a script, scripts are disabled by the %q feature flag", s.Name, config.DisableInlineScriptsKey), "script"))
+		}
 	}
 
 	// StdoutConfig is an alpha feature and will fail validation if it's used in a task spec
@@ -386,6 +424,144 @@ func (sc *Sidecar) Validate(ctx context.Context) (errs *apis.FieldError) {
 				Paths:   []string{"script"},
 			})
 		}
+		if ff := config.FromContextOrDefaults(ctx).FeatureFlags; ff != nil && ff.DisableInlineScripts {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("sidecar %q can't have a script, scripts are disabled by the %q feature flag", sc.Name, config.DisableInlineScriptsKey), "script"))
+		}
+	}
+
+	if sc.OnError != "" && sc.OnError != StopAndFail && sc.OnError != Ignore {
+		errs = errs.Also(&apis.FieldError{
+			Message: fmt.Sprintf("invalid value: \"%v\"", sc.OnError),
+			Paths:   []string{"onError"},
+			Details: "Sidecar onError must be either \"stopAndFail\" or \"ignore\"",
+		})
+	}
+	return errs
+}
+
+// sidecarVariableField is a field of a Sidecar in which variables are substituted.
+// +k8s:openapi-gen=false
+type sidecarVariableField struct {
+	path  string
+	value string
+	// expandsArrays is set for the fields which array params are expanded into, where the array
+	// params have to be referenced on their own.
+	expandsArrays bool
+}
+
+// variableFields returns the fields of the Sidecar in which variables are substituted, i.e. the
+// fields a reference to a param, a workspace or a context variable can be used in.
+func (sc *Sidecar) variableFields() []sidecarVariableField {
+	fields := []sidecarVariableField{
+		{path: "name", value: sc.Name},
+		{path: "image", value: sc.Image},
+		{path: "imagePullPolicy", value: string(sc.ImagePullPolicy)},
+		{path: "workingDir", value: sc.WorkingDir},
+		{path: "script", value: sc.Script},
+	}
+	for i, cmd := range sc.Command {
+		fields = append(fields, sidecarVariableField{path: fmt.Sprintf("command[%d]", i), value: cmd, expandsArrays: true})
+	}
+	for i, arg := range sc.Args {
+		fields = append(fields, sidecarVariableField{path: fmt.Sprintf("args[%d]", i), value: arg, expandsArrays: true})
+	}
+	for _, env := range sc.Env {
+		path := fmt.Sprintf("env[%s]", env.Name)
+		fields = append(fields, sidecarVariableField{path: path, value: env.Value})
+		if env.ValueFrom == nil {
+			continue
+		}
+		if ref := env.ValueFrom.FieldRef; ref != nil {
+			fields = append(fields, sidecarVariableField{path: path + ".valueFrom.fieldRef.fieldPath", value: ref.FieldPath})
+		}
+		if ref := env.ValueFrom.ResourceFieldRef; ref != nil {
+			fields = append(fields,
+				sidecarVariableField{path: path + ".valueFrom.resourceFieldRef.containerName", value: ref.ContainerName},
+				sidecarVariableField{path: path + ".valueFrom.resourceFieldRef.resource", value: ref.Resource})
+		}
+		if ref := env.ValueFrom.SecretKeyRef; ref != nil {
+			fields = append(fields,
+				sidecarVariableField{path: path + ".valueFrom.secretKeyRef.name", value: ref.Name},
+				sidecarVariableField{path: path + ".valueFrom.secretKeyRef.key", value: ref.Key})
+		}
+		if ref := env.ValueFrom.ConfigMapKeyRef; ref != nil {
+			fields = append(fields,
+				sidecarVariableField{path: path + ".valueFrom.configMapKeyRef.name", value: ref.Name},
+				sidecarVariableField{path: path + ".valueFrom.configMapKeyRef.key", value: ref.Key})
+		}
+	}
+	for i, env := range sc.EnvFrom {
+		path := fmt.Sprintf("envFrom[%d]", i)
+		fields = append(fields, sidecarVariableField{path: path + ".prefix", value: env.Prefix})
+		if env.ConfigMapRef != nil {
+			fields = append(fields, sidecarVariableField{path: path + ".configMapRef.name", value: env.ConfigMapRef.Name})
+		}
+		if env.SecretRef != nil {
+			fields = append(fields, sidecarVariableField{path: path + ".secretRef.name", value: env.SecretRef.Name})
+		}
+	}
+	for i, v := range sc.VolumeMounts {
+		path := fmt.Sprintf("volumeMounts[%d]", i)
+		fields = append(fields,
+			sidecarVariableField{path: path + ".name", value: v.Name},
+			sidecarVariableField{path: path + ".mountPath", value: v.MountPath},
+			sidecarVariableField{path: path + ".subPath", value: v.SubPath})
+	}
+	for _, probe := range []struct {
+		path  string
+		probe *corev1.Probe
+	}{{"livenessProbe", sc.LivenessProbe}, {"readinessProbe", sc.ReadinessProbe}, {"startupProbe", sc.StartupProbe}} {
+		if probe.probe == nil {
+			continue
+		}
+		fields = append(fields, handlerVariableFields(probe.path, probe.probe.Exec, probe.probe.HTTPGet, probe.probe.TCPSocket)...)
+		if grpc := probe.probe.GRPC; grpc != nil && grpc.Service != nil {
+			fields = append(fields, sidecarVariableField{path: probe.path + ".grpc.service", value: *grpc.Service})
+		}
+	}
+	if sc.Lifecycle != nil {
+		if h := sc.Lifecycle.PostStart; h != nil {
+			fields = append(fields, handlerVariableFields("lifecycle.postStart", h.Exec, h.HTTPGet, h.TCPSocket)...)
+		}
+		if h := sc.Lifecycle.PreStop; h != nil {
+			fields = append(fields, handlerVariableFields("lifecycle.preStop", h.Exec, h.HTTPGet, h.TCPSocket)...)
+		}
+	}
+	return fields
+}
+
+// handlerVariableFields returns the fields of the actions of a probe or a lifecycle hook in which variables are substituted.
+func handlerVariableFields(path string, exec *corev1.ExecAction, httpGet *corev1.HTTPGetAction, tcpSocket *corev1.TCPSocketAction) []sidecarVariableField {
+	var fields []sidecarVariableField
+	if exec != nil {
+		for i, cmd := range exec.Command {
+			fields = append(fields, sidecarVariableField{path: fmt.Sprintf("%s.exec.command[%d]", path, i), value: cmd, expandsArrays: true})
+		}
+	}
+	if httpGet != nil {
+		fields = append(fields,
+			sidecarVariableField{path: path + ".httpGet.path", value: httpGet.Path},
+			sidecarVariableField{path: path + ".httpGet.host", value: httpGet.Host},
+			sidecarVariableField{path: path + ".httpGet.port", value: httpGet.Port.StrVal})
+		for i, h := range httpGet.HTTPHeaders {
+			fields = append(fields, sidecarVariableField{path: fmt.Sprintf("%s.httpGet.httpHeaders[%d].value", path, i), value: h.Value})
+		}
+	}
+	if tcpSocket != nil {
+		fields = append(fields,
+			sidecarVariableField{path: path + ".tcpSocket.host", value: tcpSocket.Host},
+			sidecarVariableField{path: path + ".tcpSocket.port", value: tcpSocket.Port.StrVal})
+	}
+	return fields
+}
+
+// validateAddedCapabilities validates that the Linux capabilities added to the step named stepName are
+// allowed by the "default-allowed-step-capabilities" default.
+func validateAddedCapabilities(defaults *config.Defaults, stepName string, added []corev1.Capability) (errs *apis.FieldError) {
+	for i, c := range added {
+		if defaults == nil || !defaults.StepCapabilityAllowed(c) {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("capability %q can't be added to step %q, it isn't allowed by the %q default", c, stepName, config.DefaultAllowedStepCapabilitiesKey), fmt.Sprintf("add[%d]", i)))
+		}
 	}
 	return errs
 }
@@ -31,6 +31,7 @@ import (
 	"github.com/tektoncd/pipeline/test/diff"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 	"knative.dev/pkg/apis"
 )
 
@@ -1080,6 +1081,20 @@ func TestSidecarValidate(t *testing.T) {
 			Name:  "my-sidecar",
 			Image: "my-image",
 		},
+	}, {
+		name: "sidecar failing the TaskRun on error",
+		sidecar: v1.Sidecar{
+			Name:    "my-sidecar",
+			Image:   "my-image",
+			OnError: v1.StopAndFail,
+		},
+	}, {
+		name: "sidecar ignoring errors",
+		sidecar: v1.Sidecar{
+			Name:    "my-sidecar",
+			Image:   "my-image",
+			OnError: v1.Ignore,
+		},
 	}}
 
 	for _, sct := range tests {
@@ -1130,6 +1145,18 @@ func TestSidecarValidateError(t *testing.T) {
 			Message: "script cannot be used with command",
 			Paths:   []string{"script"},
 		},
+	}, {
+		name: "invalid onError",
+		sidecar: v1.Sidecar{
+			Name:    "my-sidecar",
+			Image:   "my-image",
+			OnError: v1.Continue,
+		},
+		expectedError: apis.FieldError{
+			Message: `invalid value: "continue"`,
+			Paths:   []string{"onError"},
+			Details: `Sidecar onError must be either "stopAndFail" or "ignore"`,
+		},
 	}}
 
 	for _, sct := range tests {
@@ -1144,3 +1171,187 @@ func TestSidecarValidateError(t *testing.T) {
 		})
 	}
 }
+
+func TestStepValidate_SecurityProfile(t *testing.T) {
+	tests := []struct {
+		name          string
+		profile       v1.StepSecurityProfile
+		runtimeClass  string
+		expectedError string
+	}{{
+		name:         "isolated step with the isolated steps runtime class set",
+		profile:      v1.StepSecurityProfileIsolated,
+		runtimeClass: "gvisor",
+	}, {
+		name:          "isolated step without the isolated steps runtime class",
+		profile:       v1.StepSecurityProfileIsolated,
+		expectedError: `steps with the "isolated" securityProfile need the "isolated-steps-runtime-class" feature flag to be set, as the RuntimeClass of a pod applies to all of its steps: securityProfile`,
+	}, {
+		name:          "unknown security profile",
+		profile:       "gvisor",
+		runtimeClass:  "gvisor",
+		expectedError: `invalid value: gvisor: securityProfile` + "\n" + `Task step securityProfile must be "isolated"`,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := config.ToContext(t.Context(), &config.Config{
+				FeatureFlags: &config.FeatureFlags{IsolatedStepsRuntimeClass: tt.runtimeClass},
+			})
+			s := v1.Step{Image: "my-image", SecurityProfile: tt.profile}
+			gotError := ""
+			if err := s.Validate(ctx); err != nil {
+				gotError = err.Error()
+			}
+			if d := cmp.Diff(tt.expectedError, gotError); d != "" {
+				t.Errorf("Step.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestStepValidate_Capabilities(t *testing.T) {
+	tests := []struct {
+		name            string
+		capabilities    *corev1.Capabilities
+		securityContext *corev1.SecurityContext
+		allowed         string
+		expectedError   string
+	}{{
+		name:         "allowed capabilities",
+		capabilities: &corev1.Capabilities{Add: []corev1.Capability{"SETFCAP", "CAP_SETGID"}, Drop: []corev1.Capability{"NET_RAW"}},
+		allowed:      "SETFCAP,SETGID",
+	}, {
+		name:         "all capabilities allowed",
+		capabilities: &corev1.Capabilities{Add: []corev1.Capability{"SYS_ADMIN"}},
+		allowed:      "ALL",
+	}, {
+		name:         "dropped capabilities",
+		capabilities: &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}},
+	}, {
+		name:          "capability not allowed",
+		capabilities:  &corev1.Capabilities{Add: []corev1.Capability{"SETFCAP", "SYS_ADMIN"}},
+		allowed:       "SETFCAP",
+		expectedError: `capability "SYS_ADMIN" can't be added to step "build", it isn't allowed by the "default-allowed-step-capabilities" default: capabilities.add[1]`,
+	}, {
+		name:          "no capability allowed",
+		capabilities:  &corev1.Capabilities{Add: []corev1.Capability{"SETFCAP"}},
+		expectedError: `capability "SETFCAP" can't be added to step "build", it isn't allowed by the "default-allowed-step-capabilities" default: capabilities.add[0]`,
+	}, {
+		name:            "allowed capabilities of the securityContext",
+		securityContext: &corev1.SecurityContext{Capabilities: &corev1.Capabilities{Add: []corev1.Capability{"SETFCAP"}}},
+		allowed:         "SETFCAP",
+	}, {
+		name:            "capability of the securityContext not allowed",
+		securityContext: &corev1.SecurityContext{Capabilities: &corev1.Capabilities{Add: []corev1.Capability{"SYS_ADMIN"}}},
+		allowed:         "SETFCAP",
+		expectedError:   `capability "SYS_ADMIN" can't be added to step "build", it isn't allowed by the "default-allowed-step-capabilities" default: securityContext.capabilities.add[0]`,
+	}, {
+		name:            "capabilities of the securityContext without allowed capabilities",
+		securityContext: &corev1.SecurityContext{Capabilities: &corev1.Capabilities{Add: []corev1.Capability{"SYS_ADMIN"}}},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defaults, err := config.NewDefaultsFromMap(map[string]string{config.DefaultAllowedStepCapabilitiesKey: tt.allowed})
+			if err != nil {
+				t.Fatal(err)
+			}
+			ctx := config.ToContext(t.Context(), &config.Config{Defaults: defaults, FeatureFlags: config.DefaultFeatureFlags.DeepCopy()})
+			s := v1.Step{Name: "build", Image: "my-image", Capabilities: tt.capabilities, SecurityContext: tt.securityContext}
+			gotError := ""
+			if err := s.Validate(ctx); err != nil {
+				gotError = err.Error()
+			}
+			if d := cmp.Diff(tt.expectedError, gotError); d != "" {
+				t.Errorf("Step.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestStepValidate_AutomountServiceAccountToken(t *testing.T) {
+	tests := []struct {
+		name             string
+		automount        *bool
+		disableCredsInit bool
+		expectedError    string
+	}{{
+		name: "token mounted by default",
+	}, {
+		name:      "token mounted",
+		automount: ptr.To(true),
+	}, {
+		name:             "token removed without creds-init",
+		automount:        ptr.To(false),
+		disableCredsInit: true,
+	}, {
+		name:          "token removed with creds-init",
+		automount:     ptr.To(false),
+		expectedError: `the ServiceAccount token is removed from step "build" but the credentials of the ServiceAccount are still initialized in it unless the "disable-creds-init" feature flag is set: automountServiceAccountToken`,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			featureFlags := config.DefaultFeatureFlags.DeepCopy()
+			featureFlags.DisableCredsInit = tt.disableCredsInit
+			ctx := config.ToContext(t.Context(), &config.Config{FeatureFlags: featureFlags})
+			s := v1.Step{Name: "build", Image: "my-image", AutomountServiceAccountToken: tt.automount}
+			gotError := ""
+			if err := s.Validate(ctx); err != nil {
+				if err.Filter(apis.ErrorLevel) != nil {
+					t.Errorf("Expected only warnings but got %v", err)
+				}
+				gotError = err.Error()
+			}
+			if d := cmp.Diff(tt.expectedError, gotError); d != "" {
+				t.Errorf("Step.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestStepValidate_Retries(t *testing.T) {
+	tests := []struct {
+		name          string
+		retries       int
+		maxRetries    int
+		expectedError string
+	}{{
+		name:       "retries within the maximum",
+		retries:    3,
+		maxRetries: 5,
+	}, {
+		name:       "retries at the maximum",
+		retries:    5,
+		maxRetries: 5,
+	}, {
+		name:          "retries over the maximum",
+		retries:       6,
+		maxRetries:    5,
+		expectedError: "expected 0 <= 6 <= 5: retries",
+	}, {
+		name:          "negative retries",
+		retries:       -1,
+		maxRetries:    5,
+		expectedError: "expected 0 <= -1 <= 5: retries",
+	}, {
+		name:          "retries disabled",
+		retries:       1,
+		maxRetries:    0,
+		expectedError: "expected 0 <= 1 <= 0: retries",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := config.ToContext(t.Context(), &config.Config{
+				Defaults:     &config.Defaults{DefaultMaxStepRetries: tt.maxRetries},
+				FeatureFlags: config.DefaultFeatureFlags.DeepCopy(),
+			})
+			s := v1.Step{Image: "my-image", Retries: tt.retries}
+			gotError := ""
+			if err := s.Validate(ctx); err != nil {
+				gotError = err.Error()
+			}
+			if d := cmp.Diff(tt.expectedError, gotError); d != "" {
+				t.Errorf("Step.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
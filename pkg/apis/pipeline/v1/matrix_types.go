@@ -36,6 +36,12 @@ type Matrix struct {
 	// Include is a list of IncludeParams which allows passing in specific combinations of Parameters into the Matrix.
 	// +optional
 	Include IncludeParamsList `json:"include,omitempty"`
+
+	// FailFast, true by default, stops the instances of the Matrix which weren't created yet from being
+	// created once one of its instances failed. When false, all the instances are created and awaited,
+	// and the PipelineTask fails once all of them are done.
+	// +optional
+	FailFast *bool `json:"failFast,omitempty"`
 }
 
 // IncludeParamsList is a list of IncludeParams which allows passing in specific combinations of Parameters into the Matrix.
@@ -272,6 +278,11 @@ func (m *Matrix) HasInclude() bool {
 	return m != nil && m.Include != nil && len(m.Include) > 0
 }
 
+// IsFailFast returns true unless the Matrix sets FailFast to false
+func (m *Matrix) IsFailFast() bool {
+	return m == nil || m.FailFast == nil || *m.FailFast
+}
+
 // HasParams returns true if the Matrix has Parameters
 func (m *Matrix) HasParams() bool {
 	return m != nil && m.Params != nil && len(m.Params) > 0
@@ -316,6 +327,15 @@ func (m *Matrix) validateUniqueParams() (errs *apis.FieldError) {
 	return errs
 }
 
+// validateFailFastUnsupported rejects failFast in the Matrix of a Custom Task or of a Pipeline, which
+// only the TaskRuns of the instances of a Matrix honor.
+func (m *Matrix) validateFailFastUnsupported() *apis.FieldError {
+	if m != nil && m.FailFast != nil {
+		return apis.ErrGeneric("failFast is only supported by the matrices of Tasks, not by the ones of Custom Tasks or Pipelines", "matrix.failFast")
+	}
+	return nil
+}
+
 // validatePipelineParametersVariablesInMatrixParameters validates all pipeline parameter variables including Matrix.Params and Matrix.Include.Params
 // that may contain the reference(s) to other params to make sure those references are used appropriately.
 func (m *Matrix) validatePipelineParametersVariablesInMatrixParameters(prefix string, paramNames sets.String, arrayParamNames sets.String, objectParamNameKeys map[string][]string) (errs *apis.FieldError) {
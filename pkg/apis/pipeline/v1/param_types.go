@@ -20,7 +20,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"regexp"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/substitution"
@@ -63,6 +65,22 @@ type ParamSpec struct {
 	// If Enum is not set, no input validation is performed for the param.
 	// +optional
 	Enum []string `json:"enum,omitempty"`
+	// Pattern is a regular expression, in the RE2 syntax, that the value of a string param must match.
+	// +optional
+	Pattern string `json:"pattern,omitempty"`
+	// MinLength is the minimum number of characters of the value of a string param.
+	// +optional
+	MinLength int `json:"minLength,omitempty"`
+	// MaxLength is the maximum number of characters of the value of a string param.
+	// If MaxLength is not set, the length of the value isn't limited.
+	// +optional
+	MaxLength int `json:"maxLength,omitempty"`
+	// AsFile delivers the value of the parameter in a file under /tekton/params/<name>
+	// instead of splicing it in the Steps: $(params.<name>.path) is replaced by the path
+	// of this file, or of the directory holding one file per key for an object parameter.
+	// It can't be set on array parameters.
+	// +optional
+	AsFile bool `json:"asFile,omitempty"`
 }
 
 // ParamSpecs is a list of ParamSpec
@@ -176,6 +194,88 @@ func (ps ParamSpecs) validateParamEnums(ctx context.Context) *apis.FieldError {
 	return errs
 }
 
+// hasConstraints returns true if the ParamSpec declares a pattern, a minLength or a maxLength.
+func (pp *ParamSpec) hasConstraints() bool {
+	return pp.Pattern != "" || pp.MinLength != 0 || pp.MaxLength != 0
+}
+
+// ValidateConstraints returns an error naming the param and the constraint violated if the value
+// doesn't satisfy the minLength, the maxLength or the pattern of the ParamSpec.
+func (pp *ParamSpec) ValidateConstraints(value string) error {
+	length := utf8.RuneCountInString(value)
+	if length < pp.MinLength {
+		return fmt.Errorf("param %q value %q is shorter than its minLength %d", pp.Name, value, pp.MinLength)
+	}
+	if pp.MaxLength > 0 && length > pp.MaxLength {
+		return fmt.Errorf("param %q value %q is longer than its maxLength %d", pp.Name, value, pp.MaxLength)
+	}
+	if pp.Pattern != "" {
+		re, err := regexp.Compile(pp.Pattern)
+		if err != nil {
+			return fmt.Errorf("param %q pattern %q is invalid: %w", pp.Name, pp.Pattern, err)
+		}
+		if !re.MatchString(value) {
+			return fmt.Errorf("param %q value %q does not match its pattern %q", pp.Name, value, pp.Pattern)
+		}
+	}
+	return nil
+}
+
+// validateParamConstraints validates the allowed types, the lengths and the pattern of the Param
+// constraints, and that the default values satisfy them.
+func (ps ParamSpecs) validateParamConstraints() *apis.FieldError {
+	var errs *apis.FieldError
+	for _, p := range ps {
+		if !p.hasConstraints() {
+			continue
+		}
+		if p.Type != ParamTypeString {
+			errs = errs.Also(apis.ErrGeneric("pattern, minLength and maxLength can only be set with string type param", "").ViaKey(p.Name))
+			continue
+		}
+		var paramErrs *apis.FieldError
+		if p.MinLength < 0 {
+			paramErrs = paramErrs.Also(apis.ErrInvalidValue(fmt.Sprintf("%d should be >= 0", p.MinLength), "minLength"))
+		}
+		if p.MaxLength < 0 {
+			paramErrs = paramErrs.Also(apis.ErrInvalidValue(fmt.Sprintf("%d should be >= 0", p.MaxLength), "maxLength"))
+		}
+		if p.MaxLength > 0 && p.MinLength > p.MaxLength {
+			paramErrs = paramErrs.Also(apis.ErrInvalidValue(fmt.Sprintf("%d should be <= maxLength %d", p.MinLength, p.MaxLength), "minLength"))
+		}
+		if _, err := regexp.Compile(p.Pattern); err != nil {
+			paramErrs = paramErrs.Also(apis.ErrInvalidValue(fmt.Sprintf("%q is not a valid RE2 regular expression: %v", p.Pattern, err), "pattern"))
+		}
+		if paramErrs == nil && p.Default != nil && !strings.Contains(p.Default.StringVal, "$(") {
+			if err := p.ValidateConstraints(p.Default.StringVal); err != nil {
+				paramErrs = apis.ErrInvalidValue(err.Error(), "default")
+			}
+		}
+		errs = errs.Also(paramErrs.ViaKey(p.Name))
+	}
+	return errs
+}
+
+// validateParamValueConstraints validates that the literal values of the params satisfy the constraints
+// of their ParamSpecs. The values referencing variables are validated once they are resolved.
+func (ps ParamSpecs) validateParamValueConstraints(params Params) *apis.FieldError {
+	var errs *apis.FieldError
+	for _, p := range params {
+		if p.Value.Type != ParamTypeString || strings.Contains(p.Value.StringVal, "$(") {
+			continue
+		}
+		for _, spec := range ps {
+			if spec.Name != p.Name || spec.Type != ParamTypeString || !spec.hasConstraints() {
+				continue
+			}
+			if err := spec.ValidateConstraints(p.Value.StringVal); err != nil {
+				errs = errs.Also(apis.ErrInvalidValue(err.Error(), "value").ViaKey(p.Name))
+			}
+		}
+	}
+	return errs
+}
+
 // findDups returns the duplicate element in the given slice
 func findDups(vals []string) sets.String {
 	seen := sets.String{}
@@ -195,6 +295,21 @@ type Param struct {
 	// +kubebuilder:pruning:PreserveUnknownFields
 	// +kubebuilder:validation:Schemaless
 	Value ParamValue `json:"value"`
+	// RunRef refers to a result of a completed PipelineRun to take the value of the param from,
+	// instead of setting it in Value. It can only be used in the params of a PipelineRun.
+	// +optional
+	RunRef *PipelineRunResultRef `json:"runRef,omitempty"`
+}
+
+// PipelineRunResultRef refers to a result of a completed PipelineRun in the same namespace.
+type PipelineRunResultRef struct {
+	// Name is the name of the PipelineRun.
+	Name string `json:"name"`
+	// Result is the name of the result of the PipelineRun.
+	Result string `json:"result"`
+	// AllowFailed allows taking the result from a PipelineRun that did not succeed.
+	// +optional
+	AllowFailed bool `json:"allowFailed,omitempty"`
 }
 
 // GetVarSubstitutionExpressions extracts all the value between "$(" and ")"" for a Parameter
@@ -300,6 +415,17 @@ func (ps Params) ExtractParamArrayLengths() map[string]int {
 	return arrayParamsLengths
 }
 
+// validateNoRunRefs makes sure none of the params is taken from a PipelineRun result,
+// which is only supported in the params of a PipelineRun.
+func (ps Params) validateNoRunRefs() (errs *apis.FieldError) {
+	for _, p := range ps {
+		if p.RunRef != nil {
+			errs = errs.Also(apis.ErrDisallowedFields("runRef").ViaKey(p.Name))
+		}
+	}
+	return errs
+}
+
 // validateDuplicateParameters checks if a parameter with the same name is defined more than once
 func (ps Params) validateDuplicateParameters() (errs *apis.FieldError) {
 	taskParamNames := sets.NewString()
@@ -197,6 +197,11 @@ type PipelineTask struct {
 	// +optional
 	Description string `json:"description,omitempty"`
 
+	// Labels are added to the TaskRuns created for this task, so that they can be
+	// selected by them. Their keys can't have the tekton.dev/ prefix.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
 	// TaskRef is a reference to a task definition.
 	// +optional
 	TaskRef *TaskRef `json:"taskRef,omitempty"`
@@ -218,6 +223,12 @@ type PipelineTask struct {
 	// +optional
 	Retries int `json:"retries,omitempty"`
 
+	// RetriesFrom takes the number of retries of this task from a string parameter
+	// or from a string result of another task, e.g. "$(tasks.sizing.results.retries)".
+	// It is resolved when the task becomes schedulable and is mutually exclusive with Retries.
+	// +optional
+	RetriesFrom string `json:"retriesFrom,omitempty"`
+
 	// RunAfter is the list of PipelineTask names that should be executed before
 	// this Task executes. (Used to force a specific ordering in graph execution.)
 	// +optional
@@ -243,6 +254,18 @@ type PipelineTask struct {
 	// +optional
 	Timeout *metav1.Duration `json:"timeout,omitempty"`
 
+	// TimeoutFrom takes the timeout of the TaskRun from a string parameter or from
+	// a string result of another task, e.g. "$(tasks.sizing.results.timeout)".
+	// It is resolved when the task becomes schedulable and is mutually exclusive with Timeout.
+	// +optional
+	TimeoutFrom string `json:"timeoutFrom,omitempty"`
+
+	// ExpectedDuration is how long the TaskRun is expected to take. It overrides
+	// the expectedDuration of the Task and, like it, does not affect timeouts.
+	// Refer Go's ParseDuration documentation for expected format: https://golang.org/pkg/time/#ParseDuration
+	// +optional
+	ExpectedDuration *metav1.Duration `json:"expectedDuration,omitempty"`
+
 	// PipelineRef is a reference to a pipeline definition.
 	// This is an alpha field. You must set the "enable-api-fields" feature flag
 	// to "alpha" for this field to be supported. When enabled, the referenced
@@ -266,6 +289,14 @@ type PipelineTask struct {
 	// can be set to [ continue | stopAndFail ]
 	// +optional
 	OnError PipelineTaskOnErrorType `json:"onError,omitempty"`
+
+	// ApprovalGate makes the PipelineTask an approval gate, which pauses the
+	// PipelineRun until one of its approvers approves or rejects it, instead
+	// of running a Task or a Pipeline.
+	// This is an alpha field. You must set the "enable-api-fields" feature flag
+	// to "alpha" for this field to be supported.
+	// +optional
+	ApprovalGate *ApprovalGate `json:"approvalGate,omitempty"`
 }
 
 // IsCustomTask checks whether an embedded TaskSpec is a Custom Task
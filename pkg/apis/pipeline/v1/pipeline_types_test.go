@@ -20,6 +20,7 @@ import (
 	"context"
 	"encoding/hex"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -156,6 +157,97 @@ func TestPipelineTask_OnError(t *testing.T) {
 	}
 }
 
+func TestPipelineTask_RetriesAndTimeoutFrom(t *testing.T) {
+	tests := []struct {
+		name          string
+		p             PipelineTask
+		expectedError *apis.FieldError
+	}{{
+		name: "retries and timeout from results",
+		p: PipelineTask{
+			Name:        "foo",
+			TaskRef:     &TaskRef{Name: "foo"},
+			RetriesFrom: "$(tasks.sizing.results.retries)",
+			TimeoutFrom: "$(tasks.sizing.results.timeout)",
+		},
+	}, {
+		name: "retries and timeout from params",
+		p: PipelineTask{
+			Name:        "foo",
+			TaskRef:     &TaskRef{Name: "foo"},
+			RetriesFrom: "$(params.retries)",
+			TimeoutFrom: "$(params.timeout)",
+		},
+	}, {
+		name: "retries and retriesFrom",
+		p: PipelineTask{
+			Name:        "foo",
+			TaskRef:     &TaskRef{Name: "foo"},
+			Retries:     1,
+			RetriesFrom: "$(params.retries)",
+		},
+		expectedError: apis.ErrMultipleOneOf("retries", "retriesFrom"),
+	}, {
+		name: "timeout and timeoutFrom",
+		p: PipelineTask{
+			Name:        "foo",
+			TaskRef:     &TaskRef{Name: "foo"},
+			Timeout:     &metav1.Duration{Duration: time.Hour},
+			TimeoutFrom: "$(params.timeout)",
+		},
+		expectedError: apis.ErrMultipleOneOf("timeout", "timeoutFrom"),
+	}, {
+		name: "retriesFrom is not a reference",
+		p: PipelineTask{
+			Name:        "foo",
+			TaskRef:     &TaskRef{Name: "foo"},
+			RetriesFrom: "3",
+		},
+		expectedError: apis.ErrInvalidValue("3", "retriesFrom", "must be a single reference to a parameter or to a result of another task"),
+	}, {
+		name: "timeoutFrom is not an isolated reference",
+		p: PipelineTask{
+			Name:        "foo",
+			TaskRef:     &TaskRef{Name: "foo"},
+			TimeoutFrom: "$(tasks.sizing.results.minutes)m",
+		},
+		expectedError: apis.ErrInvalidValue("$(tasks.sizing.results.minutes)m", "timeoutFrom", "must be a single reference to a parameter or to a result of another task"),
+	}, {
+		name: "timeoutFrom references a context variable",
+		p: PipelineTask{
+			Name:        "foo",
+			TaskRef:     &TaskRef{Name: "foo"},
+			TimeoutFrom: "$(context.pipelineRun.timeoutRemaining)",
+		},
+		expectedError: apis.ErrInvalidValue("$(context.pipelineRun.timeoutRemaining)", "timeoutFrom", "must be a single reference to a parameter or to a result of another task"),
+	}, {
+		name: "retriesFrom references an element of an array result",
+		p: PipelineTask{
+			Name:        "foo",
+			TaskRef:     &TaskRef{Name: "foo"},
+			RetriesFrom: "$(tasks.sizing.results.retries[0])",
+		},
+		expectedError: apis.ErrInvalidValue("$(tasks.sizing.results.retries[0])", "retriesFrom", "must reference a whole string result"),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.p.Validate(t.Context())
+			if tt.expectedError == nil {
+				if err != nil {
+					t.Errorf("PipelineTask.Validate() returned error for valid pipeline task: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("PipelineTask.Validate() did not return error for invalid pipeline task")
+			}
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error()); d != "" {
+				t.Errorf("PipelineTask.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 func TestPipelineTask_ValidateRefOrSpec(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -516,6 +608,20 @@ func TestPipelineTask_ValidateCustomTask(t *testing.T) {
 			Message: `invalid value: invalid apiVersion format "example/", must be in the format "group/version"`,
 			Paths:   []string{"taskRef.apiVersion"},
 		},
+	}, {
+		name: "custom task - matrix with failFast",
+		task: PipelineTask{
+			Name:    "foo",
+			TaskRef: &TaskRef{APIVersion: "example.dev/v0", Kind: "Example"},
+			Matrix: &Matrix{
+				Params:   Params{{Name: "platform", Value: ParamValue{Type: ParamTypeArray, ArrayVal: []string{"linux", "mac"}}}},
+				FailFast: new(bool),
+			},
+		},
+		expectedError: apis.FieldError{
+			Message: `failFast is only supported by the matrices of Tasks, not by the ones of Custom Tasks or Pipelines`,
+			Paths:   []string{"matrix.failFast"},
+		},
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -780,8 +886,27 @@ func TestPipelineTask_Validate_Failure(t *testing.T) {
 				Kind: "Example",
 			}}},
 		expectedError: *apis.ErrInvalidValue("custom task spec must specify apiVersion", "taskSpec.apiVersion"),
-	},
-	}
+	}, {
+		name: "labels with a reserved prefix",
+		p: PipelineTask{
+			Name:    "foo",
+			TaskRef: &TaskRef{Name: "foo-task"},
+			Labels:  map[string]string{"team": "payments", "tekton.dev/pipelineTask": "bar"},
+		},
+		expectedError: *apis.ErrInvalidKeyName("tekton.dev/pipelineTask", "labels", "the tekton.dev/ prefix is reserved for the labels set by Tekton"),
+	}, {
+		name: "pipeline in pipeline - matrix with failFast",
+		p: PipelineTask{
+			Name:        "foo",
+			PipelineRef: &PipelineRef{Name: "foo-pipeline"},
+			Matrix: &Matrix{
+				Params:   Params{{Name: "platform", Value: ParamValue{Type: ParamTypeArray, ArrayVal: []string{"linux", "mac"}}}},
+				FailFast: new(bool),
+			},
+		},
+		expectedError: *apis.ErrGeneric("failFast is only supported by the matrices of Tasks, not by the ones of Custom Tasks or Pipelines", "matrix.failFast"),
+		wc:            cfgtesting.EnableAlphaAPIFields,
+	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			ctx := t.Context()
@@ -850,6 +975,20 @@ func TestPipelineTaskList_Deps(t *testing.T) {
 		expectedDeps: map[string][]string{
 			"task-2": {"task-1"},
 		},
+	}, {
+		name: "valid pipeline with Task Results in retriesFrom and timeoutFrom deps",
+		tasks: []PipelineTask{{
+			Name: "task-1",
+		}, {
+			Name: "task-2",
+		}, {
+			Name:        "task-3",
+			RetriesFrom: "$(tasks.task-1.results.retries)",
+			TimeoutFrom: "$(tasks.task-2.results.timeout)",
+		}},
+		expectedDeps: map[string][]string{
+			"task-3": {"task-1", "task-2"},
+		},
 	}, {
 		name: "valid pipeline with Task Results in Matrix deps",
 		tasks: []PipelineTask{{
@@ -24,6 +24,7 @@ import (
 
 	"github.com/tektoncd/pipeline/internal/artifactref"
 	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	"github.com/tektoncd/pipeline/pkg/apis/validate"
 	"github.com/tektoncd/pipeline/pkg/internal/resultref"
 	"github.com/tektoncd/pipeline/pkg/reconciler/pipeline/dag"
@@ -57,6 +58,7 @@ func (p *Pipeline) SupportedVerbs() []admissionregistrationv1.OperationType {
 // that any references resources exist, that is done at run time.
 func (p *Pipeline) Validate(ctx context.Context) *apis.FieldError {
 	errs := validate.ObjectMetadata(p.GetObjectMeta()).ViaField("metadata")
+	errs = errs.Also(validate.ObjectAnnotations(ctx, pipeline.PipelineControllerName, p).ViaField("metadata"))
 	errs = errs.Also(p.Spec.Validate(apis.WithinSpec(ctx)).ViaField("spec"))
 	// When a Pipeline is created directly, instead of declared inline in a PipelineRun,
 	// we do not support propagated parameters and workspaces.
@@ -76,12 +78,12 @@ func (ps *PipelineSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
 	// PipelineTask must have a valid unique label and at least one of taskRef or taskSpec should be specified
 	errs = errs.Also(ValidatePipelineTasks(ctx, ps.Tasks, ps.Finally))
 	// Validate the pipeline task graph
-	errs = errs.Also(validateGraph(ps.Tasks))
+	errs = errs.Also(validateGraph(ctx, ps.Tasks))
 	// The parameter variables should be valid
 	errs = errs.Also(ValidatePipelineParameterVariables(ctx, ps.Tasks, ps.Params).ViaField("tasks"))
 	errs = errs.Also(ValidatePipelineParameterVariables(ctx, ps.Finally, ps.Params).ViaField("finally"))
-	errs = errs.Also(validatePipelineContextVariables(ps.Tasks).ViaField("tasks"))
-	errs = errs.Also(validatePipelineContextVariables(ps.Finally).ViaField("finally"))
+	errs = errs.Also(validatePipelineContextVariables(ps.Tasks, false).ViaField("tasks"))
+	errs = errs.Also(validatePipelineContextVariables(ps.Finally, true).ViaField("finally"))
 	errs = errs.Also(validateExecutionStatusVariables(ps.Tasks, ps.Finally))
 	// Validate the pipeline's workspaces.
 	errs = errs.Also(validatePipelineWorkspacesDeclarations(ps.Workspaces))
@@ -91,9 +93,12 @@ func (ps *PipelineSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
 	// Result propagation from a child Pipeline is not implemented in the initial
 	// Pipelines-in-Pipelines alpha; see docs/pipelines-in-pipelines.md#limitations.
 	errs = errs.Also(validatePipelineRefResultReferencesDisallowed(ps.Tasks, ps.Finally))
+	errs = errs.Also(validateApprovalGateResultReferencesDisallowed(ps.Tasks, ps.Finally))
 	errs = errs.Also(validateTasksAndFinallySection(ps))
 	errs = errs.Also(validateFinalTasks(ps.Tasks, ps.Finally))
 	errs = errs.Also(validateWhenExpressions(ctx, ps.Tasks, ps.Finally))
+	errs = errs.Also(validateWorkspaceBoundVariablesInWhenExpressions(ps.Workspaces, ps.Tasks).ViaField("tasks"))
+	errs = errs.Also(validateWorkspaceBoundVariablesInWhenExpressions(ps.Workspaces, ps.Finally).ViaField("finally"))
 	errs = errs.Also(validateArtifactReference(ctx, ps.Tasks, ps.Finally))
 	errs = errs.Also(validateMatrix(ctx, ps.Tasks).ViaField("tasks"))
 	errs = errs.Also(validateMatrix(ctx, ps.Finally).ViaField("finally"))
@@ -163,6 +168,7 @@ func (l PipelineTaskList) validateUsageOfDeclaredPipelineTaskParameters(ctx cont
 	for i, t := range l {
 		if t.TaskSpec != nil {
 			errs = errs.Also(ValidateUsageOfDeclaredParameters(ctx, t.TaskSpec.Steps, append(t.TaskSpec.Params, additionalParams...)).ViaFieldIndex(path, i))
+			errs = errs.Also(ValidateUsageOfDeclaredParametersInSidecars(t.TaskSpec.Sidecars, append(t.TaskSpec.Params, additionalParams...)).ViaFieldIndex(path, i))
 		}
 	}
 	return errs
@@ -184,6 +190,10 @@ func (pt PipelineTask) ValidateName() *apis.FieldError {
 // Validate classifies whether a task is a custom task or a regular task(dag/final)
 // calls the validation routine based on the type of the task
 func (pt PipelineTask) Validate(ctx context.Context) (errs *apis.FieldError) {
+	if pt.ApprovalGate != nil {
+		return pt.validateApprovalGate(ctx)
+	}
+
 	errs = errs.Also(pt.validateRefOrSpec(ctx))
 
 	errs = errs.Also(pt.validateEnabledInlineSpec(ctx))
@@ -198,6 +208,12 @@ func (pt PipelineTask) Validate(ctx context.Context) (errs *apis.FieldError) {
 
 	errs = errs.Also(pt.ValidateOnError(ctx))
 
+	errs = errs.Also(pt.validateRetriesAndTimeoutFrom())
+
+	errs = errs.Also(validate.Labels(pt.Labels).ViaField("labels"))
+
+	errs = errs.Also(pt.Params.validateNoRunRefs().ViaField("params"))
+
 	// Pipeline task having taskRef/taskSpec with APIVersion is classified as custom task
 	switch {
 	case pt.TaskRef != nil && !taskKinds[pt.TaskRef.Kind]:
@@ -228,6 +244,44 @@ func (pt PipelineTask) ValidateOnError(ctx context.Context) (errs *apis.FieldErr
 	return errs
 }
 
+// validateRetriesAndTimeoutFrom validates the retriesFrom and timeoutFrom fields of a PipelineTask,
+// which are mutually exclusive with retries and timeout and are resolved once the task is schedulable.
+func (pt PipelineTask) validateRetriesAndTimeoutFrom() (errs *apis.FieldError) {
+	if pt.RetriesFrom != "" {
+		if pt.Retries != 0 {
+			errs = errs.Also(apis.ErrMultipleOneOf("retries", "retriesFrom"))
+		}
+		errs = errs.Also(validateValueFromReference(pt.RetriesFrom).ViaField("retriesFrom"))
+	}
+	if pt.TimeoutFrom != "" {
+		if pt.Timeout != nil {
+			errs = errs.Also(apis.ErrMultipleOneOf("timeout", "timeoutFrom"))
+		}
+		errs = errs.Also(validateValueFromReference(pt.TimeoutFrom).ViaField("timeoutFrom"))
+	}
+	return errs
+}
+
+// validateValueFromReference validates that value is a single reference to a parameter or to a
+// string result of another task, e.g. "$(params.retries)" or "$(tasks.sizing.results.timeout)".
+func validateValueFromReference(value string) *apis.FieldError {
+	if !exactVariableSubstitutionRegex.MatchString(value) {
+		return apis.ErrInvalidValue(value, "", "must be a single reference to a parameter or to a result of another task")
+	}
+	expression := stripVarSubExpression(value)
+	if strings.HasPrefix(expression, ParamsPrefix+".") {
+		return nil
+	}
+	refs := NewResultRefs([]string{expression})
+	if len(refs) != 1 {
+		return apis.ErrInvalidValue(value, "", "must be a single reference to a parameter or to a result of another task")
+	}
+	if refs[0].ResultsIndex != nil || refs[0].Property != "" || strings.HasSuffix(expression, "[*]") {
+		return apis.ErrInvalidValue(value, "", "must reference a whole string result")
+	}
+	return nil
+}
+
 func (pt *PipelineTask) validateMatrix(ctx context.Context) (errs *apis.FieldError) {
 	if pt.IsMatrixed() {
 		// This is a beta feature and will fail validation if it's used in a pipeline spec
@@ -281,12 +335,31 @@ func (pt *PipelineTask) validateWorkspaces(workspaceNames sets.String) (errs *ap
 				"",
 			).ViaFieldIndex("workspaces", i))
 		}
+		errs = errs.Also(ws.ValidateSubPath().ViaFieldIndex("workspaces", i))
+		if ws.PerMatrixInstance {
+			errs = errs.Also(pt.validatePerMatrixInstanceWorkspace().ViaFieldIndex("workspaces", i))
+		}
 
 		workspaceBindingNames.Insert(ws.Name)
 	}
 	return errs
 }
 
+// validatePerMatrixInstanceWorkspace validates that the pipeline task binding a workspace per matrix
+// instance is matrixed, and that its instances are known when the PipelineRun starts, as their
+// PersistentVolumeClaims are created then.
+func (pt *PipelineTask) validatePerMatrixInstanceWorkspace() *apis.FieldError {
+	if !pt.IsMatrixed() {
+		return apis.ErrGeneric(fmt.Sprintf("pipeline task %q can't bind a workspace per matrix instance as it isn't matrixed", pt.Name), "perMatrixInstance")
+	}
+	for _, param := range pt.Matrix.GetAllParams() {
+		if expressions, ok := param.GetVarSubstitutionExpressions(); ok && LooksLikeContainsResultRefs(expressions) {
+			return apis.ErrGeneric(fmt.Sprintf("pipeline task %q can't bind a workspace per matrix instance as its matrix references results", pt.Name), "perMatrixInstance")
+		}
+	}
+	return nil
+}
+
 // validateEnabledInlineSpec validates that pipelineSpec or taskSpec is allowed by checking
 // disable-inline-spec field
 func (pt PipelineTask) validateEnabledInlineSpec(ctx context.Context) (errs *apis.FieldError) {
@@ -378,6 +451,7 @@ func (pt PipelineTask) validateCustomTask() (errs *apis.FieldError) {
 	} else if pt.TaskSpec != nil {
 		errs = errs.Also(apis.ErrInvalidValue("custom task spec must specify apiVersion", "taskSpec.apiVersion"))
 	}
+	errs = errs.Also(pt.Matrix.validateFailFastUnsupported())
 	return errs
 }
 
@@ -386,6 +460,7 @@ func (pt PipelineTask) validateTask(ctx context.Context) (errs *apis.FieldError)
 	// Validate TaskSpec if it's present
 	if pt.TaskSpec != nil {
 		errs = errs.Also(pt.TaskSpec.Validate(ctx).ViaField(taskSpec))
+		errs = errs.Also(pt.TaskSpec.Params.validateParamValueConstraints(pt.Params).ViaField("params"))
 	}
 	if pt.TaskRef != nil {
 		errs = errs.Also(pt.TaskRef.Validate(ctx).ViaField(taskRef))
@@ -396,6 +471,10 @@ func (pt PipelineTask) validateTask(ctx context.Context) (errs *apis.FieldError)
 	if pt.PipelineSpec != nil {
 		errs = errs.Also(pt.PipelineSpec.Validate(ctx).ViaField(pipelineSpec))
 	}
+	if pt.PipelineRef != nil || pt.PipelineSpec != nil {
+		errs = errs.Also(pt.Matrix.validateFailFastUnsupported())
+	}
+	errs = errs.Also(validateExpectedDuration(ctx, pt.ExpectedDuration))
 	return errs
 }
 
@@ -474,6 +553,7 @@ func ValidatePipelineParameterVariables(ctx context.Context, tasks []PipelineTas
 	errs = errs.Also(ValidateParameterTypes(ctx, params).ViaField("params"))
 	errs = errs.Also(params.ValidateNoDuplicateNames())
 	errs = errs.Also(params.validateParamEnums(ctx).ViaField("params"))
+	errs = errs.Also(params.validateParamConstraints().ViaField("params"))
 	for i, task := range tasks {
 		errs = errs.Also(task.Params.validateDuplicateParameters().ViaField("params").ViaIndex(i))
 	}
@@ -483,6 +563,8 @@ func ValidatePipelineParameterVariables(ctx context.Context, tasks []PipelineTas
 func validatePipelineParametersVariables(tasks []PipelineTask, prefix string, paramNames sets.String, arrayParamNames sets.String, objectParamNameKeys map[string][]string) (errs *apis.FieldError) {
 	for idx, task := range tasks {
 		errs = errs.Also(validatePipelineParametersVariablesInTaskParameters(task.Params, prefix, paramNames, arrayParamNames, objectParamNameKeys).ViaIndex(idx))
+		errs = errs.Also(validateStringVariable(task.RetriesFrom, prefix, paramNames, arrayParamNames, objectParamNameKeys).ViaField("retriesFrom").ViaIndex(idx))
+		errs = errs.Also(validateStringVariable(task.TimeoutFrom, prefix, paramNames, arrayParamNames, objectParamNameKeys).ViaField("timeoutFrom").ViaIndex(idx))
 		if task.IsMatrixed() {
 			errs = errs.Also(task.Matrix.validatePipelineParametersVariablesInMatrixParameters(prefix, paramNames, arrayParamNames, objectParamNameKeys).ViaIndex(idx))
 		}
@@ -491,12 +573,19 @@ func validatePipelineParametersVariables(tasks []PipelineTask, prefix string, pa
 	return errs
 }
 
-func validatePipelineContextVariables(tasks []PipelineTask) *apis.FieldError {
+// validatePipelineContextVariables validates the context variables referenced by the given tasks, which are
+// the final tasks of the Pipeline when finally is true.
+func validatePipelineContextVariables(tasks []PipelineTask, finally bool) *apis.FieldError {
 	pipelineRunContextNames := sets.NewString().Insert(
 		"name",
 		"namespace",
 		"uid",
+		"timeoutRemaining",
 	)
+	if finally {
+		// the time left before the finally timeout is only known to final tasks
+		pipelineRunContextNames.Insert("finallyTimeoutRemaining")
+	}
 	pipelineContextNames := sets.NewString().Insert(
 		"name",
 	)
@@ -546,6 +635,35 @@ func (pt *PipelineTask) GetVarSubstitutionExpressions() []string {
 	return allExpressions
 }
 
+// ParamNames returns the names of the Pipeline params referenced by the PipelineTask in its params,
+// matrix and when expressions, and in the steps and sidecars of its embedded taskSpec.
+// The params declared by the embedded taskSpec shadow the Pipeline params with the same names.
+func (pt *PipelineTask) ParamNames() sets.String {
+	names := sets.NewString()
+	insert := func(expressions []string, shadowed sets.String) {
+		for _, e := range expressions {
+			vars, _, _ := substitution.ExtractVariablesFromString("$("+substitution.TrimArrayIndex(e)+")", "params")
+			for _, v := range vars {
+				if v != "" && !shadowed.Has(v) {
+					names.Insert(v)
+				}
+			}
+		}
+	}
+	for _, p := range pt.extractAllParams() {
+		expressions, _ := p.GetVarSubstitutionExpressions()
+		insert(expressions, nil)
+	}
+	for _, we := range pt.When {
+		expressions, _ := we.GetVarSubstitutionExpressions()
+		insert(expressions, nil)
+	}
+	if pt.TaskSpec != nil {
+		insert(pt.GetVarSubstitutionExpressions(), sets.NewString(pt.TaskSpec.Params.GetNames()...))
+	}
+	return names
+}
+
 // containsExecutionStatusRef checks if a specified param has a reference to execution status or reason
 // $(tasks.<task-name>.status), $(tasks.status), or $(tasks.<task-name>.reason)
 func containsExecutionStatusRef(p string) bool {
@@ -804,6 +922,7 @@ func validateFinalTasks(tasks []PipelineTask, finalTasks []PipelineTask) (errs *
 	fts := PipelineTaskList(finalTasks).Names()
 
 	errs = errs.Also(validateTaskResultReferenceInFinallyTasks(finalTasks, ts, fts))
+	errs = errs.Also(validateApprovalGatesNotInFinally(finalTasks))
 
 	return errs
 }
@@ -853,10 +972,54 @@ func validateWhenExpressions(ctx context.Context, tasks []PipelineTask, finalTas
 	return errs
 }
 
+// validateWorkspaceBoundVariablesInWhenExpressions validates that the `$(workspaces.<name>.bound)` variables used in
+// the when expressions of the pipeline tasks refer to optional workspaces of the pipeline, the other workspaces being
+// always bound.
+func validateWorkspaceBoundVariablesInWhenExpressions(wss []PipelineWorkspaceDeclaration, tasks []PipelineTask) (errs *apis.FieldError) {
+	optional := map[string]bool{}
+	for _, ws := range wss {
+		optional[ws.Name] = ws.Optional
+	}
+	for i, t := range tasks {
+		for j, we := range t.When {
+			expressions, _ := we.GetVarSubstitutionExpressions()
+			for _, expression := range expressions {
+				if !strings.HasPrefix(expression, "workspaces.") || !strings.HasSuffix(expression, ".bound") {
+					continue
+				}
+				name := strings.TrimSuffix(strings.TrimPrefix(expression, "workspaces."), ".bound")
+				isOptional, declared := optional[name]
+				switch {
+				case !declared:
+					errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("$(%s) refers to workspace %q which is not declared by the pipeline", expression, name), "").ViaFieldIndex("when", j).ViaIndex(i))
+				case !isOptional:
+					errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("$(%s) refers to workspace %q which is not optional and so always bound", expression, name), "").ViaFieldIndex("when", j).ViaIndex(i))
+				}
+			}
+		}
+	}
+	return errs
+}
+
 // validateGraph ensures the Pipeline's dependency Graph (DAG) make sense: that there is no dependency
 // cycle or that they rely on values from Tasks that ran previously.
-func validateGraph(tasks []PipelineTask) (errs *apis.FieldError) {
-	if _, err := dag.Build(PipelineTaskList(tasks), PipelineTaskList(tasks).Deps()); err != nil {
+// It also enforces the maximum number of tasks and depth of the DAG set in config-defaults.
+func validateGraph(ctx context.Context, tasks []PipelineTask) (errs *apis.FieldError) {
+	var maxTasks, maxDepth int
+	if defaults := config.FromContextOrDefaults(ctx).Defaults; defaults != nil {
+		maxTasks, maxDepth = defaults.DefaultMaxDAGTasks, defaults.DefaultMaxDAGDepth
+	}
+	if maxTasks > 0 && len(tasks) > maxTasks {
+		return apis.ErrInvalidValue(fmt.Sprintf("pipeline has %d tasks, more than the maximum of %d set by %s", len(tasks), maxTasks, config.DefaultMaxDAGTasksKey), "tasks")
+	}
+	deps := PipelineTaskList(tasks).Deps()
+	// The depth is checked before the graph is built, so that building it is skipped for pipelines too deep
+	if maxDepth > 0 {
+		if head, depth := dag.LongestChain(PipelineTaskList(tasks), deps); depth > maxDepth {
+			return apis.ErrInvalidValue(fmt.Sprintf("the chain of dependent tasks starting at task %q has %d tasks, more than the maximum depth of %d set by %s", head, depth, maxDepth, config.DefaultMaxDAGDepthKey), "tasks")
+		}
+	}
+	if _, err := dag.Build(PipelineTaskList(tasks), deps); err != nil {
 		errs = errs.Also(apis.ErrInvalidValue(err.Error(), "tasks"))
 	}
 	return errs
@@ -1036,7 +1199,7 @@ func validateStringResults(results []TaskResult, resultName string) (errs *apis.
 // validateArtifactReference ensure that the feature flag enableArtifacts is set to true when using artifacts
 func validateArtifactReference(ctx context.Context, tasks []PipelineTask, finalTasks []PipelineTask) (errs *apis.FieldError) {
 	if config.FromContextOrDefaults(ctx).FeatureFlags.EnableArtifacts {
-		return errs
+		return validateArtifactReferenceInFinally(tasks, finalTasks)
 	}
 	for i, t := range tasks {
 		for _, v := range t.Params.extractValues() {
@@ -1055,6 +1218,37 @@ func validateArtifactReference(ctx context.Context, tasks []PipelineTask, finalT
 	return errs
 }
 
+// validateArtifactReferenceInFinally ensures that the artifacts of pipeline tasks are only referenced in the
+// params of finally tasks, which run once all the pipeline tasks are done, and that the pipeline tasks are
+// defined in the pipeline.
+func validateArtifactReferenceInFinally(tasks []PipelineTask, finalTasks []PipelineTask) (errs *apis.FieldError) {
+	for i, t := range tasks {
+		for _, p := range t.Params {
+			for _, v := range (Params{p}).extractValues() {
+				if len(artifactref.TaskArtifactRegex.FindAllStringSubmatch(v, -1)) > 0 {
+					errs = errs.Also(apis.ErrInvalidValue("pipeline tasks can not refer to the artifacts of other pipeline tasks, only finally tasks can", "value").
+						ViaFieldKey("params", p.Name).ViaFieldIndex("tasks", i))
+					break
+				}
+			}
+		}
+	}
+	ptNames := PipelineTaskList(tasks).Names()
+	for i, t := range finalTasks {
+		for _, p := range t.Params {
+			for _, v := range (Params{p}).extractValues() {
+				for _, match := range artifactref.TaskArtifactRegex.FindAllStringSubmatch(v, -1) {
+					if !ptNames.Has(match[1]) {
+						errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("pipeline task %s is not defined in the pipeline", match[1]), "value").
+							ViaFieldKey("params", p.Name).ViaFieldIndex("finally", i))
+					}
+				}
+			}
+		}
+	}
+	return errs
+}
+
 // GetIndexingReferencesToArrayParams returns all strings referencing indices of PipelineRun array parameters
 // from parameters, workspaces, and when expressions defined in the Pipeline's Tasks and Finally Tasks.
 // For example, if a Task in the Pipeline has a parameter with a value "$(params.array-param-name[1])",
@@ -18,6 +18,7 @@ package v1
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -291,7 +292,7 @@ func TestPipeline_Validate_Success(t *testing.T) {
 			},
 		},
 	}, {
-		name: "valid pipeline with pipeline task and final task referencing artifacts in task params with enable-artifacts flag true",
+		name: "valid pipeline with final task referencing artifacts of pipeline task in task params with enable-artifacts flag true",
 		p: &Pipeline{
 			ObjectMeta: metav1.ObjectMeta{Name: "pipeline"},
 			Spec: PipelineSpec{
@@ -299,11 +300,12 @@ func TestPipeline_Validate_Success(t *testing.T) {
 				Tasks: []PipelineTask{{
 					Name:    "pre-task",
 					TaskRef: &TaskRef{Name: "foo-task"},
-				}, {
+				}},
+				Finally: []PipelineTask{{
 					Name: "consume-artifacts-task",
 					Params: Params{{Name: "aaa", Value: ParamValue{
 						Type:      ParamTypeString,
-						StringVal: "$(tasks.produce-artifacts-task.outputs.image)",
+						StringVal: "$(tasks.pre-task.outputs.image)",
 					}}},
 					TaskSpec: &EmbeddedTask{TaskSpec: getTaskSpec()},
 				}},
@@ -1479,6 +1481,67 @@ func TestPipelineSpec_Validate_Failure(t *testing.T) {
 			Message: `feature flag enable-artifacts should be set to true to use artifacts feature.`,
 			Paths:   []string{"finally[0].params"},
 		},
+	}, {
+		name: "invalid pipeline with pipeline task referencing artifacts in task params",
+		ps: &PipelineSpec{
+			Tasks: []PipelineTask{{
+				Name:    "pre-task",
+				TaskRef: &TaskRef{Name: "foo-task"},
+			}, {
+				Name: "consume-artifacts-task",
+				Params: Params{{Name: "aaa", Value: ParamValue{
+					Type:      ParamTypeString,
+					StringVal: "$(tasks.pre-task.outputs.image)",
+				}}},
+				TaskSpec: &EmbeddedTask{TaskSpec: getTaskSpec()},
+			}},
+		},
+		wc: func(ctx context.Context) context.Context {
+			return cfgtesting.SetFeatureFlags(ctx, t, map[string]string{"enable-artifacts": "true"})
+		},
+		expectedError: apis.FieldError{
+			Message: `invalid value: pipeline tasks can not refer to the artifacts of other pipeline tasks, only finally tasks can`,
+			Paths:   []string{"tasks[1].params[aaa].value"},
+		},
+	}, {
+		name: "invalid pipeline with final task referencing artifacts of undefined pipeline task in params",
+		ps: &PipelineSpec{
+			Tasks: []PipelineTask{{
+				Name:    "pre-task",
+				TaskRef: &TaskRef{Name: "foo-task"},
+			}},
+			Finally: []PipelineTask{{
+				Name: "consume-artifacts-task",
+				Params: Params{{Name: "aaa", Value: ParamValue{
+					Type:      ParamTypeString,
+					StringVal: "$(tasks.produce-artifacts-task.outputs.image)",
+				}}},
+				TaskSpec: &EmbeddedTask{TaskSpec: getTaskSpec()},
+			}},
+		},
+		wc: func(ctx context.Context) context.Context {
+			return cfgtesting.SetFeatureFlags(ctx, t, map[string]string{"enable-artifacts": "true"})
+		},
+		expectedError: apis.FieldError{
+			Message: `invalid value: pipeline task produce-artifacts-task is not defined in the pipeline`,
+			Paths:   []string{"finally[0].params[aaa].value"},
+		},
+	}, {
+		name: "invalid pipeline with pipeline task param not matching the pattern of its embedded task",
+		ps: &PipelineSpec{
+			Tasks: []PipelineTask{{
+				Name:   "build",
+				Params: Params{{Name: "tag", Value: *NewStructuredValues("latest")}},
+				TaskSpec: &EmbeddedTask{TaskSpec: TaskSpec{
+					Params: ParamSpecs{{Name: "tag", Type: ParamTypeString, Pattern: "^v[0-9]+$"}},
+					Steps:  []Step{{Name: "build", Image: "busybox"}},
+				}},
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `invalid value: param "tag" value "latest" does not match its pattern "^v[0-9]+$"`,
+			Paths:   []string{"tasks[0].params[tag].value"},
+		},
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1599,7 +1662,7 @@ func TestValidateGraph_Success(t *testing.T) {
 	}, {
 		Name: "foo-bar", TaskRef: &TaskRef{Name: "bar-task"}, RunAfter: []string{"foo1", "bar1"},
 	}}
-	if err := validateGraph(tasks); err != nil {
+	if err := validateGraph(t.Context(), tasks); err != nil {
 		t.Errorf("Pipeline.validateGraph() returned error for valid DAG of pipeline tasks: %s: %v", desc, err)
 	}
 }
@@ -1615,7 +1678,7 @@ func TestValidateGraph_Failure(t *testing.T) {
 		Message: `invalid value: cycle detected; task "bar" depends on "foo"`,
 		Paths:   []string{"tasks"},
 	}
-	err := validateGraph(tasks)
+	err := validateGraph(t.Context(), tasks)
 	if err == nil {
 		t.Error("Pipeline.validateGraph() did not return error for invalid DAG of pipeline tasks:", desc)
 	} else if d := cmp.Diff(expectedError.Error(), err.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
@@ -1623,6 +1686,111 @@ func TestValidateGraph_Failure(t *testing.T) {
 	}
 }
 
+func TestValidateGraph_Limits(t *testing.T) {
+	// parallel returns n tasks that don't depend on each other.
+	parallel := func(n int) []PipelineTask {
+		tasks := make([]PipelineTask, n)
+		for i := range tasks {
+			tasks[i] = PipelineTask{Name: fmt.Sprintf("task-%d", i), TaskRef: &TaskRef{Name: "task"}}
+		}
+		return tasks
+	}
+	tcs := []struct {
+		name          string
+		tasks         []PipelineTask
+		maxDepth      int
+		maxTasks      int
+		expectedError string
+	}{{
+		name:     "depth at the maximum",
+		tasks:    chainedTasks(5),
+		maxDepth: 5,
+	}, {
+		name:          "depth over the maximum",
+		tasks:         chainedTasks(6),
+		maxDepth:      5,
+		expectedError: `invalid value: the chain of dependent tasks starting at task "task-0" has 6 tasks, more than the maximum depth of 5 set by default-max-dag-depth: tasks`,
+	}, {
+		name:  "no maximum depth",
+		tasks: chainedTasks(6),
+	}, {
+		name:     "tasks at the maximum",
+		tasks:    parallel(5),
+		maxTasks: 5,
+	}, {
+		name:          "tasks over the maximum",
+		tasks:         parallel(6),
+		maxTasks:      5,
+		expectedError: `invalid value: pipeline has 6 tasks, more than the maximum of 5 set by default-max-dag-tasks: tasks`,
+	}, {
+		name:     "parallel tasks don't count towards the depth",
+		tasks:    parallel(6),
+		maxDepth: 1,
+	}}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := config.ToContext(t.Context(), &config.Config{
+				Defaults: &config.Defaults{
+					DefaultMaxDAGDepth: tc.maxDepth,
+					DefaultMaxDAGTasks: tc.maxTasks,
+				},
+			})
+			err := validateGraph(ctx, tc.tasks)
+			if tc.expectedError == "" {
+				if err != nil {
+					t.Errorf("Pipeline.validateGraph() returned error for DAG within the limits: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("Pipeline.validateGraph() did not return error for DAG over the limits")
+			}
+			if d := cmp.Diff(tc.expectedError, err.Error()); d != "" {
+				t.Errorf("Pipeline.validateGraph() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+// BenchmarkValidateGraph measures the validation of the graph of long chains of tasks, which is
+// cheap when the chain is rejected for exceeding the maximum depth, as the graph isn't built then.
+func BenchmarkValidateGraph(b *testing.B) {
+	for _, n := range []int{1000, 10000} {
+		tasks := chainedTasks(n)
+		for _, bc := range []struct {
+			name     string
+			maxDepth int
+			wantErr  bool
+		}{
+			{name: "within-limits", maxDepth: n},
+			{name: "over-max-depth", maxDepth: n / 2, wantErr: true},
+		} {
+			ctx := config.ToContext(b.Context(), &config.Config{
+				Defaults: &config.Defaults{DefaultMaxDAGDepth: bc.maxDepth},
+			})
+			b.Run(fmt.Sprintf("tasks-%d/%s", n, bc.name), func(b *testing.B) {
+				for range b.N {
+					if err := validateGraph(ctx, tasks); (err != nil) != bc.wantErr {
+						b.Fatalf("validateGraph() = %v, want error: %t", err, bc.wantErr)
+					}
+				}
+			})
+		}
+	}
+}
+
+// chainedTasks returns n tasks, each one running after the previous one.
+func chainedTasks(n int) []PipelineTask {
+	tasks := make([]PipelineTask, n)
+	for i := range tasks {
+		tasks[i] = PipelineTask{Name: fmt.Sprintf("task-%d", i), TaskRef: &TaskRef{Name: "task"}}
+		if i > 0 {
+			tasks[i].RunAfter = []string{tasks[i-1].Name}
+		}
+	}
+	return tasks
+}
+
 func TestValidatePipelineResults_Success(t *testing.T) {
 	desc := "valid pipeline with valid pipeline results syntax"
 	results := []PipelineResult{{
@@ -2532,6 +2700,31 @@ func TestValidatePipelineDeclaredParameterUsage_Failure(t *testing.T) {
 			Message: `non-existent variable in "$(params.myObject.non-exist-key)"`,
 			Paths:   []string{"[0].matrix.params[b-param].value[0]"},
 		},
+	}, {
+		name: "retriesFrom references an array parameter",
+		params: []ParamSpec{{
+			Name: "retries", Type: ParamTypeArray,
+		}},
+		tasks: []PipelineTask{{
+			Name:        "foo",
+			TaskRef:     &TaskRef{Name: "foo-task"},
+			RetriesFrom: "$(params.retries)",
+		}},
+		expectedError: apis.FieldError{
+			Message: `variable type invalid in "$(params.retries)"`,
+			Paths:   []string{"[0].retriesFrom"},
+		},
+	}, {
+		name: "timeoutFrom references an undefined parameter",
+		tasks: []PipelineTask{{
+			Name:        "foo",
+			TaskRef:     &TaskRef{Name: "foo-task"},
+			TimeoutFrom: "$(params.timeout)",
+		}},
+		expectedError: apis.FieldError{
+			Message: `non-existent variable in "$(params.timeout)"`,
+			Paths:   []string{"[0].timeoutFrom"},
+		},
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -2843,6 +3036,22 @@ func TestValidatePipelineWorkspacesUsage_Success(t *testing.T) {
 			}},
 		}},
 		skipValidation: false,
+	}, {
+		name: "workspace bound per matrix instance",
+		workspaces: []PipelineWorkspaceDeclaration{{
+			Name: "cache",
+		}},
+		tasks: []PipelineTask{{
+			Name: "build", TaskRef: &TaskRef{Name: "build"},
+			Matrix: &Matrix{Params: Params{{
+				Name: "platform", Value: ParamValue{Type: ParamTypeArray, ArrayVal: []string{"linux", "mac", "windows"}},
+			}}},
+			Workspaces: []WorkspacePipelineTaskBinding{{
+				Name:              "cache",
+				PerMatrixInstance: true,
+			}},
+		}},
+		skipValidation: false,
 	}, {
 		name: "skip validating workspace usage",
 		workspaces: []PipelineWorkspaceDeclaration{{
@@ -2863,6 +3072,52 @@ func TestValidatePipelineWorkspacesUsage_Success(t *testing.T) {
 	}
 }
 
+func TestValidateWorkspaceBoundVariablesInWhenExpressions(t *testing.T) {
+	workspaces := []PipelineWorkspaceDeclaration{{
+		Name:     "cache",
+		Optional: true,
+	}, {
+		Name: "source",
+	}}
+	tests := []struct {
+		name          string
+		when          WhenExpressions
+		expectedError *apis.FieldError
+	}{{
+		name: "optional workspace in input",
+		when: WhenExpressions{{Input: "$(workspaces.cache.bound)", Operator: selection.In, Values: []string{"true"}}},
+	}, {
+		name: "optional workspace in values",
+		when: WhenExpressions{{Input: "true", Operator: selection.In, Values: []string{"$(workspaces.cache.bound)"}}},
+	}, {
+		name: "other variables are ignored",
+		when: WhenExpressions{{Input: "$(params.foo)", Operator: selection.In, Values: []string{"$(workspaces.source.path)"}}},
+	}, {
+		name: "workspace which is not optional",
+		when: WhenExpressions{{Input: "$(workspaces.source.bound)", Operator: selection.In, Values: []string{"true"}}},
+		expectedError: &apis.FieldError{
+			Message: `invalid value: $(workspaces.source.bound) refers to workspace "source" which is not optional and so always bound`,
+			Paths:   []string{"tasks[0].when[0]"},
+		},
+	}, {
+		name: "workspace which is not declared",
+		when: WhenExpressions{{Input: "true", Operator: selection.In, Values: []string{"$(workspaces.missing.bound)"}}},
+		expectedError: &apis.FieldError{
+			Message: `invalid value: $(workspaces.missing.bound) refers to workspace "missing" which is not declared by the pipeline`,
+			Paths:   []string{"tasks[0].when[0]"},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tasks := []PipelineTask{{Name: "upload", TaskRef: &TaskRef{Name: "upload"}, When: tt.when}}
+			errs := validateWorkspaceBoundVariablesInWhenExpressions(workspaces, tasks).ViaField("tasks")
+			if d := cmp.Diff(tt.expectedError.Error(), errs.Error()); d != "" {
+				t.Errorf("validateWorkspaceBoundVariablesInWhenExpressions() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 func TestValidatePipelineWorkspacesDeclarations_Failure(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -2947,6 +3202,22 @@ func TestValidatePipelineWorkspacesUsage_Failure(t *testing.T) {
 			Message: `invalid value: pipeline task "foo" expects workspace with name "taskWorkspaceName" but none exists in pipeline spec`,
 			Paths:   []string{"tasks[0].workspaces[0]"},
 		},
+	}, {
+		name: "workspace subPath leaving the workspace",
+		workspaces: []PipelineWorkspaceDeclaration{{
+			Name: "foo",
+		}},
+		tasks: []PipelineTask{{
+			Name: "foo", TaskRef: &TaskRef{Name: "foo"},
+			Workspaces: []WorkspacePipelineTaskBinding{{
+				Name:    "foo",
+				SubPath: "$(params.dir)/../../etc",
+			}},
+		}},
+		expectedError: apis.FieldError{
+			Message: `invalid value: "$(params.dir)/../../etc" must be a relative path without ".." elements`,
+			Paths:   []string{"tasks[0].workspaces[0].subPath"},
+		},
 	}, {
 		name: "invalid pipeline task use duplicate workspace binding name",
 		workspaces: []PipelineWorkspaceDeclaration{{
@@ -2970,6 +3241,41 @@ func TestValidatePipelineWorkspacesUsage_Failure(t *testing.T) {
 			Message: `workspace name "repo" must be unique`,
 			Paths:   []string{"tasks[0].workspaces[1]"},
 		},
+	}, {
+		name: "workspace bound per matrix instance of a pipeline task which isn't matrixed",
+		workspaces: []PipelineWorkspaceDeclaration{{
+			Name: "cache",
+		}},
+		tasks: []PipelineTask{{
+			Name: "build", TaskRef: &TaskRef{Name: "build"},
+			Workspaces: []WorkspacePipelineTaskBinding{{
+				Name:              "cache",
+				PerMatrixInstance: true,
+			}},
+		}},
+		expectedError: apis.FieldError{
+			Message: `pipeline task "build" can't bind a workspace per matrix instance as it isn't matrixed`,
+			Paths:   []string{"tasks[0].workspaces[0].perMatrixInstance"},
+		},
+	}, {
+		name: "workspace bound per matrix instance of a matrix referencing results",
+		workspaces: []PipelineWorkspaceDeclaration{{
+			Name: "cache",
+		}},
+		tasks: []PipelineTask{{
+			Name: "build", TaskRef: &TaskRef{Name: "build"},
+			Matrix: &Matrix{Params: Params{{
+				Name: "platform", Value: ParamValue{Type: ParamTypeString, StringVal: "$(tasks.platforms.results.names[*])"},
+			}}},
+			Workspaces: []WorkspacePipelineTaskBinding{{
+				Name:              "cache",
+				PerMatrixInstance: true,
+			}},
+		}},
+		expectedError: apis.FieldError{
+			Message: `pipeline task "build" can't bind a workspace per matrix instance as its matrix references results`,
+			Paths:   []string{"tasks[0].workspaces[0].perMatrixInstance"},
+		},
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -3050,6 +3356,24 @@ func TestValidatePipelineWithFinalTasks_Success(t *testing.T) {
 				}},
 			},
 		},
+	}, {
+		name: "valid pipeline with tasks referring to the remaining pipelinerun timeout",
+		p: &Pipeline{
+			ObjectMeta: metav1.ObjectMeta{Name: "pipeline"},
+			Spec: PipelineSpec{
+				Tasks: []PipelineTask{{
+					Name:    "non-final-task",
+					TaskRef: &TaskRef{Name: "non-final-task"},
+				}},
+				Finally: []PipelineTask{{
+					Name:    "final-task-1",
+					TaskRef: &TaskRef{Name: "final-task"},
+					Params: Params{{
+						Name: "param1", Value: ParamValue{Type: ParamTypeString, StringVal: "$(context.pipelineRun.timeoutRemaining)"},
+					}},
+				}},
+			},
+		},
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -3670,9 +3994,20 @@ func TestValidateFinalTasks_Failure(t *testing.T) {
 
 func TestContextValid(t *testing.T) {
 	tests := []struct {
-		name  string
-		tasks []PipelineTask
+		name    string
+		tasks   []PipelineTask
+		finally bool
 	}{{
+		name: "valid string context variable for PipelineRun finallyTimeoutRemaining in finally",
+		tasks: []PipelineTask{{
+			Name:    "bar",
+			TaskRef: &TaskRef{Name: "bar-task"},
+			Params: Params{{
+				Name: "a-param", Value: ParamValue{Type: ParamTypeString, StringVal: "$(context.pipelineRun.finallyTimeoutRemaining)"},
+			}},
+		}},
+		finally: true,
+	}, {
 		name: "valid string context variable for Pipeline name",
 		tasks: []PipelineTask{{
 			Name:    "bar",
@@ -3807,7 +4142,7 @@ func TestContextValid(t *testing.T) {
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if err := validatePipelineContextVariables(tt.tasks); err != nil {
+			if err := validatePipelineContextVariables(tt.tasks, tt.finally); err != nil {
 				t.Errorf("Pipeline.validatePipelineContextVariables() returned error for valid pipeline context variables: %v", err)
 			}
 		})
@@ -3820,6 +4155,19 @@ func TestContextInvalid(t *testing.T) {
 		tasks         []PipelineTask
 		expectedError apis.FieldError
 	}{{
+		name: "invalid string context variable for PipelineRun finallyTimeoutRemaining outside of finally",
+		tasks: []PipelineTask{{
+			Name:    "bar",
+			TaskRef: &TaskRef{Name: "bar-task"},
+			Params: Params{{
+				Name: "a-param", Value: ParamValue{Type: ParamTypeString, StringVal: "$(context.pipelineRun.finallyTimeoutRemaining)"},
+			}},
+		}},
+		expectedError: *apis.ErrGeneric("").Also(&apis.FieldError{
+			Message: `non-existent variable in "$(context.pipelineRun.finallyTimeoutRemaining)"`,
+			Paths:   []string{"value"},
+		}),
+	}, {
 		name: "invalid string context variable for pipeline",
 		tasks: []PipelineTask{{
 			Name:    "bar",
@@ -3959,9 +4307,9 @@ func TestContextInvalid(t *testing.T) {
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := validatePipelineContextVariables(tt.tasks)
+			err := validatePipelineContextVariables(tt.tasks, false)
 			if err == nil {
-				t.Errorf("Pipeline.validatePipelineContextVariables() did not return error for invalid pipeline parameters: %s", tt.tasks[0].Params)
+				t.Errorf("Pipeline.validatePipelineContextVariables() did not return error for invalid pipeline parameters: %v", tt.tasks[0].Params)
 			}
 			if d := cmp.Diff(tt.expectedError.Error(), err.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
 				t.Errorf("PipelineSpec.Validate() errors diff %s", diff.PrintWantGot(d))
@@ -4217,7 +4565,7 @@ func TestPipelineTasksExecutionStatus(t *testing.T) {
 				}
 			} else {
 				if err == nil {
-					t.Errorf("Pipeline.validateExecutionStatusVariables() did not return error for invalid pipeline parameters accessing execution status: %s, %s", tt.name, tt.tasks[0].Params)
+					t.Errorf("Pipeline.validateExecutionStatusVariables() did not return error for invalid pipeline parameters accessing execution status: %s, %v", tt.name, tt.tasks[0].Params)
 				}
 				if d := cmp.Diff(tt.expectedError.Error(), err.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
 					t.Errorf("PipelineSpec.Validate() errors diff %s", diff.PrintWantGot(d))
@@ -5129,3 +5477,46 @@ func TestValidatePipelineRefResultReferencesDisallowed(t *testing.T) {
 		})
 	}
 }
+
+func TestPipeline_Validate_Annotations(t *testing.T) {
+	tests := []struct {
+		name            string
+		annotations     map[string]string
+		expectedError   string
+		expectedWarning string
+	}{{
+		name:        "registered annotation",
+		annotations: map[string]string{"tekton.dev/deprecated": "false"},
+	}, {
+		name:          "registered annotation with an invalid value",
+		annotations:   map[string]string{"tekton.dev/deprecated": "no"},
+		expectedError: `invalid value: no: metadata.annotations[tekton.dev/deprecated]` + "\n" + `"no" must be one of true, false`,
+	}, {
+		name:            "unknown annotation under tekton.dev",
+		annotations:     map[string]string{"tekton.dev/display-name": "true"},
+		expectedWarning: `unknown annotation with a prefix reserved for Tekton Pipelines: metadata.annotations[tekton.dev/display-name]`,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &Pipeline{
+				ObjectMeta: metav1.ObjectMeta{Name: "pipeline"},
+				Spec:       PipelineSpec{Tasks: []PipelineTask{{Name: "foo", TaskRef: &TaskRef{Name: "foo-task"}}}},
+			}
+			p.Annotations = tt.annotations
+			errs := p.Validate(t.Context())
+			gotError, gotWarning := "", ""
+			if err := errs.Filter(apis.ErrorLevel); err != nil {
+				gotError = err.Error()
+			}
+			if warning := errs.Filter(apis.WarningLevel); warning != nil {
+				gotWarning = warning.Error()
+			}
+			if d := cmp.Diff(tt.expectedError, gotError); d != "" {
+				t.Errorf("Pipeline.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+			if d := cmp.Diff(tt.expectedWarning, gotWarning); d != "" {
+				t.Errorf("Pipeline.Validate() warnings diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
@@ -19,6 +19,7 @@ package v1
 import (
 	"context"
 	"regexp"
+	"strings"
 	"time"
 
 	"github.com/tektoncd/pipeline/pkg/apis/config"
@@ -43,9 +44,30 @@ func (pr *PipelineRun) SetDefaults(ctx context.Context) {
 		pr.ObjectMeta.Annotations = kmap.Filter(pr.ObjectMeta.Annotations, func(s string) bool {
 			return filterReservedAnnotationRegexp.MatchString(s)
 		})
+		setWorkspaceStorageDefaults(ctx, &pr.ObjectMeta, pr.Spec.Workspaces)
 	}
 }
 
+// setWorkspaceStorageDefaults sets the default storage class and access mode to the volumeClaimTemplates
+// of the workspaces which don't set them, only at creation as the spec of a run can't change once it
+// started, and notes the defaulted workspaces in the DefaultedWorkspaceStorageAnnotation.
+func setWorkspaceStorageDefaults(ctx context.Context, meta *metav1.ObjectMeta, workspaces []WorkspaceBinding) {
+	cfg := config.FromContextOrDefaults(ctx)
+	var defaulted []string
+	for i := range workspaces {
+		if cfg.Defaults.SetVolumeClaimTemplateDefaults(workspaces[i].VolumeClaimTemplate) {
+			defaulted = append(defaulted, workspaces[i].Name)
+		}
+	}
+	if len(defaulted) == 0 {
+		return
+	}
+	if meta.Annotations == nil {
+		meta.Annotations = map[string]string{}
+	}
+	meta.Annotations[DefaultedWorkspaceStorageAnnotation] = strings.Join(defaulted, ",")
+}
+
 // SetDefaults implements apis.Defaultable
 func (prs *PipelineRunSpec) SetDefaults(ctx context.Context) {
 	cfg := config.FromContextOrDefaults(ctx)
@@ -69,6 +91,14 @@ func (prs *PipelineRunSpec) SetDefaults(ctx context.Context) {
 	defaultPodTemplate := cfg.Defaults.DefaultPodTemplate
 	prs.TaskRunTemplate.PodTemplate = pod.MergePodTemplateWithDefault(prs.TaskRunTemplate.PodTemplate, defaultPodTemplate)
 
+	for i := range prs.Params {
+		// The value of a param taken from a PipelineRun result is only known once the
+		// PipelineRun starts; default it to an empty string until then.
+		if prs.Params[i].RunRef != nil && prs.Params[i].Value.Type == "" {
+			prs.Params[i].Value = ParamValue{Type: ParamTypeString}
+		}
+	}
+
 	if prs.PipelineSpec != nil {
 		prs.PipelineSpec.SetDefaults(ctx)
 	}
@@ -430,6 +430,36 @@ func TestPipelineRunDefaulting(t *testing.T) {
 				},
 			},
 		},
+	}, {
+		name: "VolumeClaimTemplates of workspaces are only defaulted on create",
+		in: &v1.PipelineRun{
+			Spec: v1.PipelineRunSpec{
+				PipelineRef: &v1.PipelineRef{Name: "foo"},
+				Workspaces: []v1.WorkspaceBinding{{
+					Name:                "ws",
+					VolumeClaimTemplate: &corev1.PersistentVolumeClaim{},
+				}},
+			},
+		},
+		want: &v1.PipelineRun{
+			Spec: v1.PipelineRunSpec{
+				TaskRunTemplate: v1.PipelineTaskRunTemplate{
+					ServiceAccountName: config.DefaultServiceAccountValue,
+				},
+				Timeouts: &v1.TimeoutFields{
+					Pipeline: &metav1.Duration{Duration: config.DefaultTimeoutMinutes * time.Minute},
+				},
+				PipelineRef: &v1.PipelineRef{Name: "foo"},
+				Workspaces: []v1.WorkspaceBinding{{
+					Name:                "ws",
+					VolumeClaimTemplate: &corev1.PersistentVolumeClaim{},
+				}},
+			},
+		},
+		defaults: map[string]string{
+			"default-workspace-storage-class": "local-wait-for-first-consumer",
+			"default-workspace-access-mode":   "ReadWriteOnce",
+		},
 	}}
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
@@ -445,6 +475,7 @@ func TestPipelineRunDefaulting(t *testing.T) {
 }
 
 func TestPipelineRunDefaultingOnCreate(t *testing.T) {
+	storageClass, explicitStorageClass := "local-wait-for-first-consumer", "explicit"
 	tests := []struct {
 		name     string
 		in       *v1.PipelineRun
@@ -478,6 +509,70 @@ func TestPipelineRunDefaultingOnCreate(t *testing.T) {
 				},
 			},
 		},
+	}, {
+		name: "VolumeClaimTemplates of workspaces get the default storage class and access mode on create",
+		in: &v1.PipelineRun{
+			Spec: v1.PipelineRunSpec{
+				PipelineRef: &v1.PipelineRef{
+					Name: "foo",
+				},
+				Workspaces: []v1.WorkspaceBinding{{
+					Name:                "defaulted",
+					VolumeClaimTemplate: &corev1.PersistentVolumeClaim{},
+				}, {
+					Name: "explicit",
+					VolumeClaimTemplate: &corev1.PersistentVolumeClaim{
+						Spec: corev1.PersistentVolumeClaimSpec{
+							StorageClassName: &explicitStorageClass,
+							AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany},
+						},
+					},
+				}, {
+					Name:     "empty-dir",
+					EmptyDir: &corev1.EmptyDirVolumeSource{},
+				}},
+			},
+		},
+		want: &v1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{"tekton.dev/defaulted-workspace-storage": "defaulted"},
+			},
+			Spec: v1.PipelineRunSpec{
+				TaskRunTemplate: v1.PipelineTaskRunTemplate{
+					ServiceAccountName: config.DefaultServiceAccountValue,
+				},
+				Timeouts: &v1.TimeoutFields{
+					Pipeline: &metav1.Duration{Duration: config.DefaultTimeoutMinutes * time.Minute},
+				},
+				PipelineRef: &v1.PipelineRef{
+					Name: "foo",
+				},
+				Workspaces: []v1.WorkspaceBinding{{
+					Name: "defaulted",
+					VolumeClaimTemplate: &corev1.PersistentVolumeClaim{
+						Spec: corev1.PersistentVolumeClaimSpec{
+							StorageClassName: &storageClass,
+							AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+						},
+					},
+				}, {
+					Name: "explicit",
+					VolumeClaimTemplate: &corev1.PersistentVolumeClaim{
+						Spec: corev1.PersistentVolumeClaimSpec{
+							StorageClassName: &explicitStorageClass,
+							AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany},
+						},
+					},
+				}, {
+					Name:     "empty-dir",
+					EmptyDir: &corev1.EmptyDirVolumeSource{},
+				}},
+			},
+		},
+		defaults: map[string]string{
+			"default-workspace-storage-class": "local-wait-for-first-consumer",
+			"default-workspace-access-mode":   "ReadWriteOnce",
+		},
 	}}
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
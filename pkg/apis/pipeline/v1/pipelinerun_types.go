@@ -19,6 +19,8 @@ package v1
 import (
 	"context"
 	"fmt"
+	"path"
+	"strings"
 	"time"
 
 	"github.com/tektoncd/pipeline/pkg/apis/config"
@@ -300,6 +302,11 @@ type PipelineRunSpec struct {
 	// This field is immutable.
 	// +optional
 	ManagedBy *string `json:"managedBy,omitempty"`
+	// TTLSecondsAfterFinished is the number of seconds after which the PipelineRun is deleted
+	// once it completed, unless it has the "tekton.dev/keep" annotation set to "true".
+	// Defaults to the "default-ttl-seconds-after-finished" default, if any.
+	// +optional
+	TTLSecondsAfterFinished *int32 `json:"ttlSecondsAfterFinished,omitempty"`
 }
 
 // TimeoutFields allows granular specification of pipeline, task, and finally timeouts
@@ -442,11 +449,48 @@ const (
 	PipelineRunReasonCELEvaluationFailed PipelineRunReason = "CELEvaluationFailed"
 	// PipelineRunReasonInvalidParamValue indicates that the PipelineRun Param input value is not allowed.
 	PipelineRunReasonInvalidParamValue PipelineRunReason = "InvalidParamValue"
+	// PipelineRunReasonStartDelayed is the reason set when the start of the PipelineRun is delayed
+	// by the "default-start-jitter" config
+	PipelineRunReasonStartDelayed PipelineRunReason = "PipelineRunStartDelayed"
+	// PipelineRunReasonReferencedRunResultUnavailable indicates that the result of another PipelineRun
+	// a param is taken from is not available
+	PipelineRunReasonReferencedRunResultUnavailable PipelineRunReason = "ReferencedRunResultUnavailable"
+	// PipelineRunReasonWaitingForApproval is the reason set when the PipelineRun is waiting for the decision
+	// of the approvers of one of its approval gates
+	PipelineRunReasonWaitingForApproval PipelineRunReason = "WaitingForApproval"
+	// PipelineRunReasonApprovalRejected is the reason set when the PipelineRun failed because one of its
+	// approval gates was rejected
+	PipelineRunReasonApprovalRejected PipelineRunReason = "ApprovalRejected"
+	// PipelineRunReasonApprovalTimedOut is the reason set when the PipelineRun failed because one of its
+	// approval gates timed out before any decision was taken
+	PipelineRunReasonApprovalTimedOut PipelineRunReason = "ApprovalTimedOut"
+	// PipelineRunReasonInvalidRetriesOrTimeout is the reason set when the PipelineRun failed because the
+	// retries or the timeout a PipelineTask takes from a param or a result can't be parsed
+	PipelineRunReasonInvalidRetriesOrTimeout PipelineRunReason = "InvalidRetriesOrTimeout"
+	// PipelineRunReasonCancelledDueToDeletion is the reason set when the PipelineRun was deleted, e.g. with
+	// its namespace, before it completed
+	PipelineRunReasonCancelledDueToDeletion PipelineRunReason = "CancelledDueToDeletion"
 )
 
 // PipelineTaskOnErrorAnnotation is used to pass the failure strategy to TaskRun pods from PipelineTask OnError field
 const PipelineTaskOnErrorAnnotation = "pipeline.tekton.dev/pipeline-task-on-error"
 
+// PipelineTaskExpectedDurationAnnotation is used to pass the expected duration to TaskRuns from PipelineTask ExpectedDuration field
+const PipelineTaskExpectedDurationAnnotation = "pipeline.tekton.dev/pipeline-task-expected-duration"
+
+// PipelineTaskDisplayNameAnnotation is used to pass the display name to TaskRuns from PipelineTask DisplayName field
+const PipelineTaskDisplayNameAnnotation = "pipeline.tekton.dev/pipeline-task-display-name"
+
+// PipelineTaskDescriptionAnnotation is used to pass the description to TaskRuns from PipelineTask Description field
+const PipelineTaskDescriptionAnnotation = "pipeline.tekton.dev/pipeline-task-description"
+
+// PipelineRunPriorityAnnotation is used to set the priority of a PipelineRun. PipelineRuns with the
+// PipelineRunPriorityHigh priority start right away instead of being delayed by the "default-start-jitter" config.
+const PipelineRunPriorityAnnotation = "pipeline.tekton.dev/priority"
+
+// PipelineRunPriorityHigh is the value of the PipelineRunPriorityAnnotation for PipelineRuns that must start right away
+const PipelineRunPriorityHigh = "high"
+
 func (t PipelineRunReason) String() string {
 	return string(t)
 }
@@ -519,6 +563,15 @@ type ChildStatusReference struct {
 	// +optional
 	// +listType=atomic
 	WhenExpressions []WhenExpression `json:"whenExpressions,omitempty"`
+
+	// Labels are the labels of the PipelineTask added to the TaskRun this is referencing.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Reason is the reason of the condition of the TaskRun this is referencing once it is done,
+	// when it is an instance of a Matrix which doesn't fail fast.
+	// +optional
+	Reason string `json:"reason,omitempty"`
 }
 
 // PipelineRunStatusFields holds the fields of PipelineRunStatus' status.
@@ -562,6 +615,104 @@ type PipelineRunStatusFields struct {
 
 	// SpanContext contains tracing span context fields
 	SpanContext map[string]string `json:"spanContext,omitempty"`
+
+	// Timing breaks down where the PipelineRun spent its time.
+	// +optional
+	Timing *PipelineRunTiming `json:"timing,omitempty"`
+
+	// ApprovalGates is the status of the approval gates of the PipelineRun which were reached.
+	// +optional
+	// +listType=atomic
+	ApprovalGates []ApprovalGateStatus `json:"approvalGates,omitempty"`
+
+	// Approvals are the decisions taken on the approval gates of the PipelineRun by their
+	// approvers, who add them through the status subresource.
+	// +optional
+	// +listType=atomic
+	Approvals []Approval `json:"approvals,omitempty"`
+
+	// FailureCauses are the failed runs of the PipelineTasks which made the PipelineRun fail,
+	// ordered by the time they completed. They are set once the PipelineRun failed.
+	// +optional
+	// +listType=atomic
+	FailureCauses []PipelineRunFailureCause `json:"failureCauses,omitempty"`
+
+	// Layers is the progress of the PipelineRun through the layers of the DAG of its tasks, the
+	// finally tasks excluded.
+	// +optional
+	Layers *PipelineRunLayers `json:"layers,omitempty"`
+
+	// Coschedule is the "coschedule" feature flag the PipelineRun was started with, recorded if it binds
+	// PersistentVolumeClaims or volumeClaimTemplates to its workspaces, which its Affinity Assistants keep
+	// using until it is done even if the feature flag is changed in the meantime.
+	// +optional
+	Coschedule string `json:"coschedule,omitempty"`
+
+	// SkippedAffinityAssistantWorkspaces are the workspaces of the PipelineRun which have no Affinity
+	// Assistant, because they are bound to ReadWriteMany PersistentVolumeClaims or volumeClaimTemplates
+	// and the "skip-affinity-assistant-for-read-write-many" feature flag was set when Coschedule was recorded.
+	// +optional
+	// +listType=atomic
+	SkippedAffinityAssistantWorkspaces []string `json:"skippedAffinityAssistantWorkspaces,omitempty"`
+}
+
+// PipelineRunTiming breaks down where a PipelineRun spent its time.
+type PipelineRunTiming struct {
+	// WorkspacePVCWaits lists how long the PVCs created from the volumeClaimTemplates of the
+	// workspaces of the PipelineRun waited to be bound.
+	// +optional
+	// +listType=atomic
+	WorkspacePVCWaits []WorkspacePVCWait `json:"workspacePVCWaits,omitempty"`
+}
+
+// WorkspacePVCWait records how long the PVC created for a workspace waited to be bound.
+type WorkspacePVCWait struct {
+	// Workspace is the name of the workspace of the PipelineRun.
+	Workspace string `json:"workspace"`
+	// ClaimName is the name of the PVC created for the workspace.
+	ClaimName string `json:"claimName"`
+	// StorageClassName is the storage class of the PVC.
+	// +optional
+	StorageClassName string `json:"storageClassName,omitempty"`
+	// Duration is the time between the creation of the PVC and the PipelineRun reconciler
+	// observing it Bound. It is not set while the PVC is not bound.
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
+}
+
+// PipelineRunLayers is the progress of a PipelineRun through the layers of the DAG of its tasks: the tasks
+// without dependencies are in the first layer, and the other tasks in the layer following the last layer of
+// the tasks they depend on. The finally tasks are not in any layer.
+type PipelineRunLayers struct {
+	// Total is the number of layers of the DAG of the tasks of the PipelineRun.
+	Total int `json:"total"`
+	// Completed is the highest layer of which all the tasks, and all the tasks of the layers before it,
+	// are done: succeeded, failed, skipped or cancelled. It is 0 until all the tasks of the first layer
+	// are done.
+	Completed int `json:"completed"`
+}
+
+// PipelineRunFailureCause is a failed run of a PipelineTask which made the PipelineRun fail.
+type PipelineRunFailureCause struct {
+	// PipelineTaskName is the name of the PipelineTask which failed.
+	PipelineTaskName string `json:"pipelineTaskName"`
+	// Kind is the kind of the failed run: TaskRun, CustomRun or PipelineRun. It is not set
+	// for approval gates.
+	// +optional
+	Kind string `json:"kind,omitempty"`
+	// Name is the name of the failed run.
+	// +optional
+	Name string `json:"name,omitempty"`
+	// Reason is the reason of the Succeeded condition of the failed run, or the reason of
+	// the approval gate which was rejected or timed out.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// StepName is the name of the first step of a failed TaskRun which failed.
+	// +optional
+	StepName string `json:"stepName,omitempty"`
+	// StepTerminationReason is the termination reason of the step, e.g. OOMKilled.
+	// +optional
+	StepTerminationReason string `json:"stepTerminationReason,omitempty"`
 }
 
 // SkippedTask is used to describe the Tasks that were skipped due to their When Expressions
@@ -602,6 +753,10 @@ const (
 	FinallyTimedOutSkip SkippingReason = "PipelineRun Finally timeout has been reached"
 	// EmptyArrayInMatrixParams means the task was skipped because Matrix parameters contain empty array.
 	EmptyArrayInMatrixParams SkippingReason = "Matrix Parameters have an empty array"
+	// ApprovalRejectedSkip means the task was skipped because an approval gate of the PipelineRun was rejected.
+	ApprovalRejectedSkip SkippingReason = "Approval gate was rejected"
+	// ApprovalTimedOutSkip means the task was skipped because an approval gate of the PipelineRun timed out.
+	ApprovalTimedOutSkip SkippingReason = "Approval gate timed out"
 	// None means the task was not skipped
 	None SkippingReason = "None"
 )
@@ -663,6 +818,9 @@ type PipelineTaskRun struct {
 // PipelineTaskRunSpec  can be used to configure specific
 // specs for a concrete Task
 type PipelineTaskRunSpec struct {
+	// PipelineTaskName is the name of the PipelineTask this spec applies to, or a glob pattern
+	// such as "deploy-*" matching the names of PipelineTasks. The spec named after a PipelineTask
+	// takes precedence over the patterns matching its name, and the first matching pattern applies.
 	PipelineTaskName   string           `json:"pipelineTaskName,omitempty"`
 	ServiceAccountName string           `json:"serviceAccountName,omitempty"`
 	PodTemplate        *pod.PodTemplate `json:"podTemplate,omitempty"`
@@ -687,30 +845,64 @@ type PipelineTaskRunSpec struct {
 
 // GetTaskRunSpec returns the task specific spec for a given
 // PipelineTask if configured, otherwise it returns the PipelineRun's default.
+// The spec named after the PipelineTask takes precedence over the first spec
+// whose name is a glob pattern matching the name of the PipelineTask.
 func (pr *PipelineRun) GetTaskRunSpec(pipelineTaskName string) PipelineTaskRunSpec {
 	s := PipelineTaskRunSpec{
 		PipelineTaskName:   pipelineTaskName,
 		ServiceAccountName: pr.Spec.TaskRunTemplate.ServiceAccountName,
 		PodTemplate:        pr.Spec.TaskRunTemplate.PodTemplate,
 	}
-	for _, task := range pr.Spec.TaskRunSpecs {
-		if task.PipelineTaskName == pipelineTaskName {
-			// merge podTemplates specified in pipelineRun.spec.taskRunSpecs[].podTemplate and pipelineRun.spec.podTemplate
-			// with taskRunSpecs taking higher precedence
-			s.PodTemplate = pod.MergePodTemplateWithDefault(task.PodTemplate, s.PodTemplate)
-			if task.ServiceAccountName != "" {
-				s.ServiceAccountName = task.ServiceAccountName
-			}
-			s.StepSpecs = task.StepSpecs
-			s.SidecarSpecs = task.SidecarSpecs
-			s.Metadata = task.Metadata
-			s.ComputeResources = task.ComputeResources
-			s.Timeout = task.Timeout
+	if task, ok := pr.matchTaskRunSpec(pipelineTaskName); ok {
+		// merge podTemplates specified in pipelineRun.spec.taskRunSpecs[].podTemplate and pipelineRun.spec.podTemplate
+		// with taskRunSpecs taking higher precedence
+		s.PodTemplate = pod.MergePodTemplateWithDefault(task.PodTemplate, s.PodTemplate)
+		if task.ServiceAccountName != "" {
+			s.ServiceAccountName = task.ServiceAccountName
 		}
+		s.StepSpecs = task.StepSpecs
+		s.SidecarSpecs = task.SidecarSpecs
+		s.Metadata = task.Metadata
+		s.ComputeResources = task.ComputeResources
+		s.Timeout = task.Timeout
 	}
 	return s
 }
 
+// matchTaskRunSpec returns the taskRunSpec named after the PipelineTask or, if there is none,
+// the first one whose name is a glob pattern matching the name of the PipelineTask.
+func (pr *PipelineRun) matchTaskRunSpec(pipelineTaskName string) (PipelineTaskRunSpec, bool) {
+	var pattern *PipelineTaskRunSpec
+	for i, task := range pr.Spec.TaskRunSpecs {
+		if task.PipelineTaskName == pipelineTaskName {
+			return task, true
+		}
+		if pattern == nil && task.IsPattern() && task.Matches(pipelineTaskName) {
+			pattern = &pr.Spec.TaskRunSpecs[i]
+		}
+	}
+	if pattern == nil {
+		return PipelineTaskRunSpec{}, false
+	}
+	return *pattern, true
+}
+
+// IsPattern returns true if the PipelineTaskName of the spec is a glob pattern, such as "deploy-*",
+// rather than the name of a PipelineTask.
+func (trs PipelineTaskRunSpec) IsPattern() bool {
+	return strings.ContainsAny(trs.PipelineTaskName, "*?[")
+}
+
+// Matches returns true if the spec applies to the PipelineTask named pipelineTaskName, either by
+// its name or by a glob pattern matching it.
+func (trs PipelineTaskRunSpec) Matches(pipelineTaskName string) bool {
+	if !trs.IsPattern() {
+		return trs.PipelineTaskName == pipelineTaskName
+	}
+	matched, err := path.Match(trs.PipelineTaskName, pipelineTaskName)
+	return err == nil && matched
+}
+
 // PipelineTaskRunTemplate is used to specify run specifications for all Task in pipelinerun.
 type PipelineTaskRunTemplate struct {
 	// +optional
@@ -701,6 +701,50 @@ func TestPipelineRun_GetTaskRunSpec(t *testing.T) {
 	}
 }
 
+func TestPipelineRun_GetTaskRunSpec_Patterns(t *testing.T) {
+	pr := &v1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "pr"},
+		Spec: v1.PipelineRunSpec{
+			TaskRunTemplate: v1.PipelineTaskRunTemplate{ServiceAccountName: "defaultSA"},
+			PipelineRef:     &v1.PipelineRef{Name: "prs"},
+			TaskRunSpecs: []v1.PipelineTaskRunSpec{{
+				PipelineTaskName:   "deploy-*",
+				ServiceAccountName: "deployer",
+			}, {
+				PipelineTaskName:   "deploy-prod-?",
+				ServiceAccountName: "prod-deployer",
+			}, {
+				PipelineTaskName:   "deploy-prod-eu",
+				ServiceAccountName: "eu-deployer",
+			}},
+		},
+	}
+	for _, tc := range []struct {
+		pipelineTaskName string
+		want             string
+	}{{
+		pipelineTaskName: "deploy-staging",
+		want:             "deployer",
+	}, {
+		// the first matching pattern applies
+		pipelineTaskName: "deploy-prod-1",
+		want:             "deployer",
+	}, {
+		// the spec named after the PipelineTask takes precedence over the patterns
+		pipelineTaskName: "deploy-prod-eu",
+		want:             "eu-deployer",
+	}, {
+		pipelineTaskName: "build",
+		want:             "defaultSA",
+	}} {
+		t.Run(tc.pipelineTaskName, func(t *testing.T) {
+			if got := pr.GetTaskRunSpec(tc.pipelineTaskName).ServiceAccountName; got != tc.want {
+				t.Errorf("GetTaskRunSpec(%q).ServiceAccountName = %q, want %q", tc.pipelineTaskName, got, tc.want)
+			}
+		})
+	}
+}
+
 func TestPipelineRunMarkFailedCondition(t *testing.T) {
 	failedRunReason := v1.PipelineRunReasonFailed
 	messageFormat := "error bar occurred %s"
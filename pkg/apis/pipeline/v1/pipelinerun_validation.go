@@ -19,11 +19,14 @@ package v1
 import (
 	"context"
 	"fmt"
+	"maps"
+	"path"
 	"slices"
 	"strings"
 	"time"
 
 	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	"github.com/tektoncd/pipeline/pkg/apis/validate"
 	"github.com/tektoncd/pipeline/pkg/internal/resultref"
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
@@ -46,14 +49,65 @@ func (pr *PipelineRun) SupportedVerbs() []admissionregistrationv1.OperationType
 // Validate pipelinerun
 func (pr *PipelineRun) Validate(ctx context.Context) *apis.FieldError {
 	errs := validate.ObjectMetadata(pr.GetObjectMeta()).ViaField("metadata")
+	errs = errs.Also(validate.ObjectAnnotations(ctx, pipeline.PipelineRunControllerName, pr).ViaField("metadata"))
 
 	if pr.IsPending() && pr.HasStarted() {
 		errs = errs.Also(apis.ErrInvalidValue("PipelineRun cannot be Pending after it is started", "spec.status"))
 	}
 
+	if apis.IsInStatusUpdate(ctx) {
+		errs = errs.Also(pr.Status.validateApprovals(ctx).ViaField("status"))
+	}
+
+	if apis.IsInCreate(ctx) && pr.Spec.PipelineSpec != nil {
+		errs = errs.Also(validateRunRefResultTypes(ctx, pr.Namespace, pr.Spec.Params, pr.Spec.PipelineSpec.Params).ViaField("spec"))
+	}
+
 	return errs.Also(pr.Spec.Validate(apis.WithinSpec(ctx)).ViaField("spec"))
 }
 
+// RunRefResultTypeFunc returns the type of the result of another PipelineRun a param is taken from,
+// and whether that PipelineRun and its result were found.
+type RunRefResultTypeFunc func(ctx context.Context, namespace string, ref PipelineRunResultRef) (ParamType, bool)
+
+type runRefResultTypeKey struct{}
+
+// WithRunRefResultType returns a context in which the types of the results the params of the
+// PipelineRuns are taken from are checked at admission with resultType.
+func WithRunRefResultType(ctx context.Context, resultType RunRefResultTypeFunc) context.Context {
+	return context.WithValue(ctx, runRefResultTypeKey{}, resultType)
+}
+
+// RunRefResultTypeFromContext returns the function set with WithRunRefResultType, if any.
+func RunRefResultTypeFromContext(ctx context.Context) RunRefResultTypeFunc {
+	resultType, _ := ctx.Value(runRefResultTypeKey{}).(RunRefResultTypeFunc)
+	return resultType
+}
+
+// validateRunRefResultTypes makes sure the results that params are taken from have the types the
+// params are declared with. The results which can't be found yet are only checked by the reconciler,
+// once the PipelineRun starts.
+func validateRunRefResultTypes(ctx context.Context, namespace string, params Params, specs ParamSpecs) (errs *apis.FieldError) {
+	resultType := RunRefResultTypeFromContext(ctx)
+	if resultType == nil {
+		return nil
+	}
+	for _, p := range params {
+		if p.RunRef == nil {
+			continue
+		}
+		i := slices.IndexFunc(specs, func(s ParamSpec) bool { return s.Name == p.Name })
+		if i < 0 || specs[i].Type == "" {
+			continue
+		}
+		if t, ok := resultType(ctx, namespace, *p.RunRef); ok && t != specs[i].Type {
+			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("result %q of PipelineRun %q is of type %q, while the param is of type %q",
+				p.RunRef.Result, p.RunRef.Name, t, specs[i].Type), "runRef.result").ViaFieldKey("params", p.Name))
+		}
+	}
+	return errs
+}
+
 // Validate pipelinerun spec
 func (ps *PipelineRunSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
 	// Validate the spec changes
@@ -79,6 +133,11 @@ func (ps *PipelineRunSpec) Validate(ctx context.Context) (errs *apis.FieldError)
 			errs = errs.Also(apis.ErrDisallowedFields("pipelineSpec"))
 		}
 		errs = errs.Also(ps.PipelineSpec.Validate(ctx).ViaField("pipelineSpec"))
+		// The params of pending PipelineRuns may be patched before they are started, so their
+		// values are only validated once the PipelineRun is started.
+		if ps.Status != PipelineRunSpecStatusPending {
+			errs = errs.Also(ps.PipelineSpec.Params.validateParamValueConstraints(ps.Params).ViaField("params"))
+		}
 	}
 
 	// Validate PipelineRun parameters
@@ -106,11 +165,33 @@ func (ps *PipelineRunSpec) Validate(ctx context.Context) (errs *apis.FieldError)
 	}
 
 	// Validate individual TaskRunSpecs with timeout context
+	var finallyTasks []string
+	if ps.PipelineSpec != nil {
+		for _, ft := range ps.PipelineSpec.Finally {
+			finallyTasks = append(finallyTasks, ft.Name)
+		}
+	}
+	taskRunSpecNames := make(map[string]int)
 	for idx, trs := range ps.TaskRunSpecs {
-		errs = errs.Also(validateTaskRunSpec(ctx, trs, ps.Timeouts).ViaIndex(idx).ViaField("taskRunSpecs"))
+		finally := slices.ContainsFunc(finallyTasks, trs.Matches)
+		errs = errs.Also(validateTaskRunSpec(ctx, trs, ps.Timeouts, ps.PipelineSpec != nil, finally).ViaIndex(idx).ViaField("taskRunSpecs"))
+		if trs.IsPattern() {
+			if _, err := path.Match(trs.PipelineTaskName, ""); err != nil {
+				errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%q is not a valid glob pattern: %v", trs.PipelineTaskName, err), "pipelineTaskName").ViaFieldIndex("taskRunSpecs", idx))
+			}
+			continue
+		}
+		if prevIdx, alreadyExists := taskRunSpecNames[trs.PipelineTaskName]; alreadyExists {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("taskRunSpec for pipelineTask %q provided by pipelinerun more than once, at index %d and %d", trs.PipelineTaskName, prevIdx, idx), "pipelineTaskName").ViaFieldIndex("taskRunSpecs", idx))
+		}
+		taskRunSpecNames[trs.PipelineTaskName] = idx
 	}
 	errs = errs.Also(validateSpecStatus(ps.Status))
 
+	if ps.TTLSecondsAfterFinished != nil && *ps.TTLSecondsAfterFinished < 0 {
+		errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%d should be >= 0", *ps.TTLSecondsAfterFinished), "ttlSecondsAfterFinished"))
+	}
+
 	if ps.Workspaces != nil {
 		wsNames := make(map[string]int)
 		for idx, ws := range ps.Workspaces {
@@ -124,6 +205,7 @@ func (ps *PipelineRunSpec) Validate(ctx context.Context) (errs *apis.FieldError)
 
 	if ps.TaskRunTemplate.PodTemplate != nil {
 		errs = errs.Also(validatePodTemplateEnv(ctx, *ps.TaskRunTemplate.PodTemplate).ViaField("taskRunTemplate"))
+		errs = errs.Also(ps.TaskRunTemplate.PodTemplate.ValidateTopologySpreadConstraints().ViaField("podTemplate").ViaField("taskRunTemplate"))
 	}
 
 	return errs
@@ -167,6 +249,10 @@ func (ps *PipelineRunSpec) ValidateUpdate(ctx context.Context) (errs *apis.Field
 	// Handle started but not done case
 	old := oldObj.Spec.DeepCopy()
 	old.Status = ps.Status
+	if oldObj.IsPending() && !oldObj.HasStarted() {
+		// The params of a pending PipelineRun can be patched until it is started.
+		old.Params = ps.Params
+	}
 	old.ManagedBy = ps.ManagedBy // Already tested before
 	if !equality.Semantic.DeepEqual(old, ps) {
 		errs = errs.Also(apis.ErrInvalidValue("Once the PipelineRun has started, only status updates are allowed", ""))
@@ -182,21 +268,64 @@ func (ps *PipelineRunSpec) validatePipelineRunParameters(ctx context.Context) (e
 	// Validate parameter types and uniqueness
 	errs = errs.Also(ValidateParameters(ctx, ps.Params).ViaField("params"))
 
-	// Validate that task results aren't used in param values
 	for _, param := range ps.Params {
-		expressions, ok := param.GetVarSubstitutionExpressions()
-		if ok {
-			if LooksLikeContainsResultRefs(expressions) {
-				expressions = filter(expressions, resultref.LooksLikeResultRef)
-				resultRefs := NewResultRefs(expressions)
-				if len(resultRefs) > 0 {
-					errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("cannot use result expressions in %v as PipelineRun parameter values", expressions),
-						"value").ViaFieldKey("params", param.Name))
-				}
+		if param.RunRef != nil {
+			errs = errs.Also(param.validateRunRef().ViaFieldKey("params", param.Name))
+		}
+	}
+
+	// Validate that task and step results, and task execution statuses, aren't used in param values
+	for _, param := range ps.Params {
+		if expressions := param.pipelineRunTimeRefs(); len(expressions) > 0 {
+			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("cannot use result expressions in %v as PipelineRun parameter values: "+
+				"the results of Tasks and Steps and the execution status of Tasks are only known while the Pipeline runs, so they can only "+
+				"be referenced in the Pipeline, e.g. in the params and when expressions of its Tasks; "+
+				"use runRef to take the value of a param from a result of another PipelineRun", expressions),
+				"value").ViaFieldKey("params", param.Name))
+		}
+	}
+
+	return errs
+}
+
+// pipelineRunTimeRefs returns the expressions in the value of a param which reference the results of
+// Tasks or Steps, or the execution status of Tasks, which are only known while a Pipeline runs.
+func (p Param) pipelineRunTimeRefs() []string {
+	var values []string
+	switch p.Value.Type {
+	case ParamTypeString:
+		values = []string{p.Value.StringVal}
+	case ParamTypeArray:
+		values = p.Value.ArrayVal
+	case ParamTypeObject:
+		for _, key := range slices.Sorted(maps.Keys(p.Value.ObjectVal)) {
+			values = append(values, p.Value.ObjectVal[key])
+		}
+	}
+	var refs []string
+	for _, value := range values {
+		for _, loc := range VariableSubstitutionRegex.FindAllStringIndex(value, -1) {
+			expression := stripVarSubExpression(value[loc[0]:loc[1]])
+			if resultref.LooksLikeResultRef(expression) || resultref.LooksLikeStepResultRef(expression) || containsExecutionStatusRef(expression) {
+				refs = append(refs, expression)
 			}
 		}
 	}
+	return refs
+}
 
+// validateRunRef validates a param whose value is taken from a result of another PipelineRun.
+func (p Param) validateRunRef() (errs *apis.FieldError) {
+	if p.RunRef.Name == "" {
+		errs = errs.Also(apis.ErrMissingField("runRef.name"))
+	}
+	if p.RunRef.Result == "" {
+		errs = errs.Also(apis.ErrMissingField("runRef.result"))
+	}
+	// The value of the param is empty until it is taken from the result.
+	if p.Value.Type != "" && (p.Value.Type != ParamTypeString || p.Value.StringVal != "") {
+		errs = errs.Also(apis.ErrMultipleOneOf("value", "runRef"))
+	}
 	return errs
 }
 
@@ -211,6 +340,10 @@ func (ps *PipelineRunSpec) validateInlineParameters(ctx context.Context) (errs *
 	}
 	paramSpecForValidation := make(map[string]ParamSpec)
 	for _, p := range ps.Params {
+		// The type of a param taken from a PipelineRun result is only known once the PipelineRun starts.
+		if p.RunRef != nil {
+			continue
+		}
 		paramSpecForValidation = createParamSpecFromParam(p, paramSpecForValidation)
 	}
 	for _, p := range ps.PipelineSpec.Params {
@@ -242,6 +375,7 @@ func (ps *PipelineRunSpec) validateInlineParameters(ctx context.Context) (errs *
 				errs = errs.Also(ValidateParameterTypes(ctx, paramSpec))
 				errs = errs.Also(ValidateParameterVariables(ctx, pt.TaskSpec.Steps, paramSpec))
 				errs = errs.Also(ValidateUsageOfDeclaredParameters(ctx, pt.TaskSpec.Steps, paramSpec))
+				errs = errs.Also(ValidateUsageOfDeclaredParametersInSidecars(pt.TaskSpec.Sidecars, paramSpec))
 			}
 		}
 		errs = errs.Also(ValidatePipelineParameterVariables(ctx, ps.PipelineSpec.Tasks, paramSpec))
@@ -334,7 +468,7 @@ func (ps *PipelineRunSpec) validatePipelineTimeout(timeout time.Duration, errorM
 	return errs
 }
 
-func validateTaskRunSpec(ctx context.Context, trs PipelineTaskRunSpec, pipelineTimeouts *TimeoutFields) (errs *apis.FieldError) {
+func validateTaskRunSpec(ctx context.Context, trs PipelineTaskRunSpec, pipelineTimeouts *TimeoutFields, embeddedPipelineSpec, finally bool) (errs *apis.FieldError) {
 	if trs.StepSpecs != nil {
 		errs = errs.Also(config.ValidateEnabledAPIFields(ctx, "stepSpecs", config.BetaAPIFields).ViaField("stepSpecs"))
 		errs = errs.Also(validateStepSpecs(trs.StepSpecs).ViaField("stepSpecs"))
@@ -349,16 +483,20 @@ func validateTaskRunSpec(ctx context.Context, trs PipelineTaskRunSpec, pipelineT
 	}
 	if trs.PodTemplate != nil {
 		errs = errs.Also(validatePodTemplateEnv(ctx, *trs.PodTemplate))
+		errs = errs.Also(trs.PodTemplate.ValidateTopologySpreadConstraints().ViaField("podTemplate"))
 	}
 
-	errs = errs.Also(validateTaskRunSpecTimeout(ctx, trs.Timeout, pipelineTimeouts))
+	errs = errs.Also(validateTaskRunSpecTimeout(ctx, trs.Timeout, pipelineTimeouts, embeddedPipelineSpec, finally))
 
 	return errs
 }
 
-// validateTaskRunSpecTimeout validates a TaskRunSpec's timeout against pipeline timeouts.
+// validateTaskRunSpecTimeout validates a TaskRunSpec's timeout against pipeline timeouts, i.e. the
+// finally timeout for the TaskRunSpec of a finally task, or else the timeout left to the tasks.
+// The finally tasks are only known when the PipelineSpec is embedded, so the pipeline timeout isn't
+// reduced by the finally timeout for the tasks of a referenced Pipeline.
 // This function works in isolation and doesn't rely on previous validation steps.
-func validateTaskRunSpecTimeout(ctx context.Context, timeout *metav1.Duration, pipelineTimeouts *TimeoutFields) *apis.FieldError {
+func validateTaskRunSpecTimeout(ctx context.Context, timeout *metav1.Duration, pipelineTimeouts *TimeoutFields, embeddedPipelineSpec, finally bool) *apis.FieldError {
 	if timeout == nil {
 		return nil
 	}
@@ -369,13 +507,20 @@ func validateTaskRunSpecTimeout(ctx context.Context, timeout *metav1.Duration, p
 	if _, err := validateTimeout(timeout, cfg.Defaults.DefaultTimeoutMinutes); err != nil {
 		return err
 	}
+	// The TaskRun must not run longer than the maximum TaskRun duration either
+	if err := validateMaximumTaskRunTimeout(ctx, timeout.Duration); err != nil {
+		return err
+	}
 
-	// Find applicable timeout limit: Tasks -> Pipeline -> Default (60min)
+	// Find applicable timeout limit: Finally or Tasks -> Pipeline minus Finally -> Pipeline -> Default (60min)
 	var maxTimeout *metav1.Duration
 	var timeoutSource string
 
 	switch {
-	case pipelineTimeouts != nil && pipelineTimeouts.Tasks != nil:
+	case finally && pipelineTimeouts != nil && pipelineTimeouts.Finally != nil:
+		maxTimeout = pipelineTimeouts.Finally
+		timeoutSource = "pipeline finally duration"
+	case !finally && pipelineTimeouts != nil && pipelineTimeouts.Tasks != nil:
 		if validatedTimeout, err := validateTimeout(pipelineTimeouts.Tasks, cfg.Defaults.DefaultTimeoutMinutes); err != nil {
 			// Return error if Tasks timeout is invalid (prevents silent failures)
 			return err
@@ -383,6 +528,11 @@ func validateTaskRunSpecTimeout(ctx context.Context, timeout *metav1.Duration, p
 			maxTimeout = validatedTimeout
 			timeoutSource = "pipeline tasks duration"
 		}
+	case embeddedPipelineSpec && !finally && pipelineTimeouts != nil && pipelineTimeouts.Pipeline != nil && pipelineTimeouts.Finally != nil &&
+		pipelineTimeouts.Pipeline.Duration != config.NoTimeoutDuration && pipelineTimeouts.Finally.Duration < pipelineTimeouts.Pipeline.Duration:
+		// The tasks are left the time the finally tasks don't take
+		maxTimeout = &metav1.Duration{Duration: pipelineTimeouts.Pipeline.Duration - pipelineTimeouts.Finally.Duration}
+		timeoutSource = "pipeline tasks duration"
 	case pipelineTimeouts != nil && pipelineTimeouts.Pipeline != nil:
 		if validatedTimeout, err := validateTimeout(pipelineTimeouts.Pipeline, cfg.Defaults.DefaultTimeoutMinutes); err != nil {
 			// Return error if Pipeline timeout is invalid (prevents silent failures)
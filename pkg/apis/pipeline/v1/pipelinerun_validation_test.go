@@ -18,6 +18,7 @@ package v1_test
 
 import (
 	"context"
+	"fmt"
 	"strings"
 	"testing"
 	"time"
@@ -691,7 +692,7 @@ func TestPipelineRun_Validate(t *testing.T) {
 						Name: "echoit",
 						Workspaces: []v1.WorkspacePipelineTaskBinding{{
 							Name:    "ws",
-							SubPath: "/foo",
+							SubPath: "foo",
 						}},
 						TaskSpec: &v1.EmbeddedTask{TaskSpec: v1.TaskSpec{
 							Workspaces: []v1.WorkspaceDeclaration{{
@@ -709,7 +710,7 @@ func TestPipelineRun_Validate(t *testing.T) {
 						Name: "echoitfinally",
 						Workspaces: []v1.WorkspacePipelineTaskBinding{{
 							Name:    "ws",
-							SubPath: "/foo",
+							SubPath: "foo",
 						}},
 						TaskSpec: &v1.EmbeddedTask{TaskSpec: v1.TaskSpec{
 							Workspaces: []v1.WorkspaceDeclaration{{
@@ -1119,6 +1120,48 @@ func TestPipelineRunSpec_Invalidate(t *testing.T) {
 		},
 		withContext: cfgtesting.EnableStableAPIFields,
 		wantErr:     apis.ErrGeneric("computeResources requires \"enable-api-fields\" feature gate to be \"alpha\" or \"beta\" but it is \"stable\"").ViaIndex(0).ViaField("taskRunSpecs"),
+	}, {
+		name: "duplicate taskRunSpecs for a pipelineTask",
+		spec: v1.PipelineRunSpec{
+			PipelineRef: &v1.PipelineRef{Name: "foo"},
+			TaskRunSpecs: []v1.PipelineTaskRunSpec{
+				{PipelineTaskName: "deploy-*"},
+				{PipelineTaskName: "bar"},
+				{PipelineTaskName: "deploy-*"},
+				{PipelineTaskName: "bar"},
+			},
+		},
+		wantErr: apis.ErrGeneric(`taskRunSpec for pipelineTask "bar" provided by pipelinerun more than once, at index 1 and 3`, "taskRunSpecs[3].pipelineTaskName"),
+	}, {
+		name: "invalid taskRunSpecs pattern",
+		spec: v1.PipelineRunSpec{
+			PipelineRef: &v1.PipelineRef{Name: "foo"},
+			TaskRunSpecs: []v1.PipelineTaskRunSpec{
+				{PipelineTaskName: "deploy-[a"},
+			},
+		},
+		wantErr: apis.ErrInvalidValue(`"deploy-[a" is not a valid glob pattern: syntax error in pattern`, "taskRunSpecs[0].pipelineTaskName"),
+	}, {
+		name: "param taken from a PipelineRun result without a result name",
+		spec: v1.PipelineRunSpec{
+			PipelineRef: &v1.PipelineRef{Name: "foo"},
+			Params: v1.Params{{
+				Name:   "digest",
+				RunRef: &v1.PipelineRunResultRef{Name: "build-run"},
+			}},
+		},
+		wantErr: apis.ErrMissingField("params[digest].runRef.result"),
+	}, {
+		name: "param with both a value and a PipelineRun result",
+		spec: v1.PipelineRunSpec{
+			PipelineRef: &v1.PipelineRef{Name: "foo"},
+			Params: v1.Params{{
+				Name:   "digest",
+				Value:  *v1.NewStructuredValues("sha256:abc"),
+				RunRef: &v1.PipelineRunResultRef{Name: "build-run", Result: "digest"},
+			}},
+		},
+		wantErr: apis.ErrMultipleOneOf("params[digest].value", "params[digest].runRef"),
 	}}
 
 	for _, ps := range tests {
@@ -1190,6 +1233,30 @@ func TestPipelineRunSpec_Validate(t *testing.T) {
 			}},
 		},
 		withContext: cfgtesting.EnableBetaAPIFields,
+	}, {
+		name: "param taken from a PipelineRun result",
+		spec: v1.PipelineRunSpec{
+			PipelineRef: &v1.PipelineRef{Name: "pipeline"},
+			Params: v1.Params{{
+				Name:   "digest",
+				Value:  v1.ParamValue{Type: v1.ParamTypeString},
+				RunRef: &v1.PipelineRunResultRef{Name: "build-run", Result: "digest"},
+			}},
+		},
+	}, {
+		name: "pending PipelineRun defers the validation of its param values",
+		spec: v1.PipelineRunSpec{
+			Status: v1.PipelineRunSpecStatusPending,
+			PipelineSpec: &v1.PipelineSpec{
+				Params: v1.ParamSpecs{{Name: "version", Type: v1.ParamTypeString, Pattern: "^v[0-9]+$"}},
+				Tasks: []v1.PipelineTask{{
+					Name:    "mytask",
+					TaskRef: &v1.TaskRef{Name: "mytask"},
+					Params:  v1.Params{{Name: "version", Value: *v1.NewStructuredValues("$(params.version)")}},
+				}},
+			},
+			Params: v1.Params{{Name: "version", Value: *v1.NewStructuredValues("to-be-patched")}},
+		},
 	}}
 
 	for _, ps := range tests {
@@ -1205,6 +1272,148 @@ func TestPipelineRunSpec_Validate(t *testing.T) {
 	}
 }
 
+func TestPipelineRun_Validate_RunRefResultTypes(t *testing.T) {
+	resultType := func(ctx context.Context, namespace string, ref v1.PipelineRunResultRef) (v1.ParamType, bool) {
+		if namespace != "ns" || ref.Name != "build-run" {
+			return "", false
+		}
+		switch ref.Result {
+		case "digest":
+			return v1.ParamTypeString, true
+		case "tags":
+			return v1.ParamTypeArray, true
+		}
+		return "", false
+	}
+	pipelineRun := func(result string) *v1.PipelineRun {
+		return &v1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{Name: "promote-run", Namespace: "ns"},
+			Spec: v1.PipelineRunSpec{
+				PipelineSpec: &v1.PipelineSpec{
+					Params: v1.ParamSpecs{{Name: "tags", Type: v1.ParamTypeArray}},
+					Tasks: []v1.PipelineTask{{
+						Name:    "promote",
+						TaskRef: &v1.TaskRef{Name: "promote"},
+						Params:  v1.Params{{Name: "tags", Value: *v1.NewStructuredValues("$(params.tags[*])")}},
+					}},
+				},
+				Params: v1.Params{{
+					Name:   "tags",
+					Value:  v1.ParamValue{Type: v1.ParamTypeString},
+					RunRef: &v1.PipelineRunResultRef{Name: "build-run", Result: result},
+				}},
+			},
+		}
+	}
+	for _, tc := range []struct {
+		name    string
+		pr      *v1.PipelineRun
+		wantErr *apis.FieldError
+	}{{
+		name: "result of the type of the param",
+		pr:   pipelineRun("tags"),
+	}, {
+		name:    "result of another type than the param",
+		pr:      pipelineRun("digest"),
+		wantErr: apis.ErrInvalidValue(`result "digest" of PipelineRun "build-run" is of type "string", while the param is of type "array"`, "spec.params[tags].runRef.result"),
+	}, {
+		name: "result not found yet",
+		pr:   pipelineRun("labels"),
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := apis.WithinCreate(v1.WithRunRefResultType(t.Context(), resultType))
+			err := tc.pr.Validate(ctx)
+			if d := cmp.Diff(tc.wantErr.Error(), err.Error()); d != "" {
+				t.Error(diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestPipelineRunSpec_ParamsWithRunTimeReferences(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		value    v1.ParamValue
+		wantRefs []string
+	}{{
+		name:     "task result",
+		value:    *v1.NewStructuredValues("$(tasks.build.results.digest)"),
+		wantRefs: []string{"tasks.build.results.digest"},
+	}, {
+		name:     "finally task result in a string",
+		value:    *v1.NewStructuredValues("image@$(finally.report.results.id)"),
+		wantRefs: []string{"finally.report.results.id"},
+	}, {
+		name:     "indexed task result",
+		value:    *v1.NewStructuredValues("$(tasks.build.results.images[0])"),
+		wantRefs: []string{"tasks.build.results.images[0]"},
+	}, {
+		name:     "object task result property",
+		value:    *v1.NewStructuredValues("$(tasks.build.results.image.url)"),
+		wantRefs: []string{"tasks.build.results.image.url"},
+	}, {
+		name:     "step result",
+		value:    *v1.NewStructuredValues("$(steps.build.results.digest)"),
+		wantRefs: []string{"steps.build.results.digest"},
+	}, {
+		name:     "task execution status and reason",
+		value:    *v1.NewStructuredValues("$(tasks.build.status)", "$(tasks.build.reason)"),
+		wantRefs: []string{"tasks.build.status", "tasks.build.reason"},
+	}, {
+		name:     "aggregate execution status",
+		value:    *v1.NewStructuredValues("$(tasks.status)"),
+		wantRefs: []string{"tasks.status"},
+	}, {
+		name:     "task result in an object",
+		value:    *v1.NewObject(map[string]string{"url": "$(tasks.build.results.url)", "digest": "$(tasks.build.results.digest)"}),
+		wantRefs: []string{"tasks.build.results.digest", "tasks.build.results.url"},
+	}, {
+		name:     "escaped task result",
+		value:    *v1.NewStructuredValues("$$(tasks.build.results.digest)"),
+		wantRefs: []string{"tasks.build.results.digest"},
+	}, {
+		name:     "escaped task status",
+		value:    *v1.NewStructuredValues("echo $$(tasks.build.status)"),
+		wantRefs: []string{"tasks.build.status"},
+	}, {
+		name:  "task result without substitution",
+		value: *v1.NewStructuredValues("tasks.build.results.digest"),
+	}, {
+		name:  "task results without a result name",
+		value: *v1.NewStructuredValues("$(tasks.build.results)"),
+	}, {
+		name:  "param named like a task result",
+		value: *v1.NewStructuredValues("$(params.tasks.build.results.digest)"),
+	}, {
+		name:  "context variable",
+		value: *v1.NewStructuredValues("$(context.pipelineRun.name)"),
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			spec := v1.PipelineRunSpec{
+				PipelineRef: &v1.PipelineRef{Name: "pipeline"},
+				Params:      v1.Params{{Name: "image", Value: tc.value}},
+			}
+			err := spec.Validate(t.Context())
+			if tc.wantRefs == nil {
+				if err != nil {
+					t.Errorf("Validate() = %v, want no error", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("Validate() succeeded, want an error")
+			}
+			want := fmt.Sprintf("cannot use result expressions in %v as PipelineRun parameter values", tc.wantRefs)
+			if !strings.Contains(err.Error(), want) || !strings.Contains(err.Error(), "runRef") {
+				t.Errorf("Validate() = %v, want an error containing %q and the use of runRef", err, want)
+			}
+			if !strings.HasSuffix(err.Error(), ": params[image].value") {
+				t.Errorf("Validate() = %v, want an error on params[image].value", err)
+			}
+		})
+	}
+}
+
 func TestPipelineRun_InvalidTimeouts(t *testing.T) {
 	tests := []struct {
 		name string
@@ -1226,6 +1435,20 @@ func TestPipelineRun_InvalidTimeouts(t *testing.T) {
 			},
 		},
 		want: apis.ErrInvalidValue("-48h0m0s should be >= 0", "spec.timeouts.pipeline"),
+	}, {
+		name: "negative ttlSecondsAfterFinished",
+		pr: v1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "pipelinelinename",
+			},
+			Spec: v1.PipelineRunSpec{
+				PipelineRef: &v1.PipelineRef{
+					Name: "prname",
+				},
+				TTLSecondsAfterFinished: ptr.Int32(-1),
+			},
+		},
+		want: apis.ErrInvalidValue("-1 should be >= 0", "spec.ttlSecondsAfterFinished"),
 	}, {
 		name: "negative task-specific timeout",
 		pr: v1.PipelineRun{
@@ -1727,6 +1950,22 @@ func TestPipelineRunSpec_ValidateUpdate(t *testing.T) {
 				Message: `invalid value: Once the PipelineRun has started, only status updates are allowed`,
 				Paths:   []string{""},
 			},
+		}, {
+			name: "is update ctx, baseline is pending, status changes from PipelineRunPending to Empty, and params changes",
+			baselinePipelineRun: &v1.PipelineRun{
+				Spec: v1.PipelineRunSpec{
+					Status: "PipelineRunPending",
+				},
+			},
+			pipelineRun: &v1.PipelineRun{
+				Spec: v1.PipelineRunSpec{
+					Status: "",
+					Params: v1.Params{{Name: "notify-url", Value: *v1.NewStructuredValues("https://example.com")}},
+				},
+			},
+			isCreate:      false,
+			isUpdate:      true,
+			expectedError: apis.FieldError{},
 		}, {
 			name: "is update ctx, baseline is done, status changes",
 			baselinePipelineRun: &v1.PipelineRun{
@@ -1941,10 +2180,11 @@ func TestPipelineRunSpec_ValidateUpdate_FinalizerChanges(t *testing.T) {
 
 func TestPipelineRunTaskRunSpecTimeout_Validate(t *testing.T) {
 	tests := []struct {
-		name        string
-		spec        v1.PipelineRunSpec
-		wantErr     bool
-		expectedErr string
+		name                  string
+		spec                  v1.PipelineRunSpec
+		maximumTaskRunTimeout time.Duration
+		wantErr               bool
+		expectedErr           string
 	}{{
 		name: "taskRunSpec timeout within pipeline timeout",
 		spec: v1.PipelineRunSpec{
@@ -2022,14 +2262,130 @@ func TestPipelineRunTaskRunSpecTimeout_Validate(t *testing.T) {
 		},
 		wantErr:     true,
 		expectedErr: "taskRunSpecs[0].timeout",
+	}, {
+		name: "taskRunSpec timeout exceeds pipeline timeout left by finally timeout",
+		spec: v1.PipelineRunSpec{
+			PipelineSpec: &v1.PipelineSpec{
+				Tasks:   []v1.PipelineTask{{Name: "task1", TaskRef: &v1.TaskRef{Name: "foo"}}},
+				Finally: []v1.PipelineTask{{Name: "final1", TaskRef: &v1.TaskRef{Name: "foo"}}},
+			},
+			Timeouts: &v1.TimeoutFields{
+				Pipeline: &metav1.Duration{Duration: 1 * time.Hour},
+				Finally:  &metav1.Duration{Duration: 20 * time.Minute},
+			},
+			TaskRunSpecs: []v1.PipelineTaskRunSpec{{
+				PipelineTaskName: "task1",
+				Timeout:          &metav1.Duration{Duration: 45 * time.Minute},
+			}},
+		},
+		wantErr:     true,
+		expectedErr: "45m0s should be <= pipeline tasks duration 40m0s: taskRunSpecs[0].timeout",
+	}, {
+		// The finally tasks of a referenced Pipeline aren't known, so final1 may be one of them
+		name: "taskRunSpec timeout of a referenced Pipeline within pipeline timeout",
+		spec: v1.PipelineRunSpec{
+			PipelineRef: &v1.PipelineRef{Name: "test"},
+			Timeouts: &v1.TimeoutFields{
+				Pipeline: &metav1.Duration{Duration: 1 * time.Hour},
+				Finally:  &metav1.Duration{Duration: 50 * time.Minute},
+			},
+			TaskRunSpecs: []v1.PipelineTaskRunSpec{{
+				PipelineTaskName: "final1",
+				Timeout:          &metav1.Duration{Duration: 30 * time.Minute},
+			}},
+		},
+		wantErr: false,
+	}, {
+		name: "taskRunSpec timeout of a referenced Pipeline exceeds pipeline timeout",
+		spec: v1.PipelineRunSpec{
+			PipelineRef: &v1.PipelineRef{Name: "test"},
+			Timeouts: &v1.TimeoutFields{
+				Pipeline: &metav1.Duration{Duration: 1 * time.Hour},
+				Finally:  &metav1.Duration{Duration: 50 * time.Minute},
+			},
+			TaskRunSpecs: []v1.PipelineTaskRunSpec{{
+				PipelineTaskName: "final1",
+				Timeout:          &metav1.Duration{Duration: 90 * time.Minute},
+			}},
+		},
+		wantErr:     true,
+		expectedErr: "1h30m0s should be <= pipeline duration 1h0m0s: taskRunSpecs[0].timeout",
+	}, {
+		name: "finally taskRunSpec timeout within finally timeout",
+		spec: v1.PipelineRunSpec{
+			PipelineSpec: &v1.PipelineSpec{
+				Tasks:   []v1.PipelineTask{{Name: "task1", TaskRef: &v1.TaskRef{Name: "foo"}}},
+				Finally: []v1.PipelineTask{{Name: "final1", TaskRef: &v1.TaskRef{Name: "foo"}}},
+			},
+			Timeouts: &v1.TimeoutFields{
+				Pipeline: &metav1.Duration{Duration: 1 * time.Hour},
+				Tasks:    &metav1.Duration{Duration: 10 * time.Minute},
+				Finally:  &metav1.Duration{Duration: 50 * time.Minute},
+			},
+			TaskRunSpecs: []v1.PipelineTaskRunSpec{{
+				PipelineTaskName: "final1",
+				Timeout:          &metav1.Duration{Duration: 30 * time.Minute},
+			}},
+		},
+		wantErr: false,
+	}, {
+		name: "finally taskRunSpec timeout exceeds finally timeout",
+		spec: v1.PipelineRunSpec{
+			PipelineSpec: &v1.PipelineSpec{
+				Tasks:   []v1.PipelineTask{{Name: "task1", TaskRef: &v1.TaskRef{Name: "foo"}}},
+				Finally: []v1.PipelineTask{{Name: "final1", TaskRef: &v1.TaskRef{Name: "foo"}}},
+			},
+			Timeouts: &v1.TimeoutFields{
+				Pipeline: &metav1.Duration{Duration: 1 * time.Hour},
+				Tasks:    &metav1.Duration{Duration: 50 * time.Minute},
+				Finally:  &metav1.Duration{Duration: 10 * time.Minute},
+			},
+			TaskRunSpecs: []v1.PipelineTaskRunSpec{{
+				PipelineTaskName: "final1",
+				Timeout:          &metav1.Duration{Duration: 30 * time.Minute},
+			}},
+		},
+		wantErr:     true,
+		expectedErr: "30m0s should be <= pipeline finally duration 10m0s: taskRunSpecs[0].timeout",
+	}, {
+		name: "taskRunSpec timeout within maximum taskrun timeout and pipeline timeout above it",
+		spec: v1.PipelineRunSpec{
+			PipelineRef: &v1.PipelineRef{Name: "test"},
+			Timeouts: &v1.TimeoutFields{
+				Pipeline: &metav1.Duration{Duration: 3 * time.Hour},
+			},
+			TaskRunSpecs: []v1.PipelineTaskRunSpec{{
+				PipelineTaskName: "task1",
+				Timeout:          &metav1.Duration{Duration: 1 * time.Hour},
+			}},
+		},
+		maximumTaskRunTimeout: time.Hour,
+		wantErr:               false,
+	}, {
+		name: "taskRunSpec timeout exceeds maximum taskrun timeout",
+		spec: v1.PipelineRunSpec{
+			PipelineRef: &v1.PipelineRef{Name: "test"},
+			Timeouts: &v1.TimeoutFields{
+				Pipeline: &metav1.Duration{Duration: 3 * time.Hour},
+			},
+			TaskRunSpecs: []v1.PipelineTaskRunSpec{{
+				PipelineTaskName: "task1",
+				Timeout:          &metav1.Duration{Duration: 2 * time.Hour},
+			}},
+		},
+		maximumTaskRunTimeout: time.Hour,
+		wantErr:               true,
+		expectedErr:           "2h0m0s should be <= maximum TaskRun duration 1h0m0s: taskRunSpecs[0].timeout",
 	}}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			ctx := config.ToContext(t.Context(), &config.Config{
 				Defaults: &config.Defaults{
-					DefaultTimeoutMinutes: 60,
+					DefaultTimeoutMinutes:        60,
+					DefaultMaximumTaskRunTimeout: tt.maximumTaskRunTimeout,
 				},
+				FeatureFlags: config.DefaultFeatureFlags.DeepCopy(),
 			})
 			err := tt.spec.Validate(ctx)
 			if tt.wantErr {
@@ -2044,3 +2400,46 @@ func TestPipelineRunTaskRunSpecTimeout_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestPipelineRun_Validate_Annotations(t *testing.T) {
+	tests := []struct {
+		name            string
+		annotations     map[string]string
+		expectedError   string
+		expectedWarning string
+	}{{
+		name:        "registered annotation",
+		annotations: map[string]string{"pipeline.tekton.dev/priority": "high"},
+	}, {
+		name:          "registered annotation with an invalid value",
+		annotations:   map[string]string{"pipeline.tekton.dev/priority": "urgent"},
+		expectedError: `invalid value: urgent: metadata.annotations[pipeline.tekton.dev/priority]` + "\n" + `"urgent" must be one of high`,
+	}, {
+		name:            "unknown annotation under tekton.dev",
+		annotations:     map[string]string{"tekton.dev/priority": "true"},
+		expectedWarning: `unknown annotation with a prefix reserved for Tekton Pipelines: metadata.annotations[tekton.dev/priority]`,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pr := &v1.PipelineRun{
+				ObjectMeta: metav1.ObjectMeta{Name: "pipelinerun"},
+				Spec:       v1.PipelineRunSpec{PipelineRef: &v1.PipelineRef{Name: "pipeline"}},
+			}
+			pr.Annotations = tt.annotations
+			errs := pr.Validate(t.Context())
+			gotError, gotWarning := "", ""
+			if err := errs.Filter(apis.ErrorLevel); err != nil {
+				gotError = err.Error()
+			}
+			if warning := errs.Filter(apis.WarningLevel); warning != nil {
+				gotWarning = warning.Error()
+			}
+			if d := cmp.Diff(tt.expectedError, gotError); d != "" {
+				t.Errorf("PipelineRun.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+			if d := cmp.Diff(tt.expectedWarning, gotWarning); d != "" {
+				t.Errorf("PipelineRun.Validate() warnings diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
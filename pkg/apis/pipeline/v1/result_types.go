@@ -72,6 +72,11 @@ type TaskRunResult struct {
 	// +kubebuilder:pruning:PreserveUnknownFields
 	// +kubebuilder:validation:Schemaless
 	Value ResultValue `json:"value"`
+
+	// ProducedBy is the name of the Step which produced the result, if known. When several
+	// Steps write the same result, the last one wins and is recorded here.
+	// +optional
+	ProducedBy string `json:"producedBy,omitempty"`
 }
 
 // TaskRunStepResult is a type alias of TaskRunResult
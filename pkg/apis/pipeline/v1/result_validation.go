@@ -17,6 +17,7 @@ import (
 	"context"
 	"fmt"
 	"regexp"
+	"strings"
 
 	"k8s.io/apimachinery/pkg/util/validation"
 	"knative.dev/pkg/apis"
@@ -82,6 +83,9 @@ func (tr TaskResult) validateValue(ctx context.Context) (errs *apis.FieldError)
 		}
 	}
 	if tr.Value.StringVal != "" {
+		if strings.HasPrefix(tr.Value.StringVal, "$(sidecars.") {
+			return tr.validateSidecarResultValue()
+		}
 		stepName, resultName, err := ExtractStepResultName(tr.Value.StringVal)
 		if err != nil {
 			return &apis.FieldError{
@@ -107,6 +111,32 @@ func (tr TaskResult) validateValue(ctx context.Context) (errs *apis.FieldError)
 	return errs
 }
 
+// validateSidecarResultValue validates a value of format $(sidecars.<sidecarName>.results.<resultName>).
+func (tr TaskResult) validateSidecarResultValue() (errs *apis.FieldError) {
+	sidecarName, resultName, err := ExtractSidecarResultName(tr.Value.StringVal)
+	if err != nil {
+		return &apis.FieldError{
+			Message: fmt.Sprintf("%v", err),
+			Paths:   []string{tr.Name + ".value"},
+		}
+	}
+	if e := validation.IsDNS1123Label(sidecarName); len(e) > 0 {
+		errs = errs.Also(&apis.FieldError{
+			Message: fmt.Sprintf("invalid extracted sidecar name %q", sidecarName),
+			Paths:   []string{tr.Name + ".value"},
+			Details: "sidecarName in $(sidecars.<sidecarName>.results.<resultName>) must be a valid DNS Label, For more info refer to https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names",
+		})
+	}
+	if !resultNameFormatRegex.MatchString(resultName) {
+		errs = errs.Also(&apis.FieldError{
+			Message: fmt.Sprintf("invalid extracted result name %q", resultName),
+			Paths:   []string{tr.Name + ".value"},
+			Details: fmt.Sprintf("resultName in $(sidecars.<sidecarName>.results.<resultName>) must consist of alphanumeric characters, '-', '_', and must start and end with an alphanumeric character (e.g. 'MyName',  or 'my-name',  or 'my_name', regex used for validation is '%s')", ResultNameFormat),
+		})
+	}
+	return errs
+}
+
 // Validate implements apis.Validatable
 func (sr StepResult) Validate(ctx context.Context) (errs *apis.FieldError) {
 	if !resultNameFormatRegex.MatchString(sr.Name) {
@@ -163,3 +193,15 @@ func ExtractStepResultName(value string) (string, string, error) {
 	}
 	return rs[1], rs[2], nil
 }
+
+// ExtractSidecarResultName extracts the sidecar name and result name from a string matching
+// format $(sidecars.<sidecarName>.results.<resultName>).
+// If a match is not found, an error is returned.
+func ExtractSidecarResultName(value string) (string, string, error) {
+	re := regexp.MustCompile(`^\$\(sidecars\.(.*?)\.results\.(.*?)\)$`)
+	rs := re.FindStringSubmatch(value)
+	if len(rs) != 3 {
+		return "", "", fmt.Errorf("Could not extract sidecar name and result name. Expected value to look like $(sidecars.<sidecarName>.results.<resultName>) but got \"%v\"", value)
+	}
+	return rs[1], rs[2], nil
+}
@@ -133,7 +133,18 @@ func PipelineTaskResultRefs(pt *PipelineTask) []*ResultRef {
 		expressions, _ := whenExpression.GetVarSubstitutionExpressions()
 		refs = append(refs, NewResultRefs(expressions)...)
 	}
+	for _, ws := range pt.Workspaces {
+		refs = append(refs, NewResultRefs(validateString(ws.SubPath))...)
+	}
 	taskSubExpressions := pt.GetVarSubstitutionExpressions()
 	refs = append(refs, NewResultRefs(taskSubExpressions)...)
+	refs = append(refs, NewResultRefsFromString(pt.RetriesFrom)...)
+	refs = append(refs, NewResultRefsFromString(pt.TimeoutFrom)...)
 	return refs
 }
+
+// NewResultRefsFromString extracts the result references from a string field of a
+// PipelineTask, such as retriesFrom or timeoutFrom.
+func NewResultRefsFromString(value string) []*ResultRef {
+	return NewResultRefs(validateString(value))
+}
@@ -886,6 +886,10 @@ func TestPipelineTaskResultRefs(t *testing.T) {
 				},
 			},
 		},
+		Workspaces: []v1.WorkspacePipelineTaskBinding{{
+			Name:    "source",
+			SubPath: "$(params.dir)/$(tasks.pt15.results.r15)",
+		}},
 	}
 	refs := v1.PipelineTaskResultRefs(&pt)
 	expectedRefs := []*v1.ResultRef{{
@@ -936,6 +940,9 @@ func TestPipelineTaskResultRefs(t *testing.T) {
 	}, {
 		PipelineTask: "pt14",
 		Result:       "r14",
+	}, {
+		PipelineTask: "pt15",
+		Result:       "r15",
 	}}
 	if d := cmp.Diff(refs, expectedRefs, cmpopts.SortSlices(lessResultRef)); d != "" {
 		t.Errorf("%v", d)
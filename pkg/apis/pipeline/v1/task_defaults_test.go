@@ -83,6 +83,42 @@ func TestTask_SetDefaults(t *testing.T) {
 				}},
 			},
 		},
+	}, {
+		name: "unnamed steps are named after their index",
+		in: &v1.Task{
+			Spec: v1.TaskSpec{
+				Steps: []v1.Step{{}, {Name: "foo"}, {}},
+			},
+		},
+		want: &v1.Task{
+			Spec: v1.TaskSpec{
+				Steps: []v1.Step{{Name: "unnamed-0"}, {Name: "foo"}, {Name: "unnamed-2"}},
+			},
+		},
+	}, {
+		name: "unnamed step names don't collide with step names",
+		in: &v1.Task{
+			Spec: v1.TaskSpec{
+				Steps: []v1.Step{{}, {Name: "unnamed-0"}, {Name: "unnamed-2"}, {}, {Name: "unnamed-2-1"}},
+			},
+		},
+		want: &v1.Task{
+			Spec: v1.TaskSpec{
+				Steps: []v1.Step{{Name: "unnamed-0-1"}, {Name: "unnamed-0"}, {Name: "unnamed-2"}, {Name: "unnamed-3"}, {Name: "unnamed-2-1"}},
+			},
+		},
+	}, {
+		name: "defaulted step names are stable",
+		in: &v1.Task{
+			Spec: v1.TaskSpec{
+				Steps: []v1.Step{{Name: "unnamed-0-1"}, {Name: "unnamed-0"}},
+			},
+		},
+		want: &v1.Task{
+			Spec: v1.TaskSpec{
+				Steps: []v1.Step{{Name: "unnamed-0-1"}, {Name: "unnamed-0"}},
+			},
+		},
 	}}
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
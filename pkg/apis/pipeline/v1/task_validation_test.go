@@ -17,7 +17,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -27,6 +29,7 @@ import (
 	"github.com/tektoncd/pipeline/test/diff"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/utils/pointer"
 	"knative.dev/pkg/apis"
@@ -301,7 +304,7 @@ func TestTaskSpecValidate(t *testing.T) {
 		name: "valid results path variable in script",
 		fields: fields{
 			Steps: []v1.Step{{
-				Name:  "step-name",
+				Name:  "mystep",
 				Image: "my-image",
 				Script: `
 				#!/usr/bin/env bash
@@ -847,7 +850,7 @@ func TestTaskSpecValidateError(t *testing.T) {
 		name: "step script refers to nonexistent result",
 		fields: fields{
 			Steps: []v1.Step{{
-				Name:  "step-name",
+				Name:  "mystep",
 				Image: "my-image",
 				Script: `
 				#!/usr/bin/env bash
@@ -2110,6 +2113,117 @@ func TestParamEnum_Failure(t *testing.T) {
 	}
 }
 
+func TestParamConstraints_Success(t *testing.T) {
+	tcs := []struct {
+		name   string
+		params v1.ParamSpecs
+	}{{
+		name: "pattern, minLength and maxLength",
+		params: []v1.ParamSpec{{
+			Name:      "param1",
+			Type:      v1.ParamTypeString,
+			Pattern:   "^v[0-9]+$",
+			MinLength: 2,
+			MaxLength: 4,
+			Default:   v1.NewStructuredValues("v12"),
+		}},
+	}, {
+		name: "minLength without maxLength",
+		params: []v1.ParamSpec{{
+			Name:      "param1",
+			Type:      v1.ParamTypeString,
+			MinLength: 8,
+		}},
+	}, {
+		name: "default referencing a variable",
+		params: []v1.ParamSpec{{
+			Name:      "param1",
+			Type:      v1.ParamTypeString,
+			MaxLength: 4,
+			Default:   v1.NewStructuredValues("$(context.taskRun.name)"),
+		}},
+	}}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := v1.ValidateParameterVariables(t.Context(), []v1.Step{{Image: "foo"}}, tc.params); err != nil {
+				t.Errorf("No error expected from ValidateParameterVariables() but got = %v", err)
+			}
+		})
+	}
+}
+
+func TestParamConstraints_Failure(t *testing.T) {
+	tcs := []struct {
+		name        string
+		params      v1.ParamSpecs
+		expectedErr error
+	}{{
+		name: "invalid regular expression",
+		params: []v1.ParamSpec{{
+			Name:    "param1",
+			Type:    v1.ParamTypeString,
+			Pattern: "v[0-9",
+		}},
+		expectedErr: errors.New("invalid value: \"v[0-9\" is not a valid RE2 regular expression: error parsing regexp: missing closing ]: `[0-9`: params[param1].pattern"),
+	}, {
+		name: "negative minLength",
+		params: []v1.ParamSpec{{
+			Name:      "param1",
+			Type:      v1.ParamTypeString,
+			MinLength: -1,
+		}},
+		expectedErr: errors.New("invalid value: -1 should be >= 0: params[param1].minLength"),
+	}, {
+		name: "minLength greater than maxLength",
+		params: []v1.ParamSpec{{
+			Name:      "param1",
+			Type:      v1.ParamTypeString,
+			MinLength: 5,
+			MaxLength: 4,
+		}},
+		expectedErr: errors.New("invalid value: 5 should be <= maxLength 4: params[param1].minLength"),
+	}, {
+		name: "constraints with array type",
+		params: []v1.ParamSpec{{
+			Name:      "param1",
+			Type:      v1.ParamTypeArray,
+			MaxLength: 4,
+		}},
+		expectedErr: errors.New("pattern, minLength and maxLength can only be set with string type param: params[param1]"),
+	}, {
+		name: "default value not matching the pattern",
+		params: []v1.ParamSpec{{
+			Name:    "param1",
+			Type:    v1.ParamTypeString,
+			Pattern: "^v[0-9]+$",
+			Default: v1.NewStructuredValues("latest"),
+		}},
+		expectedErr: errors.New("invalid value: param \"param1\" value \"latest\" does not match its pattern \"^v[0-9]+$\": params[param1].default"),
+	}, {
+		name: "default value longer than maxLength",
+		params: []v1.ParamSpec{{
+			Name:      "param1",
+			Type:      v1.ParamTypeString,
+			MaxLength: 4,
+			Default:   v1.NewStructuredValues("v1234"),
+		}},
+		expectedErr: errors.New("invalid value: param \"param1\" value \"v1234\" is longer than its maxLength 4: params[param1].default"),
+	}}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			err := v1.ValidateParameterVariables(t.Context(), []v1.Step{{Image: "foo"}}, tc.params)
+			if err == nil {
+				t.Fatalf("Expected error from ValidateParameterVariables() = %v, but got none", tc.expectedErr)
+			}
+			if d := cmp.Diff(tc.expectedErr.Error(), err.Error()); d != "" {
+				t.Errorf("Returned error from ValidateParameterVariables() does not match with the expected error: %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 func TestTaskSpecValidate_StepResults(t *testing.T) {
 	type fields struct {
 		Image   string
@@ -2298,3 +2412,640 @@ func TestTaskSpecValidate_StepWhen_Error(t *testing.T) {
 		})
 	}
 }
+
+func TestTaskSpecValidate_ExpectedDuration(t *testing.T) {
+	tests := []struct {
+		name             string
+		expectedDuration *metav1.Duration
+		enableAlpha      bool
+		expectedError    *apis.FieldError
+	}{{
+		name:             "valid expected duration",
+		expectedDuration: &metav1.Duration{Duration: 5 * time.Minute},
+		enableAlpha:      true,
+	}, {
+		name:             "expected duration requires alpha",
+		expectedDuration: &metav1.Duration{Duration: 5 * time.Minute},
+		expectedError: &apis.FieldError{
+			Message: `expectedDuration requires "enable-api-fields" feature gate to be "alpha" but it is "beta"`,
+		},
+	}, {
+		name:             "zero expected duration",
+		expectedDuration: &metav1.Duration{},
+		enableAlpha:      true,
+		expectedError: &apis.FieldError{
+			Message: `invalid value: 0s`,
+			Paths:   []string{"expectedDuration"},
+			Details: "expectedDuration must be greater than 0",
+		},
+	}, {
+		name:             "negative expected duration",
+		expectedDuration: &metav1.Duration{Duration: -time.Minute},
+		enableAlpha:      true,
+		expectedError: &apis.FieldError{
+			Message: `invalid value: -1m0s`,
+			Paths:   []string{"expectedDuration"},
+			Details: "expectedDuration must be greater than 0",
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &v1.TaskSpec{
+				Steps:            validSteps,
+				ExpectedDuration: tt.expectedDuration,
+			}
+			ctx := t.Context()
+			if tt.enableAlpha {
+				ctx = cfgtesting.EnableAlphaAPIFields(ctx)
+			}
+			ts.SetDefaults(ctx)
+			err := ts.Validate(ctx)
+			if tt.expectedError == nil {
+				if err != nil {
+					t.Errorf("TaskSpec.Validate() = %v", err)
+				}
+				return
+			}
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error()); d != "" {
+				t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestTaskSpecValidate_ExpectedArtifactDigests(t *testing.T) {
+	tests := []struct {
+		name            string
+		digests         []v1.ExpectedArtifactDigest
+		enableArtifacts bool
+		expectedError   *apis.FieldError
+	}{{
+		name:            "valid expected digests",
+		digests:         []v1.ExpectedArtifactDigest{{Name: "image", Digest: "sha256:$(params.digest)"}, {Name: "sbom", Digest: "sha1:95588b8f"}},
+		enableArtifacts: true,
+	}, {
+		name:    "expected digests require artifacts",
+		digests: []v1.ExpectedArtifactDigest{{Name: "image", Digest: "sha256:abc"}},
+		expectedError: &apis.FieldError{
+			Message: "feature flag enable-artifacts should be set to true to use artifacts feature.",
+			Paths:   []string{"expectedArtifactDigests"},
+		},
+	}, {
+		name:            "missing name and digest",
+		digests:         []v1.ExpectedArtifactDigest{{}},
+		enableArtifacts: true,
+		expectedError: &apis.FieldError{
+			Message: "missing field(s)",
+			Paths:   []string{"expectedArtifactDigests[0].digest", "expectedArtifactDigests[0].name"},
+		},
+	}, {
+		name:            "duplicate name",
+		digests:         []v1.ExpectedArtifactDigest{{Name: "image", Digest: "sha256:abc"}, {Name: "image", Digest: "sha256:def"}},
+		enableArtifacts: true,
+		expectedError: &apis.FieldError{
+			Message: "expected exactly one, got both",
+			Paths:   []string{"expectedArtifactDigests[1].name"},
+		},
+	}, {
+		name:            "digest without algorithm",
+		digests:         []v1.ExpectedArtifactDigest{{Name: "image", Digest: "abc"}},
+		enableArtifacts: true,
+		expectedError: &apis.FieldError{
+			Message: "invalid value: abc",
+			Paths:   []string{"expectedArtifactDigests[0].digest"},
+			Details: `digest must be in the "<algorithm>:<value>" format`,
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &v1.TaskSpec{
+				Steps:                   validSteps,
+				ExpectedArtifactDigests: tt.digests,
+			}
+			ctx := config.ToContext(t.Context(), &config.Config{
+				FeatureFlags: &config.FeatureFlags{EnableArtifacts: tt.enableArtifacts},
+			})
+			ts.SetDefaults(ctx)
+			err := ts.Validate(ctx)
+			if tt.expectedError == nil {
+				if err != nil {
+					t.Errorf("TaskSpec.Validate() = %v", err)
+				}
+				return
+			}
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error()); d != "" {
+				t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestTaskSpecValidate_ShadowedResultNames(t *testing.T) {
+	tests := []struct {
+		name            string
+		results         []v1.TaskResult
+		expectedWarning string
+	}{{
+		name:    "no task result of the same name",
+		results: []v1.TaskResult{{Name: "url", Type: v1.ResultsTypeString}},
+	}, {
+		name: "task result fetched from the step result",
+		results: []v1.TaskResult{{
+			Name:  "digest",
+			Type:  v1.ResultsTypeString,
+			Value: v1.NewStructuredValues("$(steps.build.results.digest)"),
+		}},
+	}, {
+		name:            "task result written by a step",
+		results:         []v1.TaskResult{{Name: "digest", Type: v1.ResultsTypeString}},
+		expectedWarning: `step result "digest" shadows the task result of the same name: $(steps.build.results.digest) refers to the step result and $(results.digest) to the task result: steps[0].results[0].name`,
+	}, {
+		name: "task result fetched from another step",
+		results: []v1.TaskResult{{
+			Name:  "digest",
+			Type:  v1.ResultsTypeString,
+			Value: v1.NewStructuredValues("$(steps.push.results.image)"),
+		}},
+		expectedWarning: `step result "digest" shadows the task result of the same name: $(steps.build.results.digest) refers to the step result and $(results.digest) to the task result: steps[0].results[0].name`,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &v1.TaskSpec{
+				Steps: []v1.Step{{
+					Name:    "build",
+					Image:   "my-image",
+					Results: []v1.StepResult{{Name: "digest", Type: v1.ResultsTypeString}},
+				}, {
+					Name:    "push",
+					Image:   "my-image",
+					Results: []v1.StepResult{{Name: "image", Type: v1.ResultsTypeString}},
+				}},
+				Results: tt.results,
+			}
+			ctx := t.Context()
+			ts.SetDefaults(ctx)
+			err := ts.Validate(ctx)
+			if err.Filter(apis.ErrorLevel) != nil {
+				t.Fatalf("TaskSpec.Validate() returned errors: %v", err)
+			}
+			got := ""
+			if warning := err.Filter(apis.WarningLevel); warning != nil {
+				got = warning.Error()
+			}
+			if d := cmp.Diff(tt.expectedWarning, got); d != "" {
+				t.Errorf("TaskSpec.Validate() warnings diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestTaskSpecValidate_SidecarResults(t *testing.T) {
+	tests := []struct {
+		name          string
+		sidecars      []v1.Sidecar
+		steps         []v1.Step
+		results       []v1.TaskResult
+		enableAlpha   bool
+		expectedError string
+	}{{
+		name: "task result fetched from a sidecar result",
+		sidecars: []v1.Sidecar{{
+			Name:    "server",
+			Image:   "my-image",
+			Results: []v1.StepResult{{Name: "port", Type: v1.ResultsTypeString}},
+		}},
+		results: []v1.TaskResult{{
+			Name:  "port",
+			Type:  v1.ResultsTypeString,
+			Value: v1.NewStructuredValues("$(sidecars.server.results.port)"),
+		}},
+		enableAlpha: true,
+	}, {
+		name: "sidecar results not enabled",
+		sidecars: []v1.Sidecar{{
+			Name:    "server",
+			Image:   "my-image",
+			Results: []v1.StepResult{{Name: "port", Type: v1.ResultsTypeString}},
+		}},
+		expectedError: `sidecar results requires "enable-api-fields" feature gate to be "alpha" but it is "beta": `,
+	}, {
+		name: "unnamed sidecar with results",
+		sidecars: []v1.Sidecar{{
+			Image:   "my-image",
+			Results: []v1.StepResult{{Name: "port", Type: v1.ResultsTypeString}},
+		}},
+		enableAlpha:   true,
+		expectedError: `missing field(s): sidecars[0].name`,
+	}, {
+		name: "invalid sidecar result name",
+		sidecars: []v1.Sidecar{{
+			Name:    "server",
+			Image:   "my-image",
+			Results: []v1.StepResult{{Name: "-port", Type: v1.ResultsTypeString}},
+		}},
+		enableAlpha:   true,
+		expectedError: `invalid key name "-port": sidecars[0].results[0].name` + "\n" + `Name must consist of alphanumeric characters, '-', '_', and must start and end with an alphanumeric character (e.g. 'MyName',  or 'my-name',  or 'my_name', regex used for validation is '^([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9]$')`,
+	}, {
+		name: "task result fetched from an undeclared sidecar result",
+		sidecars: []v1.Sidecar{{
+			Name:    "server",
+			Image:   "my-image",
+			Results: []v1.StepResult{{Name: "port", Type: v1.ResultsTypeString}},
+		}},
+		results: []v1.TaskResult{{
+			Name:  "address",
+			Type:  v1.ResultsTypeString,
+			Value: v1.NewStructuredValues("$(sidecars.server.results.address)"),
+		}},
+		enableAlpha:   true,
+		expectedError: `sidecar "server" does not declare the result "address": results.address.value`,
+	}, {
+		name: "task result fetched from a sidecar result and written by a step",
+		sidecars: []v1.Sidecar{{
+			Name:    "server",
+			Image:   "my-image",
+			Results: []v1.StepResult{{Name: "port", Type: v1.ResultsTypeString}},
+		}},
+		steps: []v1.Step{{
+			Name:   "write",
+			Image:  "my-image",
+			Script: "echo -n 8080 > $(results.port.path)",
+		}},
+		results: []v1.TaskResult{{
+			Name:  "port",
+			Type:  v1.ResultsTypeString,
+			Value: v1.NewStructuredValues("$(sidecars.server.results.port)"),
+		}},
+		enableAlpha:   true,
+		expectedError: `task result "port" is fetched from the result "port" of sidecar "server" and cannot also be written by a step: steps[1]`,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &v1.TaskSpec{
+				Steps: append([]v1.Step{{
+					Name:  "client",
+					Image: "my-image",
+				}}, tt.steps...),
+				Sidecars: tt.sidecars,
+				Results:  tt.results,
+			}
+			ctx := cfgtesting.EnableBetaAPIFields(t.Context())
+			if tt.enableAlpha {
+				ctx = cfgtesting.EnableAlphaAPIFields(t.Context())
+			}
+			ts.SetDefaults(ctx)
+			got := ""
+			if err := ts.Validate(ctx).Filter(apis.ErrorLevel); err != nil {
+				got = err.Error()
+			}
+			if d := cmp.Diff(tt.expectedError, got); d != "" {
+				t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestTaskSpecValidate_ContainerNames(t *testing.T) {
+	longName := strings.Repeat("a", 60)
+	tests := []struct {
+		name          string
+		inCreate      bool
+		dryRun        bool
+		steps         []v1.Step
+		sidecars      []v1.Sidecar
+		expectedError string
+	}{{
+		name:     "step and sidecar with the same name",
+		steps:    []v1.Step{{Name: "foo"}},
+		sidecars: []v1.Sidecar{{Name: "foo"}},
+	}, {
+		name:  "unnamed step defaulted apart from a named step",
+		steps: []v1.Step{{}, {Name: "unnamed-0"}},
+	}, {
+		name:     "step and sidecar names of the maximum length",
+		inCreate: true,
+		steps:    []v1.Step{{Name: strings.Repeat("a", 58)}},
+		sidecars: []v1.Sidecar{{Name: strings.Repeat("b", 55)}},
+	}, {
+		name:          "step name one character too long",
+		inCreate:      true,
+		steps:         []v1.Step{{Name: strings.Repeat("a", 59)}},
+		expectedError: fmt.Sprintf(`%q is longer than the 58 characters allowed for the names of steps: steps[0].name`, strings.Repeat("a", 59)),
+	}, {
+		name:          "sidecar name one character too long",
+		inCreate:      true,
+		steps:         []v1.Step{{Name: "foo"}},
+		sidecars:      []v1.Sidecar{{Name: strings.Repeat("b", 56)}},
+		expectedError: fmt.Sprintf(`%q is longer than the 55 characters allowed for the names of sidecars: sidecars[0].name`, strings.Repeat("b", 56)),
+	}, {
+		name:     "long step names in a dry run",
+		inCreate: true,
+		dryRun:   true,
+		steps:    []v1.Step{{Name: longName + "-x"}, {Name: longName + "-y"}},
+	}, {
+		// The container names of remotely resolved specs are shortened with distinct hashes
+		name:  "long step names beginning alike outside of admission",
+		steps: []v1.Step{{Name: longName + "-x"}, {Name: longName + "-y"}},
+	}, {
+		name:          "sidecars with the same name",
+		steps:         []v1.Step{{Name: "foo"}},
+		sidecars:      []v1.Sidecar{{Name: "server"}, {Name: "server"}},
+		expectedError: `"server" and "server" are both run by a container named "sidecar-server": sidecars[1].name`,
+	}, {
+		name:          "step name beginning with the sidecar prefix",
+		inCreate:      true,
+		steps:         []v1.Step{{Name: "sidecar-foo"}},
+		expectedError: `"sidecar-foo" begins with a prefix reserved for container names: steps[0].name`,
+	}, {
+		name:          "sidecar name beginning with the step prefix",
+		inCreate:      true,
+		steps:         []v1.Step{{Name: "foo"}},
+		sidecars:      []v1.Sidecar{{Name: "step-foo"}},
+		expectedError: `"step-foo" begins with a prefix reserved for container names: sidecars[0].name`,
+	}, {
+		// The containers of the steps and sidecars of existing specs keep their prefixed names
+		name:     "step name beginning with the step prefix outside of admission",
+		steps:    []v1.Step{{Name: "step-foo"}},
+		sidecars: []v1.Sidecar{{Name: "sidecar-bar"}},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &v1.TaskSpec{Steps: tt.steps, Sidecars: tt.sidecars}
+			for i := range ts.Steps {
+				ts.Steps[i].Image = "my-image"
+			}
+			for i := range ts.Sidecars {
+				ts.Sidecars[i].Image = "my-image"
+			}
+			ctx := t.Context()
+			ts.SetDefaults(ctx)
+			if tt.inCreate {
+				ctx = apis.WithinCreate(ctx)
+			}
+			if tt.dryRun {
+				ctx = apis.WithDryRun(ctx)
+			}
+			got := ""
+			if err := ts.Validate(ctx); err != nil {
+				got = err.Error()
+			}
+			if d := cmp.Diff(tt.expectedError, got); d != "" {
+				t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestTaskValidate_ParamsAsFile(t *testing.T) {
+	tests := []struct {
+		name          string
+		params        v1.ParamSpecs
+		args          []string
+		expectedError string
+	}{{
+		name:   "string param referenced by its path",
+		params: v1.ParamSpecs{{Name: "manifest", Type: v1.ParamTypeString, AsFile: true}},
+		args:   []string{"-f", "$(params.manifest.path)"},
+	}, {
+		name: "object param referenced by the path of its directory",
+		params: v1.ParamSpecs{{
+			Name:       "config",
+			Type:       v1.ParamTypeObject,
+			AsFile:     true,
+			Properties: map[string]v1.PropertySpec{"url": {Type: v1.ParamTypeString}},
+		}},
+		args: []string{"$(params.config.path)/url"},
+	}, {
+		name:          "content of a string param",
+		params:        v1.ParamSpecs{{Name: "manifest", Type: v1.ParamTypeString, AsFile: true}},
+		args:          []string{"$(params.manifest)"},
+		expectedError: `variable type invalid in "$(params.manifest)": spec.steps[0].args[0]`,
+	}, {
+		name: "content of a key of an object param",
+		params: v1.ParamSpecs{{
+			Name:       "config",
+			Type:       v1.ParamTypeObject,
+			AsFile:     true,
+			Properties: map[string]v1.PropertySpec{"url": {Type: v1.ParamTypeString}},
+		}},
+		args:          []string{"$(params.config.url)"},
+		expectedError: `non-existent variable in "$(params.config.url)": spec.steps[0].args[0]`,
+	}, {
+		name:          "array param",
+		params:        v1.ParamSpecs{{Name: "files", Type: v1.ParamTypeArray, AsFile: true}},
+		args:          []string{"$(params.files[*])"},
+		expectedError: `asFile can't be set on array params: spec.params.files.asFile`,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &v1.Task{
+				ObjectMeta: metav1.ObjectMeta{Name: "task"},
+				Spec: v1.TaskSpec{
+					Params: tt.params,
+					Steps: []v1.Step{{
+						Name:  "my-step",
+						Image: "my-image",
+						Args:  tt.args,
+					}},
+				},
+			}
+			gotError := ""
+			if err := task.Validate(t.Context()); err != nil {
+				gotError = err.Error()
+			}
+			if d := cmp.Diff(tt.expectedError, gotError); d != "" {
+				t.Errorf("Task.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestTaskValidate_SidecarVariables(t *testing.T) {
+	params := v1.ParamSpecs{
+		{Name: "port", Type: v1.ParamTypeString},
+		{Name: "flags", Type: v1.ParamTypeArray},
+		{Name: "config", Type: v1.ParamTypeObject, Properties: map[string]v1.PropertySpec{"url": {Type: v1.ParamTypeString}}},
+		{Name: "manifest", Type: v1.ParamTypeString, AsFile: true},
+	}
+	tests := []struct {
+		name          string
+		sidecar       v1.Sidecar
+		expectedError string
+	}{{
+		name: "variables in the fields they are substituted in",
+		sidecar: v1.Sidecar{
+			Name:   "server",
+			Image:  "my-image",
+			Script: "serve --port $(params.port) --url $(params.config.url) --manifest $(params.manifest.path)",
+			Env:    []corev1.EnvVar{{Name: "TASKRUN", Value: "$(context.taskRun.name)"}},
+			ReadinessProbe: &corev1.Probe{ProbeHandler: corev1.ProbeHandler{
+				HTTPGet: &corev1.HTTPGetAction{Path: "/healthz", Port: intstr.FromString("$(params.port)")},
+			}},
+			Lifecycle: &corev1.Lifecycle{PreStop: &corev1.LifecycleHandler{
+				Exec: &corev1.ExecAction{Command: []string{"stop", "$(params.flags[*])"}},
+			}},
+		},
+	}, {
+		name: "undeclared param in a probe",
+		sidecar: v1.Sidecar{
+			Name:  "server",
+			Image: "my-image",
+			ReadinessProbe: &corev1.Probe{ProbeHandler: corev1.ProbeHandler{
+				HTTPGet: &corev1.HTTPGetAction{Path: "$(params.path)", Port: intstr.FromInt32(8080)},
+			}},
+		},
+		expectedError: `non-existent variable in "$(params.path)": spec.sidecars[0].readinessProbe.httpGet.path`,
+	}, {
+		name: "array param in the script",
+		sidecar: v1.Sidecar{
+			Name:   "server",
+			Image:  "my-image",
+			Script: "serve $(params.flags[*])",
+		},
+		expectedError: `variable type invalid in "serve $(params.flags[*])": spec.sidecars[0].script`,
+	}, {
+		name: "array param not isolated in a lifecycle hook",
+		sidecar: v1.Sidecar{
+			Name:  "server",
+			Image: "my-image",
+			Lifecycle: &corev1.Lifecycle{PostStart: &corev1.LifecycleHandler{
+				Exec: &corev1.ExecAction{Command: []string{"start $(params.flags[*])"}},
+			}},
+		},
+		expectedError: `variable is not properly isolated in "start $(params.flags[*])": spec.sidecars[0].lifecycle.postStart.exec.command[0]`,
+	}, {
+		name: "undeclared key of an object param",
+		sidecar: v1.Sidecar{
+			Name:  "server",
+			Image: "my-image",
+			Env:   []corev1.EnvVar{{Name: "TOKEN", Value: "$(params.config.token)"}},
+		},
+		expectedError: `non-existent variable in "$(params.config.token)": spec.sidecars[0].env[TOKEN]`,
+	}, {
+		name: "content of a param delivered as a file",
+		sidecar: v1.Sidecar{
+			Name:  "server",
+			Image: "my-image",
+			Args:  []string{"$(params.manifest)"},
+		},
+		expectedError: `variable type invalid in "$(params.manifest)": spec.sidecars[0].args[0]`,
+	}, {
+		name: "unknown context variable",
+		sidecar: v1.Sidecar{
+			Name:       "server",
+			Image:      "my-image",
+			WorkingDir: "/workspace/$(context.taskRun.namespac)",
+		},
+		expectedError: `non-existent variable in "/workspace/$(context.taskRun.namespac)": spec.sidecars[0].workingDir`,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &v1.Task{
+				ObjectMeta: metav1.ObjectMeta{Name: "task"},
+				Spec: v1.TaskSpec{
+					Params: params,
+					Steps: []v1.Step{{
+						Name:  "my-step",
+						Image: "my-image",
+					}},
+					Sidecars: []v1.Sidecar{tt.sidecar},
+				},
+			}
+			gotError := ""
+			if err := task.Validate(t.Context()); err != nil {
+				gotError = err.Error()
+			}
+			if d := cmp.Diff(tt.expectedError, gotError); d != "" {
+				t.Errorf("Task.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestTask_Validate_Annotations(t *testing.T) {
+	tests := []struct {
+		name            string
+		annotations     map[string]string
+		expectedError   string
+		expectedWarning string
+	}{{
+		name:        "registered annotation",
+		annotations: map[string]string{"tekton.dev/deprecated": "true"},
+	}, {
+		name:          "registered annotation with an invalid value",
+		annotations:   map[string]string{"tekton.dev/deprecated": "yes"},
+		expectedError: `invalid value: yes: metadata.annotations[tekton.dev/deprecated]` + "\n" + `"yes" must be one of true, false`,
+	}, {
+		name:            "unknown annotation under tekton.dev",
+		annotations:     map[string]string{"tekton.dev/minVersion": "true"},
+		expectedWarning: `unknown annotation with a prefix reserved for Tekton Pipelines: metadata.annotations[tekton.dev/minVersion]`,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &v1.Task{
+				ObjectMeta: metav1.ObjectMeta{Name: "task"},
+				Spec:       v1.TaskSpec{Steps: []v1.Step{{Name: "my-step", Image: "my-image"}}},
+			}
+			task.Annotations = tt.annotations
+			errs := task.Validate(t.Context())
+			gotError, gotWarning := "", ""
+			if err := errs.Filter(apis.ErrorLevel); err != nil {
+				gotError = err.Error()
+			}
+			if warning := errs.Filter(apis.WarningLevel); warning != nil {
+				gotWarning = warning.Error()
+			}
+			if d := cmp.Diff(tt.expectedError, gotError); d != "" {
+				t.Errorf("Task.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+			if d := cmp.Diff(tt.expectedWarning, gotWarning); d != "" {
+				t.Errorf("Task.Validate() warnings diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestTaskSpecValidate_DisableInlineScripts(t *testing.T) {
+	tests := []struct {
+		name          string
+		taskSpec      v1.TaskSpec
+		expectedError string
+	}{{
+		name: "steps and sidecars running commands",
+		taskSpec: v1.TaskSpec{
+			Steps:    []v1.Step{{Name: "build", Image: "my-image", Command: []string{"build"}}},
+			Sidecars: []v1.Sidecar{{Name: "registry", Image: "my-image", Command: []string{"serve"}}},
+		},
+	}, {
+		name: "step with a script",
+		taskSpec: v1.TaskSpec{
+			Steps: []v1.Step{{Name: "build", Image: "my-image", Command: []string{"build"}}, {Name: "test", Image: "my-image", Script: "go test ./..."}},
+		},
+		expectedError: `step "test" can't have a script, scripts are disabled by the "disable-inline-scripts" feature flag: steps[1].script`,
+	}, {
+		name: "sidecar with a script",
+		taskSpec: v1.TaskSpec{
+			Steps:    []v1.Step{{Name: "build", Image: "my-image", Command: []string{"build"}}},
+			Sidecars: []v1.Sidecar{{Name: "registry", Image: "my-image", Script: "serve"}},
+		},
+		expectedError: `sidecar "registry" can't have a script, scripts are disabled by the "disable-inline-scripts" feature flag: sidecars.script`,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := cfgtesting.SetFeatureFlags(t.Context(), t, map[string]string{config.DisableInlineScriptsKey: "true"})
+			gotError := ""
+			if err := tt.taskSpec.Validate(ctx); err != nil {
+				gotError = err.Error()
+			}
+			if d := cmp.Diff(tt.expectedError, gotError); d != "" {
+				t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+
+	// Scripts are allowed unless the feature flag is set.
+	ts := v1.TaskSpec{Steps: []v1.Step{{Name: "test", Image: "my-image", Script: "go test ./..."}}}
+	if err := ts.Validate(t.Context()); err != nil {
+		t.Errorf("TaskSpec.Validate() = %v, want no error", err)
+	}
+}
@@ -196,6 +196,47 @@ func TestTaskRunDefaulting(t *testing.T) {
 			"default-timeout-minutes": "5",
 			"default-service-account": "tekton",
 		},
+	}, {
+		name: "TaskRef default timeout capped to the maximum taskrun timeout",
+		in: &v1.TaskRun{
+			Spec: v1.TaskRunSpec{
+				TaskRef: &v1.TaskRef{Name: "foo"},
+			},
+		},
+		want: &v1.TaskRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels: map[string]string{"app.kubernetes.io/managed-by": "tekton-pipelines"},
+			},
+			Spec: v1.TaskRunSpec{
+				ServiceAccountName: config.DefaultServiceAccountValue,
+				TaskRef:            &v1.TaskRef{Name: "foo", Kind: v1.NamespacedTaskKind},
+				Timeout:            &metav1.Duration{Duration: 30 * time.Minute},
+			},
+		},
+		defaults: map[string]string{
+			"default-maximum-taskrun-timeout": "30m",
+		},
+	}, {
+		name: "TaskRef default no timeout capped to the maximum taskrun timeout",
+		in: &v1.TaskRun{
+			Spec: v1.TaskRunSpec{
+				TaskRef: &v1.TaskRef{Name: "foo"},
+			},
+		},
+		want: &v1.TaskRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels: map[string]string{"app.kubernetes.io/managed-by": "tekton-pipelines"},
+			},
+			Spec: v1.TaskRunSpec{
+				ServiceAccountName: config.DefaultServiceAccountValue,
+				TaskRef:            &v1.TaskRef{Name: "foo", Kind: v1.NamespacedTaskKind},
+				Timeout:            &metav1.Duration{Duration: 2 * time.Hour},
+			},
+		},
+		defaults: map[string]string{
+			"default-timeout-minutes":         "0",
+			"default-maximum-taskrun-timeout": "2h",
+		},
 	}, {
 		name: "TaskRun managed-by set in config",
 		in: &v1.TaskRun{
@@ -428,6 +469,7 @@ func TestTaskRunDefaulting(t *testing.T) {
 }
 
 func TestTaskRunDefaultingOnCreate(t *testing.T) {
+	storageClass, explicitStorageClass := "local-wait-for-first-consumer", "explicit"
 	tests := []struct {
 		name     string
 		in       *v1.TaskRun
@@ -459,6 +501,62 @@ func TestTaskRunDefaultingOnCreate(t *testing.T) {
 				ServiceAccountName: "default",
 			},
 		},
+	}, {
+		name: "VolumeClaimTemplates of workspaces get the default storage class and access mode on create",
+		in: &v1.TaskRun{
+			Spec: v1.TaskRunSpec{
+				TaskRef: &v1.TaskRef{
+					Name: "foo",
+				},
+				Workspaces: []v1.WorkspaceBinding{{
+					Name:                "defaulted",
+					VolumeClaimTemplate: &corev1.PersistentVolumeClaim{},
+				}, {
+					Name: "explicit",
+					VolumeClaimTemplate: &corev1.PersistentVolumeClaim{
+						Spec: corev1.PersistentVolumeClaimSpec{
+							StorageClassName: &explicitStorageClass,
+							AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany},
+						},
+					},
+				}},
+			},
+		},
+		want: &v1.TaskRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels:      map[string]string{"app.kubernetes.io/managed-by": "tekton-pipelines"},
+				Annotations: map[string]string{"tekton.dev/defaulted-workspace-storage": "defaulted"},
+			},
+			Spec: v1.TaskRunSpec{
+				TaskRef: &v1.TaskRef{
+					Kind: "Task",
+					Name: "foo",
+				},
+				Timeout:            &metav1.Duration{Duration: time.Hour},
+				ServiceAccountName: "default",
+				Workspaces: []v1.WorkspaceBinding{{
+					Name: "defaulted",
+					VolumeClaimTemplate: &corev1.PersistentVolumeClaim{
+						Spec: corev1.PersistentVolumeClaimSpec{
+							StorageClassName: &storageClass,
+							AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+						},
+					},
+				}, {
+					Name: "explicit",
+					VolumeClaimTemplate: &corev1.PersistentVolumeClaim{
+						Spec: corev1.PersistentVolumeClaimSpec{
+							StorageClassName: &explicitStorageClass,
+							AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany},
+						},
+					},
+				}},
+			},
+		},
+		defaults: map[string]string{
+			"default-workspace-storage-class": "local-wait-for-first-consumer",
+			"default-workspace-access-mode":   "ReadWriteOnce",
+		},
 	}}
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
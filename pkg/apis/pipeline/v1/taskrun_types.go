@@ -91,6 +91,11 @@ type TaskRunSpec struct {
 	// This field is immutable.
 	// +optional
 	ManagedBy *string `json:"managedBy,omitempty"`
+	// TTLSecondsAfterFinished is the number of seconds after which the TaskRun is deleted
+	// once it completed, unless it has the "tekton.dev/keep" annotation set to "true".
+	// Defaults to the "default-ttl-seconds-after-finished" default, if any.
+	// +optional
+	TTLSecondsAfterFinished *int32 `json:"ttlSecondsAfterFinished,omitempty"`
 }
 
 // TaskRunSpecStatus defines the TaskRun spec status the user can provide
@@ -116,6 +121,21 @@ const (
 	TaskRunCancelledByPipelineTimeoutMsg TaskRunSpecStatusMessage = "TaskRun cancelled as the PipelineRun it belongs to has timed out."
 )
 
+// TaskRunRunningSlowAnnotation is set to "true" on TaskRuns that have been running for longer than
+// the configured multiple of their expected duration
+const TaskRunRunningSlowAnnotation = "tekton.dev/running-slow"
+
+// ResultExtractionMethodAnnotation can be set on PipelineRuns and TaskRuns to extract their results
+// with one of the "results-from" methods allowed by the "allowed-results-from-overrides" feature flag,
+// instead of the method set by "results-from". It is copied to the pods of TaskRuns, which keep the
+// method they were created with.
+const ResultExtractionMethodAnnotation = "tekton.dev/results-from"
+
+// RecreateDeletedPodAnnotation can be set to "true" on Tasks, or on the runs propagating it to their
+// TaskRuns, to create a replacement pod when the pod of a TaskRun is deleted out-of-band, e.g. when its
+// node is drained, instead of failing it, when the "enable-deleted-pod-recreation" feature flag is enabled.
+const RecreateDeletedPodAnnotation = "tekton.dev/recreate-deleted-pod"
+
 const (
 	// EnabledOnFailureBreakpoint is the value for TaskRunDebug.Breakpoints.OnFailure that means the breakpoint onFailure is enabled
 	EnabledOnFailureBreakpoint = "enabled"
@@ -250,8 +270,12 @@ const (
 	// to running out of memory (OOMKilled).
 	TaskRunReasonSidecarOOM TaskRunReason = "SidecarOOM"
 	// TaskRunReasonSidecarFailed indicates a sidecar container failed
-	// (non-OOM), e.g., bad image or crash.
+	// (non-OOM), e.g., bad image or crash, failing the pod.
 	TaskRunReasonSidecarFailed TaskRunReason = "SidecarFailed"
+	// TaskRunReasonSidecarStopAndFail is the reason set when a sidecar whose onError is "stopAndFail"
+	// exits with a non-zero exit code while the steps are still running, failing the TaskRun before
+	// its pod, unlike TaskRunReasonSidecarFailed.
+	TaskRunReasonSidecarStopAndFail TaskRunReason = "TaskRunSidecarFailed"
 	// TaskRunReasonInitContainerOOM indicates an internal Tekton init
 	// container (prepare, place-scripts, working-dir-initializer) was
 	// killed due to running out of memory (OOMKilled).
@@ -265,6 +289,15 @@ const (
 	TaskRunReasonFailureIgnored TaskRunReason = "FailureIgnored"
 	// TaskRunReasonPending is the reason set when the TaskRun is in the pending state
 	TaskRunReasonPending TaskRunReason = "TaskRunPending"
+	// TaskRunReasonArtifactDigestMismatch is the reason set when an output artifact
+	// does not have the digest declared in the expectedArtifactDigests of the Task
+	TaskRunReasonArtifactDigestMismatch TaskRunReason = "ArtifactDigestMismatch"
+	// TaskRunReasonPodDeleted is the reason set when the pod of the TaskRun was deleted out-of-band,
+	// e.g. when its node was drained, while the TaskRun was running
+	TaskRunReasonPodDeleted TaskRunReason = "TaskRunPodDeleted"
+	// TaskRunReasonCancelledDueToDeletion is the reason set when the TaskRun was deleted, e.g. with its
+	// namespace, before it completed
+	TaskRunReasonCancelledDueToDeletion TaskRunReason = "TaskRunCancelledDueToDeletion"
 )
 
 func (t TaskRunReason) String() string {
@@ -318,6 +351,11 @@ type TaskRunStatusFields struct {
 	// PodName is the name of the pod responsible for executing this task's steps.
 	PodName string `json:"podName"`
 
+	// IsolatedPodName is the name of the pod running the steps with the "isolated" security profile,
+	// if any, along with the pod named PodName which runs the other steps.
+	// +optional
+	IsolatedPodName string `json:"isolatedPodName,omitempty"`
+
 	// StartTime is the time the build is actually started.
 	StartTime *metav1.Time `json:"startTime,omitempty"`
 
@@ -359,6 +397,99 @@ type TaskRunStatusFields struct {
 
 	// SpanContext contains tracing span context fields
 	SpanContext map[string]string `json:"spanContext,omitempty"`
+
+	// PodRetained is true when the pod of this failed TaskRun is retained for investigation,
+	// as configured with the retain-failed-pods policy.
+	// +optional
+	PodRetained bool `json:"podRetained,omitempty"`
+
+	// ReasonHistory lists the last reasons of the Succeeded condition of this TaskRun,
+	// oldest first, with the time the condition changed to each of them.
+	// +optional
+	// +listType=atomic
+	ReasonHistory []TaskRunReasonTransition `json:"reasonHistory,omitempty"`
+
+	// ShortenedContainerNames maps the steps and sidecars whose names are too long to be prefixed
+	// in the names of their containers to the shortened names of these containers.
+	// +optional
+	// +listType=atomic
+	ShortenedContainerNames []ShortenedContainerName `json:"shortenedContainerNames,omitempty"`
+
+	// PinnedImages lists the digests the images of the steps and sidecars referenced by tag were
+	// pinned to when the pod was created, if the TaskRun has the "tekton.dev/pin-image-digests" annotation.
+	// +optional
+	// +listType=atomic
+	PinnedImages []PinnedImage `json:"pinnedImages,omitempty"`
+
+	// Attempts summarizes the previous attempts of this TaskRun, archived in RetriesStatus,
+	// oldest first.
+	// +optional
+	// +listType=atomic
+	Attempts []TaskRunAttempt `json:"attempts,omitempty"`
+
+	// ResultsSchema lists the names, types and object properties of the results declared by the Task
+	// of this TaskRun once it succeeded, so that its results can be discovered without the Task.
+	// +optional
+	// +listType=atomic
+	ResultsSchema []ResultSchema `json:"resultsSchema,omitempty"`
+}
+
+// ResultSchema is the name, type and object properties of a result declared by a Task.
+type ResultSchema struct {
+	// Name is the name of the result.
+	Name string `json:"name"`
+	// Type is the type of the result.
+	Type ResultsType `json:"type"`
+	// Properties are the properties of the result, if it is an object.
+	// +optional
+	Properties map[string]PropertySpec `json:"properties,omitempty"`
+}
+
+// TaskRunAttempt summarizes a previous attempt of a TaskRun.
+type TaskRunAttempt struct {
+	// Attempt is the index of the attempt, starting at 0 for the first attempt.
+	Attempt int `json:"attempt"`
+	// PodName is the name of the pod of the attempt.
+	// +optional
+	PodName string `json:"podName,omitempty"`
+	// Reason is the reason of the Succeeded condition the attempt ended with.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// CompletionTime is the time the attempt completed.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+	// Results are the results written out by the attempt.
+	// +optional
+	// +listType=atomic
+	Results []TaskRunResult `json:"results,omitempty"`
+}
+
+// PinnedImage records the digest an image referenced by tag was pinned to.
+type PinnedImage struct {
+	// Image is the reference of the image, as specified by the steps or sidecars.
+	Image string `json:"image"`
+	// Digest is the digest the image was pinned to.
+	Digest string `json:"digest"`
+}
+
+// ShortenedContainerName maps the name of a step or a sidecar to the shortened name of its container.
+type ShortenedContainerName struct {
+	// Step is the name of the step run by the container.
+	// +optional
+	Step string `json:"step,omitempty"`
+	// Sidecar is the name of the sidecar run by the container.
+	// +optional
+	Sidecar string `json:"sidecar,omitempty"`
+	// Container is the name of the container.
+	Container string `json:"container"`
+}
+
+// TaskRunReasonTransition records a change of the reason of the Succeeded condition of a TaskRun.
+type TaskRunReasonTransition struct {
+	// Reason is the reason the condition changed to.
+	Reason string `json:"reason"`
+	// Time is the time the condition changed to the reason.
+	Time metav1.Time `json:"time"`
 }
 
 // TaskRunStepSpec is used to override the values of a Step in the corresponding Task.
@@ -418,6 +549,30 @@ func (trs *TaskRunStatus) SetCondition(newCond *apis.Condition) {
 	}
 }
 
+// MaxTaskRunReasonHistory is the number of reason transitions kept in the ReasonHistory of a TaskRun.
+const MaxTaskRunReasonHistory = 10
+
+// SetConditions sets the conditions of the TaskRun. As every change of the conditions goes through it,
+// it is where the reason of the Succeeded condition is appended to the ReasonHistory when it changes,
+// keeping the last MaxTaskRunReasonHistory transitions.
+func (trs *TaskRunStatus) SetConditions(conditions apis.Conditions) {
+	trs.Status.SetConditions(conditions)
+	cond := trs.GetCondition(apis.ConditionSucceeded)
+	if cond == nil || cond.Reason == "" {
+		return
+	}
+	if n := len(trs.ReasonHistory); n > 0 && trs.ReasonHistory[n-1].Reason == cond.Reason {
+		return
+	}
+	trs.ReasonHistory = append(trs.ReasonHistory, TaskRunReasonTransition{
+		Reason: cond.Reason,
+		Time:   *cond.LastTransitionTime.Inner.DeepCopy(),
+	})
+	if n := len(trs.ReasonHistory); n > MaxTaskRunReasonHistory {
+		trs.ReasonHistory = trs.ReasonHistory[n-MaxTaskRunReasonHistory:]
+	}
+}
+
 // StepState reports the results of running a step in a Task.
 type StepState struct {
 	corev1.ContainerState `json:",inline"`
@@ -427,6 +582,7 @@ type StepState struct {
 	Results               []TaskRunStepResult   `json:"results,omitempty"`
 	Provenance            *Provenance           `json:"provenance,omitempty"`
 	TerminationReason     string                `json:"terminationReason,omitempty"`
+	RetryCount            int                   `json:"retryCount,omitempty"`
 	Inputs                []TaskRunStepArtifact `json:"inputs,omitempty"`
 	Outputs               []TaskRunStepArtifact `json:"outputs,omitempty"`
 }
@@ -434,9 +590,10 @@ type StepState struct {
 // SidecarState reports the results of running a sidecar in a Task.
 type SidecarState struct {
 	corev1.ContainerState `json:",inline"`
-	Name                  string `json:"name,omitempty"`
-	Container             string `json:"container,omitempty"`
-	ImageID               string `json:"imageID,omitempty"`
+	Name                  string          `json:"name,omitempty"`
+	Container             string          `json:"container,omitempty"`
+	ImageID               string          `json:"imageID,omitempty"`
+	Results               []TaskRunResult `json:"results,omitempty"`
 }
 
 // +genclient
@@ -544,14 +701,16 @@ func (tr *TaskRun) HasTimedOut(ctx context.Context, c clock.PassiveClock) bool {
 	return runtime > timeout
 }
 
-// GetTimeout returns the timeout for the TaskRun, or the default if not specified
+// GetTimeout returns the timeout for the TaskRun, or the default if not specified, capped to the
+// maximum TaskRun duration, which applies to the TaskRuns without timeout too
 func (tr *TaskRun) GetTimeout(ctx context.Context) time.Duration {
+	defaults := config.FromContextOrDefaults(ctx).Defaults
 	// Use the platform default is no timeout is set
 	if tr.Spec.Timeout == nil {
-		defaultTimeout := time.Duration(config.FromContextOrDefaults(ctx).Defaults.DefaultTimeoutMinutes)
-		return defaultTimeout * time.Minute //nolint:durationcheck
+		defaultTimeout := time.Duration(defaults.DefaultTimeoutMinutes)
+		return defaults.CapTaskRunTimeout(defaultTimeout * time.Minute) //nolint:durationcheck
 	}
-	return tr.Spec.Timeout.Duration
+	return defaults.CapTaskRunTimeout(tr.Spec.Timeout.Duration)
 }
 
 // GetNamespacedName returns a k8s namespaced name that identifies this TaskRun
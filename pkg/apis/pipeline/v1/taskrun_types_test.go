@@ -17,10 +17,14 @@ limitations under the License.
 package v1_test
 
 import (
+	"errors"
+	"fmt"
 	"testing"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	cfgtesting "github.com/tektoncd/pipeline/pkg/apis/config/testing"
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"github.com/tektoncd/pipeline/test/diff"
 	corev1 "k8s.io/api/core/v1"
@@ -374,6 +378,56 @@ func TestHasTimedOut(t *testing.T) {
 	}
 }
 
+func TestHasTimedOut_MaximumTaskRunTimeout(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		timeout *metav1.Duration
+		elapsed time.Duration
+		want    bool
+	}{{
+		name:    "no timeout within the maximum",
+		timeout: &metav1.Duration{Duration: config.NoTimeoutDuration},
+		elapsed: 59 * time.Minute,
+		want:    false,
+	}, {
+		name:    "no timeout beyond the maximum",
+		timeout: &metav1.Duration{Duration: config.NoTimeoutDuration},
+		elapsed: 61 * time.Minute,
+		want:    true,
+	}, {
+		name:    "timeout above the maximum",
+		timeout: &metav1.Duration{Duration: 2 * time.Hour},
+		elapsed: 61 * time.Minute,
+		want:    true,
+	}, {
+		name:    "timeout below the maximum",
+		timeout: &metav1.Duration{Duration: 10 * time.Minute},
+		elapsed: 11 * time.Minute,
+		want:    true,
+	}, {
+		name:    "default timeout within the maximum",
+		elapsed: 59 * time.Minute,
+		want:    false,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := cfgtesting.SetDefaults(t.Context(), t, map[string]string{
+				"default-maximum-taskrun-timeout": "1h",
+			})
+			tr := &v1.TaskRun{
+				Spec: v1.TaskRunSpec{Timeout: tc.timeout},
+				Status: v1.TaskRunStatus{
+					TaskRunStatusFields: v1.TaskRunStatusFields{
+						StartTime: &metav1.Time{Time: now.Add(-tc.elapsed)},
+					},
+				},
+			}
+			if got := tr.HasTimedOut(ctx, testClock); got != tc.want {
+				t.Errorf("HasTimedOut() = %t, want %t", got, tc.want)
+			}
+		})
+	}
+}
+
 func TestInitializeTaskRunConditions(t *testing.T) {
 	tr := &v1.TaskRun{
 		ObjectMeta: metav1.ObjectMeta{
@@ -408,6 +462,53 @@ func TestInitializeTaskRunConditions(t *testing.T) {
 	}
 }
 
+func TestTaskRunStatus_ReasonHistory(t *testing.T) {
+	trs := v1.TaskRunStatus{}
+	trs.InitializeConditions()
+	trs.MarkResourceOngoing(v1.TaskRunReasonResolvingTaskRef, "resolving")
+	trs.MarkResourceOngoing(v1.TaskRunReasonResolvingTaskRef, "still resolving")
+	trs.SetCondition(&apis.Condition{Type: apis.ConditionSucceeded, Status: corev1.ConditionUnknown, Reason: "Pending"})
+	trs.SetCondition(&apis.Condition{Type: apis.ConditionSucceeded, Status: corev1.ConditionUnknown, Reason: v1.TaskRunReasonRunning.String()})
+	trs.MarkResourceFailed(v1.TaskRunReasonFailed, errors.New("boom"))
+
+	want := []string{
+		v1.TaskRunReasonStarted.String(),
+		v1.TaskRunReasonResolvingTaskRef,
+		"Pending",
+		v1.TaskRunReasonRunning.String(),
+		v1.TaskRunReasonFailed.String(),
+	}
+	var got []string
+	for _, rt := range trs.ReasonHistory {
+		if rt.Time.IsZero() {
+			t.Errorf("Expected the transition to %s to have a time", rt.Reason)
+		}
+		got = append(got, rt.Reason)
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("Unexpected reason history: %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestTaskRunStatus_ReasonHistoryCapped(t *testing.T) {
+	trs := v1.TaskRunStatus{}
+	var want []string
+	for i := range v1.MaxTaskRunReasonHistory + 3 {
+		reason := fmt.Sprintf("Reason%d", i)
+		trs.SetCondition(&apis.Condition{Type: apis.ConditionSucceeded, Status: corev1.ConditionUnknown, Reason: reason})
+		want = append(want, reason)
+	}
+	want = want[len(want)-v1.MaxTaskRunReasonHistory:]
+
+	var got []string
+	for _, rt := range trs.ReasonHistory {
+		got = append(got, rt.Reason)
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("Unexpected reason history: %s", diff.PrintWantGot(d))
+	}
+}
+
 func TestIsDebugBeforeStep(t *testing.T) {
 	type args struct {
 		stepName string
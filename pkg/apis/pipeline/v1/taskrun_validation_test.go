@@ -565,6 +565,39 @@ func TestTaskRunSpec_Invalidate(t *testing.T) {
 		},
 		wc:      EnableForbiddenEnv,
 		wantErr: apis.ErrInvalidValue("PodTemplate cannot update a forbidden env: TEST_ENV", "PodTemplate.Env"),
+	}, {
+		name: "PodTemplate with maxSkew lower than 1",
+		spec: v1.TaskRunSpec{
+			TaskRef: &v1.TaskRef{Name: "mytask"},
+			PodTemplate: &pod.Template{
+				TopologySpreadConstraints: []corev1.TopologySpreadConstraint{{
+					MaxSkew:           0,
+					TopologyKey:       "kubernetes.io/hostname",
+					WhenUnsatisfiable: corev1.DoNotSchedule,
+				}},
+			},
+		},
+		wantErr: apis.ErrInvalidValue("0 should be >= 1", "podTemplate.topologySpreadConstraints[0].maxSkew"),
+	}, {
+		name: "param taken from a PipelineRun result",
+		spec: v1.TaskRunSpec{
+			TaskRef: &v1.TaskRef{Name: "mytask"},
+			Params: v1.Params{{
+				Name:   "digest",
+				RunRef: &v1.PipelineRunResultRef{Name: "build-run", Result: "digest"},
+			}},
+		},
+		wantErr: apis.ErrDisallowedFields("params[digest].runRef"),
+	}, {
+		name: "param value shorter than the minLength of the embedded task",
+		spec: v1.TaskRunSpec{
+			TaskSpec: &v1.TaskSpec{
+				Params: v1.ParamSpecs{{Name: "tag", Type: v1.ParamTypeString, MinLength: 3}},
+				Steps:  []v1.Step{{Name: "mystep", Image: "myimage"}},
+			},
+			Params: v1.Params{{Name: "tag", Value: *v1.NewStructuredValues("v1")}},
+		},
+		wantErr: apis.ErrInvalidValue(`param "tag" value "v1" is shorter than its minLength 3`, "params[tag].value"),
 	}, {
 		name: "invalid taskref and taskspec together",
 		spec: v1.TaskRunSpec{
@@ -624,6 +657,29 @@ func TestTaskRunSpec_Invalidate(t *testing.T) {
 			Timeout: &metav1.Duration{Duration: -48 * time.Hour},
 		},
 		wantErr: apis.ErrInvalidValue("-48h0m0s should be >= 0", "timeout"),
+	}, {
+		name: "negative ttlSecondsAfterFinished",
+		spec: v1.TaskRunSpec{
+			TaskRef: &v1.TaskRef{
+				Name: "taskrefname",
+			},
+			TTLSecondsAfterFinished: ptr.Int32(-1),
+		},
+		wantErr: apis.ErrInvalidValue("-1 should be >= 0", "ttlSecondsAfterFinished"),
+	}, {
+		name: "timeout above the maximum taskrun timeout",
+		spec: v1.TaskRunSpec{
+			TaskRef: &v1.TaskRef{
+				Name: "taskrefname",
+			},
+			Timeout: &metav1.Duration{Duration: 2 * time.Hour},
+		},
+		wantErr: apis.ErrInvalidValue("2h0m0s should be <= maximum TaskRun duration 1h0m0s", "timeout"),
+		wc: func(ctx context.Context) context.Context {
+			return config.ToContext(ctx, &config.Config{
+				Defaults: &config.Defaults{DefaultMaximumTaskRunTimeout: time.Hour},
+			})
+		},
 	}, {
 		name: "negative pipeline retries",
 		spec: v1.TaskRunSpec{
@@ -1000,6 +1056,32 @@ func TestTaskRunSpec_Validate(t *testing.T) {
 			}},
 		},
 		wc: cfgtesting.EnableAlphaAPIFields,
+	}, {
+		name: "no timeout with a maximum taskrun timeout",
+		spec: v1.TaskRunSpec{
+			TaskRef: &v1.TaskRef{Name: "taskrefname"},
+			Timeout: &metav1.Duration{Duration: config.NoTimeoutDuration},
+		},
+		wc: func(ctx context.Context) context.Context {
+			return config.ToContext(ctx, &config.Config{
+				Defaults: &config.Defaults{DefaultMaximumTaskRunTimeout: time.Hour},
+			})
+		},
+	}, {
+		name: "timeout above a lowered maximum taskrun timeout on update",
+		spec: v1.TaskRunSpec{
+			TaskRef: &v1.TaskRef{Name: "taskrefname"},
+			Timeout: &metav1.Duration{Duration: 2 * time.Hour},
+		},
+		wc: func(ctx context.Context) context.Context {
+			ctx = config.ToContext(ctx, &config.Config{
+				Defaults: &config.Defaults{DefaultMaximumTaskRunTimeout: time.Hour},
+			})
+			return apis.WithinUpdate(ctx, &v1.TaskRun{Spec: v1.TaskRunSpec{
+				TaskRef: &v1.TaskRef{Name: "taskrefname"},
+				Timeout: &metav1.Duration{Duration: 2 * time.Hour},
+			}})
+		},
 	}}
 
 	for _, ts := range tests {
@@ -1318,3 +1400,46 @@ func TestTaskRunSpec_ValidateUpdate_FinalizerChanges(t *testing.T) {
 		})
 	}
 }
+
+func TestTaskRun_Validate_Annotations(t *testing.T) {
+	tests := []struct {
+		name            string
+		annotations     map[string]string
+		expectedError   string
+		expectedWarning string
+	}{{
+		name:        "registered annotation",
+		annotations: map[string]string{"pipeline.tekton.dev/pipeline-task-on-error": "continue"},
+	}, {
+		name:          "registered annotation with an invalid value",
+		annotations:   map[string]string{"pipeline.tekton.dev/pipeline-task-on-error": "ignore"},
+		expectedError: `invalid value: ignore: metadata.annotations[pipeline.tekton.dev/pipeline-task-on-error]` + "\n" + `"ignore" must be one of stopAndFail, continue`,
+	}, {
+		name:            "unknown annotation under tekton.dev",
+		annotations:     map[string]string{"tekton.dev/on-error": "true"},
+		expectedWarning: `unknown annotation with a prefix reserved for Tekton Pipelines: metadata.annotations[tekton.dev/on-error]`,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr := &v1.TaskRun{
+				ObjectMeta: metav1.ObjectMeta{Name: "taskrun"},
+				Spec:       v1.TaskRunSpec{TaskRef: &v1.TaskRef{Name: "task"}},
+			}
+			tr.Annotations = tt.annotations
+			errs := tr.Validate(t.Context())
+			gotError, gotWarning := "", ""
+			if err := errs.Filter(apis.ErrorLevel); err != nil {
+				gotError = err.Error()
+			}
+			if warning := errs.Filter(apis.WarningLevel); warning != nil {
+				gotWarning = warning.Error()
+			}
+			if d := cmp.Diff(tt.expectedError, gotError); d != "" {
+				t.Errorf("TaskRun.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+			if d := cmp.Diff(tt.expectedWarning, gotWarning); d != "" {
+				t.Errorf("TaskRun.Validate() warnings diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
@@ -50,6 +50,17 @@ func (w *WorkspaceDeclaration) GetMountPath() string {
 	return filepath.Join(pipeline.WorkspaceDir, w.Name)
 }
 
+// DuplicateWorkspaceBindingsAnnotation is set by the controllers on PipelineRuns and TaskRuns which bind
+// the same workspaces more than once, to the comma separated names of these workspaces. Such runs are
+// rejected by the webhook, but may have been created before they were: their workspaces are bound to
+// their first binding.
+const DuplicateWorkspaceBindingsAnnotation = "tekton.dev/duplicate-workspace-bindings"
+
+// DefaultedWorkspaceStorageAnnotation is set by the webhook on the PipelineRuns and TaskRuns created with
+// workspace volumeClaimTemplates which got the default storage class or access mode of the config-defaults,
+// to the comma separated names of these workspaces.
+const DefaultedWorkspaceStorageAnnotation = "tekton.dev/defaulted-workspace-storage"
+
 // WorkspaceBinding maps a Task's declared workspace to a Volume.
 type WorkspaceBinding struct {
 	// Name is the name of the workspace populated by the volume.
@@ -86,6 +97,20 @@ type WorkspaceBinding struct {
 	// CSI (Container Storage Interface) represents ephemeral storage that is handled by certain external CSI drivers.
 	// +optional
 	CSI *corev1.CSIVolumeSource `json:"csi,omitempty"`
+	// OCIImage represents an OCI image or artifact whose content populates this workspace,
+	// which can only be bound to read-only workspaces.
+	// +optional
+	OCIImage *OCIImageWorkspaceSource `json:"ociImage,omitempty"`
+}
+
+// OCIImageWorkspaceSource is an OCI image or artifact whose content populates a workspace.
+type OCIImageWorkspaceSource struct {
+	// Reference is the reference of the image or artifact, as the image of a container.
+	Reference string `json:"reference"`
+	// PullSecret is the name of the secret of type kubernetes.io/dockerconfigjson used to pull
+	// the image or artifact, along with the image pull secrets of the pod.
+	// +optional
+	PullSecret string `json:"pullSecret,omitempty"`
 }
 
 // WorkspacePipelineDeclaration creates a named slot in a Pipeline that a PipelineRun
@@ -121,6 +146,11 @@ type WorkspacePipelineTaskBinding struct {
 	// for this binding (i.e. the volume will be mounted at this sub directory).
 	// +optional
 	SubPath string `json:"subPath,omitempty"`
+	// PerMatrixInstance binds each instance of a matrixed PipelineTask to its own
+	// PersistentVolumeClaim, created from the volumeClaimTemplate bound to the workspace,
+	// instead of sharing a single claim between the instances.
+	// +optional
+	PerMatrixInstance bool `json:"perMatrixInstance,omitempty"`
 }
 
 // WorkspaceUsage is used by a Step or Sidecar to declare that it wants isolated access
@@ -18,7 +18,12 @@ package v1
 
 import (
 	"context"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
 
+	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"k8s.io/apimachinery/pkg/api/equality"
 	"knative.dev/pkg/apis"
 )
@@ -80,6 +85,16 @@ func (b *WorkspaceBinding) Validate(ctx context.Context) (errs *apis.FieldError)
 		}
 	}
 
+	// For an OCI image to work, you must provide the reference of the image to use.
+	if b.OCIImage != nil {
+		if err := config.ValidateEnabledAPIFields(ctx, "ociImage workspace binding", config.AlphaAPIFields); err != nil {
+			return err
+		}
+		if b.OCIImage.Reference == "" {
+			return apis.ErrMissingField("ociImage.reference")
+		}
+	}
+
 	return nil
 }
 
@@ -108,5 +123,21 @@ func (b *WorkspaceBinding) numSources() int {
 	if b.CSI != nil {
 		n++
 	}
+	if b.OCIImage != nil {
+		n++
+	}
 	return n
 }
+
+// ValidateSubPath validates that the subPath of the binding is a relative path which doesn't leave the
+// workspace. The values of the variables it references are validated once they are replaced, when the
+// TaskRun of the PipelineTask is created.
+func (b WorkspacePipelineTaskBinding) ValidateSubPath() *apis.FieldError {
+	if b.SubPath == "" {
+		return nil
+	}
+	if path.IsAbs(b.SubPath) || slices.Contains(strings.Split(b.SubPath, "/"), "..") {
+		return apis.ErrInvalidValue(fmt.Sprintf("%q must be a relative path without \"..\" elements", b.SubPath), "subPath")
+	}
+	return nil
+}
@@ -110,6 +110,16 @@ func TestWorkspaceBindingValidateValid(t *testing.T) {
 				Driver: "my-csi",
 			},
 		},
+	}, {
+		name: "Valid ociImage",
+		binding: &v1.WorkspaceBinding{
+			Name: "beth",
+			OCIImage: &v1.OCIImageWorkspaceSource{
+				Reference:  "registry.example.com/config:v1",
+				PullSecret: "registry-creds",
+			},
+		},
+		wc: cfgtesting.EnableAlphaAPIFields,
 	}} {
 		t.Run(tc.name, func(t *testing.T) {
 			ctx := t.Context()
@@ -179,6 +189,22 @@ func TestWorkspaceBindingValidateInvalid(t *testing.T) {
 			},
 		},
 		wc: cfgtesting.EnableBetaAPIFields,
+	}, {
+		name: "Provide ociImage without a reference",
+		binding: &v1.WorkspaceBinding{
+			Name:     "beth",
+			OCIImage: &v1.OCIImageWorkspaceSource{},
+		},
+		wc: cfgtesting.EnableAlphaAPIFields,
+	}, {
+		name: "Provide ociImage without alpha api fields",
+		binding: &v1.WorkspaceBinding{
+			Name: "beth",
+			OCIImage: &v1.OCIImageWorkspaceSource{
+				Reference: "registry.example.com/config:v1",
+			},
+		},
+		wc: cfgtesting.EnableBetaAPIFields,
 	}} {
 		t.Run(tc.name, func(t *testing.T) {
 			ctx := t.Context()
@@ -191,3 +217,28 @@ func TestWorkspaceBindingValidateInvalid(t *testing.T) {
 		})
 	}
 }
+
+func TestWorkspacePipelineTaskBinding_ValidateSubPath(t *testing.T) {
+	for _, tc := range []struct {
+		subPath string
+		wantErr bool
+	}{
+		{subPath: ""},
+		{subPath: "cache"},
+		{subPath: "cache/./go"},
+		{subPath: "$(params.dir)/$(tasks.build.results.target)"},
+		{subPath: "$(context.pipelineRun.name)"},
+		{subPath: "cache..old"},
+		{subPath: "/etc", wantErr: true},
+		{subPath: "..", wantErr: true},
+		{subPath: "../cache", wantErr: true},
+		{subPath: "cache/../../etc", wantErr: true},
+	} {
+		t.Run(tc.subPath, func(t *testing.T) {
+			err := v1.WorkspacePipelineTaskBinding{Name: "source", SubPath: tc.subPath}.ValidateSubPath()
+			if (err != nil) != tc.wantErr {
+				t.Errorf("ValidateSubPath() = %v, want error: %t", err, tc.wantErr)
+			}
+		})
+	}
+}
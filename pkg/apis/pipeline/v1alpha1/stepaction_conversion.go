@@ -50,6 +50,12 @@ func (ss *StepActionSpec) ConvertTo(ctx context.Context, sink *v1beta1.StepActio
 	sink.Results = ss.Results
 	sink.SecurityContext = ss.SecurityContext
 	sink.VolumeMounts = ss.VolumeMounts
+	if ss.Uses != nil {
+		sink.Uses = &v1beta1.StepActionUses{
+			Ref:    ss.Uses.Ref,
+			Params: ss.Uses.Params,
+		}
+	}
 
 	return nil
 }
@@ -82,6 +88,12 @@ func (ss *StepActionSpec) ConvertFrom(ctx context.Context, source *v1beta1.StepA
 	ss.Results = source.Results
 	ss.SecurityContext = source.SecurityContext
 	ss.VolumeMounts = source.VolumeMounts
+	if source.Uses != nil {
+		ss.Uses = &StepActionUses{
+			Ref:    source.Uses.Ref,
+			Params: source.Uses.Params,
+		}
+	}
 
 	return nil
 }
@@ -71,6 +71,12 @@ spec:
       mountPath: /data
   securityContext:
     privileged: true
+  uses:
+    ref:
+      name: inner-step-action
+    params:
+    - name: inner-param
+      value: $(params.string-param)
 `
 
 	stepActionV1alpha1 := parse.MustParseV1alpha1StepAction(t, stepActionWithAllFieldsYaml)
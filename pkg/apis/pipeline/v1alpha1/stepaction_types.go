@@ -144,6 +144,25 @@ type StepActionSpec struct {
 	// +patchStrategy=merge
 	// +listType=atomic
 	VolumeMounts []corev1.VolumeMount `json:"volumeMounts,omitempty" patchMergeKey:"mountPath" patchStrategy:"merge" protobuf:"bytes,9,rep,name=volumeMounts"`
+	// Uses references another StepAction that this StepAction is built on.
+	// The image, environment, volume mounts and results of the referenced
+	// StepAction are inherited unless this StepAction overrides them.
+	// If this StepAction sets a command, the command and args of the referenced
+	// StepAction are appended to its args; otherwise they are used as they are.
+	// +optional
+	Uses *StepActionUses `json:"uses,omitempty"`
+}
+
+// StepActionUses references the StepAction that another StepAction is built on,
+// along with the values passed to its parameters.
+type StepActionUses struct {
+	// Ref references the StepAction to use.
+	Ref *v1.Ref `json:"ref"`
+	// Params are the values passed to the parameters of the referenced StepAction.
+	// They can refer to the parameters of the StepAction using it.
+	// +optional
+	// +listType=atomic
+	Params v1.Params `json:"params,omitempty"`
 }
 
 // ToStep converts the StepActionSpec to a Step struct
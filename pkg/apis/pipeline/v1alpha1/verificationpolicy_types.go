@@ -78,6 +78,15 @@ type ResourcePattern struct {
 	// Bundle resource: gcr.io/tekton-releases/catalog/upstream/git-clone, gcr.io/tekton-releases/catalog/upstream/*
 	// Hub resource: https://artifacthub.io/*,
 	Pattern string `json:"pattern"`
+	// Resolver restricts the pattern to the resources resolved by the named resolver, e.g. "git",
+	// "hub" or "bundles". The pattern applies to the resources of any resolver if it is empty.
+	// +optional
+	Resolver string `json:"resolver,omitempty"`
+	// Params restricts the pattern to the resources resolved with params whose string values match
+	// the regular expressions keyed by the names of the params, e.g. {"url": "https://github.com/my-org/.*"}.
+	// A resource resolved without one of the params doesn't match the pattern.
+	// +optional
+	Params map[string]string `json:"params,omitempty"`
 }
 
 // The Authority block defines the keys for validating signatures.
@@ -90,13 +90,18 @@ func (key *KeyRef) Validate(ctx context.Context) (errs *apis.FieldError) {
 	return errs
 }
 
-// Validate ResourcePattern and make sure the Pattern is valid regex expression
+// Validate ResourcePattern and make sure the Pattern and the patterns of the Params are valid regex expressions
 func (r *ResourcePattern) Validate(ctx context.Context) (errs *apis.FieldError) {
 	if _, err := regexp.Compile(r.Pattern); err != nil {
 		errs = errs.Also(apis.ErrInvalidValue(r.Pattern, "ResourcePattern", fmt.Sprintf("%v: %v", InvalidResourcePatternErr, err)))
 		return errs
 	}
-	return nil
+	for name, pattern := range r.Params {
+		if _, err := regexp.Compile(pattern); err != nil {
+			errs = errs.Also(apis.ErrInvalidValue(pattern, "", fmt.Sprintf("%v: %v", InvalidResourcePatternErr, err)).ViaKey(name).ViaField("params"))
+		}
+	}
+	return errs
 }
 
 // validateHashAlgorithm checks if the algorithm is supported
@@ -55,7 +55,7 @@ func TestVerificationPolicy_Invalid(t *testing.T) {
 				Name: "vp",
 			},
 			Spec: v1alpha1.VerificationPolicySpec{
-				Resources: []v1alpha1.ResourcePattern{{"^["}},
+				Resources: []v1alpha1.ResourcePattern{{Pattern: "^["}},
 				Authorities: []v1alpha1.Authority{
 					{
 						Name: "foo",
@@ -67,6 +67,29 @@ func TestVerificationPolicy_Invalid(t *testing.T) {
 			},
 		},
 		want: apis.ErrInvalidValue("^[", "ResourcePattern", fmt.Sprintf("%v: error parsing regexp: missing closing ]: `[`", v1alpha1.InvalidResourcePatternErr)),
+	}, {
+		name: "invalid pattern of a resolver param",
+		verificationPolicy: &v1alpha1.VerificationPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "vp",
+			},
+			Spec: v1alpha1.VerificationPolicySpec{
+				Resources: []v1alpha1.ResourcePattern{{Pattern: ".*", Resolver: "git", Params: map[string]string{"url": "^["}}},
+				Authorities: []v1alpha1.Authority{
+					{
+						Name: "foo",
+						Key: &v1alpha1.KeyRef{
+							Data: "inline_key",
+						},
+					},
+				},
+			},
+		},
+		want: &apis.FieldError{
+			Message: "invalid value: ^[",
+			Paths:   []string{"params[url]"},
+			Details: fmt.Sprintf("%v: error parsing regexp: missing closing ]: `[`", v1alpha1.InvalidResourcePatternErr),
+		},
 	}, {
 		name: "missing Authoritities",
 		verificationPolicy: &v1alpha1.VerificationPolicy{
@@ -74,7 +97,7 @@ func TestVerificationPolicy_Invalid(t *testing.T) {
 				Name: "vp",
 			},
 			Spec: v1alpha1.VerificationPolicySpec{
-				Resources: []v1alpha1.ResourcePattern{{".*"}},
+				Resources: []v1alpha1.ResourcePattern{{Pattern: ".*"}},
 			},
 		},
 		want: apis.ErrMissingField("authorities"),
@@ -85,7 +108,7 @@ func TestVerificationPolicy_Invalid(t *testing.T) {
 				Name: "vp",
 			},
 			Spec: v1alpha1.VerificationPolicySpec{
-				Resources: []v1alpha1.ResourcePattern{{".*"}},
+				Resources: []v1alpha1.ResourcePattern{{Pattern: ".*"}},
 				Authorities: []v1alpha1.Authority{
 					{
 						Name: "foo",
@@ -105,7 +128,7 @@ func TestVerificationPolicy_Invalid(t *testing.T) {
 				Name: "vp",
 			},
 			Spec: v1alpha1.VerificationPolicySpec{
-				Resources: []v1alpha1.ResourcePattern{{".*"}},
+				Resources: []v1alpha1.ResourcePattern{{Pattern: ".*"}},
 				Authorities: []v1alpha1.Authority{
 					{
 						Name: "foo",
@@ -122,7 +145,7 @@ func TestVerificationPolicy_Invalid(t *testing.T) {
 				Name: "vp",
 			},
 			Spec: v1alpha1.VerificationPolicySpec{
-				Resources: []v1alpha1.ResourcePattern{{".*"}},
+				Resources: []v1alpha1.ResourcePattern{{Pattern: ".*"}},
 				Authorities: []v1alpha1.Authority{
 					{
 						Name: "foo",
@@ -144,7 +167,7 @@ func TestVerificationPolicy_Invalid(t *testing.T) {
 				Name: "vp",
 			},
 			Spec: v1alpha1.VerificationPolicySpec{
-				Resources: []v1alpha1.ResourcePattern{{".*"}},
+				Resources: []v1alpha1.ResourcePattern{{Pattern: ".*"}},
 				Authorities: []v1alpha1.Authority{
 					{
 						Name: "foo",
@@ -164,7 +187,7 @@ func TestVerificationPolicy_Invalid(t *testing.T) {
 				Name: "vp",
 			},
 			Spec: v1alpha1.VerificationPolicySpec{
-				Resources: []v1alpha1.ResourcePattern{{".*"}},
+				Resources: []v1alpha1.ResourcePattern{{Pattern: ".*"}},
 				Authorities: []v1alpha1.Authority{
 					{
 						Name: "foo",
@@ -187,7 +210,7 @@ func TestVerificationPolicy_Invalid(t *testing.T) {
 				Name: "vp",
 			},
 			Spec: v1alpha1.VerificationPolicySpec{
-				Resources: []v1alpha1.ResourcePattern{{".*"}},
+				Resources: []v1alpha1.ResourcePattern{{Pattern: ".*"}},
 				Authorities: []v1alpha1.Authority{
 					{
 						Name: "foo",
@@ -211,7 +234,7 @@ func TestVerificationPolicy_Invalid(t *testing.T) {
 				Name: "vp",
 			},
 			Spec: v1alpha1.VerificationPolicySpec{
-				Resources: []v1alpha1.ResourcePattern{{".*"}},
+				Resources: []v1alpha1.ResourcePattern{{Pattern: ".*"}},
 				Authorities: []v1alpha1.Authority{
 					{
 						Name: "foo",
@@ -247,7 +270,7 @@ func TestVerificationPolicy_Valid(t *testing.T) {
 					Name: "vp",
 				},
 				Spec: v1alpha1.VerificationPolicySpec{
-					Resources: []v1alpha1.ResourcePattern{{".*"}},
+					Resources: []v1alpha1.ResourcePattern{{Pattern: ".*"}},
 					Authorities: []v1alpha1.Authority{
 						{
 							Name: "foo",
@@ -266,7 +289,7 @@ func TestVerificationPolicy_Valid(t *testing.T) {
 					Name: "vp",
 				},
 				Spec: v1alpha1.VerificationPolicySpec{
-					Resources: []v1alpha1.ResourcePattern{{".*"}},
+					Resources: []v1alpha1.ResourcePattern{{Pattern: ".*"}},
 					Authorities: []v1alpha1.Authority{
 						{
 							Name: "foo",
@@ -287,7 +310,7 @@ func TestVerificationPolicy_Valid(t *testing.T) {
 					Name: "vp",
 				},
 				Spec: v1alpha1.VerificationPolicySpec{
-					Resources: []v1alpha1.ResourcePattern{{".*"}},
+					Resources: []v1alpha1.ResourcePattern{{Pattern: ".*"}},
 					Authorities: []v1alpha1.Authority{
 						{
 							Name: "foo",
@@ -305,7 +328,7 @@ func TestVerificationPolicy_Valid(t *testing.T) {
 					Name: "vp",
 				},
 				Spec: v1alpha1.VerificationPolicySpec{
-					Resources: []v1alpha1.ResourcePattern{{".*"}},
+					Resources: []v1alpha1.ResourcePattern{{Pattern: ".*"}},
 					Authorities: []v1alpha1.Authority{
 						{
 							Name: "foo",
@@ -324,7 +347,7 @@ func TestVerificationPolicy_Valid(t *testing.T) {
 					Name: "vp",
 				},
 				Spec: v1alpha1.VerificationPolicySpec{
-					Resources: []v1alpha1.ResourcePattern{{".*"}},
+					Resources: []v1alpha1.ResourcePattern{{Pattern: ".*"}},
 					Authorities: []v1alpha1.Authority{
 						{
 							Name: "foo",
@@ -94,6 +94,13 @@ func (in *KeyRef) DeepCopy() *KeyRef {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResourcePattern) DeepCopyInto(out *ResourcePattern) {
 	*out = *in
+	if in.Params != nil {
+		in, out := &in.Params, &out.Params
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	return
 }
 
@@ -324,6 +331,11 @@ func (in *StepActionSpec) DeepCopyInto(out *StepActionSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Uses != nil {
+		in, out := &in.Uses, &out.Uses
+		*out = new(StepActionUses)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -337,6 +349,34 @@ func (in *StepActionSpec) DeepCopy() *StepActionSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StepActionUses) DeepCopyInto(out *StepActionUses) {
+	*out = *in
+	if in.Ref != nil {
+		in, out := &in.Ref, &out.Ref
+		*out = new(pipelinev1.Ref)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Params != nil {
+		in, out := &in.Params, &out.Params
+		*out = make(pipelinev1.Params, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StepActionUses.
+func (in *StepActionUses) DeepCopy() *StepActionUses {
+	if in == nil {
+		return nil
+	}
+	out := new(StepActionUses)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VerificationPolicy) DeepCopyInto(out *VerificationPolicy) {
 	*out = *in
@@ -403,7 +443,9 @@ func (in *VerificationPolicySpec) DeepCopyInto(out *VerificationPolicySpec) {
 	if in.Resources != nil {
 		in, out := &in.Resources, &out.Resources
 		*out = make([]ResourcePattern, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.Authorities != nil {
 		in, out := &in.Authorities, &out.Authorities
@@ -0,0 +1,103 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+// ApprovalGate pauses the PipelineRun when the PipelineTask is reached, until one of
+// its approvers approves or rejects it.
+type ApprovalGate struct {
+	// Approvers are the names of the users who may approve or reject the gate,
+	// as authenticated by the Kubernetes API server.
+	// +listType=atomic
+	Approvers []string `json:"approvers"`
+
+	// Timeout is how long the gate waits for a decision before failing. It is
+	// independent of the timeouts of the PipelineTasks; if it isn't set, the gate
+	// waits until the PipelineRun times out.
+	// Refer Go's ParseDuration documentation for expected format: https://golang.org/pkg/time/#ParseDuration
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// ApprovalDecision is the decision taken on an approval gate.
+type ApprovalDecision string
+
+const (
+	// ApprovalDecisionApprove lets the PipelineRun go on past the approval gate.
+	ApprovalDecisionApprove ApprovalDecision = "approve"
+	// ApprovalDecisionReject fails the approval gate, and with it the PipelineRun.
+	ApprovalDecisionReject ApprovalDecision = "reject"
+)
+
+// ApprovalGateReason is the state of an approval gate which was reached.
+type ApprovalGateReason string
+
+const (
+	// ApprovalGateReasonWaiting is the reason of a gate waiting for the decision of one of its approvers.
+	ApprovalGateReasonWaiting ApprovalGateReason = "WaitingForApproval"
+	// ApprovalGateReasonApproved is the reason of a gate approved by one of its approvers.
+	ApprovalGateReasonApproved ApprovalGateReason = "Approved"
+	// ApprovalGateReasonRejected is the reason of a gate rejected by one of its approvers.
+	ApprovalGateReasonRejected ApprovalGateReason = "Rejected"
+	// ApprovalGateReasonTimedOut is the reason of a gate which timed out before any decision was taken.
+	ApprovalGateReasonTimedOut ApprovalGateReason = "TimedOut"
+	// ApprovalGateReasonCancelled is the reason of a gate which was waiting when the PipelineRun was cancelled or stopped.
+	ApprovalGateReasonCancelled ApprovalGateReason = "Cancelled"
+)
+
+// ApprovalGateStatus is the status of an approval gate of the PipelineRun which was reached.
+type ApprovalGateStatus struct {
+	// PipelineTaskName is the name of the PipelineTask of the approval gate.
+	PipelineTaskName string `json:"pipelineTaskName"`
+
+	// Approvers are the names of the users who may approve or reject the gate.
+	// +listType=atomic
+	Approvers []string `json:"approvers"`
+
+	// Reason is the state of the approval gate.
+	Reason ApprovalGateReason `json:"reason"`
+
+	// StartTime is the time the approval gate was reached.
+	StartTime metav1.Time `json:"startTime"`
+
+	// CompletionTime is the time the approval gate was approved, rejected, timed out or cancelled.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// Approval is the decision of an approver on an approval gate of the PipelineRun.
+// Approvals are added by the approvers to the status of the PipelineRun, through
+// its status subresource; they can't be changed or removed once added.
+type Approval struct {
+	// PipelineTaskName is the name of the PipelineTask of the approval gate.
+	PipelineTaskName string `json:"pipelineTaskName"`
+
+	// Approver is the name of the user who took the decision, which must be
+	// the name the user is authenticated with.
+	Approver string `json:"approver"`
+
+	// Decision is either "approve" or "reject".
+	Decision ApprovalDecision `json:"decision"`
+
+	// Time is when the decision was taken.
+	Time metav1.Time `json:"time"`
+
+	// Message explains the decision.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
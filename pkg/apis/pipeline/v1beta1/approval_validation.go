@@ -0,0 +1,203 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"knative.dev/pkg/apis"
+)
+
+const approvalGate = "approvalGate"
+
+// validateApprovalGate validates a PipelineTask which is an approval gate: it only waits for a
+// decision, so it can't run a Task or a Pipeline, nor set the fields configuring how they run.
+func (pt PipelineTask) validateApprovalGate(ctx context.Context) (errs *apis.FieldError) {
+	errs = errs.Also(config.ValidateEnabledAPIFields(ctx, approvalGate, config.AlphaAPIFields))
+
+	var disallowed []string
+	if pt.TaskRef != nil {
+		disallowed = append(disallowed, taskRef)
+	}
+	if pt.TaskSpec != nil {
+		disallowed = append(disallowed, taskSpec)
+	}
+	if pt.PipelineRef != nil {
+		disallowed = append(disallowed, pipelineRef)
+	}
+	if pt.PipelineSpec != nil {
+		disallowed = append(disallowed, pipelineSpec)
+	}
+	if pt.Resources != nil {
+		disallowed = append(disallowed, "resources")
+	}
+	if len(pt.Params) > 0 {
+		disallowed = append(disallowed, "params")
+	}
+	if pt.Matrix != nil {
+		disallowed = append(disallowed, "matrix")
+	}
+	if len(pt.Workspaces) > 0 {
+		disallowed = append(disallowed, "workspaces")
+	}
+	if pt.Retries != 0 {
+		disallowed = append(disallowed, "retries")
+	}
+	if pt.Timeout != nil {
+		disallowed = append(disallowed, "timeout")
+	}
+	if pt.ExpectedDuration != nil {
+		disallowed = append(disallowed, "expectedDuration")
+	}
+	if pt.OnError != "" {
+		disallowed = append(disallowed, "onError")
+	}
+	if len(disallowed) > 0 {
+		errs = errs.Also(&apis.FieldError{
+			Message: "must not set the field(s) with an approval gate",
+			Paths:   disallowed,
+			Details: "approval gates only wait for a decision; their timeout is set with approvalGate.timeout",
+		})
+	}
+	return errs.Also(pt.ApprovalGate.validate().ViaField(approvalGate))
+}
+
+func (g *ApprovalGate) validate() (errs *apis.FieldError) {
+	if len(g.Approvers) == 0 {
+		errs = errs.Also(apis.ErrMissingField("approvers"))
+	}
+	seen := sets.New[string]()
+	for i, approver := range g.Approvers {
+		switch {
+		case strings.TrimSpace(approver) == "":
+			errs = errs.Also(apis.ErrInvalidValue(approver, "", "approver names can't be empty").ViaFieldIndex("approvers", i))
+		case seen.Has(approver):
+			errs = errs.Also(apis.ErrInvalidValue(approver, "", "approvers must be unique").ViaFieldIndex("approvers", i))
+		}
+		seen.Insert(approver)
+	}
+	if g.Timeout != nil && g.Timeout.Duration <= 0 {
+		errs = errs.Also(apis.ErrInvalidValue(g.Timeout.Duration.String(), "timeout", "should be > 0"))
+	}
+	return errs
+}
+
+// validateApprovalGatesNotInFinally rejects approval gates among the finally tasks, which run
+// once the PipelineRun is over and so can't pause it anymore.
+func validateApprovalGatesNotInFinally(finally []PipelineTask) (errs *apis.FieldError) {
+	for idx, f := range finally {
+		if f.ApprovalGate != nil {
+			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("final task %s can't be an approval gate", f.Name), approvalGate).ViaFieldIndex("finally", idx))
+		}
+	}
+	return errs
+}
+
+// validateApprovalGateResultReferencesDisallowed rejects result references to approval gates,
+// which don't produce results.
+func validateApprovalGateResultReferencesDisallowed(tasks []PipelineTask, finally []PipelineTask) (errs *apis.FieldError) {
+	gates := sets.NewString()
+	for _, t := range tasks {
+		if t.ApprovalGate != nil {
+			gates.Insert(t.Name)
+		}
+	}
+	if gates.Len() == 0 {
+		return nil
+	}
+
+	check := func(pts []PipelineTask, field string) {
+		for idx, pt := range pts {
+			for _, ref := range PipelineTaskResultRefs(&pt) {
+				if gates.Has(ref.PipelineTask) {
+					errs = errs.Also(apis.ErrInvalidValue(
+						fmt.Sprintf("result reference to pipelineTask %q is not supported: approval gates don't produce results", ref.PipelineTask),
+						"").ViaFieldIndex(field, idx))
+				}
+			}
+		}
+	}
+	check(tasks, "tasks")
+	check(finally, "finally")
+	return errs
+}
+
+// validateApprovals validates the approvals added to the status of the PipelineRun through its
+// status subresource. Approvals can't be changed or removed once added, and each approval added
+// must be the decision of one of the approvers of an approval gate waiting for a decision, added
+// by the approver. The status updates of the PipelineRun reconciler, which don't add approvals,
+// are rejected if they drop approvals added since the PipelineRun was last read, so that they
+// are retried with the approvals.
+func (prs *PipelineRunStatus) validateApprovals(ctx context.Context) (errs *apis.FieldError) {
+	old, ok := apis.GetBaseline(ctx).(*PipelineRun)
+	if !ok || old == nil {
+		return nil
+	}
+	oldApprovals := old.Status.Approvals
+	if len(prs.Approvals) < len(oldApprovals) || !equality.Semantic.DeepEqual(oldApprovals, prs.Approvals[:len(oldApprovals)]) {
+		return apis.ErrInvalidValue("approvals can't be changed or removed once added", "approvals")
+	}
+
+	gates := make(map[string]ApprovalGateStatus, len(old.Status.ApprovalGates))
+	for _, g := range old.Status.ApprovalGates {
+		gates[g.PipelineTaskName] = g
+	}
+	decided := sets.New[string]()
+	for _, a := range oldApprovals {
+		decided.Insert(a.PipelineTaskName)
+	}
+	for i := len(oldApprovals); i < len(prs.Approvals); i++ {
+		a := prs.Approvals[i]
+		errs = errs.Also(a.validate(ctx, gates, decided).ViaFieldIndex("approvals", i))
+		decided.Insert(a.PipelineTaskName)
+	}
+	return errs
+}
+
+func (a Approval) validate(ctx context.Context, gates map[string]ApprovalGateStatus, decided sets.Set[string]) (errs *apis.FieldError) {
+	if a.Decision != ApprovalDecisionApprove && a.Decision != ApprovalDecisionReject {
+		errs = errs.Also(apis.ErrInvalidValue(a.Decision, "decision", fmt.Sprintf("must be either %q or %q", ApprovalDecisionApprove, ApprovalDecisionReject)))
+	}
+	if a.Time.IsZero() {
+		errs = errs.Also(apis.ErrMissingField("time"))
+	}
+
+	gate, ok := gates[a.PipelineTaskName]
+	switch {
+	case !ok:
+		errs = errs.Also(apis.ErrInvalidValue(a.PipelineTaskName, "pipelineTaskName", "no approval gate with this name was reached"))
+	case gate.Reason != ApprovalGateReasonWaiting || decided.Has(a.PipelineTaskName):
+		errs = errs.Also(apis.ErrInvalidValue(a.PipelineTaskName, "pipelineTaskName", "the approval gate isn't waiting for a decision anymore"))
+	case !slices.Contains(gate.Approvers, a.Approver):
+		errs = errs.Also(apis.ErrInvalidValue(a.Approver, "approver", fmt.Sprintf("not one of the approvers of the approval gate: %s", strings.Join(gate.Approvers, ", "))))
+	}
+
+	user := apis.GetUserInfo(ctx)
+	switch {
+	case user == nil:
+		errs = errs.Also(apis.ErrGeneric("approvals can only be added by authenticated users", "approver"))
+	case user.Username != a.Approver:
+		errs = errs.Also(apis.ErrInvalidValue(a.Approver, "approver", fmt.Sprintf("approvals must be added by the approver, not by %q", user.Username)))
+	}
+	return errs
+}
@@ -0,0 +1,162 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	cfgtesting "github.com/tektoncd/pipeline/pkg/apis/config/testing"
+	"github.com/tektoncd/pipeline/test/diff"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+func TestPipelineSpec_ValidateApprovalGate(t *testing.T) {
+	for _, tc := range []struct {
+		name          string
+		ps            *PipelineSpec
+		wc            func(context.Context) context.Context
+		expectedError string
+	}{{
+		name: "valid approval gate",
+		ps: &PipelineSpec{
+			Tasks: []PipelineTask{{
+				Name:    "build",
+				TaskRef: &TaskRef{Name: "build"},
+			}, {
+				Name:         "approve-release",
+				ApprovalGate: &ApprovalGate{Approvers: []string{"alice", "bob"}, Timeout: &metav1.Duration{Duration: time.Hour}},
+				RunAfter:     []string{"build"},
+			}, {
+				Name:     "release",
+				TaskRef:  &TaskRef{Name: "release"},
+				RunAfter: []string{"approve-release"},
+			}},
+		},
+		wc: cfgtesting.EnableAlphaAPIFields,
+	}, {
+		name: "approval gate with a task",
+		ps: &PipelineSpec{
+			Tasks: []PipelineTask{{
+				Name:         "approve",
+				TaskRef:      &TaskRef{Name: "build"},
+				ApprovalGate: &ApprovalGate{Approvers: []string{"alice"}},
+			}},
+		},
+		wc:            cfgtesting.EnableAlphaAPIFields,
+		expectedError: "must not set the field(s) with an approval gate: tasks[0].taskRef\napproval gates only wait for a decision; their timeout is set with approvalGate.timeout",
+	}, {
+		name: "approval gate without approvers",
+		ps: &PipelineSpec{
+			Tasks: []PipelineTask{{
+				Name:         "approve",
+				ApprovalGate: &ApprovalGate{},
+			}},
+		},
+		wc:            cfgtesting.EnableAlphaAPIFields,
+		expectedError: "missing field(s): tasks[0].approvalGate.approvers",
+	}, {
+		name: "approval gate in finally",
+		ps: &PipelineSpec{
+			Tasks: []PipelineTask{{
+				Name:    "build",
+				TaskRef: &TaskRef{Name: "build"},
+			}},
+			Finally: []PipelineTask{{
+				Name:         "approve",
+				ApprovalGate: &ApprovalGate{Approvers: []string{"alice"}},
+			}},
+		},
+		wc:            cfgtesting.EnableAlphaAPIFields,
+		expectedError: "invalid value: final task approve can't be an approval gate: finally[0].approvalGate",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := t.Context()
+			if tc.wc != nil {
+				ctx = tc.wc(ctx)
+			}
+			var got string
+			if err := tc.ps.Validate(ctx); err != nil {
+				got = err.Error()
+			}
+			if d := cmp.Diff(tc.expectedError, got); d != "" {
+				t.Errorf("PipelineSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestPipelineRun_ValidateApprovals(t *testing.T) {
+	now := metav1.NewTime(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC))
+	pipelineRun := func(approvals ...Approval) *PipelineRun {
+		return &PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{Name: "pr"},
+			Spec:       PipelineRunSpec{PipelineRef: &PipelineRef{Name: "release"}},
+			Status: PipelineRunStatus{PipelineRunStatusFields: PipelineRunStatusFields{
+				ApprovalGates: []ApprovalGateStatus{{
+					PipelineTaskName: "approve",
+					Approvers:        []string{"alice", "bob"},
+					Reason:           ApprovalGateReasonWaiting,
+					StartTime:        now,
+				}},
+				Approvals: approvals,
+			}},
+		}
+	}
+	approval := Approval{PipelineTaskName: "approve", Approver: "bob", Decision: ApprovalDecisionApprove, Time: now}
+
+	for _, tc := range []struct {
+		name          string
+		baseline      *PipelineRun
+		pr            *PipelineRun
+		user          string
+		expectedError string
+	}{{
+		name:     "approved by an approver",
+		baseline: pipelineRun(),
+		pr:       pipelineRun(approval),
+		user:     "bob",
+	}, {
+		name:          "approval added by another user",
+		baseline:      pipelineRun(),
+		pr:            pipelineRun(approval),
+		user:          "alice",
+		expectedError: "invalid value: bob: status.approvals[0].approver\napprovals must be added by the approver, not by \"alice\"",
+	}, {
+		name:          "approval removed",
+		baseline:      pipelineRun(approval),
+		pr:            pipelineRun(),
+		user:          "bob",
+		expectedError: "invalid value: approvals can't be changed or removed once added: status.approvals",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := apis.WithinSubResourceUpdate(t.Context(), tc.baseline, "status")
+			ctx = apis.WithUserInfo(ctx, &authenticationv1.UserInfo{Username: tc.user})
+			var got string
+			if err := tc.pr.Validate(ctx); err != nil {
+				got = err.Error()
+			}
+			if d := cmp.Diff(tc.expectedError, got); d != "" {
+				t.Errorf("PipelineRun.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
@@ -18,6 +18,17 @@ type Artifact struct {
 type ArtifactValue struct {
 	Digest map[Algorithm]string `json:"digest,omitempty"` // Algorithm-specific digests for verifying the content (e.g., SHA256)
 	Uri    string               `json:"uri,omitempty"`    // Location where the artifact value can be retrieved
+	// Whether the digest matched the one the Task expected for the artifact
+	Verified bool `json:"verified,omitempty"`
+}
+
+// ExpectedArtifactDigest declares the digest an output artifact of the Steps of a Task must have.
+type ExpectedArtifactDigest struct {
+	// Name is the name of the output artifact.
+	Name string `json:"name"`
+	// Digest is the expected digest, in the "<algorithm>:<value>" format, e.g. "sha256:$(params.digest)".
+	// The digest is not checked when it is empty, e.g. because the param it refers to is empty.
+	Digest string `json:"digest"`
 }
 
 // TaskRunStepArtifact represents an artifact produced or used by a step within a task run.
@@ -60,6 +60,7 @@ func (s Step) convertTo(ctx context.Context, sink *v1.Step) {
 		sink.Workspaces = append(sink.Workspaces, new)
 	}
 	sink.OnError = (v1.OnErrorType)(s.OnError)
+	sink.Retries = s.Retries
 	sink.StdoutConfig = (*v1.StepOutputConfig)(s.StdoutConfig)
 	sink.StderrConfig = (*v1.StepOutputConfig)(s.StderrConfig)
 	if s.Ref != nil {
@@ -78,6 +79,9 @@ func (s Step) convertTo(ctx context.Context, sink *v1.Step) {
 		w.convertTo(ctx, &new)
 		sink.When = append(sink.When, new)
 	}
+	sink.SecurityProfile = (v1.StepSecurityProfile)(s.SecurityProfile)
+	sink.Capabilities = s.Capabilities
+	sink.AutomountServiceAccountToken = s.AutomountServiceAccountToken
 }
 
 func (s *Step) convertFrom(ctx context.Context, source v1.Step) {
@@ -104,6 +108,7 @@ func (s *Step) convertFrom(ctx context.Context, source v1.Step) {
 		s.Workspaces = append(s.Workspaces, new)
 	}
 	s.OnError = (OnErrorType)(source.OnError)
+	s.Retries = source.Retries
 	s.StdoutConfig = (*StepOutputConfig)(source.StdoutConfig)
 	s.StderrConfig = (*StepOutputConfig)(source.StderrConfig)
 	if source.Ref != nil {
@@ -123,6 +128,9 @@ func (s *Step) convertFrom(ctx context.Context, source v1.Step) {
 		new.convertFrom(ctx, w)
 		s.When = append(s.When, new)
 	}
+	s.SecurityProfile = (StepSecurityProfile)(source.SecurityProfile)
+	s.Capabilities = source.Capabilities
+	s.AutomountServiceAccountToken = source.AutomountServiceAccountToken
 }
 
 func (s StepTemplate) convertTo(ctx context.Context, sink *v1.StepTemplate) {
@@ -183,6 +191,8 @@ func (s Sidecar) convertTo(ctx context.Context, sink *v1.Sidecar) {
 		w.convertTo(ctx, &new)
 		sink.Workspaces = append(sink.Workspaces, new)
 	}
+	sink.Results = s.Results
+	sink.OnError = (v1.OnErrorType)(s.OnError)
 }
 
 func (s *Sidecar) convertFrom(ctx context.Context, source v1.Sidecar) {
@@ -215,4 +225,6 @@ func (s *Sidecar) convertFrom(ctx context.Context, source v1.Sidecar) {
 		new.convertFrom(ctx, w)
 		s.Workspaces = append(s.Workspaces, new)
 	}
+	s.Results = source.Results
+	s.OnError = (OnErrorType)(source.OnError)
 }
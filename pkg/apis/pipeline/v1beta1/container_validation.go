@@ -24,6 +24,7 @@ import (
 	"strings"
 
 	"github.com/tektoncd/pipeline/pkg/apis/config"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/validation"
 	"knative.dev/pkg/apis"
 )
@@ -97,3 +98,119 @@ func RefNameLikeUrl(name string) error {
 	}
 	return nil
 }
+
+// sidecarVariableField is a field of a Sidecar in which variables are substituted.
+// +k8s:openapi-gen=false
+type sidecarVariableField struct {
+	path  string
+	value string
+	// expandsArrays is set for the fields which array params are expanded into, where the array
+	// params have to be referenced on their own.
+	expandsArrays bool
+}
+
+// variableFields returns the fields of the Sidecar in which variables are substituted, i.e. the
+// fields a reference to a param, a workspace or a context variable can be used in.
+func (sc *Sidecar) variableFields() []sidecarVariableField {
+	fields := []sidecarVariableField{
+		{path: "name", value: sc.Name},
+		{path: "image", value: sc.Image},
+		{path: "imagePullPolicy", value: string(sc.ImagePullPolicy)},
+		{path: "workingDir", value: sc.WorkingDir},
+		{path: "script", value: sc.Script},
+	}
+	for i, cmd := range sc.Command {
+		fields = append(fields, sidecarVariableField{path: fmt.Sprintf("command[%d]", i), value: cmd, expandsArrays: true})
+	}
+	for i, arg := range sc.Args {
+		fields = append(fields, sidecarVariableField{path: fmt.Sprintf("args[%d]", i), value: arg, expandsArrays: true})
+	}
+	for _, env := range sc.Env {
+		path := fmt.Sprintf("env[%s]", env.Name)
+		fields = append(fields, sidecarVariableField{path: path, value: env.Value})
+		if env.ValueFrom == nil {
+			continue
+		}
+		if ref := env.ValueFrom.FieldRef; ref != nil {
+			fields = append(fields, sidecarVariableField{path: path + ".valueFrom.fieldRef.fieldPath", value: ref.FieldPath})
+		}
+		if ref := env.ValueFrom.ResourceFieldRef; ref != nil {
+			fields = append(fields,
+				sidecarVariableField{path: path + ".valueFrom.resourceFieldRef.containerName", value: ref.ContainerName},
+				sidecarVariableField{path: path + ".valueFrom.resourceFieldRef.resource", value: ref.Resource})
+		}
+		if ref := env.ValueFrom.SecretKeyRef; ref != nil {
+			fields = append(fields,
+				sidecarVariableField{path: path + ".valueFrom.secretKeyRef.name", value: ref.Name},
+				sidecarVariableField{path: path + ".valueFrom.secretKeyRef.key", value: ref.Key})
+		}
+		if ref := env.ValueFrom.ConfigMapKeyRef; ref != nil {
+			fields = append(fields,
+				sidecarVariableField{path: path + ".valueFrom.configMapKeyRef.name", value: ref.Name},
+				sidecarVariableField{path: path + ".valueFrom.configMapKeyRef.key", value: ref.Key})
+		}
+	}
+	for i, env := range sc.EnvFrom {
+		path := fmt.Sprintf("envFrom[%d]", i)
+		fields = append(fields, sidecarVariableField{path: path + ".prefix", value: env.Prefix})
+		if env.ConfigMapRef != nil {
+			fields = append(fields, sidecarVariableField{path: path + ".configMapRef.name", value: env.ConfigMapRef.Name})
+		}
+		if env.SecretRef != nil {
+			fields = append(fields, sidecarVariableField{path: path + ".secretRef.name", value: env.SecretRef.Name})
+		}
+	}
+	for i, v := range sc.VolumeMounts {
+		path := fmt.Sprintf("volumeMounts[%d]", i)
+		fields = append(fields,
+			sidecarVariableField{path: path + ".name", value: v.Name},
+			sidecarVariableField{path: path + ".mountPath", value: v.MountPath},
+			sidecarVariableField{path: path + ".subPath", value: v.SubPath})
+	}
+	for _, probe := range []struct {
+		path  string
+		probe *corev1.Probe
+	}{{"livenessProbe", sc.LivenessProbe}, {"readinessProbe", sc.ReadinessProbe}, {"startupProbe", sc.StartupProbe}} {
+		if probe.probe == nil {
+			continue
+		}
+		fields = append(fields, handlerVariableFields(probe.path, probe.probe.Exec, probe.probe.HTTPGet, probe.probe.TCPSocket)...)
+		if grpc := probe.probe.GRPC; grpc != nil && grpc.Service != nil {
+			fields = append(fields, sidecarVariableField{path: probe.path + ".grpc.service", value: *grpc.Service})
+		}
+	}
+	if sc.Lifecycle != nil {
+		if h := sc.Lifecycle.PostStart; h != nil {
+			fields = append(fields, handlerVariableFields("lifecycle.postStart", h.Exec, h.HTTPGet, h.TCPSocket)...)
+		}
+		if h := sc.Lifecycle.PreStop; h != nil {
+			fields = append(fields, handlerVariableFields("lifecycle.preStop", h.Exec, h.HTTPGet, h.TCPSocket)...)
+		}
+	}
+	return fields
+}
+
+// handlerVariableFields returns the fields of the actions of a probe or a lifecycle hook in which variables are substituted.
+func handlerVariableFields(path string, exec *corev1.ExecAction, httpGet *corev1.HTTPGetAction, tcpSocket *corev1.TCPSocketAction) []sidecarVariableField {
+	var fields []sidecarVariableField
+	if exec != nil {
+		for i, cmd := range exec.Command {
+			fields = append(fields, sidecarVariableField{path: fmt.Sprintf("%s.exec.command[%d]", path, i), value: cmd, expandsArrays: true})
+		}
+	}
+	if httpGet != nil {
+		fields = append(fields,
+			sidecarVariableField{path: path + ".httpGet.path", value: httpGet.Path},
+			sidecarVariableField{path: path + ".httpGet.host", value: httpGet.Host},
+			sidecarVariableField{path: path + ".httpGet.port", value: httpGet.Port.StrVal})
+		for i, h := range httpGet.HTTPHeaders {
+			fields = append(fields, sidecarVariableField{path: fmt.Sprintf("%s.httpGet.httpHeaders[%d].value", path, i), value: h.Value})
+		}
+	}
+	if tcpSocket != nil {
+		fields = append(fields,
+			sidecarVariableField{path: path + ".tcpSocket.host", value: tcpSocket.Host},
+			sidecarVariableField{path: path + ".tcpSocket.port", value: tcpSocket.Port.StrVal})
+	}
+	return fields
+}
@@ -31,6 +31,10 @@ func (p ParamSpec) convertTo(ctx context.Context, sink *v1.ParamSpec) {
 	}
 	sink.Description = p.Description
 	sink.Enum = p.Enum
+	sink.AsFile = p.AsFile
+	sink.Pattern = p.Pattern
+	sink.MinLength = p.MinLength
+	sink.MaxLength = p.MaxLength
 	var properties map[string]v1.PropertySpec
 	if p.Properties != nil {
 		properties = make(map[string]v1.PropertySpec)
@@ -56,6 +60,10 @@ func (p *ParamSpec) convertFrom(ctx context.Context, source v1.ParamSpec) {
 	}
 	p.Description = source.Description
 	p.Enum = source.Enum
+	p.AsFile = source.AsFile
+	p.Pattern = source.Pattern
+	p.MinLength = source.MinLength
+	p.MaxLength = source.MaxLength
 	var properties map[string]PropertySpec
 	if source.Properties != nil {
 		properties = make(map[string]PropertySpec)
@@ -77,6 +85,9 @@ func (p Param) convertTo(ctx context.Context, sink *v1.Param) {
 	newValue := v1.ParamValue{}
 	p.Value.convertTo(ctx, &newValue)
 	sink.Value = newValue
+	if p.RunRef != nil {
+		sink.RunRef = &v1.PipelineRunResultRef{Name: p.RunRef.Name, Result: p.RunRef.Result, AllowFailed: p.RunRef.AllowFailed}
+	}
 }
 
 // ConvertFrom converts v1beta1 Param from v1 Param
@@ -85,6 +96,9 @@ func (p *Param) ConvertFrom(ctx context.Context, source v1.Param) {
 	newValue := ParamValue{}
 	newValue.convertFrom(ctx, source.Value)
 	p.Value = newValue
+	if source.RunRef != nil {
+		p.RunRef = &PipelineRunResultRef{Name: source.RunRef.Name, Result: source.RunRef.Result, AllowFailed: source.RunRef.AllowFailed}
+	}
 }
 
 func (v ParamValue) convertTo(ctx context.Context, sink *v1.ParamValue) {
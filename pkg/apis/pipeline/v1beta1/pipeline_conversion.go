@@ -150,6 +150,7 @@ func (pt PipelineTask) convertTo(ctx context.Context, sink *v1.PipelineTask, met
 	sink.Name = pt.Name
 	sink.DisplayName = pt.DisplayName
 	sink.Description = pt.Description
+	sink.Labels = pt.Labels
 	if pt.TaskRef != nil {
 		sink.TaskRef = &v1.TaskRef{}
 		pt.TaskRef.convertTo(ctx, sink.TaskRef)
@@ -169,6 +170,7 @@ func (pt PipelineTask) convertTo(ctx context.Context, sink *v1.PipelineTask, met
 	}
 	sink.OnError = (v1.PipelineTaskOnErrorType)(pt.OnError)
 	sink.Retries = pt.Retries
+	sink.RetriesFrom = pt.RetriesFrom
 	sink.RunAfter = pt.RunAfter
 	sink.Params = nil
 	for _, p := range pt.Params {
@@ -190,6 +192,13 @@ func (pt PipelineTask) convertTo(ctx context.Context, sink *v1.PipelineTask, met
 	}
 
 	sink.Timeout = pt.Timeout
+	sink.TimeoutFrom = pt.TimeoutFrom
+	sink.ExpectedDuration = pt.ExpectedDuration
+	sink.ApprovalGate = nil
+	if pt.ApprovalGate != nil {
+		new := v1.ApprovalGate(*pt.ApprovalGate)
+		sink.ApprovalGate = &new
+	}
 	return nil
 }
 
@@ -197,6 +206,7 @@ func (pt *PipelineTask) convertFrom(ctx context.Context, source v1.PipelineTask,
 	pt.Name = source.Name
 	pt.DisplayName = source.DisplayName
 	pt.Description = source.Description
+	pt.Labels = source.Labels
 	if source.TaskRef != nil {
 		newTaskRef := TaskRef{}
 		newTaskRef.ConvertFrom(ctx, *source.TaskRef)
@@ -218,6 +228,7 @@ func (pt *PipelineTask) convertFrom(ctx context.Context, source v1.PipelineTask,
 	}
 	pt.OnError = (PipelineTaskOnErrorType)(source.OnError)
 	pt.Retries = source.Retries
+	pt.RetriesFrom = source.RetriesFrom
 	pt.RunAfter = source.RunAfter
 	pt.Params = nil
 	for _, p := range source.Params {
@@ -239,6 +250,13 @@ func (pt *PipelineTask) convertFrom(ctx context.Context, source v1.PipelineTask,
 	}
 
 	pt.Timeout = source.Timeout
+	pt.TimeoutFrom = source.TimeoutFrom
+	pt.ExpectedDuration = source.ExpectedDuration
+	pt.ApprovalGate = nil
+	if source.ApprovalGate != nil {
+		new := ApprovalGate(*source.ApprovalGate)
+		pt.ApprovalGate = &new
+	}
 	return nil
 }
 
@@ -286,6 +304,7 @@ func (m *Matrix) convertTo(ctx context.Context, sink *v1.Matrix) {
 			sink.Include[i].Params = append(sink.Include[i].Params, newIncludeParam)
 		}
 	}
+	sink.FailFast = m.FailFast
 }
 
 func (m *Matrix) convertFrom(ctx context.Context, source v1.Matrix) {
@@ -303,6 +322,7 @@ func (m *Matrix) convertFrom(ctx context.Context, source v1.Matrix) {
 			m.Include[i].Params = append(m.Include[i].Params, new)
 		}
 	}
+	m.FailFast = source.FailFast
 }
 
 func (pr PipelineResult) convertTo(ctx context.Context, sink *v1.PipelineResult) {
@@ -28,6 +28,7 @@ import (
 	"github.com/tektoncd/pipeline/test/diff"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/utils/ptr"
 	"knative.dev/pkg/apis"
 )
 
@@ -73,6 +74,27 @@ func TestPipelineConversion(t *testing.T) {
 				}},
 			},
 		},
+	}, {
+		name: "pipeline with param constraints",
+		in: &v1beta1.Pipeline{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "foo",
+				Namespace: "bar",
+			},
+			Spec: v1beta1.PipelineSpec{
+				Tasks: []v1beta1.PipelineTask{{
+					Name:    "foo",
+					TaskRef: &v1beta1.TaskRef{Name: "example.com/my-foo-task"},
+				}},
+				Params: []v1beta1.ParamSpec{{
+					Name:      "param-1",
+					Type:      v1beta1.ParamTypeString,
+					Pattern:   "^v[0-9]+$",
+					MinLength: 2,
+					MaxLength: 8,
+				}},
+			},
+		},
 	}, {
 		name: "pipeline with deprecated fields in step and stepTemplate",
 		in: &v1beta1.Pipeline{
@@ -144,10 +166,17 @@ func TestPipelineConversion(t *testing.T) {
 				Tasks: []v1beta1.PipelineTask{{
 					Name:    "task-1",
 					OnError: v1beta1.PipelineTaskContinue,
+				}, {
+					Name: "approve",
+					ApprovalGate: &v1beta1.ApprovalGate{
+						Approvers: []string{"alice", "bob"},
+						Timeout:   &metav1.Duration{Duration: time.Hour},
+					},
 				}, {
 					Name:        "foo",
 					DisplayName: "task-display-name",
 					Description: "task-description",
+					Labels:      map[string]string{"team": "payments"},
 					OnError:     v1beta1.PipelineTaskContinue,
 					TaskRef:     &v1beta1.TaskRef{Name: "example.com/my-foo-task"},
 					TaskSpec: &v1beta1.EmbeddedTask{
@@ -192,12 +221,15 @@ func TestPipelineConversion(t *testing.T) {
 							}, {
 								Name: "flags", Value: v1beta1.ParamValue{Type: v1beta1.ParamTypeString, StringVal: "-cover -v"}}},
 						}},
+						FailFast: ptr.To(false),
 					},
 					Workspaces: []v1beta1.WorkspacePipelineTaskBinding{{
-						Name:      "my-task-workspace",
-						Workspace: "source",
+						Name:              "my-task-workspace",
+						Workspace:         "source",
+						PerMatrixInstance: true,
 					}},
-					Timeout: &metav1.Duration{Duration: 5 * time.Minute},
+					Timeout:          &metav1.Duration{Duration: 5 * time.Minute},
+					ExpectedDuration: &metav1.Duration{Duration: time.Minute},
 				},
 				},
 				Params: []v1beta1.ParamSpec{{
@@ -223,6 +255,8 @@ func TestPipelineConversion(t *testing.T) {
 					DisplayName: "final-task-display-name",
 					Description: "final-task-description",
 					TaskRef:     &v1beta1.TaskRef{Name: "foo-task"},
+					RetriesFrom: "$(params.retries)",
+					TimeoutFrom: "$(tasks.foo.results.timeout)",
 				}},
 			},
 		},
@@ -516,6 +516,20 @@ func TestPipelineTask_ValidateCustomTask(t *testing.T) {
 			Message: `invalid value: invalid apiVersion format "example/", must be in the format "group/version"`,
 			Paths:   []string{"taskRef.apiVersion"},
 		},
+	}, {
+		name: "custom task - matrix with failFast",
+		task: PipelineTask{
+			Name:    "foo",
+			TaskRef: &TaskRef{APIVersion: "example.dev/v0", Kind: "Example"},
+			Matrix: &Matrix{
+				Params:   Params{{Name: "platform", Value: ParamValue{Type: ParamTypeArray, ArrayVal: []string{"linux", "mac"}}}},
+				FailFast: new(bool),
+			},
+		},
+		expectedError: apis.FieldError{
+			Message: `failFast is only supported by the matrices of Tasks, not by the ones of Custom Tasks or Pipelines`,
+			Paths:   []string{"matrix.failFast"},
+		},
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -778,6 +792,26 @@ func TestPipelineTask_Validate_Failure(t *testing.T) {
 			},
 		}},
 		expectedError: *apis.ErrInvalidValue("custom task spec must specify apiVersion", "taskSpec.apiVersion"),
+	}, {
+		name: "labels with a reserved prefix",
+		p: PipelineTask{
+			Name:    "foo",
+			TaskRef: &TaskRef{Name: "foo-task"},
+			Labels:  map[string]string{"team": "payments", "tekton.dev/pipelineTask": "bar"},
+		},
+		expectedError: *apis.ErrInvalidKeyName("tekton.dev/pipelineTask", "labels", "the tekton.dev/ prefix is reserved for the labels set by Tekton"),
+	}, {
+		name: "pipeline in pipeline - matrix with failFast",
+		p: PipelineTask{
+			Name:        "foo",
+			PipelineRef: &PipelineRef{Name: "foo-pipeline"},
+			Matrix: &Matrix{
+				Params:   Params{{Name: "platform", Value: ParamValue{Type: ParamTypeArray, ArrayVal: []string{"linux", "mac"}}}},
+				FailFast: new(bool),
+			},
+		},
+		expectedError: *apis.ErrGeneric("failFast is only supported by the matrices of Tasks, not by the ones of Custom Tasks or Pipelines", "matrix.failFast"),
+		wc:            cfgtesting.EnableAlphaAPIFields,
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -18,6 +18,7 @@ package v1beta1
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -218,7 +219,7 @@ func TestPipeline_Validate_Success(t *testing.T) {
 			},
 		},
 	}, {
-		name: "valid pipeline with pipeline task and final task referencing artifacts in task params with enable-artifacts flag true",
+		name: "valid pipeline with final task referencing artifacts of pipeline task in task params with enable-artifacts flag true",
 		p: &Pipeline{
 			ObjectMeta: metav1.ObjectMeta{Name: "pipeline"},
 			Spec: PipelineSpec{
@@ -226,11 +227,12 @@ func TestPipeline_Validate_Success(t *testing.T) {
 				Tasks: []PipelineTask{{
 					Name:    "pre-task",
 					TaskRef: &TaskRef{Name: "foo-task"},
-				}, {
+				}},
+				Finally: []PipelineTask{{
 					Name: "consume-artifacts-task",
 					Params: Params{{Name: "aaa", Value: ParamValue{
 						Type:      ParamTypeString,
-						StringVal: "$(tasks.produce-artifacts-task.outputs.image)",
+						StringVal: "$(tasks.pre-task.outputs.image)",
 					}}},
 					TaskSpec: &EmbeddedTask{TaskSpec: getTaskSpec()},
 				}},
@@ -1447,6 +1449,51 @@ func TestPipelineSpec_Validate_Failure(t *testing.T) {
 			Message: `feature flag enable-artifacts should be set to true to use artifacts feature.`,
 			Paths:   []string{"finally[0].params"},
 		},
+	}, {
+		name: "invalid pipeline with pipeline task referencing artifacts in task params",
+		ps: &PipelineSpec{
+			Tasks: []PipelineTask{{
+				Name:    "pre-task",
+				TaskRef: &TaskRef{Name: "foo-task"},
+			}, {
+				Name: "consume-artifacts-task",
+				Params: Params{{Name: "aaa", Value: ParamValue{
+					Type:      ParamTypeString,
+					StringVal: "$(tasks.pre-task.outputs.image)",
+				}}},
+				TaskSpec: &EmbeddedTask{TaskSpec: getTaskSpec()},
+			}},
+		},
+		wc: func(ctx context.Context) context.Context {
+			return cfgtesting.SetFeatureFlags(ctx, t, map[string]string{"enable-artifacts": "true"})
+		},
+		expectedError: apis.FieldError{
+			Message: `invalid value: pipeline tasks can not refer to the artifacts of other pipeline tasks, only finally tasks can`,
+			Paths:   []string{"tasks[1].params[aaa].value"},
+		},
+	}, {
+		name: "invalid pipeline with final task referencing artifacts of undefined pipeline task in params",
+		ps: &PipelineSpec{
+			Tasks: []PipelineTask{{
+				Name:    "pre-task",
+				TaskRef: &TaskRef{Name: "foo-task"},
+			}},
+			Finally: []PipelineTask{{
+				Name: "consume-artifacts-task",
+				Params: Params{{Name: "aaa", Value: ParamValue{
+					Type:      ParamTypeString,
+					StringVal: "$(tasks.produce-artifacts-task.outputs.image)",
+				}}},
+				TaskSpec: &EmbeddedTask{TaskSpec: getTaskSpec()},
+			}},
+		},
+		wc: func(ctx context.Context) context.Context {
+			return cfgtesting.SetFeatureFlags(ctx, t, map[string]string{"enable-artifacts": "true"})
+		},
+		expectedError: apis.FieldError{
+			Message: `invalid value: pipeline task produce-artifacts-task is not defined in the pipeline`,
+			Paths:   []string{"finally[0].params[aaa].value"},
+		},
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1567,7 +1614,7 @@ func TestValidateGraph_Success(t *testing.T) {
 	}, {
 		Name: "foo-bar", TaskRef: &TaskRef{Name: "bar-task"}, RunAfter: []string{"foo1", "bar1"},
 	}}
-	if err := validateGraph(tasks); err != nil {
+	if err := validateGraph(t.Context(), tasks); err != nil {
 		t.Errorf("Pipeline.validateGraph() returned error for valid DAG of pipeline tasks: %s: %v", desc, err)
 	}
 }
@@ -1583,7 +1630,7 @@ func TestValidateGraph_Failure(t *testing.T) {
 		Message: `invalid value: cycle detected; task "bar" depends on "foo"`,
 		Paths:   []string{"tasks"},
 	}
-	err := validateGraph(tasks)
+	err := validateGraph(t.Context(), tasks)
 	if err == nil {
 		t.Error("Pipeline.validateGraph() did not return error for invalid DAG of pipeline tasks:", desc)
 	} else if d := cmp.Diff(expectedError.Error(), err.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
@@ -1591,6 +1638,83 @@ func TestValidateGraph_Failure(t *testing.T) {
 	}
 }
 
+func TestValidateGraph_Limits(t *testing.T) {
+	// chain returns n tasks, each one running after the previous one.
+	chain := func(n int) []PipelineTask {
+		tasks := make([]PipelineTask, n)
+		for i := range tasks {
+			tasks[i] = PipelineTask{Name: fmt.Sprintf("task-%d", i), TaskRef: &TaskRef{Name: "task"}}
+			if i > 0 {
+				tasks[i].RunAfter = []string{tasks[i-1].Name}
+			}
+		}
+		return tasks
+	}
+	// parallel returns n tasks that don't depend on each other.
+	parallel := func(n int) []PipelineTask {
+		tasks := make([]PipelineTask, n)
+		for i := range tasks {
+			tasks[i] = PipelineTask{Name: fmt.Sprintf("task-%d", i), TaskRef: &TaskRef{Name: "task"}}
+		}
+		return tasks
+	}
+	tcs := []struct {
+		name          string
+		tasks         []PipelineTask
+		maxDepth      int
+		maxTasks      int
+		expectedError string
+	}{{
+		name:     "depth at the maximum",
+		tasks:    chain(5),
+		maxDepth: 5,
+	}, {
+		name:          "depth over the maximum",
+		tasks:         chain(6),
+		maxDepth:      5,
+		expectedError: `invalid value: the chain of dependent tasks starting at task "task-0" has 6 tasks, more than the maximum depth of 5 set by default-max-dag-depth: tasks`,
+	}, {
+		name:  "no maximum depth",
+		tasks: chain(6),
+	}, {
+		name:     "tasks at the maximum",
+		tasks:    parallel(5),
+		maxTasks: 5,
+	}, {
+		name:          "tasks over the maximum",
+		tasks:         parallel(6),
+		maxTasks:      5,
+		expectedError: `invalid value: pipeline has 6 tasks, more than the maximum of 5 set by default-max-dag-tasks: tasks`,
+	}, {
+		name:     "parallel tasks don't count towards the depth",
+		tasks:    parallel(6),
+		maxDepth: 1,
+	}}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := config.ToContext(t.Context(), &config.Config{
+				Defaults: &config.Defaults{
+					DefaultMaxDAGDepth: tc.maxDepth,
+					DefaultMaxDAGTasks: tc.maxTasks,
+				},
+			})
+			err := validateGraph(ctx, tc.tasks)
+			if tc.expectedError == "" {
+				if err != nil {
+					t.Errorf("Pipeline.validateGraph() returned error for DAG within the limits: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("Pipeline.validateGraph() did not return error for DAG over the limits")
+			}
+			if d := cmp.Diff(tc.expectedError, err.Error()); d != "" {
+				t.Errorf("Pipeline.validateGraph() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 func TestValidatePipelineResults_Success(t *testing.T) {
 	desc := "valid pipeline with valid pipeline results syntax"
 	results := []PipelineResult{{
@@ -2832,6 +2956,52 @@ func TestValidatePipelineWorkspacesUsage_Success(t *testing.T) {
 	}
 }
 
+func TestValidateWorkspaceBoundVariablesInWhenExpressions(t *testing.T) {
+	workspaces := []PipelineWorkspaceDeclaration{{
+		Name:     "cache",
+		Optional: true,
+	}, {
+		Name: "source",
+	}}
+	tests := []struct {
+		name          string
+		when          WhenExpressions
+		expectedError *apis.FieldError
+	}{{
+		name: "optional workspace in input",
+		when: WhenExpressions{{Input: "$(workspaces.cache.bound)", Operator: selection.In, Values: []string{"true"}}},
+	}, {
+		name: "optional workspace in values",
+		when: WhenExpressions{{Input: "true", Operator: selection.In, Values: []string{"$(workspaces.cache.bound)"}}},
+	}, {
+		name: "other variables are ignored",
+		when: WhenExpressions{{Input: "$(params.foo)", Operator: selection.In, Values: []string{"$(workspaces.source.path)"}}},
+	}, {
+		name: "workspace which is not optional",
+		when: WhenExpressions{{Input: "$(workspaces.source.bound)", Operator: selection.In, Values: []string{"true"}}},
+		expectedError: &apis.FieldError{
+			Message: `invalid value: $(workspaces.source.bound) refers to workspace "source" which is not optional and so always bound`,
+			Paths:   []string{"tasks[0].when[0]"},
+		},
+	}, {
+		name: "workspace which is not declared",
+		when: WhenExpressions{{Input: "true", Operator: selection.In, Values: []string{"$(workspaces.missing.bound)"}}},
+		expectedError: &apis.FieldError{
+			Message: `invalid value: $(workspaces.missing.bound) refers to workspace "missing" which is not declared by the pipeline`,
+			Paths:   []string{"tasks[0].when[0]"},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tasks := []PipelineTask{{Name: "upload", TaskRef: &TaskRef{Name: "upload"}, WhenExpressions: tt.when}}
+			errs := validateWorkspaceBoundVariablesInWhenExpressions(workspaces, tasks).ViaField("tasks")
+			if d := cmp.Diff(tt.expectedError.Error(), errs.Error()); d != "" {
+				t.Errorf("validateWorkspaceBoundVariablesInWhenExpressions() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 func TestValidatePipelineWorkspacesDeclarations_Failure(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -3639,9 +3809,20 @@ func TestValidateFinalTasks_Failure(t *testing.T) {
 
 func TestContextValid(t *testing.T) {
 	tests := []struct {
-		name  string
-		tasks []PipelineTask
+		name    string
+		tasks   []PipelineTask
+		finally bool
 	}{{
+		name: "valid string context variable for PipelineRun finallyTimeoutRemaining in finally",
+		tasks: []PipelineTask{{
+			Name:    "bar",
+			TaskRef: &TaskRef{Name: "bar-task"},
+			Params: Params{{
+				Name: "a-param", Value: ParamValue{Type: ParamTypeString, StringVal: "$(context.pipelineRun.finallyTimeoutRemaining)"},
+			}},
+		}},
+		finally: true,
+	}, {
 		name: "valid string context variable for Pipeline name",
 		tasks: []PipelineTask{{
 			Name:    "bar",
@@ -3776,7 +3957,7 @@ func TestContextValid(t *testing.T) {
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if err := validatePipelineContextVariables(tt.tasks); err != nil {
+			if err := validatePipelineContextVariables(tt.tasks, tt.finally); err != nil {
 				t.Errorf("Pipeline.validatePipelineContextVariables() returned error for valid pipeline context variables: %v", err)
 			}
 		})
@@ -3789,6 +3970,19 @@ func TestContextInvalid(t *testing.T) {
 		tasks         []PipelineTask
 		expectedError apis.FieldError
 	}{{
+		name: "invalid string context variable for PipelineRun finallyTimeoutRemaining outside of finally",
+		tasks: []PipelineTask{{
+			Name:    "bar",
+			TaskRef: &TaskRef{Name: "bar-task"},
+			Params: Params{{
+				Name: "a-param", Value: ParamValue{Type: ParamTypeString, StringVal: "$(context.pipelineRun.finallyTimeoutRemaining)"},
+			}},
+		}},
+		expectedError: *apis.ErrGeneric("").Also(&apis.FieldError{
+			Message: `non-existent variable in "$(context.pipelineRun.finallyTimeoutRemaining)"`,
+			Paths:   []string{"value"},
+		}),
+	}, {
 		name: "invalid string context variable for pipeline",
 		tasks: []PipelineTask{{
 			Name:    "bar",
@@ -3928,9 +4122,9 @@ func TestContextInvalid(t *testing.T) {
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := validatePipelineContextVariables(tt.tasks)
+			err := validatePipelineContextVariables(tt.tasks, false)
 			if err == nil {
-				t.Errorf("Pipeline.validatePipelineContextVariables() did not return error for invalid pipeline parameters: %s", tt.tasks[0].Params)
+				t.Errorf("Pipeline.validatePipelineContextVariables() did not return error for invalid pipeline parameters: %v", tt.tasks[0].Params)
 			}
 			if d := cmp.Diff(tt.expectedError.Error(), err.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
 				t.Errorf("PipelineSpec.Validate() errors diff %s", diff.PrintWantGot(d))
@@ -4186,7 +4380,7 @@ func TestPipelineTasksExecutionStatus(t *testing.T) {
 				}
 			} else {
 				if err == nil {
-					t.Errorf("Pipeline.validateExecutionStatusVariables() did not return error for invalid pipeline parameters accessing execution status: %s, %s", tt.name, tt.tasks[0].Params)
+					t.Errorf("Pipeline.validateExecutionStatusVariables() did not return error for invalid pipeline parameters accessing execution status: %s, %v", tt.name, tt.tasks[0].Params)
 				}
 				if d := cmp.Diff(tt.expectedError.Error(), err.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
 					t.Errorf("PipelineSpec.Validate() errors diff %s", diff.PrintWantGot(d))
@@ -92,6 +92,7 @@ func (prs PipelineRunSpec) ConvertTo(ctx context.Context, sink *v1.PipelineRunSp
 		ptrs.convertTo(ctx, &new)
 		sink.TaskRunSpecs = append(sink.TaskRunSpecs, new)
 	}
+	sink.TTLSecondsAfterFinished = prs.TTLSecondsAfterFinished
 	return nil
 }
 
@@ -153,6 +154,7 @@ func (prs *PipelineRunSpec) ConvertFrom(ctx context.Context, source *v1.Pipeline
 		new.convertFrom(ctx, trs)
 		prs.TaskRunSpecs = append(prs.TaskRunSpecs, new)
 	}
+	prs.TTLSecondsAfterFinished = source.TTLSecondsAfterFinished
 	return nil
 }
 
@@ -252,6 +254,40 @@ func (prs *PipelineRunStatus) convertTo(ctx context.Context, sink *v1.PipelineRu
 		prs.Provenance.convertTo(ctx, &new)
 		sink.Provenance = &new
 	}
+	sink.Timing = nil
+	if prs.Timing != nil {
+		sink.Timing = &v1.PipelineRunTiming{}
+		for _, w := range prs.Timing.WorkspacePVCWaits {
+			sink.Timing.WorkspacePVCWaits = append(sink.Timing.WorkspacePVCWaits, v1.WorkspacePVCWait(w))
+		}
+	}
+	sink.ApprovalGates = nil
+	for _, g := range prs.ApprovalGates {
+		sink.ApprovalGates = append(sink.ApprovalGates, v1.ApprovalGateStatus{
+			PipelineTaskName: g.PipelineTaskName,
+			Approvers:        g.Approvers,
+			Reason:           v1.ApprovalGateReason(g.Reason),
+			StartTime:        g.StartTime,
+			CompletionTime:   g.CompletionTime,
+		})
+	}
+	sink.Approvals = nil
+	for _, a := range prs.Approvals {
+		sink.Approvals = append(sink.Approvals, v1.Approval{
+			PipelineTaskName: a.PipelineTaskName,
+			Approver:         a.Approver,
+			Decision:         v1.ApprovalDecision(a.Decision),
+			Time:             a.Time,
+			Message:          a.Message,
+		})
+	}
+	sink.FailureCauses = nil
+	for _, c := range prs.FailureCauses {
+		sink.FailureCauses = append(sink.FailureCauses, v1.PipelineRunFailureCause(c))
+	}
+	sink.Layers = (*v1.PipelineRunLayers)(prs.Layers)
+	sink.Coschedule = prs.Coschedule
+	sink.SkippedAffinityAssistantWorkspaces = prs.SkippedAffinityAssistantWorkspaces
 	return nil
 }
 
@@ -292,6 +328,40 @@ func (prs *PipelineRunStatus) convertFrom(ctx context.Context, source *v1.Pipeli
 		new.convertFrom(ctx, *source.Provenance)
 		prs.Provenance = &new
 	}
+	prs.Timing = nil
+	if source.Timing != nil {
+		prs.Timing = &PipelineRunTiming{}
+		for _, w := range source.Timing.WorkspacePVCWaits {
+			prs.Timing.WorkspacePVCWaits = append(prs.Timing.WorkspacePVCWaits, WorkspacePVCWait(w))
+		}
+	}
+	prs.ApprovalGates = nil
+	for _, g := range source.ApprovalGates {
+		prs.ApprovalGates = append(prs.ApprovalGates, ApprovalGateStatus{
+			PipelineTaskName: g.PipelineTaskName,
+			Approvers:        g.Approvers,
+			Reason:           ApprovalGateReason(g.Reason),
+			StartTime:        g.StartTime,
+			CompletionTime:   g.CompletionTime,
+		})
+	}
+	prs.Approvals = nil
+	for _, a := range source.Approvals {
+		prs.Approvals = append(prs.Approvals, Approval{
+			PipelineTaskName: a.PipelineTaskName,
+			Approver:         a.Approver,
+			Decision:         ApprovalDecision(a.Decision),
+			Time:             a.Time,
+			Message:          a.Message,
+		})
+	}
+	prs.FailureCauses = nil
+	for _, c := range source.FailureCauses {
+		prs.FailureCauses = append(prs.FailureCauses, PipelineRunFailureCause(c))
+	}
+	prs.Layers = (*PipelineRunLayers)(source.Layers)
+	prs.Coschedule = source.Coschedule
+	prs.SkippedAffinityAssistantWorkspaces = source.SkippedAffinityAssistantWorkspaces
 	return nil
 }
 
@@ -342,6 +412,8 @@ func (csr ChildStatusReference) convertTo(ctx context.Context, sink *v1.ChildSta
 		we.convertTo(ctx, &new)
 		sink.WhenExpressions = append(sink.WhenExpressions, new)
 	}
+	sink.Labels = csr.Labels
+	sink.Reason = csr.Reason
 }
 
 func (csr *ChildStatusReference) convertFrom(ctx context.Context, source v1.ChildStatusReference) {
@@ -355,6 +427,8 @@ func (csr *ChildStatusReference) convertFrom(ctx context.Context, source v1.Chil
 		new.convertFrom(ctx, we)
 		csr.WhenExpressions = append(csr.WhenExpressions, new)
 	}
+	csr.Labels = source.Labels
+	csr.Reason = source.Reason
 }
 
 func serializePipelineRunResources(meta *metav1.ObjectMeta, spec *PipelineRunSpec) error {
@@ -34,6 +34,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/utils/ptr"
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 )
@@ -220,6 +221,10 @@ func TestPipelineRunConversion(t *testing.T) {
 				}, {
 					Name:  "bar",
 					Value: *v1beta1.NewStructuredValues("value"),
+				}, {
+					Name:   "digest",
+					Value:  *v1beta1.NewStructuredValues(""),
+					RunRef: &v1beta1.PipelineRunResultRef{Name: "build-run", Result: "digest", AllowFailed: true},
 				}},
 				ServiceAccountName: "test-sa",
 				Status:             v1beta1.PipelineRunSpecStatusPending,
@@ -228,6 +233,7 @@ func TestPipelineRunConversion(t *testing.T) {
 					Finally:  &metav1.Duration{Duration: 1 * time.Hour},
 					Tasks:    &metav1.Duration{Duration: 1 * time.Hour},
 				},
+				TTLSecondsAfterFinished: ptr.To(int32(3600)),
 				PodTemplate: &pod.Template{
 					NodeSelector: map[string]string{
 						"label": "value",
@@ -321,6 +327,34 @@ func TestPipelineRunConversion(t *testing.T) {
 							},
 						}},
 					},
+					ApprovalGates: []v1beta1.ApprovalGateStatus{{
+						PipelineTaskName: "approve",
+						Approvers:        []string{"alice", "bob"},
+						Reason:           v1beta1.ApprovalGateReasonApproved,
+						StartTime:        metav1.Time{Time: time.Now()},
+						CompletionTime:   &metav1.Time{Time: time.Now().Add(1 * time.Minute)},
+					}},
+					Approvals: []v1beta1.Approval{{
+						PipelineTaskName: "approve",
+						Approver:         "bob",
+						Decision:         v1beta1.ApprovalDecisionApprove,
+						Time:             metav1.Time{Time: time.Now().Add(1 * time.Minute)},
+						Message:          "lgtm",
+					}},
+					FailureCauses: []v1beta1.PipelineRunFailureCause{{
+						PipelineTaskName:      "deploy",
+						Kind:                  "TaskRun",
+						Name:                  "pr-deploy",
+						Reason:                "StepOOM",
+						StepName:              "apply",
+						StepTerminationReason: "OOMKilled",
+					}, {
+						PipelineTaskName: "approve",
+						Reason:           "Rejected",
+					}},
+					Layers:                             &v1beta1.PipelineRunLayers{Total: 3, Completed: 1},
+					Coschedule:                         "workspaces",
+					SkippedAffinityAssistantWorkspaces: []string{"cache"},
 					SkippedTasks: []v1beta1.SkippedTask{
 						{
 							Name:   "skipped-1",
@@ -345,6 +379,8 @@ func TestPipelineRunConversion(t *testing.T) {
 								Operator: "notin",
 								Values:   []string{"foo", "bar"},
 							}},
+							Labels: map[string]string{"team": "payments"},
+							Reason: "Failed",
 						},
 						{
 							TypeMeta:         runtime.TypeMeta{Kind: "Run"},
@@ -358,6 +394,13 @@ func TestPipelineRunConversion(t *testing.T) {
 							URI:    "test-uri",
 							Digest: map[string]string{"sha256": "digest"},
 						},
+						ParamSources: []v1beta1.ParamSource{{
+							Param:       "digest",
+							PipelineRun: "build-run",
+							UID:         "build-run-uid",
+							Result:      "digest",
+							Value:       *v1beta1.NewStructuredValues("sha256:abc"),
+						}},
 						FeatureFlags: config.DefaultFeatureFlags.DeepCopy(),
 					},
 				},
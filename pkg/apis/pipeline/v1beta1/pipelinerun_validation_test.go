@@ -18,6 +18,7 @@ package v1beta1_test
 
 import (
 	"context"
+	"fmt"
 	"strings"
 	"testing"
 	"time"
@@ -27,6 +28,7 @@ import (
 	"github.com/tektoncd/pipeline/pkg/apis/config"
 	cfgtesting "github.com/tektoncd/pipeline/pkg/apis/config/testing"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/pod"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/pipeline/test/diff"
 	corev1 "k8s.io/api/core/v1"
@@ -401,7 +403,7 @@ func TestPipelineRun_Invalid(t *testing.T) {
 			},
 		},
 		want: &apis.FieldError{
-			Message: "invalid value: cannot use result expressions in [tasks.some-task.results.foo] as PipelineRun parameter values",
+			Message: "invalid value: cannot use result expressions in [tasks.some-task.results.foo] as PipelineRun parameter values: the results of Tasks and Steps and the execution status of Tasks are only known while the Pipeline runs, so they can only be referenced in the Pipeline, e.g. in the params and when expressions of its Tasks; use runRef to take the value of a param from a result of another PipelineRun",
 			Paths:   []string{"spec.params[some-param].value"},
 		},
 	}, {
@@ -424,7 +426,7 @@ func TestPipelineRun_Invalid(t *testing.T) {
 			},
 		},
 		want: &apis.FieldError{
-			Message: "invalid value: cannot use result expressions in [tasks.some-task.results.foo] as PipelineRun parameter values",
+			Message: "invalid value: cannot use result expressions in [tasks.some-task.results.foo] as PipelineRun parameter values: the results of Tasks and Steps and the execution status of Tasks are only known while the Pipeline runs, so they can only be referenced in the Pipeline, e.g. in the params and when expressions of its Tasks; use runRef to take the value of a param from a result of another PipelineRun",
 			Paths:   []string{"spec.params[some-param].value"},
 		},
 	}, {
@@ -889,7 +891,7 @@ func TestPipelineRun_Validate(t *testing.T) {
 						Name: "echoit",
 						Workspaces: []v1beta1.WorkspacePipelineTaskBinding{{
 							Name:    "ws",
-							SubPath: "/foo",
+							SubPath: "foo",
 						}},
 						TaskSpec: &v1beta1.EmbeddedTask{TaskSpec: v1beta1.TaskSpec{
 							Workspaces: []v1beta1.WorkspaceDeclaration{{
@@ -907,7 +909,7 @@ func TestPipelineRun_Validate(t *testing.T) {
 						Name: "echoitfinally",
 						Workspaces: []v1beta1.WorkspacePipelineTaskBinding{{
 							Name:    "ws",
-							SubPath: "/foo",
+							SubPath: "foo",
 						}},
 						TaskSpec: &v1beta1.EmbeddedTask{TaskSpec: v1beta1.TaskSpec{
 							Workspaces: []v1beta1.WorkspaceDeclaration{{
@@ -1271,6 +1273,27 @@ func TestPipelineRunSpec_Invalidate(t *testing.T) {
 		},
 		withContext: cfgtesting.EnableStableAPIFields,
 		wantErr:     apis.ErrGeneric("computeResources requires \"enable-api-fields\" feature gate to be \"alpha\" or \"beta\" but it is \"stable\"").ViaIndex(0).ViaField("taskRunSpecs"),
+	}, {
+		name: "duplicate taskRunSpecs for a pipelineTask",
+		spec: v1beta1.PipelineRunSpec{
+			PipelineRef: &v1beta1.PipelineRef{Name: "foo"},
+			TaskRunSpecs: []v1beta1.PipelineTaskRunSpec{
+				{PipelineTaskName: "deploy-*"},
+				{PipelineTaskName: "bar"},
+				{PipelineTaskName: "deploy-*"},
+				{PipelineTaskName: "bar"},
+			},
+		},
+		wantErr: apis.ErrGeneric(`taskRunSpec for pipelineTask "bar" provided by pipelinerun more than once, at index 1 and 3`, "taskRunSpecs[3].pipelineTaskName"),
+	}, {
+		name: "invalid taskRunSpecs pattern",
+		spec: v1beta1.PipelineRunSpec{
+			PipelineRef: &v1beta1.PipelineRef{Name: "foo"},
+			TaskRunSpecs: []v1beta1.PipelineTaskRunSpec{
+				{PipelineTaskName: "deploy-[a"},
+			},
+		},
+		wantErr: apis.ErrInvalidValue(`"deploy-[a" is not a valid glob pattern: syntax error in pattern`, "taskRunSpecs[0].pipelineTaskName"),
 	}}
 
 	for _, ps := range tests {
@@ -1369,6 +1392,148 @@ func TestPipelineRunSpec_Validate(t *testing.T) {
 	}
 }
 
+func TestPipelineRun_Validate_RunRefResultTypes(t *testing.T) {
+	resultType := func(ctx context.Context, namespace string, ref v1.PipelineRunResultRef) (v1.ParamType, bool) {
+		if namespace != "ns" || ref.Name != "build-run" {
+			return "", false
+		}
+		switch ref.Result {
+		case "digest":
+			return v1.ParamTypeString, true
+		case "tags":
+			return v1.ParamTypeArray, true
+		}
+		return "", false
+	}
+	pipelineRun := func(result string) *v1beta1.PipelineRun {
+		return &v1beta1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{Name: "promote-run", Namespace: "ns"},
+			Spec: v1beta1.PipelineRunSpec{
+				PipelineSpec: &v1beta1.PipelineSpec{
+					Params: v1beta1.ParamSpecs{{Name: "tags", Type: v1beta1.ParamTypeArray}},
+					Tasks: []v1beta1.PipelineTask{{
+						Name:    "promote",
+						TaskRef: &v1beta1.TaskRef{Name: "promote"},
+						Params:  v1beta1.Params{{Name: "tags", Value: *v1beta1.NewStructuredValues("$(params.tags[*])")}},
+					}},
+				},
+				Params: v1beta1.Params{{
+					Name:   "tags",
+					Value:  v1beta1.ParamValue{Type: v1beta1.ParamTypeString},
+					RunRef: &v1beta1.PipelineRunResultRef{Name: "build-run", Result: result},
+				}},
+			},
+		}
+	}
+	for _, tc := range []struct {
+		name    string
+		pr      *v1beta1.PipelineRun
+		wantErr *apis.FieldError
+	}{{
+		name: "result of the type of the param",
+		pr:   pipelineRun("tags"),
+	}, {
+		name:    "result of another type than the param",
+		pr:      pipelineRun("digest"),
+		wantErr: apis.ErrInvalidValue(`result "digest" of PipelineRun "build-run" is of type "string", while the param is of type "array"`, "spec.params[tags].runRef.result"),
+	}, {
+		name: "result not found yet",
+		pr:   pipelineRun("labels"),
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := apis.WithinCreate(v1.WithRunRefResultType(t.Context(), resultType))
+			err := tc.pr.Validate(ctx)
+			if d := cmp.Diff(tc.wantErr.Error(), err.Error()); d != "" {
+				t.Error(diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestPipelineRunSpec_ParamsWithRunTimeReferences(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		value    v1beta1.ParamValue
+		wantRefs []string
+	}{{
+		name:     "task result",
+		value:    *v1beta1.NewStructuredValues("$(tasks.build.results.digest)"),
+		wantRefs: []string{"tasks.build.results.digest"},
+	}, {
+		name:     "finally task result in a string",
+		value:    *v1beta1.NewStructuredValues("image@$(finally.report.results.id)"),
+		wantRefs: []string{"finally.report.results.id"},
+	}, {
+		name:     "indexed task result",
+		value:    *v1beta1.NewStructuredValues("$(tasks.build.results.images[0])"),
+		wantRefs: []string{"tasks.build.results.images[0]"},
+	}, {
+		name:     "object task result property",
+		value:    *v1beta1.NewStructuredValues("$(tasks.build.results.image.url)"),
+		wantRefs: []string{"tasks.build.results.image.url"},
+	}, {
+		name:     "step result",
+		value:    *v1beta1.NewStructuredValues("$(steps.build.results.digest)"),
+		wantRefs: []string{"steps.build.results.digest"},
+	}, {
+		name:     "task execution status and reason",
+		value:    *v1beta1.NewStructuredValues("$(tasks.build.status)", "$(tasks.build.reason)"),
+		wantRefs: []string{"tasks.build.status", "tasks.build.reason"},
+	}, {
+		name:     "aggregate execution status",
+		value:    *v1beta1.NewStructuredValues("$(tasks.status)"),
+		wantRefs: []string{"tasks.status"},
+	}, {
+		name:     "task result in an object",
+		value:    *v1beta1.NewObject(map[string]string{"url": "$(tasks.build.results.url)", "digest": "$(tasks.build.results.digest)"}),
+		wantRefs: []string{"tasks.build.results.digest", "tasks.build.results.url"},
+	}, {
+		name:     "escaped task result",
+		value:    *v1beta1.NewStructuredValues("$$(tasks.build.results.digest)"),
+		wantRefs: []string{"tasks.build.results.digest"},
+	}, {
+		name:     "escaped task status",
+		value:    *v1beta1.NewStructuredValues("echo $$(tasks.build.status)"),
+		wantRefs: []string{"tasks.build.status"},
+	}, {
+		name:  "task result without substitution",
+		value: *v1beta1.NewStructuredValues("tasks.build.results.digest"),
+	}, {
+		name:  "task results without a result name",
+		value: *v1beta1.NewStructuredValues("$(tasks.build.results)"),
+	}, {
+		name:  "param named like a task result",
+		value: *v1beta1.NewStructuredValues("$(params.tasks.build.results.digest)"),
+	}, {
+		name:  "context variable",
+		value: *v1beta1.NewStructuredValues("$(context.pipelineRun.name)"),
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			spec := v1beta1.PipelineRunSpec{
+				PipelineRef: &v1beta1.PipelineRef{Name: "pipeline"},
+				Params:      v1beta1.Params{{Name: "image", Value: tc.value}},
+			}
+			err := spec.Validate(t.Context())
+			if tc.wantRefs == nil {
+				if err != nil {
+					t.Errorf("Validate() = %v, want no error", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("Validate() succeeded, want an error")
+			}
+			want := fmt.Sprintf("cannot use result expressions in %v as PipelineRun parameter values", tc.wantRefs)
+			if !strings.Contains(err.Error(), want) || !strings.Contains(err.Error(), "runRef") {
+				t.Errorf("Validate() = %v, want an error containing %q and the use of runRef", err, want)
+			}
+			if !strings.HasSuffix(err.Error(), ": params[image].value") {
+				t.Errorf("Validate() = %v, want an error on params[image].value", err)
+			}
+		})
+	}
+}
+
 func TestPipelineRun_InvalidTimeouts(t *testing.T) {
 	tests := []struct {
 		name string
@@ -1390,6 +1555,20 @@ func TestPipelineRun_InvalidTimeouts(t *testing.T) {
 			},
 		},
 		want: apis.ErrInvalidValue("-48h0m0s should be >= 0", "spec.timeouts.pipeline"),
+	}, {
+		name: "negative ttlSecondsAfterFinished",
+		pr: v1beta1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "pipelinelinename",
+			},
+			Spec: v1beta1.PipelineRunSpec{
+				PipelineRef: &v1beta1.PipelineRef{
+					Name: "prname",
+				},
+				TTLSecondsAfterFinished: ptr.Int32(-1),
+			},
+		},
+		want: apis.ErrInvalidValue("-1 should be >= 0", "spec.ttlSecondsAfterFinished"),
 	}, {
 		name: "negative pipeline tasks Timeout",
 		pr: v1beta1.PipelineRun{
@@ -2162,6 +2341,91 @@ func TestPipelineRunTaskRunSpecTimeout_Validate(t *testing.T) {
 		},
 		wantErr:     true,
 		expectedErr: "taskRunSpecs[0].timeout",
+	}, {
+		name: "taskRunSpec timeout exceeds pipeline timeout left by finally timeout",
+		spec: v1beta1.PipelineRunSpec{
+			PipelineSpec: &v1beta1.PipelineSpec{
+				Tasks:   []v1beta1.PipelineTask{{Name: "task1", TaskRef: &v1beta1.TaskRef{Name: "foo"}}},
+				Finally: []v1beta1.PipelineTask{{Name: "final1", TaskRef: &v1beta1.TaskRef{Name: "foo"}}},
+			},
+			Timeouts: &v1beta1.TimeoutFields{
+				Pipeline: &metav1.Duration{Duration: 1 * time.Hour},
+				Finally:  &metav1.Duration{Duration: 20 * time.Minute},
+			},
+			TaskRunSpecs: []v1beta1.PipelineTaskRunSpec{{
+				PipelineTaskName: "task1",
+				Timeout:          &metav1.Duration{Duration: 45 * time.Minute},
+			}},
+		},
+		wantErr:     true,
+		expectedErr: "45m0s should be <= pipeline tasks duration 40m0s: taskRunSpecs[0].timeout",
+	}, {
+		// The finally tasks of a referenced Pipeline aren't known, so final1 may be one of them
+		name: "taskRunSpec timeout of a referenced Pipeline within pipeline timeout",
+		spec: v1beta1.PipelineRunSpec{
+			PipelineRef: &v1beta1.PipelineRef{Name: "test"},
+			Timeouts: &v1beta1.TimeoutFields{
+				Pipeline: &metav1.Duration{Duration: 1 * time.Hour},
+				Finally:  &metav1.Duration{Duration: 50 * time.Minute},
+			},
+			TaskRunSpecs: []v1beta1.PipelineTaskRunSpec{{
+				PipelineTaskName: "final1",
+				Timeout:          &metav1.Duration{Duration: 30 * time.Minute},
+			}},
+		},
+		wantErr: false,
+	}, {
+		name: "taskRunSpec timeout of a referenced Pipeline exceeds pipeline timeout",
+		spec: v1beta1.PipelineRunSpec{
+			PipelineRef: &v1beta1.PipelineRef{Name: "test"},
+			Timeouts: &v1beta1.TimeoutFields{
+				Pipeline: &metav1.Duration{Duration: 1 * time.Hour},
+				Finally:  &metav1.Duration{Duration: 50 * time.Minute},
+			},
+			TaskRunSpecs: []v1beta1.PipelineTaskRunSpec{{
+				PipelineTaskName: "final1",
+				Timeout:          &metav1.Duration{Duration: 90 * time.Minute},
+			}},
+		},
+		wantErr:     true,
+		expectedErr: "1h30m0s should be <= pipeline duration 1h0m0s: taskRunSpecs[0].timeout",
+	}, {
+		name: "finally taskRunSpec timeout within finally timeout",
+		spec: v1beta1.PipelineRunSpec{
+			PipelineSpec: &v1beta1.PipelineSpec{
+				Tasks:   []v1beta1.PipelineTask{{Name: "task1", TaskRef: &v1beta1.TaskRef{Name: "foo"}}},
+				Finally: []v1beta1.PipelineTask{{Name: "final1", TaskRef: &v1beta1.TaskRef{Name: "foo"}}},
+			},
+			Timeouts: &v1beta1.TimeoutFields{
+				Pipeline: &metav1.Duration{Duration: 1 * time.Hour},
+				Tasks:    &metav1.Duration{Duration: 10 * time.Minute},
+				Finally:  &metav1.Duration{Duration: 50 * time.Minute},
+			},
+			TaskRunSpecs: []v1beta1.PipelineTaskRunSpec{{
+				PipelineTaskName: "final1",
+				Timeout:          &metav1.Duration{Duration: 30 * time.Minute},
+			}},
+		},
+		wantErr: false,
+	}, {
+		name: "finally taskRunSpec timeout exceeds finally timeout",
+		spec: v1beta1.PipelineRunSpec{
+			PipelineSpec: &v1beta1.PipelineSpec{
+				Tasks:   []v1beta1.PipelineTask{{Name: "task1", TaskRef: &v1beta1.TaskRef{Name: "foo"}}},
+				Finally: []v1beta1.PipelineTask{{Name: "final1", TaskRef: &v1beta1.TaskRef{Name: "foo"}}},
+			},
+			Timeouts: &v1beta1.TimeoutFields{
+				Pipeline: &metav1.Duration{Duration: 1 * time.Hour},
+				Tasks:    &metav1.Duration{Duration: 50 * time.Minute},
+				Finally:  &metav1.Duration{Duration: 10 * time.Minute},
+			},
+			TaskRunSpecs: []v1beta1.PipelineTaskRunSpec{{
+				PipelineTaskName: "final1",
+				Timeout:          &metav1.Duration{Duration: 30 * time.Minute},
+			}},
+		},
+		wantErr:     true,
+		expectedErr: "30m0s should be <= pipeline finally duration 10m0s: taskRunSpecs[0].timeout",
 	}}
 
 	for _, tt := range tests {
@@ -2170,6 +2434,7 @@ func TestPipelineRunTaskRunSpecTimeout_Validate(t *testing.T) {
 				Defaults: &config.Defaults{
 					DefaultTimeoutMinutes: 60,
 				},
+				FeatureFlags: config.DefaultFeatureFlags.DeepCopy(),
 			})
 			err := tt.spec.Validate(ctx)
 			if tt.wantErr {
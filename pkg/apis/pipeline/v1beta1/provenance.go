@@ -13,7 +13,10 @@ limitations under the License.
 
 package v1beta1
 
-import "github.com/tektoncd/pipeline/pkg/apis/config"
+import (
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"k8s.io/apimachinery/pkg/types"
+)
 
 // Provenance contains metadata about resources used in the TaskRun/PipelineRun
 // such as the source from where a remote build definition was fetched.
@@ -26,8 +29,25 @@ type Provenance struct {
 	// RefSource identifies the source where a remote task/pipeline came from.
 	RefSource *RefSource `json:"refSource,omitempty"`
 
+	// NestedRefSources identifies the sources of the remote StepActions used, directly or not,
+	// by the StepAction a Step references, from the outermost to the innermost one.
+	// +optional
+	// +listType=atomic
+	NestedRefSources []*RefSource `json:"nestedRefSources,omitempty"`
+
+	// ParamSources identifies the PipelineRun results the values of params were taken from.
+	// +optional
+	// +listType=atomic
+	ParamSources []ParamSource `json:"paramSources,omitempty"`
+
 	// FeatureFlags identifies the feature flags that were used during the task/pipeline run
 	FeatureFlags *config.FeatureFlags `json:"featureFlags,omitempty"`
+
+	// UsedFeatureFlags identifies the feature flags whose gated behavior was exercised by the
+	// task/pipeline run, unlike FeatureFlags which holds the values of all the feature flags.
+	// +optional
+	// +listType=atomic
+	UsedFeatureFlags []string `json:"usedFeatureFlags,omitempty"`
 }
 
 // RefSource contains the information that can uniquely identify where a remote
@@ -65,3 +85,19 @@ type ConfigSource struct {
 	// Example: "task/git-clone/0.10/git-clone.yaml"
 	EntryPoint string `json:"entryPoint,omitempty"`
 }
+
+// ParamSource identifies the PipelineRun result the value of a param was taken from.
+type ParamSource struct {
+	// Param is the name of the param.
+	Param string `json:"param"`
+	// PipelineRun is the name of the PipelineRun the result was taken from.
+	PipelineRun string `json:"pipelineRun"`
+	// UID is the UID of the PipelineRun the result was taken from.
+	UID types.UID `json:"uid"`
+	// Result is the name of the result.
+	Result string `json:"result"`
+	// Value is the value of the result, which the param keeps for the rest of the run.
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:Schemaless
+	Value ParamValue `json:"value"`
+}
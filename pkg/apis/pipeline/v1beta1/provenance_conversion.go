@@ -25,9 +25,20 @@ func (p Provenance) convertTo(ctx context.Context, sink *v1.Provenance) {
 		p.RefSource.convertTo(ctx, &new)
 		sink.RefSource = &new
 	}
+	for _, nested := range p.NestedRefSources {
+		new := v1.RefSource{}
+		nested.convertTo(ctx, &new)
+		sink.NestedRefSources = append(sink.NestedRefSources, &new)
+	}
+	for _, ps := range p.ParamSources {
+		new := v1.ParamSource{}
+		ps.convertTo(ctx, &new)
+		sink.ParamSources = append(sink.ParamSources, new)
+	}
 	if p.FeatureFlags != nil {
 		sink.FeatureFlags = p.FeatureFlags
 	}
+	sink.UsedFeatureFlags = p.UsedFeatureFlags
 }
 
 func (p *Provenance) convertFrom(ctx context.Context, source v1.Provenance) {
@@ -36,9 +47,20 @@ func (p *Provenance) convertFrom(ctx context.Context, source v1.Provenance) {
 		new.convertFrom(ctx, *source.RefSource)
 		p.RefSource = &new
 	}
+	for _, nested := range source.NestedRefSources {
+		new := RefSource{}
+		new.convertFrom(ctx, *nested)
+		p.NestedRefSources = append(p.NestedRefSources, &new)
+	}
+	for _, ps := range source.ParamSources {
+		new := ParamSource{}
+		new.convertFrom(ctx, ps)
+		p.ParamSources = append(p.ParamSources, new)
+	}
 	if source.FeatureFlags != nil {
 		p.FeatureFlags = source.FeatureFlags
 	}
+	p.UsedFeatureFlags = source.UsedFeatureFlags
 }
 
 func (cs RefSource) convertTo(ctx context.Context, sink *v1.RefSource) {
@@ -52,3 +74,19 @@ func (cs *RefSource) convertFrom(ctx context.Context, source v1.RefSource) {
 	cs.Digest = source.Digest
 	cs.EntryPoint = source.EntryPoint
 }
+
+func (ps ParamSource) convertTo(ctx context.Context, sink *v1.ParamSource) {
+	sink.Param = ps.Param
+	sink.PipelineRun = ps.PipelineRun
+	sink.UID = ps.UID
+	sink.Result = ps.Result
+	ps.Value.convertTo(ctx, &sink.Value)
+}
+
+func (ps *ParamSource) convertFrom(ctx context.Context, source v1.ParamSource) {
+	ps.Param = source.Param
+	ps.PipelineRun = source.PipelineRun
+	ps.UID = source.UID
+	ps.Result = source.Result
+	ps.Value.convertFrom(ctx, source.Value)
+}
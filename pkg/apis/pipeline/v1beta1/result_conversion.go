@@ -55,3 +55,27 @@ func (r *TaskResult) convertFrom(ctx context.Context, source v1.TaskResult) {
 		r.Value.convertFrom(ctx, *source.Value)
 	}
 }
+
+func (r ResultSchema) convertTo(sink *v1.ResultSchema) {
+	sink.Name = r.Name
+	sink.Type = v1.ResultsType(r.Type)
+	sink.Properties = nil
+	if r.Properties != nil {
+		sink.Properties = make(map[string]v1.PropertySpec, len(r.Properties))
+		for k, v := range r.Properties {
+			sink.Properties[k] = v1.PropertySpec{Type: v1.ParamType(v.Type)}
+		}
+	}
+}
+
+func (r *ResultSchema) convertFrom(source v1.ResultSchema) {
+	r.Name = source.Name
+	r.Type = ResultsType(source.Type)
+	r.Properties = nil
+	if source.Properties != nil {
+		r.Properties = make(map[string]PropertySpec, len(source.Properties))
+		for k, v := range source.Properties {
+			r.Properties[k] = PropertySpec{Type: ParamType(v.Type)}
+		}
+	}
+}
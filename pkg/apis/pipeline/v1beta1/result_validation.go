@@ -16,6 +16,7 @@ package v1beta1
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"k8s.io/apimachinery/pkg/util/validation"
@@ -82,6 +83,9 @@ func (tr TaskResult) validateValue(ctx context.Context) (errs *apis.FieldError)
 		}
 	}
 	if tr.Value.StringVal != "" {
+		if strings.HasPrefix(tr.Value.StringVal, "$(sidecars.") {
+			return tr.validateSidecarResultValue()
+		}
 		stepName, resultName, err := v1.ExtractStepResultName(tr.Value.StringVal)
 		if err != nil {
 			return &apis.FieldError{
@@ -106,3 +110,29 @@ func (tr TaskResult) validateValue(ctx context.Context) (errs *apis.FieldError)
 	}
 	return errs
 }
+
+// validateSidecarResultValue validates a value of format $(sidecars.<sidecarName>.results.<resultName>).
+func (tr TaskResult) validateSidecarResultValue() (errs *apis.FieldError) {
+	sidecarName, resultName, err := v1.ExtractSidecarResultName(tr.Value.StringVal)
+	if err != nil {
+		return &apis.FieldError{
+			Message: err.Error(),
+			Paths:   []string{tr.Name + ".value"},
+		}
+	}
+	if e := validation.IsDNS1123Label(sidecarName); len(e) > 0 {
+		errs = errs.Also(&apis.FieldError{
+			Message: fmt.Sprintf("invalid extracted sidecar name %q", sidecarName),
+			Paths:   []string{tr.Name + ".value"},
+			Details: "sidecarName in $(sidecars.<sidecarName>.results.<resultName>) must be a valid DNS Label, For more info refer to https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names",
+		})
+	}
+	if !resultNameFormatRegex.MatchString(resultName) {
+		errs = errs.Also(&apis.FieldError{
+			Message: fmt.Sprintf("invalid extracted result name %q", resultName),
+			Paths:   []string{tr.Name + ".value"},
+			Details: fmt.Sprintf("resultName in $(sidecars.<sidecarName>.results.<resultName>) must consist of alphanumeric characters, '-', '_', and must start and end with an alphanumeric character (e.g. 'MyName',  or 'my-name',  or 'my_name', regex used for validation is '%s')", ResultNameFormat),
+		})
+	}
+	return errs
+}
@@ -165,5 +165,10 @@ func PipelineTaskResultRefs(pt *PipelineTask) []*ResultRef {
 		expressions, _ := whenExpression.GetVarSubstitutionExpressions()
 		refs = append(refs, NewResultRefs(expressions)...)
 	}
+	for _, ws := range pt.Workspaces {
+		refs = append(refs, NewResultRefs(validateString(ws.SubPath))...)
+	}
+	refs = append(refs, NewResultRefs(validateString(pt.RetriesFrom))...)
+	refs = append(refs, NewResultRefs(validateString(pt.TimeoutFrom))...)
 	return refs
 }
@@ -47,7 +47,7 @@ func (s *StepAction) Validate(ctx context.Context) (errs *apis.FieldError) {
 
 // Validate implements apis.Validatable
 func (ss *StepActionSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
-	if ss.Image == "" {
+	if ss.Image == "" && ss.Uses == nil {
 		errs = errs.Also(apis.ErrMissingField("Image"))
 	}
 
@@ -71,6 +71,35 @@ func (ss *StepActionSpec) Validate(ctx context.Context) (errs *apis.FieldError)
 	errs = errs.Also(v1.ValidateStepActionResultsVariables(ctx, ss.Results, ss.Script))
 	errs = errs.Also(v1.ValidateStepResults(ctx, ss.Results).ViaField("results"))
 	errs = errs.Also(validateVolumeMounts(ss.VolumeMounts, ss.Params).ViaField("volumeMounts"))
+	errs = errs.Also(validateUses(ctx, *ss))
+	return errs
+}
+
+// validateUses validates the reference to the StepAction a StepAction is built on,
+// and that the values passed to its parameters only refer to declared parameters.
+func validateUses(ctx context.Context, sas StepActionSpec) (errs *apis.FieldError) {
+	if sas.Uses == nil {
+		return nil
+	}
+	errs = errs.Also(config.ValidateEnabledAPIFields(ctx, "stepaction uses", config.AlphaAPIFields))
+	if sas.Script != "" {
+		errs = errs.Also(apis.ErrMultipleOneOf("script", "uses"))
+	}
+	if sas.Uses.Ref == nil {
+		return errs.Also(apis.ErrMissingField("uses.ref"))
+	}
+	errs = errs.Also(sas.Uses.Ref.Validate(ctx).ViaField("uses.ref"))
+	errs = errs.Also(v1.ValidateParameters(ctx, sas.Uses.Params).ViaField("uses.params"))
+	paramNames := sets.NewString(sas.Params.GetNames()...)
+	for i, p := range sas.Uses.Params {
+		values := append([]string{p.Value.StringVal}, p.Value.ArrayVal...)
+		for _, v := range p.Value.ObjectVal {
+			values = append(values, v)
+		}
+		for _, value := range values {
+			errs = errs.Also(substitution.ValidateNoReferencesToUnknownVariables(value, "params", paramNames).ViaFieldIndex("uses.params", i))
+		}
+	}
 	return errs
 }
 
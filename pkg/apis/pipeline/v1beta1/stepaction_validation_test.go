@@ -20,6 +20,7 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	cfgtesting "github.com/tektoncd/pipeline/pkg/apis/config/testing"
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/pipeline/test/diff"
@@ -983,3 +984,90 @@ func TestStepActionSpecValidateError(t *testing.T) {
 		})
 	}
 }
+
+func TestStepActionSpecValidateUses(t *testing.T) {
+	tests := []struct {
+		name          string
+		spec          v1beta1.StepActionSpec
+		enableAlpha   bool
+		expectedError *apis.FieldError
+	}{{
+		name: "uses without image",
+		spec: v1beta1.StepActionSpec{
+			Command: []string{"with-auth", "--"},
+			Params:  v1.ParamSpecs{{Name: "subcommand"}},
+			Uses: &v1beta1.StepActionUses{
+				Ref: &v1.Ref{Name: "run-cli"},
+				Params: v1.Params{{
+					Name:  "subcommand",
+					Value: *v1.NewStructuredValues("$(params.subcommand)"),
+				}},
+			},
+		},
+		enableAlpha: true,
+	}, {
+		name: "uses requires alpha",
+		spec: v1beta1.StepActionSpec{
+			Uses: &v1beta1.StepActionUses{Ref: &v1.Ref{Name: "run-cli"}},
+		},
+		expectedError: &apis.FieldError{
+			Message: `stepaction uses requires "enable-api-fields" feature gate to be "alpha" but it is "beta"`,
+		},
+	}, {
+		name: "uses without ref",
+		spec: v1beta1.StepActionSpec{
+			Uses: &v1beta1.StepActionUses{},
+		},
+		enableAlpha: true,
+		expectedError: &apis.FieldError{
+			Message: `missing field(s)`,
+			Paths:   []string{"uses.ref"},
+		},
+	}, {
+		name: "uses with script",
+		spec: v1beta1.StepActionSpec{
+			Script: "echo hello",
+			Uses:   &v1beta1.StepActionUses{Ref: &v1.Ref{Name: "run-cli"}},
+		},
+		enableAlpha: true,
+		expectedError: &apis.FieldError{
+			Message: `expected exactly one, got both`,
+			Paths:   []string{"script", "uses"},
+		},
+	}, {
+		name: "uses params referring to undeclared params",
+		spec: v1beta1.StepActionSpec{
+			Uses: &v1beta1.StepActionUses{
+				Ref: &v1.Ref{Name: "run-cli"},
+				Params: v1.Params{{
+					Name:  "subcommand",
+					Value: *v1.NewStructuredValues("$(params.subcommand)"),
+				}},
+			},
+		},
+		enableAlpha: true,
+		expectedError: &apis.FieldError{
+			Message: `non-existent variable in "$(params.subcommand)"`,
+			Paths:   []string{"uses.params[0]"},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := t.Context()
+			if tt.enableAlpha {
+				ctx = cfgtesting.EnableAlphaAPIFields(ctx)
+			}
+			tt.spec.SetDefaults(ctx)
+			err := tt.spec.Validate(ctx)
+			if tt.expectedError == nil {
+				if err != nil {
+					t.Errorf("StepActionSpec.Validate() = %v", err)
+				}
+				return
+			}
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
+				t.Errorf("StepActionSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
@@ -121,6 +121,11 @@ func (ts *TaskSpec) ConvertTo(ctx context.Context, sink *v1.TaskSpec, meta *meta
 	}
 	sink.DisplayName = ts.DisplayName
 	sink.Description = ts.Description
+	sink.ExpectedDuration = ts.ExpectedDuration
+	sink.ExpectedArtifactDigests = nil
+	for _, d := range ts.ExpectedArtifactDigests {
+		sink.ExpectedArtifactDigests = append(sink.ExpectedArtifactDigests, v1.ExpectedArtifactDigest{Name: d.Name, Digest: d.Digest})
+	}
 	return nil
 }
 
@@ -184,6 +189,11 @@ func (ts *TaskSpec) ConvertFrom(ctx context.Context, source *v1.TaskSpec, meta *
 	}
 	ts.DisplayName = source.DisplayName
 	ts.Description = source.Description
+	ts.ExpectedDuration = source.ExpectedDuration
+	ts.ExpectedArtifactDigests = nil
+	for _, d := range source.ExpectedArtifactDigests {
+		ts.ExpectedArtifactDigests = append(ts.ExpectedArtifactDigests, ExpectedArtifactDigest{Name: d.Name, Digest: d.Digest})
+	}
 	return nil
 }
 
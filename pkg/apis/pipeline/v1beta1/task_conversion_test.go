@@ -50,6 +50,10 @@ metadata:
 spec:
   displayName: "task-display-name"
   description: test
+  expectedDuration: 5m0s
+  expectedArtifactDigests:
+  - name: image
+    digest: sha256:$(params.param-1)
   steps:
   - image: foo
   - displayName: "step-display-name"
@@ -186,6 +190,10 @@ spec:
     workspaces:
     - name: workspace
     onError: continue
+    capabilities:
+      add: ["SETFCAP"]
+      drop: ["NET_RAW"]
+    automountServiceAccountToken: false
     stdoutConfig:
       path: /path
     stderrConfig:
@@ -209,6 +217,7 @@ spec:
   sidecars:
   - name: sidecar
     image: foo
+    onError: stopAndFail
     command: ["hello"]
     args: ["world"]
     workingDir: "/dir"
@@ -18,7 +18,9 @@ package v1beta1
 
 import (
 	"context"
+	"fmt"
 
+	"k8s.io/apimachinery/pkg/util/sets"
 	"knative.dev/pkg/apis"
 )
 
@@ -31,6 +33,7 @@ func (t *Task) SetDefaults(ctx context.Context) {
 
 // SetDefaults set any defaults for the task spec
 func (ts *TaskSpec) SetDefaults(ctx context.Context) {
+	ts.setDefaultStepNames()
 	for i := range ts.Params {
 		ts.Params[i].SetDefaults(ctx)
 	}
@@ -38,3 +41,24 @@ func (ts *TaskSpec) SetDefaults(ctx context.Context) {
 		ts.Results[i].SetDefaults(ctx)
 	}
 }
+
+// setDefaultStepNames names each unnamed step "unnamed-<step-index>", so that its name is persisted in
+// the spec and its container and its state in the TaskRun status are named after it, suffixed with
+// "-<n>" if another step is already named so.
+func (ts *TaskSpec) setDefaultStepNames() {
+	names := sets.New[string]()
+	for _, s := range ts.Steps {
+		names.Insert(s.Name)
+	}
+	for i := range ts.Steps {
+		if ts.Steps[i].Name != "" {
+			continue
+		}
+		name := fmt.Sprintf("unnamed-%d", i)
+		for n := 1; names.Has(name); n++ {
+			name = fmt.Sprintf("unnamed-%d-%d", i, n)
+		}
+		ts.Steps[i].Name = name
+		names.Insert(name)
+	}
+}
@@ -148,6 +148,19 @@ type TaskSpec struct {
 	// Results are values that this Task can output
 	// +listType=atomic
 	Results []TaskResult `json:"results,omitempty"`
+
+	// ExpectedDuration is how long the Task is expected to take. It is used for
+	// observability only: TaskRuns running longer than a configurable multiple of
+	// it are reported as running slow. It does not affect timeouts.
+	// Refer Go's ParseDuration documentation for expected format: https://golang.org/pkg/time/#ParseDuration
+	// +optional
+	ExpectedDuration *metav1.Duration `json:"expectedDuration,omitempty"`
+
+	// ExpectedArtifactDigests are the digests that the output artifacts of the Steps
+	// must have. The TaskRun fails if an artifact is produced with another digest.
+	// +optional
+	// +listType=atomic
+	ExpectedArtifactDigests []ExpectedArtifactDigest `json:"expectedArtifactDigests,omitempty"`
 }
 
 // TaskList contains a list of Task
@@ -35,6 +35,7 @@ import (
 
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation"
 	"knative.dev/pkg/apis"
@@ -70,10 +71,12 @@ var (
 // Validate implements apis.Validatable
 func (t *Task) Validate(ctx context.Context) *apis.FieldError {
 	errs := validate.ObjectMetadata(t.GetObjectMeta()).ViaField("metadata")
+	errs = errs.Also(validate.ObjectAnnotations(ctx, pipeline.TaskControllerName, t).ViaField("metadata"))
 	errs = errs.Also(t.Spec.Validate(apis.WithinSpec(ctx)).ViaField("spec"))
 	// When a Task is created directly, instead of declared inline in a TaskRun or PipelineRun,
 	// we do not support propagated parameters. Validate that all params it uses are declared.
-	return errs.Also(ValidateUsageOfDeclaredParameters(ctx, t.Spec.Steps, t.Spec.Params).ViaField("spec"))
+	errs = errs.Also(ValidateUsageOfDeclaredParameters(ctx, t.Spec.Steps, t.Spec.Params).ViaField("spec"))
+	return errs.Also(ValidateUsageOfDeclaredParametersInSidecars(t.Spec.Sidecars, t.Spec.Params).ViaField("spec"))
 }
 
 // Validate implements apis.Validatable
@@ -96,17 +99,69 @@ func (ts *TaskSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
 
 	errs = errs.Also(validateSteps(ctx, mergedSteps).ViaField("steps"))
 	errs = errs.Also(validateSidecarNames(ts.Sidecars))
+	errs = errs.Also(validateSidecarOnError(ts.Sidecars))
+	errs = errs.Also(validateSidecarScripts(ctx, ts.Sidecars))
+	errs = errs.Also(validateContainerNames(ctx, ts.Steps, ts.Sidecars))
 	errs = errs.Also(ValidateParameterTypes(ctx, ts.Params).ViaField("params"))
 	errs = errs.Also(ValidateParameterVariables(ctx, ts.Steps, ts.Params))
-	errs = errs.Also(validateTaskContextVariables(ctx, ts.Steps))
+	_, arrayParams, _ := ts.Params.sortByType()
+	errs = errs.Also(validateSidecarArrayUsage(ts.Sidecars, "params", sets.NewString(arrayParams.getNames()...)))
+	errs = errs.Also(validateTaskContextVariables(ctx, ts.Steps, ts.Sidecars))
 	errs = errs.Also(validateTaskResultsVariables(ctx, ts.Steps, ts.Results))
 	errs = errs.Also(validateResults(ctx, ts.Results).ViaField("results"))
+	errs = errs.Also(validateShadowedResultNames(ts.Steps, ts.Results))
+	errs = errs.Also(validateSidecarResults(ctx, ts.Sidecars, ts.Steps, ts.Results))
+	errs = errs.Also(validateExpectedDuration(ctx, ts.ExpectedDuration))
+	errs = errs.Also(validateExpectedArtifactDigests(ctx, ts.ExpectedArtifactDigests))
 	if ts.Resources != nil {
 		errs = errs.Also(apis.ErrDisallowedFields("resources"))
 	}
 	return errs
 }
 
+// validateExpectedDuration validates the expectedDuration of a Task or a PipelineTask
+func validateExpectedDuration(ctx context.Context, expectedDuration *metav1.Duration) (errs *apis.FieldError) {
+	if expectedDuration == nil {
+		return nil
+	}
+	errs = errs.Also(config.ValidateEnabledAPIFields(ctx, "expectedDuration", config.AlphaAPIFields))
+	if expectedDuration.Duration <= 0 {
+		errs = errs.Also(apis.ErrInvalidValue(expectedDuration.Duration.String(), "expectedDuration", "expectedDuration must be greater than 0"))
+	}
+	return errs
+}
+
+// validateExpectedArtifactDigests validates the expectedArtifactDigests of a Task
+func validateExpectedArtifactDigests(ctx context.Context, digests []ExpectedArtifactDigest) (errs *apis.FieldError) {
+	if len(digests) == 0 {
+		return nil
+	}
+	if !config.FromContextOrDefaults(ctx).FeatureFlags.EnableArtifacts {
+		return apis.ErrGeneric(fmt.Sprintf("feature flag %s should be set to true to use artifacts feature.", config.EnableArtifacts), "expectedArtifactDigests")
+	}
+	names := sets.NewString()
+	for i, d := range digests {
+		if d.Name == "" {
+			errs = errs.Also(apis.ErrMissingField("name").ViaFieldIndex("expectedArtifactDigests", i))
+		} else if names.Has(d.Name) {
+			errs = errs.Also(apis.ErrMultipleOneOf("name").ViaFieldIndex("expectedArtifactDigests", i))
+		}
+		names.Insert(d.Name)
+		if d.Digest == "" {
+			errs = errs.Also(apis.ErrMissingField("digest").ViaFieldIndex("expectedArtifactDigests", i))
+			continue
+		}
+		// The digest can only be checked once its variables are replaced at runtime.
+		if strings.Contains(d.Digest, "$(") {
+			continue
+		}
+		if alg, value, ok := strings.Cut(d.Digest, ":"); !ok || alg == "" || value == "" {
+			errs = errs.Also(apis.ErrInvalidValue(d.Digest, "digest", `digest must be in the "<algorithm>:<value>" format`).ViaFieldIndex("expectedArtifactDigests", i))
+		}
+	}
+	return errs
+}
+
 // ValidateUsageOfDeclaredParameters validates that all parameters referenced in the Task are declared by the Task.
 func ValidateUsageOfDeclaredParameters(ctx context.Context, steps []Step, params ParamSpecs) *apis.FieldError {
 	var errs *apis.FieldError
@@ -114,10 +169,40 @@ func ValidateUsageOfDeclaredParameters(ctx context.Context, steps []Step, params
 	allParameterNames := sets.NewString(params.getNames()...)
 	errs = errs.Also(validateVariables(ctx, steps, "params", allParameterNames))
 	errs = errs.Also(validateObjectUsage(ctx, steps, objectParams))
+	errs = errs.Also(validateAsFileUsage(steps, params))
 	errs = errs.Also(validateObjectParamsHaveProperties(ctx, params))
 	return errs
 }
 
+// ValidateUsageOfDeclaredParametersInSidecars validates that all parameters referenced in the Sidecars
+// of the Task are declared by the Task, and that they are referenced the way they can be substituted.
+func ValidateUsageOfDeclaredParametersInSidecars(sidecars []Sidecar, params ParamSpecs) *apis.FieldError {
+	if len(sidecars) == 0 {
+		return nil
+	}
+	errs := validateSidecarVariables(sidecars, "params", sets.NewString(params.getNames()...))
+	// object params and the params delivered as files can't be referenced as a whole
+	wholeParameterNames := sets.NewString()
+	for _, p := range params {
+		switch {
+		case p.Type == ParamTypeObject:
+			objectKeys := sets.NewString()
+			if p.AsFile {
+				objectKeys.Insert("path")
+			} else {
+				for key := range p.Properties {
+					objectKeys.Insert(key)
+				}
+			}
+			errs = errs.Also(validateSidecarVariables(sidecars, "params\\."+p.Name, objectKeys))
+			wholeParameterNames.Insert(p.Name)
+		case p.AsFile && p.Type != ParamTypeArray:
+			wholeParameterNames.Insert(p.Name)
+		}
+	}
+	return errs.Also(validateSidecarObjectUsageAsWhole(sidecars, "params", wholeParameterNames))
+}
+
 // validateObjectParamsHaveProperties returns an error if any declared object params are missing properties
 func validateObjectParamsHaveProperties(ctx context.Context, params ParamSpecs) *apis.FieldError {
 	var errs *apis.FieldError
@@ -141,6 +226,74 @@ func validateSidecarNames(sidecars []Sidecar) (errs *apis.FieldError) {
 	return errs
 }
 
+// validateSidecarOnError validates that the onError of the sidecars is either stopAndFail or ignore.
+func validateSidecarOnError(sidecars []Sidecar) (errs *apis.FieldError) {
+	for i, sc := range sidecars {
+		if sc.OnError != "" && sc.OnError != StopAndFail && sc.OnError != Ignore {
+			errs = errs.Also((&apis.FieldError{
+				Message: fmt.Sprintf("invalid value: \"%v\"", sc.OnError),
+				Paths:   []string{"onError"},
+				Details: "Sidecar onError must be either \"stopAndFail\" or \"ignore\"",
+			}).ViaFieldIndex("sidecars", i))
+		}
+	}
+	return errs
+}
+
+// validateSidecarScripts validates that the sidecars don't have a script when the
+// "disable-inline-scripts" feature flag is set.
+func validateSidecarScripts(ctx context.Context, sidecars []Sidecar) (errs *apis.FieldError) {
+	if ff := config.FromContextOrDefaults(ctx).FeatureFlags; ff == nil || !ff.DisableInlineScripts {
+		return nil
+	}
+	for i, sc := range sidecars {
+		if sc.Script != "" {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("sidecar %q can't have a script, scripts are disabled by the %q feature flag", sc.Name, config.DisableInlineScriptsKey), "script").ViaFieldIndex("sidecars", i))
+		}
+	}
+	return errs
+}
+
+// validateContainerNames validates that the steps and sidecars are run by containers with distinct names,
+// which can collide once step and sidecar names are prefixed with "step-" or "sidecar-".
+// When the spec is created, it also validates that the prefixed names fit in the 63 characters allowed
+// for container names and that step and sidecar names don't begin with these prefixes, which are reserved
+// for container names. The specs which are remotely resolved are only validated with dry runs, and their
+// container names are shortened when the pod is built instead.
+func validateContainerNames(ctx context.Context, steps []Step, sidecars []Sidecar) (errs *apis.FieldError) {
+	inCreate := apis.IsInCreate(ctx) && !apis.IsDryRun(ctx)
+	// The name of the step or sidecar each container name was given to, by container name
+	containers := map[string]string{}
+	validate := func(name, container, field string, idx int) {
+		maxLength := pipeline.MaxStepNameLength
+		if field == "sidecars" {
+			maxLength = pipeline.MaxSidecarNameLength
+		}
+		if inCreate && len(name) > maxLength {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("%q is longer than the %d characters allowed for the names of %s", name, maxLength, field), "name").ViaFieldIndex(field, idx))
+		}
+		if inCreate && (strings.HasPrefix(name, pipeline.StepContainerPrefix) || strings.HasPrefix(name, pipeline.SidecarContainerPrefix)) {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("%q begins with a prefix reserved for container names", name), "name").ViaFieldIndex(field, idx))
+		}
+		// Steps with the same name are already reported
+		if other, ok := containers[container]; ok && (other != name || field == "sidecars") {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("%q and %q are both run by a container named %q", other, name, container), "name").ViaFieldIndex(field, idx))
+		}
+		containers[container] = name
+	}
+	for i, s := range steps {
+		if s.Name != "" {
+			validate(s.Name, pipeline.StepContainerName(s.Name), "steps", i)
+		}
+	}
+	for i, sc := range sidecars {
+		if sc.Name != "" {
+			validate(sc.Name, pipeline.SidecarContainerName(sc.Name), "sidecars", i)
+		}
+	}
+	return errs
+}
+
 func validateResults(ctx context.Context, results []TaskResult) (errs *apis.FieldError) {
 	for index, result := range results {
 		errs = errs.Also(result.Validate(ctx).ViaIndex(index))
@@ -454,11 +607,48 @@ func validateStep(ctx context.Context, s Step, names sets.String) (errs *apis.Fi
 		}
 	}
 
+	maxRetries := config.DefaultMaxStepRetries
+	if defaults := config.FromContextOrDefaults(ctx).Defaults; defaults != nil {
+		maxRetries = defaults.DefaultMaxStepRetries
+	}
+	if s.Retries < 0 || s.Retries > maxRetries {
+		errs = errs.Also(apis.ErrOutOfBoundsValue(s.Retries, 0, maxRetries, "retries"))
+	}
+
+	// The RuntimeClass of a pod applies to all of its containers, so the steps asking for another
+	// RuntimeClass can only run in a second pod of the TaskRun.
+	switch {
+	case s.SecurityProfile == "":
+	case s.SecurityProfile != StepSecurityProfileIsolated:
+		errs = errs.Also(apis.ErrInvalidValue(s.SecurityProfile, "securityProfile", fmt.Sprintf("Task step securityProfile must be %q", StepSecurityProfileIsolated)))
+	case config.FromContextOrDefaults(ctx).FeatureFlags.IsolatedStepsRuntimeClass == "":
+		errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("steps with the %q securityProfile need the %q feature flag to be set, as the RuntimeClass of a pod applies to all of its steps", StepSecurityProfileIsolated, config.IsolatedStepsRuntimeClassKey), "securityProfile"))
+	}
+
+	defaults := config.FromContextOrDefaults(ctx).Defaults
+	if s.Capabilities != nil {
+		errs = errs.Also(validateAddedCapabilities(defaults, s.Name, s.Capabilities.Add).ViaField("capabilities"))
+	}
+	// The capabilities added by the securityContext are only restricted once the allowed capabilities
+	// are set, as they were allowed before.
+	if s.SecurityContext != nil && s.SecurityContext.Capabilities != nil && defaults != nil && len(defaults.DefaultAllowedStepCapabilities) > 0 {
+		errs = errs.Also(validateAddedCapabilities(defaults, s.Name, s.SecurityContext.Capabilities.Add).ViaField("securityContext.capabilities"))
+	}
+
+	// The legacy credentials helper (aka "creds-init") initializes the credentials of the ServiceAccount
+	// for every step, so removing the token doesn't remove all of its credentials from the step.
+	if s.AutomountServiceAccountToken != nil && !*s.AutomountServiceAccountToken && !config.FromContextOrDefaults(ctx).FeatureFlags.DisableCredsInit {
+		errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("the ServiceAccount token is removed from step %q but the credentials of the ServiceAccount are still initialized in it unless the \"disable-creds-init\" feature flag is set", s.Name), "automountServiceAccountToken").At(apis.WarningLevel))
+	}
+
 	if s.Script != "" {
 		cleaned := strings.TrimSpace(s.Script)
 		if strings.HasPrefix(cleaned, "#!win") {
 			errs = errs.Also(config.ValidateEnabledAPIFields(ctx, "windows script support", config.AlphaAPIFields).ViaField("script"))
 		}
+		if ff := config.FromContextOrDefaults(ctx).FeatureFlags; ff != nil && ff.DisableInlineScripts {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("step %q can't have a script, scripts are disabled by the %q feature flag", s.Name, config.DisableInlineScriptsKey), "script"))
+		}
 	}
 
 	// StdoutConfig is an alpha feature and will fail validation if it's used in a task spec
@@ -532,6 +722,10 @@ func (p ParamSpec) ValidateType(ctx context.Context) *apis.FieldError {
 		}
 	}
 
+	if p.AsFile && p.Type == ParamTypeArray {
+		return apis.ErrGeneric("asFile can't be set on array params", p.Name+".asFile")
+	}
+
 	// Check object type and its PropertySpec type
 	return p.ValidateObjectType(ctx)
 }
@@ -562,6 +756,7 @@ func ValidateParameterVariables(ctx context.Context, steps []Step, params ParamS
 	var errs *apis.FieldError
 	errs = errs.Also(params.validateNoDuplicateNames())
 	errs = errs.Also(params.validateParamEnums(ctx).ViaField("params"))
+	errs = errs.Also(params.validateParamConstraints().ViaField("params"))
 	stringParams, arrayParams, objectParams := params.sortByType()
 	stringParameterNames := sets.NewString(stringParams.getNames()...)
 	arrayParameterNames := sets.NewString(arrayParams.getNames()...)
@@ -570,7 +765,7 @@ func ValidateParameterVariables(ctx context.Context, steps []Step, params ParamS
 }
 
 // validateTaskContextVariables returns an error if any Steps reference context variables that don't exist.
-func validateTaskContextVariables(ctx context.Context, steps []Step) *apis.FieldError {
+func validateTaskContextVariables(ctx context.Context, steps []Step, sidecars []Sidecar) *apis.FieldError {
 	taskRunContextNames := sets.NewString().Insert(
 		"name",
 		"namespace",
@@ -581,7 +776,100 @@ func validateTaskContextVariables(ctx context.Context, steps []Step) *apis.Field
 		"retry-count",
 	)
 	errs := validateVariables(ctx, steps, "context\\.taskRun", taskRunContextNames)
-	return errs.Also(validateVariables(ctx, steps, "context\\.task", taskContextNames))
+	errs = errs.Also(validateSidecarVariables(sidecars, "context\\.taskRun", taskRunContextNames))
+	errs = errs.Also(validateVariables(ctx, steps, "context\\.task", taskContextNames))
+	return errs.Also(validateSidecarVariables(sidecars, "context\\.task", taskContextNames))
+}
+
+// validateShadowedResultNames warns about StepResults with the same name as a TaskResult whose
+// value isn't fetched from them. $(steps.<stepName>.results.<resultName>) always refers to the
+// StepResult and $(results.<resultName>) to the TaskResult, which is easy to mix up.
+func validateShadowedResultNames(steps []Step, results []TaskResult) (errs *apis.FieldError) {
+	// The StepResult each TaskResult is fetched from, if any, as "<stepName>.<resultName>"
+	fetchedFrom := make(map[string]string, len(results))
+	for _, r := range results {
+		fetchedFrom[r.Name] = ""
+		if r.Value != nil && r.Value.StringVal != "" {
+			if stepName, resultName, err := v1.ExtractStepResultName(r.Value.StringVal); err == nil {
+				fetchedFrom[r.Name] = stepName + "." + resultName
+			}
+		}
+	}
+	for i, s := range steps {
+		for j, r := range s.Results {
+			if from, ok := fetchedFrom[r.Name]; ok && from != s.Name+"."+r.Name {
+				errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("step result %q shadows the task result of the same name: $(steps.%s.results.%s) refers to the step result and $(results.%s) to the task result", r.Name, s.Name, r.Name, r.Name), "name").
+					ViaFieldIndex("results", j).ViaFieldIndex("steps", i).At(apis.WarningLevel))
+			}
+		}
+	}
+	return errs
+}
+
+// validateSidecarResults validates the results declared by the sidecars and the TaskResults whose
+// value is fetched from them with $(sidecars.<sidecarName>.results.<resultName>). Such a TaskResult
+// must not also be written by a step to $(results.<resultName>.path), as it would have two sources.
+func validateSidecarResults(ctx context.Context, sidecars []Sidecar, steps []Step, results []TaskResult) (errs *apis.FieldError) {
+	declared := sets.NewString()
+	for i, sc := range sidecars {
+		if len(sc.Results) == 0 {
+			continue
+		}
+		errs = errs.Also(config.ValidateEnabledAPIFields(ctx, "sidecar results", config.AlphaAPIFields))
+		if sc.Name == "" {
+			errs = errs.Also(apis.ErrMissingField("name").ViaFieldIndex("sidecars", i))
+		}
+		errs = errs.Also(v1.ValidateStepResults(ctx, sc.Results).ViaField("results").ViaFieldIndex("sidecars", i))
+		for _, r := range sc.Results {
+			declared.Insert(sc.Name + "." + r.Name)
+		}
+	}
+	for _, r := range results {
+		if r.Value == nil || !strings.HasPrefix(r.Value.StringVal, "$(sidecars.") {
+			continue
+		}
+		sidecarName, resultName, err := v1.ExtractSidecarResultName(r.Value.StringVal)
+		if err != nil {
+			// Reported when validating the TaskResult
+			continue
+		}
+		if !declared.Has(sidecarName + "." + resultName) {
+			errs = errs.Also(&apis.FieldError{
+				Message: fmt.Sprintf("sidecar %q does not declare the result %q", sidecarName, resultName),
+				Paths:   []string{"results." + r.Name + ".value"},
+			})
+		}
+		for i, s := range steps {
+			if stepWritesTaskResult(s, r.Name) {
+				errs = errs.Also(&apis.FieldError{
+					Message: fmt.Sprintf("task result %q is fetched from the result %q of sidecar %q and cannot also be written by a step", r.Name, resultName, sidecarName),
+					Paths:   []string{fmt.Sprintf("steps[%d]", i)},
+				})
+			}
+		}
+	}
+	return errs
+}
+
+// stepWritesTaskResult returns true if the step refers to the path of the named TaskResult.
+func stepWritesTaskResult(step Step, resultName string) bool {
+	paths := []string{
+		fmt.Sprintf("$(results.%s.path)", resultName),
+		fmt.Sprintf("$(results[%q].path)", resultName),
+		fmt.Sprintf("$(results['%s'].path)", resultName),
+	}
+	values := append(append([]string{step.Script}, step.Args...), step.Command...)
+	for _, e := range step.Env {
+		values = append(values, e.Value)
+	}
+	for _, v := range values {
+		for _, p := range paths {
+			if strings.Contains(v, p) {
+				return true
+			}
+		}
+	}
+	return false
 }
 
 // validateTaskResultsVariables validates if the results referenced in step script are defined in task results
@@ -603,10 +891,15 @@ func validateObjectUsage(ctx context.Context, steps []Step, params []ParamSpec)
 		// collect all names of object type params
 		objectParameterNames.Insert(p.Name)
 
-		// collect all keys for this object param
+		// collect all keys for this object param, an object param delivered as files
+		// can only be referenced by the path of its directory i.e. param.objectParam.path
 		objectKeys := sets.NewString()
-		for key := range p.Properties {
-			objectKeys.Insert(key)
+		if p.AsFile {
+			objectKeys.Insert("path")
+		} else {
+			for key := range p.Properties {
+				objectKeys.Insert(key)
+			}
 		}
 
 		// check if the object's key names are referenced correctly i.e. param.objectParam.key1
@@ -616,6 +909,21 @@ func validateObjectUsage(ctx context.Context, steps []Step, params []ParamSpec)
 	return errs.Also(validateObjectUsageAsWhole(steps, "params", objectParameterNames))
 }
 
+// validateAsFileUsage returns an error if the Steps contain references to the content of the string params
+// delivered as files, which can only be referenced by their path i.e. params.stringParam.path
+func validateAsFileUsage(steps []Step, params []ParamSpec) *apis.FieldError {
+	asFileParameterNames := sets.NewString()
+	for _, p := range params {
+		if p.AsFile && p.Type != ParamTypeObject && p.Type != ParamTypeArray {
+			asFileParameterNames.Insert(p.Name)
+		}
+	}
+	if asFileParameterNames.Len() == 0 {
+		return nil
+	}
+	return validateObjectUsageAsWhole(steps, "params", asFileParameterNames)
+}
+
 // validateObjectUsageAsWhole returns an error if the Steps contain references to the entire input object params in fields where these references are prohibited
 func validateObjectUsageAsWhole(steps []Step, prefix string, vars sets.String) (errs *apis.FieldError) {
 	for idx, step := range steps {
@@ -766,6 +1074,40 @@ func isParamRefs(s string) bool {
 	return strings.HasPrefix(s, "$("+ParamsPrefix)
 }
 
+// validateSidecarVariables returns an error if the Sidecars contain references to any unknown variables
+func validateSidecarVariables(sidecars []Sidecar, prefix string, vars sets.String) (errs *apis.FieldError) {
+	for idx, sc := range sidecars {
+		for _, f := range sc.variableFields() {
+			errs = errs.Also(substitution.ValidateNoReferencesToUnknownVariables(f.value, prefix, vars).ViaField(f.path).ViaFieldIndex("sidecars", idx))
+		}
+	}
+	return errs
+}
+
+// validateSidecarArrayUsage returns an error if the Sidecars contain references to the input array params in fields where these references are prohibited
+func validateSidecarArrayUsage(sidecars []Sidecar, prefix string, arrayParamNames sets.String) (errs *apis.FieldError) {
+	for idx, sc := range sidecars {
+		for _, f := range sc.variableFields() {
+			if f.expandsArrays {
+				errs = errs.Also(substitution.ValidateVariableReferenceIsIsolated(f.value, prefix, arrayParamNames).ViaField(f.path).ViaFieldIndex("sidecars", idx))
+			} else {
+				errs = errs.Also(substitution.ValidateNoReferencesToProhibitedVariables(f.value, prefix, arrayParamNames).ViaField(f.path).ViaFieldIndex("sidecars", idx))
+			}
+		}
+	}
+	return errs
+}
+
+// validateSidecarObjectUsageAsWhole returns an error if the Sidecars contain references to the entire input object params in fields where these references are prohibited
+func validateSidecarObjectUsageAsWhole(sidecars []Sidecar, prefix string, vars sets.String) (errs *apis.FieldError) {
+	for idx, sc := range sidecars {
+		for _, f := range sc.variableFields() {
+			errs = errs.Also(substitution.ValidateNoReferencesToEntireProhibitedVariables(f.value, prefix, vars).ViaField(f.path).ViaFieldIndex("sidecars", idx))
+		}
+	}
+	return errs
+}
+
 // GetIndexingReferencesToArrayParams returns all strings referencing indices of TaskRun array parameters
 // from parameters, workspaces, and when expressions defined in the Task.
 // For example, if a Task has a parameter with a value "$(params.array-param-name[1])",
@@ -787,3 +1129,14 @@ func (ts *TaskSpec) GetIndexingReferencesToArrayParams() sets.String {
 	}
 	return sets.NewString(arrayIndexParamRefs...)
 }
+
+// validateAddedCapabilities validates that the Linux capabilities added to the step named stepName are
+// allowed by the "default-allowed-step-capabilities" default.
+func validateAddedCapabilities(defaults *config.Defaults, stepName string, added []corev1.Capability) (errs *apis.FieldError) {
+	for i, c := range added {
+		if defaults == nil || !defaults.StepCapabilityAllowed(c) {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("capability %q can't be added to step %q, it isn't allowed by the %q default", c, stepName, config.DefaultAllowedStepCapabilitiesKey), fmt.Sprintf("add[%d]", i)))
+		}
+	}
+	return errs
+}
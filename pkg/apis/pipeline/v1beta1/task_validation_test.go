@@ -33,6 +33,7 @@ import (
 	"github.com/tektoncd/pipeline/test/diff"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/utils/pointer"
 	"knative.dev/pkg/apis"
@@ -299,7 +300,7 @@ func TestTaskSpecValidate(t *testing.T) {
 		name: "valid results path variable in script",
 		fields: fields{
 			Steps: []v1beta1.Step{{
-				Name:  "step-name",
+				Name:  "mystep",
 				Image: "my-image",
 				Script: `
 				#!/usr/bin/env bash
@@ -888,7 +889,7 @@ func TestTaskSpecValidateError(t *testing.T) {
 		name: "step script refers to nonexistent result",
 		fields: fields{
 			Steps: []v1beta1.Step{{
-				Name:  "step-name",
+				Name:  "mystep",
 				Image: "my-image",
 				Script: `
 				#!/usr/bin/env bash
@@ -1806,6 +1807,21 @@ func TestTaskSpecValidateErrorSidecarName(t *testing.T) {
 			Message: fmt.Sprintf("Invalid: cannot use reserved sidecar name %v ", pipeline.ReservedResultsSidecarName),
 			Paths:   []string{"sidecars"},
 		},
+	}, {
+		name: "invalid onError",
+		sidecars: []v1beta1.Sidecar{{
+			Name:  "valid",
+			Image: "my-image",
+		}, {
+			Name:    "proxy",
+			Image:   "my-image",
+			OnError: v1beta1.Continue,
+		}},
+		expectedError: apis.FieldError{
+			Message: `invalid value: "continue"`,
+			Paths:   []string{"sidecars[1].onError"},
+			Details: `Sidecar onError must be either "stopAndFail" or "ignore"`,
+		},
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -2405,6 +2421,117 @@ func TestParamEnum_Failure(t *testing.T) {
 	}
 }
 
+func TestParamConstraints_Success(t *testing.T) {
+	tcs := []struct {
+		name   string
+		params v1beta1.ParamSpecs
+	}{{
+		name: "pattern, minLength and maxLength",
+		params: []v1beta1.ParamSpec{{
+			Name:      "param1",
+			Type:      v1beta1.ParamTypeString,
+			Pattern:   "^v[0-9]+$",
+			MinLength: 2,
+			MaxLength: 4,
+			Default:   v1beta1.NewStructuredValues("v12"),
+		}},
+	}, {
+		name: "minLength without maxLength",
+		params: []v1beta1.ParamSpec{{
+			Name:      "param1",
+			Type:      v1beta1.ParamTypeString,
+			MinLength: 8,
+		}},
+	}, {
+		name: "default referencing a variable",
+		params: []v1beta1.ParamSpec{{
+			Name:      "param1",
+			Type:      v1beta1.ParamTypeString,
+			MaxLength: 4,
+			Default:   v1beta1.NewStructuredValues("$(context.taskRun.name)"),
+		}},
+	}}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := v1beta1.ValidateParameterVariables(t.Context(), []v1beta1.Step{{Image: "foo"}}, tc.params); err != nil {
+				t.Errorf("No error expected from ValidateParameterVariables() but got = %v", err)
+			}
+		})
+	}
+}
+
+func TestParamConstraints_Failure(t *testing.T) {
+	tcs := []struct {
+		name        string
+		params      v1beta1.ParamSpecs
+		expectedErr error
+	}{{
+		name: "invalid regular expression",
+		params: []v1beta1.ParamSpec{{
+			Name:    "param1",
+			Type:    v1beta1.ParamTypeString,
+			Pattern: "v[0-9",
+		}},
+		expectedErr: errors.New("invalid value: \"v[0-9\" is not a valid RE2 regular expression: error parsing regexp: missing closing ]: `[0-9`: params[param1].pattern"),
+	}, {
+		name: "negative minLength",
+		params: []v1beta1.ParamSpec{{
+			Name:      "param1",
+			Type:      v1beta1.ParamTypeString,
+			MinLength: -1,
+		}},
+		expectedErr: errors.New("invalid value: -1 should be >= 0: params[param1].minLength"),
+	}, {
+		name: "minLength greater than maxLength",
+		params: []v1beta1.ParamSpec{{
+			Name:      "param1",
+			Type:      v1beta1.ParamTypeString,
+			MinLength: 5,
+			MaxLength: 4,
+		}},
+		expectedErr: errors.New("invalid value: 5 should be <= maxLength 4: params[param1].minLength"),
+	}, {
+		name: "constraints with array type",
+		params: []v1beta1.ParamSpec{{
+			Name:      "param1",
+			Type:      v1beta1.ParamTypeArray,
+			MaxLength: 4,
+		}},
+		expectedErr: errors.New("pattern, minLength and maxLength can only be set with string type param: params[param1]"),
+	}, {
+		name: "default value not matching the pattern",
+		params: []v1beta1.ParamSpec{{
+			Name:    "param1",
+			Type:    v1beta1.ParamTypeString,
+			Pattern: "^v[0-9]+$",
+			Default: v1beta1.NewStructuredValues("latest"),
+		}},
+		expectedErr: errors.New("invalid value: param \"param1\" value \"latest\" does not match its pattern \"^v[0-9]+$\": params[param1].default"),
+	}, {
+		name: "default value longer than maxLength",
+		params: []v1beta1.ParamSpec{{
+			Name:      "param1",
+			Type:      v1beta1.ParamTypeString,
+			MaxLength: 4,
+			Default:   v1beta1.NewStructuredValues("v1234"),
+		}},
+		expectedErr: errors.New("invalid value: param \"param1\" value \"v1234\" is longer than its maxLength 4: params[param1].default"),
+	}}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			err := v1beta1.ValidateParameterVariables(t.Context(), []v1beta1.Step{{Image: "foo"}}, tc.params)
+			if err == nil {
+				t.Fatalf("Expected error from ValidateParameterVariables() = %v, but got none", tc.expectedErr)
+			}
+			if d := cmp.Diff(tc.expectedErr.Error(), err.Error()); d != "" {
+				t.Errorf("Returned error from ValidateParameterVariables() does not match with the expected error: %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 func TestTaskSpecValidate_StepResults(t *testing.T) {
 	type fields struct {
 		Image   string
@@ -2898,3 +3025,150 @@ func TestTaskValidateStepWithDisplayName(t *testing.T) {
 		}
 	})
 }
+
+func TestTaskValidate_SidecarVariables(t *testing.T) {
+	params := v1beta1.ParamSpecs{
+		{Name: "port", Type: v1beta1.ParamTypeString},
+		{Name: "flags", Type: v1beta1.ParamTypeArray},
+		{Name: "config", Type: v1beta1.ParamTypeObject, Properties: map[string]v1beta1.PropertySpec{"url": {Type: v1beta1.ParamTypeString}}},
+		{Name: "manifest", Type: v1beta1.ParamTypeString, AsFile: true},
+	}
+	tests := []struct {
+		name          string
+		sidecar       v1beta1.Sidecar
+		expectedError string
+	}{{
+		name: "variables in the fields they are substituted in",
+		sidecar: v1beta1.Sidecar{
+			Name:   "server",
+			Image:  "my-image",
+			Script: "serve --port $(params.port) --url $(params.config.url) --manifest $(params.manifest.path)",
+			Env:    []corev1.EnvVar{{Name: "TASKRUN", Value: "$(context.taskRun.name)"}},
+			ReadinessProbe: &corev1.Probe{ProbeHandler: corev1.ProbeHandler{
+				HTTPGet: &corev1.HTTPGetAction{Path: "/healthz", Port: intstr.FromString("$(params.port)")},
+			}},
+			Lifecycle: &corev1.Lifecycle{PreStop: &corev1.LifecycleHandler{
+				Exec: &corev1.ExecAction{Command: []string{"stop", "$(params.flags[*])"}},
+			}},
+		},
+	}, {
+		name: "undeclared param in a probe",
+		sidecar: v1beta1.Sidecar{
+			Name:  "server",
+			Image: "my-image",
+			ReadinessProbe: &corev1.Probe{ProbeHandler: corev1.ProbeHandler{
+				HTTPGet: &corev1.HTTPGetAction{Path: "$(params.path)", Port: intstr.FromInt32(8080)},
+			}},
+		},
+		expectedError: `non-existent variable in "$(params.path)": spec.sidecars[0].readinessProbe.httpGet.path`,
+	}, {
+		name: "array param in the script",
+		sidecar: v1beta1.Sidecar{
+			Name:   "server",
+			Image:  "my-image",
+			Script: "serve $(params.flags[*])",
+		},
+		expectedError: `variable type invalid in "serve $(params.flags[*])": spec.sidecars[0].script`,
+	}, {
+		name: "array param not isolated in a lifecycle hook",
+		sidecar: v1beta1.Sidecar{
+			Name:  "server",
+			Image: "my-image",
+			Lifecycle: &corev1.Lifecycle{PostStart: &corev1.LifecycleHandler{
+				Exec: &corev1.ExecAction{Command: []string{"start $(params.flags[*])"}},
+			}},
+		},
+		expectedError: `variable is not properly isolated in "start $(params.flags[*])": spec.sidecars[0].lifecycle.postStart.exec.command[0]`,
+	}, {
+		name: "undeclared key of an object param",
+		sidecar: v1beta1.Sidecar{
+			Name:  "server",
+			Image: "my-image",
+			Env:   []corev1.EnvVar{{Name: "TOKEN", Value: "$(params.config.token)"}},
+		},
+		expectedError: `non-existent variable in "$(params.config.token)": spec.sidecars[0].env[TOKEN]`,
+	}, {
+		name: "content of a param delivered as a file",
+		sidecar: v1beta1.Sidecar{
+			Name:  "server",
+			Image: "my-image",
+			Args:  []string{"$(params.manifest)"},
+		},
+		expectedError: `variable type invalid in "$(params.manifest)": spec.sidecars[0].args[0]`,
+	}, {
+		name: "unknown context variable",
+		sidecar: v1beta1.Sidecar{
+			Name:       "server",
+			Image:      "my-image",
+			WorkingDir: "/workspace/$(context.taskRun.namespac)",
+		},
+		expectedError: `non-existent variable in "/workspace/$(context.taskRun.namespac)": spec.sidecars[0].workingDir`,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &v1beta1.Task{
+				ObjectMeta: metav1.ObjectMeta{Name: "task"},
+				Spec: v1beta1.TaskSpec{
+					Params: params,
+					Steps: []v1beta1.Step{{
+						Name:  "my-step",
+						Image: "my-image",
+					}},
+					Sidecars: []v1beta1.Sidecar{tt.sidecar},
+				},
+			}
+			gotError := ""
+			if err := task.Validate(t.Context()); err != nil {
+				gotError = err.Error()
+			}
+			if d := cmp.Diff(tt.expectedError, gotError); d != "" {
+				t.Errorf("Task.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestTaskSpecValidate_DisableInlineScripts(t *testing.T) {
+	tests := []struct {
+		name          string
+		taskSpec      v1beta1.TaskSpec
+		expectedError string
+	}{{
+		name: "steps and sidecars running commands",
+		taskSpec: v1beta1.TaskSpec{
+			Steps:    []v1beta1.Step{{Name: "build", Image: "my-image", Command: []string{"build"}}},
+			Sidecars: []v1beta1.Sidecar{{Name: "registry", Image: "my-image", Command: []string{"serve"}}},
+		},
+	}, {
+		name: "step with a script",
+		taskSpec: v1beta1.TaskSpec{
+			Steps: []v1beta1.Step{{Name: "build", Image: "my-image", Command: []string{"build"}}, {Name: "test", Image: "my-image", Script: "go test ./..."}},
+		},
+		expectedError: `step "test" can't have a script, scripts are disabled by the "disable-inline-scripts" feature flag: steps[1].script`,
+	}, {
+		name: "sidecar with a script",
+		taskSpec: v1beta1.TaskSpec{
+			Steps:    []v1beta1.Step{{Name: "build", Image: "my-image", Command: []string{"build"}}},
+			Sidecars: []v1beta1.Sidecar{{Name: "registry", Image: "my-image", Script: "serve"}},
+		},
+		expectedError: `sidecar "registry" can't have a script, scripts are disabled by the "disable-inline-scripts" feature flag: sidecars[0].script`,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := cfgtesting.SetFeatureFlags(t.Context(), t, map[string]string{config.DisableInlineScriptsKey: "true"})
+			gotError := ""
+			if err := tt.taskSpec.Validate(ctx); err != nil {
+				gotError = err.Error()
+			}
+			if d := cmp.Diff(tt.expectedError, gotError); d != "" {
+				t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+
+	// Scripts are allowed unless the feature flag is set.
+	ts := v1beta1.TaskSpec{Steps: []v1beta1.Step{{Name: "test", Image: "my-image", Script: "go test ./..."}}}
+	if err := ts.Validate(t.Context()); err != nil {
+		t.Errorf("TaskSpec.Validate() = %v, want no error", err)
+	}
+}
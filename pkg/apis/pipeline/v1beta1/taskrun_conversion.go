@@ -19,6 +19,7 @@ package v1beta1
 import (
 	"context"
 	"fmt"
+	"maps"
 
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"github.com/tektoncd/pipeline/pkg/apis/version"
@@ -30,6 +31,9 @@ const (
 	cloudEventsAnnotationKey     = "tekton.dev/v1beta1CloudEvents"
 	resourcesResultAnnotationKey = "tekton.dev/v1beta1ResourcesResult"
 	resourcesStatusAnnotationKey = "tekton.dev/v1beta1ResourcesStatus"
+	// artifactsAnnotationKey is the annotation of a v1beta1 TaskRun holding the artifacts of its v1
+	// status, which v1beta1 doesn't have.
+	artifactsAnnotationKey = "tekton.dev/v1Artifacts"
 )
 
 var _ apis.Convertible = (*TaskRun)(nil)
@@ -57,6 +61,9 @@ func (tr *TaskRun) ConvertTo(ctx context.Context, to apis.Convertible) error {
 		if err := tr.Status.ConvertTo(ctx, &sink.Status, &sink.ObjectMeta); err != nil {
 			return err
 		}
+		if err := deserializeTaskRunArtifacts(&sink.ObjectMeta, &sink.Status); err != nil {
+			return err
+		}
 		return tr.Spec.ConvertTo(ctx, &sink.Spec, &sink.ObjectMeta)
 	default:
 		return fmt.Errorf("unknown version, got: %T", sink)
@@ -111,6 +118,7 @@ func (trs *TaskRunSpec) ConvertTo(ctx context.Context, sink *v1.TaskRunSpec, met
 		sink.SidecarSpecs = append(sink.SidecarSpecs, new)
 	}
 	sink.ComputeResources = trs.ComputeResources
+	sink.TTLSecondsAfterFinished = trs.TTLSecondsAfterFinished
 	return nil
 }
 
@@ -137,6 +145,9 @@ func (tr *TaskRun) ConvertFrom(ctx context.Context, from apis.Convertible) error
 		if err := deserializeTaskRunResourcesStatus(&tr.ObjectMeta, &tr.Status); err != nil {
 			return err
 		}
+		if err := serializeTaskRunArtifacts(&tr.ObjectMeta, &source.Status); err != nil {
+			return err
+		}
 		return tr.Spec.ConvertFrom(ctx, &source.Spec, &tr.ObjectMeta)
 	default:
 		return fmt.Errorf("unknown version, got: %T", tr)
@@ -194,6 +205,7 @@ func (trs *TaskRunSpec) ConvertFrom(ctx context.Context, source *v1.TaskRunSpec,
 		trs.SidecarOverrides = append(trs.SidecarOverrides, new)
 	}
 	trs.ComputeResources = source.ComputeResources
+	trs.TTLSecondsAfterFinished = source.TTLSecondsAfterFinished
 	return nil
 }
 
@@ -252,8 +264,34 @@ func (trso *TaskRunSidecarOverride) convertFrom(ctx context.Context, source v1.T
 func (trs *TaskRunStatus) ConvertTo(ctx context.Context, sink *v1.TaskRunStatus, meta *metav1.ObjectMeta) error {
 	sink.Status = trs.Status
 	sink.PodName = trs.PodName
+	sink.IsolatedPodName = trs.IsolatedPodName
 	sink.StartTime = trs.StartTime
 	sink.CompletionTime = trs.CompletionTime
+	sink.PodRetained = trs.PodRetained
+	sink.ReasonHistory = nil
+	for _, rt := range trs.ReasonHistory {
+		sink.ReasonHistory = append(sink.ReasonHistory, v1.TaskRunReasonTransition{Reason: rt.Reason, Time: rt.Time})
+	}
+	sink.ShortenedContainerNames = nil
+	for _, sc := range trs.ShortenedContainerNames {
+		sink.ShortenedContainerNames = append(sink.ShortenedContainerNames, v1.ShortenedContainerName(sc))
+	}
+	sink.PinnedImages = nil
+	for _, pi := range trs.PinnedImages {
+		sink.PinnedImages = append(sink.PinnedImages, v1.PinnedImage(pi))
+	}
+	sink.Attempts = nil
+	for _, a := range trs.Attempts {
+		new := v1.TaskRunAttempt{}
+		a.convertTo(ctx, &new)
+		sink.Attempts = append(sink.Attempts, new)
+	}
+	sink.ResultsSchema = nil
+	for _, rs := range trs.ResultsSchema {
+		new := v1.ResultSchema{}
+		rs.convertTo(&new)
+		sink.ResultsSchema = append(sink.ResultsSchema, new)
+	}
 	sink.Steps = nil
 	for _, ss := range trs.Steps {
 		new := v1.StepState{}
@@ -301,8 +339,34 @@ func (trs *TaskRunStatus) ConvertTo(ctx context.Context, sink *v1.TaskRunStatus,
 func (trs *TaskRunStatus) ConvertFrom(ctx context.Context, source v1.TaskRunStatus, meta *metav1.ObjectMeta) error {
 	trs.Status = source.Status
 	trs.PodName = source.PodName
+	trs.IsolatedPodName = source.IsolatedPodName
 	trs.StartTime = source.StartTime
 	trs.CompletionTime = source.CompletionTime
+	trs.PodRetained = source.PodRetained
+	trs.ReasonHistory = nil
+	for _, rt := range source.ReasonHistory {
+		trs.ReasonHistory = append(trs.ReasonHistory, TaskRunReasonTransition{Reason: rt.Reason, Time: rt.Time})
+	}
+	trs.ShortenedContainerNames = nil
+	for _, sc := range source.ShortenedContainerNames {
+		trs.ShortenedContainerNames = append(trs.ShortenedContainerNames, ShortenedContainerName(sc))
+	}
+	trs.PinnedImages = nil
+	for _, pi := range source.PinnedImages {
+		trs.PinnedImages = append(trs.PinnedImages, PinnedImage(pi))
+	}
+	trs.Attempts = nil
+	for _, a := range source.Attempts {
+		new := TaskRunAttempt{}
+		new.convertFrom(ctx, a)
+		trs.Attempts = append(trs.Attempts, new)
+	}
+	trs.ResultsSchema = nil
+	for _, rs := range source.ResultsSchema {
+		new := ResultSchema{}
+		new.convertFrom(rs)
+		trs.ResultsSchema = append(trs.ResultsSchema, new)
+	}
 	trs.Steps = nil
 	for _, ss := range source.Steps {
 		new := StepState{}
@@ -351,6 +415,7 @@ func (ss StepState) convertTo(ctx context.Context, sink *v1.StepState) {
 	sink.Name = ss.Name
 	sink.Container = ss.ContainerName
 	sink.ImageID = ss.ImageID
+	sink.RetryCount = ss.RetryCount
 	sink.Results = nil
 
 	if ss.Provenance != nil {
@@ -387,6 +452,7 @@ func (ss *StepState) convertFrom(ctx context.Context, source v1.StepState) {
 	ss.Name = source.Name
 	ss.ContainerName = source.Container
 	ss.ImageID = source.ImageID
+	ss.RetryCount = source.RetryCount
 	ss.Results = nil
 	for _, r := range source.Results {
 		new := TaskRunStepResult{}
@@ -416,6 +482,7 @@ func (trr TaskRunResult) convertTo(ctx context.Context, sink *v1.TaskRunResult)
 	newValue := v1.ParamValue{}
 	trr.Value.convertTo(ctx, &newValue)
 	sink.Value = newValue
+	sink.ProducedBy = trr.ProducedBy
 }
 
 func (trr *TaskRunResult) convertFrom(ctx context.Context, source v1.TaskRunResult) {
@@ -424,10 +491,38 @@ func (trr *TaskRunResult) convertFrom(ctx context.Context, source v1.TaskRunResu
 	newValue := ParamValue{}
 	newValue.convertFrom(ctx, source.Value)
 	trr.Value = newValue
+	trr.ProducedBy = source.ProducedBy
+}
+
+func (a TaskRunAttempt) convertTo(ctx context.Context, sink *v1.TaskRunAttempt) {
+	sink.Attempt = a.Attempt
+	sink.PodName = a.PodName
+	sink.Reason = a.Reason
+	sink.CompletionTime = a.CompletionTime
+	sink.Results = nil
+	for _, trr := range a.Results {
+		new := v1.TaskRunResult{}
+		trr.convertTo(ctx, &new)
+		sink.Results = append(sink.Results, new)
+	}
+}
+
+func (a *TaskRunAttempt) convertFrom(ctx context.Context, source v1.TaskRunAttempt) {
+	a.Attempt = source.Attempt
+	a.PodName = source.PodName
+	a.Reason = source.Reason
+	a.CompletionTime = source.CompletionTime
+	a.Results = nil
+	for _, trr := range source.Results {
+		new := TaskRunResult{}
+		new.convertFrom(ctx, trr)
+		a.Results = append(a.Results, new)
+	}
 }
 
 func (t *TaskRunStepArtifact) convertFrom(ctx context.Context, source v1.TaskRunStepArtifact) {
 	t.Name = source.Name
+	t.BuildOutput = source.BuildOutput
 	for _, v := range source.Values {
 		new := ArtifactValue{}
 		new.convertFrom(ctx, v)
@@ -437,6 +532,7 @@ func (t *TaskRunStepArtifact) convertFrom(ctx context.Context, source v1.TaskRun
 
 func (t TaskRunStepArtifact) convertTo(ctx context.Context, sink *v1.TaskRunStepArtifact) {
 	sink.Name = t.Name
+	sink.BuildOutput = t.BuildOutput
 	for _, v := range t.Values {
 		new := v1.ArtifactValue{}
 		v.convertTo(ctx, &new)
@@ -446,6 +542,7 @@ func (t TaskRunStepArtifact) convertTo(ctx context.Context, sink *v1.TaskRunStep
 
 func (t *ArtifactValue) convertFrom(ctx context.Context, source v1.ArtifactValue) {
 	t.Uri = source.Uri
+	t.Verified = source.Verified
 	if source.Digest != nil {
 		t.Digest = map[Algorithm]string{}
 		for i, a := range source.Digest {
@@ -455,6 +552,7 @@ func (t *ArtifactValue) convertFrom(ctx context.Context, source v1.ArtifactValue
 }
 func (t ArtifactValue) convertTo(ctx context.Context, sink *v1.ArtifactValue) {
 	sink.Uri = t.Uri
+	sink.Verified = t.Verified
 	if t.Digest != nil {
 		sink.Digest = map[v1.Algorithm]string{}
 		for i, a := range t.Digest {
@@ -468,6 +566,12 @@ func (ss SidecarState) convertTo(ctx context.Context, sink *v1.SidecarState) {
 	sink.Name = ss.Name
 	sink.Container = ss.ContainerName
 	sink.ImageID = ss.ImageID
+	sink.Results = nil
+	for _, r := range ss.Results {
+		new := v1.TaskRunResult{}
+		r.convertTo(ctx, &new)
+		sink.Results = append(sink.Results, new)
+	}
 }
 
 func (ss *SidecarState) convertFrom(ctx context.Context, source v1.SidecarState) {
@@ -475,6 +579,12 @@ func (ss *SidecarState) convertFrom(ctx context.Context, source v1.SidecarState)
 	ss.Name = source.Name
 	ss.ContainerName = source.Container
 	ss.ImageID = source.ImageID
+	ss.Results = nil
+	for _, r := range source.Results {
+		new := TaskRunResult{}
+		new.convertFrom(ctx, r)
+		ss.Results = append(ss.Results, new)
+	}
 }
 
 func serializeTaskRunResources(meta *metav1.ObjectMeta, spec *TaskRunSpec) error {
@@ -558,3 +668,28 @@ func deserializeTaskRunResourcesStatus(meta *metav1.ObjectMeta, status *TaskRunS
 	}
 	return nil
 }
+
+// serializeTaskRunArtifacts serializes the artifacts of the v1 status into an annotation of the
+// v1beta1 TaskRun, so that they aren't lost when it is converted back to v1.
+func serializeTaskRunArtifacts(meta *metav1.ObjectMeta, status *v1.TaskRunStatus) error {
+	if status.Artifacts == nil {
+		return nil
+	}
+	// The annotations are copied not to add the annotation to the v1 TaskRun sharing them.
+	meta.Annotations = maps.Clone(meta.Annotations)
+	return version.SerializeToMetadata(meta, status.Artifacts, artifactsAnnotationKey)
+}
+
+func deserializeTaskRunArtifacts(meta *metav1.ObjectMeta, status *v1.TaskRunStatus) error {
+	if _, ok := meta.Annotations[artifactsAnnotationKey]; !ok {
+		return nil
+	}
+	// The annotations are copied not to remove the annotation from the v1beta1 TaskRun sharing them.
+	meta.Annotations = maps.Clone(meta.Annotations)
+	var artifacts *v1.Artifacts
+	if err := version.DeserializeFromMetadata(meta, &artifacts, artifactsAnnotationKey); err != nil {
+		return err
+	}
+	status.Artifacts = artifacts
+	return nil
+}
@@ -17,10 +17,13 @@ limitations under the License.
 package v1beta1_test
 
 import (
+	"fmt"
+	"math/rand"
 	"testing"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/tektoncd/pipeline/pkg/apis/config"
 	pod "github.com/tektoncd/pipeline/pkg/apis/pipeline/pod"
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
@@ -29,6 +32,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	corev1resources "k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 )
@@ -236,6 +240,12 @@ func TestTaskRunConversion(t *testing.T) {
 								},
 								VolumeAttributes: map[string]string{"key": "attribute-val"},
 							},
+						}, {
+							Name: "workspace-oci-image",
+							OCIImage: &v1beta1.OCIImageWorkspaceSource{
+								Reference:  "registry.example.com/config:v1",
+								PullSecret: "registry-creds",
+							},
 						},
 					},
 					StepOverrides: []v1beta1.TaskRunStepOverride{{
@@ -255,6 +265,7 @@ func TestTaskRunConversion(t *testing.T) {
 							corev1.ResourceMemory: corev1resources.MustParse("1Gi"),
 						},
 					},
+					TTLSecondsAfterFinished: ptr.To(int32(3600)),
 				},
 				Status: v1beta1.TaskRunStatus{
 					Status: duckv1.Status{
@@ -300,9 +311,10 @@ func TestTaskRunConversion(t *testing.T) {
 							},
 						}},
 						TaskRunResults: []v1beta1.TaskRunResult{{
-							Name:  "resultName",
-							Type:  v1beta1.ResultsTypeObject,
-							Value: *v1beta1.NewObject(map[string]string{"hello": "world"}),
+							Name:       "resultName",
+							Type:       v1beta1.ResultsTypeObject,
+							Value:      *v1beta1.NewObject(map[string]string{"hello": "world"}),
+							ProducedBy: "build",
 						}},
 						TaskSpec: &v1beta1.TaskSpec{
 							Description: "test",
@@ -326,6 +338,90 @@ func TestTaskRunConversion(t *testing.T) {
 					},
 				},
 			},
+		}, {
+			name: "taskrun with attempts",
+			in: &v1beta1.TaskRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "foo",
+					Namespace: "bar",
+				},
+				Spec: v1beta1.TaskRunSpec{Retries: 2},
+				Status: v1beta1.TaskRunStatus{
+					TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+						PodName: "foo-pod-retry2",
+						Attempts: []v1beta1.TaskRunAttempt{{
+							Attempt:        0,
+							PodName:        "foo-pod",
+							Reason:         "Failed",
+							CompletionTime: &metav1.Time{Time: time.Now()},
+							Results: []v1beta1.TaskRunResult{{
+								Name:  "digest",
+								Type:  v1beta1.ResultsTypeString,
+								Value: *v1beta1.NewStructuredValues("sha256:abc"),
+							}},
+						}, {
+							Attempt: 1,
+							PodName: "foo-pod-retry1",
+							Reason:  "TaskRunTimeout",
+						}},
+					},
+				},
+			},
+		}, {
+			name: "taskrun with results schema",
+			in: &v1beta1.TaskRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "foo",
+					Namespace: "bar",
+				},
+				Spec: v1beta1.TaskRunSpec{},
+				Status: v1beta1.TaskRunStatus{
+					TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+						ResultsSchema: []v1beta1.ResultSchema{{
+							Name: "digest",
+							Type: v1beta1.ResultsTypeString,
+						}, {
+							Name: "image",
+							Type: v1beta1.ResultsTypeObject,
+							Properties: map[string]v1beta1.PropertySpec{
+								"url": {Type: v1beta1.ParamTypeString},
+							},
+						}},
+					},
+				},
+			},
+		}, {
+			name: "taskrun with trimmed step termination message",
+			in: &v1beta1.TaskRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "foo",
+					Namespace: "bar",
+				},
+				Spec: v1beta1.TaskRunSpec{},
+				Status: v1beta1.TaskRunStatus{
+					TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+						Steps: []v1beta1.StepState{{
+							ContainerState: corev1.ContainerState{
+								Terminated: &corev1.ContainerStateTerminated{
+									Message: "<results extracted>",
+								},
+							},
+							Name:          "one",
+							ContainerName: "step-one",
+							Results: []v1beta1.TaskRunStepResult{{
+								Name:  "digest",
+								Type:  v1beta1.ResultsTypeString,
+								Value: *v1beta1.NewStructuredValues("sha256:1234"),
+							}},
+						}},
+						TaskRunResults: []v1beta1.TaskRunResult{{
+							Name:  "digest",
+							Type:  v1beta1.ResultsTypeString,
+							Value: *v1beta1.NewStructuredValues("sha256:1234"),
+						}},
+					},
+				},
+			},
 		}, {
 			name: "taskrun with stepArtifacts in step state",
 			in: &v1beta1.TaskRun{
@@ -353,7 +449,8 @@ func TestTaskRunConversion(t *testing.T) {
 								Name: "Output",
 								Values: []v1beta1.ArtifactValue{
 									{
-										Uri: "docker:example.aaa/bbb:latest",
+										Uri:      "docker:example.aaa/bbb:latest",
+										Verified: true,
 										Digest: map[v1beta1.Algorithm]string{
 											"sha256": "f05a847a269ccafc90af40ad55aedef62d165227475e4d95ef6812f7c5daa21a",
 										},
@@ -760,3 +857,104 @@ func TestTaskRunConvertFrom(t *testing.T) {
 		}
 	}
 }
+
+func TestTaskRunConversionFromV1Artifacts(t *testing.T) {
+	in := &v1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "bar", Annotations: map[string]string{"keep": "me"}},
+		Spec:       v1.TaskRunSpec{TaskRef: &v1.TaskRef{Name: "task"}},
+		Status: v1.TaskRunStatus{TaskRunStatusFields: v1.TaskRunStatusFields{
+			PodName: "foo-pod",
+			Steps: []v1.StepState{{
+				Name:      "build",
+				Container: "step-build",
+				Inputs:    []v1.TaskRunStepArtifact{{Name: "source", Values: []v1.ArtifactValue{{Uri: "git:example.com/repo", Digest: map[v1.Algorithm]string{"sha1": "95588b8f34c31eb7d62c92aaa4e6506639b06ef2"}}}}},
+				Outputs:   []v1.TaskRunStepArtifact{{Name: "image", BuildOutput: true, Values: []v1.ArtifactValue{{Uri: "pkg:oci/app", Digest: map[v1.Algorithm]string{"sha256": "df85b9e3983fe2ce20ef76ad675ecf435cc99fc9350adc54fa230bae8c32ce48"}}}}},
+			}},
+			Artifacts: &v1.Artifacts{
+				Inputs:  []v1.Artifact{{Name: "source", Values: []v1.ArtifactValue{{Uri: "git:example.com/repo", Digest: map[v1.Algorithm]string{"sha1": "95588b8f34c31eb7d62c92aaa4e6506639b06ef2"}}}}},
+				Outputs: []v1.Artifact{{Name: "image", BuildOutput: true, Values: []v1.ArtifactValue{{Uri: "pkg:oci/app", Digest: map[v1.Algorithm]string{"sha256": "df85b9e3983fe2ce20ef76ad675ecf435cc99fc9350adc54fa230bae8c32ce48"}}}}},
+			},
+		}},
+	}
+	original := in.DeepCopy()
+
+	beta := &v1beta1.TaskRun{}
+	if err := beta.ConvertFrom(t.Context(), in); err != nil {
+		t.Fatalf("ConvertFrom() = %v", err)
+	}
+	if _, ok := beta.Annotations["tekton.dev/v1Artifacts"]; !ok {
+		t.Errorf("the artifacts of the status aren't serialized into the annotations of the v1beta1 TaskRun: %v", beta.Annotations)
+	}
+	if d := cmp.Diff(original, in); d != "" {
+		t.Errorf("ConvertFrom() modified the v1 TaskRun %s", diff.PrintWantGot(d))
+	}
+
+	got := &v1.TaskRun{}
+	if err := beta.ConvertTo(t.Context(), got); err != nil {
+		t.Fatalf("ConvertTo() = %v", err)
+	}
+	if d := cmp.Diff(original, got); d != "" {
+		t.Errorf("roundtrip %s", diff.PrintWantGot(d))
+	}
+}
+
+// TestTaskRunConversionFromV1ArtifactsRoundTrip round-trips randomly generated artifacts of v1
+// TaskRuns through v1beta1.
+func TestTaskRunConversionFromV1ArtifactsRoundTrip(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	randomValues := func() []v1.ArtifactValue {
+		var values []v1.ArtifactValue
+		for i := range r.Intn(3) {
+			value := v1.ArtifactValue{Uri: fmt.Sprintf("pkg:oci/image-%d-%d", r.Int63(), i), Verified: r.Intn(2) == 0}
+			if r.Intn(2) == 0 {
+				value.Digest = map[v1.Algorithm]string{"sha256": fmt.Sprintf("%064x", r.Int63())}
+			}
+			values = append(values, value)
+		}
+		return values
+	}
+	randomArtifacts := func() []v1.Artifact {
+		var artifacts []v1.Artifact
+		for i := range r.Intn(3) {
+			artifacts = append(artifacts, v1.Artifact{Name: fmt.Sprintf("artifact-%d", i), Values: randomValues(), BuildOutput: r.Intn(2) == 0})
+		}
+		return artifacts
+	}
+	randomStepArtifacts := func() []v1.TaskRunStepArtifact {
+		var artifacts []v1.TaskRunStepArtifact
+		for _, a := range randomArtifacts() {
+			artifacts = append(artifacts, v1.TaskRunStepArtifact(a))
+		}
+		return artifacts
+	}
+
+	for i := range 100 {
+		in := &v1.TaskRun{
+			ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "bar"},
+			Spec:       v1.TaskRunSpec{TaskRef: &v1.TaskRef{Name: "task"}},
+		}
+		for j := range r.Intn(3) {
+			in.Status.Steps = append(in.Status.Steps, v1.StepState{
+				Name:    fmt.Sprintf("step-%d", j),
+				Inputs:  randomStepArtifacts(),
+				Outputs: randomStepArtifacts(),
+			})
+		}
+		if r.Intn(4) != 0 {
+			in.Status.Artifacts = &v1.Artifacts{Inputs: randomArtifacts(), Outputs: randomArtifacts()}
+		}
+		original := in.DeepCopy()
+
+		beta := &v1beta1.TaskRun{}
+		if err := beta.ConvertFrom(t.Context(), in); err != nil {
+			t.Fatalf("ConvertFrom() #%d = %v", i, err)
+		}
+		got := &v1.TaskRun{}
+		if err := beta.ConvertTo(t.Context(), got); err != nil {
+			t.Fatalf("ConvertTo() #%d = %v", i, err)
+		}
+		if d := cmp.Diff(original, got, cmpopts.EquateEmpty()); d != "" {
+			t.Errorf("roundtrip #%d %s", i, diff.PrintWantGot(d))
+		}
+	}
+}
@@ -42,6 +42,7 @@ func (tr *TaskRun) SetDefaults(ctx context.Context) {
 		tr.ObjectMeta.Annotations = kmap.Filter(tr.ObjectMeta.Annotations, func(s string) bool {
 			return filterReservedAnnotationRegexp.MatchString(s)
 		})
+		setWorkspaceStorageDefaults(ctx, &tr.ObjectMeta, tr.Spec.Workspaces)
 	}
 
 	// If the TaskRun doesn't have a managed-by label, apply the default
@@ -68,7 +69,8 @@ func (trs *TaskRunSpec) SetDefaults(ctx context.Context) {
 	}
 
 	if trs.Timeout == nil {
-		trs.Timeout = &metav1.Duration{Duration: time.Duration(cfg.Defaults.DefaultTimeoutMinutes) * time.Minute}
+		defaultTimeout := time.Duration(cfg.Defaults.DefaultTimeoutMinutes) * time.Minute
+		trs.Timeout = &metav1.Duration{Duration: cfg.Defaults.CapTaskRunTimeout(defaultTimeout)}
 	}
 
 	defaultSA := cfg.Defaults.DefaultServiceAccount
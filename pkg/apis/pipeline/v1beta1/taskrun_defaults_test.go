@@ -402,6 +402,7 @@ func TestTaskRunDefaulting(t *testing.T) {
 }
 
 func TestTaskRunDefaultingOnCreate(t *testing.T) {
+	storageClass, explicitStorageClass := "local-wait-for-first-consumer", "explicit"
 	tests := []struct {
 		name     string
 		in       *v1beta1.TaskRun
@@ -433,6 +434,62 @@ func TestTaskRunDefaultingOnCreate(t *testing.T) {
 				ServiceAccountName: "default",
 			},
 		},
+	}, {
+		name: "VolumeClaimTemplates of workspaces get the default storage class and access mode on create",
+		in: &v1beta1.TaskRun{
+			Spec: v1beta1.TaskRunSpec{
+				TaskRef: &v1beta1.TaskRef{
+					Name: "foo",
+				},
+				Workspaces: []v1beta1.WorkspaceBinding{{
+					Name:                "defaulted",
+					VolumeClaimTemplate: &corev1.PersistentVolumeClaim{},
+				}, {
+					Name: "explicit",
+					VolumeClaimTemplate: &corev1.PersistentVolumeClaim{
+						Spec: corev1.PersistentVolumeClaimSpec{
+							StorageClassName: &explicitStorageClass,
+							AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany},
+						},
+					},
+				}},
+			},
+		},
+		want: &v1beta1.TaskRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels:      map[string]string{"app.kubernetes.io/managed-by": "tekton-pipelines"},
+				Annotations: map[string]string{"tekton.dev/defaulted-workspace-storage": "defaulted"},
+			},
+			Spec: v1beta1.TaskRunSpec{
+				TaskRef: &v1beta1.TaskRef{
+					Kind: "Task",
+					Name: "foo",
+				},
+				Timeout:            &metav1.Duration{Duration: time.Hour},
+				ServiceAccountName: "default",
+				Workspaces: []v1beta1.WorkspaceBinding{{
+					Name: "defaulted",
+					VolumeClaimTemplate: &corev1.PersistentVolumeClaim{
+						Spec: corev1.PersistentVolumeClaimSpec{
+							StorageClassName: &storageClass,
+							AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+						},
+					},
+				}, {
+					Name: "explicit",
+					VolumeClaimTemplate: &corev1.PersistentVolumeClaim{
+						Spec: corev1.PersistentVolumeClaimSpec{
+							StorageClassName: &explicitStorageClass,
+							AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany},
+						},
+					},
+				}},
+			},
+		},
+		defaults: map[string]string{
+			"default-workspace-storage-class": "local-wait-for-first-consumer",
+			"default-workspace-access-mode":   "ReadWriteOnce",
+		},
 	}}
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
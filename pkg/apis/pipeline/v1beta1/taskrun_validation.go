@@ -20,8 +20,10 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	pod "github.com/tektoncd/pipeline/pkg/apis/pipeline/pod"
 	"github.com/tektoncd/pipeline/pkg/apis/validate"
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
@@ -46,6 +48,7 @@ func (tr *TaskRun) SupportedVerbs() []admissionregistrationv1.OperationType {
 // Validate taskrun
 func (tr *TaskRun) Validate(ctx context.Context) *apis.FieldError {
 	errs := validate.ObjectMetadata(tr.GetObjectMeta()).ViaField("metadata")
+	errs = errs.Also(validate.ObjectAnnotations(ctx, pipeline.TaskRunControllerName, tr).ViaField("metadata"))
 
 	if tr.IsPending() && tr.HasStarted() {
 		errs = errs.Also(apis.ErrInvalidValue("TaskRun cannot be Pending after it is started", "spec.status"))
@@ -77,9 +80,11 @@ func (ts *TaskRunSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
 			errs = errs.Also(apis.ErrDisallowedFields("taskSpec"))
 		}
 		errs = errs.Also(ts.TaskSpec.Validate(ctx).ViaField("taskSpec"))
+		errs = errs.Also(ts.TaskSpec.Params.validateParamValueConstraints(ts.Params).ViaField("params"))
 	}
 
 	errs = errs.Also(ValidateParameters(ctx, ts.Params).ViaField("params"))
+	errs = errs.Also(ts.Params.validateNoRunRefs().ViaField("params"))
 
 	// Validate propagated parameters
 	errs = errs.Also(ts.validateInlineParameters(ctx))
@@ -114,11 +119,19 @@ func (ts *TaskRunSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
 
 	if ts.PodTemplate != nil {
 		errs = errs.Also(validatePodTemplateEnv(ctx, *ts.PodTemplate))
+		errs = errs.Also(ts.PodTemplate.ValidateTopologySpreadConstraints().ViaField("podTemplate"))
 	}
 
 	if ts.Timeout != nil && ts.Timeout.Duration < 0 {
 		errs = errs.Also(apis.ErrInvalidValue(ts.Timeout.Duration.String()+" should be >= 0", "timeout"))
 	}
+	if ts.Timeout != nil {
+		errs = errs.Also(validateMaximumTaskRunTimeout(ctx, ts.Timeout.Duration))
+	}
+
+	if ts.TTLSecondsAfterFinished != nil && *ts.TTLSecondsAfterFinished < 0 {
+		errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%d should be >= 0", *ts.TTLSecondsAfterFinished), "ttlSecondsAfterFinished"))
+	}
 
 	if ts.Resources != nil {
 		errs = errs.Also(apis.ErrDisallowedFields("resources"))
@@ -126,6 +139,17 @@ func (ts *TaskRunSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
 	return errs
 }
 
+// validateMaximumTaskRunTimeout validates that the timeout of a TaskRun doesn't exceed the maximum
+// TaskRun duration on creation, the TaskRuns created before the maximum was lowered being capped
+// when they run instead. A timeout of 0, meaning no timeout, is capped when the TaskRun runs too.
+func validateMaximumTaskRunTimeout(ctx context.Context, timeout time.Duration) *apis.FieldError {
+	maximum := config.FromContextOrDefaults(ctx).Defaults.DefaultMaximumTaskRunTimeout
+	if apis.IsInUpdate(ctx) || maximum <= 0 || timeout <= maximum {
+		return nil
+	}
+	return apis.ErrInvalidValue(fmt.Sprintf("%s should be <= maximum TaskRun duration %s", timeout, maximum), "timeout")
+}
+
 // ValidateUpdate validates the update of a TaskRunSpec
 func (ts *TaskRunSpec) ValidateUpdate(ctx context.Context) (errs *apis.FieldError) {
 	if !apis.IsInUpdate(ctx) {
@@ -203,6 +227,7 @@ func (ts *TaskRunSpec) validateInlineParameters(ctx context.Context) (errs *apis
 		errs = errs.Also(ValidateParameterTypes(ctx, paramSpec))
 		errs = errs.Also(ValidateParameterVariables(ctx, ts.TaskSpec.Steps, paramSpec))
 		errs = errs.Also(ValidateUsageOfDeclaredParameters(ctx, ts.TaskSpec.Steps, paramSpec))
+		errs = errs.Also(ValidateUsageOfDeclaredParametersInSidecars(ts.TaskSpec.Sidecars, paramSpec))
 	}
 	return errs
 }
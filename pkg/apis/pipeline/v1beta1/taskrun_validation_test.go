@@ -593,6 +593,29 @@ func TestTaskRunSpec_Invalidate(t *testing.T) {
 			Timeout: &metav1.Duration{Duration: -48 * time.Hour},
 		},
 		wantErr: apis.ErrInvalidValue("-48h0m0s should be >= 0", "timeout"),
+	}, {
+		name: "negative ttlSecondsAfterFinished",
+		spec: v1beta1.TaskRunSpec{
+			TaskRef: &v1beta1.TaskRef{
+				Name: "taskrefname",
+			},
+			TTLSecondsAfterFinished: ptr.Int32(-1),
+		},
+		wantErr: apis.ErrInvalidValue("-1 should be >= 0", "ttlSecondsAfterFinished"),
+	}, {
+		name: "timeout above the maximum taskrun timeout",
+		spec: v1beta1.TaskRunSpec{
+			TaskRef: &v1beta1.TaskRef{
+				Name: "taskrefname",
+			},
+			Timeout: &metav1.Duration{Duration: 2 * time.Hour},
+		},
+		wantErr: apis.ErrInvalidValue("2h0m0s should be <= maximum TaskRun duration 1h0m0s", "timeout"),
+		wc: func(ctx context.Context) context.Context {
+			return config.ToContext(ctx, &config.Config{
+				Defaults: &config.Defaults{DefaultMaximumTaskRunTimeout: time.Hour},
+			})
+		},
 	}, {
 		name: "wrong taskrun cancel",
 		spec: v1beta1.TaskRunSpec{
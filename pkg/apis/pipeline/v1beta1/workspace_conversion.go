@@ -64,12 +64,14 @@ func (w WorkspacePipelineTaskBinding) convertTo(ctx context.Context, sink *v1.Wo
 	sink.Name = w.Name
 	sink.Workspace = w.Workspace
 	sink.SubPath = w.SubPath
+	sink.PerMatrixInstance = w.PerMatrixInstance
 }
 
 func (w *WorkspacePipelineTaskBinding) convertFrom(ctx context.Context, source v1.WorkspacePipelineTaskBinding) {
 	w.Name = source.Name
 	w.Workspace = source.Workspace
 	w.SubPath = source.SubPath
+	w.PerMatrixInstance = source.PerMatrixInstance
 }
 
 func (w WorkspaceBinding) convertTo(ctx context.Context, sink *v1.WorkspaceBinding) {
@@ -82,6 +84,12 @@ func (w WorkspaceBinding) convertTo(ctx context.Context, sink *v1.WorkspaceBindi
 	sink.Secret = w.Secret
 	sink.Projected = w.Projected
 	sink.CSI = w.CSI
+	if w.OCIImage != nil {
+		sink.OCIImage = &v1.OCIImageWorkspaceSource{
+			Reference:  w.OCIImage.Reference,
+			PullSecret: w.OCIImage.PullSecret,
+		}
+	}
 }
 
 // ConvertFrom converts v1beta1 Param from v1 Param
@@ -95,4 +103,10 @@ func (w *WorkspaceBinding) ConvertFrom(ctx context.Context, source v1.WorkspaceB
 	w.Secret = source.Secret
 	w.Projected = source.Projected
 	w.CSI = source.CSI
+	if source.OCIImage != nil {
+		w.OCIImage = &OCIImageWorkspaceSource{
+			Reference:  source.OCIImage.Reference,
+			PullSecret: source.OCIImage.PullSecret,
+		}
+	}
 }
@@ -86,6 +86,20 @@ type WorkspaceBinding struct {
 	// CSI (Container Storage Interface) represents ephemeral storage that is handled by certain external CSI drivers.
 	// +optional
 	CSI *corev1.CSIVolumeSource `json:"csi,omitempty"`
+	// OCIImage represents an OCI image or artifact whose content populates this workspace,
+	// which can only be bound to read-only workspaces.
+	// +optional
+	OCIImage *OCIImageWorkspaceSource `json:"ociImage,omitempty"`
+}
+
+// OCIImageWorkspaceSource is an OCI image or artifact whose content populates a workspace.
+type OCIImageWorkspaceSource struct {
+	// Reference is the reference of the image or artifact, as the image of a container.
+	Reference string `json:"reference"`
+	// PullSecret is the name of the secret of type kubernetes.io/dockerconfigjson used to pull
+	// the image or artifact, along with the image pull secrets of the pod.
+	// +optional
+	PullSecret string `json:"pullSecret,omitempty"`
 }
 
 // WorkspacePipelineDeclaration creates a named slot in a Pipeline that a PipelineRun
@@ -121,6 +135,11 @@ type WorkspacePipelineTaskBinding struct {
 	// for this binding (i.e. the volume will be mounted at this sub directory).
 	// +optional
 	SubPath string `json:"subPath,omitempty"`
+	// PerMatrixInstance binds each instance of a matrixed PipelineTask to its own
+	// PersistentVolumeClaim, created from the volumeClaimTemplate bound to the workspace,
+	// instead of sharing a single claim between the instances.
+	// +optional
+	PerMatrixInstance bool `json:"perMatrixInstance,omitempty"`
 }
 
 // WorkspaceUsage is used by a Step or Sidecar to declare that it wants isolated access
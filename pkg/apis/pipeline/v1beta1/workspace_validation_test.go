@@ -20,6 +20,7 @@ import (
 	"context"
 	"testing"
 
+	cfgtesting "github.com/tektoncd/pipeline/pkg/apis/config/testing"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -109,6 +110,16 @@ func TestWorkspaceBindingValidateValid(t *testing.T) {
 				Driver: "my-csi",
 			},
 		},
+	}, {
+		name: "Valid ociImage",
+		binding: &v1beta1.WorkspaceBinding{
+			Name: "beth",
+			OCIImage: &v1beta1.OCIImageWorkspaceSource{
+				Reference:  "registry.example.com/config:v1",
+				PullSecret: "registry-creds",
+			},
+		},
+		wc: cfgtesting.EnableAlphaAPIFields,
 	}} {
 		t.Run(tc.name, func(t *testing.T) {
 			ctx := t.Context()
@@ -176,6 +187,22 @@ func TestWorkspaceBindingValidateInvalid(t *testing.T) {
 				Driver: "",
 			},
 		},
+	}, {
+		name: "Provide ociImage without a reference",
+		binding: &v1beta1.WorkspaceBinding{
+			Name:     "beth",
+			OCIImage: &v1beta1.OCIImageWorkspaceSource{},
+		},
+		wc: cfgtesting.EnableAlphaAPIFields,
+	}, {
+		name: "Provide ociImage without alpha api fields",
+		binding: &v1beta1.WorkspaceBinding{
+			Name: "beth",
+			OCIImage: &v1beta1.OCIImageWorkspaceSource{
+				Reference: "registry.example.com/config:v1",
+			},
+		},
+		wc: cfgtesting.EnableBetaAPIFields,
 	}} {
 		t.Run(tc.name, func(t *testing.T) {
 			ctx := t.Context()
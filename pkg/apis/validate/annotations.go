@@ -0,0 +1,349 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"knative.dev/pkg/apis"
+)
+
+// reservedAnnotationPrefixes are the prefixes of the annotation keys owned by Tekton Pipelines. Unknown
+// keys with these prefixes are most likely typos of the keys of registered annotations.
+var reservedAnnotationPrefixes = []string{"tekton.dev/", "pipeline.tekton.dev/"}
+
+// Annotation describes an annotation recognized by Tekton Pipelines on its resources.
+type Annotation struct {
+	// Key is the key of the annotation.
+	Key string
+	// Description tells what the annotation is used for.
+	Description string
+	// Kinds are the kinds of the resources the annotation applies to, including the kinds it is propagated to.
+	Kinds []string
+	// Values are the values allowed for the annotation, any value is allowed if empty.
+	Values []string
+	// validateValue further validates the values of the annotation, if not nil.
+	validateValue func(string) error
+}
+
+// ValidateValue returns an error if value isn't allowed for the annotation.
+func (a Annotation) ValidateValue(value string) error {
+	if len(a.Values) > 0 && !slices.Contains(a.Values, value) {
+		return fmt.Errorf("%q must be one of %s", value, strings.Join(a.Values, ", "))
+	}
+	if a.validateValue != nil {
+		return a.validateValue(value)
+	}
+	return nil
+}
+
+// AppliesTo returns whether the annotation applies to resources of the given kind.
+func (a Annotation) AppliesTo(kind string) bool {
+	return slices.Contains(a.Kinds, kind)
+}
+
+var (
+	// The annotations of Tasks and Pipelines are propagated to their runs, and the ones of PipelineRuns
+	// to their TaskRuns, which then carry them too.
+	allKinds = []string{
+		pipeline.TaskControllerName,
+		pipeline.TaskRunControllerName,
+		pipeline.PipelineControllerName,
+		pipeline.PipelineRunControllerName,
+	}
+	runKinds = []string{pipeline.TaskRunControllerName, pipeline.PipelineRunControllerName}
+
+	registeredAnnotations = map[string]Annotation{}
+)
+
+func init() {
+	for _, a := range []Annotation{{
+		Key:         "tekton.dev/pipelines.minVersion",
+		Description: "The minimum version of Tekton Pipelines the resource is meant for, as published in catalogs.",
+		Kinds:       allKinds,
+	}, {
+		Key:         "tekton.dev/categories",
+		Description: "The comma separated categories of the resource, as published in catalogs.",
+		Kinds:       allKinds,
+	}, {
+		Key:         "tekton.dev/tags",
+		Description: "The comma separated tags of the resource, as published in catalogs.",
+		Kinds:       allKinds,
+	}, {
+		Key:         "tekton.dev/displayName",
+		Description: "The display name of the resource, as published in catalogs.",
+		Kinds:       allKinds,
+	}, {
+		Key:         "tekton.dev/platforms",
+		Description: "The comma separated platforms the resource can run on, as published in catalogs.",
+		Kinds:       allKinds,
+	}, {
+		Key:         "tekton.dev/deprecated",
+		Description: "Whether the resource is deprecated, as published in catalogs.",
+		Kinds:       allKinds,
+		Values:      []string{"true", "false"},
+	}, {
+		Key:         "tekton.dev/signature",
+		Description: "The signature of the resource, verified by the trusted resources verification policies.",
+		Kinds:       allKinds,
+	}, {
+		Key:         "tekton.dev/v1beta1.task-deprecations",
+		Description: "The deprecated v1beta1 fields of a Task, kept when it is converted to v1.",
+		Kinds:       []string{pipeline.TaskControllerName, pipeline.TaskRunControllerName},
+	}, {
+		Key:         "tekton.dev/v1beta1Resources",
+		Description: "The v1beta1 PipelineResources of the resource, kept when it is converted to v1.",
+		Kinds:       allKinds,
+	}, {
+		Key:         "tekton.dev/v1beta1CloudEvents",
+		Description: "The v1beta1 cloud events of the status of a TaskRun, kept when it is converted to v1.",
+		Kinds:       []string{pipeline.TaskRunControllerName},
+	}, {
+		Key:         "tekton.dev/v1beta1ResourcesResult",
+		Description: "The v1beta1 PipelineResources results of the status of a TaskRun, kept when it is converted to v1.",
+		Kinds:       []string{pipeline.TaskRunControllerName},
+	}, {
+		Key:         "tekton.dev/v1beta1ResourcesStatus",
+		Description: "The v1beta1 PipelineResources of the status of a TaskRun, kept when it is converted to v1.",
+		Kinds:       []string{pipeline.TaskRunControllerName},
+	}, {
+		Key:           "tekton.dev/v1Artifacts",
+		Description:   "The v1 artifacts of the status of a TaskRun, kept when it is converted to v1beta1.",
+		Kinds:         []string{pipeline.TaskRunControllerName},
+		validateValue: validateJSON,
+	}, {
+		Key:           "tekton.dev/pipelinerunSpanContext",
+		Description:   "The JSON encoded tracing span context of a PipelineRun.",
+		Kinds:         runKinds,
+		validateValue: validateJSON,
+	}, {
+		Key:           "tekton.dev/taskrunSpanContext",
+		Description:   "The JSON encoded tracing span context of a TaskRun.",
+		Kinds:         []string{pipeline.TaskRunControllerName},
+		validateValue: validateJSON,
+	}, {
+		Key:           "tekton.dev/customrunSpanContext",
+		Description:   "The JSON encoded tracing span context of a CustomRun.",
+		Kinds:         []string{pipeline.CustomRunControllerName},
+		validateValue: validateJSON,
+	}, {
+		Key:         "tekton.dev/status-hash",
+		Description: "The hash of the status of a TaskRun, set in the annotations of its status when SPIRE is enabled.",
+		Kinds:       []string{pipeline.TaskRunControllerName},
+	}, {
+		Key:         "tekton.dev/status-hash-sig",
+		Description: "The signature of the hash of the status of a TaskRun, set in the annotations of its status when SPIRE is enabled.",
+		Kinds:       []string{pipeline.TaskRunControllerName},
+	}, {
+		Key:         "tekton.dev/controller-svid",
+		Description: "The SVID of the controller which signed the status of a TaskRun, set in the annotations of its status when SPIRE is enabled.",
+		Kinds:       []string{pipeline.TaskRunControllerName},
+	}, {
+		Key:         "tekton.dev/spire-verified",
+		Description: "Set in the annotations of the status of a TaskRun when the status fails the SPIRE checks.",
+		Kinds:       []string{pipeline.TaskRunControllerName},
+		Values:      []string{"no"},
+	}, {
+		Key:         "tekton.dev/results-from",
+		Description: "The method used to extract the results of the run, instead of the one set by the \"results-from\" feature flag.",
+		Kinds:       runKinds,
+		Values:      []string{"termination-message", "sidecar-logs", "sidecar-volume"},
+	}, {
+		Key:         "tekton.dev/running-slow",
+		Description: "Set on TaskRuns running for longer than the configured multiple of their expected duration.",
+		Kinds:       []string{pipeline.TaskRunControllerName},
+		Values:      []string{"true"},
+	}, {
+		Key:         "tekton.dev/auto-cleanup-pvc",
+		Description: "Whether the PVCs created for the workspaces of a PipelineRun are deleted once it completes.",
+		Kinds:       runKinds,
+		Values:      []string{"true", "false"},
+	}, {
+		Key:         "tekton.dev/pin-image-digests",
+		Description: "Whether the images of the steps and sidecars of a TaskRun are pinned to their digests when its pod is created.",
+		Kinds:       runKinds,
+		Values:      []string{"true", "false"},
+	}, {
+		Key:         "tekton.dev/recreate-deleted-pod",
+		Description: "Whether a replacement pod is created when the pod of a TaskRun is deleted out-of-band, e.g. when its node is drained, instead of failing it.",
+		Kinds:       allKinds,
+		Values:      []string{"true", "false"},
+	}, {
+		Key:         "tekton.dev/helper-image-set",
+		Description: "The name of the helper image set, configured with \"helper-image-sets\" in config-defaults, the pods and Affinity Assistants of the run are created with.",
+		Kinds:       runKinds,
+	}, {
+		Key:         "tekton.dev/defaulted-workspace-storage",
+		Description: "The comma separated names of the workspaces of the run whose volumeClaimTemplates got the default storage class or access mode of config-defaults.",
+		Kinds:       runKinds,
+	}, {
+		Key:         "tekton.dev/duplicate-workspace-bindings",
+		Description: "The comma separated names of the workspaces bound more than once by a run created before such runs were rejected, which are bound to their first binding.",
+		Kinds:       runKinds,
+	}, {
+		Key:           "tekton.dev/clock-skew-detected",
+		Description:   "The duration by which the completion time of a TaskRun preceded its start time, which it was set to instead.",
+		Kinds:         []string{pipeline.TaskRunControllerName},
+		validateValue: validateDuration,
+	}, {
+		Key:           "tekton.dev/pod-group-size",
+		Description:   "The number of pods of the pod group of the PipelineRun of a TaskRun, unless \"default-pod-group-size-annotation-key\" is set in config-defaults.",
+		Kinds:         []string{pipeline.TaskRunControllerName},
+		validateValue: validateCount,
+	}, {
+		Key:         "tekton.dev/keep",
+		Description: "Whether a completed run is kept instead of deleted once its ttlSecondsAfterFinished elapsed.",
+		Kinds:       runKinds,
+		Values:      []string{"true", "false"},
+	}, {
+		Key:         "pipeline.tekton.dev/pipeline-task-on-error",
+		Description: "The onError strategy of the PipelineTask of a TaskRun.",
+		Kinds:       []string{pipeline.TaskRunControllerName},
+		Values:      []string{"stopAndFail", "continue"},
+	}, {
+		Key:           "pipeline.tekton.dev/pipeline-task-expected-duration",
+		Description:   "The expected duration of the PipelineTask of a TaskRun.",
+		Kinds:         []string{pipeline.TaskRunControllerName},
+		validateValue: validateDuration,
+	}, {
+		Key:         "pipeline.tekton.dev/pipeline-task-display-name",
+		Description: "The display name of the PipelineTask of a TaskRun.",
+		Kinds:       []string{pipeline.TaskRunControllerName},
+	}, {
+		Key:         "pipeline.tekton.dev/pipeline-task-description",
+		Description: "The description of the PipelineTask of a TaskRun.",
+		Kinds:       []string{pipeline.TaskRunControllerName},
+	}, {
+		Key:         "pipeline.tekton.dev/priority",
+		Description: "The priority of a PipelineRun, which starts right away without being delayed by the \"default-start-jitter\" config when high.",
+		Kinds:       runKinds,
+		Values:      []string{"high"},
+	}, {
+		Key:         "pipeline.tekton.dev/affinity-assistant",
+		Description: "The name of the Affinity Assistant the pods of a TaskRun are scheduled with.",
+		Kinds:       []string{pipeline.TaskRunControllerName},
+	}, {
+		Key:         "pipeline.tekton.dev/coschedule",
+		Description: "The \"coschedule\" feature flag the PipelineRun of a TaskRun was started with, as recorded in the status of the PipelineRun.",
+		Kinds:       []string{pipeline.TaskRunControllerName},
+		Values:      []string{"workspaces", "pipelineruns", "isolate-pipelinerun", "disabled"},
+	}, {
+		Key:         "pipeline.tekton.dev/release",
+		Description: "The version of Tekton Pipelines which created the pod of a TaskRun.",
+		Kinds:       []string{pipeline.TaskRunControllerName},
+	}, {
+		Key:         "experimental.tekton.dev/execution-mode",
+		Description: "The execution mode of the steps of a TaskRun, with no network access when hermetic.",
+		Kinds:       runKinds,
+		Values:      []string{"hermetic"},
+	}} {
+		registeredAnnotations[a.Key] = a
+	}
+}
+
+func validateJSON(value string) error {
+	if !json.Valid([]byte(value)) {
+		return fmt.Errorf("%q is not valid JSON", value)
+	}
+	return nil
+}
+
+func validateDuration(value string) error {
+	if _, err := time.ParseDuration(value); err != nil {
+		return fmt.Errorf("%q is not a valid duration: %w", value, err)
+	}
+	return nil
+}
+
+func validateCount(value string) error {
+	if n, err := strconv.Atoi(value); err != nil || n < 0 {
+		return fmt.Errorf("%q is not a non-negative integer", value)
+	}
+	return nil
+}
+
+// RegisteredAnnotations returns the annotations recognized by Tekton Pipelines, sorted by key.
+func RegisteredAnnotations() []Annotation {
+	annotations := make([]Annotation, 0, len(registeredAnnotations))
+	for _, a := range registeredAnnotations {
+		annotations = append(annotations, a)
+	}
+	sort.Slice(annotations, func(i, j int) bool { return annotations[i].Key < annotations[j].Key })
+	return annotations
+}
+
+// LookupAnnotation returns the registered annotation with the given key, if any.
+func LookupAnnotation(key string) (Annotation, bool) {
+	a, ok := registeredAnnotations[key]
+	return a, ok
+}
+
+// ObjectAnnotations validates the annotations of meta, a resource of the given kind, against the
+// registered annotations: the values of registered annotations must be allowed, and a warning is
+// returned for unknown keys with the prefixes owned by Tekton Pipelines and for annotations which
+// don't apply to the kind. On updates, only the annotations added or changed are validated, so that
+// resources created before an annotation was registered can still be updated.
+func ObjectAnnotations(ctx context.Context, kind string, meta interface{ GetAnnotations() map[string]string }) (errs *apis.FieldError) {
+	var baseline map[string]string
+	if apis.IsInUpdate(ctx) {
+		if old, ok := apis.GetBaseline(ctx).(interface{ GetAnnotations() map[string]string }); ok {
+			baseline = old.GetAnnotations()
+		}
+	}
+
+	annotations := meta.GetAnnotations()
+	keys := make([]string, 0, len(annotations))
+	for k := range annotations {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		value := annotations[k]
+		if old, ok := baseline[k]; ok && old == value {
+			continue
+		}
+		a, ok := registeredAnnotations[k]
+		if !ok {
+			if slices.ContainsFunc(reservedAnnotationPrefixes, func(prefix string) bool { return strings.HasPrefix(k, prefix) }) {
+				errs = errs.Also(apis.ErrGeneric("unknown annotation with a prefix reserved for Tekton Pipelines", annotationPath(k)).At(apis.WarningLevel))
+			}
+			continue
+		}
+		if err := a.ValidateValue(value); err != nil {
+			errs = errs.Also(apis.ErrInvalidValue(value, annotationPath(k), err.Error()))
+			continue
+		}
+		if !a.AppliesTo(kind) {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("annotation doesn't apply to %ss", kind), annotationPath(k)).At(apis.WarningLevel))
+		}
+	}
+	return errs
+}
+
+// annotationPath returns the field path of the annotation with the given key, which isn't split on the
+// dots of the key as with ViaFieldKey.
+func annotationPath(key string) string {
+	return fmt.Sprintf("annotations[%s]", key)
+}
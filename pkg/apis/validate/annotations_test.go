@@ -0,0 +1,189 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validate_test
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/validate"
+	"github.com/tektoncd/pipeline/test/diff"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+func TestObjectAnnotations_RegisteredKeys(t *testing.T) {
+	for _, tc := range []struct {
+		key          string
+		kind         string
+		validValue   string
+		invalidValue string
+	}{
+		{key: "tekton.dev/pipelines.minVersion", kind: "Task", validValue: "0.50.0"},
+		{key: "tekton.dev/categories", kind: "Task", validValue: "Build Tools"},
+		{key: "tekton.dev/tags", kind: "Pipeline", validValue: "build, image"},
+		{key: "tekton.dev/displayName", kind: "Task", validValue: "Git Clone"},
+		{key: "tekton.dev/platforms", kind: "Task", validValue: "linux/amd64,linux/arm64"},
+		{key: "tekton.dev/deprecated", kind: "Task", validValue: "true", invalidValue: "yes"},
+		{key: "tekton.dev/signature", kind: "Pipeline", validValue: "MEUCIQ=="},
+		{key: "tekton.dev/v1beta1.task-deprecations", kind: "Task", validValue: "{}"},
+		{key: "tekton.dev/v1beta1Resources", kind: "PipelineRun", validValue: "{}"},
+		{key: "tekton.dev/v1beta1CloudEvents", kind: "TaskRun", validValue: "[]"},
+		{key: "tekton.dev/v1beta1ResourcesResult", kind: "TaskRun", validValue: "[]"},
+		{key: "tekton.dev/v1beta1ResourcesStatus", kind: "TaskRun", validValue: "[]"},
+		{key: "tekton.dev/v1Artifacts", kind: "TaskRun", validValue: `{"outputs":[{"name":"image"}]}`, invalidValue: "{"},
+		{key: "tekton.dev/pipelinerunSpanContext", kind: "PipelineRun", validValue: `{"traceparent":"00-0f57-01"}`, invalidValue: "traceparent"},
+		{key: "tekton.dev/taskrunSpanContext", kind: "TaskRun", validValue: `{"traceparent":"00-0f57-01"}`, invalidValue: "{"},
+		{key: "tekton.dev/customrunSpanContext", kind: "CustomRun", validValue: `{"traceparent":"00-0f57-01"}`, invalidValue: "{"},
+		{key: "tekton.dev/status-hash", kind: "TaskRun", validValue: "8c2f4d"},
+		{key: "tekton.dev/status-hash-sig", kind: "TaskRun", validValue: "MEUCIQ=="},
+		{key: "tekton.dev/controller-svid", kind: "TaskRun", validValue: "-----BEGIN CERTIFICATE-----"},
+		{key: "tekton.dev/spire-verified", kind: "TaskRun", validValue: "no", invalidValue: "yes"},
+		{key: "tekton.dev/results-from", kind: "PipelineRun", validValue: "sidecar-logs", invalidValue: "sidecar"},
+		{key: "tekton.dev/running-slow", kind: "TaskRun", validValue: "true", invalidValue: "false"},
+		{key: "tekton.dev/auto-cleanup-pvc", kind: "PipelineRun", validValue: "false", invalidValue: "always"},
+		{key: "tekton.dev/pin-image-digests", kind: "PipelineRun", validValue: "true", invalidValue: "yes"},
+		{key: "tekton.dev/helper-image-set", kind: "TaskRun", validValue: "arm64"},
+		{key: "tekton.dev/defaulted-workspace-storage", kind: "PipelineRun", validValue: "source,cache"},
+		{key: "tekton.dev/duplicate-workspace-bindings", kind: "TaskRun", validValue: "source"},
+		{key: "tekton.dev/clock-skew-detected", kind: "TaskRun", validValue: "1.5s", invalidValue: "1500"},
+		{key: "tekton.dev/pod-group-size", kind: "TaskRun", validValue: "3", invalidValue: "all"},
+		{key: "tekton.dev/keep", kind: "PipelineRun", validValue: "true", invalidValue: "forever"},
+		{key: "pipeline.tekton.dev/pipeline-task-on-error", kind: "TaskRun", validValue: "continue", invalidValue: "ignore"},
+		{key: "pipeline.tekton.dev/pipeline-task-expected-duration", kind: "TaskRun", validValue: "1h30m", invalidValue: "90"},
+		{key: "pipeline.tekton.dev/pipeline-task-display-name", kind: "TaskRun", validValue: "Build the image"},
+		{key: "pipeline.tekton.dev/pipeline-task-description", kind: "TaskRun", validValue: "Builds the image of the app"},
+		{key: "pipeline.tekton.dev/priority", kind: "PipelineRun", validValue: "high", invalidValue: "low"},
+		{key: "pipeline.tekton.dev/affinity-assistant", kind: "TaskRun", validValue: "affinity-assistant-0a1b2c"},
+		{key: "pipeline.tekton.dev/coschedule", kind: "TaskRun", validValue: "workspaces", invalidValue: "nodes"},
+		{key: "pipeline.tekton.dev/release", kind: "TaskRun", validValue: "v1.9.0"},
+		{key: "experimental.tekton.dev/execution-mode", kind: "TaskRun", validValue: "hermetic", invalidValue: "offline"},
+	} {
+		t.Run(tc.key, func(t *testing.T) {
+			a, ok := validate.LookupAnnotation(tc.key)
+			if !ok {
+				t.Fatalf("annotation %s is not registered", tc.key)
+			}
+			if !a.AppliesTo(tc.kind) {
+				t.Errorf("annotation %s doesn't apply to %ss", tc.key, tc.kind)
+			}
+			meta := &metav1.ObjectMeta{Annotations: map[string]string{tc.key: tc.validValue}}
+			if err := validate.ObjectAnnotations(t.Context(), tc.kind, meta); err != nil {
+				t.Errorf("ObjectAnnotations(%q) returned error: %v", tc.validValue, err)
+			}
+			if tc.invalidValue == "" {
+				return
+			}
+			meta = &metav1.ObjectMeta{Annotations: map[string]string{tc.key: tc.invalidValue}}
+			if err := validate.ObjectAnnotations(t.Context(), tc.kind, meta).Filter(apis.ErrorLevel); err == nil {
+				t.Errorf("ObjectAnnotations(%q) returned no error", tc.invalidValue)
+			}
+		})
+	}
+}
+
+func TestObjectAnnotations(t *testing.T) {
+	for _, tc := range []struct {
+		name            string
+		kind            string
+		annotations     map[string]string
+		baseline        map[string]string
+		expectedError   string
+		expectedWarning string
+	}{{
+		name:        "no annotations",
+		kind:        "TaskRun",
+		annotations: nil,
+	}, {
+		name:        "annotations of other projects",
+		kind:        "TaskRun",
+		annotations: map[string]string{"chains.tekton.dev/signed": "true", "example.com/owner": "team"},
+	}, {
+		name:          "invalid value",
+		kind:          "PipelineRun",
+		annotations:   map[string]string{"pipeline.tekton.dev/priority": "low"},
+		expectedError: `invalid value: low: annotations[pipeline.tekton.dev/priority]` + "\n" + `"low" must be one of high`,
+	}, {
+		name:            "unknown key under tekton.dev",
+		kind:            "TaskRun",
+		annotations:     map[string]string{"tekton.dev/on-error": "continue"},
+		expectedWarning: `unknown annotation with a prefix reserved for Tekton Pipelines: annotations[tekton.dev/on-error]`,
+	}, {
+		name:            "unknown key under pipeline.tekton.dev",
+		kind:            "PipelineRun",
+		annotations:     map[string]string{"pipeline.tekton.dev/priorty": "high"},
+		expectedWarning: `unknown annotation with a prefix reserved for Tekton Pipelines: annotations[pipeline.tekton.dev/priorty]`,
+	}, {
+		name:            "annotation of another kind",
+		kind:            "Task",
+		annotations:     map[string]string{"tekton.dev/auto-cleanup-pvc": "true"},
+		expectedWarning: `annotation doesn't apply to Tasks: annotations[tekton.dev/auto-cleanup-pvc]`,
+	}, {
+		name:        "unchanged invalid value on update",
+		kind:        "PipelineRun",
+		annotations: map[string]string{"pipeline.tekton.dev/priority": "low", "tekton.dev/legacy": "true"},
+		baseline:    map[string]string{"pipeline.tekton.dev/priority": "low", "tekton.dev/legacy": "true"},
+	}, {
+		name:          "changed invalid value on update",
+		kind:          "PipelineRun",
+		annotations:   map[string]string{"pipeline.tekton.dev/priority": "lowest"},
+		baseline:      map[string]string{"pipeline.tekton.dev/priority": "low"},
+		expectedError: `invalid value: lowest: annotations[pipeline.tekton.dev/priority]` + "\n" + `"lowest" must be one of high`,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := t.Context()
+			if tc.baseline != nil {
+				ctx = apis.WithinUpdate(ctx, &metav1.ObjectMeta{Annotations: tc.baseline})
+			}
+			errs := validate.ObjectAnnotations(ctx, tc.kind, &metav1.ObjectMeta{Annotations: tc.annotations})
+			gotError, gotWarning := "", ""
+			if err := errs.Filter(apis.ErrorLevel); err != nil {
+				gotError = err.Error()
+			}
+			if warning := errs.Filter(apis.WarningLevel); warning != nil {
+				gotWarning = warning.Error()
+			}
+			if d := cmp.Diff(tc.expectedError, gotError); d != "" {
+				t.Errorf("ObjectAnnotations() errors diff %s", diff.PrintWantGot(d))
+			}
+			if d := cmp.Diff(tc.expectedWarning, gotWarning); d != "" {
+				t.Errorf("ObjectAnnotations() warnings diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestRegisteredAnnotations(t *testing.T) {
+	annotations := validate.RegisteredAnnotations()
+	if len(annotations) == 0 {
+		t.Fatal("no registered annotations")
+	}
+	if !sort.SliceIsSorted(annotations, func(i, j int) bool { return annotations[i].Key < annotations[j].Key }) {
+		t.Errorf("registered annotations are not sorted by key")
+	}
+	for _, a := range annotations {
+		if a.Description == "" || len(a.Kinds) == 0 {
+			t.Errorf("annotation %s has no description or kinds", a.Key)
+		}
+		for _, v := range a.Values {
+			if err := a.ValidateValue(v); err != nil {
+				t.Errorf("allowed value %q of annotation %s is invalid: %v", v, a.Key, err)
+			}
+		}
+	}
+}
@@ -18,7 +18,11 @@ package validate
 
 import (
 	"fmt"
+	"maps"
+	"slices"
+	"strings"
 
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation"
 	"knative.dev/pkg/apis"
@@ -46,3 +50,22 @@ func ObjectMetadata(meta metav1.Object) *apis.FieldError {
 	}
 	return nil
 }
+
+// Labels validates that the given labels, which Tekton adds to the resources it creates, have valid
+// keys and values, and that their keys don't have the tekton.dev/ prefix, or the one of one of its
+// subdomains, reserved for the labels set by Tekton.
+func Labels(labels map[string]string) (errs *apis.FieldError) {
+	for _, key := range slices.Sorted(maps.Keys(labels)) {
+		if msgs := validation.IsQualifiedName(key); len(msgs) > 0 {
+			errs = errs.Also(apis.ErrInvalidKeyName(key, apis.CurrentField, msgs...))
+			continue
+		}
+		if prefix, _, ok := strings.Cut(key, "/"); ok && (prefix == pipeline.GroupName || strings.HasSuffix(prefix, "."+pipeline.GroupName)) {
+			errs = errs.Also(apis.ErrInvalidKeyName(key, apis.CurrentField, fmt.Sprintf("the %s/ prefix is reserved for the labels set by Tekton", prefix)))
+		}
+		if msgs := validation.IsValidLabelValue(labels[key]); len(msgs) > 0 {
+			errs = errs.Also(apis.ErrInvalidValue(labels[key], key, strings.Join(msgs, "; ")))
+		}
+	}
+	return errs
+}
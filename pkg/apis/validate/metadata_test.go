@@ -35,3 +35,44 @@ func TestMetadataInvalidLongName(t *testing.T) {
 		}
 	}
 }
+
+func TestLabels(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		labels   map[string]string
+		wantErrs []string
+	}{{
+		name:   "valid labels",
+		labels: map[string]string{"team": "payments", "example.com/tier": "backend"},
+	}, {
+		name:     "reserved prefix",
+		labels:   map[string]string{"tekton.dev/pipelineTask": "build"},
+		wantErrs: []string{`invalid key name "tekton.dev/pipelineTask"`, "the tekton.dev/ prefix is reserved for the labels set by Tekton"},
+	}, {
+		name:     "reserved prefix of a subdomain",
+		labels:   map[string]string{"pipeline.tekton.dev/team": "payments"},
+		wantErrs: []string{`invalid key name "pipeline.tekton.dev/team"`, "the pipeline.tekton.dev/ prefix is reserved for the labels set by Tekton"},
+	}, {
+		name:     "invalid key and value",
+		labels:   map[string]string{"bad key": "payments", "team": "bad value"},
+		wantErrs: []string{`invalid key name "bad key"`, "invalid value: bad value: team"},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validate.Labels(tc.labels)
+			if len(tc.wantErrs) == 0 {
+				if err != nil {
+					t.Errorf("Labels() = %v, want no error", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("Labels() = nil, want %q", tc.wantErrs)
+			}
+			for _, want := range tc.wantErrs {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("Labels() = %q, want it to contain %q", err.Error(), want)
+				}
+			}
+		})
+	}
+}
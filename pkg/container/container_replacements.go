@@ -17,9 +17,12 @@
 package container
 
 import (
+	"strconv"
+
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"github.com/tektoncd/pipeline/pkg/substitution"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // applyStepReplacements returns a StepContainer with variable interpolation applied.
@@ -33,9 +36,62 @@ func applyStepReplacements(step *v1.Step, stringReplacements map[string]string,
 func applySidecarReplacements(sidecar *v1.Sidecar, stringReplacements map[string]string, arrayReplacements map[string][]string) {
 	c := sidecar.ToK8sContainer()
 	applyContainerReplacements(c, stringReplacements, arrayReplacements)
+	for _, probe := range []*corev1.Probe{c.LivenessProbe, c.ReadinessProbe, c.StartupProbe} {
+		if probe != nil {
+			applyHandlerReplacements(probe.Exec, probe.HTTPGet, probe.TCPSocket, stringReplacements, arrayReplacements)
+			if probe.GRPC != nil && probe.GRPC.Service != nil {
+				service := substitution.ApplyReplacements(*probe.GRPC.Service, stringReplacements)
+				probe.GRPC.Service = &service
+			}
+		}
+	}
+	if c.Lifecycle != nil {
+		for _, handler := range []*corev1.LifecycleHandler{c.Lifecycle.PostStart, c.Lifecycle.PreStop} {
+			if handler != nil {
+				applyHandlerReplacements(handler.Exec, handler.HTTPGet, handler.TCPSocket, stringReplacements, arrayReplacements)
+			}
+		}
+	}
 	sidecar.SetContainerFields(*c)
 }
 
+// applyHandlerReplacements applies variable interpolation on the actions of a probe or a lifecycle hook.
+func applyHandlerReplacements(exec *corev1.ExecAction, httpGet *corev1.HTTPGetAction, tcpSocket *corev1.TCPSocketAction, stringReplacements map[string]string, arrayReplacements map[string][]string) {
+	if exec != nil {
+		// Use ApplyArrayReplacements here, as additional commands may be added via an array parameter.
+		var newCommand []string
+		for _, c := range exec.Command {
+			newCommand = append(newCommand, substitution.ApplyArrayReplacements(c, stringReplacements, arrayReplacements)...)
+		}
+		exec.Command = newCommand
+	}
+	if httpGet != nil {
+		httpGet.Path = substitution.ApplyReplacements(httpGet.Path, stringReplacements)
+		httpGet.Host = substitution.ApplyReplacements(httpGet.Host, stringReplacements)
+		httpGet.Port = applyPortReplacements(httpGet.Port, stringReplacements)
+		for i, h := range httpGet.HTTPHeaders {
+			httpGet.HTTPHeaders[i].Value = substitution.ApplyReplacements(h.Value, stringReplacements)
+		}
+	}
+	if tcpSocket != nil {
+		tcpSocket.Host = substitution.ApplyReplacements(tcpSocket.Host, stringReplacements)
+		tcpSocket.Port = applyPortReplacements(tcpSocket.Port, stringReplacements)
+	}
+}
+
+// applyPortReplacements applies variable interpolation on a port given by name, which becomes a port
+// number if it's replaced by one, e.g. with port: $(params.port).
+func applyPortReplacements(port intstr.IntOrString, stringReplacements map[string]string) intstr.IntOrString {
+	if port.Type != intstr.String {
+		return port
+	}
+	value := substitution.ApplyReplacements(port.StrVal, stringReplacements)
+	if number, err := strconv.ParseInt(value, 10, 32); err == nil {
+		return intstr.FromInt32(int32(number))
+	}
+	return intstr.FromString(value)
+}
+
 func applyContainerReplacements(c *corev1.Container, stringReplacements map[string]string, arrayReplacements map[string][]string) {
 	c.Name = substitution.ApplyReplacements(c.Name, stringReplacements)
 	c.Image = substitution.ApplyReplacements(c.Image, stringReplacements)
@@ -51,6 +107,13 @@ func applyContainerReplacements(c *corev1.Container, stringReplacements map[stri
 	for ie, e := range c.Env {
 		c.Env[ie].Value = substitution.ApplyReplacements(e.Value, stringReplacements)
 		if c.Env[ie].ValueFrom != nil {
+			if e.ValueFrom.FieldRef != nil {
+				c.Env[ie].ValueFrom.FieldRef.FieldPath = substitution.ApplyReplacements(e.ValueFrom.FieldRef.FieldPath, stringReplacements)
+			}
+			if e.ValueFrom.ResourceFieldRef != nil {
+				c.Env[ie].ValueFrom.ResourceFieldRef.ContainerName = substitution.ApplyReplacements(e.ValueFrom.ResourceFieldRef.ContainerName, stringReplacements)
+				c.Env[ie].ValueFrom.ResourceFieldRef.Resource = substitution.ApplyReplacements(e.ValueFrom.ResourceFieldRef.Resource, stringReplacements)
+			}
 			if e.ValueFrom.SecretKeyRef != nil {
 				c.Env[ie].ValueFrom.SecretKeyRef.LocalObjectReference.Name = substitution.ApplyReplacements(e.ValueFrom.SecretKeyRef.LocalObjectReference.Name, stringReplacements)
 				c.Env[ie].ValueFrom.SecretKeyRef.Key = substitution.ApplyReplacements(e.ValueFrom.SecretKeyRef.Key, stringReplacements)
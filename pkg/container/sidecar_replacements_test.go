@@ -23,6 +23,8 @@ import (
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"github.com/tektoncd/pipeline/pkg/container"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
 )
 
 func TestApplySidecarReplacements(t *testing.T) {
@@ -126,3 +128,76 @@ func TestApplySidecarReplacements(t *testing.T) {
 		t.Errorf("Container replacements failed: %s", d)
 	}
 }
+
+func TestApplySidecarReplacements_ProbesAndLifecycle(t *testing.T) {
+	replacements := map[string]string{
+		"params.port":    "8080",
+		"params.path":    "/healthz",
+		"params.host":    "localhost",
+		"params.service": "grpc.health.v1.Health",
+		"params.name":    "http",
+	}
+	arrayReplacements := map[string][]string{
+		"params.check": {"--timeout", "5s"},
+	}
+
+	s := v1.Sidecar{
+		Name:  "server",
+		Image: "server",
+		Env: []corev1.EnvVar{{
+			Name: "LIMIT",
+			ValueFrom: &corev1.EnvVarSource{
+				ResourceFieldRef: &corev1.ResourceFieldSelector{ContainerName: "$(params.name)", Resource: "limits.memory"},
+			},
+		}},
+		ReadinessProbe: &corev1.Probe{ProbeHandler: corev1.ProbeHandler{
+			HTTPGet: &corev1.HTTPGetAction{
+				Path:        "$(params.path)",
+				Port:        intstr.FromString("$(params.port)"),
+				HTTPHeaders: []corev1.HTTPHeader{{Name: "Host", Value: "$(params.host)"}},
+			},
+		}},
+		LivenessProbe: &corev1.Probe{ProbeHandler: corev1.ProbeHandler{
+			TCPSocket: &corev1.TCPSocketAction{Host: "$(params.host)", Port: intstr.FromString("$(params.name)")},
+		}},
+		StartupProbe: &corev1.Probe{ProbeHandler: corev1.ProbeHandler{
+			GRPC: &corev1.GRPCAction{Port: 9090, Service: ptr.To("$(params.service)")},
+		}},
+		Lifecycle: &corev1.Lifecycle{
+			PostStart: &corev1.LifecycleHandler{Exec: &corev1.ExecAction{Command: []string{"check", "$(params.check[*])", "$(params.host)"}}},
+			PreStop:   &corev1.LifecycleHandler{HTTPGet: &corev1.HTTPGetAction{Path: "/stop", Port: intstr.FromInt32(8081), Host: "$(params.host)"}},
+		},
+	}
+
+	expected := v1.Sidecar{
+		Name:  "server",
+		Image: "server",
+		Env: []corev1.EnvVar{{
+			Name: "LIMIT",
+			ValueFrom: &corev1.EnvVarSource{
+				ResourceFieldRef: &corev1.ResourceFieldSelector{ContainerName: "http", Resource: "limits.memory"},
+			},
+		}},
+		ReadinessProbe: &corev1.Probe{ProbeHandler: corev1.ProbeHandler{
+			HTTPGet: &corev1.HTTPGetAction{
+				Path:        "/healthz",
+				Port:        intstr.FromInt32(8080),
+				HTTPHeaders: []corev1.HTTPHeader{{Name: "Host", Value: "localhost"}},
+			},
+		}},
+		LivenessProbe: &corev1.Probe{ProbeHandler: corev1.ProbeHandler{
+			TCPSocket: &corev1.TCPSocketAction{Host: "localhost", Port: intstr.FromString("http")},
+		}},
+		StartupProbe: &corev1.Probe{ProbeHandler: corev1.ProbeHandler{
+			GRPC: &corev1.GRPCAction{Port: 9090, Service: ptr.To("grpc.health.v1.Health")},
+		}},
+		Lifecycle: &corev1.Lifecycle{
+			PostStart: &corev1.LifecycleHandler{Exec: &corev1.ExecAction{Command: []string{"check", "--timeout", "5s", "localhost"}}},
+			PreStop:   &corev1.LifecycleHandler{HTTPGet: &corev1.HTTPGetAction{Path: "/stop", Port: intstr.FromInt32(8081), Host: "localhost"}},
+		},
+	}
+	container.ApplySidecarReplacements(&s, replacements, arrayReplacements)
+	if d := cmp.Diff(expected, s); d != "" {
+		t.Errorf("Container replacements failed: %s", d)
+	}
+}
@@ -18,6 +18,8 @@ package entrypoint
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -35,6 +37,7 @@ import (
 
 	"github.com/google/cel-go/cel"
 	"github.com/tektoncd/pipeline/internal/artifactref"
+	pipelineapi "github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1/types"
 	"github.com/tektoncd/pipeline/pkg/entrypoint/pipeline"
 	"github.com/tektoncd/pipeline/pkg/internal/resultref"
@@ -56,10 +59,10 @@ const (
 	TerminationReasonSkipped                 = "Skipped"
 	TerminationReasonCancelled               = "Cancelled"
 	TerminationReasonTimeoutExceeded         = "TimeoutExceeded"
+	TerminationReasonScriptTampered          = "ScriptTampered"
 	// DownwardMountCancelFile is cancellation file mount to step, entrypoint will check this file to cancel the step.
 	downwardMountPoint      = "/tekton/downward"
 	downwardMountCancelFile = "cancel"
-	stepPrefix              = "step-"
 )
 const (
 	// CredsDir is the directory where credentials are placed to meet the legacy credentials
@@ -108,6 +111,8 @@ var (
 	ErrContextCanceled = ContextError(context.Canceled.Error())
 	// ErrSkipPreviousStepFailed is the error returned when the step is skipped due to previous step error
 	ErrSkipPreviousStepFailed = SkipError("error file present, bail and skip the step")
+	// ErrScriptTampered is the error returned when the script of the step doesn't match the script of the Task
+	ErrScriptTampered = errors.New("the script of the step doesn't match the script of the Task")
 )
 
 // IsContextDeadlineError determine whether the error is context deadline
@@ -135,6 +140,10 @@ type Entrypointer struct {
 	// PostFile is the file to write when complete. If not specified, no
 	// file is written.
 	PostFile string
+	// FailFastFile is the file written when the step fails, shared by all the steps, whose
+	// presence makes the steps waiting for their WaitFiles skip at once. If not specified,
+	// a failure only skips the next step, which in turn skips the one after it.
+	FailFastFile string
 
 	// Termination path is the path of a file to write the starting time of this endpopint
 	TerminationPath string
@@ -150,6 +159,9 @@ type Entrypointer struct {
 	StepResults []string
 	// Results is the set of files that might contain task results
 	Results []string
+	// SidecarResults is the set of sidecar results, named <sidecarName>.<resultName>,
+	// that might have been written by the sidecars
+	SidecarResults []string
 	// Timeout is an optional user-specified duration within which the Step must complete
 	Timeout *time.Duration
 	// BreakpointOnFailure helps determine if entrypoint execution needs to adapt debugging requirements
@@ -160,6 +172,10 @@ type Entrypointer struct {
 	// set it to "stopAndFail" to indicate the entrypoint to exit the taskRun if the container exits with non zero exit code
 	// set it to "continue" to indicate the entrypoint to continue executing the rest of the steps irrespective of the container exit code
 	OnError string
+	// Retries is the number of times the command is run again when it exits with a non-zero exit code
+	Retries int
+	// RetryBackoff is the delay before the first retry of the command, doubled before each following retry
+	RetryBackoff time.Duration
 	// StepMetadataDir is the directory for a step where the step related metadata can be stored
 	StepMetadataDir string
 	// SpireWorkloadAPI connects to spire and does obtains SVID based on taskrun
@@ -177,6 +193,9 @@ type Entrypointer struct {
 	// CompressTerminationMessage enables flate compression of termination messages
 	// to fit more results in the 4KB Kubernetes limit.
 	CompressTerminationMessage bool
+	// ScriptSHA256 is the hex-encoded SHA-256 digest the script run by the step, the first element
+	// of Command, must have before it is run
+	ScriptSHA256 string
 }
 
 // Waiter encapsulates waiting for files to exist.
@@ -213,7 +232,7 @@ func (e Entrypointer) Go() error {
 		return err
 	}
 	for _, f := range e.WaitFiles {
-		if err := e.Waiter.Wait(context.Background(), f, e.WaitFileContent, e.BreakpointOnFailure); err != nil {
+		if err := e.waitFile(f); err != nil {
 			// An error happened while waiting, so we bail
 			// *but* we write postfile to make next steps bail too.
 			// In case of breakpoint on failure do not write post file.
@@ -248,6 +267,9 @@ func (e Entrypointer) Go() error {
 	if e.Timeout != nil && *e.Timeout < time.Duration(0) {
 		err = errors.New("negative timeout specified")
 	}
+	if err == nil && e.ScriptSHA256 != "" {
+		err = e.verifyScript()
+	}
 	ctx := context.Background()
 	var cancel context.CancelFunc
 	if err == nil {
@@ -259,6 +281,8 @@ func (e Entrypointer) Go() error {
 		}
 
 		ctx, cancel = context.WithCancel(ctx)
+		// The timeout is a duration rather than a deadline so that it only starts once
+		// the step is done waiting, e.g. for the previous steps or for the pod to be ready.
 		if e.Timeout != nil && *e.Timeout > time.Duration(0) {
 			ctx, cancel = context.WithTimeout(ctx, *e.Timeout)
 		}
@@ -275,7 +299,15 @@ func (e Entrypointer) Go() error {
 		case err1 != nil:
 			err = err1
 		case allowExec:
-			err = e.Runner.Run(ctx, e.Command...)
+			var retries int
+			retries, err = e.runWithRetries(ctx)
+			if retries > 0 {
+				output = append(output, result.RunResult{
+					Key:        "RetryCount",
+					Value:      strconv.Itoa(retries),
+					ResultType: result.InternalTektonResultType,
+				})
+			}
 		default:
 			slog.Info("Step was skipped due to when expressions were evaluated to false.")
 			output = append(output, e.outputRunResult(TerminationReasonSkipped))
@@ -297,6 +329,10 @@ func (e Entrypointer) Go() error {
 	case errors.Is(err, ErrContextDeadlineExceeded):
 		e.WritePostFile(e.PostFile, err)
 		output = append(output, e.outputRunResult(TerminationReasonTimeoutExceeded))
+	case errors.Is(err, ErrScriptTampered):
+		slog.Error("Not running the step", slog.Any("error", err))
+		e.WritePostFile(e.PostFile, err)
+		output = append(output, e.outputRunResult(TerminationReasonScriptTampered))
 	case err != nil && e.BreakpointOnFailure:
 		slog.Info("Skipping writing to PostFile")
 	case e.OnError == ContinueOnError && errors.As(err, &ee):
@@ -341,6 +377,13 @@ func (e Entrypointer) Go() error {
 		}
 	}
 
+	if len(e.SidecarResults) >= 1 && e.SidecarResults[0] != "" {
+		if err := e.readResultsFromDisk(ctx, pipeline.SidecarsDir, result.SidecarResultType); err != nil {
+			slog.Error("Error while reading sidecar results:", slog.Any("error", err))
+			return err
+		}
+	}
+
 	if e.ResultExtractionMethod == ResultExtractionMethodTerminationMessage {
 		e.appendArtifactOutputs(&output)
 	}
@@ -348,6 +391,47 @@ func (e Entrypointer) Go() error {
 	return err
 }
 
+// verifyScript verifies that the script run by the step, the first element of its command, has the
+// SHA-256 digest computed by the controller when it created the pod, so that a script modified by an
+// earlier step isn't run.
+func (e Entrypointer) verifyScript() error {
+	if len(e.Command) == 0 {
+		return fmt.Errorf("%w: the step has no script", ErrScriptTampered)
+	}
+	content, err := os.ReadFile(e.Command[0])
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrScriptTampered, err)
+	}
+	sum := sha256.Sum256(content)
+	if got := hex.EncodeToString(sum[:]); got != e.ScriptSHA256 {
+		return fmt.Errorf("%w: the SHA-256 digest of %s is %s, expected %s", ErrScriptTampered, e.Command[0], got, e.ScriptSHA256)
+	}
+	return nil
+}
+
+// runWithRetries runs the command, running it again up to e.Retries times while it exits with a
+// non-zero exit code. It returns the number of retries along with the error of the last attempt.
+func (e Entrypointer) runWithRetries(ctx context.Context) (int, error) {
+	backoff := e.RetryBackoff
+	for retries := 0; ; retries++ {
+		err := e.Runner.Run(ctx, e.Command...)
+		var ee *exec.ExitError
+		if retries >= e.Retries || !errors.As(err, &ee) {
+			return retries, err
+		}
+		slog.Info("Step command exited with a non-zero exit code, retrying", slog.Int("exitCode", ee.ExitCode()), slog.Int("retry", retries+1), slog.Int("retries", e.Retries))
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return retries, ErrContextDeadlineExceeded
+			}
+			return retries, ErrContextCanceled
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
 func readArtifacts(fp string, resultType result.ResultType) ([]result.RunResult, error) {
 	file, err := os.ReadFile(fp)
 	if os.IsNotExist(err) {
@@ -440,14 +524,23 @@ func (e Entrypointer) waitBeforeStepDebug() error {
 func (e Entrypointer) readResultsFromDisk(ctx context.Context, resultDir string, resultType result.ResultType) error {
 	output := []result.RunResult{}
 	results := e.Results
-	if resultType == result.StepResultType {
+	switch resultType {
+	case result.StepResultType:
 		results = e.StepResults
+	case result.SidecarResultType:
+		results = e.SidecarResults
 	}
 	for _, resultFile := range results {
 		if resultFile == "" {
 			continue
 		}
-		fileContents, err := os.ReadFile(filepath.Join(resultDir, resultFile))
+		resultPath := filepath.Join(resultDir, resultFile)
+		if resultType == result.SidecarResultType {
+			// The results of a sidecar are stored in its own directory
+			sidecarName, resultName, _ := strings.Cut(resultFile, ".")
+			resultPath = filepath.Join(resultDir, sidecarName, resultName)
+		}
+		fileContents, err := os.ReadFile(resultPath)
 		if os.IsNotExist(err) {
 			continue
 		} else if err != nil {
@@ -497,6 +590,30 @@ func (e Entrypointer) BreakpointExitCode(breakpointExitPostFile string) (int, er
 	return strconv.Atoi(strExitCode)
 }
 
+// waitFile waits for the file f, or until a step fails when FailFastFile is set, in which case
+// it returns ErrSkipPreviousStepFailed.
+func (e Entrypointer) waitFile(f string) error {
+	if e.FailFastFile == "" || e.BreakpointOnFailure {
+		return e.Waiter.Wait(context.Background(), f, e.WaitFileContent, e.BreakpointOnFailure)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	failed := make(chan struct{})
+	go func() {
+		if err := e.Waiter.Wait(ctx, e.FailFastFile, false, false); err == nil && ctx.Err() == nil {
+			close(failed)
+			cancel()
+		}
+	}()
+	err := e.Waiter.Wait(ctx, f, e.WaitFileContent, e.BreakpointOnFailure)
+	select {
+	case <-failed:
+		return ErrSkipPreviousStepFailed
+	default:
+		return err
+	}
+}
+
 // WritePostFile write the postfile
 func (e Entrypointer) WritePostFile(postFile string, err error) {
 	if err != nil && postFile != "" {
@@ -505,6 +622,9 @@ func (e Entrypointer) WritePostFile(postFile string, err error) {
 	if postFile != "" {
 		e.PostWriter.Write(postFile, "")
 	}
+	if err != nil && e.FailFastFile != "" {
+		e.PostWriter.Write(e.FailFastFile, "")
+	}
 }
 
 // WriteExitCodeFile write the exitCodeFile
@@ -544,9 +664,10 @@ func (e Entrypointer) CheckForBreakpointOnFailure() {
 	}
 }
 
-// GetContainerName prefixes the input name with "step-"
+// GetContainerName returns the name of the container running the step named name, shortened
+// as the pod builder shortens it when it is longer than the 63 characters allowed.
 func GetContainerName(name string) string {
-	return fmt.Sprintf("%s%s", stepPrefix, name)
+	return pipelineapi.StepContainerName(name)
 }
 
 // loadStepResult reads the step result file and returns the string, array or object result value.
@@ -798,7 +919,7 @@ func parseArtifactTemplate(template string) (ArtifactTemplate, error) {
 	template = strings.TrimSuffix(strings.TrimPrefix(template, "$("), ")")
 	split := strings.Split(template, ".")
 	at := ArtifactTemplate{
-		ContainerName: "step-" + split[1],
+		ContainerName: GetContainerName(split[1]),
 		Type:          split[2],
 	}
 	if len(split) == 4 {
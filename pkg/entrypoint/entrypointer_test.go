@@ -18,9 +18,12 @@ package entrypoint
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io/fs"
 	"log"
 	"os"
 	"os/exec"
@@ -31,6 +34,7 @@ import (
 	"strings"
 	"sync"
 	"testing"
+	"testing/synctest"
 	"time"
 
 	"github.com/tektoncd/pipeline/pkg/apis/config"
@@ -460,6 +464,44 @@ func TestReadResultsFromDisk(t *testing.T) {
 	}
 }
 
+func TestReadSidecarResultsFromDisk(t *testing.T) {
+	sidecarsDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(sidecarsDir, "server"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(sidecarsDir, "server", "port"), []byte("8080"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	terminationPath := filepath.Join(t.TempDir(), "termination")
+
+	e := Entrypointer{
+		// The credentials result was never written by the server sidecar.
+		SidecarResults:         []string{"server.port", "server.credentials"},
+		TerminationPath:        terminationPath,
+		ResultExtractionMethod: config.ResultExtractionMethodTerminationMessage,
+	}
+	if err := e.readResultsFromDisk(t.Context(), sidecarsDir, result.SidecarResultType); err != nil {
+		t.Fatal(err)
+	}
+	msg, err := os.ReadFile(terminationPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	logger, _ := logging.NewLogger("", "status")
+	got, err := termination.ParseMessage(logger, string(msg))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []result.RunResult{{
+		Key:        "server.port",
+		Value:      "8080",
+		ResultType: result.SidecarResultType,
+	}}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("Diff %s", diff.PrintWantGot(d))
+	}
+}
+
 func TestEntrypointer_ReadBreakpointExitCodeFromDisk(t *testing.T) {
 	expectedExitCode := 1
 	// setup test
@@ -579,6 +621,191 @@ func TestEntrypointer_OnError(t *testing.T) {
 	}
 }
 
+func TestEntrypointer_FailFastFile(t *testing.T) {
+	for _, c := range []struct {
+		desc          string
+		runner        Runner
+		onError       string
+		waiter        Waiter
+		wantFailFast  bool
+		wantPostFiles []string
+	}{{
+		desc:          "the step fails",
+		runner:        &fakeExitErrorRunner{},
+		waiter:        &fakeWaiter{},
+		wantFailFast:  true,
+		wantPostFiles: []string{"step-one.err", "fail-fast"},
+	}, {
+		desc:          "the step error is ignored with onError set to continue",
+		runner:        &fakeExitErrorRunner{},
+		onError:       ContinueOnError,
+		waiter:        &fakeWaiter{},
+		wantPostFiles: []string{"step-one", "exitCode"},
+	}, {
+		desc:          "the step succeeds",
+		runner:        &fakeRunner{},
+		waiter:        &fakeWaiter{},
+		wantPostFiles: []string{"step-one", "exitCode"},
+	}, {
+		desc:          "the step is skipped as a previous step failed",
+		runner:        &fakeRunner{},
+		waiter:        &fakeWaiter{skipStep: true},
+		wantFailFast:  true,
+		wantPostFiles: []string{"step-one.err", "fail-fast"},
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			fpw := &recordingPostWriter{}
+			entry := Entrypointer{
+				Command:         []string{"echo", "some", "args"},
+				WaitFiles:       []string{"step-zero"},
+				PostFile:        "step-one",
+				FailFastFile:    "fail-fast",
+				Waiter:          c.waiter,
+				Runner:          c.runner,
+				PostWriter:      fpw,
+				TerminationPath: filepath.Join(t.TempDir(), "termination"),
+				StepMetadataDir: t.TempDir(),
+				OnError:         c.onError,
+			}
+			_ = entry.Go()
+			var got []string
+			for _, f := range fpw.files {
+				got = append(got, filepath.Base(f))
+			}
+			if d := cmp.Diff(c.wantPostFiles, got); d != "" {
+				t.Errorf("Unexpected post files %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestEntrypointer_FailFastFileSkipsWaitingStep(t *testing.T) {
+	dir := t.TempDir()
+	failFastFile := filepath.Join(dir, "fail-fast")
+	if err := os.WriteFile(failFastFile, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	entry := Entrypointer{
+		Command:         []string{"echo", "some", "args"},
+		WaitFiles:       []string{filepath.Join(dir, "never-written")},
+		PostFile:        filepath.Join(dir, "step-five"),
+		FailFastFile:    failFastFile,
+		Waiter:          &fileWaiter{},
+		Runner:          &fakeRunner{},
+		PostWriter:      &recordingPostWriter{},
+		TerminationPath: filepath.Join(dir, "termination"),
+		StepMetadataDir: dir,
+	}
+	if err := entry.Go(); !errors.Is(err, ErrSkipPreviousStepFailed) {
+		t.Errorf("Go() = %v, want %v", err, ErrSkipPreviousStepFailed)
+	}
+}
+
+func TestEntrypointer_Retries(t *testing.T) {
+	for _, c := range []struct {
+		desc              string
+		retries           int
+		failures          int
+		expectedRuns      int
+		expectedError     bool
+		expectedExitCode  *string
+		expectedWrotefile *string
+		expectedStatus    []result.RunResult
+	}{{
+		desc:              "the step succeeds on its second attempt",
+		retries:           3,
+		failures:          1,
+		expectedRuns:      2,
+		expectedExitCode:  ptr("0"),
+		expectedWrotefile: ptr("postfile"),
+		expectedStatus: []result.RunResult{{
+			Key:        "RetryCount",
+			Value:      "1",
+			ResultType: result.InternalTektonResultType,
+		}, {
+			Key:        "StartedAt",
+			ResultType: result.InternalTektonResultType,
+		}},
+	}, {
+		desc:              "the step exhausts its retries",
+		retries:           2,
+		failures:          5,
+		expectedRuns:      3,
+		expectedError:     true,
+		expectedWrotefile: ptr("postfile.err"),
+		expectedStatus: []result.RunResult{{
+			Key:        "RetryCount",
+			Value:      "2",
+			ResultType: result.InternalTektonResultType,
+		}, {
+			Key:        "StartedAt",
+			ResultType: result.InternalTektonResultType,
+		}},
+	}, {
+		desc:              "the step succeeds on its first attempt",
+		retries:           2,
+		expectedRuns:      1,
+		expectedExitCode:  ptr("0"),
+		expectedWrotefile: ptr("postfile"),
+		expectedStatus: []result.RunResult{{
+			Key:        "StartedAt",
+			ResultType: result.InternalTektonResultType,
+		}},
+	}, {
+		desc:              "the step has no retries",
+		failures:          1,
+		expectedRuns:      1,
+		expectedError:     true,
+		expectedWrotefile: ptr("postfile.err"),
+		expectedStatus: []result.RunResult{{
+			Key:        "StartedAt",
+			ResultType: result.InternalTektonResultType,
+		}},
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			fr, fpw := &fakeFlakyRunner{failures: c.failures}, &fakePostWriter{}
+			tmpFolder := t.TempDir()
+			terminationFile, err := os.CreateTemp(tmpFolder, "termination")
+			if err != nil {
+				t.Fatalf("unexpected error creating termination file: %v", err)
+			}
+			e := Entrypointer{
+				Command:         []string{"curl", "https://example.com"},
+				PostFile:        "postfile",
+				Waiter:          &fakeDelayedWaiter{},
+				Runner:          fr,
+				PostWriter:      fpw,
+				TerminationPath: terminationFile.Name(),
+				StepMetadataDir: tmpFolder,
+				Retries:         c.retries,
+				RetryBackoff:    time.Millisecond,
+			}
+
+			err = e.Go()
+			var ee *exec.ExitError
+			if c.expectedError != errors.As(err, &ee) {
+				t.Fatalf("expected an exit error: %t, got %v", c.expectedError, err)
+			}
+			if fr.runs != c.expectedRuns {
+				t.Errorf("expected the command to run %d times, ran %d times", c.expectedRuns, fr.runs)
+			}
+			if d := cmp.Diff(c.expectedExitCode, fpw.exitCode); d != "" {
+				t.Errorf("exitCode doesn't match %s", diff.PrintWantGot(d))
+			}
+			if d := cmp.Diff(c.expectedWrotefile, fpw.wrote); d != "" {
+				t.Errorf("wrote file doesn't match %s", diff.PrintWantGot(d))
+			}
+			termination, err := getTermination(t, terminationFile.Name())
+			if err != nil {
+				t.Fatalf("error getting termination output: %v", err)
+			}
+			if d := cmp.Diff(c.expectedStatus, termination); d != "" {
+				t.Errorf("termination status doesn't match %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 func TestEntrypointerResults(t *testing.T) {
 	for _, c := range []struct {
 		desc, entrypoint, postFile, stepDir, stepDirLink string
@@ -830,6 +1057,86 @@ func TestEntrypointerStopOnCancel(t *testing.T) {
 	}
 }
 
+func TestEntrypointerTimeoutStartsAfterWaiting(t *testing.T) {
+	testCases := []struct {
+		name            string
+		delay           time.Duration
+		timeout         time.Duration
+		runningDuration time.Duration
+		expectError     error
+		expectReason    string
+	}{
+		{
+			name:            "step started after its timeout, expect no error",
+			delay:           10 * time.Minute,
+			timeout:         2 * time.Minute,
+			runningDuration: time.Minute,
+		},
+		{
+			name:            "step running longer than its timeout after a delayed start, expect timeout exceeded",
+			delay:           10 * time.Minute,
+			timeout:         2 * time.Minute,
+			runningDuration: 5 * time.Minute,
+			expectError:     ErrContextDeadlineExceeded,
+			expectReason:    pod.TerminationReasonTimeoutExceeded,
+		},
+	}
+	for _, tc := range testCases {
+		// The test runs in a bubble whose fake clock only advances when all of its goroutines are
+		// blocked, so that the delayed start and the timeout don't depend on the wall clock.
+		synctest.Test(t, func(t *testing.T) {
+			tmpFolder := t.TempDir()
+			terminationFile, err := os.CreateTemp(tmpFolder, "termination")
+			if err != nil {
+				t.Fatalf("unexpected error creating termination file: %v", err)
+			}
+			start := time.Now()
+			fw := &fakeDelayedWaiter{delay: tc.delay}
+			fr := &fakeLongRunner{runningDuration: tc.runningDuration, waitingDuration: tc.runningDuration}
+			fpw := &fakePostWriter{}
+			err = Entrypointer{
+				// The first step waits for the pod to be ready before starting.
+				WaitFiles:       []string{"/tekton/downward/ready"},
+				Waiter:          fw,
+				Runner:          fr,
+				PostWriter:      fpw,
+				TerminationPath: terminationFile.Name(),
+				StepMetadataDir: tmpFolder,
+				Timeout:         &tc.timeout,
+			}.Go()
+			if !errors.Is(err, tc.expectError) {
+				t.Errorf("%s: expected error %v, got %v", tc.name, tc.expectError, err)
+			}
+
+			// getTermination clears StartedAt, which is checked against the fake clock here.
+			fileContents, err := os.ReadFile(terminationFile.Name())
+			if err != nil {
+				t.Fatalf("error reading termination file: %v", err)
+			}
+			logger, _ := logging.NewLogger("", "status")
+			results, err := termination.ParseMessage(logger, string(fileContents))
+			if err != nil {
+				t.Fatalf("error getting termination output: %v", err)
+			}
+			reason, startedAt := "", ""
+			for _, r := range results {
+				switch r.Key {
+				case "Reason":
+					reason = r.Value
+				case "StartedAt":
+					startedAt = r.Value
+				}
+			}
+			if want := start.Add(tc.delay).Format(timeFormat); startedAt != want {
+				t.Errorf("%s: expected the step to start at %s, got %s", tc.name, want, startedAt)
+			}
+			if reason != tc.expectReason {
+				t.Errorf("%s: expected termination reason %q, got %q", tc.name, tc.expectReason, reason)
+			}
+		})
+	}
+}
+
 func TestApplyStepResultSubstitutions_Env(t *testing.T) {
 	testCases := []struct {
 		name       string
@@ -1586,6 +1893,83 @@ func TestTerminationReason(t *testing.T) {
 	}
 }
 
+func TestEntrypointer_ScriptSHA256(t *testing.T) {
+	const script = "#!/bin/sh\necho hello\n"
+	sum := sha256.Sum256([]byte(script))
+	for _, tc := range []struct {
+		desc              string
+		content           string
+		expectedRan       bool
+		expectedWrotefile *string
+		expectedReason    []result.RunResult
+	}{{
+		desc:              "script matches",
+		content:           script,
+		expectedRan:       true,
+		expectedWrotefile: ptr("postfile"),
+	}, {
+		desc:              "script corrupted",
+		content:           "#!/bin/sh\necho tampered\n",
+		expectedWrotefile: ptr("postfile.err"),
+		expectedReason: []result.RunResult{{
+			Key:        "Reason",
+			Value:      pod.TerminationReasonScriptTampered,
+			ResultType: result.InternalTektonResultType,
+		}},
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			fr, fpw := &fakeRunner{}, &fakePostWriter{}
+			tmpFolder := t.TempDir()
+			scriptFile := filepath.Join(tmpFolder, "script-0-abcde")
+			if err := os.WriteFile(scriptFile, []byte(tc.content), 0o755); err != nil {
+				t.Fatalf("unexpected error writing script file: %v", err)
+			}
+			terminationFile, err := os.CreateTemp(tmpFolder, "termination")
+			if err != nil {
+				t.Fatalf("unexpected error creating termination file: %v", err)
+			}
+
+			err = Entrypointer{
+				Command:         []string{scriptFile},
+				PostFile:        "postfile",
+				Waiter:          &fakeWaiter{},
+				Runner:          fr,
+				PostWriter:      fpw,
+				TerminationPath: terminationFile.Name(),
+				StepMetadataDir: tmpFolder,
+				ScriptSHA256:    hex.EncodeToString(sum[:]),
+			}.Go()
+
+			if tc.expectedRan {
+				if err != nil {
+					t.Fatalf("Entrypointer failed: %v", err)
+				}
+			} else if !errors.Is(err, ErrScriptTampered) {
+				t.Fatalf("Entrypointer error = %v, want %v", err, ErrScriptTampered)
+			}
+			if ran := fr.args != nil; ran != tc.expectedRan {
+				t.Errorf("script ran = %t, want %t", ran, tc.expectedRan)
+			}
+			if d := cmp.Diff(tc.expectedWrotefile, fpw.wrote); d != "" {
+				t.Errorf("wrote file doesn't match %s", diff.PrintWantGot(d))
+			}
+			termination, err := getTermination(t, terminationFile.Name())
+			if err != nil {
+				t.Fatalf("error getting termination output: %v", err)
+			}
+			var reason []result.RunResult
+			for _, r := range termination {
+				if r.Key == "Reason" {
+					reason = append(reason, r)
+				}
+			}
+			if d := cmp.Diff(tc.expectedReason, reason); d != "" {
+				t.Errorf("termination reason doesn't match %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 func TestReadArtifactsFileDoesNotExist(t *testing.T) {
 	t.Run("readArtifact file doesn't exist, empty result, no error.", func(t *testing.T) {
 		dir := t.TempDir()
@@ -1965,6 +2349,88 @@ func TestApplyStepArtifactSubstitutionsCommandSuccess(t *testing.T) {
 	}
 }
 
+// TestEntrypointer_ReadOnlyRootFilesystem tests that the entrypoint only writes to the directories which stay
+// writable when the root filesystem of the step is read-only: the run directory of the step, its termination
+// file and /tmp, where the scripts the artifacts are substituted in are rewritten.
+func TestEntrypointer_ReadOnlyRootFilesystem(t *testing.T) {
+	root := t.TempDir()
+	stepDir := filepath.Join(root, "run", "0")
+	tmpDir := filepath.Join(root, "tmp")
+	scriptDir := filepath.Join(root, "scripts")
+	terminationPath := filepath.Join(root, "termination")
+	for _, dir := range []string{stepDir, tmpDir, scriptDir} {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatalf("failed to create %s: %v", dir, err)
+		}
+	}
+	// Kubelet creates the termination file of the container.
+	if err := os.WriteFile(terminationPath, nil, 0o644); err != nil {
+		t.Fatalf("failed to create the termination file: %v", err)
+	}
+	t.Setenv("TMPDIR", tmpDir)
+	cur := ScriptDir
+	ScriptDir = scriptDir
+	t.Cleanup(func() {
+		ScriptDir = cur
+	})
+
+	script := filepath.Join(scriptDir, "script-0")
+	if err := os.WriteFile(script, []byte("echo $(steps.build.outputs.image)"), 0o755); err != nil {
+		t.Fatalf("failed to write the script: %v", err)
+	}
+	artifactsPath := getStepArtifactsPath(stepDir, "step-build")
+	if err := os.MkdirAll(filepath.Dir(artifactsPath), 0o755); err != nil {
+		t.Fatalf("failed to create the artifacts directory: %v", err)
+	}
+	if err := os.WriteFile(artifactsPath, []byte(`{"outputs":[{"name":"image","values":[{"uri":"docker:example.registry.com/outputs"}]}]}`), 0o644); err != nil {
+		t.Fatalf("failed to write the artifacts: %v", err)
+	}
+	before := map[string]bool{}
+	if err := filepath.WalkDir(root, func(path string, _ fs.DirEntry, err error) error {
+		before[path] = true
+		return err
+	}); err != nil {
+		t.Fatalf("failed to walk %s: %v", root, err)
+	}
+
+	e := Entrypointer{Command: []string{script}}
+	if err := e.applyStepArtifactSubstitutions(stepDir); err != nil {
+		t.Fatalf("applyStepArtifactSubstitutions: %v", err)
+	}
+	if d := filepath.Dir(e.Command[0]); d != tmpDir {
+		t.Errorf("expected the substituted script to be written to %s, got %s", tmpDir, d)
+	}
+
+	timeout := time.Duration(0)
+	fr := &fakeRunner{}
+	if err := (Entrypointer{
+		Command:         e.Command,
+		Waiter:          &fakeWaiter{},
+		Runner:          fr,
+		PostWriter:      &fakePostWriter{},
+		TerminationPath: terminationPath,
+		Timeout:         &timeout,
+		StepMetadataDir: stepDir,
+	}).Go(); err != nil {
+		t.Fatalf("Entrypointer failed: %v", err)
+	}
+	if fr.args == nil || !reflect.DeepEqual(*fr.args, e.Command) {
+		t.Errorf("expected the substituted script to be run, got %v", fr.args)
+	}
+
+	if err := filepath.WalkDir(root, func(path string, _ fs.DirEntry, err error) error {
+		if err != nil || before[path] || path == stepDir || path == tmpDir {
+			return err
+		}
+		if !strings.HasPrefix(path, stepDir+string(filepath.Separator)) && !strings.HasPrefix(path, tmpDir+string(filepath.Separator)) {
+			t.Errorf("the entrypoint wrote %s, outside of the writable directories", path)
+		}
+		return nil
+	}); err != nil {
+		t.Fatalf("failed to walk %s: %v", root, err)
+	}
+}
+
 func TestApplyStepArtifactSubstitutionsCommand(t *testing.T) {
 	stepName := "name"
 	scriptDir := t.TempDir()
@@ -2178,6 +2644,22 @@ func (f *fakeWaiter) Wait(ctx context.Context, file string, _ bool, _ bool) erro
 	return nil
 }
 
+// fakeDelayedWaiter simulates a step whose start is delayed, e.g. by slow image pulls. It is meant
+// to run in a synctest bubble, where the delay elapses on its fake clock.
+type fakeDelayedWaiter struct {
+	delay time.Duration
+}
+
+func (f *fakeDelayedWaiter) Wait(ctx context.Context, file string, _ bool, _ bool) error {
+	if file == pod.DownwardMountCancelFile {
+		// The step is never cancelled.
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	time.Sleep(f.delay)
+	return nil
+}
+
 type fakeRunner struct {
 	args     *[]string
 	runError error
@@ -2203,6 +2685,31 @@ func (f *fakePostWriter) Write(file, content string) {
 	}
 }
 
+// recordingPostWriter records the files written, in order.
+type recordingPostWriter struct {
+	files []string
+}
+
+func (f *recordingPostWriter) Write(file, _ string) {
+	f.files = append(f.files, file)
+}
+
+// fileWaiter waits for the files to exist, until the context is done.
+type fileWaiter struct{}
+
+func (f *fileWaiter) Wait(ctx context.Context, file string, _ bool, _ bool) error {
+	for {
+		if _, err := os.Stat(file); err == nil {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ErrContextCanceled
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
+
 type fakeErrorWaiter struct{ waited *string }
 
 func (f *fakeErrorWaiter) Wait(ctx context.Context, file string, expectContent bool, breakpointOnFailure bool) error {
@@ -2244,6 +2751,20 @@ func (f *fakeExitErrorRunner) Run(ctx context.Context, args ...string) error {
 	return exec.Command("ls", "/bogus/path").Run()
 }
 
+// fakeFlakyRunner exits with a non-zero exit code the first failures times it runs.
+type fakeFlakyRunner struct {
+	failures int
+	runs     int
+}
+
+func (f *fakeFlakyRunner) Run(ctx context.Context, args ...string) error {
+	f.runs++
+	if f.runs <= f.failures {
+		return exec.Command("ls", "/bogus/path").Run()
+	}
+	return nil
+}
+
 type fakeLongRunner struct {
 	runningDuration time.Duration
 	waitingDuration time.Duration
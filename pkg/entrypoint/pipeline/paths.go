@@ -28,6 +28,8 @@ const (
 	CredsDir = "/tekton/creds" // #nosec
 	// StepsDir is the directory used for a step to store any metadata related to the step
 	StepsDir = "/tekton/steps"
+	// SidecarsDir is the directory where the steps can read the results of the sidecars
+	SidecarsDir = "/tekton/sidecars"
 
 	ScriptDir = "/tekton/scripts"
 
@@ -31,11 +31,28 @@ const (
 	AffinityAssistantPerPipelineRunWithIsolation = AffinityAssistantBehavior("AffinityAssistantPerPipelineRunWithIsolation")
 )
 
+// CoscheduleAnnotation is the annotation the TaskRuns of a PipelineRun are created with, recording
+// the "coschedule" feature flag the PipelineRun was started with, as kept in its status, so that they
+// keep the same AffinityAssistantBehavior as the PipelineRun even if the feature flag is changed in
+// the meantime.
+const CoscheduleAnnotation = "pipeline.tekton.dev/coschedule"
+
 // GetAffinityAssistantBehavior returns an AffinityAssistantBehavior based on the "coschedule" feature flags
 func GetAffinityAssistantBehavior(ctx context.Context) (AffinityAssistantBehavior, error) {
 	cfg := config.FromContextOrDefaults(ctx)
-	coschedule := cfg.FeatureFlags.Coschedule
+	return behaviorForCoschedule(cfg.FeatureFlags.Coschedule)
+}
+
+// GetRunAffinityAssistantBehavior returns the AffinityAssistantBehavior based on the "coschedule" feature
+// flag recorded for a run, or on the "coschedule" feature flags if none was recorded.
+func GetRunAffinityAssistantBehavior(ctx context.Context, coschedule string) (AffinityAssistantBehavior, error) {
+	if coschedule != "" {
+		return behaviorForCoschedule(coschedule)
+	}
+	return GetAffinityAssistantBehavior(ctx)
+}
 
+func behaviorForCoschedule(coschedule string) (AffinityAssistantBehavior, error) {
 	switch coschedule {
 	case config.CoschedulePipelineRuns:
 		return AffinityAssistantPerPipelineRun, nil
@@ -66,3 +66,44 @@ func Test_GetAffinityAssistantBehavior(t *testing.T) {
 		}
 	}
 }
+
+func Test_GetRunAffinityAssistantBehavior(t *testing.T) {
+	ctx := cfgtesting.SetFeatureFlags(t.Context(), t, map[string]string{
+		"coschedule": config.CoscheduleDisabled,
+	})
+	tcs := []struct {
+		name       string
+		coschedule string
+		expect     AffinityAssistantBehavior
+		expectErr  string
+	}{{
+		name:   "no recorded coschedule",
+		expect: AffinityAssistantDisabled,
+	}, {
+		name:       "recorded coschedule",
+		coschedule: config.CoscheduleWorkspaces,
+		expect:     AffinityAssistantPerWorkspace,
+	}, {
+		name:       "unknown recorded coschedule",
+		coschedule: "nodes",
+		expectErr:  "unknown affinity assistant coschedule: nodes",
+	}}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			get, err := GetRunAffinityAssistantBehavior(ctx, tc.coschedule)
+			if tc.expectErr != "" {
+				if err == nil || err.Error() != tc.expectErr {
+					t.Fatalf("expected error %q, got %v", tc.expectErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error when getting affinity assistant behavior: %v", err)
+			}
+			if d := cmp.Diff(tc.expect, get); d != "" {
+				t.Errorf("AffinityAssistantBehavior mismatch: %v", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
@@ -58,9 +58,9 @@ func LooksLikeResultRef(expression string) bool {
 	return len(subExpressions) >= 4 && (subExpressions[0] == ResultTaskPart || subExpressions[0] == ResultFinallyPart) && subExpressions[2] == ResultResultPart
 }
 
-// looksLikeStepResultRef attempts to check if the given string looks like it contains any
+// LooksLikeStepResultRef attempts to check if the given string looks like it contains any
 // step result references. Returns true if it does, false otherwise
-func looksLikeStepResultRef(expression string) bool {
+func LooksLikeStepResultRef(expression string) bool {
 	subExpressions := strings.Split(expression, ".")
 	return len(subExpressions) >= 4 && subExpressions[0] == ResultStepPart && subExpressions[2] == ResultResultPart
 }
@@ -96,7 +96,7 @@ type ParsedResult struct {
 // - Output: "", "", nil, "", error
 // TODO: may use regex for each type to handle possible reference formats
 func parseExpression(substitutionExpression string) (ParsedResult, error) {
-	if LooksLikeResultRef(substitutionExpression) || looksLikeStepResultRef(substitutionExpression) {
+	if LooksLikeResultRef(substitutionExpression) || LooksLikeStepResultRef(substitutionExpression) {
 		subExpressions := strings.Split(substitutionExpression, ".")
 		// For string result: tasks.<taskName>.results.<stringResultName>
 		// For string step result: steps.<stepName>.results.<stringResultName>
@@ -153,7 +153,7 @@ func ParseTaskExpression(substitutionExpression string) (ParsedResult, error) {
 
 // ParseStepExpression parses the input string and searches for the use of step result usage.
 func ParseStepExpression(substitutionExpression string) (ParsedResult, error) {
-	if looksLikeStepResultRef(substitutionExpression) {
+	if LooksLikeStepResultRef(substitutionExpression) {
 		return parseExpression(substitutionExpression)
 	}
 	return ParsedResult{}, fmt.Errorf("must be one of the form 1). %q; 2). %q", stepResultExpressionFormat, objectStepResultExpressionFormat)
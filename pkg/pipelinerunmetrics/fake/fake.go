@@ -22,6 +22,7 @@ import (
 	_ "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1/pipelinerun/fake" // Make sure the fake pipelinerun informer is setup
 	"github.com/tektoncd/pipeline/pkg/pipelinerunmetrics"
 	"k8s.io/client-go/rest"
+	_ "knative.dev/pkg/client/injection/kube/client/fake" // Make sure the fake kube client is setup
 	"knative.dev/pkg/injection"
 )
 
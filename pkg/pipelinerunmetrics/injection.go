@@ -21,7 +21,13 @@ import (
 
 	pipelineruninformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1/pipelinerun"
 	listers "github.com/tektoncd/pipeline/pkg/client/listers/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/workspace"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	appsinformers "k8s.io/client-go/informers/apps/v1"
+	appslisters "k8s.io/client-go/listers/apps/v1"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+	kubeclient "knative.dev/pkg/client/injection/kube/client"
 	"knative.dev/pkg/controller"
 	"knative.dev/pkg/injection"
 	"knative.dev/pkg/logging"
@@ -58,17 +64,32 @@ type InformerKey struct{}
 
 // WithInformer returns the given context, and a configured informer
 func WithInformer(ctx context.Context) (context.Context, controller.Informer) {
+	// Only affinity assistants are of interest, so watch StatefulSets through a
+	// label-filtered informer rather than caching every StatefulSet in the cluster.
+	statefulSetInformer := appsinformers.NewFilteredStatefulSetInformer(
+		kubeclient.Get(ctx),
+		metav1.NamespaceAll,
+		controller.GetResyncPeriod(ctx),
+		cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc},
+		func(opts *metav1.ListOptions) {
+			opts.LabelSelector = workspace.LabelComponent + "=" + workspace.ComponentNameAffinityAssistant
+		},
+	)
 	return ctx, &recorderInformer{
-		ctx:     ctx,
-		metrics: Get(ctx),
-		lister:  pipelineruninformer.Get(ctx).Lister(),
+		ctx:                 ctx,
+		metrics:             Get(ctx),
+		lister:              pipelineruninformer.Get(ctx).Lister(),
+		statefulSetInformer: statefulSetInformer,
+		statefulSetLister:   appslisters.NewStatefulSetLister(statefulSetInformer.GetIndexer()),
 	}
 }
 
 type recorderInformer struct {
-	ctx     context.Context
-	metrics *Recorder
-	lister  listers.PipelineRunLister
+	ctx                 context.Context
+	metrics             *Recorder
+	lister              listers.PipelineRunLister
+	statefulSetInformer cache.SharedIndexInformer
+	statefulSetLister   appslisters.StatefulSetLister
 }
 
 var _ controller.Informer = (*recorderInformer)(nil)
@@ -82,7 +103,9 @@ func (ri *recorderInformer) Run(stopCh <-chan struct{}) {
 		cancel()
 	}()
 
+	go ri.statefulSetInformer.Run(stopCh)
 	go ri.metrics.ReportRunningPipelineRuns(ctx, ri.lister)
+	go ri.metrics.ReportAffinityAssistants(ctx, ri.statefulSetLister)
 }
 
 // HasSynced returns whether the informer has synced, which in this case will always be true.
@@ -27,6 +27,7 @@ import (
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	listers "github.com/tektoncd/pipeline/pkg/client/listers/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/workspace"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
@@ -34,6 +35,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/labels"
+	appslisters "k8s.io/client-go/listers/apps/v1"
 	"knative.dev/pkg/apis"
 	"knative.dev/pkg/logging"
 )
@@ -59,6 +61,12 @@ type Recorder struct {
 	runningPRsGauge                            metric.Int64ObservableGauge
 	runningPRsWaitingOnPipelineResolutionGauge metric.Int64ObservableGauge
 	runningPRsWaitingOnTaskResolutionGauge     metric.Int64ObservableGauge
+	affinityAssistantsGauge                    metric.Int64ObservableGauge
+	affinityAssistantsCleanedUpCounter         metric.Int64Counter
+	leakedPVCsSweptCounter                     metric.Int64Counter
+	workspacePVCWaitHistogram                  metric.Float64Histogram
+	featureFlagUsedCounter                     metric.Int64Counter
+	reconcilePhaseHistogram                    metric.Float64Histogram
 
 	insertTag func(pipeline, pipelinerun string) []attribute.KeyValue
 }
@@ -172,6 +180,64 @@ func (r *Recorder) configure(cfg *config.Metrics) error {
 	}
 	r.runningPRsWaitingOnTaskResolutionGauge = runningPRsWaitingOnTaskResolutionGauge
 
+	affinityAssistantsGauge, err := r.meter.Int64ObservableGauge(
+		"tekton_pipelines_controller_affinity_assistants",
+		metric.WithDescription("Number of affinity assistant StatefulSets currently existing"),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create affinity assistants gauge: %w", err)
+	}
+	r.affinityAssistantsGauge = affinityAssistantsGauge
+
+	affinityAssistantsCleanedUpCounter, err := r.meter.Int64Counter(
+		"tekton_pipelines_controller_affinity_assistants_cleaned_up_total",
+		metric.WithDescription("Number of affinity assistant StatefulSets deleted after their pipelinerun completed"),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create affinity assistants cleaned up counter: %w", err)
+	}
+	r.affinityAssistantsCleanedUpCounter = affinityAssistantsCleanedUpCounter
+
+	leakedPVCsSweptCounter, err := r.meter.Int64Counter(
+		"tekton_pipelines_controller_leaked_pvcs_swept_total",
+		metric.WithDescription("Number of PVCs from volumeClaimTemplates deleted after outliving their pipelinerun"),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create leaked PVCs swept counter: %w", err)
+	}
+	r.leakedPVCsSweptCounter = leakedPVCsSweptCounter
+
+	workspacePVCWaitHistogram, err := r.meter.Float64Histogram(
+		"tekton_pipelines_controller_pipelinerun_workspace_pvc_wait_seconds",
+		metric.WithDescription("The time PVCs created from the volumeClaimTemplates of pipelinerun workspaces waited to be bound in seconds"),
+		metric.WithUnit("s"),
+		metric.WithExplicitBucketBoundaries(1, 5, 10, 30, 60, 120, 300, 600, 1800, 3600),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create workspace PVC wait histogram: %w", err)
+	}
+	r.workspacePVCWaitHistogram = workspacePVCWaitHistogram
+
+	featureFlagUsedCounter, err := r.meter.Int64Counter(
+		"tekton_pipelines_controller_pipelinerun_feature_flag_used_total",
+		metric.WithDescription("Number of pipelineruns which exercised the behavior gated by a feature flag"),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create pipelinerun feature flag used counter: %w", err)
+	}
+	r.featureFlagUsedCounter = featureFlagUsedCounter
+
+	reconcilePhaseHistogram, err := r.meter.Float64Histogram(
+		"tekton_pipelines_controller_pipelinerun_reconcile_phase_duration_seconds",
+		metric.WithDescription("The time spent in each phase of the reconciles of pipelineruns in seconds"),
+		metric.WithUnit("s"),
+		metric.WithExplicitBucketBoundaries(0.001, 0.005, 0.01, 0.05, 0.1, 0.5, 1, 5, 10, 30),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create pipelinerun reconcile phase duration histogram: %w", err)
+	}
+	r.reconcilePhaseHistogram = reconcilePhaseHistogram
+
 	return nil
 }
 
@@ -197,6 +263,10 @@ func (r *Recorder) DurationAndCount(ctx context.Context, pr *v1.PipelineRun, bef
 			duration = pr.Status.CompletionTime.Sub(pr.Status.StartTime.Time)
 		}
 	}
+	// The completion time can precede the start time when the clocks of the nodes are skewed.
+	if duration < 0 {
+		duration = 0
+	}
 
 	pipelineName := getPipelineTagName(pr)
 
@@ -324,6 +394,125 @@ func (r *Recorder) ReportRunningPipelineRuns(ctx context.Context, lister listers
 	<-ctx.Done()
 }
 
+// AffinityAssistantCleanedUp counts an affinity assistant StatefulSet deleted
+// once the PipelineRun that owned it is done
+func (r *Recorder) AffinityAssistantCleanedUp(ctx context.Context, namespace string) error {
+	if !r.initialized {
+		return errors.New("ignoring the metrics recording, failed to initialize the metrics recorder")
+	}
+
+	r.mutex.Lock()
+	counter := r.affinityAssistantsCleanedUpCounter
+	r.mutex.Unlock()
+
+	counter.Add(ctx, 1, metric.WithAttributes(attribute.String("namespace", namespace)))
+	return nil
+}
+
+// LeakedPVCsSwept counts the PVCs from volumeClaimTemplates deleted
+// because the pipelinerun they were created for no longer exists
+func (r *Recorder) LeakedPVCsSwept(ctx context.Context, namespace string, count int) error {
+	if !r.initialized {
+		return errors.New("ignoring the metrics recording, failed to initialize the metrics recorder")
+	}
+
+	r.mutex.Lock()
+	counter := r.leakedPVCsSweptCounter
+	r.mutex.Unlock()
+
+	counter.Add(ctx, int64(count), metric.WithAttributes(attribute.String("namespace", namespace)))
+	return nil
+}
+
+// WorkspacePVCBound records the time a PVC created from the volumeClaimTemplate
+// of a pipelinerun workspace waited to be bound
+func (r *Recorder) WorkspacePVCBound(ctx context.Context, storageClassName string, wait time.Duration) error {
+	if !r.initialized {
+		return errors.New("ignoring the metrics recording, failed to initialize the metrics recorder")
+	}
+
+	r.mutex.Lock()
+	histogram := r.workspacePVCWaitHistogram
+	r.mutex.Unlock()
+
+	histogram.Record(ctx, wait.Seconds(), metric.WithAttributes(attribute.String("storage_class", storageClassName)))
+	return nil
+}
+
+// FeatureFlagUsed counts a PipelineRun which exercised the behavior gated by the feature flag
+func (r *Recorder) FeatureFlagUsed(ctx context.Context, namespace, flag string) error {
+	if !r.initialized {
+		return errors.New("ignoring the metrics recording, failed to initialize the metrics recorder")
+	}
+
+	r.mutex.Lock()
+	counter := r.featureFlagUsedCounter
+	r.mutex.Unlock()
+
+	counter.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("namespace", namespace),
+		attribute.String("flag", flag),
+	))
+	return nil
+}
+
+// ReconcilePhaseDuration records the time spent in a phase of the reconcile of a PipelineRun
+func (r *Recorder) ReconcilePhaseDuration(ctx context.Context, phase string, duration time.Duration) error {
+	if !r.initialized {
+		return errors.New("ignoring the metrics recording, failed to initialize the metrics recorder")
+	}
+
+	r.mutex.Lock()
+	histogram := r.reconcilePhaseHistogram
+	r.mutex.Unlock()
+
+	histogram.Record(ctx, duration.Seconds(), metric.WithAttributes(attribute.String("phase", phase)))
+	return nil
+}
+
+// observeAffinityAssistants logs the number of affinity assistant StatefulSets existing right now, per namespace
+func (r *Recorder) observeAffinityAssistants(ctx context.Context, o metric.Observer, lister appslisters.StatefulSetLister) error {
+	if !r.initialized {
+		return errors.New("ignoring the metrics recording, failed to initialize the metrics recorder")
+	}
+
+	r.mutex.Lock()
+	affinityAssistantsGauge := r.affinityAssistantsGauge
+	r.mutex.Unlock()
+
+	selector := labels.SelectorFromSet(labels.Set{workspace.LabelComponent: workspace.ComponentNameAffinityAssistant})
+	statefulSets, err := lister.List(selector)
+	if err != nil {
+		return fmt.Errorf("failed to list affinity assistant statefulsets: %w", err)
+	}
+
+	counts := make(map[string]int64)
+	for _, ss := range statefulSets {
+		counts[ss.Namespace]++
+	}
+	for namespace, value := range counts {
+		o.ObserveInt64(affinityAssistantsGauge, value, metric.WithAttributes(attribute.String("namespace", namespace)))
+	}
+
+	return nil
+}
+
+// ReportAffinityAssistants invokes observeAffinityAssistants each time the metrics are collected
+// until the context is cancelled.
+func (r *Recorder) ReportAffinityAssistants(ctx context.Context, lister appslisters.StatefulSetLister) {
+	logger := logging.FromContext(ctx)
+
+	_, err := r.meter.RegisterCallback(func(ctx context.Context, o metric.Observer) error {
+		return r.observeAffinityAssistants(ctx, o, lister)
+	}, r.affinityAssistantsGauge)
+	if err != nil {
+		logger.Errorf("failed to register callback for affinity assistants: %v", err)
+		return
+	}
+
+	<-ctx.Done()
+}
+
 // Helper functions for tag insertion
 func pipelinerunInsertTag(pipeline, pipelinerun string) []attribute.KeyValue {
 	return []attribute.KeyValue{
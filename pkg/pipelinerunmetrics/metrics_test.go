@@ -29,15 +29,19 @@ import (
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	listers "github.com/tektoncd/pipeline/pkg/client/listers/pipeline/v1"
 	"github.com/tektoncd/pipeline/pkg/names"
+	"github.com/tektoncd/pipeline/pkg/workspace"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
 	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 	"go.uber.org/zap"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	appslisters "k8s.io/client-go/listers/apps/v1"
+	"k8s.io/client-go/tools/cache"
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 )
@@ -212,6 +216,76 @@ func TestDurationAndCountGaugeDurationType(t *testing.T) {
 	t.Error("duration metric not found")
 }
 
+func TestDurationAndCountClockSkew(t *testing.T) {
+	resetMetrics()
+	ctx := context.Background()
+	cfg := &config.Config{
+		Metrics: &config.Metrics{
+			TaskrunLevel:            config.TaskrunLevelAtTaskrun,
+			PipelinerunLevel:        config.PipelinerunLevelAtPipelinerun,
+			RunningPipelinerunLevel: config.DefaultRunningPipelinerunLevel,
+			DurationTaskrunType:     config.DefaultDurationTaskrunType,
+			DurationPipelinerunType: config.DurationPipelinerunTypeLastValue,
+		},
+	}
+	ctx = config.ToContext(ctx, cfg)
+
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	otel.SetMeterProvider(provider)
+
+	r, err := NewRecorder(ctx)
+	if err != nil {
+		t.Fatalf("NewRecorder: %v", err)
+	}
+
+	// The completion time precedes the start time when the clocks of the nodes are skewed.
+	skewedCompletionTime := metav1.NewTime(startTime.Add(-time.Minute))
+	pr := &v1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "pipelinerun-1", Namespace: "ns"},
+		Spec: v1.PipelineRunSpec{
+			PipelineRef: &v1.PipelineRef{Name: "pipeline-1"},
+		},
+		Status: v1.PipelineRunStatus{
+			Status: duckv1.Status{
+				Conditions: duckv1.Conditions{{
+					Type:   apis.ConditionSucceeded,
+					Status: corev1.ConditionTrue,
+				}},
+			},
+			PipelineRunStatusFields: v1.PipelineRunStatusFields{
+				StartTime:      &startTime,
+				CompletionTime: &skewedCompletionTime,
+			},
+		},
+	}
+
+	if err := r.DurationAndCount(ctx, pr, nil); err != nil {
+		t.Fatalf("DurationAndCount: %v", err)
+	}
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(ctx, &rm); err != nil {
+		t.Fatalf("Collect error: %v", err)
+	}
+
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name == "tekton_pipelines_controller_pipelinerun_duration_seconds" {
+				gauge, ok := m.Data.(metricdata.Gauge[float64])
+				if !ok || len(gauge.DataPoints) != 1 {
+					t.Fatalf("Expected a single Gauge[float64] data point, got %v", m.Data)
+				}
+				if got := gauge.DataPoints[0].Value; got != 0 {
+					t.Errorf("Expected a duration of 0 for a completion time preceding the start time, got %v", got)
+				}
+				return
+			}
+		}
+	}
+	t.Error("duration metric not found")
+}
+
 func TestOnStoreInvalidConfig(t *testing.T) {
 	resetMetrics()
 	ctx := getConfigContext(false)
@@ -1275,6 +1349,310 @@ func TestGetPipelineTagName(t *testing.T) {
 	}
 }
 
+func TestAffinityAssistantCleanedUp(t *testing.T) {
+	resetMetrics()
+	ctx := getConfigContext(false)
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	otel.SetMeterProvider(provider)
+
+	r, err := NewRecorder(ctx)
+	if err != nil {
+		t.Fatalf("NewRecorder: %v", err)
+	}
+
+	for _, ns := range []string{"foo", "foo", "bar"} {
+		if err := r.AffinityAssistantCleanedUp(ctx, ns); err != nil {
+			t.Fatalf("AffinityAssistantCleanedUp: %v", err)
+		}
+	}
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(ctx, &rm); err != nil {
+		t.Fatalf("Collect error: %v", err)
+	}
+
+	m := getMetric(t, rm, "tekton_pipelines_controller_affinity_assistants_cleaned_up_total")
+	sum, ok := m.Data.(metricdata.Sum[int64])
+	if !ok {
+		t.Fatalf("metric data is not a Sum[int64]: %T", m.Data)
+	}
+	got := map[string]int64{}
+	for _, dp := range sum.DataPoints {
+		ns, _ := dp.Attributes.Value("namespace")
+		got[ns.AsString()] = dp.Value
+	}
+	if d := cmp.Diff(map[string]int64{"foo": 2, "bar": 1}, got); d != "" {
+		t.Errorf("Unexpected cleaned up counts (-want +got): %s", d)
+	}
+}
+
+func TestLeakedPVCsSwept(t *testing.T) {
+	resetMetrics()
+	ctx := getConfigContext(false)
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	otel.SetMeterProvider(provider)
+
+	r, err := NewRecorder(ctx)
+	if err != nil {
+		t.Fatalf("NewRecorder: %v", err)
+	}
+
+	for ns, count := range map[string]int{"foo": 3, "bar": 1} {
+		if err := r.LeakedPVCsSwept(ctx, ns, count); err != nil {
+			t.Fatalf("LeakedPVCsSwept: %v", err)
+		}
+	}
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(ctx, &rm); err != nil {
+		t.Fatalf("Collect error: %v", err)
+	}
+
+	m := getMetric(t, rm, "tekton_pipelines_controller_leaked_pvcs_swept_total")
+	sum, ok := m.Data.(metricdata.Sum[int64])
+	if !ok {
+		t.Fatalf("metric data is not a Sum[int64]: %T", m.Data)
+	}
+	got := map[string]int64{}
+	for _, dp := range sum.DataPoints {
+		ns, _ := dp.Attributes.Value("namespace")
+		got[ns.AsString()] = dp.Value
+	}
+	if d := cmp.Diff(map[string]int64{"foo": 3, "bar": 1}, got); d != "" {
+		t.Errorf("Unexpected swept counts (-want +got): %s", d)
+	}
+}
+
+func TestWorkspacePVCBound(t *testing.T) {
+	resetMetrics()
+	ctx := getConfigContext(false)
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	otel.SetMeterProvider(provider)
+
+	r, err := NewRecorder(ctx)
+	if err != nil {
+		t.Fatalf("NewRecorder: %v", err)
+	}
+
+	for _, wait := range []time.Duration{30 * time.Second, 90 * time.Second} {
+		if err := r.WorkspacePVCBound(ctx, "slow", wait); err != nil {
+			t.Fatalf("WorkspacePVCBound: %v", err)
+		}
+	}
+	if err := r.WorkspacePVCBound(ctx, "fast", time.Second); err != nil {
+		t.Fatalf("WorkspacePVCBound: %v", err)
+	}
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(ctx, &rm); err != nil {
+		t.Fatalf("Collect error: %v", err)
+	}
+
+	m := getMetric(t, rm, "tekton_pipelines_controller_pipelinerun_workspace_pvc_wait_seconds")
+	hist, ok := m.Data.(metricdata.Histogram[float64])
+	if !ok {
+		t.Fatalf("metric data is not a Histogram[float64]: %T", m.Data)
+	}
+	got := map[string]float64{}
+	for _, dp := range hist.DataPoints {
+		sc, _ := dp.Attributes.Value("storage_class")
+		got[sc.AsString()] = dp.Sum
+	}
+	if d := cmp.Diff(map[string]float64{"slow": 120, "fast": 1}, got); d != "" {
+		t.Errorf("Unexpected PVC wait sums (-want +got): %s", d)
+	}
+}
+
+func TestReconcilePhaseDuration(t *testing.T) {
+	resetMetrics()
+	ctx := getConfigContext(false)
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	otel.SetMeterProvider(provider)
+
+	r, err := NewRecorder(ctx)
+	if err != nil {
+		t.Fatalf("NewRecorder: %v", err)
+	}
+
+	for _, d := range []time.Duration{time.Second, 500 * time.Millisecond} {
+		if err := r.ReconcilePhaseDuration(ctx, "resolution", d); err != nil {
+			t.Fatalf("ReconcilePhaseDuration: %v", err)
+		}
+	}
+	if err := r.ReconcilePhaseDuration(ctx, "status_computation", 2*time.Second); err != nil {
+		t.Fatalf("ReconcilePhaseDuration: %v", err)
+	}
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(ctx, &rm); err != nil {
+		t.Fatalf("Collect error: %v", err)
+	}
+
+	m := getMetric(t, rm, "tekton_pipelines_controller_pipelinerun_reconcile_phase_duration_seconds")
+	hist, ok := m.Data.(metricdata.Histogram[float64])
+	if !ok {
+		t.Fatalf("metric data is not a Histogram[float64]: %T", m.Data)
+	}
+	got := map[string]float64{}
+	for _, dp := range hist.DataPoints {
+		phase, _ := dp.Attributes.Value("phase")
+		got[phase.AsString()] = dp.Sum
+	}
+	if d := cmp.Diff(map[string]float64{"resolution": 1.5, "status_computation": 2}, got); d != "" {
+		t.Errorf("Unexpected reconcile phase durations (-want +got): %s", d)
+	}
+}
+
+func TestAffinityAssistantCleanedUpUninitialized(t *testing.T) {
+	metrics := Recorder{}
+	if err := metrics.AffinityAssistantCleanedUp(t.Context(), "foo"); err == nil {
+		t.Error("AffinityAssistantCleanedUp expected to return error but got nil")
+	}
+	if err := metrics.LeakedPVCsSwept(t.Context(), "foo", 1); err == nil {
+		t.Error("LeakedPVCsSwept expected to return error but got nil")
+	}
+	if err := metrics.WorkspacePVCBound(t.Context(), "standard", time.Second); err == nil {
+		t.Error("WorkspacePVCBound expected to return error but got nil")
+	}
+	if err := metrics.FeatureFlagUsed(t.Context(), "foo", "enable-cel-in-whenexpression"); err == nil {
+		t.Error("FeatureFlagUsed expected to return error but got nil")
+	}
+	if err := metrics.ReconcilePhaseDuration(t.Context(), "resolution", time.Second); err == nil {
+		t.Error("ReconcilePhaseDuration expected to return error but got nil")
+	}
+	if err := metrics.observeAffinityAssistants(t.Context(), nil, nil); err == nil {
+		t.Error("affinity assistant count recording expected to return error but got nil")
+	}
+}
+
+func TestRecordAffinityAssistantsCount(t *testing.T) {
+	resetMetrics()
+	ctx := getConfigContext(false)
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	otel.SetMeterProvider(provider)
+
+	r, err := NewRecorder(ctx)
+	if err != nil {
+		t.Fatalf("NewRecorder: %v", err)
+	}
+
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	lister := appslisters.NewStatefulSetLister(indexer)
+	for _, ss := range []*appsv1.StatefulSet{
+		newAffinityAssistant("foo", "affinity-assistant-1"),
+		newAffinityAssistant("foo", "affinity-assistant-2"),
+		newAffinityAssistant("bar", "affinity-assistant-3"),
+		{ObjectMeta: metav1.ObjectMeta{Name: "not-an-affinity-assistant", Namespace: "foo"}},
+	} {
+		if err := indexer.Add(ss); err != nil {
+			t.Fatalf("Failed to add StatefulSet to indexer: %v", err)
+		}
+	}
+
+	_, err = r.meter.RegisterCallback(func(ctx context.Context, o metric.Observer) error {
+		return r.observeAffinityAssistants(ctx, o, lister)
+	}, r.affinityAssistantsGauge)
+	if err != nil {
+		t.Fatalf("Failed to register callback: %v", err)
+	}
+
+	collect := func() map[string]int64 {
+		t.Helper()
+		var rm metricdata.ResourceMetrics
+		if err := reader.Collect(ctx, &rm); err != nil {
+			t.Fatalf("Collect error: %v", err)
+		}
+		m := getMetric(t, rm, "tekton_pipelines_controller_affinity_assistants")
+		gauge, ok := m.Data.(metricdata.Gauge[int64])
+		if !ok {
+			t.Fatalf("metric data is not a Gauge[int64]: %T", m.Data)
+		}
+		got := map[string]int64{}
+		for _, dp := range gauge.DataPoints {
+			ns, _ := dp.Attributes.Value("namespace")
+			got[ns.AsString()] = dp.Value
+		}
+		return got
+	}
+
+	if d := cmp.Diff(map[string]int64{"foo": 2, "bar": 1}, collect()); d != "" {
+		t.Errorf("Unexpected affinity assistant counts (-want +got): %s", d)
+	}
+
+	// The gauge is recomputed from the lister on every collection.
+	if err := indexer.Delete(newAffinityAssistant("foo", "affinity-assistant-1")); err != nil {
+		t.Fatalf("Failed to delete StatefulSet from indexer: %v", err)
+	}
+	if err := indexer.Delete(newAffinityAssistant("bar", "affinity-assistant-3")); err != nil {
+		t.Fatalf("Failed to delete StatefulSet from indexer: %v", err)
+	}
+	if d := cmp.Diff(map[string]int64{"foo": 1}, collect()); d != "" {
+		t.Errorf("Unexpected affinity assistant counts after deletion (-want +got): %s", d)
+	}
+}
+
+func TestReportAffinityAssistants(t *testing.T) {
+	resetMetrics()
+	ctx := getConfigContext(false)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	otel.SetMeterProvider(provider)
+
+	r, err := NewRecorder(ctx)
+	if err != nil {
+		t.Fatalf("NewRecorder: %v", err)
+	}
+
+	lister := appslisters.NewStatefulSetLister(cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{}))
+
+	done := make(chan struct{})
+	go func() {
+		r.ReportAffinityAssistants(ctx, lister)
+		close(done)
+	}()
+
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(1 * time.Second):
+		t.Error("ReportAffinityAssistants did not exit after context cancellation")
+	}
+}
+
+func newAffinityAssistant(namespace, name string) *appsv1.StatefulSet {
+	return &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels: map[string]string{
+				workspace.LabelComponent: workspace.ComponentNameAffinityAssistant,
+			},
+		},
+	}
+}
+
+func getMetric(t *testing.T, rm metricdata.ResourceMetrics, name string) metricdata.Metrics {
+	t.Helper()
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name == name {
+				return m
+			}
+		}
+	}
+	t.Fatalf("%s metric not found", name)
+	return metricdata.Metrics{}
+}
+
 func getRunningPRsMetric(t *testing.T, rm metricdata.ResourceMetrics) metricdata.Metrics {
 	t.Helper()
 	if len(rm.ScopeMetrics) == 0 {
@@ -25,6 +25,7 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
@@ -44,6 +45,20 @@ const (
 
 	runVolumeName = "tekton-internal-run"
 
+	// resultsVolumeName and stepsVolumeName are the implicit volumes mounted at /tekton/results and
+	// /tekton/steps, which the results sidecar reads once the steps are done.
+	resultsVolumeName = "tekton-internal-results"
+	stepsVolumeName   = "tekton-internal-steps"
+
+	// failFastVolumeName is the emptyDir of the pod holding failFastFile. It is named like the run volumes
+	// so that SplitIsolatedSteps replaces it, as it does them, with a directory of the workspace shared
+	// with the pod running the isolated steps of the TaskRun, if any.
+	failFastVolumeName = runVolumeName + "-fail-fast"
+	failFastDir        = RunDir + "/fail-fast"
+	// failFastFile is written by the step failing the TaskRun with "enable-step-fail-fast", so that
+	// the steps waiting to run are skipped at once.
+	failFastFile = failFastDir + "/failed"
+
 	// RunDir is the directory that contains runtime variable data for TaskRuns.
 	// This includes files for handling container ordering, exit status codes, and more.
 	// See [https://github.com/tektoncd/pipeline/blob/main/docs/developers/taskruns.md#tekton]
@@ -57,8 +72,8 @@ const (
 	readyAnnotation        = "tekton.dev/ready"
 	readyAnnotationValue   = "READY"
 
-	stepPrefix    = "step-"
-	sidecarPrefix = "sidecar-"
+	stepPrefix    = pipeline.StepContainerPrefix
+	sidecarPrefix = pipeline.SidecarContainerPrefix
 
 	downwardMountCancelFile = "cancel"
 	cancelAnnotation        = "tekton.dev/cancel"
@@ -80,8 +95,16 @@ var (
 		Name:         binVolumeName,
 		VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
 	}
+	failFastVolume = corev1.Volume{
+		Name:         failFastVolumeName,
+		VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+	}
+	failFastMount = corev1.VolumeMount{
+		Name:      failFastVolumeName,
+		MountPath: failFastDir,
+	}
 	internalStepsMount = corev1.VolumeMount{
-		Name:      "tekton-internal-steps",
+		Name:      stepsVolumeName,
 		MountPath: pipeline.StepsDir,
 	}
 
@@ -123,7 +146,8 @@ var (
 // Containers must have Command specified; if the user didn't specify a
 // command, we must have fetched the image's ENTRYPOINT before calling this
 // method, using entrypoint_lookup.go.
-// Additionally, Step timeouts are added as entrypoint flag.
+// Additionally, Step timeouts are added as entrypoint flag. They are passed as durations
+// so that the entrypoint starts counting them when the Step starts rather than when the Pod is created.
 func orderContainers(ctx context.Context, commonExtraEntrypointArgs []string, steps []corev1.Container, taskSpec *v1.TaskSpec, breakpointConfig *v1.TaskRunDebug, waitForReadyAnnotation, enableKeepPodOnCancel bool) ([]corev1.Container, error) {
 	if len(steps) == 0 {
 		return nil, errors.New("no steps specified")
@@ -160,6 +184,9 @@ func orderContainers(ctx context.Context, commonExtraEntrypointArgs []string, st
 					}
 					argsForEntrypoint = append(argsForEntrypoint, "-on_error", string(taskSpec.Steps[i].OnError))
 				}
+				if taskSpec.Steps[i].Retries > 0 {
+					argsForEntrypoint = append(argsForEntrypoint, "-retries", strconv.Itoa(taskSpec.Steps[i].Retries))
+				}
 				if taskSpec.Steps[i].Timeout != nil {
 					argsForEntrypoint = append(argsForEntrypoint, "-timeout", taskSpec.Steps[i].Timeout.Duration.String())
 				}
@@ -169,6 +196,9 @@ func orderContainers(ctx context.Context, commonExtraEntrypointArgs []string, st
 				if taskSpec.Steps[i].StderrConfig != nil {
 					argsForEntrypoint = append(argsForEntrypoint, "-stderr_path", taskSpec.Steps[i].StderrConfig.Path)
 				}
+				if sum := scriptSHA256(taskSpec.Steps[i].Script); sum != "" {
+					argsForEntrypoint = append(argsForEntrypoint, "-script_sha256", sum)
+				}
 				// add step results
 				stepResultArgs := stepResultArgument(taskSpec.Steps[i].Results)
 
@@ -184,6 +214,10 @@ func orderContainers(ctx context.Context, commonExtraEntrypointArgs []string, st
 				}
 			}
 			argsForEntrypoint = append(argsForEntrypoint, resultArgument(steps, taskSpec.Results)...)
+			if i == len(steps)-1 {
+				// The last step reports the results of the sidecars once it is done
+				argsForEntrypoint = append(argsForEntrypoint, sidecarResultArgument(taskSpec.Sidecars)...)
+			}
 		}
 
 		if breakpointConfig != nil && breakpointConfig.NeedsDebugOnFailure() {
@@ -272,15 +306,18 @@ func init() {
 }
 
 // buildSidecarStopPatch creates a JSON Patch to replace sidecar container images with nop image
-func buildSidecarStopPatch(pod *corev1.Pod, nopImage string, ctx context.Context) ([]byte, error) {
+func buildSidecarStopPatch(pod *corev1.Pod, nopImage string, ctx context.Context, now time.Time) ([]byte, error) {
 	var patchOps []jsonpatch.JsonPatchOperation
 
+	// A results sidecar which didn't complete within its grace period is stopped like the other sidecars.
+	resultsSidecarHung := IsResultsSidecarHung(ctx, pod.Status, now)
+
 	// Iterate over container statuses to find running sidecars
 	for _, s := range pod.Status.ContainerStatuses {
-		// If the results-from is set to sidecar logs,
+		// If the results-from is set to sidecar logs or sidecar volume,
 		// a sidecar container with name `sidecar-log-results` is injected by the reconciler.
 		// Do not kill this sidecar. Let it exit gracefully.
-		if config.FromContextOrDefaults(ctx).FeatureFlags.ResultExtractionMethod == config.ResultExtractionMethodSidecarLogs && s.Name == pipeline.ReservedResultsSidecarContainerName {
+		if config.FromContextOrDefaults(ctx).FeatureFlags.UsesResultsSidecar() && s.Name == pipeline.ReservedResultsSidecarContainerName && !resultsSidecarHung {
 			continue
 		}
 		// Stop any running container that isn't a step.
@@ -331,8 +368,9 @@ func UpdateReady(ctx context.Context, kubeclient kubernetes.Interface, pod corev
 }
 
 // StopSidecars updates sidecar containers in the Pod to a nop image, which
-// exits successfully immediately.
-func StopSidecars(ctx context.Context, nopImage string, kubeclient kubernetes.Interface, namespace, name string) (*corev1.Pod, error) {
+// exits successfully immediately. The results sidecar is only stopped if it is
+// hung at now.
+func StopSidecars(ctx context.Context, nopImage string, kubeclient kubernetes.Interface, namespace, name string, now time.Time) (*corev1.Pod, error) {
 	pod, err := kubeclient.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
 	if k8serrors.IsNotFound(err) {
 		// return NotFound as-is, since the K8s error checks don't handle wrapping.
@@ -347,7 +385,7 @@ func StopSidecars(ctx context.Context, nopImage string, kubeclient kubernetes.In
 	}
 
 	// Build JSON Patch operations to replace sidecar images
-	patchBytes, err := buildSidecarStopPatch(pod, nopImage, ctx)
+	patchBytes, err := buildSidecarStopPatch(pod, nopImage, ctx, now)
 	if err != nil {
 		return nil, fmt.Errorf("error building patch for stopping sidecars of Pod %q: %w", name, err)
 	}
@@ -393,16 +431,8 @@ func TrimStepPrefix(name string) string { return strings.TrimPrefix(name, stepPr
 // prefix.
 func TrimSidecarPrefix(name string) string { return strings.TrimPrefix(name, sidecarPrefix) }
 
-// StepName returns the step name after adding "step-" prefix to the actual step name or
-// returns "step-unnamed-<step-index>" if not specified
-func StepName(name string, i int) string {
-	if name != "" {
-		return GetContainerName(name)
-	}
-	return fmt.Sprintf("%sunnamed-%d", stepPrefix, i)
-}
-
-// GetContainerName prefixes the input name with "step-"
+// GetContainerName returns the name of the container running the step named name, shortened
+// as pipeline.StepContainerName shortens it when it is longer than the 63 characters allowed.
 func GetContainerName(name string) string {
-	return fmt.Sprintf("%s%s", stepPrefix, name)
+	return pipeline.StepContainerName(name)
 }
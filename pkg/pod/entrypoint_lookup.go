@@ -30,13 +30,20 @@ import (
 type EntrypointCache interface {
 	// get the Image data for the given image reference. If the value is
 	// not found in the cache, it will be fetched from the image registry,
-	// possibly using K8s service account imagePullSecrets.
+	// possibly using the given imagePullSecrets and then the K8s service
+	// account imagePullSecrets.
 	//
 	// It also returns the digest associated with the given reference. If
 	// the reference referred to an index, the returned digest will be the
 	// index's digest, not any platform-specific image contained by the
 	// index.
 	get(ctx context.Context, ref name.Reference, namespace, serviceAccountName string, imagePullSecrets []corev1.LocalObjectReference, hasArgs bool) (*imageData, error)
+
+	// resolveDigest gets the digest of the given image reference from the
+	// image registry, with the same credentials as get. The digests of
+	// references by tag are cached for a short time, so that the TaskRuns
+	// started together don't all look up the same tags.
+	resolveDigest(ctx context.Context, ref name.Reference, namespace, serviceAccountName string, imagePullSecrets []corev1.LocalObjectReference) (v1.Hash, error)
 }
 
 // imageData contains information looked up about an image or multi-platform image index.
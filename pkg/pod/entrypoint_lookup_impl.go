@@ -20,7 +20,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
+	"github.com/google/go-containerregistry/pkg/authn"
 	"github.com/google/go-containerregistry/pkg/authn/k8schain"
 	"github.com/google/go-containerregistry/pkg/name"
 	v1 "github.com/google/go-containerregistry/pkg/v1"
@@ -31,16 +33,34 @@ import (
 	"k8s.io/client-go/kubernetes"
 )
 
-const cacheSize = 1024
+const (
+	cacheSize = 1024
+
+	// digestCacheTTL is how long the digests resolved for image references by tag are cached, so that the
+	// TaskRuns started together don't look up the same tags in the registry, while pushes are seen shortly.
+	digestCacheTTL = time.Minute
+)
 
 type entrypointCache struct {
 	kubeclient kubernetes.Interface
 	lru        *lru.Cache // cache of digest->map[string][]string commands
+	digests    *lru.Cache // cache of namespace/tag->cachedDigest
+	now        func() time.Time
+}
+
+// cachedDigest is a digest resolved for an image reference by tag, until it expires.
+type cachedDigest struct {
+	digest  v1.Hash
+	expires time.Time
 }
 
 // NewEntrypointCache returns a new entrypoint cache implementation that uses
 // K8s credentials to pull image metadata from a container image registry.
 func NewEntrypointCache(kubeclient kubernetes.Interface) (EntrypointCache, error) {
+	digests, err := lru.New(cacheSize)
+	if err != nil {
+		return nil, err
+	}
 	lru, err := lru.New(cacheSize)
 	if err != nil {
 		return nil, err
@@ -48,6 +68,8 @@ func NewEntrypointCache(kubeclient kubernetes.Interface) (EntrypointCache, error
 	return &entrypointCache{
 		kubeclient: kubeclient,
 		lru:        lru,
+		digests:    digests,
+		now:        time.Now,
 	}, nil
 }
 
@@ -64,18 +86,9 @@ func (e *entrypointCache) get(ctx context.Context, ref name.Reference, namespace
 		}
 	}
 
-	pullSecretsNames := make([]string, 0, len(imagePullSecrets))
-	for _, ps := range imagePullSecrets {
-		pullSecretsNames = append(pullSecretsNames, ps.Name)
-	}
-	// Consult the remote registry, using imagePullSecrets.
-	kc, err := k8schain.New(ctx, e.kubeclient, k8schain.Options{
-		Namespace:          namespace,
-		ServiceAccountName: serviceAccountName,
-		ImagePullSecrets:   pullSecretsNames,
-	})
+	kc, err := e.keychain(ctx, namespace, serviceAccountName, imagePullSecrets)
 	if err != nil {
-		return nil, fmt.Errorf("error creating k8schain: %w", err)
+		return nil, err
 	}
 
 	desc, err := remote.Get(ref, remote.WithAuthFromKeychain(kc))
@@ -125,6 +138,52 @@ func (e *entrypointCache) get(ctx context.Context, ref name.Reference, namespace
 	return id, nil
 }
 
+// resolveDigest returns the digest of the given image reference, cached for
+// digestCacheTTL for references by tag.
+func (e *entrypointCache) resolveDigest(ctx context.Context, ref name.Reference, namespace, serviceAccountName string, imagePullSecrets []corev1.LocalObjectReference) (v1.Hash, error) {
+	if digest, ok := ref.(name.Digest); ok {
+		return v1.NewHash(digest.DigestStr())
+	}
+
+	// The digests are cached per namespace, so that a namespace can't learn the
+	// digests of images it doesn't have the credentials to pull.
+	key := namespace + "/" + ref.Name()
+	if cd, ok := e.digests.Get(key); ok && e.now().Before(cd.(cachedDigest).expires) {
+		return cd.(cachedDigest).digest, nil
+	}
+
+	kc, err := e.keychain(ctx, namespace, serviceAccountName, imagePullSecrets)
+	if err != nil {
+		return v1.Hash{}, err
+	}
+	desc, err := remote.Head(ref, remote.WithAuthFromKeychain(kc))
+	if err != nil {
+		return v1.Hash{}, err
+	}
+	e.digests.Add(key, cachedDigest{digest: desc.Digest, expires: e.now().Add(digestCacheTTL)})
+	return desc.Digest, nil
+}
+
+// keychain returns the keychain to consult the remote registry, using the
+// given imagePullSecrets (usually the ones of the pod template) before the
+// ones of the service account, so that they can be used in namespaces where
+// the service account can't be modified.
+func (e *entrypointCache) keychain(ctx context.Context, namespace, serviceAccountName string, imagePullSecrets []corev1.LocalObjectReference) (authn.Keychain, error) {
+	pullSecretsNames := make([]string, 0, len(imagePullSecrets))
+	for _, ps := range imagePullSecrets {
+		pullSecretsNames = append(pullSecretsNames, ps.Name)
+	}
+	kc, err := k8schain.New(ctx, e.kubeclient, k8schain.Options{
+		Namespace:          namespace,
+		ServiceAccountName: serviceAccountName,
+		ImagePullSecrets:   pullSecretsNames,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error creating k8schain: %w", err)
+	}
+	return kc, nil
+}
+
 func buildCommandMap(idx v1.ImageIndex, hasArgs bool) (map[string][]string, error) {
 	// Map platform strings to digest, to handle some ~malformed images
 	// that specify the same manifest multiple times.
@@ -25,6 +25,7 @@ import (
 	"net/url"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-containerregistry/pkg/name"
@@ -33,6 +34,7 @@ import (
 	"github.com/google/go-containerregistry/pkg/v1/empty"
 	"github.com/google/go-containerregistry/pkg/v1/mutate"
 	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
 	pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	remotetest "github.com/tektoncd/pipeline/test"
 	"github.com/tektoncd/pipeline/test/diff"
@@ -183,6 +185,149 @@ func TestGetImageWithImagePullSecrets(t *testing.T) {
 	}
 }
 
+func TestGetImageWithPodTemplateAndServiceAccountPullSecrets(t *testing.T) {
+	ctx := t.Context()
+
+	ftp := newfakeHTTP()
+	s := httptest.NewServer(&ftp)
+	defer s.Close()
+
+	u, err := url.Parse(s.URL)
+	if err != nil {
+		t.Fatalf("Parsing url with an error: %v", err)
+	}
+
+	task := &pipelinev1.Task{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "tekton.dev/v1",
+			Kind:       "Task",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-create-image",
+		},
+	}
+	ref, err := remotetest.CreateImageWithAnnotations(u.Host+"/task/test-create-image", remotetest.DefaultObjectAnnotationMapper, task)
+	if err != nil {
+		t.Fatalf("uploading image failed unexpectedly with an error: %v", err)
+	}
+	imgRef, err := name.ParseReference(ref)
+	if err != nil {
+		t.Fatalf("digest %s is not a valid reference: %v", ref, err)
+	}
+
+	// Only the pod template's secret holds valid credentials for the registry.
+	podTemplateSecret := generateSecret(u.Host, username, password)
+	saSecret := generateSecret(u.Host, username, "wrong password")
+	saSecret.Name = "sa-secret"
+	sa := &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "tenant",
+			Namespace: nameSpace,
+		},
+		ImagePullSecrets: []corev1.LocalObjectReference{{Name: saSecret.Name}},
+	}
+
+	for _, tc := range []struct {
+		name             string
+		imagePullSecrets []corev1.LocalObjectReference
+		wantErr          bool
+	}{{
+		name:             "pod template secret used before the service account one",
+		imagePullSecrets: []corev1.LocalObjectReference{{Name: podTemplateSecret.Name}},
+	}, {
+		name:    "service account secret only",
+		wantErr: true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			client := fakeclient.NewSimpleClientset(sa, saSecret, podTemplateSecret)
+			entrypointCache, err := NewEntrypointCache(client)
+			if err != nil {
+				t.Fatalf("Creating entrypointCache with an error: %v", err)
+			}
+
+			steps := []corev1.Container{{Image: imgRef.String()}}
+			got, err := resolveEntrypoints(ctx, entrypointCache, nameSpace, sa.Name, tc.imagePullSecrets, steps)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("resolveEntrypoints() = %+v, %v, wantErr %t", got, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestResolveDigest(t *testing.T) {
+	ctx := t.Context()
+
+	ftp := newfakeHTTP()
+	s := httptest.NewServer(&ftp)
+	defer s.Close()
+
+	u, err := url.Parse(s.URL)
+	if err != nil {
+		t.Fatalf("Parsing url with an error: %v", err)
+	}
+	ref, err := name.ParseReference(u.Host + "/pinned/image:1.0")
+	if err != nil {
+		t.Fatalf("ParseReference: %v", err)
+	}
+	push := func() v1.Hash {
+		t.Helper()
+		img := mustRandomImage(t)
+		if err := remote.Write(ref, img); err != nil {
+			t.Fatalf("remote.Write: %v", err)
+		}
+		dig, err := img.Digest()
+		if err != nil {
+			t.Fatalf("image.Digest: %v", err)
+		}
+		return dig
+	}
+
+	client := fakeclient.NewSimpleClientset(&corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: nameSpace},
+	})
+	cache, err := NewEntrypointCache(client)
+	if err != nil {
+		t.Fatalf("Creating entrypointCache with an error: %v", err)
+	}
+	now := time.Now()
+	cache.(*entrypointCache).now = func() time.Time { return now }
+	resolve := func(namespace string) v1.Hash {
+		t.Helper()
+		dig, err := cache.resolveDigest(ctx, ref, namespace, "default", nil)
+		if err != nil {
+			t.Fatalf("resolveDigest: %v", err)
+		}
+		return dig
+	}
+
+	first := push()
+	if got := resolve(nameSpace); got != first {
+		t.Errorf("resolveDigest() = %s, want %s", got, first)
+	}
+
+	// The tag is pushed to again, but the digest is still cached.
+	second := push()
+	if got := resolve(nameSpace); got != first {
+		t.Errorf("resolveDigest() before the cached digest expired = %s, want %s", got, first)
+	}
+	// The digests aren't shared with other namespaces.
+	if got := resolve("other"); got != second {
+		t.Errorf("resolveDigest() in another namespace = %s, want %s", got, second)
+	}
+
+	now = now.Add(digestCacheTTL)
+	if got := resolve(nameSpace); got != second {
+		t.Errorf("resolveDigest() after the cached digest expired = %s, want %s", got, second)
+	}
+
+	// References by digest are resolved without consulting the registry.
+	s.Close()
+	byDigest := ref.Context().Digest(first.String())
+	if got, err := cache.resolveDigest(ctx, byDigest, nameSpace, "default", nil); err != nil || got != first {
+		t.Errorf("resolveDigest(%s) = %s, %v, want %s", byDigest, got, err, first)
+	}
+}
+
 func mustRandomImage(t *testing.T) v1.Image {
 	t.Helper()
 	img, err := random.Image(10, 10)
@@ -23,6 +23,7 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
 	"github.com/google/go-containerregistry/pkg/v1/random"
 	"github.com/tektoncd/pipeline/test/diff"
 	corev1 "k8s.io/api/core/v1"
@@ -159,3 +160,14 @@ func (f fakeCache) get(ctx context.Context, ref name.Reference, _, _ string, _ [
 	}
 	return d.id, nil
 }
+
+func (f fakeCache) resolveDigest(_ context.Context, ref name.Reference, _, _ string, _ []corev1.LocalObjectReference) (v1.Hash, error) {
+	if d, ok := ref.(name.Digest); ok {
+		return v1.NewHash(d.DigestStr())
+	}
+	d, found := f[ref.Name()]
+	if !found {
+		return v1.Hash{}, fmt.Errorf("image %q not found", ref)
+	}
+	return d.id.digest, nil
+}
@@ -655,6 +655,7 @@ func TestEntryPointOnError(t *testing.T) {
 				OnError: v1.Continue,
 			}, {
 				OnError: v1.StopAndFail,
+				Retries: 3,
 			}},
 		},
 		wantContainers: []corev1.Container{{
@@ -682,6 +683,7 @@ func TestEntryPointOnError(t *testing.T) {
 				"-termination_path", "/tekton/termination",
 				"-step_metadata_dir", "/tekton/run/1/status",
 				"-on_error", "stopAndFail",
+				"-retries", "3",
 				"-entrypoint", "cmd", "--",
 			},
 			TerminationMessagePath: "/tekton/termination",
@@ -1013,6 +1015,60 @@ func TestStopSidecars(t *testing.T) {
 		},
 		resultExtractionMethod: "sidecar-logs",
 		wantContainers:         []corev1.Container{stepContainer, stoppedSidecarContainer, resultsSidecar},
+	}, {
+		desc: "Results Sidecar should not be stopped with sidecar volume",
+		pod: corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test-pod",
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{stepContainer, sidecarContainer, resultsSidecar},
+			},
+			Status: corev1.PodStatus{
+				Phase: corev1.PodRunning,
+				ContainerStatuses: []corev1.ContainerStatus{{
+					// Step state doesn't matter.
+				}, {
+					Name: sidecarContainer.Name,
+					// Sidecar is running.
+					State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{StartedAt: metav1.NewTime(time.Now())}},
+				}, {
+					Name: resultsSidecar.Name,
+					// Results sidecar is running.
+					State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{StartedAt: metav1.NewTime(time.Now())}},
+				}},
+			},
+		},
+		resultExtractionMethod: "sidecar-volume",
+		wantContainers:         []corev1.Container{stepContainer, stoppedSidecarContainer, resultsSidecar},
+	}, {
+		desc: "Hung Results Sidecar should be stopped",
+		pod: corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test-pod",
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{stepContainer, sidecarContainer, resultsSidecar},
+			},
+			Status: corev1.PodStatus{
+				Phase: corev1.PodRunning,
+				ContainerStatuses: []corev1.ContainerStatus{{
+					Name: stepContainer.Name,
+					// Step finished longer than the grace period of the results sidecar ago.
+					State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{FinishedAt: metav1.NewTime(time.Now().Add(-time.Hour))}},
+				}, {
+					Name: sidecarContainer.Name,
+					// Sidecar is running.
+					State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{StartedAt: metav1.NewTime(time.Now())}},
+				}, {
+					Name: resultsSidecar.Name,
+					// Results sidecar is still running.
+					State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{StartedAt: metav1.NewTime(time.Now())}},
+				}},
+			},
+		},
+		resultExtractionMethod: "sidecar-logs",
+		wantContainers:         []corev1.Container{stepContainer, stoppedSidecarContainer, stoppedResultsSidecar},
 	}, {
 		desc: "Results Sidecar should be stopped result method is not sidecar logs",
 		pod: corev1.Pod{
@@ -1089,7 +1145,7 @@ func TestStopSidecars(t *testing.T) {
 			ctx, cancel := context.WithCancel(ctx)
 			defer cancel()
 			kubeclient := fakek8s.NewSimpleClientset(&c.pod)
-			if got, err := StopSidecars(ctx, nopImage, kubeclient, c.pod.Namespace, c.pod.Name); err != nil {
+			if got, err := StopSidecars(ctx, nopImage, kubeclient, c.pod.Namespace, c.pod.Name, time.Now()); err != nil {
 				t.Errorf("error stopping sidecar: %v", err)
 			} else if d := cmp.Diff(c.wantContainers, got.Spec.Containers); d != "" {
 				t.Errorf("Containers Diff %s", diff.PrintWantGot(d))
@@ -1174,7 +1230,7 @@ func TestStopSidecarsUsesPatch(t *testing.T) {
 	ctx, cancel := context.WithCancel(t.Context())
 	defer cancel()
 
-	got, err := StopSidecars(ctx, nopImage, kubeclient, pod.Namespace, pod.Name)
+	got, err := StopSidecars(ctx, nopImage, kubeclient, pod.Namespace, pod.Name, time.Now())
 	if err != nil {
 		t.Fatalf("StopSidecars failed: %v", err)
 	}
@@ -1291,7 +1347,7 @@ func TestStopSidecarsWithConflictSimulation(t *testing.T) {
 	ctx, cancel := context.WithCancel(t.Context())
 	defer cancel()
 
-	got, err := StopSidecars(ctx, nopImage, kubeclient, pod.Namespace, pod.Name)
+	got, err := StopSidecars(ctx, nopImage, kubeclient, pod.Namespace, pod.Name, time.Now())
 	if err != nil {
 		if k8serrors.IsConflict(err) {
 			t.Fatalf("got 409 conflict, this indicates UPDATE is being used instead of PATCH: %v", err)
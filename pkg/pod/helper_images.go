@@ -0,0 +1,62 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+)
+
+// HelperImageSetAnnotation is the annotation of a TaskRun, or of a PipelineRun propagating it to its
+// TaskRuns, selecting by name the set of helper images, configured with the "helper-image-sets" key
+// in the config-defaults ConfigMap, its pods and Affinity Assistants are created with.
+const HelperImageSetAnnotation = "tekton.dev/helper-image-set"
+
+// HelperImages returns the helper images of the TaskRun or PipelineRun with the given annotations: the
+// images the controller is started with, overridden by the images of the helper image set selected with
+// the tekton.dev/helper-image-set annotation, if any. The images the controller is started with are
+// returned along with an error if the helper image set isn't configured.
+func HelperImages(ctx context.Context, images pipeline.Images, annotations map[string]string) (pipeline.Images, error) {
+	name, ok := annotations[HelperImageSetAnnotation]
+	if !ok {
+		return images, nil
+	}
+	sets := config.FromContextOrDefaults(ctx).Defaults.HelperImageSets
+	set, ok := sets[name]
+	if !ok {
+		names := slices.Sorted(maps.Keys(sets))
+		return images, fmt.Errorf("helper image set %q of annotation %s is not one of the helper image sets configured in config-defaults: [%s]",
+			name, HelperImageSetAnnotation, strings.Join(names, ", "))
+	}
+	override := func(image *string, with string) {
+		if with != "" {
+			*image = with
+		}
+	}
+	override(&images.EntrypointImage, set.Entrypoint)
+	override(&images.NopImage, set.Nop)
+	override(&images.SidecarLogResultsImage, set.SidecarLogResults)
+	override(&images.WorkingDirInitImage, set.WorkingDirInit)
+	override(&images.OCIImageFetchImage, set.OCIImageFetch)
+	return images, nil
+}
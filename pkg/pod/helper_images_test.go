@@ -0,0 +1,169 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/test/diff"
+	"github.com/tektoncd/pipeline/test/names"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakek8s "k8s.io/client-go/kubernetes/fake"
+	logtesting "knative.dev/pkg/logging/testing"
+	"knative.dev/pkg/system"
+)
+
+const helperImageSets = `
+arm64:
+  entrypoint: registry.example.com/entrypoint:arm64
+  nop: registry.example.com/nop:arm64
+  sidecarlogresults: registry.example.com/sidecarlogresults:arm64
+  workingdirinit: registry.example.com/workingdirinit:arm64
+  ociimagefetch: registry.example.com/ociimagefetch:arm64
+proxy:
+  nop: registry.example.com/nop:proxy
+`
+
+func TestHelperImages(t *testing.T) {
+	controllerImages := pipeline.Images{
+		EntrypointImage:        "entrypoint",
+		NopImage:               "nop",
+		SidecarLogResultsImage: "sidecarlogresults",
+		ShellImage:             "shell",
+		ShellImageWin:          "shell-win",
+		WorkingDirInitImage:    "workingdirinit",
+		OCIImageFetchImage:     "ociimagefetch",
+	}
+	for _, tc := range []struct {
+		name        string
+		annotations map[string]string
+		want        pipeline.Images
+		wantErr     string
+	}{{
+		name: "no helper image set",
+		want: controllerImages,
+	}, {
+		name:        "all the helper images overridden",
+		annotations: map[string]string{HelperImageSetAnnotation: "arm64"},
+		want: pipeline.Images{
+			EntrypointImage:        "registry.example.com/entrypoint:arm64",
+			NopImage:               "registry.example.com/nop:arm64",
+			SidecarLogResultsImage: "registry.example.com/sidecarlogresults:arm64",
+			ShellImage:             "shell",
+			ShellImageWin:          "shell-win",
+			WorkingDirInitImage:    "registry.example.com/workingdirinit:arm64",
+			OCIImageFetchImage:     "registry.example.com/ociimagefetch:arm64",
+		},
+	}, {
+		name:        "some of the helper images overridden",
+		annotations: map[string]string{HelperImageSetAnnotation: "proxy"},
+		want: pipeline.Images{
+			EntrypointImage:        "entrypoint",
+			NopImage:               "registry.example.com/nop:proxy",
+			SidecarLogResultsImage: "sidecarlogresults",
+			ShellImage:             "shell",
+			ShellImageWin:          "shell-win",
+			WorkingDirInitImage:    "workingdirinit",
+			OCIImageFetchImage:     "ociimagefetch",
+		},
+	}, {
+		name:        "helper image set not configured",
+		annotations: map[string]string{HelperImageSetAnnotation: "s390x"},
+		want:        controllerImages,
+		wantErr:     `helper image set "s390x" of annotation tekton.dev/helper-image-set is not one of the helper image sets configured in config-defaults: [arm64, proxy]`,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			store := config.NewStore(logtesting.TestLogger(t))
+			store.OnConfigChanged(&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: config.GetDefaultsConfigName(), Namespace: system.Namespace()},
+				Data:       map[string]string{"helper-image-sets": helperImageSets},
+			})
+			got, err := HelperImages(store.ToContext(t.Context()), controllerImages, tc.annotations)
+			gotErr := ""
+			if err != nil {
+				gotErr = err.Error()
+			}
+			if d := cmp.Diff(tc.wantErr, gotErr); d != "" {
+				t.Errorf("HelperImages() error %s", diff.PrintWantGot(d))
+			}
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("HelperImages() %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestPodBuild_HelperImageSet(t *testing.T) {
+	names.TestingSeed()
+	store := config.NewStore(logtesting.TestLogger(t))
+	store.OnConfigChanged(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: config.GetFeatureFlagsConfigName(), Namespace: system.Namespace()},
+		Data:       map[string]string{"results-from": config.ResultExtractionMethodSidecarLogs},
+	})
+	store.OnConfigChanged(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: config.GetDefaultsConfigName(), Namespace: system.Namespace()},
+		Data:       map[string]string{"helper-image-sets": helperImageSets},
+	})
+	builder := Builder{
+		Images: images,
+		KubeClient: fakek8s.NewSimpleClientset(
+			&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "default"}},
+		),
+		EntrypointCache: fakeCache{},
+	}
+	tr := &v1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "taskrun-arm64",
+			Namespace: "default",
+			Annotations: map[string]string{
+				ReleaseAnnotation:        fakeVersion,
+				HelperImageSetAnnotation: "arm64",
+			},
+		},
+	}
+	pod, err := builder.Build(store.ToContext(t.Context()), tr, v1.TaskSpec{
+		Steps: []v1.Step{{
+			Name:       "build",
+			Image:      "builder",
+			Command:    []string{"make"},
+			WorkingDir: "src",
+		}},
+		Results: []v1.TaskResult{{Name: "digest"}},
+	})
+	if err != nil {
+		t.Fatalf("builder.Build: %v", err)
+	}
+
+	want := map[string]string{
+		"prepare":                 "registry.example.com/entrypoint:arm64",
+		"working-dir-initializer": "registry.example.com/workingdirinit:arm64",
+		"step-build":              "builder",
+		pipeline.ReservedResultsSidecarContainerName: "registry.example.com/sidecarlogresults:arm64",
+	}
+	got := map[string]string{}
+	for _, c := range append(pod.Spec.InitContainers, pod.Spec.Containers...) {
+		got[c.Name] = c.Image
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("container images %s", diff.PrintWantGot(d))
+	}
+}
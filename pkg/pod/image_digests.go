@@ -0,0 +1,93 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// PinImageDigestsAnnotation is the annotation of a TaskRun, or of a PipelineRun propagating it to its
+	// TaskRuns, which pins the images of the steps and sidecars to their digests when the pod is created.
+	PinImageDigestsAnnotation = "tekton.dev/pin-image-digests"
+
+	// PinnedImagesAnnotation is the annotation of the pod recording the digests the images of its steps
+	// and sidecars were pinned to, as the JSON encoded list of the pinned images of the TaskRun status.
+	PinnedImagesAnnotation = "tekton.dev/pinned-images"
+)
+
+// ImageDigestResolutionError is returned when building the pod of a TaskRun pinning its images to
+// their digests if the digest of one of the images can't be resolved.
+type ImageDigestResolutionError struct {
+	Image string
+	Err   error
+}
+
+func (e *ImageDigestResolutionError) Error() string {
+	return fmt.Sprintf("failed to resolve the digest of image %q: %v", e.Image, e.Err)
+}
+
+func (e *ImageDigestResolutionError) Unwrap() error {
+	return e.Err
+}
+
+// pinImageDigests replaces the images of the given containers which aren't referenced by digest with
+// their references by digest, and returns the digests they were pinned to, in the order of the containers.
+func pinImageDigests(ctx context.Context, cache EntrypointCache, namespace, serviceAccountName string, imagePullSecrets []corev1.LocalObjectReference, containers ...[]corev1.Container) ([]v1.PinnedImage, error) {
+	var pinned []v1.PinnedImage
+	seen := map[string]bool{}
+	for _, cs := range containers {
+		for i, c := range cs {
+			ref, err := name.ParseReference(c.Image, name.WeakValidation)
+			if err != nil {
+				return nil, &ImageDigestResolutionError{Image: c.Image, Err: err}
+			}
+			if _, ok := ref.(name.Digest); ok {
+				continue
+			}
+			digest, err := cache.resolveDigest(ctx, ref, namespace, serviceAccountName, imagePullSecrets)
+			if err != nil {
+				return nil, &ImageDigestResolutionError{Image: c.Image, Err: err}
+			}
+			cs[i].Image = ref.Context().Digest(digest.String()).String()
+			if !seen[c.Image] {
+				seen[c.Image] = true
+				pinned = append(pinned, v1.PinnedImage{Image: c.Image, Digest: digest.String()})
+			}
+		}
+	}
+	return pinned, nil
+}
+
+// pinnedImages returns the pinned images recorded in the annotations of the pod, if any.
+func pinnedImages(pod *corev1.Pod) ([]v1.PinnedImage, error) {
+	value, ok := pod.Annotations[PinnedImagesAnnotation]
+	if !ok {
+		return nil, nil
+	}
+	var pinned []v1.PinnedImage
+	if err := json.Unmarshal([]byte(value), &pinned); err != nil {
+		return nil, fmt.Errorf("invalid %s annotation of pod %s: %w", PinnedImagesAnnotation, pod.Name, err)
+	}
+	return pinned, nil
+}
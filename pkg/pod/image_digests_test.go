@@ -0,0 +1,187 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/test/diff"
+	"github.com/tektoncd/pipeline/test/names"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakek8s "k8s.io/client-go/kubernetes/fake"
+	logtesting "knative.dev/pkg/logging/testing"
+	"knative.dev/pkg/system"
+)
+
+func TestPinImageDigests(t *testing.T) {
+	img, err := random.Image(1, 1)
+	if err != nil {
+		t.Fatalf("random.Image: %v", err)
+	}
+	dig, err := img.Digest()
+	if err != nil {
+		t.Fatalf("image.Digest: %v", err)
+	}
+	cache := fakeCache{
+		"registry.example.com/builder:1.0":    &data{id: &imageData{digest: dig}},
+		"registry.example.com/builder:latest": &data{id: &imageData{digest: dig}},
+	}
+
+	steps := []corev1.Container{{
+		Name:  "step-build",
+		Image: "registry.example.com/builder:1.0",
+	}, {
+		// Images referenced by digest are already pinned.
+		Name:  "step-push",
+		Image: "registry.example.com/pusher@" + dig.String(),
+	}, {
+		// An image without a tag is pinned from the latest tag.
+		Name:  "step-test",
+		Image: "registry.example.com/builder",
+	}}
+	sidecars := []corev1.Container{{
+		Name:  "sidecar-builder",
+		Image: "registry.example.com/builder:1.0",
+	}}
+	got, err := pinImageDigests(t.Context(), cache, "namespace", "serviceAccountName", nil, steps, sidecars)
+	if err != nil {
+		t.Fatalf("pinImageDigests: %v", err)
+	}
+
+	want := []v1.PinnedImage{{
+		Image:  "registry.example.com/builder:1.0",
+		Digest: dig.String(),
+	}, {
+		Image:  "registry.example.com/builder",
+		Digest: dig.String(),
+	}}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("pinned images %s", diff.PrintWantGot(d))
+	}
+	wantImages := []string{
+		"registry.example.com/builder@" + dig.String(),
+		"registry.example.com/pusher@" + dig.String(),
+		"registry.example.com/builder@" + dig.String(),
+		"registry.example.com/builder@" + dig.String(),
+	}
+	var gotImages []string
+	for _, c := range append(steps, sidecars...) {
+		gotImages = append(gotImages, c.Image)
+	}
+	if d := cmp.Diff(wantImages, gotImages); d != "" {
+		t.Errorf("container images %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestPinImageDigests_ResolutionFailed(t *testing.T) {
+	_, err := pinImageDigests(t.Context(), fakeCache{}, "namespace", "serviceAccountName", nil, []corev1.Container{{
+		Name:  "step-build",
+		Image: "registry.example.com/missing:1.0",
+	}})
+	var digestErr *ImageDigestResolutionError
+	if !errors.As(err, &digestErr) {
+		t.Fatalf("pinImageDigests: got error %v, want an ImageDigestResolutionError", err)
+	}
+	if digestErr.Image != "registry.example.com/missing:1.0" {
+		t.Errorf("ImageDigestResolutionError image: got %q, want %q", digestErr.Image, "registry.example.com/missing:1.0")
+	}
+}
+
+func TestPodBuild_PinImageDigests(t *testing.T) {
+	img, err := random.Image(1, 1)
+	if err != nil {
+		t.Fatalf("random.Image: %v", err)
+	}
+	dig, err := img.Digest()
+	if err != nil {
+		t.Fatalf("image.Digest: %v", err)
+	}
+
+	for _, tc := range []struct {
+		name       string
+		annotation string
+		wantImages []string
+		wantPinned string
+	}{{
+		name:       "pinned",
+		annotation: "true",
+		wantImages: []string{"registry.example.com/builder@" + dig.String(), "registry.example.com/proxy@" + dig.String()},
+		wantPinned: `[{"image":"registry.example.com/builder:1.0","digest":"` + dig.String() + `"},{"image":"registry.example.com/proxy:2","digest":"` + dig.String() + `"}]`,
+	}, {
+		name:       "not pinned",
+		annotation: "false",
+		wantImages: []string{"registry.example.com/builder:1.0", "registry.example.com/proxy:2"},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			names.TestingSeed()
+			store := config.NewStore(logtesting.TestLogger(t))
+			store.OnConfigChanged(&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: config.GetFeatureFlagsConfigName(), Namespace: system.Namespace()}})
+			store.OnConfigChanged(&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: config.GetDefaultsConfigName(), Namespace: system.Namespace()}})
+			builder := Builder{
+				Images: images,
+				KubeClient: fakek8s.NewSimpleClientset(
+					&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "default"}},
+				),
+				EntrypointCache: fakeCache{
+					"registry.example.com/builder:1.0": &data{id: &imageData{digest: dig}},
+					"registry.example.com/proxy:2":     &data{id: &imageData{digest: dig}},
+				},
+			}
+			tr := &v1.TaskRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "taskrun-pinned",
+					Namespace: "default",
+					Annotations: map[string]string{
+						ReleaseAnnotation:         fakeVersion,
+						PinImageDigestsAnnotation: tc.annotation,
+					},
+				},
+			}
+			pod, err := builder.Build(store.ToContext(t.Context()), tr, v1.TaskSpec{
+				Steps: []v1.Step{{
+					Name:    "build",
+					Image:   "registry.example.com/builder:1.0",
+					Command: []string{"make"},
+				}},
+				Sidecars: []v1.Sidecar{{
+					Name:  "proxy",
+					Image: "registry.example.com/proxy:2",
+				}},
+			})
+			if err != nil {
+				t.Fatalf("builder.Build: %v", err)
+			}
+
+			var gotImages []string
+			for _, c := range pod.Spec.Containers {
+				gotImages = append(gotImages, c.Image)
+			}
+			if d := cmp.Diff(tc.wantImages, gotImages); d != "" {
+				t.Errorf("container images %s", diff.PrintWantGot(d))
+			}
+			if d := cmp.Diff(tc.wantPinned, pod.Annotations[PinnedImagesAnnotation]); d != "" {
+				t.Errorf("%s annotation %s", PinnedImagesAnnotation, diff.PrintWantGot(d))
+			}
+		})
+	}
+}
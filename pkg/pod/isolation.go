@@ -0,0 +1,210 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/kmeta"
+)
+
+const (
+	// IsolatedPodAnnotation is the annotation of the pod running the steps of a TaskRun without the
+	// "isolated" security profile, naming the pod which runs the isolated steps.
+	IsolatedPodAnnotation = "tekton.dev/isolated-pod-name"
+
+	// IsolatedPodLabelKey is the label of the pod running the isolated steps of a TaskRun, which tells it
+	// apart from the pod running its other steps, as both have the labels of the TaskRun.
+	IsolatedPodLabelKey = "tekton.dev/isolated-pod"
+
+	// isolatedPodSuffix is the suffix of the name of the pod running the isolated steps of a TaskRun,
+	// appended to the name of the pod running its other steps.
+	isolatedPodSuffix = "-isolated"
+)
+
+// ErrNoSharedWorkspace is returned when the isolated steps of a TaskRun can't be sequenced with its
+// other steps, as none of its workspaces is bound to a persistent volume claim both pods can mount.
+var ErrNoSharedWorkspace = errors.New("steps with the \"isolated\" securityProfile need a workspace bound to a persistent volume claim to be sequenced with the other steps")
+
+// HasIsolatedSteps returns whether some of the steps of ts have the "isolated" security profile.
+func HasIsolatedSteps(ts v1.TaskSpec) bool {
+	return slices.ContainsFunc(ts.Steps, func(s v1.Step) bool {
+		return s.SecurityProfile == v1.StepSecurityProfileIsolated
+	})
+}
+
+// SplitIsolatedSteps moves the containers of the steps of ts with the "isolated" security profile out
+// of pod, as built for all of the steps of ts, into a second pod using the RuntimeClass runtimeClass,
+// which it returns. Both pods keep the init containers preparing the steps, but only pod runs the
+// sidecars. The steps of both pods are still sequenced by the entrypoint waiting for the files posted
+// by the previous step, as the run volumes holding these files are replaced by directories of the
+// first workspace volume bound to a persistent volume claim, which both pods mount. Both pods are
+// scheduled on the same node, so that they can mount the claim even if it is ReadWriteOnce.
+func SplitIsolatedSteps(pod *corev1.Pod, ts v1.TaskSpec, runtimeClass string) (*corev1.Pod, error) {
+	sharedVolume := ""
+	for _, v := range pod.Spec.Volumes {
+		if v.PersistentVolumeClaim != nil && strings.HasPrefix(v.Name, "ws-") {
+			sharedVolume = v.Name
+			break
+		}
+	}
+	if sharedVolume == "" {
+		return nil, ErrNoSharedWorkspace
+	}
+
+	isolatedContainers := map[string]bool{}
+	for _, s := range ts.Steps {
+		if s.SecurityProfile == v1.StepSecurityProfileIsolated {
+			isolatedContainers[pipeline.StepContainerName(s.Name)] = true
+		}
+	}
+	if len(isolatedContainers) == 0 {
+		return nil, fmt.Errorf("none of the steps of pod %s has the %q securityProfile", pod.Name, v1.StepSecurityProfileIsolated)
+	}
+
+	// Share the run volumes, and the results and steps volumes read by the results sidecar, between
+	// the pods, in directories of the shared workspace for this pod
+	sharedDir := filepath.Join(".tekton", pod.Name)
+	sharedSubPath := func(name string) (string, bool) {
+		if step, ok := strings.CutPrefix(name, runVolumeName+"-"); ok {
+			return filepath.Join("run", step), true
+		}
+		switch name {
+		case resultsVolumeName:
+			return "results", true
+		case stepsVolumeName:
+			return "steps", true
+		}
+		return "", false
+	}
+	pod.Spec.Volumes = slices.DeleteFunc(pod.Spec.Volumes, func(v corev1.Volume) bool {
+		_, ok := sharedSubPath(v.Name)
+		return ok
+	})
+	for _, containers := range [][]corev1.Container{pod.Spec.InitContainers, pod.Spec.Containers} {
+		for i := range containers {
+			for j, vm := range containers[i].VolumeMounts {
+				if dir, ok := sharedSubPath(vm.Name); ok {
+					containers[i].VolumeMounts[j].Name = sharedVolume
+					containers[i].VolumeMounts[j].SubPath = filepath.Join(sharedDir, dir, vm.SubPath)
+				}
+			}
+		}
+	}
+
+	// Each pod is scheduled on the node of the other one, if it is already scheduled
+	if pod.Spec.Affinity == nil {
+		pod.Spec.Affinity = &corev1.Affinity{}
+	}
+	if pod.Spec.Affinity.PodAffinity == nil {
+		pod.Spec.Affinity.PodAffinity = &corev1.PodAffinity{}
+	}
+	pod.Spec.Affinity.PodAffinity.RequiredDuringSchedulingIgnoredDuringExecution = append(pod.Spec.Affinity.PodAffinity.RequiredDuringSchedulingIgnoredDuringExecution, corev1.PodAffinityTerm{
+		LabelSelector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{pipeline.TaskRunLabelKey: pod.Labels[pipeline.TaskRunLabelKey]},
+		},
+		TopologyKey: "kubernetes.io/hostname",
+	})
+
+	isolated := pod.DeepCopy()
+	isolated.Name = kmeta.ChildName(pod.Name, isolatedPodSuffix)
+	if isolated.Labels == nil {
+		isolated.Labels = map[string]string{}
+	}
+	isolated.Labels[IsolatedPodLabelKey] = "true"
+	isolated.Spec.RuntimeClassName = &runtimeClass
+	// The isolated pod doesn't run sidecars to wait for
+	if isolated.Annotations == nil {
+		isolated.Annotations = map[string]string{}
+	}
+	isolated.Annotations[readyAnnotation] = readyAnnotationValue
+	isolated.Spec.InitContainers = slices.DeleteFunc(isolated.Spec.InitContainers, func(c corev1.Container) bool {
+		return IsContainerSidecar(c.Name)
+	})
+	isolated.Spec.Containers = slices.DeleteFunc(isolated.Spec.Containers, func(c corev1.Container) bool {
+		return !isolatedContainers[c.Name]
+	})
+	pod.Spec.Containers = slices.DeleteFunc(pod.Spec.Containers, func(c corev1.Container) bool {
+		return isolatedContainers[c.Name]
+	})
+	if pod.Annotations == nil {
+		pod.Annotations = map[string]string{}
+	}
+	pod.Annotations[IsolatedPodAnnotation] = isolated.Name
+	return isolated, nil
+}
+
+// IsIsolatedPod returns whether pod runs the isolated steps of a TaskRun.
+func IsIsolatedPod(pod *corev1.Pod) bool {
+	return pod.Labels[IsolatedPodLabelKey] == "true"
+}
+
+// IsolatedPodName returns the name of the pod running the isolated steps of the TaskRun run by pod,
+// or "" if none of its steps is isolated.
+func IsolatedPodName(pod *corev1.Pod) string {
+	return pod.Annotations[IsolatedPodAnnotation]
+}
+
+// MergeIsolatedPod returns a copy of pod merged with the pod running the isolated steps of the same
+// TaskRun, so that the status of the TaskRun is made from the statuses of the containers of both pods,
+// with the step containers in the order of the steps of ts. The merged pod fails as soon as one of the
+// pods fails and succeeds once both pods succeed.
+func MergeIsolatedPod(pod, isolated *corev1.Pod, ts *v1.TaskSpec) *corev1.Pod {
+	merged := pod.DeepCopy()
+	merged.Spec.Containers = append(merged.Spec.Containers, isolated.Spec.Containers...)
+	merged.Status.ContainerStatuses = append(merged.Status.ContainerStatuses, isolated.Status.ContainerStatuses...)
+	if ts != nil {
+		order := map[string]int{}
+		for i, s := range ts.Steps {
+			order[pipeline.StepContainerName(s.Name)] = i
+		}
+		// The containers which don't run steps, e.g. the sidecars, keep their relative order after the steps
+		position := func(c corev1.Container) int {
+			if i, ok := order[c.Name]; ok {
+				return i
+			}
+			return len(ts.Steps)
+		}
+		slices.SortStableFunc(merged.Spec.Containers, func(a, b corev1.Container) int {
+			return position(a) - position(b)
+		})
+	}
+
+	switch {
+	case pod.Status.Phase == corev1.PodFailed:
+	case isolated.Status.Phase == corev1.PodFailed:
+		merged.Status.Phase = corev1.PodFailed
+		merged.Status.Reason = isolated.Status.Reason
+		merged.Status.Message = isolated.Status.Message
+	case pod.Status.Phase == corev1.PodSucceeded && isolated.Status.Phase == corev1.PodSucceeded:
+	case pod.Status.Phase == corev1.PodPending && isolated.Status.Phase == corev1.PodPending:
+	default:
+		merged.Status.Phase = corev1.PodRunning
+	}
+	if isolated.Status.StartTime != nil && (merged.Status.StartTime == nil || isolated.Status.StartTime.Before(merged.Status.StartTime)) {
+		merged.Status.StartTime = isolated.Status.StartTime.DeepCopy()
+	}
+	return merged
+}
@@ -0,0 +1,363 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/test/diff"
+	"github.com/tektoncd/pipeline/test/names"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakek8s "k8s.io/client-go/kubernetes/fake"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/logging"
+	logtesting "knative.dev/pkg/logging/testing"
+	"knative.dev/pkg/system"
+)
+
+func isolatedTaskSpec(volumes ...corev1.Volume) v1.TaskSpec {
+	return v1.TaskSpec{
+		Steps: []v1.Step{{
+			Name:    "checkout",
+			Image:   "image",
+			Command: []string{"cmd"},
+		}, {
+			Name:            "untrusted",
+			Image:           "image",
+			Command:         []string{"cmd"},
+			SecurityProfile: v1.StepSecurityProfileIsolated,
+		}, {
+			Name:    "publish",
+			Image:   "image",
+			Command: []string{"cmd"},
+		}},
+		Sidecars: []v1.Sidecar{{
+			Name:  "registry",
+			Image: "registry",
+		}},
+		Volumes: volumes,
+	}
+}
+
+func buildIsolatedTaskPod(t *testing.T, ts v1.TaskSpec, featureFlags ...string) *corev1.Pod {
+	t.Helper()
+	names.TestingSeed()
+	flags := map[string]string{}
+	for i := 0; i+1 < len(featureFlags); i += 2 {
+		flags[featureFlags[i]] = featureFlags[i+1]
+	}
+	store := config.NewStore(logtesting.TestLogger(t))
+	store.OnConfigChanged(&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: config.GetFeatureFlagsConfigName(), Namespace: system.Namespace()}, Data: flags})
+	store.OnConfigChanged(&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: config.GetDefaultsConfigName(), Namespace: system.Namespace()}})
+	builder := Builder{
+		Images: images,
+		KubeClient: fakek8s.NewSimpleClientset(
+			&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "default"}},
+		),
+		EntrypointCache: fakeCache{},
+	}
+	tr := &v1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "taskrun-isolated",
+			Namespace:   "default",
+			Annotations: map[string]string{ReleaseAnnotation: fakeVersion},
+		},
+	}
+	pod, err := builder.Build(store.ToContext(t.Context()), tr, ts)
+	if err != nil {
+		t.Fatalf("builder.Build: %v", err)
+	}
+	return pod
+}
+
+func containerNames(containers []corev1.Container) []string {
+	var names []string
+	for _, c := range containers {
+		names = append(names, c.Name)
+	}
+	return names
+}
+
+func TestSplitIsolatedSteps(t *testing.T) {
+	sharedVolume := corev1.Volume{
+		Name:         "ws-abcde",
+		VolumeSource: corev1.VolumeSource{PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "source"}},
+	}
+	ts := isolatedTaskSpec(sharedVolume)
+	pod := buildIsolatedTaskPod(t, ts)
+
+	isolated, err := SplitIsolatedSteps(pod, ts, "gvisor")
+	if err != nil {
+		t.Fatalf("SplitIsolatedSteps: %v", err)
+	}
+
+	if d := cmp.Diff([]string{"step-checkout", "step-publish", "sidecar-registry"}, containerNames(pod.Spec.Containers)); d != "" {
+		t.Errorf("Unexpected containers of the pod %s", diff.PrintWantGot(d))
+	}
+	if d := cmp.Diff([]string{"step-untrusted"}, containerNames(isolated.Spec.Containers)); d != "" {
+		t.Errorf("Unexpected containers of the isolated pod %s", diff.PrintWantGot(d))
+	}
+	if d := cmp.Diff(containerNames(pod.Spec.InitContainers), containerNames(isolated.Spec.InitContainers)); d != "" {
+		t.Errorf("Expected the isolated pod to prepare the steps like the pod %s", diff.PrintWantGot(d))
+	}
+	if isolated.Name != "taskrun-isolated-pod-isolated" || IsolatedPodName(pod) != isolated.Name {
+		t.Errorf("Expected the pod to name the isolated pod %q, got %q", isolated.Name, IsolatedPodName(pod))
+	}
+	if isolated.Spec.RuntimeClassName == nil || *isolated.Spec.RuntimeClassName != "gvisor" {
+		t.Errorf("Expected the isolated pod to use the gvisor RuntimeClass, got %v", isolated.Spec.RuntimeClassName)
+	}
+	if pod.Spec.RuntimeClassName != nil {
+		t.Errorf("Expected the pod to use the default RuntimeClass, got %q", *pod.Spec.RuntimeClassName)
+	}
+	if !IsIsolatedPod(isolated) || IsIsolatedPod(pod) {
+		t.Errorf("Expected only the isolated pod to have the %s label, got %v and %v", IsolatedPodLabelKey, isolated.Labels, pod.Labels)
+	}
+	// Both pods mount the shared workspace, so they are scheduled on the same node
+	wantAffinity := &corev1.Affinity{PodAffinity: &corev1.PodAffinity{
+		RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{{
+			LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tekton.dev/taskRun": "taskrun-isolated"}},
+			TopologyKey:   "kubernetes.io/hostname",
+		}},
+	}}
+	for _, p := range []*corev1.Pod{pod, isolated} {
+		if d := cmp.Diff(wantAffinity, p.Spec.Affinity); d != "" {
+			t.Errorf("Unexpected affinity of pod %s %s", p.Name, diff.PrintWantGot(d))
+		}
+	}
+	if isolated.Annotations[readyAnnotation] != readyAnnotationValue {
+		t.Errorf("Expected the isolated pod to be ready immediately, got annotations %v", isolated.Annotations)
+	}
+
+	// The steps of both pods wait for the files posted by the previous step in the shared workspace
+	for _, p := range []*corev1.Pod{pod, isolated} {
+		for _, v := range p.Spec.Volumes {
+			if v.Name == "tekton-internal-run-0" {
+				t.Errorf("Expected the run volumes to be replaced by the shared workspace in pod %s", p.Name)
+			}
+		}
+	}
+	wantMounts := []corev1.VolumeMount{{
+		Name: "ws-abcde", MountPath: "/tekton/run/0", SubPath: ".tekton/taskrun-isolated-pod/run/0", ReadOnly: true,
+	}, {
+		Name: "ws-abcde", MountPath: "/tekton/run/1", SubPath: ".tekton/taskrun-isolated-pod/run/1",
+	}, {
+		Name: "ws-abcde", MountPath: "/tekton/run/2", SubPath: ".tekton/taskrun-isolated-pod/run/2", ReadOnly: true,
+	}}
+	var gotMounts []corev1.VolumeMount
+	for _, vm := range isolated.Spec.Containers[0].VolumeMounts {
+		if vm.Name == sharedVolume.Name && strings.HasPrefix(vm.MountPath, RunDir) {
+			gotMounts = append(gotMounts, vm)
+		}
+	}
+	if d := cmp.Diff(wantMounts, gotMounts); d != "" {
+		t.Errorf("Unexpected run volume mounts of the isolated step %s", diff.PrintWantGot(d))
+	}
+
+	// The results and the step directories written by the isolated steps are read by the results
+	// sidecar of the pod, so they are shared too
+	for _, p := range []*corev1.Pod{pod, isolated} {
+		for _, v := range p.Spec.Volumes {
+			if v.Name == resultsVolumeName || v.Name == stepsVolumeName {
+				t.Errorf("Expected the volume %s to be replaced by the shared workspace in pod %s", v.Name, p.Name)
+			}
+		}
+		wantShared := map[string]string{
+			pipeline.DefaultResultPath: ".tekton/taskrun-isolated-pod/results",
+			pipeline.StepsDir:          ".tekton/taskrun-isolated-pod/steps",
+		}
+		for _, c := range p.Spec.Containers {
+			if !strings.HasPrefix(c.Name, stepPrefix) {
+				continue
+			}
+			for _, vm := range c.VolumeMounts {
+				if subPath, ok := wantShared[vm.MountPath]; ok && (vm.Name != sharedVolume.Name || vm.SubPath != subPath) {
+					t.Errorf("Expected %s of container %s of pod %s to be mounted from %s of the shared workspace, got %s of %s", vm.MountPath, c.Name, p.Name, subPath, vm.SubPath, vm.Name)
+				}
+			}
+		}
+	}
+}
+
+func TestSplitIsolatedSteps_ResultsSidecar(t *testing.T) {
+	sharedVolume := corev1.Volume{
+		Name:         "ws-abcde",
+		VolumeSource: corev1.VolumeSource{PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "source"}},
+	}
+	ts := isolatedTaskSpec(sharedVolume)
+	ts.Results = []v1.TaskResult{{Name: "digest"}}
+	pod := buildIsolatedTaskPod(t, ts, "results-from", "sidecar-logs")
+
+	if _, err := SplitIsolatedSteps(pod, ts, "gvisor"); err != nil {
+		t.Fatalf("SplitIsolatedSteps: %v", err)
+	}
+
+	var sidecar *corev1.Container
+	for _, containers := range [][]corev1.Container{pod.Spec.InitContainers, pod.Spec.Containers} {
+		for i := range containers {
+			if containers[i].Name == pipeline.SidecarContainerName(pipeline.ReservedResultsSidecarName) {
+				sidecar = &containers[i]
+			}
+		}
+	}
+	if sidecar == nil {
+		t.Fatalf("Expected the pod to run the results sidecar, got init containers %v and containers %v", containerNames(pod.Spec.InitContainers), containerNames(pod.Spec.Containers))
+	}
+	got := map[string]string{}
+	for _, vm := range sidecar.VolumeMounts {
+		if vm.Name == sharedVolume.Name {
+			got[vm.MountPath] = vm.SubPath
+		}
+	}
+	for mountPath, subPath := range map[string]string{
+		pipeline.DefaultResultPath: ".tekton/taskrun-isolated-pod/results",
+		pipeline.StepsDir:          ".tekton/taskrun-isolated-pod/steps",
+		"/tekton/run/1":            ".tekton/taskrun-isolated-pod/run/1",
+	} {
+		if got[mountPath] != subPath {
+			t.Errorf("Expected the results sidecar to mount %s from %s of the shared workspace, got mounts %v", mountPath, subPath, got)
+		}
+	}
+}
+
+func TestSplitIsolatedSteps_NoSharedWorkspace(t *testing.T) {
+	ts := isolatedTaskSpec(corev1.Volume{
+		Name:         "ws-abcde",
+		VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+	})
+	pod := buildIsolatedTaskPod(t, ts)
+	if _, err := SplitIsolatedSteps(pod, ts, "gvisor"); !errors.Is(err, ErrNoSharedWorkspace) {
+		t.Errorf("Expected ErrNoSharedWorkspace, got %v", err)
+	}
+}
+
+func TestMergeIsolatedPod(t *testing.T) {
+	for _, tc := range []struct {
+		desc                     string
+		podPhase, isolatedPhase  corev1.PodPhase
+		wantPhase                corev1.PodPhase
+		wantReason, wantMessage  string
+		isolatedReason, isolated string
+	}{{
+		desc:          "both pods pending",
+		podPhase:      corev1.PodPending,
+		isolatedPhase: corev1.PodPending,
+		wantPhase:     corev1.PodPending,
+	}, {
+		desc:          "isolated pod still pending",
+		podPhase:      corev1.PodRunning,
+		isolatedPhase: corev1.PodPending,
+		wantPhase:     corev1.PodRunning,
+	}, {
+		desc:          "pod succeeded before the isolated pod",
+		podPhase:      corev1.PodSucceeded,
+		isolatedPhase: corev1.PodRunning,
+		wantPhase:     corev1.PodRunning,
+	}, {
+		desc:          "both pods succeeded",
+		podPhase:      corev1.PodSucceeded,
+		isolatedPhase: corev1.PodSucceeded,
+		wantPhase:     corev1.PodSucceeded,
+	}, {
+		desc:           "isolated pod failed",
+		podPhase:       corev1.PodRunning,
+		isolatedPhase:  corev1.PodFailed,
+		isolatedReason: "Evicted",
+		isolated:       "The node was low on resource: memory.",
+		wantPhase:      corev1.PodFailed,
+		wantReason:     "Evicted",
+		wantMessage:    "The node was low on resource: memory.",
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			pod := &corev1.Pod{Status: corev1.PodStatus{Phase: tc.podPhase}}
+			isolated := &corev1.Pod{Status: corev1.PodStatus{Phase: tc.isolatedPhase, Reason: tc.isolatedReason, Message: tc.isolated}}
+			got := MergeIsolatedPod(pod, isolated, nil)
+			if got.Status.Phase != tc.wantPhase || got.Status.Reason != tc.wantReason || got.Status.Message != tc.wantMessage {
+				t.Errorf("Expected phase %q, reason %q and message %q, got %q, %q and %q", tc.wantPhase, tc.wantReason, tc.wantMessage, got.Status.Phase, got.Status.Reason, got.Status.Message)
+			}
+		})
+	}
+}
+
+// TestMakeTaskRunStatus_IsolatedPod tests that the status of a TaskRun is made from the statuses of the
+// containers of both of its pods, with its steps in the order of the Task spec.
+func TestMakeTaskRunStatus_IsolatedPod(t *testing.T) {
+	ts := isolatedTaskSpec()
+	tr := v1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "task-run", Namespace: "foo"},
+		Spec:       v1.TaskRunSpec{TaskSpec: &ts},
+	}
+	startedAt := metav1.NewTime(time.Date(2026, time.October, 16, 8, 0, 0, 0, time.UTC))
+	terminated := corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{StartedAt: startedAt}}
+	running := corev1.ContainerState{Running: &corev1.ContainerStateRunning{StartedAt: startedAt}}
+	waiting := corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "PodInitializing"}}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "task-run-pod", Namespace: "foo"},
+		Spec: corev1.PodSpec{Containers: []corev1.Container{
+			{Name: "step-checkout"}, {Name: "step-publish"}, {Name: "sidecar-registry"},
+		}},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			ContainerStatuses: []corev1.ContainerStatus{
+				{Name: "step-checkout", State: terminated},
+				{Name: "step-publish", State: running},
+				{Name: "sidecar-registry", State: running},
+			},
+		},
+	}
+	isolated := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "task-run-pod-isolated", Namespace: "foo"},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "step-untrusted"}}},
+		Status: corev1.PodStatus{
+			Phase:             corev1.PodPending,
+			ContainerStatuses: []corev1.ContainerStatus{{Name: "step-untrusted", State: waiting}},
+		},
+	}
+
+	logger, _ := logging.NewLogger("", "status")
+	got, err := MakeTaskRunStatus(t.Context(), logger, tr, MergeIsolatedPod(pod, isolated, &ts), fakek8s.NewSimpleClientset(), &ts)
+	if err != nil {
+		t.Fatalf("MakeTaskRunStatus: %v", err)
+	}
+	wantSteps := []v1.StepState{{
+		ContainerState: terminated, Name: "checkout", Container: "step-checkout", Results: []v1.TaskRunStepResult{},
+	}, {
+		ContainerState: waiting, Name: "untrusted", Container: "step-untrusted", Results: []v1.TaskRunStepResult{},
+	}, {
+		ContainerState: running, Name: "publish", Container: "step-publish", Results: []v1.TaskRunStepResult{},
+	}}
+	if d := cmp.Diff(wantSteps, got.Steps, ignoreVolatileTime); d != "" {
+		t.Errorf("Unexpected steps %s", diff.PrintWantGot(d))
+	}
+	wantSidecars := []v1.SidecarState{{ContainerState: running, Name: "registry", Container: "sidecar-registry"}}
+	if d := cmp.Diff(wantSidecars, got.Sidecars); d != "" {
+		t.Errorf("Unexpected sidecars %s", diff.PrintWantGot(d))
+	}
+	if got.PodName != pod.Name {
+		t.Errorf("Expected the pod name %q, got %q", pod.Name, got.PodName)
+	}
+	if c := got.GetCondition(apis.ConditionSucceeded); c == nil || c.Status != corev1.ConditionUnknown {
+		t.Errorf("Expected the TaskRun to be running, got condition %v", c)
+	}
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"fmt"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/pod"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// NamespaceDefaultsConfigMapName is the name of the ConfigMap holding the defaults of the
+	// pods created in the namespace it is in.
+	NamespaceDefaultsConfigMapName = config.NamespacePodDefaultsConfigMapName
+	// NamespaceDefaultsNodeSelectorKey is the key of the node selector in the namespace defaults ConfigMap.
+	NamespaceDefaultsNodeSelectorKey = "nodeSelector"
+	// NamespaceDefaultsTolerationsKey is the key of the tolerations in the namespace defaults ConfigMap.
+	NamespaceDefaultsTolerationsKey = "tolerations"
+)
+
+// NamespaceDefaults holds the node selector and tolerations that the pods created in a namespace,
+// for TaskRuns as well as for affinity assistants, get by default.
+type NamespaceDefaults struct {
+	NodeSelector map[string]string
+	Tolerations  []corev1.Toleration
+}
+
+// GetNamespaceDefaults reads the defaults of the pods created in namespace from its
+// "tekton-pod-defaults" ConfigMap. It returns nil if the namespace does not have one.
+func GetNamespaceDefaults(lister corev1listers.ConfigMapLister, namespace string) (*NamespaceDefaults, error) {
+	cm, err := lister.ConfigMaps(namespace).Get(NamespaceDefaultsConfigMapName)
+	switch {
+	case k8serrors.IsNotFound(err):
+		return nil, nil
+	case err != nil:
+		return nil, err
+	}
+	defaults := &NamespaceDefaults{}
+	if v, ok := cm.Data[NamespaceDefaultsNodeSelectorKey]; ok {
+		if err := yaml.Unmarshal([]byte(v), &defaults.NodeSelector); err != nil {
+			return nil, fmt.Errorf("failed to parse %q of ConfigMap %s/%s: %w", NamespaceDefaultsNodeSelectorKey, namespace, NamespaceDefaultsConfigMapName, err)
+		}
+	}
+	if v, ok := cm.Data[NamespaceDefaultsTolerationsKey]; ok {
+		if err := yaml.Unmarshal([]byte(v), &defaults.Tolerations); err != nil {
+			return nil, fmt.Errorf("failed to parse %q of ConfigMap %s/%s: %w", NamespaceDefaultsTolerationsKey, namespace, NamespaceDefaultsConfigMapName, err)
+		}
+	}
+	return defaults, nil
+}
+
+// MergePodTemplate returns a copy of tpl with the namespace defaults merged into it with the lowest
+// precedence: the node selector labels and the tolerations, matched by key, of tpl win.
+func (d *NamespaceDefaults) MergePodTemplate(tpl *pod.Template) *pod.Template {
+	if d == nil || (len(d.NodeSelector) == 0 && len(d.Tolerations) == 0) {
+		return tpl
+	}
+	merged := &pod.Template{}
+	if tpl != nil {
+		merged = tpl.DeepCopy()
+	}
+	merged.NodeSelector = d.mergeNodeSelector(merged.NodeSelector)
+	merged.Tolerations = d.mergeTolerations(merged.Tolerations)
+	return merged
+}
+
+// MergePodSpec merges the namespace defaults into spec with the lowest precedence, as MergePodTemplate does.
+func (d *NamespaceDefaults) MergePodSpec(spec *corev1.PodSpec) {
+	if d == nil {
+		return
+	}
+	spec.NodeSelector = d.mergeNodeSelector(spec.NodeSelector)
+	spec.Tolerations = d.mergeTolerations(spec.Tolerations)
+}
+
+func (d *NamespaceDefaults) mergeNodeSelector(nodeSelector map[string]string) map[string]string {
+	if len(d.NodeSelector) == 0 {
+		return nodeSelector
+	}
+	merged := make(map[string]string, len(d.NodeSelector)+len(nodeSelector))
+	for k, v := range d.NodeSelector {
+		merged[k] = v
+	}
+	for k, v := range nodeSelector {
+		merged[k] = v
+	}
+	return merged
+}
+
+func (d *NamespaceDefaults) mergeTolerations(tolerations []corev1.Toleration) []corev1.Toleration {
+	if len(d.Tolerations) == 0 {
+		return tolerations
+	}
+	keys := make(map[string]bool, len(tolerations))
+	for _, t := range tolerations {
+		keys[t.Key] = true
+	}
+	merged := append([]corev1.Toleration{}, tolerations...)
+	for _, t := range d.Tolerations {
+		if !keys[t.Key] {
+			merged = append(merged, t)
+		}
+	}
+	return merged
+}
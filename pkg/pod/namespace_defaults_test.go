@@ -0,0 +1,192 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/pod"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+var dedicatedToleration = corev1.Toleration{
+	Key:      "dedicated",
+	Operator: corev1.TolerationOpEqual,
+	Value:    "ci",
+	Effect:   corev1.TaintEffectNoSchedule,
+}
+
+func newNamespaceDefaultsConfigMap(data map[string]string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: NamespaceDefaultsConfigMapName, Namespace: "ci"},
+		Data:       data,
+	}
+}
+
+func TestGetNamespaceDefaults(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		cm      *corev1.ConfigMap
+		want    *NamespaceDefaults
+		wantErr bool
+	}{{
+		name: "no configmap",
+	}, {
+		name: "node selector and tolerations",
+		cm: newNamespaceDefaultsConfigMap(map[string]string{
+			NamespaceDefaultsNodeSelectorKey: "pool: ci\n",
+			NamespaceDefaultsTolerationsKey:  "- key: dedicated\n  operator: Equal\n  value: ci\n  effect: NoSchedule\n",
+		}),
+		want: &NamespaceDefaults{
+			NodeSelector: map[string]string{"pool": "ci"},
+			Tolerations:  []corev1.Toleration{dedicatedToleration},
+		},
+	}, {
+		name: "empty configmap",
+		cm:   newNamespaceDefaultsConfigMap(nil),
+		want: &NamespaceDefaults{},
+	}, {
+		name:    "invalid node selector",
+		cm:      newNamespaceDefaultsConfigMap(map[string]string{NamespaceDefaultsNodeSelectorKey: "- pool"}),
+		wantErr: true,
+	}, {
+		name:    "invalid tolerations",
+		cm:      newNamespaceDefaultsConfigMap(map[string]string{NamespaceDefaultsTolerationsKey: "key: dedicated"}),
+		wantErr: true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+			if tc.cm != nil {
+				if err := indexer.Add(tc.cm); err != nil {
+					t.Fatal(err)
+				}
+			}
+			got, err := GetNamespaceDefaults(corev1listers.NewConfigMapLister(indexer), "ci")
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("GetNamespaceDefaults() error = %v, wantErr %t", err, tc.wantErr)
+			}
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("GetNamespaceDefaults() %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestGetNamespaceDefaultsFollowsInformerCache(t *testing.T) {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	lister := corev1listers.NewConfigMapLister(indexer)
+	cm := newNamespaceDefaultsConfigMap(map[string]string{NamespaceDefaultsNodeSelectorKey: "pool: ci"})
+
+	if err := indexer.Add(cm); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := GetNamespaceDefaults(lister, "ci"); got == nil || got.NodeSelector["pool"] != "ci" {
+		t.Fatalf("GetNamespaceDefaults() = %v, want the node selector of the configmap", got)
+	}
+	if got, _ := GetNamespaceDefaults(lister, "other"); got != nil {
+		t.Errorf("GetNamespaceDefaults() = %v for another namespace, want nil", got)
+	}
+
+	updated := cm.DeepCopy()
+	updated.Data[NamespaceDefaultsNodeSelectorKey] = "pool: ci-large"
+	if err := indexer.Update(updated); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := GetNamespaceDefaults(lister, "ci"); got == nil || got.NodeSelector["pool"] != "ci-large" {
+		t.Errorf("GetNamespaceDefaults() = %v, want the node selector of the updated configmap", got)
+	}
+
+	if err := indexer.Delete(updated); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := GetNamespaceDefaults(lister, "ci"); got != nil {
+		t.Errorf("GetNamespaceDefaults() = %v after the configmap was deleted, want nil", got)
+	}
+}
+
+func TestNamespaceDefaultsMergePodTemplate(t *testing.T) {
+	defaults := &NamespaceDefaults{
+		NodeSelector: map[string]string{"pool": "ci", "disk": "ssd"},
+		Tolerations: []corev1.Toleration{dedicatedToleration, {
+			Key:      "spot",
+			Operator: corev1.TolerationOpExists,
+		}},
+	}
+	for _, tc := range []struct {
+		name     string
+		defaults *NamespaceDefaults
+		tpl      *pod.Template
+		want     *pod.Template
+	}{{
+		name:     "no defaults",
+		defaults: nil,
+		tpl:      &pod.Template{SchedulerName: "custom"},
+		want:     &pod.Template{SchedulerName: "custom"},
+	}, {
+		name:     "no pod template",
+		defaults: defaults,
+		want: &pod.Template{
+			NodeSelector: map[string]string{"pool": "ci", "disk": "ssd"},
+			Tolerations:  defaults.Tolerations,
+		},
+	}, {
+		name:     "pod template wins per key",
+		defaults: defaults,
+		tpl: &pod.Template{
+			NodeSelector: map[string]string{"pool": "gpu"},
+			Tolerations: []corev1.Toleration{{
+				Key:      "dedicated",
+				Operator: corev1.TolerationOpEqual,
+				Value:    "gpu",
+				Effect:   corev1.TaintEffectNoExecute,
+			}},
+			SchedulerName: "custom",
+		},
+		want: &pod.Template{
+			NodeSelector: map[string]string{"pool": "gpu", "disk": "ssd"},
+			Tolerations: []corev1.Toleration{{
+				Key:      "dedicated",
+				Operator: corev1.TolerationOpEqual,
+				Value:    "gpu",
+				Effect:   corev1.TaintEffectNoExecute,
+			}, {
+				Key:      "spot",
+				Operator: corev1.TolerationOpExists,
+			}},
+			SchedulerName: "custom",
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			var original *pod.Template
+			if tc.tpl != nil {
+				original = tc.tpl.DeepCopy()
+			}
+			got := tc.defaults.MergePodTemplate(tc.tpl)
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("MergePodTemplate() %s", diff.PrintWantGot(d))
+			}
+			if d := cmp.Diff(original, tc.tpl); d != "" {
+				t.Errorf("MergePodTemplate() modified the pod template %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
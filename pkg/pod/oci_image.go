@@ -0,0 +1,124 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/version"
+)
+
+const (
+	// ImageVolumeK8sMinorVersionCheck is the minor version of Kubernetes from which image volumes
+	// are enabled by default, and the OCI images bound to workspaces are mounted as image volumes.
+	ImageVolumeK8sMinorVersionCheck = 35
+
+	// ContainerNamePrefixOCIImageFetch is the prefix of the names of the init containers fetching
+	// the OCI images bound to workspaces on clusters without image volumes.
+	ContainerNamePrefixOCIImageFetch = "fetch-"
+
+	ociImageFetchDir      = "/tekton/oci-image"
+	ociImagePullSecretDir = "/tekton/oci-image-pull-secret"
+)
+
+// IsImageVolumeSupport returns true if k8s api has image volumes enabled by default
+// based on the k8s version (1.35+).
+// See https://kubernetes.io/docs/concepts/storage/volumes/#image for more info.
+func IsImageVolumeSupport(serverVersion *version.Info) bool {
+	minor := strings.TrimSuffix(serverVersion.Minor, "+") // Remove '+' if present
+	majorInt, _ := strconv.Atoi(serverVersion.Major)
+	minorInt, _ := strconv.Atoi(minor)
+	return (majorInt == 1 && minorInt >= ImageVolumeK8sMinorVersionCheck) || majorInt > 1
+}
+
+// ociImageSources returns the OCI images bound to the workspaces of the TaskRun.
+func ociImageSources(wb []v1.WorkspaceBinding) []v1.OCIImageWorkspaceSource {
+	var sources []v1.OCIImageWorkspaceSource
+	for _, w := range wb {
+		if w.OCIImage != nil {
+			sources = append(sources, *w.OCIImage)
+		}
+	}
+	return sources
+}
+
+// withOCIImagePullSecrets returns the image pull secrets of the pod along with the pull secrets of
+// the OCI images mounted as image volumes, which are pulled with the image pull secrets of the pod.
+func withOCIImagePullSecrets(pullSecrets []corev1.LocalObjectReference, sources []v1.OCIImageWorkspaceSource) []corev1.LocalObjectReference {
+	pullSecrets = slices.Clone(pullSecrets)
+	for _, s := range sources {
+		ref := corev1.LocalObjectReference{Name: s.PullSecret}
+		if s.PullSecret != "" && !slices.Contains(pullSecrets, ref) {
+			pullSecrets = append(pullSecrets, ref)
+		}
+	}
+	return pullSecrets
+}
+
+// fetchOCIImages replaces the image volumes of the OCI images bound to workspaces with emptyDir
+// volumes, populated by init containers running the ociImageFetchImage, for clusters without image
+// volumes. It returns the init containers along with the volumes of the pull secrets they use.
+func fetchOCIImages(ociImageFetchImage string, sources []v1.OCIImageWorkspaceSource, volumes []corev1.Volume, securityContext SecurityContextConfig, windows bool) ([]corev1.Container, []corev1.Volume) {
+	pullSecrets := make(map[string]string, len(sources))
+	for _, s := range sources {
+		if _, ok := pullSecrets[s.Reference]; !ok {
+			pullSecrets[s.Reference] = s.PullSecret
+		}
+	}
+
+	var initContainers []corev1.Container
+	var pullSecretVolumes []corev1.Volume
+	for i := range volumes {
+		v := &volumes[i]
+		if v.Image == nil {
+			continue
+		}
+		pullSecret, ok := pullSecrets[v.Image.Reference]
+		if !ok {
+			continue
+		}
+		c := corev1.Container{
+			Name:         ContainerNamePrefixOCIImageFetch + v.Name,
+			Image:        ociImageFetchImage,
+			Command:      []string{"/ko-app/ociimagefetch"},
+			Args:         []string{"-image", v.Image.Reference, "-dest", ociImageFetchDir},
+			VolumeMounts: []corev1.VolumeMount{{Name: v.Name, MountPath: ociImageFetchDir}},
+		}
+		if pullSecret != "" {
+			secretVolume := corev1.Volume{
+				Name: v.Name + "-pull-secret",
+				VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{
+					SecretName: pullSecret,
+					Items:      []corev1.KeyToPath{{Key: corev1.DockerConfigJsonKey, Path: "config.json"}},
+				}},
+			}
+			pullSecretVolumes = append(pullSecretVolumes, secretVolume)
+			c.VolumeMounts = append(c.VolumeMounts, corev1.VolumeMount{Name: secretVolume.Name, MountPath: ociImagePullSecretDir, ReadOnly: true})
+			c.Env = []corev1.EnvVar{{Name: "DOCKER_CONFIG", Value: ociImagePullSecretDir}}
+		}
+		if securityContext.SetSecurityContext {
+			c.SecurityContext = securityContext.GetSecurityContext(windows)
+		}
+		initContainers = append(initContainers, c)
+		v.VolumeSource = corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}
+	}
+	return initContainers, pullSecretVolumes
+}
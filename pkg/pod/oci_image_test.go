@@ -0,0 +1,158 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/pod"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/version"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	fakek8s "k8s.io/client-go/kubernetes/fake"
+	logtesting "knative.dev/pkg/logging/testing"
+	"knative.dev/pkg/system"
+)
+
+func TestIsImageVolumeSupport(t *testing.T) {
+	for _, tc := range []struct {
+		serverVersion *version.Info
+		want          bool
+	}{
+		{serverVersion: &version.Info{Major: "1", Minor: "34"}, want: false},
+		{serverVersion: &version.Info{Major: "1", Minor: "35"}, want: true},
+		{serverVersion: &version.Info{Major: "1", Minor: "36+"}, want: true},
+		{serverVersion: &version.Info{Major: "2", Minor: "0"}, want: true},
+	} {
+		if got := IsImageVolumeSupport(tc.serverVersion); got != tc.want {
+			t.Errorf("IsImageVolumeSupport(%s.%s) = %t, want %t", tc.serverVersion.Major, tc.serverVersion.Minor, got, tc.want)
+		}
+	}
+}
+
+func TestPodBuild_OCIImageWorkspace(t *testing.T) {
+	imageVolume := corev1.Volume{
+		Name:         "ws-config",
+		VolumeSource: corev1.VolumeSource{Image: &corev1.ImageVolumeSource{Reference: "registry.example.com/config:v1"}},
+	}
+	for _, tc := range []struct {
+		name                 string
+		minor                string
+		wantVolumes          []corev1.Volume
+		wantInitContainers   []corev1.Container
+		wantImagePullSecrets []corev1.LocalObjectReference
+	}{{
+		name:                 "mounted as an image volume",
+		minor:                "35",
+		wantVolumes:          []corev1.Volume{imageVolume},
+		wantImagePullSecrets: []corev1.LocalObjectReference{{Name: "pod-creds"}, {Name: "registry-creds"}},
+	}, {
+		name:  "fetched by an init container",
+		minor: "34",
+		wantVolumes: []corev1.Volume{{
+			Name:         "ws-config",
+			VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+		}, {
+			Name: "ws-config-pull-secret",
+			VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{
+				SecretName: "registry-creds",
+				Items:      []corev1.KeyToPath{{Key: corev1.DockerConfigJsonKey, Path: "config.json"}},
+			}},
+		}},
+		wantInitContainers: []corev1.Container{{
+			Name:    "fetch-ws-config",
+			Image:   "ociimagefetch-image",
+			Command: []string{"/ko-app/ociimagefetch"},
+			Args:    []string{"-image", "registry.example.com/config:v1", "-dest", "/tekton/oci-image"},
+			Env:     []corev1.EnvVar{{Name: "DOCKER_CONFIG", Value: "/tekton/oci-image-pull-secret"}},
+			VolumeMounts: []corev1.VolumeMount{
+				{Name: "ws-config", MountPath: "/tekton/oci-image"},
+				{Name: "ws-config-pull-secret", MountPath: "/tekton/oci-image-pull-secret", ReadOnly: true},
+			},
+		}},
+		wantImagePullSecrets: []corev1.LocalObjectReference{{Name: "pod-creds"}},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			store := config.NewStore(logtesting.TestLogger(t))
+			store.OnConfigChanged(&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: config.GetFeatureFlagsConfigName(), Namespace: system.Namespace()}})
+			store.OnConfigChanged(&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: config.GetDefaultsConfigName(), Namespace: system.Namespace()}})
+			kubeclient := fakek8s.NewSimpleClientset(
+				&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "default"}},
+			)
+			fakeDisc, _ := kubeclient.Discovery().(*fakediscovery.FakeDiscovery)
+			fakeDisc.FakedServerVersion = &version.Info{Major: "1", Minor: tc.minor}
+
+			tr := &v1.TaskRun{
+				ObjectMeta: metav1.ObjectMeta{Name: "taskrun-oci-image", Namespace: "default"},
+				Spec: v1.TaskRunSpec{
+					Workspaces: []v1.WorkspaceBinding{{
+						Name:     "config",
+						OCIImage: &v1.OCIImageWorkspaceSource{Reference: "registry.example.com/config:v1", PullSecret: "registry-creds"},
+					}},
+					PodTemplate: &pod.Template{ImagePullSecrets: []corev1.LocalObjectReference{{Name: "pod-creds"}}},
+				},
+			}
+			ts := v1.TaskSpec{
+				Steps: []v1.Step{{
+					Name:         "deploy",
+					Image:        "image",
+					Command:      []string{"cmd"},
+					VolumeMounts: []corev1.VolumeMount{{Name: "ws-config", MountPath: "/workspace/config", ReadOnly: true}},
+				}},
+				Volumes: []corev1.Volume{imageVolume},
+			}
+			ociImages := images
+			ociImages.OCIImageFetchImage = "ociimagefetch-image"
+			builder := Builder{
+				Images:          ociImages,
+				KubeClient:      kubeclient,
+				EntrypointCache: fakeCache{},
+			}
+			got, err := builder.Build(store.ToContext(t.Context()), tr, ts)
+			if err != nil {
+				t.Fatalf("builder.Build: %v", err)
+			}
+
+			var gotVolumes []corev1.Volume
+			for _, v := range got.Spec.Volumes {
+				if v.Name == "ws-config" || v.Name == "ws-config-pull-secret" {
+					gotVolumes = append(gotVolumes, v)
+				}
+			}
+			if d := cmp.Diff(tc.wantVolumes, gotVolumes); d != "" {
+				t.Errorf("Unexpected workspace volumes %s", diff.PrintWantGot(d))
+			}
+			var gotInitContainers []corev1.Container
+			for _, c := range got.Spec.InitContainers {
+				if c.Name == "fetch-ws-config" {
+					gotInitContainers = append(gotInitContainers, c)
+				}
+			}
+			if d := cmp.Diff(tc.wantInitContainers, gotInitContainers); d != "" {
+				t.Errorf("Unexpected fetching init containers %s", diff.PrintWantGot(d))
+			}
+			if d := cmp.Diff(tc.wantImagePullSecrets, got.Spec.ImagePullSecrets); d != "" {
+				t.Errorf("Unexpected image pull secrets %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
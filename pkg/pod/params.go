@@ -0,0 +1,141 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"fmt"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const (
+	paramsVolumeName = "tekton-internal-params"
+
+	// ParamAnnotationPrefix is the prefix of the annotations of the pod holding the values of the params
+	// declared with asFile, which are projected into the files of the params volume.
+	ParamAnnotationPrefix = "params.tekton.dev/"
+
+	// MaxParamFileSize is the maximum size in bytes of the value of a param declared with asFile, or of
+	// the value of each key of an object param. All of the values must also fit in the annotations of the pod.
+	MaxParamFileSize = 128 * 1024
+)
+
+// paramsMount lets the steps and sidecars read the files holding the values of the params declared with asFile.
+var paramsMount = corev1.VolumeMount{
+	Name:      paramsVolumeName,
+	MountPath: pipeline.ParamsDir,
+	ReadOnly:  true,
+}
+
+// paramFiles returns the annotations holding the values of the params of taskSpec declared with asFile,
+// as provided by taskRun or defaulted by taskSpec, and the downward API volume projecting each of them
+// into the file /tekton/params/<name>, or /tekton/params/<name>/<key> for the keys of object params.
+// The volume is nil if none of the params is declared with asFile.
+func paramFiles(taskRun *v1.TaskRun, taskSpec v1.TaskSpec) (map[string]string, *corev1.Volume, error) {
+	provided := map[string]v1.ParamValue{}
+	for _, p := range taskRun.Spec.Params {
+		provided[p.Name] = p.Value
+	}
+
+	annotations := map[string]string{}
+	var items []corev1.DownwardAPIVolumeFile
+	addFile := func(path, value string) error {
+		if err := validateParamFileContent(path, value); err != nil {
+			return err
+		}
+		key := ParamAnnotationPrefix + strings.ReplaceAll(path, "/", ".")
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return fmt.Errorf("param %q can't be delivered as a file: %s", path, strings.Join(errs, ", "))
+		}
+		annotations[key] = value
+		items = append(items, corev1.DownwardAPIVolumeFile{
+			Path:     path,
+			FieldRef: &corev1.ObjectFieldSelector{FieldPath: fmt.Sprintf("metadata.annotations['%s']", key)},
+		})
+		return nil
+	}
+
+	for _, p := range taskSpec.Params {
+		if !p.AsFile {
+			continue
+		}
+		value, ok := provided[p.Name]
+		if !ok {
+			if p.Default == nil {
+				return nil, nil, fmt.Errorf("no value provided for param %q declared with asFile", p.Name)
+			}
+			value = *p.Default
+		}
+		switch p.Type {
+		case v1.ParamTypeObject:
+			// The keys provided by the TaskRun are merged with the default ones, as in the substitutions
+			object := map[string]string{}
+			if p.Default != nil {
+				maps.Copy(object, p.Default.ObjectVal)
+			}
+			maps.Copy(object, value.ObjectVal)
+			for _, k := range slices.Sorted(maps.Keys(object)) {
+				if k == "" || k == "." || k == ".." || strings.Contains(k, "/") {
+					return nil, nil, fmt.Errorf("key %q of param %q can't be delivered as a file", k, p.Name)
+				}
+				if err := addFile(filepath.Join(p.Name, k), object[k]); err != nil {
+					return nil, nil, err
+				}
+			}
+		case v1.ParamTypeArray:
+			return nil, nil, fmt.Errorf("array param %q can't be delivered as a file", p.Name)
+		default:
+			if err := addFile(p.Name, value.StringVal); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+	if len(items) == 0 {
+		return nil, nil, nil
+	}
+
+	return annotations, &corev1.Volume{
+		Name: paramsVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			DownwardAPI: &corev1.DownwardAPIVolumeSource{Items: items},
+		},
+	}, nil
+}
+
+// validateParamFileContent returns an error if value is too large to be delivered as the file path of
+// the params volume, or if it can't be stored in an annotation without being altered, i.e. if it
+// isn't valid UTF-8 or contains NUL bytes.
+func validateParamFileContent(path, value string) error {
+	if len(value) > MaxParamFileSize {
+		return fmt.Errorf("value of param %q is %d bytes, larger than the %d bytes limit of params delivered as files", path, len(value), MaxParamFileSize)
+	}
+	if !utf8.ValidString(value) {
+		return fmt.Errorf("value of param %q is not valid UTF-8 and can't be delivered as a file", path)
+	}
+	if strings.ContainsRune(value, 0) {
+		return fmt.Errorf("value of param %q contains NUL bytes and can't be delivered as a file", path)
+	}
+	return nil
+}
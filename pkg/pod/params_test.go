@@ -0,0 +1,245 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/test/diff"
+	"github.com/tektoncd/pipeline/test/names"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakek8s "k8s.io/client-go/kubernetes/fake"
+	logtesting "knative.dev/pkg/logging/testing"
+	"knative.dev/pkg/system"
+)
+
+func buildParamsTaskPod(t *testing.T, params v1.Params, ts v1.TaskSpec) (*corev1.Pod, error) {
+	t.Helper()
+	names.TestingSeed()
+	store := config.NewStore(logtesting.TestLogger(t))
+	store.OnConfigChanged(&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: config.GetFeatureFlagsConfigName(), Namespace: system.Namespace()}})
+	store.OnConfigChanged(&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: config.GetDefaultsConfigName(), Namespace: system.Namespace()}})
+	builder := Builder{
+		Images: images,
+		KubeClient: fakek8s.NewSimpleClientset(
+			&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "default"}},
+		),
+		EntrypointCache: fakeCache{},
+	}
+	tr := &v1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "taskrun-params",
+			Namespace:   "default",
+			Annotations: map[string]string{ReleaseAnnotation: fakeVersion},
+		},
+		Spec: v1.TaskRunSpec{Params: params},
+	}
+	return builder.Build(store.ToContext(t.Context()), tr, ts)
+}
+
+func TestPodBuild_ParamsAsFile(t *testing.T) {
+	manifest := strings.Repeat("kind: ConfigMap\n", 100*1024/len("kind: ConfigMap\n")+1)[:100*1024]
+	ts := v1.TaskSpec{
+		Params: v1.ParamSpecs{{
+			Name:   "manifest",
+			Type:   v1.ParamTypeString,
+			AsFile: true,
+		}, {
+			Name:   "config",
+			Type:   v1.ParamTypeObject,
+			AsFile: true,
+			Properties: map[string]v1.PropertySpec{
+				"url":    {Type: v1.ParamTypeString},
+				"script": {Type: v1.ParamTypeString},
+			},
+			Default: v1.NewObject(map[string]string{
+				"url":    "https://example.com",
+				"script": "#!/bin/sh\necho default\n",
+			}),
+		}, {
+			Name:    "inline",
+			Type:    v1.ParamTypeString,
+			Default: v1.NewStructuredValues("not a file"),
+		}},
+		Steps: []v1.Step{{
+			Name:    "apply",
+			Image:   "image",
+			Command: []string{"kubectl", "apply", "-f", "/tekton/params/manifest"},
+		}},
+		Sidecars: []v1.Sidecar{{
+			Name:    "server",
+			Image:   "image",
+			Command: []string{"serve", "--config-dir", "/tekton/params/config"},
+		}},
+	}
+	pod, err := buildParamsTaskPod(t, v1.Params{{
+		Name:  "manifest",
+		Value: *v1.NewStructuredValues(manifest),
+	}, {
+		Name:  "config",
+		Value: *v1.NewObject(map[string]string{"script": "#!/bin/sh\necho 'provided'\n"}),
+	}}, ts)
+	if err != nil {
+		t.Fatalf("builder.Build: %v", err)
+	}
+
+	wantAnnotations := map[string]string{
+		"params.tekton.dev/manifest":      manifest,
+		"params.tekton.dev/config.script": "#!/bin/sh\necho 'provided'\n",
+		"params.tekton.dev/config.url":    "https://example.com",
+	}
+	for k, want := range wantAnnotations {
+		if got := pod.Annotations[k]; got != want {
+			t.Errorf("annotation %s: got %d bytes, want %d bytes", k, len(got), len(want))
+		}
+	}
+	if _, ok := pod.Annotations["params.tekton.dev/inline"]; ok {
+		t.Errorf("param inline not declared with asFile got annotation")
+	}
+
+	var paramsVolume *corev1.Volume
+	for i, v := range pod.Spec.Volumes {
+		if v.Name == paramsVolumeName {
+			paramsVolume = &pod.Spec.Volumes[i]
+		}
+	}
+	if paramsVolume == nil {
+		t.Fatalf("pod has no %s volume: %v", paramsVolumeName, pod.Spec.Volumes)
+	}
+	wantItems := []corev1.DownwardAPIVolumeFile{{
+		Path:     "manifest",
+		FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.annotations['params.tekton.dev/manifest']"},
+	}, {
+		Path:     "config/script",
+		FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.annotations['params.tekton.dev/config.script']"},
+	}, {
+		Path:     "config/url",
+		FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.annotations['params.tekton.dev/config.url']"},
+	}}
+	if d := cmp.Diff(wantItems, paramsVolume.DownwardAPI.Items); d != "" {
+		t.Errorf("params volume items %s", diff.PrintWantGot(d))
+	}
+
+	// Both the step and the sidecar read the params from the volume
+	for _, c := range pod.Spec.Containers {
+		mounted := false
+		for _, vm := range c.VolumeMounts {
+			if vm.Name == paramsVolumeName {
+				mounted = true
+				if d := cmp.Diff(paramsMount, vm); d != "" {
+					t.Errorf("params volume mount of container %s %s", c.Name, diff.PrintWantGot(d))
+				}
+			}
+		}
+		if !mounted {
+			t.Errorf("container %s doesn't mount the params volume: %v", c.Name, c.VolumeMounts)
+		}
+		for _, arg := range append(c.Command, c.Args...) {
+			if strings.Contains(arg, "kind: ConfigMap") {
+				t.Errorf("content of param manifest spliced in the args of container %s", c.Name)
+			}
+		}
+	}
+	if len(pod.Spec.Containers) != 2 {
+		t.Errorf("got containers %v, want the step and the sidecar", pod.Spec.Containers)
+	}
+}
+
+func TestPodBuild_NoParamsAsFile(t *testing.T) {
+	pod, err := buildParamsTaskPod(t, v1.Params{{
+		Name:  "inline",
+		Value: *v1.NewStructuredValues("value"),
+	}}, v1.TaskSpec{
+		Params: v1.ParamSpecs{{Name: "inline", Type: v1.ParamTypeString}},
+		Steps: []v1.Step{{
+			Name:    "echo",
+			Image:   "image",
+			Command: []string{"echo", "value"},
+		}},
+	})
+	if err != nil {
+		t.Fatalf("builder.Build: %v", err)
+	}
+	for _, v := range pod.Spec.Volumes {
+		if v.Name == paramsVolumeName {
+			t.Errorf("pod got %s volume without params declared with asFile", paramsVolumeName)
+		}
+	}
+}
+
+func TestPodBuild_ParamsAsFileInvalid(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		params  v1.Params
+		spec    v1.ParamSpec
+		wantErr string
+	}{{
+		name:    "value larger than the limit",
+		params:  v1.Params{{Name: "file", Value: *v1.NewStructuredValues(strings.Repeat("a", MaxParamFileSize+1))}},
+		spec:    v1.ParamSpec{Name: "file", Type: v1.ParamTypeString, AsFile: true},
+		wantErr: "larger than the 131072 bytes limit",
+	}, {
+		name: "values larger than the annotations",
+		params: v1.Params{{Name: "file", Value: *v1.NewObject(map[string]string{
+			"a": strings.Repeat("a", MaxParamFileSize),
+			"b": strings.Repeat("b", MaxParamFileSize),
+		})}},
+		spec: v1.ParamSpec{Name: "file", Type: v1.ParamTypeObject, AsFile: true, Properties: map[string]v1.PropertySpec{
+			"a": {Type: v1.ParamTypeString},
+			"b": {Type: v1.ParamTypeString},
+		}},
+		wantErr: "don't fit in the annotations of the pod",
+	}, {
+		name:    "invalid UTF-8",
+		params:  v1.Params{{Name: "file", Value: *v1.NewStructuredValues("\xff\xfe")}},
+		spec:    v1.ParamSpec{Name: "file", Type: v1.ParamTypeString, AsFile: true},
+		wantErr: "is not valid UTF-8",
+	}, {
+		name:    "NUL bytes",
+		params:  v1.Params{{Name: "file", Value: *v1.NewStructuredValues("binary\x00content")}},
+		spec:    v1.ParamSpec{Name: "file", Type: v1.ParamTypeString, AsFile: true},
+		wantErr: "contains NUL bytes",
+	}, {
+		name:    "object key with a slash",
+		params:  v1.Params{{Name: "file", Value: *v1.NewObject(map[string]string{"../etc": "value"})}},
+		spec:    v1.ParamSpec{Name: "file", Type: v1.ParamTypeObject, AsFile: true, Properties: map[string]v1.PropertySpec{"../etc": {Type: v1.ParamTypeString}}},
+		wantErr: `key "../etc" of param "file" can't be delivered as a file`,
+	}, {
+		name:    "no value",
+		spec:    v1.ParamSpec{Name: "file", Type: v1.ParamTypeString, AsFile: true},
+		wantErr: `no value provided for param "file"`,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := buildParamsTaskPod(t, tc.params, v1.TaskSpec{
+				Params: v1.ParamSpecs{tc.spec},
+				Steps: []v1.Step{{
+					Name:    "cat",
+					Image:   "image",
+					Command: []string{"cat"},
+				}},
+			})
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("builder.Build: got error %v, want error containing %q", err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"log"
 	"math"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -33,15 +34,15 @@ import (
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/pod"
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"github.com/tektoncd/pipeline/pkg/internal/computeresources/tasklevel"
-	"github.com/tektoncd/pipeline/pkg/names"
 	tknreconciler "github.com/tektoncd/pipeline/pkg/reconciler"
 	"github.com/tektoncd/pipeline/pkg/spire"
+	"github.com/tektoncd/pipeline/pkg/tracing"
 	corev1 "k8s.io/api/core/v1"
+	apivalidation "k8s.io/apimachinery/pkg/api/validation"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/version"
 	"k8s.io/client-go/kubernetes"
-	"k8s.io/utils/strings/slices"
 	"knative.dev/pkg/changeset"
 	"knative.dev/pkg/kmap"
 	"knative.dev/pkg/kmeta"
@@ -51,6 +52,11 @@ const (
 	// TektonHermeticEnvVar is the env var we set in containers to indicate they should be run hermetically
 	TektonHermeticEnvVar = "TEKTON_HERMETIC"
 
+	// TektonPodIPEnvVar is the env var we set in steps to the IP of their pod, e.g. for them to register
+	// callbacks with external systems. It is set through the downward API, on a best-effort basis: the IP
+	// may not be routable yet when the step starts, before the networking of the pod is ready.
+	TektonPodIPEnvVar = "TEKTON_POD_IP"
+
 	// ExecutionModeAnnotation is an experimental optional annotation to set the execution mode on a TaskRun
 	ExecutionModeAnnotation = "experimental.tekton.dev/execution-mode"
 
@@ -79,6 +85,14 @@ const (
 	// TerminationReasonCancelled indicates a step was cancelled.
 	TerminationReasonCancelled = "Cancelled"
 
+	// TerminationReasonRunContainerError indicates the container runtime couldn't start a step,
+	// e.g. because its command doesn't exist.
+	TerminationReasonRunContainerError = "RunContainerError"
+
+	// TerminationReasonScriptTampered indicates the script of a step was modified after it was placed,
+	// e.g. by an earlier step, and the step wasn't run.
+	TerminationReasonScriptTampered = "ScriptTampered"
+
 	StepArtifactPathPattern = "step.artifacts.path"
 
 	// K8s version to determine if to use native k8s sidecar or Tekton sidecar
@@ -108,10 +122,10 @@ var (
 		Name:      "tekton-internal-home",
 		MountPath: pipeline.HomeDir,
 	}, {
-		Name:      "tekton-internal-results",
+		Name:      resultsVolumeName,
 		MountPath: pipeline.DefaultResultPath,
 	}, {
-		Name:      "tekton-internal-steps",
+		Name:      stepsVolumeName,
 		MountPath: pipeline.StepsDir,
 		ReadOnly:  true,
 	}, {
@@ -125,10 +139,10 @@ var (
 		Name:         "tekton-internal-home",
 		VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
 	}, {
-		Name:         "tekton-internal-results",
+		Name:         resultsVolumeName,
 		VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
 	}, {
-		Name:         "tekton-internal-steps",
+		Name:         stepsVolumeName,
 		VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
 	}, {
 		Name:         "tekton-internal-artifacts",
@@ -170,15 +184,27 @@ func (b *Builder) Build(ctx context.Context, taskRun *v1.TaskRun, taskSpec v1.Ta
 		volumes                                           []corev1.Volume
 	)
 	volumeMounts := []corev1.VolumeMount{binROMount}
-	implicitEnvVars := []corev1.EnvVar{}
+	implicitEnvVars := []corev1.EnvVar{{
+		Name:      TektonPodIPEnvVar,
+		ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "status.podIP"}},
+	}}
 	featureFlags := config.FromContextOrDefaults(ctx).FeatureFlags
 	defaultForbiddenEnv := config.FromContextOrDefaults(ctx).Defaults.DefaultForbiddenEnv
 	alphaAPIEnabled := featureFlags.EnableAPIFields == config.AlphaAPIFields
-	sidecarLogsResultsEnabled := config.FromContextOrDefaults(ctx).FeatureFlags.ResultExtractionMethod == config.ResultExtractionMethodSidecarLogs
+	resultsSidecarEnabled := featureFlags.UsesResultsSidecar()
 	enableKeepPodOnCancel := featureFlags.EnableKeepPodOnCancel
 	setSecurityContext := config.FromContextOrDefaults(ctx).FeatureFlags.SetSecurityContext
 	setSecurityContextReadOnlyRootFilesystem := config.FromContextOrDefaults(ctx).FeatureFlags.SetSecurityContextReadOnlyRootFilesystem
 	defaultManagedByLabelValue := config.FromContextOrDefaults(ctx).Defaults.DefaultManagedByLabelValue
+	images, err := HelperImages(ctx, b.Images, taskRun.Annotations)
+	if err != nil {
+		return nil, err
+	}
+
+	// Propagate the trace context of the TaskRun to its steps, so that they can attach their own spans.
+	if tracingCfg := config.FromContextOrDefaults(ctx).Tracing; tracingCfg != nil && tracingCfg.ExportRunSpans {
+		implicitEnvVars = append(implicitEnvVars, tracing.TraceContextEnvVars(taskRun.Status.SpanContext)...)
+	}
 
 	// Add our implicit volumes first, so they can be overridden by the user if they prefer.
 	volumes = append(volumes, implicitVolumes...)
@@ -200,6 +226,24 @@ func (b *Builder) Build(ctx context.Context, taskRun *v1.TaskRun, taskSpec v1.Ta
 	volumes = append(volumes, credVolumes...)
 	volumeMounts = append(volumeMounts, credVolumeMounts...)
 
+	// Sidecars write their results to their own directory of a shared volume,
+	// that the steps and the results sidecar can read.
+	declaredSidecarResults := sidecarResultNames(taskSpec.Sidecars)
+	if len(declaredSidecarResults) > 0 {
+		volumes = append(volumes, sidecarResultsVolume)
+		volumeMounts = append(volumeMounts, sidecarResultsROMount)
+	}
+
+	// The values of the params declared with asFile are projected into files from annotations of the pod.
+	paramAnnotations, paramsVolume, err := paramFiles(taskRun, taskSpec)
+	if err != nil {
+		return nil, err
+	}
+	if paramsVolume != nil {
+		volumes = append(volumes, *paramsVolume)
+		volumeMounts = append(volumeMounts, paramsMount)
+	}
+
 	// Merge step template with steps.
 	// TODO(#1605): Move MergeSteps to pkg/pod
 	steps, err := v1.MergeStepsWithStepTemplate(taskSpec.StepTemplate, taskSpec.Steps)
@@ -221,23 +265,30 @@ func (b *Builder) Build(ctx context.Context, taskRun *v1.TaskRun, taskSpec v1.Ta
 
 	windows := usesWindows(taskRun)
 	pollingInterval := config.FromContextOrDefaults(ctx).Defaults.DefaultSidecarLogPollingInterval
-	if sidecarLogsResultsEnabled {
-		if taskSpec.Results != nil || artifactsPathReferenced(steps) {
+	resultsSidecarNeeded := taskSpec.Results != nil || artifactsPathReferenced(steps) || len(declaredSidecarResults) > 0
+	if resultsSidecarEnabled {
+		if resultsSidecarNeeded {
 			// create a results sidecar
-			resultsSidecar, err := createResultsSidecar(taskSpec, b.Images.SidecarLogResultsImage, securityContextConfig, windows, pollingInterval)
+			resultsSidecar, err := createResultsSidecar(taskSpec, images.SidecarLogResultsImage, securityContextConfig, windows, pollingInterval, featureFlags.ResultExtractionMethod)
 			if err != nil {
 				return nil, err
 			}
 			taskSpec.Sidecars = append(taskSpec.Sidecars, resultsSidecar)
-			commonExtraEntrypointArgs = append(commonExtraEntrypointArgs, "-result_from", config.ResultExtractionMethodSidecarLogs)
+			commonExtraEntrypointArgs = append(commonExtraEntrypointArgs, "-result_from", featureFlags.ResultExtractionMethod)
 		}
 	}
 
-	if featureFlags.EnableTerminationMessageCompression && !sidecarLogsResultsEnabled {
+	if featureFlags.EnableStepFailFast {
+		commonExtraEntrypointArgs = append(commonExtraEntrypointArgs, "-fail_fast_file", failFastFile)
+		volumes = append(volumes, failFastVolume)
+		volumeMounts = append(volumeMounts, failFastMount)
+	}
+
+	if featureFlags.EnableTerminationMessageCompression && !resultsSidecarEnabled {
 		commonExtraEntrypointArgs = append(commonExtraEntrypointArgs, "-compress_termination_message=true")
 	}
-	if featureFlags.EnableTerminationMessageCompression && sidecarLogsResultsEnabled {
-		log.Printf("warning: enable-termination-message-compression has no effect when results-from is set to sidecar-logs")
+	if featureFlags.EnableTerminationMessageCompression && resultsSidecarEnabled {
+		log.Printf("warning: enable-termination-message-compression has no effect when results-from is set to %s", featureFlags.ResultExtractionMethod)
 	}
 
 	sidecars, err := v1.MergeSidecarsWithSpecs(taskSpec.Sidecars, taskRun.Spec.SidecarSpecs)
@@ -246,7 +297,13 @@ func (b *Builder) Build(ctx context.Context, taskRun *v1.TaskRun, taskSpec v1.Ta
 	}
 
 	initContainers = []corev1.Container{
-		entrypointInitContainer(b.Images.EntrypointImage, steps, securityContextConfig, windows),
+		entrypointInitContainer(images.EntrypointImage, steps, securityContextConfig, windows),
+	}
+
+	if featureFlags.DisableInlineScripts {
+		if err := checkInlineScripts(steps, sidecars); err != nil {
+			return nil, err
+		}
 	}
 
 	// Convert any steps with Script to command+args.
@@ -257,6 +314,23 @@ func (b *Builder) Build(ctx context.Context, taskRun *v1.TaskRun, taskSpec v1.Ta
 		scriptsInit, stepContainers, sidecarContainers = convertScripts(b.Images.ShellImage, "", steps, sidecars, nil, securityContextConfig)
 	}
 
+	// The capabilities of the steps are translated into the security context of their containers.
+	for i, s := range steps {
+		if s.Capabilities != nil {
+			stepContainers[i].SecurityContext = withStepCapabilities(stepContainers[i].SecurityContext, s.Capabilities)
+		}
+	}
+
+	for i, sc := range sidecarContainers {
+		if _, ok := declaredSidecarResults[sc.Name]; ok {
+			sidecarContainers[i].VolumeMounts = append(sc.VolumeMounts, sidecarResultsMount(sc.Name)) //nolint:gocritic
+		}
+		// The sidecars read the values of the params declared with asFile from the same files as the steps.
+		if paramsVolume != nil && sc.Name != pipeline.ReservedResultsSidecarName {
+			sidecarContainers[i].VolumeMounts = append(sidecarContainers[i].VolumeMounts, paramsMount)
+		}
+	}
+
 	if scriptsInit != nil {
 		initContainers = append(initContainers, *scriptsInit)
 		volumes = append(volumes, scriptsVolume)
@@ -265,7 +339,7 @@ func (b *Builder) Build(ctx context.Context, taskRun *v1.TaskRun, taskSpec v1.Ta
 		volumes = append(volumes, debugScriptsVolume, debugInfoVolume)
 	}
 	// Initialize any workingDirs under /workspace.
-	if workingDirInit := workingDirInit(b.Images.WorkingDirInitImage, stepContainers, securityContextConfig, windows); workingDirInit != nil {
+	if workingDirInit := workingDirInit(images.WorkingDirInitImage, stepContainers, securityContextConfig, windows); workingDirInit != nil {
 		initContainers = append(initContainers, *workingDirInit)
 	}
 
@@ -276,6 +350,16 @@ func (b *Builder) Build(ctx context.Context, taskRun *v1.TaskRun, taskSpec v1.Ta
 		podTemplate = *taskRun.Spec.PodTemplate
 	}
 
+	// Pin the images of the steps and sidecars to their digests, before resolving the entrypoints which
+	// then look up the images by digest.
+	var pinned []v1.PinnedImage
+	if taskRun.Annotations[PinImageDigestsAnnotation] == "true" {
+		pinned, err = pinImageDigests(ctx, b.EntrypointCache, taskRun.Namespace, taskRun.Spec.ServiceAccountName, podTemplate.ImagePullSecrets, stepContainers, sidecarContainers)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Resolve entrypoint for any steps that don't specify command.
 	stepContainers, err = resolveEntrypoints(ctx, b.EntrypointCache, taskRun.Namespace, taskRun.Spec.ServiceAccountName, podTemplate.ImagePullSecrets, stepContainers)
 	if err != nil {
@@ -308,8 +392,7 @@ func (b *Builder) Build(ctx context.Context, taskRun *v1.TaskRun, taskSpec v1.Ta
 	// Superceded by podTemplate envs
 	if len(implicitEnvVars) > 0 {
 		for i, s := range stepContainers {
-			env := append(implicitEnvVars, s.Env...) //nolint:gocritic
-			stepContainers[i].Env = env
+			stepContainers[i].Env = slices.Concat(implicitEnvVars, s.Env)
 		}
 	}
 	filteredEnvs := []corev1.EnvVar{}
@@ -354,6 +437,13 @@ func (b *Builder) Build(ctx context.Context, taskRun *v1.TaskRun, taskSpec v1.Ta
 		for j := range stepContainers {
 			s.VolumeMounts = append(s.VolumeMounts, runMount(j, i != j))
 		}
+		// With isolated step directories, the /tekton/steps/<step> directory of each step is
+		// mounted from its run volume instead of the shared /tekton/steps tree.
+		if featureFlags.EnableStepDirectoryIsolation {
+			for j := range stepContainers {
+				s.VolumeMounts = append(s.VolumeMounts, stepDirMount(j, pipeline.StepContainerName(stepContainers[j].Name), i != j))
+			}
+		}
 
 		requestedVolumeMounts := map[string]bool{}
 		for _, vm := range s.VolumeMounts {
@@ -361,6 +451,9 @@ func (b *Builder) Build(ctx context.Context, taskRun *v1.TaskRun, taskSpec v1.Ta
 		}
 		var toAdd []corev1.VolumeMount
 		for _, imp := range volumeMounts {
+			if featureFlags.EnableStepDirectoryIsolation && imp.Name == internalStepsMount.Name {
+				continue
+			}
 			if !requestedVolumeMounts[filepath.Clean(imp.MountPath)] {
 				toAdd = append(toAdd, imp)
 			}
@@ -369,10 +462,10 @@ func (b *Builder) Build(ctx context.Context, taskRun *v1.TaskRun, taskSpec v1.Ta
 		stepContainers[i].VolumeMounts = vms
 	}
 
-	if sidecarLogsResultsEnabled {
+	if resultsSidecarEnabled {
 		// Mount implicit volumes onto sidecarContainers
 		// so that they can access /tekton/results and /tekton/run.
-		if taskSpec.Results != nil || artifactsPathReferenced(steps) {
+		if resultsSidecarNeeded {
 			for i, s := range sidecarContainers {
 				if s.Name != pipeline.ReservedResultsSidecarName {
 					continue
@@ -397,21 +490,44 @@ func (b *Builder) Build(ctx context.Context, taskRun *v1.TaskRun, taskSpec v1.Ta
 	}
 
 	// This loop:
-	// - sets container name to add "step-" prefix or "step-unnamed-#" if not specified.
+	// - sets container name to add "step-" prefix to the step name, which is defaulted if not specified.
 	// TODO(#1605): Remove this loop and make each transformation in
 	// isolation.
 	for i, s := range stepContainers {
-		stepContainers[i].Name = names.SimpleNameGenerator.RestrictLength(StepName(s.Name, i))
+		stepContainers[i].Name = pipeline.StepContainerName(s.Name)
 	}
 
 	// Add podTemplate Volumes to the explicitly declared use volumes
 	volumes = append(volumes, taskSpec.Volumes...)
 	volumes = append(volumes, podTemplate.Volumes...)
 
+	// Mask the token of the ServiceAccount in the steps opting out of it.
+	volumes = append(volumes, maskServiceAccountTokens(podTemplate, steps, stepContainers)...)
+
+	// Make the root filesystem of the steps and sidecars read-only, with a writable /tmp.
+	if featureFlags.EnforceReadOnlyRootFilesystem && !windows {
+		volumes = append(volumes, enforceReadOnlyRootFilesystem(stepContainers, sidecarContainers)...)
+	}
+
 	if err := v1.ValidateVolumes(volumes); err != nil {
 		return nil, err
 	}
 
+	imagePullSecrets := podTemplate.ImagePullSecrets
+	if ociImages := ociImageSources(taskRun.Spec.Workspaces); len(ociImages) > 0 {
+		sv, err := b.KubeClient.Discovery().ServerVersion()
+		if err != nil {
+			return nil, err
+		}
+		if IsImageVolumeSupport(sv) {
+			imagePullSecrets = withOCIImagePullSecrets(imagePullSecrets, ociImages)
+		} else {
+			fetchInits, pullSecretVolumes := fetchOCIImages(images.OCIImageFetchImage, ociImages, volumes, securityContextConfig, windows)
+			initContainers = append(initContainers, fetchInits...)
+			volumes = append(volumes, pullSecretVolumes...)
+		}
+	}
+
 	readonly := true
 	if config.IsSpireEnabled(ctx) {
 		// add SPIRE's CSI volume to the explicitly declared use volumes
@@ -480,7 +596,7 @@ func (b *Builder) Build(ctx context.Context, taskRun *v1.TaskRun, taskSpec v1.Ta
 					}
 				}
 
-				sc.Name = names.SimpleNameGenerator.RestrictLength(fmt.Sprintf("%v%v", sidecarPrefix, sc.Name))
+				sc.Name = pipeline.SidecarContainerName(sc.Name)
 				mergedPodInitContainers = append(mergedPodInitContainers, *sc)
 			}
 		}
@@ -488,7 +604,7 @@ func (b *Builder) Build(ctx context.Context, taskRun *v1.TaskRun, taskSpec v1.Ta
 	if useTektonSidecar {
 		// Merge sidecar containers with step containers.
 		for _, sc := range sidecarContainers {
-			sc.Name = names.SimpleNameGenerator.RestrictLength(fmt.Sprintf("%v%v", sidecarPrefix, sc.Name))
+			sc.Name = pipeline.SidecarContainerName(sc.Name)
 			mergedPodContainers = append(mergedPodContainers, sc)
 		}
 	}
@@ -509,6 +625,21 @@ func (b *Builder) Build(ctx context.Context, taskRun *v1.TaskRun, taskSpec v1.Ta
 	if readyImmediately {
 		podAnnotations[readyAnnotation] = readyAnnotationValue
 	}
+	if len(pinned) > 0 {
+		pinnedJSON, err := json.Marshal(pinned)
+		if err != nil {
+			return nil, err
+		}
+		podAnnotations[PinnedImagesAnnotation] = string(pinnedJSON)
+	}
+	if paramsVolume != nil {
+		for k, v := range paramAnnotations {
+			podAnnotations[k] = v
+		}
+		if err := apivalidation.ValidateAnnotationsSize(podAnnotations); err != nil {
+			return nil, fmt.Errorf("params declared with asFile don't fit in the annotations of the pod: %w", err)
+		}
+	}
 
 	// calculate the activeDeadlineSeconds based on the specified timeout (uses default timeout if it's not specified)
 	activeDeadlineSeconds := int64(taskRun.GetTimeout(ctx).Seconds() * deadlineFactor)
@@ -556,7 +687,7 @@ func (b *Builder) Build(ctx context.Context, taskRun *v1.TaskRun, taskSpec v1.Ta
 			DNSConfig:                    podTemplate.DNSConfig,
 			EnableServiceLinks:           podTemplate.EnableServiceLinks,
 			PriorityClassName:            priorityClassName,
-			ImagePullSecrets:             podTemplate.ImagePullSecrets,
+			ImagePullSecrets:             imagePullSecrets,
 			HostAliases:                  podTemplate.HostAliases,
 			TopologySpreadConstraints:    podTemplate.TopologySpreadConstraints,
 			ActiveDeadlineSeconds:        &activeDeadlineSeconds, // Set ActiveDeadlineSeconds to mark the pod as "terminating" (like a Job)
@@ -616,6 +747,17 @@ func runMount(i int, ro bool) corev1.VolumeMount {
 	}
 }
 
+// stepDirMount mounts the status directory of the run volume of the i-th step, which the
+// /tekton/steps/<stepDir> symlink of the step otherwise points to, at that path.
+func stepDirMount(i int, stepDir string, ro bool) corev1.VolumeMount {
+	return corev1.VolumeMount{
+		Name:      fmt.Sprintf("%s-%d", runVolumeName, i),
+		MountPath: filepath.Join(pipeline.StepsDir, stepDir),
+		SubPath:   "status",
+		ReadOnly:  ro,
+	}
+}
+
 func runVolume(i int) corev1.Volume {
 	return corev1.Volume{
 		Name:         fmt.Sprintf("%s-%d", runVolumeName, i),
@@ -631,8 +773,8 @@ func entrypointInitContainer(image string, steps []v1.Step, securityContext Secu
 	// Invoke the entrypoint binary in "cp mode" to copy itself
 	// into the correct location for later steps and initialize steps folder
 	command := []string{"/ko-app/entrypoint", "init", "/ko-app/entrypoint", entrypointBinary}
-	for i, s := range steps {
-		command = append(command, StepName(s.Name, i))
+	for _, s := range steps {
+		command = append(command, pipeline.StepContainerName(s.Name))
 	}
 	volumeMounts := []corev1.VolumeMount{binMount, internalStepsMount}
 
@@ -659,8 +801,8 @@ func entrypointInitContainer(image string, steps []v1.Step, securityContext Secu
 // based on the spec of the Task, the image that should run in the results sidecar,
 // whether it will run on a windows node, and whether the sidecar should include a security context
 // that will allow it to run in namespaces with "restricted" pod security admission.
-// It will also provide arguments to the binary that allow it to surface the step results.
-func createResultsSidecar(taskSpec v1.TaskSpec, image string, securityContext SecurityContextConfig, windows bool, pollingInterval time.Duration) (v1.Sidecar, error) {
+// It will also provide arguments to the binary that allow it to surface the step and sidecar results.
+func createResultsSidecar(taskSpec v1.TaskSpec, image string, securityContext SecurityContextConfig, windows bool, pollingInterval time.Duration, resultExtractionMethod string) (v1.Sidecar, error) {
 	names := make([]string, 0, len(taskSpec.Results))
 	for _, r := range taskSpec.Results {
 		names = append(names, r.Name)
@@ -668,22 +810,30 @@ func createResultsSidecar(taskSpec v1.TaskSpec, image string, securityContext Se
 
 	stepNames := make([]string, 0, len(taskSpec.Steps))
 	var artifactProducerSteps []string
-	for i, s := range taskSpec.Steps {
-		stepName := StepName(s.Name, i)
+	for _, s := range taskSpec.Steps {
+		stepName := pipeline.StepContainerName(s.Name)
 		stepNames = append(stepNames, stepName)
 		if artifactPathReferencedInStep(s) {
-			artifactProducerSteps = append(artifactProducerSteps, GetContainerName(s.Name))
+			artifactProducerSteps = append(artifactProducerSteps, pipeline.StepContainerName(s.Name))
 		}
 	}
 
 	resultsStr := strings.Join(names, ",")
 	command := []string{"/ko-app/sidecarlogresults", "-results-dir", pipeline.DefaultResultPath, "-result-names", resultsStr, "-step-names", strings.Join(artifactProducerSteps, ",")}
 
+	if sidecarResults := sidecarResultNames(taskSpec.Sidecars); len(sidecarResults) > 0 {
+		sidecarResultsBytes, err := json.Marshal(sidecarResults)
+		if err != nil {
+			return v1.Sidecar{}, err
+		}
+		command = append(command, "-sidecar-results", string(sidecarResultsBytes))
+	}
+
 	// create a map of container Name to step results
 	stepResults := map[string][]string{}
-	for i, s := range taskSpec.Steps {
+	for _, s := range taskSpec.Steps {
 		if len(s.Results) > 0 {
-			stepName := StepName(s.Name, i)
+			stepName := pipeline.StepContainerName(s.Name)
 			stepResults[stepName] = make([]string, 0, len(s.Results))
 			for _, r := range s.Results {
 				stepResults[stepName] = append(stepResults[stepName], r.Name)
@@ -717,6 +867,13 @@ func createResultsSidecar(taskSpec v1.TaskSpec, image string, securityContext Se
 		},
 	}
 
+	// With "sidecar-volume", the results are written to the termination message of the sidecar
+	// instead of its logs.
+	if resultExtractionMethod == config.ResultExtractionMethodSidecarVolume {
+		sidecar.Command = append(sidecar.Command, "-termination-path", terminationPath)
+		sidecar.TerminationMessagePath = terminationPath
+	}
+
 	if securityContext.SetSecurityContext {
 		sidecar.SecurityContext = securityContext.GetSecurityContext(windows)
 	}
@@ -749,7 +906,7 @@ func artifactPathReferencedInStep(step v1.Step) bool {
 	// neither of them is substituted, so we need two forms to check if artifactsPath is referenced in steps.
 	unresolvedPath := "$(" + artifactref.StepArtifactPathPattern + ")"
 
-	path := filepath.Join(pipeline.StepsDir, GetContainerName(step.Name), "artifacts", "provenance.json")
+	path := filepath.Join(pipeline.StepsDir, pipeline.StepContainerName(step.Name), "artifacts", "provenance.json")
 	if strings.Contains(step.Script, path) || strings.Contains(step.Script, unresolvedPath) {
 		return true
 	}
@@ -0,0 +1,251 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/pod-security-admission/api"
+	"k8s.io/pod-security-admission/policy"
+)
+
+// podSecurityViolationMarker precedes the policy and the list of violations in the
+// errors returned by the Pod Security admission controller, e.g.
+// pods "foo" is forbidden: violates PodSecurity "restricted:latest": privileged (container "step-foo" must not set securityContext.privileged=true), ...
+const podSecurityViolationMarker = `violates PodSecurity "`
+
+const (
+	setSecurityContextHint = `the "set-security-context" feature flag for the containers injected by Tekton`
+	stepsAndSidecarsHint   = "on steps and sidecars"
+)
+
+// podSecurityRemediations maps the checks of the Pod Security Standards to the Tekton
+// fields and feature flags that can be used to satisfy them. Checks are matched by
+// prefix so that minor changes to their wording in new Kubernetes versions, such as
+// plurals, don't prevent a match.
+var podSecurityRemediations = []struct {
+	check       string
+	remediation string
+}{
+	{"allowPrivilegeEscalation", setSecurityContextHint + ", securityContext.allowPrivilegeEscalation=false " + stepsAndSidecarsHint},
+	{"unrestricted capabilities", setSecurityContextHint + `, securityContext.capabilities.drop=["ALL"] ` + stepsAndSidecarsHint},
+	{"runAsNonRoot", setSecurityContextHint + ", podTemplate.securityContext.runAsNonRoot=true or securityContext.runAsNonRoot=true " + stepsAndSidecarsHint},
+	{"seccompProfile", setSecurityContextHint + ", podTemplate.securityContext.seccompProfile or securityContext.seccompProfile " + stepsAndSidecarsHint},
+	{"non-default capabilities", "securityContext.capabilities.add " + stepsAndSidecarsHint},
+	{"runAsUser", "podTemplate.securityContext.runAsUser or securityContext.runAsUser " + stepsAndSidecarsHint},
+	{"privileged", "securityContext.privileged " + stepsAndSidecarsHint},
+	{"host namespaces", "podTemplate.hostNetwork"},
+	{"hostPath volumes", "podTemplate.volumes and the volumes of the Task"},
+	{"restricted volume types", "podTemplate.volumes and the volumes of the Task"},
+	{"hostPort", "the ports of sidecars"},
+	{"seLinuxOptions", "podTemplate.securityContext.seLinuxOptions or securityContext.seLinuxOptions " + stepsAndSidecarsHint},
+	{"forbidden AppArmor profile", "podTemplate.securityContext.appArmorProfile or securityContext.appArmorProfile " + stepsAndSidecarsHint},
+	{"forbidden sysctls", "podTemplate.securityContext.sysctls"},
+	{"procMount", "securityContext.procMount " + stepsAndSidecarsHint},
+}
+
+// PodSecurityViolation is a check of the Pod Security Standards that the pod of a TaskRun violates.
+type PodSecurityViolation struct {
+	// Check is the name of the violated check, e.g. "allowPrivilegeEscalation != false".
+	Check string
+	// Details describes what violates the check, e.g.
+	// `container "step-foo" must set securityContext.allowPrivilegeEscalation=false`.
+	Details string
+	// Remediation is the Tekton field or feature flag that can be used to fix the violation,
+	// empty if the check is unknown.
+	Remediation string
+}
+
+// String returns the violation with its details and remediation, if any.
+func (v PodSecurityViolation) String() string {
+	s := v.Check
+	if v.Details != "" {
+		s += " (" + v.Details + ")"
+	}
+	if v.Remediation != "" {
+		s += "; fix with " + v.Remediation
+	}
+	return s
+}
+
+// IsPodSecurityViolation returns true if msg is an error message of the Pod Security admission controller.
+func IsPodSecurityViolation(msg string) bool {
+	return strings.Contains(msg, podSecurityViolationMarker)
+}
+
+// ParsePodSecurityViolations parses the policy and the list of violations from the
+// error message the Pod Security admission controller rejects a pod with, and looks up
+// the remediation of each violation.
+// It returns false if the message can't be parsed, in which case it should be used as is.
+func ParsePodSecurityViolations(msg string) (string, []PodSecurityViolation, bool) {
+	i := strings.Index(msg, podSecurityViolationMarker)
+	if i < 0 {
+		return "", nil, false
+	}
+	rest := msg[i+len(podSecurityViolationMarker):]
+	policy, list, found := strings.Cut(rest, `": `)
+	if !found || policy == "" || strings.Contains(policy, `"`) {
+		return "", nil, false
+	}
+
+	var violations []PodSecurityViolation
+	for _, item := range splitPodSecurityViolations(list) {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		v := PodSecurityViolation{Check: item}
+		if open := strings.Index(item, " ("); open > 0 && strings.HasSuffix(item, ")") {
+			v.Check = item[:open]
+			v.Details = item[open+2 : len(item)-1]
+		}
+		v.Remediation = podSecurityRemediation(v.Check)
+		violations = append(violations, v)
+	}
+	if len(violations) == 0 {
+		return "", nil, false
+	}
+	return policy, violations, true
+}
+
+// PodSecurityViolationMessage formats the violations of a Pod Security admission error
+// along with their remediation, or returns msg as is if it can't be parsed.
+func PodSecurityViolationMessage(msg string) string {
+	policy, violations, ok := ParsePodSecurityViolations(msg)
+	if !ok {
+		return msg
+	}
+	return formatPodSecurityViolations(policy, violations)
+}
+
+func formatPodSecurityViolations(policy string, violations []PodSecurityViolation) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "pod violates PodSecurity %q:", policy)
+	for _, v := range violations {
+		b.WriteString("\n- ")
+		b.WriteString(v.String())
+	}
+	return b.String()
+}
+
+// PodSecurityPreflightError is returned by CheckPodSecurity when the pod of a TaskRun violates
+// the Pod Security Standards enforced in its namespace.
+type PodSecurityPreflightError struct {
+	// Policy is the enforced level and version, e.g. "restricted:latest".
+	Policy     string
+	Violations []PodSecurityViolation
+}
+
+func (e *PodSecurityPreflightError) Error() string {
+	return formatPodSecurityViolations(e.Policy, e.Violations)
+}
+
+// podSecurityEvaluator evaluates the pods against the checks of the Pod Security Standards, the
+// same way as the Pod Security admission controller does.
+var podSecurityEvaluator = sync.OnceValues(func() (policy.Evaluator, error) {
+	return policy.NewEvaluator(policy.DefaultChecks(), nil)
+})
+
+// PodSecurityExemptions are the namespaces and RuntimeClasses exempted in the AdmissionConfiguration of the
+// Pod Security admission controller, whose pods are admitted without being evaluated.
+type PodSecurityExemptions struct {
+	Namespaces     []string
+	RuntimeClasses []string
+}
+
+func (e PodSecurityExemptions) exempts(namespace string, pod *corev1.Pod) bool {
+	if slices.Contains(e.Namespaces, namespace) {
+		return true
+	}
+	return pod.Spec.RuntimeClassName != nil && slices.Contains(e.RuntimeClasses, *pod.Spec.RuntimeClassName)
+}
+
+// CheckPodSecurity evaluates pod against the level of the Pod Security Standards enforced by the
+// pod-security.kubernetes.io/enforce label of namespace, and returns a *PodSecurityPreflightError
+// listing the checks it violates, if any. Namespaces without the label are privileged, and the pods
+// exempted by exemptions aren't evaluated, as the admission controller does.
+func CheckPodSecurity(namespace *corev1.Namespace, pod *corev1.Pod, exemptions PodSecurityExemptions) error {
+	if exemptions.exempts(namespace.Name, pod) {
+		return nil
+	}
+	// Invalid labels are evaluated as restricted:latest, as the admission controller does.
+	p, _ := api.PolicyToEvaluate(namespace.Labels, api.Policy{
+		Enforce: api.LevelVersion{Level: api.LevelPrivileged, Version: api.LatestVersion()},
+	})
+	if p.Enforce.Level == api.LevelPrivileged {
+		return nil
+	}
+	evaluator, err := podSecurityEvaluator()
+	if err != nil {
+		return fmt.Errorf("failed to create the Pod Security evaluator: %w", err)
+	}
+	var violations []PodSecurityViolation
+	for _, result := range evaluator.EvaluatePod(p.Enforce, &pod.ObjectMeta, &pod.Spec) {
+		if result.Allowed {
+			continue
+		}
+		violations = append(violations, PodSecurityViolation{
+			Check:       result.ForbiddenReason,
+			Details:     result.ForbiddenDetail,
+			Remediation: podSecurityRemediation(result.ForbiddenReason),
+		})
+	}
+	if len(violations) == 0 {
+		return nil
+	}
+	return &PodSecurityPreflightError{Policy: p.Enforce.String(), Violations: violations}
+}
+
+// splitPodSecurityViolations splits a comma separated list of violations, ignoring the
+// commas between parentheses or double quotes, such as the ones separating container names.
+func splitPodSecurityViolations(list string) []string {
+	var items []string
+	depth, quoted, start := 0, false, 0
+	for i := range len(list) {
+		switch list[i] {
+		case '"':
+			quoted = !quoted
+		case '(':
+			if !quoted {
+				depth++
+			}
+		case ')':
+			if !quoted && depth > 0 {
+				depth--
+			}
+		case ',':
+			if !quoted && depth == 0 {
+				items = append(items, list[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(items, list[start:])
+}
+
+func podSecurityRemediation(check string) string {
+	for _, r := range podSecurityRemediations {
+		if strings.HasPrefix(check, r.check) {
+			return r.remediation
+		}
+	}
+	return ""
+}
@@ -0,0 +1,293 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestParsePodSecurityViolations(t *testing.T) {
+	for _, tc := range []struct {
+		name           string
+		msg            string
+		wantPolicy     string
+		wantViolations []PodSecurityViolation
+	}{{
+		name: "restricted policy",
+		msg: `pods "run-pod" is forbidden: violates PodSecurity "restricted:latest": ` +
+			`allowPrivilegeEscalation != false (containers "prepare", "place-scripts", "step-build" must set securityContext.allowPrivilegeEscalation=false), ` +
+			`unrestricted capabilities (containers "prepare", "place-scripts", "step-build" must set securityContext.capabilities.drop=["ALL"]), ` +
+			`runAsNonRoot != true (pod or containers "prepare", "place-scripts", "step-build" must set securityContext.runAsNonRoot=true), ` +
+			`seccompProfile (pod or containers "prepare", "place-scripts", "step-build" must set securityContext.seccompProfile.type to "RuntimeDefault" or "Localhost")`,
+		wantPolicy: "restricted:latest",
+		wantViolations: []PodSecurityViolation{{
+			Check:       "allowPrivilegeEscalation != false",
+			Details:     `containers "prepare", "place-scripts", "step-build" must set securityContext.allowPrivilegeEscalation=false`,
+			Remediation: setSecurityContextHint + ", securityContext.allowPrivilegeEscalation=false " + stepsAndSidecarsHint,
+		}, {
+			Check:       "unrestricted capabilities",
+			Details:     `containers "prepare", "place-scripts", "step-build" must set securityContext.capabilities.drop=["ALL"]`,
+			Remediation: setSecurityContextHint + `, securityContext.capabilities.drop=["ALL"] ` + stepsAndSidecarsHint,
+		}, {
+			Check:       "runAsNonRoot != true",
+			Details:     `pod or containers "prepare", "place-scripts", "step-build" must set securityContext.runAsNonRoot=true`,
+			Remediation: setSecurityContextHint + ", podTemplate.securityContext.runAsNonRoot=true or securityContext.runAsNonRoot=true " + stepsAndSidecarsHint,
+		}, {
+			Check:       "seccompProfile",
+			Details:     `pod or containers "prepare", "place-scripts", "step-build" must set securityContext.seccompProfile.type to "RuntimeDefault" or "Localhost"`,
+			Remediation: setSecurityContextHint + ", podTemplate.securityContext.seccompProfile or securityContext.seccompProfile " + stepsAndSidecarsHint,
+		}},
+	}, {
+		name: "baseline policy",
+		msg: `pods "run-pod" is forbidden: violates PodSecurity "baseline:v1.30": host namespaces (hostNetwork=true), ` +
+			`hostPath volumes (volume "docker-socket"), privileged (container "step-build" must not set securityContext.privileged=true), ` +
+			`non-default capabilities (container "sidecar-dind" must not include "NET_ADMIN", "SYS_ADMIN" in securityContext.capabilities.add)`,
+		wantPolicy: "baseline:v1.30",
+		wantViolations: []PodSecurityViolation{{
+			Check:       "host namespaces",
+			Details:     "hostNetwork=true",
+			Remediation: "podTemplate.hostNetwork",
+		}, {
+			Check:       "hostPath volumes",
+			Details:     `volume "docker-socket"`,
+			Remediation: "podTemplate.volumes and the volumes of the Task",
+		}, {
+			Check:       "privileged",
+			Details:     `container "step-build" must not set securityContext.privileged=true`,
+			Remediation: "securityContext.privileged " + stepsAndSidecarsHint,
+		}, {
+			Check:       "non-default capabilities",
+			Details:     `container "sidecar-dind" must not include "NET_ADMIN", "SYS_ADMIN" in securityContext.capabilities.add`,
+			Remediation: "securityContext.capabilities.add " + stepsAndSidecarsHint,
+		}},
+	}, {
+		name:       "unknown check",
+		msg:        `pods "run-pod" is forbidden: violates PodSecurity "restricted:latest": some new check (container "step-build" must not do that), runAsUser=0 (container "step-build" must not set runAsUser=0)`,
+		wantPolicy: "restricted:latest",
+		wantViolations: []PodSecurityViolation{{
+			Check:   "some new check",
+			Details: `container "step-build" must not do that`,
+		}, {
+			Check:       "runAsUser=0",
+			Details:     `container "step-build" must not set runAsUser=0`,
+			Remediation: "podTemplate.securityContext.runAsUser or securityContext.runAsUser " + stepsAndSidecarsHint,
+		}},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			policy, violations, ok := ParsePodSecurityViolations(tc.msg)
+			if !ok {
+				t.Fatalf("ParsePodSecurityViolations() could not parse %q", tc.msg)
+			}
+			if policy != tc.wantPolicy {
+				t.Errorf("ParsePodSecurityViolations() policy = %q, want %q", policy, tc.wantPolicy)
+			}
+			if d := cmp.Diff(tc.wantViolations, violations); d != "" {
+				t.Errorf("ParsePodSecurityViolations() violations %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestParsePodSecurityViolations_Unparsable(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		msg  string
+	}{{
+		name: "not a pod security error",
+		msg:  `pods "run-pod" is forbidden: exceeded quota: compute-resources`,
+	}, {
+		name: "no policy",
+		msg:  `pods "run-pod" is forbidden: violates PodSecurity "restricted:latest"`,
+	}, {
+		name: "no violations",
+		msg:  `pods "run-pod" is forbidden: violates PodSecurity "restricted:latest": `,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, violations, ok := ParsePodSecurityViolations(tc.msg); ok {
+				t.Errorf("ParsePodSecurityViolations() = %v, expected the message to be unparsable", violations)
+			}
+			if got := PodSecurityViolationMessage(tc.msg); got != tc.msg {
+				t.Errorf("PodSecurityViolationMessage() = %q, want the raw message %q", got, tc.msg)
+			}
+		})
+	}
+}
+
+func TestPodSecurityViolationMessage(t *testing.T) {
+	msg := `pods "run-pod" is forbidden: violates PodSecurity "baseline:latest": host namespaces (hostNetwork=true), some new check`
+	want := `pod violates PodSecurity "baseline:latest":
+- host namespaces (hostNetwork=true); fix with podTemplate.hostNetwork
+- some new check`
+	if got := PodSecurityViolationMessage(msg); got != want {
+		t.Errorf("PodSecurityViolationMessage() %s", diff.PrintWantGot(cmp.Diff(want, got)))
+	}
+}
+
+func TestCheckPodSecurity(t *testing.T) {
+	namespace := func(labels map[string]string) *corev1.Namespace {
+		return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns", Labels: labels}}
+	}
+	enforce := func(level string) *corev1.Namespace {
+		return namespace(map[string]string{"pod-security.kubernetes.io/enforce": level})
+	}
+	privilegedPod := &corev1.Pod{Spec: corev1.PodSpec{
+		HostNetwork: true,
+		Containers: []corev1.Container{{
+			Name:            "step-build",
+			Image:           "builder",
+			SecurityContext: &corev1.SecurityContext{Privileged: ptr.To(true)},
+		}},
+	}}
+	defaultPod := &corev1.Pod{Spec: corev1.PodSpec{
+		Containers: []corev1.Container{{Name: "step-build", Image: "builder"}},
+	}}
+	restrictedPod := &corev1.Pod{Spec: corev1.PodSpec{
+		SecurityContext: &corev1.PodSecurityContext{
+			RunAsNonRoot:   ptr.To(true),
+			SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+		},
+		Containers: []corev1.Container{{
+			Name:  "step-build",
+			Image: "builder",
+			SecurityContext: &corev1.SecurityContext{
+				AllowPrivilegeEscalation: ptr.To(false),
+				Capabilities:             &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}},
+			},
+		}},
+	}}
+	// The violations of defaultPod in restricted namespaces.
+	restrictedViolations := []PodSecurityViolation{{
+		Check:       "allowPrivilegeEscalation != false",
+		Details:     `container "step-build" must set securityContext.allowPrivilegeEscalation=false`,
+		Remediation: setSecurityContextHint + ", securityContext.allowPrivilegeEscalation=false " + stepsAndSidecarsHint,
+	}, {
+		Check:       "unrestricted capabilities",
+		Details:     `container "step-build" must set securityContext.capabilities.drop=["ALL"]`,
+		Remediation: setSecurityContextHint + `, securityContext.capabilities.drop=["ALL"] ` + stepsAndSidecarsHint,
+	}, {
+		Check:       "runAsNonRoot != true",
+		Details:     `pod or container "step-build" must set securityContext.runAsNonRoot=true`,
+		Remediation: setSecurityContextHint + ", podTemplate.securityContext.runAsNonRoot=true or securityContext.runAsNonRoot=true " + stepsAndSidecarsHint,
+	}, {
+		Check:       "seccompProfile",
+		Details:     `pod or container "step-build" must set securityContext.seccompProfile.type to "RuntimeDefault" or "Localhost"`,
+		Remediation: setSecurityContextHint + ", podTemplate.securityContext.seccompProfile or securityContext.seccompProfile " + stepsAndSidecarsHint,
+	}}
+
+	for _, tc := range []struct {
+		name       string
+		namespace  *corev1.Namespace
+		pod        *corev1.Pod
+		exemptions PodSecurityExemptions
+		want       *PodSecurityPreflightError
+	}{{
+		name:      "unlabeled namespace",
+		namespace: namespace(nil),
+		pod:       privilegedPod,
+	}, {
+		name:      "privileged namespace",
+		namespace: enforce("privileged"),
+		pod:       privilegedPod,
+	}, {
+		name:      "baseline namespace",
+		namespace: enforce("baseline"),
+		pod:       privilegedPod,
+		want: &PodSecurityPreflightError{Policy: "baseline:latest", Violations: []PodSecurityViolation{{
+			Check:       "host namespaces",
+			Details:     "hostNetwork=true",
+			Remediation: "podTemplate.hostNetwork",
+		}, {
+			Check:       "privileged",
+			Details:     `container "step-build" must not set securityContext.privileged=true`,
+			Remediation: "securityContext.privileged " + stepsAndSidecarsHint,
+		}}},
+	}, {
+		name:      "baseline namespace with a default pod",
+		namespace: enforce("baseline"),
+		pod:       defaultPod,
+	}, {
+		name:      "restricted namespace",
+		namespace: enforce("restricted"),
+		pod:       defaultPod,
+		want:      &PodSecurityPreflightError{Policy: "restricted:latest", Violations: restrictedViolations},
+	}, {
+		name:      "restricted namespace with a restricted pod",
+		namespace: enforce("restricted"),
+		pod:       restrictedPod,
+	}, {
+		name:      "invalid level evaluated as restricted",
+		namespace: enforce("strict"),
+		pod:       defaultPod,
+		want:      &PodSecurityPreflightError{Policy: "restricted:latest", Violations: restrictedViolations},
+	}, {
+		name:       "exempt namespace",
+		namespace:  enforce("restricted"),
+		pod:        defaultPod,
+		exemptions: PodSecurityExemptions{Namespaces: []string{"kube-system", "ns"}},
+	}, {
+		name:      "exempt runtime class",
+		namespace: enforce("restricted"),
+		pod: &corev1.Pod{Spec: corev1.PodSpec{
+			RuntimeClassName: ptr.To("gvisor"),
+			Containers:       []corev1.Container{{Name: "step-build", Image: "builder"}},
+		}},
+		exemptions: PodSecurityExemptions{RuntimeClasses: []string{"gvisor"}},
+	}, {
+		name:       "other exemptions",
+		namespace:  enforce("restricted"),
+		pod:        defaultPod,
+		exemptions: PodSecurityExemptions{Namespaces: []string{"kube-system"}, RuntimeClasses: []string{"gvisor"}},
+		want:       &PodSecurityPreflightError{Policy: "restricted:latest", Violations: restrictedViolations},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := CheckPodSecurity(tc.namespace, tc.pod, tc.exemptions)
+			if tc.want == nil {
+				if err != nil {
+					t.Fatalf("CheckPodSecurity() = %v, want no error", err)
+				}
+				return
+			}
+			var got *PodSecurityPreflightError
+			if !errors.As(err, &got) {
+				t.Fatalf("CheckPodSecurity() = %v, want a PodSecurityPreflightError", err)
+			}
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("CheckPodSecurity() %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestPodSecurityPreflightError(t *testing.T) {
+	err := &PodSecurityPreflightError{Policy: "baseline:latest", Violations: []PodSecurityViolation{{
+		Check:       "host namespaces",
+		Details:     "hostNetwork=true",
+		Remediation: "podTemplate.hostNetwork",
+	}}}
+	want := `pod violates PodSecurity "baseline:latest":
+- host namespaces (hostNetwork=true); fix with podTemplate.hostNetwork`
+	if got := err.Error(); got != want {
+		t.Errorf("Error() %s", diff.PrintWantGot(cmp.Diff(want, got)))
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 	"testing"
@@ -42,6 +43,7 @@ import (
 	"k8s.io/apimachinery/pkg/version"
 	fakediscovery "k8s.io/client-go/discovery/fake"
 	fakek8s "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/ptr"
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 	"knative.dev/pkg/kmeta"
@@ -65,6 +67,12 @@ var (
 
 	defaultActiveDeadlineSeconds = int64(config.DefaultTimeoutMinutes * 60 * deadlineFactor)
 
+	// These are injected into all of the step containers.
+	implicitEnvVars = []corev1.EnvVar{{
+		Name:      "TEKTON_POD_IP",
+		ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "status.podIP"}},
+	}}
+
 	resourceQuantityCmp = cmp.Comparer(func(x, y resource.Quantity) bool {
 		return x.Cmp(y) == 0
 	})
@@ -134,6 +142,7 @@ func TestPodBuild(t *testing.T) {
 						"cmd",
 						"--",
 					},
+					Env: implicitEnvVars,
 					VolumeMounts: append([]corev1.VolumeMount{downwardMount, {
 						Name:      "tekton-creds-init-home-0",
 						MountPath: "/tekton/creds",
@@ -184,6 +193,7 @@ func TestPodBuild(t *testing.T) {
 						"cmd",
 						"--",
 					},
+					Env: implicitEnvVars,
 					VolumeMounts: append([]corev1.VolumeMount{binROMount, runMount(0, false), downwardMount, {
 						Name:      "tekton-creds-init-home-0",
 						MountPath: "/tekton/creds",
@@ -227,6 +237,7 @@ func TestPodBuild(t *testing.T) {
 						"cmd",
 						"--",
 					},
+					Env: implicitEnvVars,
 					VolumeMounts: append([]corev1.VolumeMount{binROMount, runMount(0, false), {
 						Name:      "tekton-creds-init-home-0",
 						MountPath: "/tekton/creds",
@@ -281,6 +292,7 @@ func TestPodBuild(t *testing.T) {
 						"cmd",
 						"--",
 					},
+					Env: implicitEnvVars,
 					VolumeMounts: append([]corev1.VolumeMount{binROMount, runMount(0, false), downwardMount, {
 						Name:      "tekton-creds-init-home-0",
 						MountPath: "/tekton/creds",
@@ -345,6 +357,7 @@ func TestPodBuild(t *testing.T) {
 						"cmd",
 						"--",
 					},
+					Env: implicitEnvVars,
 					VolumeMounts: append([]corev1.VolumeMount{
 						binROMount, runMount(0, false),
 						downwardMount,
@@ -386,7 +399,7 @@ func TestPodBuild(t *testing.T) {
 				RestartPolicy:  corev1.RestartPolicyNever,
 				InitContainers: []corev1.Container{entrypointInitContainer(images.EntrypointImage, []v1.Step{{Name: "a-very-very-long-character-step-name-to-trigger-max-len----and-invalid-characters"}}, SecurityContextConfig{SetSecurityContext: false, SetReadOnlyRootFilesystem: false}, false /* windows */)},
 				Containers: []corev1.Container{{
-					Name:    "step-a-very-very-long-character-step-name-to-trigger-max-len", // step name trimmed.
+					Name:    "step-a-very-very-long-character-step-name-to-trigger-m-ff23d41e", // step name truncated and hashed.
 					Image:   "image",
 					Command: []string{"/tekton/bin/entrypoint"},
 					Args: []string{
@@ -403,6 +416,7 @@ func TestPodBuild(t *testing.T) {
 						"cmd",
 						"--",
 					},
+					Env: implicitEnvVars,
 					VolumeMounts: append([]corev1.VolumeMount{binROMount, runMount(0, false), downwardMount, {
 						Name:      "tekton-creds-init-home-0",
 						MountPath: "/tekton/creds",
@@ -446,6 +460,7 @@ func TestPodBuild(t *testing.T) {
 						"cmd",
 						"--",
 					},
+					Env: implicitEnvVars,
 					VolumeMounts: append([]corev1.VolumeMount{binROMount, runMount(0, false), downwardMount, {
 						Name:      "tekton-creds-init-home-0",
 						MountPath: "/tekton/creds",
@@ -500,6 +515,7 @@ func TestPodBuild(t *testing.T) {
 						"cmd",
 						"--",
 					},
+					Env: implicitEnvVars,
 					VolumeMounts: append([]corev1.VolumeMount{binROMount, runMount(0, false), downwardMount, {
 						Name:      "tekton-creds-init-home-0",
 						MountPath: "/tekton/creds",
@@ -549,6 +565,7 @@ func TestPodBuild(t *testing.T) {
 						"cmd",
 						"--",
 					},
+					Env: implicitEnvVars,
 					VolumeMounts: append([]corev1.VolumeMount{binROMount, runMount(0, false), downwardMount, {
 						Name:      "tekton-creds-init-home-0",
 						MountPath: "/tekton/creds",
@@ -616,6 +633,7 @@ _EOF_
 						"cmd",
 						"--",
 					},
+					Env: implicitEnvVars,
 					VolumeMounts: append([]corev1.VolumeMount{binROMount, runMount(0, false), downwardMount, {
 						Name:      "tekton-creds-init-home-0",
 						MountPath: "/tekton/creds",
@@ -672,6 +690,7 @@ _EOF_
 						"cmd",
 						"--",
 					},
+					Env: implicitEnvVars,
 					VolumeMounts: append([]corev1.VolumeMount{binROMount, runMount(0, false), downwardMount, {
 						Name:      "tekton-creds-init-home-0",
 						MountPath: "/tekton/creds",
@@ -692,6 +711,7 @@ _EOF_
 			desc: "resource request",
 			ts: v1.TaskSpec{
 				Steps: []v1.Step{{
+					Name:    "unnamed-0",
 					Image:   "image",
 					Command: []string{"cmd"}, // avoid entrypoint lookup.
 					ComputeResources: corev1.ResourceRequirements{
@@ -701,6 +721,7 @@ _EOF_
 						},
 					},
 				}, {
+					Name:    "unnamed-1",
 					Image:   "image",
 					Command: []string{"cmd"}, // avoid entrypoint lookup.
 					ComputeResources: corev1.ResourceRequirements{
@@ -735,6 +756,7 @@ _EOF_
 						"cmd",
 						"--",
 					},
+					Env: implicitEnvVars,
 					VolumeMounts: append([]corev1.VolumeMount{binROMount, runMount(0, false), runMount(1, true), downwardMount, {
 						Name:      "tekton-creds-init-home-0",
 						MountPath: "/tekton/creds",
@@ -763,6 +785,7 @@ _EOF_
 						"cmd",
 						"--",
 					},
+					Env: implicitEnvVars,
 					VolumeMounts: append([]corev1.VolumeMount{binROMount, runMount(0, true), runMount(1, false), {
 						Name:      "tekton-creds-init-home-1",
 						MountPath: "/tekton/creds",
@@ -834,6 +857,7 @@ _EOF_
 						"cmd",
 						"--",
 					},
+					Env: implicitEnvVars,
 					VolumeMounts: append([]corev1.VolumeMount{binROMount, runMount(0, false), downwardMount, {
 						Name:      "tekton-creds-init-home-0",
 						MountPath: "/tekton/creds",
@@ -904,6 +928,7 @@ _EOF_
 						"cmd",
 						"--",
 					},
+					Env: implicitEnvVars,
 					VolumeMounts: append([]corev1.VolumeMount{binROMount, runMount(0, false), downwardMount, {
 						Name:      "tekton-creds-init-home-0",
 						MountPath: "/tekton/creds",
@@ -975,6 +1000,7 @@ _EOF_
 						"cmd",
 						"--",
 					},
+					Env: implicitEnvVars,
 					VolumeMounts: append([]corev1.VolumeMount{binROMount, runMount(0, false), downwardMount, {
 						Name:      "tekton-creds-init-home-0",
 						MountPath: "/tekton/creds",
@@ -1062,13 +1088,15 @@ _EOF_
 						"/tekton/termination",
 						"-step_metadata_dir",
 						"/tekton/run/0/status",
+						"-script_sha256",
+						"67ef1ebb963f47f6640cd75bdddead0ecfd58d72aed2b92c26753b109c501944",
 						"-entrypoint",
 						"/tekton/scripts/script-0-9l9zj",
 						"--",
 						"template",
 						"args",
 					},
-					Env: []corev1.EnvVar{{Name: "FOO", Value: "bar"}},
+					Env: append(implicitEnvVars, corev1.EnvVar{Name: "FOO", Value: "bar"}),
 					VolumeMounts: append([]corev1.VolumeMount{scriptsVolumeMount, binROMount, runMount(0, false), runMount(1, true), runMount(2, true), downwardMount, {
 						Name:      "tekton-creds-init-home-0",
 						MountPath: "/tekton/creds",
@@ -1087,13 +1115,15 @@ _EOF_
 						"/tekton/termination",
 						"-step_metadata_dir",
 						"/tekton/run/1/status",
+						"-script_sha256",
+						"aeb2fdc0eef220914f4652ccf1562daf5e97556d7e5f43dcf4363f0c2483997a",
 						"-entrypoint",
 						"/tekton/scripts/script-1-mz4c7",
 						"--",
 						"template",
 						"args",
 					},
-					Env: []corev1.EnvVar{{Name: "FOO", Value: "bar"}},
+					Env: append(implicitEnvVars, corev1.EnvVar{Name: "FOO", Value: "bar"}),
 					VolumeMounts: append([]corev1.VolumeMount{{Name: "i-have-a-volume-mount"}, scriptsVolumeMount, binROMount, runMount(0, true), runMount(1, false), runMount(2, true), {
 						Name:      "tekton-creds-init-home-1",
 						MountPath: "/tekton/creds",
@@ -1119,7 +1149,7 @@ _EOF_
 						"template",
 						"args",
 					},
-					Env: []corev1.EnvVar{{Name: "FOO", Value: "bar"}},
+					Env: append(implicitEnvVars, corev1.EnvVar{Name: "FOO", Value: "bar"}),
 					VolumeMounts: append([]corev1.VolumeMount{binROMount, runMount(0, true), runMount(1, true), runMount(2, false), {
 						Name:      "tekton-creds-init-home-2",
 						MountPath: "/tekton/creds",
@@ -1180,10 +1210,13 @@ _EOF_
 						"/tekton/termination",
 						"-step_metadata_dir",
 						"/tekton/run/0/status",
+						"-script_sha256",
+						"4a6be127d5b66a96a53fedac0f1cab1a71bc12929cf401ded481c0beaf59b0f1",
 						"-entrypoint",
 						"/tekton/scripts/script-0-9l9zj",
 						"--",
 					},
+					Env: implicitEnvVars,
 					VolumeMounts: append([]corev1.VolumeMount{scriptsVolumeMount, binROMount, runMount(0, false), downwardMount, {
 						Name:      "tekton-creds-init-home-0",
 						MountPath: "/tekton/creds",
@@ -1239,6 +1272,7 @@ _EOF_
 						"cmd",
 						"--",
 					},
+					Env: implicitEnvVars,
 					VolumeMounts: append([]corev1.VolumeMount{binROMount, runMount(0, false), downwardMount, {
 						Name:      "tekton-creds-init-home-0",
 						MountPath: "/tekton/creds",
@@ -1290,6 +1324,7 @@ _EOF_
 						"cmd",
 						"--",
 					},
+					Env: implicitEnvVars,
 					VolumeMounts: append([]corev1.VolumeMount{binROMount, runMount(0, false), downwardMount, {
 						Name:      "tekton-creds-init-home-0",
 						MountPath: "/tekton/creds",
@@ -1341,6 +1376,7 @@ _EOF_
 						"cmd",
 						"--",
 					},
+					Env: implicitEnvVars,
 					VolumeMounts: append([]corev1.VolumeMount{binROMount, runMount(0, false), downwardMount, {
 						Name:      "tekton-creds-init-home-0",
 						MountPath: "/tekton/creds",
@@ -1393,6 +1429,7 @@ _EOF_
 						"cmd",
 						"--",
 					},
+					Env: implicitEnvVars,
 					VolumeMounts: append([]corev1.VolumeMount{binROMount, runMount(0, false), downwardMount, {
 						Name:      "tekton-creds-init-home-0",
 						MountPath: "/tekton/creds",
@@ -1444,6 +1481,7 @@ _EOF_
 						"cmd",
 						"--",
 					},
+					Env: implicitEnvVars,
 					VolumeMounts: append([]corev1.VolumeMount{binROMount, runMount(0, false), downwardMount, {
 						Name:      "tekton-creds-init-home-0",
 						MountPath: "/tekton/creds",
@@ -1486,6 +1524,7 @@ _EOF_
 						"cmd",
 						"--",
 					},
+					Env: implicitEnvVars,
 					VolumeMounts: append([]corev1.VolumeMount{binROMount, runMount(0, false), downwardMount, {
 						Name:      "tekton-creds-init-home-0",
 						MountPath: "/tekton/creds",
@@ -1535,6 +1574,7 @@ _EOF_
 						"cmd",
 						"--",
 					},
+					Env: implicitEnvVars,
 					VolumeMounts: append([]corev1.VolumeMount{binROMount, runMount(0, false), downwardMount, {
 						Name:      "tekton-creds-init-home-0",
 						MountPath: "/tekton/creds",
@@ -1581,6 +1621,7 @@ _EOF_
 						"cmd",
 						"--",
 					},
+					Env:                    implicitEnvVars,
 					VolumeMounts:           append([]corev1.VolumeMount{binROMount, runMount(0, false), downwardMount}, implicitVolumeMounts...),
 					TerminationMessagePath: "/tekton/termination",
 				}},
@@ -1636,10 +1677,10 @@ _EOF_
 						MountPath: "/tekton/creds",
 					}}, implicitVolumeMounts...),
 					TerminationMessagePath: "/tekton/termination",
-					Env: []corev1.EnvVar{
+					Env: append(implicitEnvVars, []corev1.EnvVar{
 						{Name: "SOME_ENV", Value: "some_val"},
 						{Name: "FORBIDDEN_ENV", Value: "some_val"},
-					},
+					}...),
 				}},
 				Volumes: append(implicitVolumes, binVolume, runVolume(0), downwardVolume, corev1.Volume{
 					Name:         "tekton-creds-init-home-0",
@@ -1692,11 +1733,11 @@ _EOF_
 						MountPath: "/tekton/creds",
 					}}, implicitVolumeMounts...),
 					TerminationMessagePath: "/tekton/termination",
-					Env: []corev1.EnvVar{
+					Env: append(implicitEnvVars, []corev1.EnvVar{
 						{Name: "SOME_ENV", Value: "some_val"},
 						{Name: "SOME_ENV", Value: "overridden_val"},
 						{Name: "SOME_ENV2", Value: "new_val"},
-					},
+					}...),
 				}},
 				Volumes: append(implicitVolumes, binVolume, runVolume(0), downwardVolume, corev1.Volume{
 					Name:         "tekton-creds-init-home-0",
@@ -1744,9 +1785,9 @@ _EOF_
 						MountPath: "/tekton/creds",
 					}}, implicitVolumeMounts...),
 					TerminationMessagePath: "/tekton/termination",
-					Env: []corev1.EnvVar{
+					Env: append(implicitEnvVars, []corev1.EnvVar{
 						{Name: "TEKTON_HERMETIC", Value: "1"},
-					},
+					}...),
 				}},
 				Volumes: append(implicitVolumes, binVolume, runVolume(0), downwardVolume, corev1.Volume{
 					Name:         "tekton-creds-init-home-0",
@@ -1795,11 +1836,11 @@ _EOF_
 						MountPath: "/tekton/creds",
 					}}, implicitVolumeMounts...),
 					TerminationMessagePath: "/tekton/termination",
-					Env: []corev1.EnvVar{
+					Env: append(implicitEnvVars, []corev1.EnvVar{
 						{Name: "TEKTON_HERMETIC", Value: "something_else"},
 						// this value must be second to override the first
 						{Name: "TEKTON_HERMETIC", Value: "1"},
-					},
+					}...),
 				}},
 				Volumes: append(implicitVolumes, binVolume, runVolume(0), downwardVolume, corev1.Volume{
 					Name:         "tekton-creds-init-home-0",
@@ -1857,6 +1898,7 @@ _EOF_
 						"cmd",
 						"--",
 					},
+					Env: implicitEnvVars,
 					VolumeMounts: append([]corev1.VolumeMount{binROMount, runMount(0, false), downwardMount, {
 						Name:      "tekton-creds-init-home-0",
 						MountPath: "/tekton/creds",
@@ -1903,6 +1945,7 @@ _EOF_
 						"cmd",
 						"--",
 					},
+					Env: implicitEnvVars,
 					VolumeMounts: append([]corev1.VolumeMount{downwardMount, {
 						Name:      "tekton-creds-init-home-0",
 						MountPath: "/tekton/creds",
@@ -1980,6 +2023,7 @@ _EOF_
 						"cmd",
 						"--",
 					},
+					Env: implicitEnvVars,
 					VolumeMounts: append([]corev1.VolumeMount{binROMount, runMount(0, false), downwardMount, {
 						Name:      "tekton-creds-init-home-0",
 						MountPath: "/tekton/creds",
@@ -2030,6 +2074,7 @@ _EOF_
 						"cmd",
 						"--",
 					},
+					Env: implicitEnvVars,
 					VolumeMounts: append([]corev1.VolumeMount{binROMount, runMount(0, false), downwardMount, {
 						Name:      "tekton-creds-init-home-0",
 						MountPath: "/tekton/creds",
@@ -2062,6 +2107,83 @@ _EOF_
 				ActiveDeadlineSeconds: &defaultActiveDeadlineSeconds,
 			},
 		},
+		{
+			desc:         "sidecar volume enabled",
+			featureFlags: map[string]string{"results-from": "sidecar-volume"},
+			ts: v1.TaskSpec{
+				Results: []v1.TaskResult{{
+					Name: "foo",
+					Type: v1.ResultsTypeString,
+				}},
+				Steps: []v1.Step{{
+					Name:    "name",
+					Image:   "image",
+					Command: []string{"cmd"}, // avoid entrypoint lookup.
+				}},
+			},
+			want: &corev1.PodSpec{
+				RestartPolicy: corev1.RestartPolicyNever,
+				InitContainers: []corev1.Container{
+					entrypointInitContainer(images.EntrypointImage, []v1.Step{{Name: "name"}}, SecurityContextConfig{SetSecurityContext: false, SetReadOnlyRootFilesystem: false}, false /* windows */),
+				},
+				Containers: []corev1.Container{{
+					Name:    "step-name",
+					Image:   "image",
+					Command: []string{"/tekton/bin/entrypoint"},
+					Args: []string{
+						"-wait_file",
+						"/tekton/downward/ready",
+						"-wait_file_content",
+						"-post_file",
+						"/tekton/run/0/out",
+						"-termination_path",
+						"/tekton/termination",
+						"-step_metadata_dir",
+						"/tekton/run/0/status",
+						"-result_from",
+						"sidecar-volume",
+						"-results",
+						"foo",
+						"-entrypoint",
+						"cmd",
+						"--",
+					},
+					Env: implicitEnvVars,
+					VolumeMounts: append([]corev1.VolumeMount{binROMount, runMount(0, false), downwardMount, {
+						Name:      "tekton-creds-init-home-0",
+						MountPath: "/tekton/creds",
+					}}, implicitVolumeMounts...),
+					TerminationMessagePath: "/tekton/termination",
+				}, {
+					Name:  pipeline.ReservedResultsSidecarContainerName,
+					Image: "",
+					Command: []string{
+						"/ko-app/sidecarlogresults",
+						"-results-dir",
+						"/tekton/results",
+						"-result-names",
+						"foo",
+						"-step-names",
+						"",
+						"-step-results",
+						"{}",
+						"-termination-path",
+						"/tekton/termination",
+					},
+					VolumeMounts: append([]corev1.VolumeMount{
+						{Name: "tekton-internal-bin", ReadOnly: true, MountPath: "/tekton/bin"},
+						{Name: "tekton-internal-run-0", ReadOnly: true, MountPath: "/tekton/run/0"},
+					}, implicitVolumeMounts...),
+					Env:                    []corev1.EnvVar{{Name: "SIDECAR_LOG_POLLING_INTERVAL", Value: "100ms"}},
+					TerminationMessagePath: "/tekton/termination",
+				}},
+				Volumes: append(implicitVolumes, binVolume, runVolume(0), downwardVolume, corev1.Volume{
+					Name:         "tekton-creds-init-home-0",
+					VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumMemory}},
+				}),
+				ActiveDeadlineSeconds: &defaultActiveDeadlineSeconds,
+			},
+		},
 		{
 			desc:         "sidecar logs enabled with step results, artifacts not enabled",
 			featureFlags: map[string]string{"results-from": "sidecar-logs"},
@@ -2109,6 +2231,7 @@ _EOF_
 						"cmd",
 						"--",
 					},
+					Env: implicitEnvVars,
 					VolumeMounts: append([]corev1.VolumeMount{binROMount, runMount(0, false), downwardMount, {
 						Name:      "tekton-creds-init-home-0",
 						MountPath: "/tekton/creds",
@@ -2182,6 +2305,7 @@ _EOF_
 						"cmd",
 						"--",
 					},
+					Env: implicitEnvVars,
 					VolumeMounts: append([]corev1.VolumeMount{binROMount, runMount(0, false), downwardMount, {
 						Name:      "tekton-creds-init-home-0",
 						MountPath: "/tekton/creds",
@@ -2259,6 +2383,7 @@ _EOF_
 						">>>",
 						"/tekton/steps/step-name/artifacts/provenance.json",
 					},
+					Env: implicitEnvVars,
 					VolumeMounts: append([]corev1.VolumeMount{binROMount, runMount(0, false), downwardMount, {
 						Name:      "tekton-creds-init-home-0",
 						MountPath: "/tekton/creds",
@@ -2341,6 +2466,7 @@ _EOF_
 						">>>",
 						"/tekton/steps/step-name/artifacts/provenance.json",
 					},
+					Env: implicitEnvVars,
 					VolumeMounts: append([]corev1.VolumeMount{binROMount, runMount(0, false), downwardMount, {
 						Name:      "tekton-creds-init-home-0",
 						MountPath: "/tekton/creds",
@@ -2417,6 +2543,7 @@ _EOF_
 						">>>",
 						"/tekton/steps/step-name/artifacts/provenance.json",
 					},
+					Env: implicitEnvVars,
 					VolumeMounts: append([]corev1.VolumeMount{binROMount, runMount(0, false), downwardMount, {
 						Name:      "tekton-creds-init-home-0",
 						MountPath: "/tekton/creds",
@@ -2481,6 +2608,7 @@ _EOF_
 						"cmd",
 						"--",
 					},
+					Env: implicitEnvVars,
 					VolumeMounts: append([]corev1.VolumeMount{downwardMount, {
 						Name:      "tekton-creds-init-home-0",
 						MountPath: "/tekton/creds",
@@ -2527,6 +2655,7 @@ _EOF_
 						"cmd",
 						"--",
 					},
+					Env: implicitEnvVars,
 					VolumeMounts: append([]corev1.VolumeMount{binROMount, runMount(0, false), downwardMount, {
 						Name:      "tekton-creds-init-home-0",
 						MountPath: "/tekton/creds",
@@ -2585,6 +2714,7 @@ _EOF_
 						"cmd",
 						"--",
 					},
+					Env: implicitEnvVars,
 					VolumeMounts: append([]corev1.VolumeMount{binROMount, runMount(0, false), downwardMount, {
 						Name:      "tekton-creds-init-home-0",
 						MountPath: "/tekton/creds",
@@ -2816,6 +2946,7 @@ debug-fail-continue-heredoc-randomly-generated-mz4c7
 					"cmd",
 					"--",
 				},
+				Env:                    implicitEnvVars,
 				VolumeMounts:           containersVolumeMounts,
 				TerminationMessagePath: "/tekton/termination",
 			}},
@@ -3158,6 +3289,7 @@ func TestPodBuildwithSpireEnabled(t *testing.T) {
 					"cmd",
 					"--",
 				},
+				Env: implicitEnvVars,
 				VolumeMounts: append([]corev1.VolumeMount{binROMount, runMount(0, false), downwardMount, {
 					Name:      "tekton-creds-init-home-0",
 					MountPath: "/tekton/creds",
@@ -4120,3 +4252,818 @@ func TestPodBuild_CompressTerminationMessage(t *testing.T) {
 		})
 	}
 }
+
+// TestPodBuild_StepFailFast tests that, with "enable-step-fail-fast", all the steps mount the
+// writable directory of the fail-fast file and are passed its path.
+func TestPodBuild_StepFailFast(t *testing.T) {
+	for _, tc := range []struct {
+		desc         string
+		featureFlags map[string]string
+		wantFailFast bool
+	}{{
+		desc:         "fail-fast enabled",
+		featureFlags: map[string]string{"enable-step-fail-fast": "true"},
+		wantFailFast: true,
+	}, {
+		desc:         "fail-fast disabled",
+		featureFlags: map[string]string{},
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			store := config.NewStore(logtesting.TestLogger(t))
+			store.OnConfigChanged(&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: config.GetFeatureFlagsConfigName(), Namespace: system.Namespace()},
+				Data:       tc.featureFlags,
+			})
+			store.OnConfigChanged(&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: config.GetDefaultsConfigName(), Namespace: system.Namespace()}})
+			kubeclient := fakek8s.NewSimpleClientset(
+				&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "default"}},
+			)
+			tr := &v1.TaskRun{ObjectMeta: metav1.ObjectMeta{Name: "taskrun-fail-fast", Namespace: "default"}}
+			ts := v1.TaskSpec{
+				Steps: []v1.Step{{Name: "build", Image: "image", Command: []string{"cmd"}}, {Name: "test", Image: "image", Command: []string{"cmd"}}},
+			}
+			builder := Builder{
+				Images:          images,
+				KubeClient:      kubeclient,
+				EntrypointCache: fakeCache{},
+			}
+			got, err := builder.Build(store.ToContext(t.Context()), tr, ts)
+			if err != nil {
+				t.Fatalf("builder.Build: %v", err)
+			}
+
+			if hasVolume := slices.ContainsFunc(got.Spec.Volumes, func(v corev1.Volume) bool {
+				return v.Name == "tekton-internal-run-fail-fast"
+			}); hasVolume != tc.wantFailFast {
+				t.Errorf("fail-fast volume: got %t, want %t", hasVolume, tc.wantFailFast)
+			}
+			for _, c := range got.Spec.Containers {
+				if hasMount := slices.Contains(c.VolumeMounts, corev1.VolumeMount{Name: "tekton-internal-run-fail-fast", MountPath: "/tekton/run/fail-fast"}); hasMount != tc.wantFailFast {
+					t.Errorf("fail-fast mount of container %s: got %t, want %t", c.Name, hasMount, tc.wantFailFast)
+				}
+				hasArg := slices.Contains(c.Args, "-fail_fast_file") && slices.Contains(c.Args, "/tekton/run/fail-fast/failed")
+				if hasArg != tc.wantFailFast {
+					t.Errorf("-fail_fast_file flag of container %s: got %t, want %t; args: %v", c.Name, hasArg, tc.wantFailFast, c.Args)
+				}
+			}
+		})
+	}
+}
+
+func TestPodBuild_StepCapabilities(t *testing.T) {
+	store := config.NewStore(logtesting.TestLogger(t))
+	store.OnConfigChanged(&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: config.GetFeatureFlagsConfigName(), Namespace: system.Namespace()}})
+	store.OnConfigChanged(&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: config.GetDefaultsConfigName(), Namespace: system.Namespace()}})
+	kubeclient := fakek8s.NewSimpleClientset(
+		&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "default"}},
+	)
+	runAsUser := int64(1000)
+	tr := &v1.TaskRun{ObjectMeta: metav1.ObjectMeta{Name: "taskrun-capabilities", Namespace: "default"}}
+	ts := v1.TaskSpec{
+		StepTemplate: &v1.StepTemplate{SecurityContext: &corev1.SecurityContext{RunAsUser: &runAsUser}},
+		Steps: []v1.Step{{
+			Name:         "build",
+			Image:        "image",
+			Command:      []string{"cmd"},
+			Capabilities: &corev1.Capabilities{Add: []corev1.Capability{"SETFCAP"}, Drop: []corev1.Capability{"NET_RAW"}},
+		}, {
+			Name:            "push",
+			Image:           "image",
+			Command:         []string{"cmd"},
+			SecurityContext: &corev1.SecurityContext{Capabilities: &corev1.Capabilities{Add: []corev1.Capability{"SETFCAP"}, Drop: []corev1.Capability{"ALL"}}},
+			Capabilities:    &corev1.Capabilities{Add: []corev1.Capability{"SETFCAP", "SETGID"}},
+		}, {
+			Name:    "test",
+			Image:   "image",
+			Command: []string{"cmd"},
+		}},
+	}
+	builder := Builder{
+		Images:          images,
+		KubeClient:      kubeclient,
+		EntrypointCache: fakeCache{},
+	}
+	got, err := builder.Build(store.ToContext(t.Context()), tr, ts)
+	if err != nil {
+		t.Fatalf("builder.Build: %v", err)
+	}
+
+	want := map[string]*corev1.SecurityContext{
+		"step-build": {
+			RunAsUser:    &runAsUser,
+			Capabilities: &corev1.Capabilities{Add: []corev1.Capability{"SETFCAP"}, Drop: []corev1.Capability{"NET_RAW"}},
+		},
+		"step-push": {
+			RunAsUser:    &runAsUser,
+			Capabilities: &corev1.Capabilities{Add: []corev1.Capability{"SETFCAP", "SETGID"}, Drop: []corev1.Capability{"ALL"}},
+		},
+		"step-test": {RunAsUser: &runAsUser},
+	}
+	gotSecurityContexts := map[string]*corev1.SecurityContext{}
+	for _, c := range got.Spec.Containers {
+		gotSecurityContexts[c.Name] = c.SecurityContext
+	}
+	if d := cmp.Diff(want, gotSecurityContexts); d != "" {
+		t.Errorf("security context of the step containers %s", diff.PrintWantGot(d))
+	}
+	if d := cmp.Diff([]corev1.Capability{"SETFCAP"}, ts.Steps[1].SecurityContext.Capabilities.Add); d != "" {
+		t.Errorf("the security context of the step was modified %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestPodBuild_StepAutomountServiceAccountToken(t *testing.T) {
+	for _, tc := range []struct {
+		name             string
+		podAutomount     *bool
+		stepAutomount    *bool
+		wantPodAutomount *bool
+		wantMasked       bool
+	}{{
+		name:             "token not mounted in the pod",
+		podAutomount:     ptr.To(false),
+		stepAutomount:    ptr.To(false),
+		wantPodAutomount: ptr.To(false),
+	}, {
+		name:          "token removed from the step",
+		stepAutomount: ptr.To(false),
+		wantMasked:    true,
+	}, {
+		name:             "token mounted in the step",
+		podAutomount:     ptr.To(true),
+		stepAutomount:    ptr.To(true),
+		wantPodAutomount: ptr.To(true),
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			store := config.NewStore(logtesting.TestLogger(t))
+			store.OnConfigChanged(&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: config.GetFeatureFlagsConfigName(), Namespace: system.Namespace()}})
+			kubeclient := fakek8s.NewSimpleClientset(
+				&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "default"}},
+			)
+			tr := &v1.TaskRun{
+				ObjectMeta: metav1.ObjectMeta{Name: "taskrun-token", Namespace: "default"},
+				Spec: v1.TaskRunSpec{
+					PodTemplate: &pod.Template{AutomountServiceAccountToken: tc.podAutomount},
+				},
+			}
+			ts := v1.TaskSpec{
+				Steps: []v1.Step{{
+					Name:                         "build",
+					Image:                        "image",
+					Command:                      []string{"cmd"},
+					AutomountServiceAccountToken: tc.stepAutomount,
+				}, {
+					Name:    "push",
+					Image:   "image",
+					Command: []string{"cmd"},
+				}},
+			}
+			builder := Builder{
+				Images:          images,
+				KubeClient:      kubeclient,
+				EntrypointCache: fakeCache{},
+			}
+			got, err := builder.Build(store.ToContext(t.Context()), tr, ts)
+			if err != nil {
+				t.Fatalf("builder.Build: %v", err)
+			}
+
+			if d := cmp.Diff(tc.wantPodAutomount, got.Spec.AutomountServiceAccountToken); d != "" {
+				t.Errorf("automountServiceAccountToken of the pod %s", diff.PrintWantGot(d))
+			}
+			wantMasked := map[string]bool{"step-build": tc.wantMasked, "step-push": false}
+			gotMasked := map[string]bool{}
+			for _, c := range got.Spec.Containers {
+				gotMasked[c.Name] = false
+				for _, vm := range c.VolumeMounts {
+					if vm.MountPath == "/var/run/secrets/kubernetes.io/serviceaccount" {
+						if d := cmp.Diff(corev1.VolumeMount{Name: "tekton-internal-no-sa-token", MountPath: vm.MountPath, ReadOnly: true}, vm); d != "" {
+							t.Errorf("volume mount masking the token of container %s %s", c.Name, diff.PrintWantGot(d))
+						}
+						gotMasked[c.Name] = true
+					}
+				}
+			}
+			if d := cmp.Diff(wantMasked, gotMasked); d != "" {
+				t.Errorf("containers with the token masked %s", diff.PrintWantGot(d))
+			}
+			hasVolume := false
+			for _, v := range got.Spec.Volumes {
+				if v.Name == "tekton-internal-no-sa-token" {
+					hasVolume = v.EmptyDir != nil
+				}
+			}
+			if hasVolume != tc.wantMasked {
+				t.Errorf("expected the empty volume masking the token to be in the pod: %t, got %t", tc.wantMasked, hasVolume)
+			}
+		})
+	}
+}
+
+// TestPodBuild_EnforceReadOnlyRootFilesystem tests that, with enforce-read-only-root-filesystem, the steps and
+// sidecars which don't set readOnlyRootFilesystem run with a read-only root filesystem and a writable /tmp, the
+// directories of /tekton the entrypoint writes to staying writable.
+func TestPodBuild_EnforceReadOnlyRootFilesystem(t *testing.T) {
+	for _, tc := range []struct {
+		name         string
+		enforce      bool
+		wantReadOnly map[string]*bool
+		wantTmp      map[string]corev1.VolumeMount
+	}{{
+		name:    "enforced",
+		enforce: true,
+		wantReadOnly: map[string]*bool{
+			"step-build":    ptr.To(true),
+			"step-debug":    ptr.To(false),
+			"step-cache":    ptr.To(true),
+			"sidecar-proxy": ptr.To(true),
+		},
+		wantTmp: map[string]corev1.VolumeMount{
+			"step-build":    {Name: "tekton-internal-tmp", MountPath: "/tmp", SubPath: "step-build"},
+			"step-cache":    {Name: "cache", MountPath: "/tmp"},
+			"sidecar-proxy": {Name: "tekton-internal-tmp", MountPath: "/tmp", SubPath: "sidecar-proxy"},
+		},
+	}, {
+		name: "not enforced",
+		wantReadOnly: map[string]*bool{
+			"step-build":    nil,
+			"step-debug":    ptr.To(false),
+			"step-cache":    nil,
+			"sidecar-proxy": nil,
+		},
+		wantTmp: map[string]corev1.VolumeMount{
+			"step-cache": {Name: "cache", MountPath: "/tmp"},
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			store := config.NewStore(logtesting.TestLogger(t))
+			store.OnConfigChanged(&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: config.GetFeatureFlagsConfigName(), Namespace: system.Namespace()},
+				Data:       map[string]string{"enforce-read-only-root-filesystem": strconv.FormatBool(tc.enforce)},
+			})
+			kubeclient := fakek8s.NewSimpleClientset(
+				&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "default"}},
+			)
+			tr := &v1.TaskRun{
+				ObjectMeta: metav1.ObjectMeta{Name: "taskrun-read-only", Namespace: "default"},
+			}
+			ts := v1.TaskSpec{
+				Steps: []v1.Step{{
+					Name:   "build",
+					Image:  "image",
+					Script: "echo hello > /tmp/hello",
+				}, {
+					Name:            "debug",
+					Image:           "image",
+					Command:         []string{"cmd"},
+					SecurityContext: &corev1.SecurityContext{ReadOnlyRootFilesystem: ptr.To(false)},
+				}, {
+					Name:         "cache",
+					Image:        "image",
+					Command:      []string{"cmd"},
+					VolumeMounts: []corev1.VolumeMount{{Name: "cache", MountPath: "/tmp"}},
+				}},
+				Sidecars: []v1.Sidecar{{
+					Name:  "proxy",
+					Image: "proxy",
+				}},
+				Volumes: []corev1.Volume{{
+					Name:         "cache",
+					VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+				}},
+			}
+			builder := Builder{
+				Images:          images,
+				KubeClient:      kubeclient,
+				EntrypointCache: fakeCache{},
+			}
+			got, err := builder.Build(store.ToContext(t.Context()), tr, ts)
+			if err != nil {
+				t.Fatalf("builder.Build: %v", err)
+			}
+
+			gotReadOnly := map[string]*bool{}
+			gotTmp := map[string]corev1.VolumeMount{}
+			for i, c := range got.Spec.Containers {
+				gotReadOnly[c.Name] = nil
+				if c.SecurityContext != nil {
+					gotReadOnly[c.Name] = c.SecurityContext.ReadOnlyRootFilesystem
+				}
+				writable := map[string]bool{}
+				for _, vm := range c.VolumeMounts {
+					if vm.MountPath == "/tmp" {
+						gotTmp[c.Name] = vm
+					}
+					if !vm.ReadOnly {
+						writable[vm.MountPath] = true
+					}
+				}
+				// The entrypoint of a step writes its results and the files of its run directory.
+				if strings.HasPrefix(c.Name, "step-") {
+					for _, dir := range []string{"/tekton/results", "/tekton/home", "/workspace", filepath.Join(RunDir, strconv.Itoa(i))} {
+						if !writable[dir] {
+							t.Errorf("expected %s to be writable in container %s", dir, c.Name)
+						}
+					}
+				}
+			}
+			if d := cmp.Diff(tc.wantReadOnly, gotReadOnly); d != "" {
+				t.Errorf("readOnlyRootFilesystem of the containers %s", diff.PrintWantGot(d))
+			}
+			if d := cmp.Diff(tc.wantTmp, gotTmp); d != "" {
+				t.Errorf("volume mounts at /tmp %s", diff.PrintWantGot(d))
+			}
+			hasVolume := false
+			for _, v := range got.Spec.Volumes {
+				if v.Name == "tekton-internal-tmp" {
+					hasVolume = v.EmptyDir != nil
+				}
+			}
+			if hasVolume != tc.enforce {
+				t.Errorf("expected the /tmp volume to be in the pod: %t, got %t", tc.enforce, hasVolume)
+			}
+		})
+	}
+}
+
+// TestPodBuild_StepDirectoryIsolation tests that, with isolated step directories, each step mounts the
+// /tekton/steps directories of the steps from their run volumes, only its own being writable, instead of
+// the shared /tekton/steps tree.
+func TestPodBuild_StepDirectoryIsolation(t *testing.T) {
+	store := config.NewStore(logtesting.TestLogger(t))
+	store.OnConfigChanged(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: config.GetFeatureFlagsConfigName(), Namespace: system.Namespace()},
+		Data:       map[string]string{"enable-step-directory-isolation": "true"},
+	})
+	store.OnConfigChanged(&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: config.GetDefaultsConfigName(), Namespace: system.Namespace()}})
+	kubeclient := fakek8s.NewSimpleClientset(
+		&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "default"}},
+	)
+	tr := &v1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "taskrun-step-dirs",
+			Namespace:   "default",
+			Annotations: map[string]string{ReleaseAnnotation: fakeVersion},
+		},
+	}
+	ts := v1.TaskSpec{
+		Steps: []v1.Step{{
+			Name:    "build",
+			Image:   "image",
+			Command: []string{"cmd"},
+		}, {
+			Name:    "push",
+			Image:   "image",
+			Command: []string{"cmd"},
+		}},
+	}
+	builder := Builder{
+		Images:          images,
+		KubeClient:      kubeclient,
+		EntrypointCache: fakeCache{},
+	}
+	got, err := builder.Build(store.ToContext(t.Context()), tr, ts)
+	if err != nil {
+		t.Fatalf("builder.Build: %v", err)
+	}
+
+	for i, c := range got.Spec.Containers {
+		var stepDirMounts []corev1.VolumeMount
+		for _, vm := range c.VolumeMounts {
+			if vm.Name == "tekton-internal-steps" {
+				t.Errorf("container %s mounts the shared steps directory", c.Name)
+			}
+			if strings.HasPrefix(vm.MountPath, pipeline.StepsDir) {
+				stepDirMounts = append(stepDirMounts, vm)
+			}
+		}
+		want := []corev1.VolumeMount{
+			{Name: "tekton-internal-run-0", MountPath: "/tekton/steps/step-build", SubPath: "status", ReadOnly: i != 0},
+			{Name: "tekton-internal-run-1", MountPath: "/tekton/steps/step-push", SubPath: "status", ReadOnly: i != 1},
+		}
+		if d := cmp.Diff(want, stepDirMounts); d != "" {
+			t.Errorf("Unexpected steps directory mounts of container %s %s", c.Name, diff.PrintWantGot(d))
+		}
+	}
+}
+
+// TestPodBuild_StepContainerNames tests that the step containers are named after the step names of the
+// spec, as defaulted, rather than after the positions of the steps.
+func TestPodBuild_StepContainerNames(t *testing.T) {
+	names.TestingSeed()
+	store := config.NewStore(logtesting.TestLogger(t))
+	store.OnConfigChanged(&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: config.GetFeatureFlagsConfigName(), Namespace: system.Namespace()}})
+	store.OnConfigChanged(&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: config.GetDefaultsConfigName(), Namespace: system.Namespace()}})
+	ctx := store.ToContext(t.Context())
+	kubeclient := fakek8s.NewSimpleClientset(
+		&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "default"}},
+	)
+	tr := &v1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "taskrun-step-names",
+			Namespace:   "default",
+			Annotations: map[string]string{ReleaseAnnotation: fakeVersion},
+		},
+	}
+	ts := v1.TaskSpec{
+		Steps: []v1.Step{{
+			Image:   "image",
+			Command: []string{"cmd"},
+		}, {
+			Name:    "unnamed-0",
+			Image:   "image",
+			Command: []string{"cmd"},
+		}, {
+			Image:   "image",
+			Command: []string{"cmd"},
+		}},
+	}
+	ts.SetDefaults(ctx)
+	builder := Builder{
+		Images:          images,
+		KubeClient:      kubeclient,
+		EntrypointCache: fakeCache{},
+	}
+	got, err := builder.Build(ctx, tr, ts)
+	if err != nil {
+		t.Fatalf("builder.Build: %v", err)
+	}
+
+	want := []string{"step-unnamed-0-1", "step-unnamed-0", "step-unnamed-2"}
+	var gotContainers []string
+	for _, c := range got.Spec.Containers {
+		gotContainers = append(gotContainers, c.Name)
+	}
+	if d := cmp.Diff(want, gotContainers); d != "" {
+		t.Errorf("Unexpected step containers %s", diff.PrintWantGot(d))
+	}
+	// The entrypoint init container creates the directories of the steps named after their containers
+	initCommand := got.Spec.InitContainers[0].Command
+	if d := cmp.Diff(want, initCommand[len(initCommand)-len(want):]); d != "" {
+		t.Errorf("Unexpected steps of the init container %s", diff.PrintWantGot(d))
+	}
+}
+
+// TestPodBuild_LongStepContainerNames tests that the container names of the steps whose names are too
+// long to be prefixed, e.g. in remotely resolved specs, are shortened to distinct names, the same each
+// time the pod is built.
+func TestPodBuild_LongStepContainerNames(t *testing.T) {
+	store := config.NewStore(logtesting.TestLogger(t))
+	store.OnConfigChanged(&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: config.GetFeatureFlagsConfigName(), Namespace: system.Namespace()}})
+	store.OnConfigChanged(&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: config.GetDefaultsConfigName(), Namespace: system.Namespace()}})
+	ctx := store.ToContext(t.Context())
+	kubeclient := fakek8s.NewSimpleClientset(
+		&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "default"}},
+	)
+	tr := &v1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "taskrun-long-step-names",
+			Namespace:   "default",
+			Annotations: map[string]string{ReleaseAnnotation: fakeVersion},
+		},
+	}
+	longName := strings.Repeat("a", 60)
+	ts := v1.TaskSpec{
+		Steps: []v1.Step{{
+			Name:    strings.Repeat("a", pipeline.MaxStepNameLength),
+			Image:   "image",
+			Command: []string{"cmd"},
+		}, {
+			Name:    longName + "-x",
+			Image:   "image",
+			Command: []string{"cmd"},
+		}, {
+			Name:    longName + "-y",
+			Image:   "image",
+			Command: []string{"cmd"},
+		}},
+	}
+	builder := Builder{
+		Images:          images,
+		KubeClient:      kubeclient,
+		EntrypointCache: fakeCache{},
+	}
+	build := func() []string {
+		names.TestingSeed()
+		got, err := builder.Build(ctx, tr, ts)
+		if err != nil {
+			t.Fatalf("builder.Build: %v", err)
+		}
+		var containers []string
+		for _, c := range got.Spec.Containers {
+			containers = append(containers, c.Name)
+		}
+		return containers
+	}
+
+	got := build()
+	if len(got) != 3 {
+		t.Fatalf("Expected 3 step containers, got %v", got)
+	}
+	if want := "step-" + strings.Repeat("a", pipeline.MaxStepNameLength); got[0] != want {
+		t.Errorf("Expected the step name of the maximum length to be prefixed as %q, got %q", want, got[0])
+	}
+	for _, name := range got[1:] {
+		if len(name) != pipeline.MaxContainerNameLength {
+			t.Errorf("Expected the container name %q to be shortened to %d characters, got %d", name, pipeline.MaxContainerNameLength, len(name))
+		}
+		if !strings.HasPrefix(name, "step-"+longName[:45]) {
+			t.Errorf("Expected the container name %q to begin with the truncated step name", name)
+		}
+	}
+	if got[1] == got[2] {
+		t.Errorf("Expected the step names colliding once truncated to be run by distinct containers, got %q", got[1])
+	}
+	if d := cmp.Diff(got, build()); d != "" {
+		t.Errorf("Expected the same container names each time the pod is built %s", diff.PrintWantGot(d))
+	}
+}
+
+// TestPodBuild_LongStepNameContainerAgree tests that, for a step whose name is too long to be prefixed,
+// the step container, the directory of the step and the key of its results in the results sidecar are
+// all derived from the same shortened container name.
+func TestPodBuild_LongStepNameContainerAgree(t *testing.T) {
+	names.TestingSeed()
+	store := config.NewStore(logtesting.TestLogger(t))
+	store.OnConfigChanged(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: config.GetFeatureFlagsConfigName(), Namespace: system.Namespace()},
+		Data: map[string]string{
+			"enable-step-directory-isolation": "true",
+			"results-from":                    "sidecar-logs",
+		},
+	})
+	store.OnConfigChanged(&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: config.GetDefaultsConfigName(), Namespace: system.Namespace()}})
+	kubeclient := fakek8s.NewSimpleClientset(
+		&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "default"}},
+	)
+	tr := &v1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "taskrun-long-step-name",
+			Namespace:   "default",
+			Annotations: map[string]string{ReleaseAnnotation: fakeVersion},
+		},
+	}
+	stepName := strings.Repeat("a", pipeline.MaxStepNameLength+10)
+	ts := v1.TaskSpec{
+		Steps: []v1.Step{{
+			Name:    stepName,
+			Image:   "image",
+			Command: []string{"cmd"},
+			Results: []v1.StepResult{{Name: "digest"}},
+		}},
+		Results: []v1.TaskResult{{Name: "digest"}},
+	}
+	builder := Builder{
+		Images:          images,
+		KubeClient:      kubeclient,
+		EntrypointCache: fakeCache{},
+	}
+	got, err := builder.Build(store.ToContext(t.Context()), tr, ts)
+	if err != nil {
+		t.Fatalf("builder.Build: %v", err)
+	}
+
+	want := pipeline.StepContainerName(stepName)
+	if len(want) != pipeline.MaxContainerNameLength {
+		t.Fatalf("Expected the container name %q to be shortened to %d characters", want, pipeline.MaxContainerNameLength)
+	}
+	var step, resultsSidecar corev1.Container
+	for _, c := range got.Spec.Containers {
+		switch c.Name {
+		case want:
+			step = c
+		case pipeline.ReservedResultsSidecarContainerName:
+			resultsSidecar = c
+		}
+	}
+	if step.Name == "" {
+		t.Fatalf("Expected a step container named %q, got %v", want, got.Spec.Containers)
+	}
+	if !slices.ContainsFunc(step.VolumeMounts, func(vm corev1.VolumeMount) bool {
+		return vm.MountPath == filepath.Join(pipeline.StepsDir, want)
+	}) {
+		t.Errorf("Expected the directory of the step to be mounted at %s, got %v", filepath.Join(pipeline.StepsDir, want), step.VolumeMounts)
+	}
+	initCommand := got.Spec.InitContainers[0].Command
+	if initCommand[len(initCommand)-1] != want {
+		t.Errorf("Expected the init container to create the directory of the step %q, got %v", want, initCommand)
+	}
+	wantStepResults := fmt.Sprintf(`-step-results {"%s":["digest"]}`, want)
+	if gotCommand := strings.Join(resultsSidecar.Command, " "); !strings.Contains(gotCommand, wantStepResults) {
+		t.Errorf("Expected the results sidecar command to contain %q, got %q", wantStepResults, gotCommand)
+	}
+}
+
+func TestPodBuild_SidecarResults(t *testing.T) {
+	for _, tc := range []struct {
+		desc                   string
+		featureFlags           map[string]string
+		wantStepArgs           bool
+		wantResultsSidecarArgs bool
+	}{{
+		desc:         "sidecar results reported by the last step",
+		featureFlags: map[string]string{"enable-api-fields": "alpha"},
+		wantStepArgs: true,
+	}, {
+		desc:                   "sidecar results reported by the results sidecar",
+		featureFlags:           map[string]string{"enable-api-fields": "alpha", "results-from": "sidecar-logs"},
+		wantStepArgs:           true,
+		wantResultsSidecarArgs: true,
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			names.TestingSeed()
+			store := config.NewStore(logtesting.TestLogger(t))
+			store.OnConfigChanged(
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Name: config.GetFeatureFlagsConfigName(), Namespace: system.Namespace()},
+					Data:       tc.featureFlags,
+				},
+			)
+			store.OnConfigChanged(
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Name: config.GetDefaultsConfigName(), Namespace: system.Namespace()},
+				},
+			)
+			kubeclient := fakek8s.NewSimpleClientset(
+				&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "default"}},
+			)
+			tr := &v1.TaskRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "taskrun-sidecar-results",
+					Namespace:   "default",
+					Annotations: map[string]string{ReleaseAnnotation: fakeVersion},
+				},
+			}
+			ts := v1.TaskSpec{
+				Steps: []v1.Step{{
+					Name:    "first",
+					Image:   "image",
+					Command: []string{"cmd"},
+				}, {
+					Name:    "last",
+					Image:   "image",
+					Command: []string{"cmd"},
+				}},
+				Sidecars: []v1.Sidecar{{
+					Name:    "server",
+					Image:   "server",
+					Results: []v1.StepResult{{Name: "port"}, {Name: "address"}},
+				}, {
+					Name:  "other",
+					Image: "other",
+				}},
+			}
+
+			builder := Builder{
+				Images:          images,
+				KubeClient:      kubeclient,
+				EntrypointCache: fakeCache{},
+			}
+			got, err := builder.Build(store.ToContext(t.Context()), tr, ts)
+			if err != nil {
+				t.Fatalf("builder.Build: %v", err)
+			}
+
+			if !slices.ContainsFunc(got.Spec.Volumes, func(v corev1.Volume) bool { return v.Name == sidecarResultsVolumeName }) {
+				t.Errorf("expected volume %q, got %v", sidecarResultsVolumeName, got.Spec.Volumes)
+			}
+			containers := map[string]corev1.Container{}
+			for _, c := range got.Spec.Containers {
+				containers[c.Name] = c
+			}
+			for _, name := range []string{"step-first", "step-last"} {
+				if !slices.Contains(containers[name].VolumeMounts, sidecarResultsROMount) {
+					t.Errorf("expected mount %v in container %q, got %v", sidecarResultsROMount, name, containers[name].VolumeMounts)
+				}
+			}
+			if slices.Contains(containers["step-first"].Args, "-sidecar_results") {
+				t.Errorf("expected no sidecar results args in the first step, got %v", containers["step-first"].Args)
+			}
+			if gotArgs := strings.Join(containers["step-last"].Args, " "); strings.Contains(gotArgs, "-sidecar_results server.port,server.address") != tc.wantStepArgs {
+				t.Errorf("sidecar results args in the last step: got %q, want %v", gotArgs, tc.wantStepArgs)
+			}
+			if !slices.Contains(containers["sidecar-server"].VolumeMounts, sidecarResultsMount("server")) {
+				t.Errorf("expected mount %v in the sidecar, got %v", sidecarResultsMount("server"), containers["sidecar-server"].VolumeMounts)
+			}
+			if slices.ContainsFunc(containers["sidecar-other"].VolumeMounts, func(m corev1.VolumeMount) bool { return m.Name == sidecarResultsVolumeName }) {
+				t.Errorf("expected no sidecar results mount in a sidecar without results, got %v", containers["sidecar-other"].VolumeMounts)
+			}
+			resultsSidecar := containers[pipeline.ReservedResultsSidecarContainerName]
+			if gotArgs := strings.Join(resultsSidecar.Command, " "); strings.Contains(gotArgs, `-sidecar-results {"server":["port","address"]}`) != tc.wantResultsSidecarArgs {
+				t.Errorf("sidecar results args in the results sidecar: got %q, want %v", gotArgs, tc.wantResultsSidecarArgs)
+			}
+		})
+	}
+}
+
+func TestPodBuild_DisableInlineScripts(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		taskSpec v1.TaskSpec
+		wantErr  string
+	}{{
+		name: "step with a script",
+		taskSpec: v1.TaskSpec{Steps: []v1.Step{{
+			Name:    "build",
+			Image:   "image",
+			Command: []string{"cmd"},
+		}, {
+			Name:   "test",
+			Image:  "image",
+			Script: "echo hello",
+		}}},
+		wantErr: `step "test" can't have a script, scripts are disabled by the "disable-inline-scripts" feature flag`,
+	}, {
+		name: "sidecar with a script",
+		taskSpec: v1.TaskSpec{
+			Steps:    []v1.Step{{Name: "build", Image: "image", Command: []string{"cmd"}}},
+			Sidecars: []v1.Sidecar{{Name: "registry", Image: "image", Script: "echo hello"}},
+		},
+		wantErr: `sidecar "registry" can't have a script, scripts are disabled by the "disable-inline-scripts" feature flag`,
+	}, {
+		name: "steps and sidecars running commands",
+		taskSpec: v1.TaskSpec{
+			Steps:    []v1.Step{{Name: "build", Image: "image", Command: []string{"cmd"}}},
+			Sidecars: []v1.Sidecar{{Name: "registry", Image: "image", Command: []string{"cmd"}}},
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			store := config.NewStore(logtesting.TestLogger(t))
+			store.OnConfigChanged(&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: config.GetFeatureFlagsConfigName(), Namespace: system.Namespace()},
+				Data:       map[string]string{config.DisableInlineScriptsKey: "true"},
+			})
+			kubeclient := fakek8s.NewSimpleClientset(
+				&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "default"}},
+			)
+			builder := Builder{
+				Images:          images,
+				KubeClient:      kubeclient,
+				EntrypointCache: fakeCache{},
+			}
+			tr := &v1.TaskRun{ObjectMeta: metav1.ObjectMeta{Name: "taskrun-scripts", Namespace: "default"}}
+			_, err := builder.Build(store.ToContext(t.Context()), tr, tc.taskSpec)
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("builder.Build: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tc.wantErr {
+				t.Errorf("builder.Build() = %v, want %s", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestPodBuild_TraceContextEnv(t *testing.T) {
+	traceparent := "00-0f57e147e992b304d977436289d10628-73d5909e31793992-01"
+	for _, tc := range []struct {
+		name           string
+		exportRunSpans string
+		want           []corev1.EnvVar
+	}{{
+		name:           "run spans exported",
+		exportRunSpans: "true",
+		want:           append(implicitEnvVars, corev1.EnvVar{Name: "TRACEPARENT", Value: traceparent}, corev1.EnvVar{Name: "FOO", Value: "bar"}),
+	}, {
+		name:           "run spans not exported",
+		exportRunSpans: "false",
+		want:           append(implicitEnvVars, corev1.EnvVar{Name: "FOO", Value: "bar"}),
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			store := config.NewStore(logtesting.TestLogger(t))
+			store.OnConfigChanged(&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: config.GetFeatureFlagsConfigName(), Namespace: system.Namespace()}})
+			store.OnConfigChanged(&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: config.GetTracingConfigName(), Namespace: system.Namespace()},
+				Data:       map[string]string{"enabled": "true", "exportRunSpans": tc.exportRunSpans},
+			})
+			kubeclient := fakek8s.NewSimpleClientset(
+				&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "default"}},
+			)
+			builder := Builder{
+				Images:          images,
+				KubeClient:      kubeclient,
+				EntrypointCache: fakeCache{},
+			}
+			tr := &v1.TaskRun{
+				ObjectMeta: metav1.ObjectMeta{Name: "taskrun-traced", Namespace: "default"},
+				Status: v1.TaskRunStatus{TaskRunStatusFields: v1.TaskRunStatusFields{
+					SpanContext: map[string]string{"traceparent": traceparent},
+				}},
+			}
+			ts := v1.TaskSpec{Steps: []v1.Step{{
+				Name:    "build",
+				Image:   "image",
+				Command: []string{"cmd"},
+				Env:     []corev1.EnvVar{{Name: "FOO", Value: "bar"}},
+			}}}
+			got, err := builder.Build(store.ToContext(t.Context()), tr, ts)
+			if err != nil {
+				t.Fatalf("builder.Build: %v", err)
+			}
+			if d := cmp.Diff(tc.want, got.Spec.Containers[0].Env); d != "" {
+				t.Errorf("env of the step container %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
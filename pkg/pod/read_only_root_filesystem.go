@@ -0,0 +1,76 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"path/filepath"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	tmpDir        = "/tmp"
+	tmpVolumeName = "tekton-internal-tmp"
+)
+
+// tmpVolume is the volume mounted at /tmp in the containers whose root filesystem is made read-only.
+var tmpVolume = corev1.Volume{
+	Name:         tmpVolumeName,
+	VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+}
+
+// enforceReadOnlyRootFilesystem makes the root filesystem of the containers of the steps and sidecars read-only,
+// except for the ones setting readOnlyRootFilesystem in their security context, and mounts a directory of an
+// emptyDir volume of its own at /tmp in each of them, unless it already mounts a volume there. The directories of
+// /tekton the steps and the entrypoint write to are already mounted from emptyDir volumes. It returns the volume
+// to add to the pod, if any container needs it.
+func enforceReadOnlyRootFilesystem(stepContainers, sidecarContainers []corev1.Container) []corev1.Volume {
+	mounted := false
+	enforce := func(c *corev1.Container, subPath string) {
+		if c.SecurityContext != nil && c.SecurityContext.ReadOnlyRootFilesystem != nil {
+			return
+		}
+		c.SecurityContext = c.SecurityContext.DeepCopy()
+		if c.SecurityContext == nil {
+			c.SecurityContext = &corev1.SecurityContext{}
+		}
+		c.SecurityContext.ReadOnlyRootFilesystem = &readOnlyRootFilesystem
+		for _, vm := range c.VolumeMounts {
+			if filepath.Clean(vm.MountPath) == tmpDir {
+				return
+			}
+		}
+		c.VolumeMounts = append(c.VolumeMounts, corev1.VolumeMount{
+			Name:      tmpVolumeName,
+			MountPath: tmpDir,
+			SubPath:   subPath,
+		})
+		mounted = true
+	}
+	// The names of the step containers already have their prefix, unlike the ones of the sidecar containers.
+	for i := range stepContainers {
+		enforce(&stepContainers[i], stepContainers[i].Name)
+	}
+	for i := range sidecarContainers {
+		enforce(&sidecarContainers[i], pipeline.SidecarContainerName(sidecarContainers[i].Name))
+	}
+	if !mounted {
+		return nil
+	}
+	return []corev1.Volume{tmpVolume}
+}
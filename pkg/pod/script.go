@@ -17,12 +17,15 @@ limitations under the License.
 package pod
 
 import (
+	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
 	"fmt"
 	"path/filepath"
 	"strconv"
 	"strings"
 
+	"github.com/tektoncd/pipeline/pkg/apis/config"
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"github.com/tektoncd/pipeline/pkg/names"
 	corev1 "k8s.io/api/core/v1"
@@ -161,13 +164,8 @@ func placeScriptInContainer(script, scriptFile string, c *corev1.Container, init
 	if script == "" {
 		return
 	}
-	cleaned := strings.TrimSpace(script)
-	hasShebang := strings.HasPrefix(cleaned, "#!")
-	requiresWindows := strings.HasPrefix(cleaned, "#!win")
-
-	if !hasShebang {
-		script = defaultScriptPreamble + script
-	}
+	requiresWindows := strings.HasPrefix(strings.TrimSpace(script), "#!win")
+	script = scriptFileContent(script)
 
 	// Append to the place-scripts script to place the
 	// script file in a known location in the scripts volume.
@@ -204,6 +202,25 @@ cat > ${scriptfile} << '%s'
 	c.VolumeMounts = append(c.VolumeMounts, scriptsVolumeMount)
 }
 
+// scriptFileContent returns the content of the file a script is placed in, which starts with the
+// default preamble if the script has no shebang.
+func scriptFileContent(script string) string {
+	if !strings.HasPrefix(strings.TrimSpace(script), "#!") {
+		return defaultScriptPreamble + script
+	}
+	return script
+}
+
+// scriptSHA256 returns the hex-encoded SHA-256 digest of the file a Linux script is placed in, which
+// the entrypoint verifies before running it, or "" if there is no script or it is a Windows script.
+func scriptSHA256(script string) string {
+	if script == "" || strings.HasPrefix(strings.TrimSpace(script), "#!win") {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(scriptFileContent(script)))
+	return hex.EncodeToString(sum[:])
+}
+
 // encodeScript encodes a script field into a format that avoids kubernetes' built-in processing of container args,
 // which can mangle dollar signs and unexpectedly replace variable references in the user's script.
 func encodeScript(script string) string {
@@ -280,6 +297,22 @@ func hasScripts(steps []v1.Step, sidecars []v1.Sidecar, debugConfig *v1.TaskRunD
 	return debugConfig != nil && debugConfig.NeedsDebug()
 }
 
+// checkInlineScripts returns an error naming the first step or sidecar with a script, for the
+// TaskRuns whose spec wasn't validated with the "disable-inline-scripts" feature flag set.
+func checkInlineScripts(steps []v1.Step, sidecars []v1.Sidecar) error {
+	for _, s := range steps {
+		if s.Script != "" {
+			return fmt.Errorf("step %q can't have a script, scripts are disabled by the %q feature flag", s.Name, config.DisableInlineScriptsKey)
+		}
+	}
+	for _, s := range sidecars {
+		if s.Script != "" {
+			return fmt.Errorf("sidecar %q can't have a script, scripts are disabled by the %q feature flag", s.Name, config.DisableInlineScriptsKey)
+		}
+	}
+	return nil
+}
+
 func checkWindowsRequirement(steps []v1.Step, sidecars []v1.Sidecar) bool {
 	// Detect windows shebangs
 	for _, step := range steps {
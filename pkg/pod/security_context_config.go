@@ -17,6 +17,8 @@ limitations under the License.
 package pod
 
 import (
+	"slices"
+
 	corev1 "k8s.io/api/core/v1"
 )
 
@@ -65,3 +67,26 @@ func (c SecurityContextConfig) GetSecurityContext(isWindows bool) *corev1.Securi
 	securityContext.ReadOnlyRootFilesystem = &readOnlyRootFilesystem
 	return securityContext
 }
+
+// withStepCapabilities returns a copy of the security context of a step container, which may be nil,
+// with the capabilities of the step added and dropped on top of the ones it already sets.
+func withStepCapabilities(securityContext *corev1.SecurityContext, capabilities *corev1.Capabilities) *corev1.SecurityContext {
+	securityContext = securityContext.DeepCopy()
+	if securityContext == nil {
+		securityContext = &corev1.SecurityContext{}
+	}
+	if securityContext.Capabilities == nil {
+		securityContext.Capabilities = &corev1.Capabilities{}
+	}
+	for _, c := range capabilities.Add {
+		if !slices.Contains(securityContext.Capabilities.Add, c) {
+			securityContext.Capabilities.Add = append(securityContext.Capabilities.Add, c)
+		}
+	}
+	for _, c := range capabilities.Drop {
+		if !slices.Contains(securityContext.Capabilities.Drop, c) {
+			securityContext.Capabilities.Drop = append(securityContext.Capabilities.Drop, c)
+		}
+	}
+	return securityContext
+}
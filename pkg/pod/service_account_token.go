@@ -0,0 +1,61 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/pod"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// serviceAccountTokenMountPath is where Kubernetes mounts the token of the ServiceAccount in the containers.
+	serviceAccountTokenMountPath    = "/var/run/secrets/kubernetes.io/serviceaccount"
+	noServiceAccountTokenVolumeName = "tekton-internal-no-sa-token"
+)
+
+// noServiceAccountTokenVolume is the empty volume masking the token of the ServiceAccount in the steps opting out of it.
+var noServiceAccountTokenVolume = corev1.Volume{
+	Name:         noServiceAccountTokenVolumeName,
+	VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+}
+
+// maskServiceAccountTokens mounts an empty volume at the path of the token of the ServiceAccount in the
+// containers of the steps setting automountServiceAccountToken to false, so that Kubernetes doesn't mount
+// the token there. It returns the volume to add to the pod, if any step needs it. Nothing is masked when
+// the pod template already keeps the token from being mounted in the pod.
+func maskServiceAccountTokens(podTemplate pod.Template, steps []v1.Step, stepContainers []corev1.Container) []corev1.Volume {
+	if podTemplate.AutomountServiceAccountToken != nil && !*podTemplate.AutomountServiceAccountToken {
+		return nil
+	}
+	masked := false
+	for i, s := range steps {
+		if s.AutomountServiceAccountToken == nil || *s.AutomountServiceAccountToken {
+			continue
+		}
+		stepContainers[i].VolumeMounts = append(stepContainers[i].VolumeMounts, corev1.VolumeMount{
+			Name:      noServiceAccountTokenVolumeName,
+			MountPath: serviceAccountTokenMountPath,
+			ReadOnly:  true,
+		})
+		masked = true
+	}
+	if !masked {
+		return nil
+	}
+	return []corev1.Volume{noServiceAccountTokenVolume}
+}
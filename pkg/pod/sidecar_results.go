@@ -0,0 +1,155 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/result"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const sidecarResultsVolumeName = "tekton-internal-sidecar-results"
+
+var (
+	// sidecarResultsVolume holds the results of all the sidecars, each in a directory named after its sidecar.
+	sidecarResultsVolume = corev1.Volume{
+		Name:         sidecarResultsVolumeName,
+		VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+	}
+	// sidecarResultsROMount lets the steps and the results sidecar read the results of all the sidecars.
+	sidecarResultsROMount = corev1.VolumeMount{
+		Name:      sidecarResultsVolumeName,
+		MountPath: pipeline.SidecarsDir,
+		ReadOnly:  true,
+	}
+)
+
+// sidecarResultNames returns the names of the results declared by the sidecars, by sidecar name.
+// Sidecars that don't declare any result are left out.
+func sidecarResultNames(sidecars []v1.Sidecar) map[string][]string {
+	names := map[string][]string{}
+	for _, s := range sidecars {
+		for _, r := range s.Results {
+			names[s.Name] = append(names[s.Name], r.Name)
+		}
+	}
+	return names
+}
+
+// sidecarResultsMount mounts the directory of the sidecar results volume holding the results of
+// the named sidecar where the sidecar writes them, i.e. $(sidecar.results.<resultName>.path).
+func sidecarResultsMount(sidecarName string) corev1.VolumeMount {
+	return corev1.VolumeMount{
+		Name:      sidecarResultsVolumeName,
+		MountPath: pipeline.DefaultResultPath,
+		SubPath:   sidecarName,
+	}
+}
+
+// sidecarResultArgument creates the cli arguments for the entrypointer to report the results of
+// the sidecars in the termination message of the step, as <sidecarName>.<resultName>.
+func sidecarResultArgument(sidecars []v1.Sidecar) []string {
+	var names []string
+	for _, s := range sidecars {
+		for _, r := range s.Results {
+			names = append(names, s.Name+"."+r.Name)
+		}
+	}
+	if len(names) == 0 {
+		return nil
+	}
+	return []string{"-sidecar_results", strings.Join(names, ",")}
+}
+
+// getSidecarResults returns the RunResults holding the results of the sidecars.
+func getSidecarResults(runResults []result.RunResult) []result.RunResult {
+	sidecarResults := []result.RunResult{}
+	for _, r := range runResults {
+		if r.ResultType == result.SidecarResultType {
+			sidecarResults = append(sidecarResults, r)
+		}
+	}
+	return sidecarResults
+}
+
+// setSidecarResults sets the results of the sidecars, reported as <sidecarName>.<resultName>, in the
+// states of their sidecars, in the order they are declared in. The TaskResults whose value is fetched
+// from a sidecar result with $(sidecars.<sidecarName>.results.<resultName>) are set from them too.
+// Results that aren't declared by the sidecars are ignored.
+func setSidecarResults(trs *v1.TaskRunStatus, ts *v1.TaskSpec, specResults []v1.TaskResult, runResults []result.RunResult) {
+	if ts == nil || len(runResults) == 0 {
+		return
+	}
+	declaredTypes := map[string]v1.ResultsType{}
+	for _, sc := range ts.Sidecars {
+		for _, r := range sc.Results {
+			declaredTypes[sc.Name+"."+r.Name] = r.Type
+		}
+	}
+	values := map[string]v1.ResultValue{}
+	for _, r := range runResults {
+		t, ok := declaredTypes[r.Key]
+		if !ok {
+			continue
+		}
+		if t == v1.ResultsTypeString || t == "" {
+			values[r.Key] = *v1.NewStructuredValues(r.Value)
+			continue
+		}
+		v := v1.ResultValue{}
+		if err := v.UnmarshalJSON([]byte(r.Value)); err != nil {
+			continue
+		}
+		values[r.Key] = v
+	}
+
+	for i := range trs.Sidecars {
+		for _, sc := range ts.Sidecars {
+			if sc.Name != trs.Sidecars[i].Name {
+				continue
+			}
+			for _, r := range sc.Results {
+				if v, ok := values[sc.Name+"."+r.Name]; ok {
+					trs.Sidecars[i].Results = append(trs.Sidecars[i].Results, v1.TaskRunResult{
+						Name:  r.Name,
+						Type:  v1.ResultsType(v.Type),
+						Value: v,
+					})
+				}
+			}
+		}
+	}
+	for _, r := range specResults {
+		if r.Value == nil || !strings.HasPrefix(r.Value.StringVal, "$(sidecars.") {
+			continue
+		}
+		sidecarName, resultName, err := v1.ExtractSidecarResultName(r.Value.StringVal)
+		if err != nil {
+			continue
+		}
+		if v, ok := values[sidecarName+"."+resultName]; ok {
+			trs.Results = append(trs.Results, v1.TaskRunResult{
+				Name:  r.Name,
+				Type:  v1.ResultsType(v.Type),
+				Value: v,
+			})
+		}
+	}
+}
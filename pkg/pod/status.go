@@ -21,9 +21,12 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
+	"unicode"
 
 	"github.com/tektoncd/pipeline/internal/sidecarlogresults"
 	"github.com/tektoncd/pipeline/pkg/apis/config"
@@ -31,11 +34,16 @@ import (
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"github.com/tektoncd/pipeline/pkg/result"
 	"github.com/tektoncd/pipeline/pkg/termination"
+	"github.com/tektoncd/pipeline/pkg/workspace"
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/utils/clock"
 	"knative.dev/pkg/apis"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
 )
 
 // Aliased for backwards compatibility; do not add additional TaskRun reasons here
@@ -63,6 +71,10 @@ const (
 	// to resource constraints on the node
 	ReasonExceededNodeResources = "ExceededNodeResources"
 
+	// ReasonBlockedByAffinityAssistant indicates that the TaskRun's pod can't be scheduled on the
+	// node of the Affinity Assistant it must be co-located with
+	ReasonBlockedByAffinityAssistant = "TaskRunBlockedByAffinityAssistant"
+
 	// ReasonPullImageFailed indicates that the TaskRun's pod failed to pull image
 	ReasonPullImageFailed = "PullImageFailed"
 
@@ -70,6 +82,30 @@ const (
 	// config error of container
 	ReasonCreateContainerConfigError = "CreateContainerConfigError"
 
+	// ReasonImageDigestResolutionFailed indicates that the digest of one of the images of a TaskRun
+	// pinning its images to their digests couldn't be resolved
+	ReasonImageDigestResolutionFailed = "ImageDigestResolutionFailed"
+
+	// ReasonResultsSidecarRestarted indicates that the container of the results sidecar restarted
+	// while the TaskRun was running
+	ReasonResultsSidecarRestarted = "ResultsSidecarRestarted"
+
+	// ReasonStepResultsRejected indicates that step results were reported for a step which doesn't
+	// declare them while the step directories are isolated
+	ReasonStepResultsRejected = "StepResultsRejected"
+
+	// ReasonResultsSidecarHung indicates that the results sidecar didn't complete within the grace
+	// period after the steps finished, and that the results couldn't be read from its logs
+	ReasonResultsSidecarHung = "ResultsSidecarHung"
+
+	// ReasonStepContainerRestarted indicates that the container of a step was restarted, e.g. because
+	// a mutating webhook changed the restart policy of the pod, while Steps are expected to run once
+	ReasonStepContainerRestarted = "StepContainerRestarted"
+
+	// ReasonReadinessGatesPending indicates that the steps are waiting for the readiness gates of the
+	// pod, e.g. added by a mutating webhook, to be met before the first step starts
+	ReasonReadinessGatesPending = "ReadinessGatesPending"
+
 	// ReasonPodCreationFailed indicates that the reason for the current condition
 	// is that the creation of the pod backing the TaskRun failed
 	ReasonPodCreationFailed = "PodCreationFailed"
@@ -77,17 +113,43 @@ const (
 	// ReasonPodAdmissionFailed indicates that the TaskRun's pod failed to pass admission validation
 	ReasonPodAdmissionFailed = "PodAdmissionFailed"
 
+	// ReasonPodSecurityViolation indicates that the TaskRun's pod was rejected by the
+	// Pod Security admission controller
+	ReasonPodSecurityViolation = "PodSecurityViolation"
+
+	// ReasonTaskRunPodSecurityViolation indicates that the TaskRun's pod violates the Pod Security
+	// Standards enforced in its namespace, and wasn't created
+	ReasonTaskRunPodSecurityViolation = "TaskRunPodSecurityViolation"
+
 	// ReasonPending indicates that the pod is in corev1.Pending, and the reason is not
 	// ReasonExceededNodeResources or isPodHitConfigError
 	ReasonPodPending = "Pending"
 
+	// ReasonPodFailedScheduling indicates that the steps of a TaskRun are waiting for its pod, which
+	// couldn't be scheduled, to create their containers
+	ReasonPodFailedScheduling = "PodFailedScheduling"
+
+	// TerminationMessageResultsExtracted replaces a step's termination message in the TaskRun status
+	// when "enable-step-termination-message-trimming" is on and everything in the message has been
+	// extracted into step results, task results or artifacts
+	TerminationMessageResultsExtracted = "<results extracted>"
+
 	// timeFormat is RFC3339 with millisecond
 	timeFormat = "2006-01-02T15:04:05.000Z07:00"
+
+	// maxTerminationMessagePrefixLength is the number of bytes kept from the text an image
+	// writes to the termination log before the results appended by the entrypoint
+	maxTerminationMessagePrefixLength = 256
 )
 
 const (
 	oomKilled = "OOMKilled"
 	evicted   = "Evicted"
+
+	// startError and containerCannotRun are the reasons containerd and docker terminate a
+	// container with when it couldn't be started, e.g. because its command doesn't exist
+	startError         = "StartError"
+	containerCannotRun = "ContainerCannotRun"
 )
 
 // SidecarsReady returns true if all of the Pod's sidecars are Ready or
@@ -115,8 +177,98 @@ func SidecarsReady(podStatus corev1.PodStatus) bool {
 	return true
 }
 
-// MakeTaskRunStatus returns a TaskRunStatus based on the Pod's status.
+// ReadinessGatesReady returns true if the conditions of all of the readiness gates of the Pod are
+// True, so that the first step can start.
+func ReadinessGatesReady(pod *corev1.Pod) bool {
+	return len(pendingReadinessGates(pod)) == 0
+}
+
+// pendingReadinessGates returns the condition types of the readiness gates of the Pod whose
+// condition isn't True.
+func pendingReadinessGates(pod *corev1.Pod) []string {
+	var pending []string
+	for _, gate := range pod.Spec.ReadinessGates {
+		ready := false
+		for _, c := range pod.Status.Conditions {
+			if c.Type == gate.ConditionType {
+				ready = c.Status == corev1.ConditionTrue
+				break
+			}
+		}
+		if !ready {
+			pending = append(pending, string(gate.ConditionType))
+		}
+	}
+	return pending
+}
+
+// ObserveOptions are the inputs Status computes the status of a TaskRun from.
+type ObserveOptions struct {
+	// TaskRun is the TaskRun, with its previous status. It isn't modified.
+	TaskRun *v1.TaskRun
+	// Pod is the pod running the steps of the TaskRun.
+	Pod *corev1.Pod
+	// TaskSpec is the spec of the Task run by the TaskRun, the one recorded in the status of the
+	// TaskRun if nil.
+	// +optional
+	TaskSpec *v1.TaskSpec
+	// KubeClient reads the logs of the results sidecar of the pod. It is only required when the results
+	// are extracted from the sidecar logs, as configured by the feature flags of the context.
+	// +optional
+	KubeClient kubernetes.Interface
+	// Logger logs the problems found while interpreting the pod, the logger of the context if nil.
+	// +optional
+	Logger *zap.SugaredLogger
+	// Clock tells whether the results sidecar of the pod outlived its grace period, the real clock if nil.
+	// +optional
+	Clock clock.PassiveClock
+	// ObservedAt is the time the pod was observed at, which is the completion time of the TaskRun if the
+	// pod completed, and is used instead of the time of Clock if set, so that the caller knows it.
+	// +optional
+	ObservedAt time.Time
+	// AffinityAssistantPodLister gets the pod of the Affinity Assistant of the pod, to name its node when
+	// the pod can't be scheduled on it. The node isn't named if nil.
+	// +optional
+	AffinityAssistantPodLister corev1listers.PodLister
+}
+
+// Status returns the status of a TaskRun computed from its previous status and the state of its pod,
+// exactly as the TaskRun reconciler computes it, with the feature flags of ctx. It is the entry point
+// for tools outside of Tekton Pipelines interpreting the pods of TaskRuns, which can render the states
+// of the steps offline, without a KubeClient, unless the results are extracted from the sidecar logs.
+func Status(ctx context.Context, opts ObserveOptions) (v1.TaskRunStatus, error) {
+	if opts.TaskRun == nil || opts.Pod == nil {
+		return v1.TaskRunStatus{}, errors.New("the status of a TaskRun can't be computed without the TaskRun and its pod")
+	}
+	logger := opts.Logger
+	if logger == nil {
+		logger = logging.FromContext(ctx)
+	}
+	tr := opts.TaskRun.DeepCopy()
+	ts := opts.TaskSpec
+	if ts == nil {
+		ts = tr.Status.TaskSpec
+	}
+	if ts == nil {
+		ts = &v1.TaskSpec{}
+	}
+	now := opts.ObservedAt
+	if now.IsZero() {
+		now = time.Now()
+		if opts.Clock != nil {
+			now = opts.Clock.Now()
+		}
+	}
+	return makeTaskRunStatus(ctx, logger, *tr, opts.Pod, opts.KubeClient, opts.AffinityAssistantPodLister, ts, now)
+}
+
+// MakeTaskRunStatus returns a TaskRunStatus based on the Pod's status. The slices of the status of
+// tr may be modified, use Status to leave tr untouched.
 func MakeTaskRunStatus(ctx context.Context, logger *zap.SugaredLogger, tr v1.TaskRun, pod *corev1.Pod, kubeclient kubernetes.Interface, ts *v1.TaskSpec) (v1.TaskRunStatus, error) {
+	return makeTaskRunStatus(ctx, logger, tr, pod, kubeclient, nil, ts, time.Now())
+}
+
+func makeTaskRunStatus(ctx context.Context, logger *zap.SugaredLogger, tr v1.TaskRun, pod *corev1.Pod, kubeclient kubernetes.Interface, aaPodLister corev1listers.PodLister, ts *v1.TaskSpec, now time.Time) (v1.TaskRunStatus, error) {
 	trs := &tr.Status
 	if trs.GetCondition(apis.ConditionSucceeded) == nil || trs.GetCondition(apis.ConditionSucceeded).Status == corev1.ConditionUnknown {
 		// If the taskRunStatus doesn't exist yet, it's because we just started running
@@ -134,53 +286,159 @@ func MakeTaskRunStatus(ctx context.Context, logger *zap.SugaredLogger, tr v1.Tas
 		complete = complete && areInitContainersDone(ctx, pod)
 	}
 
+	// The TaskRun doesn't wait for a results sidecar which didn't complete within the grace period,
+	// its results are read from the logs it wrote so far.
+	resultsSidecarHung := !complete && IsResultsSidecarHung(ctx, pod.Status, now)
+	if resultsSidecarHung {
+		complete = true
+	}
+
 	if complete {
 		onError, ok := tr.Annotations[v1.PipelineTaskOnErrorAnnotation]
 		if ok {
-			updateCompletedTaskRunStatus(logger, trs, pod, v1.PipelineTaskOnErrorType(onError))
+			updateCompletedTaskRunStatus(logger, trs, pod, v1.PipelineTaskOnErrorType(onError), now)
 		} else {
-			updateCompletedTaskRunStatus(logger, trs, pod, "")
+			updateCompletedTaskRunStatus(logger, trs, pod, "", now)
 		}
 	} else {
-		updateIncompleteTaskRunStatus(trs, pod)
+		updateIncompleteTaskRunStatus(trs, pod, aaPodLister)
 	}
 
 	trs.PodName = pod.Name
 	trs.Sidecars = []v1.SidecarState{}
 
+	containers := newPodContainers(pod, ts, trs.ShortenedContainerNames)
+	trs.ShortenedContainerNames = containers.shortened
+	if pinned, err := pinnedImages(pod); err != nil {
+		logger.Errorf("Failed to read the pinned images of pod %s: %v", pod.Name, err)
+	} else if pinned != nil {
+		trs.PinnedImages = pinned
+	}
 	var stepStatuses []corev1.ContainerStatus
 	var sidecarStatuses []corev1.ContainerStatus
 	for _, s := range pod.Status.ContainerStatuses {
-		if IsContainerStep(s.Name) {
+		if _, ok := containers.stepName(s.Name); ok {
 			stepStatuses = append(stepStatuses, s)
-		} else if IsContainerSidecar(s.Name) {
+		} else if _, ok := containers.sidecarName(s.Name); ok {
 			sidecarStatuses = append(sidecarStatuses, s)
 		}
 	}
 	for _, s := range pod.Status.InitContainerStatuses {
-		if IsContainerSidecar(s.Name) {
+		if _, ok := containers.sidecarName(s.Name); ok {
 			sidecarStatuses = append(sidecarStatuses, s)
 		}
 	}
 
-	err := setTaskRunStatusBasedOnStepStatus(ctx, logger, stepStatuses, &tr, pod.Status.Phase, kubeclient, ts)
+	// The sidecar states are set first so that the results of the sidecars can be attached to them.
+	setTaskRunStatusBasedOnSidecarStatus(sidecarStatuses, containers, trs)
+
+	err := setTaskRunStatusBasedOnStepStatus(ctx, logger, stepStatuses, containers, &tr, pod.Status, kubeclient, ts, resultsSidecarHung)
+	if len(stepStatuses) == 0 {
+		setWaitingStepStates(trs, pod, ts, containers)
+	}
+	if resultsSidecarHung {
+		if recorder := controller.GetEventRecorder(ctx); recorder != nil {
+			recorder.Eventf(&tr, corev1.EventTypeWarning, ReasonResultsSidecarHung,
+				"The results sidecar container %s didn't complete within %s after the steps finished, the results were read from the logs it wrote so far",
+				pipeline.ReservedResultsSidecarContainerName, resultsSidecarGracePeriod(ctx))
+		}
+	}
+
+	// The expected digests are read from the status so that their params are already replaced.
+	if tr.IsDone() && trs.TaskSpec != nil {
+		verifyArtifactDigests(trs, trs.TaskSpec.ExpectedArtifactDigests)
+	}
 
-	setTaskRunStatusBasedOnSidecarStatus(sidecarStatuses, trs)
+	// Results are only extracted once the TaskRun is done, so the messages must be kept until then.
+	if config.FromContextOrDefaults(ctx).FeatureFlags.EnableStepTerminationMessageTrimming && tr.IsDone() {
+		trimStepTerminationMessages(logger, trs.Steps)
+	}
 
 	trs.Results = removeDuplicateResults(trs.Results)
 
 	return *trs, err
 }
 
-func createTaskResultsFromStepResults(stepRunRes []v1.TaskRunStepResult, neededStepResults map[string]string) []v1.TaskRunResult {
+// verifyArtifactDigests compares the digests of the output artifacts of the steps and the task with
+// the expected digests, marking the matching artifact values as verified. A successful TaskRun is
+// failed when a value doesn't match. Expected digests that are empty, and artifacts that weren't
+// produced, are skipped.
+func verifyArtifactDigests(trs *v1.TaskRunStatus, expected []v1.ExpectedArtifactDigest) {
+	var mismatches []string
+	verify := func(outputs []v1.Artifact) {
+		for _, e := range expected {
+			alg, digest, ok := strings.Cut(e.Digest, ":")
+			if !ok || digest == "" {
+				continue
+			}
+			for i := range outputs {
+				if outputs[i].Name != e.Name {
+					continue
+				}
+				for j, v := range outputs[i].Values {
+					if actual := v.Digest[v1.Algorithm(alg)]; actual == digest {
+						outputs[i].Values[j].Verified = true
+					} else if m := fmt.Sprintf("artifact %q has digest %q, expected %q", e.Name, alg+":"+actual, e.Digest); !slices.Contains(mismatches, m) {
+						// Step outputs are also merged into the task artifacts, so report each mismatch once.
+						mismatches = append(mismatches, m)
+					}
+				}
+			}
+		}
+	}
+	for _, step := range trs.Steps {
+		verify(step.Outputs)
+	}
+	if trs.Artifacts != nil {
+		verify(trs.Artifacts.Outputs)
+	}
+	if len(mismatches) > 0 && trs.GetCondition(apis.ConditionSucceeded).IsTrue() {
+		markStatusFailure(trs, v1.TaskRunReasonArtifactDigestMismatch.String(), strings.Join(mismatches, "; "))
+	}
+}
+
+// trimStepTerminationMessages replaces the termination message of each step with
+// TerminationMessageResultsExtracted when it only holds results and artifacts, which
+// are already stored in the step results, task results and artifacts of the status.
+// Messages that can't be parsed or that hold anything else are kept as they are.
+func trimStepTerminationMessages(logger *zap.SugaredLogger, steps []v1.StepState) {
+	for i := range steps {
+		terminated := steps[i].Terminated
+		if terminated == nil || terminated.Message == "" {
+			continue
+		}
+		results, err := termination.ParseMessage(logger, terminated.Message)
+		if err != nil {
+			continue
+		}
+		if slices.ContainsFunc(results, func(r result.RunResult) bool { return !isExtractedResultType(r.ResultType) }) {
+			continue
+		}
+		terminated.Message = TerminationMessageResultsExtracted
+	}
+}
+
+// isExtractedResultType returns true if results of the given type are extracted
+// from the termination message into the TaskRun status.
+func isExtractedResultType(t result.ResultType) bool {
+	switch t {
+	case result.TaskRunResultType, result.StepResultType, result.StepArtifactsResultType, result.TaskRunArtifactsResultType, result.SidecarResultType:
+		return true
+	default:
+		return false
+	}
+}
+
+func createTaskResultsFromStepResults(stepRunRes []v1.TaskRunStepResult, neededStepResults map[string][]string, stepName string) []v1.TaskRunResult {
 	taskResults := []v1.TaskRunResult{}
 	for _, r := range stepRunRes {
-		// this result was requested by the Task
-		if _, ok := neededStepResults[r.Name]; ok {
+		// this result was requested by the Task, possibly by several of its results
+		for _, name := range neededStepResults[r.Name] {
 			taskRunResult := v1.TaskRunResult{
-				Name:  neededStepResults[r.Name],
-				Type:  r.Type,
-				Value: r.Value,
+				Name:       name,
+				Type:       r.Type,
+				Value:      r.Value,
+				ProducedBy: stepName,
 			}
 			taskResults = append(taskResults, taskRunResult)
 		}
@@ -197,6 +455,34 @@ func setTaskRunArtifactsFromRunResult(runResults []result.RunResult, artifacts *
 	return nil
 }
 
+// resultsSidecarRestartCount returns the number of times the container of the results sidecar restarted,
+// as a regular or as a native sidecar.
+func resultsSidecarRestartCount(podStatus corev1.PodStatus) int32 {
+	for _, s := range append(slices.Clone(podStatus.InitContainerStatuses), podStatus.ContainerStatuses...) {
+		if s.Name == pipeline.ReservedResultsSidecarContainerName {
+			return s.RestartCount
+		}
+	}
+	return 0
+}
+
+// resultsSidecarTerminationMessage returns the termination message of the container of the results
+// sidecar, as a regular or as a native sidecar, or of its previous instance if it restarted since.
+func resultsSidecarTerminationMessage(podStatus corev1.PodStatus) string {
+	for _, s := range append(slices.Clone(podStatus.InitContainerStatuses), podStatus.ContainerStatuses...) {
+		if s.Name != pipeline.ReservedResultsSidecarContainerName {
+			continue
+		}
+		if s.State.Terminated != nil {
+			return s.State.Terminated.Message
+		}
+		if s.LastTerminationState.Terminated != nil {
+			return s.LastTerminationState.Terminated.Message
+		}
+	}
+	return ""
+}
+
 func getTaskResultsFromSidecarLogs(runResults []result.RunResult) []result.RunResult {
 	taskResultsFromSidecarLogs := []result.RunResult{}
 	for _, slr := range runResults {
@@ -224,7 +510,43 @@ func getStepResultsFromSidecarLogs(sidecarLogResults []result.RunResult, contain
 	return stepResultsFromSidecarLogs, nil
 }
 
-func setTaskRunStatusBasedOnStepStatus(ctx context.Context, logger *zap.SugaredLogger, stepStatuses []corev1.ContainerStatus, tr *v1.TaskRun, podPhase corev1.PodPhase, kubeclient kubernetes.Interface, ts *v1.TaskSpec) error {
+// rejectUndeclaredStepResults drops the step results reported for the step run by containerName
+// which the step doesn't declare in stepResults, and returns an error naming them: as only its own
+// step can write into the directory of a step when the step directories are isolated, these results
+// claim to be written by the step without coming from it.
+func rejectUndeclaredStepResults(results []result.RunResult, containerName string, stepResults []v1.StepResult) ([]result.RunResult, error) {
+	declared := make(map[string]bool, len(stepResults))
+	for _, r := range stepResults {
+		declared[r.Name] = true
+	}
+	var kept []result.RunResult
+	var rejected []string
+	for _, r := range results {
+		if r.ResultType == result.StepResultType && !declared[r.Key] {
+			rejected = append(rejected, r.Key)
+			continue
+		}
+		kept = append(kept, r)
+	}
+	if len(rejected) > 0 {
+		return kept, fmt.Errorf("rejected the step results %s reported for step container %s: the step doesn't declare them, so they weren't written by it", strings.Join(rejected, ", "), containerName)
+	}
+	return kept, nil
+}
+
+// recordRejectedStepResults logs the error of the step results rejected by rejectUndeclaredStepResults
+// and, once the TaskRun is done, reports it with an event rather than failing the reconciliation.
+func recordRejectedStepResults(ctx context.Context, logger *zap.SugaredLogger, tr *v1.TaskRun, err error) {
+	logger.Errorf("error reading the step results of taskrun %q: %v", tr.Name, err)
+	if !tr.IsDone() {
+		return
+	}
+	if recorder := controller.GetEventRecorder(ctx); recorder != nil {
+		recorder.Event(tr, corev1.EventTypeWarning, ReasonStepResultsRejected, err.Error())
+	}
+}
+
+func setTaskRunStatusBasedOnStepStatus(ctx context.Context, logger *zap.SugaredLogger, stepStatuses []corev1.ContainerStatus, containers podContainers, tr *v1.TaskRun, podStatus corev1.PodStatus, kubeclient kubernetes.Interface, ts *v1.TaskSpec, resultsSidecarHung bool) error {
 	trs := &tr.Status
 	var errs []error
 
@@ -238,15 +560,55 @@ func setTaskRunStatusBasedOnStepStatus(ctx context.Context, logger *zap.SugaredL
 	}
 
 	// Extract results from sidecar logs
-	sidecarLogsResultsEnabled := config.FromContextOrDefaults(ctx).FeatureFlags.ResultExtractionMethod == config.ResultExtractionMethodSidecarLogs
+	featureFlags := config.FromContextOrDefaults(ctx).FeatureFlags
 	// temporary solution to check if artifacts sidecar created in taskRun as we don't have the api for users to declare if a step/task is producing artifacts yet
 	artifactsSidecarCreated := artifactsPathReferenced(ts.Steps)
+	resultsSidecarCreated := featureFlags.UsesResultsSidecar() && (tr.Status.TaskSpec.Results != nil || artifactsSidecarCreated || len(sidecarResultNames(ts.Sidecars)) > 0)
 	sidecarLogResults := []result.RunResult{}
 
-	if sidecarLogsResultsEnabled {
+	switch featureFlags.ResultExtractionMethod {
+	case config.ResultExtractionMethodSidecarLogs:
 		// extraction of results from sidecar logs
-		if tr.Status.TaskSpec.Results != nil || artifactsSidecarCreated {
-			slr, err := sidecarlogresults.GetResultsFromSidecarLogs(ctx, kubeclient, tr.Namespace, tr.Status.PodName, pipeline.ReservedResultsSidecarContainerName, podPhase)
+		if resultsSidecarCreated {
+			if kubeclient == nil {
+				return errors.New("the results are extracted from the sidecar logs, which can't be read without a kube client")
+			}
+			restarts := resultsSidecarRestartCount(podStatus)
+			slr, err := sidecarlogresults.GetResultsFromSidecarLogs(ctx, kubeclient, tr.Namespace, tr.Status.PodName, pipeline.ReservedResultsSidecarContainerName, podStatus.Phase, restarts)
+			switch {
+			case err != nil && resultsSidecarHung && !errors.Is(err, sidecarlogresults.ErrSizeExceeded):
+				// The results of a hung results sidecar can't be read again later, the TaskRun fails
+				// unless it already failed for another reason.
+				logger.Errorf("Failed to read the results from the logs of the hung results sidecar of TaskRun %s: %v", tr.Name, err)
+				if trs.GetCondition(apis.ConditionSucceeded).IsTrue() {
+					markStatusFailure(trs, ReasonResultsSidecarHung, fmt.Sprintf(
+						"The results sidecar container %s didn't complete within %s after the steps finished and its logs couldn't be read: %v",
+						pipeline.ReservedResultsSidecarContainerName, resultsSidecarGracePeriod(ctx), err))
+				}
+			case err != nil:
+				errs = append(errs, err)
+			}
+			// The results are recorded once the TaskRun is done, so is the restart of the results sidecar.
+			if restarts > 0 && tr.IsDone() {
+				if recorder := controller.GetEventRecorder(ctx); recorder != nil {
+					if restarts == 1 {
+						recorder.Eventf(tr, corev1.EventTypeWarning, ReasonResultsSidecarRestarted,
+							"The results sidecar container %s restarted once, the results it emitted before restarting were read from the logs of its previous instance",
+							pipeline.ReservedResultsSidecarContainerName)
+					} else {
+						recorder.Eventf(tr, corev1.EventTypeWarning, ReasonResultsSidecarRestarted,
+							"The results sidecar container %s restarted %d times, only the results it emitted before its last restart were read from the logs of its previous instance, the ones emitted before its earlier restarts are lost",
+							pipeline.ReservedResultsSidecarContainerName, restarts)
+					}
+				}
+			}
+			sidecarLogResults = append(sidecarLogResults, slr...)
+		}
+	case config.ResultExtractionMethodSidecarVolume:
+		// extraction of results from the termination message of the results sidecar, which are
+		// available once it terminated
+		if resultsSidecarCreated {
+			slr, err := sidecarlogresults.GetResultsFromTerminationMessage(ctx, resultsSidecarTerminationMessage(podStatus))
 			if err != nil {
 				errs = append(errs, err)
 			}
@@ -268,24 +630,32 @@ func setTaskRunStatusBasedOnStepStatus(ctx context.Context, logger *zap.SugaredL
 		}
 	}
 
+	// Sidecar results are reported by the results sidecar or in the termination message of the last step
+	sidecarResults := getSidecarResults(sidecarLogResults)
+
 	// Build a lookup map for step state provenances.
 	stepStateProvenances := make(map[string]*v1.Provenance)
 	for _, ss := range trs.Steps {
 		stepStateProvenances[ss.Name] = ss.Provenance
 	}
 
+	// With isolated step directories, a step can only write the results of its own directory, so the
+	// step results its step doesn't declare have been spoofed.
+	isolatedStepDirs := config.FromContextOrDefaults(ctx).FeatureFlags.EnableStepDirectoryIsolation && ts != nil
+
 	// Continue with extraction of termination messages
 	orderedStepStates := make([]v1.StepState, len(stepStatuses))
 	for i, s := range stepStatuses {
 		// Avoid changing the original value by modifying the pointer value.
-		state := s.State.DeepCopy()
+		state := stepContainerState(s, podStatus.Phase)
 		taskRunStepResults := []v1.TaskRunStepResult{}
 
 		// Identify Step Results
+		stepName, _ := containers.stepName(s.Name)
 		stepResults := []v1.StepResult{}
 		if ts != nil {
 			for _, step := range ts.Steps {
-				if GetContainerName(step.Name) == s.Name {
+				if pipeline.StepContainerName(step.Name) == s.Name {
 					stepResults = append(stepResults, step.Results...)
 				}
 			}
@@ -301,11 +671,17 @@ func setTaskRunStatusBasedOnStepStatus(ctx context.Context, logger *zap.SugaredL
 		if err != nil {
 			errs = append(errs, err)
 		}
+		if isolatedStepDirs {
+			stepResultsFromSidecarLogs, err = rejectUndeclaredStepResults(stepResultsFromSidecarLogs, s.Name, stepResults)
+			if err != nil {
+				recordRejectedStepResults(ctx, logger, tr, err)
+			}
+		}
 		_, stepRunRes, _ := filterResults(stepResultsFromSidecarLogs, specResults, stepResults)
 		if tr.IsDone() {
 			taskRunStepResults = append(taskRunStepResults, stepRunRes...)
 			// Set TaskResults from StepResults
-			trs.Results = append(trs.Results, createTaskResultsFromStepResults(stepRunRes, neededStepResults)...)
+			trs.Results = append(trs.Results, createTaskResultsFromStepResults(stepRunRes, neededStepResults, stepName)...)
 		}
 		var sas v1.Artifacts
 
@@ -317,10 +693,15 @@ func setTaskRunStatusBasedOnStepStatus(ctx context.Context, logger *zap.SugaredL
 
 		// Parse termination messages
 		terminationReason := ""
-		if state.Terminated != nil && len(state.Terminated.Message) != 0 {
+		retryCount := 0
+		if isContainerStartError(state.Terminated) {
+			// The termination message of a step which couldn't be started is the error of the
+			// container runtime, not the results written by the entrypoint.
+			terminationReason = TerminationReasonRunContainerError
+		} else if state.Terminated != nil && len(state.Terminated.Message) != 0 {
 			msg := state.Terminated.Message
 
-			results, err := termination.ParseMessage(logger, msg)
+			prefix, results, err := parseTerminationMessage(logger, msg)
 			if err != nil {
 				logger.Errorf("termination message could not be parsed sas JSON: %v", err)
 				errs = append(errs, err)
@@ -340,12 +721,27 @@ func setTaskRunStatusBasedOnStepStatus(ctx context.Context, logger *zap.SugaredL
 					logger.Errorf("error extracting the exit code of step %q in taskrun %q: %v", s.Name, tr.Name, err)
 					errs = append(errs, err)
 				}
+				retryCount, err = extractRetryCountFromResults(results)
+				if err != nil {
+					logger.Errorf("error extracting the retry count of step %q in taskrun %q: %v", s.Name, tr.Name, err)
+					errs = append(errs, err)
+				}
 
+				sidecarResults = append(sidecarResults, getSidecarResults(results)...)
+				if isolatedStepDirs {
+					if results, err = rejectUndeclaredStepResults(results, s.Name, stepResults); err != nil {
+						recordRejectedStepResults(ctx, logger, tr, err)
+					}
+				}
 				taskResults, stepRunRes, filteredResults := filterResults(results, specResults, stepResults)
 				if tr.IsDone() {
 					taskRunStepResults = append(taskRunStepResults, stepRunRes...)
+					// The results in the termination message of a step were written by the step
+					for i := range taskResults {
+						taskResults[i].ProducedBy = stepName
+					}
 					// Set TaskResults from StepResults
-					taskResults = append(taskResults, createTaskResultsFromStepResults(stepRunRes, neededStepResults)...)
+					taskResults = append(taskResults, createTaskResultsFromStepResults(stepRunRes, neededStepResults, stepName)...)
 					trs.Results = append(trs.Results, taskResults...)
 
 					var tras v1.Artifacts
@@ -362,7 +758,7 @@ func setTaskRunStatusBasedOnStepStatus(ctx context.Context, logger *zap.SugaredL
 					logger.Errorf("%v", err)
 					errs = append(errs, err)
 				} else {
-					state.Terminated.Message = msg
+					state.Terminated.Message = truncateTerminationMessagePrefix(prefix) + msg
 				}
 				if time != nil {
 					state.Terminated.StartedAt = *time
@@ -377,11 +773,12 @@ func setTaskRunStatusBasedOnStepStatus(ctx context.Context, logger *zap.SugaredL
 		}
 		stepState := v1.StepState{
 			ContainerState:    *state.DeepCopy(),
-			Name:              TrimStepPrefix(s.Name),
+			Name:              stepName,
 			Container:         s.Name,
 			ImageID:           s.ImageID,
 			Results:           taskRunStepResults,
 			TerminationReason: terminationReason,
+			RetryCount:        retryCount,
 			Inputs:            sas.Inputs,
 			Outputs:           sas.Outputs,
 		}
@@ -393,10 +790,76 @@ func setTaskRunStatusBasedOnStepStatus(ctx context.Context, logger *zap.SugaredL
 	if len(orderedStepStates) > 0 {
 		trs.Steps = orderedStepStates
 	}
+	if tr.IsDone() {
+		setSidecarResults(trs, ts, specResults, sidecarResults)
+	}
 
 	return errors.Join(errs...)
 }
 
+// setWaitingStepStates sets the states of all the steps of ts, whose containers weren't created in pod,
+// e.g. because it couldn't be scheduled or was rejected by its node, to waiting for the pod, so that the
+// status lists all the steps of the Task. The states are replaced by the ones of the containers once
+// they are created. The steps which already have a state other than waiting are kept.
+func setWaitingStepStates(trs *v1.TaskRunStatus, pod *corev1.Pod, ts *v1.TaskSpec, containers podContainers) {
+	if ts == nil || len(ts.Steps) == 0 || slices.ContainsFunc(trs.Steps, func(s v1.StepState) bool { return s.Waiting == nil }) {
+		return
+	}
+	containerNames := make(map[string]string, len(containers.steps))
+	for container, step := range containers.steps {
+		containerNames[step] = container
+	}
+	reason, message := podWaitingReason(pod)
+	steps := WaitingStepStates(ts, reason, message)
+	for i := range steps {
+		if container, ok := containerNames[steps[i].Name]; ok {
+			steps[i].Container = container
+		}
+	}
+	trs.Steps = steps
+}
+
+// WaitingStepStates returns the states of all the steps of ts waiting for their containers to be created
+// with reason and message, e.g. when the pod of the TaskRun couldn't be created, so that the status of the
+// TaskRun lists all the steps of the Task.
+func WaitingStepStates(ts *v1.TaskSpec, reason, message string) []v1.StepState {
+	if ts == nil {
+		return nil
+	}
+	steps := make([]v1.StepState, 0, len(ts.Steps))
+	for _, step := range ts.Steps {
+		steps = append(steps, v1.StepState{
+			ContainerState: corev1.ContainerState{
+				Waiting: &corev1.ContainerStateWaiting{Reason: reason, Message: message},
+			},
+			Name:      step.Name,
+			Container: pipeline.StepContainerName(step.Name),
+		})
+	}
+	return steps
+}
+
+// podWaitingReason returns the reason and message of the state of the steps waiting for pod to create
+// their containers: the reason of pod if it has one, e.g. when it was rejected by its node,
+// ReasonPodFailedScheduling when it couldn't be scheduled, or the reason of its first condition
+// which isn't true.
+func podWaitingReason(pod *corev1.Pod) (string, string) {
+	if pod.Status.Reason != "" {
+		return pod.Status.Reason, pod.Status.Message
+	}
+	for _, c := range pod.Status.Conditions {
+		if c.Type == corev1.PodScheduled && c.Status == corev1.ConditionFalse {
+			return ReasonPodFailedScheduling, c.Message
+		}
+	}
+	for _, c := range pod.Status.Conditions {
+		if c.Status != corev1.ConditionTrue && c.Reason != "" {
+			return c.Reason, c.Message
+		}
+	}
+	return ReasonPodPending, pod.Status.Message
+}
+
 func setStepArtifactsValueFromSidecarLogResult(results []result.RunResult, name string, artifacts *v1.Artifacts) error {
 	for _, r := range results {
 		if r.Key == name && r.ResultType == result.StepArtifactsResultType {
@@ -415,17 +878,203 @@ func setStepArtifactsValueFromTerminationMessageRunResult(results []result.RunRe
 	return nil
 }
 
-func setTaskRunStatusBasedOnSidecarStatus(sidecarStatuses []corev1.ContainerStatus, trs *v1.TaskRunStatus) {
+func setTaskRunStatusBasedOnSidecarStatus(sidecarStatuses []corev1.ContainerStatus, containers podContainers, trs *v1.TaskRunStatus) {
 	for _, s := range sidecarStatuses {
+		name, _ := containers.sidecarName(s.Name)
 		trs.Sidecars = append(trs.Sidecars, v1.SidecarState{
 			ContainerState: *s.State.DeepCopy(),
-			Name:           TrimSidecarPrefix(s.Name),
+			Name:           name,
 			Container:      s.Name,
 			ImageID:        s.ImageID,
 		})
 	}
 }
 
+// FailedSidecar returns the state of the first sidecar of ts whose onError is "stopAndFail" and which
+// terminated with a non-zero exit code, as reported in trs, while some steps are still running. A
+// sidecar restarted by Kubernetes, as the sidecars run as init containers are, failed if the last
+// termination of its container in pod did, and its returned state is that termination.
+func FailedSidecar(trs *v1.TaskRunStatus, pod *corev1.Pod, ts *v1.TaskSpec) (v1.SidecarState, bool) {
+	if ts == nil || stepsTerminated(trs.Steps) {
+		return v1.SidecarState{}, false
+	}
+	stopAndFail := map[string]bool{}
+	for _, sc := range ts.Sidecars {
+		if sc.OnError == v1.StopAndFail {
+			stopAndFail[sc.Name] = true
+		}
+	}
+	if len(stopAndFail) == 0 {
+		return v1.SidecarState{}, false
+	}
+	for _, sc := range trs.Sidecars {
+		if !stopAndFail[sc.Name] {
+			continue
+		}
+		if sc.Terminated != nil && sc.Terminated.ExitCode != 0 {
+			return sc, true
+		}
+		if last := lastTermination(pod, sc.Container); last != nil && last.ExitCode != 0 {
+			sc.ContainerState = corev1.ContainerState{Terminated: last.DeepCopy()}
+			return sc, true
+		}
+	}
+	return v1.SidecarState{}, false
+}
+
+// lastTermination returns the last termination of the container of pod named name before it was
+// restarted, nil if it wasn't.
+func lastTermination(pod *corev1.Pod, name string) *corev1.ContainerStateTerminated {
+	if pod == nil {
+		return nil
+	}
+	for _, statuses := range [][]corev1.ContainerStatus{pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses} {
+		for _, s := range statuses {
+			if s.Name == name {
+				return s.LastTerminationState.Terminated
+			}
+		}
+	}
+	return nil
+}
+
+// stepsTerminated returns true if all of the steps have started and terminated.
+func stepsTerminated(steps []v1.StepState) bool {
+	if len(steps) == 0 {
+		return false
+	}
+	for _, s := range steps {
+		if s.Terminated == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// podContainers attributes the containers of a pod to the steps and sidecars of the Task it runs.
+type podContainers struct {
+	// steps and sidecars map the names of the containers of the pod spec to the names of the steps
+	// and sidecars they run.
+	steps    map[string]string
+	sidecars map[string]string
+	// shortened lists the containers whose names are not the prefixed names of their steps or sidecars.
+	shortened []v1.ShortenedContainerName
+}
+
+// newPodContainers attributes the containers of pod to the steps and sidecars of ts by their exact
+// names in the pod spec rather than by their prefixes, as step and sidecar names can begin with
+// "step-" or "sidecar-" themselves and long names are shortened in container names. The shortened
+// container names recorded in the status of the TaskRun take precedence over the ones computed from
+// ts, so that the steps and sidecars keep their names even if the shortening changes.
+func newPodContainers(pod *corev1.Pod, ts *v1.TaskSpec, recorded []v1.ShortenedContainerName) podContainers {
+	c := podContainers{steps: map[string]string{}, sidecars: map[string]string{}}
+	if ts == nil {
+		c.shortened = recorded
+		return c
+	}
+	inSpec := map[string]bool{}
+	for _, container := range append(slices.Clone(pod.Spec.InitContainers), pod.Spec.Containers...) {
+		inSpec[container.Name] = true
+	}
+	add := func(names map[string]string, container, name, prefix string) {
+		if !inSpec[container] {
+			return
+		}
+		if _, ok := c.steps[container]; ok {
+			return
+		}
+		if _, ok := c.sidecars[container]; ok {
+			return
+		}
+		names[container] = name
+		if container != prefix+name {
+			sc := v1.ShortenedContainerName{Container: container}
+			if prefix == pipeline.StepContainerPrefix {
+				sc.Step = name
+			} else {
+				sc.Sidecar = name
+			}
+			c.shortened = append(c.shortened, sc)
+		}
+	}
+	for _, sc := range recorded {
+		if sc.Step != "" {
+			add(c.steps, sc.Container, sc.Step, pipeline.StepContainerPrefix)
+		} else if sc.Sidecar != "" {
+			add(c.sidecars, sc.Container, sc.Sidecar, pipeline.SidecarContainerPrefix)
+		}
+	}
+	for _, step := range ts.Steps {
+		add(c.steps, pipeline.StepContainerName(step.Name), step.Name, pipeline.StepContainerPrefix)
+	}
+	for _, sidecar := range ts.Sidecars {
+		add(c.sidecars, pipeline.SidecarContainerName(sidecar.Name), sidecar.Name, pipeline.SidecarContainerPrefix)
+	}
+	return c
+}
+
+// stepName returns the name of the step run by container, if it runs one. The containers which don't
+// run a step or a sidecar of the Task spec, e.g. when the spec isn't known, fall back to their prefix.
+func (c podContainers) stepName(container string) (string, bool) {
+	if name, ok := c.steps[container]; ok {
+		return name, true
+	}
+	if _, ok := c.sidecars[container]; ok || !IsContainerStep(container) {
+		return "", false
+	}
+	return TrimStepPrefix(container), true
+}
+
+// sidecarName returns the name of the sidecar run by container, if it runs one, falling back to the
+// prefix of the containers which are not in the Task spec, e.g. the results sidecar injected by Tekton.
+func (c podContainers) sidecarName(container string) (string, bool) {
+	if name, ok := c.sidecars[container]; ok {
+		return name, true
+	}
+	if _, ok := c.steps[container]; ok || !IsContainerSidecar(container) {
+		return "", false
+	}
+	return TrimSidecarPrefix(container), true
+}
+
+// parseTerminationMessage parses the results of a step's termination message.
+// Some images write their own text to the termination log before the entrypoint
+// appends the results to it, so when the message as a whole is not a JSON array
+// of results, the last well-formed one it ends with is parsed instead and the text
+// preceding it is returned along with the results.
+// If the message doesn't end with such an array, the original parsing error is returned.
+func parseTerminationMessage(logger *zap.SugaredLogger, msg string) (string, []result.RunResult, error) {
+	results, err := termination.ParseMessage(logger, msg)
+	if err == nil {
+		return "", results, nil
+	}
+	trimmed := strings.TrimRightFunc(msg, unicode.IsSpace)
+	if !strings.HasSuffix(trimmed, "]") {
+		return "", nil, err
+	}
+	// The first opening bracket that starts a valid array is the one of the outermost
+	// array the message ends with, so brackets nested in it are never considered.
+	for i := 1; i < len(trimmed); i++ {
+		if trimmed[i] != '[' {
+			continue
+		}
+		if r, perr := termination.ParseMessage(logger, trimmed[i:]); perr == nil {
+			logger.Warnf("termination message has non-JSON text before its results, ignoring %d bytes", i)
+			return msg[:i], r, nil
+		}
+	}
+	return "", nil, err
+}
+
+// truncateTerminationMessagePrefix truncates the non-JSON text found before the results of a
+// termination message so that it can be kept in the step state for debugging.
+func truncateTerminationMessagePrefix(prefix string) string {
+	if len(prefix) <= maxTerminationMessagePrefixLength {
+		return prefix
+	}
+	return prefix[:maxTerminationMessagePrefixLength] + "...(truncated)"
+}
+
 func createMessageFromResults(results []result.RunResult) (string, error) {
 	if len(results) == 0 {
 		return "", nil
@@ -440,18 +1089,18 @@ func createMessageFromResults(results []result.RunResult) (string, error) {
 // findStepResultsFetchedByTask fetches step results that the Task needs.
 // It accepts a container name and the TaskResults as input and outputs
 // a map with the name of the step result as the key and the name of the task result that is fetching it as value.
-func findStepResultsFetchedByTask(containerName string, specResults []v1.TaskResult) (map[string]string, error) {
-	neededStepResults := map[string]string{}
+func findStepResultsFetchedByTask(containerName string, specResults []v1.TaskResult) (map[string][]string, error) {
+	neededStepResults := map[string][]string{}
 	for _, r := range specResults {
 		if r.Value != nil {
-			if r.Value.StringVal != "" {
+			if r.Value.StringVal != "" && !strings.HasPrefix(r.Value.StringVal, "$(sidecars.") {
 				sName, resultName, err := v1.ExtractStepResultName(r.Value.StringVal)
 				if err != nil {
 					return nil, err
 				}
 				// Only look at named results - referencing unnamed steps is unsupported.
-				if GetContainerName(sName) == containerName {
-					neededStepResults[resultName] = r.Name
+				if pipeline.StepContainerName(sName) == containerName {
+					neededStepResults[resultName] = append(neededStepResults[resultName], r.Name)
 				}
 			}
 		}
@@ -462,14 +1111,23 @@ func findStepResultsFetchedByTask(containerName string, specResults []v1.TaskRes
 // filterResults filters the RunResults and TaskResults based on the results declared in the task spec.
 // It returns a slice of any of the input results that are defined in the task spec, converted to TaskRunResults,
 // and a slice of any of the RunResults that don't represent internal values (i.e. those that should not be displayed in the TaskRun status.
+//
+// A RunResult is attributed by its ResultType only, even when its key is both the name of a StepResult and of a
+// TaskResult: StepResultType RunResults become TaskRunStepResults and TaskRunResultType ones become TaskRunResults.
+// TaskResults whose value is fetched from a StepResult or a sidecar result take their value from that result only,
+// so the values written to their path by any step are not turned into TaskRunResults.
 func filterResults(results []result.RunResult, specResults []v1.TaskResult, stepResults []v1.StepResult) ([]v1.TaskRunResult, []v1.TaskRunStepResult, []result.RunResult) {
 	var taskResults []v1.TaskRunResult
 	var taskRunStepResults []v1.TaskRunStepResult
 	var filteredResults []result.RunResult
 	neededTypes := make(map[string]v1.ResultsType)
 	neededStepTypes := make(map[string]v1.ResultsType)
+	fetchedFromResults := make(map[string]bool)
 	for _, r := range specResults {
 		neededTypes[r.Name] = r.Type
+		if r.Value != nil && r.Value.StringVal != "" {
+			fetchedFromResults[r.Name] = true
+		}
 	}
 	for _, r := range stepResults {
 		neededStepTypes[r.Name] = r.Type
@@ -477,6 +1135,10 @@ func filterResults(results []result.RunResult, specResults []v1.TaskResult, step
 	for _, r := range results {
 		switch r.ResultType {
 		case result.TaskRunResultType:
+			if fetchedFromResults[r.Key] {
+				filteredResults = append(filteredResults, r)
+				continue
+			}
 			var taskRunResult v1.TaskRunResult
 			if neededTypes[r.Key] == v1.ResultsTypeString {
 				taskRunResult = v1.TaskRunResult{
@@ -584,6 +1246,19 @@ func extractExitCodeFromResults(results []result.RunResult) (*int32, error) {
 	return nil, nil //nolint:nilnil // would be more ergonomic to return a sentinel error
 }
 
+func extractRetryCountFromResults(results []result.RunResult) (int, error) {
+	for _, r := range results {
+		if r.ResultType == result.InternalTektonResultType && r.Key == "RetryCount" {
+			i, err := strconv.Atoi(r.Value)
+			if err != nil {
+				return 0, fmt.Errorf("could not parse int value %q in RetryCount field: %w", r.Value, err)
+			}
+			return i, nil
+		}
+	}
+	return 0, nil
+}
+
 func extractTerminationReasonFromResults(results []result.RunResult) string {
 	for _, r := range results {
 		if r.ResultType == result.InternalTektonResultType && r.Key == "Reason" {
@@ -605,7 +1280,7 @@ func getTerminationReason(terminatedStateReason string, terminationFromResults s
 	return terminatedStateReason
 }
 
-func updateCompletedTaskRunStatus(logger *zap.SugaredLogger, trs *v1.TaskRunStatus, pod *corev1.Pod, onError v1.PipelineTaskOnErrorType) {
+func updateCompletedTaskRunStatus(logger *zap.SugaredLogger, trs *v1.TaskRunStatus, pod *corev1.Pod, onError v1.PipelineTaskOnErrorType, completionTime time.Time) {
 	if DidTaskRunFail(pod) {
 		msg := getFailureMessage(logger, pod)
 		if onError == v1.PipelineTaskContinue {
@@ -618,18 +1293,47 @@ func updateCompletedTaskRunStatus(logger *zap.SugaredLogger, trs *v1.TaskRunStat
 		markStatusSuccess(trs)
 	}
 
-	// update tr completed time
-	trs.CompletionTime = &metav1.Time{Time: time.Now()}
+	// update tr completed time, which can't precede its start time when the clock of the controller
+	// is behind the one of the controller which started the TaskRun
+	if skew := ClockSkew(trs, completionTime); skew > 0 {
+		logger.Warnf("The completion time of the TaskRun precedes its start time by %s, setting it to its start time", skew)
+		completionTime = trs.StartTime.Time
+	}
+	trs.CompletionTime = &metav1.Time{Time: completionTime}
+}
+
+// ClockSkewAnnotation is set on a TaskRun whose completion time preceded its start time, to the
+// duration by which it did. Its completion time is set to its start time instead.
+const ClockSkewAnnotation = "tekton.dev/clock-skew-detected"
+
+// ClockSkew returns the duration by which completionTime precedes the start time of the TaskRun
+// with status trs, which happens when the clocks of the nodes of the controller are skewed, and 0
+// when it doesn't precede it.
+func ClockSkew(trs *v1.TaskRunStatus, completionTime time.Time) time.Duration {
+	if trs.StartTime == nil || !completionTime.Before(trs.StartTime.Time) {
+		return 0
+	}
+	return trs.StartTime.Sub(completionTime)
 }
 
-func updateIncompleteTaskRunStatus(trs *v1.TaskRunStatus, pod *corev1.Pod) {
+func updateIncompleteTaskRunStatus(trs *v1.TaskRunStatus, pod *corev1.Pod, aaPodLister corev1listers.PodLister) {
 	switch pod.Status.Phase {
 	case corev1.PodRunning:
-		markStatusRunning(trs, v1.TaskRunReasonRunning.String(), "Not all Steps in the Task have finished executing")
+		if name, restarts, ok := restartedStepContainer(pod.Status); ok {
+			markStatusRunning(trs, ReasonStepContainerRestarted, fmt.Sprintf(
+				"The step container %s was restarted %d times while Steps are expected to run exactly once, its last termination is ignored until the pod completes",
+				name, restarts))
+		} else if pending := pendingReadinessGates(pod); len(pending) > 0 && pod.Annotations[readyAnnotation] != readyAnnotationValue {
+			markStatusRunning(trs, ReasonReadinessGatesPending, "Waiting for the readiness gates of the pod to be met before the Steps start: "+strings.Join(pending, ", "))
+		} else {
+			markStatusRunning(trs, v1.TaskRunReasonRunning.String(), "Not all Steps in the Task have finished executing")
+		}
 	case corev1.PodPending:
 		switch {
 		case IsPodExceedingNodeResources(pod):
 			markStatusRunning(trs, ReasonExceededNodeResources, "TaskRun Pod exceeded available resources")
+		case IsPodBlockedByAffinityAssistant(pod):
+			markStatusRunning(trs, ReasonBlockedByAffinityAssistant, affinityAssistantBlockedMessage(pod, aaPodLister))
 		case isSubPathDirectoryError(pod):
 			// if subPath directory creation errors, mark as running and wait for recovery
 			markStatusRunning(trs, ReasonPodPending, "Waiting for subPath directory creation to complete")
@@ -645,6 +1349,28 @@ func updateIncompleteTaskRunStatus(trs *v1.TaskRunStatus, pod *corev1.Pod) {
 	}
 }
 
+// restartedStepContainer returns the name and the restart count of the first step container of the pod
+// which was restarted.
+func restartedStepContainer(podStatus corev1.PodStatus) (string, int32, bool) {
+	for _, s := range podStatus.ContainerStatuses {
+		if IsContainerStep(s.Name) && s.RestartCount > 0 {
+			return s.Name, s.RestartCount, true
+		}
+	}
+	return "", 0, false
+}
+
+// stepContainerState returns the state of the container of a step. A restarted step container runs
+// the step again, so its last termination is only taken as the outcome of the step once the pod is
+// terminal and the container can't run anymore.
+func stepContainerState(s corev1.ContainerStatus, phase corev1.PodPhase) *corev1.ContainerState {
+	terminal := phase == corev1.PodSucceeded || phase == corev1.PodFailed
+	if terminal && s.RestartCount > 0 && s.State.Terminated == nil && s.LastTerminationState.Terminated != nil {
+		return s.LastTerminationState.DeepCopy()
+	}
+	return s.State.DeepCopy()
+}
+
 // isPodCompleted checks if the given pod is completed.
 // A pod is considered completed if its phase is either "Succeeded" or "Failed".
 //
@@ -734,8 +1460,8 @@ func areInitContainersDone(ctx context.Context, pod *corev1.Pod) bool {
 // areContainersCompleted returns true if all related containers in the pod are completed.
 func areContainersCompleted(ctx context.Context, pod *corev1.Pod) bool {
 	nameFilters := []containerNameFilter{IsContainerStep}
-	if config.FromContextOrDefaults(ctx).FeatureFlags.ResultExtractionMethod == config.ResultExtractionMethodSidecarLogs {
-		// If we are using sidecar logs to extract results, we need to wait for the sidecar to complete.
+	if config.FromContextOrDefaults(ctx).FeatureFlags.UsesResultsSidecar() {
+		// If we are using the results sidecar to extract results, we need to wait for the sidecar to complete.
 		// Avoid failing to obtain the final result from the sidecar because the sidecar is not yet complete.
 		nameFilters = append(nameFilters, func(name string) bool {
 			return name == pipeline.ReservedResultsSidecarContainerName
@@ -744,6 +1470,52 @@ func areContainersCompleted(ctx context.Context, pod *corev1.Pod) bool {
 	return checkContainersCompleted(pod, nameFilters)
 }
 
+// ResultsSidecarGraceDeadline returns the time after which the results sidecar of a pod is considered
+// hung, which is the grace period configured with "default-sidecar-log-results-grace-period" after the
+// last of the steps finished. It returns false if the results are not extracted by the results sidecar,
+// if the grace period is 0, or if the steps or the results sidecar are still running.
+func ResultsSidecarGraceDeadline(ctx context.Context, podStatus corev1.PodStatus) (time.Time, bool) {
+	gracePeriod := resultsSidecarGracePeriod(ctx)
+	if !config.FromContextOrDefaults(ctx).FeatureFlags.UsesResultsSidecar() || gracePeriod <= 0 ||
+		podStatus.Phase != corev1.PodRunning {
+		return time.Time{}, false
+	}
+	var stepsFinished time.Time
+	resultsSidecarRunning := false
+	for _, s := range append(slices.Clone(podStatus.InitContainerStatuses), podStatus.ContainerStatuses...) {
+		switch {
+		case s.Name == pipeline.ReservedResultsSidecarContainerName:
+			resultsSidecarRunning = s.State.Terminated == nil
+		case IsContainerStep(s.Name):
+			// Steps whose end time isn't known can't be used to tell how long the results sidecar ran for.
+			if s.State.Terminated == nil || s.State.Terminated.FinishedAt.IsZero() {
+				return time.Time{}, false
+			}
+			if finished := s.State.Terminated.FinishedAt.Time; finished.After(stepsFinished) {
+				stepsFinished = finished
+			}
+		}
+	}
+	if !resultsSidecarRunning || stepsFinished.IsZero() {
+		return time.Time{}, false
+	}
+	return stepsFinished.Add(gracePeriod), true
+}
+
+func resultsSidecarGracePeriod(ctx context.Context) time.Duration {
+	if defaults := config.FromContextOrDefaults(ctx).Defaults; defaults != nil {
+		return defaults.DefaultSidecarLogResultsGracePeriod
+	}
+	return config.DefaultSidecarLogResultsGracePeriod
+}
+
+// IsResultsSidecarHung returns true if the results sidecar of a pod is still running once the grace
+// period after the end of the steps expired at the given time.
+func IsResultsSidecarHung(ctx context.Context, podStatus corev1.PodStatus, now time.Time) bool {
+	deadline, ok := ResultsSidecarGraceDeadline(ctx, podStatus)
+	return ok && !now.Before(deadline)
+}
+
 // checkContainersCompleted returns true if containers in the pod are completed.
 func checkContainersCompleted(pod *corev1.Pod, nameFilters []containerNameFilter) bool {
 	if len(pod.Status.ContainerStatuses) == 0 ||
@@ -930,11 +1702,18 @@ func extractContainerFailureMessage(logger *zap.SugaredLogger, status corev1.Con
 	term := status.State.Terminated
 	if term != nil {
 		msg := status.State.Terminated.Message
-		r, _ := termination.ParseMessage(logger, msg)
+		_, r, _ := parseTerminationMessage(logger, msg)
 		for _, runResult := range r {
 			if runResult.ResultType == result.InternalTektonResultType && runResult.Key == "Reason" && runResult.Value == TerminationReasonTimeoutExceeded {
 				return fmt.Sprintf("%q exited because the step exceeded the specified timeout limit", status.Name)
 			}
+			if runResult.ResultType == result.InternalTektonResultType && runResult.Key == "Reason" && runResult.Value == TerminationReasonScriptTampered {
+				return fmt.Sprintf("%q exited because its script was modified after it was placed, and it wasn't run", status.Name)
+			}
+		}
+		if isContainerStartError(term) && term.Message != "" {
+			// Include the error of the container runtime, e.g. the command of the step not being found
+			return fmt.Sprintf("%q exited with code %d: %s: %s", status.Name, term.ExitCode, term.Reason, term.Message)
 		}
 		if term.ExitCode != 0 {
 			// Include the termination reason, if available to add clarity for causes such as external signals, e.g. OOM
@@ -948,6 +1727,11 @@ func extractContainerFailureMessage(logger *zap.SugaredLogger, status corev1.Con
 	return ""
 }
 
+// isContainerStartError returns true if the container runtime couldn't start the container.
+func isContainerStartError(term *corev1.ContainerStateTerminated) bool {
+	return term != nil && (term.Reason == startError || term.Reason == containerCannotRun)
+}
+
 // IsPodExceedingNodeResources returns true if the Pod's status indicates there
 // are insufficient resources to schedule the Pod.
 func IsPodExceedingNodeResources(pod *corev1.Pod) bool {
@@ -959,6 +1743,36 @@ func IsPodExceedingNodeResources(pod *corev1.Pod) bool {
 	return false
 }
 
+// IsPodBlockedByAffinityAssistant returns true if the Pod's status indicates it can't be
+// scheduled while it must be co-located with an Affinity Assistant, whose node, e.g. a cordoned
+// node, doesn't accept it.
+func IsPodBlockedByAffinityAssistant(pod *corev1.Pod) bool {
+	if pod.Annotations[workspace.AnnotationAffinityAssistantName] == "" {
+		return false
+	}
+	for _, podStatus := range pod.Status.Conditions {
+		if podStatus.Reason == corev1.PodReasonUnschedulable {
+			return true
+		}
+	}
+	return false
+}
+
+// affinityAssistantBlockedMessage returns the message of a TaskRun whose pod is blocked by its
+// Affinity Assistant, naming the StatefulSet of the Affinity Assistant along with the node of its
+// pod when it is known to aaPodLister.
+func affinityAssistantBlockedMessage(pod *corev1.Pod, aaPodLister corev1listers.PodLister) string {
+	name := pod.Annotations[workspace.AnnotationAffinityAssistantName]
+	if aaPodLister != nil {
+		// The pod of the StatefulSet is assigned ordinal 0 as its replicas are set to 1
+		aaPod, err := aaPodLister.Pods(pod.Namespace).Get(name + "-0")
+		if err == nil && aaPod.Spec.NodeName != "" {
+			return fmt.Sprintf("TaskRun Pod can't be scheduled on node %s of the Affinity Assistant StatefulSet %s", aaPod.Spec.NodeName, name)
+		}
+	}
+	return fmt.Sprintf("TaskRun Pod can't be scheduled on the node of the Affinity Assistant StatefulSet %s", name)
+}
+
 // hasContainerWaitingReason checks if any container (init or regular) is waiting with a reason
 // that matches the provided predicate function
 func hasContainerWaitingReason(pod *corev1.Pod, predicate func(corev1.ContainerStateWaiting) bool) bool {
@@ -1074,18 +1888,31 @@ func markStatusSuccess(trs *v1.TaskRunStatus) {
 }
 
 // sortPodContainerStatuses reorders a pod's container statuses so that
-// they're in the same order as the step containers from the TaskSpec.
+// they're in the same order as the step containers from the TaskSpec. The
+// statuses of containers which aren't in the spec sort last, in their original order.
 func sortPodContainerStatuses(podContainerStatuses []corev1.ContainerStatus, podSpecContainers []corev1.Container) {
-	statuses := map[string]corev1.ContainerStatus{}
-	for _, status := range podContainerStatuses {
-		statuses[status.Name] = status
-	}
+	indexes := make(map[string]int, len(podSpecContainers))
 	for i, c := range podSpecContainers {
-		// prevent out-of-bounds panic on incorrectly formed lists
-		if i < len(podContainerStatuses) {
-			podContainerStatuses[i] = statuses[c.Name]
+		indexes[c.Name] = i
+	}
+	// The positions of the statuses are sorted rather than the statuses, which are large structs.
+	keys := make([]int, len(podContainerStatuses))
+	positions := make([]int, len(podContainerStatuses))
+	for i := range podContainerStatuses {
+		keys[i] = len(podSpecContainers)
+		if index, ok := indexes[podContainerStatuses[i].Name]; ok {
+			keys[i] = index
 		}
+		positions[i] = i
+	}
+	sort.SliceStable(positions, func(i, j int) bool {
+		return keys[positions[i]] < keys[positions[j]]
+	})
+	sorted := make([]corev1.ContainerStatus, len(podContainerStatuses))
+	for i, position := range positions {
+		sorted[i] = podContainerStatuses[position]
 	}
+	copy(podContainerStatuses, sorted)
 }
 
 func isOOMKilled(s corev1.ContainerStatus) bool {
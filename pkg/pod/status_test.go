@@ -17,8 +17,11 @@ limitations under the License.
 package pod
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"reflect"
+	"slices"
 	"strings"
 	"testing"
 	"time"
@@ -27,19 +30,30 @@ import (
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/tektoncd/pipeline/internal/sidecarlogresults"
 	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"github.com/tektoncd/pipeline/pkg/result"
+	"github.com/tektoncd/pipeline/pkg/workspace"
 	"github.com/tektoncd/pipeline/test/diff"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
 	fakek8s "k8s.io/client-go/kubernetes/fake"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	testclock "k8s.io/utils/clock/testing"
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/controller"
 	"knative.dev/pkg/logging"
 )
 
 var ignoreVolatileTime = cmp.Comparer(func(_, _ apis.VolatileTime) bool { return true })
 
+// ignoreReasonHistory ignores the reason history, which is covered by TestRecordReason
+var ignoreReasonHistory = cmpopts.IgnoreFields(v1.TaskRunStatusFields{}, "ReasonHistory")
+
 func TestSetTaskRunStatusBasedOnStepStatus(t *testing.T) {
 	for _, c := range []struct {
 		desc              string
@@ -104,7 +118,7 @@ func TestSetTaskRunStatusBasedOnStepStatus(t *testing.T) {
 			for _, cs := range c.ContainerStatuses {
 				originalStatuses = append(originalStatuses, *cs.DeepCopy())
 			}
-			gotErr := setTaskRunStatusBasedOnStepStatus(t.Context(), logger, c.ContainerStatuses, &tr, corev1.PodRunning, kubeclient, &v1.TaskSpec{})
+			gotErr := setTaskRunStatusBasedOnStepStatus(t.Context(), logger, c.ContainerStatuses, podContainers{}, &tr, corev1.PodStatus{Phase: corev1.PodRunning}, kubeclient, &v1.TaskSpec{}, false)
 			if gotErr != nil {
 				t.Errorf("setTaskRunStatusBasedOnStepStatus: %s", gotErr)
 			}
@@ -237,7 +251,7 @@ func TestSetTaskRunStatusBasedOnStepStatus_sidecar_logs(t *testing.T) {
 			ctx := config.ToContext(t.Context(), &config.Config{
 				FeatureFlags: featureFlags,
 			})
-			gotErr := setTaskRunStatusBasedOnStepStatus(ctx, logger, []corev1.ContainerStatus{{}}, &c.tr, pod.Status.Phase, kubeclient, ts)
+			gotErr := setTaskRunStatusBasedOnStepStatus(ctx, logger, []corev1.ContainerStatus{{}}, newPodContainers(pod, ts, nil), &c.tr, pod.Status, kubeclient, ts, false)
 			if gotErr == nil {
 				t.Fatalf("Expected error but got nil")
 			}
@@ -248,6 +262,167 @@ func TestSetTaskRunStatusBasedOnStepStatus_sidecar_logs(t *testing.T) {
 	}
 }
 
+func TestSetTaskRunStatusBasedOnStepStatus_ResultsSidecarRestarted(t *testing.T) {
+	for _, c := range []struct {
+		desc         string
+		status       corev1.ConditionStatus
+		restartCount int32
+		wantEvents   []string
+	}{{
+		desc:         "running",
+		status:       corev1.ConditionUnknown,
+		restartCount: 2,
+	}, {
+		desc:         "done after one restart",
+		status:       corev1.ConditionTrue,
+		restartCount: 1,
+		wantEvents:   []string{"Warning ResultsSidecarRestarted The results sidecar container sidecar-tekton-log-results restarted once, the results it emitted before restarting were read from the logs of its previous instance"},
+	}, {
+		desc:         "done after several restarts",
+		status:       corev1.ConditionTrue,
+		restartCount: 2,
+		wantEvents:   []string{"Warning ResultsSidecarRestarted The results sidecar container sidecar-tekton-log-results restarted 2 times, only the results it emitted before its last restart were read from the logs of its previous instance, the ones emitted before its earlier restarts are lost"},
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			logger, _ := logging.NewLogger("", "status")
+			tr := v1.TaskRun{
+				ObjectMeta: metav1.ObjectMeta{Name: "task-run", Namespace: "foo"},
+				Status: v1.TaskRunStatus{
+					Status: duckv1.Status{Conditions: []apis.Condition{{Type: apis.ConditionSucceeded, Status: c.status}}},
+					TaskRunStatusFields: v1.TaskRunStatusFields{
+						PodName:  "task-run-pod",
+						TaskSpec: &v1.TaskSpec{Results: []v1.TaskResult{{Name: "digest"}}},
+					},
+				},
+			}
+			podStatus := corev1.PodStatus{
+				Phase: corev1.PodRunning,
+				ContainerStatuses: []corev1.ContainerStatus{{
+					Name:         pipeline.ReservedResultsSidecarContainerName,
+					RestartCount: c.restartCount,
+				}},
+			}
+			recorder := record.NewFakeRecorder(10)
+			ctx := controller.WithEventRecorder(t.Context(), recorder)
+			ctx = config.ToContext(ctx, &config.Config{
+				FeatureFlags: &config.FeatureFlags{
+					ResultExtractionMethod: config.ResultExtractionMethodSidecarLogs,
+					MaxResultSize:          4096,
+				},
+			})
+			// The fake client doesn't return valid logs, so that reading the results fails.
+			_ = setTaskRunStatusBasedOnStepStatus(ctx, logger, nil, podContainers{}, &tr, podStatus, fakek8s.NewSimpleClientset(), &v1.TaskSpec{}, false)
+
+			var events []string
+			close(recorder.Events)
+			for e := range recorder.Events {
+				events = append(events, e)
+			}
+			if d := cmp.Diff(c.wantEvents, events); d != "" {
+				t.Errorf("Unexpected events %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestSetTaskRunStatusBasedOnStepStatus_SidecarVolume(t *testing.T) {
+	message := `{"name":"digest","value":"sha256:1234","type":"task"}
+{"name":"step-foo.version","value":"1.0","type":"step"}
+`
+	for _, c := range []struct {
+		desc            string
+		sidecarStatus   corev1.ContainerStatus
+		wantResults     []v1.TaskRunResult
+		wantStepResults []v1.TaskRunStepResult
+		wantErr         string
+	}{{
+		desc: "results sidecar terminated",
+		sidecarStatus: corev1.ContainerStatus{
+			Name:  pipeline.ReservedResultsSidecarContainerName,
+			State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Message: message}},
+		},
+		wantResults:     []v1.TaskRunResult{{Name: "digest", Type: v1.ResultsTypeString, Value: *v1.NewStructuredValues("sha256:1234")}},
+		wantStepResults: []v1.TaskRunStepResult{{Name: "version", Type: v1.ResultsTypeString, Value: *v1.NewStructuredValues("1.0")}},
+	}, {
+		desc: "results sidecar restarted",
+		sidecarStatus: corev1.ContainerStatus{
+			Name:                 pipeline.ReservedResultsSidecarContainerName,
+			RestartCount:         1,
+			State:                corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+			LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Message: message}},
+		},
+		wantResults:     []v1.TaskRunResult{{Name: "digest", Type: v1.ResultsTypeString, Value: *v1.NewStructuredValues("sha256:1234")}},
+		wantStepResults: []v1.TaskRunStepResult{{Name: "version", Type: v1.ResultsTypeString, Value: *v1.NewStructuredValues("1.0")}},
+	}, {
+		desc: "results sidecar running",
+		sidecarStatus: corev1.ContainerStatus{
+			Name:  pipeline.ReservedResultsSidecarContainerName,
+			State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+		},
+	}, {
+		desc: "invalid termination message",
+		sidecarStatus: corev1.ContainerStatus{
+			Name:  pipeline.ReservedResultsSidecarContainerName,
+			State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Message: "invalid"}},
+		},
+		wantErr: "invalid result \"\": invalid character 'i' looking for beginning of value",
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			logger, _ := logging.NewLogger("", "status")
+			ts := &v1.TaskSpec{
+				Results: []v1.TaskResult{{Name: "digest"}},
+				Steps:   []v1.Step{{Name: "foo", Results: []v1.StepResult{{Name: "version"}}}},
+			}
+			tr := v1.TaskRun{
+				ObjectMeta: metav1.ObjectMeta{Name: "task-run", Namespace: "foo"},
+				Status: v1.TaskRunStatus{
+					Status: duckv1.Status{Conditions: []apis.Condition{{Type: apis.ConditionSucceeded, Status: corev1.ConditionTrue}}},
+					TaskRunStatusFields: v1.TaskRunStatusFields{
+						PodName:  "task-run-pod",
+						TaskSpec: ts,
+					},
+				},
+			}
+			stepStatuses := []corev1.ContainerStatus{{
+				Name:  "step-foo",
+				State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{}},
+			}}
+			podStatus := corev1.PodStatus{
+				Phase:             corev1.PodSucceeded,
+				ContainerStatuses: append(stepStatuses, c.sidecarStatus),
+			}
+			ctx := config.ToContext(t.Context(), &config.Config{
+				FeatureFlags: &config.FeatureFlags{
+					ResultExtractionMethod: config.ResultExtractionMethodSidecarVolume,
+					MaxResultSize:          4096,
+				},
+			})
+			pod := &corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "step-foo"}}}}
+			// The results are read from the status of the pod, without a kube client.
+			err := setTaskRunStatusBasedOnStepStatus(ctx, logger, stepStatuses, newPodContainers(pod, ts, nil), &tr, podStatus, nil, ts, false)
+			if c.wantErr != "" {
+				if err == nil || err.Error() != c.wantErr {
+					t.Fatalf("setTaskRunStatusBasedOnStepStatus() = %v, want error %q", err, c.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("setTaskRunStatusBasedOnStepStatus: %v", err)
+			}
+			if d := cmp.Diff(c.wantResults, tr.Status.Results, cmpopts.EquateEmpty()); d != "" {
+				t.Errorf("Unexpected results %s", diff.PrintWantGot(d))
+			}
+			var gotStepResults []v1.TaskRunStepResult
+			for _, s := range tr.Status.Steps {
+				gotStepResults = append(gotStepResults, s.Results...)
+			}
+			if d := cmp.Diff(c.wantStepResults, gotStepResults, cmpopts.EquateEmpty()); d != "" {
+				t.Errorf("Unexpected step results %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 func TestMakeTaskRunStatus_StepResults(t *testing.T) {
 	for _, c := range []struct {
 		desc      string
@@ -313,9 +488,10 @@ func TestMakeTaskRunStatus_StepResults(t *testing.T) {
 				Sidecars:  []v1.SidecarState{},
 				Artifacts: &v1.Artifacts{},
 				Results: []v1.TaskRunResult{{
-					Name:  "task-result",
-					Type:  v1.ResultsTypeString,
-					Value: *v1.NewStructuredValues("https://foo.bar\n"),
+					Name:       "task-result",
+					Type:       v1.ResultsTypeString,
+					Value:      *v1.NewStructuredValues("https://foo.bar\n"),
+					ProducedBy: "one",
 				}},
 				// We don't actually care about the time, just that it's not nil
 				CompletionTime: &metav1.Time{Time: time.Now()},
@@ -379,9 +555,10 @@ func TestMakeTaskRunStatus_StepResults(t *testing.T) {
 				Sidecars:  []v1.SidecarState{},
 				Artifacts: &v1.Artifacts{},
 				Results: []v1.TaskRunResult{{
-					Name:  "resultName",
-					Type:  v1.ResultsTypeArray,
-					Value: *v1.NewStructuredValues("hello", "world"),
+					Name:       "resultName",
+					Type:       v1.ResultsTypeArray,
+					Value:      *v1.NewStructuredValues("hello", "world"),
+					ProducedBy: "one",
 				}},
 				// We don't actually care about the time, just that it's not nil
 				CompletionTime: &metav1.Time{Time: time.Now()},
@@ -452,66 +629,33 @@ func TestMakeTaskRunStatus_StepResults(t *testing.T) {
 				Sidecars:  []v1.SidecarState{},
 				Artifacts: &v1.Artifacts{},
 				Results: []v1.TaskRunResult{{
-					Name:  "resultDigest",
-					Type:  v1.ResultsTypeString,
-					Value: *v1.NewStructuredValues("sha256:1234"),
+					Name:       "resultDigest",
+					Type:       v1.ResultsTypeString,
+					Value:      *v1.NewStructuredValues("sha256:1234"),
+					ProducedBy: "one",
 				}},
 				// We don't actually care about the time, just that it's not nil
 				CompletionTime: &metav1.Time{Time: time.Now()},
 			},
 		},
-	}} {
-		t.Run(c.desc, func(t *testing.T) {
-			now := metav1.Now()
-			if cmp.Diff(c.pod, corev1.Pod{}) == "" {
-				c.pod = corev1.Pod{
-					ObjectMeta: metav1.ObjectMeta{
-						Name:              "pod",
-						Namespace:         "foo",
-						CreationTimestamp: now,
-					},
-					Status: c.podStatus,
-				}
-			}
-
-			logger, _ := logging.NewLogger("", "status")
-			kubeclient := fakek8s.NewSimpleClientset()
-			got, err := MakeTaskRunStatus(t.Context(), logger, c.tr, &c.pod, kubeclient, c.tr.Spec.TaskSpec)
-			if err != nil {
-				t.Errorf("MakeTaskRunResult: %s", err)
-			}
-
-			// Common traits, set for test case brevity.
-			c.want.PodName = "pod"
-
-			ensureTimeNotNil := cmp.Comparer(func(x, y *metav1.Time) bool {
-				if x == nil {
-					return y == nil
-				}
-				return y != nil
-			})
-			if d := cmp.Diff(c.want, got, ignoreVolatileTime, ensureTimeNotNil); d != "" {
-				t.Errorf("Diff %s", diff.PrintWantGot(d))
-			}
-		})
-	}
-}
-
-func TestMakeTaskRunStatus_StepProvenance(t *testing.T) {
-	for _, c := range []struct {
-		desc      string
-		podStatus corev1.PodStatus
-		pod       corev1.Pod
-		tr        v1.TaskRun
-		want      v1.TaskRunStatus
-	}{{
-		desc: "provenance in step",
+	}, {
+		// $(steps.one.results.digest) resolves to the StepResult of step one and $(results.digest) to the TaskResult written by step two.
+		desc: "step result shadowing a task result",
 		podStatus: corev1.PodStatus{
 			Phase: corev1.PodSucceeded,
 			ContainerStatuses: []corev1.ContainerStatus{{
 				Name: "step-one",
 				State: corev1.ContainerState{
-					Terminated: &corev1.ContainerStateTerminated{},
+					Terminated: &corev1.ContainerStateTerminated{
+						Message: `[{"key":"digest","value":"sha256:step","type":4}]`,
+					},
+				},
+			}, {
+				Name: "step-two",
+				State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{
+						Message: `[{"key":"digest","value":"sha256:task","type":1}]`,
+					},
 				},
 			}},
 		},
@@ -522,20 +666,18 @@ func TestMakeTaskRunStatus_StepProvenance(t *testing.T) {
 			},
 			Spec: v1.TaskRunSpec{
 				TaskSpec: &v1.TaskSpec{
-					Steps: []v1.Step{{
-						Name:  "one",
-						Image: "bash",
+					Results: []v1.TaskResult{{
+						Name: "digest",
+						Type: v1.ResultsTypeString,
 					}},
-				},
-			},
-			Status: v1.TaskRunStatus{
-				TaskRunStatusFields: v1.TaskRunStatusFields{
-					Steps: []v1.StepState{{
+					Steps: []v1.Step{{
 						Name: "one",
-						Provenance: &v1.Provenance{RefSource: &v1.RefSource{
-							URI:    "pkg://foo/bar",
-							Digest: map[string]string{"sha256": "digest"},
+						Results: []v1.StepResult{{
+							Name: "digest",
+							Type: v1.ResultsTypeString,
 						}},
+					}, {
+						Name: "two",
 					}},
 				},
 			},
@@ -545,35 +687,57 @@ func TestMakeTaskRunStatus_StepProvenance(t *testing.T) {
 			TaskRunStatusFields: v1.TaskRunStatusFields{
 				Steps: []v1.StepState{{
 					ContainerState: corev1.ContainerState{
-						Terminated: &corev1.ContainerStateTerminated{},
+						Terminated: &corev1.ContainerStateTerminated{
+							Message: `[{"key":"digest","value":"sha256:step","type":4}]`,
+						},
 					},
 					Name:      "one",
 					Container: "step-one",
-					Results:   []v1.TaskRunResult{},
-					Provenance: &v1.Provenance{RefSource: &v1.RefSource{
-						URI:    "pkg://foo/bar",
-						Digest: map[string]string{"sha256": "digest"},
+					Results: []v1.TaskRunStepResult{{
+						Name:  "digest",
+						Type:  v1.ResultsTypeString,
+						Value: *v1.NewStructuredValues("sha256:step"),
 					}},
+				}, {
+					ContainerState: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{
+							Message: `[{"key":"digest","value":"sha256:task","type":1}]`,
+						},
+					},
+					Name:      "two",
+					Container: "step-two",
+					Results:   []v1.TaskRunStepResult{},
 				}},
 				Sidecars:  []v1.SidecarState{},
 				Artifacts: &v1.Artifacts{},
+				Results: []v1.TaskRunResult{{
+					Name:       "digest",
+					Type:       v1.ResultsTypeString,
+					Value:      *v1.NewStructuredValues("sha256:task"),
+					ProducedBy: "two",
+				}},
 				// We don't actually care about the time, just that it's not nil
 				CompletionTime: &metav1.Time{Time: time.Now()},
 			},
 		},
 	}, {
-		desc: "provenance in some steps",
+		// The TaskResult declares its value from the StepResult of step one, so what step two writes to $(results.digest.path) is not used.
+		desc: "task result fetched from a shadowed step result ignores values written to its path",
 		podStatus: corev1.PodStatus{
 			Phase: corev1.PodSucceeded,
 			ContainerStatuses: []corev1.ContainerStatus{{
 				Name: "step-one",
 				State: corev1.ContainerState{
-					Terminated: &corev1.ContainerStateTerminated{},
+					Terminated: &corev1.ContainerStateTerminated{
+						Message: `[{"key":"digest","value":"sha256:step","type":4}]`,
+					},
 				},
 			}, {
 				Name: "step-two",
 				State: corev1.ContainerState{
-					Terminated: &corev1.ContainerStateTerminated{},
+					Terminated: &corev1.ContainerStateTerminated{
+						Message: `[{"key":"digest","value":"sha256:task","type":1}]`,
+					},
 				},
 			}},
 		},
@@ -584,23 +748,22 @@ func TestMakeTaskRunStatus_StepProvenance(t *testing.T) {
 			},
 			Spec: v1.TaskRunSpec{
 				TaskSpec: &v1.TaskSpec{
-					Steps: []v1.Step{{
-						Name:  "one",
-						Image: "bash",
-					}, {
-						Name:  "two",
-						Image: "bash",
+					Results: []v1.TaskResult{{
+						Name: "digest",
+						Type: v1.ResultsTypeString,
+						Value: &v1.ParamValue{
+							Type:      v1.ParamTypeString,
+							StringVal: "$(steps.one.results.digest)",
+						},
 					}},
-				},
-			},
-			Status: v1.TaskRunStatus{
-				TaskRunStatusFields: v1.TaskRunStatusFields{
-					Steps: []v1.StepState{{
+					Steps: []v1.Step{{
 						Name: "one",
-						Provenance: &v1.Provenance{RefSource: &v1.RefSource{
-							URI:    "pkg://foo/bar",
-							Digest: map[string]string{"sha256": "digest"},
+						Results: []v1.StepResult{{
+							Name: "digest",
+							Type: v1.ResultsTypeString,
 						}},
+					}, {
+						Name: "two",
 					}},
 				},
 			},
@@ -610,74 +773,353 @@ func TestMakeTaskRunStatus_StepProvenance(t *testing.T) {
 			TaskRunStatusFields: v1.TaskRunStatusFields{
 				Steps: []v1.StepState{{
 					ContainerState: corev1.ContainerState{
-						Terminated: &corev1.ContainerStateTerminated{},
+						Terminated: &corev1.ContainerStateTerminated{
+							Message: `[{"key":"digest","value":"sha256:step","type":4}]`,
+						},
 					},
 					Name:      "one",
 					Container: "step-one",
-					Results:   []v1.TaskRunResult{},
-					Provenance: &v1.Provenance{RefSource: &v1.RefSource{
-						URI:    "pkg://foo/bar",
-						Digest: map[string]string{"sha256": "digest"},
+					Results: []v1.TaskRunStepResult{{
+						Name:  "digest",
+						Type:  v1.ResultsTypeString,
+						Value: *v1.NewStructuredValues("sha256:step"),
 					}},
 				}, {
 					ContainerState: corev1.ContainerState{
-						Terminated: &corev1.ContainerStateTerminated{},
+						Terminated: &corev1.ContainerStateTerminated{
+							Message: `[{"key":"digest","value":"sha256:task","type":1}]`,
+						},
 					},
 					Name:      "two",
 					Container: "step-two",
-					Results:   []v1.TaskRunResult{},
+					Results:   []v1.TaskRunStepResult{},
 				}},
 				Sidecars:  []v1.SidecarState{},
 				Artifacts: &v1.Artifacts{},
+				Results: []v1.TaskRunResult{{
+					Name:       "digest",
+					Type:       v1.ResultsTypeString,
+					Value:      *v1.NewStructuredValues("sha256:step"),
+					ProducedBy: "one",
+				}},
 				// We don't actually care about the time, just that it's not nil
 				CompletionTime: &metav1.Time{Time: time.Now()},
 			},
 		},
-	}} {
-		t.Run(c.desc, func(t *testing.T) {
-			now := metav1.Now()
-			if cmp.Diff(c.pod, corev1.Pod{}) == "" {
-				c.pod = corev1.Pod{
-					ObjectMeta: metav1.ObjectMeta{
-						Name:              "pod",
-						Namespace:         "foo",
-						CreationTimestamp: now,
+	}, {
+		// Each TaskResult fetching the StepResult gets its value.
+		desc: "several task results fetched from the same step result",
+		podStatus: corev1.PodStatus{
+			Phase: corev1.PodSucceeded,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name: "step-one",
+				State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{
+						Message: `[{"key":"digest","value":"sha256:step","type":4}]`,
 					},
-					Status: c.podStatus,
-				}
-			}
-
-			logger, _ := logging.NewLogger("", "status")
-			kubeclient := fakek8s.NewSimpleClientset()
-			got, err := MakeTaskRunStatus(t.Context(), logger, c.tr, &c.pod, kubeclient, c.tr.Spec.TaskSpec)
-			if err != nil {
-				t.Errorf("MakeTaskRunResult: %s", err)
-			}
-
-			// Common traits, set for test case brevity.
-			c.want.PodName = "pod"
-
-			ensureTimeNotNil := cmp.Comparer(func(x, y *metav1.Time) bool {
-				if x == nil {
-					return y == nil
-				}
-				return y != nil
-			})
-			if d := cmp.Diff(c.want, got, ignoreVolatileTime, ensureTimeNotNil); d != "" {
-				t.Errorf("Diff %s", diff.PrintWantGot(d))
-			}
-		})
-	}
-}
-
-func TestMakeTaskRunStatus_StepArtifacts(t *testing.T) {
-	for _, c := range []struct {
-		desc      string
-		podStatus corev1.PodStatus
-		pod       corev1.Pod
-		tr        v1.TaskRun
-		want      v1.TaskRunStatus
-	}{
+				},
+			}, {
+				Name: "step-two",
+				State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{},
+				},
+			}},
+		},
+		tr: v1.TaskRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "task-run",
+				Namespace: "foo",
+			},
+			Spec: v1.TaskRunSpec{
+				TaskSpec: &v1.TaskSpec{
+					Results: []v1.TaskResult{{
+						Name: "digest",
+						Type: v1.ResultsTypeString,
+						Value: &v1.ParamValue{
+							Type:      v1.ParamTypeString,
+							StringVal: "$(steps.one.results.digest)",
+						},
+					}, {
+						Name: "image-digest",
+						Type: v1.ResultsTypeString,
+						Value: &v1.ParamValue{
+							Type:      v1.ParamTypeString,
+							StringVal: "$(steps.one.results.digest)",
+						},
+					}},
+					Steps: []v1.Step{{
+						Name: "one",
+						Results: []v1.StepResult{{
+							Name: "digest",
+							Type: v1.ResultsTypeString,
+						}},
+					}, {
+						Name: "two",
+					}},
+				},
+			},
+		},
+		want: v1.TaskRunStatus{
+			Status: statusSuccess(),
+			TaskRunStatusFields: v1.TaskRunStatusFields{
+				Steps: []v1.StepState{{
+					ContainerState: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{
+							Message: `[{"key":"digest","value":"sha256:step","type":4}]`,
+						},
+					},
+					Name:      "one",
+					Container: "step-one",
+					Results: []v1.TaskRunStepResult{{
+						Name:  "digest",
+						Type:  v1.ResultsTypeString,
+						Value: *v1.NewStructuredValues("sha256:step"),
+					}},
+				}, {
+					ContainerState: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{},
+					},
+					Name:      "two",
+					Container: "step-two",
+					Results:   []v1.TaskRunStepResult{},
+				}},
+				Sidecars:  []v1.SidecarState{},
+				Artifacts: &v1.Artifacts{},
+				Results: []v1.TaskRunResult{{
+					Name:       "digest",
+					Type:       v1.ResultsTypeString,
+					Value:      *v1.NewStructuredValues("sha256:step"),
+					ProducedBy: "one",
+				}, {
+					Name:       "image-digest",
+					Type:       v1.ResultsTypeString,
+					Value:      *v1.NewStructuredValues("sha256:step"),
+					ProducedBy: "one",
+				}},
+				// We don't actually care about the time, just that it's not nil
+				CompletionTime: &metav1.Time{Time: time.Now()},
+			},
+		},
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			now := metav1.Now()
+			if cmp.Diff(c.pod, corev1.Pod{}) == "" {
+				c.pod = corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:              "pod",
+						Namespace:         "foo",
+						CreationTimestamp: now,
+					},
+					Status: c.podStatus,
+				}
+			}
+
+			logger, _ := logging.NewLogger("", "status")
+			kubeclient := fakek8s.NewSimpleClientset()
+			checkStatus(t, t.Context(), c.tr, &c.pod, kubeclient, c.tr.Spec.TaskSpec)
+			got, err := MakeTaskRunStatus(t.Context(), logger, c.tr, &c.pod, kubeclient, c.tr.Spec.TaskSpec)
+			if err != nil {
+				t.Errorf("MakeTaskRunResult: %s", err)
+			}
+
+			// Common traits, set for test case brevity.
+			c.want.PodName = "pod"
+
+			ensureTimeNotNil := cmp.Comparer(func(x, y *metav1.Time) bool {
+				if x == nil {
+					return y == nil
+				}
+				return y != nil
+			})
+			if d := cmp.Diff(c.want, got, ignoreVolatileTime, ignoreReasonHistory, ensureTimeNotNil); d != "" {
+				t.Errorf("Diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestMakeTaskRunStatus_StepProvenance(t *testing.T) {
+	for _, c := range []struct {
+		desc      string
+		podStatus corev1.PodStatus
+		pod       corev1.Pod
+		tr        v1.TaskRun
+		want      v1.TaskRunStatus
+	}{{
+		desc: "provenance in step",
+		podStatus: corev1.PodStatus{
+			Phase: corev1.PodSucceeded,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name: "step-one",
+				State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{},
+				},
+			}},
+		},
+		tr: v1.TaskRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "task-run",
+				Namespace: "foo",
+			},
+			Spec: v1.TaskRunSpec{
+				TaskSpec: &v1.TaskSpec{
+					Steps: []v1.Step{{
+						Name:  "one",
+						Image: "bash",
+					}},
+				},
+			},
+			Status: v1.TaskRunStatus{
+				TaskRunStatusFields: v1.TaskRunStatusFields{
+					Steps: []v1.StepState{{
+						Name: "one",
+						Provenance: &v1.Provenance{RefSource: &v1.RefSource{
+							URI:    "pkg://foo/bar",
+							Digest: map[string]string{"sha256": "digest"},
+						}},
+					}},
+				},
+			},
+		},
+		want: v1.TaskRunStatus{
+			Status: statusSuccess(),
+			TaskRunStatusFields: v1.TaskRunStatusFields{
+				Steps: []v1.StepState{{
+					ContainerState: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{},
+					},
+					Name:      "one",
+					Container: "step-one",
+					Results:   []v1.TaskRunResult{},
+					Provenance: &v1.Provenance{RefSource: &v1.RefSource{
+						URI:    "pkg://foo/bar",
+						Digest: map[string]string{"sha256": "digest"},
+					}},
+				}},
+				Sidecars:  []v1.SidecarState{},
+				Artifacts: &v1.Artifacts{},
+				// We don't actually care about the time, just that it's not nil
+				CompletionTime: &metav1.Time{Time: time.Now()},
+			},
+		},
+	}, {
+		desc: "provenance in some steps",
+		podStatus: corev1.PodStatus{
+			Phase: corev1.PodSucceeded,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name: "step-one",
+				State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{},
+				},
+			}, {
+				Name: "step-two",
+				State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{},
+				},
+			}},
+		},
+		tr: v1.TaskRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "task-run",
+				Namespace: "foo",
+			},
+			Spec: v1.TaskRunSpec{
+				TaskSpec: &v1.TaskSpec{
+					Steps: []v1.Step{{
+						Name:  "one",
+						Image: "bash",
+					}, {
+						Name:  "two",
+						Image: "bash",
+					}},
+				},
+			},
+			Status: v1.TaskRunStatus{
+				TaskRunStatusFields: v1.TaskRunStatusFields{
+					Steps: []v1.StepState{{
+						Name: "one",
+						Provenance: &v1.Provenance{RefSource: &v1.RefSource{
+							URI:    "pkg://foo/bar",
+							Digest: map[string]string{"sha256": "digest"},
+						}},
+					}},
+				},
+			},
+		},
+		want: v1.TaskRunStatus{
+			Status: statusSuccess(),
+			TaskRunStatusFields: v1.TaskRunStatusFields{
+				Steps: []v1.StepState{{
+					ContainerState: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{},
+					},
+					Name:      "one",
+					Container: "step-one",
+					Results:   []v1.TaskRunResult{},
+					Provenance: &v1.Provenance{RefSource: &v1.RefSource{
+						URI:    "pkg://foo/bar",
+						Digest: map[string]string{"sha256": "digest"},
+					}},
+				}, {
+					ContainerState: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{},
+					},
+					Name:      "two",
+					Container: "step-two",
+					Results:   []v1.TaskRunResult{},
+				}},
+				Sidecars:  []v1.SidecarState{},
+				Artifacts: &v1.Artifacts{},
+				// We don't actually care about the time, just that it's not nil
+				CompletionTime: &metav1.Time{Time: time.Now()},
+			},
+		},
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			now := metav1.Now()
+			if cmp.Diff(c.pod, corev1.Pod{}) == "" {
+				c.pod = corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:              "pod",
+						Namespace:         "foo",
+						CreationTimestamp: now,
+					},
+					Status: c.podStatus,
+				}
+			}
+
+			logger, _ := logging.NewLogger("", "status")
+			kubeclient := fakek8s.NewSimpleClientset()
+			checkStatus(t, t.Context(), c.tr, &c.pod, kubeclient, c.tr.Spec.TaskSpec)
+			got, err := MakeTaskRunStatus(t.Context(), logger, c.tr, &c.pod, kubeclient, c.tr.Spec.TaskSpec)
+			if err != nil {
+				t.Errorf("MakeTaskRunResult: %s", err)
+			}
+
+			// Common traits, set for test case brevity.
+			c.want.PodName = "pod"
+
+			ensureTimeNotNil := cmp.Comparer(func(x, y *metav1.Time) bool {
+				if x == nil {
+					return y == nil
+				}
+				return y != nil
+			})
+			if d := cmp.Diff(c.want, got, ignoreVolatileTime, ignoreReasonHistory, ensureTimeNotNil); d != "" {
+				t.Errorf("Diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestMakeTaskRunStatus_StepArtifacts(t *testing.T) {
+	for _, c := range []struct {
+		desc      string
+		podStatus corev1.PodStatus
+		pod       corev1.Pod
+		tr        v1.TaskRun
+		want      v1.TaskRunStatus
+	}{
 		{
 			desc: "step artifacts result type",
 			podStatus: corev1.PodStatus{
@@ -703,110 +1145,574 @@ func TestMakeTaskRunStatus_StepArtifacts(t *testing.T) {
 						}},
 					},
 				},
-			},
-			want: v1.TaskRunStatus{
-				Status: statusSuccess(),
-				TaskRunStatusFields: v1.TaskRunStatusFields{
-					Steps: []v1.StepState{{
-						ContainerState: corev1.ContainerState{
-							Terminated: &corev1.ContainerStateTerminated{
-								Message: `[{"key":"/tekton/run/0/status/artifacts/provenance.json","value":"{\n  \"inputs\":[\n    {\n      \"name\":\"input-artifacts\",\n      \"values\":[\n        {\n          \"uri\":\"git:jjjsss\",\n          \"digest\":{\n            \"sha256\":\"b35cacccfdb1e24dc497d15d553891345fd155713ffe647c281c583269eaaae0\"\n          }\n        }\n      ]\n    }\n  ],\n  \"outputs\":[\n    {\n      \"name\":\"build-results\",\n      \"values\":[\n        {\n          \"uri\":\"pkg:balba\",\n          \"digest\":{\n            \"sha256\":\"df85b9e3983fe2ce20ef76ad675ecf435cc99fc9350adc54fa230bae8c32ce48\",\n            \"sha1\":\"95588b8f34c31eb7d62c92aaa4e6506639b06ef2\"\n          }\n        }\n      ]\n    }\n  ]\n}\n","type":5}]`,
-							},
-						},
-						Name:      "one",
-						Container: "step-one",
-						Inputs: []v1.Artifact{
-							{
-								Name: "input-artifacts",
-								Values: []v1.ArtifactValue{
-									{
-										Digest: map[v1.Algorithm]string{"sha256": "b35cacccfdb1e24dc497d15d553891345fd155713ffe647c281c583269eaaae0"},
-										Uri:    "git:jjjsss",
-									},
-								},
-							},
-						},
-						Outputs: []v1.Artifact{
-							{
-								Name: "build-results",
-								Values: []v1.ArtifactValue{
-									{
-										Digest: map[v1.Algorithm]string{
-											"sha1":   "95588b8f34c31eb7d62c92aaa4e6506639b06ef2",
-											"sha256": "df85b9e3983fe2ce20ef76ad675ecf435cc99fc9350adc54fa230bae8c32ce48",
-										},
-										Uri: "pkg:balba",
-									},
-								},
-							},
+			},
+			want: v1.TaskRunStatus{
+				Status: statusSuccess(),
+				TaskRunStatusFields: v1.TaskRunStatusFields{
+					Steps: []v1.StepState{{
+						ContainerState: corev1.ContainerState{
+							Terminated: &corev1.ContainerStateTerminated{
+								Message: `[{"key":"/tekton/run/0/status/artifacts/provenance.json","value":"{\n  \"inputs\":[\n    {\n      \"name\":\"input-artifacts\",\n      \"values\":[\n        {\n          \"uri\":\"git:jjjsss\",\n          \"digest\":{\n            \"sha256\":\"b35cacccfdb1e24dc497d15d553891345fd155713ffe647c281c583269eaaae0\"\n          }\n        }\n      ]\n    }\n  ],\n  \"outputs\":[\n    {\n      \"name\":\"build-results\",\n      \"values\":[\n        {\n          \"uri\":\"pkg:balba\",\n          \"digest\":{\n            \"sha256\":\"df85b9e3983fe2ce20ef76ad675ecf435cc99fc9350adc54fa230bae8c32ce48\",\n            \"sha1\":\"95588b8f34c31eb7d62c92aaa4e6506639b06ef2\"\n          }\n        }\n      ]\n    }\n  ]\n}\n","type":5}]`,
+							},
+						},
+						Name:      "one",
+						Container: "step-one",
+						Inputs: []v1.Artifact{
+							{
+								Name: "input-artifacts",
+								Values: []v1.ArtifactValue{
+									{
+										Digest: map[v1.Algorithm]string{"sha256": "b35cacccfdb1e24dc497d15d553891345fd155713ffe647c281c583269eaaae0"},
+										Uri:    "git:jjjsss",
+									},
+								},
+							},
+						},
+						Outputs: []v1.Artifact{
+							{
+								Name: "build-results",
+								Values: []v1.ArtifactValue{
+									{
+										Digest: map[v1.Algorithm]string{
+											"sha1":   "95588b8f34c31eb7d62c92aaa4e6506639b06ef2",
+											"sha256": "df85b9e3983fe2ce20ef76ad675ecf435cc99fc9350adc54fa230bae8c32ce48",
+										},
+										Uri: "pkg:balba",
+									},
+								},
+							},
+						},
+						Results: []v1.TaskRunResult{},
+					}},
+					Artifacts: &v1.Artifacts{
+						Inputs: []v1.Artifact{
+							{
+								Name: "input-artifacts",
+								Values: []v1.ArtifactValue{
+									{
+										Digest: map[v1.Algorithm]string{"sha256": "b35cacccfdb1e24dc497d15d553891345fd155713ffe647c281c583269eaaae0"},
+										Uri:    "git:jjjsss",
+									},
+								},
+							},
+						},
+						Outputs: []v1.Artifact{
+							{
+								Name: "build-results",
+								Values: []v1.ArtifactValue{
+									{
+										Digest: map[v1.Algorithm]string{
+											"sha1":   "95588b8f34c31eb7d62c92aaa4e6506639b06ef2",
+											"sha256": "df85b9e3983fe2ce20ef76ad675ecf435cc99fc9350adc54fa230bae8c32ce48",
+										},
+										Uri: "pkg:balba",
+									},
+								},
+							},
+						},
+					},
+					Sidecars: []v1.SidecarState{},
+					// We don't actually care about the time, just that it's not nil
+					CompletionTime: &metav1.Time{Time: time.Now()},
+				},
+			},
+		},
+	} {
+		t.Run(c.desc, func(t *testing.T) {
+			now := metav1.Now()
+			if cmp.Diff(c.pod, corev1.Pod{}) == "" {
+				c.pod = corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:              "pod",
+						Namespace:         "foo",
+						CreationTimestamp: now,
+					},
+					Status: c.podStatus,
+				}
+			}
+
+			logger, _ := logging.NewLogger("", "status")
+			kubeclient := fakek8s.NewSimpleClientset()
+			checkStatus(t, t.Context(), c.tr, &c.pod, kubeclient, c.tr.Spec.TaskSpec)
+			got, err := MakeTaskRunStatus(t.Context(), logger, c.tr, &c.pod, kubeclient, c.tr.Spec.TaskSpec)
+			if err != nil {
+				t.Errorf("MakeTaskRunResult: %s", err)
+			}
+
+			// Common traits, set for test case brevity.
+			c.want.PodName = "pod"
+
+			ensureTimeNotNil := cmp.Comparer(func(x, y *metav1.Time) bool {
+				if x == nil {
+					return y == nil
+				}
+				return y != nil
+			})
+			if d := cmp.Diff(c.want, got, ignoreVolatileTime, ignoreReasonHistory, ensureTimeNotNil); d != "" {
+				t.Errorf("Diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestMakeTaskRunStatus_SidecarResults(t *testing.T) {
+	msg := `[{"key":"server.endpoints","value":"[\"a\",\"b\"]","type":7},{"key":"server.port","value":"8080","type":7},{"key":"server.undeclared","value":"foo","type":7}]`
+	tr := v1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "task-run",
+			Namespace: "foo",
+		},
+		Spec: v1.TaskRunSpec{
+			TaskSpec: &v1.TaskSpec{
+				Steps: []v1.Step{{
+					Name: "one",
+				}},
+				Sidecars: []v1.Sidecar{{
+					Name: "server",
+					Results: []v1.StepResult{{
+						Name: "port",
+						Type: v1.ResultsTypeString,
+					}, {
+						Name: "endpoints",
+						Type: v1.ResultsTypeArray,
+					}},
+				}},
+				Results: []v1.TaskResult{{
+					Name:  "server-port",
+					Type:  v1.ResultsTypeString,
+					Value: v1.NewStructuredValues("$(sidecars.server.results.port)"),
+				}},
+			},
+		},
+	}
+	pod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "pod",
+			Namespace: "foo",
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodSucceeded,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name: "step-one",
+				State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{Message: msg},
+				},
+			}, {
+				Name: "sidecar-server",
+				State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{},
+				},
+			}},
+		},
+	}
+	want := v1.TaskRunStatus{
+		Status: statusSuccess(),
+		TaskRunStatusFields: v1.TaskRunStatusFields{
+			PodName: "pod",
+			Steps: []v1.StepState{{
+				ContainerState: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{Message: msg},
+				},
+				Name:      "one",
+				Container: "step-one",
+				Results:   []v1.TaskRunStepResult{},
+			}},
+			Sidecars: []v1.SidecarState{{
+				ContainerState: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{},
+				},
+				Name:      "server",
+				Container: "sidecar-server",
+				Results: []v1.TaskRunResult{{
+					Name:  "port",
+					Type:  v1.ResultsTypeString,
+					Value: *v1.NewStructuredValues("8080"),
+				}, {
+					Name:  "endpoints",
+					Type:  v1.ResultsTypeArray,
+					Value: *v1.NewStructuredValues("a", "b"),
+				}},
+			}},
+			Results: []v1.TaskRunResult{{
+				Name:  "server-port",
+				Type:  v1.ResultsTypeString,
+				Value: *v1.NewStructuredValues("8080"),
+			}},
+			Artifacts: &v1.Artifacts{},
+			// We don't actually care about the time, just that it's not nil
+			CompletionTime: &metav1.Time{Time: time.Now()},
+		},
+	}
+
+	logger, _ := logging.NewLogger("", "status")
+	kubeclient := fakek8s.NewSimpleClientset()
+	got, err := MakeTaskRunStatus(t.Context(), logger, tr, &pod, kubeclient, tr.Spec.TaskSpec)
+	if err != nil {
+		t.Errorf("MakeTaskRunResult: %s", err)
+	}
+	ensureTimeNotNil := cmp.Comparer(func(x, y *metav1.Time) bool {
+		if x == nil {
+			return y == nil
+		}
+		return y != nil
+	})
+	if d := cmp.Diff(want, got, ignoreVolatileTime, ignoreReasonHistory, ensureTimeNotNil); d != "" {
+		t.Errorf("Diff %s", diff.PrintWantGot(d))
+	}
+}
+
+// TestMakeTaskRunStatus_AdversarialContainerNames tests that the containers of the pod are attributed to
+// the steps and sidecars they run by their names in the pod spec, even when the step and sidecar names
+// begin with the prefix of the other kind of container or are shortened in the container names.
+func TestMakeTaskRunStatus_AdversarialContainerNames(t *testing.T) {
+	longStepName := strings.Repeat("s", 63)
+	longSidecarName := strings.Repeat("c", 63)
+	ts := &v1.TaskSpec{
+		Steps:    []v1.Step{{Name: "sidecar-x"}, {}, {Name: longStepName}},
+		Sidecars: []v1.Sidecar{{Name: "step-y"}, {Name: longSidecarName}},
+	}
+	tr := v1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "task-run", Namespace: "foo"},
+		Spec:       v1.TaskRunSpec{TaskSpec: ts},
+	}
+	containers := []string{
+		"step-sidecar-x",
+		"step-unnamed-1",
+		pipeline.StepContainerName(longStepName),
+		"sidecar-step-y",
+		pipeline.SidecarContainerName(longSidecarName),
+	}
+	pod := corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "foo"}}
+	pod.Status.Phase = corev1.PodSucceeded
+	for _, name := range containers {
+		pod.Spec.Containers = append(pod.Spec.Containers, corev1.Container{Name: name})
+		pod.Status.ContainerStatuses = append(pod.Status.ContainerStatuses, corev1.ContainerStatus{
+			Name:  name,
+			State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{}},
+		})
+	}
+	terminated := corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{}}
+	wantSteps := []v1.StepState{{
+		ContainerState: terminated, Name: "sidecar-x", Container: containers[0], Results: []v1.TaskRunStepResult{},
+	}, {
+		ContainerState: terminated, Name: "unnamed-1", Container: containers[1], Results: []v1.TaskRunStepResult{},
+	}, {
+		ContainerState: terminated, Name: longStepName, Container: containers[2], Results: []v1.TaskRunStepResult{},
+	}}
+	wantSidecars := []v1.SidecarState{{
+		ContainerState: terminated, Name: "step-y", Container: containers[3],
+	}, {
+		ContainerState: terminated, Name: longSidecarName, Container: containers[4],
+	}}
+
+	logger, _ := logging.NewLogger("", "status")
+	got, err := MakeTaskRunStatus(t.Context(), logger, tr, &pod, fakek8s.NewSimpleClientset(), ts)
+	if err != nil {
+		t.Errorf("MakeTaskRunResult: %s", err)
+	}
+	if d := cmp.Diff(wantSteps, got.Steps, ignoreVolatileTime, ignoreReasonHistory); d != "" {
+		t.Errorf("Unexpected steps %s", diff.PrintWantGot(d))
+	}
+	if d := cmp.Diff(wantSidecars, got.Sidecars, ignoreVolatileTime, ignoreReasonHistory); d != "" {
+		t.Errorf("Unexpected sidecars %s", diff.PrintWantGot(d))
+	}
+	wantShortened := []v1.ShortenedContainerName{{Step: longStepName, Container: containers[2]}, {Sidecar: longSidecarName, Container: containers[4]}}
+	if d := cmp.Diff(wantShortened, got.ShortenedContainerNames); d != "" {
+		t.Errorf("Unexpected shortened container names %s", diff.PrintWantGot(d))
+	}
+}
+
+// TestMakeTaskRunStatus_RecordedShortenedContainerNames tests that the shortened container names recorded
+// in the status of the TaskRun attribute the containers to the steps whose names they don't derive from.
+func TestMakeTaskRunStatus_RecordedShortenedContainerNames(t *testing.T) {
+	longStepName := strings.Repeat("s", 63)
+	ts := &v1.TaskSpec{Steps: []v1.Step{{Name: longStepName}}}
+	// e.g. the name a previous release shortened the step name to
+	container := "step-" + strings.Repeat("s", 58)
+	tr := v1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "task-run", Namespace: "foo"},
+		Spec:       v1.TaskRunSpec{TaskSpec: ts},
+		Status: v1.TaskRunStatus{TaskRunStatusFields: v1.TaskRunStatusFields{
+			ShortenedContainerNames: []v1.ShortenedContainerName{{Step: longStepName, Container: container}},
+		}},
+	}
+	pod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "foo"},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: container}}},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name:  container,
+				State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+			}},
+		},
+	}
+
+	logger, _ := logging.NewLogger("", "status")
+	got, err := MakeTaskRunStatus(t.Context(), logger, tr, &pod, fakek8s.NewSimpleClientset(), ts)
+	if err != nil {
+		t.Errorf("MakeTaskRunResult: %s", err)
+	}
+	if len(got.Steps) != 1 || got.Steps[0].Name != longStepName {
+		t.Errorf("Expected the container %q to run the step %q, got steps %v", container, longStepName, got.Steps)
+	}
+	if d := cmp.Diff(tr.Status.ShortenedContainerNames, got.ShortenedContainerNames); d != "" {
+		t.Errorf("Unexpected shortened container names %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestMakeTaskRunStatus_PinnedImages(t *testing.T) {
+	const digest = "sha256:df85b9e3983fe2ce20ef76ad675ecf435cc99fc9350adc54fa230bae8c32ce48"
+	ts := &v1.TaskSpec{Steps: []v1.Step{{Name: "build", Image: "registry.example.com/builder:1.0"}}}
+	tr := v1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "task-run", Namespace: "foo"},
+		Spec:       v1.TaskRunSpec{TaskSpec: ts},
+	}
+	pod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "pod",
+			Namespace:   "foo",
+			Annotations: map[string]string{PinnedImagesAnnotation: `[{"image":"registry.example.com/builder:1.0","digest":"` + digest + `"}]`},
+		},
+		Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "step-build", Image: "registry.example.com/builder@" + digest}}},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name:  "step-build",
+				State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+			}},
+		},
+	}
+
+	logger, _ := logging.NewLogger("", "status")
+	got, err := MakeTaskRunStatus(t.Context(), logger, tr, &pod, fakek8s.NewSimpleClientset(), ts)
+	if err != nil {
+		t.Errorf("MakeTaskRunResult: %s", err)
+	}
+	want := []v1.PinnedImage{{Image: "registry.example.com/builder:1.0", Digest: digest}}
+	if d := cmp.Diff(want, got.PinnedImages); d != "" {
+		t.Errorf("Unexpected pinned images %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestMakeTaskRunStatus_ExpectedArtifactDigests(t *testing.T) {
+	const digest = "df85b9e3983fe2ce20ef76ad675ecf435cc99fc9350adc54fa230bae8c32ce48"
+	message := `[{"key":"/tekton/run/0/status/artifacts/provenance.json","value":"{\"outputs\":[{\"name\":\"image\",\"values\":[{\"uri\":\"pkg:image\",\"digest\":{\"sha256\":\"` + digest + `\"}}]}]}","type":5}]`
+	for _, c := range []struct {
+		desc         string
+		expected     []v1.ExpectedArtifactDigest
+		wantReason   string
+		wantMessage  string
+		wantVerified bool
+	}{{
+		desc:         "digest matches",
+		expected:     []v1.ExpectedArtifactDigest{{Name: "image", Digest: "sha256:" + digest}},
+		wantReason:   v1.TaskRunReasonSuccessful.String(),
+		wantMessage:  "All Steps have completed executing",
+		wantVerified: true,
+	}, {
+		desc:        "digest mismatches",
+		expected:    []v1.ExpectedArtifactDigest{{Name: "image", Digest: "sha256:0123"}},
+		wantReason:  v1.TaskRunReasonArtifactDigestMismatch.String(),
+		wantMessage: `artifact "image" has digest "sha256:` + digest + `", expected "sha256:0123"`,
+	}, {
+		desc:        "expected digest param is empty",
+		expected:    []v1.ExpectedArtifactDigest{{Name: "image", Digest: "sha256:"}},
+		wantReason:  v1.TaskRunReasonSuccessful.String(),
+		wantMessage: "All Steps have completed executing",
+	}, {
+		desc:        "artifact not produced",
+		expected:    []v1.ExpectedArtifactDigest{{Name: "sbom", Digest: "sha256:0123"}},
+		wantReason:  v1.TaskRunReasonSuccessful.String(),
+		wantMessage: "All Steps have completed executing",
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			taskSpec := &v1.TaskSpec{
+				Steps:                   []v1.Step{{Name: "one"}},
+				ExpectedArtifactDigests: c.expected,
+			}
+			tr := v1.TaskRun{
+				ObjectMeta: metav1.ObjectMeta{Name: "task-run", Namespace: "foo"},
+				Spec:       v1.TaskRunSpec{TaskSpec: taskSpec},
+				Status: v1.TaskRunStatus{
+					TaskRunStatusFields: v1.TaskRunStatusFields{TaskSpec: taskSpec},
+				},
+			}
+			pod := corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "foo"},
+				Status: corev1.PodStatus{
+					Phase: corev1.PodSucceeded,
+					ContainerStatuses: []corev1.ContainerStatus{{
+						Name: "step-one",
+						State: corev1.ContainerState{
+							Terminated: &corev1.ContainerStateTerminated{Message: message},
 						},
-						Results: []v1.TaskRunResult{},
 					}},
-					Artifacts: &v1.Artifacts{
-						Inputs: []v1.Artifact{
-							{
-								Name: "input-artifacts",
-								Values: []v1.ArtifactValue{
-									{
-										Digest: map[v1.Algorithm]string{"sha256": "b35cacccfdb1e24dc497d15d553891345fd155713ffe647c281c583269eaaae0"},
-										Uri:    "git:jjjsss",
-									},
-								},
-							},
-						},
-						Outputs: []v1.Artifact{
-							{
-								Name: "build-results",
-								Values: []v1.ArtifactValue{
-									{
-										Digest: map[v1.Algorithm]string{
-											"sha1":   "95588b8f34c31eb7d62c92aaa4e6506639b06ef2",
-											"sha256": "df85b9e3983fe2ce20ef76ad675ecf435cc99fc9350adc54fa230bae8c32ce48",
-										},
-										Uri: "pkg:balba",
-									},
-								},
-							},
-						},
-					},
-					Sidecars: []v1.SidecarState{},
-					// We don't actually care about the time, just that it's not nil
-					CompletionTime: &metav1.Time{Time: time.Now()},
 				},
-			},
-		},
-	} {
-		t.Run(c.desc, func(t *testing.T) {
-			now := metav1.Now()
-			if cmp.Diff(c.pod, corev1.Pod{}) == "" {
-				c.pod = corev1.Pod{
-					ObjectMeta: metav1.ObjectMeta{
-						Name:              "pod",
-						Namespace:         "foo",
-						CreationTimestamp: now,
-					},
-					Status: c.podStatus,
-				}
 			}
 
 			logger, _ := logging.NewLogger("", "status")
-			kubeclient := fakek8s.NewSimpleClientset()
-			got, err := MakeTaskRunStatus(t.Context(), logger, c.tr, &c.pod, kubeclient, c.tr.Spec.TaskSpec)
+			got, err := MakeTaskRunStatus(t.Context(), logger, tr, &pod, fakek8s.NewSimpleClientset(), taskSpec)
 			if err != nil {
-				t.Errorf("MakeTaskRunResult: %s", err)
+				t.Fatalf("MakeTaskRunStatus: %s", err)
 			}
 
-			// Common traits, set for test case brevity.
-			c.want.PodName = "pod"
+			cond := got.GetCondition(apis.ConditionSucceeded)
+			if cond.Reason != c.wantReason || cond.Message != c.wantMessage {
+				t.Errorf("got condition reason %q and message %q, want %q and %q", cond.Reason, cond.Message, c.wantReason, c.wantMessage)
+			}
+			if v := got.Steps[0].Outputs[0].Values[0].Verified; v != c.wantVerified {
+				t.Errorf("got step output verified %t, want %t", v, c.wantVerified)
+			}
+			if v := got.Artifacts.Outputs[0].Values[0].Verified; v != c.wantVerified {
+				t.Errorf("got taskrun output verified %t, want %t", v, c.wantVerified)
+			}
+		})
+	}
+}
 
-			ensureTimeNotNil := cmp.Comparer(func(x, y *metav1.Time) bool {
-				if x == nil {
-					return y == nil
-				}
-				return y != nil
+// checkStatus checks that Status computes the same status as MakeTaskRunStatus, without a kube client
+// unless the results are extracted from the sidecar logs, and leaves the TaskRun untouched.
+func checkStatus(t *testing.T, ctx context.Context, tr v1.TaskRun, pod *corev1.Pod, kubeclient kubernetes.Interface, ts *v1.TaskSpec) {
+	t.Helper()
+	logger, _ := logging.NewLogger("", "status")
+	want, wantErr := MakeTaskRunStatus(ctx, logger, *tr.DeepCopy(), pod, kubeclient, ts)
+
+	if config.FromContextOrDefaults(ctx).FeatureFlags.ResultExtractionMethod != config.ResultExtractionMethodSidecarLogs {
+		kubeclient = nil
+	}
+	before := tr.DeepCopy()
+	got, gotErr := Status(ctx, ObserveOptions{TaskRun: &tr, Pod: pod, TaskSpec: ts, KubeClient: kubeclient, Logger: logger})
+	if (gotErr == nil) != (wantErr == nil) {
+		t.Errorf("Status() returned error %v, MakeTaskRunStatus() returned error %v", gotErr, wantErr)
+	}
+	// The times set to now differ between the two calls.
+	closeTimes := cmp.Options{
+		cmp.Comparer(func(x, y metav1.Time) bool { return x.Sub(y.Time).Abs() < time.Minute }),
+		cmp.Comparer(func(x, y *metav1.Time) bool {
+			if x == nil || y == nil {
+				return x == y
+			}
+			return x.Sub(y.Time).Abs() < time.Minute
+		}),
+	}
+	if d := cmp.Diff(want, got, ignoreVolatileTime, closeTimes); d != "" {
+		t.Errorf("Status() and MakeTaskRunStatus() computed different statuses %s", diff.PrintWantGot(d))
+	}
+	if d := cmp.Diff(before, &tr); d != "" {
+		t.Errorf("Status() modified the TaskRun %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestStatus_WithoutTaskRunOrPod(t *testing.T) {
+	if _, err := Status(t.Context(), ObserveOptions{Pod: &corev1.Pod{}}); err == nil {
+		t.Error("Status() without a TaskRun returned no error")
+	}
+	if _, err := Status(t.Context(), ObserveOptions{TaskRun: &v1.TaskRun{}}); err == nil {
+		t.Error("Status() without a pod returned no error")
+	}
+}
+
+func TestStatus_SidecarLogsWithoutKubeClient(t *testing.T) {
+	ctx := config.ToContext(t.Context(), &config.Config{
+		FeatureFlags: &config.FeatureFlags{ResultExtractionMethod: config.ResultExtractionMethodSidecarLogs},
+	})
+	tr := &v1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "task-run", Namespace: "foo"},
+		Status: v1.TaskRunStatus{TaskRunStatusFields: v1.TaskRunStatusFields{
+			PodName:  "pod",
+			TaskSpec: &v1.TaskSpec{Results: []v1.TaskResult{{Name: "digest"}}},
+		}},
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "foo"},
+		Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+	_, err := Status(ctx, ObserveOptions{TaskRun: tr, Pod: pod})
+	if err == nil || !strings.Contains(err.Error(), "can't be read without a kube client") {
+		t.Errorf("Status() returned error %v, want an error about the missing kube client", err)
+	}
+}
+
+func TestStatus_ResultsSidecarHungClock(t *testing.T) {
+	stepsFinished := time.Date(2026, time.January, 1, 12, 0, 0, 0, time.UTC)
+	for _, c := range []struct {
+		desc string
+		now  time.Time
+		want corev1.ConditionStatus
+	}{{
+		desc: "within the grace period",
+		now:  stepsFinished.Add(time.Minute),
+		want: corev1.ConditionUnknown,
+	}, {
+		desc: "after the grace period",
+		now:  stepsFinished.Add(10 * time.Minute),
+		want: corev1.ConditionTrue,
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			ctx := config.ToContext(t.Context(), &config.Config{
+				FeatureFlags: &config.FeatureFlags{ResultExtractionMethod: config.ResultExtractionMethodSidecarLogs, MaxResultSize: 1024},
 			})
-			if d := cmp.Diff(c.want, got, ignoreVolatileTime, ensureTimeNotNil); d != "" {
-				t.Errorf("Diff %s", diff.PrintWantGot(d))
+			tr := &v1.TaskRun{
+				ObjectMeta: metav1.ObjectMeta{Name: "task-run", Namespace: "foo"},
+				Status: v1.TaskRunStatus{TaskRunStatusFields: v1.TaskRunStatusFields{
+					PodName:  "pod",
+					TaskSpec: &v1.TaskSpec{},
+				}},
+			}
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "foo"},
+				Spec: corev1.PodSpec{Containers: []corev1.Container{{
+					Name: "step-bar",
+				}, {
+					Name: pipeline.ReservedResultsSidecarContainerName,
+				}}},
+				Status: corev1.PodStatus{
+					Phase: corev1.PodRunning,
+					ContainerStatuses: []corev1.ContainerStatus{{
+						Name:  "step-bar",
+						State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{FinishedAt: metav1.NewTime(stepsFinished)}},
+					}, {
+						Name:  pipeline.ReservedResultsSidecarContainerName,
+						State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+					}},
+				},
+			}
+			got, err := Status(ctx, ObserveOptions{TaskRun: tr, Pod: pod, KubeClient: fakek8s.NewSimpleClientset(), Clock: testclock.NewFakePassiveClock(c.now)})
+			if err != nil {
+				t.Fatalf("Status() returned error %v", err)
+			}
+			if status := got.GetCondition(apis.ConditionSucceeded).Status; status != c.want {
+				t.Errorf("Status() returned a TaskRun whose Succeeded condition is %s, want %s", status, c.want)
+			}
+		})
+	}
+}
+
+func TestStatus_CompletionTime(t *testing.T) {
+	observedAt := time.Date(2026, time.January, 1, 12, 0, 0, 0, time.UTC)
+	tr := &v1.TaskRun{ObjectMeta: metav1.ObjectMeta{Name: "task-run", Namespace: "foo"}}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "foo"},
+		Status:     corev1.PodStatus{Phase: corev1.PodSucceeded},
+	}
+	for _, c := range []struct {
+		desc string
+		opts ObserveOptions
+	}{{
+		desc: "observed at",
+		opts: ObserveOptions{TaskRun: tr, Pod: pod, ObservedAt: observedAt, Clock: testclock.NewFakePassiveClock(observedAt.Add(time.Hour))},
+	}, {
+		desc: "clock",
+		opts: ObserveOptions{TaskRun: tr, Pod: pod, Clock: testclock.NewFakePassiveClock(observedAt)},
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			got, err := Status(t.Context(), c.opts)
+			if err != nil {
+				t.Fatalf("Status() returned error %v", err)
+			}
+			if got.CompletionTime == nil || !got.CompletionTime.Time.Equal(observedAt) {
+				t.Errorf("Status() returned completion time %v, want %v", got.CompletionTime, observedAt)
 			}
 		})
 	}
@@ -1350,9 +2256,10 @@ func TestMakeTaskRunStatus(t *testing.T) {
 				Sidecars:  []v1.SidecarState{},
 				Artifacts: &v1.Artifacts{},
 				Results: []v1.TaskRunResult{{
-					Name:  "resultName",
-					Type:  v1.ResultsTypeString,
-					Value: *v1.NewStructuredValues("resultValue"),
+					Name:       "resultName",
+					Type:       v1.ResultsTypeString,
+					Value:      *v1.NewStructuredValues("resultValue"),
+					ProducedBy: "bar",
 				}},
 				// We don't actually care about the time, just that it's not nil
 				CompletionTime: &metav1.Time{Time: time.Now()},
@@ -1386,9 +2293,10 @@ func TestMakeTaskRunStatus(t *testing.T) {
 				Sidecars:  []v1.SidecarState{},
 				Artifacts: &v1.Artifacts{},
 				Results: []v1.TaskRunResult{{
-					Name:  "resultName",
-					Type:  v1.ResultsTypeString,
-					Value: *v1.NewStructuredValues("resultValue"),
+					Name:       "resultName",
+					Type:       v1.ResultsTypeString,
+					Value:      *v1.NewStructuredValues("resultValue"),
+					ProducedBy: "banana",
 				}},
 				// We don't actually care about the time, just that it's not nil
 				CompletionTime: &metav1.Time{Time: time.Now()},
@@ -1437,13 +2345,15 @@ func TestMakeTaskRunStatus(t *testing.T) {
 				Sidecars:  []v1.SidecarState{},
 				Artifacts: &v1.Artifacts{},
 				Results: []v1.TaskRunResult{{
-					Name:  "resultNameOne",
-					Type:  v1.ResultsTypeString,
-					Value: *v1.NewStructuredValues("resultValueThree"),
+					Name:       "resultNameOne",
+					Type:       v1.ResultsTypeString,
+					Value:      *v1.NewStructuredValues("resultValueThree"),
+					ProducedBy: "two",
 				}, {
-					Name:  "resultNameTwo",
-					Type:  v1.ResultsTypeString,
-					Value: *v1.NewStructuredValues("resultValueTwo"),
+					Name:       "resultNameTwo",
+					Type:       v1.ResultsTypeString,
+					Value:      *v1.NewStructuredValues("resultValueTwo"),
+					ProducedBy: "two",
 				}},
 				// We don't actually care about the time, just that it's not nil
 				CompletionTime: &metav1.Time{Time: time.Now()},
@@ -1489,6 +2399,42 @@ func TestMakeTaskRunStatus(t *testing.T) {
 				CompletionTime: &metav1.Time{Time: time.Now()},
 			},
 		},
+	}, {
+		desc: "step command not found by the container runtime",
+		podStatus: corev1.PodStatus{
+			Phase: corev1.PodFailed,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name: "step-one",
+				State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{
+						Reason:   "StartError",
+						Message:  `failed to create containerd task: failed to create shim task: OCI runtime create failed: runc create failed: unable to start container process: exec: "foo": executable file not found in $PATH: unknown`,
+						ExitCode: 128,
+					},
+				},
+			}},
+		},
+		want: v1.TaskRunStatus{
+			Status: statusFailure(v1.TaskRunReasonStepFailed.String(), `"step-one" exited with code 128: StartError: failed to create containerd task: failed to create shim task: OCI runtime create failed: runc create failed: unable to start container process: exec: "foo": executable file not found in $PATH: unknown`),
+			TaskRunStatusFields: v1.TaskRunStatusFields{
+				Steps: []v1.StepState{{
+					ContainerState: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{
+							Reason:   "StartError",
+							Message:  `failed to create containerd task: failed to create shim task: OCI runtime create failed: runc create failed: unable to start container process: exec: "foo": executable file not found in $PATH: unknown`,
+							ExitCode: 128,
+						},
+					},
+					Name:              "one",
+					Container:         "step-one",
+					TerminationReason: TerminationReasonRunContainerError,
+				}},
+				Sidecars:  []v1.SidecarState{},
+				Artifacts: &v1.Artifacts{},
+				// We don't actually care about the time, just that it's not nil
+				CompletionTime: &metav1.Time{Time: time.Now()},
+			},
+		},
 	}, {
 		desc: "the failed task show task results",
 		podStatus: corev1.PodStatus{
@@ -1518,9 +2464,10 @@ func TestMakeTaskRunStatus(t *testing.T) {
 				Artifacts:      &v1.Artifacts{},
 				CompletionTime: &metav1.Time{Time: time.Now()},
 				Results: []v1.TaskRunResult{{
-					Name:  "resultName",
-					Type:  v1.ResultsTypeString,
-					Value: *v1.NewStructuredValues("resultValue"),
+					Name:       "resultName",
+					Type:       v1.ResultsTypeString,
+					Value:      *v1.NewStructuredValues("resultValue"),
+					ProducedBy: "task-result",
 				}},
 			},
 		},
@@ -1540,13 +2487,50 @@ func TestMakeTaskRunStatus(t *testing.T) {
 			TaskRunStatusFields: v1.TaskRunStatusFields{
 				Steps: []v1.StepState{{
 					ContainerState: corev1.ContainerState{
-						Terminated: &corev1.ContainerStateTerminated{},
+						Terminated: &corev1.ContainerStateTerminated{},
+					},
+					Name:      "mango",
+					Container: "step-mango",
+				}},
+				Sidecars:  []v1.SidecarState{},
+				Artifacts: &v1.Artifacts{},
+				// We don't actually care about the time, just that it's not nil
+				CompletionTime: &metav1.Time{Time: time.Now()},
+			},
+		},
+	}, {
+		desc: "termination message with non-JSON text before the results",
+		podStatus: corev1.PodStatus{
+			Phase: corev1.PodSucceeded,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name: "step-bar",
+				State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{
+						Message: "wrapper: starting [pid 1]\n" + `[{"key":"resultName","value":"resultValue", "type":1}]`,
+					},
+				},
+			}},
+		},
+		want: v1.TaskRunStatus{
+			Status: statusSuccess(),
+			TaskRunStatusFields: v1.TaskRunStatusFields{
+				Steps: []v1.StepState{{
+					ContainerState: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{
+							Message: "wrapper: starting [pid 1]\n" + `[{"key":"resultName","value":"resultValue","type":1}]`,
+						},
 					},
-					Name:      "mango",
-					Container: "step-mango",
+					Name:      "bar",
+					Container: "step-bar",
 				}},
 				Sidecars:  []v1.SidecarState{},
 				Artifacts: &v1.Artifacts{},
+				Results: []v1.TaskRunResult{{
+					Name:       "resultName",
+					Type:       v1.ResultsTypeString,
+					Value:      *v1.NewStructuredValues("resultValue"),
+					ProducedBy: "bar",
+				}},
 				// We don't actually care about the time, just that it's not nil
 				CompletionTime: &metav1.Time{Time: time.Now()},
 			},
@@ -1608,9 +2592,10 @@ func TestMakeTaskRunStatus(t *testing.T) {
 				Sidecars:  []v1.SidecarState{},
 				Artifacts: &v1.Artifacts{},
 				Results: []v1.TaskRunResult{{
-					Name:  "resultNameThree",
-					Type:  v1.ResultsTypeString,
-					Value: *v1.NewStructuredValues(""),
+					Name:       "resultNameThree",
+					Type:       v1.ResultsTypeString,
+					Value:      *v1.NewStructuredValues(""),
+					ProducedBy: "pear",
 				}},
 				// We don't actually care about the time, just that it's not nil
 				CompletionTime: &metav1.Time{Time: time.Now()},
@@ -1644,9 +2629,10 @@ func TestMakeTaskRunStatus(t *testing.T) {
 				Sidecars:  []v1.SidecarState{},
 				Artifacts: &v1.Artifacts{},
 				Results: []v1.TaskRunResult{{
-					Name:  "resultNameThree",
-					Type:  v1.ResultsTypeString,
-					Value: *v1.NewStructuredValues(""),
+					Name:       "resultNameThree",
+					Type:       v1.ResultsTypeString,
+					Value:      *v1.NewStructuredValues(""),
+					ProducedBy: "pear",
 				}},
 				// We don't actually care about the time, just that it's not nil
 				CompletionTime: &metav1.Time{Time: time.Now()},
@@ -2314,6 +3300,7 @@ func TestMakeTaskRunStatus(t *testing.T) {
 			}
 			logger, _ := logging.NewLogger("", "status")
 			kubeclient := fakek8s.NewSimpleClientset()
+			checkStatus(t, t.Context(), tr, &c.pod, kubeclient, &v1.TaskSpec{})
 			got, err := MakeTaskRunStatus(t.Context(), logger, tr, &c.pod, kubeclient, &v1.TaskSpec{})
 			if err != nil {
 				t.Errorf("MakeTaskRunResult: %s", err)
@@ -2334,7 +3321,7 @@ func TestMakeTaskRunStatus(t *testing.T) {
 				}
 				return y != nil
 			})
-			if d := cmp.Diff(c.want, got, ignoreVolatileTime, ensureTimeNotNil); d != "" {
+			if d := cmp.Diff(c.want, got, ignoreVolatileTime, ignoreReasonHistory, ensureTimeNotNil); d != "" {
 				t.Errorf("Diff %s", diff.PrintWantGot(d))
 			}
 			if tr.Status.StartTime.Time != c.want.StartTime.Time {
@@ -2406,7 +3393,7 @@ func TestMakeRunStatus_OnError(t *testing.T) {
 				t.Errorf("Unexpected err in MakeTaskRunResult: %s", err)
 			}
 
-			if d := cmp.Diff(c.want.Status, got.Status, ignoreVolatileTime); d != "" {
+			if d := cmp.Diff(c.want.Status, got.Status, ignoreVolatileTime, ignoreReasonHistory); d != "" {
 				t.Errorf("Diff %s", diff.PrintWantGot(d))
 			}
 		})
@@ -2492,6 +3479,112 @@ func TestMakeTaskRunStatus_SidecarNotCompleted(t *testing.T) {
 		want: v1.TaskRunStatus{
 			Status: statusSuccess(),
 		},
+	}, {
+		desc: "test sidecar not completed within the grace period",
+		podStatus: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			ContainerStatuses: []corev1.ContainerStatus{
+				{
+					Name: "other-prefix-container",
+					State: corev1.ContainerState{
+						Running: &corev1.ContainerStateRunning{},
+					},
+				},
+				{
+					Name: "step-bar",
+					State: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{
+							FinishedAt: metav1.NewTime(time.Now().Add(-time.Minute)),
+						},
+					},
+				},
+				{
+					Name: "sidecar-tekton-log-results",
+					State: corev1.ContainerState{
+						Running: &corev1.ContainerStateRunning{},
+					},
+				},
+			},
+		},
+		taskSpec: v1.TaskSpec{
+			Results: []v1.TaskResult{
+				{
+					Name: "resultName",
+					Type: v1.ResultsTypeString,
+				},
+			},
+		},
+		want: v1.TaskRunStatus{
+			Status: statusRunning(),
+		},
+	}, {
+		desc: "test sidecar hung without results to read",
+		podStatus: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			ContainerStatuses: []corev1.ContainerStatus{
+				{
+					Name: "other-prefix-container",
+					State: corev1.ContainerState{
+						Running: &corev1.ContainerStateRunning{},
+					},
+				},
+				{
+					Name: "step-bar",
+					State: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{
+							FinishedAt: metav1.NewTime(time.Now().Add(-time.Hour)),
+						},
+					},
+				},
+				{
+					Name: "sidecar-tekton-log-results",
+					State: corev1.ContainerState{
+						Running: &corev1.ContainerStateRunning{},
+					},
+				},
+			},
+		},
+		want: v1.TaskRunStatus{
+			Status: statusSuccess(),
+		},
+	}, {
+		desc: "test sidecar hung with logs which can't be read",
+		podStatus: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			ContainerStatuses: []corev1.ContainerStatus{
+				{
+					Name: "other-prefix-container",
+					State: corev1.ContainerState{
+						Running: &corev1.ContainerStateRunning{},
+					},
+				},
+				{
+					Name: "step-bar",
+					State: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{
+							FinishedAt: metav1.NewTime(time.Now().Add(-time.Hour)),
+						},
+					},
+				},
+				{
+					Name: "sidecar-tekton-log-results",
+					State: corev1.ContainerState{
+						Running: &corev1.ContainerStateRunning{},
+					},
+				},
+			},
+		},
+		taskSpec: v1.TaskSpec{
+			Results: []v1.TaskResult{
+				{
+					Name: "resultName",
+					Type: v1.ResultsTypeString,
+				},
+			},
+		},
+		want: v1.TaskRunStatus{
+			Status: statusFailure(ReasonResultsSidecarHung, "The results sidecar container sidecar-tekton-log-results didn't complete within 5m0s after the steps finished and its logs couldn't be read: invalid result \"\": invalid character 'k' in literal false (expecting 'l')"),
+		},
 	}} {
 		t.Run(c.desc, func(t *testing.T) {
 			c.pod = corev1.Pod{
@@ -2531,8 +3624,9 @@ func TestMakeTaskRunStatus_SidecarNotCompleted(t *testing.T) {
 					MaxResultSize:          1024,
 				},
 			})
+			checkStatus(t, ctx, tr, &c.pod, kubeclient, &c.taskSpec)
 			got, _ := MakeTaskRunStatus(ctx, logger, tr, &c.pod, kubeclient, &c.taskSpec)
-			if d := cmp.Diff(c.want.Status, got.Status, ignoreVolatileTime); d != "" {
+			if d := cmp.Diff(c.want.Status, got.Status, ignoreVolatileTime, ignoreReasonHistory); d != "" {
 				t.Errorf("Unexpected status: %s", diff.PrintWantGot(d))
 			}
 		})
@@ -2796,8 +3890,9 @@ func TestMakeTaskRunStatus_KubernetesNativeSidecar(t *testing.T) {
 					EnableKubernetesSidecar: true,
 				},
 			})
+			checkStatus(t, ctx, tr, &c.pod, kubeclient, &c.taskSpec)
 			got, _ := MakeTaskRunStatus(ctx, logger, tr, &c.pod, kubeclient, &c.taskSpec)
-			if d := cmp.Diff(c.want.Status, got.Status, ignoreVolatileTime); d != "" {
+			if d := cmp.Diff(c.want.Status, got.Status, ignoreVolatileTime, ignoreReasonHistory); d != "" {
 				t.Errorf("Unexpected status: %s", diff.PrintWantGot(d))
 			}
 		})
@@ -2847,9 +3942,10 @@ func TestMakeTaskRunStatusAlpha(t *testing.T) {
 				Sidecars:  []v1.SidecarState{},
 				Artifacts: &v1.Artifacts{},
 				Results: []v1.TaskRunResult{{
-					Name:  "resultName",
-					Type:  v1.ResultsTypeString,
-					Value: *v1.NewStructuredValues(""),
+					Name:       "resultName",
+					Type:       v1.ResultsTypeString,
+					Value:      *v1.NewStructuredValues(""),
+					ProducedBy: "bar",
 				}},
 				// We don't actually care about the time, just that it's not nil
 				CompletionTime: &metav1.Time{Time: time.Now()},
@@ -2891,9 +3987,10 @@ func TestMakeTaskRunStatusAlpha(t *testing.T) {
 				Sidecars:  []v1.SidecarState{},
 				Artifacts: &v1.Artifacts{},
 				Results: []v1.TaskRunResult{{
-					Name:  "resultName",
-					Type:  v1.ResultsTypeString,
-					Value: *v1.NewStructuredValues("hello"),
+					Name:       "resultName",
+					Type:       v1.ResultsTypeString,
+					Value:      *v1.NewStructuredValues("hello"),
+					ProducedBy: "bar",
 				}},
 				// We don't actually care about the time, just that it's not nil
 				CompletionTime: &metav1.Time{Time: time.Now()},
@@ -2935,9 +4032,10 @@ func TestMakeTaskRunStatusAlpha(t *testing.T) {
 				Sidecars:  []v1.SidecarState{},
 				Artifacts: &v1.Artifacts{},
 				Results: []v1.TaskRunResult{{
-					Name:  "resultName",
-					Type:  v1.ResultsTypeArray,
-					Value: *v1.NewStructuredValues("hello", "world"),
+					Name:       "resultName",
+					Type:       v1.ResultsTypeArray,
+					Value:      *v1.NewStructuredValues("hello", "world"),
+					ProducedBy: "bar",
 				}},
 				// We don't actually care about the time, just that it's not nil
 				CompletionTime: &metav1.Time{Time: time.Now()},
@@ -2979,9 +4077,10 @@ func TestMakeTaskRunStatusAlpha(t *testing.T) {
 				Sidecars:  []v1.SidecarState{},
 				Artifacts: &v1.Artifacts{},
 				Results: []v1.TaskRunResult{{
-					Name:  "resultName",
-					Type:  v1.ResultsTypeObject,
-					Value: *v1.NewObject(map[string]string{"hello": "world"}),
+					Name:       "resultName",
+					Type:       v1.ResultsTypeObject,
+					Value:      *v1.NewObject(map[string]string{"hello": "world"}),
+					ProducedBy: "bar",
 				}},
 				// We don't actually care about the time, just that it's not nil
 				CompletionTime: &metav1.Time{Time: time.Now()},
@@ -3027,13 +4126,15 @@ func TestMakeTaskRunStatusAlpha(t *testing.T) {
 				Sidecars:  []v1.SidecarState{},
 				Artifacts: &v1.Artifacts{},
 				Results: []v1.TaskRunResult{{
-					Name:  "resultName",
-					Type:  v1.ResultsTypeString,
-					Value: *v1.NewStructuredValues("{\"hello\":\"world\"}"),
+					Name:       "resultName",
+					Type:       v1.ResultsTypeString,
+					Value:      *v1.NewStructuredValues("{\"hello\":\"world\"}"),
+					ProducedBy: "bar",
 				}, {
-					Name:  "resultName2",
-					Type:  v1.ResultsTypeString,
-					Value: *v1.NewStructuredValues("[\"hello\",\"world\"]"),
+					Name:       "resultName2",
+					Type:       v1.ResultsTypeString,
+					Value:      *v1.NewStructuredValues("[\"hello\",\"world\"]"),
+					ProducedBy: "bar",
 				}},
 				// We don't actually care about the time, just that it's not nil
 				CompletionTime: &metav1.Time{Time: time.Now()},
@@ -3067,6 +4168,7 @@ func TestMakeTaskRunStatusAlpha(t *testing.T) {
 			}
 			logger, _ := logging.NewLogger("", "status")
 			kubeclient := fakek8s.NewSimpleClientset()
+			checkStatus(t, t.Context(), tr, &c.pod, kubeclient, &c.taskSpec)
 			got, err := MakeTaskRunStatus(t.Context(), logger, tr, &c.pod, kubeclient, &c.taskSpec)
 			if err != nil {
 				t.Errorf("MakeTaskRunResult: %s", err)
@@ -3087,7 +4189,7 @@ func TestMakeTaskRunStatusAlpha(t *testing.T) {
 				}
 				return y != nil
 			})
-			if d := cmp.Diff(c.want, got, ignoreVolatileTime, ensureTimeNotNil); d != "" {
+			if d := cmp.Diff(c.want, got, ignoreVolatileTime, ignoreReasonHistory, ensureTimeNotNil); d != "" {
 				t.Errorf("Diff %s", diff.PrintWantGot(d))
 			}
 			if tr.Status.StartTime.Time != c.want.StartTime.Time {
@@ -3097,6 +4199,254 @@ func TestMakeTaskRunStatusAlpha(t *testing.T) {
 	}
 }
 
+func TestMakeTaskRunStatus_TrimStepTerminationMessages(t *testing.T) {
+	resultsMessage := `[{"key":"digest","value":"sha256:1234","type":1},{"key":"uri","value":"https://foo.bar","type":4}]`
+	for _, c := range []struct {
+		desc        string
+		enabled     bool
+		phase       corev1.PodPhase
+		message     string
+		wantMessage string
+	}{{
+		desc:        "results extracted",
+		enabled:     true,
+		phase:       corev1.PodSucceeded,
+		message:     resultsMessage,
+		wantMessage: TerminationMessageResultsExtracted,
+	}, {
+		desc:        "internal results are dropped before trimming",
+		enabled:     true,
+		phase:       corev1.PodSucceeded,
+		message:     `[{"key":"StartedAt","value":"2023-01-01T00:00:00.000Z","type":3},{"key":"digest","value":"sha256:1234","type":1}]`,
+		wantMessage: TerminationMessageResultsExtracted,
+	}, {
+		desc:        "flag disabled",
+		enabled:     false,
+		phase:       corev1.PodSucceeded,
+		message:     resultsMessage,
+		wantMessage: resultsMessage,
+	}, {
+		desc:        "message with non result content is kept",
+		enabled:     true,
+		phase:       corev1.PodSucceeded,
+		message:     `[{"key":"digest","value":"sha256:1234","resourceName":"source-image"}]`,
+		wantMessage: `[{"key":"digest","value":"sha256:1234","resourceName":"source-image"}]`,
+	}, {
+		desc:        "unparsable message is kept",
+		enabled:     true,
+		phase:       corev1.PodSucceeded,
+		message:     "not json",
+		wantMessage: "not json",
+	}, {
+		desc:        "message is kept while the taskrun is running",
+		enabled:     true,
+		phase:       corev1.PodRunning,
+		message:     resultsMessage,
+		wantMessage: resultsMessage,
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			pod := corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "pod",
+					Namespace: "foo",
+				},
+				Status: corev1.PodStatus{
+					Phase: c.phase,
+					ContainerStatuses: []corev1.ContainerStatus{{
+						Name: "step-one",
+						State: corev1.ContainerState{
+							Terminated: &corev1.ContainerStateTerminated{
+								Message: c.message,
+							},
+						},
+					}, {
+						Name: "step-two",
+						State: corev1.ContainerState{
+							Running: &corev1.ContainerStateRunning{},
+						},
+					}},
+				},
+			}
+			if c.phase == corev1.PodSucceeded {
+				pod.Status.ContainerStatuses[1].State = corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{}}
+			}
+			ts := &v1.TaskSpec{
+				Results: []v1.TaskResult{{Name: "digest", Type: v1.ResultsTypeString}},
+				Steps: []v1.Step{{
+					Name:    "one",
+					Results: []v1.StepResult{{Name: "uri", Type: v1.ResultsTypeString}},
+				}, {
+					Name: "two",
+				}},
+			}
+			tr := v1.TaskRun{ObjectMeta: metav1.ObjectMeta{Name: "task-run", Namespace: "foo"}}
+			ctx := config.ToContext(t.Context(), &config.Config{
+				FeatureFlags: &config.FeatureFlags{
+					EnableStepTerminationMessageTrimming: c.enabled,
+				},
+			})
+
+			logger, _ := logging.NewLogger("", "status")
+			got, _ := MakeTaskRunStatus(ctx, logger, tr, &pod, fakek8s.NewSimpleClientset(), ts)
+
+			if len(got.Steps) != 2 {
+				t.Fatalf("expected 2 step states, got %d", len(got.Steps))
+			}
+			if d := cmp.Diff(c.wantMessage, got.Steps[0].Terminated.Message); d != "" {
+				t.Errorf("unexpected termination message %s", diff.PrintWantGot(d))
+			}
+			if c.wantMessage == TerminationMessageResultsExtracted && len(got.Results) == 0 {
+				t.Errorf("expected results to be extracted before the message was trimmed")
+			}
+		})
+	}
+}
+
+func TestMakeTaskRunStatus_StepDirectoryIsolation(t *testing.T) {
+	for _, c := range []struct {
+		desc           string
+		enabled        bool
+		wantTwoResults []v1.TaskRunStepResult
+		wantEvents     []string
+	}{{
+		desc:       "spoofed step result rejected",
+		enabled:    true,
+		wantEvents: []string{"Warning StepResultsRejected rejected the step results uri reported for step container step-two: the step doesn't declare them, so they weren't written by it"},
+	}, {
+		desc:           "flag disabled",
+		enabled:        false,
+		wantTwoResults: []v1.TaskRunStepResult{{Name: "uri", Type: v1.ResultsTypeString, Value: *v1.NewStructuredValues("https://evil.example")}},
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			pod := corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "pod",
+					Namespace: "foo",
+				},
+				Status: corev1.PodStatus{
+					Phase: corev1.PodSucceeded,
+					ContainerStatuses: []corev1.ContainerStatus{{
+						Name: "step-one",
+						State: corev1.ContainerState{
+							Terminated: &corev1.ContainerStateTerminated{
+								Message: `[{"key":"uri","value":"https://foo.bar","type":4}]`,
+							},
+						},
+					}, {
+						// step-two claims the result of step-one in its termination message
+						Name: "step-two",
+						State: corev1.ContainerState{
+							Terminated: &corev1.ContainerStateTerminated{
+								Message: `[{"key":"uri","value":"https://evil.example","type":4}]`,
+							},
+						},
+					}},
+				},
+			}
+			ts := &v1.TaskSpec{
+				Steps: []v1.Step{{
+					Name:    "one",
+					Results: []v1.StepResult{{Name: "uri", Type: v1.ResultsTypeString}},
+				}, {
+					Name: "two",
+				}},
+			}
+			tr := v1.TaskRun{ObjectMeta: metav1.ObjectMeta{Name: "task-run", Namespace: "foo"}}
+			recorder := record.NewFakeRecorder(10)
+			ctx := controller.WithEventRecorder(t.Context(), recorder)
+			ctx = config.ToContext(ctx, &config.Config{
+				FeatureFlags: &config.FeatureFlags{
+					EnableStepDirectoryIsolation: c.enabled,
+				},
+			})
+
+			logger, _ := logging.NewLogger("", "status")
+			got, err := MakeTaskRunStatus(ctx, logger, tr, &pod, fakek8s.NewSimpleClientset(), ts)
+			if err != nil {
+				t.Fatalf("MakeTaskRunStatus: %v", err)
+			}
+			var events []string
+			close(recorder.Events)
+			for e := range recorder.Events {
+				events = append(events, e)
+			}
+			if d := cmp.Diff(c.wantEvents, events); d != "" {
+				t.Errorf("Unexpected events %s", diff.PrintWantGot(d))
+			}
+			if len(got.Steps) != 2 {
+				t.Fatalf("expected 2 step states, got %d", len(got.Steps))
+			}
+			wantOneResults := []v1.TaskRunStepResult{{Name: "uri", Type: v1.ResultsTypeString, Value: *v1.NewStructuredValues("https://foo.bar")}}
+			if d := cmp.Diff(wantOneResults, got.Steps[0].Results); d != "" {
+				t.Errorf("unexpected results of step one %s", diff.PrintWantGot(d))
+			}
+			if d := cmp.Diff(c.wantTwoResults, got.Steps[1].Results, cmpopts.EquateEmpty()); d != "" {
+				t.Errorf("unexpected results of step two %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestMakeTaskRunStatus_TrimStepTerminationMessagesSize(t *testing.T) {
+	const numResults = 40
+	var runResults []result.RunResult
+	var specResults []v1.TaskResult
+	for i := range numResults {
+		name := fmt.Sprintf("result-%d", i)
+		runResults = append(runResults, result.RunResult{Key: name, Value: strings.Repeat("v", 64), ResultType: result.TaskRunResultType})
+		specResults = append(specResults, v1.TaskResult{Name: name, Type: v1.ResultsTypeString})
+	}
+	message, err := json.Marshal(runResults)
+	if err != nil {
+		t.Fatalf("failed to marshal results: %v", err)
+	}
+
+	pod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "foo"},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodSucceeded,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name: "step-one",
+				State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{Message: string(message)},
+				},
+			}},
+		},
+	}
+	ts := &v1.TaskSpec{Results: specResults, Steps: []v1.Step{{Name: "one"}}}
+	tr := v1.TaskRun{ObjectMeta: metav1.ObjectMeta{Name: "task-run", Namespace: "foo"}}
+	logger, _ := logging.NewLogger("", "status")
+
+	statusSize := func(enabled bool) (v1.TaskRunStatus, int) {
+		t.Helper()
+		ctx := config.ToContext(t.Context(), &config.Config{
+			FeatureFlags: &config.FeatureFlags{EnableStepTerminationMessageTrimming: enabled},
+		})
+		trs, err := MakeTaskRunStatus(ctx, logger, tr, &pod, fakek8s.NewSimpleClientset(), ts)
+		if err != nil {
+			t.Fatalf("MakeTaskRunStatus: %v", err)
+		}
+		b, err := json.Marshal(trs)
+		if err != nil {
+			t.Fatalf("failed to marshal status: %v", err)
+		}
+		return trs, len(b)
+	}
+
+	untrimmed, untrimmedSize := statusSize(false)
+	trimmed, trimmedSize := statusSize(true)
+
+	if d := cmp.Diff(untrimmed.Results, trimmed.Results); d != "" {
+		t.Errorf("trimming must not change the task results %s", diff.PrintWantGot(d))
+	}
+	if len(trimmed.Results) != numResults {
+		t.Errorf("expected %d task results, got %d", numResults, len(trimmed.Results))
+	}
+	if saved := untrimmedSize - trimmedSize; saved < len(message)-len(TerminationMessageResultsExtracted) {
+		t.Errorf("expected trimming to save at least %d bytes, saved %d (untrimmed %d, trimmed %d)", len(message)-len(TerminationMessageResultsExtracted), saved, untrimmedSize, trimmedSize)
+	}
+}
+
 func TestMakeRunStatusJSONError(t *testing.T) {
 	pod := &corev1.Pod{
 		ObjectMeta: metav1.ObjectMeta{
@@ -3199,21 +4549,101 @@ func TestMakeRunStatusJSONError(t *testing.T) {
 		},
 	}
 
-	logger, _ := logging.NewLogger("", "status")
-	kubeclient := fakek8s.NewSimpleClientset()
-	gotTr, err := MakeTaskRunStatus(t.Context(), logger, tr, pod, kubeclient, &v1.TaskSpec{})
-	if err == nil {
-		t.Error("Expected error, got nil")
+	logger, _ := logging.NewLogger("", "status")
+	kubeclient := fakek8s.NewSimpleClientset()
+	gotTr, err := MakeTaskRunStatus(t.Context(), logger, tr, pod, kubeclient, &v1.TaskSpec{})
+	if err == nil {
+		t.Error("Expected error, got nil")
+	}
+
+	ensureTimeNotNil := cmp.Comparer(func(x, y *metav1.Time) bool {
+		if x == nil {
+			return y == nil
+		}
+		return y != nil
+	})
+	if d := cmp.Diff(wantTr, gotTr, ignoreVolatileTime, ignoreReasonHistory, ensureTimeNotNil); d != "" {
+		t.Errorf("Diff %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestParseTerminationMessage(t *testing.T) {
+	longPrefix := strings.Repeat("a", maxTerminationMessagePrefixLength+10)
+	for _, c := range []struct {
+		desc        string
+		msg         string
+		wantPrefix  string
+		wantResults []result.RunResult
+		wantErr     bool
+	}{{
+		desc: "results only",
+		msg:  `[{"key":"foo","value":"bar","type":1}]`,
+		wantResults: []result.RunResult{{
+			Key:        "foo",
+			Value:      "bar",
+			ResultType: result.TaskRunResultType,
+		}},
+	}, {
+		desc:       "text before the results",
+		msg:        "starting up...\n" + `[{"key":"foo","value":"[bar]","type":1}]` + "\n",
+		wantPrefix: "starting up...\n",
+		wantResults: []result.RunResult{{
+			Key:        "foo",
+			Value:      "[bar]",
+			ResultType: result.TaskRunResultType,
+		}},
+	}, {
+		desc:       "other JSON documents before the results",
+		msg:        `{"level":"info"}["not","results"]` + `[{"key":"foo","value":"bar","type":1}]`,
+		wantPrefix: `{"level":"info"}["not","results"]`,
+		wantResults: []result.RunResult{{
+			Key:        "foo",
+			Value:      "bar",
+			ResultType: result.TaskRunResultType,
+		}},
+	}, {
+		desc:    "no results",
+		msg:     "this is a non-json termination message. dont panic!",
+		wantErr: true,
+	}, {
+		desc:    "no well-formed array at the end",
+		msg:     `starting up [{"key":"foo"]`,
+		wantErr: true,
+	}, {
+		desc:       "long text before the results",
+		msg:        longPrefix + `[{"key":"foo","value":"bar","type":1}]`,
+		wantPrefix: longPrefix,
+		wantResults: []result.RunResult{{
+			Key:        "foo",
+			Value:      "bar",
+			ResultType: result.TaskRunResultType,
+		}},
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			logger, _ := logging.NewLogger("", "status")
+			prefix, results, err := parseTerminationMessage(logger, c.msg)
+			if (err != nil) != c.wantErr {
+				t.Fatalf("parseTerminationMessage() error = %v, wantErr %t", err, c.wantErr)
+			}
+			if prefix != c.wantPrefix {
+				t.Errorf("parseTerminationMessage() prefix = %q, want %q", prefix, c.wantPrefix)
+			}
+			if d := cmp.Diff(c.wantResults, results); d != "" {
+				t.Errorf("parseTerminationMessage() results %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestTruncateTerminationMessagePrefix(t *testing.T) {
+	short := "starting up...\n"
+	if got := truncateTerminationMessagePrefix(short); got != short {
+		t.Errorf("truncateTerminationMessagePrefix() = %q, want %q", got, short)
 	}
-
-	ensureTimeNotNil := cmp.Comparer(func(x, y *metav1.Time) bool {
-		if x == nil {
-			return y == nil
-		}
-		return y != nil
-	})
-	if d := cmp.Diff(wantTr, gotTr, ignoreVolatileTime, ensureTimeNotNil); d != "" {
-		t.Errorf("Diff %s", diff.PrintWantGot(d))
+	long := strings.Repeat("a", maxTerminationMessagePrefixLength) + "bcd"
+	want := strings.Repeat("a", maxTerminationMessagePrefixLength) + "...(truncated)"
+	if got := truncateTerminationMessagePrefix(long); got != want {
+		t.Errorf("truncateTerminationMessagePrefix() = %q, want %q", got, want)
 	}
 }
 
@@ -3355,6 +4785,27 @@ func TestMarkStatusSuccess(t *testing.T) {
 	}
 }
 
+func TestRecordReason(t *testing.T) {
+	trs := v1.TaskRunStatus{}
+	markStatusRunning(&trs, ReasonPodPending, "pending")
+	markStatusRunning(&trs, ReasonPodPending, "still pending")
+	markStatusRunning(&trs, v1.TaskRunReasonRunning.String(), "running")
+	markStatusRunning(&trs, v1.TaskRunReasonRunning.String(), "still running")
+	markStatusSuccess(&trs)
+
+	want := []string{ReasonPodPending, v1.TaskRunReasonRunning.String(), v1.TaskRunReasonSuccessful.String()}
+	var got []string
+	for _, rt := range trs.ReasonHistory {
+		if rt.Time.IsZero() {
+			t.Errorf("Expected the transition to %s to have a time", rt.Reason)
+		}
+		got = append(got, rt.Reason)
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("Unexpected reason history: %s", diff.PrintWantGot(d))
+	}
+}
+
 func TestIsPodArchived(t *testing.T) {
 	for _, tc := range []struct {
 		name          string
@@ -3466,6 +4917,7 @@ func TestSortPodContainerStatuses(t *testing.T) {
 	}
 	containerStatusNames := []string{
 		"step-copy-to-latest-bucket",
+		"step-unknown-b",
 		"step-create-dir-bucket-gtw4k",
 		"step-create-dir-bucket-jr2lk",
 		"step-create-dir-builtcontrollerimage-4nncs",
@@ -3489,6 +4941,7 @@ func TestSortPodContainerStatuses(t *testing.T) {
 		"step-source-mkdir-bucket-ljkcz",
 		"step-tag-images",
 		"step-upload-bucket-kt9b4",
+		"step-unknown-a",
 	}
 	containers := []corev1.Container{}
 	statuses := []corev1.ContainerStatus{}
@@ -3503,10 +4956,44 @@ func TestSortPodContainerStatuses(t *testing.T) {
 		})
 	}
 	sortPodContainerStatuses(statuses, containers)
-	for i := range statuses {
-		if statuses[i].Name != containers[i].Name {
-			t.Errorf("container status out of order: want %q got %q", containers[i].Name, statuses[i].Name)
-		}
+	var got []string
+	for _, s := range statuses {
+		got = append(got, s.Name)
+	}
+	// The statuses of the containers which aren't in the spec sort last, in their original order.
+	want := append(slices.Clone(containerNames), "step-unknown-b", "step-unknown-a")
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("container statuses out of order %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestSortPodContainerStatuses_MissingStatuses(t *testing.T) {
+	containers := []corev1.Container{{Name: "step-a"}, {Name: "step-b"}, {Name: "step-c"}, {Name: "sidecar-d"}}
+	statuses := []corev1.ContainerStatus{{Name: "sidecar-d"}, {Name: "step-unknown"}, {Name: "step-c"}, {Name: "step-a"}}
+	sortPodContainerStatuses(statuses, containers)
+	var got []string
+	for _, s := range statuses {
+		got = append(got, s.Name)
+	}
+	if d := cmp.Diff([]string{"step-a", "step-c", "sidecar-d", "step-unknown"}, got); d != "" {
+		t.Errorf("container statuses out of order %s", diff.PrintWantGot(d))
+	}
+}
+
+func BenchmarkSortPodContainerStatuses(b *testing.B) {
+	var containers []corev1.Container
+	for i := range 50 {
+		containers = append(containers, corev1.Container{Name: fmt.Sprintf("step-%02d", i)})
+	}
+	// The statuses are in the reverse order of the containers.
+	reversed := make([]corev1.ContainerStatus, len(containers))
+	for i, c := range containers {
+		reversed[len(containers)-1-i] = corev1.ContainerStatus{Name: c.Name}
+	}
+	statuses := make([]corev1.ContainerStatus, len(containers))
+	for b.Loop() {
+		copy(statuses, reversed)
+		sortPodContainerStatuses(statuses, containers)
 	}
 }
 
@@ -3551,6 +5038,36 @@ func TestGetStepResultsFromSidecarLogs_Error(t *testing.T) {
 	}
 }
 
+func TestRejectUndeclaredStepResults(t *testing.T) {
+	// The sidecar logs claim that step-foo wrote "spoofed", which only step-bar declares
+	sidecarLogResults := []result.RunResult{{
+		Key:        "step-foo.digest",
+		Value:      "sha256:1234",
+		ResultType: result.StepResultType,
+	}, {
+		Key:        "step-foo.spoofed",
+		Value:      "sha256:6666",
+		ResultType: result.StepResultType,
+	}}
+	stepResults, err := getStepResultsFromSidecarLogs(sidecarLogResults, "step-foo")
+	if err != nil {
+		t.Fatalf("did not expect an error but got: %v", err)
+	}
+	got, err := rejectUndeclaredStepResults(stepResults, "step-foo", []v1.StepResult{{Name: "digest"}})
+	want := []result.RunResult{{
+		Key:        "digest",
+		Value:      "sha256:1234",
+		ResultType: result.StepResultType,
+	}}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Error(diff.PrintWantGot(d))
+	}
+	wantErr := "rejected the step results spoofed reported for step container step-foo: the step doesn't declare them, so they weren't written by it"
+	if err == nil || err.Error() != wantErr {
+		t.Errorf("expected error %q but got: %v", wantErr, err)
+	}
+}
+
 func TestGetStepTerminationReasonFromContainerStatus(t *testing.T) {
 	tests := []struct {
 		desc                      string
@@ -3653,6 +5170,38 @@ func TestGetStepTerminationReasonFromContainerStatus(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "Step script tampered",
+			expectedTerminationReason: map[string]string{
+				"step-1": "ScriptTampered",
+			},
+			pod: corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "pod",
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{Name: "step-1"},
+					},
+				},
+				Status: corev1.PodStatus{
+					Phase: corev1.PodFailed,
+					ContainerStatuses: []corev1.ContainerStatus{
+						{
+							Name:    "step-1",
+							ImageID: "image-id-1",
+							State: corev1.ContainerState{
+								Terminated: &corev1.ContainerStateTerminated{
+									Message:  `[{"key":"StartedAt","value":"2023-11-26T19:53:29.452Z","type":3},{"key":"Reason","value":"ScriptTampered","type":3}]`,
+									ExitCode: 1,
+									Reason:   "Error",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			desc: "Step completed",
 			expectedTerminationReason: map[string]string{
@@ -3663,92 +5212,533 @@ func TestGetStepTerminationReasonFromContainerStatus(t *testing.T) {
 					Name: "pod",
 				},
 				Spec: corev1.PodSpec{
-					Containers: []corev1.Container{
-						{Name: "step-1"},
-					},
+					Containers: []corev1.Container{
+						{Name: "step-1"},
+					},
+				},
+				Status: corev1.PodStatus{
+					Phase: corev1.PodFailed,
+					ContainerStatuses: []corev1.ContainerStatus{
+						{
+							Name:    "step-1",
+							ImageID: "image-id-1",
+							State: corev1.ContainerState{
+								Terminated: &corev1.ContainerStateTerminated{
+									Message:  `[{"key":"StartedAt","value":"2023-11-26T19:53:29.452Z","type":3}]`,
+									ExitCode: 0,
+									Reason:   "Completed",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			desc: "Step not started by the container runtime",
+			expectedTerminationReason: map[string]string{
+				"step-1": "RunContainerError",
+			},
+			pod: corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "pod",
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{Name: "step-1"},
+					},
+				},
+				Status: corev1.PodStatus{
+					Phase: corev1.PodFailed,
+					ContainerStatuses: []corev1.ContainerStatus{
+						{
+							Name:    "step-1",
+							ImageID: "image-id-1",
+							State: corev1.ContainerState{
+								Terminated: &corev1.ContainerStateTerminated{
+									Message:  `exec: "foo": executable file not found in $PATH`,
+									ExitCode: 127,
+									Reason:   "ContainerCannotRun",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			desc: "Step error",
+			expectedTerminationReason: map[string]string{
+				"step-1": "Error",
+			},
+			pod: corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "pod",
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{Name: "step-1"},
+					},
+				},
+				Status: corev1.PodStatus{
+					Phase: corev1.PodFailed,
+					ContainerStatuses: []corev1.ContainerStatus{
+						{
+							Name:    "step-1",
+							ImageID: "image-id-1",
+							State: corev1.ContainerState{
+								Terminated: &corev1.ContainerStateTerminated{
+									Message:  `[{"key":"StartedAt","value":"2023-11-26T19:53:29.452Z","type":3}]`,
+									ExitCode: 1,
+									Reason:   "Error",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			startTime := time.Date(2010, 1, 1, 1, 1, 1, 1, time.UTC)
+			tr := v1.TaskRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "task-run",
+				},
+				Status: v1.TaskRunStatus{
+					TaskRunStatusFields: v1.TaskRunStatusFields{
+						StartTime: &metav1.Time{Time: startTime},
+					},
+				},
+			}
+			logger, _ := logging.NewLogger("", "status")
+			kubeclient := fakek8s.NewSimpleClientset()
+
+			trs, err := MakeTaskRunStatus(t.Context(), logger, tr, &test.pod, kubeclient, &v1.TaskSpec{})
+			if err != nil {
+				t.Errorf("MakeTaskRunResult: %s", err)
+			}
+
+			for _, step := range trs.Steps {
+				if step.TerminationReason == "" {
+					t.Errorf("Terminated status not found for %s", step.Name)
+				}
+				want := test.expectedTerminationReason[step.Container]
+				got := step.TerminationReason
+				if d := cmp.Diff(want, got, ignoreVolatileTime, ignoreReasonHistory); d != "" {
+					t.Errorf("Diff %s", diff.PrintWantGot(d))
+				}
+			}
+		})
+	}
+}
+
+func TestMakeTaskRunStatus_StepRetryCount(t *testing.T) {
+	pod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "pod"},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "step-fetch"}, {Name: "step-push"}, {Name: "step-report"}},
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodFailed,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name: "step-fetch",
+				State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{
+						Message: `[{"key":"RetryCount","value":"1","type":3},{"key":"StartedAt","value":"2026-10-16T08:00:00.000Z","type":3}]`,
+						Reason:  "Completed",
+					},
+				},
+			}, {
+				Name: "step-push",
+				State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{
+						Message:  `[{"key":"RetryCount","value":"3","type":3},{"key":"StartedAt","value":"2026-10-16T08:01:00.000Z","type":3}]`,
+						ExitCode: 7,
+						Reason:   "Error",
+					},
+				},
+			}, {
+				Name: "step-report",
+				State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{
+						Message:  `[{"key":"StartedAt","value":"2026-10-16T08:02:00.000Z","type":3}]`,
+						ExitCode: 1,
+						Reason:   "Error",
+					},
+				},
+			}},
+		},
+	}
+	tr := v1.TaskRun{ObjectMeta: metav1.ObjectMeta{Name: "task-run"}}
+	logger, _ := logging.NewLogger("", "status")
+
+	trs, err := MakeTaskRunStatus(t.Context(), logger, tr, &pod, fakek8s.NewSimpleClientset(), &v1.TaskSpec{})
+	if err != nil {
+		t.Fatalf("MakeTaskRunStatus: %s", err)
+	}
+	want := map[string]int{"fetch": 1, "push": 3, "report": 0}
+	got := map[string]int{}
+	for _, step := range trs.Steps {
+		got[step.Name] = step.RetryCount
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("Unexpected retry counts %s", diff.PrintWantGot(d))
+	}
+	if trs.Steps[1].Terminated.ExitCode != 7 {
+		t.Errorf("Expected the exit code of the last attempt of step push, got %d", trs.Steps[1].Terminated.ExitCode)
+	}
+}
+
+func TestMakeTaskRunStatus_StepContainerRestarted(t *testing.T) {
+	lastTermination := corev1.ContainerState{
+		Terminated: &corev1.ContainerStateTerminated{
+			ExitCode: 1,
+			Reason:   "Error",
+		},
+	}
+	for _, tc := range []struct {
+		name       string
+		phase      corev1.PodPhase
+		state      corev1.ContainerState
+		wantReason string
+		wantState  corev1.ContainerState
+	}{{
+		name:  "running pod",
+		phase: corev1.PodRunning,
+		state: corev1.ContainerState{
+			Running: &corev1.ContainerStateRunning{},
+		},
+		wantReason: ReasonStepContainerRestarted,
+		wantState: corev1.ContainerState{
+			Running: &corev1.ContainerStateRunning{},
+		},
+	}, {
+		name:  "failed pod",
+		phase: corev1.PodFailed,
+		state: corev1.ContainerState{
+			Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"},
+		},
+		wantReason: v1.TaskRunReasonFailed.String(),
+		wantState:  lastTermination,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			pod := corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "pod"},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "step-build"}},
 				},
 				Status: corev1.PodStatus{
-					Phase: corev1.PodFailed,
-					ContainerStatuses: []corev1.ContainerStatus{
-						{
-							Name:    "step-1",
-							ImageID: "image-id-1",
-							State: corev1.ContainerState{
-								Terminated: &corev1.ContainerStateTerminated{
-									Message:  `[{"key":"StartedAt","value":"2023-11-26T19:53:29.452Z","type":3}]`,
-									ExitCode: 0,
-									Reason:   "Completed",
-								},
-							},
-						},
-					},
+					Phase: tc.phase,
+					ContainerStatuses: []corev1.ContainerStatus{{
+						Name:                 "step-build",
+						RestartCount:         1,
+						State:                tc.state,
+						LastTerminationState: lastTermination,
+					}},
 				},
-			},
+			}
+			tr := v1.TaskRun{ObjectMeta: metav1.ObjectMeta{Name: "task-run"}}
+			logger, _ := logging.NewLogger("", "status")
+
+			trs, err := MakeTaskRunStatus(t.Context(), logger, tr, &pod, fakek8s.NewSimpleClientset(), &v1.TaskSpec{})
+			if err != nil {
+				t.Fatalf("MakeTaskRunStatus: %s", err)
+			}
+			if reason := trs.GetCondition(apis.ConditionSucceeded).Reason; reason != tc.wantReason {
+				t.Errorf("Expected the reason %q, got %q", tc.wantReason, reason)
+			}
+			if len(trs.Steps) != 1 {
+				t.Fatalf("Expected one step state, got %v", trs.Steps)
+			}
+			if d := cmp.Diff(tc.wantState, trs.Steps[0].ContainerState); d != "" {
+				t.Errorf("Unexpected step state %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestMakeTaskRunStatus_ReadinessGatesPending(t *testing.T) {
+	gates := []corev1.PodReadinessGate{{ConditionType: "mesh.example.com/ready"}, {ConditionType: "networking.example.com/ready"}}
+	for _, tc := range []struct {
+		name        string
+		annotations map[string]string
+		conditions  []corev1.PodCondition
+		wantReason  string
+		wantMessage string
+	}{{
+		name:        "readiness gates not met",
+		conditions:  []corev1.PodCondition{{Type: "mesh.example.com/ready", Status: corev1.ConditionTrue}},
+		wantReason:  ReasonReadinessGatesPending,
+		wantMessage: "Waiting for the readiness gates of the pod to be met before the Steps start: networking.example.com/ready",
+	}, {
+		name: "readiness gates met",
+		conditions: []corev1.PodCondition{
+			{Type: "mesh.example.com/ready", Status: corev1.ConditionTrue},
+			{Type: "networking.example.com/ready", Status: corev1.ConditionTrue},
 		},
-		{
-			desc: "Step error",
-			expectedTerminationReason: map[string]string{
-				"step-1": "Error",
-			},
-			pod: corev1.Pod{
-				ObjectMeta: metav1.ObjectMeta{
-					Name: "pod",
-				},
+		wantReason:  v1.TaskRunReasonRunning.String(),
+		wantMessage: "Not all Steps in the Task have finished executing",
+	}, {
+		name:        "steps already started",
+		annotations: map[string]string{readyAnnotation: readyAnnotationValue},
+		conditions:  []corev1.PodCondition{{Type: "mesh.example.com/ready", Status: corev1.ConditionFalse}},
+		wantReason:  v1.TaskRunReasonRunning.String(),
+		wantMessage: "Not all Steps in the Task have finished executing",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			pod := corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "pod", Annotations: tc.annotations},
 				Spec: corev1.PodSpec{
-					Containers: []corev1.Container{
-						{Name: "step-1"},
-					},
+					Containers:     []corev1.Container{{Name: "step-build"}},
+					ReadinessGates: gates,
 				},
 				Status: corev1.PodStatus{
-					Phase: corev1.PodFailed,
-					ContainerStatuses: []corev1.ContainerStatus{
-						{
-							Name:    "step-1",
-							ImageID: "image-id-1",
-							State: corev1.ContainerState{
-								Terminated: &corev1.ContainerStateTerminated{
-									Message:  `[{"key":"StartedAt","value":"2023-11-26T19:53:29.452Z","type":3}]`,
-									ExitCode: 1,
-									Reason:   "Error",
-								},
-							},
-						},
+					Phase:      corev1.PodRunning,
+					Conditions: tc.conditions,
+					ContainerStatuses: []corev1.ContainerStatus{{
+						Name:  "step-build",
+						State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+					}},
+				},
+			}
+			tr := v1.TaskRun{ObjectMeta: metav1.ObjectMeta{Name: "task-run"}}
+			logger, _ := logging.NewLogger("", "status")
+
+			trs, err := MakeTaskRunStatus(t.Context(), logger, tr, &pod, fakek8s.NewSimpleClientset(), &v1.TaskSpec{})
+			if err != nil {
+				t.Fatalf("MakeTaskRunStatus: %s", err)
+			}
+			cond := trs.GetCondition(apis.ConditionSucceeded)
+			if cond.Reason != tc.wantReason || cond.Message != tc.wantMessage {
+				t.Errorf("Expected the reason %q and message %q, got %q and %q", tc.wantReason, tc.wantMessage, cond.Reason, cond.Message)
+			}
+			if got, want := ReadinessGatesReady(&pod), tc.wantReason != ReasonReadinessGatesPending && tc.annotations == nil; got != want {
+				t.Errorf("ReadinessGatesReady() = %t, want %t", got, want)
+			}
+		})
+	}
+}
+
+func TestMakeTaskRunStatus_ClockSkew(t *testing.T) {
+	// The TaskRun was started by a controller whose clock is an hour ahead of the one of the node
+	// the step ran on and of the controller completing it.
+	finishedAt := time.Now()
+	startTime := metav1.NewTime(finishedAt.Add(time.Hour))
+	pod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "pod"},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "step-build"}},
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodSucceeded,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name: "step-build",
+				State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{
+						StartedAt:  metav1.NewTime(finishedAt.Add(-time.Minute)),
+						FinishedAt: metav1.NewTime(finishedAt),
+						Reason:     "Completed",
 					},
 				},
-			},
+			}},
 		},
 	}
+	tr := v1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "task-run"},
+		Status: v1.TaskRunStatus{
+			TaskRunStatusFields: v1.TaskRunStatusFields{StartTime: &startTime},
+		},
+	}
+	logger, _ := logging.NewLogger("", "status")
 
-	for _, test := range tests {
-		t.Run(test.desc, func(t *testing.T) {
-			startTime := time.Date(2010, 1, 1, 1, 1, 1, 1, time.UTC)
+	trs, err := MakeTaskRunStatus(t.Context(), logger, tr, &pod, fakek8s.NewSimpleClientset(), &v1.TaskSpec{})
+	if err != nil {
+		t.Fatalf("MakeTaskRunStatus: %s", err)
+	}
+	if trs.CompletionTime == nil || !trs.CompletionTime.Equal(&startTime) {
+		t.Errorf("Expected the completion time to be set to the start time %v, got %v", startTime, trs.CompletionTime)
+	}
+}
+
+func TestMakeTaskRunStatus_WaitingStepStates(t *testing.T) {
+	ts := &v1.TaskSpec{Steps: []v1.Step{{Name: "build"}, {Name: "push"}}}
+	podSpec := corev1.PodSpec{
+		Containers: []corev1.Container{{Name: "step-build"}, {Name: "step-push"}},
+	}
+	waitingSteps := func(reason, message string) []v1.StepState {
+		return []v1.StepState{{
+			ContainerState: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: reason, Message: message}},
+			Name:           "build",
+			Container:      "step-build",
+		}, {
+			ContainerState: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: reason, Message: message}},
+			Name:           "push",
+			Container:      "step-push",
+		}}
+	}
+	for _, tc := range []struct {
+		name      string
+		podStatus corev1.PodStatus
+		steps     []v1.StepState
+		want      []v1.StepState
+	}{{
+		name: "pod failed scheduling",
+		podStatus: corev1.PodStatus{
+			Phase: corev1.PodPending,
+			Conditions: []corev1.PodCondition{{
+				Type:    corev1.PodScheduled,
+				Status:  corev1.ConditionFalse,
+				Reason:  corev1.PodReasonUnschedulable,
+				Message: "0/3 nodes are available",
+			}},
+		},
+		want: waitingSteps("PodFailedScheduling", "0/3 nodes are available"),
+	}, {
+		name: "pod rejected by its node",
+		podStatus: corev1.PodStatus{
+			Phase:   corev1.PodFailed,
+			Reason:  "OutOfcpu",
+			Message: "Pod was rejected: Node didn't have enough resource: cpu",
+		},
+		want: waitingSteps("OutOfcpu", "Pod was rejected: Node didn't have enough resource: cpu"),
+	}, {
+		name: "pod failed during init",
+		podStatus: corev1.PodStatus{
+			Phase: corev1.PodFailed,
+			Conditions: []corev1.PodCondition{{
+				Type:   corev1.PodScheduled,
+				Status: corev1.ConditionTrue,
+			}, {
+				Type:    corev1.PodInitialized,
+				Status:  corev1.ConditionFalse,
+				Reason:  "ContainersNotInitialized",
+				Message: "containers with incomplete status: [prepare]",
+			}},
+		},
+		want: waitingSteps("ContainersNotInitialized", "containers with incomplete status: [prepare]"),
+	}, {
+		name:      "pod without conditions",
+		podStatus: corev1.PodStatus{Phase: corev1.PodPending},
+		want:      waitingSteps("Pending", ""),
+	}, {
+		name: "waiting step states are replaced",
+		podStatus: corev1.PodStatus{
+			Phase:   corev1.PodFailed,
+			Reason:  "Evicted",
+			Message: "The node was low on resource: memory",
+		},
+		steps: waitingSteps("PodFailedScheduling", "0/3 nodes are available"),
+		want:  waitingSteps("Evicted", "The node was low on resource: memory"),
+	}, {
+		name:      "terminated step states are kept",
+		podStatus: corev1.PodStatus{Phase: corev1.PodFailed},
+		steps: []v1.StepState{{
+			ContainerState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 1}},
+			Name:           "build",
+			Container:      "step-build",
+		}},
+		want: []v1.StepState{{
+			ContainerState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 1}},
+			Name:           "build",
+			Container:      "step-build",
+		}},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			pod := corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "pod"},
+				Spec:       podSpec,
+				Status:     tc.podStatus,
+			}
 			tr := v1.TaskRun{
-				ObjectMeta: metav1.ObjectMeta{
-					Name: "task-run",
-				},
+				ObjectMeta: metav1.ObjectMeta{Name: "task-run"},
 				Status: v1.TaskRunStatus{
-					TaskRunStatusFields: v1.TaskRunStatusFields{
-						StartTime: &metav1.Time{Time: startTime},
-					},
+					TaskRunStatusFields: v1.TaskRunStatusFields{Steps: tc.steps},
 				},
 			}
 			logger, _ := logging.NewLogger("", "status")
-			kubeclient := fakek8s.NewSimpleClientset()
-
-			trs, err := MakeTaskRunStatus(t.Context(), logger, tr, &test.pod, kubeclient, &v1.TaskSpec{})
+			trs, err := MakeTaskRunStatus(t.Context(), logger, tr, &pod, fakek8s.NewSimpleClientset(), ts)
 			if err != nil {
-				t.Errorf("MakeTaskRunResult: %s", err)
+				t.Fatalf("MakeTaskRunStatus: %s", err)
+			}
+			if d := cmp.Diff(tc.want, trs.Steps); d != "" {
+				t.Errorf("Unexpected step states %s", diff.PrintWantGot(d))
 			}
+		})
+	}
 
-			for _, step := range trs.Steps {
-				if step.TerminationReason == "" {
-					t.Errorf("Terminated status not found for %s", step.Name)
-				}
-				want := test.expectedTerminationReason[step.Container]
-				got := step.TerminationReason
-				if d := cmp.Diff(want, got, ignoreVolatileTime); d != "" {
-					t.Errorf("Diff %s", diff.PrintWantGot(d))
-				}
+	// The waiting step states are replaced by the states of the containers once they are created.
+	pod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "pod"},
+		Spec:       podSpec,
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name:  "step-build",
+				State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+			}, {
+				Name:  "step-push",
+				State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "PodInitializing"}},
+			}},
+		},
+	}
+	tr := v1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "task-run"},
+		Status: v1.TaskRunStatus{
+			TaskRunStatusFields: v1.TaskRunStatusFields{Steps: waitingSteps("PodFailedScheduling", "0/3 nodes are available")},
+		},
+	}
+	logger, _ := logging.NewLogger("", "status")
+	trs, err := MakeTaskRunStatus(t.Context(), logger, tr, &pod, fakek8s.NewSimpleClientset(), ts)
+	if err != nil {
+		t.Fatalf("MakeTaskRunStatus: %s", err)
+	}
+	want := []v1.StepState{{
+		ContainerState: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+		Name:           "build",
+		Container:      "step-build",
+		Results:        []v1.TaskRunStepResult{},
+	}, {
+		ContainerState: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "PodInitializing"}},
+		Name:           "push",
+		Container:      "step-push",
+		Results:        []v1.TaskRunStepResult{},
+	}}
+	if d := cmp.Diff(want, trs.Steps); d != "" {
+		t.Errorf("Unexpected step states once the containers are created %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestClockSkew(t *testing.T) {
+	startTime := metav1.NewTime(time.Date(2026, time.October, 16, 8, 0, 0, 0, time.UTC))
+	for _, tc := range []struct {
+		name           string
+		startTime      *metav1.Time
+		completionTime time.Time
+		want           time.Duration
+	}{{
+		name:           "completion time precedes the start time",
+		startTime:      &startTime,
+		completionTime: startTime.Add(-90 * time.Second),
+		want:           90 * time.Second,
+	}, {
+		name:           "completion time follows the start time",
+		startTime:      &startTime,
+		completionTime: startTime.Add(time.Minute),
+	}, {
+		name:           "completion time is the start time",
+		startTime:      &startTime,
+		completionTime: startTime.Time,
+	}, {
+		name:           "no start time",
+		completionTime: startTime.Time,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			trs := &v1.TaskRunStatus{TaskRunStatusFields: v1.TaskRunStatusFields{StartTime: tc.startTime}}
+			if got := ClockSkew(trs, tc.completionTime); got != tc.want {
+				t.Errorf("ClockSkew() = %s, want %s", got, tc.want)
 			}
 		})
 	}
@@ -3905,7 +5895,7 @@ func TestUpdateIncompleteTaskRunStatus_SubPathError(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			updateIncompleteTaskRunStatus(tt.trs, tt.pod)
+			updateIncompleteTaskRunStatus(tt.trs, tt.pod, nil)
 			if d := cmp.Diff(tt.expected, tt.trs.GetCondition(apis.ConditionSucceeded), cmpopts.IgnoreFields(apis.Condition{}, "LastTransitionTime.Inner.Time")); d != "" {
 				t.Errorf("Unexpected status: %s", diff.PrintWantGot(d))
 			}
@@ -3913,6 +5903,85 @@ func TestUpdateIncompleteTaskRunStatus_SubPathError(t *testing.T) {
 	}
 }
 
+func TestUpdateIncompleteTaskRunStatus_BlockedByAffinityAssistant(t *testing.T) {
+	unschedulablePod := func(annotations map[string]string, message string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "foo", Annotations: annotations},
+			Status: corev1.PodStatus{
+				Phase: corev1.PodPending,
+				Conditions: []corev1.PodCondition{{
+					Type:    corev1.PodScheduled,
+					Status:  corev1.ConditionFalse,
+					Reason:  corev1.PodReasonUnschedulable,
+					Message: message,
+				}},
+			},
+		}
+	}
+	aaAnnotations := map[string]string{workspace.AnnotationAffinityAssistantName: "affinity-assistant-abc"}
+	affinityMessage := "0/3 nodes are available: 1 node(s) were unschedulable, 2 node(s) didn't match pod affinity rules. preemption: 0/3 nodes are available: 3 Preemption is not helpful for scheduling."
+	aaPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "affinity-assistant-abc-0", Namespace: "foo"},
+		Spec:       corev1.PodSpec{NodeName: "node-1"},
+	}
+	for _, tc := range []struct {
+		name       string
+		pod        *corev1.Pod
+		aaPods     []*corev1.Pod
+		wantReason string
+		wantMsg    string
+	}{{
+		name:       "blocked on the node of the affinity assistant pod",
+		pod:        unschedulablePod(aaAnnotations, affinityMessage),
+		aaPods:     []*corev1.Pod{aaPod},
+		wantReason: ReasonBlockedByAffinityAssistant,
+		wantMsg:    "TaskRun Pod can't be scheduled on node node-1 of the Affinity Assistant StatefulSet affinity-assistant-abc",
+	}, {
+		name:       "affinity assistant pod not found",
+		pod:        unschedulablePod(aaAnnotations, affinityMessage),
+		wantReason: ReasonBlockedByAffinityAssistant,
+		wantMsg:    "TaskRun Pod can't be scheduled on the node of the Affinity Assistant StatefulSet affinity-assistant-abc",
+	}, {
+		name:       "unschedulable whatever the message",
+		pod:        unschedulablePod(aaAnnotations, "0/1 nodes are available: 1 node(s) had untolerated taint {node.kubernetes.io/unschedulable: }."),
+		aaPods:     []*corev1.Pod{aaPod},
+		wantReason: ReasonBlockedByAffinityAssistant,
+		wantMsg:    "TaskRun Pod can't be scheduled on node node-1 of the Affinity Assistant StatefulSet affinity-assistant-abc",
+	}, {
+		name:       "insufficient resources",
+		pod:        unschedulablePod(aaAnnotations, "0/3 nodes are available: 1 Insufficient cpu, 2 node(s) didn't match pod affinity rules."),
+		aaPods:     []*corev1.Pod{aaPod},
+		wantReason: ReasonExceededNodeResources,
+		wantMsg:    "TaskRun Pod exceeded available resources",
+	}, {
+		name:       "pod without affinity assistant",
+		pod:        unschedulablePod(nil, affinityMessage),
+		aaPods:     []*corev1.Pod{aaPod},
+		wantReason: ReasonPodPending,
+		wantMsg:    `pod status "PodScheduled":"False"; message: "` + affinityMessage + `"`,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+			for _, p := range tc.aaPods {
+				if err := indexer.Add(p); err != nil {
+					t.Fatal(err)
+				}
+			}
+			trs := &v1.TaskRunStatus{}
+			updateIncompleteTaskRunStatus(trs, tc.pod, corev1listers.NewPodLister(indexer))
+			want := &apis.Condition{
+				Type:    apis.ConditionSucceeded,
+				Status:  corev1.ConditionUnknown,
+				Reason:  tc.wantReason,
+				Message: tc.wantMsg,
+			}
+			if d := cmp.Diff(want, trs.GetCondition(apis.ConditionSucceeded), cmpopts.IgnoreFields(apis.Condition{}, "LastTransitionTime.Inner.Time")); d != "" {
+				t.Errorf("Unexpected status: %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 func Test_getFailureInfo(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -4308,7 +6377,7 @@ func TestMakeTaskRunStatus_SidecarFailed(t *testing.T) {
 		}
 		return y != nil
 	})
-	if d := cmp.Diff(want, got, ignoreVolatileTime, ensureTimeNotNil); d != "" {
+	if d := cmp.Diff(want, got, ignoreVolatileTime, ignoreReasonHistory, ensureTimeNotNil); d != "" {
 		t.Errorf("Diff %s", diff.PrintWantGot(d))
 	}
 }
@@ -4520,3 +6589,137 @@ func Test_getFailureMessage_consistent_with_reason(t *testing.T) {
 		})
 	}
 }
+
+func TestFailedSidecar(t *testing.T) {
+	sidecarTerminated := corev1.ContainerStatus{
+		Name: "sidecar-proxy",
+		State: corev1.ContainerState{
+			Terminated: &corev1.ContainerStateTerminated{
+				ExitCode: 2,
+				Reason:   "Error",
+				Message:  "upstream unreachable",
+			},
+		},
+	}
+	stepRunning := corev1.ContainerStatus{
+		Name:  "step-build",
+		State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+	}
+	stepTerminated := corev1.ContainerStatus{
+		Name:  "step-build",
+		State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 0}},
+	}
+	sidecarSucceeded := corev1.ContainerStatus{
+		Name:  "sidecar-proxy",
+		State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 0}},
+	}
+	// A sidecar restarted by Kubernetes after it failed
+	sidecarRestarted := corev1.ContainerStatus{
+		Name:                 "sidecar-proxy",
+		State:                corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+		LastTerminationState: sidecarTerminated.State,
+		RestartCount:         1,
+	}
+	sidecarRestartedSuccessfully := corev1.ContainerStatus{
+		Name:                 "sidecar-proxy",
+		State:                corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+		LastTerminationState: sidecarSucceeded.State,
+		RestartCount:         1,
+	}
+	for _, tc := range []struct {
+		name          string
+		onError       v1.OnErrorType
+		stepStatus    corev1.ContainerStatus
+		sidecarStatus corev1.ContainerStatus
+		wantFailed    bool
+	}{{
+		name:          "stopAndFail sidecar failed while the steps are running",
+		onError:       v1.StopAndFail,
+		stepStatus:    stepRunning,
+		sidecarStatus: sidecarTerminated,
+		wantFailed:    true,
+	}, {
+		name:          "ignore sidecar failed while the steps are running",
+		onError:       v1.Ignore,
+		stepStatus:    stepRunning,
+		sidecarStatus: sidecarTerminated,
+	}, {
+		name:          "sidecar without onError failed while the steps are running",
+		stepStatus:    stepRunning,
+		sidecarStatus: sidecarTerminated,
+	}, {
+		name:          "stopAndFail sidecar failed once the steps terminated",
+		onError:       v1.StopAndFail,
+		stepStatus:    stepTerminated,
+		sidecarStatus: sidecarTerminated,
+	}, {
+		name:          "stopAndFail sidecar exited successfully",
+		onError:       v1.StopAndFail,
+		stepStatus:    stepRunning,
+		sidecarStatus: sidecarSucceeded,
+	}, {
+		name:          "stopAndFail sidecar restarted after it failed",
+		onError:       v1.StopAndFail,
+		stepStatus:    stepRunning,
+		sidecarStatus: sidecarRestarted,
+		wantFailed:    true,
+	}, {
+		name:          "ignore sidecar restarted after it failed",
+		onError:       v1.Ignore,
+		stepStatus:    stepRunning,
+		sidecarStatus: sidecarRestarted,
+	}, {
+		name:          "stopAndFail sidecar restarted after it exited successfully",
+		onError:       v1.StopAndFail,
+		stepStatus:    stepRunning,
+		sidecarStatus: sidecarRestartedSuccessfully,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "foo"},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "step-build"}, {Name: "sidecar-proxy"}},
+				},
+				Status: corev1.PodStatus{
+					Phase:             corev1.PodRunning,
+					ContainerStatuses: []corev1.ContainerStatus{tc.stepStatus, tc.sidecarStatus},
+				},
+			}
+			ts := &v1.TaskSpec{
+				Steps:    []v1.Step{{Name: "build", Image: "image"}},
+				Sidecars: []v1.Sidecar{{Name: "proxy", Image: "image", OnError: tc.onError}},
+			}
+			tr := v1.TaskRun{ObjectMeta: metav1.ObjectMeta{Name: "task-run", Namespace: "foo"}}
+			logger, _ := logging.NewLogger("", "status")
+			trs, err := MakeTaskRunStatus(t.Context(), logger, tr, pod, fakek8s.NewSimpleClientset(), ts)
+			if err != nil {
+				t.Fatalf("MakeTaskRunStatus: %v", err)
+			}
+
+			// The state of the sidecar is surfaced in the status of the TaskRun.
+			wantSidecars := []v1.SidecarState{{
+				ContainerState: *tc.sidecarStatus.State.DeepCopy(),
+				Name:           "proxy",
+				Container:      "sidecar-proxy",
+			}}
+			if d := cmp.Diff(wantSidecars, trs.Sidecars); d != "" {
+				t.Errorf("Sidecars %s", diff.PrintWantGot(d))
+			}
+
+			got, failed := FailedSidecar(&trs, pod, ts)
+			if failed != tc.wantFailed {
+				t.Fatalf("FailedSidecar() = %t, want %t", failed, tc.wantFailed)
+			}
+			if failed {
+				// The state of a restarted sidecar is its last termination.
+				want := wantSidecars[0]
+				if tc.sidecarStatus.LastTerminationState.Terminated != nil {
+					want.ContainerState = tc.sidecarStatus.LastTerminationState
+				}
+				if d := cmp.Diff(want, got); d != "" {
+					t.Errorf("FailedSidecar() %s", diff.PrintWantGot(d))
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconciler
+
+import (
+	"context"
+
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"knative.dev/pkg/logging"
+)
+
+// FeatureFlagUsageRecorder counts the runs which exercised the behavior gated by a feature flag.
+type FeatureFlagUsageRecorder interface {
+	FeatureFlagUsed(ctx context.Context, namespace, flag string) error
+}
+
+// TrackFeatureFlagUsage records that a run took a branch gated by the feature flag. The flag is
+// added to the used feature flags of the provenance of the run and, the first time it is added,
+// counted by the recorder, unless it is a nil pointer, e.g. for a reconciler without metrics.
+// Nothing is recorded for runs without provenance, i.e. when enable-provenance-in-status is false,
+// as reconciling the run again would count it again.
+func TrackFeatureFlagUsage[R interface {
+	*T
+	FeatureFlagUsageRecorder
+}, T any](ctx context.Context, provenance *v1.Provenance, recorder R, namespace, flag string) {
+	if provenance == nil || !provenance.AddUsedFeatureFlag(flag) || recorder == nil {
+		return
+	}
+	if err := recorder.FeatureFlagUsed(ctx, namespace, flag); err != nil {
+		logging.FromContext(ctx).Warnf("Failed to record the usage of feature flag %s: %v", flag, err)
+	}
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconciler_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	reconciler "github.com/tektoncd/pipeline/pkg/reconciler"
+	"github.com/tektoncd/pipeline/test/diff"
+)
+
+type fakeFeatureFlagUsageRecorder struct {
+	used []string
+}
+
+func (r *fakeFeatureFlagUsageRecorder) FeatureFlagUsed(_ context.Context, namespace, flag string) error {
+	r.used = append(r.used, namespace+"/"+flag)
+	return nil
+}
+
+func TestTrackFeatureFlagUsage(t *testing.T) {
+	provenance := &v1.Provenance{}
+	recorder := &fakeFeatureFlagUsageRecorder{}
+	for _, flag := range []string{"results-from", "enable-cel-in-whenexpression", "results-from"} {
+		reconciler.TrackFeatureFlagUsage(t.Context(), provenance, recorder, "ns", flag)
+	}
+
+	if d := cmp.Diff([]string{"enable-cel-in-whenexpression", "results-from"}, provenance.UsedFeatureFlags); d != "" {
+		t.Errorf("UsedFeatureFlags %s", diff.PrintWantGot(d))
+	}
+	if d := cmp.Diff([]string{"ns/results-from", "ns/enable-cel-in-whenexpression"}, recorder.used); d != "" {
+		t.Errorf("recorded usages %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestTrackFeatureFlagUsage_NoProvenance(t *testing.T) {
+	recorder := &fakeFeatureFlagUsageRecorder{}
+	reconciler.TrackFeatureFlagUsage(t.Context(), nil, recorder, "ns", "results-from")
+	if len(recorder.used) != 0 {
+		t.Errorf("recorded usages %v without provenance, want none", recorder.used)
+	}
+}
+
+func TestTrackFeatureFlagUsage_NoRecorder(t *testing.T) {
+	provenance := &v1.Provenance{}
+	var recorder *fakeFeatureFlagUsageRecorder
+	reconciler.TrackFeatureFlagUsage(t.Context(), provenance, recorder, "ns", "results-from")
+	if d := cmp.Diff([]string{"results-from"}, provenance.UsedFeatureFlags); d != "" {
+		t.Errorf("UsedFeatureFlags %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestTrackFeatureFlagUsage_Bounded(t *testing.T) {
+	provenance := &v1.Provenance{}
+	recorder := &fakeFeatureFlagUsageRecorder{}
+	for i := range v1.MaxUsedFeatureFlags + 1 {
+		reconciler.TrackFeatureFlagUsage(t.Context(), provenance, recorder, "ns", fmt.Sprintf("flag-%02d", i))
+	}
+	if len(provenance.UsedFeatureFlags) != v1.MaxUsedFeatureFlags {
+		t.Errorf("got %d used feature flags, want %d", len(provenance.UsedFeatureFlags), v1.MaxUsedFeatureFlags)
+	}
+	if len(recorder.used) != v1.MaxUsedFeatureFlags {
+		t.Errorf("got %d recorded usages, want %d", len(recorder.used), v1.MaxUsedFeatureFlags)
+	}
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconciler
+
+import (
+	"encoding/json"
+	"slices"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// FinalStatusDeadline is the time after the deletion of a run holding the FinalStatusFinalizer
+	// after which the finalizer is removed even if the final status of the run couldn't be recorded,
+	// so that the deletion of its namespace can't hang.
+	FinalStatusDeadline = 5 * time.Minute
+
+	// FinalStatusRetryDelay is the delay after which recording the final status of a deleted run is
+	// retried when it failed.
+	FinalStatusRetryDelay = 10 * time.Second
+)
+
+// FinalStatusDeadlineExceeded returns whether the FinalStatusDeadline of the deleted obj passed at now.
+func FinalStatusDeadlineExceeded(obj metav1.Object, now time.Time) bool {
+	deleted := obj.GetDeletionTimestamp()
+	return deleted != nil && now.Sub(deleted.Time) >= FinalStatusDeadline
+}
+
+// FinalStatusFinalizerPatch returns the JSON patch adding the FinalStatusFinalizer to the finalizers
+// of an object, or removing it, and whether they need to be patched. The finalizers are tested first
+// so that those added in the meantime aren't dropped.
+func FinalStatusFinalizerPatch(finalizers []string, add bool) ([]byte, bool, error) {
+	if slices.Contains(finalizers, pipeline.FinalStatusFinalizer) == add {
+		return nil, false, nil
+	}
+	var want []string
+	if add {
+		want = append(slices.Clone(finalizers), pipeline.FinalStatusFinalizer)
+	} else {
+		want = slices.DeleteFunc(slices.Clone(finalizers), func(f string) bool {
+			return f == pipeline.FinalStatusFinalizer
+		})
+	}
+	ops := []map[string]any{{"op": "add", "path": "/metadata/finalizers", "value": want}}
+	if len(finalizers) > 0 {
+		ops = []map[string]any{
+			{"op": "test", "path": "/metadata/finalizers", "value": finalizers},
+			{"op": "replace", "path": "/metadata/finalizers", "value": want},
+		}
+	}
+	patch, err := json.Marshal(ops)
+	return patch, err == nil, err
+}
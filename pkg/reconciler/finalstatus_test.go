@@ -0,0 +1,90 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconciler_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	reconciler "github.com/tektoncd/pipeline/pkg/reconciler"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestFinalStatusFinalizerPatch(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		finalizers []string
+		add        bool
+		want       string
+	}{{
+		name: "add to no finalizers",
+		add:  true,
+		want: `[{"op":"add","path":"/metadata/finalizers","value":["tekton.dev/final-status"]}]`,
+	}, {
+		name:       "add to other finalizers",
+		finalizers: []string{"other"},
+		add:        true,
+		want:       `[{"op":"test","path":"/metadata/finalizers","value":["other"]},{"op":"replace","path":"/metadata/finalizers","value":["other","tekton.dev/final-status"]}]`,
+	}, {
+		name:       "already added",
+		finalizers: []string{pipeline.FinalStatusFinalizer},
+		add:        true,
+	}, {
+		name:       "remove",
+		finalizers: []string{pipeline.FinalStatusFinalizer, "other"},
+		want:       `[{"op":"test","path":"/metadata/finalizers","value":["tekton.dev/final-status","other"]},{"op":"replace","path":"/metadata/finalizers","value":["other"]}]`,
+	}, {
+		name:       "already removed",
+		finalizers: []string{"other"},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			patch, ok, err := reconciler.FinalStatusFinalizerPatch(tc.finalizers, tc.add)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if ok != (tc.want != "") || string(patch) != tc.want {
+				t.Errorf("Expected the patch %q, got %q (%t)", tc.want, patch, ok)
+			}
+		})
+	}
+}
+
+func TestFinalStatusDeadlineExceeded(t *testing.T) {
+	now := time.Now()
+	for _, tc := range []struct {
+		name    string
+		deleted *metav1.Time
+		want    bool
+	}{{
+		name: "not deleted",
+	}, {
+		name:    "deleted recently",
+		deleted: &metav1.Time{Time: now.Add(-time.Minute)},
+	}, {
+		name:    "deleted before the deadline",
+		deleted: &metav1.Time{Time: now.Add(-reconciler.FinalStatusDeadline)},
+		want:    true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			obj := &metav1.ObjectMeta{DeletionTimestamp: tc.deleted}
+			if got := reconciler.FinalStatusDeadlineExceeded(obj, now); got != tc.want {
+				t.Errorf("Expected %t, got %t", tc.want, got)
+			}
+		})
+	}
+}
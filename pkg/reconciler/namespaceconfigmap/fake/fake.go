@@ -0,0 +1,35 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"context"
+
+	"github.com/tektoncd/pipeline/pkg/reconciler/namespaceconfigmap"
+	fakekubeclient "knative.dev/pkg/client/injection/kube/client/fake"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/injection"
+)
+
+// Get extracts the fake informer of the ConfigMaps named name from the context.
+var Get = namespaceconfigmap.Get
+
+func init() {
+	injection.Fake.RegisterFilteredInformers(func(ctx context.Context) (context.Context, []controller.Informer) {
+		return namespaceconfigmap.WithInformers(ctx, fakekubeclient.Get(ctx))
+	})
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package namespaceconfigmap injects the informers of the ConfigMaps the namespaces configure their
+// TaskRuns and PipelineRuns with. Each informer only watches the ConfigMaps of one name, instead of all
+// the ConfigMaps of the cluster.
+package namespaceconfigmap
+
+import (
+	"context"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/informers"
+	corev1informers "k8s.io/client-go/informers/core/v1"
+	"k8s.io/client-go/kubernetes"
+	kubeclient "knative.dev/pkg/client/injection/kube/client"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/injection"
+	"knative.dev/pkg/logging"
+)
+
+// Names are the names of the ConfigMaps watched by the informers.
+var Names = []string{config.NamespaceFeatureFlagsConfigMapName, config.NamespacePodDefaultsConfigMapName}
+
+func init() {
+	injection.Default.RegisterFilteredInformers(func(ctx context.Context) (context.Context, []controller.Informer) {
+		return WithInformers(ctx, kubeclient.Get(ctx))
+	})
+}
+
+// Key is used for associating the informer of the ConfigMaps of a name inside the context.Context.
+type Key struct {
+	Name string
+}
+
+// WithInformers returns the given context with an informer of the ConfigMaps of each of the Names,
+// listed and watched with client.
+func WithInformers(ctx context.Context, client kubernetes.Interface) (context.Context, []controller.Informer) {
+	infs := []controller.Informer{}
+	for _, name := range Names {
+		opts := []informers.SharedInformerOption{
+			informers.WithTweakListOptions(func(l *metav1.ListOptions) {
+				l.FieldSelector = fields.OneTermEqualSelector("metadata.name", name).String()
+			}),
+		}
+		if injection.HasNamespaceScope(ctx) {
+			opts = append(opts, informers.WithNamespace(injection.GetNamespaceScope(ctx)))
+		}
+		inf := informers.NewSharedInformerFactoryWithOptions(client, controller.GetResyncPeriod(ctx), opts...).Core().V1().ConfigMaps()
+		ctx = context.WithValue(ctx, Key{Name: name}, inf)
+		infs = append(infs, inf.Informer())
+	}
+	return ctx, infs
+}
+
+// Get extracts the informer of the ConfigMaps named name from the context.
+func Get(ctx context.Context, name string) corev1informers.ConfigMapInformer {
+	untyped := ctx.Value(Key{Name: name})
+	if untyped == nil {
+		logging.FromContext(ctx).Panicf("Unable to fetch the informer of the ConfigMaps named %s from context.", name)
+	}
+	return untyped.(corev1informers.ConfigMapInformer)
+}
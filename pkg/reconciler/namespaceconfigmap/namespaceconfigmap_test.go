@@ -0,0 +1,67 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package namespaceconfigmap_test
+
+import (
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/pod"
+	"github.com/tektoncd/pipeline/pkg/reconciler/namespaceconfigmap"
+	"github.com/tektoncd/pipeline/test/diff"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakekubeclientset "k8s.io/client-go/kubernetes/fake"
+	ktesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+	logtesting "knative.dev/pkg/logging/testing"
+)
+
+func TestWithInformers(t *testing.T) {
+	ctx := logtesting.TestContextWithLogger(t)
+	client := fakekubeclientset.NewSimpleClientset()
+	var mu sync.Mutex
+	var got []string
+	client.PrependReactor("list", "configmaps", func(action ktesting.Action) (bool, runtime.Object, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		got = append(got, action.(ktesting.ListAction).GetListRestrictions().Fields.String())
+		return false, nil, nil
+	})
+
+	ctx, infs := namespaceconfigmap.WithInformers(ctx, client)
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	for _, inf := range infs {
+		go inf.Run(stopCh)
+	}
+	for _, name := range namespaceconfigmap.Names {
+		if !cache.WaitForCacheSync(stopCh, namespaceconfigmap.Get(ctx, name).Informer().HasSynced) {
+			t.Fatalf("Failed to sync the informer of the ConfigMaps named %s", name)
+		}
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	sort.Strings(got)
+	want := []string{"metadata.name=" + config.NamespaceFeatureFlagsConfigMapName, "metadata.name=" + pod.NamespaceDefaultsConfigMapName}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("Unexpected field selectors %s", diff.PrintWantGot(d))
+	}
+}
@@ -96,6 +96,90 @@ func Build(tasks Tasks, deps map[string][]string) (*Graph, error) {
 	return d, nil
 }
 
+// LongestChain returns the number of tasks of the longest chain of dependent tasks and the first
+// task of that chain, the one with the smallest name if several chains are as long. It is computed
+// from the dependencies of the tasks alone, so that pipelines too deep can be rejected before their
+// graph is built by Build. The tasks in a dependency cycle, or depending on a missing task, which
+// Build rejects, are ignored. It runs in linear time of the number of tasks and dependencies.
+func LongestChain(tasks Tasks, deps map[string][]string) (head string, depth int) {
+	items := tasks.Items()
+	// Visit the tasks in topological order, so that the length of the longest chain ending with a
+	// task, and the first task of that chain, are computed after the ones of the tasks it depends on.
+	remaining := make(map[string]int, len(items))
+	dependents := make(map[string][]string, len(deps))
+	order := make([]string, 0, len(items))
+	for _, t := range items {
+		key := t.HashKey()
+		remaining[key] = len(deps[key])
+		if len(deps[key]) == 0 {
+			order = append(order, key)
+		}
+		for _, dep := range deps[key] {
+			dependents[dep] = append(dependents[dep], key)
+		}
+	}
+	lengths := make(map[string]int, len(items))
+	heads := make(map[string]string, len(items))
+	for i := 0; i < len(order); i++ {
+		key := order[i]
+		lengths[key], heads[key] = 1, key
+		for _, dep := range deps[key] {
+			if l := lengths[dep] + 1; l > lengths[key] || l == lengths[key] && heads[dep] < heads[key] {
+				lengths[key], heads[key] = l, heads[dep]
+			}
+		}
+		if lengths[key] > depth || lengths[key] == depth && heads[key] < head {
+			head, depth = heads[key], lengths[key]
+		}
+		for _, next := range dependents[key] {
+			remaining[next]--
+			if remaining[next] == 0 {
+				order = append(order, next)
+			}
+		}
+	}
+	return head, depth
+}
+
+// Layers returns the layer of each task of the graph, from 1: the tasks without dependencies are in
+// the first layer, and the other tasks in the layer following the last layer of the tasks they
+// depend on. The number of layers is the number of tasks of the longest chain of the graph. The
+// graph must be acyclic, which the graphs returned by Build are.
+func (g *Graph) Layers() map[string]int {
+	// Visit the nodes in topological order, so that the layer of a node is computed after the
+	// layers of all the nodes it depends on.
+	layers := make(map[string]int, len(g.Nodes))
+	for _, n := range g.topologicalOrder() {
+		l := 0
+		for _, prev := range n.Prev {
+			l = max(l, layers[prev.Key])
+		}
+		layers[n.Key] = l + 1
+	}
+	return layers
+}
+
+// topologicalOrder returns the nodes of the acyclic graph, each after all the nodes it depends on.
+func (g *Graph) topologicalOrder() []*Node {
+	remaining := make(map[string]int, len(g.Nodes))
+	order := make([]*Node, 0, len(g.Nodes))
+	for _, n := range g.Nodes {
+		remaining[n.Key] = len(n.Prev)
+		if len(n.Prev) == 0 {
+			order = append(order, n)
+		}
+	}
+	for i := 0; i < len(order); i++ {
+		for _, next := range order[i].Next {
+			remaining[next.Key]--
+			if remaining[next.Key] == 0 {
+				order = append(order, next)
+			}
+		}
+	}
+	return order
+}
+
 // GetCandidateTasks returns a set of names of PipelineTasks whose ancestors are all completed,
 // given a list of finished doneTasks. If the specified
 // doneTasks are invalid (i.e. if it is indicated that a Task is done, but the
@@ -702,6 +702,101 @@ func TestBuildGraphWithHundredsOfTasks_InvalidDAG(t *testing.T) {
 	}
 }
 
+func TestLongestChain(t *testing.T) {
+	tcs := []struct {
+		name      string
+		tasks     []v1.PipelineTask
+		wantHead  string
+		wantDepth int
+	}{{
+		name: "empty",
+	}, {
+		name: "joined-branches",
+		tasks: []v1.PipelineTask{
+			{Name: "a"}, {Name: "b"}, {Name: "w", RunAfter: []string{"b", "y"}},
+			{Name: "x", RunAfter: []string{"a"}}, {Name: "y", RunAfter: []string{"a", "x"}}, {Name: "z", RunAfter: []string{"x"}},
+		},
+		wantHead:  "a",
+		wantDepth: 4,
+	}, {
+		name:      "chain",
+		tasks:     chainTasks(100),
+		wantHead:  "t000000",
+		wantDepth: 100,
+	}, {
+		name:      "chains-as-long",
+		tasks:     []v1.PipelineTask{{Name: "b"}, {Name: "c", RunAfter: []string{"b"}}, {Name: "a"}, {Name: "d", RunAfter: []string{"a"}}},
+		wantHead:  "a",
+		wantDepth: 2,
+	}, {
+		name:      "cycle-ignored",
+		tasks:     []v1.PipelineTask{{Name: "a"}, {Name: "b", RunAfter: []string{"a", "c"}}, {Name: "c", RunAfter: []string{"b"}}},
+		wantHead:  "a",
+		wantDepth: 1,
+	}}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			head, depth := dag.LongestChain(v1.PipelineTaskList(tc.tasks), v1.PipelineTaskList(tc.tasks).Deps())
+			if head != tc.wantHead || depth != tc.wantDepth {
+				t.Errorf("LongestChain() = (%q, %d), want (%q, %d)", head, depth, tc.wantHead, tc.wantDepth)
+			}
+		})
+	}
+}
+
+// BenchmarkLongestChain shows that the cost of LongestChain grows linearly with the length of the chain.
+func BenchmarkLongestChain(b *testing.B) {
+	for _, n := range []int{1000, 10000, 100000} {
+		tasks := v1.PipelineTaskList(chainTasks(n))
+		deps := tasks.Deps()
+		b.Run(fmt.Sprintf("tasks-%d", n), func(b *testing.B) {
+			for range b.N {
+				if _, depth := dag.LongestChain(tasks, deps); depth != n {
+					b.Fatalf("LongestChain() depth = %d, want %d", depth, n)
+				}
+			}
+		})
+	}
+}
+
+func TestLayers(t *testing.T) {
+	tcs := []struct {
+		name string
+		g    *dag.Graph
+		want map[string]int
+	}{{
+		name: "empty",
+		g:    &dag.Graph{Nodes: map[string]*dag.Node{}},
+		want: map[string]int{},
+	}, {
+		name: "joined-branches",
+		g:    testGraph(t),
+		want: map[string]int{"a": 1, "b": 1, "x": 2, "y": 3, "z": 3, "w": 4},
+	}, {
+		name: "chain",
+		g:    chainGraph(t, 3),
+		want: map[string]int{"t000000": 1, "t000001": 2, "t000002": 3},
+	}}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			if d := cmp.Diff(tc.want, tc.g.Layers()); d != "" {
+				t.Errorf("Layers() %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+// chainGraph builds a graph of n tasks, each one running after the previous one.
+func chainGraph(tb testing.TB, n int) *dag.Graph {
+	tb.Helper()
+	tasks := chainTasks(n)
+	g, err := dag.Build(v1.PipelineTaskList(tasks), v1.PipelineTaskList(tasks).Deps())
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return g
+}
+
 func testGraph(t *testing.T) *dag.Graph {
 	//  b     a
 	//  |    / \
@@ -849,3 +944,15 @@ func TestFindCyclesInDependencies(t *testing.T) {
 		})
 	}
 }
+
+// chainTasks returns n tasks, each one running after the previous one.
+func chainTasks(n int) []v1.PipelineTask {
+	tasks := make([]v1.PipelineTask, n)
+	for i := range tasks {
+		tasks[i].Name = fmt.Sprintf("t%06d", i)
+		if i > 0 {
+			tasks[i].RunAfter = []string{tasks[i-1].Name}
+		}
+	}
+	return tasks
+}
@@ -22,6 +22,8 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
@@ -30,6 +32,7 @@ import (
 	"github.com/tektoncd/pipeline/pkg/internal/affinityassistant"
 	aa "github.com/tektoncd/pipeline/pkg/internal/affinityassistant"
 	pipelinePod "github.com/tektoncd/pipeline/pkg/pod"
+	tknreconciler "github.com/tektoncd/pipeline/pkg/reconciler"
 	"github.com/tektoncd/pipeline/pkg/reconciler/volumeclaim"
 	"github.com/tektoncd/pipeline/pkg/workspace"
 	appsv1 "k8s.io/api/apps/v1"
@@ -37,6 +40,7 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	errorutils "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"knative.dev/pkg/kmeta"
@@ -53,9 +57,6 @@ const (
 	// This annotation only affects volumeClaimTemplate workspaces; user-provided persistentVolumeClaim
 	// workspaces are never deleted.
 	AutoCleanupPVCAnnotation = "tekton.dev/auto-cleanup-pvc"
-
-	// volumeNameMaxLength is the maximum length for a Kubernetes volume name.
-	volumeNameMaxLength = 63
 )
 
 var (
@@ -73,41 +74,71 @@ var (
 // If the AffinityAssistantBehavior is AffinityAssistantPerPipelineRun or AffinityAssistantPerPipelineRunWithIsolation,
 // it creates one Affinity Assistant for the pipelinerun.
 func (c *Reconciler) createOrUpdateAffinityAssistantsAndPVCs(ctx context.Context, pr *v1.PipelineRun, aaBehavior aa.AffinityAssistantBehavior) error {
+	defer tknreconciler.StartReconcilePhase(ctx, tknreconciler.ReconcilePhaseResources)()
+	pvcWorkspaces := pvcBackedWorkspaces(pr.Spec.Workspaces)
+	// Without PVC-backed workspaces, there is no PVC to create nor any pods sharing a PVC to coschedule:
+	// only the Affinity Assistant coscheduling all the pods of the pipelinerun is still needed.
+	if len(pvcWorkspaces) == 0 && (aaBehavior == aa.AffinityAssistantPerWorkspace || aaBehavior == aa.AffinityAssistantDisabled) {
+		return nil
+	}
+
 	var unschedulableNodes sets.Set[string] = nil
 
 	var claimTemplates []corev1.PersistentVolumeClaim
 	var claimNames []string
+	var clonedClaimWorkspaces []v1.WorkspaceBinding
 	claimNameToWorkspaceName := map[string]string{}
 	claimTemplateToWorkspace := map[*corev1.PersistentVolumeClaim]v1.WorkspaceBinding{}
+	// the workspaces of claimTemplateToWorkspace, in order
+	var claimTemplateWorkspaces []v1.WorkspaceBinding
 
-	for _, w := range pr.Spec.Workspaces {
-		if w.PersistentVolumeClaim == nil && w.VolumeClaimTemplate == nil {
-			continue
-		}
+	for _, w := range pvcWorkspaces {
 		if w.PersistentVolumeClaim != nil {
 			claim := w.PersistentVolumeClaim
 			claimNames = append(claimNames, claim.ClaimName)
 			claimNameToWorkspaceName[claim.ClaimName] = w.Name
 		} else if w.VolumeClaimTemplate != nil {
 			claimTemplate := w.VolumeClaimTemplate.DeepCopy()
-			claimTemplate.Name = volumeclaim.GeneratePVCNameFromWorkspaceBinding(w.VolumeClaimTemplate.Name, w, *kmeta.NewControllerRef(pr))
-			claimTemplates = append(claimTemplates, *claimTemplate)
+			claimTemplate.Name = volumeclaim.PVCName(w, *kmeta.NewControllerRef(pr))
+			volumeclaim.LabelPVCWithOwner(claimTemplate, *kmeta.NewControllerRef(pr))
+			volumeclaim.AnnotatePVCWithWorkspace(claimTemplate, w, *kmeta.NewControllerRef(pr))
+			if clonesDataSource(w) {
+				clonedClaimWorkspaces = append(clonedClaimWorkspaces, w)
+			} else {
+				claimTemplates = append(claimTemplates, *claimTemplate)
+			}
 			claimTemplateToWorkspace[claimTemplate] = w
+			claimTemplateWorkspaces = append(claimTemplateWorkspaces, w)
 		}
 	}
+	// The PVCs of the matrix instances are created from the PipelineRun, and have their own Affinity
+	// Assistant in AffinityAssistantPerWorkspace behavior.
+	instanceWorkspaces := matrixInstanceWorkspaces(pr)
+	for _, w := range instanceWorkspaces {
+		claimTemplate := w.VolumeClaimTemplate.DeepCopy()
+		claimTemplate.Name = volumeclaim.PVCName(w, *kmeta.NewControllerRef(pr))
+		claimTemplateToWorkspace[claimTemplate] = w
+		claimTemplateWorkspaces = append(claimTemplateWorkspaces, w)
+	}
 	switch aaBehavior {
 	case aa.AffinityAssistantPerWorkspace:
 		for claimName, workspaceName := range claimNameToWorkspaceName {
+			if skipsAffinityAssistant(pr, aaBehavior, workspaceName) {
+				continue
+			}
 			aaName := GetAffinityAssistantName(workspaceName, pr.Name)
 			if err := c.createOrUpdateAffinityAssistant(ctx, aaName, pr, nil, []string{claimName}, unschedulableNodes); err != nil {
 				return fmt.Errorf("%w: %v", ErrAffinityAssistantCreationFailed, err)
 			}
 		}
+		// To support PVC auto deletion at pipelinerun deletion time, the OwnerReference of the PVCs should be set to the owning pipelinerun instead of the StatefulSets,
+		// so we create PVCs from PipelineRuns' VolumeClaimTemplate and pass the PVCs to the Affinity Assistant StatefulSet for volume scheduling.
+		if err := c.pvcHandler.CreatePVCsForWorkspaces(ctx, claimTemplateWorkspaces, *kmeta.NewControllerRef(pr), pr.Namespace); err != nil {
+			return err
+		}
 		for claimTemplate, workspace := range claimTemplateToWorkspace {
-			// To support PVC auto deletion at pipelinerun deletion time, the OwnerReference of the PVCs should be set to the owning pipelinerun instead of the StatefulSets,
-			// so we create PVCs from PipelineRuns' VolumeClaimTemplate and pass the PVCs to the Affinity Assistant StatefulSet for volume scheduling.
-			if err := c.pvcHandler.CreatePVCFromVolumeClaimTemplate(ctx, workspace, *kmeta.NewControllerRef(pr), pr.Namespace); err != nil {
-				return err
+			if skipsAffinityAssistant(pr, aaBehavior, workspace.Name) {
+				continue
 			}
 			aaName := GetAffinityAssistantName(workspace.Name, pr.Name)
 			if err := c.createOrUpdateAffinityAssistant(ctx, aaName, pr, nil, []string{claimTemplate.Name}, unschedulableNodes); err != nil {
@@ -120,20 +151,87 @@ func (c *Reconciler) createOrUpdateAffinityAssistantsAndPVCs(ctx context.Context
 		// in AffinityAssistantPerPipelineRun or AffinityAssistantPerPipelineRunWithIsolation modes.
 		// This is because PVCs from pipelinerun's VolumeClaimTemplate are enforced to be deleted at pipelinerun completion time in these modes,
 		// and there is no requirement of the PVC OwnerReference.
+		// The topology of a volume cloned from a data source isn't known until it is provisioned though, so the StatefulSet
+		// could be scheduled to another zone: those PVCs are created from the PipelineRun instead, and mounted into the
+		// Affinity Assistant, so that with a WaitForFirstConsumer StorageClass the volume is provisioned for its node.
+		if err := c.pvcHandler.CreatePVCsForWorkspaces(ctx, slices.Concat(clonedClaimWorkspaces, instanceWorkspaces), *kmeta.NewControllerRef(pr), pr.Namespace); err != nil {
+			return err
+		}
+		for _, workspace := range clonedClaimWorkspaces {
+			claimNames = append(claimNames, getPersistentVolumeClaimNameWithAffinityAssistant("", pr.Name, workspace, *kmeta.NewControllerRef(pr)))
+		}
+		for _, workspace := range instanceWorkspaces {
+			claimNames = append(claimNames, volumeclaim.PVCName(workspace, *kmeta.NewControllerRef(pr)))
+		}
 		if err := c.createOrUpdateAffinityAssistant(ctx, aaName, pr, claimTemplates, claimNames, unschedulableNodes); err != nil {
 			return fmt.Errorf("%w: %v", ErrAffinityAssistantCreationFailed, err)
 		}
 	case aa.AffinityAssistantDisabled:
-		for _, workspace := range claimTemplateToWorkspace {
-			if err := c.pvcHandler.CreatePVCFromVolumeClaimTemplate(ctx, workspace, *kmeta.NewControllerRef(pr), pr.Namespace); err != nil {
-				return err
-			}
+		if err := c.pvcHandler.CreatePVCsForWorkspaces(ctx, claimTemplateWorkspaces, *kmeta.NewControllerRef(pr), pr.Namespace); err != nil {
+			return err
 		}
 	}
 
 	return nil
 }
 
+// pvcBackedWorkspaces returns the workspace bindings backed by a PersistentVolumeClaim, either bound
+// directly or created from a VolumeClaimTemplate.
+func pvcBackedWorkspaces(wb []v1.WorkspaceBinding) []v1.WorkspaceBinding {
+	var pvcWorkspaces []v1.WorkspaceBinding
+	for _, w := range wb {
+		if w.PersistentVolumeClaim != nil || w.VolumeClaimTemplate != nil {
+			pvcWorkspaces = append(pvcWorkspaces, w)
+		}
+	}
+	return pvcWorkspaces
+}
+
+// skipsAffinityAssistant returns whether the workspace of pr named workspaceName has no Affinity Assistant in
+// AffinityAssistantPerWorkspace behavior, as recorded in the status of pr when its Coschedule was recorded. The
+// workspaces of the matrix instances have no Affinity Assistant if the workspace they are created from has none.
+func skipsAffinityAssistant(pr *v1.PipelineRun, aaBehavior aa.AffinityAssistantBehavior, workspaceName string) bool {
+	if aaBehavior != aa.AffinityAssistantPerWorkspace {
+		return false
+	}
+	// The workspaces of the matrix instances are named <workspace>/<pipeline-task>/<ordinal>
+	pipelineRunWorkspace, _, _ := strings.Cut(workspaceName, "/")
+	return slices.Contains(pr.Status.SkippedAffinityAssistantWorkspaces, pipelineRunWorkspace)
+}
+
+// matrixInstanceWorkspaces returns the bindings of the instances of the matrixed PipelineTasks of pr to
+// their own PersistentVolumeClaim, for the workspaces they bind per matrix instance, which are provided
+// as volumeClaimTemplates. The instances are counted from the resolved PipelineSpec in the status of pr.
+func matrixInstanceWorkspaces(pr *v1.PipelineRun) []v1.WorkspaceBinding {
+	if pr.Status.PipelineSpec == nil {
+		return nil
+	}
+	pipelineRunWorkspaces := make(map[string]v1.WorkspaceBinding, len(pr.Spec.Workspaces))
+	for _, w := range pr.Spec.Workspaces {
+		pipelineRunWorkspaces[w.Name] = w
+	}
+	var instances []v1.WorkspaceBinding
+	for _, pt := range append(slices.Clone(pr.Status.PipelineSpec.Tasks), pr.Status.PipelineSpec.Finally...) {
+		if !pt.IsMatrixed() {
+			continue
+		}
+		for _, ws := range pt.Workspaces {
+			pipelineWorkspace := ws.Workspace
+			if pipelineWorkspace == "" {
+				pipelineWorkspace = ws.Name
+			}
+			w, ok := pipelineRunWorkspaces[pipelineWorkspace]
+			if !ws.PerMatrixInstance || !ok || w.VolumeClaimTemplate == nil {
+				continue
+			}
+			for ordinal := range pt.Matrix.CountCombinations() {
+				instances = append(instances, volumeclaim.MatrixInstanceWorkspaceBinding(w, pt.Name, ordinal))
+			}
+		}
+	}
+	return instances
+}
+
 // createOrUpdateAffinityAssistant creates an Affinity Assistant Statefulset with the provided affinityAssistantName and pipelinerun information.
 // The VolumeClaimTemplates and Volumes of StatefulSet reference the resolved claimTemplates and claims respectively.
 // It maintains a set of unschedulableNodes to detect and recreate Affinity Assistant in case of the node is cordoned to avoid pipelinerun deadlock.
@@ -146,7 +244,7 @@ func (c *Reconciler) createOrUpdateAffinityAssistant(ctx context.Context, affini
 	switch {
 	// check whether the affinity assistant (StatefulSet) exists or not, create one if it does not exist
 	case apierrors.IsNotFound(err):
-		aaBehavior, err := aa.GetAffinityAssistantBehavior(ctx)
+		aaBehavior, err := aa.GetRunAffinityAssistantBehavior(ctx, pr.Status.Coschedule)
 		if err != nil {
 			return []error{err}
 		}
@@ -156,12 +254,25 @@ func (c *Reconciler) createOrUpdateAffinityAssistant(ctx context.Context, affini
 			SetReadOnlyRootFilesystem: cfg.FeatureFlags.SetSecurityContextReadOnlyRootFilesystem,
 		}
 
+		// The Affinity Assistant runs on the nodes the pods of the PipelineRun are scheduled on
+		images, err := pipelinePod.HelperImages(ctx, c.Images, pr.Annotations)
+		if err != nil {
+			return []error{err}
+		}
 		containerConfig := aa.ContainerConfig{
-			Image:                 c.Images.NopImage,
+			Image:                 images.NopImage,
 			SecurityContextConfig: securityContextConfig,
 		}
 
 		affinityAssistantStatefulSet := affinityAssistantStatefulSet(aaBehavior, affinityAssistantName, pr, claimTemplates, claimNames, containerConfig, cfg.Defaults.DefaultAAPodTemplate)
+		// The Affinity Assistant pod is scheduled with the pods of the TaskRuns it coschedules
+		setPodGroup(ctx, pr, &affinityAssistantStatefulSet.Spec.Template.ObjectMeta)
+		// Merge the pod defaults of the namespace into the affinity assistant, with the lowest precedence
+		nsDefaults, nsErr := pipelinePod.GetNamespaceDefaults(c.configMapLister, pr.Namespace)
+		if nsErr != nil {
+			logger.Errorf("Ignoring the pod defaults of namespace %s for affinity assistant %s: %v", pr.Namespace, affinityAssistantName, nsErr)
+		}
+		nsDefaults.MergePodSpec(&affinityAssistantStatefulSet.Spec.Template.Spec)
 		_, err = c.KubeClientSet.AppsV1().StatefulSets(pr.Namespace).Create(ctx, affinityAssistantStatefulSet, metav1.CreateOptions{})
 		if err != nil {
 			errs = append(errs, fmt.Errorf("failed to create StatefulSet %s: %w", affinityAssistantName, err))
@@ -210,9 +321,80 @@ func (c *Reconciler) createOrUpdateAffinityAssistant(ctx context.Context, affini
 	return errs
 }
 
+// recordCoschedule records the "coschedule" feature flag in the status of pr when it is first
+// reconciled, so that its affinity assistants keep being created, used and cleaned up the same way
+// until it is done, even if the feature flag is changed in the meantime. It is only recorded for the
+// PipelineRuns binding PersistentVolumeClaims or volumeClaimTemplates to their workspaces, as the
+// other PipelineRuns don't use affinity assistants. The workspaces which have no Affinity Assistant
+// because of the "skip-affinity-assistant-for-read-write-many" feature flag are recorded along.
+func (c *Reconciler) recordCoschedule(ctx context.Context, pr *v1.PipelineRun) error {
+	if pr.Status.Coschedule != "" {
+		return nil
+	}
+	pvcWorkspaces := pvcBackedWorkspaces(pr.Spec.Workspaces)
+	if len(pvcWorkspaces) == 0 {
+		return nil
+	}
+	cfg := config.FromContextOrDefaults(ctx)
+	aaBehavior, err := aa.GetRunAffinityAssistantBehavior(ctx, "")
+	if err != nil {
+		return err
+	}
+	if aaBehavior == aa.AffinityAssistantPerWorkspace && cfg.FeatureFlags.SkipAffinityAssistantForReadWriteMany {
+		skipped, err := c.readWriteManyWorkspaces(ctx, pr.Namespace, pvcWorkspaces)
+		if err != nil {
+			return err
+		}
+		pr.Status.SkippedAffinityAssistantWorkspaces = skipped
+	}
+	pr.Status.Coschedule = cfg.FeatureFlags.Coschedule
+	return nil
+}
+
+// readWriteManyWorkspaces returns the names of the workspaces bound to ReadWriteMany PersistentVolumeClaims
+// or volumeClaimTemplates among pvcWorkspaces: the pods using them can mount them from any node, so they
+// don't need to be coscheduled. The PersistentVolumeClaims bound to the workspaces are fetched from the
+// API server, since the informer cache of the reconciler only holds the ones managed by Tekton, and not
+// the ones created by users, but only once per PipelineRun as the result is recorded in its status.
+func (c *Reconciler) readWriteManyWorkspaces(ctx context.Context, namespace string, pvcWorkspaces []v1.WorkspaceBinding) ([]string, error) {
+	var names []string
+	for _, w := range pvcWorkspaces {
+		var accessModes []corev1.PersistentVolumeAccessMode
+		switch {
+		case w.VolumeClaimTemplate != nil:
+			accessModes = w.VolumeClaimTemplate.Spec.AccessModes
+		case w.PersistentVolumeClaim != nil:
+			pvc, err := c.KubeClientSet.CoreV1().PersistentVolumeClaims(namespace).Get(ctx, w.PersistentVolumeClaim.ClaimName, metav1.GetOptions{})
+			switch {
+			case apierrors.IsNotFound(err):
+				// The access modes of a missing PersistentVolumeClaim are unknown: coschedule as usual
+				continue
+			case err != nil:
+				return nil, fmt.Errorf("failed to get the PersistentVolumeClaim %q of workspace %q: %w", w.PersistentVolumeClaim.ClaimName, w.Name, err)
+			}
+			accessModes = pvc.Spec.AccessModes
+		}
+		if slices.Contains(accessModes, corev1.ReadWriteMany) {
+			names = append(names, w.Name)
+		}
+	}
+	return names, nil
+}
+
+// setCoscheduleAnnotation sets the CoscheduleAnnotation of the TaskRuns and CustomRuns of pr to the
+// "coschedule" feature flag recorded in its status, in place of any value propagated from the
+// annotations of pr or set in its taskRunSpecs.
+func setCoscheduleAnnotation(annotations map[string]string, pr *v1.PipelineRun) {
+	delete(annotations, aa.CoscheduleAnnotation)
+	if pr.Status.Coschedule != "" {
+		annotations[aa.CoscheduleAnnotation] = pr.Status.Coschedule
+	}
+}
+
 // cleanupAffinityAssistantsAndPVCs deletes Affinity Assistant StatefulSets and PVCs created from VolumeClaimTemplates
 func (c *Reconciler) cleanupAffinityAssistantsAndPVCs(ctx context.Context, pr *v1.PipelineRun) error {
-	aaBehavior, err := aa.GetAffinityAssistantBehavior(ctx)
+	defer tknreconciler.StartReconcilePhase(ctx, tknreconciler.ReconcilePhaseResources)()
+	aaBehavior, err := aa.GetRunAffinityAssistantBehavior(ctx, pr.Status.Coschedule)
 	if err != nil {
 		return err
 	}
@@ -223,18 +405,18 @@ func (c *Reconciler) cleanupAffinityAssistantsAndPVCs(ctx context.Context, pr *v
 		// Check if auto-cleanup annotation is enabled
 		autoCleanup := pr.Annotations != nil && pr.Annotations[AutoCleanupPVCAnnotation] == "true"
 
-		for _, w := range pr.Spec.Workspaces {
+		for _, w := range append(slices.Clone(pr.Spec.Workspaces), matrixInstanceWorkspaces(pr)...) {
 			if w.PersistentVolumeClaim != nil || w.VolumeClaimTemplate != nil {
 				affinityAssistantName := GetAffinityAssistantName(w.Name, pr.Name)
-				if err := c.KubeClientSet.AppsV1().StatefulSets(pr.Namespace).Delete(ctx, affinityAssistantName, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
-					errs = append(errs, fmt.Errorf("failed to delete StatefulSet %s: %w", affinityAssistantName, err))
+				if err := c.deleteAffinityAssistant(ctx, affinityAssistantName, pr.Namespace); err != nil {
+					errs = append(errs, err)
 				}
 			}
 
 			// Delete PVCs from volumeClaimTemplate if auto-cleanup is enabled.
 			// User-provided persistentVolumeClaim workspaces are never deleted.
 			if w.VolumeClaimTemplate != nil && autoCleanup {
-				pvcName := volumeclaim.GeneratePVCNameFromWorkspaceBinding(w.VolumeClaimTemplate.Name, w, *kmeta.NewControllerRef(pr))
+				pvcName := volumeclaim.PVCName(w, *kmeta.NewControllerRef(pr))
 				if err := c.pvcHandler.PurgeFinalizerAndDeletePVCForWorkspace(ctx, pvcName, pr.Namespace); err != nil {
 					errs = append(errs, err)
 				}
@@ -242,8 +424,8 @@ func (c *Reconciler) cleanupAffinityAssistantsAndPVCs(ctx context.Context, pr *v
 		}
 	case aa.AffinityAssistantPerPipelineRun, aa.AffinityAssistantPerPipelineRunWithIsolation:
 		affinityAssistantName := GetAffinityAssistantName("", pr.Name)
-		if err := c.KubeClientSet.AppsV1().StatefulSets(pr.Namespace).Delete(ctx, affinityAssistantName, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
-			errs = append(errs, fmt.Errorf("failed to delete StatefulSet %s: %w", affinityAssistantName, err))
+		if err := c.deleteAffinityAssistant(ctx, affinityAssistantName, pr.Namespace); err != nil {
+			errs = append(errs, err)
 		}
 
 		// cleanup PVCs created by Affinity Assistants
@@ -255,6 +437,12 @@ func (c *Reconciler) cleanupAffinityAssistantsAndPVCs(ctx context.Context, pr *v
 				}
 			}
 		}
+		for _, w := range matrixInstanceWorkspaces(pr) {
+			pvcName := volumeclaim.PVCName(w, *kmeta.NewControllerRef(pr))
+			if err := c.pvcHandler.PurgeFinalizerAndDeletePVCForWorkspace(ctx, pvcName, pr.Namespace); err != nil {
+				errs = append(errs, err)
+			}
+		}
 	case aa.AffinityAssistantDisabled:
 		return nil
 	}
@@ -262,13 +450,68 @@ func (c *Reconciler) cleanupAffinityAssistantsAndPVCs(ctx context.Context, pr *v
 	return errorutils.NewAggregate(errs)
 }
 
+// sweepLeakedPVCs deletes the PVCs in namespace created from the volumeClaimTemplates of PipelineRuns which
+// no longer exist, e.g. because they were deleted mid-reconcile, if the "enable-leaked-pvc-cleanup" feature
+// flag is set, and records the number of PVCs deleted in the PipelineRun metrics.
+func (c *Reconciler) sweepLeakedPVCs(ctx context.Context, namespace string) {
+	if !config.FromContextOrDefaults(ctx).FeatureFlags.EnableLeakedPVCCleanup || c.pvcLister == nil {
+		return
+	}
+	logger := logging.FromContext(ctx)
+	swept, err := c.pvcHandler.SweepLeakedPVCs(ctx, c.pvcLister.PersistentVolumeClaims(namespace), func(name string, uid types.UID) (bool, error) {
+		pr, err := c.pipelineRunLister.PipelineRuns(namespace).Get(name)
+		switch {
+		case apierrors.IsNotFound(err):
+			return false, nil
+		case err != nil:
+			return false, err
+		}
+		return pr.UID == uid, nil
+	})
+	if err != nil {
+		logger.Errorf("Failed to sweep the leaked PVCs of namespace %s: %v", namespace, err)
+	}
+	if swept > 0 && c.metrics != nil {
+		if err := c.metrics.LeakedPVCsSwept(ctx, namespace, swept); err != nil {
+			logger.Warnf("Failed to log the metrics : %v", err)
+		}
+	}
+}
+
+// deleteAffinityAssistant deletes the Affinity Assistant StatefulSet with the given name, ignoring
+// StatefulSets that are already gone, and records the cleanup in the PipelineRun metrics.
+func (c *Reconciler) deleteAffinityAssistant(ctx context.Context, affinityAssistantName, namespace string) error {
+	err := c.KubeClientSet.AppsV1().StatefulSets(namespace).Delete(ctx, affinityAssistantName, metav1.DeleteOptions{})
+	switch {
+	case apierrors.IsNotFound(err):
+		return nil
+	case err != nil:
+		return fmt.Errorf("failed to delete StatefulSet %s: %w", affinityAssistantName, err)
+	}
+	if c.metrics != nil {
+		if err := c.metrics.AffinityAssistantCleanedUp(ctx, namespace); err != nil {
+			logging.FromContext(ctx).Warnf("Failed to log the metrics : %v", err)
+		}
+	}
+	return nil
+}
+
 // getPersistentVolumeClaimNameWithAffinityAssistant returns the PersistentVolumeClaim name that is
 // created by the Affinity Assistant StatefulSet VolumeClaimTemplate when Affinity Assistant is enabled.
-// The PVCs created by StatefulSet VolumeClaimTemplates follow the format `<pvcName>-<affinityAssistantName>-0`
+// The PVCs created by StatefulSet VolumeClaimTemplates follow the format `<pvcName>-<affinityAssistantName>-0`,
+// see volumeclaim.AffinityAssistantPVCName.
+// The PVCs cloning a data source are created from the PipelineRun instead, and keep the name of their VolumeClaimTemplate.
 func getPersistentVolumeClaimNameWithAffinityAssistant(pipelineWorkspaceName, prName string, wb v1.WorkspaceBinding, owner metav1.OwnerReference) string {
-	pvcName := sanitizeVolumeName(volumeclaim.GeneratePVCNameFromWorkspaceBinding(wb.VolumeClaimTemplate.Name, wb, owner))
-	affinityAssistantName := GetAffinityAssistantName(pipelineWorkspaceName, prName)
-	return fmt.Sprintf("%s-%s-0", pvcName, affinityAssistantName)
+	if clonesDataSource(wb) {
+		return volumeclaim.PVCName(wb, owner)
+	}
+	return volumeclaim.AffinityAssistantPVCName(wb, owner, GetAffinityAssistantName(pipelineWorkspaceName, prName))
+}
+
+// clonesDataSource returns whether the VolumeClaimTemplate of wb populates its volume from a data source,
+// e.g. by cloning a VolumeSnapshot or another PersistentVolumeClaim.
+func clonesDataSource(wb v1.WorkspaceBinding) bool {
+	return wb.VolumeClaimTemplate != nil && (wb.VolumeClaimTemplate.Spec.DataSource != nil || wb.VolumeClaimTemplate.Spec.DataSourceRef != nil)
 }
 
 // getAffinityAssistantAnnotationVal generates and returns the value for `pipeline.tekton.dev/affinity-assistant` annotation
@@ -310,20 +553,6 @@ func getStatefulSetLabels(pr *v1.PipelineRun, affinityAssistantName string) map[
 	return labels
 }
 
-// sanitizeVolumeName returns a volume-safe name that is at most volumeNameMaxLength characters.
-// If the name is already short enough, it is returned as-is.
-// Otherwise, it is truncated and a short hash is appended to preserve uniqueness.
-func sanitizeVolumeName(name string) string {
-	if len(name) <= volumeNameMaxLength {
-		return name
-	}
-	hashBytes := sha256.Sum256([]byte(name))
-	hashStr := hex.EncodeToString(hashBytes[:])[:10]
-	// truncate prefix to leave room for "-" + 10-char hash = 11 chars
-	prefix := name[:volumeNameMaxLength-11]
-	return fmt.Sprintf("%s-%s", prefix, hashStr)
-}
-
 // affinityAssistantStatefulSet returns an Affinity Assistant as a StatefulSet based on the AffinityAssistantBehavior
 // with the given AffinityAssistantTemplate applied to the StatefulSet PodTemplateSpec.
 // The VolumeClaimTemplates and Volume of StatefulSet reference the PipelineRun WorkspaceBinding VolumeClaimTempalte and the PVCs respectively.
@@ -340,7 +569,7 @@ func affinityAssistantStatefulSet(aaBehavior aa.AffinityAssistantBehavior, name
 
 	// Sanitize VolumeClaimTemplate names to stay within Kubernetes' 63-char volume name limit.
 	for i := range claimTemplates {
-		claimTemplates[i].Name = sanitizeVolumeName(claimTemplates[i].Name)
+		claimTemplates[i].Name = volumeclaim.SanitizeVolumeName(claimTemplates[i].Name)
 	}
 
 	var mounts []corev1.VolumeMount
@@ -31,6 +31,7 @@ import (
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/pod"
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	listers "github.com/tektoncd/pipeline/pkg/client/listers/pipeline/v1"
 	aa "github.com/tektoncd/pipeline/pkg/internal/affinityassistant"
 	pipelinePod "github.com/tektoncd/pipeline/pkg/pod"
 	"github.com/tektoncd/pipeline/pkg/reconciler/volumeclaim"
@@ -44,9 +45,13 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	errorutils "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
 	fakek8s "k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/kubernetes/typed/core/v1/fake"
+	corev1listers "k8s.io/client-go/listers/core/v1"
 	testing2 "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
 	"knative.dev/pkg/kmeta"
 	logtesting "knative.dev/pkg/logging/testing"
 	"knative.dev/pkg/system"
@@ -208,7 +213,19 @@ func TestCreateOrUpdateAffinityAssistantsAndPVCsPerPipelineRun(t *testing.T) {
 				},
 			},
 			VolumeClaimTemplates: []corev1.PersistentVolumeClaim{{
-				ObjectMeta: metav1.ObjectMeta{Name: "pvc-b9eea16dce"},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "pvc-b9eea16dce",
+					Labels: map[string]string{
+						pipeline.PipelineRunLabelKey:    testPRWithVolumeClaimTemplate.Name,
+						pipeline.PipelineRunUIDLabelKey: "",
+						v1.ManagedByLabelKey:            config.DefaultManagedByLabelValue,
+					},
+					Annotations: map[string]string{
+						pipeline.PipelineRunLabelKey:            testPRWithVolumeClaimTemplate.Name,
+						volumeclaim.WorkspaceAnnotationKey:      "test-workspace-vct",
+						volumeclaim.PVCNameVersionAnnotationKey: volumeclaim.PVCNameVersion,
+					},
+				},
 			}},
 		},
 	}, {
@@ -224,7 +241,19 @@ func TestCreateOrUpdateAffinityAssistantsAndPVCsPerPipelineRun(t *testing.T) {
 				},
 			},
 			VolumeClaimTemplates: []corev1.PersistentVolumeClaim{{
-				ObjectMeta: metav1.ObjectMeta{Name: "pvc-b9eea16dce"},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "pvc-b9eea16dce",
+					Labels: map[string]string{
+						pipeline.PipelineRunLabelKey:    testPRWithVolumeClaimTemplateAndPVC.Name,
+						pipeline.PipelineRunUIDLabelKey: "",
+						v1.ManagedByLabelKey:            config.DefaultManagedByLabelValue,
+					},
+					Annotations: map[string]string{
+						pipeline.PipelineRunLabelKey:            testPRWithVolumeClaimTemplateAndPVC.Name,
+						volumeclaim.WorkspaceAnnotationKey:      "test-workspace-vct",
+						volumeclaim.PVCNameVersionAnnotationKey: volumeclaim.PVCNameVersion,
+					},
+				},
 			}},
 			Template: corev1.PodTemplateSpec{
 				Spec: corev1.PodSpec{
@@ -353,8 +382,9 @@ func TestCreateOrUpdateAffinityAssistantsAndPVCsPerPipelineRun(t *testing.T) {
 			kubeClientSet := fakek8s.NewSimpleClientset()
 			ctx := cfgtesting.SetFeatureFlags(t.Context(), t, featureFlags)
 			c := Reconciler{
-				KubeClientSet: kubeClientSet,
-				pvcHandler:    volumeclaim.NewPVCHandler(kubeClientSet, zap.NewExample().Sugar()),
+				KubeClientSet:   kubeClientSet,
+				pvcHandler:      volumeclaim.NewPVCHandler(kubeClientSet, zap.NewExample().Sugar()),
+				configMapLister: newConfigMapLister(),
 			}
 
 			err := c.createOrUpdateAffinityAssistantsAndPVCs(ctx, tc.pr, aa.AffinityAssistantPerPipelineRun)
@@ -514,8 +544,9 @@ func TestCreateOrUpdateAffinityAssistantsAndPVCsPerWorkspaceOrDisabled(t *testin
 			ctx := t.Context()
 			kubeClientSet := fakek8s.NewSimpleClientset()
 			c := Reconciler{
-				KubeClientSet: kubeClientSet,
-				pvcHandler:    volumeclaim.NewPVCHandler(kubeClientSet, zap.NewExample().Sugar()),
+				KubeClientSet:   kubeClientSet,
+				pvcHandler:      volumeclaim.NewPVCHandler(kubeClientSet, zap.NewExample().Sugar()),
+				configMapLister: newConfigMapLister(),
 			}
 
 			err := c.createOrUpdateAffinityAssistantsAndPVCs(ctx, tc.pr, tc.aaBehavior)
@@ -559,6 +590,569 @@ func TestCreateOrUpdateAffinityAssistantsAndPVCsPerWorkspaceOrDisabled(t *testin
 	}
 }
 
+// TestAffinityAssistantsKeepCoscheduleOfPipelineRun tests that the affinity assistants of a PipelineRun keep being
+// created and cleaned up with the "coschedule" feature flag it was started with when the feature flag is changed
+func TestAffinityAssistantsKeepCoscheduleOfPipelineRun(t *testing.T) {
+	for _, tc := range []struct {
+		name, started, changed string
+		expectAAName           string
+	}{{
+		name:         "per workspace, then disabled",
+		started:      config.CoscheduleWorkspaces,
+		changed:      config.CoscheduleDisabled,
+		expectAAName: GetAffinityAssistantName(workspacePVCName, testPRWithPVC.Name),
+	}, {
+		name:         "per pipelinerun, then per workspace",
+		started:      config.CoschedulePipelineRuns,
+		changed:      config.CoscheduleWorkspaces,
+		expectAAName: GetAffinityAssistantName("", testPRWithPVC.Name),
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			pr := testPRWithPVC.DeepCopy()
+			kubeClientSet := fakek8s.NewSimpleClientset()
+			c := Reconciler{
+				KubeClientSet:   kubeClientSet,
+				pvcHandler:      volumeclaim.NewPVCHandler(kubeClientSet, zap.NewExample().Sugar()),
+				configMapLister: newConfigMapLister(),
+			}
+
+			if err := c.recordCoschedule(cfgtesting.SetFeatureFlags(t.Context(), t, map[string]string{"coschedule": tc.started}), pr); err != nil {
+				t.Fatalf("unexpected error recording the coschedule: %v", err)
+			}
+			ctx := cfgtesting.SetFeatureFlags(t.Context(), t, map[string]string{"coschedule": tc.changed})
+			if err := c.recordCoschedule(ctx, pr); err != nil {
+				t.Fatalf("unexpected error recording the coschedule: %v", err)
+			}
+			if got := pr.Status.Coschedule; got != tc.started {
+				t.Fatalf("expected the coschedule %q to be recorded, got %q", tc.started, got)
+			}
+
+			aaBehavior, err := aa.GetRunAffinityAssistantBehavior(ctx, pr.Status.Coschedule)
+			if err != nil {
+				t.Fatalf("unexpected error getting the affinity assistant behavior: %v", err)
+			}
+			if err := c.createOrUpdateAffinityAssistantsAndPVCs(ctx, pr, aaBehavior); err != nil {
+				t.Fatalf("unexpected error from createOrUpdateAffinityAssistantsAndPVCs: %v", err)
+			}
+			stss, err := kubeClientSet.AppsV1().StatefulSets(pr.Namespace).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				t.Fatalf("unexpected error listing StatefulSets: %v", err)
+			}
+			if len(stss.Items) != 1 || stss.Items[0].Name != tc.expectAAName {
+				t.Fatalf("expected only the StatefulSet %s to be created, got %v", tc.expectAAName, stss.Items)
+			}
+
+			if err := c.cleanupAffinityAssistantsAndPVCs(ctx, pr); err != nil {
+				t.Fatalf("unexpected error from cleanupAffinityAssistantsAndPVCs: %v", err)
+			}
+			if _, err := kubeClientSet.AppsV1().StatefulSets(pr.Namespace).Get(ctx, tc.expectAAName, metav1.GetOptions{}); !apierrors.IsNotFound(err) {
+				t.Errorf("expected the StatefulSet %s to be deleted, got: %v", tc.expectAAName, err)
+			}
+		})
+	}
+}
+
+// TestCoscheduleAnnotationOfPipelineRunIgnored tests that the "coschedule" feature flag of a PipelineRun is
+// recorded in its status regardless of the coschedule annotation it was created with, and that its TaskRuns
+// get the recorded one instead of the one propagated from the annotations of the PipelineRun
+func TestCoscheduleAnnotationOfPipelineRunIgnored(t *testing.T) {
+	pr := testPRWithPVC.DeepCopy()
+	pr.Annotations = map[string]string{aa.CoscheduleAnnotation: config.CoscheduleDisabled}
+	ctx := cfgtesting.SetFeatureFlags(t.Context(), t, map[string]string{"coschedule": config.CoscheduleWorkspaces})
+
+	c := Reconciler{KubeClientSet: fakek8s.NewSimpleClientset()}
+	if err := c.recordCoschedule(ctx, pr); err != nil {
+		t.Fatalf("unexpected error recording the coschedule: %v", err)
+	}
+	if pr.Status.Coschedule != config.CoscheduleWorkspaces {
+		t.Errorf("expected the coschedule %q to be recorded, got %q", config.CoscheduleWorkspaces, pr.Status.Coschedule)
+	}
+	aaBehavior, err := aa.GetRunAffinityAssistantBehavior(ctx, pr.Status.Coschedule)
+	if err != nil {
+		t.Fatalf("unexpected error getting the affinity assistant behavior: %v", err)
+	}
+	if aaBehavior != aa.AffinityAssistantPerWorkspace {
+		t.Errorf("expected the affinity assistant behavior %s, got %s", aa.AffinityAssistantPerWorkspace, aaBehavior)
+	}
+
+	annotations := createChildResourceAnnotations(pr)
+	setCoscheduleAnnotation(annotations, pr)
+	if got := annotations[aa.CoscheduleAnnotation]; got != config.CoscheduleWorkspaces {
+		t.Errorf("expected the TaskRuns to be annotated with the coschedule %q, got %q", config.CoscheduleWorkspaces, got)
+	}
+}
+
+// TestAffinityAssistantsAndPVCsPerMatrixInstance tests that a PVC is created for each instance of a matrixed
+// PipelineTask binding a workspace per matrix instance, coscheduled by its own Affinity Assistant or by the one of
+// the PipelineRun, and that all of them are deleted at cleanup
+func TestAffinityAssistantsAndPVCsPerMatrixInstance(t *testing.T) {
+	pr := &v1.PipelineRun{
+		TypeMeta: metav1.TypeMeta{Kind: "PipelineRun"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "pipelinerun-per-matrix-instance",
+			Namespace:   "ns",
+			UID:         "pipelinerun-per-matrix-instance-uid",
+			Annotations: map[string]string{AutoCleanupPVCAnnotation: "true"},
+		},
+		Spec: v1.PipelineRunSpec{
+			Workspaces: []v1.WorkspaceBinding{{
+				Name: "cache",
+				VolumeClaimTemplate: &corev1.PersistentVolumeClaim{
+					ObjectMeta: metav1.ObjectMeta{Name: "cache"},
+				},
+			}},
+		},
+		Status: v1.PipelineRunStatus{
+			PipelineRunStatusFields: v1.PipelineRunStatusFields{
+				PipelineSpec: &v1.PipelineSpec{
+					Tasks: []v1.PipelineTask{{
+						Name: "build",
+						Matrix: &v1.Matrix{Params: v1.Params{{
+							Name: "platform", Value: *v1.NewStructuredValues("linux", "mac", "windows"),
+						}}},
+						Workspaces: []v1.WorkspacePipelineTaskBinding{{Name: "cache", PerMatrixInstance: true}},
+					}},
+				},
+			},
+		},
+	}
+	owner := *kmeta.NewControllerRef(pr)
+	var instanceAANames, instancePVCNames []string
+	for ordinal := range 3 {
+		instance := volumeclaim.MatrixInstanceWorkspaceBinding(pr.Spec.Workspaces[0], "build", ordinal)
+		instanceAANames = append(instanceAANames, GetAffinityAssistantName(instance.Name, pr.Name))
+		instancePVCNames = append(instancePVCNames, volumeclaim.GeneratePVCNameFromWorkspaceBinding(instance.VolumeClaimTemplate.Name, instance, owner))
+	}
+	sharedPVCName := volumeclaim.GeneratePVCNameFromWorkspaceBinding("cache", pr.Spec.Workspaces[0], owner)
+
+	for _, tc := range []struct {
+		coschedule    string
+		expectAANames []string
+		expectPVCs    []string
+		// expectAAClaims are the PVCs mounted into the Affinity Assistant of the PipelineRun.
+		expectAAClaims []string
+	}{{
+		coschedule:    config.CoscheduleWorkspaces,
+		expectAANames: append([]string{GetAffinityAssistantName("cache", pr.Name)}, instanceAANames...),
+		expectPVCs:    append([]string{sharedPVCName}, instancePVCNames...),
+	}, {
+		coschedule:     config.CoschedulePipelineRuns,
+		expectAANames:  []string{GetAffinityAssistantName("", pr.Name)},
+		expectPVCs:     instancePVCNames,
+		expectAAClaims: instancePVCNames,
+	}, {
+		coschedule: config.CoscheduleDisabled,
+		expectPVCs: append([]string{sharedPVCName}, instancePVCNames...),
+	}} {
+		t.Run(tc.coschedule, func(t *testing.T) {
+			pr := pr.DeepCopy()
+			ctx := cfgtesting.SetFeatureFlags(t.Context(), t, map[string]string{"coschedule": tc.coschedule})
+			kubeClientSet := fakek8s.NewSimpleClientset()
+			c := Reconciler{
+				KubeClientSet:   kubeClientSet,
+				pvcHandler:      volumeclaim.NewPVCHandler(kubeClientSet, zap.NewExample().Sugar()),
+				configMapLister: newConfigMapLister(),
+			}
+			if err := c.recordCoschedule(ctx, pr); err != nil {
+				t.Fatalf("unexpected error recording the coschedule: %v", err)
+			}
+			aaBehavior, err := aa.GetRunAffinityAssistantBehavior(ctx, pr.Status.Coschedule)
+			if err != nil {
+				t.Fatalf("unexpected error getting the affinity assistant behavior: %v", err)
+			}
+			if err := c.createOrUpdateAffinityAssistantsAndPVCs(ctx, pr, aaBehavior); err != nil {
+				t.Fatalf("unexpected error from createOrUpdateAffinityAssistantsAndPVCs: %v", err)
+			}
+
+			pvcs, err := kubeClientSet.CoreV1().PersistentVolumeClaims(pr.Namespace).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				t.Fatalf("unexpected error listing PVCs: %v", err)
+			}
+			var pvcNames []string
+			for _, pvc := range pvcs.Items {
+				pvcNames = append(pvcNames, pvc.Name)
+			}
+			if d := cmp.Diff(tc.expectPVCs, pvcNames, cmpopts.SortSlices(func(a, b string) bool { return a < b })); d != "" {
+				t.Errorf("created PVCs %s", diff.PrintWantGot(d))
+			}
+
+			stss, err := kubeClientSet.AppsV1().StatefulSets(pr.Namespace).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				t.Fatalf("unexpected error listing StatefulSets: %v", err)
+			}
+			var aaNames []string
+			for _, sts := range stss.Items {
+				aaNames = append(aaNames, sts.Name)
+				if tc.expectAAClaims == nil {
+					continue
+				}
+				var claims []string
+				for _, v := range sts.Spec.Template.Spec.Volumes {
+					if v.PersistentVolumeClaim != nil {
+						claims = append(claims, v.PersistentVolumeClaim.ClaimName)
+					}
+				}
+				if d := cmp.Diff(tc.expectAAClaims, claims, cmpopts.SortSlices(func(a, b string) bool { return a < b })); d != "" {
+					t.Errorf("PVCs mounted into the Affinity Assistant %s", diff.PrintWantGot(d))
+				}
+			}
+			if d := cmp.Diff(tc.expectAANames, aaNames, cmpopts.SortSlices(func(a, b string) bool { return a < b })); d != "" {
+				t.Errorf("created Affinity Assistants %s", diff.PrintWantGot(d))
+			}
+
+			// mocks the `kubernetes.io/pvc-protection` finalizer by keeping the deleted PVCs until it is removed
+			deletedPVCs := sets.New[string]()
+			kubeClientSet.PrependReactor("delete", "persistentvolumeclaims", func(action testing2.Action) (bool, runtime.Object, error) {
+				deletedPVCs.Insert(action.(testing2.DeleteAction).GetName())
+				return true, nil, nil
+			})
+			if err := c.cleanupAffinityAssistantsAndPVCs(ctx, pr); err != nil {
+				t.Fatalf("unexpected error from cleanupAffinityAssistantsAndPVCs: %v", err)
+			}
+			for _, name := range tc.expectAANames {
+				if _, err := kubeClientSet.AppsV1().StatefulSets(pr.Namespace).Get(ctx, name, metav1.GetOptions{}); !apierrors.IsNotFound(err) {
+					t.Errorf("expected the StatefulSet %s to be deleted, got: %v", name, err)
+				}
+			}
+			if tc.coschedule == config.CoscheduleDisabled {
+				// Without Affinity Assistants, the PVCs are deleted along with their owning PipelineRun.
+				return
+			}
+			for _, name := range instancePVCNames {
+				if !deletedPVCs.Has(name) {
+					t.Errorf("expected the PVC %s to be deleted", name)
+				}
+			}
+		})
+	}
+}
+
+// TestCreateOrUpdateAffinityAssistantsAndPVCs_DataSource tests that the PVCs of VolumeClaimTemplates cloning a data source
+// are created from the PipelineRun and mounted into the Affinity Assistant, instead of being created by its StatefulSet
+func TestCreateOrUpdateAffinityAssistantsAndPVCs_DataSource(t *testing.T) {
+	apiGroup := "snapshot.storage.k8s.io"
+	pr := &v1.PipelineRun{
+		TypeMeta: metav1.TypeMeta{Kind: "PipelineRun"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "pipelinerun-with-snapshot",
+			UID:  "pipelinerun-with-snapshot-uid",
+		},
+		Spec: v1.PipelineRunSpec{
+			Workspaces: []v1.WorkspaceBinding{{
+				Name: "source",
+				VolumeClaimTemplate: &corev1.PersistentVolumeClaim{
+					ObjectMeta: metav1.ObjectMeta{Name: "source"},
+					Spec: corev1.PersistentVolumeClaimSpec{
+						DataSourceRef: &corev1.TypedObjectReference{
+							APIGroup: &apiGroup,
+							Kind:     "VolumeSnapshot",
+							Name:     "golden-source",
+						},
+					},
+				},
+			}, {
+				Name: "cache",
+				VolumeClaimTemplate: &corev1.PersistentVolumeClaim{
+					ObjectMeta: metav1.ObjectMeta{Name: "cache"},
+				},
+			}},
+		},
+	}
+	owner := *kmeta.NewControllerRef(pr)
+	clonedPVCName := volumeclaim.GeneratePVCNameFromWorkspaceBinding("source", pr.Spec.Workspaces[0], owner)
+
+	for coschedule, aaBehavior := range map[string]aa.AffinityAssistantBehavior{
+		config.CoschedulePipelineRuns:       aa.AffinityAssistantPerPipelineRun,
+		config.CoscheduleIsolatePipelineRun: aa.AffinityAssistantPerPipelineRunWithIsolation,
+	} {
+		t.Run(string(aaBehavior), func(t *testing.T) {
+			ctx := cfgtesting.SetFeatureFlags(t.Context(), t, map[string]string{"coschedule": coschedule})
+			kubeClientSet := fakek8s.NewSimpleClientset()
+			c := Reconciler{
+				KubeClientSet:   kubeClientSet,
+				pvcHandler:      volumeclaim.NewPVCHandler(kubeClientSet, zap.NewExample().Sugar()),
+				configMapLister: newConfigMapLister(),
+			}
+
+			if err := c.createOrUpdateAffinityAssistantsAndPVCs(ctx, pr, aaBehavior); err != nil {
+				t.Fatalf("unexpected error from createOrUpdateAffinityAssistantsAndPVCs: %v", err)
+			}
+
+			pvc, err := c.KubeClientSet.CoreV1().PersistentVolumeClaims("").Get(ctx, clonedPVCName, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("expected the PVC cloning the snapshot to be created from the PipelineRun: %v", err)
+			}
+			if d := cmp.Diff([]metav1.OwnerReference{owner}, pvc.OwnerReferences); d != "" {
+				t.Errorf("PVC OwnerReferences diff %s", diff.PrintWantGot(d))
+			}
+			if d := cmp.Diff(pr.Spec.Workspaces[0].VolumeClaimTemplate.Spec.DataSourceRef, pvc.Spec.DataSourceRef); d != "" {
+				t.Errorf("PVC DataSourceRef diff %s", diff.PrintWantGot(d))
+			}
+
+			aaName := GetAffinityAssistantName("", pr.Name)
+			sts, err := c.KubeClientSet.AppsV1().StatefulSets("").Get(ctx, aaName, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("unexpected error when retrieving StatefulSet: %v", err)
+			}
+			var claimTemplateNames []string
+			for _, ct := range sts.Spec.VolumeClaimTemplates {
+				claimTemplateNames = append(claimTemplateNames, ct.Name)
+			}
+			wantClaimTemplateNames := []string{volumeclaim.GeneratePVCNameFromWorkspaceBinding("cache", pr.Spec.Workspaces[1], owner)}
+			if d := cmp.Diff(wantClaimTemplateNames, claimTemplateNames); d != "" {
+				t.Errorf("StatefulSet VolumeClaimTemplates diff %s", diff.PrintWantGot(d))
+			}
+			wantVolumes := []corev1.Volume{{
+				Name: "workspace-0",
+				VolumeSource: corev1.VolumeSource{
+					PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: clonedPVCName},
+				},
+			}}
+			if d := cmp.Diff(wantVolumes, sts.Spec.Template.Spec.Volumes); d != "" {
+				t.Errorf("StatefulSet Volumes diff %s", diff.PrintWantGot(d))
+			}
+
+			// the TaskRuns mount the PVC created from the PipelineRun
+			if got := getPersistentVolumeClaimNameWithAffinityAssistant("", pr.Name, pr.Spec.Workspaces[0], owner); got != clonedPVCName {
+				t.Errorf("expected the TaskRuns to mount the PVC %s, got %s", clonedPVCName, got)
+			}
+
+			// the fake clientset deletes the PVC right away, so removing its pvc-protection finalizer afterwards fails
+			_ = c.cleanupAffinityAssistantsAndPVCs(ctx, pr)
+			pvcDeleted := false
+			for _, action := range kubeClientSet.Actions() {
+				if d, ok := action.(testing2.DeleteAction); ok && d.GetResource().Resource == "persistentvolumeclaims" && d.GetName() == clonedPVCName {
+					pvcDeleted = true
+				}
+			}
+			if !pvcDeleted {
+				t.Errorf("expected the PVC cloning the snapshot %s to be deleted", clonedPVCName)
+			}
+		})
+	}
+}
+
+// TestCreateOrUpdateAffinityAssistantsAndPVCs_ReadWriteMany tests that, with the "skip-affinity-assistant-for-read-write-many"
+// feature flag set, no Affinity Assistant is created for the workspaces bound to a ReadWriteMany PersistentVolumeClaim in
+// AffinityAssistantPerWorkspace behavior, and that their TaskRuns aren't coscheduled. The claims created by users
+// don't have the managed-by label, so they aren't in the informer cache of the reconciler.
+func TestCreateOrUpdateAffinityAssistantsAndPVCs_ReadWriteMany(t *testing.T) {
+	claim := func(name string, accessMode corev1.PersistentVolumeAccessMode) *corev1.PersistentVolumeClaim {
+		return &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns"},
+			Spec:       corev1.PersistentVolumeClaimSpec{AccessModes: []corev1.PersistentVolumeAccessMode{accessMode}},
+		}
+	}
+	managedClaim := claim("managed-rwx", corev1.ReadWriteMany)
+	managedClaim.Labels = map[string]string{v1.ManagedByLabelKey: "tekton-pipelines"}
+	for _, tc := range []struct {
+		name      string
+		skipRWX   string
+		workspace v1.WorkspaceBinding
+		expectAA  bool
+	}{{
+		name:      "ReadWriteMany claim",
+		skipRWX:   "true",
+		workspace: v1.WorkspaceBinding{Name: "source", PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "rwx"}},
+	}, {
+		name:      "ReadWriteMany claim managed by Tekton",
+		skipRWX:   "true",
+		workspace: v1.WorkspaceBinding{Name: "source", PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "managed-rwx"}},
+	}, {
+		name:      "ReadWriteOnce claim",
+		skipRWX:   "true",
+		workspace: v1.WorkspaceBinding{Name: "source", PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "rwo"}},
+		expectAA:  true,
+	}, {
+		name:      "unknown claim",
+		skipRWX:   "true",
+		workspace: v1.WorkspaceBinding{Name: "source", PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "unknown"}},
+		expectAA:  true,
+	}, {
+		name:      "ReadWriteMany claim without the feature flag",
+		skipRWX:   "false",
+		workspace: v1.WorkspaceBinding{Name: "source", PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "rwx"}},
+		expectAA:  true,
+	}, {
+		name:      "ReadWriteMany volumeClaimTemplate",
+		skipRWX:   "true",
+		workspace: v1.WorkspaceBinding{Name: "source", VolumeClaimTemplate: claim("template", corev1.ReadWriteMany)},
+	}, {
+		name:      "ReadWriteOnce volumeClaimTemplate",
+		skipRWX:   "true",
+		workspace: v1.WorkspaceBinding{Name: "source", VolumeClaimTemplate: claim("template", corev1.ReadWriteOnce)},
+		expectAA:  true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := cfgtesting.SetFeatureFlags(t.Context(), t, map[string]string{
+				"coschedule": "workspaces",
+				"skip-affinity-assistant-for-read-write-many": tc.skipRWX,
+			})
+			pr := &v1.PipelineRun{
+				ObjectMeta: metav1.ObjectMeta{Name: "pipelinerun", Namespace: "ns"},
+				Spec:       v1.PipelineRunSpec{Workspaces: []v1.WorkspaceBinding{tc.workspace}},
+			}
+			// the informer cache of the reconciler only holds the claims with the managed-by label
+			pvcs := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+			_ = pvcs.Add(managedClaim)
+			kubeClientSet := fakek8s.NewSimpleClientset(claim("rwx", corev1.ReadWriteMany), claim("rwo", corev1.ReadWriteOnce), managedClaim)
+			c := Reconciler{
+				KubeClientSet:   kubeClientSet,
+				pvcHandler:      volumeclaim.NewPVCHandler(kubeClientSet, zap.NewExample().Sugar()),
+				pvcLister:       corev1listers.NewPersistentVolumeClaimLister(pvcs),
+				configMapLister: newConfigMapLister(),
+			}
+
+			if err := c.recordCoschedule(ctx, pr); err != nil {
+				t.Fatalf("unexpected error recording the coschedule: %v", err)
+			}
+			// The decision recorded in the status holds even if the feature flag is changed in the meantime
+			ctx = cfgtesting.SetFeatureFlags(t.Context(), t, map[string]string{"coschedule": "workspaces"})
+			if err := c.createOrUpdateAffinityAssistantsAndPVCs(ctx, pr, aa.AffinityAssistantPerWorkspace); err != nil {
+				t.Fatalf("unexpected error from createOrUpdateAffinityAssistantsAndPVCs: %v", err)
+			}
+
+			_, err := c.KubeClientSet.AppsV1().StatefulSets(pr.Namespace).Get(ctx, GetAffinityAssistantName(tc.workspace.Name, pr.Name), metav1.GetOptions{})
+			switch {
+			case tc.expectAA && err != nil:
+				t.Errorf("expected the Affinity Assistant of the workspace to be created, got: %v", err)
+			case !tc.expectAA && !apierrors.IsNotFound(err):
+				t.Errorf("expected the Affinity Assistant of the workspace not to be created, got: %v", err)
+			}
+			if tc.workspace.VolumeClaimTemplate != nil {
+				pvcName := volumeclaim.PVCName(tc.workspace, *kmeta.NewControllerRef(pr))
+				if _, err := c.KubeClientSet.CoreV1().PersistentVolumeClaims(pr.Namespace).Get(ctx, pvcName, metav1.GetOptions{}); err != nil {
+					t.Errorf("expected the PVC of the volumeClaimTemplate to be created, got: %v", err)
+				}
+			}
+			if got := skipsAffinityAssistant(pr, aa.AffinityAssistantPerWorkspace, tc.workspace.Name); got == tc.expectAA {
+				t.Errorf("expected the TaskRuns using the workspace to be coscheduled: %t, got %t", tc.expectAA, !got)
+			}
+			if skipsAffinityAssistant(pr, aa.AffinityAssistantPerPipelineRun, tc.workspace.Name) {
+				t.Error("expected the TaskRuns to be coscheduled with the Affinity Assistant of the PipelineRun")
+			}
+		})
+	}
+}
+
+// TestRecordCoschedule_ReadWriteManyClaimError tests that the coschedule of a PipelineRun isn't recorded
+// when the PersistentVolumeClaim bound to one of its workspaces can't be fetched, so that it is retried
+func TestRecordCoschedule_ReadWriteManyClaimError(t *testing.T) {
+	ctx := cfgtesting.SetFeatureFlags(t.Context(), t, map[string]string{
+		"coschedule": "workspaces",
+		"skip-affinity-assistant-for-read-write-many": "true",
+	})
+	pr := &v1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "pipelinerun", Namespace: "ns"},
+		Spec: v1.PipelineRunSpec{Workspaces: []v1.WorkspaceBinding{{
+			Name:                  "source",
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "rwx"},
+		}}},
+	}
+	kubeClientSet := fakek8s.NewSimpleClientset()
+	kubeClientSet.PrependReactor("get", "persistentvolumeclaims", func(action testing2.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.New("etcdserver: request timed out")
+	})
+	c := Reconciler{KubeClientSet: kubeClientSet}
+
+	if err := c.recordCoschedule(ctx, pr); err == nil {
+		t.Fatal("expected an error recording the coschedule")
+	}
+	if pr.Status.Coschedule != "" || pr.Status.SkippedAffinityAssistantWorkspaces != nil {
+		t.Errorf("expected nothing to be recorded, got the coschedule %q skipping %v", pr.Status.Coschedule, pr.Status.SkippedAffinityAssistantWorkspaces)
+	}
+}
+
+func TestCreateOrUpdateAffinityAssistantsAndPVCs_HelperImageSet(t *testing.T) {
+	ctx := cfgtesting.SetDefaults(t.Context(), t, map[string]string{
+		"helper-image-sets": "arm64: {nop: registry.example.com/nop:arm64}",
+	})
+	pr := &v1.PipelineRun{
+		TypeMeta: metav1.TypeMeta{Kind: "PipelineRun"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "pipelinerun-arm64",
+			Annotations: map[string]string{pipelinePod.HelperImageSetAnnotation: "arm64"},
+		},
+		Spec: v1.PipelineRunSpec{
+			Workspaces: []v1.WorkspaceBinding{{
+				Name:                  "source",
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "source"},
+			}},
+		},
+	}
+	kubeClientSet := fakek8s.NewSimpleClientset()
+	c := Reconciler{
+		KubeClientSet:   kubeClientSet,
+		Images:          pipeline.Images{NopImage: "nop"},
+		pvcHandler:      volumeclaim.NewPVCHandler(kubeClientSet, zap.NewExample().Sugar()),
+		configMapLister: newConfigMapLister(),
+	}
+	if err := c.createOrUpdateAffinityAssistantsAndPVCs(ctx, pr, aa.AffinityAssistantPerWorkspace); err != nil {
+		t.Fatalf("unexpected error from createOrUpdateAffinityAssistantsAndPVCs: %v", err)
+	}
+
+	aaName := GetAffinityAssistantName("source", pr.Name)
+	sts, err := c.KubeClientSet.AppsV1().StatefulSets("").Get(ctx, aaName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error when retrieving StatefulSet: %v", err)
+	}
+	if got := sts.Spec.Template.Spec.Containers[0].Image; got != "registry.example.com/nop:arm64" {
+		t.Errorf("expected the Affinity Assistant to run the nop image of the helper image set, got %s", got)
+	}
+}
+
+func TestCreateOrUpdateAffinityAssistantsAndPVCs_PodGroup(t *testing.T) {
+	ctx := cfgtesting.SetDefaults(t.Context(), t, map[string]string{
+		"default-pod-group-label-key": "scheduling.example.com/group",
+	})
+	pr := &v1.PipelineRun{
+		TypeMeta: metav1.TypeMeta{Kind: "PipelineRun"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "pipelinerun-pod-group",
+			UID:  "pr-uid",
+		},
+		Spec: v1.PipelineRunSpec{
+			Workspaces: []v1.WorkspaceBinding{{
+				Name:                  "source",
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "source"},
+			}},
+		},
+		Status: v1.PipelineRunStatus{
+			PipelineRunStatusFields: v1.PipelineRunStatusFields{
+				PipelineSpec: &v1.PipelineSpec{
+					Tasks: []v1.PipelineTask{{Name: "a"}, {Name: "b", RunAfter: []string{"a"}}},
+				},
+			},
+		},
+	}
+	kubeClientSet := fakek8s.NewSimpleClientset()
+	c := Reconciler{
+		KubeClientSet:   kubeClientSet,
+		Images:          pipeline.Images{NopImage: "nop"},
+		pvcHandler:      volumeclaim.NewPVCHandler(kubeClientSet, zap.NewExample().Sugar()),
+		configMapLister: newConfigMapLister(),
+	}
+	if err := c.createOrUpdateAffinityAssistantsAndPVCs(ctx, pr, aa.AffinityAssistantPerWorkspace); err != nil {
+		t.Fatalf("unexpected error from createOrUpdateAffinityAssistantsAndPVCs: %v", err)
+	}
+
+	aaName := GetAffinityAssistantName("source", pr.Name)
+	sts, err := c.KubeClientSet.AppsV1().StatefulSets("").Get(ctx, aaName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error when retrieving StatefulSet: %v", err)
+	}
+	podMeta := sts.Spec.Template.ObjectMeta
+	if got := podMeta.Labels["scheduling.example.com/group"]; got != "pr-uid" {
+		t.Errorf("expected the Affinity Assistant pod to be labeled with the UID of the PipelineRun, got %q", got)
+	}
+	if got := podMeta.Annotations[config.DefaultPodGroupSizeAnnotationKey]; got != "2" {
+		t.Errorf("expected the Affinity Assistant pod to have a pod group size of 2, got %q", got)
+	}
+	if _, ok := sts.Spec.Selector.MatchLabels["scheduling.example.com/group"]; ok {
+		t.Errorf("expected the pod group label not to be part of the StatefulSet selector")
+	}
+}
+
 func TestCreateOrUpdateAffinityAssistantsAndPVCs_Failure(t *testing.T) {
 	testCases := []struct {
 		name, failureType string
@@ -578,12 +1172,12 @@ func TestCreateOrUpdateAffinityAssistantsAndPVCs_Failure(t *testing.T) {
 		name:        "pvc creation failed - per workspace",
 		failureType: "pvc",
 		aaBehavior:  aa.AffinityAssistantPerWorkspace,
-		expectedErr: fmt.Errorf("%w for pvc-b9eea16dce: error creating persistentvolumeclaims", ErrPvcCreationFailed),
+		expectedErr: fmt.Errorf("workspace test-workspace-vct: %w for pvc-b9eea16dce: error creating persistentvolumeclaims", ErrPvcCreationFailed),
 	}, {
 		name:        "pvc creation failed - disabled",
 		failureType: "pvc",
 		aaBehavior:  aa.AffinityAssistantDisabled,
-		expectedErr: fmt.Errorf("%w for pvc-b9eea16dce: error creating persistentvolumeclaims", ErrPvcCreationFailed),
+		expectedErr: fmt.Errorf("workspace test-workspace-vct: %w for pvc-b9eea16dce: error creating persistentvolumeclaims", ErrPvcCreationFailed),
 	}}
 
 	for _, tc := range testCases {
@@ -591,8 +1185,9 @@ func TestCreateOrUpdateAffinityAssistantsAndPVCs_Failure(t *testing.T) {
 			ctx := t.Context()
 			kubeClientSet := fakek8s.NewSimpleClientset()
 			c := Reconciler{
-				KubeClientSet: kubeClientSet,
-				pvcHandler:    volumeclaim.NewPVCHandler(kubeClientSet, zap.NewExample().Sugar()),
+				KubeClientSet:   kubeClientSet,
+				pvcHandler:      volumeclaim.NewPVCHandler(kubeClientSet, zap.NewExample().Sugar()),
+				configMapLister: newConfigMapLister(),
 			}
 
 			switch tc.failureType {
@@ -626,6 +1221,65 @@ func TestCreateOrUpdateAffinityAssistantsAndPVCs_Failure(t *testing.T) {
 	}
 }
 
+// TestCreateOrUpdateAffinityAssistantsAndPVCs_NoPVCWorkspaces tests that no API call is made for a PipelineRun
+// without PVC-backed workspaces when there is no Affinity Assistant per pipelinerun
+func TestCreateOrUpdateAffinityAssistantsAndPVCs_NoPVCWorkspaces(t *testing.T) {
+	for _, aaBehavior := range []aa.AffinityAssistantBehavior{aa.AffinityAssistantPerWorkspace, aa.AffinityAssistantDisabled} {
+		t.Run(string(aaBehavior), func(t *testing.T) {
+			kubeClientSet := fakek8s.NewSimpleClientset()
+			c := Reconciler{
+				KubeClientSet:   kubeClientSet,
+				pvcHandler:      volumeclaim.NewPVCHandler(kubeClientSet, zap.NewExample().Sugar()),
+				configMapLister: newConfigMapLister(),
+			}
+
+			if err := c.createOrUpdateAffinityAssistantsAndPVCs(t.Context(), testPRWithEmptyDir, aaBehavior); err != nil {
+				t.Fatalf("unexpected error from createOrUpdateAffinityAssistantsAndPVCs: %v", err)
+			}
+			for _, action := range kubeClientSet.Actions() {
+				if action.GetResource().Resource == "statefulsets" {
+					t.Errorf("expected no StatefulSet API call, got %s", action.GetVerb())
+				}
+			}
+			if len(kubeClientSet.Actions()) != 0 {
+				t.Errorf("expected no API call, got %v", kubeClientSet.Actions())
+			}
+		})
+	}
+}
+
+func BenchmarkCreateOrUpdateAffinityAssistantsAndPVCs(b *testing.B) {
+	for _, bc := range []struct {
+		name       string
+		pr         *v1.PipelineRun
+		aaBehavior aa.AffinityAssistantBehavior
+	}{{
+		name:       "emptyDir per workspace",
+		pr:         testPRWithEmptyDir,
+		aaBehavior: aa.AffinityAssistantPerWorkspace,
+	}, {
+		name:       "PersistentVolumeClaim per workspace",
+		pr:         testPRWithPVC,
+		aaBehavior: aa.AffinityAssistantPerWorkspace,
+	}} {
+		b.Run(bc.name, func(b *testing.B) {
+			kubeClientSet := fakek8s.NewSimpleClientset()
+			c := Reconciler{
+				KubeClientSet:   kubeClientSet,
+				pvcHandler:      volumeclaim.NewPVCHandler(kubeClientSet, zap.NewNop().Sugar()),
+				configMapLister: newConfigMapLister(),
+			}
+			ctx := b.Context()
+			b.ReportAllocs()
+			for b.Loop() {
+				if err := c.createOrUpdateAffinityAssistantsAndPVCs(ctx, bc.pr, bc.aaBehavior); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
 // TestCreateOrUpdateAffinityAssistantWhenNodeIsCordoned tests an existing Affinity Assistant can identify the node failure and
 // can migrate the affinity assistant pod to a healthy node so that the existing pipelineRun runs to compleition
 func TestCreateOrUpdateAffinityAssistantWhenNodeIsCordoned(t *testing.T) {
@@ -930,6 +1584,11 @@ func TestOnlySelectPodTemplateFieldsArePropagatedToAffinityAssistant(t *testing.
 						IP:        "1.2.3.4",
 						Hostnames: []string{"localhost"},
 					}},
+					TopologySpreadConstraints: []corev1.TopologySpreadConstraint{{
+						MaxSkew:           1,
+						TopologyKey:       "kubernetes.io/hostname",
+						WhenUnsatisfiable: corev1.ScheduleAnyway,
+					}},
 				},
 			},
 		},
@@ -944,6 +1603,10 @@ func TestOnlySelectPodTemplateFieldsArePropagatedToAffinityAssistant(t *testing.
 	if len(stsWithOverridenTemplateFields.Spec.Template.Spec.HostAliases) != 0 {
 		t.Errorf("expected HostAliases to not be passed from pod template")
 	}
+
+	if len(stsWithOverridenTemplateFields.Spec.Template.Spec.TopologySpreadConstraints) != 0 {
+		t.Errorf("expected TopologySpreadConstraints to not be passed from pod template")
+	}
 }
 
 func TestThatTheAffinityAssistantIsWithoutNodeSelectorAndTolerations(t *testing.T) {
@@ -986,48 +1649,6 @@ func TestThatAffinityAssistantNameIsNoLongerThan53(t *testing.T) {
 	}
 }
 
-func TestSanitizeVolumeName(t *testing.T) {
-	tests := []struct {
-		name     string
-		input    string
-		wantLen  int  // 0 means just check <= 63
-		wantSame bool // expect output == input
-	}{
-		{"short name unchanged", "my-pvc", 6, true},
-		{"exactly 63 chars unchanged", "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", 63, true},
-		{"64 chars gets truncated", "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", 63, false},
-		{"long name from issue 9739", "master-pipeline-700-generic-feature-execution-15943-error-handle-e46a3a1317", 63, false},
-	}
-	for _, tc := range tests {
-		t.Run(tc.name, func(t *testing.T) {
-			got := sanitizeVolumeName(tc.input)
-			if len(got) > 63 {
-				t.Errorf("sanitizeVolumeName(%q) = %q (%d chars), want <= 63", tc.input, got, len(got))
-			}
-			if tc.wantSame && got != tc.input {
-				t.Errorf("sanitizeVolumeName(%q) = %q, want same as input", tc.input, got)
-			}
-			if tc.wantLen > 0 && len(got) != tc.wantLen {
-				t.Errorf("sanitizeVolumeName(%q) length = %d, want %d", tc.input, len(got), tc.wantLen)
-			}
-		})
-	}
-
-	// Verify determinism: same input always produces same output
-	long := "master-pipeline-700-generic-feature-execution-15943-error-handle-e46a3a1317"
-	a := sanitizeVolumeName(long)
-	b := sanitizeVolumeName(long)
-	if a != b {
-		t.Errorf("sanitizeVolumeName is not deterministic: %q != %q", a, b)
-	}
-
-	// Verify uniqueness: different inputs produce different outputs
-	c := sanitizeVolumeName(long + "-different")
-	if a == c {
-		t.Errorf("sanitizeVolumeName collision: %q and %q both produced %q", long, long+"-different", a)
-	}
-}
-
 // TestAffinityAssistantStatefulSet_VolumeNamesNoLongerThan63 tests that volume names
 // and VolumeClaimTemplate names in the Affinity Assistant StatefulSet are no longer than
 // 63 characters. Kubernetes rejects volume names exceeding this limit.
@@ -1148,16 +1769,16 @@ func TestCleanupAffinityAssistants_LongVolumeClaimTemplateNames(t *testing.T) {
 
 	// Compute the expected PVC name the same way creation + K8s StatefulSet would:
 	// 1. GeneratePVCNameFromWorkspaceBinding produces the raw VCT name
-	// 2. sanitizeVolumeName truncates it to fit Kubernetes' 63-char volume name limit
+	// 2. volumeclaim.SanitizeVolumeName truncates it to fit Kubernetes' 63-char volume name limit
 	// 3. K8s appends "-<statefulSetName>-0" to form the actual PVC name
 	rawVCTName := volumeclaim.GeneratePVCNameFromWorkspaceBinding(longClaimName, workspaces[0], *kmeta.NewControllerRef(pr))
-	sanitizedVCTName := sanitizeVolumeName(rawVCTName)
+	sanitizedVCTName := volumeclaim.SanitizeVolumeName(rawVCTName)
 	aaName := GetAffinityAssistantName("", pr.Name)
 	expectedPVCName := fmt.Sprintf("%s-%s-0", sanitizedVCTName, aaName)
 
 	// Verify that the raw name would indeed exceed 63 chars (otherwise this test is pointless)
-	if len(rawVCTName) <= volumeNameMaxLength {
-		t.Fatalf("test setup error: rawVCTName %q is only %d chars, expected > %d", rawVCTName, len(rawVCTName), volumeNameMaxLength)
+	if len(rawVCTName) <= validation.DNS1123LabelMaxLength {
+		t.Fatalf("test setup error: rawVCTName %q is only %d chars, expected > %d", rawVCTName, len(rawVCTName), validation.DNS1123LabelMaxLength)
 	}
 
 	// Verify that getPersistentVolumeClaimNameWithAffinityAssistant produces the same PVC name
@@ -1636,6 +2257,83 @@ func TestThatCleanupIsAvoided(t *testing.T) {
 	}
 }
 
+// TestSweepLeakedPVCs tests that the PVCs of PipelineRuns which no longer exist are deleted, using the
+// names the PVCs are given with and without Affinity Assistants, only if the feature flag is set
+func TestSweepLeakedPVCs(t *testing.T) {
+	existing := &v1.PipelineRun{
+		TypeMeta:   metav1.TypeMeta{Kind: "PipelineRun"},
+		ObjectMeta: metav1.ObjectMeta{Name: "existing", Namespace: "ns", UID: "existing-uid"},
+	}
+	deleted := &v1.PipelineRun{
+		TypeMeta:   metav1.TypeMeta{Kind: "PipelineRun"},
+		ObjectMeta: metav1.ObjectMeta{Name: "deleted", Namespace: "ns", UID: "deleted-uid"},
+	}
+	wb := v1.WorkspaceBinding{
+		Name:                "ws",
+		VolumeClaimTemplate: &corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: "data"}},
+	}
+	pvcFor := func(name string, pr *v1.PipelineRun) *corev1.PersistentVolumeClaim {
+		claim := &corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: pr.Namespace}}
+		volumeclaim.LabelPVCWithOwner(claim, *kmeta.NewControllerRef(pr))
+		return claim
+	}
+	pvcs := []runtime.Object{
+		pvcFor(volumeclaim.GeneratePVCNameFromWorkspaceBinding("data", wb, *kmeta.NewControllerRef(existing)), existing),
+		pvcFor(getPersistentVolumeClaimNameWithAffinityAssistant("", existing.Name, wb, *kmeta.NewControllerRef(existing)), existing),
+		pvcFor(volumeclaim.GeneratePVCNameFromWorkspaceBinding("data", wb, *kmeta.NewControllerRef(deleted)), deleted),
+		pvcFor(getPersistentVolumeClaimNameWithAffinityAssistant("", deleted.Name, wb, *kmeta.NewControllerRef(deleted)), deleted),
+	}
+
+	for _, tc := range []struct {
+		name        string
+		enabled     string
+		wantDeleted []string
+	}{{
+		name:    "feature flag set",
+		enabled: "true",
+		wantDeleted: []string{
+			volumeclaim.GeneratePVCNameFromWorkspaceBinding("data", wb, *kmeta.NewControllerRef(deleted)),
+			getPersistentVolumeClaimNameWithAffinityAssistant("", deleted.Name, wb, *kmeta.NewControllerRef(deleted)),
+		},
+	}, {
+		name:    "feature flag not set",
+		enabled: "false",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			kubeClientSet := fakek8s.NewSimpleClientset(pvcs...)
+			var gotDeleted []string
+			kubeClientSet.CoreV1().(*fake.FakeCoreV1).PrependReactor("delete", "persistentvolumeclaims",
+				func(action testing2.Action) (handled bool, ret runtime.Object, err error) {
+					gotDeleted = append(gotDeleted, action.(testing2.DeleteAction).GetName())
+					return true, nil, nil
+				})
+			indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+			if err := indexer.Add(existing); err != nil {
+				t.Fatal(err)
+			}
+			pvcIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+			for _, pvc := range pvcs {
+				if err := pvcIndexer.Add(pvc); err != nil {
+					t.Fatal(err)
+				}
+			}
+			c := Reconciler{
+				KubeClientSet:     kubeClientSet,
+				pvcHandler:        volumeclaim.NewPVCHandler(kubeClientSet, zap.NewExample().Sugar()),
+				pipelineRunLister: listers.NewPipelineRunLister(indexer),
+				pvcLister:         corev1listers.NewPersistentVolumeClaimLister(pvcIndexer),
+			}
+			ctx := cfgtesting.SetFeatureFlags(t.Context(), t, map[string]string{"enable-leaked-pvc-cleanup": tc.enabled})
+
+			c.sweepLeakedPVCs(ctx, "ns")
+
+			if d := cmp.Diff(tc.wantDeleted, gotDeleted, cmpopts.SortSlices(func(a, b string) bool { return a < b })); d != "" {
+				t.Errorf("unexpected deleted PVCs %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 func TestGetAssistantAffinityMergedWithPodTemplateAffinity(t *testing.T) {
 	labelSelector := &metav1.LabelSelector{
 		MatchLabels: map[string]string{
@@ -1865,8 +2563,9 @@ func seedTestData(d Data) (context.Context, Reconciler, func()) {
 
 	kubeClientSet := fakek8s.NewSimpleClientset()
 	c := Reconciler{
-		KubeClientSet: kubeClientSet,
-		pvcHandler:    volumeclaim.NewPVCHandler(kubeClientSet, zap.NewExample().Sugar()),
+		KubeClientSet:   kubeClientSet,
+		pvcHandler:      volumeclaim.NewPVCHandler(kubeClientSet, zap.NewExample().Sugar()),
+		configMapLister: newConfigMapLister(),
 	}
 	for _, s := range d.StatefulSets {
 		c.KubeClientSet.AppsV1().StatefulSets(s.Namespace).Create(ctx, s, metav1.CreateOptions{})
@@ -2130,3 +2829,12 @@ func TestAffinityAssistantServiceAccountPriorityOrder(t *testing.T) {
 		})
 	}
 }
+
+// newConfigMapLister returns a lister of the given ConfigMaps.
+func newConfigMapLister(cms ...*corev1.ConfigMap) corev1listers.ConfigMapLister {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	for _, cm := range cms {
+		_ = indexer.Add(cm)
+	}
+	return corev1listers.NewConfigMapLister(indexer)
+}
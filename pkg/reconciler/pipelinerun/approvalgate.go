@@ -0,0 +1,129 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipelinerun
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/reconciler/pipelinerun/resources"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/controller"
+)
+
+// startApprovalGate marks the approval gate of rpt as waiting for the decision of one of its approvers.
+func (c *Reconciler) startApprovalGate(ctx context.Context, pr *v1.PipelineRun, rpt *resources.ResolvedPipelineTask) {
+	rpt.ApprovalGateStatus = &v1.ApprovalGateStatus{
+		PipelineTaskName: rpt.PipelineTask.Name,
+		Approvers:        rpt.PipelineTask.ApprovalGate.Approvers,
+		Reason:           v1.ApprovalGateReasonWaiting,
+		StartTime:        metav1.NewTime(c.Clock.Now()),
+	}
+	controller.GetEventRecorder(ctx).Eventf(pr, corev1.EventTypeNormal, "WaitingForApproval",
+		"PipelineTask %q is waiting for the approval of one of: %s", rpt.PipelineTask.Name, strings.Join(rpt.ApprovalGateStatus.Approvers, ", "))
+}
+
+// decideApprovalGates completes the approval gates waiting for a decision: a gate is approved or
+// rejected by the first approval added for it to the status of the PipelineRun, times out once its
+// own timeout elapsed, and is cancelled when the PipelineRun is gracefully cancelled or stopped, or
+// when it timed out.
+func (c *Reconciler) decideApprovalGates(ctx context.Context, pr *v1.PipelineRun, facts *resources.PipelineRunFacts) {
+	recorder := controller.GetEventRecorder(ctx)
+	now := c.Clock.Now()
+	for _, rpt := range facts.State {
+		if !rpt.IsApprovalGate() || rpt.ApprovalGateStatus == nil || !rpt.ApprovalGateStatus.IsWaiting() {
+			continue
+		}
+		gate := rpt.ApprovalGateStatus
+		approval := firstApproval(pr.Status.Approvals, rpt.PipelineTask.Name)
+		deadline := gate.Deadline(rpt.PipelineTask.ApprovalGate)
+		switch {
+		case approval != nil && approval.Decision == v1.ApprovalDecisionApprove:
+			gate.Reason = v1.ApprovalGateReasonApproved
+			recorder.Eventf(pr, corev1.EventTypeNormal, "ApprovalGateApproved",
+				"PipelineTask %q was approved by %s", rpt.PipelineTask.Name, approval.Approver)
+		case approval != nil && approval.Decision == v1.ApprovalDecisionReject:
+			gate.Reason = v1.ApprovalGateReasonRejected
+			recorder.Eventf(pr, corev1.EventTypeWarning, "ApprovalGateRejected",
+				"PipelineTask %q was rejected by %s", rpt.PipelineTask.Name, approval.Approver)
+		case deadline != nil && !now.Before(*deadline):
+			gate.Reason = v1.ApprovalGateReasonTimedOut
+			recorder.Eventf(pr, corev1.EventTypeWarning, "ApprovalGateTimedOut",
+				"PipelineTask %q wasn't approved within %s", rpt.PipelineTask.Name, rpt.PipelineTask.ApprovalGate.Timeout.Duration)
+		case pr.IsGracefullyCancelled() || pr.IsGracefullyStopped() || pr.HasTimedOut(ctx, c.Clock) || pr.HaveTasksTimedOut(ctx, c.Clock):
+			gate.Reason = v1.ApprovalGateReasonCancelled
+		default:
+			continue
+		}
+		gate.CompletionTime = &metav1.Time{Time: now}
+	}
+	facts.ResetSkippedCache()
+}
+
+// cancelApprovalGates cancels the approval gates of the cancelled PipelineRun which are still waiting
+// for a decision.
+func cancelApprovalGates(pr *v1.PipelineRun, completionTime *metav1.Time) {
+	for i := range pr.Status.ApprovalGates {
+		if gate := &pr.Status.ApprovalGates[i]; gate.IsWaiting() {
+			gate.Reason = v1.ApprovalGateReasonCancelled
+			gate.CompletionTime = completionTime
+		}
+	}
+}
+
+func firstApproval(approvals []v1.Approval, pipelineTaskName string) *v1.Approval {
+	for i := range approvals {
+		if approvals[i].PipelineTaskName == pipelineTaskName {
+			return &approvals[i]
+		}
+	}
+	return nil
+}
+
+// approvalGatesWaitTime returns how long until the first of the approval gates waiting for a decision
+// times out, if any of them has a timeout.
+func approvalGatesWaitTime(pr *v1.PipelineRun, now time.Time) (time.Duration, bool) {
+	if pr.Status.PipelineSpec == nil {
+		return 0, false
+	}
+	gates := make(map[string]*v1.ApprovalGate)
+	for _, pt := range pr.Status.PipelineSpec.Tasks {
+		if pt.ApprovalGate != nil {
+			gates[pt.Name] = pt.ApprovalGate
+		}
+	}
+
+	var waitTime time.Duration
+	found := false
+	for i := range pr.Status.ApprovalGates {
+		gate := &pr.Status.ApprovalGates[i]
+		if !gate.IsWaiting() {
+			continue
+		}
+		deadline := gate.Deadline(gates[gate.PipelineTaskName])
+		if deadline == nil {
+			continue
+		}
+		if wait := deadline.Sub(now); !found || wait < waitTime {
+			waitTime, found = wait, true
+		}
+	}
+	return waitTime, found
+}
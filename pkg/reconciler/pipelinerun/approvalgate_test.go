@@ -0,0 +1,81 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipelinerun
+
+import (
+	"testing"
+	"time"
+
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestApprovalGatesWaitTime(t *testing.T) {
+	spec := &v1.PipelineSpec{Tasks: []v1.PipelineTask{{
+		Name:         "approve-staging",
+		ApprovalGate: &v1.ApprovalGate{Approvers: []string{"alice"}, Timeout: &metav1.Duration{Duration: time.Hour}},
+	}, {
+		Name:         "approve-prod",
+		ApprovalGate: &v1.ApprovalGate{Approvers: []string{"bob"}, Timeout: &metav1.Duration{Duration: 2 * time.Hour}},
+	}, {
+		Name:         "approve-docs",
+		ApprovalGate: &v1.ApprovalGate{Approvers: []string{"carol"}},
+	}}}
+	gate := func(name string, reason v1.ApprovalGateReason, started time.Duration) v1.ApprovalGateStatus {
+		return v1.ApprovalGateStatus{PipelineTaskName: name, Reason: reason, StartTime: metav1.NewTime(now.Add(-started))}
+	}
+
+	for _, tc := range []struct {
+		name     string
+		spec     *v1.PipelineSpec
+		gates    []v1.ApprovalGateStatus
+		wantWait time.Duration
+		wantOK   bool
+	}{{
+		name:  "no pipeline spec",
+		gates: []v1.ApprovalGateStatus{gate("approve-staging", v1.ApprovalGateReasonWaiting, 0)},
+	}, {
+		name:  "gate without timeout",
+		spec:  spec,
+		gates: []v1.ApprovalGateStatus{gate("approve-docs", v1.ApprovalGateReasonWaiting, 0)},
+	}, {
+		name:  "gate not waiting",
+		spec:  spec,
+		gates: []v1.ApprovalGateStatus{gate("approve-staging", v1.ApprovalGateReasonApproved, 0)},
+	}, {
+		name: "first of the waiting gates to time out",
+		spec: spec,
+		gates: []v1.ApprovalGateStatus{
+			gate("approve-prod", v1.ApprovalGateReasonWaiting, 90*time.Minute),
+			gate("approve-staging", v1.ApprovalGateReasonWaiting, 15*time.Minute),
+			gate("approve-docs", v1.ApprovalGateReasonWaiting, 0),
+		},
+		wantWait: 30 * time.Minute,
+		wantOK:   true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			pr := &v1.PipelineRun{Status: v1.PipelineRunStatus{PipelineRunStatusFields: v1.PipelineRunStatusFields{
+				PipelineSpec:  tc.spec,
+				ApprovalGates: tc.gates,
+			}}}
+			wait, ok := approvalGatesWaitTime(pr, now)
+			if wait != tc.wantWait || ok != tc.wantOK {
+				t.Errorf("approvalGatesWaitTime() = %s, %t, want %s, %t", wait, ok, tc.wantWait, tc.wantOK)
+			}
+		})
+	}
+}
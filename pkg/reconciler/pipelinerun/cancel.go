@@ -130,6 +130,7 @@ func cancelPipelineRun(ctx context.Context, logger *zap.SugaredLogger, pr *v1.Pi
 		})
 		// update pr completed time
 		pr.Status.CompletionTime = &metav1.Time{Time: time.Now()}
+		cancelApprovalGates(pr, pr.Status.CompletionTime)
 	} else {
 		e := strings.Join(errs, "\n")
 		// Indicate that we failed to cancel the PipelineRun
@@ -31,16 +31,20 @@ import (
 	resolutionclient "github.com/tektoncd/pipeline/pkg/client/resolution/injection/client"
 	resolutioninformer "github.com/tektoncd/pipeline/pkg/client/resolution/injection/informers/resolution/v1beta1/resolutionrequest"
 	"github.com/tektoncd/pipeline/pkg/pipelinerunmetrics"
+	pipelinePod "github.com/tektoncd/pipeline/pkg/pod"
+	"github.com/tektoncd/pipeline/pkg/reconciler/namespaceconfigmap"
 	"github.com/tektoncd/pipeline/pkg/reconciler/volumeclaim"
 	resolution "github.com/tektoncd/pipeline/pkg/remoteresolution/resource"
 	"github.com/tektoncd/pipeline/pkg/tracing"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/utils/clock"
 	kubeclient "knative.dev/pkg/client/injection/kube/client"
+	filteredpvcinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/persistentvolumeclaim/filtered"
 	secretinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/secret"
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/controller"
 	"knative.dev/pkg/logging"
+	pkgreconciler "knative.dev/pkg/reconciler"
 )
 
 const (
@@ -71,6 +75,7 @@ func NewController(opts *pipeline.Options, clock clock.PassiveClock) func(contex
 		pipelineRunInformer := pipelineruninformer.Get(ctx)
 		resolutionInformer := resolutioninformer.Get(ctx)
 		verificationpolicyInformer := verificationpolicyinformer.Get(ctx)
+		pvcInformer := filteredpvcinformer.Get(ctx, v1.ManagedByLabelKey)
 		secretinformer := secretinformer.Get(ctx)
 		tracerProvider := tracing.New(TracerProviderName, logger.Named("tracing"))
 		pipelinerunmetricsRecorder := pipelinerunmetrics.Get(ctx)
@@ -90,10 +95,14 @@ func NewController(opts *pipeline.Options, clock clock.PassiveClock) func(contex
 			taskRunLister:            taskRunInformer.Lister(),
 			customRunLister:          customRunInformer.Lister(),
 			verificationPolicyLister: verificationpolicyInformer.Lister(),
+			configMapLister:          namespaceconfigmap.Get(ctx, pipelinePod.NamespaceDefaultsConfigMapName).Lister(),
+			pvcLister:                pvcInformer.Lister(),
 			metrics:                  pipelinerunmetricsRecorder,
 			pvcHandler:               volumeclaim.NewPVCHandler(kubeclientset, logger),
 			resolutionRequester:      resolution.NewCRDRequester(resolutionclient.Get(ctx), resolutionInformer.Lister()),
 			tracerProvider:           tracerProvider,
+			statusUpdates:            newStatusUpdateLimiter(),
+			namespaceFeatureFlags:    config.NewNamespaceFeatureFlags(namespaceconfigmap.Get(ctx, config.NamespaceFeatureFlagsConfigMapName).Lister()),
 		}
 		impl := pipelinerunreconciler.NewImpl(ctx, c, func(impl *controller.Impl) controller.Options {
 			return controller.Options{
@@ -135,6 +144,15 @@ func NewController(opts *pipeline.Options, clock clock.PassiveClock) func(contex
 			logging.FromContext(ctx).Panicf("Couldn't register CustomRun informer event handler: %w", err)
 		}
 
+		// PVCs created from volumeClaimTemplates are labeled with the name of their PipelineRun, also when
+		// they are created by the StatefulSet of an Affinity Assistant rather than owned by the PipelineRun.
+		if _, err := pvcInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+			FilterFunc: pkgreconciler.LabelExistsFilterFunc(pipeline.PipelineRunLabelKey),
+			Handler:    controller.HandleAll(impl.EnqueueLabelOfNamespaceScopedResource("", pipeline.PipelineRunLabelKey)),
+		}); err != nil {
+			logging.FromContext(ctx).Panicf("Couldn't register PersistentVolumeClaim informer event handler: %w", err)
+		}
+
 		if _, err := resolutionInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
 			FilterFunc: controller.FilterController(&v1.PipelineRun{}),
 			Handler:    controller.HandleAll(impl.EnqueueControllerOf),
@@ -145,3 +163,39 @@ func NewController(opts *pipeline.Options, clock clock.PassiveClock) func(contex
 		return impl
 	}
 }
+
+// NewFinalStatusController instantiates the controller.Impl recording the final status of the PipelineRuns
+// deleted before they completed while they hold the FinalStatusFinalizer, and then removing the finalizer.
+func NewFinalStatusController(clock clock.PassiveClock) func(context.Context, configmap.Watcher) *controller.Impl {
+	return func(ctx context.Context, cmw configmap.Watcher) *controller.Impl {
+		logger := logging.FromContext(ctx)
+		pipelineRunInformer := pipelineruninformer.Get(ctx)
+		configStore := config.NewStore(logger.Named("config-store"))
+		configStore.WatchConfigs(cmw)
+
+		r := &finalStatusReconciler{c: &Reconciler{
+			PipelineClientSet: pipelineclient.Get(ctx),
+			Clock:             clock,
+			metrics:           pipelinerunmetrics.Get(ctx),
+			tracerProvider:    tracing.New(TracerProviderName, logger.Named("tracing")),
+		}}
+		impl := pipelinerunreconciler.NewImpl(ctx, r, func(impl *controller.Impl) controller.Options {
+			return controller.Options{
+				AgentName:         pipeline.PipelineRunControllerName,
+				ConfigStore:       configStore,
+				FinalizerName:     pipeline.FinalStatusFinalizer,
+				SkipStatusUpdates: true,
+				PromoteFilterFunc: deletedWithFinalStatusFinalizer,
+			}
+		})
+
+		if _, err := pipelineRunInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+			FilterFunc: deletedWithFinalStatusFinalizer,
+			Handler:    controller.HandleAll(impl.Enqueue),
+		}); err != nil {
+			logging.FromContext(ctx).Panicf("Couldn't register PipelineRun informer event handler: %w", err)
+		}
+
+		return impl
+	}
+}
@@ -24,6 +24,7 @@ import (
 	"maps"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"sort"
 	"strings"
 	"time"
@@ -45,6 +46,7 @@ import (
 	resolutionutil "github.com/tektoncd/pipeline/pkg/internal/resolution"
 	"github.com/tektoncd/pipeline/pkg/names"
 	"github.com/tektoncd/pipeline/pkg/pipelinerunmetrics"
+	pipelinePod "github.com/tektoncd/pipeline/pkg/pod"
 	tknreconciler "github.com/tektoncd/pipeline/pkg/reconciler"
 	"github.com/tektoncd/pipeline/pkg/reconciler/apiserver"
 	"github.com/tektoncd/pipeline/pkg/reconciler/events"
@@ -73,6 +75,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/kubernetes"
+	corev1listers "k8s.io/client-go/listers/core/v1"
 	"k8s.io/utils/clock"
 	"knative.dev/pkg/apis"
 	"knative.dev/pkg/controller"
@@ -167,10 +170,14 @@ type Reconciler struct {
 	taskRunLister            listers.TaskRunLister
 	customRunLister          beta1listers.CustomRunLister
 	verificationPolicyLister alpha1listers.VerificationPolicyLister
+	configMapLister          corev1listers.ConfigMapLister
+	pvcLister                corev1listers.PersistentVolumeClaimLister
 	metrics                  *pipelinerunmetrics.Recorder
 	pvcHandler               volumeclaim.PvcHandler
 	resolutionRequester      resolution.Requester
 	tracerProvider           trace.TracerProvider
+	statusUpdates            *statusUpdateLimiter
+	namespaceFeatureFlags    *config.NamespaceFeatureFlags
 }
 
 var (
@@ -196,11 +203,30 @@ func (c *Reconciler) ReconcileKind(ctx context.Context, pr *v1.PipelineRun) pkgr
 		ctx = logging.WithLogger(ctx, logger)
 	}
 
+	// Override the feature flags with the ones set in the namespace of the PipelineRun, if any.
+	ctx, nsFlagsErr := c.namespaceFeatureFlags.ToContext(ctx, pr.Namespace)
+	if nsFlagsErr != nil {
+		logger.Errorf("Ignoring the feature flags of namespace %s for pipelinerun %s: %v", pr.Namespace, pr.Name, nsFlagsErr)
+	}
+
+	// Time the phases of the reconcile, which are recorded and logged when the reconcile is slow.
+	ctx, phases := tknreconciler.WithReconcilePhases(ctx, c.Clock)
+	defer phases.Record(ctx, c.metrics)
+
 	// Read the initial condition
 	before := pr.Status.GetCondition(apis.ConditionSucceeded)
 
 	// Record the duration and count after the reconcile cycle.
 	defer c.durationAndCountMetrics(ctx, pr, before)
+	// Record the spans of the PipelineRun once it completes.
+	defer c.recordRunSpans(ctx, pr, before)
+
+	dedupeWorkspaceBindings(ctx, pr)
+
+	// Keep the PipelineRun, if it is deleted before it completes, until its final status is recorded.
+	if err := c.syncFinalStatusFinalizer(ctx, pr); err != nil {
+		return err
+	}
 
 	// Check if we are failing to mark this as timed out for a while. If we are, mark immediately and finish the
 	// reconcile. We are assuming here that if the PipelineRun has timed out for a long time, it had time to run
@@ -225,10 +251,25 @@ func (c *Reconciler) ReconcileKind(ctx context.Context, pr *v1.PipelineRun) pkgr
 		if err != nil {
 			logger.Errorf("Failed to delete StatefulSet or PVC for PipelineRun %s: %v", pr.Name, err)
 		}
-		return c.finishReconcileUpdateEmitEvents(ctx, pr, before, err)
+		if err := c.finishReconcileUpdateEmitEvents(ctx, pr, before, err); err != nil {
+			return err
+		}
+		// Delete the PipelineRun once its ttlSecondsAfterFinished elapsed, if any.
+		return c.deleteAfterTTL(ctx, pr)
 	}
 
 	if !pr.HasStarted() && !pr.IsPending() {
+		// Delay the start of PipelineRuns created at the same time, before any resolution or
+		// TaskRun creation happens. The start time is only set once the delay is over so that
+		// it doesn't count towards the timeouts of the PipelineRun.
+		if startTime, delayed := delayedStartTime(ctx, pr, c.Clock); delayed {
+			pr.Status.MarkRunning(v1.PipelineRunReasonStartDelayed.String(), fmt.Sprintf("PipelineRun %q start is delayed until %s", pr.Name, startTime.UTC().Format(time.RFC3339)))
+			if err := c.finishReconcileUpdateEmitEvents(ctx, pr, before, nil); err != nil {
+				return err
+			}
+			return controller.NewRequeueAfter(startTime.Sub(c.Clock.Now()))
+		}
+
 		pr.Status.InitializeConditions(c.Clock)
 		// In case node time was not synchronized, when controller has been scheduled to other nodes.
 		if pr.Status.StartTime.Sub(pr.CreationTimestamp.Time) < 0 {
@@ -255,6 +296,11 @@ func (c *Reconciler) ReconcileKind(ctx context.Context, pr *v1.PipelineRun) pkgr
 	}
 	getPipelineFunc := resources.GetPipelineFunc(ctx, c.KubeClientSet, c.PipelineClientSet, c.resolutionRequester, pr, vp)
 
+	if err := c.recordCoschedule(ctx, pr); err != nil {
+		logger.Errorf("Failed to record the coschedule of pipelinerun %s: %v", pr.Name, err)
+		return c.finishReconcileUpdateEmitEvents(ctx, pr, before, err)
+	}
+
 	if err := propagatePipelineNameLabelToPipelineRun(pr); err != nil {
 		logger.Errorf("Failed to propagate pipeline name label to pipelinerun %s: %v", pr.Name, err)
 		return c.finishReconcileUpdateEmitEvents(ctx, pr, before, err)
@@ -282,17 +328,46 @@ func (c *Reconciler) ReconcileKind(ctx context.Context, pr *v1.PipelineRun) pkgr
 
 	// If the PipelineRun just transitioned to done during this reconcile,
 	// perform cleanup eagerly so subsequent reconciles find nothing to do.
+	// This is also when the PVCs left behind by other PipelineRuns of the
+	// namespace are swept, so that the sweep doesn't run on every resync.
 	if pr.IsDone() {
 		if cleanupErr := c.cleanupAffinityAssistantsAndPVCs(ctx, pr); cleanupErr != nil {
 			logger.Errorf("Failed to delete StatefulSet or PVC for PipelineRun %s: %v", pr.Name, cleanupErr)
 			err = errors.Join(err, cleanupErr)
 		}
+		c.sweepLeakedPVCs(ctx, pr.Namespace)
+	}
+
+	// Coalesce the status updates which only report the progress of the children, so that the
+	// completions of the many children of large matrices don't each update the status.
+	var coalesceWait time.Duration
+	coalesced := false
+	if err == nil {
+		if original, getErr := c.pipelineRunLister.PipelineRuns(pr.Namespace).Get(pr.Name); getErr == nil {
+			coalesceWait, coalesced = c.coalesceStatusUpdate(ctx, original, pr)
+		}
 	}
 
 	if err = c.finishReconcileUpdateEmitEvents(ctx, pr, before, err); err != nil {
 		return err
 	}
+	if coalesced {
+		return controller.NewRequeueAfter(coalesceWait)
+	}
 
+	err = c.requeueAfterTimeout(ctx, pr)
+	// Snooze this resource until the first of its approval gates times out, if it's earlier.
+	if waitTime, ok := approvalGatesWaitTime(pr, c.Clock.Now()); ok && !pr.IsDone() {
+		if isRequeue, requeueAfter := controller.IsRequeueKey(err); !isRequeue || waitTime < requeueAfter {
+			return controller.NewRequeueAfter(waitTime)
+		}
+	}
+	return err
+}
+
+// requeueAfterTimeout returns an error requeuing the PipelineRun once the timeout that applies to it
+// elapsed, if any.
+func (c *Reconciler) requeueAfterTimeout(ctx context.Context, pr *v1.PipelineRun) error {
 	if pr.Status.StartTime != nil {
 		// Compute the time since the pipeline started.
 		elapsed := c.Clock.Since(pr.Status.StartTime.Time)
@@ -356,6 +431,7 @@ func (c *Reconciler) durationAndCountMetrics(ctx context.Context, pr *v1.Pipelin
 func (c *Reconciler) finishReconcileUpdateEmitEvents(ctx context.Context, pr *v1.PipelineRun, beforeCondition *apis.Condition, previousError error) error {
 	ctx, span := c.tracerProvider.Tracer(TracerName).Start(ctx, "finishReconcileUpdateEmitEvents")
 	defer span.End()
+	defer tknreconciler.StartReconcilePhase(ctx, tknreconciler.ReconcilePhaseStatusComputation)()
 	logger := logging.FromContext(ctx)
 
 	afterCondition := pr.Status.GetCondition(apis.ConditionSucceeded)
@@ -394,6 +470,7 @@ func (c *Reconciler) resolvePipelineState(
 	pst resources.PipelineRunState,
 ) (resources.PipelineRunState, error) {
 	ctx, span := c.tracerProvider.Tracer(TracerName).Start(ctx, "resolvePipelineState")
+	defer tknreconciler.StartReconcilePhase(ctx, tknreconciler.ReconcilePhaseResolution)()
 	defer span.End()
 
 	// List VerificationPolicies once per reconcile for trusted resources (used by all pipeline tasks).
@@ -503,15 +580,22 @@ func (c *Reconciler) resolvePipelineState(
 			}
 			var nfErr *resources.TaskNotFoundError
 			var pnfErr *resources.PipelineNotFoundError
-			if errors.As(err, &nfErr) {
+			switch {
+			case errors.As(err, &nfErr) && len(pr.Status.ChildReferences) > 0:
+				// All the Tasks of the Pipeline are resolved before any of its TaskRuns is created, so the Task
+				// resolved when the PipelineRun started and was deleted since then: it can't be retrieved again.
+				pr.Status.MarkFailed(v1.PipelineRunReasonCouldntGetTask.String(),
+					"Task %q referenced by pipeline task %q of PipelineRun %s/%s can't be retrieved anymore, it may have been deleted since the PipelineRun started: %s",
+					nfErr.Name, pipelineTask.Name, pr.Namespace, pr.Name, nfErr.Err)
+			case errors.As(err, &nfErr):
 				pr.Status.MarkFailed(v1.PipelineRunReasonCouldntGetTask.String(),
 					"Pipeline %s/%s can't be Run; it contains Tasks that don't exist: %s",
 					pipelineMeta.Namespace, pipelineMeta.Name, nfErr)
-			} else if errors.As(err, &pnfErr) {
+			case errors.As(err, &pnfErr):
 				pr.Status.MarkFailed(v1.PipelineRunReasonCouldntGetPipeline.String(),
 					"Pipeline %s/%s can't be Run; it contains child Pipelines that don't exist: %s",
 					pipelineMeta.Namespace, pipelineMeta.Name, pnfErr)
-			} else {
+			default:
 				pr.Status.MarkFailed(v1.PipelineRunReasonFailedValidation.String(),
 					"PipelineRun %s/%s can't be Run; couldn't resolve all references: %s",
 					pipelineMeta.Namespace, pr.Name, pipelineErrors.WrapUserError(err))
@@ -553,7 +637,9 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1.PipelineRun, getPipel
 		return nil
 	}
 
+	endResolution := tknreconciler.StartReconcilePhase(ctx, tknreconciler.ReconcilePhaseResolution)
 	pipelineMeta, pipelineSpec, err := rprp.GetPipelineData(ctx, pr, getPipelineFunc)
+	endResolution()
 	switch {
 	case errors.Is(err, remote.ErrRequestInProgress):
 		message := fmt.Sprintf("PipelineRun %s/%s awaiting remote resource", pr.Namespace, pr.Name)
@@ -589,6 +675,8 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1.PipelineRun, getPipel
 		}
 	}
 
+	endValidation := tknreconciler.StartReconcilePhase(ctx, tknreconciler.ReconcilePhaseValidation)
+	defer endValidation()
 	d, err := dag.Build(v1.PipelineTaskList(pipelineSpec.Tasks), v1.PipelineTaskList(pipelineSpec.Tasks).Deps())
 	if err != nil {
 		// This Run has failed, so we need to mark it as failed and stop reconciling it
@@ -611,7 +699,8 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1.PipelineRun, getPipel
 		return controller.NewPermanentError(err)
 	}
 
-	if err := pipelineSpec.Validate(ctx); err != nil {
+	// Warnings, e.g. about shadowed result names, don't prevent the PipelineRun from running.
+	if err := pipelineSpec.Validate(ctx).Filter(apis.ErrorLevel); err != nil {
 		// This Run has failed, so we need to mark it as failed and stop reconciling it
 		pr.Status.MarkFailed(v1.PipelineRunReasonFailedValidation.String(),
 			"Pipeline %s/%s can't be Run; it has an invalid spec: %s",
@@ -619,8 +708,28 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1.PipelineRun, getPipel
 		return controller.NewPermanentError(err)
 	}
 
+	// Take the values of the params that refer to results of other PipelineRuns
+	params, err := c.resolveRunRefParams(pr)
+	if err != nil {
+		if !errors.Is(err, errRunResultUnavailable) {
+			return err
+		}
+		logger.Errorf("PipelineRun %s/%s params can't be resolved: %v", pr.Namespace, pr.Name, err)
+		pr.Status.MarkFailed(v1.PipelineRunReasonReferencedRunResultUnavailable.String(),
+			"PipelineRun %s/%s can't be run: %s",
+			pr.Namespace, pr.Name, err)
+		return controller.NewPermanentError(err)
+	}
+	// paramsPR is the PipelineRun the params are validated and applied from: a copy of it, with the
+	// values taken from the results of other PipelineRuns, when it has some, not to rewrite its spec.
+	paramsPR := pr
+	if slices.ContainsFunc(pr.Spec.Params, func(p v1.Param) bool { return p.RunRef != nil }) {
+		paramsPR = pr.DeepCopy()
+		paramsPR.Spec.Params = params
+	}
+
 	// Ensure that the PipelineRun provides all the parameters required by the Pipeline
-	if err := resources.ValidateRequiredParametersProvided(&pipelineSpec.Params, &pr.Spec.Params); err != nil {
+	if err := resources.ValidateRequiredParametersProvided(pipelineSpec, &params); err != nil {
 		// This Run has failed, so we need to mark it as failed and stop reconciling it
 		pr.Status.MarkFailed(v1.PipelineRunReasonParameterMissing.String(),
 			"PipelineRun %s/%s is missing some parameters required by Pipeline %s/%s: %s",
@@ -630,7 +739,7 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1.PipelineRun, getPipel
 
 	// Ensure that the parameters from the PipelineRun are overriding Pipeline parameters with the same type.
 	// Weird substitution issues can occur if this is not validated (ApplyParameters() does not verify type).
-	if err = resources.ValidateParamTypesMatching(pipelineSpec, pr); err != nil {
+	if err = resources.ValidateParamTypesMatching(pipelineSpec, paramsPR); err != nil {
 		// This Run has failed, so we need to mark it as failed and stop reconciling it
 		pr.Status.MarkFailed(v1.PipelineRunReasonParameterTypeMismatch.String(),
 			"PipelineRun %s/%s parameters have mismatching types with Pipeline %s/%s's parameters: %s",
@@ -639,7 +748,7 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1.PipelineRun, getPipel
 	}
 
 	if config.FromContextOrDefaults(ctx).FeatureFlags.EnableParamEnum {
-		if err := taskrun.ValidateEnumParam(ctx, pr.Spec.Params, pipelineSpec.Params); err != nil {
+		if err := taskrun.ValidateEnumParam(ctx, params, pipelineSpec.Params); err != nil {
 			logger.Errorf("PipelineRun %q Param Enum validation failed: %v", pr.Name, err)
 			pr.Status.MarkFailed(v1.PipelineRunReasonInvalidParamValue.String(),
 				"PipelineRun %s/%s parameters have invalid value: %s",
@@ -648,8 +757,16 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1.PipelineRun, getPipel
 		}
 	}
 
+	if err := taskrun.ValidateParamConstraints(params, pipelineSpec.Params); err != nil {
+		logger.Errorf("PipelineRun %q Param constraints validation failed: %v", pr.Name, err)
+		pr.Status.MarkFailed(v1.PipelineRunReasonInvalidParamValue.String(),
+			"PipelineRun %s/%s parameters have invalid value: %s",
+			pr.Namespace, pr.Name, err)
+		return controller.NewPermanentError(err)
+	}
+
 	// Ensure that the keys of an object param declared in PipelineSpec are not missed in the PipelineRunSpec
-	if err = resources.ValidateObjectParamRequiredKeys(pipelineSpec.Params, pr.Spec.Params); err != nil {
+	if err = resources.ValidateObjectParamRequiredKeys(pipelineSpec.Params, params); err != nil {
 		// This Run has failed, so we need to mark it as failed and stop reconciling it
 		pr.Status.MarkFailed(v1.PipelineRunReasonObjectParameterMissKeys.String(),
 			"PipelineRun %s/%s parameters is missing object keys required by Pipeline %s/%s's parameters: %s",
@@ -658,7 +775,7 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1.PipelineRun, getPipel
 	}
 
 	// Ensure that the array reference is not out of bound
-	if err := resources.ValidateParamArrayIndex(pipelineSpec, pr.Spec.Params); err != nil {
+	if err := resources.ValidateParamArrayIndex(pipelineSpec, params); err != nil {
 		// This Run has failed, so we need to mark it as failed and stop reconciling it
 		pr.Status.MarkFailed(v1.PipelineRunReasonParamArrayIndexingInvalid.String(),
 			"PipelineRun %s/%s failed validation: failed to validate Pipeline %s/%s's parameter which has an invalid index while referring to an array: %s",
@@ -682,7 +799,16 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1.PipelineRun, getPipel
 		return controller.NewPermanentError(err)
 	}
 
-	resources.ApplyParametersToWorkspaceBindings(pr)
+	// Ensure that the helper image sets selected by the PipelineRun and its taskRunSpecs are configured.
+	if err := c.validateHelperImageSets(ctx, pr); err != nil {
+		pr.Status.MarkFailed(v1.PipelineRunReasonFailedValidation.String(),
+			"PipelineRun %s/%s can't be Run: %s",
+			pr.Namespace, pr.Name, pipelineErrors.WrapUserError(err))
+		return controller.NewPermanentError(err)
+	}
+
+	resources.ApplyParametersToWorkspaceBindings(paramsPR)
+	pr.Spec.Workspaces = paramsPR.Spec.Workspaces
 	// Make a deep copy of the Pipeline and its Tasks before value substitution.
 	// This is used to find referenced pipeline-level params at each PipelineTask when validate param enum subset requirement
 	originalPipeline := pipelineSpec.DeepCopy()
@@ -690,7 +816,7 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1.PipelineRun, getPipel
 	originalTasks = append(originalTasks, originalPipeline.Finally...)
 
 	// Apply parameter substitution from the PipelineRun
-	pipelineSpec, err = resources.ApplyParameters(pipelineSpec, pr)
+	pipelineSpec, err = resources.ApplyParameters(pipelineSpec, paramsPR)
 	if err != nil {
 		logger.Errorf("Failed to apply parameters to pipeline %q: %v", pipelineMeta.Name, err)
 		pr.Status.MarkFailed(v1.PipelineRunReasonFailedValidation.String(),
@@ -711,6 +837,7 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1.PipelineRun, getPipel
 			pipelineMeta.Namespace, pipelineMeta.Name, pipelineErrors.WrapUserError(err))
 		return controller.NewPermanentError(err)
 	}
+	endValidation()
 
 	// pipelineRunState holds a list of pipeline tasks after fetching their resolved Task specs.
 	// pipelineRunState also holds a taskRun for each pipeline task after the taskRun is created
@@ -780,6 +907,7 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1.PipelineRun, getPipel
 		TimeoutsState: resources.PipelineRunTimeoutsState{
 			Clock: c.Clock,
 		},
+		Logger: logger.With(zap.String("pipelinerun", pr.Name)),
 	}
 	if pr.Status.StartTime != nil {
 		pipelineRunFacts.TimeoutsState.StartTime = &pr.Status.StartTime.Time
@@ -799,9 +927,11 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1.PipelineRun, getPipel
 		pipelineRunFacts.TimeoutsState.PipelineTimeout = &pipelineTimeout
 	}
 
+	endValidation = tknreconciler.StartReconcilePhase(ctx, tknreconciler.ReconcilePhaseValidation)
+	defer endValidation()
 	for i, rpt := range pipelineRunFacts.State {
 		// Task?
-		if !rpt.IsCustomTask() && !rpt.IsChildPipeline() {
+		if !rpt.IsCustomTask() && !rpt.IsChildPipeline() && !rpt.IsApprovalGate() {
 			err := taskrun.ValidateResolvedTask(ctx, rpt.PipelineTask.Params, rpt.PipelineTask.Matrix, rpt.ResolvedTask)
 			if err != nil {
 				logger.Errorf("Failed to validate pipelinerun %s with error %v", pr.Name, err)
@@ -822,6 +952,7 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1.PipelineRun, getPipel
 			}
 		}
 	}
+	endValidation()
 
 	// Evaluate the CEL of PipelineTask after the variable substitutions and validations.
 	for _, rpt := range pipelineRunFacts.State {
@@ -832,6 +963,9 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1.PipelineRun, getPipel
 				"Error evaluating CEL %s: %v", pr.Name, pipelineErrors.WrapUserError(err))
 			return controller.NewPermanentError(err)
 		}
+		if len(rpt.EvaluatedCEL) > 0 {
+			tknreconciler.TrackFeatureFlagUsage(ctx, pr.Status.Provenance, c.metrics, pr.Namespace, config.EnableCELInWhenExpression)
+		}
 	}
 
 	// check if pipeline run is gracefully cancelled and there are active pipeline task runs, which require cancelling
@@ -866,7 +1000,7 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1.PipelineRun, getPipel
 			return controller.NewPermanentError(err)
 		}
 
-		aaBehavior, err := affinityassistant.GetAffinityAssistantBehavior(ctx)
+		aaBehavior, err := affinityassistant.GetRunAffinityAssistantBehavior(ctx, pr.Status.Coschedule)
 		if err != nil {
 			return controller.NewPermanentError(err)
 		}
@@ -929,6 +1063,8 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1.PipelineRun, getPipel
 		}
 	}
 
+	c.decideApprovalGates(ctx, pr, pipelineRunFacts)
+
 	if err := c.runNextSchedulableTask(ctx, pr, pipelineRunFacts); err != nil {
 		return err
 	}
@@ -952,11 +1088,21 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1.PipelineRun, getPipel
 	case corev1.ConditionUnknown:
 		pr.Status.MarkRunning(after.Reason, after.Message)
 	}
+	if pending := c.observeWorkspacePVCWaits(ctx, pr); pending != nil && after.Status == corev1.ConditionUnknown {
+		pr.Status.MarkRunning(after.Reason, "%s; PVC %s of workspace %s has been waiting %s to be bound",
+			after.Message, pending.claimName, pending.workspace, pending.wait.Round(time.Second))
+	}
 	// Read the condition the way it was set by the Mark* helpers
 	after = pr.Status.GetCondition(apis.ConditionSucceeded)
 	pr.Status.StartTime = pipelineRunFacts.State.AdjustStartTime(pr.Status.StartTime)
 
 	pr.Status.ChildReferences = pipelineRunFacts.GetChildReferences()
+	pr.Status.ApprovalGates = pipelineRunFacts.GetApprovalGates()
+	pr.Status.Layers = pipelineRunFacts.GetLayers()
+	pr.Status.FailureCauses = nil
+	if after.Status == corev1.ConditionFalse {
+		pr.Status.FailureCauses = pipelineRunFacts.GetFailureCauses()
+	}
 
 	pr.Status.SkippedTasks = pipelineRunFacts.GetSkippedTasks()
 	pipelineTaskStatus := pipelineRunFacts.GetPipelineTaskStatus()
@@ -988,6 +1134,7 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1.PipelineRun, getPipel
 func (c *Reconciler) runNextSchedulableTask(ctx context.Context, pr *v1.PipelineRun, pipelineRunFacts *resources.PipelineRunFacts) error {
 	ctx, span := c.tracerProvider.Tracer(TracerName).Start(ctx, "runNextSchedulableTask")
 	defer span.End()
+	defer tknreconciler.StartReconcilePhase(ctx, tknreconciler.ReconcilePhaseResources)()
 
 	logger := logging.FromContext(ctx)
 	recorder := controller.GetEventRecorder(ctx)
@@ -1033,6 +1180,10 @@ func (c *Reconciler) runNextSchedulableTask(ctx context.Context, pr *v1.Pipeline
 	if len(fNextRpts) != 0 {
 		// apply the runtime context just before creating taskRuns for final tasks in queue
 		resources.ApplyPipelineTaskStateContext(fNextRpts, pipelineRunFacts.GetPipelineTaskStatus())
+		if err := resources.ApplyPipelineTaskArtifacts(fNextRpts, pipelineRunFacts.State.GetTaskRunsArtifacts()); err != nil {
+			logger.Errorf("Failed to apply the artifacts of the pipeline tasks to the final tasks due to error: %v", err)
+			return controller.NewPermanentError(err)
+		}
 
 		// Before creating TaskRun for scheduled final task, check if it's consuming a task result
 		// Resolve and apply task result wherever applicable, report warning in case resolution fails
@@ -1072,6 +1223,14 @@ func (c *Reconciler) runNextSchedulableTask(ctx context.Context, pr *v1.Pipeline
 			continue
 		}
 
+		// resolve the retries and timeout the pipeline task takes from a param or a result
+		if err := resources.ApplyRetriesAndTimeout(pipelineRunFacts.State, rpt); err != nil {
+			logger.Errorf("Failed to resolve the retries or timeout of pipeline task %q: %v", rpt.PipelineTask.Name, err)
+			pr.Status.MarkFailed(v1.PipelineRunReasonInvalidRetriesOrTimeout.String(),
+				"PipelineRun %s/%s can't run PipelineTask %s: %s", pr.Namespace, pr.Name, rpt.PipelineTask.Name, pipelineErrors.WrapUserError(err))
+			return controller.NewPermanentError(err)
+		}
+
 		// propagate previous task results
 		resources.PropagateResults(rpt, pipelineRunFacts.State)
 
@@ -1093,6 +1252,8 @@ func (c *Reconciler) runNextSchedulableTask(ctx context.Context, pr *v1.Pipeline
 		}
 
 		switch {
+		case rpt.IsApprovalGate():
+			c.startApprovalGate(ctx, pr, rpt)
 		case rpt.IsChildPipeline():
 			rpt.ChildPipelineRuns, err = c.createChildPipelineRuns(ctx, rpt, pr, pipelineRunFacts)
 			if err != nil {
@@ -1257,7 +1418,7 @@ func (c *Reconciler) getChildPipelineRunWorkspaces(ctx context.Context, pr *v1.P
 			pipelinePVCWorkspaceName = parentName
 		}
 
-		aaBehavior, err := affinityassistant.GetAffinityAssistantBehavior(ctx)
+		aaBehavior, err := affinityassistant.GetRunAffinityAssistantBehavior(ctx, pr.Status.Coschedule)
 		if err != nil {
 			return nil, err
 		}
@@ -1320,14 +1481,15 @@ func (c *Reconciler) createTaskRuns(ctx context.Context, rpt *resources.Resolved
 		matrixCombinations = rpt.PipelineTask.Matrix.FanOut()
 	}
 
-	// validate the param values meet resolved Task Param Enum requirements before creating TaskRuns
-	if config.FromContextOrDefaults(ctx).FeatureFlags.EnableParamEnum {
-		for i := range rpt.TaskRunNames {
-			var params v1.Params
-			if len(matrixCombinations) > i {
-				params = matrixCombinations[i]
-			}
-			params = append(params, rpt.PipelineTask.Params...)
+	// validate the param values meet resolved Task Param Enum and constraints requirements before creating TaskRuns
+	enableParamEnum := config.FromContextOrDefaults(ctx).FeatureFlags.EnableParamEnum
+	for i := range rpt.TaskRunNames {
+		var params v1.Params
+		if len(matrixCombinations) > i {
+			params = matrixCombinations[i]
+		}
+		params = append(params, rpt.PipelineTask.Params...)
+		if enableParamEnum {
 			if err := taskrun.ValidateEnumParam(ctx, params, rpt.ResolvedTask.TaskSpec.Params); err != nil {
 				pr.Status.MarkFailed(v1.PipelineRunReasonInvalidParamValue.String(),
 					"Invalid param value from PipelineTask \"%s\": %v",
@@ -1335,15 +1497,30 @@ func (c *Reconciler) createTaskRuns(ctx context.Context, rpt *resources.Resolved
 				return nil, controller.NewPermanentError(err)
 			}
 		}
+		if err := taskrun.ValidateParamConstraints(params, rpt.ResolvedTask.TaskSpec.Params); err != nil {
+			pr.Status.MarkFailed(v1.PipelineRunReasonInvalidParamValue.String(),
+				"Invalid param value from PipelineTask \"%s\": %v",
+				rpt.PipelineTask.Name, err)
+			return nil, controller.NewPermanentError(err)
+		}
 	}
 
+	// The TaskRuns of the instances of a matrix which doesn't fail fast which were created already are kept
+	created := make(map[string]*v1.TaskRun, len(rpt.TaskRuns))
+	for _, tr := range rpt.TaskRuns {
+		created[tr.Name] = tr
+	}
 	var taskRuns []*v1.TaskRun
 	for i, taskRunName := range rpt.TaskRunNames {
+		if tr, ok := created[taskRunName]; ok {
+			taskRuns = append(taskRuns, tr)
+			continue
+		}
 		var params v1.Params
 		if len(matrixCombinations) > i {
 			params = matrixCombinations[i]
 		}
-		taskRun, err := c.createTaskRun(ctx, taskRunName, params, rpt, pr, facts)
+		taskRun, err := c.createTaskRun(ctx, taskRunName, i, params, rpt, pr, facts)
 		if err != nil {
 			err := c.handleRunCreationError(pr, err)
 			return nil, err
@@ -1354,7 +1531,7 @@ func (c *Reconciler) createTaskRuns(ctx context.Context, rpt *resources.Resolved
 	return taskRuns, nil
 }
 
-func (c *Reconciler) createTaskRun(ctx context.Context, taskRunName string, params v1.Params, rpt *resources.ResolvedPipelineTask, pr *v1.PipelineRun, facts *resources.PipelineRunFacts) (_ *v1.TaskRun, err error) {
+func (c *Reconciler) createTaskRun(ctx context.Context, taskRunName string, matrixInstance int, params v1.Params, rpt *resources.ResolvedPipelineTask, pr *v1.PipelineRun, facts *resources.PipelineRunFacts) (_ *v1.TaskRun, err error) {
 	ctx, span := c.tracerProvider.Tracer(TracerName).Start(ctx, "createTaskRun")
 	defer span.End()
 	defer func() {
@@ -1394,6 +1571,16 @@ func (c *Reconciler) createTaskRun(ctx context.Context, taskRunName string, para
 	if rpt.PipelineTask.OnError == v1.PipelineTaskContinue {
 		tr.Annotations[v1.PipelineTaskOnErrorAnnotation] = string(v1.PipelineTaskContinue)
 	}
+	if rpt.PipelineTask.ExpectedDuration != nil {
+		tr.Annotations[v1.PipelineTaskExpectedDurationAnnotation] = rpt.PipelineTask.ExpectedDuration.Duration.String()
+	}
+	if rpt.PipelineTask.DisplayName != "" {
+		tr.Annotations[v1.PipelineTaskDisplayNameAnnotation] = rpt.PipelineTask.DisplayName
+	}
+	if rpt.PipelineTask.Description != "" {
+		tr.Annotations[v1.PipelineTaskDescriptionAnnotation] = rpt.PipelineTask.Description
+	}
+	setPodGroup(ctx, pr, &tr.ObjectMeta)
 
 	if rpt.PipelineTask.Timeout != nil {
 		tr.Spec.Timeout = rpt.PipelineTask.Timeout
@@ -1405,6 +1592,12 @@ func (c *Reconciler) createTaskRun(ctx context.Context, taskRunName string, para
 	if taskRunSpec.Timeout != nil {
 		tr.Spec.Timeout = taskRunSpec.Timeout
 	}
+	// the TaskRun must not outlive the PipelineRun timeouts that apply to it
+	tr.Spec.Timeout = capTimeoutToRemaining(ctx, tr.Spec.Timeout, rpt.TimeoutRemaining(facts))
+	// nor the maximum TaskRun duration, which the timeouts of the PipelineRun may exceed
+	if tr.Spec.Timeout != nil {
+		tr.Spec.Timeout = &metav1.Duration{Duration: config.FromContextOrDefaults(ctx).Defaults.CapTaskRunTimeout(tr.Spec.Timeout.Duration)}
+	}
 
 	if rpt.ResolvedTask.TaskName != "" {
 		// We pass the entire, original task ref because it may contain additional references like a Bundle url.
@@ -1414,18 +1607,19 @@ func (c *Reconciler) createTaskRun(ctx context.Context, taskRunName string, para
 	}
 
 	var pipelinePVCWorkspaceName string
-	tr.Spec.Workspaces, pipelinePVCWorkspaceName, err = c.getTaskrunWorkspaces(ctx, pr, rpt)
+	tr.Spec.Workspaces, pipelinePVCWorkspaceName, err = c.getTaskrunWorkspaces(ctx, pr, rpt, matrixInstance)
 	if err != nil {
 		return nil, err
 	}
 
-	aaBehavior, err := affinityassistant.GetAffinityAssistantBehavior(ctx)
+	aaBehavior, err := affinityassistant.GetRunAffinityAssistantBehavior(ctx, pr.Status.Coschedule)
 	if err != nil {
 		return nil, err
 	}
-	if aaAnnotationVal := getAffinityAssistantAnnotationVal(aaBehavior, pipelinePVCWorkspaceName, pr.Name); aaAnnotationVal != "" {
+	if aaAnnotationVal := getAffinityAssistantAnnotationVal(aaBehavior, pipelinePVCWorkspaceName, pr.Name); aaAnnotationVal != "" && !skipsAffinityAssistant(pr, aaBehavior, pipelinePVCWorkspaceName) {
 		tr.Annotations[workspace.AnnotationAffinityAssistantName] = aaAnnotationVal
 	}
+	setCoscheduleAnnotation(tr.Annotations, pr)
 
 	logger.Infof("Creating a new TaskRun object %s for pipeline task %s", taskRunName, rpt.PipelineTask.Name)
 
@@ -1487,7 +1681,7 @@ func (c *Reconciler) createCustomRuns(ctx context.Context, rpt *resources.Resolv
 		if len(matrixCombinations) > i {
 			params = matrixCombinations[i]
 		}
-		customRun, err := c.createCustomRun(ctx, customRunName, params, rpt, pr, facts)
+		customRun, err := c.createCustomRun(ctx, customRunName, i, params, rpt, pr, facts)
 		if err != nil {
 			err := c.handleRunCreationError(pr, err)
 			return nil, err
@@ -1497,7 +1691,7 @@ func (c *Reconciler) createCustomRuns(ctx context.Context, rpt *resources.Resolv
 	return customRuns, nil
 }
 
-func (c *Reconciler) createCustomRun(ctx context.Context, runName string, params v1.Params, rpt *resources.ResolvedPipelineTask, pr *v1.PipelineRun, facts *resources.PipelineRunFacts) (_ *v1beta1.CustomRun, err error) {
+func (c *Reconciler) createCustomRun(ctx context.Context, runName string, matrixInstance int, params v1.Params, rpt *resources.ResolvedPipelineTask, pr *v1.PipelineRun, facts *resources.PipelineRunFacts) (_ *v1beta1.CustomRun, err error) {
 	ctx, span := c.tracerProvider.Tracer(TracerName).Start(ctx, "createCustomRun")
 	defer span.End()
 	defer func() {
@@ -1522,7 +1716,7 @@ func (c *Reconciler) createCustomRun(ctx context.Context, runName string, params
 
 	var pipelinePVCWorkspaceName string
 	var workspaces []v1.WorkspaceBinding
-	workspaces, pipelinePVCWorkspaceName, err = c.getTaskrunWorkspaces(ctx, pr, rpt)
+	workspaces, pipelinePVCWorkspaceName, err = c.getTaskrunWorkspaces(ctx, pr, rpt, matrixInstance)
 	if err != nil {
 		return nil, err
 	}
@@ -1588,13 +1782,14 @@ func (c *Reconciler) createCustomRun(ctx context.Context, runName string, params
 
 	// Set the affinity assistant annotation in case the custom task creates TaskRuns or Pods
 	// that can take advantage of it.
-	aaBehavior, err := affinityassistant.GetAffinityAssistantBehavior(ctx)
+	aaBehavior, err := affinityassistant.GetRunAffinityAssistantBehavior(ctx, pr.Status.Coschedule)
 	if err != nil {
 		return nil, err
 	}
-	if aaAnnotationVal := getAffinityAssistantAnnotationVal(aaBehavior, pipelinePVCWorkspaceName, pr.Name); aaAnnotationVal != "" {
+	if aaAnnotationVal := getAffinityAssistantAnnotationVal(aaBehavior, pipelinePVCWorkspaceName, pr.Name); aaAnnotationVal != "" && !skipsAffinityAssistant(pr, aaBehavior, pipelinePVCWorkspaceName) {
 		r.Annotations[workspace.AnnotationAffinityAssistantName] = aaAnnotationVal
 	}
+	setCoscheduleAnnotation(r.Annotations, pr)
 
 	logger.Infof("Creating a new CustomRun object %s", runName)
 
@@ -1651,7 +1846,10 @@ func propagateWorkspaces(rpt *resources.ResolvedPipelineTask) (*resources.Resolv
 	return rpt, nil
 }
 
-func (c *Reconciler) getTaskrunWorkspaces(ctx context.Context, pr *v1.PipelineRun, rpt *resources.ResolvedPipelineTask) ([]v1.WorkspaceBinding, string, error) {
+// getTaskrunWorkspaces returns the workspaces of the run of the instance matrixInstance of rpt, and the name of
+// the PipelineRun workspace backed by a PersistentVolumeClaim it binds, if any. The name is the one of the binding
+// of the instance to its own claim if the workspace is bound per matrix instance.
+func (c *Reconciler) getTaskrunWorkspaces(ctx context.Context, pr *v1.PipelineRun, rpt *resources.ResolvedPipelineTask, matrixInstance int) ([]v1.WorkspaceBinding, string, error) {
 	var err error
 	var workspaces []v1.WorkspaceBinding
 	var pipelinePVCWorkspaceName string
@@ -1669,7 +1867,21 @@ func (c *Reconciler) getTaskrunWorkspaces(ctx context.Context, pr *v1.PipelineRu
 		}
 	}
 
+	// replace pipelineRun context variables in workspace subPath in the workspace binding
+	var p string
+	if pr.Spec.PipelineRef != nil {
+		p = pr.Spec.PipelineRef.Name
+	}
+	contextReplacements := resources.GetContextReplacements(p, pr)
+
 	for _, ws := range rpt.PipelineTask.Workspaces {
+		// The params and results the subPath references are already replaced
+		ws.SubPath = substitution.ApplyReplacements(ws.SubPath, contextReplacements)
+		if err := ws.ValidateSubPath(); err != nil {
+			err := fmt.Errorf("invalid workspace %q of pipeline task %q: %w", ws.Name, rpt.PipelineTask.Name, err)
+			// This error cannot be recovered without modifying the params or the results of the PipelineRun
+			return nil, "", controller.NewPermanentError(err)
+		}
 		taskWorkspaceName, pipelineTaskSubPath, pipelineWorkspaceName := ws.Name, ws.SubPath, ws.Workspace
 		pipelineWorkspace := pipelineWorkspaceName
 
@@ -1678,11 +1890,24 @@ func (c *Reconciler) getTaskrunWorkspaces(ctx context.Context, pr *v1.PipelineRu
 		}
 
 		if b, hasBinding := pipelineRunWorkspaces[pipelineWorkspace]; hasBinding {
+			if ws.PerMatrixInstance && b.VolumeClaimTemplate != nil && rpt.PipelineTask.IsMatrixed() {
+				// The PVC of the instance is created from the PipelineRun whatever the AffinityAssistantBehavior
+				instance := volumeclaim.MatrixInstanceWorkspaceBinding(b, rpt.PipelineTask.Name, matrixInstance)
+				pipelinePVCWorkspaceName = instance.Name
+				workspaces = append(workspaces, v1.WorkspaceBinding{
+					Name:    taskWorkspaceName,
+					SubPath: combinedSubPath(b.SubPath, pipelineTaskSubPath),
+					PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+						ClaimName: volumeclaim.PVCName(instance, *kmeta.NewControllerRef(pr)),
+					},
+				})
+				continue
+			}
 			if b.PersistentVolumeClaim != nil || b.VolumeClaimTemplate != nil {
 				pipelinePVCWorkspaceName = pipelineWorkspace
 			}
 
-			aaBehavior, err := affinityassistant.GetAffinityAssistantBehavior(ctx)
+			aaBehavior, err := affinityassistant.GetRunAffinityAssistantBehavior(ctx, pr.Status.Coschedule)
 			if err != nil {
 				return nil, "", err
 			}
@@ -1707,13 +1932,9 @@ func (c *Reconciler) getTaskrunWorkspaces(ctx context.Context, pr *v1.PipelineRu
 		}
 	}
 
-	// replace pipelineRun context variables in workspace subPath in the workspace binding
-	var p string
-	if pr.Spec.PipelineRef != nil {
-		p = pr.Spec.PipelineRef.Name
-	}
+	// replace pipelineRun context variables in the subPath of the PipelineRun workspace binding
 	for j := range workspaces {
-		workspaces[j].SubPath = substitution.ApplyReplacements(workspaces[j].SubPath, resources.GetContextReplacements(p, pr))
+		workspaces[j].SubPath = substitution.ApplyReplacements(workspaces[j].SubPath, contextReplacements)
 	}
 
 	return workspaces, pipelinePVCWorkspaceName, nil
@@ -1738,7 +1959,7 @@ func (c *Reconciler) taskWorkspaceByWorkspaceVolumeSource(ctx context.Context, p
 
 	switch aaBehavior {
 	case affinityassistant.AffinityAssistantPerWorkspace, affinityassistant.AffinityAssistantDisabled:
-		binding.PersistentVolumeClaim.ClaimName = volumeclaim.GeneratePVCNameFromWorkspaceBinding(wb.VolumeClaimTemplate.Name, wb, owner)
+		binding.PersistentVolumeClaim.ClaimName = volumeclaim.PVCName(wb, owner)
 	case affinityassistant.AffinityAssistantPerPipelineRun, affinityassistant.AffinityAssistantPerPipelineRunWithIsolation:
 		binding.PersistentVolumeClaim.ClaimName = getPersistentVolumeClaimNameWithAffinityAssistant("", prName, wb, owner)
 	}
@@ -1771,13 +1992,29 @@ func pipelineRunTimeout(ctx context.Context, pr *v1.PipelineRun, rpt *resources.
 		return prTimeout
 	}
 	// When the PipelineRun timeout is smaller than or equal to the global default
-	// (e.g., tasks: 20m with default: 60m), we return nil and the global default to
-	// the TaskRun gets applied. The PipelineRun's cumulative timer will
-	// cancel the TaskRun at 20m, before the 60m default fires, so there is no
-	// risk of premature cancellation and no need to set an individual timeout.
+	// (e.g., tasks: 20m with default: 60m), we return nil and the TaskRun timeout
+	// is capped to the time remaining before the PipelineRun timeout instead,
+	// see capTimeoutToRemaining.
 	return nil
 }
 
+// capTimeoutToRemaining returns the remaining time before the PipelineRun times out if the
+// given TaskRun timeout, or the default timeout when none is set, would exceed it.
+// Otherwise, the given timeout is returned unchanged.
+func capTimeoutToRemaining(ctx context.Context, timeout *metav1.Duration, remaining *time.Duration) *metav1.Duration {
+	if remaining == nil || *remaining <= 0 {
+		return timeout
+	}
+	effective := time.Duration(config.FromContextOrDefaults(ctx).Defaults.DefaultTimeoutMinutes) * time.Minute
+	if timeout != nil {
+		effective = timeout.Duration
+	}
+	if effective != config.NoTimeoutDuration && effective <= *remaining {
+		return timeout
+	}
+	return &metav1.Duration{Duration: *remaining}
+}
+
 // combinedSubPath returns the combined value of the optional subPath from workspaceBinding and the optional
 // subPath from pipelineTask. If both is set, they are joined with a slash.
 func combinedSubPath(workspaceSubPath string, pipelineTaskSubPath string) string {
@@ -1796,10 +2033,32 @@ func createChildResourceAnnotations(pr *v1.PipelineRun) map[string]string {
 		annotations[key] = val
 	}
 	return kmap.Filter(annotations, func(s string) bool {
-		return filterReservedAnnotationRegexp.MatchString(s)
+		return filterReservedAnnotationRegexp.MatchString(s) || s == v1.DuplicateWorkspaceBindingsAnnotation ||
+			s == v1.DefaultedWorkspaceStorageAnnotation
 	})
 }
 
+// dedupeWorkspaceBindings binds each workspace of a PipelineRun created before duplicate workspace
+// bindings were rejected to its first binding. The duplicates are noted, the first time they are
+// found, through a Warning event and the DuplicateWorkspaceBindingsAnnotation.
+func dedupeWorkspaceBindings(ctx context.Context, pr *v1.PipelineRun) {
+	bindings, duplicates := workspace.DedupeBindings(pr.Spec.Workspaces)
+	if len(duplicates) == 0 {
+		return
+	}
+	pr.Spec.Workspaces = bindings
+	names := strings.Join(duplicates, ",")
+	if pr.Annotations[v1.DuplicateWorkspaceBindingsAnnotation] == names {
+		return
+	}
+	if pr.Annotations == nil {
+		pr.Annotations = make(map[string]string, 1)
+	}
+	pr.Annotations[v1.DuplicateWorkspaceBindingsAnnotation] = names
+	controller.GetEventRecorder(ctx).Eventf(pr, corev1.EventTypeWarning, "DuplicateWorkspaceBindings",
+		"Workspaces %q are bound more than once by PipelineRun %q, only their first binding is used", duplicates, pr.Name)
+}
+
 func propagatePipelineNameLabelToPipelineRun(pr *v1.PipelineRun) error {
 	if pr.ObjectMeta.Labels == nil {
 		pr.ObjectMeta.Labels = make(map[string]string)
@@ -1886,6 +2145,8 @@ func combineTaskRunAndTaskSpecLabels(pr *v1.PipelineRun, pipelineTask *v1.Pipeli
 		addMetadataByPrecedence(labels, taskRunSpec.Metadata.Labels)
 	}
 
+	addMetadataByPrecedence(labels, pipelineTask.Labels)
+
 	addMetadataByPrecedence(labels, createChildResourceLabels(pr, pipelineTask.Name, true))
 
 	if pipelineTask.TaskSpec != nil {
@@ -1984,6 +2245,7 @@ func storePipelineSpecAndMergeMeta(ctx context.Context, pr *v1.PipelineRun, ps *
 func (c *Reconciler) updatePipelineRunStatusFromInformer(ctx context.Context, pr *v1.PipelineRun) error {
 	ctx, span := c.tracerProvider.Tracer(TracerName).Start(ctx, "updatePipelineRunStatusFromInformer")
 	defer span.End()
+	defer tknreconciler.StartReconcilePhase(ctx, tknreconciler.ReconcilePhaseStatusComputation)()
 	logger := logging.FromContext(ctx)
 
 	// Get the parent PipelineRun label that is set on each child (PinP) PipelineRun/TaskRun/CustomRun. Do not include the propagated labels from the
@@ -2244,3 +2506,20 @@ func validatePipelineSpecAfterApplyParameters(ctx context.Context, pipelineSpec
 	}
 	return errs
 }
+
+// validateHelperImageSets returns an error if the helper image set selected by the PipelineRun, or by the
+// metadata of one of its taskRunSpecs, isn't configured.
+func (c *Reconciler) validateHelperImageSets(ctx context.Context, pr *v1.PipelineRun) error {
+	if _, err := pipelinePod.HelperImages(ctx, c.Images, pr.Annotations); err != nil {
+		return err
+	}
+	for _, trs := range pr.Spec.TaskRunSpecs {
+		if trs.Metadata == nil {
+			continue
+		}
+		if _, err := pipelinePod.HelperImages(ctx, c.Images, trs.Metadata.Annotations); err != nil {
+			return fmt.Errorf("taskRunSpecs of pipelineTask %q: %w", trs.PipelineTaskName, err)
+		}
+	}
+	return nil
+}
@@ -25,6 +25,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
 	"testing"
@@ -71,6 +72,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	fakek8s "k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/kubernetes/typed/core/v1/fake"
@@ -106,6 +108,7 @@ var (
 	ignoreCompletionTime     = cmpopts.IgnoreFields(v1.PipelineRunStatusFields{}, "CompletionTime")
 	ignoreFinallyStartTime   = cmpopts.IgnoreFields(v1.PipelineRunStatusFields{}, "FinallyStartTime")
 	ignoreProvenance         = cmpopts.IgnoreFields(v1.PipelineRunStatusFields{}, "Provenance")
+	ignoreLayers             = cmpopts.IgnoreFields(v1.PipelineRunStatusFields{}, "Layers")
 	trueb                    = true
 	simpleHelloWorldTask     = &v1.Task{ObjectMeta: baseObjectMeta("hello-world", "foo")}
 	simpleSomeTask           = &v1.Task{ObjectMeta: baseObjectMeta("some-task", "foo")}
@@ -566,6 +569,7 @@ spec:
 			taskRunObjectMetaWithAnnotations("test-pipelinerun-custom-task", "namespace", "test-pipelinerun",
 				"test-pipelinerun", "custom-task", false, map[string]string{
 					"pipeline.tekton.dev/affinity-assistant": GetAffinityAssistantName("pipelinews", pipelineRunName),
+					"pipeline.tekton.dev/coschedule":         "workspaces",
 				}),
 			`
 spec:
@@ -694,6 +698,134 @@ spec:
 	th.VerifyTaskRunStatusesNames(t, reconciledRun.Status, trName)
 }
 
+// TestReconcile_NamespaceFeatureFlags tests that a PipelineRun is reconciled with the feature flags overridden
+// by the "tekton-feature-flags" ConfigMap of its namespace.
+func TestReconcile_NamespaceFeatureFlags(t *testing.T) {
+	prs := []*v1.PipelineRun{parse.MustParseV1PipelineRun(t, `
+metadata:
+  name: test-pipeline-run
+  namespace: foo
+spec:
+  pipelineSpec:
+    tasks:
+    - name: a-task
+      taskSpec:
+        steps:
+        - image: myimage
+          script: echo foo
+`)}
+	cms := []*corev1.ConfigMap{{
+		ObjectMeta: metav1.ObjectMeta{Name: config.GetFeatureFlagsConfigName(), Namespace: system.Namespace()},
+		Data: map[string]string{
+			"keep-pod-on-cancel": "false",
+		},
+	}, {
+		ObjectMeta: metav1.ObjectMeta{Name: config.NamespaceFeatureFlagsConfigMapName, Namespace: "foo"},
+		Data: map[string]string{
+			"keep-pod-on-cancel": "true",
+		},
+	}}
+	d := test.Data{
+		PipelineRuns: prs,
+		ConfigMaps:   cms,
+	}
+	prt := newPipelineRunTest(t, d)
+	defer prt.Cancel()
+
+	wantEvents := []string{
+		"Normal Started",
+		"Normal Running Tasks Completed: 0 \\(Failed: 0, Cancelled 0\\), Incomplete: 1, Skipped: 0",
+	}
+	pipelineRun, _ := prt.reconcileRun("foo", "test-pipeline-run", wantEvents, false)
+
+	if pipelineRun.Status.Provenance == nil || pipelineRun.Status.Provenance.FeatureFlags == nil {
+		t.Fatalf("expected the PipelineRun provenance to record its feature flags, got %v", pipelineRun.Status.Provenance)
+	}
+	if !pipelineRun.Status.Provenance.FeatureFlags.EnableKeepPodOnCancel {
+		t.Error("expected the PipelineRun to be reconciled with keep-pod-on-cancel overridden by its namespace")
+	}
+}
+
+// TestReconcileWithTaskDeletedMidRun tests that a PipelineRun fails terminally when the Task referenced by one of
+// its PipelineTasks is deleted while it's running, before the TaskRun of the PipelineTask was created
+func TestReconcileWithTaskDeletedMidRun(t *testing.T) {
+	ps := []*v1.Pipeline{parse.MustParseV1Pipeline(t, `
+metadata:
+  name: test-pipeline
+  namespace: foo
+spec:
+  tasks:
+  - name: unit-test
+    taskRef:
+      name: hello-world
+  - name: deploy
+    runAfter: [unit-test]
+    taskRef:
+      name: deploy-task
+`)}
+	prs := []*v1.PipelineRun{parse.MustParseV1PipelineRun(t, `
+metadata:
+  name: test-pipeline-run
+  namespace: foo
+spec:
+  pipelineRef:
+    name: test-pipeline
+  taskRunTemplate:
+    serviceAccountName: test-sa
+status:
+  conditions:
+  - type: Succeeded
+    status: Unknown
+    reason: Running
+  startTime: "2022-01-01T00:00:00Z"
+  pipelineSpec:
+    tasks:
+    - name: unit-test
+      taskRef:
+        name: hello-world
+    - name: deploy
+      runAfter: [unit-test]
+      taskRef:
+        name: deploy-task
+  childReferences:
+  - apiVersion: tekton.dev/v1
+    kind: TaskRun
+    name: test-pipeline-run-unit-test
+    pipelineTaskName: unit-test
+`)}
+	// The Task deploy-task was deleted once the layer of unit-test completed, before the TaskRun of deploy was created.
+	trs := []*v1.TaskRun{createHelloWorldTaskRunWithStatus(t, "test-pipeline-run-unit-test", "foo",
+		"test-pipeline-run", "test-pipeline", "test-pipeline-run-unit-test-pod",
+		apis.Condition{Type: apis.ConditionSucceeded, Status: corev1.ConditionTrue})}
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        []*v1.Task{simpleHelloWorldTask},
+		TaskRuns:     trs,
+		ConfigMaps:   th.NewFeatureFlagsConfigMapInSlice(),
+	}
+	prt := newPipelineRunTest(t, d)
+	defer prt.Cancel()
+
+	wantEvents := []string{
+		`Warning Failed Task "deploy-task" referenced by pipeline task "deploy" of PipelineRun foo/test-pipeline-run can't be retrieved anymore, it may have been deleted since the PipelineRun started: tasks.tekton.dev "deploy-task" not found`,
+		"Warning InternalError",
+	}
+	reconciledRun, clients := prt.reconcileRun("foo", "test-pipeline-run", wantEvents, true)
+
+	condition := reconciledRun.Status.GetCondition(apis.ConditionSucceeded)
+	if !condition.IsFalse() || condition.Reason != v1.PipelineRunReasonCouldntGetTask.String() {
+		t.Errorf("expected the PipelineRun to fail with reason %s, got %v", v1.PipelineRunReasonCouldntGetTask, condition)
+	}
+	taskRuns, err := clients.Pipeline.TektonV1().TaskRuns("foo").List(prt.TestAssets.Ctx, metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error when listing TaskRuns: %v", err)
+	}
+	if len(taskRuns.Items) != 1 {
+		t.Errorf("expected no TaskRun to be created for pipeline task deploy, got %d TaskRuns", len(taskRuns.Items))
+	}
+}
+
 // TestReconcile_InvalidPipelineRuns runs "Reconcile" on several PipelineRuns that are invalid in different ways.
 // It verifies that reconcile fails, how it fails and which events are triggered.
 func TestReconcile_InvalidPipelineRuns(t *testing.T) {
@@ -1012,6 +1144,35 @@ spec:
 			"Normal Started",
 			"Warning Failed [User error] PipelineRun foo/pipelinerun-missing-params-2 is missing some parameters required by Pipeline foo/pipelinerun-missing-params-2: pipelineRun missing parameters: [some-param]",
 		},
+	}, {
+		name: "invalid-pipeline-run-missing-finally-params-shd-stop-reconciling",
+		pipelineRun: parse.MustParseV1PipelineRun(t, `
+metadata:
+  name: pipelinerun-missing-params-3
+  namespace: foo
+spec:
+  pipelineSpec:
+    params:
+      - name: notify-url
+        type: string
+    tasks:
+      - name: some-task
+        taskRef:
+          name: a-task-that-needs-params
+    finally:
+      - name: notify
+        taskRef:
+          name: a-task-that-needs-params
+        params:
+          - name: url
+            value: $(params.notify-url)
+`),
+		reason:         v1.PipelineRunReasonParameterMissing.String(),
+		permanentError: true,
+		wantEvents: []string{
+			"Normal Started",
+			`Warning Failed [User error] PipelineRun foo/pipelinerun-missing-params-3 is missing some parameters required by Pipeline foo/pipelinerun-missing-params-3: pipelineRun missing parameters: [notify-url]: "notify-url" is only used by finally tasks [notify]`,
+		},
 	}, {
 		name: "invalid-pipeline-with-invalid-dag-graph",
 		pipelineRun: parse.MustParseV1PipelineRun(t, `
@@ -1352,7 +1513,7 @@ status:
 
 	// The PipelineRun should be marked as failed
 	if d := cmp.Diff(expectedPipelineRun, reconciledRun, ignoreResourceVersion, ignoreLastTransitionTime, ignoreTypeMeta,
-		ignoreStartTime, ignoreCompletionTime, ignoreProvenance); d != "" {
+		ignoreStartTime, ignoreCompletionTime, ignoreProvenance, ignoreLayers); d != "" {
 		t.Errorf("Expected to see PipelineRun run marked as failed. Diff %s", diff.PrintWantGot(d))
 	}
 
@@ -1678,6 +1839,63 @@ status:
 	}
 }
 
+// TestReconcilePipelineRunRecordsReconcilePhases verifies that the time spent in the phases of a
+// reconcile starting a PipelineRun is recorded in the
+// tekton_pipelines_controller_pipelinerun_reconcile_phase_duration_seconds histogram.
+func TestReconcilePipelineRunRecordsReconcilePhases(t *testing.T) {
+	countForPhases := func() map[string]uint64 {
+		var rm metricdata.ResourceMetrics
+		if err := testMetricsReader.Collect(t.Context(), &rm); err != nil {
+			t.Fatalf("failed to collect metrics: %v", err)
+		}
+		counts := map[string]uint64{}
+		for _, sm := range rm.ScopeMetrics {
+			for _, m := range sm.Metrics {
+				if m.Name != "tekton_pipelines_controller_pipelinerun_reconcile_phase_duration_seconds" {
+					continue
+				}
+				hist, ok := m.Data.(metricdata.Histogram[float64])
+				if !ok {
+					continue
+				}
+				for _, dp := range hist.DataPoints {
+					phase, _ := dp.Attributes.Value("phase")
+					counts[phase.AsString()] += dp.Count
+				}
+			}
+		}
+		return counts
+	}
+
+	pr := parse.MustParseV1PipelineRun(t, `
+metadata:
+  name: phases-pr
+  namespace: foo
+spec:
+  pipelineRef:
+    name: test-pipeline
+  taskRunTemplate:
+    serviceAccountName: test-sa
+`)
+	d := test.Data{
+		PipelineRuns: []*v1.PipelineRun{pr},
+		Pipelines:    []*v1.Pipeline{simpleHelloWorldPipeline},
+		Tasks:        []*v1.Task{simpleHelloWorldTask},
+		ConfigMaps:   th.NewFeatureFlagsConfigMapInSlice(),
+	}
+	prt := newPipelineRunTest(t, d)
+	defer prt.Cancel()
+
+	baseline := countForPhases()
+	prt.reconcileRun("foo", pr.Name, nil, false)
+	after := countForPhases()
+	for _, phase := range []string{"resolution", "validation", "resources", "status_computation"} {
+		if after[phase] != baseline[phase]+1 {
+			t.Errorf("reconcile_phase_duration_seconds{phase=%s}: got %d after reconcile, want %d", phase, after[phase], baseline[phase]+1)
+		}
+	}
+}
+
 func TestReconcileForCustomTaskWithPipelineTaskTimedOut(t *testing.T) {
 	names.TestingSeed()
 	// TestReconcileForCustomTaskWithPipelineTaskTimedOut runs "Reconcile" on a PipelineRun.
@@ -2439,53 +2657,302 @@ spec:
 	}
 }
 
-func TestReconcileWithTimeouts_Pipeline(t *testing.T) {
-	// TestReconcileWithTimeouts_Pipeline runs "Reconcile" on a PipelineRun that has timed out.
-	// It verifies that reconcile is successful, no TaskRun is created, the PipelineTask is marked as skipped, and the
-	// pipeline status updated and events generated.
+func TestReconcileWithStartJitter(t *testing.T) {
+	// TestReconcileWithStartJitter runs "Reconcile" on PipelineRuns when "default-start-jitter" is set.
+	// It verifies that new PipelineRuns are delayed without starting, and that once started
+	// the delay is not counted in their timeout.
+	for _, tc := range []struct {
+		name        string
+		createdAgo  time.Duration
+		annotations string
+		wantDelayed bool
+	}{{
+		name:        "new pipelinerun is delayed",
+		wantDelayed: true,
+	}, {
+		name:        "high priority pipelinerun is not delayed",
+		annotations: "pipeline.tekton.dev/priority: high",
+	}, {
+		name:       "pipelinerun starts once the delay is over",
+		createdAgo: 2 * time.Hour,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			names.TestingSeed()
+			prs := []*v1.PipelineRun{parse.MustParseV1PipelineRun(t, fmt.Sprintf(`
+metadata:
+  name: test-pipeline-run-jitter
+  namespace: foo
+  uid: jitter
+  creationTimestamp: %s
+  annotations:
+    %s
+spec:
+  pipelineRef:
+    name: test-pipeline
+  timeouts:
+    pipeline: 1h
+`, now.Add(-tc.createdAgo).Format(time.RFC3339), tc.annotations))}
+			cms := []*corev1.ConfigMap{{
+				ObjectMeta: metav1.ObjectMeta{Name: config.GetDefaultsConfigName(), Namespace: system.Namespace()},
+				Data: map[string]string{
+					"default-start-jitter": "1h",
+				},
+			}}
+			d := test.Data{
+				PipelineRuns: prs,
+				Pipelines:    []*v1.Pipeline{simpleHelloWorldPipeline},
+				Tasks:        []*v1.Task{simpleHelloWorldTask},
+				ConfigMaps:   cms,
+			}
+			prt := newPipelineRunTest(t, d)
+			defer prt.Cancel()
+			// The fake client doesn't set the creation time of the TaskRuns, which the start time
+			// of the PipelineRun is adjusted to.
+			prt.TestAssets.Clients.Pipeline.PrependReactor("create", "taskruns", func(action ktesting.Action) (bool, runtime.Object, error) {
+				action.(ktesting.CreateAction).GetObject().(*v1.TaskRun).CreationTimestamp = metav1.NewTime(now)
+				return false, nil, nil
+			})
+
+			reconcileErr := prt.TestAssets.Controller.Reconciler.Reconcile(prt.TestAssets.Ctx, "foo/test-pipeline-run-jitter")
+			isRequeue, requeueAfter := controller.IsRequeueKey(reconcileErr)
+			if !isRequeue {
+				t.Fatalf("expected the PipelineRun to be requeued, got %v", reconcileErr)
+			}
+			reconciledRun, err := prt.TestAssets.Clients.Pipeline.TektonV1().PipelineRuns("foo").Get(prt.TestAssets.Ctx, "test-pipeline-run-jitter", metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Somehow had error getting reconciled run out of fake client: %s", err)
+			}
+			taskRuns := getTaskRunsForPipelineRun(prt.TestAssets.Ctx, t, prt.TestAssets.Clients, "foo", "test-pipeline-run-jitter")
+
+			if tc.wantDelayed {
+				th.CheckPipelineRunConditionStatusAndReason(t, reconciledRun.Status, corev1.ConditionUnknown, v1.PipelineRunReasonStartDelayed.String())
+				if reconciledRun.Status.StartTime != nil {
+					t.Errorf("Start time should be nil, not: %s", reconciledRun.Status.StartTime)
+				}
+				if requeueAfter <= 0 || requeueAfter >= time.Hour {
+					t.Errorf("expected the PipelineRun to be requeued after a delay in (0, 1h), got %s", requeueAfter)
+				}
+				validateTaskRunsCount(t, taskRuns, 0)
+				return
+			}
+
+			th.CheckPipelineRunConditionStatusAndReason(t, reconciledRun.Status, corev1.ConditionUnknown, v1.PipelineRunReasonRunning.String())
+			if reconciledRun.Status.StartTime == nil || !reconciledRun.Status.StartTime.Time.Equal(now) {
+				t.Errorf("expected the start time to be %s, got %v", now, reconciledRun.Status.StartTime)
+			}
+			// The timeout is counted from the actual start, not from the creation.
+			if requeueAfter != time.Hour {
+				t.Errorf("expected the PipelineRun to be requeued after its full timeout of 1h, got %s", requeueAfter)
+			}
+			validateTaskRunsCount(t, taskRuns, 1)
+		})
+	}
+}
+
+func TestReconcileWithRunRefParams(t *testing.T) {
+	// TestReconcileWithRunRefParams runs "Reconcile" on PipelineRuns whose params are taken from
+	// the results of another PipelineRun. It verifies that the values of string and array results
+	// are passed on to the TaskRuns and recorded in the provenance, and that the PipelineRun fails
+	// when the result is not available or does not have the type of the param.
 	ps := []*v1.Pipeline{parse.MustParseV1Pipeline(t, `
 metadata:
-  name: test-pipeline
+  name: promote-pipeline
   namespace: foo
 spec:
+  params:
+  - name: digest
+    type: string
+  - name: tags
+    type: array
   tasks:
-  - name: hello-world-1
-    taskRef:
-      name: hello-world
-  - name: hello-world-2
-    taskRef:
-      name: hello-world
+  - name: promote
+    taskSpec:
+      params:
+      - name: digest
+        type: string
+      - name: tags
+        type: array
+      steps:
+      - name: promote
+        image: foo
+        args: ["$(params.digest)", "$(params.tags[*])"]
+    params:
+    - name: digest
+      value: $(params.digest)
+    - name: tags
+      value: $(params.tags[*])
 `)}
-	prs := []*v1.PipelineRun{parse.MustParseV1PipelineRun(t, `
+	for _, tc := range []struct {
+		name             string
+		buildCondition   string
+		allowFailed      bool
+		tagsResult       string
+		wantFailedReason string
+	}{{
+		name:           "string and array results of a successful run",
+		buildCondition: `{type: Succeeded, status: "True", reason: Succeeded}`,
+	}, {
+		name:           "results of a failed run when failures are allowed",
+		buildCondition: `{type: Succeeded, status: "False", reason: Failed}`,
+		allowFailed:    true,
+	}, {
+		name:             "results of a failed run",
+		buildCondition:   `{type: Succeeded, status: "False", reason: Failed}`,
+		wantFailedReason: v1.PipelineRunReasonReferencedRunResultUnavailable.String(),
+	}, {
+		name:             "referenced run has not finished",
+		buildCondition:   `{type: Succeeded, status: Unknown, reason: Running}`,
+		wantFailedReason: v1.PipelineRunReasonReferencedRunResultUnavailable.String(),
+	}, {
+		name:             "result missing from the referenced run",
+		buildCondition:   `{type: Succeeded, status: "True", reason: Succeeded}`,
+		tagsResult:       "labels",
+		wantFailedReason: v1.PipelineRunReasonReferencedRunResultUnavailable.String(),
+	}, {
+		name:             "result type does not match the param type",
+		buildCondition:   `{type: Succeeded, status: "True", reason: Succeeded}`,
+		tagsResult:       "digest",
+		wantFailedReason: v1.PipelineRunReasonParameterTypeMismatch.String(),
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.tagsResult == "" {
+				tc.tagsResult = "tags"
+			}
+			prs := []*v1.PipelineRun{parse.MustParseV1PipelineRun(t, `
 metadata:
-  name: test-pipeline-run-with-timeout
+  name: build-run
   namespace: foo
+  uid: build-run-uid
 spec:
   pipelineRef:
-    name: test-pipeline
-  taskRunTemplate:
-    serviceAccountName: test-sa
-  timeouts:
-    pipeline: 12h0m0s
+    name: build-pipeline
 status:
-  startTime: "2021-12-31T11:00:00Z"
-  childReferences:
-  - name: test-pipeline-run-with-timeout-hello-world-1
-    pipelineTaskName: hello-world-1
-    kind: TaskRun
-`)}
-	ts := []*v1.Task{simpleHelloWorldTask}
-
-	trs := []*v1.TaskRun{parse.MustParseTaskRunWithObjectMeta(t, taskRunObjectMeta("test-pipeline-run-with-timeout-hello-world-1", "foo", "test-pipeline-run-with-timeout",
-		"test-pipeline", "hello-world-1", false), `
+  conditions:
+  - `+tc.buildCondition+`
+  results:
+  - name: digest
+    value: sha256:abc
+  - name: tags
+    value: [v1, latest]
+`), parse.MustParseV1PipelineRun(t, fmt.Sprintf(`
+metadata:
+  name: promote-run
+  namespace: foo
 spec:
-  serviceAccountName: test-sa
-  taskRef:
-    name: hello-world
-    kind: Task
-`)}
-
-	d := test.Data{
+  pipelineRef:
+    name: promote-pipeline
+  params:
+  - name: digest
+    runRef:
+      name: build-run
+      result: digest
+      allowFailed: %t
+  - name: tags
+    runRef:
+      name: build-run
+      result: %s
+      allowFailed: %t
+`, tc.allowFailed, tc.tagsResult, tc.allowFailed))}
+			d := test.Data{
+				PipelineRuns: prs,
+				Pipelines:    ps,
+			}
+			prt := newPipelineRunTest(t, d)
+			defer prt.Cancel()
+
+			reconciledRun, clients := prt.reconcileRun("foo", "promote-run", nil, tc.wantFailedReason != "")
+			taskRuns := getTaskRunsForPipelineRun(prt.TestAssets.Ctx, t, clients, "foo", "promote-run")
+
+			if tc.wantFailedReason != "" {
+				th.CheckPipelineRunConditionStatusAndReason(t, reconciledRun.Status, corev1.ConditionFalse, tc.wantFailedReason)
+				validateTaskRunsCount(t, taskRuns, 0)
+				return
+			}
+
+			th.CheckPipelineRunConditionStatusAndReason(t, reconciledRun.Status, corev1.ConditionUnknown, v1.PipelineRunReasonRunning.String())
+			validateTaskRunsCount(t, taskRuns, 1)
+			wantParams := v1.Params{{
+				Name:  "digest",
+				Value: *v1.NewStructuredValues("sha256:abc"),
+			}, {
+				Name:  "tags",
+				Value: *v1.NewStructuredValues("v1", "latest"),
+			}}
+			for _, tr := range taskRuns {
+				if d := cmp.Diff(wantParams, tr.Spec.Params); d != "" {
+					t.Errorf("TaskRun params %s", diff.PrintWantGot(d))
+				}
+			}
+			wantSources := []v1.ParamSource{{
+				Param:       "digest",
+				PipelineRun: "build-run",
+				UID:         "build-run-uid",
+				Result:      "digest",
+				Value:       *v1.NewStructuredValues("sha256:abc"),
+			}, {
+				Param:       "tags",
+				PipelineRun: "build-run",
+				UID:         "build-run-uid",
+				Result:      "tags",
+				Value:       *v1.NewStructuredValues("v1", "latest"),
+			}}
+			if reconciledRun.Status.Provenance == nil {
+				t.Fatal("expected the provenance to record the sources of the params")
+			}
+			if d := cmp.Diff(wantSources, reconciledRun.Status.Provenance.ParamSources); d != "" {
+				t.Errorf("provenance param sources %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestReconcileWithTimeouts_Pipeline(t *testing.T) {
+	// TestReconcileWithTimeouts_Pipeline runs "Reconcile" on a PipelineRun that has timed out.
+	// It verifies that reconcile is successful, no TaskRun is created, the PipelineTask is marked as skipped, and the
+	// pipeline status updated and events generated.
+	ps := []*v1.Pipeline{parse.MustParseV1Pipeline(t, `
+metadata:
+  name: test-pipeline
+  namespace: foo
+spec:
+  tasks:
+  - name: hello-world-1
+    taskRef:
+      name: hello-world
+  - name: hello-world-2
+    taskRef:
+      name: hello-world
+`)}
+	prs := []*v1.PipelineRun{parse.MustParseV1PipelineRun(t, `
+metadata:
+  name: test-pipeline-run-with-timeout
+  namespace: foo
+spec:
+  pipelineRef:
+    name: test-pipeline
+  taskRunTemplate:
+    serviceAccountName: test-sa
+  timeouts:
+    pipeline: 12h0m0s
+status:
+  startTime: "2021-12-31T11:00:00Z"
+  childReferences:
+  - name: test-pipeline-run-with-timeout-hello-world-1
+    pipelineTaskName: hello-world-1
+    kind: TaskRun
+`)}
+	ts := []*v1.Task{simpleHelloWorldTask}
+
+	trs := []*v1.TaskRun{parse.MustParseTaskRunWithObjectMeta(t, taskRunObjectMeta("test-pipeline-run-with-timeout-hello-world-1", "foo", "test-pipeline-run-with-timeout",
+		"test-pipeline", "hello-world-1", false), `
+spec:
+  serviceAccountName: test-sa
+  taskRef:
+    name: hello-world
+    kind: Task
+`)}
+
+	d := test.Data{
 		PipelineRuns: prs,
 		Pipelines:    ps,
 		Tasks:        ts,
@@ -2528,6 +2995,260 @@ spec:
 	}
 }
 
+func TestReconcile_ApprovalGate(t *testing.T) {
+	// TestReconcile_ApprovalGate runs "Reconcile" on a PipelineRun with an approval gate between its
+	// build and release PipelineTasks. It verifies that the PipelineRun waits for the approval once the
+	// gate is reached, and that the gate is approved, rejected or timed out according to the approvals
+	// added to the status of the PipelineRun and the timeout of the gate.
+	ps := []*v1.Pipeline{parse.MustParseV1Pipeline(t, `
+metadata:
+  name: test-pipeline
+  namespace: foo
+spec:
+  tasks:
+  - name: build
+    taskRef:
+      name: hello-world
+  - name: approve
+    runAfter: [build]
+    approvalGate:
+      approvers: [alice, bob]
+      timeout: 1h
+  - name: release
+    runAfter: [approve]
+    taskRef:
+      name: hello-world
+`)}
+	ts := []*v1.Task{simpleHelloWorldTask}
+	trs := []*v1.TaskRun{createHelloWorldTaskRunWithStatus(t, "test-pipeline-run-approval-build", "foo",
+		"test-pipeline-run-approval", "test-pipeline", "",
+		apis.Condition{Type: apis.ConditionSucceeded, Status: corev1.ConditionTrue})}
+
+	for _, tc := range []struct {
+		name                 string
+		status               string
+		wantGate             v1.ApprovalGateStatus
+		wantCondition        apis.Condition
+		wantEvents           []string
+		wantReleaseTaskRun   bool
+		wantSkippedTaskCause v1.SkippingReason
+	}{{
+		name: "gate reached",
+		wantGate: v1.ApprovalGateStatus{
+			PipelineTaskName: "approve",
+			Approvers:        []string{"alice", "bob"},
+			Reason:           v1.ApprovalGateReasonWaiting,
+			StartTime:        metav1.NewTime(now),
+		},
+		wantCondition: apis.Condition{
+			Type:    apis.ConditionSucceeded,
+			Status:  corev1.ConditionUnknown,
+			Reason:  v1.PipelineRunReasonWaitingForApproval.String(),
+			Message: `PipelineTask "approve" is waiting for the approval of one of: alice, bob`,
+		},
+		wantEvents: []string{
+			`Normal WaitingForApproval PipelineTask "approve" is waiting for the approval of one of: alice, bob`,
+			`Normal Started`,
+		},
+	}, {
+		name: "approved",
+		status: `
+  approvalGates:
+  - pipelineTaskName: approve
+    approvers: [alice, bob]
+    reason: WaitingForApproval
+    startTime: "2021-12-31T23:30:00Z"
+  approvals:
+  - pipelineTaskName: approve
+    approver: bob
+    decision: approve
+    time: "2021-12-31T23:45:00Z"
+`,
+		wantGate: v1.ApprovalGateStatus{
+			PipelineTaskName: "approve",
+			Approvers:        []string{"alice", "bob"},
+			Reason:           v1.ApprovalGateReasonApproved,
+			StartTime:        metav1.NewTime(now.Add(-30 * time.Minute)),
+			CompletionTime:   &metav1.Time{Time: now},
+		},
+		wantCondition: apis.Condition{
+			Type:    apis.ConditionSucceeded,
+			Status:  corev1.ConditionUnknown,
+			Reason:  v1.PipelineRunReasonRunning.String(),
+			Message: "Tasks Completed: 2 (Failed: 0, Cancelled 0), Incomplete: 1, Skipped: 0",
+		},
+		wantEvents: []string{
+			`Normal ApprovalGateApproved PipelineTask "approve" was approved by bob`,
+			`Normal Started`,
+		},
+		wantReleaseTaskRun: true,
+	}, {
+		name: "rejected",
+		status: `
+  approvalGates:
+  - pipelineTaskName: approve
+    approvers: [alice, bob]
+    reason: WaitingForApproval
+    startTime: "2021-12-31T23:30:00Z"
+  approvals:
+  - pipelineTaskName: approve
+    approver: alice
+    decision: reject
+    time: "2021-12-31T23:45:00Z"
+`,
+		wantGate: v1.ApprovalGateStatus{
+			PipelineTaskName: "approve",
+			Approvers:        []string{"alice", "bob"},
+			Reason:           v1.ApprovalGateReasonRejected,
+			StartTime:        metav1.NewTime(now.Add(-30 * time.Minute)),
+			CompletionTime:   &metav1.Time{Time: now},
+		},
+		wantCondition: apis.Condition{
+			Type:    apis.ConditionSucceeded,
+			Status:  corev1.ConditionFalse,
+			Reason:  v1.PipelineRunReasonApprovalRejected.String(),
+			Message: "Tasks Completed: 2 (Failed: 1, Cancelled 0), Skipped: 1",
+		},
+		wantEvents: []string{
+			`Warning ApprovalGateRejected PipelineTask "approve" was rejected by alice`,
+			`Warning Failed Tasks Completed: 2 \(Failed: 1, Cancelled 0\), Skipped: 1`,
+		},
+		wantSkippedTaskCause: v1.ApprovalRejectedSkip,
+	}, {
+		name: "timed out",
+		status: `
+  approvalGates:
+  - pipelineTaskName: approve
+    approvers: [alice, bob]
+    reason: WaitingForApproval
+    startTime: "2021-12-31T22:30:00Z"
+`,
+		wantGate: v1.ApprovalGateStatus{
+			PipelineTaskName: "approve",
+			Approvers:        []string{"alice", "bob"},
+			Reason:           v1.ApprovalGateReasonTimedOut,
+			StartTime:        metav1.NewTime(now.Add(-90 * time.Minute)),
+			CompletionTime:   &metav1.Time{Time: now},
+		},
+		wantCondition: apis.Condition{
+			Type:    apis.ConditionSucceeded,
+			Status:  corev1.ConditionFalse,
+			Reason:  v1.PipelineRunReasonApprovalTimedOut.String(),
+			Message: "Tasks Completed: 2 (Failed: 1, Cancelled 0), Skipped: 1",
+		},
+		wantEvents: []string{
+			`Warning ApprovalGateTimedOut PipelineTask "approve" wasn't approved within 1h0m0s`,
+			`Warning Failed Tasks Completed: 2 \(Failed: 1, Cancelled 0\), Skipped: 1`,
+		},
+		wantSkippedTaskCause: v1.ApprovalTimedOutSkip,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			prs := []*v1.PipelineRun{parse.MustParseV1PipelineRun(t, `
+metadata:
+  name: test-pipeline-run-approval
+  namespace: foo
+spec:
+  pipelineRef:
+    name: test-pipeline
+  timeouts:
+    pipeline: 12h0m0s
+status:
+  startTime: "2021-12-31T22:00:00Z"
+  childReferences:
+  - name: test-pipeline-run-approval-build
+    pipelineTaskName: build
+    kind: TaskRun
+`+tc.status)}
+			d := test.Data{
+				PipelineRuns: prs,
+				Pipelines:    ps,
+				Tasks:        ts,
+				TaskRuns:     trs,
+				ConfigMaps:   th.NewAlphaFeatureFlagsConfigMapInSlice(),
+			}
+			prt := newPipelineRunTest(t, d)
+			defer prt.Cancel()
+
+			reconciledRun, clients := prt.reconcileRun("foo", "test-pipeline-run-approval", tc.wantEvents, false)
+
+			if d := cmp.Diff([]v1.ApprovalGateStatus{tc.wantGate}, reconciledRun.Status.ApprovalGates); d != "" {
+				t.Errorf("Unexpected approval gates %s", diff.PrintWantGot(d))
+			}
+			if d := cmp.Diff(tc.wantCondition, *reconciledRun.Status.GetCondition(apis.ConditionSucceeded), ignoreLastTransitionTime); d != "" {
+				t.Errorf("Unexpected condition %s", diff.PrintWantGot(d))
+			}
+
+			taskRuns := getTaskRunsForPipelineRun(prt.TestAssets.Ctx, t, clients, "foo", "test-pipeline-run-approval")
+			_, hasReleaseTaskRun := taskRuns["test-pipeline-run-approval-release"]
+			if hasReleaseTaskRun != tc.wantReleaseTaskRun {
+				t.Errorf("Expected the TaskRun of the release task to be created: %t, but was: %t", tc.wantReleaseTaskRun, hasReleaseTaskRun)
+			}
+
+			var skippedReason v1.SkippingReason
+			for _, skipped := range reconciledRun.Status.SkippedTasks {
+				if skipped.Name == "release" {
+					skippedReason = skipped.Reason
+				}
+			}
+			if skippedReason != tc.wantSkippedTaskCause {
+				t.Errorf("Expected the release task to be skipped with %q, but was skipped with %q", tc.wantSkippedTaskCause, skippedReason)
+			}
+		})
+	}
+}
+
+func TestReconcile_ApprovalGateCancelled(t *testing.T) {
+	// TestReconcile_ApprovalGateCancelled runs "Reconcile" on a cancelled PipelineRun waiting for an approval
+	// and verifies that the approval gate is cancelled too.
+	ps := []*v1.Pipeline{parse.MustParseV1Pipeline(t, `
+metadata:
+  name: test-pipeline
+  namespace: foo
+spec:
+  tasks:
+  - name: approve
+    approvalGate:
+      approvers: [alice]
+`)}
+	prs := []*v1.PipelineRun{parse.MustParseV1PipelineRun(t, `
+metadata:
+  name: test-pipeline-run-approval-cancelled
+  namespace: foo
+spec:
+  pipelineRef:
+    name: test-pipeline
+  status: Cancelled
+status:
+  approvalGates:
+  - pipelineTaskName: approve
+    approvers: [alice]
+    reason: WaitingForApproval
+    startTime: "2021-12-31T23:30:00Z"
+`)}
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		ConfigMaps:   th.NewAlphaFeatureFlagsConfigMapInSlice(),
+	}
+	prt := newPipelineRunTest(t, d)
+	defer prt.Cancel()
+
+	reconciledRun, _ := prt.reconcileRun("foo", "test-pipeline-run-approval-cancelled", []string{
+		"Normal Started",
+		"Warning Failed PipelineRun \"test-pipeline-run-approval-cancelled\" was cancelled",
+	}, false)
+
+	if reason := reconciledRun.Status.GetCondition(apis.ConditionSucceeded).Reason; reason != v1.PipelineRunReasonCancelled.String() {
+		t.Errorf("Expected the PipelineRun to be cancelled, but the condition reason is %s", reason)
+	}
+	if len(reconciledRun.Status.ApprovalGates) != 1 {
+		t.Fatalf("Expected one approval gate, found %d", len(reconciledRun.Status.ApprovalGates))
+	}
+	if gate := reconciledRun.Status.ApprovalGates[0]; gate.Reason != v1.ApprovalGateReasonCancelled || gate.CompletionTime == nil {
+		t.Errorf("Expected the approval gate to be cancelled, but was %s completed at %v", gate.Reason, gate.CompletionTime)
+	}
+}
+
 func TestReconcileWithTimeoutGreaterThan24h(t *testing.T) {
 	testCases := []struct {
 		name      string
@@ -4016,6 +4737,83 @@ spec:
 	}
 }
 
+func TestReconcileWithServiceAccountPatterns(t *testing.T) {
+	names.TestingSeed()
+
+	ps := []*v1.Pipeline{parse.MustParseV1Pipeline(t, `
+metadata:
+  name: test-pipeline
+  namespace: foo
+spec:
+  tasks:
+  - name: build
+    taskRef:
+      name: hello-world-task
+  - name: deploy-us
+    taskRef:
+      name: hello-world-task
+    matrix:
+      params:
+      - name: region
+        value: [us-east, us-west]
+  - name: deploy-eu
+    taskRef:
+      name: hello-world-task
+`)}
+	prs := []*v1.PipelineRun{parse.MustParseV1PipelineRun(t, `
+metadata:
+  name: test-pipeline-run-service-account-patterns
+  namespace: foo
+spec:
+  pipelineRef:
+    name: test-pipeline
+  taskRunTemplate:
+    serviceAccountName: test-sa-0
+  taskRunSpecs:
+  - serviceAccountName: deployer
+    pipelineTaskName: deploy-*
+  - serviceAccountName: eu-deployer
+    pipelineTaskName: deploy-eu
+`)}
+	ts := []*v1.Task{parse.MustParseV1Task(t, `
+metadata:
+  name: hello-world-task
+  namespace: foo
+spec:
+  params:
+  - name: region
+    default: eu-west
+`)}
+
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+	}
+	prt := newPipelineRunTest(t, d)
+	defer prt.Cancel()
+
+	_, clients := prt.reconcileRun("foo", "test-pipeline-run-service-account-patterns", []string{}, false)
+
+	// The matrixed TaskRuns of deploy-us match the pattern, and the spec named after deploy-eu takes
+	// precedence over it.
+	wantServiceAccounts := map[string]string{
+		"test-pipeline-run-service-account-patterns-build":       "test-sa-0",
+		"test-pipeline-run-service-account-patterns-deploy-us-0": "deployer",
+		"test-pipeline-run-service-account-patterns-deploy-us-1": "deployer",
+		"test-pipeline-run-service-account-patterns-deploy-eu":   "eu-deployer",
+	}
+	for name, want := range wantServiceAccounts {
+		actual, err := clients.Pipeline.TektonV1().TaskRuns("foo").Get(prt.TestAssets.Ctx, name, metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("Expected a TaskRun to be created, but it wasn't: %s", err)
+		}
+		if actual.Spec.ServiceAccountName != want {
+			t.Errorf("TaskRun %s has service account %q, want %q", name, actual.Spec.ServiceAccountName, want)
+		}
+	}
+}
+
 func TestReconcileCustomTasksWithDifferentServiceAccounts(t *testing.T) {
 	names.TestingSeed()
 
@@ -4216,6 +5014,8 @@ metadata:
 spec:
   pipelineRef:
     name: test-pipeline
+  timeouts:
+    pipeline: 3h0m0s
   taskRunSpecs:
   - pipelineTaskName: hello-world-1
     timeout: "2h"
@@ -4245,27 +5045,16 @@ spec:
 	}
 }
 
-// TestReconcileTaskRunSpecTimeoutPrecedence tests that taskRunSpec timeout
-// takes precedence over pipelineTask timeout
-func TestReconcileTaskRunSpecTimeoutPrecedence(t *testing.T) {
+// TestReconcileTimeoutCappedToMaximumTaskRunTimeout tests that the timeout a TaskRun gets from
+// the timeouts of its PipelineRun is capped to the maximum TaskRun duration
+func TestReconcileTimeoutCappedToMaximumTaskRunTimeout(t *testing.T) {
 	names.TestingSeed()
 
 	namespace := "foo"
 	prName := "test-pipeline-run"
 	trName := "test-pipeline-run-hello-world-1"
 
-	ps := []*v1.Pipeline{parse.MustParseV1Pipeline(t, `
-metadata:
-  name: test-pipeline
-  namespace: foo
-spec:
-  tasks:
-  - name: hello-world-1
-    taskRef:
-      name: hello-world
-      kind: Task
-    timeout: "1h"
-`)}
+	ps := []*v1.Pipeline{simpleHelloWorldPipeline}
 	prs := []*v1.PipelineRun{parse.MustParseV1PipelineRun(t, `
 metadata:
   name: test-pipeline-run
@@ -4273,9 +5062,71 @@ metadata:
 spec:
   pipelineRef:
     name: test-pipeline
-  taskRunSpecs:
-  - pipelineTaskName: hello-world-1
-    timeout: "30m"
+  timeouts:
+    pipeline: 4h0m0s
+    tasks: 3h0m0s
+`)}
+	ts := []*v1.Task{simpleHelloWorldTask}
+
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+		ConfigMaps: []*corev1.ConfigMap{{
+			ObjectMeta: metav1.ObjectMeta{Name: config.GetDefaultsConfigName(), Namespace: system.Namespace()},
+			Data: map[string]string{
+				"default-maximum-taskrun-timeout": "90m",
+			},
+		}},
+	}
+	prt := newPipelineRunTest(t, d)
+	defer prt.Cancel()
+
+	_, clients := prt.reconcileRun("foo", prName, []string{}, false)
+
+	taskRuns := getTaskRunsForPipelineRun(prt.TestAssets.Ctx, t, clients, namespace, prName)
+	validateTaskRunsCount(t, taskRuns, 1)
+
+	actual := getTaskRunByName(t, taskRuns, trName)
+	expectedTimeout := metav1.Duration{Duration: 90 * time.Minute}
+	if actual.Spec.Timeout == nil {
+		t.Errorf("expected TaskRun timeout to be set, but was nil")
+	} else if *actual.Spec.Timeout != expectedTimeout {
+		t.Errorf("expected TaskRun timeout to be %v, but was %v", expectedTimeout, *actual.Spec.Timeout)
+	}
+}
+
+// TestReconcileTaskRunSpecTimeoutPrecedence tests that taskRunSpec timeout
+// takes precedence over pipelineTask timeout
+func TestReconcileTaskRunSpecTimeoutPrecedence(t *testing.T) {
+	names.TestingSeed()
+
+	namespace := "foo"
+	prName := "test-pipeline-run"
+	trName := "test-pipeline-run-hello-world-1"
+
+	ps := []*v1.Pipeline{parse.MustParseV1Pipeline(t, `
+metadata:
+  name: test-pipeline
+  namespace: foo
+spec:
+  tasks:
+  - name: hello-world-1
+    taskRef:
+      name: hello-world
+      kind: Task
+    timeout: "1h"
+`)}
+	prs := []*v1.PipelineRun{parse.MustParseV1PipelineRun(t, `
+metadata:
+  name: test-pipeline-run
+  namespace: foo
+spec:
+  pipelineRef:
+    name: test-pipeline
+  taskRunSpecs:
+  - pipelineTaskName: hello-world-1
+    timeout: "30m"
 `)}
 	ts := []*v1.Task{simpleHelloWorldTask}
 
@@ -4443,7 +5294,7 @@ spec:
     finally: 10m0s`,
 		expectedTimeout: &metav1.Duration{Duration: 110 * time.Minute},
 	}, {
-		name: "computed tasks timeout below default not propagated",
+		name: "computed tasks timeout below default capped to the remaining tasks timeout",
 		pipeline: `
 metadata:
   name: test-pipeline
@@ -4463,7 +5314,7 @@ spec:
   timeouts:
     pipeline: 1h0m0s
     finally: 20m0s`,
-		expectedTimeout: nil,
+		expectedTimeout: &metav1.Duration{Duration: 40 * time.Minute},
 	}, {
 		name: "timeouts.tasks zero means no timeout",
 		pipeline: `
@@ -4487,7 +5338,7 @@ spec:
     tasks: "0"`,
 		expectedTimeout: &metav1.Duration{Duration: 0},
 	}, {
-		name: "timeouts.tasks smaller than global default not propagated - cumulative enforcement handles it",
+		name: "timeouts.tasks smaller than global default capped to the remaining tasks timeout",
 		pipeline: `
 metadata:
   name: test-pipeline
@@ -4507,7 +5358,7 @@ spec:
   timeouts:
     pipeline: 1h0m0s
     tasks: 30m0s`,
-		expectedTimeout: nil,
+		expectedTimeout: &metav1.Duration{Duration: 30 * time.Minute},
 	}, {
 		name: "all three set - taskRunSpecs wins over pipelineTask and timeouts.tasks",
 		pipeline: `
@@ -4577,30 +5428,57 @@ spec:
 	}
 }
 
-// TestReconcileFinallyTimeoutPropagatedToTaskRun tests that spec.timeouts.finally
-// is propagated to finally TaskRuns when no per-task timeout is set.
-func TestReconcileFinallyTimeoutPropagatedToTaskRun(t *testing.T) {
-	names.TestingSeed()
+// TestReconcileTimeoutRemaining tests that TaskRuns created close to the PipelineRun deadline get
+// their timeout capped to the remaining time, which is also exposed via $(context.pipelineRun.timeoutRemaining)
+func TestReconcileTimeoutRemaining(t *testing.T) {
+	tcs := []struct {
+		name                 string
+		pipelineTaskTimeout  string
+		pipelineTimeout      string
+		expectedTimeout      *metav1.Duration
+		wantTimeoutRemaining string
+	}{{
+		name:                 "task timeout beyond the deadline is capped",
+		pipelineTaskTimeout:  "10m",
+		pipelineTimeout:      "1h0m0s",
+		expectedTimeout:      &metav1.Duration{Duration: 5 * time.Minute},
+		wantTimeoutRemaining: "5m0s",
+	}, {
+		name:                 "task timeout within the deadline is kept",
+		pipelineTaskTimeout:  "2m",
+		pipelineTimeout:      "1h0m0s",
+		expectedTimeout:      &metav1.Duration{Duration: 2 * time.Minute},
+		wantTimeoutRemaining: "5m0s",
+	}, {
+		name:                 "no pipeline timeout",
+		pipelineTaskTimeout:  "10m",
+		pipelineTimeout:      "0",
+		expectedTimeout:      &metav1.Duration{Duration: 10 * time.Minute},
+		wantTimeoutRemaining: "0s",
+	}}
 
-	namespace := "foo"
-	prName := "test-pipeline-run"
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			names.TestingSeed()
+			namespace := "foo"
+			prName := "test-pipeline-run"
+			trName := "test-pipeline-run-hello-world-1"
 
-	ps := []*v1.Pipeline{parse.MustParseV1Pipeline(t, `
+			ps := []*v1.Pipeline{parse.MustParseV1Pipeline(t, fmt.Sprintf(`
 metadata:
   name: test-pipeline
   namespace: foo
 spec:
   tasks:
-  - name: task1
+  - name: hello-world-1
     taskRef:
       name: hello-world
-  finally:
-  - name: final-task-1
-    taskRef:
-      name: some-task
-`)}
-
-	prs := []*v1.PipelineRun{parse.MustParseV1PipelineRun(t, `
+    timeout: %q
+    params:
+    - name: timeout-remaining
+      value: $(context.pipelineRun.timeoutRemaining)
+`, tc.pipelineTaskTimeout))}
+			prs := []*v1.PipelineRun{parse.MustParseV1PipelineRun(t, fmt.Sprintf(`
 metadata:
   name: test-pipeline-run
   namespace: foo
@@ -4608,67 +5486,181 @@ spec:
   pipelineRef:
     name: test-pipeline
   timeouts:
-    pipeline: 3h0m0s
-    tasks: 1h50m0s
-    finally: 1h10m0s
+    pipeline: %q
 status:
-  startTime: "2022-01-01T00:00:00Z"
-  childReferences:
-  - apiVersion: tekton.dev/v1
+  conditions:
+  - message: running...
+    reason: Running
+    status: Unknown
+    type: Succeeded
+  startTime: "2021-12-31T23:05:00Z"
+`, tc.pipelineTimeout))}
+			ts := []*v1.Task{simpleHelloWorldTask}
+
+			d := test.Data{
+				PipelineRuns: prs,
+				Pipelines:    ps,
+				Tasks:        ts,
+			}
+			prt := newPipelineRunTest(t, d)
+			defer prt.Cancel()
+
+			_, clients := prt.reconcileRun(namespace, prName, []string{}, false)
+
+			taskRuns := getTaskRunsForPipelineRun(prt.TestAssets.Ctx, t, clients, namespace, prName)
+			validateTaskRunsCount(t, taskRuns, 1)
+
+			actual := getTaskRunByName(t, taskRuns, trName)
+			if actual.Spec.Timeout == nil {
+				t.Errorf("expected TaskRun timeout to be %v, but was nil", tc.expectedTimeout)
+			} else if *actual.Spec.Timeout != *tc.expectedTimeout {
+				t.Errorf("expected TaskRun timeout to be %v, but was %v", tc.expectedTimeout, actual.Spec.Timeout)
+			}
+			wantParams := v1.Params{{Name: "timeout-remaining", Value: *v1.NewStructuredValues(tc.wantTimeoutRemaining)}}
+			if d := cmp.Diff(wantParams, actual.Spec.Params); d != "" {
+				t.Errorf("expected TaskRun params to match: %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+// TestReconcileFinallyTimeoutRemaining tests that finally TaskRuns get their timeout set to the time left
+// before the finally timeout when they are created, which is also exposed via
+// $(context.pipelineRun.finallyTimeoutRemaining), so that final tasks scheduled later get a smaller budget
+func TestReconcileFinallyTimeoutRemaining(t *testing.T) {
+	prName := "test-pipeline-run-finally-timeout-remaining"
+	ps := []*v1.Pipeline{parse.MustParseV1Pipeline(t, `
+metadata:
+  name: test-pipeline-with-finally
+  namespace: foo
+spec:
+  tasks:
+  - name: task1
+    taskRef:
+      name: hello-world
+  finally:
+  - name: finaltask-1
+    taskRef:
+      name: hello-world
+    params:
+    - name: finally-timeout-remaining
+      value: $(context.pipelineRun.finallyTimeoutRemaining)
+  - name: finaltask-2
+    taskRef:
+      name: hello-world
+    params:
+    - name: finally-timeout-remaining
+      value: $(context.pipelineRun.finallyTimeoutRemaining)
+`)}
+	ts := []*v1.Task{simpleHelloWorldTask}
+	dagTaskRun := getTaskRun(t, prName+"-task1", prName, "test-pipeline-with-finally", "task1", corev1.ConditionTrue)
+	dagChildReference := `
+  - name: test-pipeline-run-finally-timeout-remaining-task1
+    apiVersion: tekton.dev/v1
     kind: TaskRun
-    name: test-pipeline-run-task1
     pipelineTaskName: task1
     status:
       conditions:
       - lastTransitionTime: null
         status: "True"
-        type: Succeeded
-`)}
-
-	trs := []*v1.TaskRun{
-		getTaskRun(t, "test-pipeline-run-task1", prName, "test-pipeline", "task1", corev1.ConditionTrue),
-	}
-	ts := []*v1.Task{simpleHelloWorldTask, simpleSomeTask}
+        type: Succeeded`
 
-	d := test.Data{
-		PipelineRuns: prs,
-		Pipelines:    ps,
-		Tasks:        ts,
-		TaskRuns:     trs,
-	}
-	prt := newPipelineRunTest(t, d)
-	defer prt.Cancel()
+	tcs := []struct {
+		name                 string
+		trs                  []*v1.TaskRun
+		finallyStatus        string
+		wantTaskRunNames     []string
+		wantTimeout          time.Duration
+		wantTimeoutRemaining string
+	}{{
+		name:                 "final tasks scheduled when finally starts get the whole finally timeout",
+		trs:                  []*v1.TaskRun{dagTaskRun},
+		wantTaskRunNames:     []string{prName + "-finaltask-1", prName + "-finaltask-2"},
+		wantTimeout:          8 * time.Minute,
+		wantTimeoutRemaining: "8m0s",
+	}, {
+		name: "final task scheduled after finally started gets the time left",
+		trs: []*v1.TaskRun{dagTaskRun, getTaskRun(t, prName+"-finaltask-1", prName, "test-pipeline-with-finally", "finaltask-1",
+			corev1.ConditionUnknown)},
+		finallyStatus: `
+  finallyStartTime: "2021-12-31T23:55:00Z"
+  childReferences:` + dagChildReference + `
+  - name: test-pipeline-run-finally-timeout-remaining-finaltask-1
+    apiVersion: tekton.dev/v1
+    kind: TaskRun
+    pipelineTaskName: finaltask-1
+    status:
+      conditions:
+      - lastTransitionTime: null
+        status: "Unknown"
+        type: Succeeded`,
+		wantTaskRunNames:     []string{prName + "-finaltask-2"},
+		wantTimeout:          3 * time.Minute,
+		wantTimeoutRemaining: "3m0s",
+	}}
 
-	_, clients := prt.reconcileRun(namespace, prName, []string{}, false)
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			finallyStatus := tc.finallyStatus
+			if finallyStatus == "" {
+				finallyStatus = `
+  childReferences:` + dagChildReference
+			}
+			prs := []*v1.PipelineRun{parse.MustParseV1PipelineRun(t, fmt.Sprintf(`
+metadata:
+  name: %s
+  namespace: foo
+spec:
+  pipelineRef:
+    name: test-pipeline-with-finally
+  timeouts:
+    pipeline: 1h
+    finally: 8m
+status:
+  conditions:
+  - message: running...
+    reason: Running
+    status: Unknown
+    type: Succeeded
+  startTime: "2021-12-31T23:30:00Z"%s
+`, prName, finallyStatus))}
 
-	taskRuns := getTaskRunsForPipelineRun(prt.TestAssets.Ctx, t, clients, namespace, prName)
+			d := test.Data{
+				PipelineRuns: prs,
+				Pipelines:    ps,
+				Tasks:        ts,
+				TaskRuns:     tc.trs,
+			}
+			prt := newPipelineRunTest(t, d)
+			defer prt.Cancel()
 
-	var finallyTaskRun *v1.TaskRun
-	for name, tr := range taskRuns {
-		if name != "test-pipeline-run-task1" {
-			finallyTaskRun = tr
-			break
-		}
-	}
-	if finallyTaskRun == nil {
-		t.Fatal("expected a finally TaskRun to be created, but none was found")
-	}
+			_, clients := prt.reconcileRun("foo", prName, []string{}, false)
 
-	expectedTimeout := metav1.Duration{Duration: 70 * time.Minute}
-	if finallyTaskRun.Spec.Timeout == nil {
-		t.Errorf("expected finally TaskRun timeout to be %v, but was nil", expectedTimeout)
-	} else if *finallyTaskRun.Spec.Timeout != expectedTimeout {
-		t.Errorf("expected finally TaskRun timeout to be %v, but was %v", expectedTimeout, finallyTaskRun.Spec.Timeout)
+			taskRuns := getTaskRunsForPipelineRun(prt.TestAssets.Ctx, t, clients, "foo", prName)
+			for _, trName := range tc.wantTaskRunNames {
+				actual := getTaskRunByName(t, taskRuns, trName)
+				if actual.Spec.Timeout == nil {
+					t.Errorf("expected TaskRun %s timeout to be %v, but was nil", trName, tc.wantTimeout)
+				} else if actual.Spec.Timeout.Duration != tc.wantTimeout {
+					t.Errorf("expected TaskRun %s timeout to be %v, but was %v", trName, tc.wantTimeout, actual.Spec.Timeout.Duration)
+				}
+				wantParams := v1.Params{{Name: "finally-timeout-remaining", Value: *v1.NewStructuredValues(tc.wantTimeoutRemaining)}}
+				if d := cmp.Diff(wantParams, actual.Spec.Params); d != "" {
+					t.Errorf("expected TaskRun %s params to match: %s", trName, diff.PrintWantGot(d))
+				}
+			}
+		})
 	}
 }
 
-// TestReconcileCustomRunSpecTimeout tests that timeout specified in taskRunSpecs
-// is applied to CustomRuns
-func TestReconcileCustomRunSpecTimeout(t *testing.T) {
+// TestReconcileExpectedDurationPropagatedToTaskRun tests that the expectedDuration of a
+// PipelineTask is passed on to its TaskRun through an annotation.
+func TestReconcileExpectedDurationPropagatedToTaskRun(t *testing.T) {
 	names.TestingSeed()
 
 	namespace := "foo"
 	prName := "test-pipeline-run"
+	trName := "test-pipeline-run-hello-world-1"
 
 	ps := []*v1.Pipeline{parse.MustParseV1Pipeline(t, `
 metadata:
@@ -4678,8 +5670,8 @@ spec:
   tasks:
   - name: hello-world-1
     taskRef:
-      apiVersion: example.dev/v0
-      kind: Example
+      name: hello-world
+    expectedDuration: 90s
 `)}
 	prs := []*v1.PipelineRun{parse.MustParseV1PipelineRun(t, `
 metadata:
@@ -4688,35 +5680,246 @@ metadata:
 spec:
   pipelineRef:
     name: test-pipeline
-  taskRunSpecs:
-  - pipelineTaskName: hello-world-1
-    timeout: "45m"
 `)}
+	cms := []*corev1.ConfigMap{{
+		ObjectMeta: metav1.ObjectMeta{Name: config.GetFeatureFlagsConfigName(), Namespace: system.Namespace()},
+		Data: map[string]string{
+			"enable-api-fields": config.AlphaAPIFields,
+		},
+	}}
 
 	d := test.Data{
 		PipelineRuns: prs,
 		Pipelines:    ps,
+		Tasks:        []*v1.Task{simpleHelloWorldTask},
+		ConfigMaps:   cms,
 	}
 	prt := newPipelineRunTest(t, d)
 	defer prt.Cancel()
 
-	_, clients := prt.reconcileRun("foo", prName, []string{}, false)
+	_, clients := prt.reconcileRun(namespace, prName, []string{}, false)
 
-	// Check that the expected CustomRun was created with correct timeout
-	customRuns, err := clients.Pipeline.TektonV1beta1().CustomRuns(namespace).List(prt.TestAssets.Ctx, metav1.ListOptions{
-		LabelSelector: "tekton.dev/pipelineRun=" + prName,
-	})
-	if err != nil {
-		t.Fatalf("Failure to list CustomRuns: %s", err)
-	}
+	taskRuns := getTaskRunsForPipelineRun(prt.TestAssets.Ctx, t, clients, namespace, prName)
+	validateTaskRunsCount(t, taskRuns, 1)
 
-	if len(customRuns.Items) != 1 {
-		t.Fatalf("Expected 1 CustomRun but got %d", len(customRuns.Items))
+	actual := getTaskRunByName(t, taskRuns, trName)
+	if got := actual.Annotations[v1.PipelineTaskExpectedDurationAnnotation]; got != "1m30s" {
+		t.Errorf("expected TaskRun annotation %s to be %q, but was %q", v1.PipelineTaskExpectedDurationAnnotation, "1m30s", got)
 	}
+}
 
-	actual := &customRuns.Items[0]
-	expectedTimeout := metav1.Duration{Duration: 45 * time.Minute}
-	if actual.Spec.Timeout == nil {
+// TestReconcilePipelineTaskMetadataPropagatedToTaskRun tests that the labels, display name and
+// description of a PipelineTask are passed on to its TaskRun, and that its labels are reported
+// in the child references of the PipelineRun.
+func TestReconcilePipelineTaskMetadataPropagatedToTaskRun(t *testing.T) {
+	names.TestingSeed()
+
+	namespace := "foo"
+	prName := "test-pipeline-run"
+	trName := "test-pipeline-run-hello-world-1"
+
+	ps := []*v1.Pipeline{parse.MustParseV1Pipeline(t, `
+metadata:
+  name: test-pipeline
+  namespace: foo
+spec:
+  tasks:
+  - name: hello-world-1
+    displayName: Hello World
+    description: Says hello to the world
+    labels:
+      app: hello
+      team: greetings
+    taskRef:
+      name: hello-world
+`)}
+	prs := []*v1.PipelineRun{parse.MustParseV1PipelineRun(t, `
+metadata:
+  name: test-pipeline-run
+  namespace: foo
+  labels:
+    team: pipelines
+spec:
+  pipelineRef:
+    name: test-pipeline
+`)}
+
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        []*v1.Task{simpleHelloWorldTask},
+	}
+	prt := newPipelineRunTest(t, d)
+	defer prt.Cancel()
+
+	reconciledRun, clients := prt.reconcileRun(namespace, prName, []string{}, false)
+
+	taskRuns := getTaskRunsForPipelineRun(prt.TestAssets.Ctx, t, clients, namespace, prName)
+	validateTaskRunsCount(t, taskRuns, 1)
+
+	actual := getTaskRunByName(t, taskRuns, trName)
+	for key, want := range map[string]string{"app": "hello", "team": "greetings"} {
+		if got := actual.Labels[key]; got != want {
+			t.Errorf("expected TaskRun label %s to be %q, but was %q", key, want, got)
+		}
+	}
+	for key, want := range map[string]string{
+		v1.PipelineTaskDisplayNameAnnotation: "Hello World",
+		v1.PipelineTaskDescriptionAnnotation: "Says hello to the world",
+	} {
+		if got := actual.Annotations[key]; got != want {
+			t.Errorf("expected TaskRun annotation %s to be %q, but was %q", key, want, got)
+		}
+	}
+
+	if len(reconciledRun.Status.ChildReferences) != 1 {
+		t.Fatalf("expected 1 child reference, got %d", len(reconciledRun.Status.ChildReferences))
+	}
+	if d := cmp.Diff(map[string]string{"app": "hello", "team": "greetings"}, reconciledRun.Status.ChildReferences[0].Labels); d != "" {
+		t.Errorf("child reference labels diff %s", diff.PrintWantGot(d))
+	}
+}
+
+// TestReconcileFinallyTimeoutPropagatedToTaskRun tests that spec.timeouts.finally
+// is propagated to finally TaskRuns when no per-task timeout is set.
+func TestReconcileFinallyTimeoutPropagatedToTaskRun(t *testing.T) {
+	names.TestingSeed()
+
+	namespace := "foo"
+	prName := "test-pipeline-run"
+
+	ps := []*v1.Pipeline{parse.MustParseV1Pipeline(t, `
+metadata:
+  name: test-pipeline
+  namespace: foo
+spec:
+  tasks:
+  - name: task1
+    taskRef:
+      name: hello-world
+  finally:
+  - name: final-task-1
+    taskRef:
+      name: some-task
+`)}
+
+	prs := []*v1.PipelineRun{parse.MustParseV1PipelineRun(t, `
+metadata:
+  name: test-pipeline-run
+  namespace: foo
+spec:
+  pipelineRef:
+    name: test-pipeline
+  timeouts:
+    pipeline: 3h0m0s
+    tasks: 1h50m0s
+    finally: 1h10m0s
+status:
+  startTime: "2022-01-01T00:00:00Z"
+  childReferences:
+  - apiVersion: tekton.dev/v1
+    kind: TaskRun
+    name: test-pipeline-run-task1
+    pipelineTaskName: task1
+    status:
+      conditions:
+      - lastTransitionTime: null
+        status: "True"
+        type: Succeeded
+`)}
+
+	trs := []*v1.TaskRun{
+		getTaskRun(t, "test-pipeline-run-task1", prName, "test-pipeline", "task1", corev1.ConditionTrue),
+	}
+	ts := []*v1.Task{simpleHelloWorldTask, simpleSomeTask}
+
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+		TaskRuns:     trs,
+	}
+	prt := newPipelineRunTest(t, d)
+	defer prt.Cancel()
+
+	_, clients := prt.reconcileRun(namespace, prName, []string{}, false)
+
+	taskRuns := getTaskRunsForPipelineRun(prt.TestAssets.Ctx, t, clients, namespace, prName)
+
+	var finallyTaskRun *v1.TaskRun
+	for name, tr := range taskRuns {
+		if name != "test-pipeline-run-task1" {
+			finallyTaskRun = tr
+			break
+		}
+	}
+	if finallyTaskRun == nil {
+		t.Fatal("expected a finally TaskRun to be created, but none was found")
+	}
+
+	expectedTimeout := metav1.Duration{Duration: 70 * time.Minute}
+	if finallyTaskRun.Spec.Timeout == nil {
+		t.Errorf("expected finally TaskRun timeout to be %v, but was nil", expectedTimeout)
+	} else if *finallyTaskRun.Spec.Timeout != expectedTimeout {
+		t.Errorf("expected finally TaskRun timeout to be %v, but was %v", expectedTimeout, finallyTaskRun.Spec.Timeout)
+	}
+}
+
+// TestReconcileCustomRunSpecTimeout tests that timeout specified in taskRunSpecs
+// is applied to CustomRuns
+func TestReconcileCustomRunSpecTimeout(t *testing.T) {
+	names.TestingSeed()
+
+	namespace := "foo"
+	prName := "test-pipeline-run"
+
+	ps := []*v1.Pipeline{parse.MustParseV1Pipeline(t, `
+metadata:
+  name: test-pipeline
+  namespace: foo
+spec:
+  tasks:
+  - name: hello-world-1
+    taskRef:
+      apiVersion: example.dev/v0
+      kind: Example
+`)}
+	prs := []*v1.PipelineRun{parse.MustParseV1PipelineRun(t, `
+metadata:
+  name: test-pipeline-run
+  namespace: foo
+spec:
+  pipelineRef:
+    name: test-pipeline
+  taskRunSpecs:
+  - pipelineTaskName: hello-world-1
+    timeout: "45m"
+`)}
+
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+	}
+	prt := newPipelineRunTest(t, d)
+	defer prt.Cancel()
+
+	_, clients := prt.reconcileRun("foo", prName, []string{}, false)
+
+	// Check that the expected CustomRun was created with correct timeout
+	customRuns, err := clients.Pipeline.TektonV1beta1().CustomRuns(namespace).List(prt.TestAssets.Ctx, metav1.ListOptions{
+		LabelSelector: "tekton.dev/pipelineRun=" + prName,
+	})
+	if err != nil {
+		t.Fatalf("Failure to list CustomRuns: %s", err)
+	}
+
+	if len(customRuns.Items) != 1 {
+		t.Fatalf("Expected 1 CustomRun but got %d", len(customRuns.Items))
+	}
+
+	actual := &customRuns.Items[0]
+	expectedTimeout := metav1.Duration{Duration: 45 * time.Minute}
+	if actual.Spec.Timeout == nil {
 		t.Errorf("expected CustomRun timeout to be set, but was nil")
 	} else if *actual.Spec.Timeout != expectedTimeout {
 		t.Errorf("expected CustomRun timeout to be %v, but was %v", expectedTimeout, *actual.Spec.Timeout)
@@ -4959,6 +6162,92 @@ spec:
 	}
 }
 
+// TestReconcileWithWhenExpressionsOnWorkspaceBound tests that the pipeline tasks guarded by the bound state of an
+// optional workspace are run when the PipelineRun binds it, and skipped otherwise.
+func TestReconcileWithWhenExpressionsOnWorkspaceBound(t *testing.T) {
+	ps := []*v1.Pipeline{parse.MustParseV1Pipeline(t, `
+metadata:
+  name: test-pipeline
+  namespace: foo
+spec:
+  workspaces:
+  - name: cache
+    optional: true
+  tasks:
+  - name: build
+    taskRef:
+      name: hello-world
+  - name: upload
+    taskRef:
+      name: hello-world
+    when:
+    - input: "$(workspaces.cache.bound)"
+      operator: in
+      values: ["true"]
+`)}
+	for _, tc := range []struct {
+		name             string
+		workspaces       string
+		wantTaskRuns     []string
+		wantSkippedTasks []v1.SkippedTask
+	}{{
+		name: "bound",
+		workspaces: `
+  workspaces:
+  - name: cache
+    emptyDir: {}`,
+		wantTaskRuns: []string{"test-pipeline-run-build", "test-pipeline-run-upload"},
+	}, {
+		name:         "unbound",
+		wantTaskRuns: []string{"test-pipeline-run-build"},
+		wantSkippedTasks: []v1.SkippedTask{{
+			Name:   "upload",
+			Reason: v1.WhenExpressionsSkip,
+			WhenExpressions: v1.WhenExpressions{{
+				Input:    "false",
+				Operator: "in",
+				Values:   []string{"true"},
+			}},
+		}},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			prs := []*v1.PipelineRun{parse.MustParseV1PipelineRun(t, `
+metadata:
+  name: test-pipeline-run
+  namespace: foo
+spec:
+  pipelineRef:
+    name: test-pipeline`+tc.workspaces+`
+`)}
+			d := test.Data{
+				PipelineRuns: prs,
+				Pipelines:    ps,
+				Tasks:        []*v1.Task{simpleHelloWorldTask},
+				ConfigMaps:   th.NewFeatureFlagsConfigMapInSlice(),
+			}
+			prt := newPipelineRunTest(t, d)
+			defer prt.Cancel()
+
+			pipelineRun, clients := prt.reconcileRun("foo", "test-pipeline-run", []string{}, false)
+			taskRuns, err := clients.Pipeline.TektonV1().TaskRuns("foo").List(prt.TestAssets.Ctx, metav1.ListOptions{})
+			if err != nil {
+				t.Fatalf("Failure to list TaskRun's %s", err)
+			}
+			var names []string
+			for _, tr := range taskRuns.Items {
+				names = append(names, tr.Name)
+			}
+			sort.Strings(names)
+			if d := cmp.Diff(tc.wantTaskRuns, names); d != "" {
+				t.Errorf("Unexpected TaskRuns %s", diff.PrintWantGot(d))
+			}
+			if d := cmp.Diff(tc.wantSkippedTasks, pipelineRun.Status.SkippedTasks); d != "" {
+				t.Errorf("Unexpected Skipped Tasks %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 func TestReconcileWithWhenExpressions(t *testing.T) {
 	//		(b)
 	//		/
@@ -5403,6 +6692,13 @@ spec:
 	}}
 	th.VerifyTaskRunStatusesWhenExpressions(t, pipelineRun.Status, expectedTaskRunName, expectedWhenExpressionsInTaskRun)
 
+	if pipelineRun.Status.Provenance == nil {
+		t.Fatal("expected the PipelineRun to have a provenance")
+	}
+	if d := cmp.Diff([]string{config.EnableCELInWhenExpression}, pipelineRun.Status.Provenance.UsedFeatureFlags); d != "" {
+		t.Errorf("unexpected used feature flags %s", diff.PrintWantGot(d))
+	}
+
 	actualSkippedTasks := pipelineRun.Status.SkippedTasks
 	expectedSkippedTasks := []v1.SkippedTask{{
 		Name:   "c-task",
@@ -5867,26 +7163,114 @@ spec:
 	th.CheckPipelineRunConditionStatusAndReason(t, pipelineRun.Status, corev1.ConditionFalse, v1.PipelineRunReasonInvalidParamValue.String())
 }
 
-// TestReconcileWithAffinityAssistantStatefulSet tests that given a pipelineRun with workspaces,
-// an Affinity Assistant StatefulSet is created for each PVC workspace and
-// that the Affinity Assistant names is propagated to TaskRuns.
-func TestReconcileWithAffinityAssistantStatefulSet(t *testing.T) {
-	workspaceName := "ws1"
-	workspaceName2 := "ws2"
-	pipelineRunName := "test-pipeline-run"
+// TestReconcile_PipelineTask_Level_Param_Constraints_Failed tests that the PipelineRun fails when a param
+// value resolved from the result of a PipelineTask doesn't satisfy the constraints of the Task it is passed to.
+func TestReconcile_PipelineTask_Level_Param_Constraints_Failed(t *testing.T) {
 	ps := []*v1.Pipeline{parse.MustParseV1Pipeline(t, `
 metadata:
   name: test-pipeline
   namespace: foo
 spec:
   tasks:
-  - name: hello-world-1
+  - name: a-task
     taskRef:
-      name: hello-world
-    workspaces:
-    - name: taskWorkspaceName
-      workspace: ws1
-  - name: hello-world-2
+      name: a-task
+  - name: b-task
+    params:
+    - name: bParam
+      value: $(tasks.a-task.results.aResult)
+    taskRef:
+      name: b-task
+`)}
+	prs := []*v1.PipelineRun{parse.MustParseV1PipelineRun(t, `
+metadata:
+  name: test-pipeline-run-param-constraints
+  namespace: foo
+spec:
+  pipelineRef:
+    name: test-pipeline
+`)}
+	ts := []*v1.Task{
+		parse.MustParseV1Task(t, `
+metadata:
+  name: a-task
+  namespace: foo
+spec: {}
+`),
+		parse.MustParseV1Task(t, `
+metadata:
+  name: b-task
+  namespace: foo
+spec:
+  params:
+  - name: bParam
+    type: string
+    maxLength: 8
+`),
+	}
+	trs := []*v1.TaskRun{parse.MustParseTaskRunWithObjectMeta(t,
+		taskRunObjectMeta("test-pipeline-run-param-constraints-a-task", "foo",
+			"test-pipeline-run-param-constraints", "test-pipeline", "a-task", true),
+		`
+spec:
+  taskRef:
+    name: a-task
+status:
+  conditions:
+  - lastTransitionTime: null
+    status: "True"
+    type: Succeeded
+  results:
+  - name: aResult
+    value: aResultValue
+`)}
+
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+		TaskRuns:     trs,
+	}
+	prt := newPipelineRunTest(t, d)
+	defer prt.Cancel()
+
+	pipelineRun, clients := prt.reconcileRun("foo", "test-pipeline-run-param-constraints", []string{}, true)
+	th.CheckPipelineRunConditionStatusAndReason(t, pipelineRun.Status, corev1.ConditionFalse, v1.PipelineRunReasonInvalidParamValue.String())
+	wantMessage := `[User error] Invalid param value from PipelineTask "b-task": param "bParam" value "aResultValue" is longer than its maxLength 8`
+	if got := pipelineRun.Status.GetCondition(apis.ConditionSucceeded).Message; got != wantMessage {
+		t.Errorf("Expected the PipelineRun to fail with message %q, got %q", wantMessage, got)
+	}
+	actual, err := clients.Pipeline.TektonV1().TaskRuns("foo").List(prt.TestAssets.Ctx, metav1.ListOptions{
+		LabelSelector: "tekton.dev/pipelineTask=b-task",
+	})
+	if err != nil {
+		t.Fatalf("Failure to list TaskRun's %s", err)
+	}
+	if len(actual.Items) != 0 {
+		t.Errorf("Expected no TaskRun for b-task, got %d", len(actual.Items))
+	}
+}
+
+// TestReconcileWithAffinityAssistantStatefulSet tests that given a pipelineRun with workspaces,
+// an Affinity Assistant StatefulSet is created for each PVC workspace and
+// that the Affinity Assistant names is propagated to TaskRuns.
+func TestReconcileWithAffinityAssistantStatefulSet(t *testing.T) {
+	workspaceName := "ws1"
+	workspaceName2 := "ws2"
+	pipelineRunName := "test-pipeline-run"
+	ps := []*v1.Pipeline{parse.MustParseV1Pipeline(t, `
+metadata:
+  name: test-pipeline
+  namespace: foo
+spec:
+  tasks:
+  - name: hello-world-1
+    taskRef:
+      name: hello-world
+    workspaces:
+    - name: taskWorkspaceName
+      workspace: ws1
+  - name: hello-world-2
     taskRef:
       name: hello-world
     workspaces:
@@ -6097,74 +7481,129 @@ spec:
 	}
 }
 
-// TestReconcileWithVolumeClaimTemplateWorkspaceUsingSubPaths tests that given a pipeline with volumeClaimTemplate workspace and
-// multiple instances of the same task, but using different subPaths in the volume - is seen as taskRuns with expected subPaths.
-func TestReconcileWithVolumeClaimTemplateWorkspaceUsingSubPaths(t *testing.T) {
-	subPath1 := "customdirectory"
-	subPath2 := "otherdirecory"
-	pipelineRunWsSubPath := "mypath"
-	pipelineRunName := "test-pipeline-run"
-	ps := []*v1.Pipeline{parse.MustParseV1Pipeline(t, `
+// TestReconcileWithVolumeClaimTemplateWorkspacePerMatrixInstance tests that the instances of a matrixed PipelineTask
+// binding a volumeClaimTemplate workspace per matrix instance are each bound to their own PVC, coscheduled with it
+func TestReconcileWithVolumeClaimTemplateWorkspacePerMatrixInstance(t *testing.T) {
+	for _, coschedule := range []string{config.CoscheduleWorkspaces, config.CoschedulePipelineRuns, config.CoscheduleDisabled} {
+		t.Run(coschedule, func(t *testing.T) {
+			ps := []*v1.Pipeline{parse.MustParseV1Pipeline(t, `
 metadata:
   name: test-pipeline
   namespace: foo
 spec:
   tasks:
-  - name: hello-world-1
+  - name: build
     taskRef:
-      name: hello-world
-    workspaces:
-    - name: taskWorkspaceName
-      subPath: customdirectory
-      workspace: ws1
-  - name: hello-world-2
-    taskRef:
-      name: hello-world
+      name: build-task
+    matrix:
+      params:
+      - name: platform
+        value: [linux, mac, windows]
     workspaces:
-    - name: taskWorkspaceName
-      subPath: otherdirecory
+    - name: cache
       workspace: ws1
-  - name: hello-world-3
+      perMatrixInstance: true
+  workspaces:
+  - name: ws1
+`)}
+			prs := []*v1.PipelineRun{parse.MustParseV1PipelineRun(t, `
+metadata:
+  name: test-pipeline-run
+  namespace: foo
+spec:
+  pipelineRef:
+    name: test-pipeline
+  workspaces:
+  - name: ws1
+    volumeClaimTemplate:
+      metadata:
+        name: myclaim
+`)}
+			ts := []*v1.Task{parse.MustParseV1Task(t, `
+metadata:
+  name: build-task
+  namespace: foo
+spec:
+  params:
+  - name: platform
+  workspaces:
+  - name: cache
+  steps:
+  - name: build
+    image: busybox
+`)}
+			cms := th.NewFeatureFlagsConfigMapInSlice()
+			cms[0].Data["coschedule"] = coschedule
+			d := test.Data{
+				PipelineRuns: prs,
+				Pipelines:    ps,
+				Tasks:        ts,
+				ConfigMaps:   cms,
+			}
+			prt := newPipelineRunTest(t, d)
+			defer prt.Cancel()
+
+			reconciledRun, clients := prt.reconcileRun("foo", "test-pipeline-run", []string{}, false)
+
+			claims := sets.New[string]()
+			for i := range 3 {
+				taskRunName := fmt.Sprintf("test-pipeline-run-build-%d", i)
+				tr, err := clients.Pipeline.TektonV1().TaskRuns("foo").Get(prt.TestAssets.Ctx, taskRunName, metav1.GetOptions{})
+				if err != nil {
+					t.Fatalf("expected the TaskRun %s to be created: %v", taskRunName, err)
+				}
+				instance := volumeclaim.MatrixInstanceWorkspaceBinding(reconciledRun.Spec.Workspaces[0], "build", i)
+				expectedClaimName := volumeclaim.GeneratePVCNameFromWorkspaceBinding(instance.VolumeClaimTemplate.Name, instance, *kmeta.NewControllerRef(reconciledRun))
+				if len(tr.Spec.Workspaces) != 1 || tr.Spec.Workspaces[0].PersistentVolumeClaim == nil || tr.Spec.Workspaces[0].PersistentVolumeClaim.ClaimName != expectedClaimName {
+					t.Errorf("expected the TaskRun %s to bind the PVC %s, got %v", taskRunName, expectedClaimName, tr.Spec.Workspaces)
+				}
+				if !strings.HasPrefix(expectedClaimName, fmt.Sprintf("myclaim-%d-", i)) {
+					t.Errorf("expected the name of the PVC %s to have the instance ordinal %d", expectedClaimName, i)
+				}
+				claims.Insert(expectedClaimName)
+				if _, err := clients.Kube.CoreV1().PersistentVolumeClaims("foo").Get(prt.TestAssets.Ctx, expectedClaimName, metav1.GetOptions{}); err != nil {
+					t.Errorf("expected the PVC %s to be created: %v", expectedClaimName, err)
+				}
+
+				var expectedAAName string
+				switch coschedule {
+				case config.CoscheduleWorkspaces:
+					expectedAAName = GetAffinityAssistantName(instance.Name, reconciledRun.Name)
+				case config.CoschedulePipelineRuns:
+					expectedAAName = GetAffinityAssistantName("", reconciledRun.Name)
+				}
+				if d := cmp.Diff(expectedAAName, tr.Annotations["pipeline.tekton.dev/affinity-assistant"]); d != "" {
+					t.Errorf("Affinity Assistant of the TaskRun %s %s", taskRunName, diff.PrintWantGot(d))
+				}
+			}
+			if claims.Len() != 3 {
+				t.Errorf("expected the TaskRuns to bind 3 distinct PVCs, got %v", sets.List(claims))
+			}
+		})
+	}
+}
+
+// TestReconcileWithDefaultedVolumeClaimTemplateWorkspace tests that the PVC created for a volumeClaimTemplate
+// workspace defaulted by the webhook has the default storage class and access mode of the config-defaults.
+func TestReconcileWithDefaultedVolumeClaimTemplateWorkspace(t *testing.T) {
+	ps := []*v1.Pipeline{parse.MustParseV1Pipeline(t, `
+metadata:
+  name: test-pipeline
+  namespace: foo
+spec:
+  tasks:
+  - name: hello-world-1
     taskRef:
       name: hello-world
     workspaces:
     - name: taskWorkspaceName
       workspace: ws1
-  - name: hello-world-4
-    taskRef:
-      name: hello-world
-    workspaces:
-    - name: taskWorkspaceName
-      workspace: ws2
-  - name: hello-world-5
-    taskRef:
-      name: hello-world
-    workspaces:
-    - name: taskWorkspaceName
-      subPath: customdirectory
-      workspace: ws2
-  - name: hello-world-6
-    taskRef:
-      name: hello-world
-    workspaces:
-    - name: taskWorkspaceName
-      workspace: ws3
-  - name: hello-world-7
-    taskRef:
-      name: hello-world
-    workspaces:
-    - name: taskWorkspaceName
-      subPath: customdirectory
-      workspace: ws3
   workspaces:
   - name: ws1
-  - name: ws2
-  - name: ws3
 `)}
-
-	prs := []*v1.PipelineRun{parse.MustParseV1PipelineRun(t, fmt.Sprintf(`
+	pr := parse.MustParseV1PipelineRun(t, `
 metadata:
-  name: %s
+  name: test-pipeline-run
   namespace: foo
 spec:
   pipelineRef:
@@ -6173,62 +7612,385 @@ spec:
   - name: ws1
     volumeClaimTemplate:
       metadata:
-        creationTimestamp: null
-        name: myclaim
-  - name: ws2
-    subPath: mypath
-    volumeClaimTemplate:
-      metadata:
-        creationTimestamp: null
-        name: myclaim
-  - name: ws3
-    subPath: $(context.pipelineRun.name)
-    volumeClaimTemplate:
-      metadata:
-        creationTimestamp: null
         name: myclaim
-`, pipelineRunName))}
-	ts := []*v1.Task{simpleHelloWorldTask}
+`)
+	defaults, err := config.NewDefaultsFromMap(map[string]string{
+		"default-workspace-storage-class": "local-wait-for-first-consumer",
+		"default-workspace-access-mode":   "ReadWriteOnce",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The webhook defaults the PipelineRun when it is created.
+	pr.SetDefaults(apis.WithinCreate(config.ToContext(t.Context(), &config.Config{Defaults: defaults})))
 
 	d := test.Data{
-		PipelineRuns: prs,
+		PipelineRuns: []*v1.PipelineRun{pr},
 		Pipelines:    ps,
-		Tasks:        ts,
+		Tasks:        []*v1.Task{simpleHelloWorldTask},
+		ConfigMaps:   th.NewFeatureFlagsConfigMapInSlice(),
 	}
 	prt := newPipelineRunTest(t, d)
 	defer prt.Cancel()
 
-	reconciledRun, clients := prt.reconcileRun("foo", pipelineRunName, []string{}, false)
+	reconciledRun, clients := prt.reconcileRun("foo", "test-pipeline-run", []string{}, false)
+	if d := cmp.Diff("ws1", reconciledRun.Annotations[v1.DefaultedWorkspaceStorageAnnotation]); d != "" {
+		t.Errorf("Unexpected defaulted workspaces annotation %s", diff.PrintWantGot(d))
+	}
 
-	taskRuns, err := clients.Pipeline.TektonV1().TaskRuns("foo").List(prt.TestAssets.Ctx, metav1.ListOptions{})
+	ws := reconciledRun.Spec.Workspaces[0]
+	pvcName := volumeclaim.GeneratePVCNameFromWorkspaceBinding(ws.VolumeClaimTemplate.Name, ws, *kmeta.NewControllerRef(reconciledRun))
+	pvc, err := clients.Kube.CoreV1().PersistentVolumeClaims("foo").Get(prt.TestAssets.Ctx, pvcName, metav1.GetOptions{})
 	if err != nil {
-		t.Fatalf("unexpected error when listing TaskRuns: %v", err)
+		t.Fatalf("expected PVC %s to exist but instead got error when getting it: %v", pvcName, err)
 	}
-
-	if len(taskRuns.Items) != 7 {
-		t.Fatalf("unexpected number of taskRuns found, expected 7, but found %d", len(taskRuns.Items))
+	if pvc.Spec.StorageClassName == nil || *pvc.Spec.StorageClassName != "local-wait-for-first-consumer" {
+		t.Errorf("expected the PVC to have the default storage class, got %v", pvc.Spec.StorageClassName)
+	}
+	if d := cmp.Diff([]corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce}, pvc.Spec.AccessModes); d != "" {
+		t.Errorf("Unexpected PVC access modes %s", diff.PrintWantGot(d))
 	}
 
-	hasSeenWorkspaceWithPipelineTaskSubPath1 := false
-	hasSeenWorkspaceWithPipelineTaskSubPath2 := false
-	hasSeenWorkspaceWithEmptyPipelineTaskSubPath := false
-	hasSeenWorkspaceWithRunSubPathAndEmptyPipelineTaskSubPath := false
-	hasSeenWorkspaceWithRunSubPathAndPipelineTaskSubPath1 := false
-	hasSeenWorkspaceWithRunSubPathContextAndEmptyPipelineTaskSubPath := false
-	hasSeenWorkspaceWithRunSubPathContextAndPipelineTaskSubPath := false
-
+	taskRuns, err := clients.Pipeline.TektonV1().TaskRuns("foo").List(prt.TestAssets.Ctx, metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error when listing TaskRuns: %v", err)
+	}
 	for _, tr := range taskRuns.Items {
-		for _, ws := range tr.Spec.Workspaces {
-			if ws.PersistentVolumeClaim == nil {
-				t.Fatalf("found taskRun workspace that is not PersistentVolumeClaim workspace. Did only expect PersistentVolumeClaims workspaces")
-			}
-
-			if ws.SubPath == subPath1 {
-				hasSeenWorkspaceWithPipelineTaskSubPath1 = true
-			}
+		if _, ok := tr.Annotations[v1.DefaultedWorkspaceStorageAnnotation]; ok {
+			t.Errorf("TaskRun %s has the defaulted workspaces annotation of its PipelineRun", tr.Name)
+		}
+	}
+}
 
-			if ws.SubPath == subPath2 {
-				hasSeenWorkspaceWithPipelineTaskSubPath2 = true
+// TestReconcileWithPodGroup tests that the TaskRuns of a PipelineRun are labeled with the UID of the
+// PipelineRun and annotated with the size of its pod group when a pod group label key is configured.
+func TestReconcileWithPodGroup(t *testing.T) {
+	for _, tc := range []struct {
+		name           string
+		defaults       map[string]string
+		wantSize       string
+		wantAnnotation string
+	}{{
+		name:     "whole dag",
+		defaults: map[string]string{"default-pod-group-label-key": "scheduling.example.com/group"},
+		wantSize: "3",
+	}, {
+		name: "first layer",
+		defaults: map[string]string{
+			"default-pod-group-label-key": "scheduling.example.com/group",
+			"default-pod-group-size":      "first-layer",
+		},
+		wantSize: "2",
+	}, {
+		name: "configured size annotation",
+		defaults: map[string]string{
+			"default-pod-group-label-key":           "kueue.x-k8s.io/pod-group-name",
+			"default-pod-group-size-annotation-key": "kueue.x-k8s.io/pod-group-total-count",
+		},
+		wantSize:       "3",
+		wantAnnotation: "kueue.x-k8s.io/pod-group-total-count",
+	}, {
+		name: "not configured",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			ps := []*v1.Pipeline{parse.MustParseV1Pipeline(t, `
+metadata:
+  name: test-pipeline
+  namespace: foo
+spec:
+  tasks:
+  - name: a
+    taskRef:
+      name: hello-world
+  - name: b
+    runAfter: [a]
+    taskRef:
+      name: hello-world
+  - name: c
+    taskRef:
+      name: hello-world
+  finally:
+  - name: final
+    taskRef:
+      name: hello-world
+`)}
+			prs := []*v1.PipelineRun{parse.MustParseV1PipelineRun(t, `
+metadata:
+  name: test-pipeline-run
+  namespace: foo
+  uid: pr-uid
+spec:
+  pipelineRef:
+    name: test-pipeline
+`)}
+			cms := th.NewFeatureFlagsConfigMapInSlice()
+			cms = append(cms, &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: config.GetDefaultsConfigName(), Namespace: system.Namespace()},
+				Data:       tc.defaults,
+			})
+			d := test.Data{
+				PipelineRuns: prs,
+				Pipelines:    ps,
+				Tasks:        []*v1.Task{simpleHelloWorldTask},
+				ConfigMaps:   cms,
+			}
+			prt := newPipelineRunTest(t, d)
+			defer prt.Cancel()
+
+			_, clients := prt.reconcileRun("foo", "test-pipeline-run", []string{}, false)
+			taskRuns, err := clients.Pipeline.TektonV1().TaskRuns("foo").List(prt.TestAssets.Ctx, metav1.ListOptions{})
+			if err != nil {
+				t.Fatalf("unexpected error when listing TaskRuns: %v", err)
+			}
+			if len(taskRuns.Items) != 2 {
+				t.Fatalf("expected 2 TaskRuns to be created, got %d", len(taskRuns.Items))
+			}
+			labelKey, annotation := "scheduling.example.com/group", config.DefaultPodGroupSizeAnnotationKey
+			if tc.wantAnnotation != "" {
+				labelKey, annotation = tc.defaults["default-pod-group-label-key"], tc.wantAnnotation
+			}
+			for _, tr := range taskRuns.Items {
+				label, ok := tr.Labels[labelKey]
+				switch {
+				case tc.wantSize == "" && ok:
+					t.Errorf("expected TaskRun %s not to have a pod group label, got %q", tr.Name, label)
+				case tc.wantSize != "" && label != "pr-uid":
+					t.Errorf("expected TaskRun %s to be labeled with the UID of the PipelineRun, got %q", tr.Name, label)
+				}
+				if got := tr.Annotations[annotation]; got != tc.wantSize {
+					t.Errorf("expected TaskRun %s to have a pod group size of %q, got %q", tr.Name, tc.wantSize, got)
+				}
+			}
+		})
+	}
+}
+
+// TestReconcileWithVolumeClaimTemplateWorkspace_PVCWait tests that the time the PVC of a volumeClaimTemplate
+// workspace waits to be bound is reported in the condition message while it is pending, and recorded in the
+// timing of the PipelineRun status once the PVC is bound.
+func TestReconcileWithVolumeClaimTemplateWorkspace_PVCWait(t *testing.T) {
+	pipelineRunName := "test-pipeline-run"
+	ps := []*v1.Pipeline{parse.MustParseV1Pipeline(t, `
+metadata:
+  name: test-pipeline
+  namespace: foo
+spec:
+  tasks:
+  - name: hello-world-1
+    taskRef:
+      name: hello-world
+    workspaces:
+    - name: taskWorkspaceName
+      workspace: ws1
+  workspaces:
+  - name: ws1
+`)}
+
+	prs := []*v1.PipelineRun{parse.MustParseV1PipelineRun(t, `
+metadata:
+  name: test-pipeline-run
+  namespace: foo
+  uid: pr-uid
+spec:
+  pipelineRef:
+    name: test-pipeline
+  workspaces:
+  - name: ws1
+    volumeClaimTemplate:
+      metadata:
+        creationTimestamp: null
+        name: myclaim
+`)}
+	wb := prs[0].Spec.Workspaces[0]
+	pvcName := volumeclaim.GeneratePVCNameFromWorkspaceBinding(wb.VolumeClaimTemplate.Name, wb, *kmeta.NewControllerRef(prs[0]))
+	storageClassName := "slow"
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              pvcName,
+			Namespace:         "foo",
+			CreationTimestamp: metav1.NewTime(now.Add(-30 * time.Second)),
+			Labels:            map[string]string{pipeline.PipelineRunLabelKey: pipelineRunName},
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{StorageClassName: &storageClassName},
+		Status: corev1.PersistentVolumeClaimStatus{
+			Phase: corev1.ClaimPending,
+		},
+	}
+
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        []*v1.Task{simpleHelloWorldTask},
+		PVCs:         []*corev1.PersistentVolumeClaim{pvc},
+		ConfigMaps:   th.NewFeatureFlagsConfigMapInSlice(),
+	}
+	prt := newPipelineRunTest(t, d)
+	defer prt.Cancel()
+
+	wantPending := fmt.Sprintf("; PVC %s of workspace ws1 has been waiting 30s to be bound", pvcName)
+	wantTiming := &v1.PipelineRunTiming{WorkspacePVCWaits: []v1.WorkspacePVCWait{{
+		Workspace:        "ws1",
+		ClaimName:        pvcName,
+		StorageClassName: storageClassName,
+	}}}
+	for range 2 {
+		reconciledRun, _ := prt.reconcileRun("foo", pipelineRunName, []string{}, false)
+		if msg := reconciledRun.Status.GetCondition(apis.ConditionSucceeded).Message; !strings.HasSuffix(msg, wantPending) {
+			t.Errorf("Expected the condition message to end with %q, got %q", wantPending, msg)
+		}
+		if d := cmp.Diff(wantTiming, reconciledRun.Status.Timing); d != "" {
+			t.Errorf("Unexpected timing %s", diff.PrintWantGot(d))
+		}
+	}
+
+	pvc = pvc.DeepCopy()
+	pvc.Status.Phase = corev1.ClaimBound
+	if _, err := prt.TestAssets.Clients.Kube.CoreV1().PersistentVolumeClaims("foo").Update(prt.TestAssets.Ctx, pvc, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("Failed to bind the PVC: %v", err)
+	}
+
+	reconciledRun, _ := prt.reconcileRun("foo", pipelineRunName, []string{}, false)
+	if msg := reconciledRun.Status.GetCondition(apis.ConditionSucceeded).Message; strings.Contains(msg, pvcName) {
+		t.Errorf("Expected the condition message not to mention the bound PVC, got %q", msg)
+	}
+	wantTiming.WorkspacePVCWaits[0].Duration = &metav1.Duration{Duration: 30 * time.Second}
+	if d := cmp.Diff(wantTiming, reconciledRun.Status.Timing); d != "" {
+		t.Errorf("Unexpected timing %s", diff.PrintWantGot(d))
+	}
+}
+
+// TestReconcileWithVolumeClaimTemplateWorkspaceUsingSubPaths tests that given a pipeline with volumeClaimTemplate workspace and
+// multiple instances of the same task, but using different subPaths in the volume - is seen as taskRuns with expected subPaths.
+func TestReconcileWithVolumeClaimTemplateWorkspaceUsingSubPaths(t *testing.T) {
+	subPath1 := "customdirectory"
+	subPath2 := "otherdirecory"
+	pipelineRunWsSubPath := "mypath"
+	pipelineRunName := "test-pipeline-run"
+	ps := []*v1.Pipeline{parse.MustParseV1Pipeline(t, `
+metadata:
+  name: test-pipeline
+  namespace: foo
+spec:
+  tasks:
+  - name: hello-world-1
+    taskRef:
+      name: hello-world
+    workspaces:
+    - name: taskWorkspaceName
+      subPath: customdirectory
+      workspace: ws1
+  - name: hello-world-2
+    taskRef:
+      name: hello-world
+    workspaces:
+    - name: taskWorkspaceName
+      subPath: otherdirecory
+      workspace: ws1
+  - name: hello-world-3
+    taskRef:
+      name: hello-world
+    workspaces:
+    - name: taskWorkspaceName
+      workspace: ws1
+  - name: hello-world-4
+    taskRef:
+      name: hello-world
+    workspaces:
+    - name: taskWorkspaceName
+      workspace: ws2
+  - name: hello-world-5
+    taskRef:
+      name: hello-world
+    workspaces:
+    - name: taskWorkspaceName
+      subPath: customdirectory
+      workspace: ws2
+  - name: hello-world-6
+    taskRef:
+      name: hello-world
+    workspaces:
+    - name: taskWorkspaceName
+      workspace: ws3
+  - name: hello-world-7
+    taskRef:
+      name: hello-world
+    workspaces:
+    - name: taskWorkspaceName
+      subPath: customdirectory
+      workspace: ws3
+  workspaces:
+  - name: ws1
+  - name: ws2
+  - name: ws3
+`)}
+
+	prs := []*v1.PipelineRun{parse.MustParseV1PipelineRun(t, fmt.Sprintf(`
+metadata:
+  name: %s
+  namespace: foo
+spec:
+  pipelineRef:
+    name: test-pipeline
+  workspaces:
+  - name: ws1
+    volumeClaimTemplate:
+      metadata:
+        creationTimestamp: null
+        name: myclaim
+  - name: ws2
+    subPath: mypath
+    volumeClaimTemplate:
+      metadata:
+        creationTimestamp: null
+        name: myclaim
+  - name: ws3
+    subPath: $(context.pipelineRun.name)
+    volumeClaimTemplate:
+      metadata:
+        creationTimestamp: null
+        name: myclaim
+`, pipelineRunName))}
+	ts := []*v1.Task{simpleHelloWorldTask}
+
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+	}
+	prt := newPipelineRunTest(t, d)
+	defer prt.Cancel()
+
+	reconciledRun, clients := prt.reconcileRun("foo", pipelineRunName, []string{}, false)
+
+	taskRuns, err := clients.Pipeline.TektonV1().TaskRuns("foo").List(prt.TestAssets.Ctx, metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error when listing TaskRuns: %v", err)
+	}
+
+	if len(taskRuns.Items) != 7 {
+		t.Fatalf("unexpected number of taskRuns found, expected 7, but found %d", len(taskRuns.Items))
+	}
+
+	hasSeenWorkspaceWithPipelineTaskSubPath1 := false
+	hasSeenWorkspaceWithPipelineTaskSubPath2 := false
+	hasSeenWorkspaceWithEmptyPipelineTaskSubPath := false
+	hasSeenWorkspaceWithRunSubPathAndEmptyPipelineTaskSubPath := false
+	hasSeenWorkspaceWithRunSubPathAndPipelineTaskSubPath1 := false
+	hasSeenWorkspaceWithRunSubPathContextAndEmptyPipelineTaskSubPath := false
+	hasSeenWorkspaceWithRunSubPathContextAndPipelineTaskSubPath := false
+
+	for _, tr := range taskRuns.Items {
+		for _, ws := range tr.Spec.Workspaces {
+			if ws.PersistentVolumeClaim == nil {
+				t.Fatalf("found taskRun workspace that is not PersistentVolumeClaim workspace. Did only expect PersistentVolumeClaims workspaces")
+			}
+
+			if ws.SubPath == subPath1 {
+				hasSeenWorkspaceWithPipelineTaskSubPath1 = true
+			}
+
+			if ws.SubPath == subPath2 {
+				hasSeenWorkspaceWithPipelineTaskSubPath2 = true
 			}
 
 			if ws.SubPath == "" {
@@ -6506,14 +8268,428 @@ spec:
 			_, clients := prt.reconcileRun("foo", prName, []string{}, false)
 			trs, _ := clients.Pipeline.TektonV1().TaskRuns(namespace).List(t.Context(), metav1.ListOptions{})
 
-			if d := cmp.Diff(tt.expected, trs.Items[0].Spec.Workspaces[0], cmpopts.IgnoreFields(v1.WorkspaceBinding{}, "Name")); d != "" {
-				t.Errorf("expected to see Workspace %v created. Diff %s", tt.expected, diff.PrintWantGot(d))
-			}
-		})
+			if d := cmp.Diff(tt.expected, trs.Items[0].Spec.Workspaces[0], cmpopts.IgnoreFields(v1.WorkspaceBinding{}, "Name")); d != "" {
+				t.Errorf("expected to see Workspace %v created. Diff %s", tt.expected, diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestReconcileWithTaskResults(t *testing.T) {
+	names.TestingSeed()
+	ps := []*v1.Pipeline{parse.MustParseV1Pipeline(t, `
+metadata:
+  name: test-pipeline
+  namespace: foo
+spec:
+  tasks:
+  - name: a-task
+    taskRef:
+      name: a-task
+  - name: b-task
+    params:
+    - name: bParam
+      value: $(tasks.a-task.results.aResult)
+    taskRef:
+      name: b-task
+`)}
+	prs := []*v1.PipelineRun{parse.MustParseV1PipelineRun(t, `
+metadata:
+  name: test-pipeline-run-different-service-accs
+  namespace: foo
+spec:
+  pipelineRef:
+    name: test-pipeline
+  taskRunTemplate:
+    serviceAccountName: test-sa-0
+`)}
+	ts := []*v1.Task{
+		parse.MustParseV1Task(t, `
+metadata:
+  name: a-task
+  namespace: foo
+spec: {}
+`),
+		parse.MustParseV1Task(t, `
+metadata:
+  name: b-task
+  namespace: foo
+spec:
+  params:
+  - name: bParam
+    type: string
+`),
+	}
+	trs := []*v1.TaskRun{parse.MustParseTaskRunWithObjectMeta(t,
+		taskRunObjectMeta("test-pipeline-run-different-service-accs-a-task-xxyyy", "foo",
+			"test-pipeline-run-different-service-accs", "test-pipeline", "a-task", true),
+		`
+spec:
+  serviceAccountName: test-sa
+  taskRef:
+    name: hello-world
+  timeout: 1h0m0s
+status:
+  conditions:
+  - lastTransitionTime: null
+    status: "True"
+    type: Succeeded
+  results:
+  - name: aResult
+    value: aResultValue
+`)}
+
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+		TaskRuns:     trs,
+	}
+	prt := newPipelineRunTest(t, d)
+	defer prt.Cancel()
+
+	_, clients := prt.reconcileRun("foo", "test-pipeline-run-different-service-accs", []string{}, false)
+
+	expectedTaskRunName := "test-pipeline-run-different-service-accs-b-task"
+	expectedTaskRun := parse.MustParseTaskRunWithObjectMeta(t,
+		taskRunObjectMeta("test-pipeline-run-different-service-accs-b-task", "foo",
+			"test-pipeline-run-different-service-accs", "test-pipeline", "b-task", false),
+		`
+spec:
+  params:
+  - name: bParam
+    value: aResultValue
+  serviceAccountName: test-sa-0
+  taskRef:
+    name: b-task
+    kind: Task
+`)
+	// Check that the expected TaskRun was created
+	actual, err := clients.Pipeline.TektonV1().TaskRuns("foo").List(prt.TestAssets.Ctx, metav1.ListOptions{
+		LabelSelector: "tekton.dev/pipelineTask=b-task,tekton.dev/pipelineRun=test-pipeline-run-different-service-accs",
+		Limit:         1,
+	})
+	if err != nil {
+		t.Fatalf("Failure to list TaskRun's %s", err)
+	}
+	if len(actual.Items) != 1 {
+		t.Fatalf("Expected 1 TaskRuns got %d", len(actual.Items))
+	}
+	actualTaskRun := actual.Items[0]
+	if d := cmp.Diff(expectedTaskRun, &actualTaskRun, ignoreResourceVersion, ignoreTypeMeta); d != "" {
+		t.Errorf("expected to see TaskRun %v created. Diff %s", expectedTaskRunName, diff.PrintWantGot(d))
+	}
+}
+
+// TestReconcileWithTaskResultsInWorkspaceSubPath tests that the params, context and results referenced by the
+// subPath of the workspace of a PipelineTask are replaced when its TaskRun is created, and that the PipelineRun
+// fails when the resolved subPath leaves the workspace.
+func TestReconcileWithTaskResultsInWorkspaceSubPath(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		result      string
+		wantSubPath string
+		wantEvents  []string
+	}{{
+		name:        "result-driven subPath",
+		result:      "go",
+		wantSubPath: "cache/test-pipeline-run/go",
+		wantEvents:  []string{"Normal Started", "Normal Running Tasks Completed: 1 (Failed: 0, Cancelled 0), Incomplete: 1, Skipped: 0"},
+	}, {
+		name:   "result leaving the workspace",
+		result: "../../secrets",
+		wantEvents: []string{
+			"Normal Started",
+			`Warning TaskRunsCreationFailed Failed to create TaskRuns ["test-pipeline-run-b-task"]: invalid workspace "s1" of pipeline task "b-task": invalid value: "cache/test-pipeline-run/../../secrets" must be a relative path without ".." elements: subPath`,
+			`Warning Failed invalid workspace "s1" of pipeline task "b-task": invalid value: "cache/test-pipeline-run/../../secrets" must be a relative path without ".." elements: subPath`,
+			`Warning InternalError error creating TaskRuns called [test-pipeline-run-b-task] for PipelineTask b-task from PipelineRun test-pipeline-run: invalid workspace "s1" of pipeline task "b-task": invalid value: "cache/test-pipeline-run/../../secrets" must be a relative path without ".." elements: subPath`,
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			names.TestingSeed()
+			ps := []*v1.Pipeline{parse.MustParseV1Pipeline(t, `
+metadata:
+  name: test-pipeline
+  namespace: foo
+spec:
+  params:
+  - name: prefix
+    type: string
+  tasks:
+  - name: a-task
+    taskRef:
+      name: a-task
+  - name: b-task
+    taskRef:
+      name: b-task
+    workspaces:
+    - name: s1
+      workspace: ws-1
+      subPath: $(params.prefix)/$(context.pipelineRun.name)/$(tasks.a-task.results.dir)
+  workspaces:
+  - name: ws-1
+`)}
+			prs := []*v1.PipelineRun{parse.MustParseV1PipelineRun(t, `
+metadata:
+  name: test-pipeline-run
+  namespace: foo
+spec:
+  params:
+  - name: prefix
+    value: cache
+  pipelineRef:
+    name: test-pipeline
+  taskRunTemplate:
+    serviceAccountName: test-sa-0
+  workspaces:
+  - name: ws-1
+    emptyDir: {}
+`)}
+			ts := []*v1.Task{parse.MustParseV1Task(t, `
+metadata:
+  name: a-task
+  namespace: foo
+spec:
+  results:
+  - name: dir
+`), parse.MustParseV1Task(t, `
+metadata:
+  name: b-task
+  namespace: foo
+spec:
+  workspaces:
+  - name: s1
+`)}
+			trs := []*v1.TaskRun{parse.MustParseTaskRunWithObjectMeta(t,
+				taskRunObjectMeta("test-pipeline-run-a-task", "foo", "test-pipeline-run", "test-pipeline", "a-task", true),
+				fmt.Sprintf(`
+spec:
+  serviceAccountName: test-sa-0
+  taskRef:
+    name: a-task
+status:
+  conditions:
+  - status: "True"
+    type: Succeeded
+  results:
+  - name: dir
+    type: string
+    value: %s
+`, tc.result))}
+			prt := newPipelineRunTest(t, test.Data{PipelineRuns: prs, Pipelines: ps, Tasks: ts, TaskRuns: trs})
+			defer prt.Cancel()
+
+			reconciledRun, clients := prt.reconcileRun("foo", "test-pipeline-run", tc.wantEvents, tc.wantSubPath == "")
+			if tc.wantSubPath == "" {
+				if reason := reconciledRun.Status.GetCondition(apis.ConditionSucceeded).Reason; reason != v1.PipelineRunReasonCreateRunFailed.String() {
+					t.Errorf("Expected the PipelineRun to fail with reason %s, got %s", v1.PipelineRunReasonCreateRunFailed, reason)
+				}
+				return
+			}
+			tr, err := clients.Pipeline.TektonV1().TaskRuns("foo").Get(prt.TestAssets.Ctx, "test-pipeline-run-b-task", metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Expected the TaskRun of b-task to be created: %v", err)
+			}
+			if len(tr.Spec.Workspaces) != 1 || tr.Spec.Workspaces[0].SubPath != tc.wantSubPath {
+				t.Errorf("Expected the workspace of the TaskRun to have subPath %q, got %v", tc.wantSubPath, tr.Spec.Workspaces)
+			}
+		})
+	}
+}
+
+// TestReconcileWithRetriesAndTimeoutFromTaskResults tests that the retries and the timeout of a PipelineTask are
+// taken from the results of another PipelineTask when its TaskRun is created, and that the PipelineRun fails when
+// they can't be parsed.
+func TestReconcileWithRetriesAndTimeoutFromTaskResults(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		timeout     string
+		wantRetries int
+		wantTimeout time.Duration
+		wantMessage string
+	}{{
+		name:        "valid retries and timeout",
+		timeout:     "30m",
+		wantRetries: 3,
+		wantTimeout: 30 * time.Minute,
+	}, {
+		name:        "invalid timeout",
+		timeout:     "soon",
+		wantMessage: `[User error] PipelineRun foo/test-pipeline-run can't run PipelineTask b-task: invalid timeout "soon" produced by PipelineTask a-task for PipelineTask b-task: must be a non-negative duration such as "1h30m"`,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			names.TestingSeed()
+			ps := []*v1.Pipeline{parse.MustParseV1Pipeline(t, `
+metadata:
+  name: test-pipeline
+  namespace: foo
+spec:
+  tasks:
+  - name: a-task
+    taskRef:
+      name: a-task
+  - name: b-task
+    taskRef:
+      name: b-task
+    retriesFrom: $(tasks.a-task.results.retries)
+    timeoutFrom: $(tasks.a-task.results.timeout)
+`)}
+			prs := []*v1.PipelineRun{parse.MustParseV1PipelineRun(t, `
+metadata:
+  name: test-pipeline-run
+  namespace: foo
+spec:
+  pipelineRef:
+    name: test-pipeline
+  taskRunTemplate:
+    serviceAccountName: test-sa-0
+`)}
+			ts := []*v1.Task{parse.MustParseV1Task(t, `
+metadata:
+  name: a-task
+  namespace: foo
+spec:
+  results:
+  - name: retries
+  - name: timeout
+`), parse.MustParseV1Task(t, `
+metadata:
+  name: b-task
+  namespace: foo
+spec: {}
+`)}
+			trs := []*v1.TaskRun{parse.MustParseTaskRunWithObjectMeta(t,
+				taskRunObjectMeta("test-pipeline-run-a-task", "foo", "test-pipeline-run", "test-pipeline", "a-task", true),
+				fmt.Sprintf(`
+spec:
+  serviceAccountName: test-sa-0
+  taskRef:
+    name: a-task
+status:
+  conditions:
+  - status: "True"
+    type: Succeeded
+  results:
+  - name: retries
+    type: string
+    value: "3"
+  - name: timeout
+    type: string
+    value: %s
+`, tc.timeout))}
+			prt := newPipelineRunTest(t, test.Data{PipelineRuns: prs, Pipelines: ps, Tasks: ts, TaskRuns: trs})
+			defer prt.Cancel()
+
+			reconciledRun, clients := prt.reconcileRun("foo", "test-pipeline-run", []string{}, tc.wantMessage != "")
+			if tc.wantMessage != "" {
+				condition := reconciledRun.Status.GetCondition(apis.ConditionSucceeded)
+				if condition.Reason != v1.PipelineRunReasonInvalidRetriesOrTimeout.String() {
+					t.Errorf("Expected the PipelineRun to fail with reason %s, got %s", v1.PipelineRunReasonInvalidRetriesOrTimeout, condition.Reason)
+				}
+				if condition.Message != tc.wantMessage {
+					t.Errorf("Expected the PipelineRun to fail with message %q, got %q", tc.wantMessage, condition.Message)
+				}
+				return
+			}
+			tr, err := clients.Pipeline.TektonV1().TaskRuns("foo").Get(prt.TestAssets.Ctx, "test-pipeline-run-b-task", metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Expected the TaskRun of b-task to be created: %v", err)
+			}
+			if tr.Spec.Retries != tc.wantRetries {
+				t.Errorf("Expected the TaskRun to have %d retries, got %d", tc.wantRetries, tr.Spec.Retries)
+			}
+			if tr.Spec.Timeout == nil || tr.Spec.Timeout.Duration != tc.wantTimeout {
+				t.Errorf("Expected the TaskRun to have timeout %v, got %v", tc.wantTimeout, tr.Spec.Timeout)
+			}
+		})
+	}
+}
+
+func TestReconcileWithArtifactsInFinallyTaskParams(t *testing.T) {
+	names.TestingSeed()
+	ps := []*v1.Pipeline{parse.MustParseV1Pipeline(t, `
+metadata:
+  name: test-pipeline
+  namespace: foo
+spec:
+  tasks:
+  - name: build
+    taskRef:
+      name: build
+  finally:
+  - name: sign
+    taskRef:
+      name: sign
+    params:
+    - name: images
+      value: $(tasks.build.outputs.image)
+`)}
+	prs := []*v1.PipelineRun{parse.MustParseV1PipelineRun(t, `
+metadata:
+  name: test-pipeline-run
+  namespace: foo
+spec:
+  pipelineRef:
+    name: test-pipeline
+  taskRunTemplate:
+    serviceAccountName: test-sa-0
+`)}
+	ts := []*v1.Task{parse.MustParseV1Task(t, `
+metadata:
+  name: build
+  namespace: foo
+`), parse.MustParseV1Task(t, `
+metadata:
+  name: sign
+  namespace: foo
+spec:
+  params:
+  - name: images
+`)}
+	trs := []*v1.TaskRun{parse.MustParseTaskRunWithObjectMeta(t,
+		taskRunObjectMeta("test-pipeline-run-build", "foo", "test-pipeline-run", "test-pipeline", "build", true), `
+spec:
+  serviceAccountName: test-sa-0
+  taskRef:
+    name: build
+status:
+  conditions:
+  - status: "True"
+    type: Succeeded
+  artifacts:
+    outputs:
+    - name: image
+      values:
+      - uri: pkg:oci/app
+        digest:
+          sha256: df85b9e3983fe2ce20ef76ad675ecf435cc99fc9350adc54fa230bae8c32ce48
+`)}
+	cms := []*corev1.ConfigMap{{
+		ObjectMeta: metav1.ObjectMeta{Name: config.GetFeatureFlagsConfigName(), Namespace: system.Namespace()},
+		Data:       map[string]string{"enable-artifacts": "true"},
+	}}
+	prt := newPipelineRunTest(t, test.Data{PipelineRuns: prs, Pipelines: ps, Tasks: ts, TaskRuns: trs, ConfigMaps: cms})
+	defer prt.Cancel()
+
+	wantEvents := []string{
+		"Normal Started",
+		"Normal Running Tasks Completed: 1 (Failed: 0, Cancelled 0), Incomplete: 1, Skipped: 0",
+	}
+	_, clients := prt.reconcileRun("foo", "test-pipeline-run", wantEvents, false)
+	tr, err := clients.Pipeline.TektonV1().TaskRuns("foo").Get(prt.TestAssets.Ctx, "test-pipeline-run-sign", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Expected the TaskRun of the finally task sign to be created: %v", err)
+	}
+	want := v1.Params{{
+		Name:  "images",
+		Value: *v1.NewStructuredValues(`[{"digest":{"sha256":"df85b9e3983fe2ce20ef76ad675ecf435cc99fc9350adc54fa230bae8c32ce48"},"uri":"pkg:oci/app"}]`),
+	}}
+	if d := cmp.Diff(want, tr.Spec.Params); d != "" {
+		t.Errorf("Unexpected params of the TaskRun of the finally task %s", diff.PrintWantGot(d))
 	}
 }
 
-func TestReconcileWithTaskResults(t *testing.T) {
+func TestReconcileWithDuplicateWorkspaceBindings(t *testing.T) {
 	names.TestingSeed()
 	ps := []*v1.Pipeline{parse.MustParseV1Pipeline(t, `
 metadata:
@@ -6524,98 +8700,58 @@ spec:
   - name: a-task
     taskRef:
       name: a-task
-  - name: b-task
-    params:
-    - name: bParam
-      value: $(tasks.a-task.results.aResult)
-    taskRef:
-      name: b-task
+    workspaces:
+    - name: s1
+      workspace: ws-1
+  workspaces:
+  - name: ws-1
 `)}
+	// The PipelineRun was created before duplicate workspace bindings were rejected by the webhook.
 	prs := []*v1.PipelineRun{parse.MustParseV1PipelineRun(t, `
 metadata:
-  name: test-pipeline-run-different-service-accs
+  name: test-pipeline-run
   namespace: foo
 spec:
   pipelineRef:
     name: test-pipeline
   taskRunTemplate:
     serviceAccountName: test-sa-0
+  workspaces:
+  - name: ws-1
+    emptyDir: {}
+  - name: ws-1
+    configMap:
+      name: config
 `)}
-	ts := []*v1.Task{
-		parse.MustParseV1Task(t, `
+	ts := []*v1.Task{parse.MustParseV1Task(t, `
 metadata:
   name: a-task
   namespace: foo
-spec: {}
-`),
-		parse.MustParseV1Task(t, `
-metadata:
-  name: b-task
-  namespace: foo
-spec:
-  params:
-  - name: bParam
-    type: string
-`),
-	}
-	trs := []*v1.TaskRun{parse.MustParseTaskRunWithObjectMeta(t,
-		taskRunObjectMeta("test-pipeline-run-different-service-accs-a-task-xxyyy", "foo",
-			"test-pipeline-run-different-service-accs", "test-pipeline", "a-task", true),
-		`
 spec:
-  serviceAccountName: test-sa
-  taskRef:
-    name: hello-world
-  timeout: 1h0m0s
-status:
-  conditions:
-  - lastTransitionTime: null
-    status: "True"
-    type: Succeeded
-  results:
-  - name: aResult
-    value: aResultValue
+  workspaces:
+  - name: s1
 `)}
-
-	d := test.Data{
-		PipelineRuns: prs,
-		Pipelines:    ps,
-		Tasks:        ts,
-		TaskRuns:     trs,
-	}
-	prt := newPipelineRunTest(t, d)
+	prt := newPipelineRunTest(t, test.Data{PipelineRuns: prs, Pipelines: ps, Tasks: ts})
 	defer prt.Cancel()
 
-	_, clients := prt.reconcileRun("foo", "test-pipeline-run-different-service-accs", []string{}, false)
-
-	expectedTaskRunName := "test-pipeline-run-different-service-accs-b-task"
-	expectedTaskRun := parse.MustParseTaskRunWithObjectMeta(t,
-		taskRunObjectMeta("test-pipeline-run-different-service-accs-b-task", "foo",
-			"test-pipeline-run-different-service-accs", "test-pipeline", "b-task", false),
-		`
-spec:
-  params:
-  - name: bParam
-    value: aResultValue
-  serviceAccountName: test-sa-0
-  taskRef:
-    name: b-task
-    kind: Task
-`)
-	// Check that the expected TaskRun was created
-	actual, err := clients.Pipeline.TektonV1().TaskRuns("foo").List(prt.TestAssets.Ctx, metav1.ListOptions{
-		LabelSelector: "tekton.dev/pipelineTask=b-task,tekton.dev/pipelineRun=test-pipeline-run-different-service-accs",
-		Limit:         1,
-	})
+	wantEvents := []string{
+		`Warning DuplicateWorkspaceBindings Workspaces ["ws-1"] are bound more than once by PipelineRun "test-pipeline-run", only their first binding is used`,
+		"Normal Started",
+		"Normal Running Tasks Completed: 0 (Failed: 0, Cancelled 0), Incomplete: 1, Skipped: 0",
+	}
+	reconciledRun, clients := prt.reconcileRun("foo", "test-pipeline-run", wantEvents, false)
+	if got := reconciledRun.Annotations[v1.DuplicateWorkspaceBindingsAnnotation]; got != "ws-1" {
+		t.Errorf("Expected the PipelineRun to be annotated with its duplicate workspace bindings %q, got %q", "ws-1", got)
+	}
+	tr, err := clients.Pipeline.TektonV1().TaskRuns("foo").Get(prt.TestAssets.Ctx, "test-pipeline-run-a-task", metav1.GetOptions{})
 	if err != nil {
-		t.Fatalf("Failure to list TaskRun's %s", err)
+		t.Fatalf("Expected the TaskRun of a-task to be created: %v", err)
 	}
-	if len(actual.Items) != 1 {
-		t.Fatalf("Expected 1 TaskRuns got %d", len(actual.Items))
+	if len(tr.Spec.Workspaces) != 1 || tr.Spec.Workspaces[0].EmptyDir == nil {
+		t.Errorf("Expected the workspace of the TaskRun to be bound to the first binding of the PipelineRun, got %v", tr.Spec.Workspaces)
 	}
-	actualTaskRun := actual.Items[0]
-	if d := cmp.Diff(expectedTaskRun, &actualTaskRun, ignoreResourceVersion, ignoreTypeMeta); d != "" {
-		t.Errorf("expected to see TaskRun %v created. Diff %s", expectedTaskRunName, diff.PrintWantGot(d))
+	if _, ok := tr.Annotations[v1.DuplicateWorkspaceBindingsAnnotation]; ok {
+		t.Errorf("Expected the TaskRun not to inherit the %s annotation", v1.DuplicateWorkspaceBindingsAnnotation)
 	}
 }
 
@@ -6700,6 +8836,7 @@ spec:
 				taskRunObjectMetaWithAnnotations("test-pipeline-run-variable-substitution-b-task", "foo",
 					"test-pipeline-run-variable-substitution", "test-pipeline", "b-task", false, map[string]string{
 						"pipeline.tekton.dev/affinity-assistant": "affinity-assistant-0358aabfa2",
+						"pipeline.tekton.dev/coschedule":         "workspaces",
 					}),
 				`spec:
   serviceAccountName: test-sa-0
@@ -7238,7 +9375,7 @@ status:
 	expectedPr := expectedPrStatus
 
 	if d := cmp.Diff(expectedPr, reconciledRun, ignoreResourceVersion, ignoreLastTransitionTime, ignoreCompletionTime, ignoreStartTime,
-		ignoreProvenance, ignoreFinallyStartTime, cmpopts.EquateEmpty()); d != "" {
+		ignoreProvenance, ignoreLayers, ignoreFinallyStartTime, cmpopts.EquateEmpty()); d != "" {
 		t.Errorf("expected to see pipeline run results created. Diff %s", diff.PrintWantGot(d))
 	}
 }
@@ -7387,11 +9524,123 @@ status:
 	expectedPr := expectedPrStatus
 
 	if d := cmp.Diff(expectedPr, reconciledRun, ignoreResourceVersion, ignoreLastTransitionTime, ignoreCompletionTime,
-		ignoreStartTime, ignoreProvenance, cmpopts.EquateEmpty()); d != "" {
+		ignoreStartTime, ignoreProvenance, ignoreLayers, cmpopts.EquateEmpty()); d != "" {
 		t.Errorf("expected to see pipeline run results created. Diff %s", diff.PrintWantGot(d))
 	}
 }
 
+func TestReconcileWithDAGLayers(t *testing.T) {
+	// A diamond: b-task and c-task run after a-task, and d-task after both of them, followed by a finally task
+	ps := []*v1.Pipeline{parse.MustParseV1Pipeline(t, `
+metadata:
+  name: test-pipeline
+  namespace: foo
+spec:
+  tasks:
+  - name: a-task
+    taskRef:
+      name: hello-world
+  - name: b-task
+    runAfter: [a-task]
+    taskRef:
+      name: hello-world
+  - name: c-task
+    runAfter: [a-task]
+    taskRef:
+      name: hello-world
+  - name: d-task
+    runAfter: [b-task, c-task]
+    taskRef:
+      name: hello-world
+  finally:
+  - name: final-task
+    taskRef:
+      name: hello-world
+`)}
+	taskRun := func(pipelineTaskName string, status corev1.ConditionStatus) *v1.TaskRun {
+		return parse.MustParseTaskRunWithObjectMeta(t,
+			taskRunObjectMeta("test-pipeline-run-layers-"+pipelineTaskName, "foo",
+				"test-pipeline-run-layers", "test-pipeline", pipelineTaskName, true),
+			fmt.Sprintf(`
+spec:
+  taskRef:
+    name: hello-world
+status:
+  conditions:
+  - status: %q
+    type: Succeeded
+`, status))
+	}
+
+	for _, tc := range []struct {
+		name     string
+		taskRuns []*v1.TaskRun
+		want     *v1.PipelineRunLayers
+	}{{
+		name: "first reconcile",
+		want: &v1.PipelineRunLayers{Total: 3},
+	}, {
+		name:     "first layer done",
+		taskRuns: []*v1.TaskRun{taskRun("a-task", corev1.ConditionTrue)},
+		want:     &v1.PipelineRunLayers{Total: 3, Completed: 1},
+	}, {
+		name: "second layer partially done",
+		taskRuns: []*v1.TaskRun{
+			taskRun("a-task", corev1.ConditionTrue),
+			taskRun("b-task", corev1.ConditionTrue),
+			taskRun("c-task", corev1.ConditionUnknown),
+		},
+		want: &v1.PipelineRunLayers{Total: 3, Completed: 1},
+	}, {
+		name: "second layer done",
+		taskRuns: []*v1.TaskRun{
+			taskRun("a-task", corev1.ConditionTrue),
+			taskRun("b-task", corev1.ConditionTrue),
+			taskRun("c-task", corev1.ConditionTrue),
+		},
+		want: &v1.PipelineRunLayers{Total: 3, Completed: 2},
+	}, {
+		name: "all layers done while finally is running",
+		taskRuns: []*v1.TaskRun{
+			taskRun("a-task", corev1.ConditionTrue),
+			taskRun("b-task", corev1.ConditionTrue),
+			taskRun("c-task", corev1.ConditionTrue),
+			taskRun("d-task", corev1.ConditionTrue),
+			taskRun("final-task", corev1.ConditionUnknown),
+		},
+		want: &v1.PipelineRunLayers{Total: 3, Completed: 3},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			prs := []*v1.PipelineRun{parse.MustParseV1PipelineRun(t, `
+metadata:
+  name: test-pipeline-run-layers
+  namespace: foo
+spec:
+  pipelineRef:
+    name: test-pipeline
+status:
+  conditions:
+  - status: "Unknown"
+    type: Succeeded
+`)}
+			d := test.Data{
+				PipelineRuns: prs,
+				Pipelines:    ps,
+				Tasks:        []*v1.Task{simpleHelloWorldTask},
+				TaskRuns:     tc.taskRuns,
+				ConfigMaps:   th.NewFeatureFlagsConfigMapInSlice(),
+			}
+			prt := newPipelineRunTest(t, d)
+			defer prt.Cancel()
+
+			reconciledRun, _ := prt.reconcileRun("foo", "test-pipeline-run-layers", []string{}, false)
+			if d := cmp.Diff(tc.want, reconciledRun.Status.Layers); d != "" {
+				t.Errorf("expected the layers of the PipelineRun to be reported. Diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 func TestReconcileWithPipelineResults_OnFailedPipelineRun(t *testing.T) {
 	names.TestingSeed()
 	ps := []*v1.Pipeline{parse.MustParseV1Pipeline(t, `
@@ -7441,7 +9690,8 @@ spec:
   timeout: 1h0m0s
 status:
   conditions:
-  - status: "False"
+  - reason: Failed
+    status: "False"
     type: Succeeded
   results:
   - name: bResult
@@ -7498,6 +9748,11 @@ status:
   results:
     - name: result
       value: aResultValue
+  failureCauses:
+    - pipelineTaskName: b-task
+      kind: TaskRun
+      name: test-failed-pr-with-task-results-b-task
+      reason: Failed
 `)}
 
 	d := test.Data{
@@ -7518,6 +9773,9 @@ status:
 	if d := cmp.Diff(wantPrs[0].Status.Results, reconciledRun.Status.Results, ignoreResourceVersion, ignoreLastTransitionTime); d != "" {
 		t.Errorf("expected to see pipeline run results created. Diff %s", diff.PrintWantGot(d))
 	}
+	if d := cmp.Diff(wantPrs[0].Status.FailureCauses, reconciledRun.Status.FailureCauses); d != "" {
+		t.Errorf("expected to see the failure causes of the pipeline run. Diff %s", diff.PrintWantGot(d))
+	}
 }
 
 func Test_storePipelineSpecAndRefSource(t *testing.T) {
@@ -9452,7 +11710,8 @@ spec:
   serviceAccountName: test-sa
   taskSpec:
     steps:
-    - image: foo:latest
+    - name: unnamed-0
+      image: foo:latest
     workspaces:
     - name: ws
       optional: true
@@ -10248,7 +12507,7 @@ spec:
 				KubeClientSet: fakek8s.NewSimpleClientset(),
 			}
 			rprt := &resources.ResolvedPipelineTask{PipelineTask: &tt.pr.Spec.PipelineSpec.Tasks[0]}
-			_, _, err := c.getTaskrunWorkspaces(ctx, tt.pr, rprt)
+			_, _, err := c.getTaskrunWorkspaces(ctx, tt.pr, rprt, 0)
 			if err == nil {
 				t.Errorf("Pipeline.getTaskrunWorkspaces() did not return error for invalid workspace")
 			} else if d := cmp.Diff(tt.expectedError, err.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
@@ -10358,7 +12617,7 @@ spec:
 			c := Reconciler{
 				KubeClientSet: fakek8s.NewSimpleClientset(),
 			}
-			_, _, err := c.getTaskrunWorkspaces(t.Context(), tt.pr, tt.rprt)
+			_, _, err := c.getTaskrunWorkspaces(t.Context(), tt.pr, tt.rprt, 0)
 			if err != nil {
 				t.Errorf("Pipeline.getTaskrunWorkspaces() returned error for valid pipeline: %v", err)
 			}
@@ -11109,7 +13368,7 @@ spec:
 				t.Fatalf("Got an error getting reconciled run out of fake client: %s", err)
 			}
 			if d := cmp.Diff(tt.expectedPipelineRun, pipelineRun, ignoreResourceVersion, ignoreTypeMeta, ignoreLastTransitionTime,
-				ignoreStartTime, ignoreFinallyStartTime, ignoreProvenance, cmpopts.EquateEmpty()); d != "" {
+				ignoreStartTime, ignoreFinallyStartTime, ignoreProvenance, ignoreLayers, cmpopts.EquateEmpty()); d != "" {
 				t.Errorf("expected PipelineRun was not created. Diff %s", diff.PrintWantGot(d))
 			}
 		})
@@ -11358,7 +13617,7 @@ labels:
 			}
 
 			if d := cmp.Diff(tt.expectedPipelineRun, pipelineRun, ignoreResourceVersion, ignoreTypeMeta, ignoreLastTransitionTime,
-				ignoreStartTime, ignoreFinallyStartTime, ignoreProvenance, cmpopts.EquateEmpty()); d != "" {
+				ignoreStartTime, ignoreFinallyStartTime, ignoreProvenance, ignoreLayers, cmpopts.EquateEmpty()); d != "" {
 				t.Errorf("found PipelineRun does not match expected PipelineRun. Diff %s", diff.PrintWantGot(d))
 			}
 		})
@@ -11914,7 +14173,7 @@ spec:
 			}
 
 			if d := cmp.Diff(tt.expectedPipelineRun, pipelineRun, ignoreResourceVersion, ignoreTypeMeta, ignoreLastTransitionTime,
-				ignoreStartTime, ignoreFinallyStartTime, ignoreProvenance, cmpopts.EquateEmpty()); d != "" {
+				ignoreStartTime, ignoreFinallyStartTime, ignoreProvenance, ignoreLayers, cmpopts.EquateEmpty()); d != "" {
 				t.Errorf("expected PipelineRun was not created. Diff %s", diff.PrintWantGot(d))
 			}
 		})
@@ -12142,7 +14401,7 @@ spec:
 			}
 
 			if d := cmp.Diff(tt.expectedPipelineRun, pipelineRun, ignoreResourceVersion, ignoreTypeMeta, ignoreLastTransitionTime,
-				ignoreStartTime, ignoreFinallyStartTime, ignoreProvenance, cmpopts.EquateEmpty()); d != "" {
+				ignoreStartTime, ignoreFinallyStartTime, ignoreProvenance, ignoreLayers, cmpopts.EquateEmpty()); d != "" {
 				t.Errorf("expected PipelineRun was not created. Diff %s", diff.PrintWantGot(d))
 			}
 		})
@@ -12738,7 +14997,7 @@ spec:
 			}
 
 			if d := cmp.Diff(tt.expectedPipelineRun, pipelineRun, ignoreResourceVersion, ignoreTypeMeta, ignoreLastTransitionTime,
-				ignoreStartTime, ignoreFinallyStartTime, ignoreProvenance, cmpopts.EquateEmpty()); d != "" {
+				ignoreStartTime, ignoreFinallyStartTime, ignoreProvenance, ignoreLayers, cmpopts.EquateEmpty()); d != "" {
 				t.Errorf("expected PipelineRun was not created. Diff %s", diff.PrintWantGot(d))
 			}
 		})
@@ -13235,7 +15494,7 @@ spec:
 			}
 
 			if d := cmp.Diff(tt.expectedPipelineRun, pipelineRun, ignoreResourceVersion, ignoreTypeMeta, ignoreLastTransitionTime,
-				ignoreStartTime, ignoreFinallyStartTime, ignoreProvenance, cmpopts.EquateEmpty()); d != "" {
+				ignoreStartTime, ignoreFinallyStartTime, ignoreProvenance, ignoreLayers, cmpopts.EquateEmpty()); d != "" {
 				t.Errorf("expected PipelineRun was not created. Diff %s", diff.PrintWantGot(d))
 			}
 		})
@@ -13468,7 +15727,7 @@ spec:
 			}
 
 			if d := cmp.Diff(tt.expectedPipelineRun, pipelineRun, ignoreResourceVersion, ignoreTypeMeta, ignoreLastTransitionTime,
-				ignoreStartTime, ignoreFinallyStartTime, ignoreProvenance, cmpopts.EquateEmpty()); d != "" {
+				ignoreStartTime, ignoreFinallyStartTime, ignoreProvenance, ignoreLayers, cmpopts.EquateEmpty()); d != "" {
 				t.Errorf("expected PipelineRun was not created. Diff %s", diff.PrintWantGot(d))
 			}
 		})
@@ -13911,7 +16170,7 @@ status:
 			}
 
 			if d := cmp.Diff(tt.expectedPipelineRun, pipelineRun, ignoreResourceVersion, ignoreTypeMeta, ignoreLastTransitionTime,
-				ignoreStartTime, ignoreProvenance, cmpopts.SortSlices(lessChildReferences), cmpopts.EquateEmpty()); d != "" {
+				ignoreStartTime, ignoreProvenance, ignoreLayers, cmpopts.SortSlices(lessChildReferences), cmpopts.EquateEmpty()); d != "" {
 				t.Errorf("expected PipelineRun was not created. Diff %s", diff.PrintWantGot(d))
 			}
 		})
@@ -14436,7 +16695,7 @@ spec:
 			}
 
 			if d := cmp.Diff(tt.expectedPipelineRun, pipelineRun, ignoreResourceVersion, ignoreTypeMeta, ignoreLastTransitionTime,
-				ignoreStartTime, ignoreFinallyStartTime, ignoreProvenance, cmpopts.EquateEmpty()); d != "" {
+				ignoreStartTime, ignoreFinallyStartTime, ignoreProvenance, ignoreLayers, cmpopts.EquateEmpty()); d != "" {
 				t.Errorf("expected PipelineRun was not created. Diff %s", diff.PrintWantGot(d))
 			}
 		})
@@ -14874,13 +17133,171 @@ spec:
 				t.Fatalf("Got an error getting reconciled run out of fake client: %s", err)
 			}
 			if d := cmp.Diff(tt.expectedPipelineRun, pipelineRun, ignoreResourceVersion, ignoreTypeMeta, ignoreLastTransitionTime,
-				ignoreStartTime, ignoreFinallyStartTime, ignoreProvenance, cmpopts.EquateEmpty()); d != "" {
+				ignoreStartTime, ignoreFinallyStartTime, ignoreProvenance, ignoreLayers, cmpopts.EquateEmpty()); d != "" {
 				t.Errorf("expected PipelineRun was not created. Diff %s", diff.PrintWantGot(d))
 			}
 		})
 	}
 }
 
+func TestReconciler_PipelineTaskMatrix_FailFast(t *testing.T) {
+	names.TestingSeed()
+
+	task := parse.MustParseV1Task(t, `
+metadata:
+  name: mytask
+  namespace: foo
+spec:
+  params:
+    - name: platform
+  steps:
+    - name: echo
+      image: alpine
+      script: echo $(params.platform)
+`)
+	matrixTaskRun := func(name, platform string, status corev1.ConditionStatus, reason string) *v1.TaskRun {
+		tr := parse.MustParseTaskRunWithObjectMeta(t, taskRunObjectMeta(name, "foo", "pr", "p", "build", false), fmt.Sprintf(`
+spec:
+  params:
+  - name: platform
+    value: %s
+  serviceAccountName: test-sa
+  taskRef:
+    name: mytask
+    kind: Task
+`, platform))
+		tr.Status.SetCondition(&apis.Condition{Type: apis.ConditionSucceeded, Status: status, Reason: reason})
+		return tr
+	}
+	failed := matrixTaskRun("pr-build-0", "linux", corev1.ConditionFalse, v1.TaskRunReasonFailed.String())
+
+	for _, tc := range []struct {
+		name            string
+		failFast        string
+		childRefs       []string
+		taskRuns        []*v1.TaskRun
+		wantTaskRuns    []string
+		wantCondition   corev1.ConditionStatus
+		wantChildReason map[string]string
+	}{{
+		name:          "instances created after the failure of one",
+		failFast:      "false",
+		childRefs:     []string{"pr-build-0"},
+		taskRuns:      []*v1.TaskRun{failed},
+		wantTaskRuns:  []string{"pr-build-0", "pr-build-1", "pr-build-2"},
+		wantCondition: corev1.ConditionUnknown,
+		wantChildReason: map[string]string{
+			"pr-build-0": v1.TaskRunReasonFailed.String(),
+			"pr-build-1": "",
+			"pr-build-2": "",
+		},
+	}, {
+		name:          "instances not created after the failure of one when failing fast",
+		failFast:      "true",
+		childRefs:     []string{"pr-build-0"},
+		taskRuns:      []*v1.TaskRun{failed},
+		wantTaskRuns:  []string{"pr-build-0"},
+		wantCondition: corev1.ConditionFalse,
+		wantChildReason: map[string]string{
+			"pr-build-0": "",
+		},
+	}, {
+		name:      "outcomes of the instances aggregated once all are done",
+		failFast:  "false",
+		childRefs: []string{"pr-build-0", "pr-build-1", "pr-build-2"},
+		taskRuns: []*v1.TaskRun{
+			failed,
+			matrixTaskRun("pr-build-1", "mac", corev1.ConditionTrue, v1.TaskRunReasonSuccessful.String()),
+			matrixTaskRun("pr-build-2", "windows", corev1.ConditionTrue, v1.TaskRunReasonSuccessful.String()),
+		},
+		wantTaskRuns:  []string{"pr-build-0", "pr-build-1", "pr-build-2"},
+		wantCondition: corev1.ConditionFalse,
+		wantChildReason: map[string]string{
+			"pr-build-0": v1.TaskRunReasonFailed.String(),
+			"pr-build-1": v1.TaskRunReasonSuccessful.String(),
+			"pr-build-2": v1.TaskRunReasonSuccessful.String(),
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			p := parse.MustParseV1Pipeline(t, fmt.Sprintf(`
+metadata:
+  name: p
+  namespace: foo
+spec:
+  tasks:
+    - name: build
+      taskRef:
+        name: mytask
+      matrix:
+        failFast: %s
+        params:
+          - name: platform
+            value:
+              - linux
+              - mac
+              - windows
+`, tc.failFast))
+			pr := parse.MustParseV1PipelineRun(t, `
+metadata:
+  name: pr
+  namespace: foo
+spec:
+  taskRunTemplate:
+    serviceAccountName: test-sa
+  pipelineRef:
+    name: p
+status:
+  conditions:
+  - type: Succeeded
+    status: "Unknown"
+    reason: Running
+`)
+			for _, name := range tc.childRefs {
+				pr.Status.ChildReferences = append(pr.Status.ChildReferences, v1.ChildStatusReference{
+					TypeMeta:         runtime.TypeMeta{APIVersion: "tekton.dev/v1", Kind: "TaskRun"},
+					Name:             name,
+					PipelineTaskName: "build",
+				})
+			}
+			d := test.Data{
+				PipelineRuns: []*v1.PipelineRun{pr},
+				Pipelines:    []*v1.Pipeline{p},
+				Tasks:        []*v1.Task{task},
+				TaskRuns:     tc.taskRuns,
+				ConfigMaps:   th.NewFeatureFlagsConfigMapWithMatrixInSlice(10),
+			}
+			prt := newPipelineRunTest(t, d)
+			defer prt.Cancel()
+
+			reconciledRun, clients := prt.reconcileRun("foo", "pr", []string{}, false)
+
+			taskRuns, err := clients.Pipeline.TektonV1().TaskRuns("foo").List(prt.TestAssets.Ctx, metav1.ListOptions{})
+			if err != nil {
+				t.Fatalf("Failure to list TaskRuns: %v", err)
+			}
+			var gotTaskRuns []string
+			for _, tr := range taskRuns.Items {
+				gotTaskRuns = append(gotTaskRuns, tr.Name)
+			}
+			sort.Strings(gotTaskRuns)
+			if d := cmp.Diff(tc.wantTaskRuns, gotTaskRuns); d != "" {
+				t.Errorf("Unexpected TaskRuns %s", diff.PrintWantGot(d))
+			}
+
+			if got := reconciledRun.Status.GetCondition(apis.ConditionSucceeded).Status; got != tc.wantCondition {
+				t.Errorf("Expected the PipelineRun condition to be %s, got %s", tc.wantCondition, got)
+			}
+			gotChildReason := map[string]string{}
+			for _, cr := range reconciledRun.Status.ChildReferences {
+				gotChildReason[cr.Name] = cr.Reason
+			}
+			if d := cmp.Diff(tc.wantChildReason, gotChildReason); d != "" {
+				t.Errorf("Unexpected reasons of the child references %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 func TestReconciler_PipelineTaskMatrixExplicitCombosResultsAndMatrixContextVars(t *testing.T) {
 	names.TestingSeed()
 	task1 := parse.MustParseV1Task(t, `
@@ -15620,7 +18037,7 @@ spec:
 			if err != nil {
 				t.Fatalf("Got an error getting reconciled run out of fake client: %s", err)
 			}
-			if d := cmp.Diff(tt.expectedPipelineRun, pipelineRun, ignoreResourceVersion, ignoreTypeMeta, ignoreLastTransitionTime, ignoreStartTime, ignoreFinallyStartTime, ignoreProvenance, cmpopts.EquateEmpty(), cmpopts.SortSlices(lessChildReferences)); d != "" {
+			if d := cmp.Diff(tt.expectedPipelineRun, pipelineRun, ignoreResourceVersion, ignoreTypeMeta, ignoreLastTransitionTime, ignoreStartTime, ignoreFinallyStartTime, ignoreProvenance, ignoreLayers, cmpopts.EquateEmpty(), cmpopts.SortSlices(lessChildReferences)); d != "" {
 				t.Errorf("expected PipelineRun was not created. Diff %s", diff.PrintWantGot(d))
 			}
 		})
@@ -16096,7 +18513,7 @@ spec:
 			if err != nil {
 				t.Fatalf("Got an error getting reconciled run out of fake client: %s", err)
 			}
-			if d := cmp.Diff(tt.expectedPipelineRun, pipelineRun, ignoreResourceVersion, ignoreTypeMeta, ignoreLastTransitionTime, ignoreStartTime, ignoreFinallyStartTime, ignoreProvenance, cmpopts.EquateEmpty()); d != "" {
+			if d := cmp.Diff(tt.expectedPipelineRun, pipelineRun, ignoreResourceVersion, ignoreTypeMeta, ignoreLastTransitionTime, ignoreStartTime, ignoreFinallyStartTime, ignoreProvenance, ignoreLayers, cmpopts.EquateEmpty()); d != "" {
 				t.Errorf("expected PipelineRun was not created. Diff %s", diff.PrintWantGot(d))
 			}
 		})
@@ -16394,7 +18811,7 @@ spec:
 			}
 
 			if d := cmp.Diff(tt.expectedPipelineRun, pipelineRun, ignoreResourceVersion, ignoreTypeMeta, ignoreLastTransitionTime,
-				ignoreStartTime, ignoreFinallyStartTime, ignoreProvenance, cmpopts.EquateEmpty()); d != "" {
+				ignoreStartTime, ignoreFinallyStartTime, ignoreProvenance, ignoreLayers, cmpopts.EquateEmpty()); d != "" {
 				t.Errorf("expected PipelineRun was not created. Diff %s", diff.PrintWantGot(d))
 			}
 		})
@@ -16878,7 +19295,7 @@ spec:
 	failNoKeysCondition := &apis.Condition{
 		Type:    trustedresources.ConditionTrustedResourcesVerified,
 		Status:  corev1.ConditionFalse,
-		Message: fmt.Sprintf("failed to get verifiers for resource %s from namespace %s: %s", ts.Name, ts.Namespace, verifier.ErrEmptyPublicKeys),
+		Message: fmt.Sprintf("failed to get verifiers for resource %s from namespace %s from policy %s: %s", ts.Name, ts.Namespace, "warn-policy", verifier.ErrEmptyPublicKeys),
 	}
 	testCases := []struct {
 		name                          string
@@ -17215,7 +19632,7 @@ spec:
 	failNoKeysCondition := &apis.Condition{
 		Type:    trustedresources.ConditionTrustedResourcesVerified,
 		Status:  corev1.ConditionFalse,
-		Message: fmt.Sprintf("failed to get verifiers for resource %s from namespace %s: %s", ts.Name, ts.Namespace, verifier.ErrEmptyPublicKeys),
+		Message: fmt.Sprintf("failed to get verifiers for resource %s from namespace %s from policy %s: %s", ts.Name, ts.Namespace, "warn-policy", verifier.ErrEmptyPublicKeys),
 	}
 	testCases := []struct {
 		name                          string
@@ -18006,7 +20423,7 @@ status:
 	expectedPipelineRun.Status.PipelineSpec = &ps[0].Spec
 
 	// The PipelineRun should include a task3 child
-	if d := cmp.Diff(expectedPipelineRun, reconciledRun, ignoreResourceVersion, ignoreLastTransitionTime, ignoreTypeMeta, ignoreProvenance, ignoreStartTime); d != "" {
+	if d := cmp.Diff(expectedPipelineRun, reconciledRun, ignoreResourceVersion, ignoreLastTransitionTime, ignoreTypeMeta, ignoreProvenance, ignoreLayers, ignoreStartTime); d != "" {
 		t.Errorf("Expected to see PipelineRun run with a task3 child reference %s", diff.PrintWantGot(d))
 	}
 
@@ -18822,7 +21239,7 @@ spec:
 				},
 			})
 
-			result, err := r.createTaskRun(ctx, trName, nil, rpt, pr, facts)
+			result, err := r.createTaskRun(ctx, trName, 0, nil, rpt, pr, facts)
 			if tc.expectErr {
 				if err == nil {
 					t.Fatalf("expected error, got nil")
@@ -18983,7 +21400,7 @@ spec:
 				},
 			})
 
-			result, err := r.createCustomRun(ctx, crName, nil, rpt, pr, facts)
+			result, err := r.createCustomRun(ctx, crName, 0, nil, rpt, pr, facts)
 			if tc.expectErr {
 				if err == nil {
 					t.Fatalf("expected error, got nil")
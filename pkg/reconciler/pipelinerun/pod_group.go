@@ -0,0 +1,63 @@
+/*
+Copyright 2026 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipelinerun
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// setPodGroup sets the pod group label configured by "default-pod-group-label-key" to the UID of pr,
+// and the annotation configured by "default-pod-group-size-annotation-key" to the size of the pod group,
+// to the given metadata of a TaskRun or pod, for the batch schedulers to schedule the pods of pr as a
+// group. Nothing is set when no pod group label key is configured.
+func setPodGroup(ctx context.Context, pr *v1.PipelineRun, meta *metav1.ObjectMeta) {
+	defaults := config.FromContextOrDefaults(ctx).Defaults
+	if defaults == nil || defaults.DefaultPodGroupLabelKey == "" {
+		return
+	}
+	if meta.Labels == nil {
+		meta.Labels = map[string]string{}
+	}
+	if meta.Annotations == nil {
+		meta.Annotations = map[string]string{}
+	}
+	meta.Labels[defaults.DefaultPodGroupLabelKey] = string(pr.UID)
+	meta.Annotations[defaults.PodGroupSizeAnnotationKey()] = strconv.Itoa(podGroupSize(pr, defaults.DefaultPodGroupSize))
+}
+
+// podGroupSize returns the number of tasks of the resolved Pipeline of pr in its pod group: the tasks
+// which don't depend on any other task with PodGroupSizeFirstLayer, and all the tasks otherwise. The
+// finally tasks aren't counted.
+func podGroupSize(pr *v1.PipelineRun, size string) int {
+	if pr.Status.PipelineSpec == nil {
+		return 0
+	}
+	tasks := v1.PipelineTaskList(pr.Status.PipelineSpec.Tasks)
+	if size != config.PodGroupSizeFirstLayer {
+		return len(tasks)
+	}
+	deps := tasks.Deps()
+	count := 0
+	for _, t := range tasks {
+		if len(deps[t.HashKey()]) == 0 {
+			count++
+		}
+	}
+	return count
+}
@@ -21,12 +21,15 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/tektoncd/pipeline/internal/artifactref"
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/pipeline/pkg/reconciler/taskrun/resources"
 	"github.com/tektoncd/pipeline/pkg/substitution"
 	"github.com/tektoncd/pipeline/pkg/workspace"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 const (
@@ -429,6 +432,8 @@ func filterMatrixContextVar(params v1.Params) v1.Params {
 // ApplyPipelineTaskContexts applies the substitution from $(context.pipelineTask.*) with the specified values.
 // Uses "0" as a default if a value is not available as well as matrix context variables
 // $(tasks.<pipelineTaskName>.matrix.length) and $(tasks.<pipelineTaskName>.matrix.<resultName>.length)
+// It also applies $(context.pipelineRun.timeoutRemaining), computed from the PipelineRun timeouts in facts,
+// and $(context.pipelineRun.finallyTimeoutRemaining) for final tasks, computed from the finally timeout alone.
 func ApplyPipelineTaskContexts(pt *v1.PipelineTask, pipelineRunStatus v1.PipelineRunStatus, facts *PipelineRunFacts) *v1.PipelineTask {
 	pt = pt.DeepCopy()
 	var pipelineTaskName string
@@ -438,6 +443,23 @@ func ApplyPipelineTaskContexts(pt *v1.PipelineTask, pipelineRunStatus v1.Pipelin
 	replacements := map[string]string{
 		"context.pipelineTask.retries": strconv.Itoa(pt.Retries),
 	}
+	if facts != nil {
+		rpt := &ResolvedPipelineTask{PipelineTask: pt}
+		// "0s" indicates that the PipelineRun does not time out
+		timeoutRemaining := time.Duration(0)
+		if remaining := rpt.TimeoutRemaining(facts); remaining != nil {
+			timeoutRemaining = *remaining
+		}
+		replacements["context.pipelineRun.timeoutRemaining"] = timeoutRemaining.String()
+		if facts.FinalTasksGraph != nil && rpt.IsFinalTask(facts) {
+			// "0s" indicates that the finally tasks do not time out
+			finallyTimeoutRemaining := time.Duration(0)
+			if remaining := rpt.FinallyTimeoutRemaining(facts); remaining != nil {
+				finallyTimeoutRemaining = *remaining
+			}
+			replacements["context.pipelineRun.finallyTimeoutRemaining"] = finallyTimeoutRemaining.String()
+		}
+	}
 
 	filteredParams := filterMatrixContextVar(pt.Params)
 
@@ -514,6 +536,52 @@ func ApplyTaskResults(targets PipelineRunState, resolvedResultRefs ResolvedResul
 	}
 }
 
+// ApplyRetriesAndTimeout resolves the retriesFrom and timeoutFrom fields of the PipelineTask of rpt, which hold
+// a reference to a param, already replaced by ApplyParameters, or to a string result of another PipelineTask, and
+// sets its retries and timeout from the resolved values. It returns an error naming the PipelineTask which
+// produced the value if the value can't be parsed.
+func ApplyRetriesAndTimeout(state PipelineRunState, rpt *ResolvedPipelineTask) error {
+	if rpt.PipelineTask.RetriesFrom == "" && rpt.PipelineTask.TimeoutFrom == "" {
+		return nil
+	}
+	resolvedResultRefs, _, err := ResolveResultRefs(state, PipelineRunState{rpt})
+	if err != nil {
+		return err
+	}
+	stringReplacements := resolvedResultRefs.getStringReplacements()
+	pipelineTask := rpt.PipelineTask.DeepCopy()
+	if pipelineTask.RetriesFrom != "" {
+		value := substitution.ApplyReplacements(pipelineTask.RetriesFrom, stringReplacements)
+		// the retries are parsed strictly as a non-negative decimal integer which fits the int32 of the API
+		retries, err := strconv.ParseUint(value, 10, 31)
+		if err != nil {
+			return fmt.Errorf("invalid retries %q%s for PipelineTask %s: must be a non-negative integer", value, producedBy(pipelineTask.RetriesFrom), pipelineTask.Name)
+		}
+		pipelineTask.Retries = int(retries)
+		pipelineTask.RetriesFrom = ""
+	}
+	if pipelineTask.TimeoutFrom != "" {
+		value := substitution.ApplyReplacements(pipelineTask.TimeoutFrom, stringReplacements)
+		timeout, err := time.ParseDuration(value)
+		if err != nil || timeout < 0 {
+			return fmt.Errorf("invalid timeout %q%s for PipelineTask %s: must be a non-negative duration such as \"1h30m\"", value, producedBy(pipelineTask.TimeoutFrom), pipelineTask.Name)
+		}
+		pipelineTask.Timeout = &metav1.Duration{Duration: timeout}
+		pipelineTask.TimeoutFrom = ""
+	}
+	rpt.PipelineTask = pipelineTask
+	return nil
+}
+
+// producedBy describes the PipelineTask producing the result referenced by value, if any.
+func producedBy(value string) string {
+	refs := v1.NewResultRefsFromString(value)
+	if len(refs) == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" produced by PipelineTask %s", refs[0].PipelineTask)
+}
+
 // ApplyPipelineTaskStateContext replaces context variables referring to execution status with the specified status
 func ApplyPipelineTaskStateContext(state PipelineRunState, replacements map[string]string) {
 	for _, resolvedPipelineRunTask := range state {
@@ -573,6 +641,8 @@ func replaceVariablesInPipelineTasks(tasks []v1.PipelineTask, replacements map[s
 			tasks[i].TaskRef.Name = substitution.ApplyReplacements(tasks[i].TaskRef.Name, replacements)
 		}
 		tasks[i].OnError = v1.PipelineTaskOnErrorType(substitution.ApplyReplacements(string(tasks[i].OnError), replacements))
+		tasks[i].RetriesFrom = substitution.ApplyReplacements(tasks[i].RetriesFrom, replacements)
+		tasks[i].TimeoutFrom = substitution.ApplyReplacements(tasks[i].TimeoutFrom, replacements)
 		tasks[i] = propagateParams(tasks[i], replacements, arrayReplacements, objectReplacements)
 	}
 }
@@ -685,13 +755,53 @@ func PropagateArtifacts(rpt *ResolvedPipelineTask, runStates PipelineRunState) e
 	if rpt.ResolvedTask == nil || rpt.ResolvedTask.TaskSpec == nil {
 		return nil
 	}
+	stringReplacements, err := artifactReplacements(runStates.GetTaskRunsArtifacts())
+	if err != nil {
+		return err
+	}
+	rpt.ResolvedTask.TaskSpec = resources.ApplyReplacements(rpt.ResolvedTask.TaskSpec, stringReplacements, map[string][]string{}, map[string]map[string]string{})
+	return nil
+}
+
+// ApplyPipelineTaskArtifacts replaces the references to the artifacts of the pipeline tasks in the params of
+// the final tasks, $(tasks.<name>.inputs.<artifact>) and $(tasks.<name>.outputs.<artifact>), with the JSON of
+// the values of the artifacts, collected in the status of the TaskRuns of the pipeline tasks. The references
+// to artifacts which weren't produced, e.g. by pipeline tasks which were skipped, are replaced with an empty list.
+func ApplyPipelineTaskArtifacts(state PipelineRunState, artifacts map[string]*v1.Artifacts) error {
+	replacements, err := artifactReplacements(artifacts)
+	if err != nil {
+		return err
+	}
+	for _, resolvedPipelineRunTask := range state {
+		if resolvedPipelineRunTask.PipelineTask == nil {
+			continue
+		}
+		pipelineTask := resolvedPipelineRunTask.PipelineTask.DeepCopy()
+		notProduced := map[string]string{}
+		for _, p := range pipelineTask.Params {
+			expressions, _ := p.GetVarSubstitutionExpressions()
+			for _, expression := range expressions {
+				if _, ok := replacements[expression]; !ok && artifactref.TaskArtifactRegex.MatchString("$("+expression+")") {
+					notProduced[expression] = "[]"
+				}
+			}
+		}
+		pipelineTask.Params = pipelineTask.Params.ReplaceVariables(replacements, nil, nil).ReplaceVariables(notProduced, nil, nil)
+		resolvedPipelineRunTask.PipelineTask = pipelineTask
+	}
+	return nil
+}
+
+// artifactReplacements returns the replacements of the references to the artifacts of the pipeline tasks,
+// keyed by the names of the pipeline tasks, with the JSON of their values.
+func artifactReplacements(taskArtifacts map[string]*v1.Artifacts) (map[string]string, error) {
 	stringReplacements := map[string]string{}
-	for taskName, artifacts := range runStates.GetTaskRunsArtifacts() {
+	for taskName, artifacts := range taskArtifacts {
 		if artifacts != nil {
 			for i, input := range artifacts.Inputs {
 				ib, err := json.Marshal(input.Values)
 				if err != nil {
-					return err
+					return nil, err
 				}
 				stringReplacements[fmt.Sprintf("tasks.%s.inputs.%s", taskName, input.Name)] = string(ib)
 				if i == 0 {
@@ -701,7 +811,7 @@ func PropagateArtifacts(rpt *ResolvedPipelineTask, runStates PipelineRunState) e
 			for i, output := range artifacts.Outputs {
 				ob, err := json.Marshal(output.Values)
 				if err != nil {
-					return err
+					return nil, err
 				}
 				stringReplacements[fmt.Sprintf("tasks.%s.outputs.%s", taskName, output.Name)] = string(ob)
 				if i == 0 {
@@ -710,8 +820,7 @@ func PropagateArtifacts(rpt *ResolvedPipelineTask, runStates PipelineRunState) e
 			}
 		}
 	}
-	rpt.ResolvedTask.TaskSpec = resources.ApplyReplacements(rpt.ResolvedTask.TaskSpec, stringReplacements, map[string][]string{}, map[string]map[string]string{})
-	return nil
+	return stringReplacements, nil
 }
 
 // ApplyTaskResultsToPipelineResults applies the results of completed TasksRuns and Runs to a Pipeline's
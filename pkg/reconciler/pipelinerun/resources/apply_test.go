@@ -20,16 +20,19 @@ import (
 	"errors"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/reconciler/pipeline/dag"
 	"github.com/tektoncd/pipeline/pkg/reconciler/pipelinerun/resources"
 	taskresources "github.com/tektoncd/pipeline/pkg/reconciler/taskrun/resources"
 	"github.com/tektoncd/pipeline/test/diff"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/selection"
+	clock "k8s.io/utils/clock/testing"
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 )
@@ -1782,6 +1785,36 @@ func TestApplyParameters(t *testing.T) {
 				}},
 			},
 		},
+		{
+			name: "parameters in retriesFrom and timeoutFrom",
+			original: v1.PipelineSpec{
+				Params: []v1.ParamSpec{
+					{Name: "retries", Type: v1.ParamTypeString, Default: v1.NewStructuredValues("2")},
+					{Name: "timeout", Type: v1.ParamTypeString},
+				},
+				Tasks: []v1.PipelineTask{{
+					RetriesFrom: "$(params.retries)",
+					TimeoutFrom: "$(params.timeout)",
+				}},
+				Finally: []v1.PipelineTask{{
+					TimeoutFrom: "$(params.timeout)",
+				}},
+			},
+			params: v1.Params{{Name: "timeout", Value: *v1.NewStructuredValues("10m")}},
+			expected: v1.PipelineSpec{
+				Params: []v1.ParamSpec{
+					{Name: "retries", Type: v1.ParamTypeString, Default: v1.NewStructuredValues("2")},
+					{Name: "timeout", Type: v1.ParamTypeString},
+				},
+				Tasks: []v1.PipelineTask{{
+					RetriesFrom: "2",
+					TimeoutFrom: "10m",
+				}},
+				Finally: []v1.PipelineTask{{
+					TimeoutFrom: "10m",
+				}},
+			},
+		},
 		{
 			name: "parameter default value inherited from another parameter - no override",
 			original: v1.PipelineSpec{
@@ -3831,6 +3864,10 @@ func TestContext(t *testing.T) {
 }
 
 func TestApplyPipelineTaskContexts(t *testing.T) {
+	startTime := time.Date(2022, time.January, 1, 0, 0, 0, 0, time.UTC)
+	pipelineTimeout := time.Hour
+	finallyStartTime := startTime.Add(45 * time.Minute)
+	finallyTimeout := 20 * time.Minute
 	for _, tc := range []struct {
 		description string
 		pt          v1.PipelineTask
@@ -4072,6 +4109,110 @@ func TestApplyPipelineTaskContexts(t *testing.T) {
 				Value: *v1.NewStructuredValues("3"),
 			}},
 		},
+	}, {
+		description: "context pipelineRun timeoutRemaining replacement",
+		pt: v1.PipelineTask{
+			Name: "task",
+			Params: v1.Params{{
+				Name:  "timeout-remaining",
+				Value: *v1.NewStructuredValues("$(context.pipelineRun.timeoutRemaining)"),
+			}},
+		},
+		facts: &resources.PipelineRunFacts{
+			FinalTasksGraph: &dag.Graph{},
+			TimeoutsState: resources.PipelineRunTimeoutsState{
+				StartTime:       &startTime,
+				PipelineTimeout: &pipelineTimeout,
+				Clock:           clock.NewFakePassiveClock(startTime.Add(50 * time.Minute)),
+			},
+		},
+		want: v1.PipelineTask{
+			Name: "task",
+			Params: v1.Params{{
+				Name:  "timeout-remaining",
+				Value: *v1.NewStructuredValues("10m0s"),
+			}},
+		},
+	}, {
+		description: "context pipelineRun timeoutRemaining replacement without timeout",
+		pt: v1.PipelineTask{
+			Name: "task",
+			Params: v1.Params{{
+				Name:  "timeout-remaining",
+				Value: *v1.NewStructuredValues("$(context.pipelineRun.timeoutRemaining)"),
+			}},
+		},
+		facts: &resources.PipelineRunFacts{
+			FinalTasksGraph: &dag.Graph{},
+			TimeoutsState: resources.PipelineRunTimeoutsState{
+				StartTime: &startTime,
+				Clock:     clock.NewFakePassiveClock(startTime.Add(50 * time.Minute)),
+			},
+		},
+		want: v1.PipelineTask{
+			Name: "task",
+			Params: v1.Params{{
+				Name:  "timeout-remaining",
+				Value: *v1.NewStructuredValues("0s"),
+			}},
+		},
+	}, {
+		description: "context pipelineRun finallyTimeoutRemaining replacement",
+		pt: v1.PipelineTask{
+			Name: "final-task",
+			Params: v1.Params{{
+				Name:  "timeout-remaining",
+				Value: *v1.NewStructuredValues("$(context.pipelineRun.timeoutRemaining)"),
+			}, {
+				Name:  "finally-timeout-remaining",
+				Value: *v1.NewStructuredValues("$(context.pipelineRun.finallyTimeoutRemaining)"),
+			}},
+		},
+		facts: &resources.PipelineRunFacts{
+			FinalTasksGraph: &dag.Graph{Nodes: map[string]*dag.Node{"final-task": {Key: "final-task"}}},
+			TimeoutsState: resources.PipelineRunTimeoutsState{
+				StartTime:        &startTime,
+				FinallyStartTime: &finallyStartTime,
+				PipelineTimeout:  &pipelineTimeout,
+				FinallyTimeout:   &finallyTimeout,
+				Clock:            clock.NewFakePassiveClock(startTime.Add(50 * time.Minute)),
+			},
+		},
+		want: v1.PipelineTask{
+			Name: "final-task",
+			Params: v1.Params{{
+				Name:  "timeout-remaining",
+				Value: *v1.NewStructuredValues("10m0s"),
+			}, {
+				Name:  "finally-timeout-remaining",
+				Value: *v1.NewStructuredValues("10m0s"),
+			}},
+		},
+	}, {
+		description: "context pipelineRun finallyTimeoutRemaining replacement without finally timeout",
+		pt: v1.PipelineTask{
+			Name: "final-task",
+			Params: v1.Params{{
+				Name:  "finally-timeout-remaining",
+				Value: *v1.NewStructuredValues("$(context.pipelineRun.finallyTimeoutRemaining)"),
+			}},
+		},
+		facts: &resources.PipelineRunFacts{
+			FinalTasksGraph: &dag.Graph{Nodes: map[string]*dag.Node{"final-task": {Key: "final-task"}}},
+			TimeoutsState: resources.PipelineRunTimeoutsState{
+				StartTime:        &startTime,
+				FinallyStartTime: &finallyStartTime,
+				PipelineTimeout:  &pipelineTimeout,
+				Clock:            clock.NewFakePassiveClock(startTime.Add(50 * time.Minute)),
+			},
+		},
+		want: v1.PipelineTask{
+			Name: "final-task",
+			Params: v1.Params{{
+				Name:  "finally-timeout-remaining",
+				Value: *v1.NewStructuredValues("0s"),
+			}},
+		},
 	}} {
 		t.Run(tc.description, func(t *testing.T) {
 			got := resources.ApplyPipelineTaskContexts(&tc.pt, tc.prstatus, tc.facts)
@@ -4859,6 +5000,118 @@ func TestApplyTaskRunContext(t *testing.T) {
 	}
 }
 
+func TestApplyRetriesAndTimeout(t *testing.T) {
+	sizing := &resources.ResolvedPipelineTask{
+		PipelineTask: &v1.PipelineTask{Name: "sizing"},
+		TaskRunNames: []string{"sizing-taskrun"},
+		TaskRuns: []*v1.TaskRun{{
+			ObjectMeta: metav1.ObjectMeta{Name: "sizing-taskrun"},
+			Status: v1.TaskRunStatus{
+				Status: duckv1.Status{
+					Conditions: duckv1.Conditions{{
+						Type:   apis.ConditionSucceeded,
+						Status: corev1.ConditionTrue,
+					}},
+				},
+				TaskRunStatusFields: v1.TaskRunStatusFields{
+					Results: []v1.TaskRunResult{{
+						Name:  "retries",
+						Value: *v1.NewStructuredValues("3"),
+					}, {
+						Name:  "timeout",
+						Value: *v1.NewStructuredValues("1h30m"),
+					}, {
+						Name:  "bad-retries",
+						Value: *v1.NewStructuredValues("+3"),
+					}, {
+						Name:  "bad-timeout",
+						Value: *v1.NewStructuredValues("90"),
+					}},
+				},
+			},
+		}},
+	}
+	for _, tc := range []struct {
+		name        string
+		pt          v1.PipelineTask
+		expectedPt  v1.PipelineTask
+		expectedErr string
+	}{{
+		name: "no retriesFrom nor timeoutFrom",
+		pt: v1.PipelineTask{
+			Name:    "build",
+			Retries: 1,
+			Timeout: &metav1.Duration{Duration: time.Hour},
+		},
+		expectedPt: v1.PipelineTask{
+			Name:    "build",
+			Retries: 1,
+			Timeout: &metav1.Duration{Duration: time.Hour},
+		},
+	}, {
+		name: "retries and timeout from results",
+		pt: v1.PipelineTask{
+			Name:        "build",
+			RetriesFrom: "$(tasks.sizing.results.retries)",
+			TimeoutFrom: "$(tasks.sizing.results.timeout)",
+		},
+		expectedPt: v1.PipelineTask{
+			Name:    "build",
+			Retries: 3,
+			Timeout: &metav1.Duration{Duration: 90 * time.Minute},
+		},
+	}, {
+		name: "retries and timeout from replaced params",
+		pt: v1.PipelineTask{
+			Name:        "build",
+			RetriesFrom: "0",
+			TimeoutFrom: "0s",
+		},
+		expectedPt: v1.PipelineTask{
+			Name:    "build",
+			Timeout: &metav1.Duration{Duration: 0},
+		},
+	}, {
+		name: "invalid retries from result",
+		pt: v1.PipelineTask{
+			Name:        "build",
+			RetriesFrom: "$(tasks.sizing.results.bad-retries)",
+		},
+		expectedErr: `invalid retries "+3" produced by PipelineTask sizing for PipelineTask build: must be a non-negative integer`,
+	}, {
+		name: "invalid timeout from result",
+		pt: v1.PipelineTask{
+			Name:        "build",
+			TimeoutFrom: "$(tasks.sizing.results.bad-timeout)",
+		},
+		expectedErr: `invalid timeout "90" produced by PipelineTask sizing for PipelineTask build: must be a non-negative duration such as "1h30m"`,
+	}, {
+		name: "negative timeout from replaced param",
+		pt: v1.PipelineTask{
+			Name:        "build",
+			TimeoutFrom: "-1h",
+		},
+		expectedErr: `invalid timeout "-1h" for PipelineTask build: must be a non-negative duration such as "1h30m"`,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			rpt := &resources.ResolvedPipelineTask{PipelineTask: tc.pt.DeepCopy()}
+			err := resources.ApplyRetriesAndTimeout(resources.PipelineRunState{sizing, rpt}, rpt)
+			if tc.expectedErr != "" {
+				if err == nil || err.Error() != tc.expectedErr {
+					t.Fatalf("expected error %q but got: %v", tc.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if d := cmp.Diff(&tc.expectedPt, rpt.PipelineTask); d != "" {
+				t.Errorf("ApplyRetriesAndTimeout() %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 func TestPropagateResults(t *testing.T) {
 	for _, tt := range []struct {
 		name                 string
@@ -5332,6 +5585,54 @@ func TestPropagateArtifacts(t *testing.T) {
 	}
 }
 
+func TestApplyPipelineTaskArtifacts(t *testing.T) {
+	state := resources.PipelineRunState{{
+		PipelineTask: &v1.PipelineTask{Name: "build"},
+		TaskRuns: []*v1.TaskRun{{
+			Status: v1.TaskRunStatus{
+				Status: duckv1.Status{Conditions: duckv1.Conditions{{Type: apis.ConditionSucceeded, Status: corev1.ConditionTrue}}},
+				TaskRunStatusFields: v1.TaskRunStatusFields{
+					Artifacts: &v1.Artifacts{
+						Inputs: []v1.Artifact{{Name: "source", Values: []v1.ArtifactValue{{Digest: map[v1.Algorithm]string{"sha256": "b35cacccfdb1e24dc497d15d553891345fd155713ffe647c281c583269eaaae0"}, Uri: "pkg:example.github.com/inputs"}}}},
+						Outputs: []v1.Artifact{{Name: "image", Values: []v1.ArtifactValue{
+							{Digest: map[v1.Algorithm]string{"sha256": "df85b9e3983fe2ce20ef76ad675ecf435cc99fc9350adc54fa230bae8c32ce48"}, Uri: "pkg:oci/app"},
+							{Digest: map[v1.Algorithm]string{"sha256": "95588b8f34c31eb7d62c92aaa4e6506639b06ef2df85b9e3983fe2ce20ef76ad"}, Uri: "pkg:oci/app-debug"},
+						}}},
+					},
+				},
+			},
+		}},
+	}, {
+		PipelineTask: &v1.PipelineTask{Name: "test"},
+	}}
+	finalTasks := resources.PipelineRunState{{
+		PipelineTask: &v1.PipelineTask{
+			Name: "sign",
+			Params: v1.Params{
+				{Name: "images", Value: *v1.NewStructuredValues("$(tasks.build.outputs.image)")},
+				{Name: "sources", Value: *v1.NewStructuredValues("$(tasks.build.inputs.source)")},
+				{Name: "all", Value: *v1.NewStructuredValues("$(tasks.build.outputs.image)", "$(tasks.test.outputs.report)")},
+				{Name: "not-produced", Value: *v1.NewStructuredValues("$(tasks.build.outputs.sbom)")},
+				{Name: "status", Value: *v1.NewStructuredValues("$(tasks.build.status)")},
+			},
+		},
+	}}
+
+	if err := resources.ApplyPipelineTaskArtifacts(finalTasks, state.GetTaskRunsArtifacts()); err != nil {
+		t.Fatalf("ApplyPipelineTaskArtifacts() = %v", err)
+	}
+	images := `[{"digest":{"sha256":"df85b9e3983fe2ce20ef76ad675ecf435cc99fc9350adc54fa230bae8c32ce48"},"uri":"pkg:oci/app"},{"digest":{"sha256":"95588b8f34c31eb7d62c92aaa4e6506639b06ef2df85b9e3983fe2ce20ef76ad"},"uri":"pkg:oci/app-debug"}]`
+	want := v1.Params{
+		{Name: "images", Value: *v1.NewStructuredValues(images)},
+		{Name: "sources", Value: *v1.NewStructuredValues(`[{"digest":{"sha256":"b35cacccfdb1e24dc497d15d553891345fd155713ffe647c281c583269eaaae0"},"uri":"pkg:example.github.com/inputs"}]`)},
+		{Name: "all", Value: *v1.NewStructuredValues(images, "[]")},
+		{Name: "not-produced", Value: *v1.NewStructuredValues("[]")},
+		{Name: "status", Value: *v1.NewStructuredValues("$(tasks.build.status)")},
+	}
+	if d := cmp.Diff(want, finalTasks[0].PipelineTask.Params); d != "" {
+		t.Errorf("ApplyPipelineTaskArtifacts() params %s", diff.PrintWantGot(d))
+	}
+}
 func TestApplyParametersToWorkspaceBindings(t *testing.T) {
 	testCases := []struct {
 		name       string
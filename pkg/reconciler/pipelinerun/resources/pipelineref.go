@@ -113,7 +113,8 @@ func pipelineResolverFunc(_ context.Context, k8s kubernetes.Interface, tekton cl
 			},
 		}
 		resolver := resolution.NewResolver(requester, pipelineRun, string(pipelineRef.Resolver), resolverPayload)
-		return resolvePipeline(ctx, resolver, name, namespace, k8s, tekton, verificationPolicies)
+		source := trustedresources.Source{Resolver: string(pipelineRef.Resolver), Params: replacedParams}
+		return resolvePipeline(ctx, resolver, name, namespace, k8s, tekton, source, verificationPolicies)
 	}
 }
 
@@ -146,12 +147,14 @@ func (l *LocalPipelineRefResolver) GetPipeline(ctx context.Context, name string)
 // An error is returned if the remoteresource doesn't work
 // A VerificationResult is returned if trusted resources is enabled, VerificationResult contains the result type and err.
 // or the returned data isn't a valid *v1.Pipeline.
-func resolvePipeline(ctx context.Context, resolver remote.Resolver, name string, namespace string, k8s kubernetes.Interface, tekton clientset.Interface, verificationPolicies []*v1alpha1.VerificationPolicy) (*v1.Pipeline, *v1.RefSource, *trustedresources.VerificationResult, error) {
+// The policies are matched against the resolver and params of source, and the RefSource returned by the resolver.
+func resolvePipeline(ctx context.Context, resolver remote.Resolver, name string, namespace string, k8s kubernetes.Interface, tekton clientset.Interface, source trustedresources.Source, verificationPolicies []*v1alpha1.VerificationPolicy) (*v1.Pipeline, *v1.RefSource, *trustedresources.VerificationResult, error) {
 	obj, refSource, err := resolver.Get(ctx, "pipeline", name)
 	if err != nil {
 		return nil, nil, nil, fmt.Errorf("resolver failed to get Pipeline %s: %w", name, err)
 	}
-	pipelineObj, vr, err := readRuntimeObjectAsPipeline(ctx, namespace, obj, k8s, tekton, refSource, verificationPolicies)
+	source.RefSource = refSource
+	pipelineObj, vr, err := readRuntimeObjectAsPipeline(ctx, namespace, obj, k8s, tekton, source, verificationPolicies)
 	if err != nil {
 		return nil, nil, nil, fmt.Errorf("failed to read runtime object as Pipeline: %w", err)
 	}
@@ -167,7 +170,7 @@ func resolvePipeline(ctx context.Context, resolver remote.Resolver, name string,
 // PipelineObject or if there is an error validating or upgrading an
 // older PipelineObject into its v1beta1 equivalent.
 // TODO(#5541): convert v1beta1 obj to v1 once we use v1 as the stored version
-func readRuntimeObjectAsPipeline(ctx context.Context, namespace string, obj runtime.Object, k8s kubernetes.Interface, tekton clientset.Interface, refSource *v1.RefSource, verificationPolicies []*v1alpha1.VerificationPolicy) (*v1.Pipeline, *trustedresources.VerificationResult, error) {
+func readRuntimeObjectAsPipeline(ctx context.Context, namespace string, obj runtime.Object, k8s kubernetes.Interface, tekton clientset.Interface, source trustedresources.Source, verificationPolicies []*v1alpha1.VerificationPolicy) (*v1.Pipeline, *trustedresources.VerificationResult, error) {
 	switch obj := obj.(type) {
 	case *v1beta1.Pipeline:
 		obj.SetDefaults(ctx)
@@ -175,7 +178,7 @@ func readRuntimeObjectAsPipeline(ctx context.Context, namespace string, obj runt
 		// FIXME: extract this in a function
 		obj.ObjectMeta.OwnerReferences = nil
 		// Verify the Pipeline once we fetch from the remote resolution, mutating, validation and conversion of the pipeline should happen after the verification, since signatures are based on the remote pipeline contents
-		vr := trustedresources.VerifyResource(ctx, obj, k8s, refSource, verificationPolicies)
+		vr := trustedresources.VerifyResolvedResource(ctx, obj, k8s, source, verificationPolicies)
 		// Issue a dry-run request to create the remote Pipeline, so that it can undergo validation from validating admission webhooks
 		// and mutation from mutating admission webhooks without actually creating the Pipeline on the cluster
 		o, err := apiserver.DryRunValidate(ctx, namespace, obj, tekton)
@@ -202,7 +205,7 @@ func readRuntimeObjectAsPipeline(ctx context.Context, namespace string, obj runt
 		// This SetDefaults is currently not necessary, but for consistency, it is recommended to add it.
 		// Avoid forgetting to add it in the future when there is a v2 version, causing similar problems.
 		obj.SetDefaults(ctx)
-		vr := trustedresources.VerifyResource(ctx, obj, k8s, refSource, verificationPolicies)
+		vr := trustedresources.VerifyResolvedResource(ctx, obj, k8s, source, verificationPolicies)
 		o, err := apiserver.DryRunValidate(ctx, namespace, obj, tekton)
 		if err != nil {
 			return nil, nil, err
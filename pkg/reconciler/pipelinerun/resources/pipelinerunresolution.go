@@ -18,10 +18,14 @@ package resources
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"slices"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/google/cel-go/cel"
 	"github.com/tektoncd/pipeline/pkg/apis/config"
@@ -35,7 +39,10 @@ import (
 	resolutioncommon "github.com/tektoncd/pipeline/pkg/resolution/common"
 	"github.com/tektoncd/pipeline/pkg/resolution/resource"
 	"github.com/tektoncd/pipeline/pkg/substitution"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"knative.dev/pkg/apis"
 	"knative.dev/pkg/kmeta"
 )
@@ -108,6 +115,9 @@ type ResolvedPipelineTask struct {
 	CustomRunNames []string
 	CustomRuns     []*v1beta1.CustomRun
 
+	// If the PipelineTask is an approval gate, ApprovalGateStatus is set once the gate is reached.
+	ApprovalGateStatus *v1.ApprovalGateStatus
+
 	PipelineTask *v1.PipelineTask
 	ResultsCache map[string][]string
 
@@ -165,6 +175,8 @@ func (t ResolvedPipelineTask) isDone(facts *PipelineRunFacts) bool {
 // IsRunning returns true only if the task is neither succeeded, cancelled nor failed
 func (t ResolvedPipelineTask) IsRunning() bool {
 	switch {
+	case t.IsApprovalGate():
+		return t.ApprovalGateStatus != nil && t.ApprovalGateStatus.IsWaiting()
 	case t.IsCustomTask():
 		if len(t.CustomRuns) == 0 {
 			return false
@@ -191,10 +203,22 @@ func (t ResolvedPipelineTask) IsChildPipeline() bool {
 	return t.PipelineTask.PipelineSpec != nil || t.PipelineTask.PipelineRef != nil
 }
 
+// IsApprovalGate returns true if the PipelineTask is an approval gate.
+func (t ResolvedPipelineTask) IsApprovalGate() bool {
+	return t.PipelineTask != nil && t.PipelineTask.ApprovalGate != nil
+}
+
 // getReason returns the latest reason if the run has completed successfully
 // If the PipelineTask has a Matrix, getReason returns the failure reason for any failure
 // otherwise, it returns an empty string
 func (t ResolvedPipelineTask) getReason() string {
+	if t.IsApprovalGate() {
+		if t.ApprovalGateStatus == nil {
+			return ""
+		}
+		return string(t.ApprovalGateStatus.Reason)
+	}
+
 	if t.IsChildPipeline() {
 		if len(t.ChildPipelineRuns) == 0 {
 			return ""
@@ -241,6 +265,10 @@ func (t ResolvedPipelineTask) getReason() string {
 // isSuccessful returns true only if the run has completed successfully
 // If the PipelineTask has a Matrix, isSuccessful returns true if all runs have completed successfully
 func (t ResolvedPipelineTask) isSuccessful() bool {
+	if t.IsApprovalGate() {
+		return t.ApprovalGateStatus != nil && t.ApprovalGateStatus.Reason == v1.ApprovalGateReasonApproved
+	}
+
 	if t.IsChildPipeline() {
 		if len(t.ChildPipelineRuns) == 0 {
 			return false
@@ -267,7 +295,7 @@ func (t ResolvedPipelineTask) isSuccessful() bool {
 		return true
 	}
 
-	if len(t.TaskRuns) == 0 {
+	if len(t.TaskRuns) == 0 || t.hasUncreatedMatrixInstances() {
 		return false
 	}
 	for _, taskRun := range t.TaskRuns {
@@ -279,9 +307,15 @@ func (t ResolvedPipelineTask) isSuccessful() bool {
 }
 
 // isFailure returns true only if the run has failed (if it has ConditionSucceeded = False).
-// If the PipelineTask has a Matrix, isFailure returns true if any run has failed and all other runs are done.
+// If the PipelineTask has a Matrix, isFailure returns true if any run has failed and all other runs are done,
+// and, if the Matrix doesn't fail fast, all other runs were created.
 func (t ResolvedPipelineTask) isFailure() bool {
 	var isDone bool
+	if t.IsApprovalGate() {
+		return t.ApprovalGateStatus != nil &&
+			(t.ApprovalGateStatus.Reason == v1.ApprovalGateReasonRejected || t.ApprovalGateStatus.Reason == v1.ApprovalGateReasonTimedOut)
+	}
+
 	if t.IsChildPipeline() {
 		if len(t.ChildPipelineRuns) == 0 {
 			return false
@@ -307,13 +341,19 @@ func (t ResolvedPipelineTask) isFailure() bool {
 	if len(t.TaskRuns) == 0 {
 		return false
 	}
-	isDone = true
+	isDone = !t.hasUncreatedMatrixInstances()
 	for _, taskRun := range t.TaskRuns {
 		isDone = isDone && taskRun.IsDone()
 	}
 	return t.haveAnyTaskRunsFailed() && isDone
 }
 
+// hasUncreatedMatrixInstances returns true when the PipelineTask has a Matrix which doesn't fail fast, and
+// the TaskRuns of some of its instances were created while the ones of the others weren't yet.
+func (t ResolvedPipelineTask) hasUncreatedMatrixInstances() bool {
+	return !t.PipelineTask.Matrix.IsFailFast() && len(t.TaskRuns) > 0 && len(t.TaskRuns) < len(t.TaskRunNames)
+}
+
 // isValidationFailed return true if the task is failed at the validation step
 func (t ResolvedPipelineTask) isValidationFailed(ftasks []*ResolvedPipelineTask) bool {
 	for _, ftask := range ftasks {
@@ -362,6 +402,9 @@ func (t ResolvedPipelineTask) isCancelledForTimeOut() bool {
 // isCancelled returns true only if the run is cancelled
 // If the PipelineTask has a Matrix, isCancelled returns true if any run is cancelled and all other runs are done.
 func (t ResolvedPipelineTask) isCancelled() bool {
+	if t.IsApprovalGate() {
+		return t.ApprovalGateStatus != nil && t.ApprovalGateStatus.Reason == v1.ApprovalGateReasonCancelled
+	}
 	if t.IsCustomTask() {
 		if len(t.CustomRuns) == 0 {
 			return false
@@ -391,8 +434,11 @@ func (t ResolvedPipelineTask) isCancelled() bool {
 }
 
 // isScheduled returns true when the PipelineRunTask itself has any TaskRuns/CustomRuns
-// or a singular TaskRun/CustomRun associated.
+// or a singular TaskRun/CustomRun associated, or when it's an approval gate which was reached.
 func (t ResolvedPipelineTask) isScheduled() bool {
+	if t.IsApprovalGate() {
+		return t.ApprovalGateStatus != nil
+	}
 	if t.IsCustomTask() {
 		return len(t.CustomRuns) > 0
 	}
@@ -401,6 +447,10 @@ func (t ResolvedPipelineTask) isScheduled() bool {
 
 // haveAnyRunsFailed returns true when any of the child PipelineRuns/TaskRuns/CustomRuns have succeeded condition with status set to false
 func (t ResolvedPipelineTask) haveAnyRunsFailed() bool {
+	if t.IsApprovalGate() {
+		return t.isFailure()
+	}
+
 	if t.IsChildPipeline() {
 		return t.haveAnyChildPipelineRunsFailed()
 	}
@@ -462,6 +512,8 @@ func (t *ResolvedPipelineTask) skip(facts *PipelineRunFacts) TaskSkipStatus {
 	switch {
 	case facts.isFinalTask(t.PipelineTask.Name) || t.isScheduled() || t.isValidationFailed(facts.ValidationFailedTask):
 		skippingReason = v1.None
+	case t.skipBecauseApprovalGateFailed(facts) != v1.None:
+		skippingReason = t.skipBecauseApprovalGateFailed(facts)
 	case facts.IsStopping():
 		skippingReason = v1.StoppingSkip
 	case facts.IsGracefullyCancelled():
@@ -483,6 +535,7 @@ func (t *ResolvedPipelineTask) skip(facts *PipelineRunFacts) TaskSkipStatus {
 	default:
 		skippingReason = v1.None
 	}
+	t.logSkip(facts, skippingReason)
 
 	return TaskSkipStatus{
 		IsSkipped:      skippingReason != v1.None,
@@ -496,6 +549,7 @@ func (t *ResolvedPipelineTask) skip(facts *PipelineRunFacts) TaskSkipStatus {
 // (3) its parent task was skipped
 // (4) Pipeline is in stopping state (one of the PipelineTasks failed)
 // (5) Pipeline is gracefully cancelled or stopped
+// (6) an approval gate it runs after was rejected or timed out
 func (t *ResolvedPipelineTask) Skip(facts *PipelineRunFacts) TaskSkipStatus {
 	if facts.SkipCache == nil {
 		facts.SkipCache = make(map[string]TaskSkipStatus)
@@ -506,6 +560,82 @@ func (t *ResolvedPipelineTask) Skip(facts *PipelineRunFacts) TaskSkipStatus {
 	return facts.SkipCache[t.PipelineTask.Name]
 }
 
+// whenExpressionDecision is how a when expression of a skipped PipelineTask was evaluated, as logged
+type whenExpressionDecision struct {
+	Input    string   `json:"input,omitempty"`
+	Operator string   `json:"operator,omitempty"`
+	Values   []string `json:"values,omitempty"`
+	CEL      string   `json:"cel,omitempty"`
+	Allowed  bool     `json:"allowed"`
+}
+
+// logSkip logs the decision to skip the task for the skippingReason, keyed by pipelineTask. The when expressions
+// the decision was made from, or the result references which are missing, are only computed and logged at the
+// debug level, as evaluating the result references again is expensive.
+func (t *ResolvedPipelineTask) logSkip(facts *PipelineRunFacts, skippingReason v1.SkippingReason) {
+	if facts.Logger == nil || skippingReason == v1.None || !facts.Logger.Desugar().Core().Enabled(zapcore.DebugLevel) {
+		return
+	}
+	fields := []any{zap.String("pipelineTask", t.PipelineTask.Name), zap.String("skippingReason", string(skippingReason))}
+	switch skippingReason {
+	case v1.WhenExpressionsSkip:
+		decisions := make([]whenExpressionDecision, 0, len(t.PipelineTask.When))
+		for _, we := range t.PipelineTask.When {
+			decisions = append(decisions, whenExpressionDecision{
+				Input:    we.Input,
+				Operator: string(we.Operator),
+				Values:   we.Values,
+				CEL:      we.CEL,
+				Allowed:  v1.WhenExpressions{we}.AllowsExecution(t.EvaluatedCEL),
+			})
+		}
+		fields = append(fields, zap.Any("whenExpressions", decisions))
+	case v1.MissingResultsSkip:
+		var refs []string
+		for _, ref := range v1.PipelineTaskResultRefs(t.PipelineTask) {
+			refs = append(refs, fmt.Sprintf("tasks.%s.results.%s", ref.PipelineTask, ref.Result))
+		}
+		fields = append(fields, zap.Strings("resultReferences", refs))
+		if _, pt, err := ResolveResultRefs(facts.State, PipelineRunState{t}); err != nil {
+			fields = append(fields, zap.String("missingResultsFrom", pt), zap.String("missingResultReferences", err.Error()))
+		}
+	}
+	facts.Logger.Debugw("Skipping PipelineTask", fields...)
+}
+
+// skipBecauseApprovalGateFailed returns the reason to skip the task with if one of the approval gates it
+// runs after, directly or not, was rejected or timed out, and None otherwise
+func (t *ResolvedPipelineTask) skipBecauseApprovalGateFailed(facts *PipelineRunFacts) v1.SkippingReason {
+	stateMap := facts.State.ToMap()
+	visited := sets.NewString()
+	queue := []string{t.PipelineTask.Name}
+	for len(queue) > 0 {
+		node := facts.TasksGraph.Nodes[queue[0]]
+		queue = queue[1:]
+		if node == nil {
+			continue
+		}
+		for _, p := range node.Prev {
+			if visited.Has(p.Key) {
+				continue
+			}
+			visited.Insert(p.Key)
+			queue = append(queue, p.Key)
+			parent := stateMap[p.Key]
+			if parent == nil || !parent.IsApprovalGate() || parent.ApprovalGateStatus == nil {
+				continue
+			}
+			switch parent.ApprovalGateStatus.Reason {
+			case v1.ApprovalGateReasonRejected:
+				return v1.ApprovalRejectedSkip
+			case v1.ApprovalGateReasonTimedOut:
+				return v1.ApprovalTimedOutSkip
+			}
+		}
+	}
+	return v1.None
+}
+
 // skipBecauseWhenExpressionsEvaluatedToFalse confirms that the when expressions have completed evaluating, and
 // it returns true if any of the when expressions evaluate to false
 func (t *ResolvedPipelineTask) skipBecauseWhenExpressionsEvaluatedToFalse(facts *PipelineRunFacts) bool {
@@ -598,6 +728,60 @@ func (t *ResolvedPipelineTask) skipBecausePipelineRunFinallyTimeoutReached(facts
 	return false
 }
 
+// TimeoutRemaining returns the time left, rounded up to the second, before the PipelineRun timeouts that apply to the task
+// are reached: the pipeline timeout, and either the tasks timeout or, for final tasks, the finally timeout.
+// It returns nil if none of these timeouts is set.
+func (t *ResolvedPipelineTask) TimeoutRemaining(facts *PipelineRunFacts) *time.Duration {
+	ts := facts.TimeoutsState
+	if ts.Clock == nil {
+		return nil
+	}
+	var remaining *time.Duration
+	consider := func(timeout *time.Duration, startTime *time.Time) {
+		if timeout == nil || *timeout == config.NoTimeoutDuration || startTime == nil {
+			return
+		}
+		left := *timeout - ts.Clock.Since(*startTime)
+		if remaining == nil || left < *remaining {
+			remaining = &left
+		}
+	}
+	consider(ts.PipelineTimeout, ts.StartTime)
+	if facts.FinalTasksGraph != nil && t.IsFinalTask(facts) {
+		consider(ts.FinallyTimeout, ts.FinallyStartTime)
+	} else {
+		consider(ts.TasksTimeout, ts.StartTime)
+	}
+	if remaining == nil {
+		return nil
+	}
+	return roundUpRemaining(*remaining)
+}
+
+// FinallyTimeoutRemaining returns the time left, rounded up to the second, before the PipelineRun finally timeout
+// is reached, or before the pipeline timeout if it is reached first, since the final tasks are canceled then.
+// It returns nil if the task is not a final task, or if the finally timeout is not set.
+func (t *ResolvedPipelineTask) FinallyTimeoutRemaining(facts *PipelineRunFacts) *time.Duration {
+	ts := facts.TimeoutsState
+	if ts.Clock == nil || facts.FinalTasksGraph == nil || !t.IsFinalTask(facts) {
+		return nil
+	}
+	if ts.FinallyTimeout == nil || *ts.FinallyTimeout == config.NoTimeoutDuration || ts.FinallyStartTime == nil {
+		return nil
+	}
+	remaining := *ts.FinallyTimeout - ts.Clock.Since(*ts.FinallyStartTime)
+	if ts.PipelineTimeout != nil && *ts.PipelineTimeout != config.NoTimeoutDuration && ts.StartTime != nil {
+		remaining = min(remaining, *ts.PipelineTimeout-ts.Clock.Since(*ts.StartTime))
+	}
+	return roundUpRemaining(remaining)
+}
+
+// roundUpRemaining rounds the given remaining time up to the second, and to zero if it is negative
+func roundUpRemaining(remaining time.Duration) *time.Duration {
+	rounded := max((remaining + time.Second - 1).Truncate(time.Second), 0)
+	return &rounded
+}
+
 // skipBecauseEmptyArrayInMatrixParams returns true if the matrix parameters contain an empty array
 func (t *ResolvedPipelineTask) skipBecauseEmptyArrayInMatrixParams() bool {
 	if t.PipelineTask.IsMatrixed() {
@@ -641,6 +825,7 @@ func (t *ResolvedPipelineTask) IsFinallySkipped(facts *PipelineRunFacts) TaskSki
 	default:
 		skippingReason = v1.None
 	}
+	t.logSkip(facts, skippingReason)
 
 	return TaskSkipStatus{
 		IsSkipped:      skippingReason != v1.None,
@@ -651,7 +836,8 @@ func (t *ResolvedPipelineTask) IsFinallySkipped(facts *PipelineRunFacts) TaskSki
 // GetRun is a function that will retrieve a CustomRun by name.
 type GetRun func(name string) (*v1beta1.CustomRun, error)
 
-// ValidateWorkspaceBindings validates that the Workspaces expected by a Pipeline are provided by a PipelineRun.
+// ValidateWorkspaceBindings validates that the Workspaces expected by a Pipeline are provided by a PipelineRun,
+// and that the Workspaces bound per matrix instance are provided as volumeClaimTemplates.
 func ValidateWorkspaceBindings(p *v1.PipelineSpec, pr *v1.PipelineRun) error {
 	pipelineRunWorkspaces := make(map[string]v1.WorkspaceBinding)
 	for _, binding := range pr.Spec.Workspaces {
@@ -666,6 +852,21 @@ func ValidateWorkspaceBindings(p *v1.PipelineSpec, pr *v1.PipelineRun) error {
 			return pipelineErrors.WrapUserError(fmt.Errorf("pipeline requires workspace with name %q be provided by pipelinerun", ws.Name))
 		}
 	}
+
+	for _, pt := range append(slices.Clone(p.Tasks), p.Finally...) {
+		for _, ws := range pt.Workspaces {
+			if !ws.PerMatrixInstance {
+				continue
+			}
+			pipelineWorkspace := ws.Workspace
+			if pipelineWorkspace == "" {
+				pipelineWorkspace = ws.Name
+			}
+			if b, ok := pipelineRunWorkspaces[pipelineWorkspace]; ok && b.VolumeClaimTemplate == nil {
+				return pipelineErrors.WrapUserError(fmt.Errorf("workspace %q is bound per matrix instance by pipeline task %q and must be provided by pipelinerun as a volumeClaimTemplate", pipelineWorkspace, pt.Name))
+			}
+		}
+	}
 	return nil
 }
 
@@ -681,6 +882,16 @@ func ValidateTaskRunSpecs(p *v1.PipelineSpec, pr *v1.PipelineRun) error {
 	}
 
 	for _, taskrunSpec := range pr.Spec.TaskRunSpecs {
+		if taskrunSpec.IsPattern() {
+			matched := false
+			for name := range pipelineTasks {
+				matched = matched || taskrunSpec.Matches(name)
+			}
+			if !matched {
+				return pipelineErrors.WrapUserError(fmt.Errorf("pipelineRun's taskrunSpecs defined pattern %q, which matches no task in Pipeline", taskrunSpec.PipelineTaskName))
+			}
+			continue
+		}
 		if _, ok := pipelineTasks[taskrunSpec.PipelineTaskName]; !ok {
 			return pipelineErrors.WrapUserError(fmt.Errorf("pipelineRun's taskrunSpecs defined wrong taskName: %q, does not exist in Pipeline", taskrunSpec.PipelineTaskName))
 		}
@@ -701,6 +912,8 @@ func ValidateTaskRunSpecs(p *v1.PipelineSpec, pr *v1.PipelineRun) error {
 // If the Pipeline Task is a Pipeline, it retrieves any child PipelineRuns, plus the Pipeline spec and updates the
 // ResolvedPipelineTask with this information. It also sets the ResolvedPipelineTask's ChildPipelineRunName(s) with the names
 // of child PipelineRuns that should be or already have been created.
+//
+// If the Pipeline Task is an approval gate, it retrieves the status of the gate from the PipelineRun status, if it was reached.
 func ResolvePipelineTask(
 	ctx context.Context,
 	pipelineRun v1.PipelineRun,
@@ -732,6 +945,13 @@ func ResolvePipelineTask(
 	}
 
 	switch {
+	case rpt.IsApprovalGate():
+		for i := range pipelineRun.Status.ApprovalGates {
+			if pipelineRun.Status.ApprovalGates[i].PipelineTaskName == pipelineTask.Name {
+				rpt.ApprovalGateStatus = pipelineRun.Status.ApprovalGates[i].DeepCopy()
+			}
+		}
+
 	case rpt.IsChildPipeline():
 		rpt.ChildPipelineRunNames = GetNamesOfChildPipelineRuns(
 			pipelineRun.Status.ChildReferences,
@@ -739,6 +959,7 @@ func ResolvePipelineTask(
 			pipelineRun.Name,
 			numCombinations,
 		)
+		rpt.ChildPipelineRunNames = disambiguateRunNames(pipelineRun, pipelineTask.Name, rpt.ChildPipelineRunNames, pst)
 
 		for _, childPipelineRunName := range rpt.ChildPipelineRunNames {
 			if err := rpt.setChildPipelineRunsAndResolvedPipeline(ctx, childPipelineRunName, getChildPipelineRun, getChildPipeline, pipelineTask); err != nil {
@@ -748,6 +969,7 @@ func ResolvePipelineTask(
 
 	case rpt.IsCustomTask():
 		rpt.CustomRunNames = getNamesOfCustomRuns(pipelineRun.Status.ChildReferences, pipelineTask.Name, pipelineRun.Name, numCombinations)
+		rpt.CustomRunNames = disambiguateRunNames(pipelineRun, pipelineTask.Name, rpt.CustomRunNames, pst)
 		for _, runName := range rpt.CustomRunNames {
 			run, err := getRun(runName)
 			if err != nil && !kerrors.IsNotFound(err) {
@@ -760,6 +982,10 @@ func ResolvePipelineTask(
 
 	default:
 		rpt.TaskRunNames = GetNamesOfTaskRuns(pipelineRun.Status.ChildReferences, pipelineTask.Name, pipelineRun.Name, numCombinations)
+		if !pipelineTask.Matrix.IsFailFast() {
+			rpt.TaskRunNames = completeRunNames(rpt.TaskRunNames, pipelineTask.Name, pipelineRun.Name, numCombinations)
+		}
+		rpt.TaskRunNames = disambiguateRunNames(pipelineRun, pipelineTask.Name, rpt.TaskRunNames, pst)
 		for _, taskRunName := range rpt.TaskRunNames {
 			if err := rpt.setTaskRunsAndResolvedTask(ctx, taskRunName, getTask, getTaskRun, pipelineTask); err != nil {
 				return nil, err
@@ -934,6 +1160,15 @@ func getTaskRunNamesFromChildRefs(childRefs []v1.ChildStatusReference, ptName st
 	return taskRunNames
 }
 
+// completeRunNames appends the names of the runs of the instances of a Matrix which weren't created yet to
+// the names of the runs which were, which are the ones of its first instances as they are created in order.
+func completeRunNames(runNames []string, ptName, prName string, numberOfRuns int) []string {
+	if len(runNames) >= numberOfRuns {
+		return runNames
+	}
+	return append(runNames, getNewRunNames(ptName, prName, numberOfRuns)[len(runNames):]...)
+}
+
 func getNewRunNames(ptName, prName string, numberOfRuns int) []string {
 	var runNames []string
 	// If it is a singular PipelineRun/TaskRun/CustomRun, we only append the ptName
@@ -960,6 +1195,50 @@ func getNewRunNames(ptName, prName string, numberOfRuns int) []string {
 	return runNames
 }
 
+// disambiguateRunNames returns the names of the new child runs of the PipelineTask ptName, suffixed with a
+// short hash when they collide with the names of the child runs of the other PipelineTasks, which were either
+// created, as recorded in the child references of the PipelineRun, or resolved before, in pst. e.g. the first
+// TaskRun of a matrixed PipelineTask "build" and the TaskRun of a PipelineTask "build-0" are both named
+// "<pipelinerun>-build-0". The names are stored in the child references once the runs are created, so the
+// names of the runs of a PipelineTask which has child references are kept as they are.
+func disambiguateRunNames(pipelineRun v1.PipelineRun, ptName string, names []string, pst PipelineRunState) []string {
+	taken := sets.New[string]()
+	for _, cr := range pipelineRun.Status.ChildReferences {
+		if cr.PipelineTaskName == ptName {
+			return names
+		}
+		taken.Insert(cr.Name)
+	}
+	for _, rpt := range pst {
+		if rpt.PipelineTask.Name == ptName {
+			continue
+		}
+		taken.Insert(rpt.TaskRunNames...)
+		taken.Insert(rpt.CustomRunNames...)
+		taken.Insert(rpt.ChildPipelineRunNames...)
+	}
+	disambiguated := make([]string, 0, len(names))
+	for i, name := range names {
+		for attempt := 0; taken.Has(name); attempt++ {
+			name = hashSuffixedRunName(names[i], fmt.Sprintf("%s/%d/%d", ptName, i, attempt))
+		}
+		taken.Insert(name)
+		disambiguated = append(disambiguated, name)
+	}
+	return disambiguated
+}
+
+// hashSuffixedRunName returns name, truncated if needed to fit the 63 characters limit of the names of
+// the child runs, suffixed with a short hash of key.
+func hashSuffixedRunName(name, key string) string {
+	h := sha256.Sum256([]byte(key))
+	suffix := "-" + hex.EncodeToString(h[:])[:5]
+	if longest := 63 - len(suffix); len(name) > longest {
+		name = name[:longest]
+	}
+	return strings.TrimRight(name, "-") + suffix
+}
+
 // getCustomRunName should return a unique name for a `Run` if one has not already
 // been defined, and the existing one otherwise.
 func getCustomRunName(childRefs []v1.ChildStatusReference, ptName, prName string) string {
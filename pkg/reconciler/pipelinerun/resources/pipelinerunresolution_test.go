@@ -21,6 +21,7 @@ import (
 	"errors"
 	"fmt"
 	"sort"
+	"strings"
 	"testing"
 	"time"
 
@@ -37,14 +38,20 @@ import (
 	"github.com/tektoncd/pipeline/pkg/trustedresources"
 	"github.com/tektoncd/pipeline/test/diff"
 	"github.com/tektoncd/pipeline/test/names"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/ptr"
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/kmeta"
 	logtesting "knative.dev/pkg/logging/testing"
 )
 
@@ -493,6 +500,12 @@ func withPipelineTaskRetries(pt v1.PipelineTask, retries int) *v1.PipelineTask {
 	return &pt
 }
 
+func withMatrixNotFailingFast(pt v1.PipelineTask) *v1.PipelineTask {
+	pt.Matrix = pt.Matrix.DeepCopy()
+	pt.Matrix.FailFast = ptr.To(false)
+	return &pt
+}
+
 func newCustomRun(run v1beta1.CustomRun) *v1beta1.CustomRun {
 	return &v1beta1.CustomRun{
 		ObjectMeta: metav1.ObjectMeta{
@@ -1898,6 +1911,30 @@ func TestIsFailure(t *testing.T) {
 			CustomRuns:   []*v1beta1.CustomRun{withCustomRunCancelled(withCustomRunRetries(makeCustomRunFailed(customRuns[0]))), makeCustomRunStarted(customRuns[1])},
 		},
 		want: false,
+	}, {
+		name: "one matrixed taskrun failed, the other one not created",
+		rpt: ResolvedPipelineTask{
+			PipelineTask: matrixedPipelineTask,
+			TaskRunNames: []string{trs[0].Name, trs[1].Name},
+			TaskRuns:     []*v1.TaskRun{makeFailed(trs[0])},
+		},
+		want: true,
+	}, {
+		name: "one matrixed taskrun failed, the other one not created, matrix not failing fast",
+		rpt: ResolvedPipelineTask{
+			PipelineTask: withMatrixNotFailingFast(*matrixedPipelineTask),
+			TaskRunNames: []string{trs[0].Name, trs[1].Name},
+			TaskRuns:     []*v1.TaskRun{makeFailed(trs[0])},
+		},
+		want: false,
+	}, {
+		name: "one matrixed taskrun failed, the other one succeeded, matrix not failing fast",
+		rpt: ResolvedPipelineTask{
+			PipelineTask: withMatrixNotFailingFast(*matrixedPipelineTask),
+			TaskRunNames: []string{trs[0].Name, trs[1].Name},
+			TaskRuns:     []*v1.TaskRun{makeFailed(trs[0]), makeSucceeded(trs[1])},
+		},
+		want: true,
 	}} {
 		t.Run(tc.name, func(t *testing.T) {
 			if got := tc.rpt.isFailure(); got != tc.want {
@@ -2643,6 +2680,50 @@ func TestResolvePipelineRun_CustomTask(t *testing.T) {
 	}
 }
 
+func TestResolvePipelineRun_ApprovalGate(t *testing.T) {
+	pts := []v1.PipelineTask{{
+		Name:         "approve",
+		ApprovalGate: &v1.ApprovalGate{Approvers: []string{"alice"}},
+	}, {
+		Name:         "approve-later",
+		ApprovalGate: &v1.ApprovalGate{Approvers: []string{"bob"}},
+	}}
+	gate := v1.ApprovalGateStatus{
+		PipelineTaskName: "approve",
+		Approvers:        []string{"alice"},
+		Reason:           v1.ApprovalGateReasonWaiting,
+		StartTime:        metav1.NewTime(now),
+	}
+	pr := v1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "pipelinerun"},
+		Status: v1.PipelineRunStatus{PipelineRunStatusFields: v1.PipelineRunStatusFields{
+			ApprovalGates: []v1.ApprovalGateStatus{gate},
+		}},
+	}
+	pipelineState := PipelineRunState{}
+	for _, task := range pts {
+		// The gates don't run anything, so there is nothing to get
+		ps, err := ResolvePipelineTask(t.Context(), pr, nopGetPipelineRun, nopGetPipeline, nopGetTask, nopGetTaskRun, nopGetCustomRun, task, nil)
+		if err != nil {
+			t.Fatalf("ResolvePipelineTask: %v", err)
+		}
+		pipelineState = append(pipelineState, ps)
+	}
+
+	expectedState := PipelineRunState{{
+		PipelineTask:       &pts[0],
+		ApprovalGateStatus: &gate,
+	}, {
+		PipelineTask: &pts[1],
+	}}
+	if d := cmp.Diff(expectedState, pipelineState); d != "" {
+		t.Errorf("Unexpected pipeline state: %s", diff.PrintWantGot(d))
+	}
+	if !pipelineState[0].IsApprovalGate() || !pipelineState[0].IsRunning() || pipelineState[1].IsRunning() {
+		t.Errorf("Expected the reached gate only to be running")
+	}
+}
+
 func TestResolvePipelineRun_ChildPipelineWithPipelineSpec(t *testing.T) {
 	cfg := config.NewStore(logtesting.TestLogger(t))
 	ctx := cfg.ToContext(t.Context())
@@ -2935,6 +3016,25 @@ func TestValidateWorkspaceBindingsWithValidWorkspaces(t *testing.T) {
 				Workspaces: []v1.WorkspaceBinding{},
 			},
 		},
+	}, {
+		name: "workspace bound per matrix instance from a volumeClaimTemplate",
+		spec: &v1.PipelineSpec{
+			Workspaces: []v1.PipelineWorkspaceDeclaration{{
+				Name: "foo",
+			}},
+			Tasks: []v1.PipelineTask{{
+				Name:       "build",
+				Workspaces: []v1.WorkspacePipelineTaskBinding{{Name: "cache", Workspace: "foo", PerMatrixInstance: true}},
+			}},
+		},
+		pr: &v1.PipelineRun{
+			Spec: v1.PipelineRunSpec{
+				Workspaces: []v1.WorkspaceBinding{{
+					Name:                "foo",
+					VolumeClaimTemplate: &corev1.PersistentVolumeClaim{},
+				}},
+			},
+		},
 	}} {
 		t.Run(tc.name, func(t *testing.T) {
 			if err := ValidateWorkspaceBindings(tc.spec, tc.pr); err != nil {
@@ -2962,10 +3062,35 @@ func TestValidateWorkspaceBindingsWithInvalidWorkspaces(t *testing.T) {
 				Workspaces: []v1.WorkspaceBinding{},
 			},
 		},
+		err: `pipeline requires workspace with name "foo" be provided by pipelinerun`,
+	}, {
+		name: "workspace bound per matrix instance from a persistentVolumeClaim",
+		spec: &v1.PipelineSpec{
+			Workspaces: []v1.PipelineWorkspaceDeclaration{{
+				Name: "foo",
+			}},
+			Finally: []v1.PipelineTask{{
+				Name:       "report",
+				Workspaces: []v1.WorkspacePipelineTaskBinding{{Name: "foo", PerMatrixInstance: true}},
+			}},
+		},
+		pr: &v1.PipelineRun{
+			Spec: v1.PipelineRunSpec{
+				Workspaces: []v1.WorkspaceBinding{{
+					Name:                  "foo",
+					PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "cache"},
+				}},
+			},
+		},
+		err: `workspace "foo" is bound per matrix instance by pipeline task "report" and must be provided by pipelinerun as a volumeClaimTemplate`,
 	}} {
 		t.Run(tc.name, func(t *testing.T) {
-			if err := ValidateWorkspaceBindings(tc.spec, tc.pr); err == nil {
-				t.Fatalf("Expected error indicating `foo` workspace was not provided but got no error")
+			err := ValidateWorkspaceBindings(tc.spec, tc.pr)
+			if err == nil {
+				t.Fatalf("Expected error %q but got no error", tc.err)
+			}
+			if err.Error() != tc.err {
+				t.Errorf("Expected error %q but got %q", tc.err, err)
 			}
 		})
 	}
@@ -3079,6 +3204,66 @@ func TestValidateTaskRunSpecs(t *testing.T) {
 			},
 		},
 		wantErr: true,
+	}, {
+		name: "pattern matching a task",
+		p: &v1.Pipeline{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "pipelines",
+			},
+			Spec: v1.PipelineSpec{
+				Tasks: []v1.PipelineTask{{
+					Name: "deploy-us",
+					TaskRef: &v1.TaskRef{
+						Name: "task",
+					},
+				}},
+			},
+		},
+		pr: &v1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "pipelinerun",
+			},
+			Spec: v1.PipelineRunSpec{
+				PipelineRef: &v1.PipelineRef{
+					Name: "pipeline",
+				},
+				TaskRunSpecs: []v1.PipelineTaskRunSpec{{
+					PipelineTaskName:   "deploy-*",
+					ServiceAccountName: "deployer",
+				}},
+			},
+		},
+		wantErr: false,
+	}, {
+		name: "pattern matching no task",
+		p: &v1.Pipeline{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "pipelines",
+			},
+			Spec: v1.PipelineSpec{
+				Tasks: []v1.PipelineTask{{
+					Name: "build",
+					TaskRef: &v1.TaskRef{
+						Name: "task",
+					},
+				}},
+			},
+		},
+		pr: &v1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "pipelinerun",
+			},
+			Spec: v1.PipelineRunSpec{
+				PipelineRef: &v1.PipelineRef{
+					Name: "pipeline",
+				},
+				TaskRunSpecs: []v1.PipelineTaskRunSpec{{
+					PipelineTaskName:   "deploy-*",
+					ServiceAccountName: "deployer",
+				}},
+			},
+		},
+		wantErr: true,
 	}} {
 		t.Run(tc.name, func(t *testing.T) {
 			spec := tc.p.Spec
@@ -3790,6 +3975,94 @@ func TestGetTaskRunName(t *testing.T) {
 	}
 }
 
+func TestResolvePipelineTask_CollidingRunNames(t *testing.T) {
+	longName := strings.Repeat("generated-", 6) + "task"
+	matrix := &v1.Matrix{Params: v1.Params{{Name: "platform", Value: *v1.NewStructuredValues("linux", "mac")}}}
+	for _, tc := range []struct {
+		name      string
+		pts       []v1.PipelineTask
+		childRefs []v1.ChildStatusReference
+		want      map[string][]string
+	}{{
+		name: "long names sharing a prefix",
+		pts: []v1.PipelineTask{{
+			Name:    longName + "-build",
+			TaskRef: &v1.TaskRef{Name: "task"},
+		}, {
+			Name:    longName + "-tests",
+			TaskRef: &v1.TaskRef{Name: "task"},
+		}},
+		want: map[string][]string{
+			longName + "-build": {kmeta.ChildName("pipelinerun", "-"+longName+"-build")},
+			longName + "-tests": {kmeta.ChildName("pipelinerun", "-"+longName+"-tests")},
+		},
+	}, {
+		name: "matrixed PipelineTask and PipelineTask named after its first TaskRun",
+		pts: []v1.PipelineTask{{
+			Name:    "build",
+			TaskRef: &v1.TaskRef{Name: "task"},
+			Matrix:  matrix,
+		}, {
+			Name:    "build-0",
+			TaskRef: &v1.TaskRef{Name: "task"},
+		}},
+		want: map[string][]string{
+			"build":   {"pipelinerun-build-0", "pipelinerun-build-1"},
+			"build-0": {hashSuffixedRunName("pipelinerun-build-0", "build-0/0/0")},
+		},
+	}, {
+		name: "names of the created TaskRuns are kept",
+		pts: []v1.PipelineTask{{
+			Name:    "build-0",
+			TaskRef: &v1.TaskRef{Name: "task"},
+		}, {
+			Name:    "build",
+			TaskRef: &v1.TaskRef{Name: "task"},
+			Matrix:  matrix,
+		}},
+		childRefs: []v1.ChildStatusReference{{
+			TypeMeta:         runtime.TypeMeta{Kind: "TaskRun"},
+			Name:             "pipelinerun-build-0",
+			PipelineTaskName: "build-0",
+		}},
+		want: map[string][]string{
+			"build-0": {"pipelinerun-build-0"},
+			"build":   {hashSuffixedRunName("pipelinerun-build-0", "build/0/0"), "pipelinerun-build-1"},
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			pr := v1.PipelineRun{
+				ObjectMeta: metav1.ObjectMeta{Name: "pipelinerun"},
+				Status: v1.PipelineRunStatus{
+					PipelineRunStatusFields: v1.PipelineRunStatusFields{ChildReferences: tc.childRefs},
+				},
+			}
+			pst := PipelineRunState{}
+			got := map[string][]string{}
+			for _, pt := range tc.pts {
+				rpt, err := ResolvePipelineTask(t.Context(), pr, nopGetPipelineRun, nopGetPipeline, getTaskFn(nil, nil), getTaskRunFn(nil), nopGetCustomRun, pt, pst)
+				if err != nil {
+					t.Fatalf("ResolvePipelineTask: %v", err)
+				}
+				pst = append(pst, rpt)
+				got[pt.Name] = rpt.TaskRunNames
+			}
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("TaskRun names %s", diff.PrintWantGot(d))
+			}
+			seen := sets.New[string]()
+			for _, names := range got {
+				for _, name := range names {
+					if seen.Has(name) || len(name) > 63 {
+						t.Errorf("TaskRun name %q is shared or longer than 63 characters", name)
+					}
+					seen.Insert(name)
+				}
+			}
+		})
+	}
+}
+
 func TestGetNamesOfTaskRuns(t *testing.T) {
 	prName := "mypipelinerun"
 	childRefs := []v1.ChildStatusReference{{
@@ -6326,3 +6599,347 @@ func TestSetChildPipelineRunsAndResolvedPipeline(t *testing.T) {
 		})
 	}
 }
+
+func TestResolvedPipelineTask_TimeoutRemaining(t *testing.T) {
+	dagTask := &ResolvedPipelineTask{PipelineTask: &v1.PipelineTask{Name: "dag-task", TaskRef: &v1.TaskRef{Name: "task"}}}
+	finalTask := &ResolvedPipelineTask{PipelineTask: &v1.PipelineTask{Name: "final-task", TaskRef: &v1.TaskRef{Name: "task"}}}
+	d, err := dag.Build(v1.PipelineTaskList{*dagTask.PipelineTask}, map[string][]string{})
+	if err != nil {
+		t.Fatalf("Could not get a dag from the dag tasks: %v", err)
+	}
+	dfinally, err := dag.Build(v1.PipelineTaskList{*finalTask.PipelineTask}, map[string][]string{})
+	if err != nil {
+		t.Fatalf("Could not get a dag from the finally tasks: %v", err)
+	}
+	duration := func(d time.Duration) *time.Duration { return &d }
+	startTime := now.Add(-55 * time.Minute)
+	finallyStartTime := now.Add(-8 * time.Minute)
+
+	for _, tc := range []struct {
+		name          string
+		rpt           *ResolvedPipelineTask
+		timeoutsState PipelineRunTimeoutsState
+		want          *time.Duration
+	}{{
+		name: "no timeouts",
+		rpt:  dagTask,
+		timeoutsState: PipelineRunTimeoutsState{
+			StartTime: &startTime,
+		},
+		want: nil,
+	}, {
+		name: "no timeout with pipeline timeout set to zero",
+		rpt:  dagTask,
+		timeoutsState: PipelineRunTimeoutsState{
+			StartTime:       &startTime,
+			PipelineTimeout: duration(0),
+		},
+		want: nil,
+	}, {
+		name: "pipeline timeout",
+		rpt:  dagTask,
+		timeoutsState: PipelineRunTimeoutsState{
+			StartTime:       &startTime,
+			PipelineTimeout: duration(time.Hour),
+		},
+		want: duration(5 * time.Minute),
+	}, {
+		name: "tasks timeout is reached before the pipeline timeout",
+		rpt:  dagTask,
+		timeoutsState: PipelineRunTimeoutsState{
+			StartTime:       &startTime,
+			PipelineTimeout: duration(2 * time.Hour),
+			TasksTimeout:    duration(57 * time.Minute),
+		},
+		want: duration(2 * time.Minute),
+	}, {
+		name: "finally timeout does not apply to dag tasks",
+		rpt:  dagTask,
+		timeoutsState: PipelineRunTimeoutsState{
+			StartTime:        &startTime,
+			FinallyStartTime: &finallyStartTime,
+			PipelineTimeout:  duration(time.Hour),
+			FinallyTimeout:   duration(9 * time.Minute),
+		},
+		want: duration(5 * time.Minute),
+	}, {
+		name: "finally timeout applies to final tasks",
+		rpt:  finalTask,
+		timeoutsState: PipelineRunTimeoutsState{
+			StartTime:        &startTime,
+			FinallyStartTime: &finallyStartTime,
+			PipelineTimeout:  duration(time.Hour),
+			TasksTimeout:     duration(56 * time.Minute),
+			FinallyTimeout:   duration(9 * time.Minute),
+		},
+		want: duration(time.Minute),
+	}, {
+		name: "remaining time is rounded up to the second",
+		rpt:  dagTask,
+		timeoutsState: PipelineRunTimeoutsState{
+			StartTime:       &startTime,
+			PipelineTimeout: duration(55*time.Minute + 1500*time.Millisecond),
+		},
+		want: duration(2 * time.Second),
+	}, {
+		name: "timeout already reached",
+		rpt:  dagTask,
+		timeoutsState: PipelineRunTimeoutsState{
+			StartTime:       &startTime,
+			PipelineTimeout: duration(30 * time.Minute),
+		},
+		want: duration(0),
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.timeoutsState.Clock = testClock
+			facts := &PipelineRunFacts{
+				State:           PipelineRunState{dagTask, finalTask},
+				TasksGraph:      d,
+				FinalTasksGraph: dfinally,
+				TimeoutsState:   tc.timeoutsState,
+			}
+			if d := cmp.Diff(tc.want, tc.rpt.TimeoutRemaining(facts)); d != "" {
+				t.Errorf("Didn't get expected remaining timeout: %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestResolvedPipelineTask_FinallyTimeoutRemaining(t *testing.T) {
+	dagTask := &ResolvedPipelineTask{PipelineTask: &v1.PipelineTask{Name: "dag-task", TaskRef: &v1.TaskRef{Name: "task"}}}
+	finalTask := &ResolvedPipelineTask{PipelineTask: &v1.PipelineTask{Name: "final-task", TaskRef: &v1.TaskRef{Name: "task"}}}
+	d, err := dag.Build(v1.PipelineTaskList{*dagTask.PipelineTask}, map[string][]string{})
+	if err != nil {
+		t.Fatalf("Could not get a dag from the dag tasks: %v", err)
+	}
+	dfinally, err := dag.Build(v1.PipelineTaskList{*finalTask.PipelineTask}, map[string][]string{})
+	if err != nil {
+		t.Fatalf("Could not get a dag from the finally tasks: %v", err)
+	}
+	duration := func(d time.Duration) *time.Duration { return &d }
+	startTime := now.Add(-55 * time.Minute)
+	finallyStartTime := now.Add(-8 * time.Minute)
+
+	for _, tc := range []struct {
+		name          string
+		rpt           *ResolvedPipelineTask
+		timeoutsState PipelineRunTimeoutsState
+		want          *time.Duration
+	}{{
+		name: "no finally timeout",
+		rpt:  finalTask,
+		timeoutsState: PipelineRunTimeoutsState{
+			StartTime:        &startTime,
+			FinallyStartTime: &finallyStartTime,
+			PipelineTimeout:  duration(time.Hour),
+		},
+		want: nil,
+	}, {
+		name: "finally timeout does not apply to dag tasks",
+		rpt:  dagTask,
+		timeoutsState: PipelineRunTimeoutsState{
+			StartTime:        &startTime,
+			FinallyStartTime: &finallyStartTime,
+			FinallyTimeout:   duration(9 * time.Minute),
+		},
+		want: nil,
+	}, {
+		name: "finally timeout reached before the pipeline timeout",
+		rpt:  finalTask,
+		timeoutsState: PipelineRunTimeoutsState{
+			StartTime:        &startTime,
+			FinallyStartTime: &finallyStartTime,
+			PipelineTimeout:  duration(2 * time.Hour),
+			FinallyTimeout:   duration(18 * time.Minute),
+		},
+		want: duration(10 * time.Minute),
+	}, {
+		name: "pipeline timeout reached before the finally timeout",
+		rpt:  finalTask,
+		timeoutsState: PipelineRunTimeoutsState{
+			StartTime:        &startTime,
+			FinallyStartTime: &finallyStartTime,
+			PipelineTimeout:  duration(time.Hour),
+			FinallyTimeout:   duration(18 * time.Minute),
+		},
+		want: duration(5 * time.Minute),
+	}, {
+		name: "finally timeout without pipeline timeout",
+		rpt:  finalTask,
+		timeoutsState: PipelineRunTimeoutsState{
+			StartTime:        &startTime,
+			FinallyStartTime: &finallyStartTime,
+			PipelineTimeout:  duration(config.NoTimeoutDuration),
+			FinallyTimeout:   duration(18 * time.Minute),
+		},
+		want: duration(10 * time.Minute),
+	}, {
+		name: "finally timeout already reached",
+		rpt:  finalTask,
+		timeoutsState: PipelineRunTimeoutsState{
+			StartTime:        &startTime,
+			FinallyStartTime: &finallyStartTime,
+			FinallyTimeout:   duration(5 * time.Minute),
+		},
+		want: duration(0),
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.timeoutsState.Clock = testClock
+			facts := &PipelineRunFacts{
+				State:           PipelineRunState{dagTask, finalTask},
+				TasksGraph:      d,
+				FinalTasksGraph: dfinally,
+				TimeoutsState:   tc.timeoutsState,
+			}
+			if d := cmp.Diff(tc.want, tc.rpt.FinallyTimeoutRemaining(facts)); d != "" {
+				t.Errorf("Didn't get expected remaining finally timeout: %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestSkipDecisionsAreLogged(t *testing.T) {
+	tr := &v1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "dag-task"},
+		Status: v1.TaskRunStatus{
+			Status: duckv1.Status{
+				Conditions: duckv1.Conditions{{
+					Type:   apis.ConditionSucceeded,
+					Status: corev1.ConditionTrue,
+				}},
+			},
+		},
+	}
+	dagTask := &ResolvedPipelineTask{
+		TaskRunNames: []string{"dag-task"},
+		TaskRuns:     []*v1.TaskRun{tr},
+		PipelineTask: &v1.PipelineTask{
+			Name:    "dag-task",
+			TaskRef: &v1.TaskRef{Name: "task"},
+		},
+	}
+	whenTask := &ResolvedPipelineTask{
+		PipelineTask: &v1.PipelineTask{
+			Name:    "when-task",
+			TaskRef: &v1.TaskRef{Name: "task"},
+			When: v1.WhenExpressions{{
+				Input:    "foo",
+				Operator: selection.In,
+				Values:   []string{"bar"},
+			}},
+		},
+	}
+	finalTask := &ResolvedPipelineTask{
+		PipelineTask: &v1.PipelineTask{
+			Name:    "final-task",
+			TaskRef: &v1.TaskRef{Name: "task"},
+			Params: v1.Params{{
+				Name:  "commit",
+				Value: *v1.NewStructuredValues("$(tasks.dag-task.results.commit)"),
+			}},
+		},
+	}
+
+	tasks := v1.PipelineTaskList{*dagTask.PipelineTask, *whenTask.PipelineTask}
+	d, err := dag.Build(tasks, tasks.Deps())
+	if err != nil {
+		t.Fatalf("Could not get a dag from the dag tasks %#v: %v", tasks, err)
+	}
+	dfinally, err := dag.Build(v1.PipelineTaskList{*finalTask.PipelineTask}, map[string][]string{})
+	if err != nil {
+		t.Fatalf("Could not get a dag from the finally tasks: %v", err)
+	}
+
+	core, logs := observer.New(zapcore.DebugLevel)
+	facts := &PipelineRunFacts{
+		State:           PipelineRunState{dagTask, whenTask, finalTask},
+		TasksGraph:      d,
+		FinalTasksGraph: dfinally,
+		TimeoutsState: PipelineRunTimeoutsState{
+			Clock: testClock,
+		},
+		Logger: zap.New(core).Sugar().With(zap.String("pipelinerun", "pr")),
+	}
+
+	if got := whenTask.Skip(facts).SkippingReason; got != v1.WhenExpressionsSkip {
+		t.Fatalf("Expected %s to be skipped for %s but got %s", whenTask.PipelineTask.Name, v1.WhenExpressionsSkip, got)
+	}
+	if got := finalTask.IsFinallySkipped(facts).SkippingReason; got != v1.MissingResultsSkip {
+		t.Fatalf("Expected %s to be skipped for %s but got %s", finalTask.PipelineTask.Name, v1.MissingResultsSkip, got)
+	}
+
+	entries := logs.FilterMessage("Skipping PipelineTask").AllUntimed()
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 skip decisions to be logged but got %d: %v", len(entries), entries)
+	}
+
+	when := entries[0].ContextMap()
+	for k, v := range map[string]string{
+		"pipelinerun":    "pr",
+		"pipelineTask":   "when-task",
+		"skippingReason": string(v1.WhenExpressionsSkip),
+	} {
+		if when[k] != v {
+			t.Errorf("Expected field %s of the when expressions skip to be %q but got %v", k, v, when[k])
+		}
+	}
+	wantDecisions := []whenExpressionDecision{{Input: "foo", Operator: "in", Values: []string{"bar"}}}
+	if d := cmp.Diff(wantDecisions, when["whenExpressions"]); d != "" {
+		t.Errorf("Unexpected whenExpressions field %s", diff.PrintWantGot(d))
+	}
+
+	results := entries[1].ContextMap()
+	for k, v := range map[string]string{
+		"pipelinerun":        "pr",
+		"pipelineTask":       "final-task",
+		"skippingReason":     string(v1.MissingResultsSkip),
+		"missingResultsFrom": "dag-task",
+	} {
+		if results[k] != v {
+			t.Errorf("Expected field %s of the missing results skip to be %q but got %v", k, v, results[k])
+		}
+	}
+	if d := cmp.Diff([]any{"tasks.dag-task.results.commit"}, results["resultReferences"]); d != "" {
+		t.Errorf("Unexpected resultReferences field %s", diff.PrintWantGot(d))
+	}
+	if _, ok := results["missingResultReferences"]; !ok {
+		t.Errorf("Expected the missingResultReferences field to be logged, got %v", results)
+	}
+}
+
+func TestSkipDecisionsAreNotLoggedAboveDebugLevel(t *testing.T) {
+	whenTask := &ResolvedPipelineTask{
+		PipelineTask: &v1.PipelineTask{
+			Name:    "when-task",
+			TaskRef: &v1.TaskRef{Name: "task"},
+			When: v1.WhenExpressions{{
+				Input:    "foo",
+				Operator: selection.In,
+				Values:   []string{"bar"},
+			}},
+		},
+	}
+	tasks := v1.PipelineTaskList{*whenTask.PipelineTask}
+	d, err := dag.Build(tasks, tasks.Deps())
+	if err != nil {
+		t.Fatalf("Could not get a dag from the dag tasks %#v: %v", tasks, err)
+	}
+
+	core, logs := observer.New(zapcore.InfoLevel)
+	facts := &PipelineRunFacts{
+		State:           PipelineRunState{whenTask},
+		TasksGraph:      d,
+		FinalTasksGraph: &dag.Graph{},
+		TimeoutsState: PipelineRunTimeoutsState{
+			Clock: testClock,
+		},
+		Logger: zap.New(core).Sugar(),
+	}
+
+	if got := whenTask.Skip(facts).SkippingReason; got != v1.WhenExpressionsSkip {
+		t.Fatalf("Expected %s to be skipped for %s but got %s", whenTask.PipelineTask.Name, v1.WhenExpressionsSkip, got)
+	}
+	if logs.Len() != 0 {
+		t.Errorf("Expected no skip decisions to be logged above the debug level but got %v", logs.AllUntimed())
+	}
+}
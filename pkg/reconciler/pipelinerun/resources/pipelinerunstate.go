@@ -26,6 +26,7 @@ import (
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/pod"
 	"github.com/tektoncd/pipeline/pkg/reconciler/pipeline/dag"
 	"github.com/tektoncd/pipeline/pkg/substitution"
 	"go.uber.org/zap"
@@ -45,6 +46,9 @@ const (
 	// PipelineTaskStatusSuffix is a suffix of the param representing execution state of pipelineTask
 	PipelineTaskStatusSuffix = ".status"
 	PipelineTaskReasonSuffix = ".reason"
+
+	// maxFailureCauses is the maximum number of failure causes included in the PipelineRun Status
+	maxFailureCauses = 5
 )
 
 // PipelineRunState is a slice of ResolvedPipelineRunTasks the represents the current execution
@@ -83,6 +87,10 @@ type PipelineRunFacts struct {
 	// condition to help users understand why specific tasks were not executed
 	// (e.g. missing result references).
 	ValidationFailedErrors map[string]string
+
+	// Logger logs the decisions to skip PipelineTasks, with the fields they were made from at the debug
+	// level. It should carry the "pipelinerun" field. Nothing is logged when it is nil.
+	Logger *zap.SugaredLogger
 }
 
 // PipelineRunTimeoutsState records information about start times and timeouts for the PipelineRun, so that the PipelineRunFacts
@@ -131,9 +139,10 @@ func (state PipelineRunState) ToMap() map[string]*ResolvedPipelineTask {
 }
 
 // IsBeforeFirstTaskRun returns true if the PipelineRun has not yet started its first child PipelineRun/TaskRun/CustomRun
+// nor reached any approval gate
 func (state PipelineRunState) IsBeforeFirstTaskRun() bool {
 	for _, t := range state {
-		if len(t.ChildPipelineRuns) > 0 || len(t.CustomRuns) > 0 || len(t.TaskRuns) > 0 {
+		if len(t.ChildPipelineRuns) > 0 || len(t.CustomRuns) > 0 || len(t.TaskRuns) > 0 || t.ApprovalGateStatus != nil {
 			return false
 		}
 	}
@@ -188,6 +197,9 @@ func (state PipelineRunState) GetTaskRunsResults() map[string][]v1.TaskRunResult
 		if rpt.IsCustomTask() {
 			continue
 		}
+		if rpt.IsApprovalGate() {
+			continue
+		}
 		if !rpt.isSuccessful() && !rpt.isFailure() {
 			continue
 		}
@@ -215,6 +227,9 @@ func (state PipelineRunState) GetTaskRunsArtifacts() map[string]*v1.Artifacts {
 		if rpt.IsCustomTask() {
 			continue
 		}
+		if rpt.IsApprovalGate() {
+			continue
+		}
 		if !rpt.isSuccessful() && !rpt.isFailure() {
 			continue
 		}
@@ -396,6 +411,11 @@ func (t *ResolvedPipelineTask) getChildRefForTaskRun(taskRun *v1.TaskRun) v1.Chi
 		Name:             taskRun.Name,
 		PipelineTaskName: t.PipelineTask.Name,
 		WhenExpressions:  t.PipelineTask.When,
+		Labels:           t.PipelineTask.Labels,
+	}
+	// The outcome of each instance of a matrix which doesn't fail fast is recorded, as they all run
+	if !t.PipelineTask.Matrix.IsFailFast() && taskRun.IsDone() {
+		c.Reason = taskRun.Status.GetCondition(apis.ConditionSucceeded).Reason
 	}
 	return t.getDisplayName(nil, nil, taskRun, c)
 }
@@ -407,7 +427,9 @@ func (state PipelineRunState) getNextTasks(candidateTasks sets.String) []*Resolv
 	tasks := []*ResolvedPipelineTask{}
 	for _, t := range state {
 		if _, ok := candidateTasks[t.PipelineTask.Name]; ok {
-			if len(t.TaskRuns) == 0 && len(t.CustomRuns) == 0 && len(t.ChildPipelineRuns) == 0 {
+			if len(t.TaskRuns) == 0 && len(t.CustomRuns) == 0 && len(t.ChildPipelineRuns) == 0 && t.ApprovalGateStatus == nil {
+				tasks = append(tasks, t)
+			} else if t.hasUncreatedMatrixInstances() {
 				tasks = append(tasks, t)
 			}
 		}
@@ -415,6 +437,18 @@ func (state PipelineRunState) getNextTasks(candidateTasks sets.String) []*Resolv
 	return tasks
 }
 
+// getUncreatedMatrixInstances returns the pipeline tasks from candidateTasks with a Matrix which doesn't
+// fail fast, some of whose instances were created while the others weren't yet
+func (state PipelineRunState) getUncreatedMatrixInstances(candidateTasks sets.String) []*ResolvedPipelineTask {
+	var tasks []*ResolvedPipelineTask
+	for _, t := range state {
+		if _, ok := candidateTasks[t.PipelineTask.Name]; ok && t.hasUncreatedMatrixInstances() {
+			tasks = append(tasks, t)
+		}
+	}
+	return tasks
+}
+
 // IsStopping returns true if the PipelineRun won't be scheduling any new Task because
 // at least one task already failed (with onError: stopAndFail) or was cancelled in the specified dag
 func (facts *PipelineRunFacts) IsStopping() bool {
@@ -458,9 +492,8 @@ func (facts *PipelineRunFacts) IsGracefullyStopped() bool {
 // DAGExecutionQueue returns a list of DAG tasks which needs to be scheduled next
 func (facts *PipelineRunFacts) DAGExecutionQueue() (PipelineRunState, error) {
 	var tasks PipelineRunState
-	// when pipelinerun is cancelled or gracefully cancelled, do not schedule any new tasks,
-	// and only wait for all running tasks to complete (without exhausting retries).
-	if facts.IsCancelled() || facts.IsGracefullyCancelled() {
+	// when pipelinerun is cancelled, do not schedule any new tasks
+	if facts.IsCancelled() {
 		return tasks, nil
 	}
 	// candidateTasks is initialized to DAG root nodes to start pipeline execution
@@ -469,7 +502,15 @@ func (facts *PipelineRunFacts) DAGExecutionQueue() (PipelineRunState, error) {
 	if err != nil {
 		return tasks, err
 	}
-	if !facts.IsStopping() && !facts.IsGracefullyStopped() {
+	switch {
+	case facts.IsGracefullyCancelled() || facts.IsGracefullyStopped():
+		// when pipelinerun is gracefully cancelled or stopped, do not schedule any new tasks, and only wait
+		// for all running tasks to complete (without exhausting retries)
+	case facts.IsStopping():
+		// when pipelinerun is stopping after a failure, do not schedule any new tasks but the instances of the
+		// matrices which don't fail fast which weren't created yet, and wait for all running tasks to complete
+		tasks = facts.State.getUncreatedMatrixInstances(candidateTasks)
+	default:
 		tasks = facts.State.getNextTasks(candidateTasks)
 	}
 	return tasks, nil
@@ -619,6 +660,10 @@ func (facts *PipelineRunFacts) GetPipelineConditionStatus(ctx context.Context, p
 		case s.ValidationFailed > 0:
 			reason = v1.PipelineRunReasonFailedValidation.String()
 			status = corev1.ConditionFalse
+		case s.Failed > 0 && facts.failedApprovalGateReason() != "":
+			// Set reason to the reason of the approval gate - An approval gate was rejected or timed out
+			reason = facts.failedApprovalGateReason()
+			status = corev1.ConditionFalse
 		case s.Failed > 0 || s.SkippedDueToTimeout > 0:
 			// Set reason to ReasonFailed - At least one failed
 			reason = v1.PipelineRunReasonFailed.String()
@@ -643,6 +688,8 @@ func (facts *PipelineRunFacts) GetPipelineConditionStatus(ctx context.Context, p
 	}
 
 	// Not all tasks (regular + finally) have finished.... Must keep running then....
+	message := fmt.Sprintf("Tasks Completed: %d (Failed: %d, Cancelled %d), Incomplete: %d, Skipped: %d",
+		cmTasks, s.Failed, s.Cancelled, s.Incomplete, s.Skipped)
 	switch {
 	case pr.HaveTasksTimedOut(ctx, c) && facts.hasFinalTasks() && !facts.checkFinalTasksDone():
 		// Tasks have timed out but finally tasks are still running
@@ -658,16 +705,196 @@ func (facts *PipelineRunFacts) GetPipelineConditionStatus(ctx context.Context, p
 		// for a pipeline with final tasks, single dag task failure does not transition to interim stopping state
 		// pipeline stays in running state until all final tasks are done before transitioning to failed state
 		reason = v1.PipelineRunReasonStopping.String()
+	default:
+		// Transition pipeline into waiting for approval state when one of the approval gates was reached
+		// and nothing else is left to do until it is approved
+		if waiting := facts.waitingApprovalGates(); len(waiting) > 0 {
+			reason = v1.PipelineRunReasonWaitingForApproval.String()
+			message = strings.Join(waiting, "; ")
+		}
 	}
 
 	// return the status
 	return &apis.Condition{
-		Type:   apis.ConditionSucceeded,
-		Status: corev1.ConditionUnknown,
-		Reason: reason,
-		Message: fmt.Sprintf("Tasks Completed: %d (Failed: %d, Cancelled %d), Incomplete: %d, Skipped: %d",
-			cmTasks, s.Failed, s.Cancelled, s.Incomplete, s.Skipped),
+		Type:    apis.ConditionSucceeded,
+		Status:  corev1.ConditionUnknown,
+		Reason:  reason,
+		Message: message,
+	}
+}
+
+// waitingApprovalGates returns a description of each approval gate waiting for a decision, with
+// the approvers who may take it, if no other PipelineTask is running.
+func (facts *PipelineRunFacts) waitingApprovalGates() []string {
+	var waiting []string
+	for _, t := range facts.State {
+		if !t.IsRunning() {
+			continue
+		}
+		if !t.IsApprovalGate() {
+			return nil
+		}
+		waiting = append(waiting, fmt.Sprintf("PipelineTask %q is waiting for the approval of one of: %s",
+			t.PipelineTask.Name, strings.Join(t.ApprovalGateStatus.Approvers, ", ")))
+	}
+	return waiting
+}
+
+// failedApprovalGateReason returns the reason the PipelineRun failed with if it failed because one
+// of its approval gates was rejected or timed out, and an empty string otherwise.
+func (facts *PipelineRunFacts) failedApprovalGateReason() string {
+	for _, t := range facts.State {
+		if !t.IsApprovalGate() || t.ApprovalGateStatus == nil {
+			continue
+		}
+		switch t.ApprovalGateStatus.Reason {
+		case v1.ApprovalGateReasonRejected:
+			return v1.PipelineRunReasonApprovalRejected.String()
+		case v1.ApprovalGateReasonTimedOut:
+			return v1.PipelineRunReasonApprovalTimedOut.String()
+		}
+	}
+	return ""
+}
+
+// GetApprovalGates returns the status of the approval gates which were reached, to be included in the
+// PipelineRun Status
+func (facts *PipelineRunFacts) GetApprovalGates() []v1.ApprovalGateStatus {
+	var gates []v1.ApprovalGateStatus
+	for _, rpt := range facts.State {
+		if rpt.IsApprovalGate() && rpt.ApprovalGateStatus != nil {
+			gates = append(gates, *rpt.ApprovalGateStatus)
+		}
+	}
+	return gates
+}
+
+// GetLayers returns the number of layers of the DAG of the PipelineRun and the last of them whose
+// PipelineTasks are all done, to be included in the PipelineRun Status. A layer is only completed once
+// all the layers before it are, and the finally tasks, which are not part of the DAG, are ignored.
+func (facts *PipelineRunFacts) GetLayers() *v1.PipelineRunLayers {
+	layers := facts.TasksGraph.Layers()
+	if len(layers) == 0 {
+		return nil
+	}
+	total := 0
+	for _, l := range layers {
+		total = max(total, l)
+	}
+	// The first layer with a PipelineTask which is not done yet bounds the completed layers
+	completed := total
+	for _, t := range facts.State {
+		l, ok := layers[t.PipelineTask.Name]
+		if ok && l <= completed && !t.isDone(facts) {
+			completed = l - 1
+		}
 	}
+	return &v1.PipelineRunLayers{Total: total, Completed: completed}
+}
+
+// GetFailureCauses returns the failed runs of the PipelineTasks which made the PipelineRun fail, to be
+// included in the PipelineRun Status. The PipelineTasks which failed are the ones counted as failed in
+// the condition of the PipelineRun, and their failed runs are ordered by the time they completed, then
+// by PipelineTask and run name, only the first maxFailureCauses of them being kept.
+func (facts *PipelineRunFacts) GetFailureCauses() []v1.PipelineRunFailureCause {
+	type failureCause struct {
+		v1.PipelineRunFailureCause
+		completionTime time.Time
+	}
+	var causes []failureCause
+	add := func(cause v1.PipelineRunFailureCause, completionTime *metav1.Time) {
+		c := failureCause{PipelineRunFailureCause: cause}
+		if completionTime != nil {
+			c.completionTime = completionTime.Time
+		}
+		causes = append(causes, c)
+	}
+	for _, rpt := range facts.State {
+		switch {
+		case rpt.isSuccessful():
+			continue
+		case rpt.isCancelledForTimeOut():
+		case rpt.isCancelled():
+			continue
+		case rpt.isFailure():
+			if rpt.PipelineTask.OnError == v1.PipelineTaskContinue {
+				continue
+			}
+		default:
+			continue
+		}
+
+		name := rpt.PipelineTask.Name
+		switch {
+		case rpt.IsApprovalGate():
+			add(v1.PipelineRunFailureCause{PipelineTaskName: name, Reason: string(rpt.ApprovalGateStatus.Reason)},
+				rpt.ApprovalGateStatus.CompletionTime)
+		case rpt.IsChildPipeline():
+			for _, pr := range rpt.ChildPipelineRuns {
+				if pr != nil && pr.IsFailure() {
+					add(v1.PipelineRunFailureCause{
+						PipelineTaskName: name,
+						Kind:             pipeline.PipelineRunControllerName,
+						Name:             pr.Name,
+						Reason:           pr.Status.GetCondition(apis.ConditionSucceeded).GetReason(),
+					}, pr.Status.CompletionTime)
+				}
+			}
+		case rpt.IsCustomTask():
+			for _, run := range rpt.CustomRuns {
+				if run != nil && run.IsFailure() {
+					add(v1.PipelineRunFailureCause{
+						PipelineTaskName: name,
+						Kind:             pipeline.CustomRunControllerName,
+						Name:             run.Name,
+						Reason:           run.Status.GetCondition(apis.ConditionSucceeded).GetReason(),
+					}, run.Status.CompletionTime)
+				}
+			}
+		default:
+			for _, tr := range rpt.TaskRuns {
+				if tr != nil && tr.IsFailure() {
+					cause := v1.PipelineRunFailureCause{
+						PipelineTaskName: name,
+						Kind:             pipeline.TaskRunControllerName,
+						Name:             tr.Name,
+						Reason:           tr.Status.GetCondition(apis.ConditionSucceeded).GetReason(),
+					}
+					if step := firstFailedStep(tr.Status.Steps); step != nil {
+						cause.StepName = step.Name
+						cause.StepTerminationReason = step.TerminationReason
+					}
+					add(cause, tr.Status.CompletionTime)
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(causes, func(i, j int) bool {
+		if !causes[i].completionTime.Equal(causes[j].completionTime) {
+			return causes[i].completionTime.Before(causes[j].completionTime)
+		}
+		if causes[i].PipelineTaskName != causes[j].PipelineTaskName {
+			return causes[i].PipelineTaskName < causes[j].PipelineTaskName
+		}
+		return causes[i].Name < causes[j].Name
+	})
+	var failureCauses []v1.PipelineRunFailureCause
+	for i := 0; i < len(causes) && i < maxFailureCauses; i++ {
+		failureCauses = append(failureCauses, causes[i].PipelineRunFailureCause)
+	}
+	return failureCauses
+}
+
+// firstFailedStep returns the first step which terminated with a non-zero exit code without its
+// error being ignored.
+func firstFailedStep(steps []v1.StepState) *v1.StepState {
+	for i := range steps {
+		if t := steps[i].Terminated; t != nil && t.ExitCode != 0 && steps[i].TerminationReason != pod.TerminationReasonContinued {
+			return &steps[i]
+		}
+	}
+	return nil
 }
 
 // GetSkippedTasks constructs a list of SkippedTask struct to be included in the PipelineRun Status
@@ -732,6 +959,11 @@ func (facts *PipelineRunFacts) GetPipelineTaskStatus() map[string]string {
 		for _, t := range facts.State {
 			if facts.isDAGTask(t.PipelineTask.Name) {
 				// if any of the dag pipeline tasks failed, change the aggregate status to failed and return
+				if t.IsApprovalGate() && t.isFailure() {
+					aggregateStatus = v1.PipelineRunReasonFailed.String()
+					break
+				}
+
 				if t.IsChildPipeline() && t.haveAnyChildPipelineRunsFailed() {
 					aggregateStatus = v1.PipelineRunReasonFailed.String()
 					break
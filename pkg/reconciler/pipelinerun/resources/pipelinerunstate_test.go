@@ -18,6 +18,7 @@ package resources
 
 import (
 	"fmt"
+	"slices"
 	"testing"
 	"time"
 
@@ -1109,6 +1110,100 @@ func TestDAGExecutionQueue(t *testing.T) {
 	}
 }
 
+// TestDAGExecutionQueueUncreatedMatrixInstances tests that the instances of a matrix which doesn't fail fast
+// which weren't created yet are scheduled, even when the PipelineRun is stopping, but not when it is cancelled
+// or stopped
+func TestDAGExecutionQueueUncreatedMatrixInstances(t *testing.T) {
+	matrix := &v1.Matrix{
+		Params: v1.Params{{Name: "platform", Value: v1.ParamValue{Type: v1.ParamTypeArray, ArrayVal: []string{"linux", "mac"}}}},
+	}
+	partiallyCreatedMatrix := func(failFast bool) *ResolvedPipelineTask {
+		m := matrix.DeepCopy()
+		m.FailFast = &failFast
+		return &ResolvedPipelineTask{
+			PipelineTask: &v1.PipelineTask{
+				Name:    "matrixedtask",
+				TaskRef: &v1.TaskRef{Name: "task"},
+				Matrix:  m,
+			},
+			TaskRunNames: []string{"matrixedtask-0", "matrixedtask-1"},
+			TaskRuns:     []*v1.TaskRun{makeFailed(trs[0])},
+			ResolvedTask: &resources.ResolvedTask{
+				TaskSpec: &task.Spec,
+			},
+		}
+	}
+	notFailingFast := partiallyCreatedMatrix(false)
+	failingFast := partiallyCreatedMatrix(true)
+	failedTask := &ResolvedPipelineTask{
+		PipelineTask: &v1.PipelineTask{
+			Name:    "failedtask",
+			TaskRef: &v1.TaskRef{Name: "task"},
+		},
+		TaskRunNames: []string{"failedtask"},
+		TaskRuns:     []*v1.TaskRun{makeFailed(trs[1])},
+		ResolvedTask: &resources.ResolvedTask{
+			TaskSpec: &task.Spec,
+		},
+	}
+	for _, tc := range []struct {
+		name       string
+		state      PipelineRunState
+		specStatus v1.PipelineRunSpecStatus
+		want       PipelineRunState
+	}{{
+		name:  "matrix not failing fast",
+		state: PipelineRunState{notFailingFast},
+		want:  PipelineRunState{notFailingFast},
+	}, {
+		name:  "matrix not failing fast, stopping",
+		state: PipelineRunState{notFailingFast, failedTask},
+		want:  PipelineRunState{notFailingFast},
+	}, {
+		name:       "matrix not failing fast, gracefully cancelled",
+		state:      PipelineRunState{notFailingFast},
+		specStatus: v1.PipelineRunSpecStatusCancelledRunFinally,
+	}, {
+		name:       "matrix not failing fast, gracefully stopped",
+		state:      PipelineRunState{notFailingFast},
+		specStatus: v1.PipelineRunSpecStatusStoppedRunFinally,
+	}, {
+		name:       "matrix not failing fast, stopping and gracefully cancelled",
+		state:      PipelineRunState{notFailingFast, failedTask},
+		specStatus: v1.PipelineRunSpecStatusCancelledRunFinally,
+	}, {
+		name:       "matrix not failing fast, cancelled",
+		state:      PipelineRunState{notFailingFast},
+		specStatus: v1.PipelineRunSpecStatusCancelled,
+	}, {
+		name:  "matrix failing fast",
+		state: PipelineRunState{failingFast},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			d, err := dagFromState(tc.state)
+			if err != nil {
+				t.Fatalf("Unexpected error while building DAG for state %v: %v", tc.state, err)
+			}
+			facts := PipelineRunFacts{
+				State:           tc.state,
+				SpecStatus:      tc.specStatus,
+				TasksGraph:      d,
+				FinalTasksGraph: &dag.Graph{},
+				TimeoutsState: PipelineRunTimeoutsState{
+					Clock: testClock,
+				},
+			}
+			queue, err := facts.DAGExecutionQueue()
+			if err != nil {
+				t.Errorf("unexpected error getting DAG execution queue: %s", err)
+			}
+			if d := cmp.Diff(tc.want, queue, cmpopts.EquateEmpty()); d != "" {
+				t.Errorf("Didn't get expected execution queue: %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 // TestDAGExecutionQueueSequentialTasks tests the DAGExecutionQueue function for sequential TaskRuns
 // in different states for a running or stopping PipelineRun.
 func TestDAGExecutionQueueSequentialTasks(t *testing.T) {
@@ -2589,6 +2684,145 @@ func TestGetPipelineConditionStatus_OnError(t *testing.T) {
 	}
 }
 
+func TestPipelineRunFacts_ApprovalGates(t *testing.T) {
+	build := v1.PipelineTask{Name: "build", TaskRef: &v1.TaskRef{Name: "task"}}
+	approve := v1.PipelineTask{Name: "approve", ApprovalGate: &v1.ApprovalGate{Approvers: []string{"alice", "bob"}}, RunAfter: []string{"build"}}
+	release := v1.PipelineTask{Name: "release", TaskRef: &v1.TaskRef{Name: "task"}, RunAfter: []string{"approve"}}
+	gateStatus := func(reason v1.ApprovalGateReason) *v1.ApprovalGateStatus {
+		return &v1.ApprovalGateStatus{
+			PipelineTaskName: "approve",
+			Approvers:        []string{"alice", "bob"},
+			Reason:           reason,
+			StartTime:        metav1.NewTime(now),
+		}
+	}
+	for _, tc := range []struct {
+		name               string
+		buildTaskRun       *v1.TaskRun
+		gate               *v1.ApprovalGateStatus
+		expectedStatus     corev1.ConditionStatus
+		expectedReason     string
+		expectedMessage    string
+		expectedNextTasks  []string
+		expectedSkipReason v1.SkippingReason
+		expectedTaskStatus string
+	}{{
+		name:               "gate not reached",
+		buildTaskRun:       makeStarted(trs[0]),
+		expectedStatus:     corev1.ConditionUnknown,
+		expectedReason:     v1.PipelineRunReasonRunning.String(),
+		expectedMessage:    "Tasks Completed: 0 (Failed: 0, Cancelled 0), Incomplete: 3, Skipped: 0",
+		expectedSkipReason: v1.None,
+		expectedTaskStatus: PipelineTaskStateNone,
+	}, {
+		name:               "gate reached",
+		buildTaskRun:       makeSucceeded(trs[0]),
+		expectedStatus:     corev1.ConditionUnknown,
+		expectedReason:     v1.PipelineRunReasonRunning.String(),
+		expectedMessage:    "Tasks Completed: 1 (Failed: 0, Cancelled 0), Incomplete: 2, Skipped: 0",
+		expectedNextTasks:  []string{"approve"},
+		expectedSkipReason: v1.None,
+		expectedTaskStatus: PipelineTaskStateNone,
+	}, {
+		name:               "waiting for approval",
+		buildTaskRun:       makeSucceeded(trs[0]),
+		gate:               gateStatus(v1.ApprovalGateReasonWaiting),
+		expectedStatus:     corev1.ConditionUnknown,
+		expectedReason:     v1.PipelineRunReasonWaitingForApproval.String(),
+		expectedMessage:    `PipelineTask "approve" is waiting for the approval of one of: alice, bob`,
+		expectedSkipReason: v1.None,
+		expectedTaskStatus: PipelineTaskStateNone,
+	}, {
+		name:               "approved",
+		buildTaskRun:       makeSucceeded(trs[0]),
+		gate:               gateStatus(v1.ApprovalGateReasonApproved),
+		expectedStatus:     corev1.ConditionUnknown,
+		expectedReason:     v1.PipelineRunReasonRunning.String(),
+		expectedMessage:    "Tasks Completed: 2 (Failed: 0, Cancelled 0), Incomplete: 1, Skipped: 0",
+		expectedNextTasks:  []string{"release"},
+		expectedSkipReason: v1.None,
+		expectedTaskStatus: v1.TaskRunReasonSuccessful.String(),
+	}, {
+		name:               "rejected",
+		buildTaskRun:       makeSucceeded(trs[0]),
+		gate:               gateStatus(v1.ApprovalGateReasonRejected),
+		expectedStatus:     corev1.ConditionFalse,
+		expectedReason:     v1.PipelineRunReasonApprovalRejected.String(),
+		expectedMessage:    "Tasks Completed: 2 (Failed: 1, Cancelled 0), Skipped: 1",
+		expectedSkipReason: v1.ApprovalRejectedSkip,
+		expectedTaskStatus: v1.TaskRunReasonFailed.String(),
+	}, {
+		name:               "timed out",
+		buildTaskRun:       makeSucceeded(trs[0]),
+		gate:               gateStatus(v1.ApprovalGateReasonTimedOut),
+		expectedStatus:     corev1.ConditionFalse,
+		expectedReason:     v1.PipelineRunReasonApprovalTimedOut.String(),
+		expectedMessage:    "Tasks Completed: 2 (Failed: 1, Cancelled 0), Skipped: 1",
+		expectedSkipReason: v1.ApprovalTimedOutSkip,
+		expectedTaskStatus: v1.TaskRunReasonFailed.String(),
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			state := PipelineRunState{{
+				PipelineTask: &build,
+				TaskRunNames: []string{"pipelinerun-build"},
+				TaskRuns:     []*v1.TaskRun{tc.buildTaskRun},
+				ResolvedTask: &resources.ResolvedTask{TaskSpec: &task.Spec},
+			}, {
+				PipelineTask:       &approve,
+				ApprovalGateStatus: tc.gate,
+			}, {
+				PipelineTask: &release,
+				ResolvedTask: &resources.ResolvedTask{TaskSpec: &task.Spec},
+			}}
+			d, err := dagFromState(state)
+			if err != nil {
+				t.Fatalf("Unexpected error while building DAG for state %v: %v", state, err)
+			}
+			facts := PipelineRunFacts{
+				State:           state,
+				TasksGraph:      d,
+				FinalTasksGraph: &dag.Graph{},
+				TimeoutsState: PipelineRunTimeoutsState{
+					Clock: testClock,
+				},
+			}
+			pr := &v1.PipelineRun{ObjectMeta: metav1.ObjectMeta{Name: "pipelinerun-approval"}}
+
+			c := facts.GetPipelineConditionStatus(t.Context(), pr, zap.NewNop().Sugar(), testClock)
+			if c.Status != tc.expectedStatus || c.Reason != tc.expectedReason || c.Message != tc.expectedMessage {
+				t.Errorf("Expected condition %s/%s/%q but got %s/%s/%q", tc.expectedStatus, tc.expectedReason, tc.expectedMessage, c.Status, c.Reason, c.Message)
+			}
+
+			queue, err := facts.DAGExecutionQueue()
+			if err != nil {
+				t.Fatalf("Unexpected error getting DAG execution queue: %v", err)
+			}
+			var nextTasks []string
+			for _, rpt := range queue {
+				nextTasks = append(nextTasks, rpt.PipelineTask.Name)
+			}
+			if d := cmp.Diff(tc.expectedNextTasks, nextTasks); d != "" {
+				t.Errorf("Unexpected next tasks %s", diff.PrintWantGot(d))
+			}
+
+			if skip := state[2].Skip(&facts); skip.SkippingReason != tc.expectedSkipReason {
+				t.Errorf("Expected the task after the gate to be skipped with %q but got %q", tc.expectedSkipReason, skip.SkippingReason)
+			}
+			if s := facts.GetPipelineTaskStatus()[PipelineTaskStatusPrefix+"approve"+PipelineTaskStatusSuffix]; s != tc.expectedTaskStatus {
+				t.Errorf("Expected the status of the gate to be %q but got %q", tc.expectedTaskStatus, s)
+			}
+
+			var expectedGates []v1.ApprovalGateStatus
+			if tc.gate != nil {
+				expectedGates = []v1.ApprovalGateStatus{*tc.gate}
+			}
+			if d := cmp.Diff(expectedGates, facts.GetApprovalGates()); d != "" {
+				t.Errorf("Unexpected approval gates %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 func TestAdjustStartTime(t *testing.T) {
 	baseline := metav1.Time{Time: now}
 
@@ -3234,6 +3468,224 @@ func TestPipelineRunFacts_GetSkippedTasks(t *testing.T) {
 	}
 }
 
+func TestPipelineRunFacts_GetFailureCauses(t *testing.T) {
+	taskRun := func(name string, status corev1.ConditionStatus, reason string, completed time.Duration, steps ...v1.StepState) *v1.TaskRun {
+		return &v1.TaskRun{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status: v1.TaskRunStatus{
+				Status: duckv1.Status{Conditions: duckv1.Conditions{{
+					Type:   apis.ConditionSucceeded,
+					Status: status,
+					Reason: reason,
+				}}},
+				TaskRunStatusFields: v1.TaskRunStatusFields{
+					CompletionTime: &metav1.Time{Time: now.Add(completed)},
+					Steps:          steps,
+				},
+			},
+		}
+	}
+	step := func(name string, exitCode int32, terminationReason string) v1.StepState {
+		return v1.StepState{
+			Name:              name,
+			ContainerState:    corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: exitCode}},
+			TerminationReason: terminationReason,
+		}
+	}
+	pipelineTask := func(name string) *v1.PipelineTask {
+		return &v1.PipelineTask{Name: name, TaskRef: &v1.TaskRef{Name: "task"}}
+	}
+
+	for _, tc := range []struct {
+		name  string
+		state PipelineRunState
+		want  []v1.PipelineRunFailureCause
+	}{{
+		name: "two parallel failures ordered by completion time",
+		state: PipelineRunState{{
+			PipelineTask: pipelineTask("build"),
+			TaskRuns:     []*v1.TaskRun{taskRun("pr-build", corev1.ConditionTrue, v1.TaskRunReasonSuccessful.String(), time.Minute)},
+		}, {
+			PipelineTask: pipelineTask("deploy"),
+			TaskRuns: []*v1.TaskRun{taskRun("pr-deploy", corev1.ConditionFalse, v1.TaskRunReasonStepOOM.String(), 3*time.Minute,
+				step("fetch", 0, "Completed"), step("apply", 137, "OOMKilled"))},
+		}, {
+			PipelineTask: pipelineTask("test"),
+			TaskRuns: []*v1.TaskRun{taskRun("pr-test", corev1.ConditionFalse, v1.TaskRunReasonStepFailed.String(), 2*time.Minute,
+				step("lint", 1, "Continued"), step("unit", 1, "Error"), step("report", 0, "Skipped"))},
+		}, {
+			PipelineTask: &v1.PipelineTask{Name: "scan", TaskRef: &v1.TaskRef{Name: "task"}, OnError: v1.PipelineTaskContinue},
+			TaskRuns:     []*v1.TaskRun{taskRun("pr-scan", corev1.ConditionFalse, v1.TaskRunReasonFailed.String(), time.Minute)},
+		}, {
+			PipelineTask: pipelineTask("notify"),
+			TaskRuns:     []*v1.TaskRun{taskRun("pr-notify", corev1.ConditionFalse, v1.TaskRunReasonCancelled.String(), time.Minute)},
+		}},
+		want: []v1.PipelineRunFailureCause{{
+			PipelineTaskName:      "test",
+			Kind:                  "TaskRun",
+			Name:                  "pr-test",
+			Reason:                v1.TaskRunReasonStepFailed.String(),
+			StepName:              "unit",
+			StepTerminationReason: "Error",
+		}, {
+			PipelineTaskName:      "deploy",
+			Kind:                  "TaskRun",
+			Name:                  "pr-deploy",
+			Reason:                v1.TaskRunReasonStepOOM.String(),
+			StepName:              "apply",
+			StepTerminationReason: "OOMKilled",
+		}},
+	}, {
+		name: "at most five causes ordered by name when completed at the same time",
+		state: PipelineRunState{{
+			PipelineTask: pipelineTask("unit"),
+			TaskRuns: []*v1.TaskRun{
+				taskRun("pr-unit-2", corev1.ConditionFalse, v1.TaskRunReasonFailed.String(), time.Minute),
+				taskRun("pr-unit-1", corev1.ConditionFalse, v1.TaskRunReasonFailed.String(), time.Minute),
+				taskRun("pr-unit-0", corev1.ConditionTrue, v1.TaskRunReasonSuccessful.String(), time.Minute),
+			},
+		}, {
+			PipelineTask: pipelineTask("e2e"),
+			TaskRuns: []*v1.TaskRun{
+				taskRun("pr-e2e-0", corev1.ConditionFalse, v1.TaskRunReasonFailed.String(), time.Minute),
+				taskRun("pr-e2e-1", corev1.ConditionFalse, v1.TaskRunReasonFailed.String(), time.Minute),
+				taskRun("pr-e2e-2", corev1.ConditionFalse, v1.TaskRunReasonFailed.String(), time.Minute),
+			},
+		}, {
+			PipelineTask:       &v1.PipelineTask{Name: "approve", ApprovalGate: &v1.ApprovalGate{Approvers: []string{"alice"}}},
+			ApprovalGateStatus: &v1.ApprovalGateStatus{PipelineTaskName: "approve", Reason: v1.ApprovalGateReasonRejected, CompletionTime: &metav1.Time{Time: now}},
+		}},
+		want: []v1.PipelineRunFailureCause{
+			{PipelineTaskName: "approve", Reason: string(v1.ApprovalGateReasonRejected)},
+			{PipelineTaskName: "e2e", Kind: "TaskRun", Name: "pr-e2e-0", Reason: v1.TaskRunReasonFailed.String()},
+			{PipelineTaskName: "e2e", Kind: "TaskRun", Name: "pr-e2e-1", Reason: v1.TaskRunReasonFailed.String()},
+			{PipelineTaskName: "e2e", Kind: "TaskRun", Name: "pr-e2e-2", Reason: v1.TaskRunReasonFailed.String()},
+			{PipelineTaskName: "unit", Kind: "TaskRun", Name: "pr-unit-1", Reason: v1.TaskRunReasonFailed.String()},
+		},
+	}, {
+		name: "no failure",
+		state: PipelineRunState{{
+			PipelineTask: pipelineTask("build"),
+			TaskRuns:     []*v1.TaskRun{taskRun("pr-build", corev1.ConditionTrue, v1.TaskRunReasonSuccessful.String(), time.Minute)},
+		}},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			facts := PipelineRunFacts{State: tc.state}
+			if d := cmp.Diff(tc.want, facts.GetFailureCauses()); d != "" {
+				t.Errorf("GetFailureCauses() %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestPipelineRunFacts_GetLayers(t *testing.T) {
+	// A diamond: b and c run after a, and d after both b and c, with a finally task which never runs
+	dagTasks := []v1.PipelineTask{{
+		Name:    "a",
+		TaskRef: &v1.TaskRef{Name: "task"},
+	}, {
+		Name:     "b",
+		TaskRef:  &v1.TaskRef{Name: "task"},
+		RunAfter: []string{"a"},
+	}, {
+		Name:     "c",
+		TaskRef:  &v1.TaskRef{Name: "task"},
+		RunAfter: []string{"a"},
+	}, {
+		Name:     "d",
+		TaskRef:  &v1.TaskRef{Name: "task"},
+		RunAfter: []string{"b", "c"},
+	}}
+	finallyTasks := []v1.PipelineTask{{
+		Name:    "f",
+		TaskRef: &v1.TaskRef{Name: "task"},
+	}}
+	d, err := dag.Build(v1.PipelineTaskList(dagTasks), v1.PipelineTaskList(dagTasks).Deps())
+	if err != nil {
+		t.Fatalf("Unexpected error while building graph for DAG tasks %v: %v", dagTasks, err)
+	}
+	df, err := dag.Build(v1.PipelineTaskList(finallyTasks), map[string][]string{})
+	if err != nil {
+		t.Fatalf("Unexpected error while building graph for final tasks %v: %v", finallyTasks, err)
+	}
+
+	// Each reconcile finds the runs of the PipelineTasks in the given states
+	for _, tc := range []struct {
+		name      string
+		started   []string
+		succeeded []string
+		want      *v1.PipelineRunLayers
+	}{{
+		name: "no task started",
+		want: &v1.PipelineRunLayers{Total: 3},
+	}, {
+		name:    "first layer running",
+		started: []string{"a"},
+		want:    &v1.PipelineRunLayers{Total: 3},
+	}, {
+		name:      "first layer done",
+		started:   []string{"b", "c"},
+		succeeded: []string{"a"},
+		want:      &v1.PipelineRunLayers{Total: 3, Completed: 1},
+	}, {
+		name:      "second layer partially done",
+		started:   []string{"c"},
+		succeeded: []string{"a", "b"},
+		want:      &v1.PipelineRunLayers{Total: 3, Completed: 1},
+	}, {
+		name:      "second layer done",
+		started:   []string{"d"},
+		succeeded: []string{"a", "b", "c"},
+		want:      &v1.PipelineRunLayers{Total: 3, Completed: 2},
+	}, {
+		name:      "all layers done while finally is running",
+		started:   []string{"f"},
+		succeeded: []string{"a", "b", "c", "d"},
+		want:      &v1.PipelineRunLayers{Total: 3, Completed: 3},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			var state PipelineRunState
+			for _, pt := range append(append([]v1.PipelineTask{}, dagTasks...), finallyTasks...) {
+				rpt := &ResolvedPipelineTask{
+					PipelineTask: &pt,
+					ResolvedTask: &resources.ResolvedTask{TaskSpec: &task.Spec},
+				}
+				tr := v1.TaskRun{
+					ObjectMeta: metav1.ObjectMeta{Name: "pr-" + pt.Name},
+					Status: v1.TaskRunStatus{
+						Status: duckv1.Status{Conditions: duckv1.Conditions{{Type: apis.ConditionSucceeded}}},
+					},
+				}
+				switch {
+				case slices.Contains(tc.succeeded, pt.Name):
+					rpt.TaskRuns = []*v1.TaskRun{makeSucceeded(tr)}
+				case slices.Contains(tc.started, pt.Name):
+					rpt.TaskRuns = []*v1.TaskRun{makeStarted(tr)}
+				}
+				state = append(state, rpt)
+			}
+			facts := PipelineRunFacts{
+				State:           state,
+				TasksGraph:      d,
+				FinalTasksGraph: df,
+				TimeoutsState: PipelineRunTimeoutsState{
+					Clock: testClock,
+				},
+			}
+			if d := cmp.Diff(tc.want, facts.GetLayers()); d != "" {
+				t.Errorf("GetLayers() %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+
+	t.Run("empty pipeline", func(t *testing.T) {
+		facts := PipelineRunFacts{TasksGraph: &dag.Graph{}, FinalTasksGraph: &dag.Graph{}}
+		if got := facts.GetLayers(); got != nil {
+			t.Errorf("GetLayers() = %v, want nil", got)
+		}
+	})
+}
+
 func TestPipelineRunFacts_IsRunning(t *testing.T) {
 	for _, tc := range []struct {
 		name     string
@@ -36,6 +36,30 @@ func ValidatePipelineTaskResults(state PipelineRunState) error {
 				return pipelineErrors.WrapUserError(fmt.Errorf("invalid result reference in pipeline task %q: %w", rpt.PipelineTask.Name, err))
 			}
 		}
+		if err := validateStringResultRefs(rpt.PipelineTask.RetriesFrom, ptMap); err != nil {
+			return pipelineErrors.WrapUserError(fmt.Errorf("invalid retriesFrom in pipeline task %q: %w", rpt.PipelineTask.Name, err))
+		}
+		if err := validateStringResultRefs(rpt.PipelineTask.TimeoutFrom, ptMap); err != nil {
+			return pipelineErrors.WrapUserError(fmt.Errorf("invalid timeoutFrom in pipeline task %q: %w", rpt.PipelineTask.Name, err))
+		}
+	}
+	return nil
+}
+
+// validateStringResultRefs ensures that the results referenced by value, which is used as a
+// single value such as the retries or the timeout of a PipelineTask, are string results.
+// The existence of the results is checked by validateResultRef.
+func validateStringResultRefs(value string, ptMap map[string]*ResolvedPipelineTask) error {
+	for _, ref := range v1.NewResultRefsFromString(value) {
+		rpt, ok := ptMap[ref.PipelineTask]
+		if !ok || rpt.CustomTask || rpt.ResolvedTask == nil || rpt.ResolvedTask.TaskSpec == nil {
+			continue
+		}
+		for _, taskResult := range rpt.ResolvedTask.TaskSpec.Results {
+			if taskResult.Name == ref.Result && taskResult.Type != "" && taskResult.Type != v1.ResultsTypeString {
+				return fmt.Errorf("%q is a result of type %q of pipeline task %q but must be a string result", ref.Result, taskResult.Type, ref.PipelineTask)
+			}
+		}
 	}
 	return nil
 }
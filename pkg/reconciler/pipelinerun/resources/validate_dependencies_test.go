@@ -235,6 +235,58 @@ func TestValidatePipelineTaskResults_IncorrectResultName(t *testing.T) {
 	}
 }
 
+// TestValidatePipelineTaskResults_RetriesAndTimeoutFromNonStringResult tests that the retries
+// and the timeout of a PipelineTask can't be taken from results which aren't strings.
+func TestValidatePipelineTaskResults_RetriesAndTimeoutFromNonStringResult(t *testing.T) {
+	pt1 := &prresources.ResolvedPipelineTask{
+		PipelineTask: &v1.PipelineTask{
+			Name: "pt1",
+		},
+		ResolvedTask: &resources.ResolvedTask{
+			TaskName: "t",
+			TaskSpec: &v1.TaskSpec{
+				Results: []v1.TaskResult{{
+					Name: "timeout",
+				}, {
+					Name: "retries",
+					Type: v1.ResultsTypeArray,
+				}},
+			},
+		},
+	}
+	for _, tc := range []struct {
+		desc        string
+		pt          *v1.PipelineTask
+		expectedErr string
+	}{{
+		desc: "string result in timeoutFrom",
+		pt: &v1.PipelineTask{
+			Name:        "pt2",
+			TimeoutFrom: "$(tasks.pt1.results.timeout)",
+		},
+	}, {
+		desc: "array result in retriesFrom",
+		pt: &v1.PipelineTask{
+			Name:        "pt2",
+			RetriesFrom: "$(tasks.pt1.results.retries)",
+		},
+		expectedErr: `invalid retriesFrom in pipeline task "pt2": "retries" is a result of type "array" of pipeline task "pt1" but must be a string result`,
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := prresources.ValidatePipelineTaskResults(prresources.PipelineRunState{pt1, {PipelineTask: tc.pt}})
+			if tc.expectedErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.expectedErr) {
+				t.Errorf("expected error %q but got: %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
 // TestValidatePipelineTaskResults_MissingTaskSpec tests that a malformed PipelineTask
 // with a name but no spec results in a validation error being returned.
 func TestValidatePipelineTaskResults_MissingTaskSpec(t *testing.T) {
@@ -18,6 +18,7 @@ package resources
 
 import (
 	"fmt"
+	"strings"
 
 	pipelineErrors "github.com/tektoncd/pipeline/pkg/apis/pipeline/errors"
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
@@ -53,7 +54,8 @@ func ValidateParamTypesMatching(p *v1.PipelineSpec, pr *v1.PipelineRun) error {
 
 // ValidateRequiredParametersProvided validates that all the parameters expected by the Pipeline are provided by the PipelineRun.
 // Extra Parameters are allowed, the Pipeline will use the Parameters it needs and ignore the other Parameters.
-func ValidateRequiredParametersProvided(pipelineParameters *v1.ParamSpecs, pipelineRunParameters *v1.Params) error {
+// The error names the tasks and finally tasks consuming each missing parameter.
+func ValidateRequiredParametersProvided(pipelineSpec *v1.PipelineSpec, pipelineRunParameters *v1.Params) error {
 	// Build a list of parameter names declared in pr.
 	var providedParams []string
 	for _, param := range *pipelineRunParameters {
@@ -61,7 +63,7 @@ func ValidateRequiredParametersProvided(pipelineParameters *v1.ParamSpecs, pipel
 	}
 
 	var requiredParams []string
-	for _, param := range *pipelineParameters {
+	for _, param := range pipelineSpec.Params {
 		if param.Default == nil { // include only parameters that don't have default values specified in the Pipeline
 			requiredParams = append(requiredParams, param.Name)
 		}
@@ -72,11 +74,45 @@ func ValidateRequiredParametersProvided(pipelineParameters *v1.ParamSpecs, pipel
 
 	// Return an error with the missing parameters' names, or return nil if there are none.
 	if len(missingParams) != 0 {
+		var consumers []string
+		for _, name := range missingParams {
+			if c := paramConsumers(pipelineSpec, name); c != "" {
+				consumers = append(consumers, c)
+			}
+		}
+		if len(consumers) != 0 {
+			return pipelineErrors.WrapUserError(fmt.Errorf("pipelineRun missing parameters: %s: %s", missingParams, strings.Join(consumers, ", ")))
+		}
 		return pipelineErrors.WrapUserError(fmt.Errorf("pipelineRun missing parameters: %s", missingParams))
 	}
 	return nil
 }
 
+// paramConsumers describes the tasks and finally tasks of the Pipeline referencing the parameter,
+// or returns an empty string if none of them references it.
+func paramConsumers(pipelineSpec *v1.PipelineSpec, name string) string {
+	var tasks, finallyTasks []string
+	for _, pt := range pipelineSpec.Tasks {
+		if pt.ParamNames().Has(name) {
+			tasks = append(tasks, pt.Name)
+		}
+	}
+	for _, pt := range pipelineSpec.Finally {
+		if pt.ParamNames().Has(name) {
+			finallyTasks = append(finallyTasks, pt.Name)
+		}
+	}
+	switch {
+	case len(tasks) != 0 && len(finallyTasks) != 0:
+		return fmt.Sprintf("%q is used by tasks %s and finally tasks %s", name, tasks, finallyTasks)
+	case len(tasks) != 0:
+		return fmt.Sprintf("%q is used by tasks %s", name, tasks)
+	case len(finallyTasks) != 0:
+		return fmt.Sprintf("%q is only used by finally tasks %s", name, finallyTasks)
+	}
+	return ""
+}
+
 // ValidateObjectParamRequiredKeys validates that the required keys of all the object parameters expected by the Pipeline are provided by the PipelineRun.
 func ValidateObjectParamRequiredKeys(pipelineParameters []v1.ParamSpec, pipelineRunParameters []v1.Param) error {
 	missings := taskrun.MissingKeysObjectParamNames(pipelineParameters, pipelineRunParameters)
@@ -149,7 +149,7 @@ func TestValidateRequiredParametersProvided_Valid(t *testing.T) {
 		},
 	}} {
 		t.Run(tc.name, func(t *testing.T) {
-			if err := resources.ValidateRequiredParametersProvided(&tc.pp, &tc.prp); err != nil {
+			if err := resources.ValidateRequiredParametersProvided(&v1.PipelineSpec{Params: tc.pp}, &tc.prp); err != nil {
 				t.Errorf("Didn't expect to see error when validating valid PipelineRun parameters but got: %v", err)
 			}
 		})
@@ -183,13 +183,80 @@ func TestValidateRequiredParametersProvided_Invalid(t *testing.T) {
 		},
 	}} {
 		t.Run(tc.name, func(t *testing.T) {
-			if err := resources.ValidateRequiredParametersProvided(&tc.pp, &tc.prp); err == nil {
+			if err := resources.ValidateRequiredParametersProvided(&v1.PipelineSpec{Params: tc.pp}, &tc.prp); err == nil {
 				t.Errorf("Expected to see error when validating invalid PipelineRun parameters but saw none")
 			}
 		})
 	}
 }
 
+func TestValidateRequiredParametersProvided_Consumers(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		ps      v1.PipelineSpec
+		wantErr string
+	}{{
+		name: "param consumed by no task",
+		ps: v1.PipelineSpec{
+			Params: v1.ParamSpecs{{Name: "unused", Type: v1.ParamTypeString}},
+			Tasks:  []v1.PipelineTask{{Name: "build", TaskRef: &v1.TaskRef{Name: "build"}}},
+		},
+		wantErr: "pipelineRun missing parameters: [unused]",
+	}, {
+		name: "param only consumed by finally tasks",
+		ps: v1.PipelineSpec{
+			Params: v1.ParamSpecs{{Name: "notify-url", Type: v1.ParamTypeString}},
+			Tasks:  []v1.PipelineTask{{Name: "build", TaskRef: &v1.TaskRef{Name: "build"}}},
+			Finally: []v1.PipelineTask{{
+				Name:    "notify",
+				TaskRef: &v1.TaskRef{Name: "notify"},
+				Params:  v1.Params{{Name: "url", Value: *v1.NewStructuredValues("$(params.notify-url)")}},
+			}},
+		},
+		wantErr: `pipelineRun missing parameters: [notify-url]: "notify-url" is only used by finally tasks [notify]`,
+	}, {
+		name: "params consumed by tasks and finally tasks",
+		ps: v1.PipelineSpec{
+			Params: v1.ParamSpecs{
+				{Name: "revision", Type: v1.ParamTypeString},
+				{Name: "images", Type: v1.ParamTypeArray},
+			},
+			Tasks: []v1.PipelineTask{{
+				Name:    "build",
+				TaskRef: &v1.TaskRef{Name: "build"},
+				Matrix:  &v1.Matrix{Params: v1.Params{{Name: "image", Value: *v1.NewStructuredValues("$(params.images[*])")}}},
+			}, {
+				Name: "test",
+				TaskSpec: &v1.EmbeddedTask{TaskSpec: v1.TaskSpec{
+					Steps: []v1.Step{{Name: "test", Image: "busybox", Script: "test $(params.revision)"}},
+				}},
+			}, {
+				Name: "lint",
+				TaskSpec: &v1.EmbeddedTask{TaskSpec: v1.TaskSpec{
+					Params: v1.ParamSpecs{{Name: "revision", Type: v1.ParamTypeString}},
+					Steps:  []v1.Step{{Name: "lint", Image: "busybox", Script: "lint $(params.revision)"}},
+				}},
+			}},
+			Finally: []v1.PipelineTask{{
+				Name:    "report",
+				TaskRef: &v1.TaskRef{Name: "report"},
+				When:    v1.WhenExpressions{{Input: "$(params.revision)", Operator: selection.NotIn, Values: []string{"main"}}},
+			}},
+		},
+		wantErr: `pipelineRun missing parameters: [revision images]: "revision" is used by tasks [test] and finally tasks [report], "images" is used by tasks [build]`,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := resources.ValidateRequiredParametersProvided(&tc.ps, &v1.Params{})
+			if err == nil {
+				t.Fatalf("Expected to see error when validating invalid PipelineRun parameters but saw none")
+			}
+			if d := cmp.Diff(tc.wantErr, err.Error()); d != "" {
+				t.Errorf("Unexpected error message %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 func TestValidateObjectParamRequiredKeys_Invalid(t *testing.T) {
 	for _, tc := range []struct {
 		name string
@@ -0,0 +1,95 @@
+/*
+Copyright 2026 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipelinerun
+
+import (
+	"errors"
+	"fmt"
+
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"knative.dev/pkg/apis"
+)
+
+// errRunResultUnavailable indicates that the result of another PipelineRun a param is taken from
+// can't be used, either because that PipelineRun does not exist, has not completed, did not
+// succeed or did not produce the result.
+var errRunResultUnavailable = errors.New("referenced PipelineRun result is unavailable")
+
+// resolveRunRefParams returns a copy of the params of the PipelineRun with the values of the ones that
+// are taken from a result of another PipelineRun, and records where the values came from in the
+// provenance of the PipelineRun. The spec of the PipelineRun is left untouched. The results are only
+// looked up once: later reconciles use the values recorded in the provenance so that the params don't
+// change, nor fail, while the PipelineRun is running.
+func (c *Reconciler) resolveRunRefParams(pr *v1.PipelineRun) (v1.Params, error) {
+	params := pr.Spec.Params.DeepCopy()
+	for i, p := range params {
+		if p.RunRef == nil {
+			continue
+		}
+		if source := getParamSource(pr, p.Name); source != nil {
+			params[i].Value = source.Value
+			continue
+		}
+
+		referenced, err := c.pipelineRunLister.PipelineRuns(pr.Namespace).Get(p.RunRef.Name)
+		switch {
+		case k8serrors.IsNotFound(err):
+			return nil, fmt.Errorf("%w: param %q refers to PipelineRun %q which does not exist", errRunResultUnavailable, p.Name, p.RunRef.Name)
+		case err != nil:
+			return nil, fmt.Errorf("failed to get PipelineRun %q referenced by param %q: %w", p.RunRef.Name, p.Name, err)
+		}
+		if !referenced.IsDone() {
+			return nil, fmt.Errorf("%w: param %q refers to PipelineRun %q which has not completed", errRunResultUnavailable, p.Name, p.RunRef.Name)
+		}
+		if !referenced.Status.GetCondition(apis.ConditionSucceeded).IsTrue() && !p.RunRef.AllowFailed {
+			return nil, fmt.Errorf("%w: param %q refers to PipelineRun %q which did not succeed", errRunResultUnavailable, p.Name, p.RunRef.Name)
+		}
+		var value *v1.ResultValue
+		for _, r := range referenced.Status.Results {
+			if r.Name == p.RunRef.Result {
+				value = &r.Value
+				break
+			}
+		}
+		if value == nil {
+			return nil, fmt.Errorf("%w: param %q refers to result %q which PipelineRun %q did not produce", errRunResultUnavailable, p.Name, p.RunRef.Result, p.RunRef.Name)
+		}
+
+		params[i].Value = *value.DeepCopy()
+		if pr.Status.Provenance == nil {
+			pr.Status.Provenance = &v1.Provenance{}
+		}
+		pr.Status.Provenance.ParamSources = append(pr.Status.Provenance.ParamSources, v1.ParamSource{
+			Param:       p.Name,
+			PipelineRun: referenced.Name,
+			UID:         referenced.UID,
+			Result:      p.RunRef.Result,
+			Value:       *value.DeepCopy(),
+		})
+	}
+	return params, nil
+}
+
+func getParamSource(pr *v1.PipelineRun, name string) *v1.ParamSource {
+	if pr.Status.Provenance == nil {
+		return nil
+	}
+	for i := range pr.Status.Provenance.ParamSources {
+		if pr.Status.Provenance.ParamSources[i].Param == name {
+			return &pr.Status.Provenance.ParamSources[i]
+		}
+	}
+	return nil
+}
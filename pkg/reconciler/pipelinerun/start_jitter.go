@@ -0,0 +1,46 @@
+/*
+Copyright 2026 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipelinerun
+
+import (
+	"context"
+	"hash/fnv"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"k8s.io/utils/clock"
+)
+
+// delayedStartTime returns the time at which a PipelineRun that has not started yet can start,
+// and true if that time has not been reached. The start of PipelineRuns is delayed to spread the
+// load of PipelineRuns created at the same time, as configured by "default-start-jitter".
+// PipelineRuns with a high priority, or whose spec status is set, are not delayed.
+func delayedStartTime(ctx context.Context, pr *v1.PipelineRun, c clock.PassiveClock) (time.Time, bool) {
+	jitter := config.FromContextOrDefaults(ctx).Defaults.DefaultStartJitter
+	if jitter <= 0 || pr.Spec.Status != "" || pr.Annotations[v1.PipelineRunPriorityAnnotation] == v1.PipelineRunPriorityHigh {
+		return time.Time{}, false
+	}
+	startTime := pr.CreationTimestamp.Add(jitterDelay(pr, jitter))
+	return startTime, c.Now().Before(startTime)
+}
+
+// jitterDelay returns the delay in [0, jitter) after its creation the PipelineRun starts at.
+// It is derived from the identity of the PipelineRun rather than drawn at random so that
+// it stays the same across reconciles and controller restarts without being stored.
+func jitterDelay(pr *v1.PipelineRun, jitter time.Duration) time.Duration {
+	h := fnv.New64a()
+	h.Write([]byte(string(pr.UID) + "/" + pr.Namespace + "/" + pr.Name))
+	return time.Duration(h.Sum64() % uint64(jitter))
+}
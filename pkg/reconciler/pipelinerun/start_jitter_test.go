@@ -0,0 +1,137 @@
+/*
+Copyright 2026 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipelinerun
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	clock "k8s.io/utils/clock/testing"
+)
+
+func TestJitterDelayBounds(t *testing.T) {
+	const (
+		jitter  = 30 * time.Second
+		runs    = 1000
+		buckets = 10
+	)
+	counts := make([]int, buckets)
+	for i := range runs {
+		pr := &v1.PipelineRun{ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("run-%d", i),
+			Namespace: "foo",
+			UID:       types.UID(fmt.Sprintf("uid-%d", i)),
+		}}
+		delay := jitterDelay(pr, jitter)
+		if delay < 0 || delay >= jitter {
+			t.Fatalf("jitterDelay() = %s for %s, want a delay in [0, %s)", delay, pr.Name, jitter)
+		}
+		if again := jitterDelay(pr, jitter); again != delay {
+			t.Fatalf("jitterDelay() = %s then %s for %s, want the same delay", delay, again, pr.Name)
+		}
+		counts[int(delay*buckets/jitter)]++
+	}
+	// Delays should be spread over the whole jitter rather than bunched together.
+	for i, count := range counts {
+		if count < runs/buckets/2 {
+			t.Errorf("only %d of %d delays in [%s, %s), want them spread evenly", count, runs, jitter*time.Duration(i)/buckets, jitter*time.Duration(i+1)/buckets)
+		}
+	}
+}
+
+func TestDelayedStartTime(t *testing.T) {
+	created := time.Date(2022, time.January, 1, 0, 0, 0, 0, time.UTC)
+	newPipelineRun := func(annotations map[string]string, status v1.PipelineRunSpecStatus) *v1.PipelineRun {
+		return &v1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "run",
+				Namespace:         "foo",
+				UID:               "uid",
+				CreationTimestamp: metav1.Time{Time: created},
+				Annotations:       annotations,
+			},
+			Spec: v1.PipelineRunSpec{Status: status},
+		}
+	}
+	jitter := time.Minute
+	delay := jitterDelay(newPipelineRun(nil, ""), jitter)
+	if delay == 0 {
+		t.Fatal("expected the test PipelineRun to be delayed")
+	}
+
+	for _, tc := range []struct {
+		name          string
+		jitter        time.Duration
+		pr            *v1.PipelineRun
+		now           time.Time
+		wantDelayed   bool
+		wantStartTime time.Time
+	}{{
+		name:        "jitter disabled",
+		pr:          newPipelineRun(nil, ""),
+		now:         created,
+		wantDelayed: false,
+	}, {
+		name:          "delayed",
+		jitter:        jitter,
+		pr:            newPipelineRun(nil, ""),
+		now:           created,
+		wantDelayed:   true,
+		wantStartTime: created.Add(delay),
+	}, {
+		name:          "delay almost over",
+		jitter:        jitter,
+		pr:            newPipelineRun(nil, ""),
+		now:           created.Add(delay - time.Millisecond),
+		wantDelayed:   true,
+		wantStartTime: created.Add(delay),
+	}, {
+		name:          "delay over",
+		jitter:        jitter,
+		pr:            newPipelineRun(nil, ""),
+		now:           created.Add(delay),
+		wantDelayed:   false,
+		wantStartTime: created.Add(delay),
+	}, {
+		name:        "high priority",
+		jitter:      jitter,
+		pr:          newPipelineRun(map[string]string{v1.PipelineRunPriorityAnnotation: v1.PipelineRunPriorityHigh}, ""),
+		now:         created,
+		wantDelayed: false,
+	}, {
+		name:        "cancelled",
+		jitter:      jitter,
+		pr:          newPipelineRun(nil, v1.PipelineRunSpecStatusCancelled),
+		now:         created,
+		wantDelayed: false,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := config.ToContext(t.Context(), &config.Config{
+				Defaults: &config.Defaults{DefaultStartJitter: tc.jitter},
+			})
+			startTime, delayed := delayedStartTime(ctx, tc.pr, clock.NewFakePassiveClock(tc.now))
+			if delayed != tc.wantDelayed {
+				t.Errorf("delayedStartTime() delayed = %t, want %t", delayed, tc.wantDelayed)
+			}
+			if !startTime.Equal(tc.wantStartTime) {
+				t.Errorf("delayedStartTime() start time = %s, want %s", startTime, tc.wantStartTime)
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipelinerun
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/pkg/apis"
+)
+
+// statusUpdateLimiter limits the status updates of each PipelineRun to one per window, keyed by
+// PipelineRun, for the updates which are only driven by the progress of its children.
+type statusUpdateLimiter struct {
+	mu          sync.Mutex
+	lastUpdates map[types.NamespacedName]time.Time
+}
+
+func newStatusUpdateLimiter() *statusUpdateLimiter {
+	return &statusUpdateLimiter{lastUpdates: map[types.NamespacedName]time.Time{}}
+}
+
+// wait returns how long the next status update of the PipelineRun must wait for the window since
+// its last status update to elapse.
+func (l *statusUpdateLimiter) wait(key types.NamespacedName, now time.Time, window time.Duration) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	last, ok := l.lastUpdates[key]
+	if !ok {
+		return 0
+	}
+	return max(last.Add(window).Sub(now), 0)
+}
+
+// updated records that the status of the PipelineRun is updated.
+func (l *statusUpdateLimiter) updated(key types.NamespacedName, now time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.lastUpdates[key] = now
+}
+
+// forget drops the last status update of the PipelineRun, once it is done.
+func (l *statusUpdateLimiter) forget(key types.NamespacedName) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.lastUpdates, key)
+}
+
+// coalesceStatusUpdate decides whether the status update of the reconciled PipelineRun pr, from the
+// status of original, is coalesced with the next ones. The updates which only report the progress of
+// the children of the PipelineRun, in the message of its Succeeded condition, are coalesced within
+// the pipelinerun-status-update-window: the status of pr is reset to the status of original, so that
+// it isn't updated, and the PipelineRun must be requeued after the returned time to update it.
+// Any other change of the status, such as a transition of the Succeeded condition or new children,
+// is updated right away. As the progress is computed again from the children on every reconcile,
+// no update is lost when the controller restarts.
+func (c *Reconciler) coalesceStatusUpdate(ctx context.Context, original, pr *v1.PipelineRun) (time.Duration, bool) {
+	if c.statusUpdates == nil {
+		return 0, false
+	}
+	key := pr.GetNamespacedName()
+	if pr.IsDone() {
+		c.statusUpdates.forget(key)
+		return 0, false
+	}
+	if equality.Semantic.DeepEqual(original.Status, pr.Status) {
+		return 0, false
+	}
+	now := c.Clock.Now()
+	window := config.FromContextOrDefaults(ctx).Defaults.PipelineRunStatusUpdateWindow
+	if window > 0 && onlyProgressChanged(original.Status, pr.Status) {
+		if wait := c.statusUpdates.wait(key, now, window); wait > 0 {
+			pr.Status = *original.Status.DeepCopy()
+			return wait, true
+		}
+	}
+	c.statusUpdates.updated(key, now)
+	return 0, false
+}
+
+// onlyProgressChanged returns true if the only change from the status before to the status after is
+// the message of the Succeeded condition, which reports the progress of the children.
+func onlyProgressChanged(before, after v1.PipelineRunStatus) bool {
+	beforeCondition := before.GetCondition(apis.ConditionSucceeded)
+	afterCondition := after.GetCondition(apis.ConditionSucceeded)
+	if beforeCondition == nil || afterCondition == nil ||
+		beforeCondition.Status != afterCondition.Status || beforeCondition.Reason != afterCondition.Reason {
+		return false
+	}
+	masked := after
+	masked.Conditions = slices.Clone(after.Conditions)
+	for i := range masked.Conditions {
+		if masked.Conditions[i].Type == apis.ConditionSucceeded {
+			masked.Conditions[i].Message = beforeCondition.Message
+			masked.Conditions[i].LastTransitionTime = beforeCondition.LastTransitionTime
+		}
+	}
+	return equality.Semantic.DeepEqual(before, masked)
+}
@@ -0,0 +1,190 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipelinerun
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clock "k8s.io/utils/clock/testing"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+func statusUpdateWindowContext(window time.Duration) context.Context {
+	return config.ToContext(context.Background(), &config.Config{
+		Defaults: &config.Defaults{PipelineRunStatusUpdateWindow: window},
+	})
+}
+
+func progressStatus(status corev1.ConditionStatus, reason, message string, children int) v1.PipelineRunStatus {
+	s := v1.PipelineRunStatus{
+		Status: duckv1.Status{Conditions: duckv1.Conditions{{
+			Type:    apis.ConditionSucceeded,
+			Status:  status,
+			Reason:  reason,
+			Message: message,
+		}}},
+	}
+	for i := range children {
+		s.ChildReferences = append(s.ChildReferences, v1.ChildStatusReference{
+			TypeMeta:         runtime.TypeMeta{APIVersion: "tekton.dev/v1", Kind: "TaskRun"},
+			Name:             fmt.Sprintf("matrixed-pr-platforms-%d", i),
+			PipelineTaskName: "platforms",
+		})
+	}
+	return s
+}
+
+// TestCoalesceStatusUpdate_Burst counts the status updates of a PipelineRun whose 50 matrixed
+// children complete in a burst, each completion reconciling the PipelineRun.
+func TestCoalesceStatusUpdate_Burst(t *testing.T) {
+	const children = 50
+	for _, tc := range []struct {
+		name        string
+		window      time.Duration
+		wantUpdates int
+	}{{
+		name:   "coalesced within the window",
+		window: time.Second,
+		// The first completion and the completion of the PipelineRun.
+		wantUpdates: 2,
+	}, {
+		name:        "not coalesced",
+		window:      0,
+		wantUpdates: children,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeClock := clock.NewFakePassiveClock(now)
+			c := &Reconciler{Clock: fakeClock, statusUpdates: newStatusUpdateLimiter()}
+			ctx := statusUpdateWindowContext(tc.window)
+
+			stored := &v1.PipelineRun{
+				ObjectMeta: metav1.ObjectMeta{Name: "matrixed-pr", Namespace: "foo"},
+				Status:     progressStatus(corev1.ConditionUnknown, v1.PipelineRunReasonRunning.String(), "Tasks Completed: 0 (Failed: 0, Cancelled 0), Skipped: 0", children),
+			}
+			updates := 0
+			for i := 1; i <= children; i++ {
+				fakeClock.SetTime(fakeClock.Now().Add(10 * time.Millisecond))
+				pr := stored.DeepCopy()
+				message := fmt.Sprintf("Tasks Completed: %d (Failed: 0, Cancelled 0), Skipped: 0", i)
+				if i == children {
+					pr.Status = progressStatus(corev1.ConditionTrue, v1.PipelineRunReasonSuccessful.String(), message, children)
+				} else {
+					pr.Status = progressStatus(corev1.ConditionUnknown, v1.PipelineRunReasonRunning.String(), message, children)
+				}
+
+				if _, coalesced := c.coalesceStatusUpdate(ctx, stored, pr); coalesced {
+					if !equality.Semantic.DeepEqual(stored.Status, pr.Status) {
+						t.Fatalf("completion %d: the status of a coalesced update was changed", i)
+					}
+					continue
+				}
+				if !equality.Semantic.DeepEqual(stored.Status, pr.Status) {
+					updates++
+				}
+				stored = pr
+			}
+			if updates != tc.wantUpdates {
+				t.Errorf("%d status updates for %d completions, want %d", updates, children, tc.wantUpdates)
+			}
+			if !stored.IsDone() {
+				t.Error("the completion of the PipelineRun was not updated")
+			}
+		})
+	}
+}
+
+func TestCoalesceStatusUpdate_FlushedAfterWindow(t *testing.T) {
+	fakeClock := clock.NewFakePassiveClock(now)
+	c := &Reconciler{Clock: fakeClock, statusUpdates: newStatusUpdateLimiter()}
+	ctx := statusUpdateWindowContext(time.Second)
+	running := v1.PipelineRunReasonRunning.String()
+
+	stored := &v1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "matrixed-pr", Namespace: "foo"},
+		Status:     progressStatus(corev1.ConditionUnknown, running, "Tasks Completed: 0", 3),
+	}
+	pr := stored.DeepCopy()
+	pr.Status = progressStatus(corev1.ConditionUnknown, running, "Tasks Completed: 1", 3)
+	if _, coalesced := c.coalesceStatusUpdate(ctx, stored, pr); coalesced {
+		t.Fatal("the first status update was coalesced")
+	}
+	stored = pr
+
+	fakeClock.SetTime(now.Add(300 * time.Millisecond))
+	pr = stored.DeepCopy()
+	pr.Status = progressStatus(corev1.ConditionUnknown, running, "Tasks Completed: 2", 3)
+	wait, coalesced := c.coalesceStatusUpdate(ctx, stored, pr)
+	if !coalesced {
+		t.Fatal("the status update within the window was not coalesced")
+	}
+	if wait != 700*time.Millisecond {
+		t.Errorf("requeued after %s, want 700ms", wait)
+	}
+	if got := pr.Status.GetCondition(apis.ConditionSucceeded).Message; got != "Tasks Completed: 1" {
+		t.Errorf("the status of the coalesced update has the message %q, want the stored one", got)
+	}
+
+	// The PipelineRun requeued after the window reports the progress of the children again.
+	fakeClock.SetTime(now.Add(time.Second))
+	pr = stored.DeepCopy()
+	pr.Status = progressStatus(corev1.ConditionUnknown, running, "Tasks Completed: 2", 3)
+	if _, coalesced := c.coalesceStatusUpdate(ctx, stored, pr); coalesced {
+		t.Error("the status update after the window was coalesced")
+	}
+}
+
+func TestOnlyProgressChanged(t *testing.T) {
+	running := v1.PipelineRunReasonRunning.String()
+	before := progressStatus(corev1.ConditionUnknown, running, "Tasks Completed: 1", 2)
+	for _, tc := range []struct {
+		name  string
+		after v1.PipelineRunStatus
+		want  bool
+	}{{
+		name:  "progress message",
+		after: progressStatus(corev1.ConditionUnknown, running, "Tasks Completed: 2", 2),
+		want:  true,
+	}, {
+		name:  "condition transition",
+		after: progressStatus(corev1.ConditionFalse, v1.PipelineRunReasonFailed.String(), "Tasks Completed: 2", 2),
+	}, {
+		name:  "reason",
+		after: progressStatus(corev1.ConditionUnknown, v1.PipelineRunReasonStopping.String(), "Tasks Completed: 2", 2),
+	}, {
+		name:  "new children",
+		after: progressStatus(corev1.ConditionUnknown, running, "Tasks Completed: 2", 3),
+	}, {
+		name:  "no condition",
+		after: v1.PipelineRunStatus{},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := onlyProgressChanged(before, tc.after); got != tc.want {
+				t.Errorf("onlyProgressChanged() = %t, want %t", got, tc.want)
+			}
+		})
+	}
+}
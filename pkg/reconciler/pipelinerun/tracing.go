@@ -21,12 +21,15 @@ import (
 	"encoding/json"
 	"errors"
 
+	"github.com/tektoncd/pipeline/pkg/apis/config"
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/tracing"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/trace"
+	"knative.dev/pkg/apis"
 	"knative.dev/pkg/logging"
 )
 
@@ -118,3 +121,28 @@ func recordSpanError(span trace.Span, err error) {
 		span.RecordError(err)
 	}
 }
+
+// recordRunSpans records the spans of the PipelineRun, of its TaskRuns and of their steps when the
+// PipelineRun completed during the reconcile, if the export of the spans of the runs is enabled.
+func (c *Reconciler) recordRunSpans(ctx context.Context, pr *v1.PipelineRun, beforeCondition *apis.Condition) {
+	if cfg := config.FromContextOrDefaults(ctx).Tracing; cfg == nil || !cfg.ExportRunSpans {
+		return
+	}
+	if !pr.IsDone() || (beforeCondition != nil && !beforeCondition.IsUnknown()) {
+		return
+	}
+	logger := logging.FromContext(ctx)
+	var taskRuns []*v1.TaskRun
+	for _, cr := range pr.Status.ChildReferences {
+		if cr.Kind != taskRun {
+			continue
+		}
+		tr, err := c.taskRunLister.TaskRuns(pr.Namespace).Get(cr.Name)
+		if err != nil {
+			logger.Warnf("Failed to get TaskRun %s to record its spans: %v", cr.Name, err)
+			continue
+		}
+		taskRuns = append(taskRuns, tr)
+	}
+	tracing.RecordPipelineRunSpans(ctx, c.tracerProvider.Tracer(TracerName), pr, taskRuns, c.Clock.Now())
+}
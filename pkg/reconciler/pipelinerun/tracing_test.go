@@ -15,13 +15,24 @@ package pipelinerun
 
 import (
 	"testing"
+	"time"
 
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	ttesting "github.com/tektoncd/pipeline/pkg/reconciler/testing"
+	"github.com/tektoncd/pipeline/test"
+	"github.com/tektoncd/pipeline/test/diff"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/propagation"
 	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 	"go.opentelemetry.io/otel/trace"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
 )
 
 func TestInitTracing(t *testing.T) {
@@ -117,3 +128,105 @@ func TestInitTracing(t *testing.T) {
 		})
 	}
 }
+
+func TestRecordRunSpans(t *testing.T) {
+	start := metav1.NewTime(time.Date(2026, time.March, 1, 10, 0, 0, 0, time.UTC))
+	completion := metav1.NewTime(start.Add(10 * time.Minute))
+	done := duckv1.Status{Conditions: duckv1.Conditions{{Type: apis.ConditionSucceeded, Status: corev1.ConditionTrue, Reason: "Succeeded"}}}
+	taskRuns := []*v1.TaskRun{{
+		ObjectMeta: metav1.ObjectMeta{Name: "release-build", Namespace: "ns"},
+		Status: v1.TaskRunStatus{
+			Status: done,
+			TaskRunStatusFields: v1.TaskRunStatusFields{
+				StartTime:      &start,
+				CompletionTime: &completion,
+				Steps: []v1.StepState{{Name: "compile", ContainerState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+					StartedAt: start, FinishedAt: completion,
+				}}}},
+			},
+		},
+	}, {
+		ObjectMeta: metav1.ObjectMeta{Name: "release-test", Namespace: "ns"},
+		Status: v1.TaskRunStatus{
+			Status:              done,
+			TaskRunStatusFields: v1.TaskRunStatusFields{StartTime: &start, CompletionTime: &completion},
+		},
+	}}
+	pr := &v1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "release", Namespace: "ns"},
+		Status: v1.PipelineRunStatus{
+			Status: done,
+			PipelineRunStatusFields: v1.PipelineRunStatusFields{
+				StartTime:      &start,
+				CompletionTime: &completion,
+				ChildReferences: []v1.ChildStatusReference{{
+					TypeMeta: runtime.TypeMeta{Kind: taskRun}, Name: "release-build", PipelineTaskName: "build",
+				}, {
+					TypeMeta: runtime.TypeMeta{Kind: taskRun}, Name: "release-test", PipelineTaskName: "test",
+				}, {
+					TypeMeta: runtime.TypeMeta{Kind: customRun}, Name: "release-approve", PipelineTaskName: "approve",
+				}},
+			},
+		},
+	}
+	running := &apis.Condition{Type: apis.ConditionSucceeded, Status: corev1.ConditionUnknown}
+
+	for _, tc := range []struct {
+		name           string
+		exportRunSpans bool
+		before         *apis.Condition
+		want           map[string]string
+	}{{
+		name:           "PipelineRun completed during the reconcile",
+		exportRunSpans: true,
+		before:         running,
+		want: map[string]string{
+			"PipelineRun:Run": "",
+			"TaskRun:Run":     "PipelineRun:Run",
+			"Step:Run":        "TaskRun:Run",
+		},
+	}, {
+		name:           "PipelineRun completed before the reconcile",
+		exportRunSpans: true,
+		before:         done.GetCondition(apis.ConditionSucceeded),
+		want:           map[string]string{},
+	}, {
+		name:   "run spans not exported",
+		before: running,
+		want:   map[string]string{},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, _ := ttesting.SetupFakeContext(t)
+			_, informers := test.SeedTestData(t, ctx, test.Data{TaskRuns: taskRuns})
+			ctx = config.ToContext(ctx, &config.Config{Tracing: &config.Tracing{ExportRunSpans: tc.exportRunSpans}})
+			exporter := tracetest.NewInMemoryExporter()
+			c := &Reconciler{
+				Clock:          testClock,
+				taskRunLister:  informers.TaskRun.Lister(),
+				tracerProvider: tracesdk.NewTracerProvider(tracesdk.WithSyncer(exporter)),
+			}
+
+			c.recordRunSpans(ctx, pr, tc.before)
+
+			spans := exporter.GetSpans()
+			names := map[trace.SpanID]string{}
+			for _, s := range spans {
+				names[s.SpanContext.SpanID()] = s.Name
+			}
+			got := map[string]string{}
+			taskRunSpans := 0
+			for _, s := range spans {
+				got[s.Name] = names[s.Parent.SpanID()]
+				if s.Name == "TaskRun:Run" {
+					taskRunSpans++
+				}
+			}
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("recorded spans and their parent %s", diff.PrintWantGot(d))
+			}
+			if len(tc.want) > 0 && taskRunSpans != len(taskRuns) {
+				t.Errorf("recorded %d TaskRun spans, want %d", taskRunSpans, len(taskRuns))
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipelinerun
+
+import (
+	"context"
+	"fmt"
+
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	tknreconciler "github.com/tektoncd/pipeline/pkg/reconciler"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+)
+
+// deleteAfterTTL deletes the done PipelineRun pr once its ttlSecondsAfterFinished elapsed since it completed,
+// and requeues it until then. Its TaskRuns are left to the garbage collection.
+func (c *Reconciler) deleteAfterTTL(ctx context.Context, pr *v1.PipelineRun) error {
+	wait, ok := tknreconciler.TimeUntilTTLExpires(ctx, pr, pr.Spec.TTLSecondsAfterFinished, pr.Status.CompletionTime, c.Clock.Now())
+	switch {
+	case !ok:
+		return nil
+	case wait > 0:
+		return controller.NewRequeueAfter(wait)
+	}
+	logging.FromContext(ctx).Infof("Deleting PipelineRun %s/%s as its ttlSecondsAfterFinished elapsed", pr.Namespace, pr.Name)
+	err := c.PipelineClientSet.TektonV1().PipelineRuns(pr.Namespace).Delete(ctx, pr.Name, metav1.DeleteOptions{
+		Preconditions: &metav1.Preconditions{UID: &pr.UID},
+	})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete PipelineRun %s after its ttlSecondsAfterFinished: %w", pr.Name, err)
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipelinerun
+
+import (
+	"testing"
+	"time"
+
+	cfgtesting "github.com/tektoncd/pipeline/pkg/apis/config/testing"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/client/clientset/versioned/fake"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"knative.dev/pkg/controller"
+)
+
+func TestDeleteAfterTTL(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		ttl         *int32
+		defaultTTL  string
+		completed   time.Duration
+		annotations map[string]string
+		wantDeleted bool
+		wantRequeue time.Duration
+	}{{
+		name:      "no ttl",
+		completed: time.Hour,
+	}, {
+		name:        "ttl not elapsed",
+		ttl:         ptr.To(int32(3600)),
+		completed:   10 * time.Minute,
+		wantRequeue: 50 * time.Minute,
+	}, {
+		name:        "ttl elapsed",
+		ttl:         ptr.To(int32(3600)),
+		completed:   time.Hour,
+		wantDeleted: true,
+	}, {
+		name:        "default ttl elapsed",
+		defaultTTL:  "60",
+		completed:   time.Minute,
+		wantDeleted: true,
+	}, {
+		name:        "kept",
+		ttl:         ptr.To(int32(0)),
+		completed:   time.Hour,
+		annotations: map[string]string{pipeline.KeepAnnotation: "true"},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			pr := &v1.PipelineRun{
+				ObjectMeta: metav1.ObjectMeta{Name: "pr", Namespace: "ns", Annotations: tc.annotations},
+				Spec:       v1.PipelineRunSpec{TTLSecondsAfterFinished: tc.ttl},
+				Status: v1.PipelineRunStatus{PipelineRunStatusFields: v1.PipelineRunStatusFields{
+					CompletionTime: &metav1.Time{Time: testClock.Now().Add(-tc.completed)},
+				}},
+			}
+			client := fake.NewSimpleClientset(pr)
+			c := &Reconciler{PipelineClientSet: client, Clock: testClock}
+			ctx := t.Context()
+			if tc.defaultTTL != "" {
+				ctx = cfgtesting.SetDefaults(ctx, t, map[string]string{"default-ttl-seconds-after-finished": tc.defaultTTL})
+			}
+
+			err := c.deleteAfterTTL(ctx, pr)
+			if isRequeue, requeueAfter := controller.IsRequeueKey(err); isRequeue != (tc.wantRequeue > 0) || requeueAfter != tc.wantRequeue {
+				t.Errorf("Expected to be requeued after %s, got %v", tc.wantRequeue, err)
+			} else if !isRequeue && err != nil {
+				t.Fatalf("deleteAfterTTL: %v", err)
+			}
+			_, err = client.TektonV1().PipelineRuns("ns").Get(ctx, "pr", metav1.GetOptions{})
+			if deleted := k8serrors.IsNotFound(err); deleted != tc.wantDeleted {
+				t.Errorf("Expected the PipelineRun to be deleted: %t, got %t", tc.wantDeleted, deleted)
+			}
+		})
+	}
+}
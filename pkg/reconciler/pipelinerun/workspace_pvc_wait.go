@@ -0,0 +1,110 @@
+/*
+Copyright 2026 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipelinerun
+
+import (
+	"context"
+	"time"
+
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/internal/affinityassistant"
+	"github.com/tektoncd/pipeline/pkg/reconciler/volumeclaim"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/kmeta"
+	"knative.dev/pkg/logging"
+)
+
+// pendingWorkspacePVC is a PVC created from the volumeClaimTemplate of a workspace which is not bound yet.
+type pendingWorkspacePVC struct {
+	workspace string
+	claimName string
+	wait      time.Duration
+}
+
+// observeWorkspacePVCWaits records in the timing of the status of pr how long the PVCs created from the
+// volumeClaimTemplates of its workspaces waited to be bound, as observed in the PVC informer cache, so
+// that no request is sent to the API server. It returns the PVC which has been waiting the longest
+// without being bound, or nil if all of the PVCs observed are bound.
+func (c *Reconciler) observeWorkspacePVCWaits(ctx context.Context, pr *v1.PipelineRun) *pendingWorkspacePVC {
+	if c.pvcLister == nil {
+		return nil
+	}
+	logger := logging.FromContext(ctx)
+	aaBehavior, err := affinityassistant.GetRunAffinityAssistantBehavior(ctx, pr.Status.Coschedule)
+	if err != nil {
+		return nil
+	}
+
+	recorded := map[string]v1.WorkspacePVCWait{}
+	if pr.Status.Timing != nil {
+		for _, w := range pr.Status.Timing.WorkspacePVCWaits {
+			recorded[w.Workspace] = w
+		}
+	}
+
+	var waits []v1.WorkspacePVCWait
+	var worst *pendingWorkspacePVC
+	now := c.Clock.Now()
+	for _, wb := range pr.Spec.Workspaces {
+		if wb.VolumeClaimTemplate == nil {
+			continue
+		}
+		if w, ok := recorded[wb.Name]; ok && w.Duration != nil {
+			waits = append(waits, w)
+			continue
+		}
+		pvc, err := c.pvcLister.PersistentVolumeClaims(pr.Namespace).Get(pvcNameForWorkspace(pr, wb, aaBehavior))
+		if err != nil {
+			// The PVC is not created yet, or not in the informer cache yet
+			continue
+		}
+		w := v1.WorkspacePVCWait{
+			Workspace: wb.Name,
+			ClaimName: pvc.Name,
+		}
+		if pvc.Spec.StorageClassName != nil {
+			w.StorageClassName = *pvc.Spec.StorageClassName
+		}
+		wait := now.Sub(pvc.CreationTimestamp.Time)
+		if pvc.Status.Phase == corev1.ClaimBound {
+			w.Duration = &metav1.Duration{Duration: wait}
+			if c.metrics != nil {
+				if err := c.metrics.WorkspacePVCBound(ctx, w.StorageClassName, wait); err != nil {
+					logger.Warnf("Failed to log the metrics : %v", err)
+				}
+			}
+		} else if worst == nil || wait > worst.wait {
+			worst = &pendingWorkspacePVC{workspace: wb.Name, claimName: pvc.Name, wait: wait}
+		}
+		waits = append(waits, w)
+	}
+
+	if len(waits) > 0 {
+		pr.Status.Timing = &v1.PipelineRunTiming{WorkspacePVCWaits: waits}
+	}
+	return worst
+}
+
+// pvcNameForWorkspace returns the name of the PVC created from the volumeClaimTemplate of wb,
+// either by the PipelineRun reconciler or by the StatefulSet of its Affinity Assistant.
+func pvcNameForWorkspace(pr *v1.PipelineRun, wb v1.WorkspaceBinding, aaBehavior affinityassistant.AffinityAssistantBehavior) string {
+	owner := *kmeta.NewControllerRef(pr)
+	switch aaBehavior {
+	case affinityassistant.AffinityAssistantPerPipelineRun, affinityassistant.AffinityAssistantPerPipelineRunWithIsolation:
+		return getPersistentVolumeClaimNameWithAffinityAssistant("", pr.Name, wb, owner)
+	default:
+		return volumeclaim.PVCName(wb, owner)
+	}
+}
@@ -0,0 +1,147 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconciler
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"k8s.io/utils/clock"
+	"knative.dev/pkg/logging"
+)
+
+// The phases of the reconciles of PipelineRuns and TaskRuns which are timed.
+const (
+	// ReconcilePhaseResolution is the resolution of the Pipelines, Tasks and StepActions referenced by the run.
+	ReconcilePhaseResolution = "resolution"
+	// ReconcilePhaseValidation is the validation of the run against its resolved spec.
+	ReconcilePhaseValidation = "validation"
+	// ReconcilePhaseResources is the creation, update and deletion of the pods, StatefulSets, PVCs and
+	// child runs of the run.
+	ReconcilePhaseResources = "resources"
+	// ReconcilePhaseStatusComputation is the aggregation of the status of the run from its pod or children,
+	// and the update of its labels and annotations. It doesn't include the update of the status, written by
+	// the generated reconciler once the reconcile returned and its phases were recorded.
+	ReconcilePhaseStatusComputation = "status_computation"
+)
+
+// ReconcilePhaseRecorder records the time spent in a phase of a reconcile.
+type ReconcilePhaseRecorder interface {
+	ReconcilePhaseDuration(ctx context.Context, phase string, duration time.Duration) error
+}
+
+type reconcilePhasesKey struct{}
+
+// ReconcilePhases accumulates the time spent in each phase of a reconcile. A phase may be entered
+// several times during a reconcile, e.g. to resolve the Tasks of a Pipeline after the Pipeline.
+type ReconcilePhases struct {
+	clock clock.PassiveClock
+	start time.Time
+
+	mu        sync.Mutex
+	durations map[string]time.Duration
+	running   map[*phaseTimer]struct{}
+}
+
+type phaseTimer struct {
+	phase string
+	start time.Time
+}
+
+// WithReconcilePhases returns ctx with a ReconcilePhases, starting now, timing the phases of the
+// reconcile of ctx.
+func WithReconcilePhases(ctx context.Context, c clock.PassiveClock) (context.Context, *ReconcilePhases) {
+	p := &ReconcilePhases{
+		clock:     c,
+		start:     c.Now(),
+		durations: map[string]time.Duration{},
+		running:   map[*phaseTimer]struct{}{},
+	}
+	return context.WithValue(ctx, reconcilePhasesKey{}, p), p
+}
+
+// StartReconcilePhase starts timing the phase of the reconcile of ctx and returns the function
+// ending it. Phases which aren't ended when the reconcile is recorded, e.g. because the reconcile
+// returned early on an error, end then. It is a no-op if ctx doesn't time the phases of a reconcile.
+func StartReconcilePhase(ctx context.Context, phase string) func() {
+	p, ok := ctx.Value(reconcilePhasesKey{}).(*ReconcilePhases)
+	if !ok {
+		return func() {}
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	t := &phaseTimer{phase: phase, start: p.clock.Now()}
+	p.running[t] = struct{}{}
+	return func() {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		p.end(t, p.clock.Now())
+	}
+}
+
+// end adds the time spent in the phase of t until now, unless it already ended.
+func (p *ReconcilePhases) end(t *phaseTimer, now time.Time) {
+	if _, ok := p.running[t]; !ok {
+		return
+	}
+	delete(p.running, t)
+	p.durations[t.phase] += now.Sub(t.start)
+}
+
+// Record ends the reconcile, records the time spent in each of its phases with recorder and, when
+// the reconcile took longer than the metrics.reconcile.slow-threshold, logs a warning listing them.
+func (p *ReconcilePhases) Record(ctx context.Context, recorder ReconcilePhaseRecorder) {
+	logger := logging.FromContext(ctx)
+	p.mu.Lock()
+	now := p.clock.Now()
+	for t := range p.running {
+		p.end(t, now)
+	}
+	phases := make([]string, 0, len(p.durations))
+	for phase := range p.durations {
+		phases = append(phases, phase)
+	}
+	slices.Sort(phases)
+	durations := make(map[string]time.Duration, len(phases))
+	var timed time.Duration
+	for _, phase := range phases {
+		durations[phase] = p.durations[phase]
+		timed += p.durations[phase]
+	}
+	p.mu.Unlock()
+
+	for _, phase := range phases {
+		if err := recorder.ReconcilePhaseDuration(ctx, phase, durations[phase]); err != nil {
+			logger.Warnf("Failed to record the duration of reconcile phase %s: %v", phase, err)
+		}
+	}
+
+	total := now.Sub(p.start)
+	metrics := config.FromContextOrDefaults(ctx).Metrics
+	if metrics == nil || metrics.SlowReconcileThreshold <= 0 || total <= metrics.SlowReconcileThreshold {
+		return
+	}
+	keysAndValues := []any{"duration", total.String(), "threshold", metrics.SlowReconcileThreshold.String()}
+	for _, phase := range phases {
+		keysAndValues = append(keysAndValues, "phase."+phase, durations[phase].String())
+	}
+	keysAndValues = append(keysAndValues, "phase.other", max(total-timed, 0).String())
+	logger.Warnw("Slow reconcile", keysAndValues...)
+}
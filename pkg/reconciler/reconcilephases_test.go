@@ -0,0 +1,132 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconciler_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	reconciler "github.com/tektoncd/pipeline/pkg/reconciler"
+	"github.com/tektoncd/pipeline/test/diff"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	clocktesting "k8s.io/utils/clock/testing"
+	"knative.dev/pkg/logging"
+)
+
+type fakeReconcilePhaseRecorder struct {
+	durations map[string]time.Duration
+}
+
+func (r *fakeReconcilePhaseRecorder) ReconcilePhaseDuration(_ context.Context, phase string, duration time.Duration) error {
+	r.durations[phase] += duration
+	return nil
+}
+
+// reconcileWithPhases times a reconcile spending 1s resolving, twice, 2s validating, returning
+// before the end of the validation, and 4s outside of any phase.
+func reconcileWithPhases(ctx context.Context, clock *clocktesting.FakePassiveClock) *reconciler.ReconcilePhases {
+	ctx, phases := reconciler.WithReconcilePhases(ctx, clock)
+	for range 2 {
+		endResolution := reconciler.StartReconcilePhase(ctx, reconciler.ReconcilePhaseResolution)
+		clock.SetTime(clock.Now().Add(500 * time.Millisecond))
+		endResolution()
+		// Ending a phase again doesn't count the time since it ended.
+		clock.SetTime(clock.Now().Add(time.Second))
+		endResolution()
+	}
+	clock.SetTime(clock.Now().Add(2 * time.Second))
+	reconciler.StartReconcilePhase(ctx, reconciler.ReconcilePhaseValidation)
+	clock.SetTime(clock.Now().Add(2 * time.Second))
+	return phases
+}
+
+func TestReconcilePhases(t *testing.T) {
+	clock := clocktesting.NewFakePassiveClock(time.Now())
+	recorder := &fakeReconcilePhaseRecorder{durations: map[string]time.Duration{}}
+	ctx := t.Context()
+	reconcileWithPhases(ctx, clock).Record(ctx, recorder)
+
+	want := map[string]time.Duration{
+		reconciler.ReconcilePhaseResolution: time.Second,
+		reconciler.ReconcilePhaseValidation: 2 * time.Second,
+	}
+	if d := cmp.Diff(want, recorder.durations); d != "" {
+		t.Errorf("recorded phase durations %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestReconcilePhases_NotTimed(t *testing.T) {
+	// Phases of reconciles which aren't timed are ignored.
+	reconciler.StartReconcilePhase(t.Context(), reconciler.ReconcilePhaseResources)()
+}
+
+func TestReconcilePhases_SlowReconcileLog(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		threshold time.Duration
+		wantLog   map[string]any
+	}{{
+		name:      "slow reconcile",
+		threshold: 5 * time.Second,
+		wantLog: map[string]any{
+			"msg":              "Slow reconcile",
+			"duration":         "7s",
+			"threshold":        "5s",
+			"phase.resolution": "1s",
+			"phase.validation": "2s",
+			"phase.other":      "4s",
+		},
+	}, {
+		name:      "reconcile under the threshold",
+		threshold: 10 * time.Second,
+	}, {
+		name: "no threshold",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			encoderConfig := zap.NewProductionEncoderConfig()
+			encoderConfig.TimeKey, encoderConfig.LevelKey, encoderConfig.CallerKey = "", "", ""
+			logger := zap.New(zapcore.NewCore(zapcore.NewJSONEncoder(encoderConfig), zapcore.AddSync(&buf), zap.WarnLevel)).Sugar()
+			ctx := logging.WithLogger(t.Context(), logger)
+			ctx = config.ToContext(ctx, &config.Config{Metrics: &config.Metrics{SlowReconcileThreshold: tc.threshold}})
+
+			clock := clocktesting.NewFakePassiveClock(time.Now())
+			recorder := &fakeReconcilePhaseRecorder{durations: map[string]time.Duration{}}
+			reconcileWithPhases(ctx, clock).Record(ctx, recorder)
+
+			if tc.wantLog == nil {
+				if buf.Len() != 0 {
+					t.Errorf("logged %s, want nothing", buf.String())
+				}
+				return
+			}
+			var got map[string]any
+			if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+				t.Fatalf("failed to parse the log %q: %v", buf.String(), err)
+			}
+			if d := cmp.Diff(tc.wantLog, got); d != "" {
+				t.Errorf("slow reconcile log %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
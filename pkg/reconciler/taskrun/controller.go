@@ -18,6 +18,7 @@ package taskrun
 
 import (
 	"context"
+	"slices"
 
 	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
@@ -29,20 +30,26 @@ import (
 	resolutionclient "github.com/tektoncd/pipeline/pkg/client/resolution/injection/client"
 	resolutioninformer "github.com/tektoncd/pipeline/pkg/client/resolution/injection/informers/resolution/v1beta1/resolutionrequest"
 	"github.com/tektoncd/pipeline/pkg/pod"
+	"github.com/tektoncd/pipeline/pkg/reconciler/namespaceconfigmap"
 	"github.com/tektoncd/pipeline/pkg/reconciler/volumeclaim"
 	resolution "github.com/tektoncd/pipeline/pkg/remoteresolution/resource"
 	"github.com/tektoncd/pipeline/pkg/spire"
 	"github.com/tektoncd/pipeline/pkg/taskrunmetrics"
 	"github.com/tektoncd/pipeline/pkg/tracing"
+	"github.com/tektoncd/pipeline/pkg/workspace"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/utils/clock"
 	kubeclient "knative.dev/pkg/client/injection/kube/client"
 	limitrangeinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/limitrange"
+	namespaceinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/namespace"
 	filteredpodinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/pod/filtered"
 	secretinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/secret"
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/controller"
 	"knative.dev/pkg/logging"
+	pkgreconciler "knative.dev/pkg/reconciler"
 )
 
 const (
@@ -98,6 +105,9 @@ func NewController(opts *pipeline.Options, clock clock.PassiveClock) func(contex
 			spireClient:              spireClient,
 			taskRunLister:            taskRunInformer.Lister(),
 			limitrangeLister:         limitrangeInformer.Lister(),
+			namespaceLister:          namespaceinformer.Get(ctx).Lister(),
+			configMapLister:          namespaceconfigmap.Get(ctx, pod.NamespaceDefaultsConfigMapName).Lister(),
+			namespaceFeatureFlags:    config.NewNamespaceFeatureFlags(namespaceconfigmap.Get(ctx, config.NamespaceFeatureFlagsConfigMapName).Lister()),
 			verificationPolicyLister: verificationpolicyInformer.Lister(),
 			metrics:                  taskrunmetricsRecorder,
 			entrypointCache:          entrypointCache,
@@ -105,6 +115,8 @@ func NewController(opts *pipeline.Options, clock clock.PassiveClock) func(contex
 			pvcHandler:               volumeclaim.NewPVCHandler(kubeclientset, logger),
 			resolutionRequester:      resolution.NewCRDRequester(resolutionclient.Get(ctx), resolutionInformer.Lister()),
 			tracerProvider:           tracerProvider,
+
+			affinityAssistantPodLister: filteredpodinformer.Get(ctx, workspace.AffinityAssistantPodSelector).Lister(),
 		}
 		impl := taskrunreconciler.NewImpl(ctx, c, func(impl *controller.Impl) controller.Options {
 			return controller.Options{
@@ -125,6 +137,21 @@ func NewController(opts *pipeline.Options, clock clock.PassiveClock) func(contex
 			logging.FromContext(ctx).Panicf("Couldn't register TaskRun informer event handler: %w", err)
 		}
 
+		// Deleted TaskRuns aren't reconciled, they aren't counted as throttled anymore as they are deleted.
+		if _, err := taskRunInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+			FilterFunc: taskRunFilterManagedBy,
+			Handler: cache.ResourceEventHandlerFuncs{
+				UpdateFunc: func(_, obj any) {
+					if tr, ok := obj.(*v1.TaskRun); ok && tr.DeletionTimestamp != nil {
+						c.taskRunDeleted(ctx, tr)
+					}
+				},
+				DeleteFunc: func(obj any) { c.taskRunDeleted(ctx, obj) },
+			},
+		}); err != nil {
+			logging.FromContext(ctx).Panicf("Couldn't register TaskRun informer event handler: %w", err)
+		}
+
 		if _, err := podInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
 			FilterFunc: controller.FilterController(&v1.TaskRun{}),
 			Handler:    controller.HandleAll(impl.EnqueueControllerOf),
@@ -135,3 +162,101 @@ func NewController(opts *pipeline.Options, clock clock.PassiveClock) func(contex
 		return impl
 	}
 }
+
+// NewResultsFinalizerController instantiates the controller.Impl removing the ResultsExtractionFinalizer
+// from the pods being deleted whose TaskRun is gone or done, and from the pods of deleted TaskRuns.
+func NewResultsFinalizerController() func(context.Context, configmap.Watcher) *controller.Impl {
+	return func(ctx context.Context, cmw configmap.Watcher) *controller.Impl {
+		logger := logging.FromContext(ctx)
+		taskRunInformer := taskruninformer.Get(ctx)
+		podInformer := filteredpodinformer.Get(ctx, v1.ManagedByLabelKey)
+
+		r := &resultsFinalizerReconciler{
+			LeaderAwareFuncs: pkgreconciler.LeaderAwareFuncs{
+				PromoteFunc: func(bkt pkgreconciler.Bucket, enq func(pkgreconciler.Bucket, types.NamespacedName)) error {
+					pods, err := podInformer.Lister().List(labels.Everything())
+					if err != nil {
+						return err
+					}
+					for _, p := range pods {
+						if p.DeletionTimestamp != nil && slices.Contains(p.Finalizers, pipeline.ResultsExtractionFinalizer) {
+							enq(bkt, types.NamespacedName{Namespace: p.Namespace, Name: p.Name})
+						}
+					}
+					return nil
+				},
+			},
+			c: &Reconciler{
+				KubeClientSet: kubeclient.Get(ctx),
+				taskRunLister: taskRunInformer.Lister(),
+				podLister:     podInformer.Lister(),
+			},
+		}
+		impl := controller.NewContext(ctx, r, controller.ControllerOptions{
+			WorkQueueName: "TaskRunResultsFinalizer",
+			Logger:        logger,
+		})
+
+		if _, err := podInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+			FilterFunc: controller.FilterController(&v1.TaskRun{}),
+			Handler: cache.ResourceEventHandlerFuncs{
+				AddFunc:    enqueueDeletedPod(impl),
+				UpdateFunc: func(_, obj any) { enqueueDeletedPod(impl)(obj) },
+			},
+		}); err != nil {
+			logging.FromContext(ctx).Panicf("Couldn't register Pod informer event handler: %w", err)
+		}
+
+		if _, err := taskRunInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+			FilterFunc: taskRunFilterManagedBy,
+			Handler: cache.ResourceEventHandlerFuncs{
+				UpdateFunc: func(_, obj any) {
+					if tr, ok := obj.(*v1.TaskRun); ok && tr.DeletionTimestamp != nil {
+						enqueuePodOfDeletedTaskRun(impl)(tr)
+					}
+				},
+				DeleteFunc: enqueuePodOfDeletedTaskRun(impl),
+			},
+		}); err != nil {
+			logging.FromContext(ctx).Panicf("Couldn't register TaskRun informer event handler: %w", err)
+		}
+
+		return impl
+	}
+}
+
+// NewFinalStatusController instantiates the controller.Impl recording the final status of the TaskRuns
+// deleted before they completed while they hold the FinalStatusFinalizer, and then removing the finalizer.
+func NewFinalStatusController(clock clock.PassiveClock) func(context.Context, configmap.Watcher) *controller.Impl {
+	return func(ctx context.Context, cmw configmap.Watcher) *controller.Impl {
+		logger := logging.FromContext(ctx)
+		taskRunInformer := taskruninformer.Get(ctx)
+		configStore := config.NewStore(logger.Named("config-store"))
+		configStore.WatchConfigs(cmw)
+
+		r := &finalStatusReconciler{c: &Reconciler{
+			PipelineClientSet: pipelineclient.Get(ctx),
+			Clock:             clock,
+			metrics:           taskrunmetrics.Get(ctx),
+			tracerProvider:    tracing.New(TracerProviderName, logger.Named("tracing")),
+		}}
+		impl := taskrunreconciler.NewImpl(ctx, r, func(impl *controller.Impl) controller.Options {
+			return controller.Options{
+				AgentName:         pipeline.TaskRunControllerName,
+				ConfigStore:       configStore,
+				FinalizerName:     pipeline.FinalStatusFinalizer,
+				SkipStatusUpdates: true,
+				PromoteFilterFunc: deletedWithFinalStatusFinalizer,
+			}
+		})
+
+		if _, err := taskRunInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+			FilterFunc: deletedWithFinalStatusFinalizer,
+			Handler:    controller.HandleAll(impl.Enqueue),
+		}); err != nil {
+			logging.FromContext(ctx).Panicf("Couldn't register TaskRun informer event handler: %w", err)
+		}
+
+		return impl
+	}
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package taskrun
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	taskrunreconciler "github.com/tektoncd/pipeline/pkg/client/injection/reconciler/pipeline/v1/taskrun"
+	tknreconciler "github.com/tektoncd/pipeline/pkg/reconciler"
+	"github.com/tektoncd/pipeline/pkg/reconciler/events"
+	"github.com/tektoncd/pipeline/pkg/reconciler/events/cloudevent"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+	pkgreconciler "knative.dev/pkg/reconciler"
+)
+
+// syncFinalStatusFinalizer adds the FinalStatusFinalizer to tr while it runs when the
+// "enable-final-status-finalizer" feature flag is on, and removes it once tr is done.
+func (c *Reconciler) syncFinalStatusFinalizer(ctx context.Context, tr *v1.TaskRun) error {
+	add := config.FromContextOrDefaults(ctx).FeatureFlags.EnableFinalStatusFinalizer && !tr.IsDone()
+	return c.patchFinalStatusFinalizer(ctx, tr, add)
+}
+
+// patchFinalStatusFinalizer adds the FinalStatusFinalizer to tr, or removes it, if needed.
+func (c *Reconciler) patchFinalStatusFinalizer(ctx context.Context, tr *v1.TaskRun, add bool) error {
+	patch, ok, err := tknreconciler.FinalStatusFinalizerPatch(tr.Finalizers, add)
+	if !ok {
+		return err
+	}
+	updated, err := c.PipelineClientSet.TektonV1().TaskRuns(tr.Namespace).Patch(ctx, tr.Name, types.JSONPatchType, patch, metav1.PatchOptions{})
+	switch {
+	case k8serrors.IsNotFound(err) && !add:
+		return nil
+	case err != nil:
+		return fmt.Errorf("failed to update the finalizer %s of TaskRun %s: %w", pipeline.FinalStatusFinalizer, tr.Name, err)
+	}
+	tr.Finalizers = updated.Finalizers
+	return nil
+}
+
+// finalStatusReconciler finalizes the TaskRuns deleted before they completed while they hold the
+// FinalStatusFinalizer, e.g. with their namespace. Only such TaskRuns are enqueued to it, so that the
+// generated reconciler it is run by never adds the finalizer itself: it is added by the Reconciler, when
+// the "enable-final-status-finalizer" feature flag is on, and removed by the generated reconciler once
+// FinalizeKind succeeds.
+type finalStatusReconciler struct {
+	c *Reconciler
+}
+
+var _ taskrunreconciler.Finalizer = (*finalStatusReconciler)(nil)
+
+// ReconcileKind does nothing, as only deleted TaskRuns are enqueued to the finalStatusReconciler.
+func (r *finalStatusReconciler) ReconcileKind(context.Context, *v1.TaskRun) pkgreconciler.Event {
+	return nil
+}
+
+// FinalizeKind marks the deleted TaskRun tr as cancelled if it isn't done, records its status and emits
+// its terminal events and metrics. It is retried until the FinalStatusDeadline, after which the finalizer
+// is removed regardless.
+func (r *finalStatusReconciler) FinalizeKind(ctx context.Context, tr *v1.TaskRun) pkgreconciler.Event {
+	logger := logging.FromContext(ctx)
+	switch {
+	case tknreconciler.FinalStatusDeadlineExceeded(tr, r.c.Clock.Now()):
+		logger.Warnf("The final status of deleted TaskRun %s/%s couldn't be recorded within %s, removing its finalizer %s",
+			tr.Namespace, tr.Name, tknreconciler.FinalStatusDeadline, pipeline.FinalStatusFinalizer)
+	case !tr.IsDone():
+		before := tr.Status.GetCondition(apis.ConditionSucceeded)
+		cancelled := tr.DeepCopy()
+		cancelled.Status.MarkResourceFailed(v1.TaskRunReasonCancelledDueToDeletion,
+			fmt.Errorf("TaskRun %q was cancelled as it was deleted before it completed", tr.Name))
+		updated, err := r.c.PipelineClientSet.TektonV1().TaskRuns(tr.Namespace).UpdateStatus(ctx, cancelled, metav1.UpdateOptions{})
+		if err != nil {
+			logger.Errorf("Failed to record the final status of deleted TaskRun %s/%s, retrying in %s: %v", tr.Namespace, tr.Name, tknreconciler.FinalStatusRetryDelay, err)
+			return controller.NewRequeueAfter(tknreconciler.FinalStatusRetryDelay)
+		}
+		// The events controller can't observe the status of a TaskRun which is about to disappear.
+		events.Emit(ctx, before, updated.Status.GetCondition(apis.ConditionSucceeded), updated)
+		cloudevent.EmitCloudEvents(ctx, updated)
+		r.c.durationAndCountMetrics(ctx, updated, before)
+		// The finalizer is then removed from the updated TaskRun
+		tr.ResourceVersion = updated.ResourceVersion
+	}
+	return nil
+}
+
+// deletedWithFinalStatusFinalizer returns whether obj is a TaskRun managed by this controller which was
+// deleted while it holds the FinalStatusFinalizer.
+func deletedWithFinalStatusFinalizer(obj any) bool {
+	tr, ok := obj.(*v1.TaskRun)
+	return ok && taskRunFilterManagedBy(obj) && tr.DeletionTimestamp != nil && slices.Contains(tr.Finalizers, pipeline.FinalStatusFinalizer)
+}
@@ -0,0 +1,164 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package taskrun
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/client/clientset/versioned/fake"
+	taskrunreconciler "github.com/tektoncd/pipeline/pkg/client/injection/reconciler/pipeline/v1/taskrun"
+	listers "github.com/tektoncd/pipeline/pkg/client/listers/pipeline/v1"
+	tknreconciler "github.com/tektoncd/pipeline/pkg/reconciler"
+	"github.com/tektoncd/pipeline/pkg/taskrunmetrics"
+	"github.com/tektoncd/pipeline/pkg/tracing"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ktesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/controller"
+	logtesting "knative.dev/pkg/logging/testing"
+	pkgreconciler "knative.dev/pkg/reconciler"
+)
+
+func finalStatusTaskRun(finalizers []string, deleted *metav1.Time, condition apis.Condition) *v1.TaskRun {
+	return &v1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "tr",
+			Namespace:         "ns",
+			Finalizers:        finalizers,
+			DeletionTimestamp: deleted,
+		},
+		Status: v1.TaskRunStatus{
+			Status: duckv1.Status{Conditions: duckv1.Conditions{condition}},
+			TaskRunStatusFields: v1.TaskRunStatusFields{
+				StartTime: &metav1.Time{Time: testClock.Now().Add(-time.Hour)},
+			},
+		},
+	}
+}
+
+// TestFinalStatusReconciler tests that the final status of the TaskRuns deleted before they completed is
+// recorded by the generated reconciler running the finalStatusReconciler before it removes their finalizer
+func TestFinalStatusReconciler(t *testing.T) {
+	running := apis.Condition{Type: apis.ConditionSucceeded, Status: corev1.ConditionUnknown, Reason: v1.TaskRunReasonRunning.String()}
+	succeeded := apis.Condition{Type: apis.ConditionSucceeded, Status: corev1.ConditionTrue, Reason: v1.TaskRunReasonSuccessful.String()}
+	finalizerUpdate := `Normal FinalizerUpdate Updated "tr" finalizers`
+	for _, tc := range []struct {
+		name             string
+		deleted          time.Time
+		condition        apis.Condition
+		failStatusUpdate bool
+		wantReason       string
+		wantFinalizers   []string
+		wantRequeue      bool
+		wantEvents       []string
+	}{{
+		name:       "running TaskRun",
+		deleted:    testClock.Now().Add(-time.Minute),
+		condition:  running,
+		wantReason: v1.TaskRunReasonCancelledDueToDeletion.String(),
+		wantEvents: []string{`Warning Failed TaskRun "tr" was cancelled as it was deleted before it completed`, finalizerUpdate},
+	}, {
+		name:       "done TaskRun",
+		deleted:    testClock.Now().Add(-time.Minute),
+		condition:  succeeded,
+		wantReason: v1.TaskRunReasonSuccessful.String(),
+		wantEvents: []string{finalizerUpdate},
+	}, {
+		name:             "status update failing",
+		deleted:          testClock.Now().Add(-time.Minute),
+		condition:        running,
+		failStatusUpdate: true,
+		wantReason:       v1.TaskRunReasonRunning.String(),
+		wantFinalizers:   []string{pipeline.FinalStatusFinalizer},
+		wantRequeue:      true,
+	}, {
+		name:             "deadline exceeded",
+		deleted:          testClock.Now().Add(-tknreconciler.FinalStatusDeadline),
+		condition:        running,
+		failStatusUpdate: true,
+		wantReason:       v1.TaskRunReasonRunning.String(),
+		wantEvents:       []string{finalizerUpdate},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			tr := finalStatusTaskRun([]string{pipeline.FinalStatusFinalizer}, &metav1.Time{Time: tc.deleted}, tc.condition)
+			client := fake.NewSimpleClientset(tr)
+			if tc.failStatusUpdate {
+				client.PrependReactor("update", "taskruns", func(action ktesting.Action) (bool, runtime.Object, error) {
+					return action.GetSubresource() == "status", nil, errors.New("etcdserver: request timed out")
+				})
+			}
+			indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+			if err := indexer.Add(tr); err != nil {
+				t.Fatal(err)
+			}
+			recorder := record.NewFakeRecorder(10)
+			ctx := controller.WithEventRecorder(t.Context(), recorder)
+			metrics, err := taskrunmetrics.NewRecorder(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			c := &Reconciler{
+				PipelineClientSet: client,
+				Clock:             testClock,
+				metrics:           metrics,
+				tracerProvider:    tracing.New("taskrun", logtesting.TestLogger(t)),
+			}
+			r := taskrunreconciler.NewReconciler(ctx, logtesting.TestLogger(t), client, listers.NewTaskRunLister(indexer), recorder, &finalStatusReconciler{c: c},
+				controller.Options{FinalizerName: pipeline.FinalStatusFinalizer, SkipStatusUpdates: true})
+			if err := r.(pkgreconciler.LeaderAware).Promote(pkgreconciler.UniversalBucket(), func(pkgreconciler.Bucket, types.NamespacedName) {}); err != nil {
+				t.Fatal(err)
+			}
+
+			err = r.Reconcile(ctx, "ns/tr")
+			if requeue, _ := controller.IsRequeueKey(err); requeue != tc.wantRequeue {
+				t.Errorf("Expected to be requeued: %t, got %v", tc.wantRequeue, err)
+			}
+
+			got, err := client.TektonV1().TaskRuns("ns").Get(ctx, "tr", metav1.GetOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if d := cmp.Diff(tc.wantFinalizers, got.Finalizers, cmpopts.EquateEmpty()); d != "" {
+				t.Errorf("unexpected finalizers %s", diff.PrintWantGot(d))
+			}
+			if reason := got.Status.GetCondition(apis.ConditionSucceeded).Reason; reason != tc.wantReason {
+				t.Errorf("Expected the reason %q, got %q", tc.wantReason, reason)
+			}
+			close(recorder.Events)
+			var gotEvents []string
+			for e := range recorder.Events {
+				gotEvents = append(gotEvents, e)
+			}
+			if d := cmp.Diff(tc.wantEvents, gotEvents); d != "" {
+				t.Errorf("unexpected events %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
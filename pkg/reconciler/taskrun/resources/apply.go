@@ -33,7 +33,6 @@ import (
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"github.com/tektoncd/pipeline/pkg/container"
 	"github.com/tektoncd/pipeline/pkg/internal/resultref"
-	"github.com/tektoncd/pipeline/pkg/pod"
 	"github.com/tektoncd/pipeline/pkg/substitution"
 	"github.com/tektoncd/pipeline/pkg/workspace"
 	corev1 "k8s.io/api/core/v1"
@@ -83,6 +82,11 @@ func applyStepActionParameters(step *v1.Step, spec *v1.TaskSpec, tr *v1.TaskRun,
 		stringR, arrayR, objectR := getTaskParameters(spec, tr, spec.Params...)
 		stepParams = stepParams.ReplaceVariables(stringR, arrayR, objectR)
 	}
+	// the object params are merged with their default once the task parameters are substituted
+	stepParams, err := mergeStepActionObjectParams(stepParams, defaults)
+	if err != nil {
+		return nil, err
+	}
 
 	// 2. step provided parameters
 	stepProvidedParams := make(map[string]v1.ParamValue)
@@ -388,9 +392,26 @@ func getTaskParameters(spec *v1.TaskSpec, tr *v1.TaskRun, defaults ...v1.ParamSp
 		}
 	}
 
+	// The params declared with asFile are referenced by the path of their file, or directory for objects
+	for k, v := range replacementsFromAsFileParams(defaults) {
+		stringReplacements[k] = v
+	}
+
 	return stringReplacements, arrayReplacements, objectReplacements
 }
 
+// replacementsFromAsFileParams returns the replacements of $(params.<name>.path) by the path where the
+// value of each param declared with asFile is placed.
+func replacementsFromAsFileParams(defaults []v1.ParamSpec) map[string]string {
+	stringReplacements := map[string]string{}
+	for _, p := range defaults {
+		if p.AsFile {
+			stringReplacements[fmt.Sprintf(objectIndividualVariablePattern, p.Name, "path")] = filepath.Join(pipeline.ParamsDir, p.Name)
+		}
+	}
+	return stringReplacements
+}
+
 // ApplyParameters applies the params from a TaskRun.Parameters to a TaskSpec
 func ApplyParameters(spec *v1.TaskSpec, tr *v1.TaskRun, defaults ...v1.ParamSpec) *v1.TaskSpec {
 	stringReplacements, arrayReplacements, objectReplacements := getTaskParameters(spec, tr, defaults...)
@@ -593,20 +614,26 @@ func applyWorkspaceMountPath(variable string, spec *v1.TaskSpec, declaration v1.
 	return ApplyReplacements(spec, stringReplacements, emptyArrayReplacements, map[string]map[string]string{})
 }
 
-// ApplyResults applies the substitution from values in results and step results which are referenced in spec as subitems
-// of the replacementStr.
+// ApplyResults applies the substitution from values in results, step results and sidecar results which are referenced
+// in spec as subitems of the replacementStr.
 func ApplyResults(spec *v1.TaskSpec) *v1.TaskSpec {
 	// Apply all the Step Result replacements
 	for i := range spec.Steps {
-		stringReplacements := getStepResultReplacements(spec.Steps[i], i)
+		stringReplacements := getStepResultReplacements(spec.Steps[i])
 		container.ApplyStepReplacements(&spec.Steps[i], stringReplacements, map[string][]string{})
 	}
+	// Apply all the Sidecar Result replacements
+	for i := range spec.Sidecars {
+		stringReplacements := getSidecarResultReplacements(spec.Sidecars[i])
+		container.ApplySidecarReplacements(&spec.Sidecars[i], stringReplacements, map[string][]string{})
+	}
 	stringReplacements := getTaskResultReplacements(spec)
+	maps.Copy(stringReplacements, getSidecarResultPathReplacements(spec.Sidecars))
 	return ApplyReplacements(spec, stringReplacements, map[string][]string{}, map[string]map[string]string{})
 }
 
 // getStepResultReplacements creates all combinations of string replacements from Step Results.
-func getStepResultReplacements(step v1.Step, idx int) map[string]string {
+func getStepResultReplacements(step v1.Step) map[string]string {
 	stringReplacements := map[string]string{}
 
 	patterns := []string{
@@ -614,7 +641,7 @@ func getStepResultReplacements(step v1.Step, idx int) map[string]string {
 		"step.results[%q].path",
 		"step.results['%s'].path",
 	}
-	stepName := pod.StepName(step.Name, idx)
+	stepName := pipeline.StepContainerName(step.Name)
 	for _, result := range step.Results {
 		for _, pattern := range patterns {
 			stringReplacements[fmt.Sprintf(pattern, result.Name)] = filepath.Join(pipeline.StepsDir, stepName, "results", result.Name)
@@ -623,6 +650,44 @@ func getStepResultReplacements(step v1.Step, idx int) map[string]string {
 	return stringReplacements
 }
 
+// getSidecarResultReplacements creates all combinations of string replacements from the results of a Sidecar,
+// which the Sidecar writes to its own directory of the sidecar results volume, mounted at the results path.
+func getSidecarResultReplacements(sidecar v1.Sidecar) map[string]string {
+	stringReplacements := map[string]string{}
+
+	patterns := []string{
+		"sidecar.results.%s.path",
+		"sidecar.results[%q].path",
+		"sidecar.results['%s'].path",
+	}
+	for _, result := range sidecar.Results {
+		for _, pattern := range patterns {
+			stringReplacements[fmt.Sprintf(pattern, result.Name)] = filepath.Join(pipeline.DefaultResultPath, result.Name)
+		}
+	}
+	return stringReplacements
+}
+
+// getSidecarResultPathReplacements creates all combinations of string replacements from the paths
+// the Steps read the results of the Sidecars from.
+func getSidecarResultPathReplacements(sidecars []v1.Sidecar) map[string]string {
+	stringReplacements := map[string]string{}
+
+	patterns := []string{
+		"sidecars.%s.results.%s.path",
+		"sidecars.%s.results[%q].path",
+		"sidecars.%s.results['%s'].path",
+	}
+	for _, sidecar := range sidecars {
+		for _, result := range sidecar.Results {
+			for _, pattern := range patterns {
+				stringReplacements[fmt.Sprintf(pattern, sidecar.Name, result.Name)] = filepath.Join(pipeline.SidecarsDir, sidecar.Name, result.Name)
+			}
+		}
+	}
+	return stringReplacements
+}
+
 // getTaskResultReplacements creates all combinations of string replacements from TaskResults.
 func getTaskResultReplacements(spec *v1.TaskSpec) map[string]string {
 	stringReplacements := map[string]string{}
@@ -644,15 +709,15 @@ func getTaskResultReplacements(spec *v1.TaskSpec) map[string]string {
 // ApplyArtifacts replaces the occurrences of artifacts.path and step.artifacts.path with the absolute tekton internal path
 func ApplyArtifacts(spec *v1.TaskSpec) *v1.TaskSpec {
 	for i := range spec.Steps {
-		stringReplacements := getArtifactReplacements(spec.Steps[i], i)
+		stringReplacements := getArtifactReplacements(spec.Steps[i])
 		container.ApplyStepReplacements(&spec.Steps[i], stringReplacements, map[string][]string{})
 	}
 	return spec
 }
 
-func getArtifactReplacements(step v1.Step, idx int) map[string]string {
+func getArtifactReplacements(step v1.Step) map[string]string {
 	stringReplacements := map[string]string{}
-	stepName := pod.StepName(step.Name, idx)
+	stepName := pipeline.StepContainerName(step.Name)
 	stringReplacements[artifactref.StepArtifactPathPattern] = filepath.Join(pipeline.StepsDir, stepName, "artifacts", "provenance.json")
 	stringReplacements[artifactref.TaskArtifactPathPattern] = filepath.Join(pipeline.ArtifactsDir, "provenance.json")
 
@@ -664,8 +729,8 @@ func getArtifactReplacements(step v1.Step, idx int) map[string]string {
 func ApplyStepExitCodePath(spec *v1.TaskSpec) *v1.TaskSpec {
 	stringReplacements := map[string]string{}
 
-	for i, step := range spec.Steps {
-		stringReplacements[fmt.Sprintf("steps.%s.exitCode.path", pod.StepName(step.Name, i))] = filepath.Join(pipeline.StepsDir, pod.StepName(step.Name, i), "exitCode")
+	for _, step := range spec.Steps {
+		stringReplacements[fmt.Sprintf("steps.%s.exitCode.path", pipeline.StepContainerName(step.Name))] = filepath.Join(pipeline.StepsDir, pipeline.StepContainerName(step.Name), "exitCode")
 	}
 	return ApplyReplacements(spec, stringReplacements, map[string][]string{}, map[string]map[string]string{})
 }
@@ -752,6 +817,10 @@ func ApplyReplacements(spec *v1.TaskSpec, stringReplacements map[string]string,
 		container.ApplySidecarReplacements(&sidecars[i], stringReplacements, arrayReplacements)
 	}
 
+	for i, d := range spec.ExpectedArtifactDigests {
+		spec.ExpectedArtifactDigests[i].Digest = substitution.ApplyReplacements(d.Digest, stringReplacements)
+	}
+
 	return spec
 }
 
@@ -17,6 +17,7 @@ limitations under the License.
 package resources_test
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -815,6 +816,171 @@ func TestApplyParametersDoesNotMutateObjectParamDefault(t *testing.T) {
 	}
 }
 
+func TestApplyParametersToExpectedArtifactDigests(t *testing.T) {
+	spec := &v1.TaskSpec{
+		Params: v1.ParamSpecs{{Name: "digest", Type: v1.ParamTypeString}, {
+			Name:    "sbom-digest",
+			Type:    v1.ParamTypeString,
+			Default: v1.NewStructuredValues(""),
+		}},
+		ExpectedArtifactDigests: []v1.ExpectedArtifactDigest{
+			{Name: "image", Digest: "sha256:$(params.digest)"},
+			{Name: "sbom", Digest: "sha256:$(params.sbom-digest)"},
+		},
+	}
+	tr := &v1.TaskRun{
+		Spec: v1.TaskRunSpec{
+			Params: v1.Params{{Name: "digest", Value: *v1.NewStructuredValues("abc")}},
+		},
+	}
+
+	got := resources.ApplyParameters(spec, tr, spec.Params...).ExpectedArtifactDigests
+	want := []v1.ExpectedArtifactDigest{
+		{Name: "image", Digest: "sha256:abc"},
+		{Name: "sbom", Digest: "sha256:"},
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("ApplyParameters() %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestApplyParametersAsFile(t *testing.T) {
+	manifest := strings.Repeat("0123456789abcdef", 100*1024/16)
+	spec := &v1.TaskSpec{
+		Params: v1.ParamSpecs{{
+			Name:   "manifest",
+			Type:   v1.ParamTypeString,
+			AsFile: true,
+		}, {
+			Name:       "config",
+			Type:       v1.ParamTypeObject,
+			AsFile:     true,
+			Properties: map[string]v1.PropertySpec{"url": {Type: v1.ParamTypeString}},
+			Default:    v1.NewObject(map[string]string{"url": "https://example.com"}),
+		}, {
+			Name: "inline",
+			Type: v1.ParamTypeString,
+		}},
+		Steps: []v1.Step{{
+			Name:    "apply",
+			Image:   "image",
+			Command: []string{"kubectl", "apply", "-f", "$(params.manifest.path)"},
+			Args:    []string{"--config-dir=$(params.config.path)", "$(params.inline)"},
+			Env:     []corev1.EnvVar{{Name: "CONFIG_URL", Value: "$(params.config.path)/url"}},
+		}},
+	}
+	tr := &v1.TaskRun{
+		Spec: v1.TaskRunSpec{
+			Params: v1.Params{{
+				Name:  "manifest",
+				Value: *v1.NewStructuredValues(manifest),
+			}, {
+				Name:  "inline",
+				Value: *v1.NewStructuredValues("spliced"),
+			}},
+		},
+	}
+
+	got := resources.ApplyParameters(spec, tr, spec.Params...).Steps[0]
+	want := v1.Step{
+		Name:    "apply",
+		Image:   "image",
+		Command: []string{"kubectl", "apply", "-f", "/tekton/params/manifest"},
+		Args:    []string{"--config-dir=/tekton/params/config", "spliced"},
+		Env:     []corev1.EnvVar{{Name: "CONFIG_URL", Value: "/tekton/params/config/url"}},
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("ApplyParameters() %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestApplyVariablesToSidecars(t *testing.T) {
+	params := v1.ParamSpecs{
+		{Name: "port", Type: v1.ParamTypeString},
+		{Name: "flags", Type: v1.ParamTypeArray},
+		{Name: "config", Type: v1.ParamTypeObject, Properties: map[string]v1.PropertySpec{"url": {Type: v1.ParamTypeString}}},
+		{Name: "manifest", Type: v1.ParamTypeString, AsFile: true},
+	}
+	tr := &v1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "taskrun", Namespace: "ns"},
+		Spec: v1.TaskRunSpec{
+			Params: v1.Params{
+				{Name: "port", Value: *v1.NewStructuredValues("8080")},
+				{Name: "flags", Value: *v1.NewStructuredValues("--verbose", "--tls")},
+				{Name: "config", Value: *v1.NewObject(map[string]string{"url": "https://example.com"})},
+				{Name: "manifest", Value: *v1.NewStructuredValues("kind: Service")},
+			},
+		},
+	}
+	decls := []v1.WorkspaceDeclaration{{Name: "data"}}
+	binds := []v1.WorkspaceBinding{{Name: "data", EmptyDir: &corev1.EmptyDirVolumeSource{}}}
+
+	for _, tc := range []struct {
+		name       string
+		script     string
+		args       []string
+		wantScript string
+		wantArgs   []string
+	}{{
+		name:       "string param",
+		script:     "serve --port $(params.port)",
+		args:       []string{"--port=$(params.port)"},
+		wantScript: "serve --port 8080",
+		wantArgs:   []string{"--port=8080"},
+	}, {
+		name:       "array param",
+		script:     "serve $(params.flags[0])",
+		args:       []string{"$(params.flags[*])"},
+		wantScript: "serve --verbose",
+		wantArgs:   []string{"--verbose", "--tls"},
+	}, {
+		name:       "object param",
+		script:     "serve --url $(params.config.url)",
+		args:       []string{"$(params.config.url)"},
+		wantScript: "serve --url https://example.com",
+		wantArgs:   []string{"https://example.com"},
+	}, {
+		name:       "param delivered as a file",
+		script:     "serve -f $(params.manifest.path)",
+		args:       []string{"$(params.manifest.path)"},
+		wantScript: "serve -f /tekton/params/manifest",
+		wantArgs:   []string{"/tekton/params/manifest"},
+	}, {
+		name:       "workspace",
+		script:     "serve --dir $(workspaces.data.path)",
+		args:       []string{"$(workspaces.data.path)"},
+		wantScript: "serve --dir /workspace/data",
+		wantArgs:   []string{"/workspace/data"},
+	}, {
+		name:       "context",
+		script:     "serve --name $(context.taskRun.name)",
+		args:       []string{"$(context.taskRun.namespace)"},
+		wantScript: "serve --name taskrun",
+		wantArgs:   []string{"ns"},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			spec := &v1.TaskSpec{
+				Params:     params,
+				Workspaces: decls,
+				Steps:      []v1.Step{{Name: "client", Image: "image", Script: tc.script, Args: tc.args}},
+				Sidecars:   []v1.Sidecar{{Name: "server", Image: "image", Script: tc.script, Args: tc.args}},
+			}
+			got := resources.ApplyParameters(spec, tr, spec.Params...)
+			got = resources.ApplyContexts(got, "task", tr)
+			got = resources.ApplyWorkspaces(t.Context(), got, decls, binds, workspace.CreateVolumes(binds))
+
+			// Variables in a Sidecar are expanded identically to the Steps
+			want := v1.Sidecar{Name: "server", Image: "image", Script: tc.wantScript, Args: tc.wantArgs}
+			if d := cmp.Diff(want, got.Sidecars[0]); d != "" {
+				t.Errorf("sidecar %s", diff.PrintWantGot(d))
+			}
+			if got.Sidecars[0].Script != got.Steps[0].Script || !cmp.Equal(got.Sidecars[0].Args, got.Steps[0].Args) {
+				t.Errorf("sidecar expanded to %q %q, step expanded to %q %q", got.Sidecars[0].Script, got.Sidecars[0].Args, got.Steps[0].Script, got.Steps[0].Args)
+			}
+		})
+	}
+}
+
 func TestApplyParameters(t *testing.T) {
 	tr := &v1.TaskRun{
 		Spec: v1.TaskRunSpec{
@@ -2700,6 +2866,61 @@ func TestContext(t *testing.T) {
 				Image: "trNamespace-1",
 			}},
 		},
+	}, {
+		description: "context taskRun namespace replacement in the fields of steps, stepTemplate and sidecars",
+		taskName:    "Task1",
+		tr: v1.TaskRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "taskrunName",
+				Namespace: "trNamespace",
+			},
+		},
+		spec: v1.TaskSpec{
+			StepTemplate: &v1.StepTemplate{
+				Env: []corev1.EnvVar{{Name: "TEMPLATE_NAMESPACE", Value: "$(context.taskRun.namespace)"}},
+			},
+			Steps: []v1.Step{{
+				Name:         "register",
+				Image:        "image",
+				Command:      []string{"register-$(context.taskRun.namespace)"},
+				Args:         []string{"--namespace=$(context.taskRun.namespace)"},
+				WorkingDir:   "/workspace/$(context.taskRun.namespace)",
+				Env:          []corev1.EnvVar{{Name: "NAMESPACE", Value: "$(context.taskRun.namespace)"}},
+				StdoutConfig: &v1.StepOutputConfig{Path: "/logs/$(context.taskRun.namespace)"},
+			}, {
+				Name:   "callback",
+				Image:  "image",
+				Script: "curl http://callback.$(context.taskRun.namespace).svc",
+			}},
+			Sidecars: []v1.Sidecar{{
+				Name:   "server",
+				Image:  "image",
+				Script: "serve --namespace $(context.taskRun.namespace)",
+			}},
+		},
+		want: v1.TaskSpec{
+			StepTemplate: &v1.StepTemplate{
+				Env: []corev1.EnvVar{{Name: "TEMPLATE_NAMESPACE", Value: "trNamespace"}},
+			},
+			Steps: []v1.Step{{
+				Name:         "register",
+				Image:        "image",
+				Command:      []string{"register-trNamespace"},
+				Args:         []string{"--namespace=trNamespace"},
+				WorkingDir:   "/workspace/trNamespace",
+				Env:          []corev1.EnvVar{{Name: "NAMESPACE", Value: "trNamespace"}},
+				StdoutConfig: &v1.StepOutputConfig{Path: "/logs/trNamespace"},
+			}, {
+				Name:   "callback",
+				Image:  "image",
+				Script: "curl http://callback.trNamespace.svc",
+			}},
+			Sidecars: []v1.Sidecar{{
+				Name:   "server",
+				Image:  "image",
+				Script: "serve --namespace trNamespace",
+			}},
+		},
 	}, {
 		description: "context taskRunName replacement with no defined taskName in spec container",
 		tr:          v1.TaskRun{},
@@ -2878,6 +3099,39 @@ func TestStepResults(t *testing.T) {
 	}
 }
 
+func TestSidecarResults(t *testing.T) {
+	names.TestingSeed()
+	ts := &v1.TaskSpec{
+		Sidecars: []v1.Sidecar{{
+			Name:  "server",
+			Image: "bash:latest",
+			Results: []v1.StepResult{{
+				Name: "port",
+			}, {
+				Name: "address",
+			}},
+			Args:   []string{"$(sidecar.results[\"address\"].path)"},
+			Script: "#!/usr/bin/env bash\necho -n 8080 | tee $(sidecar.results.port.path)",
+		}},
+		Steps: []v1.Step{{
+			Name:   "client",
+			Image:  "bash:latest",
+			Args:   []string{"$(sidecars.server.results['address'].path)"},
+			Script: "#!/usr/bin/env bash\ncurl localhost:$(cat $(sidecars.server.results.port.path))",
+		}},
+	}
+	want := applyMutation(ts, func(spec *v1.TaskSpec) {
+		spec.Sidecars[0].Args[0] = "/tekton/results/address"
+		spec.Sidecars[0].Script = "#!/usr/bin/env bash\necho -n 8080 | tee /tekton/results/port"
+		spec.Steps[0].Args[0] = "/tekton/sidecars/server/address"
+		spec.Steps[0].Script = "#!/usr/bin/env bash\ncurl localhost:$(cat /tekton/sidecars/server/port)"
+	})
+	got := resources.ApplyResults(ts)
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("ApplyResults() got diff %s", diff.PrintWantGot(d))
+	}
+}
+
 func TestApplyStepExitCodePath(t *testing.T) {
 	names.TestingSeed()
 	ts := &v1.TaskSpec{
@@ -2894,6 +3148,8 @@ func TestApplyStepExitCodePath(t *testing.T) {
 			Script: "#!/usr/bin/env bash\ncat $(steps.step-failing-step.exitCode.path)",
 		}},
 	}
+	// The unnamed step is named "unnamed-0" by defaulting
+	ts.SetDefaults(t.Context())
 	expected := applyMutation(ts, func(spec *v1.TaskSpec) {
 		spec.Steps[1].Script = "#!/usr/bin/env bash\ncat /tekton/steps/step-unnamed-0/exitCode"
 		spec.Steps[2].Script = "#!/usr/bin/env bash\ncat /tekton/steps/step-failing-step/exitCode"
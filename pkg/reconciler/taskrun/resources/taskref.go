@@ -120,7 +120,8 @@ func GetTaskFunc(ctx context.Context, k8s kubernetes.Interface, tekton clientset
 				},
 			}
 			resolver := resolution.NewResolver(requester, owner, string(tr.Resolver), resolverPayload)
-			return resolveTask(ctx, resolver, name, namespace, kind, k8s, tekton, verificationPolicies)
+			source := trustedresources.Source{Resolver: string(tr.Resolver), Params: replacedParams}
+			return resolveTask(ctx, resolver, name, namespace, kind, k8s, tekton, source, verificationPolicies)
 		}
 
 	default:
@@ -211,14 +212,16 @@ func extendObjectReplacements(objectReplacements map[string]map[string]string, o
 // An error is returned if the remoteresource doesn't work
 // A VerificationResult is returned if trusted resources is enabled, VerificationResult contains the result type and err.
 // or the returned data isn't a valid *v1beta1.Task.
-func resolveTask(ctx context.Context, resolver remote.Resolver, name, namespace string, kind v1.TaskKind, k8s kubernetes.Interface, tekton clientset.Interface, verificationPolicies []*v1alpha1.VerificationPolicy) (*v1.Task, *v1.RefSource, *trustedresources.VerificationResult, error) {
+// The policies are matched against the resolver and params of source, and the RefSource returned by the resolver.
+func resolveTask(ctx context.Context, resolver remote.Resolver, name, namespace string, kind v1.TaskKind, k8s kubernetes.Interface, tekton clientset.Interface, source trustedresources.Source, verificationPolicies []*v1alpha1.VerificationPolicy) (*v1.Task, *v1.RefSource, *trustedresources.VerificationResult, error) {
 	// Because the resolver will only return references with the same kind, this will ensure we
 	// don't accidentally return a Task with the same name but different kind.
 	obj, refSource, err := resolver.Get(ctx, strings.TrimSuffix(strings.ToLower(string(kind)), "s"), name)
 	if err != nil {
 		return nil, nil, nil, err
 	}
-	taskObj, vr, err := readRuntimeObjectAsTask(ctx, namespace, obj, k8s, tekton, refSource, verificationPolicies)
+	source.RefSource = refSource
+	taskObj, vr, err := readRuntimeObjectAsTask(ctx, namespace, obj, k8s, tekton, source, verificationPolicies)
 	if err != nil {
 		return nil, nil, nil, err
 	}
@@ -279,7 +282,7 @@ func resolveStepAction(ctx context.Context, resolver remote.Resolver, name, name
 // A VerificationResult is returned if trusted resources is enabled, VerificationResult contains the result type and err.
 // v1beta1 task will be verified by trusted resources if the feature is enabled
 // TODO(#5541): convert v1beta1 obj to v1 once we use v1 as the stored version
-func readRuntimeObjectAsTask(ctx context.Context, namespace string, obj runtime.Object, k8s kubernetes.Interface, tekton clientset.Interface, refSource *v1.RefSource, verificationPolicies []*v1alpha1.VerificationPolicy) (*v1.Task, *trustedresources.VerificationResult, error) {
+func readRuntimeObjectAsTask(ctx context.Context, namespace string, obj runtime.Object, k8s kubernetes.Interface, tekton clientset.Interface, source trustedresources.Source, verificationPolicies []*v1alpha1.VerificationPolicy) (*v1.Task, *trustedresources.VerificationResult, error) {
 	switch obj := obj.(type) {
 	case *v1beta1.Task:
 		obj.SetDefaults(ctx)
@@ -287,7 +290,7 @@ func readRuntimeObjectAsTask(ctx context.Context, namespace string, obj runtime.
 		// FIXME: extract this in a function
 		obj.ObjectMeta.OwnerReferences = nil
 		// Verify the Task once we fetch from the remote resolution, mutating, validation and conversion of the task should happen after the verification, since signatures are based on the remote task contents
-		vr := trustedresources.VerifyResource(ctx, obj, k8s, refSource, verificationPolicies)
+		vr := trustedresources.VerifyResolvedResource(ctx, obj, k8s, source, verificationPolicies)
 		// Issue a dry-run request to create the remote Task, so that it can undergo validation from validating admission webhooks
 		// without actually creating the Task on the cluster.
 		o, err := apiserver.DryRunValidate(ctx, namespace, obj, tekton)
@@ -314,7 +317,7 @@ func readRuntimeObjectAsTask(ctx context.Context, namespace string, obj runtime.
 		// Cleanup object from things we don't care about
 		// FIXME: extract this in a function
 		obj.ObjectMeta.OwnerReferences = nil
-		vr := trustedresources.VerifyResource(ctx, obj, k8s, refSource, verificationPolicies)
+		vr := trustedresources.VerifyResolvedResource(ctx, obj, k8s, source, verificationPolicies)
 		// Issue a dry-run request to create the remote Task, so that it can undergo validation from validating admission webhooks
 		// without actually creating the Task on the cluster
 		o, err := apiserver.DryRunValidate(ctx, namespace, obj, tekton)
@@ -20,16 +20,19 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
+	"sort"
+	"strings"
 
 	"github.com/tektoncd/pipeline/pkg/apis/config"
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	clientset "github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
 	resolutionutil "github.com/tektoncd/pipeline/pkg/internal/resolution"
-	"github.com/tektoncd/pipeline/pkg/pod"
 	remoteresource "github.com/tektoncd/pipeline/pkg/remoteresolution/resource"
 	"github.com/tektoncd/pipeline/pkg/trustedresources"
 	"golang.org/x/sync/errgroup"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 )
@@ -105,8 +108,9 @@ func GetTaskData(ctx context.Context, taskRun *v1.TaskRun, getTask GetTask) (*re
 
 // stepRefResolution holds the outcome of resolving a step referencing a StepAction.
 type stepRefResolution struct {
-	resolvedStep *v1.Step
-	source       *v1.RefSource
+	resolvedStep  *v1.Step
+	source        *v1.RefSource
+	nestedSources []*v1.RefSource
 }
 
 // hasStepRefs provides a fast check to see if any steps in a TaskSpec contain a reference to a StepAction.
@@ -120,26 +124,27 @@ func hasStepRefs(taskSpec *v1.TaskSpec) bool {
 }
 
 // resolveStepRef resolves a step referecing a StepAction by fetching the remote StepAction, merging it with the Step's specification, and returning the resolved step.
-func resolveStepRef(ctx context.Context, taskSpec v1.TaskSpec, taskRun *v1.TaskRun, tekton clientset.Interface, k8s kubernetes.Interface, requester remoteresource.Requester, step *v1.Step) (*v1.Step, *v1.RefSource, error) {
+// Along with the source of the StepAction, it returns the sources of the remote StepActions it uses, if any.
+func resolveStepRef(ctx context.Context, taskSpec v1.TaskSpec, taskRun *v1.TaskRun, tekton clientset.Interface, k8s kubernetes.Interface, requester remoteresource.Requester, step *v1.Step) (*v1.Step, *v1.RefSource, []*v1.RefSource, error) {
 	resolvedStep := step.DeepCopy()
 
-	getStepAction := GetStepActionFunc(tekton, k8s, requester, taskRun, taskSpec, resolvedStep)
-	stepAction, source, err := getStepAction(ctx, resolvedStep.Ref.Name)
+	stepActionSpec, source, nestedSources, err := resolveStepActionSpec(ctx, taskSpec, taskRun, tekton, k8s, requester, resolvedStep, 0, nil)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 
-	stepActionSpec := stepAction.StepActionSpec()
-	stepActionSpec.SetDefaults(ctx)
+	if stepActionSpec.Script != "" && config.FromContextOrDefaults(ctx).FeatureFlags.DisableInlineScripts {
+		return nil, nil, nil, fmt.Errorf("StepAction %s can't be run as it has a script, scripts are disabled by the %q feature flag", stepActionRefKey(step.Ref), config.DisableInlineScriptsKey)
+	}
 
 	stepFromStepAction := stepActionSpec.ToStep()
 	if err := validateStepHasStepActionParameters(resolvedStep.Params, stepActionSpec.Params); err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 
 	stepFromStepAction, err = applyStepActionParameters(stepFromStepAction, &taskSpec, taskRun, resolvedStep.Params, stepActionSpec.Params)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 
 	// Merge fields from the resolved StepAction into the step
@@ -169,11 +174,143 @@ func resolveStepRef(ctx context.Context, taskSpec v1.TaskSpec, taskRun *v1.TaskR
 	resolvedStep.Ref = nil
 	resolvedStep.Params = nil
 
-	return resolvedStep, source, nil
+	return resolvedStep, source, nestedSources, nil
+}
+
+// resolveStepActionSpec fetches the StepAction referenced by the step and, if it uses another StepAction,
+// resolves that one as well and composes them. It returns the resulting StepActionSpec, the source of the
+// referenced StepAction and the sources of the remote StepActions it uses, from the outermost to the innermost one.
+// depth is the number of StepActions that were followed through uses to get to the step, and seen identifies them.
+func resolveStepActionSpec(ctx context.Context, taskSpec v1.TaskSpec, taskRun *v1.TaskRun, tekton clientset.Interface, k8s kubernetes.Interface, requester remoteresource.Requester, step *v1.Step, depth int, seen []string) (*v1beta1.StepActionSpec, *v1.RefSource, []*v1.RefSource, error) {
+	key := stepActionRefKey(step.Ref)
+	if slices.Contains(seen, key) {
+		return nil, nil, nil, fmt.Errorf("cycle detected in StepActions uses: %s", strings.Join(append(seen, key), " -> "))
+	}
+	seen = append(seen, key)
+
+	getStepAction := GetStepActionFunc(tekton, k8s, requester, taskRun, taskSpec, step)
+	stepAction, source, err := getStepAction(ctx, step.Ref.Name)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	stepActionSpec := stepAction.StepActionSpec()
+	stepActionSpec.SetDefaults(ctx)
+	if stepActionSpec.Uses == nil || stepActionSpec.Uses.Ref == nil {
+		return &stepActionSpec, source, nil, nil
+	}
+
+	maxDepth := config.FromContextOrDefaults(ctx).Defaults.DefaultMaxStepActionNestingDepth
+	if depth >= maxDepth {
+		return nil, nil, nil, fmt.Errorf("StepAction %s cannot use another StepAction: StepActions can only be nested %d level(s) deep", key, maxDepth)
+	}
+	usingStep := &v1.Step{Ref: stepActionSpec.Uses.Ref.DeepCopy(), Params: stepActionSpec.Uses.Params}
+	usedSpec, usedSource, nestedSources, err := resolveStepActionSpec(ctx, taskSpec, taskRun, tekton, k8s, requester, usingStep, depth+1, seen)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to resolve StepAction used by StepAction %s: %w", key, err)
+	}
+	composedSpec, err := composeStepActionSpecs(&stepActionSpec, usedSpec)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to compose StepAction %s with the StepAction it uses: %w", key, err)
+	}
+	if usedSource != nil {
+		nestedSources = append([]*v1.RefSource{usedSource}, nestedSources...)
+	}
+	return composedSpec, source, nestedSources, nil
+}
+
+// stepActionRefKey identifies the StepAction a Ref points to, in order to detect cycles between StepActions.
+func stepActionRefKey(ref *v1.Ref) string {
+	if ref.Resolver == "" {
+		return fmt.Sprintf("%q", ref.Name)
+	}
+	params := make([]string, 0, len(ref.Params))
+	for _, p := range ref.Params {
+		params = append(params, fmt.Sprintf("%s=%s", p.Name, p.Value.StringVal))
+	}
+	sort.Strings(params)
+	return fmt.Sprintf("%q (%s resolver: %s)", ref.Name, ref.Resolver, strings.Join(params, ", "))
+}
+
+// composeStepActionSpecs returns the StepActionSpec of a StepAction built on the used StepAction.
+// The parameters of the used StepAction are replaced with the values passed through uses, which can
+// refer to the parameters of the StepAction. The image, working directory and security context of the
+// used StepAction are inherited unless the StepAction sets them, environment variables and volume mounts
+// are merged with the ones of the StepAction taking precedence, and results are merged as long as they
+// do not conflict. If the StepAction sets a command, it wraps the used StepAction: the command and args
+// of the used StepAction are appended to its args. Otherwise the command or script of the used
+// StepAction is run, with the args of the StepAction if it sets any.
+func composeStepActionSpecs(stepActionSpec, usedSpec *v1beta1.StepActionSpec) (*v1beta1.StepActionSpec, error) {
+	if err := validateStepHasStepActionParameters(stepActionSpec.Uses.Params, usedSpec.Params); err != nil {
+		return nil, err
+	}
+	usedStep, err := applyStepActionParameters(usedSpec.ToStep(), &v1.TaskSpec{}, &v1.TaskRun{}, stepActionSpec.Uses.Params, usedSpec.Params)
+	if err != nil {
+		return nil, err
+	}
+
+	composedSpec := stepActionSpec.DeepCopy()
+	composedSpec.Uses = nil
+	if composedSpec.Image == "" {
+		composedSpec.Image = usedStep.Image
+	}
+	if len(composedSpec.Command) > 0 {
+		if usedStep.Script != "" {
+			return nil, errors.New("a command cannot wrap the script of the used StepAction")
+		}
+		if len(usedStep.Command) == 0 {
+			return nil, errors.New("a command cannot wrap the used StepAction as it does not set a command")
+		}
+		composedSpec.Args = append(composedSpec.Args, usedStep.Command...)
+		composedSpec.Args = append(composedSpec.Args, usedStep.Args...)
+	} else {
+		composedSpec.Command = usedStep.Command
+		composedSpec.Script = usedStep.Script
+		if len(composedSpec.Args) == 0 {
+			composedSpec.Args = usedStep.Args
+		}
+	}
+	if composedSpec.WorkingDir == "" {
+		composedSpec.WorkingDir = usedStep.WorkingDir
+	}
+	if composedSpec.SecurityContext == nil {
+		composedSpec.SecurityContext = usedStep.SecurityContext
+	}
+
+	env := make([]corev1.EnvVar, 0, len(usedStep.Env)+len(composedSpec.Env))
+	for _, e := range usedStep.Env {
+		if !slices.ContainsFunc(composedSpec.Env, func(o corev1.EnvVar) bool { return o.Name == e.Name }) {
+			env = append(env, e)
+		}
+	}
+	if len(env) > 0 {
+		composedSpec.Env = append(env, composedSpec.Env...)
+	}
+
+	volumeMounts := make([]corev1.VolumeMount, 0, len(usedStep.VolumeMounts)+len(composedSpec.VolumeMounts))
+	for _, vm := range usedStep.VolumeMounts {
+		if !slices.ContainsFunc(composedSpec.VolumeMounts, func(o corev1.VolumeMount) bool { return o.MountPath == vm.MountPath }) {
+			volumeMounts = append(volumeMounts, vm)
+		}
+	}
+	if len(volumeMounts) > 0 {
+		composedSpec.VolumeMounts = append(volumeMounts, composedSpec.VolumeMounts...)
+	}
+
+	for _, usedResult := range usedStep.Results {
+		i := slices.IndexFunc(composedSpec.Results, func(r v1.StepResult) bool { return r.Name == usedResult.Name })
+		switch {
+		case i < 0:
+			composedSpec.Results = append(composedSpec.Results, usedResult)
+		case composedSpec.Results[i].Type != usedResult.Type:
+			return nil, fmt.Errorf("result %q is declared with type %q but the used StepAction declares it with type %q", usedResult.Name, composedSpec.Results[i].Type, usedResult.Type)
+		}
+	}
+	return composedSpec, nil
 }
 
 // updateTaskRunProvenance update the TaskRun's status with source provenance information for a given step
-func updateTaskRunProvenance(taskRun *v1.TaskRun, stepName string, stepIndex int, source *v1.RefSource, stepStatusIndex map[string]int) {
+func updateTaskRunProvenance(taskRun *v1.TaskRun, stepName string, source *v1.RefSource, nestedSources []*v1.RefSource, stepStatusIndex map[string]int) {
 	var provenance *v1.Provenance
 
 	// The StepState already exists. Update it in place
@@ -182,14 +319,15 @@ func updateTaskRunProvenance(taskRun *v1.TaskRun, stepName string, stepIndex int
 			taskRun.Status.Steps[index].Provenance = &v1.Provenance{}
 		}
 		taskRun.Status.Steps[index].Provenance.RefSource = source
+		taskRun.Status.Steps[index].Provenance.NestedRefSources = nestedSources
 		return
 	}
 
-	provenance = &v1.Provenance{RefSource: source}
+	provenance = &v1.Provenance{RefSource: source, NestedRefSources: nestedSources}
 
 	// No existing StepState found. Create and append a new one
 	newState := v1.StepState{
-		Name:       pod.TrimStepPrefix(pod.StepName(stepName, stepIndex)),
+		Name:       stepName,
 		Provenance: provenance,
 	}
 	taskRun.Status.Steps = append(taskRun.Status.Steps, newState)
@@ -212,7 +350,7 @@ func GetStepActionsData(ctx context.Context, taskSpec v1.TaskSpec, taskRun *v1.T
 	if !hasStepRefs(&taskSpec) {
 		for i, step := range taskSpec.Steps {
 			steps[i] = step
-			updateTaskRunProvenance(taskRun, step.Name, i, nil, stepStatusIndex) // create StepState with nil provenance
+			updateTaskRunProvenance(taskRun, step.Name, nil, nil, stepStatusIndex) // create StepState with nil provenance
 		}
 		return steps, nil
 	}
@@ -230,11 +368,11 @@ func GetStepActionsData(ctx context.Context, taskSpec v1.TaskSpec, taskRun *v1.T
 		}
 
 		g.Go(func() error {
-			resolvedStep, source, err := resolveStepRef(ctx, taskSpec, taskRun, tekton, k8s, requester, &step)
+			resolvedStep, source, nestedSources, err := resolveStepRef(ctx, taskSpec, taskRun, tekton, k8s, requester, &step)
 			if err != nil {
 				return fmt.Errorf("failed to resolve step ref for step %q (index %d): %w", step.Name, i, err)
 			}
-			stepRefResolutions[i] = &stepRefResolution{resolvedStep: resolvedStep, source: source}
+			stepRefResolutions[i] = &stepRefResolution{resolvedStep: resolvedStep, source: source, nestedSources: nestedSources}
 			return nil
 		})
 	}
@@ -247,13 +385,13 @@ func GetStepActionsData(ctx context.Context, taskSpec v1.TaskSpec, taskRun *v1.T
 	for i, step := range taskSpec.Steps {
 		if step.Ref == nil {
 			steps[i] = step
-			updateTaskRunProvenance(taskRun, step.Name, i, nil, stepStatusIndex) // create StepState for inline step with nil provenance
+			updateTaskRunProvenance(taskRun, step.Name, nil, nil, stepStatusIndex) // create StepState for inline step with nil provenance
 			continue
 		}
 
 		stepRefResolution := stepRefResolutions[i]
 		steps[i] = *stepRefResolution.resolvedStep
-		updateTaskRunProvenance(taskRun, stepRefResolution.resolvedStep.Name, i, stepRefResolution.source, stepStatusIndex)
+		updateTaskRunProvenance(taskRun, stepRefResolution.resolvedStep.Name, stepRefResolution.source, stepRefResolution.nestedSources, stepStatusIndex)
 	}
 
 	return steps, nil
@@ -24,6 +24,7 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/pipeline/pkg/client/clientset/versioned/fake"
@@ -1966,6 +1967,64 @@ func TestGetStepActionsData(t *testing.T) {
 			Image: "myimage",
 			Args:  []string{"override"},
 		}},
+	}, {
+		name: "partial object param merged with the default of the stepaction",
+		tr: &v1.TaskRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "mytaskrun",
+				Namespace: "default",
+			},
+			Spec: v1.TaskRunSpec{
+				Params: v1.Params{{
+					Name:  "repo",
+					Value: *v1.NewObject(map[string]string{"url": "https://taskrun.example.com"}),
+				}},
+				TaskSpec: &v1.TaskSpec{
+					Params: v1.ParamSpecs{{
+						Name:       "repo",
+						Type:       v1.ParamTypeObject,
+						Properties: map[string]v1.PropertySpec{"url": {Type: v1.ParamTypeString}},
+					}},
+					Steps: []v1.Step{{
+						Ref: &v1.Ref{
+							Name: "stepAction",
+						},
+						Params: v1.Params{{
+							Name:  "inline",
+							Value: *v1.NewObject(map[string]string{"url": "https://inline.example.com"}),
+						}, {
+							Name:  "referenced",
+							Value: *v1.NewStructuredValues("$(params.repo[*])"),
+						}},
+					}},
+				},
+			},
+		},
+		stepActions: []*v1beta1.StepAction{{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "stepAction",
+				Namespace: "default",
+			},
+			Spec: v1beta1.StepActionSpec{
+				Image: "myimage",
+				Args:  []string{"$(params.inline.url)", "$(params.inline.revision)", "$(params.referenced.url)", "$(params.referenced.revision)"},
+				Params: v1.ParamSpecs{{
+					Name:       "inline",
+					Type:       v1.ParamTypeObject,
+					Properties: map[string]v1.PropertySpec{"url": {Type: v1.ParamTypeString}, "revision": {Type: v1.ParamTypeString}},
+					Default:    v1.NewObject(map[string]string{"revision": "main"}),
+				}, {
+					Name:       "referenced",
+					Type:       v1.ParamTypeObject,
+					Properties: map[string]v1.PropertySpec{"url": {Type: v1.ParamTypeString}, "revision": {Type: v1.ParamTypeString}},
+					Default:    v1.NewObject(map[string]string{"revision": "main"}),
+				}},
+			},
+		}},
+		want: []v1.Step{{
+			Image: "myimage",
+			Args:  []string{"https://inline.example.com", "main", "https://taskrun.example.com", "main"},
+		}},
 	}, {
 		name: "inline only",
 		tr: &v1.TaskRun{
@@ -2228,6 +2287,79 @@ func TestGetStepActionsData_Error(t *testing.T) {
 			},
 		},
 		expectedError: errors.New(`failed to resolve step ref for step "test" (index 0): invalid parameter substitution: commands. Please check the types of the default value and the passed value`),
+	}, {
+		name: "object param with extra key",
+		tr: &v1.TaskRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "mytaskrun",
+				Namespace: "default",
+			},
+			Spec: v1.TaskRunSpec{
+				TaskSpec: &v1.TaskSpec{
+					Steps: []v1.Step{{
+						Name: "clone",
+						Ref:  &v1.Ref{Name: "stepAction"},
+						Params: v1.Params{{
+							Name:  "repo",
+							Value: *v1.NewObject(map[string]string{"url": "https://example.com", "branch": "main"}),
+						}},
+					}},
+				},
+			},
+		},
+		stepAction: &v1beta1.StepAction{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "stepAction",
+				Namespace: "default",
+			},
+			Spec: v1beta1.StepActionSpec{
+				Image: "myimage",
+				Args:  []string{"$(params.repo.url)", "$(params.repo.revision)"},
+				Params: v1.ParamSpecs{{
+					Name:       "repo",
+					Type:       v1.ParamTypeObject,
+					Properties: map[string]v1.PropertySpec{"url": {Type: v1.ParamTypeString}, "revision": {Type: v1.ParamTypeString}},
+					Default:    v1.NewObject(map[string]string{"revision": "main"}),
+				}},
+			},
+		},
+		expectedError: errors.New(`failed to resolve step ref for step "clone" (index 0): object param "repo" passed by Step to StepAction has keys which are neither declared in its properties nor in its default: [branch]`),
+	}, {
+		name: "object param missing a required key",
+		tr: &v1.TaskRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "mytaskrun",
+				Namespace: "default",
+			},
+			Spec: v1.TaskRunSpec{
+				TaskSpec: &v1.TaskSpec{
+					Steps: []v1.Step{{
+						Name: "clone",
+						Ref:  &v1.Ref{Name: "stepAction"},
+						Params: v1.Params{{
+							Name:  "repo",
+							Value: *v1.NewObject(map[string]string{"revision": "v1"}),
+						}},
+					}},
+				},
+			},
+		},
+		stepAction: &v1beta1.StepAction{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "stepAction",
+				Namespace: "default",
+			},
+			Spec: v1beta1.StepActionSpec{
+				Image: "myimage",
+				Args:  []string{"$(params.repo.url)", "$(params.repo.revision)"},
+				Params: v1.ParamSpecs{{
+					Name:       "repo",
+					Type:       v1.ParamTypeObject,
+					Properties: map[string]v1.PropertySpec{"url": {Type: v1.ParamTypeString}, "revision": {Type: v1.ParamTypeString}},
+				}},
+			},
+		},
+		expectedError: errors.New(`failed to resolve step ref for step "clone" (index 0): object param "repo" passed by Step to StepAction is missing keys which have no default: [url]`),
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -2292,6 +2424,75 @@ func TestGetStepActionsData_InvalidStepResultReference(t *testing.T) {
 	}
 }
 
+func TestGetStepActionsData_DisableInlineScripts(t *testing.T) {
+	runScript := parse.MustParseV1beta1StepAction(t, `
+metadata:
+  name: run-script
+  namespace: default
+spec:
+  image: myimage
+  script: echo hello
+`)
+	usesScript := parse.MustParseV1beta1StepAction(t, `
+metadata:
+  name: uses-script
+  namespace: default
+spec:
+  image: myimage
+  uses:
+    ref:
+      name: run-script
+`)
+	runCommand := parse.MustParseV1beta1StepAction(t, `
+metadata:
+  name: run-command
+  namespace: default
+spec:
+  image: myimage
+  command: ["echo", "hello"]
+`)
+	for _, tc := range []struct {
+		name       string
+		stepAction string
+		wantErr    string
+	}{{
+		name:       "StepAction with a script",
+		stepAction: "run-script",
+		wantErr:    `failed to resolve step ref for step "step1" (index 0): StepAction "run-script" can't be run as it has a script, scripts are disabled by the "disable-inline-scripts" feature flag`,
+	}, {
+		name:       "StepAction using a StepAction with a script",
+		stepAction: "uses-script",
+		wantErr:    `failed to resolve step ref for step "step1" (index 0): StepAction "uses-script" can't be run as it has a script, scripts are disabled by the "disable-inline-scripts" feature flag`,
+	}, {
+		name:       "StepAction running a command",
+		stepAction: "run-command",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := config.ToContext(t.Context(), &config.Config{
+				FeatureFlags: &config.FeatureFlags{DisableInlineScripts: true},
+				Defaults:     config.DefaultConfig.DeepCopy(),
+			})
+			tr := &v1.TaskRun{
+				ObjectMeta: metav1.ObjectMeta{Name: "mytaskrun", Namespace: "default"},
+				Spec: v1.TaskRunSpec{
+					TaskSpec: &v1.TaskSpec{Steps: []v1.Step{{Name: "step1", Ref: &v1.Ref{Name: tc.stepAction}}}},
+				},
+			}
+			tektonclient := fake.NewSimpleClientset(runScript, usesScript, runCommand)
+			_, err := GetStepActionsData(ctx, *tr.Spec.TaskSpec, tr, tektonclient, nil, nil)
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("GetStepActionsData: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tc.wantErr {
+				t.Errorf("GetStepActionsData() = %v, want %s", err, tc.wantErr)
+			}
+		})
+	}
+}
+
 // TestGetStepActionsDataConcurrentObjectParamDefault exercises the real concurrent resolution
 // path end to end. GetStepActionsData resolves the StepAction refs of all steps concurrently
 // (errgroup, default-step-ref-concurrency-limit defaults to 5), and each goroutine runs
@@ -2347,3 +2548,344 @@ func TestGetStepActionsDataConcurrentObjectParamDefault(t *testing.T) {
 		t.Errorf("object param default mutated by concurrent resolution: key = %q, want %q", got, "from-default")
 	}
 }
+
+// stepActionsRequester resolves remote StepActions by the value of their "name" resolver param.
+type stepActionsRequester map[string]resource.ResolvedResource
+
+func (r stepActionsRequester) Submit(ctx context.Context, name resource.ResolverName, req resource.Request) (resource.ResolvedResource, error) {
+	for _, p := range req.ResolverPayload().ResolutionSpec.Params {
+		if rr, ok := r[p.Value.StringVal]; ok && p.Name == "name" {
+			return rr, nil
+		}
+	}
+	return nil, errors.New("no such StepAction")
+}
+
+func TestGetStepActionsData_Uses(t *testing.T) {
+	runCLI := parse.MustParseV1beta1StepAction(t, `
+metadata:
+  name: run-cli
+  namespace: default
+spec:
+  image: cli-image
+  command: ["cli"]
+  args: ["$(params.subcommand)", "--output", "$(step.results.output.path)"]
+  workingDir: /cli
+  env:
+  - name: LOG_LEVEL
+    value: debug
+  - name: TOKEN
+    value: none
+  params:
+  - name: subcommand
+  - name: unused
+    default: value
+  results:
+  - name: output
+`)
+	withAuth := parse.MustParseV1beta1StepAction(t, `
+metadata:
+  name: with-auth
+  namespace: default
+spec:
+  command: ["with-auth", "--"]
+  env:
+  - name: TOKEN
+    value: $(params.token)
+  params:
+  - name: token
+  - name: subcommand
+  results:
+  - name: output
+  uses:
+    ref:
+      name: run-cli
+    params:
+    - name: subcommand
+      value: $(params.subcommand)
+`)
+	withDefaults := parse.MustParseV1beta1StepAction(t, `
+metadata:
+  name: with-defaults
+  namespace: default
+spec:
+  image: other-image
+  params:
+  - name: subcommand
+    default: status
+  uses:
+    ref:
+      name: run-cli
+    params:
+    - name: subcommand
+      value: $(params.subcommand)
+`)
+	tests := []struct {
+		name string
+		tr   *v1.TaskRun
+		want []v1.Step
+	}{{
+		name: "command wrapping the used step action",
+		tr: &v1.TaskRun{
+			ObjectMeta: metav1.ObjectMeta{Name: "mytaskrun", Namespace: "default"},
+			Spec: v1.TaskRunSpec{
+				TaskSpec: &v1.TaskSpec{
+					Steps: []v1.Step{{
+						Name: "deploy",
+						Ref:  &v1.Ref{Name: "with-auth"},
+						Params: v1.Params{{
+							Name:  "token",
+							Value: *v1.NewStructuredValues("secret"),
+						}, {
+							Name:  "subcommand",
+							Value: *v1.NewStructuredValues("deploy"),
+						}},
+					}},
+				},
+			},
+		},
+		want: []v1.Step{{
+			Name:       "deploy",
+			Image:      "cli-image",
+			Command:    []string{"with-auth", "--"},
+			Args:       []string{"cli", "deploy", "--output", "$(step.results.output.path)"},
+			WorkingDir: "/cli",
+			Env: []corev1.EnvVar{{
+				Name:  "LOG_LEVEL",
+				Value: "debug",
+			}, {
+				Name:  "TOKEN",
+				Value: "secret",
+			}},
+			Results: []v1.StepResult{{Name: "output", Type: v1.ResultsTypeString}},
+		}},
+	}, {
+		name: "used step action run as is",
+		tr: &v1.TaskRun{
+			ObjectMeta: metav1.ObjectMeta{Name: "mytaskrun", Namespace: "default"},
+			Spec: v1.TaskRunSpec{
+				TaskSpec: &v1.TaskSpec{
+					Steps: []v1.Step{{
+						Name: "status",
+						Ref:  &v1.Ref{Name: "with-defaults"},
+					}},
+				},
+			},
+		},
+		want: []v1.Step{{
+			Name:       "status",
+			Image:      "other-image",
+			Command:    []string{"cli"},
+			Args:       []string{"status", "--output", "$(step.results.output.path)"},
+			WorkingDir: "/cli",
+			Env: []corev1.EnvVar{{
+				Name:  "LOG_LEVEL",
+				Value: "debug",
+			}, {
+				Name:  "TOKEN",
+				Value: "none",
+			}},
+			Results: []v1.StepResult{{Name: "output", Type: v1.ResultsTypeString}},
+		}},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := t.Context()
+			tektonclient := fake.NewSimpleClientset(runCLI, withAuth, withDefaults)
+
+			got, err := GetStepActionsData(ctx, *tt.tr.Spec.TaskSpec, tt.tr, tektonclient, nil, nil)
+			if err != nil {
+				t.Fatalf("Did not expect an error but got : %s", err)
+			}
+			if d := cmp.Diff(tt.want, got); d != "" {
+				t.Errorf("the taskSpec did not match what was expected diff: %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestGetStepActionsData_UsesProvenance(t *testing.T) {
+	withAuthSource := &v1.RefSource{
+		URI:    "with-auth-source",
+		Digest: map[string]string{"sha256": "abcd123456"},
+	}
+	runCLISource := &v1.RefSource{
+		URI:    "run-cli-source",
+		Digest: map[string]string{"sha256": "efgh789012"},
+	}
+	requester := stepActionsRequester{}
+	for name, sa := range map[string]struct {
+		yaml   string
+		source *v1.RefSource
+	}{
+		"with-auth": {yaml: `
+metadata:
+  name: with-auth
+spec:
+  command: ["with-auth", "--"]
+  uses:
+    ref:
+      resolver: foo
+      params:
+      - name: name
+        value: run-cli
+`, source: withAuthSource},
+		"run-cli": {yaml: `
+metadata:
+  name: run-cli
+spec:
+  image: cli-image
+  command: ["cli"]
+`, source: runCLISource},
+	} {
+		stepActionBytes, err := yaml.Marshal(parse.MustParseV1beta1StepAction(t, sa.yaml))
+		if err != nil {
+			t.Fatal("failed to marshal StepAction", err)
+		}
+		requester[name] = test.NewResolvedResource(stepActionBytes, map[string]string{}, sa.source, nil)
+	}
+
+	tr := &v1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "mytaskrun", Namespace: "default"},
+		Spec: v1.TaskRunSpec{
+			TaskSpec: &v1.TaskSpec{
+				Steps: []v1.Step{{
+					Name: "stepname",
+					Ref: &v1.Ref{
+						ResolverRef: v1.ResolverRef{
+							Resolver: "foo",
+							Params: v1.Params{{
+								Name:  "name",
+								Value: *v1.NewStructuredValues("with-auth"),
+							}},
+						},
+					},
+				}},
+			},
+		},
+	}
+	got, err := GetStepActionsData(t.Context(), *tr.Spec.TaskSpec, tr, fake.NewSimpleClientset(), nil, requester)
+	if err != nil {
+		t.Fatalf("Did not expect an error but got : %s", err)
+	}
+	wantSteps := []v1.Step{{
+		Name:    "stepname",
+		Image:   "cli-image",
+		Command: []string{"with-auth", "--"},
+		Args:    []string{"cli"},
+	}}
+	if d := cmp.Diff(wantSteps, got); d != "" {
+		t.Errorf("the taskSpec did not match what was expected diff: %s", diff.PrintWantGot(d))
+	}
+	wantStepStates := []v1.StepState{{
+		Name: "stepname",
+		Provenance: &v1.Provenance{
+			RefSource:        withAuthSource,
+			NestedRefSources: []*v1.RefSource{runCLISource},
+		},
+	}}
+	if d := cmp.Diff(wantStepStates, tr.Status.Steps); d != "" {
+		t.Errorf("the step states did not match what was expected diff: %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestGetStepActionsData_UsesError(t *testing.T) {
+	stepActionUsing := func(name, used string) *v1beta1.StepAction {
+		return &v1beta1.StepAction{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: v1beta1.StepActionSpec{
+				Uses: &v1beta1.StepActionUses{Ref: &v1.Ref{Name: used}},
+			},
+		}
+	}
+	leaf := &v1beta1.StepAction{
+		ObjectMeta: metav1.ObjectMeta{Name: "leaf", Namespace: "default"},
+		Spec: v1beta1.StepActionSpec{
+			Image:   "myimage",
+			Command: []string{"ls"},
+			Results: []v1.StepResult{{Name: "result", Type: v1.ResultsTypeArray}},
+		},
+	}
+	leafScript := &v1beta1.StepAction{
+		ObjectMeta: metav1.ObjectMeta{Name: "leaf-script", Namespace: "default"},
+		Spec: v1beta1.StepActionSpec{
+			Image:  "myimage",
+			Script: "echo hello",
+		},
+	}
+	conflictingResults := stepActionUsing("conflicting-results", "leaf")
+	conflictingResults.Spec.Results = []v1.StepResult{{Name: "result", Type: v1.ResultsTypeString}}
+	wrappingScript := stepActionUsing("wrapping-script", "leaf-script")
+	wrappingScript.Spec.Command = []string{"wrap"}
+
+	tests := []struct {
+		name          string
+		stepActionRef string
+		maxDepth      int
+		expectedError string
+	}{{
+		name:          "step action using itself",
+		stepActionRef: "self",
+		maxDepth:      1,
+		expectedError: `failed to resolve step ref for step "" (index 0): failed to resolve StepAction used by StepAction "self": cycle detected in StepActions uses: "self" -> "self"`,
+	}, {
+		name:          "cycle between step actions",
+		stepActionRef: "cycle-a",
+		maxDepth:      3,
+		expectedError: `failed to resolve step ref for step "" (index 0): failed to resolve StepAction used by StepAction "cycle-a": failed to resolve StepAction used by StepAction "cycle-b": cycle detected in StepActions uses: "cycle-a" -> "cycle-b" -> "cycle-a"`,
+	}, {
+		name:          "nesting too deep",
+		stepActionRef: "outer",
+		maxDepth:      1,
+		expectedError: `failed to resolve step ref for step "" (index 0): failed to resolve StepAction used by StepAction "outer": StepAction "middle" cannot use another StepAction: StepActions can only be nested 1 level(s) deep`,
+	}, {
+		name:          "nesting disabled",
+		stepActionRef: "middle",
+		maxDepth:      0,
+		expectedError: `failed to resolve step ref for step "" (index 0): StepAction "middle" cannot use another StepAction: StepActions can only be nested 0 level(s) deep`,
+	}, {
+		name:          "conflicting results",
+		stepActionRef: "conflicting-results",
+		maxDepth:      1,
+		expectedError: `failed to resolve step ref for step "" (index 0): failed to compose StepAction "conflicting-results" with the StepAction it uses: result "result" is declared with type "string" but the used StepAction declares it with type "array"`,
+	}, {
+		name:          "command wrapping a script",
+		stepActionRef: "wrapping-script",
+		maxDepth:      1,
+		expectedError: `failed to resolve step ref for step "" (index 0): failed to compose StepAction "wrapping-script" with the StepAction it uses: a command cannot wrap the script of the used StepAction`,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := config.ToContext(t.Context(), &config.Config{
+				Defaults: &config.Defaults{
+					DefaultStepRefConcurrencyLimit:   config.DefaultStepRefConcurrencyLimit,
+					DefaultMaxStepActionNestingDepth: tt.maxDepth,
+				},
+			})
+			tektonclient := fake.NewSimpleClientset(
+				stepActionUsing("self", "self"),
+				stepActionUsing("cycle-a", "cycle-b"),
+				stepActionUsing("cycle-b", "cycle-a"),
+				stepActionUsing("outer", "middle"),
+				stepActionUsing("middle", "leaf"),
+				leaf, leafScript, conflictingResults, wrappingScript,
+			)
+			tr := &v1.TaskRun{
+				ObjectMeta: metav1.ObjectMeta{Name: "mytaskrun", Namespace: "default"},
+				Spec: v1.TaskRunSpec{
+					TaskSpec: &v1.TaskSpec{
+						Steps: []v1.Step{{Ref: &v1.Ref{Name: tt.stepActionRef}}},
+					},
+				},
+			}
+
+			_, err := GetStepActionsData(ctx, *tr.Spec.TaskSpec, tr, tektonclient, nil, nil)
+			if err == nil {
+				t.Fatalf("Expected to get an error but did not find any.")
+			}
+			if d := cmp.Diff(tt.expectedError, err.Error()); d != "" {
+				t.Errorf("the expected error did not match what was received: %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
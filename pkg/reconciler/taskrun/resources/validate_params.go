@@ -2,6 +2,8 @@ package resources
 
 import (
 	"fmt"
+	"maps"
+	"sort"
 
 	pipelineErrors "github.com/tektoncd/pipeline/pkg/apis/pipeline/errors"
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
@@ -83,3 +85,55 @@ func validateStepHasStepActionParameters(stepParams v1.Params, stepActionDefault
 	}
 	return nil
 }
+
+// mergeStepActionObjectParams returns the params passed by a Step to a StepAction with the object params
+// merged with their default: the keys the Step doesn't pass are taken from the default of the param.
+// An error is returned when an object param has keys which are neither declared in its properties nor
+// in its default, or misses declared keys which have no default. Object params passed as a reference to another param are left as is.
+func mergeStepActionObjectParams(stepParams v1.Params, stepActionParams []v1.ParamSpec) (v1.Params, error) {
+	specs := make(map[string]v1.ParamSpec, len(stepActionParams))
+	for _, spec := range stepActionParams {
+		specs[spec.Name] = spec
+	}
+
+	merged := make(v1.Params, 0, len(stepParams))
+	for _, p := range stepParams {
+		spec, ok := specs[p.Name]
+		if !ok || spec.Type != v1.ParamTypeObject || p.Value.Type != v1.ParamTypeObject {
+			merged = append(merged, p)
+			continue
+		}
+
+		value := make(map[string]string, len(spec.Properties))
+		if spec.Default != nil {
+			maps.Copy(value, spec.Default.ObjectVal)
+		}
+
+		extra := []string{}
+		for key := range p.Value.ObjectVal {
+			_, declared := spec.Properties[key]
+			_, defaulted := value[key]
+			if !declared && !defaulted {
+				extra = append(extra, key)
+			}
+		}
+		if len(extra) > 0 {
+			sort.Strings(extra)
+			return nil, fmt.Errorf("object param %q passed by Step to StepAction has keys which are neither declared in its properties nor in its default: %v", p.Name, extra)
+		}
+		maps.Copy(value, p.Value.ObjectVal)
+
+		missing := []string{}
+		for key := range spec.Properties {
+			if _, ok := value[key]; !ok {
+				missing = append(missing, key)
+			}
+		}
+		if len(missing) > 0 {
+			sort.Strings(missing)
+			return nil, fmt.Errorf("object param %q passed by Step to StepAction is missing keys which have no default: %v", p.Name, missing)
+		}
+		merged = append(merged, v1.Param{Name: p.Name, Value: *v1.NewObject(value)})
+	}
+	return merged, nil
+}
@@ -0,0 +1,187 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package taskrun
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+	pkgreconciler "knative.dev/pkg/reconciler"
+)
+
+// addResultsExtractionFinalizer adds the ResultsExtractionFinalizer to pod when the results of its
+// TaskRun are extracted from the logs or the termination message of its results sidecar, so that the
+// pod, and the logs and status of its results sidecar, are kept when it is deleted, e.g. by a controller cleaning up completed pods,
+// until the results are extracted.
+func addResultsExtractionFinalizer(ctx context.Context, pod *corev1.Pod) {
+	if !config.FromContextOrDefaults(ctx).FeatureFlags.UsesResultsSidecar() {
+		return
+	}
+	isResultsSidecar := func(c corev1.Container) bool {
+		return c.Name == pipeline.ReservedResultsSidecarContainerName
+	}
+	if !slices.ContainsFunc(pod.Spec.Containers, isResultsSidecar) && !slices.ContainsFunc(pod.Spec.InitContainers, isResultsSidecar) {
+		return
+	}
+	if !slices.Contains(pod.Finalizers, pipeline.ResultsExtractionFinalizer) {
+		pod.Finalizers = append(pod.Finalizers, pipeline.ResultsExtractionFinalizer)
+	}
+}
+
+// removeResultsExtractionFinalizer removes the ResultsExtractionFinalizer from the pod of tr once tr
+// is done: its results were extracted and recorded in its status, or it ended without them, e.g. when
+// it was cancelled or timed out.
+func (c *Reconciler) removeResultsExtractionFinalizer(ctx context.Context, tr *v1.TaskRun) error {
+	if !tr.IsDone() {
+		return nil
+	}
+	return c.releasePod(ctx, tr)
+}
+
+// resultsFinalizerReconciler removes the ResultsExtractionFinalizer from the pods whose TaskRun is gone,
+// done or deleted, e.g. when the TaskRun was deleted before its own deletion was observed, as the TaskRun
+// may not be reconciled again. The pods are enqueued by the informer handlers and released from a
+// rate-limited workqueue, so that failed patches are retried with backoff.
+type resultsFinalizerReconciler struct {
+	pkgreconciler.LeaderAwareFuncs
+
+	c *Reconciler
+}
+
+var (
+	_ controller.Reconciler     = (*resultsFinalizerReconciler)(nil)
+	_ pkgreconciler.LeaderAware = (*resultsFinalizerReconciler)(nil)
+)
+
+// Reconcile releases the pod of key if it has the ResultsExtractionFinalizer and its TaskRun is gone,
+// done or deleted.
+func (r *resultsFinalizerReconciler) Reconcile(ctx context.Context, key string) error {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return controller.NewPermanentError(err)
+	}
+	if !r.IsLeaderFor(types.NamespacedName{Namespace: namespace, Name: name}) {
+		return nil
+	}
+	pod, err := r.c.podLister.Pods(namespace).Get(name)
+	switch {
+	case k8serrors.IsNotFound(err):
+		return nil
+	case err != nil:
+		return err
+	}
+	if !slices.Contains(pod.Finalizers, pipeline.ResultsExtractionFinalizer) {
+		return nil
+	}
+	owner := metav1.GetControllerOf(pod)
+	if owner == nil {
+		return nil
+	}
+	tr, err := r.c.taskRunLister.TaskRuns(namespace).Get(owner.Name)
+	switch {
+	case k8serrors.IsNotFound(err):
+	case err != nil:
+		return err
+	case tr.UID != owner.UID:
+		// The TaskRun of the pod was deleted and another one was created with the same name
+	case tr.DeletionTimestamp != nil, tr.IsDone():
+	default:
+		// The pod of a running TaskRun is released once the TaskRun is done
+		return nil
+	}
+	return r.c.removeFinalizer(ctx, pod, owner.Name)
+}
+
+// enqueueDeletedPod enqueues obj, a pod being deleted, if it is kept by the ResultsExtractionFinalizer.
+func enqueueDeletedPod(impl *controller.Impl) func(obj any) {
+	return func(obj any) {
+		pod, ok := obj.(*corev1.Pod)
+		if ok && pod.DeletionTimestamp != nil && slices.Contains(pod.Finalizers, pipeline.ResultsExtractionFinalizer) {
+			impl.Enqueue(pod)
+		}
+	}
+}
+
+// enqueuePodOfDeletedTaskRun enqueues the pod of obj, a deleted TaskRun, as deleted TaskRuns aren't
+// reconciled and their results are no longer needed.
+func enqueuePodOfDeletedTaskRun(impl *controller.Impl) func(obj any) {
+	return func(obj any) {
+		if d, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+			obj = d.Obj
+		}
+		tr, ok := obj.(*v1.TaskRun)
+		if !ok || tr.Status.PodName == "" {
+			return
+		}
+		impl.EnqueueKey(types.NamespacedName{Namespace: tr.Namespace, Name: tr.Status.PodName})
+	}
+}
+
+// releasePod removes the ResultsExtractionFinalizer from the pod of tr, if it has it.
+func (c *Reconciler) releasePod(ctx context.Context, tr *v1.TaskRun) error {
+	if tr.Status.PodName == "" {
+		return nil
+	}
+	pod, err := c.podLister.Pods(tr.Namespace).Get(tr.Status.PodName)
+	switch {
+	case k8serrors.IsNotFound(err):
+		return nil
+	case err != nil:
+		return err
+	}
+	return c.removeFinalizer(ctx, pod, tr.Name)
+}
+
+// removeFinalizer removes the ResultsExtractionFinalizer from pod, run by the TaskRun named taskRunName,
+// if it has it.
+func (c *Reconciler) removeFinalizer(ctx context.Context, pod *corev1.Pod, taskRunName string) error {
+	if !slices.Contains(pod.Finalizers, pipeline.ResultsExtractionFinalizer) {
+		return nil
+	}
+	finalizers := slices.DeleteFunc(slices.Clone(pod.Finalizers), func(f string) bool {
+		return f == pipeline.ResultsExtractionFinalizer
+	})
+	// The finalizers are tested first so that those added in the meantime aren't dropped.
+	patch, err := json.Marshal([]map[string]any{
+		{"op": "test", "path": "/metadata/finalizers", "value": pod.Finalizers},
+		{"op": "replace", "path": "/metadata/finalizers", "value": finalizers},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = c.KubeClientSet.CoreV1().Pods(pod.Namespace).Patch(ctx, pod.Name, types.JSONPatchType, patch, metav1.PatchOptions{})
+	switch {
+	case k8serrors.IsNotFound(err):
+		return nil
+	case err != nil:
+		return fmt.Errorf("failed to remove the finalizer %s from the pod %s of TaskRun %s: %w", pipeline.ResultsExtractionFinalizer, pod.Name, taskRunName, err)
+	}
+	logging.FromContext(ctx).Infof("Removed the finalizer %s from the pod %s of TaskRun %s", pipeline.ResultsExtractionFinalizer, pod.Name, taskRunName)
+	return nil
+}
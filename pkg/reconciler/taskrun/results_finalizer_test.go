@@ -0,0 +1,268 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package taskrun
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	cfgtesting "github.com/tektoncd/pipeline/pkg/apis/config/testing"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	listers "github.com/tektoncd/pipeline/pkg/client/listers/pipeline/v1"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	fakek8s "k8s.io/client-go/kubernetes/fake"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	ktesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/kmeta"
+	pkgreconciler "knative.dev/pkg/reconciler"
+)
+
+func TestAddResultsExtractionFinalizer(t *testing.T) {
+	resultsSidecar := corev1.Container{Name: pipeline.ReservedResultsSidecarContainerName}
+	for _, tc := range []struct {
+		name           string
+		resultsFrom    string
+		pod            corev1.Pod
+		wantFinalizers []string
+	}{{
+		name:           "results extracted from the logs of the results sidecar",
+		resultsFrom:    "sidecar-logs",
+		pod:            corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "step-a"}, resultsSidecar}}},
+		wantFinalizers: []string{pipeline.ResultsExtractionFinalizer},
+	}, {
+		name:           "results extracted from the logs of the native results sidecar",
+		resultsFrom:    "sidecar-logs",
+		pod:            corev1.Pod{Spec: corev1.PodSpec{InitContainers: []corev1.Container{resultsSidecar}, Containers: []corev1.Container{{Name: "step-a"}}}},
+		wantFinalizers: []string{pipeline.ResultsExtractionFinalizer},
+	}, {
+		name:           "results extracted from the termination message of the results sidecar",
+		resultsFrom:    "sidecar-volume",
+		pod:            corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "step-a"}, resultsSidecar}}},
+		wantFinalizers: []string{pipeline.ResultsExtractionFinalizer},
+	}, {
+		name:        "no results sidecar",
+		resultsFrom: "sidecar-logs",
+		pod:         corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "step-a"}}}},
+	}, {
+		name:        "results extracted from the termination messages",
+		resultsFrom: "termination-message",
+		pod:         corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "step-a"}}}},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := cfgtesting.SetFeatureFlags(t.Context(), t, map[string]string{"results-from": tc.resultsFrom})
+			addResultsExtractionFinalizer(ctx, &tc.pod)
+			if d := cmp.Diff(tc.wantFinalizers, tc.pod.Finalizers); d != "" {
+				t.Errorf("unexpected finalizers %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+// TestRemoveResultsExtractionFinalizer_CompetingDelete tests that the pod of a TaskRun whose results are
+// extracted from the logs of its results sidecar is kept when it is deleted while the TaskRun runs, and
+// is released, keeping the other finalizers, once the TaskRun is done.
+func TestRemoveResultsExtractionFinalizer_CompetingDelete(t *testing.T) {
+	ctx := t.Context()
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+		Name:       "run-pod",
+		Namespace:  "ns",
+		Finalizers: []string{"example.com/other", pipeline.ResultsExtractionFinalizer},
+	}}
+	kubeClientSet := fakek8s.NewSimpleClientset(pod)
+	// Like the API server, a pod with finalizers is only marked as deleted.
+	kubeClientSet.PrependReactor("delete", "pods", func(action ktesting.Action) (bool, runtime.Object, error) {
+		name := action.(ktesting.DeleteAction).GetName()
+		obj, err := kubeClientSet.Tracker().Get(corev1.SchemeGroupVersion.WithResource("pods"), "ns", name)
+		if err != nil {
+			return true, nil, err
+		}
+		p := obj.(*corev1.Pod)
+		if len(p.Finalizers) == 0 {
+			return false, nil, nil
+		}
+		now := metav1.Now()
+		p.DeletionTimestamp = &now
+		return true, nil, kubeClientSet.Tracker().Update(corev1.SchemeGroupVersion.WithResource("pods"), p, "ns")
+	})
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	c := &Reconciler{KubeClientSet: kubeClientSet, podLister: corev1listers.NewPodLister(indexer)}
+	getPod := func() *corev1.Pod {
+		t.Helper()
+		p, err := kubeClientSet.CoreV1().Pods("ns").Get(ctx, "run-pod", metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("expected the pod to be kept: %v", err)
+		}
+		if err := indexer.Update(p); err != nil {
+			t.Fatal(err)
+		}
+		return p
+	}
+	getPod()
+
+	tr := &v1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "run", Namespace: "ns"},
+		Status: v1.TaskRunStatus{
+			Status:              duckv1.Status{Conditions: duckv1.Conditions{{Type: apis.ConditionSucceeded, Status: corev1.ConditionUnknown}}},
+			TaskRunStatusFields: v1.TaskRunStatusFields{PodName: "run-pod"},
+		},
+	}
+
+	// A controller cleaning up completed pods deletes the pod before the results are extracted.
+	if err := kubeClientSet.CoreV1().Pods("ns").Delete(ctx, "run-pod", metav1.DeleteOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.removeResultsExtractionFinalizer(ctx, tr); err != nil {
+		t.Fatalf("removeResultsExtractionFinalizer: %v", err)
+	}
+	if p := getPod(); p.DeletionTimestamp == nil || len(p.Finalizers) != 2 {
+		t.Fatalf("expected the pod of the running TaskRun to be kept with its finalizers, got deletion timestamp %v and finalizers %v", p.DeletionTimestamp, p.Finalizers)
+	}
+
+	// The results are extracted and the TaskRun is done.
+	tr.Status.Conditions[0].Status = corev1.ConditionTrue
+	if err := c.removeResultsExtractionFinalizer(ctx, tr); err != nil {
+		t.Fatalf("removeResultsExtractionFinalizer: %v", err)
+	}
+	if d := cmp.Diff([]string{"example.com/other"}, getPod().Finalizers); d != "" {
+		t.Errorf("unexpected finalizers of the pod of the done TaskRun %s", diff.PrintWantGot(d))
+	}
+
+	// Reconciling the done TaskRun again, e.g. on resync, changes nothing, even once the pod is gone.
+	if err := c.removeResultsExtractionFinalizer(ctx, tr); err != nil {
+		t.Fatalf("removeResultsExtractionFinalizer: %v", err)
+	}
+	if err := indexer.Delete(getPod()); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.removeResultsExtractionFinalizer(ctx, tr); err != nil {
+		t.Fatalf("removeResultsExtractionFinalizer of a deleted pod: %v", err)
+	}
+}
+
+// TestResultsFinalizerReconciler tests that a pod kept by the ResultsExtractionFinalizer is released when
+// its TaskRun is gone, done or deleted, as the TaskRun may not be reconciled again.
+func TestResultsFinalizerReconciler(t *testing.T) {
+	running := &v1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "run", Namespace: "ns", UID: "run-uid"},
+		Status: v1.TaskRunStatus{
+			Status:              duckv1.Status{Conditions: duckv1.Conditions{{Type: apis.ConditionSucceeded, Status: corev1.ConditionUnknown}}},
+			TaskRunStatusFields: v1.TaskRunStatusFields{PodName: "run-pod"},
+		},
+	}
+	done := running.DeepCopy()
+	done.Status.Conditions[0].Status = corev1.ConditionTrue
+	recreated := running.DeepCopy()
+	recreated.UID = "other-uid"
+	now := metav1.Now()
+	deleted := running.DeepCopy()
+	deleted.DeletionTimestamp = &now
+
+	for _, tc := range []struct {
+		name         string
+		taskRun      *v1.TaskRun
+		notDeleted   bool
+		patchErr     error
+		wantErr      bool
+		wantReleased bool
+	}{{
+		name:         "TaskRun gone",
+		wantReleased: true,
+	}, {
+		name:         "TaskRun done",
+		taskRun:      done,
+		wantReleased: true,
+	}, {
+		name:         "TaskRun recreated with the same name",
+		taskRun:      recreated,
+		wantReleased: true,
+	}, {
+		name:         "TaskRun deleted while running",
+		taskRun:      deleted,
+		notDeleted:   true,
+		wantReleased: true,
+	}, {
+		name:    "TaskRun running",
+		taskRun: running,
+	}, {
+		name:       "pod of running TaskRun not deleted",
+		taskRun:    running,
+		notDeleted: true,
+	}, {
+		name:     "patch failing",
+		patchErr: errors.New("boom"),
+		wantErr:  true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := t.Context()
+			pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+				Name:            "run-pod",
+				Namespace:       "ns",
+				Finalizers:      []string{pipeline.ResultsExtractionFinalizer},
+				OwnerReferences: []metav1.OwnerReference{*kmeta.NewControllerRef(running)},
+			}}
+			if !tc.notDeleted {
+				pod.DeletionTimestamp = &now
+			}
+			kubeClientSet := fakek8s.NewSimpleClientset(pod)
+			if tc.patchErr != nil {
+				kubeClientSet.PrependReactor("patch", "pods", func(ktesting.Action) (bool, runtime.Object, error) {
+					return true, nil, tc.patchErr
+				})
+			}
+			pods := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+			if err := pods.Add(pod); err != nil {
+				t.Fatal(err)
+			}
+			taskRuns := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+			if tc.taskRun != nil {
+				if err := taskRuns.Add(tc.taskRun); err != nil {
+					t.Fatal(err)
+				}
+			}
+			r := &resultsFinalizerReconciler{c: &Reconciler{
+				KubeClientSet: kubeClientSet,
+				taskRunLister: listers.NewTaskRunLister(taskRuns),
+				podLister:     corev1listers.NewPodLister(pods),
+			}}
+			if err := r.Promote(pkgreconciler.UniversalBucket(), func(pkgreconciler.Bucket, types.NamespacedName) {}); err != nil {
+				t.Fatal(err)
+			}
+
+			// Failed patches are returned so that the pod is requeued with backoff
+			if err := r.Reconcile(ctx, "ns/run-pod"); (err != nil) != tc.wantErr {
+				t.Fatalf("Reconcile: expected an error: %t, got %v", tc.wantErr, err)
+			}
+
+			got, err := kubeClientSet.CoreV1().Pods("ns").Get(ctx, "run-pod", metav1.GetOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if released := len(got.Finalizers) == 0; released != tc.wantReleased {
+				t.Errorf("expected the pod to be released: %t, got finalizers %v", tc.wantReleased, got.Finalizers)
+			}
+		})
+	}
+}
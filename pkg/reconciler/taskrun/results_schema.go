@@ -0,0 +1,38 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package taskrun
+
+import (
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+)
+
+// setResultsSchema publishes the names, types and object properties of the results declared by the
+// TaskSpec of the successful TaskRun tr in its status.
+func setResultsSchema(tr *v1.TaskRun, ts *v1.TaskSpec) {
+	if ts == nil || len(ts.Results) == 0 || !tr.IsSuccessful() {
+		return
+	}
+	schema := make([]v1.ResultSchema, 0, len(ts.Results))
+	for _, r := range ts.Results {
+		s := v1.ResultSchema{Name: r.Name, Type: r.Type, Properties: r.Properties}
+		if s.Type == "" {
+			s.Type = v1.ResultsTypeString
+		}
+		schema = append(schema, s)
+	}
+	tr.Status.ResultsSchema = schema
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package taskrun
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+func TestSetResultsSchema(t *testing.T) {
+	imageProperties := map[string]v1.PropertySpec{
+		"url":    {Type: v1.ParamTypeString},
+		"digest": {Type: v1.ParamTypeString},
+	}
+	imageResult := v1.TaskResult{
+		Name:        "image",
+		Type:        v1.ResultsTypeObject,
+		Properties:  imageProperties,
+		Description: "The built image",
+	}
+
+	for _, tc := range []struct {
+		name    string
+		status  corev1.ConditionStatus
+		results []v1.TaskResult
+		want    []v1.ResultSchema
+	}{{
+		name: "object, array and string results",
+		results: []v1.TaskResult{
+			imageResult,
+			{Name: "tags", Type: v1.ResultsTypeArray},
+			{Name: "digest"},
+		},
+		want: []v1.ResultSchema{
+			{Name: "image", Type: v1.ResultsTypeObject, Properties: imageProperties},
+			{Name: "tags", Type: v1.ResultsTypeArray},
+			{Name: "digest", Type: v1.ResultsTypeString},
+		},
+	}, {
+		name: "no results",
+	}, {
+		name:    "failed TaskRun",
+		status:  corev1.ConditionFalse,
+		results: []v1.TaskResult{imageResult},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			status := corev1.ConditionTrue
+			if tc.status != "" {
+				status = tc.status
+			}
+			tr := &v1.TaskRun{Status: v1.TaskRunStatus{Status: duckv1.Status{
+				Conditions: duckv1.Conditions{{Type: apis.ConditionSucceeded, Status: status}},
+			}}}
+			setResultsSchema(tr, &v1.TaskSpec{Results: tc.results})
+
+			if d := cmp.Diff(tc.want, tr.Status.ResultsSchema); d != "" {
+				t.Errorf("results schema %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
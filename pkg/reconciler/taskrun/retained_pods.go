@@ -0,0 +1,111 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package taskrun
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/pkg/logging"
+)
+
+// retainFailedPod retains the pod of tr, if tr failed and matches the retain-failed-pods policy, so that
+// it can be investigated: the pod is labeled as retained and its owner references are removed so that it
+// outlives the TaskRun. At most policy.Count pods are retained per namespace and Task, the oldest ones
+// beyond that being deleted.
+func (c *Reconciler) retainFailedPod(ctx context.Context, tr *v1.TaskRun) error {
+	policy := config.FromContextOrDefaults(ctx).Defaults.RetainFailedPods
+	taskName := tr.Labels[pipeline.TaskLabelKey]
+	if tr.Status.PodRetained || tr.Status.PodName == "" || taskName == "" || !tr.IsFailure() || !policy.Matches(tr.Labels) {
+		return nil
+	}
+	logger := logging.FromContext(ctx)
+
+	patch, err := json.Marshal(map[string]any{
+		"metadata": map[string]any{
+			"labels": map[string]string{
+				pipeline.RetainedPodLabelKey: "true",
+				pipeline.TaskLabelKey:        taskName,
+			},
+			"ownerReferences": nil,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = c.KubeClientSet.CoreV1().Pods(tr.Namespace).Patch(ctx, tr.Status.PodName, types.MergePatchType, patch, metav1.PatchOptions{})
+	switch {
+	case k8serrors.IsNotFound(err):
+		// The pod was deleted, e.g. when the TaskRun was cancelled, there is nothing to retain.
+		return nil
+	case err != nil:
+		return fmt.Errorf("failed to retain the pod %s of TaskRun %s: %w", tr.Status.PodName, tr.Name, err)
+	}
+	tr.Status.PodRetained = true
+	logger.Infof("Retained the pod %s of failed TaskRun %s", tr.Status.PodName, tr.Name)
+
+	if err := c.deleteExcessRetainedPods(ctx, tr.Namespace, taskName, policy.Count); err != nil {
+		logger.Errorf("Failed to delete the excess retained pods of Task %s in namespace %s: %v", taskName, tr.Namespace, err)
+	}
+	return nil
+}
+
+// deleteExcessRetainedPods deletes the oldest pods retained for the Task taskName in namespace so that
+// at most count pods are retained.
+func (c *Reconciler) deleteExcessRetainedPods(ctx context.Context, namespace, taskName string, count int) error {
+	pods, err := c.KubeClientSet.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(labels.Set{
+			pipeline.RetainedPodLabelKey: "true",
+			pipeline.TaskLabelKey:        taskName,
+		}).String(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list the retained pods: %w", err)
+	}
+	if len(pods.Items) <= count {
+		return nil
+	}
+
+	retained := pods.Items
+	slices.SortFunc(retained, func(a, b corev1.Pod) int {
+		if n := a.CreationTimestamp.Compare(b.CreationTimestamp.Time); n != 0 {
+			return n
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+	var errs []error
+	for _, p := range retained[:len(retained)-count] {
+		if err := c.KubeClientSet.CoreV1().Pods(namespace).Delete(ctx, p.Name, metav1.DeleteOptions{}); err != nil && !k8serrors.IsNotFound(err) {
+			errs = append(errs, fmt.Errorf("failed to delete the retained pod %s: %w", p.Name, err))
+			continue
+		}
+		logging.FromContext(ctx).Infof("Deleted the retained pod %s of Task %s in namespace %s", p.Name, taskName, namespace)
+	}
+	return errors.Join(errs...)
+}
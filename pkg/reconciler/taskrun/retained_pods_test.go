@@ -0,0 +1,133 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package taskrun
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	cfgtesting "github.com/tektoncd/pipeline/pkg/apis/config/testing"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakek8s "k8s.io/client-go/kubernetes/fake"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/kmeta"
+)
+
+func retainedPodNames(t *testing.T, c *Reconciler) []string {
+	t.Helper()
+	pods, err := c.KubeClientSet.CoreV1().Pods("ns").List(t.Context(), metav1.ListOptions{LabelSelector: pipeline.RetainedPodLabelKey})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, p := range pods.Items {
+		if len(p.OwnerReferences) != 0 {
+			t.Errorf("expected the retained pod %s to have no owner references, got %v", p.Name, p.OwnerReferences)
+		}
+		names = append(names, p.Name)
+	}
+	return names
+}
+
+// TestRetainFailedPod tests that the pods of the last failed TaskRuns of a Task are retained, the oldest
+// retained pod being deleted when a fourth TaskRun fails with a retention window of three pods.
+func TestRetainFailedPod(t *testing.T) {
+	ctx := cfgtesting.SetDefaults(t.Context(), t, map[string]string{"retain-failed-pods": "{count: 3, selector: app=ci}"})
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	newTaskRun := func(i int, status corev1.ConditionStatus, runLabels map[string]string) (*v1.TaskRun, *corev1.Pod) {
+		tr := &v1.TaskRun{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "tekton.dev/v1", Kind: "TaskRun"},
+			ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("run-%d", i), Namespace: "ns", Labels: runLabels},
+			Status: v1.TaskRunStatus{
+				Status:              duckv1.Status{Conditions: duckv1.Conditions{{Type: apis.ConditionSucceeded, Status: status}}},
+				TaskRunStatusFields: v1.TaskRunStatusFields{PodName: fmt.Sprintf("run-%d-pod", i)},
+			},
+		}
+		pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Name:              tr.Status.PodName,
+			Namespace:         "ns",
+			Labels:            runLabels,
+			CreationTimestamp: metav1.NewTime(start.Add(time.Duration(i) * time.Minute)),
+			OwnerReferences:   []metav1.OwnerReference{*kmeta.NewControllerRef(tr)},
+		}}
+		return tr, pod
+	}
+	ciLabels := map[string]string{"app": "ci", pipeline.TaskLabelKey: "build"}
+
+	var taskRuns []*v1.TaskRun
+	kubeClientSet := fakek8s.NewSimpleClientset()
+	for i := range 4 {
+		tr, pod := newTaskRun(i, corev1.ConditionFalse, ciLabels)
+		taskRuns = append(taskRuns, tr)
+		if _, err := kubeClientSet.CoreV1().Pods("ns").Create(ctx, pod, metav1.CreateOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	succeeded, succeededPod := newTaskRun(4, corev1.ConditionTrue, ciLabels)
+	unselected, unselectedPod := newTaskRun(5, corev1.ConditionFalse, map[string]string{"app": "web", pipeline.TaskLabelKey: "build"})
+	for _, pod := range []*corev1.Pod{succeededPod, unselectedPod} {
+		if _, err := kubeClientSet.CoreV1().Pods("ns").Create(ctx, pod, metav1.CreateOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	c := &Reconciler{KubeClientSet: kubeClientSet}
+
+	for _, tr := range []*v1.TaskRun{succeeded, unselected} {
+		if err := c.retainFailedPod(ctx, tr); err != nil {
+			t.Fatalf("retainFailedPod(%s): %v", tr.Name, err)
+		}
+		if tr.Status.PodRetained {
+			t.Errorf("expected the pod of TaskRun %s not to be retained", tr.Name)
+		}
+	}
+
+	for i, want := range [][]string{
+		{"run-0-pod"},
+		{"run-0-pod", "run-1-pod"},
+		{"run-0-pod", "run-1-pod", "run-2-pod"},
+		{"run-1-pod", "run-2-pod", "run-3-pod"},
+	} {
+		if err := c.retainFailedPod(ctx, taskRuns[i]); err != nil {
+			t.Fatalf("retainFailedPod(%s): %v", taskRuns[i].Name, err)
+		}
+		if !taskRuns[i].Status.PodRetained {
+			t.Errorf("expected the pod of TaskRun %s to be retained", taskRuns[i].Name)
+		}
+		if d := cmp.Diff(want, retainedPodNames(t, c)); d != "" {
+			t.Errorf("unexpected retained pods after failure %d %s", i, diff.PrintWantGot(d))
+		}
+	}
+
+	// Reconciling a TaskRun whose pod is already retained, e.g. on resync, changes nothing.
+	if err := c.retainFailedPod(ctx, taskRuns[0]); err != nil {
+		t.Fatal(err)
+	}
+	if d := cmp.Diff([]string{"run-1-pod", "run-2-pod", "run-3-pod"}, retainedPodNames(t, c)); d != "" {
+		t.Errorf("unexpected retained pods after resync %s", diff.PrintWantGot(d))
+	}
+	if _, err := kubeClientSet.CoreV1().Pods("ns").Get(ctx, "run-0-pod", metav1.GetOptions{}); err == nil {
+		t.Error("expected the oldest retained pod to be deleted")
+	}
+}
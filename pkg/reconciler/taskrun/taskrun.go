@@ -65,6 +65,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/kubernetes"
 	corev1Listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
 	"k8s.io/utils/clock"
 	"knative.dev/pkg/apis"
 	"knative.dev/pkg/changeset"
@@ -87,6 +88,8 @@ type Reconciler struct {
 	spireClient              spire.ControllerAPIClient
 	taskRunLister            listers.TaskRunLister
 	limitrangeLister         corev1Listers.LimitRangeLister
+	namespaceLister          corev1Listers.NamespaceLister
+	configMapLister          corev1Listers.ConfigMapLister
 	podLister                corev1Listers.PodLister
 	verificationPolicyLister alphalisters.VerificationPolicyLister
 	entrypointCache          podconvert.EntrypointCache
@@ -94,6 +97,11 @@ type Reconciler struct {
 	pvcHandler               volumeclaim.PvcHandler
 	resolutionRequester      resolution.Requester
 	tracerProvider           trace.TracerProvider
+	namespaceFeatureFlags    *config.NamespaceFeatureFlags
+
+	// affinityAssistantPodLister gets the pods of the Affinity Assistants, which don't have the
+	// managed-by label of the pods listed by podLister.
+	affinityAssistantPodLister corev1Listers.PodLister
 
 	// Native-sidecar detection (ServerVersion + IsNativeSidecarSupport) when EnableKubernetesSidecar
 	// is set is memoized via sync.OnceValues after lazy init guarded by nativeSidecarOnce (#9755).
@@ -124,6 +132,9 @@ var (
 		CreateContainerConfigError: {},
 		CreateContainerError:       {},
 	}
+
+	// errResultExtractionMethodNotAllowed is returned when a TaskRun selects a results-from method it isn't allowed to
+	errResultExtractionMethodNotAllowed = errors.New("results-from method not allowed")
 )
 
 // ReconcileKind compares the actual state with the desired, and attempts to
@@ -140,11 +151,33 @@ func (c *Reconciler) ReconcileKind(ctx context.Context, tr *v1.TaskRun) pkgrecon
 		logger = logger.With(zap.String("traceID", spanCtx.TraceID().String()), zap.String("spanID", spanCtx.SpanID().String()))
 		ctx = logging.WithLogger(ctx, logger)
 	}
+	// Override the feature flags with the ones set in the namespace of the TaskRun, if any.
+	ctx, nsFlagsErr := c.namespaceFeatureFlags.ToContext(ctx, tr.Namespace)
+	if nsFlagsErr != nil {
+		logger.Errorf("Ignoring the feature flags of namespace %s for taskrun %s: %v", tr.Namespace, tr.Name, nsFlagsErr)
+	}
+	// Time the phases of the reconcile, which are recorded and logged when the reconcile is slow.
+	ctx, phases := tknreconciler.WithReconcilePhases(ctx, c.Clock)
+	defer phases.Record(ctx, c.metrics)
+
 	// Read the initial condition
 	before := tr.Status.GetCondition(apis.ConditionSucceeded)
 
 	// Record the duration and count after the reconcile cycle.
 	defer c.durationAndCountMetrics(ctx, tr, before)
+	// Record the spans of the TaskRun once it completes.
+	defer c.recordRunSpans(ctx, tr, before)
+	// Count the TaskRun as throttled, or not anymore, after the reconcile cycle.
+	defer c.throttledMetrics(ctx, tr)
+	// Count the attempts of the TaskRun archived for a retry during the reconcile cycle.
+	defer c.retriesMetrics(ctx, tr, before, len(tr.Status.RetriesStatus))
+
+	dedupeWorkspaceBindings(ctx, tr)
+
+	// Keep the TaskRun, if it is deleted before it completes, until its final status is recorded.
+	if err := c.syncFinalStatusFinalizer(ctx, tr); err != nil {
+		return err
+	}
 
 	// If the TaskRun is just starting, this will also set the starttime,
 	// from which the timeout will immediately begin counting down.
@@ -164,10 +197,23 @@ func (c *Reconciler) ReconcileKind(ctx context.Context, tr *v1.TaskRun) pkgrecon
 		events.Emit(ctx, nil, afterCondition, tr)
 	}
 
+	// Extract the results of the TaskRun with the method selected for it, if any.
+	ctx, resultExtractionErr := c.withResultExtractionMethod(ctx, tr)
+	if resultExtractionErr != nil && !errors.Is(resultExtractionErr, errResultExtractionMethodNotAllowed) {
+		return resultExtractionErr
+	}
+
 	// If the TaskRun is complete, run some post run fixtures when applicable
 	if tr.IsDone() {
 		logger.Infof("taskrun done : %s \n", tr.Name)
 
+		if err := c.retainFailedPod(ctx, tr); err != nil {
+			return err
+		}
+		if err := c.removeResultsExtractionFinalizer(ctx, tr); err != nil {
+			return err
+		}
+
 		// stopSidecars must run whenever we use Tekton-managed sidecars: TaskRun status only
 		// lists containers with the sidecar- prefix; injected sidecars are visible only on
 		// the Pod (see buildSidecarStopPatch). Cache ServerVersion + native-sidecar detection
@@ -176,13 +222,18 @@ func (c *Reconciler) ReconcileKind(ctx context.Context, tr *v1.TaskRun) pkgrecon
 		if err != nil {
 			return err
 		}
-		if useTektonSidecar {
+		// Retained pods are kept as they were when the TaskRun failed.
+		if useTektonSidecar && !tr.Status.PodRetained {
 			if err := c.stopSidecars(ctx, tr); err != nil {
 				return err
 			}
 		}
 
-		return c.finishReconcileUpdateEmitEvents(ctx, tr, before, nil)
+		if err := c.finishReconcileUpdateEmitEvents(ctx, tr, before, nil); err != nil {
+			return err
+		}
+		// Delete the TaskRun once its ttlSecondsAfterFinished elapsed, if any.
+		return c.deleteAfterTTL(ctx, tr)
 	}
 
 	// If the TaskRun is cancelled, kill resources and update status
@@ -222,6 +273,12 @@ func (c *Reconciler) ReconcileKind(ctx context.Context, tr *v1.TaskRun) pkgrecon
 		return c.finishReconcileUpdateEmitEvents(ctx, tr, before, err)
 	}
 
+	if resultExtractionErr != nil {
+		logger.Errorf("TaskRun %s/%s results-from override error: %v", tr.Namespace, tr.Name, resultExtractionErr)
+		tr.Status.MarkResourceFailed(v1.TaskRunReasonFailedValidation, resultExtractionErr)
+		return c.finishReconcileUpdateEmitEvents(ctx, tr, before, controller.NewPermanentError(resultExtractionErr))
+	}
+
 	// prepare fetches all required resources, validates them together with the
 	// taskrun, runs API conversions. In case of error we update, emit events and return.
 	_, rtr, err := c.prepare(ctx, tr)
@@ -248,6 +305,15 @@ func (c *Reconciler) ReconcileKind(ctx context.Context, tr *v1.TaskRun) pkgrecon
 		}
 	}
 
+	var earlyWaitTime time.Duration
+	if !tr.IsDone() {
+		earlyWaitTime = c.checkRunningSlow(ctx, tr)
+		// Wake up when the results sidecar is considered hung, which changes nothing in the pod.
+		if wait := c.resultsSidecarWaitTime(ctx, tr); wait > 0 && (earlyWaitTime == 0 || wait < earlyWaitTime) {
+			earlyWaitTime = wait
+		}
+	}
+
 	// Emit events (only when ConditionSucceeded was changed)
 	if err = c.finishReconcileUpdateEmitEvents(ctx, tr, before, err); err != nil {
 		return err
@@ -265,14 +331,109 @@ func (c *Reconciler) ReconcileKind(ctx context.Context, tr *v1.TaskRun) pkgrecon
 		// In both cases, we should not requeue based on timeout. The reconciler will
 		// still be triggered appropriately by pod watch events when the TaskRun changes.
 		if timeout == config.NoTimeoutDuration {
+			if earlyWaitTime > 0 {
+				return controller.NewRequeueAfter(earlyWaitTime)
+			}
 			return nil
 		}
 		waitTime := timeout - elapsed
+		// Wake up earlier if the TaskRun may be running slow or its results sidecar hung by then
+		if earlyWaitTime > 0 && earlyWaitTime < waitTime {
+			waitTime = earlyWaitTime
+		}
 		return controller.NewRequeueAfter(waitTime)
 	}
 	return nil
 }
 
+// checkRunningSlow reports the TaskRun as running slow, through a Warning event, an annotation and a metric,
+// the first time it is found running for longer than the configured multiple of its expected duration.
+// It returns how long until that happens, or 0 if there is nothing left to check.
+func (c *Reconciler) checkRunningSlow(ctx context.Context, tr *v1.TaskRun) time.Duration {
+	logger := logging.FromContext(ctx)
+	if tr.Status.StartTime == nil || tr.Annotations[v1.TaskRunRunningSlowAnnotation] == "true" {
+		return 0
+	}
+	expectedDuration := getExpectedDuration(logger, tr)
+	if expectedDuration <= 0 {
+		return 0
+	}
+	multiplier := config.FromContextOrDefaults(ctx).Defaults.DefaultExpectedDurationMultiplier
+	threshold := expectedDuration * time.Duration(multiplier)
+	elapsed := c.Clock.Since(tr.Status.StartTime.Time)
+	if elapsed < threshold {
+		return threshold - elapsed
+	}
+
+	if tr.Annotations == nil {
+		tr.Annotations = make(map[string]string, 1)
+	}
+	tr.Annotations[v1.TaskRunRunningSlowAnnotation] = "true"
+	controller.GetEventRecorder(ctx).Eventf(tr, corev1.EventTypeWarning, "TaskRunRunningSlow",
+		"TaskRun %q has been running for %s, more than %d times its expected duration of %s", tr.Name, elapsed.Round(time.Second), multiplier, expectedDuration)
+	if c.metrics != nil {
+		if err := c.metrics.RunningSlow(ctx, tr); err != nil {
+			logger.Warnf("Failed to log the metrics : %v", err)
+		}
+	}
+	return 0
+}
+
+// dedupeWorkspaceBindings binds each workspace of a TaskRun created before duplicate workspace bindings
+// were rejected to its first binding. The duplicates are noted, the first time they are found, through
+// a Warning event and the DuplicateWorkspaceBindingsAnnotation.
+func dedupeWorkspaceBindings(ctx context.Context, tr *v1.TaskRun) {
+	bindings, duplicates := workspace.DedupeBindings(tr.Spec.Workspaces)
+	if len(duplicates) == 0 {
+		return
+	}
+	tr.Spec.Workspaces = bindings
+	names := strings.Join(duplicates, ",")
+	if tr.Annotations[v1.DuplicateWorkspaceBindingsAnnotation] == names {
+		return
+	}
+	if tr.Annotations == nil {
+		tr.Annotations = make(map[string]string, 1)
+	}
+	tr.Annotations[v1.DuplicateWorkspaceBindingsAnnotation] = names
+	controller.GetEventRecorder(ctx).Eventf(tr, corev1.EventTypeWarning, "DuplicateWorkspaceBindings",
+		"Workspaces %q are bound more than once by TaskRun %q, only their first binding is used", duplicates, tr.Name)
+}
+
+// resultsSidecarWaitTime returns how long until the results sidecar of the pod of the TaskRun is
+// considered hung, or 0 if it isn't waited for.
+func (c *Reconciler) resultsSidecarWaitTime(ctx context.Context, tr *v1.TaskRun) time.Duration {
+	if tr.Status.PodName == "" {
+		return 0
+	}
+	pod, err := c.podLister.Pods(tr.Namespace).Get(tr.Status.PodName)
+	if err != nil {
+		return 0
+	}
+	deadline, ok := podconvert.ResultsSidecarGraceDeadline(ctx, pod.Status)
+	if !ok {
+		return 0
+	}
+	// Wake up right after the deadline so that the results sidecar is found hung.
+	return deadline.Sub(c.Clock.Now()) + time.Second
+}
+
+// getExpectedDuration returns the expected duration of the TaskRun, as set by its PipelineTask
+// or else by its Task, or 0 if none is set.
+func getExpectedDuration(logger *zap.SugaredLogger, tr *v1.TaskRun) time.Duration {
+	if value, ok := tr.Annotations[v1.PipelineTaskExpectedDurationAnnotation]; ok {
+		expectedDuration, err := time.ParseDuration(value)
+		if err == nil {
+			return expectedDuration
+		}
+		logger.Warnf("Ignoring invalid %s annotation %q: %v", v1.PipelineTaskExpectedDurationAnnotation, value, err)
+	}
+	if tr.Status.TaskSpec != nil && tr.Status.TaskSpec.ExpectedDuration != nil {
+		return tr.Status.TaskSpec.ExpectedDuration.Duration
+	}
+	return 0
+}
+
 func (c *Reconciler) checkPodFailed(ctx context.Context, tr *v1.TaskRun) (bool, v1.TaskRunReason, string) {
 	for _, step := range tr.Status.Steps {
 		if step.Waiting == nil {
@@ -382,6 +543,38 @@ func (c *Reconciler) durationAndCountMetrics(ctx context.Context, tr *v1.TaskRun
 	}
 }
 
+func (c *Reconciler) throttledMetrics(ctx context.Context, tr *v1.TaskRun) {
+	if c.metrics == nil {
+		return
+	}
+	if err := c.metrics.ThrottledTaskRun(ctx, tr); err != nil {
+		logging.FromContext(ctx).Warnf("Failed to log the throttled taskruns : %v", err)
+	}
+}
+
+func (c *Reconciler) retriesMetrics(ctx context.Context, tr *v1.TaskRun, beforeCondition *apis.Condition, retriesBefore int) {
+	if c.metrics == nil {
+		return
+	}
+	if err := c.metrics.Retries(ctx, tr, beforeCondition, retriesBefore); err != nil {
+		logging.FromContext(ctx).Warnf("Failed to log the retries of taskruns : %v", err)
+	}
+}
+
+// taskRunDeleted stops counting a deleted TaskRun as throttled and tracking its retries, as deleted
+// TaskRuns aren't reconciled anymore.
+func (c *Reconciler) taskRunDeleted(ctx context.Context, obj any) {
+	if d, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = d.Obj
+	}
+	tr, ok := obj.(*v1.TaskRun)
+	if !ok {
+		return
+	}
+	c.throttledMetrics(ctx, tr)
+	c.retriesMetrics(ctx, tr, tr.Status.GetCondition(apis.ConditionSucceeded), len(tr.Status.RetriesStatus))
+}
+
 // useTektonSidecarMode returns whether the done path should run stopSidecars (Tekton nop
 // image) vs skipping it for native Kubernetes sidecars. When EnableKubernetesSidecar is enabled,
 // ServerVersion is queried at most once per reconciler; later reconciles reuse the memoized result.
@@ -414,6 +607,7 @@ func newNativeSidecarFromCluster(client kubernetes.Interface, log *zap.SugaredLo
 func (c *Reconciler) stopSidecars(ctx context.Context, tr *v1.TaskRun) error {
 	ctx, span := c.tracerProvider.Tracer(TracerName).Start(ctx, "stopSidecars")
 	defer span.End()
+	defer tknreconciler.StartReconcilePhase(ctx, tknreconciler.ReconcilePhaseResources)()
 	logger := logging.FromContext(ctx)
 	// do not continue without knowing the associated pod
 	if tr.Status.PodName == "" {
@@ -429,7 +623,13 @@ func (c *Reconciler) stopSidecars(ctx context.Context, tr *v1.TaskRun) error {
 		}
 	}
 
-	pod, err := podconvert.StopSidecars(ctx, c.Images.NopImage, c.KubeClientSet, tr.Namespace, tr.Status.PodName)
+	// The sidecars are stopped with the nop image of the helper image set of the TaskRun, which runs on the
+	// nodes the pod was scheduled on, falling back to the one of the controller if the set isn't configured anymore.
+	images, err := podconvert.HelperImages(ctx, c.Images, tr.Annotations)
+	if err != nil {
+		logger.Warnf("Stopping the sidecars of TaskRun %s with the nop image of the controller: %v", tr.Name, err)
+	}
+	pod, err := podconvert.StopSidecars(ctx, images.NopImage, c.KubeClientSet, tr.Namespace, tr.Status.PodName, c.Clock.Now())
 	if err == nil {
 		// Check if any SidecarStatuses are still shown as Running after stopping
 		// Sidecars. If any Running, update SidecarStatuses based on Pod ContainerStatuses.
@@ -457,11 +657,12 @@ func (c *Reconciler) stopSidecars(ctx context.Context, tr *v1.TaskRun) error {
 func (c *Reconciler) finishReconcileUpdateEmitEvents(ctx context.Context, tr *v1.TaskRun, beforeCondition *apis.Condition, previousError error) error {
 	ctx, span := c.tracerProvider.Tracer(TracerName).Start(ctx, "finishReconcileUpdateEmitEvents")
 	defer span.End()
+	defer tknreconciler.StartReconcilePhase(ctx, tknreconciler.ReconcilePhaseStatusComputation)()
 	logger := logging.FromContext(ctx)
 
 	afterCondition := tr.Status.GetCondition(apis.ConditionSucceeded)
 	if afterCondition.IsFalse() && !tr.IsCancelled() && tr.IsRetriable() {
-		retryTaskRun(tr, afterCondition.Message)
+		retryTaskRun(tr, afterCondition.Message, config.FromContextOrDefaults(ctx).FeatureFlags.EnableRetriesStatusTrimming)
 		afterCondition = tr.Status.GetCondition(apis.ConditionSucceeded)
 	}
 	events.Emit(ctx, beforeCondition, afterCondition, tr)
@@ -506,6 +707,8 @@ func (c *Reconciler) prepare(ctx context.Context, tr *v1.TaskRun) (*v1.TaskSpec,
 	}
 	getTaskfunc := resources.GetTaskFuncFromTaskRun(ctx, c.KubeClientSet, c.PipelineClientSet, c.resolutionRequester, tr, vp)
 
+	endResolution := tknreconciler.StartReconcilePhase(ctx, tknreconciler.ReconcilePhaseResolution)
+	defer endResolution()
 	taskMeta, taskSpec, err := resources.GetTaskData(ctx, tr, getTaskfunc)
 	switch {
 	case errors.Is(err, remote.ErrRequestInProgress):
@@ -556,6 +759,7 @@ func (c *Reconciler) prepare(ctx context.Context, tr *v1.TaskRun) (*v1.TaskSpec,
 			logger.Errorf("Failed to store TaskSpec on TaskRun.Status for taskrun %s: %v", tr.Name, err)
 		}
 	}
+	endResolution()
 
 	if taskMeta.VerificationResult != nil {
 		switch taskMeta.VerificationResult.VerificationResultType {
@@ -590,6 +794,7 @@ func (c *Reconciler) prepare(ctx context.Context, tr *v1.TaskRun) (*v1.TaskSpec,
 		Kind:     resources.GetTaskKind(tr),
 	}
 
+	defer tknreconciler.StartReconcilePhase(ctx, tknreconciler.ReconcilePhaseValidation)()
 	if err := func() error {
 		_, span := c.tracerProvider.Tracer(TracerName).Start(ctx, "validateTaskSpecRequestResources")
 		defer span.End()
@@ -622,6 +827,12 @@ func (c *Reconciler) prepare(ctx context.Context, tr *v1.TaskRun) (*v1.TaskSpec,
 		}
 	}
 
+	if err := ValidateParamConstraints(tr.Spec.Params, rtr.TaskSpec.Params); err != nil {
+		logger.Errorf("TaskRun %q Param constraints validation failed: %v", tr.Name, err)
+		tr.Status.MarkResourceFailed(v1.TaskRunReasonInvalidParamValue, err)
+		return nil, nil, controller.NewPermanentError(err)
+	}
+
 	if err := func() error {
 		_, span := c.tracerProvider.Tracer(TracerName).Start(ctx, "ValidateParamArrayIndex")
 		defer span.End()
@@ -661,7 +872,7 @@ func (c *Reconciler) prepare(ctx context.Context, tr *v1.TaskRun) (*v1.TaskSpec,
 		return nil, nil, controller.NewPermanentError(err)
 	}
 
-	aaBehavior, err := affinityassistant.GetAffinityAssistantBehavior(ctx)
+	aaBehavior, err := affinityassistant.GetRunAffinityAssistantBehavior(ctx, tr.Annotations[affinityassistant.CoscheduleAnnotation])
 	if err != nil {
 		return nil, nil, controller.NewPermanentError(err)
 	}
@@ -683,6 +894,12 @@ func (c *Reconciler) prepare(ctx context.Context, tr *v1.TaskRun) (*v1.TaskSpec,
 		return nil, nil, controller.NewPermanentError(err)
 	}
 
+	if _, err := podconvert.HelperImages(ctx, c.Images, tr.Annotations); err != nil {
+		logger.Errorf("TaskRun %q helper image set is invalid: %v", tr.Name, err)
+		tr.Status.MarkResourceFailed(v1.TaskRunReasonFailedValidation, err)
+		return nil, nil, controller.NewPermanentError(err)
+	}
+
 	return taskSpec, rtr, nil
 }
 
@@ -713,6 +930,12 @@ func (c *Reconciler) reconcile(ctx context.Context, tr *v1.TaskRun, rtr *resourc
 			logger.Errorf("Error getting pod %q: %v", tr.Status.PodName, err)
 			return err
 		}
+		if (pod == nil || pod.DeletionTimestamp != nil) && recreatesDeletedPod(ctx, tr) {
+			if err := c.replaceDeletedPod(ctx, tr); err != nil {
+				return err
+			}
+			pod = nil
+		}
 	} else {
 		// List pods that have a label with this TaskRun name.  Do not include other labels from the
 		// TaskRun in this selector.  The user could change them during the lifetime of the TaskRun so the
@@ -725,7 +948,8 @@ func (c *Reconciler) reconcile(ctx context.Context, tr *v1.TaskRun, rtr *resourc
 		}
 		for index := range pos {
 			po := pos[index]
-			if metav1.IsControlledBy(po, tr) && !podconvert.DidTaskRunFail(po) && !podconvert.IsPodArchived(po, &tr.Status) {
+			// The pod running the isolated steps is found through the pod running the other steps
+			if metav1.IsControlledBy(po, tr) && !podconvert.IsIsolatedPod(po) && !podconvert.DidTaskRunFail(po) && !podconvert.IsPodArchived(po, &tr.Status) {
 				pod = po
 			}
 		}
@@ -734,14 +958,12 @@ func (c *Reconciler) reconcile(ctx context.Context, tr *v1.TaskRun, rtr *resourc
 	// Please note that this block is required to run before `applyParamsContextsResultsAndWorkspaces` is called the first time,
 	// and that `applyParamsContextsResultsAndWorkspaces` _must_ be called on every reconcile.
 	if pod == nil && tr.HasVolumeClaimTemplate() {
-		for _, ws := range tr.Spec.Workspaces {
-			if err := c.pvcHandler.CreatePVCFromVolumeClaimTemplate(ctx, ws, *kmeta.NewControllerRef(tr), tr.Namespace); err != nil {
-				logger.Errorf("Failed to create PVC for TaskRun %s: %v", tr.Name, err)
-				tr.Status.MarkResourceFailed(volumeclaim.ReasonCouldntCreateWorkspacePVC,
-					fmt.Errorf("failed to create PVC for TaskRun %s workspaces correctly: %w",
-						fmt.Sprintf("%s/%s", tr.Namespace, tr.Name), err))
-				return controller.NewPermanentError(err)
-			}
+		if err := c.pvcHandler.CreatePVCsForWorkspaces(ctx, tr.Spec.Workspaces, *kmeta.NewControllerRef(tr), tr.Namespace); err != nil {
+			logger.Errorf("Failed to create PVC for TaskRun %s: %v", tr.Name, err)
+			tr.Status.MarkResourceFailed(volumeclaim.ReasonCouldntCreateWorkspacePVC,
+				fmt.Errorf("failed to create PVC for TaskRun %s workspaces correctly: %w",
+					fmt.Sprintf("%s/%s", tr.Namespace, tr.Name), err))
+			return controller.NewPermanentError(err)
 		}
 
 		taskRunWorkspaces := applyVolumeClaimTemplates(tr.Spec.Workspaces, *kmeta.NewControllerRef(tr))
@@ -767,17 +989,22 @@ func (c *Reconciler) reconcile(ctx context.Context, tr *v1.TaskRun, rtr *resourc
 	if pod == nil {
 		pod, err = c.createPod(ctx, ts, tr, rtr, workspaceVolumes)
 		if err != nil {
+			if isExceededResourceQuotaError(err) {
+				recorder.Eventf(tr, corev1.EventTypeWarning, podconvert.ReasonExceededResourceQuota, "Resource quota exceeded to create the pod of TaskRun %q", tr.Name)
+			}
 			newErr := c.handlePodCreationError(tr, err)
 			logger.Errorf("Failed to create task run pod for taskrun %q: %v", tr.Name, newErr)
 			return newErr
 		}
 	}
 
+	c.trackFeatureFlagUsage(ctx, tr, pod)
+
 	if podconvert.IsPodExceedingNodeResources(pod) {
 		recorder.Eventf(tr, corev1.EventTypeWarning, podconvert.ReasonExceededNodeResources, "Insufficient resources to schedule pod %q", pod.Name)
 	}
 
-	if podconvert.SidecarsReady(pod.Status) {
+	if podconvert.SidecarsReady(pod.Status) && podconvert.ReadinessGatesReady(pod) {
 		if err := podconvert.UpdateReady(ctx, c.KubeClientSet, *pod); err != nil {
 			return err
 		}
@@ -786,12 +1013,70 @@ func (c *Reconciler) reconcile(ctx context.Context, tr *v1.TaskRun, rtr *resourc
 		}
 	}
 
+	statusPod, err := c.mergeIsolatedPod(ctx, tr, pod, rtr.TaskSpec)
+	if k8serrors.IsNotFound(err) {
+		err = fmt.Errorf("the pod %s running the isolated steps of TaskRun %s was deleted", tr.Status.IsolatedPodName, tr.Name)
+		tr.Status.MarkResourceFailed(v1.TaskRunReasonFailed, err)
+		return controller.NewPermanentError(err)
+	} else if err != nil {
+		logger.Errorf("Error getting pod %q: %v", tr.Status.IsolatedPodName, err)
+		return err
+	}
+
 	// Convert the Pod's status to the equivalent TaskRun Status.
-	tr.Status, err = podconvert.MakeTaskRunStatus(ctx, logger, *tr, pod, c.KubeClientSet, rtr.TaskSpec)
+	endStatusComputation := tknreconciler.StartReconcilePhase(ctx, tknreconciler.ReconcilePhaseStatusComputation)
+	observedAt := c.Clock.Now()
+	tr.Status, err = podconvert.Status(ctx, podconvert.ObserveOptions{
+		TaskRun:    tr,
+		Pod:        statusPod,
+		TaskSpec:   rtr.TaskSpec,
+		KubeClient: c.KubeClientSet,
+		Logger:     logger,
+		ObservedAt: observedAt,
+
+		AffinityAssistantPodLister: c.affinityAssistantPodLister,
+	})
+	endStatusComputation()
 	if err != nil {
 		return err
 	}
 
+	// The completion time was set to the start time if it preceded it, the TaskRun records by how much.
+	if tr.IsDone() {
+		if skew := podconvert.ClockSkew(&tr.Status, observedAt); skew > 0 {
+			if tr.Annotations == nil {
+				tr.Annotations = map[string]string{}
+			}
+			tr.Annotations[podconvert.ClockSkewAnnotation] = skew.String()
+		}
+	}
+
+	// With "enable-step-fail-fast", the sidecars are stopped as soon as a step fails the TaskRun,
+	// rather than once the steps skipped after it exited.
+	if config.FromContextOrDefaults(ctx).FeatureFlags.EnableStepFailFast && !tr.IsDone() && podconvert.DidTaskRunFail(statusPod) {
+		useTektonSidecar, err := c.useTektonSidecarMode(ctx, logger)
+		if err != nil {
+			return err
+		}
+		if useTektonSidecar {
+			if err := c.stopSidecars(ctx, tr); err != nil {
+				return err
+			}
+		}
+	}
+
+	// A sidecar with onError "stopAndFail" which exits with a non-zero exit code fails the TaskRun
+	// right away, rather than leaving the steps relying on it to fail.
+	if !tr.IsDone() {
+		if sc, ok := podconvert.FailedSidecar(&tr.Status, statusPod, rtr.TaskSpec); ok {
+			message := fmt.Sprintf("sidecar %q exited with code %d", sc.Name, sc.Terminated.ExitCode)
+			if sc.Terminated.Message != "" {
+				message = fmt.Sprintf("%s: %s", message, sc.Terminated.Message)
+			}
+			return c.failTaskRun(ctx, tr, v1.TaskRunReasonSidecarStopAndFail, message)
+		}
+	}
+
 	if err := func() error {
 		_, span := c.tracerProvider.Tracer(TracerName).Start(ctx, "validateTaskRunResults")
 		defer span.End()
@@ -800,6 +1085,7 @@ func (c *Reconciler) reconcile(ctx context.Context, tr *v1.TaskRun, rtr *resourc
 		tr.Status.MarkResourceFailed(v1.TaskRunReasonFailedValidation, err)
 		return err
 	}
+	setResultsSchema(tr, rtr.TaskSpec)
 
 	logger.Infof("Successfully reconciled taskrun %s/%s with status: %#v", tr.Name, tr.Namespace, tr.Status.GetCondition(apis.ConditionSucceeded))
 	return nil
@@ -873,6 +1159,69 @@ func (c *Reconciler) updateLabelsAndAnnotations(ctx context.Context, tr *v1.Task
 	return newTr, nil
 }
 
+// withResultExtractionMethod returns ctx with the "results-from" feature flag set to the method the results
+// of the TaskRun are extracted with: the method its pod was created with, once it has one, or otherwise the
+// method selected by its ResultExtractionMethodAnnotation, which must be allowed by "allowed-results-from-overrides".
+func (c *Reconciler) withResultExtractionMethod(ctx context.Context, tr *v1.TaskRun) (context.Context, error) {
+	cfg := config.FromContextOrDefaults(ctx)
+	method, ok := tr.Annotations[v1.ResultExtractionMethodAnnotation]
+	if tr.Status.PodName != "" {
+		pod, err := c.podLister.Pods(tr.Namespace).Get(tr.Status.PodName)
+		switch {
+		case err == nil:
+			// The method was checked when the pod was created
+			method, ok = pod.Annotations[v1.ResultExtractionMethodAnnotation]
+			if !ok || method == cfg.FeatureFlags.ResultExtractionMethod {
+				return ctx, nil
+			}
+			return withResultExtractionMethodFlag(ctx, cfg, method), nil
+		case !k8serrors.IsNotFound(err):
+			return ctx, err
+		}
+	}
+	if !ok || method == cfg.FeatureFlags.ResultExtractionMethod {
+		return ctx, nil
+	}
+	if !cfg.FeatureFlags.IsResultExtractionMethodAllowed(method) {
+		return ctx, fmt.Errorf("%w: %q selected by annotation %q isn't listed by feature flag %q: %q", errResultExtractionMethodNotAllowed,
+			method, v1.ResultExtractionMethodAnnotation, config.AllowedResultExtractionMethodsKey, cfg.FeatureFlags.AllowedResultExtractionMethods)
+	}
+	return withResultExtractionMethodFlag(ctx, cfg, method), nil
+}
+
+// withResultExtractionMethodFlag returns ctx with a copy of cfg whose "results-from" feature flag is set to method.
+func withResultExtractionMethodFlag(ctx context.Context, cfg *config.Config, method string) context.Context {
+	withMethod := *cfg
+	withMethod.FeatureFlags = cfg.FeatureFlags.DeepCopy()
+	withMethod.FeatureFlags.ResultExtractionMethod = method
+	return config.ToContext(ctx, &withMethod)
+}
+
+// trackFeatureFlagUsage records the feature flags whose gated behavior is used by the pod of the TaskRun.
+func (c *Reconciler) trackFeatureFlagUsage(ctx context.Context, tr *v1.TaskRun, pod *corev1.Pod) {
+	track := func(flag string) {
+		tknreconciler.TrackFeatureFlagUsage(ctx, tr.Status.Provenance, c.metrics, tr.Namespace, flag)
+	}
+	featureFlags := config.FromContextOrDefaults(ctx).FeatureFlags
+	if slices.ContainsFunc(pod.Spec.Containers, func(c corev1.Container) bool {
+		return c.Name == pipeline.ReservedResultsSidecarContainerName
+	}) {
+		track(config.ResultExtractionMethodKey)
+	}
+	if tr.Spec.Debug != nil && tr.Spec.Debug.NeedsDebug() && featureFlags.EnableAPIFields == config.AlphaAPIFields {
+		track(config.DebugFeatureUsage)
+	}
+	if podconvert.IsolatedPodName(pod) != "" {
+		track(config.IsolatedStepsRuntimeClassKey)
+	}
+	if featureFlags.EnableStepDirectoryIsolation {
+		track(config.EnableStepDirectoryIsolation)
+	}
+	if config.IsSpireEnabled(ctx) {
+		track(config.EnforceNonfalsifiabilityKey)
+	}
+}
+
 func (c *Reconciler) handlePodCreationError(tr *v1.TaskRun, err error) error {
 	switch {
 	case isResourceQuotaConflictError(err):
@@ -887,8 +1236,16 @@ func (c *Reconciler) handlePodCreationError(tr *v1.TaskRun, err error) error {
 		return controller.NewRequeueAfter(time.Minute)
 	case isTaskRunValidationFailed(err):
 		tr.Status.MarkResourceFailed(v1.TaskRunReasonFailedValidation, err)
+	case isImageDigestResolutionFailed(err):
+		err = controller.NewPermanentError(err)
+		tr.Status.MarkResourceFailed(podconvert.ReasonImageDigestResolutionFailed, err)
 	case k8serrors.IsAlreadyExists(err):
 		tr.Status.MarkResourceOngoing(podconvert.ReasonPodPending, "tried to create pod, but it already exists")
+	case isPodSecurityPreflightFailed(err):
+		err = controller.NewPermanentError(err)
+		tr.Status.MarkResourceFailed(podconvert.ReasonTaskRunPodSecurityViolation, err)
+	case isPodSecurityViolation(err):
+		tr.Status.MarkResourceFailed(podconvert.ReasonPodSecurityViolation, errors.New(podconvert.PodSecurityViolationMessage(err.Error())))
 	case isPodAdmissionFailed(err):
 		tr.Status.MarkResourceFailed(podconvert.ReasonPodAdmissionFailed, err)
 	default:
@@ -903,6 +1260,10 @@ func (c *Reconciler) handlePodCreationError(tr *v1.TaskRun, err error) error {
 		}
 		err = controller.NewPermanentError(errors.New(msg))
 		tr.Status.MarkResourceFailed(podconvert.ReasonPodCreationFailed, err)
+		// The steps never get containers, they are listed as waiting for the pod which couldn't be created.
+		if len(tr.Status.Steps) == 0 {
+			tr.Status.Steps = podconvert.WaitingStepStates(tr.Status.TaskSpec, podconvert.ReasonPodCreationFailed, msg)
+		}
 	}
 	return err
 }
@@ -914,6 +1275,7 @@ func (c *Reconciler) handlePodCreationError(tr *v1.TaskRun, err error) error {
 func (c *Reconciler) failTaskRun(ctx context.Context, tr *v1.TaskRun, reason v1.TaskRunReason, message string) error {
 	ctx, span := c.tracerProvider.Tracer(TracerName).Start(ctx, "failTaskRun")
 	defer span.End()
+	defer tknreconciler.StartReconcilePhase(ctx, tknreconciler.ReconcilePhaseResources)()
 	logger := logging.FromContext(ctx)
 
 	logger.Warnf("stopping task run %q because of %q", tr.Name, reason)
@@ -934,16 +1296,22 @@ func (c *Reconciler) failTaskRun(ctx context.Context, tr *v1.TaskRun, reason v1.
 	// See https://github.com/tektoncd/pipeline/issues/8293 for more details.
 	terminateStepsInPod(tr, reason)
 
-	var err error
-	if (reason == v1.TaskRunReasonCancelled || reason == v1.TaskRunReasonTimedOut) && (config.FromContextOrDefaults(ctx).FeatureFlags.EnableKeepPodOnCancel) {
-		logger.Infof("Canceling task run %q by entrypoint, Reason: %s", tr.Name, reason)
-		err = podconvert.CancelPod(ctx, c.KubeClientSet, tr.Namespace, tr.Status.PodName)
-	} else {
-		err = c.KubeClientSet.CoreV1().Pods(tr.Namespace).Delete(ctx, tr.Status.PodName, metav1.DeleteOptions{})
+	podNames := []string{tr.Status.PodName}
+	if tr.Status.IsolatedPodName != "" {
+		podNames = append(podNames, tr.Status.IsolatedPodName)
 	}
-	if err != nil && !k8serrors.IsNotFound(err) {
-		logger.Errorf("Failed to terminate pod %s: %v", tr.Status.PodName, err)
-		return err
+	for _, podName := range podNames {
+		var err error
+		if (reason == v1.TaskRunReasonCancelled || reason == v1.TaskRunReasonTimedOut) && (config.FromContextOrDefaults(ctx).FeatureFlags.EnableKeepPodOnCancel) {
+			logger.Infof("Canceling task run %q by entrypoint, Reason: %s", tr.Name, reason)
+			err = podconvert.CancelPod(ctx, c.KubeClientSet, tr.Namespace, podName)
+		} else {
+			err = c.KubeClientSet.CoreV1().Pods(tr.Namespace).Delete(ctx, podName, metav1.DeleteOptions{})
+		}
+		if err != nil && !k8serrors.IsNotFound(err) {
+			logger.Errorf("Failed to terminate pod %s: %v", podName, err)
+			return err
+		}
 	}
 
 	return nil
@@ -990,9 +1358,24 @@ func (c *Reconciler) updateStepStatusesFromPod(ctx context.Context, tr *v1.TaskR
 		return err
 	}
 
-	// Update step statuses from pod using the existing MakeTaskRunStatus function
+	if merged, err := c.mergeIsolatedPod(ctx, tr, pod, tr.Status.TaskSpec); err == nil {
+		pod = merged
+	} else if !k8serrors.IsNotFound(err) {
+		return err
+	}
+
+	// Update step statuses from pod using the existing Status function
 	// This ensures consistency with the normal reconciliation path
-	status, err := podconvert.MakeTaskRunStatus(ctx, logger, *tr, pod, c.KubeClientSet, tr.Status.TaskSpec)
+	status, err := podconvert.Status(ctx, podconvert.ObserveOptions{
+		TaskRun:    tr,
+		Pod:        pod,
+		TaskSpec:   tr.Status.TaskSpec,
+		KubeClient: c.KubeClientSet,
+		Logger:     logger,
+		Clock:      c.Clock,
+
+		AffinityAssistantPodLister: c.affinityAssistantPodLister,
+	})
 	if err != nil {
 		return err
 	}
@@ -1002,6 +1385,25 @@ func (c *Reconciler) updateStepStatusesFromPod(ctx context.Context, tr *v1.TaskR
 	return nil
 }
 
+// mergeIsolatedPod returns pod merged with the pod running the isolated steps of tr, if some of its steps
+// are isolated, so that the status of tr is made from the statuses of both pods.
+func (c *Reconciler) mergeIsolatedPod(ctx context.Context, tr *v1.TaskRun, pod *corev1.Pod, ts *v1.TaskSpec) (*corev1.Pod, error) {
+	name := podconvert.IsolatedPodName(pod)
+	if name == "" {
+		return pod, nil
+	}
+	tr.Status.IsolatedPodName = name
+	isolatedPod, err := c.podLister.Pods(tr.Namespace).Get(name)
+	if k8serrors.IsNotFound(err) {
+		// The isolated pod is created right before the pod, and may not be in the informer cache yet
+		isolatedPod, err = c.KubeClientSet.CoreV1().Pods(tr.Namespace).Get(ctx, name, metav1.GetOptions{})
+	}
+	if err != nil {
+		return nil, err
+	}
+	return podconvert.MergeIsolatedPod(pod, isolatedPod, ts), nil
+}
+
 // terminateStepsInPod updates step states for TaskRun on TaskRun object since pod has been deleted for cancel or timeout
 func terminateStepsInPod(tr *v1.TaskRun, taskRunReason v1.TaskRunReason) {
 	for i, step := range tr.Status.Steps {
@@ -1041,6 +1443,7 @@ func terminateStepsInPod(tr *v1.TaskRun, taskRunReason v1.TaskRunReason) {
 func (c *Reconciler) createPod(ctx context.Context, ts *v1.TaskSpec, tr *v1.TaskRun, rtr *resources.ResolvedTask, workspaceVolumes map[string]corev1.Volume) (_ *corev1.Pod, err error) {
 	ctx, span := c.tracerProvider.Tracer(TracerName).Start(ctx, "createPod")
 	defer span.End()
+	defer tknreconciler.StartReconcilePhase(ctx, tknreconciler.ReconcilePhaseResources)()
 	defer func() {
 		if err != nil {
 			span.SetStatus(codes.Error, err.Error())
@@ -1059,11 +1462,12 @@ func (c *Reconciler) createPod(ctx context.Context, ts *v1.TaskSpec, tr *v1.Task
 
 	// By this time, params and workspaces should be propagated down so we can
 	// validate that all parameter variables and workspaces used in the TaskSpec are declared by the Task.
-	if validateErr := v1.ValidateUsageOfDeclaredParameters(ctx, ts.Steps, ts.Params); validateErr != nil {
+	if validateErr := v1.ValidateUsageOfDeclaredParameters(ctx, ts.Steps, ts.Params).Also(v1.ValidateUsageOfDeclaredParametersInSidecars(ts.Sidecars, ts.Params)); validateErr != nil {
 		logger.Errorf("Failed to create a pod for taskrun: %s due to task validation error %v", tr.Name, validateErr)
 		return nil, validateErr
 	}
-	if validateErr := ts.Validate(ctx); validateErr != nil {
+	// Warnings, e.g. about shadowed result names, don't prevent the TaskRun from running.
+	if validateErr := ts.Validate(ctx).Filter(apis.ErrorLevel); validateErr != nil {
 		logger.Errorf("Failed to create a pod for taskrun: %s due to task validation error %v", tr.Name, validateErr)
 		return nil, validateErr
 	}
@@ -1091,6 +1495,17 @@ func (c *Reconciler) createPod(ctx context.Context, ts *v1.TaskSpec, tr *v1.Task
 		}
 	}
 
+	// Merge the pod defaults of the namespace into the PodTemplate, with the lowest precedence
+	nsDefaults, nsErr := podconvert.GetNamespaceDefaults(c.configMapLister, tr.Namespace)
+	switch {
+	case nsErr != nil:
+		logger.Errorf("Ignoring the pod defaults of namespace %s for taskrun %s: %v", tr.Namespace, tr.Name, nsErr)
+	case nsDefaults != nil:
+		trCopy := tr.DeepCopy()
+		trCopy.Spec.PodTemplate = nsDefaults.MergePodTemplate(tr.Spec.PodTemplate)
+		tr = trCopy
+	}
+
 	podbuilder := podconvert.Builder{
 		Images:          c.Images,
 		KubeClient:      c.KubeClientSet,
@@ -1105,11 +1520,40 @@ func (c *Reconciler) createPod(ctx context.Context, ts *v1.TaskSpec, tr *v1.Task
 		return nil, fmt.Errorf("translating TaskSpec to Pod: %w", err)
 	}
 
+	cfg := config.FromContextOrDefaults(ctx)
+	if cfg.FeatureFlags.EnablePodSecurityPreflight {
+		// The pod is checked before the isolated steps are split out of it, so that the checks cover both pods
+		exemptions := podconvert.PodSecurityExemptions{
+			Namespaces:     cfg.FeatureFlags.PodSecurityExemptNamespaces,
+			RuntimeClasses: cfg.FeatureFlags.PodSecurityExemptRuntimeClasses,
+		}
+		ns, err := c.namespaceLister.Get(tr.Namespace)
+		if err != nil {
+			logger.Warnf("Skipping the Pod Security preflight check of taskrun %s: failed to get namespace %s: %v", tr.Name, tr.Namespace, err)
+		} else if err := podconvert.CheckPodSecurity(ns, pod, exemptions); err != nil {
+			return nil, err
+		}
+	}
+	// The isolated steps run in a second pod, created first so that the TaskRun never runs without it,
+	// and deleted if the pod running the other steps can't be created
+	var isolatedPod *corev1.Pod
+	if runtimeClass := cfg.FeatureFlags.IsolatedStepsRuntimeClass; runtimeClass != "" && podconvert.HasIsolatedSteps(*ts) {
+		isolatedPod, err = podconvert.SplitIsolatedSteps(pod, *ts, runtimeClass)
+		if err != nil {
+			return nil, fmt.Errorf("translating TaskSpec to Pod: %w", err)
+		}
+		if _, err := c.KubeClientSet.CoreV1().Pods(tr.Namespace).Create(ctx, isolatedPod, metav1.CreateOptions{}); err != nil && !k8serrors.IsAlreadyExists(err) {
+			return nil, err
+		}
+	}
+
+	// The results sidecar only runs in the main pod, which alone is kept until the results are extracted
+	addResultsExtractionFinalizer(ctx, pod)
+
 	// Stash the podname in case there's create conflict so that we can try
 	// to fetch it.
 	podName := pod.Name
 
-	cfg := config.FromContextOrDefaults(ctx)
 	if !cfg.FeatureFlags.EnableWaitExponentialBackoff {
 		pod, err = c.KubeClientSet.CoreV1().Pods(tr.Namespace).Create(ctx, pod, metav1.CreateOptions{})
 	} else {
@@ -1148,6 +1592,11 @@ func (c *Reconciler) createPod(ctx context.Context, ts *v1.TaskSpec, tr *v1.Task
 		}
 	}
 	if err != nil {
+		if isolatedPod != nil && !k8serrors.IsAlreadyExists(err) {
+			if deleteErr := c.KubeClientSet.CoreV1().Pods(tr.Namespace).Delete(ctx, isolatedPod.Name, metav1.DeleteOptions{}); deleteErr != nil && !k8serrors.IsNotFound(deleteErr) {
+				logger.Errorf("Failed to delete the pod %s running the isolated steps of taskrun %s: %v", isolatedPod.Name, tr.Name, deleteErr)
+			}
+		}
 		return nil, err
 	}
 	return pod, nil
@@ -1206,9 +1655,22 @@ func isTaskRunValidationFailed(err error) bool {
 	return err != nil && strings.Contains(err.Error(), "TaskRun validation failed")
 }
 
+func isImageDigestResolutionFailed(err error) bool {
+	var digestErr *podconvert.ImageDigestResolutionError
+	return errors.As(err, &digestErr)
+}
+
+func isPodSecurityPreflightFailed(err error) bool {
+	var preflightErr *podconvert.PodSecurityPreflightError
+	return errors.As(err, &preflightErr)
+}
+
+func isPodSecurityViolation(err error) bool {
+	return err != nil && k8serrors.IsForbidden(err) && podconvert.IsPodSecurityViolation(err.Error())
+}
+
 func isPodAdmissionFailed(err error) bool {
-	return err != nil && k8serrors.IsForbidden(err) && (strings.Contains(err.Error(), "violates PodSecurity") ||
-		strings.Contains(err.Error(), "security context constraint"))
+	return err != nil && k8serrors.IsForbidden(err) && strings.Contains(err.Error(), "security context constraint")
 }
 
 // updateStoppedSidecarStatus updates SidecarStatus for sidecars that were
@@ -1261,7 +1723,7 @@ func applyVolumeClaimTemplates(workspaceBindings []v1.WorkspaceBinding, owner me
 			Name:    wb.Name,
 			SubPath: wb.SubPath,
 			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
-				ClaimName: volumeclaim.GeneratePVCNameFromWorkspaceBinding(wb.VolumeClaimTemplate.Name, wb, owner),
+				ClaimName: volumeclaim.PVCName(wb, owner),
 			},
 		}
 		taskRunWorkspaceBindings = append(taskRunWorkspaceBindings, b)
@@ -1350,16 +1812,100 @@ func isConcurrentModificationError(err error) bool {
 	return strings.Contains(err.Error(), optimisticLockErrorMsg)
 }
 
+// recreatesDeletedPod returns true if a replacement pod is created for the TaskRun when its pod is deleted
+// out-of-band, e.g. when its node is drained, instead of failing it: the feature flag must be enabled, the
+// TaskRun must opt in with the RecreateDeletedPodAnnotation, and none of its steps must have produced results.
+func recreatesDeletedPod(ctx context.Context, tr *v1.TaskRun) bool {
+	if !config.FromContextOrDefaults(ctx).FeatureFlags.EnableDeletedPodRecreation || tr.Annotations[v1.RecreateDeletedPodAnnotation] != "true" {
+		return false
+	}
+	if len(tr.Status.Results) > 0 {
+		return false
+	}
+	return !slices.ContainsFunc(tr.Status.Steps, func(s v1.StepState) bool {
+		return len(s.Results) > 0
+	})
+}
+
+// replaceDeletedPod archives the status of the TaskRun whose pod was deleted out-of-band so that a
+// replacement pod is created, or fails the TaskRun if it has no retries left.
+func (c *Reconciler) replaceDeletedPod(ctx context.Context, tr *v1.TaskRun) error {
+	message := fmt.Sprintf("the pod %s of TaskRun %s was deleted", tr.Status.PodName, tr.Name)
+	if tr.Spec.Retries > 0 && !tr.IsRetriable() {
+		err := errors.New(message + " and no retries are left")
+		tr.Status.MarkResourceFailed(v1.TaskRunReasonPodDeleted, err)
+		return controller.NewPermanentError(err)
+	}
+	// The deleted pod is released as its results won't be extracted anymore.
+	if err := c.releasePod(ctx, tr); err != nil {
+		return err
+	}
+	logging.FromContext(ctx).Infof("Creating a replacement pod for TaskRun %s/%s: %s", tr.Namespace, tr.Name, message)
+	controller.GetEventRecorder(ctx).Event(tr, corev1.EventTypeWarning, v1.TaskRunReasonPodDeleted.String(), message)
+	archiveDeletedPod(tr, c.Clock.Now(), message, config.FromContextOrDefaults(ctx).FeatureFlags.EnableRetriesStatusTrimming)
+	return nil
+}
+
+// archiveDeletedPod archives taskRun.Status to taskRun.Status.RetriesStatus with Reason
+// v1.TaskRunReasonPodDeleted, which records the name of the deleted pod, and unsets the pod of the
+// TaskRun so that a replacement pod is created. Unlike retryTaskRun, the start time of the TaskRun
+// is kept, so that its timeout applies to all of its pods.
+func archiveDeletedPod(tr *v1.TaskRun, now time.Time, message string, trim bool) {
+	newStatus := tr.Status.DeepCopy()
+	newStatus.CompletionTime = &metav1.Time{Time: now}
+	newStatus.SetCondition(&apis.Condition{
+		Type:    apis.ConditionSucceeded,
+		Status:  corev1.ConditionFalse,
+		Reason:  v1.TaskRunReasonPodDeleted.String(),
+		Message: message,
+	})
+	archiveAttempt(tr, newStatus, trim)
+	tr.Status.PodName = ""
+	tr.Status.IsolatedPodName = ""
+	tr.Status.Results = nil
+	tr.Status.ReasonHistory = nil
+	taskRunCondSet := apis.NewBatchConditionSet()
+	taskRunCondSet.Manage(&tr.Status).MarkUnknown(apis.ConditionSucceeded, v1.TaskRunReasonPodDeleted.String(), message)
+}
+
 // retryTaskRun archives taskRun.Status to taskRun.Status.RetriesStatus, and set
 // taskRun status to Unknown with Reason v1.TaskRunReasonToBeRetried.
-func retryTaskRun(tr *v1.TaskRun, message string) {
-	newStatus := tr.Status.DeepCopy()
-	newStatus.RetriesStatus = nil
-	tr.Status.RetriesStatus = append(tr.Status.RetriesStatus, *newStatus)
+func retryTaskRun(tr *v1.TaskRun, message string, trim bool) {
+	archiveAttempt(tr, tr.Status.DeepCopy(), trim)
 	tr.Status.StartTime = nil
 	tr.Status.CompletionTime = nil
 	tr.Status.PodName = ""
+	tr.Status.IsolatedPodName = ""
 	tr.Status.Results = nil
+	tr.Status.ReasonHistory = nil
 	taskRunCondSet := apis.NewBatchConditionSet()
 	taskRunCondSet.Manage(&tr.Status).MarkUnknown(apis.ConditionSucceeded, v1.TaskRunReasonToBeRetried.String(), message)
 }
+
+// archiveAttempt appends the status of the current attempt of the TaskRun to
+// taskRun.Status.RetriesStatus, and its summary to taskRun.Status.Attempts. If trim is true,
+// the archived status is trimmed of the results and the termination messages of the steps,
+// as the results are recorded in the summary.
+func archiveAttempt(tr *v1.TaskRun, archived *v1.TaskRunStatus, trim bool) {
+	attempt := v1.TaskRunAttempt{
+		Attempt:        len(tr.Status.RetriesStatus),
+		PodName:        archived.PodName,
+		CompletionTime: archived.CompletionTime,
+		Results:        archived.Results,
+	}
+	if succeeded := archived.GetCondition(apis.ConditionSucceeded); succeeded != nil {
+		attempt.Reason = succeeded.Reason
+	}
+	archived.RetriesStatus = nil
+	archived.Attempts = nil
+	if trim {
+		archived.Results = nil
+		for i := range archived.Steps {
+			if archived.Steps[i].Terminated != nil {
+				archived.Steps[i].Terminated.Message = ""
+			}
+		}
+	}
+	tr.Status.RetriesStatus = append(tr.Status.RetriesStatus, *archived)
+	tr.Status.Attempts = append(tr.Status.Attempts, attempt)
+}
@@ -24,10 +24,12 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
 	"net/http/httptest"
 	"net/url"
 	"os"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 	"testing"
@@ -117,7 +119,7 @@ var (
 	ignoreCompletionTime      = cmpopts.IgnoreFields(v1.TaskRunStatusFields{}, "CompletionTime")
 	ignoreObjectMeta          = cmpopts.IgnoreFields(metav1.ObjectMeta{}, "Labels", "ResourceVersion", "Annotations")
 	ignoreStatusTaskSpec      = cmpopts.IgnoreFields(v1.TaskRunStatusFields{}, "TaskSpec")
-	ignoreTaskRunStatusFields = cmpopts.IgnoreFields(v1.TaskRunStatusFields{}, "Steps", "Sidecars")
+	ignoreTaskRunStatusFields = cmpopts.IgnoreFields(v1.TaskRunStatusFields{}, "Steps", "Sidecars", "ReasonHistory")
 
 	resourceQuantityCmp = cmp.Comparer(func(x, y resource.Quantity) bool {
 		return x.Cmp(y) == 0
@@ -1315,6 +1317,569 @@ spec:
 	}
 }
 
+func TestReconcile_MergesNamespacePodDefaults(t *testing.T) {
+	taskRun := parse.MustParseV1TaskRun(t, `
+metadata:
+  name: test-taskrun
+  namespace: foo
+spec:
+  taskRef:
+    name: test-task
+  podTemplate:
+    nodeSelector:
+      pool: gpu
+`)
+	d := test.Data{
+		TaskRuns: []*v1.TaskRun{taskRun},
+		Tasks:    []*v1.Task{simpleTask},
+		ConfigMaps: []*corev1.ConfigMap{{
+			ObjectMeta: metav1.ObjectMeta{Name: podconvert.NamespaceDefaultsConfigMapName, Namespace: "foo"},
+			Data: map[string]string{
+				podconvert.NamespaceDefaultsNodeSelectorKey: "pool: ci\ndisk: ssd\n",
+				podconvert.NamespaceDefaultsTolerationsKey:  "- key: dedicated\n  operator: Equal\n  value: ci\n  effect: NoSchedule\n",
+			},
+		}},
+	}
+	testAssets, cancel := getTaskRunController(t, d)
+	defer cancel()
+	createServiceAccount(t, testAssets, "default", "foo")
+
+	if err := testAssets.Controller.Reconciler.Reconcile(testAssets.Ctx, getRunName(taskRun)); err == nil {
+		t.Error("Wanted a wrapped requeue error, but got nil.")
+	} else if ok, _ := controller.IsRequeueKey(err); !ok {
+		t.Errorf("expected no error reconciling valid TaskRun but got %v", err)
+	}
+
+	newTr, err := testAssets.Clients.Pipeline.TektonV1().TaskRuns(taskRun.Namespace).Get(testAssets.Ctx, taskRun.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Expected TaskRun %s to exist but instead got error when getting it: %v", taskRun.Name, err)
+	}
+	if d := cmp.Diff(taskRun.Spec.PodTemplate, newTr.Spec.PodTemplate); d != "" {
+		t.Errorf("expected the pod template of the TaskRun to be unchanged %s", diff.PrintWantGot(d))
+	}
+	pod, err := testAssets.Clients.Kube.CoreV1().Pods(taskRun.Namespace).Get(testAssets.Ctx, newTr.Status.PodName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Failed to fetch pod: %v", err)
+	}
+	wantNodeSelector := map[string]string{"pool": "gpu", "disk": "ssd"}
+	if d := cmp.Diff(wantNodeSelector, pod.Spec.NodeSelector); d != "" {
+		t.Errorf("unexpected pod node selector %s", diff.PrintWantGot(d))
+	}
+	wantTolerations := []corev1.Toleration{{
+		Key:      "dedicated",
+		Operator: corev1.TolerationOpEqual,
+		Value:    "ci",
+		Effect:   corev1.TaintEffectNoSchedule,
+	}}
+	if d := cmp.Diff(wantTolerations, pod.Spec.Tolerations); d != "" {
+		t.Errorf("unexpected pod tolerations %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestReconcile_PodSecurityPreflight(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		level       string
+		podTemplate string
+		disabled    bool
+		exemptions  map[string]string
+		wantMessage string
+	}{{
+		name:        "baseline namespace",
+		level:       "baseline",
+		podTemplate: "\n  podTemplate:\n    hostNetwork: true",
+		wantMessage: `pod violates PodSecurity "baseline:latest":` + "\n" +
+			"- host namespaces (hostNetwork=true); fix with podTemplate.hostNetwork",
+	}, {
+		name:  "baseline namespace without violations",
+		level: "baseline",
+	}, {
+		name:  "restricted namespace",
+		level: "restricted",
+		wantMessage: `pod violates PodSecurity "restricted:latest":` + "\n" +
+			`- allowPrivilegeEscalation != false (containers "prepare", "step-simple-step" must set securityContext.allowPrivilegeEscalation=false)`,
+	}, {
+		name:     "restricted namespace without the preflight check",
+		level:    "restricted",
+		disabled: true,
+	}, {
+		name:       "exempt restricted namespace",
+		level:      "restricted",
+		exemptions: map[string]string{"pod-security-exempt-namespaces": "kube-system,foo"},
+	}, {
+		name:        "exempt runtime class",
+		level:       "restricted",
+		podTemplate: "\n  podTemplate:\n    runtimeClassName: gvisor",
+		exemptions:  map[string]string{"pod-security-exempt-runtime-classes": "gvisor"},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			taskRun := parse.MustParseV1TaskRun(t, `
+metadata:
+  name: test-taskrun
+  namespace: foo
+spec:
+  taskRef:
+    name: test-task`+tc.podTemplate)
+			featureFlags := map[string]string{config.EnablePodSecurityPreflight: strconv.FormatBool(!tc.disabled)}
+			maps.Copy(featureFlags, tc.exemptions)
+			d := test.Data{
+				TaskRuns: []*v1.TaskRun{taskRun},
+				Tasks:    []*v1.Task{simpleTask},
+				Namespaces: []*corev1.Namespace{{ObjectMeta: metav1.ObjectMeta{
+					Name:   "foo",
+					Labels: map[string]string{"pod-security.kubernetes.io/enforce": tc.level},
+				}}},
+				ConfigMaps: []*corev1.ConfigMap{{
+					ObjectMeta: metav1.ObjectMeta{Namespace: system.Namespace(), Name: config.GetFeatureFlagsConfigName()},
+					Data:       featureFlags,
+				}},
+			}
+			testAssets, cancel := getTaskRunController(t, d)
+			defer cancel()
+			createServiceAccount(t, testAssets, "default", "foo")
+
+			_ = testAssets.Controller.Reconciler.Reconcile(testAssets.Ctx, getRunName(taskRun))
+
+			newTr, err := testAssets.Clients.Pipeline.TektonV1().TaskRuns(taskRun.Namespace).Get(testAssets.Ctx, taskRun.Name, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Expected TaskRun %s to exist but instead got error when getting it: %v", taskRun.Name, err)
+			}
+			pods, err := testAssets.Clients.Kube.CoreV1().Pods(taskRun.Namespace).List(testAssets.Ctx, metav1.ListOptions{})
+			if err != nil {
+				t.Fatalf("Failed to list pods: %v", err)
+			}
+			if tc.wantMessage == "" {
+				if len(pods.Items) != 1 {
+					t.Errorf("expected the pod of the TaskRun to be created, got %d pods", len(pods.Items))
+				}
+				return
+			}
+			if len(pods.Items) != 0 {
+				t.Errorf("expected no pod to be created, got %d pods", len(pods.Items))
+			}
+			condition := newTr.Status.GetCondition(apis.ConditionSucceeded)
+			if condition == nil || !condition.IsFalse() || condition.Reason != podconvert.ReasonTaskRunPodSecurityViolation {
+				t.Fatalf("expected the TaskRun to fail with reason %s, got condition %v", podconvert.ReasonTaskRunPodSecurityViolation, condition)
+			}
+			if !strings.HasPrefix(condition.Message, tc.wantMessage) {
+				t.Errorf("expected condition message starting with %q, got %q", tc.wantMessage, condition.Message)
+			}
+		})
+	}
+}
+
+// TestReconcile_IsolatedSteps tests that the pod running the isolated steps of a TaskRun is deleted when the pod
+// running its other steps can't be created, and that it is never taken for the pod of the TaskRun.
+func TestReconcile_IsolatedSteps(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		failCreate  bool
+		isolatedPod bool
+		wantPods    []string
+	}{{
+		name:     "both pods are created",
+		wantPods: []string{"test-taskrun-pod", "test-taskrun-pod-isolated"},
+	}, {
+		name:       "isolated pod is deleted when the pod can't be created",
+		failCreate: true,
+	}, {
+		name:        "isolated pod is not taken for the pod of the TaskRun",
+		isolatedPod: true,
+		wantPods:    []string{"test-taskrun-pod", "test-taskrun-pod-isolated"},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			taskRun := parse.MustParseV1TaskRun(t, `
+metadata:
+  name: test-taskrun
+  namespace: foo
+spec:
+  workspaces:
+  - name: source
+    persistentVolumeClaim:
+      claimName: source
+  taskSpec:
+    workspaces:
+    - name: source
+    steps:
+    - name: checkout
+      image: foo
+      command: [/mycmd]
+    - name: untrusted
+      image: foo
+      command: [/mycmd]
+      securityProfile: isolated
+`)
+			d := test.Data{
+				TaskRuns: []*v1.TaskRun{taskRun},
+				ConfigMaps: []*corev1.ConfigMap{{
+					ObjectMeta: metav1.ObjectMeta{Namespace: system.Namespace(), Name: config.GetFeatureFlagsConfigName()},
+					Data:       map[string]string{"isolated-steps-runtime-class": "gvisor"},
+				}},
+			}
+			if tc.isolatedPod {
+				d.Pods = []*corev1.Pod{{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:            "test-taskrun-pod-isolated",
+						Namespace:       "foo",
+						Labels:          map[string]string{pipeline.TaskRunLabelKey: taskRun.Name, podconvert.IsolatedPodLabelKey: "true"},
+						OwnerReferences: []metav1.OwnerReference{*kmeta.NewControllerRef(taskRun)},
+					},
+				}}
+			}
+			testAssets, cancel := getTaskRunController(t, d)
+			defer cancel()
+			createServiceAccount(t, testAssets, "default", "foo")
+			if tc.failCreate {
+				testAssets.Clients.Kube.PrependReactor("create", "pods", func(action ktesting.Action) (bool, runtime.Object, error) {
+					if action.(ktesting.CreateAction).GetObject().(*corev1.Pod).Name == "test-taskrun-pod" {
+						return true, nil, errors.New("pod quota exceeded")
+					}
+					return false, nil, nil
+				})
+			}
+
+			_ = testAssets.Controller.Reconciler.Reconcile(testAssets.Ctx, getRunName(taskRun))
+
+			pods, err := testAssets.Clients.Kube.CoreV1().Pods(taskRun.Namespace).List(testAssets.Ctx, metav1.ListOptions{})
+			if err != nil {
+				t.Fatalf("Failed to list pods: %v", err)
+			}
+			var gotPods []string
+			for _, p := range pods.Items {
+				gotPods = append(gotPods, p.Name)
+			}
+			if d := cmp.Diff(tc.wantPods, gotPods); d != "" {
+				t.Errorf("Unexpected pods %s", diff.PrintWantGot(d))
+			}
+			if tc.failCreate {
+				return
+			}
+			newTr, err := testAssets.Clients.Pipeline.TektonV1().TaskRuns(taskRun.Namespace).Get(testAssets.Ctx, taskRun.Name, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Expected TaskRun %s to exist but instead got error when getting it: %v", taskRun.Name, err)
+			}
+			if newTr.Status.PodName != "test-taskrun-pod" {
+				t.Errorf("Expected the TaskRun to run in pod test-taskrun-pod, got %q", newTr.Status.PodName)
+			}
+		})
+	}
+}
+
+func TestReconcile_TracksFeatureFlagUsage(t *testing.T) {
+	resultsTaskRun := `
+metadata:
+  name: test-taskrun
+  namespace: foo
+spec:
+  taskSpec:
+    results:
+    - name: result1
+    steps:
+    - image: myimage
+      script: echo foo >> $(results.result1.path)
+`
+	for _, tc := range []struct {
+		name          string
+		taskRun       string
+		featureFlags  map[string]string
+		wantUsedFlags []string
+	}{{
+		name:          "sidecar logs",
+		taskRun:       resultsTaskRun,
+		featureFlags:  map[string]string{"results-from": config.ResultExtractionMethodSidecarLogs},
+		wantUsedFlags: []string{config.ResultExtractionMethodKey},
+	}, {
+		name:         "termination message",
+		taskRun:      resultsTaskRun,
+		featureFlags: map[string]string{"results-from": config.ResultExtractionMethodTerminationMessage},
+	}, {
+		name: "debug",
+		taskRun: `
+metadata:
+  name: test-taskrun
+  namespace: foo
+spec:
+  debug:
+    breakpoints:
+      onFailure: enabled
+  taskSpec:
+    steps:
+    - image: myimage
+      command: [/mycmd]
+`,
+		featureFlags:  map[string]string{"enable-api-fields": config.AlphaAPIFields},
+		wantUsedFlags: []string{config.DebugFeatureUsage},
+	}, {
+		name: "isolated steps",
+		taskRun: `
+metadata:
+  name: test-taskrun
+  namespace: foo
+spec:
+  workspaces:
+  - name: source
+    persistentVolumeClaim:
+      claimName: source
+  taskSpec:
+    workspaces:
+    - name: source
+    steps:
+    - name: untrusted
+      image: myimage
+      command: [/mycmd]
+      securityProfile: isolated
+`,
+		featureFlags:  map[string]string{"isolated-steps-runtime-class": "gvisor"},
+		wantUsedFlags: []string{config.IsolatedStepsRuntimeClassKey},
+	}, {
+		name:          "step directory isolation",
+		taskRun:       resultsTaskRun,
+		featureFlags:  map[string]string{config.EnableStepDirectoryIsolation: "true"},
+		wantUsedFlags: []string{config.EnableStepDirectoryIsolation},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			taskRun := parse.MustParseV1TaskRun(t, tc.taskRun)
+			d := test.Data{
+				TaskRuns: []*v1.TaskRun{taskRun},
+				ConfigMaps: []*corev1.ConfigMap{{
+					ObjectMeta: metav1.ObjectMeta{Namespace: system.Namespace(), Name: config.GetFeatureFlagsConfigName()},
+					Data:       tc.featureFlags,
+				}},
+			}
+			testAssets, cancel := getTaskRunController(t, d)
+			defer cancel()
+			createServiceAccount(t, testAssets, "default", "foo")
+
+			// The fake logs of the results sidecar aren't valid results, the status is updated regardless.
+			_ = testAssets.Controller.Reconciler.Reconcile(testAssets.Ctx, getRunName(taskRun))
+
+			newTr, err := testAssets.Clients.Pipeline.TektonV1().TaskRuns(taskRun.Namespace).Get(testAssets.Ctx, taskRun.Name, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Expected TaskRun %s to exist but instead got error when getting it: %v", taskRun.Name, err)
+			}
+			if newTr.Status.Provenance == nil {
+				t.Fatal("expected the TaskRun to have a provenance")
+			}
+			if d := cmp.Diff(tc.wantUsedFlags, newTr.Status.Provenance.UsedFeatureFlags); d != "" {
+				t.Errorf("unexpected used feature flags %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestReconcile_ResultExtractionMethodOverride(t *testing.T) {
+	for _, tc := range []struct {
+		name               string
+		resultsFrom        string
+		allowedOverrides   string
+		annotation         string
+		wantResultsSidecar bool
+		wantFailed         bool
+	}{{
+		name:               "sidecar logs instead of termination message",
+		resultsFrom:        config.ResultExtractionMethodTerminationMessage,
+		allowedOverrides:   config.ResultExtractionMethodSidecarLogs,
+		annotation:         config.ResultExtractionMethodSidecarLogs,
+		wantResultsSidecar: true,
+	}, {
+		name:             "termination message instead of sidecar logs",
+		resultsFrom:      config.ResultExtractionMethodSidecarLogs,
+		allowedOverrides: "termination-message,sidecar-logs",
+		annotation:       config.ResultExtractionMethodTerminationMessage,
+	}, {
+		name:        "no override",
+		resultsFrom: config.ResultExtractionMethodTerminationMessage,
+	}, {
+		name:        "override not allowed",
+		resultsFrom: config.ResultExtractionMethodTerminationMessage,
+		annotation:  config.ResultExtractionMethodSidecarLogs,
+		wantFailed:  true,
+	}, {
+		name:             "override not listed",
+		resultsFrom:      config.ResultExtractionMethodSidecarLogs,
+		allowedOverrides: config.ResultExtractionMethodSidecarLogs,
+		annotation:       config.ResultExtractionMethodTerminationMessage,
+		wantFailed:       true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			taskRun := parse.MustParseV1TaskRun(t, `
+metadata:
+  name: test-taskrun
+  namespace: foo
+spec:
+  taskSpec:
+    results:
+    - name: result1
+    steps:
+    - image: myimage
+      script: echo foo >> $(results.result1.path)
+`)
+			if tc.annotation != "" {
+				taskRun.Annotations = map[string]string{v1.ResultExtractionMethodAnnotation: tc.annotation}
+			}
+			d := test.Data{
+				TaskRuns: []*v1.TaskRun{taskRun},
+				ConfigMaps: []*corev1.ConfigMap{{
+					ObjectMeta: metav1.ObjectMeta{Namespace: system.Namespace(), Name: config.GetFeatureFlagsConfigName()},
+					Data: map[string]string{
+						"results-from":                   tc.resultsFrom,
+						"allowed-results-from-overrides": tc.allowedOverrides,
+					},
+				}},
+			}
+			testAssets, cancel := getTaskRunController(t, d)
+			defer cancel()
+			createServiceAccount(t, testAssets, "default", "foo")
+
+			// The fake logs of the results sidecar aren't valid results, the status is updated regardless.
+			_ = testAssets.Controller.Reconciler.Reconcile(testAssets.Ctx, getRunName(taskRun))
+
+			newTr, err := testAssets.Clients.Pipeline.TektonV1().TaskRuns(taskRun.Namespace).Get(testAssets.Ctx, taskRun.Name, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Expected TaskRun %s to exist but instead got error when getting it: %v", taskRun.Name, err)
+			}
+			if tc.wantFailed {
+				condition := newTr.Status.GetCondition(apis.ConditionSucceeded)
+				if condition == nil || condition.Status != corev1.ConditionFalse || condition.Reason != v1.TaskRunReasonFailedValidation.String() {
+					t.Errorf("Expected TaskRun to fail with reason %q but got condition %v", v1.TaskRunReasonFailedValidation, condition)
+				}
+				if newTr.Status.PodName != "" {
+					t.Errorf("Expected no pod to be created but got %q", newTr.Status.PodName)
+				}
+				return
+			}
+			pod, err := testAssets.Clients.Kube.CoreV1().Pods(taskRun.Namespace).Get(testAssets.Ctx, newTr.Status.PodName, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Failed to get the pod of the TaskRun: %v", err)
+			}
+			gotResultsSidecar := slices.ContainsFunc(pod.Spec.Containers, func(c corev1.Container) bool {
+				return c.Name == pipeline.ReservedResultsSidecarContainerName
+			})
+			if gotResultsSidecar != tc.wantResultsSidecar {
+				t.Errorf("Expected results sidecar in pod: %t, got: %t", tc.wantResultsSidecar, gotResultsSidecar)
+			}
+			if gotFinalizer := slices.Contains(pod.Finalizers, pipeline.ResultsExtractionFinalizer); gotFinalizer != tc.wantResultsSidecar {
+				t.Errorf("Expected results extraction finalizer on pod: %t, got: %t", tc.wantResultsSidecar, gotFinalizer)
+			}
+			if got := pod.Annotations[v1.ResultExtractionMethodAnnotation]; got != tc.annotation {
+				t.Errorf("Expected the pod to record results-from method %q but got %q", tc.annotation, got)
+			}
+		})
+	}
+}
+
+func TestReconcile_ResultExtractionMethodOverrideKeptByPod(t *testing.T) {
+	// The pod was created with the method selected by the TaskRun before it was removed from the allowed overrides
+	taskRun := parse.MustParseV1TaskRun(t, `
+metadata:
+  name: test-taskrun
+  namespace: foo
+  annotations:
+    tekton.dev/results-from: sidecar-logs
+spec:
+  taskSpec:
+    steps:
+    - image: myimage
+      script: echo foo
+status:
+  podName: the-pod
+`)
+	d := test.Data{
+		TaskRuns: []*v1.TaskRun{taskRun},
+		Pods: []*corev1.Pod{{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "foo",
+				Name:        "the-pod",
+				Annotations: map[string]string{v1.ResultExtractionMethodAnnotation: config.ResultExtractionMethodSidecarLogs},
+			},
+		}},
+	}
+	testAssets, cancel := getTaskRunController(t, d)
+	defer cancel()
+
+	if err := testAssets.Controller.Reconciler.Reconcile(testAssets.Ctx, getRunName(taskRun)); err == nil {
+		t.Error("Wanted a wrapped requeue error, but got nil.")
+	} else if ok, _ := controller.IsRequeueKey(err); !ok {
+		t.Errorf("expected no error. Got error %v", err)
+	}
+	newTr, err := testAssets.Clients.Pipeline.TektonV1().TaskRuns(taskRun.Namespace).Get(testAssets.Ctx, taskRun.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Expected TaskRun %s to exist but instead got error when getting it: %v", taskRun.Name, err)
+	}
+	if newTr.Status.Provenance == nil || newTr.Status.Provenance.FeatureFlags.ResultExtractionMethod != config.ResultExtractionMethodSidecarLogs {
+		t.Errorf("Expected the TaskRun provenance to record the results-from method of its pod, got %v", newTr.Status.Provenance)
+	}
+	if condition := newTr.Status.GetCondition(apis.ConditionSucceeded); condition == nil || condition.Status != corev1.ConditionUnknown {
+		t.Errorf("Expected TaskRun to keep running with the results-from method of its pod but got condition %v", condition)
+	}
+}
+
+func TestReconcile_NamespaceFeatureFlags(t *testing.T) {
+	for _, tc := range []struct {
+		name                string
+		data                map[string]string
+		wantKeepPodOnCancel bool
+		wantArtifacts       bool
+	}{{
+		name:                "flags overridden by the namespace",
+		data:                map[string]string{"keep-pod-on-cancel": "true", "enable-artifacts": "true"},
+		wantKeepPodOnCancel: true,
+		wantArtifacts:       true,
+	}, {
+		name:                "overrides with a flag which can't be overridden are ignored",
+		data:                map[string]string{"keep-pod-on-cancel": "true", "enable-artifacts": "true", "disable-creds-init": "true"},
+		wantKeepPodOnCancel: false,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			taskRun := parse.MustParseV1TaskRun(t, `
+metadata:
+  name: test-taskrun
+  namespace: foo
+spec:
+  taskSpec:
+    steps:
+    - image: myimage
+      script: echo foo
+`)
+			d := test.Data{
+				TaskRuns: []*v1.TaskRun{taskRun},
+				ConfigMaps: []*corev1.ConfigMap{{
+					ObjectMeta: metav1.ObjectMeta{Name: config.GetFeatureFlagsConfigName(), Namespace: system.Namespace()},
+					Data:       map[string]string{"keep-pod-on-cancel": "false"},
+				}, {
+					ObjectMeta: metav1.ObjectMeta{Name: config.NamespaceFeatureFlagsConfigMapName, Namespace: "foo"},
+					Data:       tc.data,
+				}},
+			}
+			testAssets, cancel := getTaskRunController(t, d)
+			defer cancel()
+			createServiceAccount(t, testAssets, "default", "foo")
+
+			if err := testAssets.Controller.Reconciler.Reconcile(testAssets.Ctx, getRunName(taskRun)); err == nil {
+				t.Error("Wanted a wrapped requeue error, but got nil.")
+			} else if ok, _ := controller.IsRequeueKey(err); !ok {
+				t.Errorf("expected no error reconciling valid TaskRun but got %v", err)
+			}
+			newTr, err := testAssets.Clients.Pipeline.TektonV1().TaskRuns(taskRun.Namespace).Get(testAssets.Ctx, taskRun.Name, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Expected TaskRun %s to exist but instead got error when getting it: %v", taskRun.Name, err)
+			}
+			if newTr.Status.Provenance == nil || newTr.Status.Provenance.FeatureFlags == nil {
+				t.Fatalf("Expected the TaskRun provenance to record its feature flags, got %v", newTr.Status.Provenance)
+			}
+			if got := newTr.Status.Provenance.FeatureFlags.EnableKeepPodOnCancel; got != tc.wantKeepPodOnCancel {
+				t.Errorf("Expected the TaskRun to be reconciled with keep-pod-on-cancel %t but got %t", tc.wantKeepPodOnCancel, got)
+			}
+			if got := newTr.Status.Provenance.FeatureFlags.EnableArtifacts; got != tc.wantArtifacts {
+				t.Errorf("Expected the TaskRun to be reconciled with enable-artifacts %t but got %t", tc.wantArtifacts, got)
+			}
+			if newTr.Status.Provenance.FeatureFlags.DisableCredsInit {
+				t.Error("Expected disable-creds-init not to be overridden by the namespace")
+			}
+		})
+	}
+}
+
 func TestReconcile_DoesntChangeStartTime(t *testing.T) {
 	startTime := time.Date(2000, 1, 1, 1, 1, 1, 1, time.UTC)
 	taskRun := parse.MustParseV1TaskRun(t, `
@@ -1542,7 +2107,17 @@ spec:
   taskRef:
     name: notask
 `)
-	taskRuns := []*v1.TaskRun{noTaskRun}
+	unknownHelperImageSetTaskRun := parse.MustParseV1TaskRun(t, `
+metadata:
+  name: unknown-helper-image-set
+  namespace: foo
+  annotations:
+    tekton.dev/helper-image-set: s390x
+spec:
+  taskRef:
+    name: test-task
+`)
+	taskRuns := []*v1.TaskRun{noTaskRun, unknownHelperImageSetTaskRun}
 	tasks := []*v1.Task{simpleTask}
 
 	d := test.Data{
@@ -1564,6 +2139,15 @@ spec:
 			"Warning Failed",
 			"Warning InternalError",
 		},
+	}, {
+		name:    "task run with a helper image set not configured",
+		taskRun: unknownHelperImageSetTaskRun,
+		reason:  v1.TaskRunReasonFailedValidation.String(),
+		wantEvents: []string{
+			"Normal Started",
+			"Warning Failed",
+			"Warning InternalError",
+		},
 	}}
 
 	for _, tc := range testcases {
@@ -1688,6 +2272,9 @@ status:
       message: TaskRun "test-taskrun-run-retry-timedout" failed to finish within "10s"
     startTime: "2021-12-31T00:00:00Z"
     completionTime: "2022-01-01T00:00:00Z"
+  attempts:
+  - attempt: 0
+    reason: "TaskRunTimeout"
     `)
 		toFailOnPodFailureTaskRun = parse.MustParseV1TaskRun(t, `
 metadata:
@@ -1743,6 +2330,10 @@ status:
         exitCode: 1
         finishedAt: "2022-01-01T00:00:00Z"
         reason: "TaskRunImagePullFailed"
+  attempts:
+  - attempt: 0
+    podName: test-taskrun-run-retry-pod-failure-pod
+    reason: "TaskRunImagePullFailed"
 `)
 		failedPod = &corev1.Pod{
 			ObjectMeta: metav1.ObjectMeta{Name: "test-taskrun-run-retry-pod-failure-pod"},
@@ -1790,6 +2381,9 @@ status:
       message: "error when listing tasks for taskRun test-taskrun-run-retry-prepare-failure: tasks.tekton.dev \"test-task\" not found"
     startTime: "2021-12-31T23:59:59Z"
     completionTime: "2022-01-01T00:00:00Z"
+  attempts:
+  - attempt: 0
+    reason: TaskRunResolutionFailed
 `)
 		prepareError                    = errors.New("error when listing tasks for taskRun test-taskrun-run-retry-prepare-failure: tasks.tekton.dev \"test-task\" not found")
 		toFailOnReconcileFailureTaskRun = parse.MustParseV1TaskRun(t, `
@@ -1844,6 +2438,10 @@ status:
         maxResultSize: 4096
         coschedule: "workspaces"
         disableInlineSpec: ""
+  attempts:
+  - attempt: 0
+    podName: "test-taskrun-results-type-mismatched-pod"
+    reason: TaskRunValidationFailed
   provenance:
     featureFlags:
       runningInEnvWithInjectedSidecars: true
@@ -1956,6 +2554,13 @@ status:
       type: string
       value: aResultValue
     startTime: "2021-12-31T23:59:59Z"
+  attempts:
+  - attempt: 0
+    reason: Failed
+    results:
+    - name: aResult
+      type: string
+      value: aResultValue
 `)
 	)
 
@@ -2070,6 +2675,7 @@ status:
 				ignoreObjectMeta,
 				ignoreStatusTaskSpec,
 				ignoreTaskRunStatusFields,
+				cmpopts.IgnoreFields(v1.TaskRunAttempt{}, "CompletionTime"),
 			}
 			if d := cmp.Diff(tc.wantTr, reconciledTaskRun, ignoreFields...); d != "" {
 				t.Errorf("Didn't get expected TaskRun: %v", diff.PrintWantGot(d))
@@ -2091,6 +2697,138 @@ status:
 	}
 }
 
+func TestRetryTaskRun_Attempts(t *testing.T) {
+	failedAttempt := func(podName, reason string, completionTime time.Time, result string) v1.TaskRunStatus {
+		return v1.TaskRunStatus{
+			Status: duckv1.Status{Conditions: duckv1.Conditions{{
+				Type:   apis.ConditionSucceeded,
+				Status: corev1.ConditionFalse,
+				Reason: reason,
+			}}},
+			TaskRunStatusFields: v1.TaskRunStatusFields{
+				PodName:        podName,
+				CompletionTime: &metav1.Time{Time: completionTime},
+				Steps: []v1.StepState{{
+					Name: "build",
+					ContainerState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+						ExitCode: 1,
+						Message:  fmt.Sprintf(`[{"key":"digest","value":%q,"type":1}]`, result),
+					}},
+				}},
+				Results: []v1.TaskRunResult{{Name: "digest", Type: v1.ResultsTypeString, Value: *v1.NewStructuredValues(result)}},
+			},
+		}
+	}
+	first := failedAttempt("build-pod", "Failed", now, "sha256:first")
+	second := failedAttempt("build-pod-retry1", "TaskRunTimeout", now.Add(time.Minute), "sha256:second")
+	wantAttempts := []v1.TaskRunAttempt{{
+		Attempt:        0,
+		PodName:        "build-pod",
+		Reason:         "Failed",
+		CompletionTime: &metav1.Time{Time: now},
+		Results:        []v1.TaskRunResult{{Name: "digest", Type: v1.ResultsTypeString, Value: *v1.NewStructuredValues("sha256:first")}},
+	}, {
+		Attempt:        1,
+		PodName:        "build-pod-retry1",
+		Reason:         "TaskRunTimeout",
+		CompletionTime: &metav1.Time{Time: now.Add(time.Minute)},
+		Results:        []v1.TaskRunResult{{Name: "digest", Type: v1.ResultsTypeString, Value: *v1.NewStructuredValues("sha256:second")}},
+	}}
+
+	for _, tc := range []struct {
+		name              string
+		trim              bool
+		wantRetriesStatus v1.RetriesStatus
+	}{{
+		name:              "full snapshots",
+		wantRetriesStatus: v1.RetriesStatus{first, second},
+	}, {
+		name: "trimmed snapshots",
+		trim: true,
+		wantRetriesStatus: func() v1.RetriesStatus {
+			trimmed := v1.RetriesStatus{*first.DeepCopy(), *second.DeepCopy()}
+			for i := range trimmed {
+				trimmed[i].Results = nil
+				trimmed[i].Steps[0].Terminated.Message = ""
+			}
+			return trimmed
+		}(),
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			tr := &v1.TaskRun{Spec: v1.TaskRunSpec{Retries: 2}, Status: *first.DeepCopy()}
+			retryTaskRun(tr, "failed", tc.trim)
+			second := second.DeepCopy()
+			second.RetriesStatus = tr.Status.RetriesStatus
+			second.Attempts = tr.Status.Attempts
+			tr.Status = *second
+			retryTaskRun(tr, "timed out", tc.trim)
+
+			if d := cmp.Diff(wantAttempts, tr.Status.Attempts); d != "" {
+				t.Errorf("Unexpected attempts %s", diff.PrintWantGot(d))
+			}
+			if d := cmp.Diff(tc.wantRetriesStatus, tr.Status.RetriesStatus, ignoreLastTransitionTime); d != "" {
+				t.Errorf("Unexpected retries status %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestReconcileWithDuplicateWorkspaceBindings(t *testing.T) {
+	// The TaskRun was created before duplicate workspace bindings were rejected by the webhook.
+	tr := parse.MustParseV1TaskRun(t, `
+metadata:
+  name: test-taskrun
+  namespace: foo
+spec:
+  taskSpec:
+    workspaces:
+    - name: s1
+    steps:
+    - image: myimage
+      script: ls $(workspaces.s1.path)
+  workspaces:
+  - name: s1
+    emptyDir: {}
+  - name: s1
+    configMap:
+      name: config
+`)
+	d := test.Data{TaskRuns: []*v1.TaskRun{tr}}
+	testAssets, cancel := getTaskRunController(t, d)
+	defer cancel()
+	createServiceAccount(t, testAssets, "default", tr.Namespace)
+
+	err := testAssets.Controller.Reconciler.Reconcile(testAssets.Ctx, getRunName(tr))
+	if ok, _ := controller.IsRequeueKey(err); !ok {
+		t.Fatalf("Unexpected error when reconciling TaskRun: %v", err)
+	}
+	wantEvents := []string{
+		`Warning DuplicateWorkspaceBindings Workspaces \["s1"\] are bound more than once by TaskRun "test-taskrun", only their first binding is used`,
+		"Normal Started",
+		"Normal Running Not all Steps in the Task have finished executing",
+	}
+	if err := k8sevent.CheckEventsOrdered(t, testAssets.Recorder.Events, "duplicate-workspace-bindings", wantEvents); err != nil {
+		t.Error(err)
+	}
+
+	reconciledRun, err := testAssets.Clients.Pipeline.TektonV1().TaskRuns(tr.Namespace).Get(testAssets.Ctx, tr.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Failed to get the reconciled TaskRun: %v", err)
+	}
+	if got := reconciledRun.Annotations[v1.DuplicateWorkspaceBindingsAnnotation]; got != "s1" {
+		t.Errorf("Expected the TaskRun to be annotated with its duplicate workspace bindings %q, got %q", "s1", got)
+	}
+	pod, err := testAssets.Clients.Kube.CoreV1().Pods(tr.Namespace).Get(testAssets.Ctx, reconciledRun.Status.PodName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Failed to get the pod of the TaskRun: %v", err)
+	}
+	for _, v := range pod.Spec.Volumes {
+		if v.ConfigMap != nil {
+			t.Errorf("Expected the workspace to be bound to its first binding, got the volume %v", v)
+		}
+	}
+}
+
 func TestReconcileGetTaskError(t *testing.T) {
 	tr := parse.MustParseV1TaskRun(t, `
 metadata:
@@ -2618,6 +3356,226 @@ status:
 	}
 }
 
+// TestReconcilePodUpdateStatus_ClockSkew tests that the completion time of a TaskRun started by a
+// controller whose clock is ahead is set to its start time, and that the skew is recorded, both
+// measured from the same reading of the clock of the reconciler.
+func TestReconcilePodUpdateStatus_ClockSkew(t *testing.T) {
+	taskRun := parse.MustParseV1TaskRun(t, `
+metadata:
+  name: test-taskrun-clock-skew
+  namespace: foo
+spec:
+  taskRef:
+    name: test-task
+status:
+  podName: test-taskrun-clock-skew-pod
+`)
+	startTime := metav1.NewTime(now.Add(time.Hour))
+	taskRun.Status.StartTime = &startTime
+	taskRun.Status.SetCondition(&apis.Condition{
+		Type:   apis.ConditionSucceeded,
+		Status: corev1.ConditionUnknown,
+		Reason: v1.TaskRunReasonRunning.String(),
+	})
+
+	pod, err := makePod(taskRun, simpleTask)
+	if err != nil {
+		t.Fatalf("MakePod: %v", err)
+	}
+	pod.Status = corev1.PodStatus{Phase: corev1.PodSucceeded}
+	d := test.Data{
+		TaskRuns: []*v1.TaskRun{taskRun},
+		Tasks:    []*v1.Task{simpleTask},
+		Pods:     []*corev1.Pod{pod},
+	}
+
+	testAssets, cancel := getTaskRunController(t, d)
+	defer cancel()
+	c := testAssets.Controller
+	clients := testAssets.Clients
+
+	if err := c.Reconciler.Reconcile(testAssets.Ctx, getRunName(taskRun)); err != nil {
+		if ok, _ := controller.IsRequeueKey(err); !ok {
+			t.Fatalf("Unexpected error when Reconcile(): %v", err)
+		}
+	}
+	newTr, err := clients.Pipeline.TektonV1().TaskRuns(taskRun.Namespace).Get(testAssets.Ctx, taskRun.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Unexpected error fetching taskrun: %v", err)
+	}
+	if !newTr.Status.GetCondition(apis.ConditionSucceeded).IsTrue() {
+		t.Fatalf("Expected the TaskRun to succeed, got condition %v", newTr.Status.GetCondition(apis.ConditionSucceeded))
+	}
+	if newTr.Status.CompletionTime == nil || !newTr.Status.CompletionTime.Equal(newTr.Status.StartTime) {
+		t.Errorf("Expected the completion time to be set to the start time %v, got %v", newTr.Status.StartTime, newTr.Status.CompletionTime)
+	}
+	if got := newTr.Annotations[podconvert.ClockSkewAnnotation]; got != time.Hour.String() {
+		t.Errorf("Expected annotation %s to be %s, got %q", podconvert.ClockSkewAnnotation, time.Hour, got)
+	}
+}
+
+// TestReconcile_DeletedPod tests that a replacement pod is created for the TaskRuns opted in with the
+// tekton.dev/recreate-deleted-pod annotation whose pod is deleted out-of-band, counting against their retries.
+func TestReconcile_DeletedPod(t *testing.T) {
+	deletedPodName := "test-taskrun-pod-deleted-pod"
+	startTime := metav1.NewTime(now.Add(-time.Minute))
+	for _, tc := range []struct {
+		name             string
+		recreateFlag     string
+		annotation       string
+		retries          int
+		retriesStatus    v1.RetriesStatus
+		stepResults      []v1.TaskRunStepResult
+		podBeingDeleted  bool
+		wantPodName      string
+		wantArchived     bool
+		wantFailedReason string
+	}{{
+		name:         "pod deleted",
+		recreateFlag: "true",
+		annotation:   "true",
+		wantPodName:  deletedPodName + "-retry1",
+		wantArchived: true,
+	}, {
+		name:            "pod being deleted",
+		recreateFlag:    "true",
+		annotation:      "true",
+		podBeingDeleted: true,
+		wantPodName:     deletedPodName + "-retry1",
+		wantArchived:    true,
+	}, {
+		name:         "pod deleted with retries left",
+		recreateFlag: "true",
+		annotation:   "true",
+		retries:      1,
+		wantPodName:  deletedPodName + "-retry1",
+		wantArchived: true,
+	}, {
+		name:             "pod deleted without retries left",
+		recreateFlag:     "true",
+		annotation:       "true",
+		retries:          1,
+		retriesStatus:    v1.RetriesStatus{{TaskRunStatusFields: v1.TaskRunStatusFields{PodName: "test-taskrun-pod-deleted-pod-previous"}}},
+		wantFailedReason: v1.TaskRunReasonPodDeleted.String(),
+	}, {
+		name:         "feature flag disabled",
+		recreateFlag: "false",
+		annotation:   "true",
+		wantPodName:  deletedPodName,
+	}, {
+		name:         "not opted in",
+		recreateFlag: "true",
+		wantPodName:  deletedPodName,
+	}, {
+		name:         "step results produced",
+		recreateFlag: "true",
+		annotation:   "true",
+		stepResults:  []v1.TaskRunStepResult{{Name: "digest", Type: v1.ResultsTypeString, Value: *v1.NewStructuredValues("sha256:abc")}},
+		wantPodName:  deletedPodName,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			tr := &v1.TaskRun{
+				ObjectMeta: objectMeta("test-taskrun-pod-deleted", "foo"),
+				Spec: v1.TaskRunSpec{
+					TaskRef: &v1.TaskRef{Name: simpleTask.Name},
+					Retries: tc.retries,
+				},
+				Status: v1.TaskRunStatus{
+					Status: duckv1.Status{Conditions: duckv1.Conditions{{
+						Type:   apis.ConditionSucceeded,
+						Status: corev1.ConditionUnknown,
+						Reason: v1.TaskRunReasonRunning.String(),
+					}}},
+					TaskRunStatusFields: v1.TaskRunStatusFields{
+						PodName:       deletedPodName,
+						StartTime:     &startTime,
+						RetriesStatus: tc.retriesStatus,
+						Steps:         []v1.StepState{{Name: "simple-step", Container: "step-simple-step", Results: tc.stepResults}},
+					},
+				},
+			}
+			if tc.annotation != "" {
+				tr.Annotations = map[string]string{v1.RecreateDeletedPodAnnotation: tc.annotation}
+			}
+			d := test.Data{
+				TaskRuns: []*v1.TaskRun{tr},
+				Tasks:    []*v1.Task{simpleTask},
+				ConfigMaps: []*corev1.ConfigMap{{
+					ObjectMeta: metav1.ObjectMeta{Namespace: system.Namespace(), Name: config.GetFeatureFlagsConfigName()},
+					Data:       map[string]string{"enable-deleted-pod-recreation": tc.recreateFlag},
+				}},
+			}
+			if tc.podBeingDeleted {
+				d.Pods = []*corev1.Pod{{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:              deletedPodName,
+						Namespace:         "foo",
+						DeletionTimestamp: &metav1.Time{Time: now},
+						Finalizers:        []string{"kubernetes"},
+					},
+				}}
+			}
+			testAssets, cancel := getTaskRunController(t, d)
+			defer cancel()
+			createServiceAccount(t, testAssets, tr.Spec.ServiceAccountName, tr.Namespace)
+
+			err := testAssets.Controller.Reconciler.Reconcile(testAssets.Ctx, getRunName(tr))
+			if tc.wantFailedReason == "" && err != nil {
+				if ok, _ := controller.IsRequeueKey(err); !ok {
+					t.Fatalf("Unexpected error when reconciling the TaskRun: %v", err)
+				}
+			}
+			reconciledRun, err := testAssets.Clients.Pipeline.TektonV1().TaskRuns(tr.Namespace).Get(testAssets.Ctx, tr.Name, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Error getting the reconciled TaskRun: %v", err)
+			}
+
+			condition := reconciledRun.Status.GetCondition(apis.ConditionSucceeded)
+			if tc.wantFailedReason != "" {
+				if !condition.IsFalse() || condition.Reason != tc.wantFailedReason {
+					t.Errorf("Expected the TaskRun to fail with reason %q, got condition %#v", tc.wantFailedReason, condition)
+				}
+				return
+			}
+			if !condition.IsUnknown() {
+				t.Errorf("Expected the TaskRun to be running, got condition %#v", condition)
+			}
+			if reconciledRun.Status.PodName != tc.wantPodName {
+				t.Errorf("Expected the TaskRun to run in pod %q, got %q", tc.wantPodName, reconciledRun.Status.PodName)
+			}
+			if _, err := testAssets.Clients.Kube.CoreV1().Pods(tr.Namespace).Get(testAssets.Ctx, tc.wantPodName, metav1.GetOptions{}); err != nil {
+				t.Errorf("Expected the pod %q to be created: %v", tc.wantPodName, err)
+			}
+			if !reconciledRun.Status.StartTime.Equal(&startTime) {
+				t.Errorf("Expected the start time of the TaskRun to be kept as %v, got %v", startTime, reconciledRun.Status.StartTime)
+			}
+
+			if !tc.wantArchived {
+				if len(reconciledRun.Status.RetriesStatus) != 0 {
+					t.Errorf("Expected no archived status, got %#v", reconciledRun.Status.RetriesStatus)
+				}
+				return
+			}
+			if len(reconciledRun.Status.RetriesStatus) != 1 {
+				t.Fatalf("Expected the status with the deleted pod to be archived, got %#v", reconciledRun.Status.RetriesStatus)
+			}
+			archived := reconciledRun.Status.RetriesStatus[0]
+			if archived.PodName != deletedPodName {
+				t.Errorf("Expected the archived status to record the deleted pod %q, got %q", deletedPodName, archived.PodName)
+			}
+			archivedCondition := archived.GetCondition(apis.ConditionSucceeded)
+			wantMessage := fmt.Sprintf("the pod %s of TaskRun %s was deleted", deletedPodName, tr.Name)
+			if !archivedCondition.IsFalse() || archivedCondition.Reason != v1.TaskRunReasonPodDeleted.String() || archivedCondition.Message != wantMessage {
+				t.Errorf("Expected the archived status to fail with reason %q and message %q, got %#v", v1.TaskRunReasonPodDeleted, wantMessage, archivedCondition)
+			}
+			deletedPod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: deletedPodName}}
+			if !podconvert.IsPodArchived(deletedPod, &reconciledRun.Status) {
+				t.Errorf("Expected the deleted pod %q to be archived", deletedPodName)
+			}
+		})
+	}
+}
+
 func TestReconcileOnCompletedTaskRun(t *testing.T) {
 	taskRun := parse.MustParseV1TaskRun(t, `
 metadata:
@@ -3486,6 +4444,57 @@ status:
 	}
 }
 
+func TestReconcileTimeoutAtMaximumTaskRunTimeout(t *testing.T) {
+	taskRun := parse.MustParseV1TaskRun(t, `
+metadata:
+  name: test-taskrun-no-timeout
+  namespace: foo
+spec:
+  taskRef:
+    name: test-task
+  timeout: 0s
+status:
+  conditions:
+  - status: Unknown
+    type: Succeeded
+  startTime: "2021-12-31T23:29:00Z"
+`)
+	d := test.Data{
+		TaskRuns: []*v1.TaskRun{taskRun},
+		Tasks:    []*v1.Task{simpleTask},
+		ConfigMaps: []*corev1.ConfigMap{{
+			ObjectMeta: metav1.ObjectMeta{Name: config.GetDefaultsConfigName(), Namespace: system.Namespace()},
+			Data: map[string]string{
+				"default-maximum-taskrun-timeout": "30m",
+			},
+		}},
+	}
+	testAssets, cancel := getTaskRunController(t, d)
+	defer cancel()
+	c := testAssets.Controller
+	clients := testAssets.Clients
+
+	if err := c.Reconciler.Reconcile(testAssets.Ctx, getRunName(taskRun)); err != nil {
+		t.Fatalf("Unexpected error when reconciling the TaskRun: %v", err)
+	}
+	newTr, err := clients.Pipeline.TektonV1().TaskRuns(taskRun.Namespace).Get(testAssets.Ctx, taskRun.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Expected TaskRun %s to exist but instead got error when getting it: %v", taskRun.Name, err)
+	}
+	wantCondition := &apis.Condition{
+		Type:    apis.ConditionSucceeded,
+		Status:  corev1.ConditionFalse,
+		Reason:  "TaskRunTimeout",
+		Message: `TaskRun "test-taskrun-no-timeout" failed to finish within "30m0s"`,
+	}
+	if d := cmp.Diff(wantCondition, newTr.Status.GetCondition(apis.ConditionSucceeded), ignoreLastTransitionTime); d != "" {
+		t.Errorf("Did not get expected condition %s", diff.PrintWantGot(d))
+	}
+	if err := k8sevent.CheckEventsOrdered(t, testAssets.Recorder.Events, taskRun.Name, []string{"Warning Failed "}); err != nil {
+		t.Error(err)
+	}
+}
+
 func TestPropagatedWorkspaces(t *testing.T) {
 	taskRun := parse.MustParseV1TaskRun(t, `
 metadata:
@@ -4393,6 +5402,7 @@ spec:
 		Clock:             testClock,
 		taskRunLister:     testAssets.Informers.TaskRun.Lister(),
 		limitrangeLister:  testAssets.Informers.LimitRange.Lister(),
+		configMapLister:   testAssets.Informers.ConfigMap.Lister(),
 		metrics:           nil, // Not used
 		entrypointCache:   nil, // Not used
 		pvcHandler:        volumeclaim.NewPVCHandler(testAssets.Clients.Kube, testAssets.Logger),
@@ -4500,6 +5510,7 @@ spec:
 		Clock:             testClock,
 		taskRunLister:     testAssets.Informers.TaskRun.Lister(),
 		limitrangeLister:  testAssets.Informers.LimitRange.Lister(),
+		configMapLister:   testAssets.Informers.ConfigMap.Lister(),
 		metrics:           nil, // Not used
 		entrypointCache:   nil, // Not used
 		pvcHandler:        volumeclaim.NewPVCHandler(testAssets.Clients.Kube, testAssets.Logger),
@@ -4552,6 +5563,7 @@ status:
 		Clock:             testClock,
 		taskRunLister:     testAssets.Informers.TaskRun.Lister(),
 		limitrangeLister:  testAssets.Informers.LimitRange.Lister(),
+		configMapLister:   testAssets.Informers.ConfigMap.Lister(),
 		metrics:           nil, // Not used
 		entrypointCache:   nil, // Not used
 		pvcHandler:        volumeclaim.NewPVCHandler(testAssets.Clients.Kube, testAssets.Logger),
@@ -4564,6 +5576,8 @@ status:
 		expectedType   apis.ConditionType
 		expectedStatus corev1.ConditionStatus
 		expectedReason string
+		// expectedMessage is checked only if set
+		expectedMessage string
 	}{
 		{
 			description:    "ResourceQuotaConflictError does not fail taskrun",
@@ -4597,7 +5611,10 @@ status:
 					"allowPrivilegeEscalation=false)")),
 			expectedType:   apis.ConditionSucceeded,
 			expectedStatus: corev1.ConditionFalse,
-			expectedReason: podconvert.ReasonPodAdmissionFailed,
+			expectedReason: podconvert.ReasonPodSecurityViolation,
+			expectedMessage: "pod violates PodSecurity \"restricted:latest\":\n" +
+				"- allowPrivilegeEscalation != false (containers \"prepare\", \"place-scripts\", \"test-task\", \"test-task\" must set securityContext.allowPrivilegeEscalation=false); " +
+				"fix with the \"set-security-context\" feature flag for the containers injected by Tekton, securityContext.allowPrivilegeEscalation=false on steps and sidecars",
 		}, {
 			description: "errors validating security context constraint (Openshift) fail the taskrun",
 			err: k8sapierrors.NewForbidden(k8sruntimeschema.GroupResource{Group: "foo", Resource: "bar"}, "baz",
@@ -4605,18 +5622,35 @@ status:
 			expectedType:   apis.ConditionSucceeded,
 			expectedStatus: corev1.ConditionFalse,
 			expectedReason: podconvert.ReasonPodAdmissionFailed,
+		}, {
+			description:     "errors resolving the digests of the images fail the taskrun",
+			err:             fmt.Errorf("translating TaskSpec to Pod: %w", &podconvert.ImageDigestResolutionError{Image: "registry.example.com/builder:1.0", Err: errors.New("MANIFEST_UNKNOWN")}),
+			expectedType:    apis.ConditionSucceeded,
+			expectedStatus:  corev1.ConditionFalse,
+			expectedReason:  podconvert.ReasonImageDigestResolutionFailed,
+			expectedMessage: `translating TaskSpec to Pod: failed to resolve the digest of image "registry.example.com/builder:1.0": MANIFEST_UNKNOWN`,
+		}, {
+			description: "pods violating the PodSecurity level of the namespace fail the taskrun",
+			err: &podconvert.PodSecurityPreflightError{Policy: "baseline:latest", Violations: []podconvert.PodSecurityViolation{{
+				Check: "host namespaces", Details: "hostNetwork=true", Remediation: "podTemplate.hostNetwork",
+			}}},
+			expectedType:    apis.ConditionSucceeded,
+			expectedStatus:  corev1.ConditionFalse,
+			expectedReason:  podconvert.ReasonTaskRunPodSecurityViolation,
+			expectedMessage: "pod violates PodSecurity \"baseline:latest\":\n- host namespaces (hostNetwork=true); fix with podTemplate.hostNetwork",
 		},
 	}
 	for _, tc := range testcases {
 		t.Run(tc.description, func(t *testing.T) {
 			c.handlePodCreationError(taskRun, tc.err)
 			foundCondition := false
-			reason := ""
+			reason, message := "", ""
 			var status corev1.ConditionStatus
 			for _, cond := range taskRun.Status.Conditions {
 				if cond.Type == tc.expectedType {
 					reason = cond.Reason
 					status = cond.Status
+					message = cond.Message
 					if status == tc.expectedStatus && reason == tc.expectedReason {
 						foundCondition = true
 						break
@@ -4626,10 +5660,41 @@ status:
 			if !foundCondition {
 				t.Errorf("expected to find condition type %q, status %q and reason %q [Found reason: %q ] [Found status: %q]", tc.expectedType, tc.expectedStatus, tc.expectedReason, reason, status)
 			}
+			if tc.expectedMessage != "" && message != tc.expectedMessage {
+				t.Errorf("expected condition message %q, got %q", tc.expectedMessage, message)
+			}
 		})
 	}
 }
 
+// TestHandlePodCreationError_WaitingSteps tests that the steps of a TaskRun whose pod couldn't be created
+// are listed as waiting for it.
+func TestHandlePodCreationError_WaitingSteps(t *testing.T) {
+	tr := &v1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-taskrun", Namespace: "foo"},
+		Spec:       v1.TaskRunSpec{TaskRef: &v1.TaskRef{Name: "test-task"}},
+		Status: v1.TaskRunStatus{TaskRunStatusFields: v1.TaskRunStatusFields{
+			TaskSpec: &v1.TaskSpec{Steps: []v1.Step{{Name: "build"}, {Name: "push"}}},
+		}},
+	}
+	c := &Reconciler{}
+	c.handlePodCreationError(tr, errors.New("this is a fatal error"))
+
+	msg := `failed to create task run pod "test-taskrun": this is a fatal error. Maybe missing or invalid Task foo/test-task`
+	want := []v1.StepState{{
+		ContainerState: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: podconvert.ReasonPodCreationFailed, Message: msg}},
+		Name:           "build",
+		Container:      "step-build",
+	}, {
+		ContainerState: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: podconvert.ReasonPodCreationFailed, Message: msg}},
+		Name:           "push",
+		Container:      "step-push",
+	}}
+	if d := cmp.Diff(want, tr.Status.Steps); d != "" {
+		t.Errorf("Unexpected step states %s", diff.PrintWantGot(d))
+	}
+}
+
 // TestCreatePod_Backoff_WebhookTimeout validates the exponential backoff and retry logic in the createPod function.
 // It simulates scenarios where the creation of a Pod initially fails due to webhook timeouts (which should be retried)
 // and where it fails due to a non-retryable error (which should not be retried). The test ensures that the number of
@@ -4734,6 +5799,7 @@ spec:
 				Clock:             testClock,
 				taskRunLister:     testAssets.Informers.TaskRun.Lister(),
 				limitrangeLister:  testAssets.Informers.LimitRange.Lister(),
+				configMapLister:   testAssets.Informers.ConfigMap.Lister(),
 				metrics:           nil,
 				entrypointCache:   nil,
 				pvcHandler:        volumeclaim.NewPVCHandler(testAssets.Clients.Kube, testAssets.Logger),
@@ -5267,6 +6333,7 @@ spec:
 	tcs := []struct {
 		name                string
 		cfgMap              map[string]string
+		annotations         map[string]string
 		expectFailureReason string
 	}{{
 		name: "multiple PVC based Workspaces in per workspace coschedule mode - failure",
@@ -5279,9 +6346,21 @@ spec:
 		cfgMap: map[string]string{
 			"coschedule": "pipelineruns",
 		},
+	}, {
+		name: "multiple PVC based Workspaces of a PipelineRun started in per pipelinerun coschedule mode - success",
+		cfgMap: map[string]string{
+			"coschedule": "workspaces",
+		},
+		annotations: map[string]string{
+			"pipeline.tekton.dev/coschedule": "pipelineruns",
+		},
 	}}
 
 	for _, tc := range tcs {
+		taskRun := taskRun.DeepCopy()
+		for k, v := range tc.annotations {
+			taskRun.Annotations[k] = v
+		}
 		d := test.Data{
 			Tasks:    []*v1.Task{taskWithTwoWorkspaces},
 			TaskRuns: []*v1.TaskRun{taskRun},
@@ -5805,6 +6884,7 @@ status:
 				Clock:             testClock,
 				taskRunLister:     testAssets.Informers.TaskRun.Lister(),
 				limitrangeLister:  testAssets.Informers.LimitRange.Lister(),
+				configMapLister:   testAssets.Informers.ConfigMap.Lister(),
 				metrics:           nil, // Not used
 				entrypointCache:   nil, // Not used
 				pvcHandler:        volumeclaim.NewPVCHandler(testAssets.Clients.Kube, testAssets.Logger),
@@ -6261,6 +7341,286 @@ status:
 	}
 }
 
+func TestReconcile_StepFailFastStopsSidecars(t *testing.T) {
+	for _, tc := range []struct {
+		name             string
+		featureFlags     map[string]string
+		wantSidecarImage string
+	}{{
+		name:             "sidecars stopped as soon as a step failed",
+		featureFlags:     map[string]string{"enable-step-fail-fast": "true"},
+		wantSidecarImage: images.NopImage,
+	}, {
+		name:             "sidecars kept until the steps exited",
+		featureFlags:     map[string]string{},
+		wantSidecarImage: "sidecar-image",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			taskRun := parse.MustParseV1TaskRun(t, `
+metadata:
+  name: test-taskrun-fail-fast
+  namespace: foo
+spec:
+  taskSpec:
+    steps:
+    - name: build
+      image: myimage
+      command: ["/mycmd"]
+    - name: test
+      image: myimage
+      command: ["/mycmd"]
+    sidecars:
+    - name: database
+      image: sidecar-image
+status:
+  podName: test-taskrun-fail-fast-pod
+  conditions:
+  - reason: Running
+    status: "Unknown"
+    type: Succeeded
+`)
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-taskrun-fail-fast-pod", Namespace: "foo"},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{Name: "step-build", Image: "myimage"},
+						{Name: "step-test", Image: "myimage"},
+						{Name: "sidecar-database", Image: "sidecar-image"},
+					},
+				},
+				Status: corev1.PodStatus{
+					Phase: corev1.PodRunning,
+					ContainerStatuses: []corev1.ContainerStatus{
+						{Name: "step-build", State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 1}}},
+						{Name: "step-test", State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}},
+						{Name: "sidecar-database", State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}},
+					},
+				},
+			}
+			d := test.Data{
+				Pods:     []*corev1.Pod{pod},
+				TaskRuns: []*v1.TaskRun{taskRun},
+				ConfigMaps: []*corev1.ConfigMap{{
+					ObjectMeta: metav1.ObjectMeta{Namespace: system.Namespace(), Name: config.GetFeatureFlagsConfigName()},
+					Data:       tc.featureFlags,
+				}},
+			}
+			testAssets, cancel := getTaskRunController(t, d)
+			defer cancel()
+			c := testAssets.Controller
+			clients := testAssets.Clients
+
+			if err := c.Reconciler.Reconcile(testAssets.Ctx, getRunName(taskRun)); err != nil {
+				if ok, _ := controller.IsRequeueKey(err); !ok {
+					t.Fatalf("Reconcile: %v", err)
+				}
+			}
+
+			tr, err := clients.Pipeline.TektonV1().TaskRuns(taskRun.Namespace).Get(testAssets.Ctx, taskRun.Name, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Get TaskRun: %v", err)
+			}
+			if tr.IsDone() {
+				t.Errorf("TaskRun is done while its steps are running: %v", tr.Status.GetCondition(apis.ConditionSucceeded))
+			}
+			retrievedPod, err := clients.Kube.CoreV1().Pods(pod.Namespace).Get(testAssets.Ctx, pod.Name, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Get pod: %v", err)
+			}
+			if d := cmp.Diff(tc.wantSidecarImage, retrievedPod.Spec.Containers[2].Image); d != "" {
+				t.Errorf("Unexpected sidecar image %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestReconcile_SidecarOnError(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		onError     string
+		wantReason  string
+		wantMessage string
+		wantPod     bool
+	}{{
+		name:        "stopAndFail sidecar fails the TaskRun",
+		onError:     "stopAndFail",
+		wantReason:  v1.TaskRunReasonSidecarStopAndFail.String(),
+		wantMessage: `sidecar "proxy" exited with code 2: upstream unreachable`,
+	}, {
+		name:       "ignore sidecar lets the steps run",
+		onError:    "ignore",
+		wantReason: v1.TaskRunReasonRunning.String(),
+		wantPod:    true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			taskRun := parse.MustParseV1TaskRun(t, fmt.Sprintf(`
+metadata:
+  name: test-taskrun-sidecar-on-error
+  namespace: foo
+spec:
+  taskSpec:
+    steps:
+    - name: build
+      image: myimage
+      command: ["/mycmd"]
+    sidecars:
+    - name: proxy
+      image: sidecar-image
+      onError: %s
+status:
+  podName: test-taskrun-sidecar-on-error-pod
+  conditions:
+  - reason: Running
+    status: "Unknown"
+    type: Succeeded
+`, tc.onError))
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "test-taskrun-sidecar-on-error-pod",
+					Namespace:   "foo",
+					Annotations: map[string]string{"tekton.dev/ready": "READY"},
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{Name: "step-build", Image: "myimage"},
+						{Name: "sidecar-proxy", Image: "sidecar-image"},
+					},
+				},
+				Status: corev1.PodStatus{
+					Phase: corev1.PodRunning,
+					ContainerStatuses: []corev1.ContainerStatus{
+						{Name: "step-build", State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}},
+						{Name: "sidecar-proxy", State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+							ExitCode: 2,
+							Reason:   "Error",
+							Message:  "upstream unreachable",
+						}}},
+					},
+				},
+			}
+			d := test.Data{
+				Pods:     []*corev1.Pod{pod},
+				TaskRuns: []*v1.TaskRun{taskRun},
+			}
+			testAssets, cancel := getTaskRunController(t, d)
+			defer cancel()
+			c := testAssets.Controller
+			clients := testAssets.Clients
+
+			if err := c.Reconciler.Reconcile(testAssets.Ctx, getRunName(taskRun)); err != nil {
+				if ok, _ := controller.IsRequeueKey(err); !ok {
+					t.Fatalf("Reconcile: %v", err)
+				}
+			}
+
+			tr, err := clients.Pipeline.TektonV1().TaskRuns(taskRun.Namespace).Get(testAssets.Ctx, taskRun.Name, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Get TaskRun: %v", err)
+			}
+			condition := tr.Status.GetCondition(apis.ConditionSucceeded)
+			if d := cmp.Diff(tc.wantReason, condition.Reason); d != "" {
+				t.Errorf("Unexpected reason %s", diff.PrintWantGot(d))
+			}
+			if tc.wantMessage != "" {
+				if d := cmp.Diff(tc.wantMessage, condition.Message); d != "" {
+					t.Errorf("Unexpected message %s", diff.PrintWantGot(d))
+				}
+			}
+			_, err = clients.Kube.CoreV1().Pods(pod.Namespace).Get(testAssets.Ctx, pod.Name, metav1.GetOptions{})
+			if gotPod := err == nil; gotPod != tc.wantPod {
+				t.Errorf("pod exists: %t, want %t (err: %v)", gotPod, tc.wantPod, err)
+			}
+		})
+	}
+}
+
+// TestReconcile_ReadinessGates tests that the first step of a TaskRun only starts, which starts the
+// timeout of the step, once the readiness gates of its pod are met.
+func TestReconcile_ReadinessGates(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		gateStatus corev1.ConditionStatus
+		wantReason string
+		wantReady  bool
+	}{{
+		name:       "readiness gate not met",
+		gateStatus: corev1.ConditionFalse,
+		wantReason: podconvert.ReasonReadinessGatesPending,
+	}, {
+		name:       "readiness gate met",
+		gateStatus: corev1.ConditionTrue,
+		wantReason: v1.TaskRunReasonRunning.String(),
+		wantReady:  true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			taskRun := parse.MustParseV1TaskRun(t, `
+metadata:
+  name: test-taskrun-readiness-gates
+  namespace: foo
+spec:
+  taskSpec:
+    steps:
+    - name: build
+      image: myimage
+      command: ["/mycmd"]
+status:
+  podName: test-taskrun-readiness-gates-pod
+  conditions:
+  - reason: Running
+    status: "Unknown"
+    type: Succeeded
+`)
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "test-taskrun-readiness-gates-pod",
+					Namespace:   "foo",
+					Annotations: map[string]string{"tekton.dev/ready": ""},
+				},
+				Spec: corev1.PodSpec{
+					Containers:     []corev1.Container{{Name: "step-build", Image: "myimage"}},
+					ReadinessGates: []corev1.PodReadinessGate{{ConditionType: "mesh.example.com/ready"}},
+				},
+				Status: corev1.PodStatus{
+					Phase:      corev1.PodRunning,
+					Conditions: []corev1.PodCondition{{Type: "mesh.example.com/ready", Status: tc.gateStatus}},
+					ContainerStatuses: []corev1.ContainerStatus{
+						{Name: "step-build", State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}},
+					},
+				},
+			}
+			d := test.Data{
+				Pods:     []*corev1.Pod{pod},
+				TaskRuns: []*v1.TaskRun{taskRun},
+			}
+			testAssets, cancel := getTaskRunController(t, d)
+			defer cancel()
+			c := testAssets.Controller
+			clients := testAssets.Clients
+
+			if err := c.Reconciler.Reconcile(testAssets.Ctx, getRunName(taskRun)); err != nil {
+				if ok, _ := controller.IsRequeueKey(err); !ok {
+					t.Fatalf("Reconcile: %v", err)
+				}
+			}
+
+			tr, err := clients.Pipeline.TektonV1().TaskRuns(taskRun.Namespace).Get(testAssets.Ctx, taskRun.Name, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Get TaskRun: %v", err)
+			}
+			if d := cmp.Diff(tc.wantReason, tr.Status.GetCondition(apis.ConditionSucceeded).Reason); d != "" {
+				t.Errorf("Unexpected reason %s", diff.PrintWantGot(d))
+			}
+			gotPod, err := clients.Kube.CoreV1().Pods(pod.Namespace).Get(testAssets.Ctx, pod.Name, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Get pod: %v", err)
+			}
+			if gotReady := gotPod.Annotations["tekton.dev/ready"] == "READY"; gotReady != tc.wantReady {
+				t.Errorf("first step released: %t, want %t", gotReady, tc.wantReady)
+			}
+		})
+	}
+}
+
 func TestStopSidecars_WithInjectedSidecarsNoTaskSpecSidecars(t *testing.T) {
 	sidecarTask := &v1.Task{
 		ObjectMeta: objectMeta("test-task-injected-sidecar", "foo"),
@@ -6710,6 +8070,10 @@ func expectedPod(podName, taskName, taskRunName, taskRunUID, ns, saName string,
 			Command:                []string{entrypointLocation},
 			VolumeMounts:           podVolumeMounts(idx, len(steps)),
 			TerminationMessagePath: "/tekton/termination",
+			Env: []corev1.EnvVar{{
+				Name:      podconvert.TektonPodIPEnvVar,
+				ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "status.podIP"}},
+			}},
 		}
 		stepContainer.Args = podArgs(s.cmd, s.stdoutPath, s.stderrPath, s.args, idx)
 
@@ -6943,6 +8307,59 @@ spec:
 	}
 }
 
+func TestReconcile_TaskRunWithParam_Constraints_invalid(t *testing.T) {
+	taskWithParamConstraints := parse.MustParseV1Task(t, `
+metadata:
+  name: test-task-param-constraints
+  namespace: foo
+spec:
+  params:
+    - name: tag
+      type: string
+      pattern: "^v[0-9]+$"
+  steps:
+    - name: simple-step
+      image: foo
+      command: ["/mycmd"]
+`)
+	taskRunWithParamInvalid := parse.MustParseV1TaskRun(t, `
+metadata:
+  name: test-taskrun-with-param-constraints-invalid
+  namespace: foo
+spec:
+  params:
+    - name: tag
+      value: latest
+  taskRef:
+    name: test-task-param-constraints
+`)
+
+	d := test.Data{
+		TaskRuns: []*v1.TaskRun{taskRunWithParamInvalid},
+		Tasks:    []*v1.Task{taskWithParamConstraints},
+	}
+
+	expectedErr := errors.New(`param "tag" value "latest" does not match its pattern "^v[0-9]+$"`)
+	expectedFailureReason := "InvalidParamValue"
+	testAssets, cancel := getTaskRunController(t, d)
+	defer cancel()
+	createServiceAccount(t, testAssets, taskRunWithParamInvalid.Spec.ServiceAccountName, taskRunWithParamInvalid.Namespace)
+
+	// Reconcile the TaskRun
+	err := testAssets.Controller.Reconciler.Reconcile(testAssets.Ctx, getRunName(taskRunWithParamInvalid))
+	if d := cmp.Diff(expectedErr.Error(), strings.TrimSuffix(err.Error(), "\n\n")); d != "" {
+		t.Errorf("Expected: %v, but Got: %v", expectedErr, err)
+	}
+	tr, err := testAssets.Clients.Pipeline.TektonV1().TaskRuns(taskRunWithParamInvalid.Namespace).Get(testAssets.Ctx, taskRunWithParamInvalid.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Error getting updated taskrun: %v", err)
+	}
+	condition := tr.Status.GetCondition(apis.ConditionSucceeded)
+	if condition.Type != apis.ConditionSucceeded || condition.Status != corev1.ConditionFalse || condition.Reason != expectedFailureReason {
+		t.Errorf("Expected TaskRun to fail with reason \"%s\" but it did not. Final conditions were:\n%#v", expectedFailureReason, tr.Status.Conditions)
+	}
+}
+
 func TestReconcile_validateTaskRunResults_valid(t *testing.T) {
 	taskRunResultsTypeMatched := parse.MustParseV1TaskRun(t, `
 metadata:
@@ -7272,7 +8689,7 @@ status:
 	failNoKeysCondition := &apis.Condition{
 		Type:    trustedresources.ConditionTrustedResourcesVerified,
 		Status:  corev1.ConditionFalse,
-		Message: fmt.Sprintf("failed to get verifiers for resource %s from namespace %s: %s", ts.Name, ts.Namespace, verifier.ErrEmptyPublicKeys),
+		Message: fmt.Sprintf("failed to get verifiers for resource %s from namespace %s from policy %s: %s", ts.Name, ts.Namespace, "warn-policy", verifier.ErrEmptyPublicKeys),
 	}
 	testCases := []struct {
 		name                          string
@@ -7530,7 +8947,7 @@ status:
 	failNoKeysCondition := &apis.Condition{
 		Type:    trustedresources.ConditionTrustedResourcesVerified,
 		Status:  corev1.ConditionFalse,
-		Message: fmt.Sprintf("failed to get verifiers for resource %s from namespace %s: %s", ts.Name, ts.Namespace, verifier.ErrEmptyPublicKeys),
+		Message: fmt.Sprintf("failed to get verifiers for resource %s from namespace %s from policy %s: %s", ts.Name, ts.Namespace, "warn-policy", verifier.ErrEmptyPublicKeys),
 	}
 	testCases := []struct {
 		name                          string
@@ -8521,3 +9938,103 @@ func TestAppendPreviousConditionContext(t *testing.T) {
 		})
 	}
 }
+
+func TestReconcile_RunningSlow(t *testing.T) {
+	cms := []*corev1.ConfigMap{{
+		ObjectMeta: metav1.ObjectMeta{Namespace: system.Namespace(), Name: config.GetFeatureFlagsConfigName()},
+		Data: map[string]string{
+			"enable-api-fields": config.AlphaAPIFields,
+		},
+	}}
+	for _, tc := range []struct {
+		name             string
+		expectedDuration string
+		annotations      string
+		startedAgo       time.Duration
+		wantSlow         bool
+		wantEvents       []string
+		wantRequeue      time.Duration
+	}{{
+		name:             "expected duration exceeded",
+		expectedDuration: "expectedDuration: 5m",
+		startedAgo:       16 * time.Minute,
+		wantSlow:         true,
+		wantEvents: []string{
+			"Warning TaskRunRunningSlow TaskRun \"test-taskrun-running-slow\" has been running for 16m0s, more than 3 times its expected duration of 5m0s",
+			"Normal Started ",
+		},
+		wantRequeue: 44 * time.Minute,
+	}, {
+		name:             "expected duration not exceeded yet",
+		expectedDuration: "expectedDuration: 5m",
+		startedAgo:       10 * time.Minute,
+		wantEvents: []string{
+			"Normal Started ",
+		},
+		wantRequeue: 5 * time.Minute,
+	}, {
+		name:             "expected duration from pipeline task exceeded",
+		expectedDuration: "expectedDuration: 1h",
+		annotations: `
+  annotations:
+    pipeline.tekton.dev/pipeline-task-expected-duration: 5m0s`,
+		startedAgo: 16 * time.Minute,
+		wantSlow:   true,
+		wantEvents: []string{
+			"Warning TaskRunRunningSlow TaskRun \"test-taskrun-running-slow\" has been running for 16m0s, more than 3 times its expected duration of 5m0s",
+			"Normal Started ",
+		},
+		wantRequeue: 44 * time.Minute,
+	}, {
+		name:       "no expected duration",
+		startedAgo: 16 * time.Minute,
+		wantEvents: []string{
+			"Normal Started ",
+		},
+		wantRequeue: 44 * time.Minute,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			taskRun := parse.MustParseV1TaskRun(t, fmt.Sprintf(`
+metadata:
+  name: test-taskrun-running-slow
+  namespace: foo%s
+spec:
+  taskSpec:
+    %s
+    steps:
+    - image: myimage
+      name: mycontainer
+      command: ["/mycmd"]
+status:
+  startTime: %s
+`, tc.annotations, tc.expectedDuration, now.Add(-tc.startedAgo).Format(time.RFC3339)))
+			d := test.Data{
+				ConfigMaps: cms,
+				TaskRuns:   []*v1.TaskRun{taskRun},
+			}
+			testAssets, cancel := getTaskRunController(t, d)
+			defer cancel()
+			createServiceAccount(t, testAssets, "default", "foo")
+
+			err := testAssets.Controller.Reconciler.Reconcile(testAssets.Ctx, getRunName(taskRun))
+			ok, requeue := controller.IsRequeueKey(err)
+			if !ok {
+				t.Fatalf("Wanted a wrapped requeue error, but got %v", err)
+			}
+			if requeue != tc.wantRequeue {
+				t.Errorf("Expected requeue after %s but got %s", tc.wantRequeue, requeue)
+			}
+
+			tr, err := testAssets.Clients.Pipeline.TektonV1().TaskRuns(taskRun.Namespace).Get(testAssets.Ctx, taskRun.Name, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("getting updated taskrun: %v", err)
+			}
+			if gotSlow := tr.Annotations[v1.TaskRunRunningSlowAnnotation] == "true"; gotSlow != tc.wantSlow {
+				t.Errorf("Expected running slow annotation to be %t but got annotations %v", tc.wantSlow, tr.Annotations)
+			}
+			if err := k8sevent.CheckEventsOrdered(t, testAssets.Recorder.Events, tc.name, tc.wantEvents); err != nil {
+				t.Error(err.Error())
+			}
+		})
+	}
+}
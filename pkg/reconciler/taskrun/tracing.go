@@ -20,11 +20,15 @@ import (
 	"context"
 	"encoding/json"
 
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/tracing"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/trace"
+	"knative.dev/pkg/apis"
 	"knative.dev/pkg/logging"
 )
 
@@ -76,3 +80,16 @@ func initTracing(ctx context.Context, tracerProvider trace.TracerProvider, tr *v
 	tr.Status.SpanContext = spanContext
 	return ctxWithTrace
 }
+
+// recordRunSpans records the spans of the TaskRun and of its steps when it completed during the
+// reconcile, if the export of the spans of the runs is enabled. The spans of the TaskRuns of a
+// PipelineRun are recorded along with the span of the PipelineRun, as its children.
+func (c *Reconciler) recordRunSpans(ctx context.Context, tr *v1.TaskRun, beforeCondition *apis.Condition) {
+	if cfg := config.FromContextOrDefaults(ctx).Tracing; cfg == nil || !cfg.ExportRunSpans {
+		return
+	}
+	if !tr.IsDone() || (beforeCondition != nil && !beforeCondition.IsUnknown()) || tr.Labels[pipeline.PipelineRunLabelKey] != "" {
+		return
+	}
+	tracing.RecordTaskRunSpans(ctx, c.tracerProvider.Tracer(TracerName), tr, c.Clock.Now())
+}
@@ -15,13 +15,19 @@ package taskrun
 
 import (
 	"testing"
+	"time"
 
+	"github.com/tektoncd/pipeline/pkg/apis/config"
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/propagation"
 	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 	"go.opentelemetry.io/otel/trace"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
 )
 
 func TestInitTracing(t *testing.T) {
@@ -121,3 +127,75 @@ func TestInitTracing(t *testing.T) {
 		})
 	}
 }
+
+func TestRecordRunSpans(t *testing.T) {
+	now := metav1.Now()
+	running := &apis.Condition{Type: apis.ConditionSucceeded, Status: corev1.ConditionUnknown}
+	newTaskRun := func(labels map[string]string) *v1.TaskRun {
+		return &v1.TaskRun{
+			ObjectMeta: metav1.ObjectMeta{Name: "lint", Namespace: "ns", Labels: labels},
+			Status: v1.TaskRunStatus{
+				Status:              duckv1.Status{Conditions: duckv1.Conditions{{Type: apis.ConditionSucceeded, Status: corev1.ConditionTrue}}},
+				TaskRunStatusFields: v1.TaskRunStatusFields{StartTime: &now, CompletionTime: &now},
+			},
+		}
+	}
+	for _, tc := range []struct {
+		name           string
+		taskRun        *v1.TaskRun
+		exportRunSpans bool
+		before         *apis.Condition
+		wantSpans      int
+		wantEnd        time.Time
+	}{{
+		name:           "TaskRun completed during the reconcile",
+		taskRun:        newTaskRun(nil),
+		exportRunSpans: true,
+		before:         running,
+		wantSpans:      1,
+		wantEnd:        now.Time,
+	}, {
+		name: "TaskRun done without a completion time, ended when observed done",
+		taskRun: func() *v1.TaskRun {
+			tr := newTaskRun(nil)
+			tr.Status.CompletionTime = nil
+			return tr
+		}(),
+		exportRunSpans: true,
+		before:         running,
+		wantSpans:      1,
+		wantEnd:        testClock.Now(),
+	}, {
+		name:           "TaskRun completed before the reconcile",
+		taskRun:        newTaskRun(nil),
+		exportRunSpans: true,
+		before:         &apis.Condition{Type: apis.ConditionSucceeded, Status: corev1.ConditionTrue},
+	}, {
+		name:           "TaskRun of a PipelineRun, recorded with the PipelineRun",
+		taskRun:        newTaskRun(map[string]string{"tekton.dev/pipelineRun": "release"}),
+		exportRunSpans: true,
+		before:         running,
+	}, {
+		name:    "run spans not exported",
+		taskRun: newTaskRun(nil),
+		before:  running,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			exporter := tracetest.NewInMemoryExporter()
+			c := &Reconciler{Clock: testClock, tracerProvider: tracesdk.NewTracerProvider(tracesdk.WithSyncer(exporter))}
+			ctx := config.ToContext(t.Context(), &config.Config{Tracing: &config.Tracing{ExportRunSpans: tc.exportRunSpans}})
+
+			c.recordRunSpans(ctx, tc.taskRun, tc.before)
+
+			spans := exporter.GetSpans()
+			if got := len(spans); got != tc.wantSpans {
+				t.Fatalf("recorded %d spans, want %d", got, tc.wantSpans)
+			}
+			for _, s := range spans {
+				if !s.EndTime.Equal(tc.wantEnd) {
+					t.Errorf("span %s ended at %v, want %v", s.Name, s.EndTime, tc.wantEnd)
+				}
+			}
+		})
+	}
+}
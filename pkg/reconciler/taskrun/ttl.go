@@ -0,0 +1,52 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package taskrun
+
+import (
+	"context"
+	"fmt"
+
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	tknreconciler "github.com/tektoncd/pipeline/pkg/reconciler"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+)
+
+// deleteAfterTTL deletes the done TaskRun tr once its ttlSecondsAfterFinished elapsed since it completed,
+// and requeues it until then. The TaskRuns of PipelineRuns are left to the garbage collection.
+func (c *Reconciler) deleteAfterTTL(ctx context.Context, tr *v1.TaskRun) error {
+	if tr.HasPipelineRunOwnerReference() {
+		return nil
+	}
+	wait, ok := tknreconciler.TimeUntilTTLExpires(ctx, tr, tr.Spec.TTLSecondsAfterFinished, tr.Status.CompletionTime, c.Clock.Now())
+	switch {
+	case !ok:
+		return nil
+	case wait > 0:
+		return controller.NewRequeueAfter(wait)
+	}
+	logging.FromContext(ctx).Infof("Deleting TaskRun %s/%s as its ttlSecondsAfterFinished elapsed", tr.Namespace, tr.Name)
+	err := c.PipelineClientSet.TektonV1().TaskRuns(tr.Namespace).Delete(ctx, tr.Name, metav1.DeleteOptions{
+		Preconditions: &metav1.Preconditions{UID: &tr.UID},
+	})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete TaskRun %s after its ttlSecondsAfterFinished: %w", tr.Name, err)
+	}
+	return nil
+}
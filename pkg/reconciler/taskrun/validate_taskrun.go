@@ -204,6 +204,26 @@ func ValidateEnumParam(ctx context.Context, params []v1.Param, paramSpecs v1.Par
 	return nil
 }
 
+// ValidateParamConstraints validates the string param values satisfy the pattern, minLength and maxLength
+// in the corresponding paramSpecs if provided. A validation error naming the param and the constraint
+// violated is returned otherwise.
+func ValidateParamConstraints(params []v1.Param, paramSpecs v1.ParamSpecs) error {
+	for _, p := range params {
+		if p.Value.Type != v1.ParamTypeString {
+			continue
+		}
+		for _, ps := range paramSpecs {
+			if ps.Name != p.Name || ps.Type != v1.ParamTypeString {
+				continue
+			}
+			if err := ps.ValidateConstraints(p.Value.StringVal); err != nil {
+				return pipelineErrors.WrapUserError(err)
+			}
+		}
+	}
+	return nil
+}
+
 func validateTaskSpecRequestResources(taskSpec *v1.TaskSpec) error {
 	if taskSpec != nil {
 		for _, step := range taskSpec.Steps {
@@ -958,3 +958,61 @@ func TestEnumValidation_Failure(t *testing.T) {
 		}
 	}
 }
+
+func TestParamConstraintsValidation(t *testing.T) {
+	paramSpecs := v1.ParamSpecs{{
+		Name:    "tag",
+		Type:    v1.ParamTypeString,
+		Pattern: "^v[0-9]+$",
+	}, {
+		Name:      "name",
+		Type:      v1.ParamTypeString,
+		MinLength: 2,
+		MaxLength: 4,
+	}, {
+		Name: "args",
+		Type: v1.ParamTypeArray,
+	}}
+	tcs := []struct {
+		name        string
+		params      []v1.Param
+		expectedErr error
+	}{{
+		name: "values satisfying the constraints - success",
+		params: []v1.Param{
+			{Name: "tag", Value: *v1.NewStructuredValues("v12")},
+			{Name: "name", Value: *v1.NewStructuredValues("héé")},
+			{Name: "args", Value: *v1.NewStructuredValues("a", "much-longer-value")},
+		},
+	}, {
+		name:        "value not matching the pattern - failure",
+		params:      []v1.Param{{Name: "tag", Value: *v1.NewStructuredValues("latest")}},
+		expectedErr: errors.New(`param "tag" value "latest" does not match its pattern "^v[0-9]+$"`),
+	}, {
+		name:        "value shorter than the minLength - failure",
+		params:      []v1.Param{{Name: "name", Value: *v1.NewStructuredValues("a")}},
+		expectedErr: errors.New(`param "name" value "a" is shorter than its minLength 2`),
+	}, {
+		name:        "value longer than the maxLength - failure",
+		params:      []v1.Param{{Name: "name", Value: *v1.NewStructuredValues("abcde")}},
+		expectedErr: errors.New(`param "name" value "abcde" is longer than its maxLength 4`),
+	}}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateParamConstraints(tc.params, paramSpecs)
+			if tc.expectedErr == nil {
+				if err != nil {
+					t.Errorf("No error expected from ValidateParamConstraints() but got = %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected error from ValidateParamConstraints() = %v, but got none", tc.expectedErr)
+			}
+			if d := cmp.Diff(tc.expectedErr.Error(), err.Error()); d != "" {
+				t.Errorf("expected error does not match: %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
@@ -22,6 +22,7 @@ import (
 
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"github.com/tektoncd/pipeline/pkg/reconciler/events/cloudevent"
+	"github.com/tektoncd/pipeline/pkg/workspace"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zaptest"
 	"k8s.io/client-go/rest"
@@ -75,7 +76,7 @@ func TestLogger(t *testing.T) *zap.SugaredLogger {
 func setupFakeContextWithLabelKey(t zaptest.TestingT) (context.Context, context.CancelFunc, []controller.Informer) {
 	ctx, c := context.WithCancel(logtesting.TestContextWithLogger(t))
 	ctx = controller.WithEventRecorder(ctx, record.NewFakeRecorder(1000))
-	ctx = filteredinformerfactory.WithSelectors(ctx, v1.ManagedByLabelKey)
+	ctx = filteredinformerfactory.WithSelectors(ctx, v1.ManagedByLabelKey, workspace.AffinityAssistantPodSelector)
 	ctx, is := injection.Fake.SetupInformers(ctx, &rest.Config{})
 	return ctx, c, is
 }
@@ -84,7 +85,7 @@ func setupFakeContextWithLabelKey(t zaptest.TestingT) (context.Context, context.
 // The provided context includes the FilteredInformerFactory LabelKey.
 func setupDefaultContextWithLabelKey(t zaptest.TestingT) (context.Context, context.CancelFunc, []controller.Informer) {
 	ctx, c := context.WithCancel(logtesting.TestContextWithLogger(t))
-	ctx = filteredinformerfactory.WithSelectors(ctx, v1.ManagedByLabelKey)
+	ctx = filteredinformerfactory.WithSelectors(ctx, v1.ManagedByLabelKey, workspace.AffinityAssistantPodSelector)
 	ctx, is := injection.Default.SetupInformers(ctx, &rest.Config{})
 	return ctx, c, is
 }
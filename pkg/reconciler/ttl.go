@@ -0,0 +1,40 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconciler
+
+import (
+	"context"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TimeUntilTTLExpires returns how long after now the run obj, which completed at completionTime, is to be
+// deleted with its ttlSecondsAfterFinished ttl, or the "default-ttl-seconds-after-finished" default when
+// it is nil, and whether it is to be deleted at all. The runs annotated with the KeepAnnotation are kept.
+func TimeUntilTTLExpires(ctx context.Context, obj metav1.Object, ttl *int32, completionTime *metav1.Time, now time.Time) (time.Duration, bool) {
+	if ttl == nil {
+		ttl = config.FromContextOrDefaults(ctx).Defaults.DefaultTTLSecondsAfterFinished
+	}
+	if ttl == nil || completionTime == nil || obj.GetDeletionTimestamp() != nil || obj.GetAnnotations()[pipeline.KeepAnnotation] == "true" {
+		return 0, false
+	}
+	expiry := completionTime.Add(time.Duration(*ttl) * time.Second)
+	return expiry.Sub(now), true
+}
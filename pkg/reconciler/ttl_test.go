@@ -0,0 +1,95 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconciler_test
+
+import (
+	"testing"
+	"time"
+
+	cfgtesting "github.com/tektoncd/pipeline/pkg/apis/config/testing"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	reconciler "github.com/tektoncd/pipeline/pkg/reconciler"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestTimeUntilTTLExpires(t *testing.T) {
+	now := time.Now()
+	completed := &metav1.Time{Time: now.Add(-time.Minute)}
+	for _, tc := range []struct {
+		name           string
+		defaultTTL     string
+		ttl            *int32
+		completionTime *metav1.Time
+		annotations    map[string]string
+		deleted        *metav1.Time
+		want           time.Duration
+		wantOK         bool
+	}{{
+		name:           "no ttl",
+		completionTime: completed,
+	}, {
+		name:           "ttl not elapsed",
+		ttl:            ptr.To(int32(3600)),
+		completionTime: completed,
+		want:           59 * time.Minute,
+		wantOK:         true,
+	}, {
+		name:           "ttl elapsed",
+		ttl:            ptr.To(int32(0)),
+		completionTime: completed,
+		want:           -time.Minute,
+		wantOK:         true,
+	}, {
+		name:           "default ttl",
+		defaultTTL:     "120",
+		completionTime: completed,
+		want:           time.Minute,
+		wantOK:         true,
+	}, {
+		name:           "ttl overrides the default ttl",
+		defaultTTL:     "120",
+		ttl:            ptr.To(int32(60)),
+		completionTime: completed,
+		wantOK:         true,
+	}, {
+		name: "not completed",
+		ttl:  ptr.To(int32(0)),
+	}, {
+		name:           "kept",
+		ttl:            ptr.To(int32(0)),
+		completionTime: completed,
+		annotations:    map[string]string{pipeline.KeepAnnotation: "true"},
+	}, {
+		name:           "already deleted",
+		ttl:            ptr.To(int32(0)),
+		completionTime: completed,
+		deleted:        &metav1.Time{Time: now},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := t.Context()
+			if tc.defaultTTL != "" {
+				ctx = cfgtesting.SetDefaults(ctx, t, map[string]string{"default-ttl-seconds-after-finished": tc.defaultTTL})
+			}
+			obj := &metav1.ObjectMeta{Annotations: tc.annotations, DeletionTimestamp: tc.deleted}
+			got, ok := reconciler.TimeUntilTTLExpires(ctx, obj, tc.ttl, tc.completionTime, now)
+			if got != tc.want || ok != tc.wantOK {
+				t.Errorf("Expected %s (%t), got %s (%t)", tc.want, tc.wantOK, got, ok)
+			}
+		})
+	}
+}
@@ -25,31 +25,56 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/workspace"
 	"go.uber.org/zap"
 	"gomodules.xyz/jsonpatch/v2"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
 	clientset "k8s.io/client-go/kubernetes"
+	corev1listers "k8s.io/client-go/listers/core/v1"
 )
 
 const (
 	// ReasonCouldntCreateWorkspacePVC indicates that a Pipeline expects a workspace from a
 	// volumeClaimTemplate but couldn't create a claim.
 	ReasonCouldntCreateWorkspacePVC = "CouldntCreateWorkspacePVC"
+
+	// PVCNameVersion is the version of the naming scheme of the PVCs created from volumeClaimTemplates by
+	// PVCName and AffinityAssistantPVCName. The names given by a version never change between releases:
+	// any change to them requires a new version.
+	PVCNameVersion = "v1"
+
+	// PVCNameVersionAnnotationKey is the annotation recording the PVCNameVersion of the name of a PVC
+	// created from a volumeClaimTemplate.
+	PVCNameVersionAnnotationKey = pipeline.GroupName + "/pvc-name-version"
+
+	// WorkspaceAnnotationKey is the annotation recording the name of the workspace binding a PVC created
+	// from a volumeClaimTemplate was created for, so that the PVC can be looked up without parsing its name.
+	WorkspaceAnnotationKey = pipeline.GroupName + "/workspace"
 )
 
 var (
 	ErrPvcCreationFailed          = errors.New("PVC creation error")
 	ErrPvcCreationFailedRetryable = errors.New("PVC creation error, retryable")
+	// ErrPvcSpecImmutable indicates that the PVC for a volumeClaimTemplate already exists with another spec,
+	// which can't be updated to the one of the volumeClaimTemplate.
+	ErrPvcSpecImmutable = errors.New("PVC spec is immutable")
 )
 
 // PvcHandler is used to create PVCs for workspaces
 type PvcHandler interface {
 	CreatePVCFromVolumeClaimTemplate(ctx context.Context, wb v1.WorkspaceBinding, ownerReference metav1.OwnerReference, namespace string) error
+	CreatePVCsForWorkspaces(ctx context.Context, wbs []v1.WorkspaceBinding, ownerReference metav1.OwnerReference, namespace string) error
 	PurgeFinalizerAndDeletePVCForWorkspace(ctx context.Context, pvcName, namespace string) error
+	SweepLeakedPVCs(ctx context.Context, lister corev1listers.PersistentVolumeClaimNamespaceLister, pipelineRunExists func(name string, uid types.UID) (bool, error)) (int, error)
 }
 
 type defaultPVCHandler struct {
@@ -65,37 +90,112 @@ func NewPVCHandler(clientset clientset.Interface, logger *zap.SugaredLogger) Pvc
 // CreatePVCFromVolumeClaimTemplate checks if a PVC named <claim-name>-<workspace-name>-<owner-name> exists;
 // where claim-name is provided by the user in the volumeClaimTemplate, and owner-name is the name of the
 // resource with the volumeClaimTemplate declared, a PipelineRun or TaskRun. If the PVC did not exist, a new PVC
-// with that name is created with the provided OwnerReference.
+// with that name is created with the provided OwnerReference. A PVC which already exists, including when it
+// was created concurrently, must have a spec matching the volumeClaimTemplate, see pvcSpecMatches.
 func (c *defaultPVCHandler) CreatePVCFromVolumeClaimTemplate(ctx context.Context, wb v1.WorkspaceBinding, ownerReference metav1.OwnerReference, namespace string) error {
 	claim := c.getPVCFromVolumeClaimTemplate(wb, ownerReference, namespace)
 	if claim == nil {
 		return nil
 	}
 
-	_, err := c.clientset.CoreV1().PersistentVolumeClaims(claim.Namespace).Get(ctx, claim.Name, metav1.GetOptions{})
+	existing, err := c.clientset.CoreV1().PersistentVolumeClaims(claim.Namespace).Get(ctx, claim.Name, metav1.GetOptions{})
 	switch {
 	case apierrors.IsNotFound(err):
 		_, err := c.clientset.CoreV1().PersistentVolumeClaims(claim.Namespace).Create(ctx, claim, metav1.CreateOptions{})
-		if err != nil {
-			if apierrors.IsAlreadyExists(err) {
+		if err != nil && isAlreadyExistsError(err) {
+			// The PVC was created since it was looked up, e.g. by a previous attempt whose response was lost.
+			// Some storage webhooks don't report this as an AlreadyExists error, so the PVC is looked up again.
+			existing, getErr := c.clientset.CoreV1().PersistentVolumeClaims(claim.Namespace).Get(ctx, claim.Name, metav1.GetOptions{})
+			switch {
+			case getErr == nil:
+				c.logger.Infof("Tried to create PersistentVolumeClaim %s in namespace %s, but it already exists",
+					claim.Name, claim.Namespace)
+				return checkPVCSpec(existing, claim, wb)
+			case apierrors.IsAlreadyExists(err):
 				c.logger.Infof("Tried to create PersistentVolumeClaim %s in namespace %s, but it already exists",
 					claim.Name, claim.Namespace)
-			} else if isRetryableError(err) {
-				// This is a retry-able error
-				return fmt.Errorf("%w for %s: %v", ErrPvcCreationFailedRetryable, claim.Name, err.Error())
-			} else {
-				return fmt.Errorf("%w for %s: %v", ErrPvcCreationFailed, claim.Name, err.Error())
+				return nil
 			}
-		} else {
+		}
+		switch {
+		case err == nil:
 			c.logger.Infof("Created PersistentVolumeClaim %s in namespace %s", claim.Name, claim.Namespace)
+		case isRetryableError(err):
+			// This is a retry-able error
+			return fmt.Errorf("%w for %s: %v", ErrPvcCreationFailedRetryable, claim.Name, err.Error())
+		default:
+			return fmt.Errorf("%w for %s: %v", ErrPvcCreationFailed, claim.Name, err.Error())
 		}
 	case err != nil:
 		return fmt.Errorf("failed to retrieve PVC %s: %w", claim.Name, err)
+	default:
+		return checkPVCSpec(existing, claim, wb)
 	}
 
 	return nil
 }
 
+// CreatePVCsForWorkspaces creates the PVCs for the workspace bindings wbs with a volumeClaimTemplate, see
+// CreatePVCFromVolumeClaimTemplate. The PVCs of all the workspaces are created even if some of them can't be,
+// and the returned error joins the errors of the workspaces whose PVC couldn't be created.
+func (c *defaultPVCHandler) CreatePVCsForWorkspaces(ctx context.Context, wbs []v1.WorkspaceBinding, ownerReference metav1.OwnerReference, namespace string) error {
+	var errs []error
+	for _, wb := range wbs {
+		if err := c.CreatePVCFromVolumeClaimTemplate(ctx, wb, ownerReference, namespace); err != nil {
+			errs = append(errs, fmt.Errorf("workspace %s: %w", wb.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// checkPVCSpec returns an error if the spec of the existing PVC doesn't match the one of claim, created from
+// the volumeClaimTemplate of wb.
+func checkPVCSpec(existing, claim *corev1.PersistentVolumeClaim, wb v1.WorkspaceBinding) error {
+	if pvcSpecMatches(existing.Spec, claim.Spec) {
+		return nil
+	}
+	return fmt.Errorf("%w for %s: %w: it already exists with a spec which differs from the volumeClaimTemplate of workspace %s",
+		ErrPvcCreationFailed, claim.Name, ErrPvcSpecImmutable, wb.Name)
+}
+
+// pvcSpecMatches reports whether the spec of an existing PVC matches the desired one, from a volumeClaimTemplate:
+// the fields set in desired must be the same in existing, except for the storage requests, which existing may
+// exceed after the PVC was expanded. The fields unset in desired may have been defaulted, or set once bound.
+func pvcSpecMatches(existing, desired corev1.PersistentVolumeClaimSpec) bool {
+	if len(desired.AccessModes) > 0 && !equality.Semantic.DeepEqual(existing.AccessModes, desired.AccessModes) {
+		return false
+	}
+	if desired.Selector != nil && !equality.Semantic.DeepEqual(existing.Selector, desired.Selector) {
+		return false
+	}
+	if desired.StorageClassName != nil && (existing.StorageClassName == nil || *existing.StorageClassName != *desired.StorageClassName) {
+		return false
+	}
+	if desired.VolumeMode != nil && (existing.VolumeMode == nil || *existing.VolumeMode != *desired.VolumeMode) {
+		return false
+	}
+	if desired.VolumeName != "" && existing.VolumeName != desired.VolumeName {
+		return false
+	}
+	if desired.DataSource != nil && !equality.Semantic.DeepEqual(existing.DataSource, desired.DataSource) {
+		return false
+	}
+	if desired.DataSourceRef != nil && !equality.Semantic.DeepEqual(existing.DataSourceRef, desired.DataSourceRef) {
+		return false
+	}
+	for name, quantity := range desired.Resources.Requests {
+		if existingQuantity, found := existing.Resources.Requests[name]; !found || existingQuantity.Cmp(quantity) < 0 {
+			return false
+		}
+	}
+	for name, quantity := range desired.Resources.Limits {
+		if existingQuantity, found := existing.Resources.Limits[name]; !found || !existingQuantity.Equal(quantity) {
+			return false
+		}
+	}
+	return true
+}
+
 // PurgeFinalizerAndDeletePVCForWorkspace deletes pvcs and then purges the `kubernetes.io/pvc-protection` finalizer protection.
 // Purging the `kubernetes.io/pvc-protection` finalizer allows the pvc to be deleted even when it is referenced by a taskrun pod.
 // See mode details in https://kubernetes.io/docs/concepts/storage/persistent-volumes/#storage-object-in-use-protection.
@@ -144,6 +244,110 @@ func (c *defaultPVCHandler) PurgeFinalizerAndDeletePVCForWorkspace(ctx context.C
 	return nil
 }
 
+// SweepLeakedPVCs deletes the PVCs listed by lister that were created from the volumeClaimTemplate of a
+// PipelineRun which no longer exists, according to pipelineRunExists, and returns the number of PVCs deleted.
+// The PipelineRun of a PVC is the one of the labels set by LabelPVCWithOwner or, for the PVCs created before
+// they were set, the PipelineRun of its owner references. The PVCs are recognized by the identity of their
+// name, for the workspace recorded by AnnotatePVCWithWorkspace, or by the format of their name for the PVCs
+// created before it was recorded. A PipelineRun with the same name but another UID, e.g. after a restore from
+// a backup, does not own the PVCs of the former one.
+func (c *defaultPVCHandler) SweepLeakedPVCs(ctx context.Context, lister corev1listers.PersistentVolumeClaimNamespaceLister, pipelineRunExists func(name string, uid types.UID) (bool, error)) (int, error) {
+	pvcs, err := lister.List(labels.Everything())
+	if err != nil {
+		return 0, fmt.Errorf("failed to list the PVCs: %w", err)
+	}
+
+	swept := 0
+	var errs []error
+	for _, pvc := range pvcs {
+		owner, ok := pipelineRunOfPVC(pvc)
+		if !ok || !isPVCOfPipelineRun(pvc, owner) {
+			continue
+		}
+		exists, err := pipelineRunExists(owner.Name, owner.UID)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to get the owner of the PVC %s: %w", pvc.Name, err))
+			continue
+		}
+		if exists {
+			continue
+		}
+		if err := c.PurgeFinalizerAndDeletePVCForWorkspace(ctx, pvc.Name, pvc.Namespace); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		c.logger.Infof("Deleted PersistentVolumeClaim %s in namespace %s, which outlived PipelineRun %s", pvc.Name, pvc.Namespace, owner.Name)
+		swept++
+	}
+	return swept, errors.Join(errs...)
+}
+
+// pipelineRunOfPVC returns the PipelineRun pvc was created for, from the labels set by LabelPVCWithOwner or,
+// for the PVCs created before they were set, from its owner references.
+func pipelineRunOfPVC(pvc *corev1.PersistentVolumeClaim) (metav1.OwnerReference, bool) {
+	name, uid := pvc.Labels[pipeline.PipelineRunLabelKey], pvc.Labels[pipeline.PipelineRunUIDLabelKey]
+	if name != "" && uid != "" {
+		return metav1.OwnerReference{Kind: pipeline.PipelineRunControllerName, Name: name, UID: types.UID(uid)}, true
+	}
+	for _, ref := range pvc.OwnerReferences {
+		if ref.Kind == pipeline.PipelineRunControllerName && strings.HasPrefix(ref.APIVersion, pipeline.GroupName+"/") {
+			return ref, true
+		}
+	}
+	return metav1.OwnerReference{}, false
+}
+
+// isPVCOfPipelineRun reports whether pvc has a name given by PVCName or AffinityAssistantPVCName to the PVC
+// of the workspace recorded by AnnotatePVCWithWorkspace for owner, whatever its claim name and Affinity
+// Assistant. The PVCs created before the workspace was recorded are recognized by the format of their name.
+func isPVCOfPipelineRun(pvc *corev1.PersistentVolumeClaim, owner metav1.OwnerReference) bool {
+	wbName, ok := pvc.Annotations[WorkspaceAnnotationKey]
+	if !ok {
+		return isPVCNameFromVolumeClaimTemplate(pvc.Name)
+	}
+	identity := "-" + getPersistentVolumeClaimIdentity(wbName, string(owner.UID))
+	if strings.HasSuffix(pvc.Name, identity) {
+		return true
+	}
+	trimmed, ok := strings.CutSuffix(pvc.Name, "-0")
+	return ok && strings.Contains(trimmed, identity+"-"+workspace.ComponentNameAffinityAssistant+"-")
+}
+
+// LabelPVCWithOwner labels claim with the name and UID of owner if it is a PipelineRun, so that
+// the PVC can be found, and swept, if it outlives the PipelineRun. The claim is also labeled as
+// managed by Tekton, unless it already has a managed-by label, so that the PipelineRun reconciler
+// can watch it through its label-filtered informer.
+func LabelPVCWithOwner(claim *corev1.PersistentVolumeClaim, owner metav1.OwnerReference) {
+	if owner.Kind != pipeline.PipelineRunControllerName {
+		return
+	}
+	if claim.Labels == nil {
+		claim.Labels = map[string]string{}
+	}
+	claim.Labels[pipeline.PipelineRunLabelKey] = owner.Name
+	claim.Labels[pipeline.PipelineRunUIDLabelKey] = string(owner.UID)
+	if _, found := claim.Labels[v1.ManagedByLabelKey]; !found {
+		claim.Labels[v1.ManagedByLabelKey] = config.DefaultManagedByLabelValue
+	}
+}
+
+// AnnotatePVCWithWorkspace annotates claim with the name of the workspace binding wb and of owner, a
+// PipelineRun or TaskRun, and with the PVCNameVersion of its name, so that the PVC can be looked up by
+// external tooling without parsing its name.
+func AnnotatePVCWithWorkspace(claim *corev1.PersistentVolumeClaim, wb v1.WorkspaceBinding, owner metav1.OwnerReference) {
+	if claim.Annotations == nil {
+		claim.Annotations = map[string]string{}
+	}
+	claim.Annotations[WorkspaceAnnotationKey] = wb.Name
+	claim.Annotations[PVCNameVersionAnnotationKey] = PVCNameVersion
+	switch owner.Kind {
+	case pipeline.PipelineRunControllerName:
+		claim.Annotations[pipeline.PipelineRunLabelKey] = owner.Name
+	case pipeline.TaskRunControllerName:
+		claim.Annotations[pipeline.TaskRunLabelKey] = owner.Name
+	}
+}
+
 // getPVCFromVolumeClaimTemplate returns a PersistentVolumeClaim based on given workspaceBinding (using VolumeClaimTemplate), ownerReference and namespace
 func (c *defaultPVCHandler) getPVCFromVolumeClaimTemplate(workspaceBinding v1.WorkspaceBinding, ownerReference metav1.OwnerReference, namespace string) *corev1.PersistentVolumeClaim {
 	if workspaceBinding.VolumeClaimTemplate == nil {
@@ -152,9 +356,11 @@ func (c *defaultPVCHandler) getPVCFromVolumeClaimTemplate(workspaceBinding v1.Wo
 	}
 
 	claim := workspaceBinding.VolumeClaimTemplate.DeepCopy()
-	claim.Name = GeneratePVCNameFromWorkspaceBinding(workspaceBinding.VolumeClaimTemplate.Name, workspaceBinding, ownerReference)
+	claim.Name = PVCName(workspaceBinding, ownerReference)
 	claim.Namespace = namespace
 	claim.OwnerReferences = []metav1.OwnerReference{ownerReference}
+	LabelPVCWithOwner(claim, ownerReference)
+	AnnotatePVCWithWorkspace(claim, workspaceBinding, ownerReference)
 
 	return claim
 }
@@ -165,18 +371,138 @@ func (c *defaultPVCHandler) getPVCFromVolumeClaimTemplate(workspaceBinding v1.Wo
 // possibly several TaskRuns to lookup the PVC to mount.
 // We use ownerReference UID over ownerReference name to distinguish runs with the same name.
 // If the given volumeClaimTemplate name is empty, the prefix "pvc" will be applied to the PersistentVolumeClaim name.
-// See function `getPersistentVolumeClaimNameWithAffinityAssistant` when the PersistentVolumeClaim is created by Affinity Assistant StatefulSet.
+// See PVCName, and AffinityAssistantPVCName when the PersistentVolumeClaim is created by Affinity Assistant StatefulSet.
+// A claim name which isn't a valid DNS-1123 subdomain is sanitized, see sanitizeClaimName.
 func GeneratePVCNameFromWorkspaceBinding(claimName string, wb v1.WorkspaceBinding, owner metav1.OwnerReference) string {
 	if claimName == "" {
-		return fmt.Sprintf("%s-%s", "pvc", getPersistentVolumeClaimIdentity(wb.Name, string(owner.UID)))
+		claimName = "pvc"
+	}
+	return fmt.Sprintf("%s-%s", sanitizeClaimName(claimName), getPersistentVolumeClaimIdentity(wb.Name, string(owner.UID)))
+}
+
+// PVCName returns the name, following the PVCNameVersion naming scheme, of the PersistentVolumeClaim created
+// from the volumeClaimTemplate of wb for owner, a PipelineRun or TaskRun, without an Affinity Assistant:
+// `<claim-name>-<identity>`, where the claim name defaults to "pvc" and the identity is derived from the
+// name of wb and the UID of owner.
+func PVCName(wb v1.WorkspaceBinding, owner metav1.OwnerReference) string {
+	var claimName string
+	if wb.VolumeClaimTemplate != nil {
+		claimName = wb.VolumeClaimTemplate.Name
 	}
-	return fmt.Sprintf("%s-%s", claimName, getPersistentVolumeClaimIdentity(wb.Name, string(owner.UID)))
+	return GeneratePVCNameFromWorkspaceBinding(claimName, wb, owner)
+}
+
+// AffinityAssistantPVCName returns the name, following the PVCNameVersion naming scheme, of the
+// PersistentVolumeClaim created from the volumeClaimTemplate of wb for owner by the VolumeClaimTemplates of
+// the StatefulSet of the Affinity Assistant affinityAssistantName: `<volume-name>-<affinity-assistant-name>-0`,
+// where the volume name is the PVCName sanitized with SanitizeVolumeName.
+func AffinityAssistantPVCName(wb v1.WorkspaceBinding, owner metav1.OwnerReference, affinityAssistantName string) string {
+	return fmt.Sprintf("%s-%s-0", SanitizeVolumeName(PVCName(wb, owner)), affinityAssistantName)
+}
+
+// SanitizeVolumeName returns name as a valid volume name, i.e. a DNS-1123 label. A valid name is returned
+// as-is. Otherwise, it is lowercased, its characters other than alphanumerics and '-' are replaced with '-',
+// defaulting to "pvc" if nothing is left, and if it is longer than 63 characters, it is truncated and a
+// short hash of name is appended to preserve uniqueness.
+func SanitizeVolumeName(name string) string {
+	if len(validation.IsDNS1123Label(name)) == 0 {
+		return name
+	}
+	sanitized := toDNS1123Label(name)
+	if sanitized == "" {
+		return "pvc"
+	}
+	if len(sanitized) <= validation.DNS1123LabelMaxLength {
+		return sanitized
+	}
+	hashBytes := sha256.Sum256([]byte(name))
+	hashStr := hex.EncodeToString(hashBytes[:])[:pvcIdentityLength]
+	// truncate prefix to leave room for "-" + the hash
+	return fmt.Sprintf("%s-%s", sanitized[:validation.DNS1123LabelMaxLength-pvcIdentityLength-1], hashStr)
+}
+
+// sanitizeClaimName returns the claimName of a volumeClaimTemplate as-is if it is a valid DNS-1123 subdomain,
+// and otherwise lowercased with its characters other than alphanumerics and '-' replaced with '-', and
+// truncated to leave room for the identity appended by GeneratePVCNameFromWorkspaceBinding.
+func sanitizeClaimName(claimName string) string {
+	if len(validation.IsDNS1123Subdomain(claimName)) == 0 && len(claimName) < validation.DNS1123SubdomainMaxLength-pvcIdentityLength {
+		return claimName
+	}
+	sanitized := toDNS1123Label(claimName)
+	if maxLength := validation.DNS1123SubdomainMaxLength - pvcIdentityLength - 1; len(sanitized) > maxLength {
+		sanitized = strings.TrimRight(sanitized[:maxLength], "-")
+	}
+	if sanitized == "" {
+		return "pvc"
+	}
+	return sanitized
+}
+
+// toDNS1123Label lowercases name, replaces its characters other than alphanumerics and '-' with '-', and
+// trims the leading and trailing '-'.
+func toDNS1123Label(name string) string {
+	sanitized := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '-':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r - 'A' + 'a'
+		default:
+			return '-'
+		}
+	}, name)
+	return strings.Trim(sanitized, "-")
+}
+
+// MatrixInstanceWorkspaceBinding returns the binding of the instance ordinal of the matrixed PipelineTask
+// pipelineTaskName to its own PersistentVolumeClaim, created from the volumeClaimTemplate of wb. The PVC
+// is named `<claim-name>-<ordinal>-<identity>`, where the identity is derived from the returned binding
+// name, which is unique per workspace, PipelineTask and ordinal.
+func MatrixInstanceWorkspaceBinding(wb v1.WorkspaceBinding, pipelineTaskName string, ordinal int) v1.WorkspaceBinding {
+	instance := *wb.DeepCopy()
+	instance.Name = fmt.Sprintf("%s/%s/%d", wb.Name, pipelineTaskName, ordinal)
+	claimName := wb.VolumeClaimTemplate.Name
+	if claimName == "" {
+		claimName = "pvc"
+	}
+	instance.VolumeClaimTemplate.Name = fmt.Sprintf("%s-%d", claimName, ordinal)
+	return instance
 }
 
 func getPersistentVolumeClaimIdentity(workspaceName, ownerName string) string {
 	hashBytes := sha256.Sum256([]byte(workspaceName + ownerName))
 	hashString := hex.EncodeToString(hashBytes[:])
-	return hashString[:10]
+	return hashString[:pvcIdentityLength]
+}
+
+// pvcIdentityLength is the length of the identity appended to the names of the PVCs created from volumeClaimTemplates.
+const pvcIdentityLength = 10
+
+// isPVCNameFromVolumeClaimTemplate reports whether name has the format of the names given to the PVCs created
+// from volumeClaimTemplates, either `<claim-name>-<identity>` by GeneratePVCNameFromWorkspaceBinding or
+// `<claim-name>-<identity>-<affinity-assistant-name>-0` by the StatefulSet of an Affinity Assistant.
+func isPVCNameFromVolumeClaimTemplate(name string) bool {
+	if trimmed, ok := strings.CutSuffix(name, "-0"); ok {
+		if i := strings.LastIndex(trimmed, "-"+workspace.ComponentNameAffinityAssistant+"-"); i > 0 && isPVCIdentity(trimmed[i+len(workspace.ComponentNameAffinityAssistant)+2:]) {
+			name = trimmed[:i]
+		}
+	}
+	i := strings.LastIndex(name, "-")
+	return i > 0 && isPVCIdentity(name[i+1:])
+}
+
+func isPVCIdentity(s string) bool {
+	if len(s) != pvcIdentityLength {
+		return false
+	}
+	_, err := hex.DecodeString(s)
+	return err == nil
+}
+
+// isAlreadyExistsError reports whether err may indicate that the object to create already exists, including
+// conflicts, which some admission webhooks return instead of an AlreadyExists error.
+func isAlreadyExistsError(err error) bool {
+	return apierrors.IsAlreadyExists(err) || apierrors.IsConflict(err) || strings.Contains(err.Error(), "already exists")
 }
 
 func isRetryableError(err error) bool {
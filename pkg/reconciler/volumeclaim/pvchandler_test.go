@@ -23,17 +23,24 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/test/diff"
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
 	fakek8s "k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/kubernetes/typed/core/v1/fake"
+	corev1listers "k8s.io/client-go/listers/core/v1"
 	client_go_testing "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
 )
 
 const (
@@ -222,11 +229,12 @@ func TestCreateExistPersistentVolumeClaims(t *testing.T) {
 			t.Fatalf("unexpected error: %v", err)
 		}
 	}
-	if len(fakekubeclient.Fake.Actions()) != 3 {
-		t.Fatalf("unexpected numer of actions; expected: %d got: %d", 3, len(fakekubeclient.Fake.Actions()))
+	// the PVC is looked up again after its creation failed as it already exists, to compare its spec
+	actions := []string{actionCreate, actionGet, actionCreate, actionGet}
+	if len(fakekubeclient.Fake.Actions()) != len(actions) {
+		t.Fatalf("unexpected numer of actions; expected: %d got: %d", len(actions), len(fakekubeclient.Fake.Actions()))
 	}
 
-	actions := []string{actionCreate, actionGet, actionCreate}
 	for i, action := range fakekubeclient.Fake.Actions() {
 		if actions[i] != action.GetVerb() {
 			t.Fatalf("PVC action, expected: %s got: %s", actions[i], action.GetVerb())
@@ -246,6 +254,176 @@ func TestCreateExistPersistentVolumeClaims(t *testing.T) {
 	}
 }
 
+// TestCreatePVCsForWorkspaces_PartialFailure tests that the PVCs of all the workspaces are created even if
+// the PVC of one of them can't be, and that the returned error names only that workspace.
+func TestCreatePVCsForWorkspaces_PartialFailure(t *testing.T) {
+	ctx := t.Context()
+	ownerRef := metav1.OwnerReference{UID: types.UID("taskrun1")}
+	namespace := "ns"
+	workspaces := []v1.WorkspaceBinding{{
+		Name:                "ws1",
+		VolumeClaimTemplate: &corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: "pvc1"}},
+	}, {
+		Name:                "failing-ws",
+		VolumeClaimTemplate: &corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: "failing"}},
+	}, {
+		Name:                "ws2",
+		VolumeClaimTemplate: &corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: "pvc2"}},
+	}}
+
+	fakekubeclient := fakek8s.NewSimpleClientset()
+	fakekubeclient.Fake.PrependReactor(actionCreate, "persistentvolumeclaims",
+		func(action client_go_testing.Action) (bool, runtime.Object, error) {
+			claim := action.(client_go_testing.CreateAction).GetObject().(*corev1.PersistentVolumeClaim)
+			if strings.HasPrefix(claim.Name, "failing-") {
+				return true, nil, apierrors.NewInternalError(errors.New("internal server error"))
+			}
+			return false, nil, nil
+		})
+	pvcHandler := defaultPVCHandler{fakekubeclient, zap.NewExample().Sugar()}
+
+	err := pvcHandler.CreatePVCsForWorkspaces(ctx, workspaces, ownerRef, namespace)
+	if !errors.Is(err, ErrPvcCreationFailed) {
+		t.Fatalf("expected a %v error, got: %v", ErrPvcCreationFailed, err)
+	}
+	if !strings.Contains(err.Error(), "workspace failing-ws:") {
+		t.Errorf("expected the error to name the failing workspace, got: %v", err)
+	}
+	for _, ws := range []string{"ws1", "ws2"} {
+		if strings.Contains(err.Error(), "workspace "+ws+":") {
+			t.Errorf("expected the error not to name the workspace %s, got: %v", ws, err)
+		}
+	}
+
+	pvcList, err := fakekubeclient.CoreV1().PersistentVolumeClaims(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var got []string
+	for _, pvc := range pvcList.Items {
+		got = append(got, pvc.Name)
+	}
+	want := []string{PVCName(workspaces[0], ownerRef), PVCName(workspaces[2], ownerRef)}
+	if d := cmp.Diff(want, got, cmpopts.SortSlices(func(a, b string) bool { return a < b })); d != "" {
+		t.Errorf("unexpected PVCs created: %s", diff.PrintWantGot(d))
+	}
+}
+
+// TestCreatePVCFromVolumeClaimTemplate_ExistingPVC tests that a PVC which already exists, including when it is
+// created concurrently, is accepted only if its spec matches the volumeClaimTemplate.
+func TestCreatePVCFromVolumeClaimTemplate_ExistingPVC(t *testing.T) {
+	ownerRef := metav1.OwnerReference{UID: types.UID("pipelinerun1")}
+	namespace := "ns"
+	standard, fast := "standard", "fast"
+	filesystem := corev1.PersistentVolumeFilesystem
+	wb := v1.WorkspaceBinding{
+		Name: "ws",
+		VolumeClaimTemplate: &corev1.PersistentVolumeClaim{
+			Spec: corev1.PersistentVolumeClaimSpec{
+				AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+				Resources: corev1.VolumeResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("1Gi")},
+				},
+			},
+		},
+	}
+	existingPVC := func(spec corev1.PersistentVolumeClaimSpec) *corev1.PersistentVolumeClaim {
+		return &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: PVCName(wb, ownerRef), Namespace: namespace},
+			Spec:       spec,
+		}
+	}
+
+	for _, tc := range []struct {
+		name string
+		// created concurrently, once the PVC was looked up and found not to exist
+		concurrently  bool
+		createErr     error
+		existing      *corev1.PersistentVolumeClaim
+		wantImmutable bool
+	}{{
+		name: "bound PVC with defaulted fields and expanded storage",
+		existing: existingPVC(corev1.PersistentVolumeClaimSpec{
+			AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("2Gi")},
+			},
+			StorageClassName: &standard,
+			VolumeMode:       &filesystem,
+			VolumeName:       "pv-1",
+		}),
+	}, {
+		name: "mismatched access modes",
+		existing: existingPVC(corev1.PersistentVolumeClaimSpec{
+			AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany},
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("1Gi")},
+			},
+		}),
+		wantImmutable: true,
+	}, {
+		name: "smaller storage request",
+		existing: existingPVC(corev1.PersistentVolumeClaimSpec{
+			AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("500Mi")},
+			},
+			StorageClassName: &fast,
+		}),
+		wantImmutable: true,
+	}, {
+		name:         "created concurrently with an AlreadyExists error",
+		concurrently: true,
+		createErr:    apierrors.NewAlreadyExists(schema.GroupResource{Resource: "persistentvolumeclaims"}, "pvc"),
+		existing:     existingPVC(*wb.VolumeClaimTemplate.Spec.DeepCopy()),
+	}, {
+		name:         "created concurrently with an error of a webhook",
+		concurrently: true,
+		createErr:    apierrors.NewBadRequest(`admission webhook "storage.example.com" denied the request: volume already exists`),
+		existing:     existingPVC(*wb.VolumeClaimTemplate.Spec.DeepCopy()),
+	}, {
+		name:         "created concurrently with a conflict and a mismatched spec",
+		concurrently: true,
+		createErr:    apierrors.NewConflict(schema.GroupResource{Resource: "persistentvolumeclaims"}, "pvc", errors.New("conflict")),
+		existing: existingPVC(corev1.PersistentVolumeClaimSpec{
+			AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadOnlyMany},
+		}),
+		wantImmutable: true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := t.Context()
+			fakekubeclient := fakek8s.NewSimpleClientset()
+			if tc.concurrently {
+				gets := 0
+				fakekubeclient.Fake.PrependReactor(actionGet, "persistentvolumeclaims",
+					func(action client_go_testing.Action) (bool, runtime.Object, error) {
+						gets++
+						if gets == 1 {
+							return true, nil, apierrors.NewNotFound(schema.GroupResource{}, action.(client_go_testing.GetAction).GetName())
+						}
+						return true, tc.existing, nil
+					})
+				fakekubeclient.Fake.PrependReactor(actionCreate, "persistentvolumeclaims",
+					func(action client_go_testing.Action) (bool, runtime.Object, error) {
+						return true, nil, tc.createErr
+					})
+			} else if _, err := fakekubeclient.CoreV1().PersistentVolumeClaims(namespace).Create(ctx, tc.existing, metav1.CreateOptions{}); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			pvcHandler := defaultPVCHandler{fakekubeclient, zap.NewExample().Sugar()}
+
+			err := pvcHandler.CreatePVCFromVolumeClaimTemplate(ctx, wb, ownerRef, namespace)
+			if tc.wantImmutable {
+				if !errors.Is(err, ErrPvcSpecImmutable) || !errors.Is(err, ErrPvcCreationFailed) {
+					t.Fatalf("expected a %v error, got: %v", ErrPvcSpecImmutable, err)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
 func TestPurgeFinalizerAndDeletePVCForWorkspace(t *testing.T) {
 	ctx := t.Context()
 	kubeClientSet := fakek8s.NewSimpleClientset()
@@ -444,3 +622,412 @@ func TestCreatePVCFromVolumeClaimTemplate_ConflictError(t *testing.T) {
 		t.Errorf("Expected retryable conflict error, got: %v", err)
 	}
 }
+
+// TestCreatePVCFromVolumeClaimTemplate_PipelineRunLabels tests that the PVCs created for a PipelineRun are
+// labeled with its name and UID, so that they can be swept if they outlive it, and that the PVCs are
+// annotated with their workspace and owner.
+func TestCreatePVCFromVolumeClaimTemplate_PipelineRunLabels(t *testing.T) {
+	ctx := t.Context()
+	namespace := "ns"
+	wb := v1.WorkspaceBinding{
+		Name: "ws",
+		VolumeClaimTemplate: &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: "pvc", Labels: map[string]string{"app": "my-app"}},
+		},
+	}
+
+	for _, tc := range []struct {
+		name            string
+		owner           metav1.OwnerReference
+		wantLabels      map[string]string
+		wantAnnotations map[string]string
+	}{{
+		name:  "PipelineRun",
+		owner: metav1.OwnerReference{Kind: "PipelineRun", Name: "pr", UID: types.UID("pr-uid")},
+		wantLabels: map[string]string{
+			"app":                          "my-app",
+			"tekton.dev/pipelineRun":       "pr",
+			"tekton.dev/pipelineRunUID":    "pr-uid",
+			"app.kubernetes.io/managed-by": "tekton-pipelines",
+		},
+		wantAnnotations: map[string]string{
+			"tekton.dev/pipelineRun":      "pr",
+			"tekton.dev/workspace":        "ws",
+			"tekton.dev/pvc-name-version": "v1",
+		},
+	}, {
+		name:       "TaskRun",
+		owner:      metav1.OwnerReference{Kind: "TaskRun", Name: "tr", UID: types.UID("tr-uid")},
+		wantLabels: map[string]string{"app": "my-app"},
+		wantAnnotations: map[string]string{
+			"tekton.dev/taskRun":          "tr",
+			"tekton.dev/workspace":        "ws",
+			"tekton.dev/pvc-name-version": "v1",
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			fakekubeclient := fakek8s.NewSimpleClientset()
+			pvcHandler := defaultPVCHandler{fakekubeclient, zap.NewExample().Sugar()}
+			if err := pvcHandler.CreatePVCFromVolumeClaimTemplate(ctx, wb, tc.owner, namespace); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			pvc, err := fakekubeclient.CoreV1().PersistentVolumeClaims(namespace).Get(ctx, GeneratePVCNameFromWorkspaceBinding("pvc", wb, tc.owner), metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if d := cmp.Diff(tc.wantLabels, pvc.Labels); d != "" {
+				t.Errorf("unexpected labels on created PVC %s", diff.PrintWantGot(d))
+			}
+			if d := cmp.Diff(tc.wantAnnotations, pvc.Annotations); d != "" {
+				t.Errorf("unexpected annotations on created PVC %s", diff.PrintWantGot(d))
+			}
+			if d := cmp.Diff(map[string]string{"app": "my-app"}, wb.VolumeClaimTemplate.Labels); d != "" {
+				t.Errorf("the volumeClaimTemplate was modified %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+// TestSweepLeakedPVCs tests that only the PVCs from the volumeClaimTemplates of PipelineRuns which no
+// longer exist are deleted, including the ones created before they were labeled with their PipelineRun.
+func TestSweepLeakedPVCs(t *testing.T) {
+	ctx := t.Context()
+	namespace := "my-ns"
+	wb := v1.WorkspaceBinding{Name: "ws", VolumeClaimTemplate: &corev1.PersistentVolumeClaim{}}
+	existing := metav1.OwnerReference{APIVersion: "tekton.dev/v1", Kind: "PipelineRun", Name: "existing", UID: types.UID("existing-uid")}
+	deleted := metav1.OwnerReference{APIVersion: "tekton.dev/v1", Kind: "PipelineRun", Name: "deleted", UID: types.UID("deleted-uid")}
+	restored := metav1.OwnerReference{APIVersion: "tekton.dev/v1", Kind: "PipelineRun", Name: "existing", UID: types.UID("former-uid")}
+	pvcFor := func(name string, owner metav1.OwnerReference) *corev1.PersistentVolumeClaim {
+		claim := &corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{
+			Name:       name,
+			Namespace:  namespace,
+			Finalizers: []string{"kubernetes.io/pvc-protection"},
+		}}
+		LabelPVCWithOwner(claim, owner)
+		return claim
+	}
+	annotatedPVCFor := func(wb v1.WorkspaceBinding, owner metav1.OwnerReference) *corev1.PersistentVolumeClaim {
+		claim := pvcFor(PVCName(wb, owner), owner)
+		AnnotatePVCWithWorkspace(claim, wb, owner)
+		return claim
+	}
+	// legacyPVCFor returns a PVC created before the PVCs were labeled with their PipelineRun
+	legacyPVCFor := func(name string, owner metav1.OwnerReference) *corev1.PersistentVolumeClaim {
+		return &corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       namespace,
+			OwnerReferences: []metav1.OwnerReference{owner},
+		}}
+	}
+	otherWorkspaceClaim := annotatedPVCFor(wb, deleted)
+	otherWorkspaceClaim.Name = PVCName(v1.WorkspaceBinding{Name: "other"}, deleted)
+
+	pvcs := []*corev1.PersistentVolumeClaim{
+		pvcFor(GeneratePVCNameFromWorkspaceBinding("", wb, existing), existing),
+		pvcFor(GeneratePVCNameFromWorkspaceBinding("", wb, deleted), deleted),
+		pvcFor(GeneratePVCNameFromWorkspaceBinding("data", wb, restored), restored),
+		pvcFor(GeneratePVCNameFromWorkspaceBinding("", wb, deleted)+"-affinity-assistant-0123456789-0", deleted),
+		annotatedPVCFor(v1.WorkspaceBinding{Name: "annotated"}, deleted),
+		legacyPVCFor(GeneratePVCNameFromWorkspaceBinding("legacy", wb, deleted), deleted),
+		legacyPVCFor(GeneratePVCNameFromWorkspaceBinding("legacy", wb, existing), existing),
+		// PVCs which weren't created from a volumeClaimTemplate are never deleted
+		pvcFor("my-own-pvc", deleted),
+		otherWorkspaceClaim,
+		legacyPVCFor("my-legacy-pvc", deleted),
+		{ObjectMeta: metav1.ObjectMeta{
+			Name:      GeneratePVCNameFromWorkspaceBinding("", wb, metav1.OwnerReference{UID: types.UID("unlabeled")}),
+			Namespace: namespace,
+		}},
+	}
+	kubeClientSet := fakek8s.NewSimpleClientset()
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	for _, pvc := range pvcs {
+		if _, err := kubeClientSet.CoreV1().PersistentVolumeClaims(namespace).Create(ctx, pvc, metav1.CreateOptions{}); err != nil {
+			t.Fatal(err)
+		}
+		if err := indexer.Add(pvc); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var deletedPVCs []string
+	kubeClientSet.CoreV1().(*fake.FakeCoreV1).PrependReactor("delete", "persistentvolumeclaims",
+		func(action client_go_testing.Action) (handled bool, ret runtime.Object, err error) {
+			deletedPVCs = append(deletedPVCs, action.(client_go_testing.DeleteAction).GetName())
+			return true, nil, nil
+		})
+
+	pvcHandler := defaultPVCHandler{kubeClientSet, zap.NewExample().Sugar()}
+	lister := corev1listers.NewPersistentVolumeClaimLister(indexer).PersistentVolumeClaims(namespace)
+	swept, err := pvcHandler.SweepLeakedPVCs(ctx, lister, func(name string, uid types.UID) (bool, error) {
+		return name == existing.Name && uid == existing.UID, nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error when sweeping PVCs: %v", err)
+	}
+
+	want := []string{
+		GeneratePVCNameFromWorkspaceBinding("data", wb, restored),
+		GeneratePVCNameFromWorkspaceBinding("", wb, deleted),
+		GeneratePVCNameFromWorkspaceBinding("", wb, deleted) + "-affinity-assistant-0123456789-0",
+		PVCName(v1.WorkspaceBinding{Name: "annotated"}, deleted),
+		GeneratePVCNameFromWorkspaceBinding("legacy", wb, deleted),
+	}
+	if d := cmp.Diff(want, deletedPVCs, cmpopts.SortSlices(func(a, b string) bool { return a < b })); d != "" {
+		t.Errorf("unexpected deleted PVCs %s", diff.PrintWantGot(d))
+	}
+	if swept != len(want) {
+		t.Errorf("expected %d PVCs to be swept but got %d", len(want), swept)
+	}
+}
+
+// TestSweepLeakedPVCs_OwnerLookupError tests that the PVCs whose owner can't be looked up are kept.
+func TestSweepLeakedPVCs_OwnerLookupError(t *testing.T) {
+	ctx := t.Context()
+	namespace := "my-ns"
+	wb := v1.WorkspaceBinding{Name: "ws", VolumeClaimTemplate: &corev1.PersistentVolumeClaim{}}
+	owner := metav1.OwnerReference{Kind: "PipelineRun", Name: "pr", UID: types.UID("pr-uid")}
+	claim := &corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{
+		Name:      GeneratePVCNameFromWorkspaceBinding("", wb, owner),
+		Namespace: namespace,
+	}}
+	LabelPVCWithOwner(claim, owner)
+	kubeClientSet := fakek8s.NewSimpleClientset(claim)
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	if err := indexer.Add(claim); err != nil {
+		t.Fatal(err)
+	}
+
+	pvcHandler := defaultPVCHandler{kubeClientSet, zap.NewExample().Sugar()}
+	lister := corev1listers.NewPersistentVolumeClaimLister(indexer).PersistentVolumeClaims(namespace)
+	swept, err := pvcHandler.SweepLeakedPVCs(ctx, lister, func(string, types.UID) (bool, error) {
+		return false, errors.New("lister error")
+	})
+	if err == nil {
+		t.Error("expected an error when the owner of a PVC can't be looked up")
+	}
+	if swept != 0 {
+		t.Errorf("expected no PVC to be swept but got %d", swept)
+	}
+	if _, err := kubeClientSet.CoreV1().PersistentVolumeClaims(namespace).Get(ctx, claim.Name, metav1.GetOptions{}); err != nil {
+		t.Errorf("expected PVC %s to be kept: %v", claim.Name, err)
+	}
+}
+
+func TestIsPVCNameFromVolumeClaimTemplate(t *testing.T) {
+	wb := v1.WorkspaceBinding{Name: "ws"}
+	owner := metav1.OwnerReference{UID: types.UID("uid")}
+	for _, tc := range []struct {
+		name string
+		want bool
+	}{
+		{name: GeneratePVCNameFromWorkspaceBinding("", wb, owner), want: true},
+		{name: GeneratePVCNameFromWorkspaceBinding("my-claim", wb, owner), want: true},
+		{name: GeneratePVCNameFromWorkspaceBinding("", wb, owner) + "-affinity-assistant-0123456789-0", want: true},
+		{name: "my-claim"},
+		{name: "my-claim-0"},
+		{name: "pvc-not-a-hash"},
+		{name: "my-claim-affinity-assistant-0123456789-0"},
+		{name: GeneratePVCNameFromWorkspaceBinding("", wb, owner) + "-affinity-assistant-0123456789-1"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := isPVCNameFromVolumeClaimTemplate(tc.name); got != tc.want {
+				t.Errorf("isPVCNameFromVolumeClaimTemplate(%q) = %t, want %t", tc.name, got, tc.want)
+			}
+		})
+	}
+}
+
+// TestPVCName_Golden pins the names of the PVCs created from volumeClaimTemplates, which external tooling
+// relies on: any change to these names requires a new PVCNameVersion.
+func TestPVCName_Golden(t *testing.T) {
+	owner := metav1.OwnerReference{Kind: "PipelineRun", Name: "pr", UID: types.UID("6b9b8c3e-1f2a-4d5b-9c7e-0a1b2c3d4e5f")}
+	for _, tc := range []struct {
+		name                      string
+		workspaceName             string
+		claimName                 string
+		want                      string
+		wantAffinityAssistantName string
+	}{{
+		name:                      "default claim name",
+		workspaceName:             "source",
+		want:                      "pvc-0e8bfee31f",
+		wantAffinityAssistantName: "pvc-0e8bfee31f-affinity-assistant-0123456789-0",
+	}, {
+		name:                      "claim name",
+		workspaceName:             "source",
+		claimName:                 "my-claim",
+		want:                      "my-claim-0e8bfee31f",
+		wantAffinityAssistantName: "my-claim-0e8bfee31f-affinity-assistant-0123456789-0",
+	}, {
+		name:                      "uppercase",
+		workspaceName:             "Source",
+		claimName:                 "My-Claim",
+		want:                      "my-claim-5faefee65a",
+		wantAffinityAssistantName: "my-claim-5faefee65a-affinity-assistant-0123456789-0",
+	}, {
+		name:                      "100 chars workspace name",
+		workspaceName:             strings.Repeat("w", 100),
+		claimName:                 "data",
+		want:                      "data-fd425971e6",
+		wantAffinityAssistantName: "data-fd425971e6-affinity-assistant-0123456789-0",
+	}, {
+		name:                      "dots",
+		workspaceName:             "my.workspace",
+		claimName:                 "my.claim",
+		want:                      "my.claim-a38e23bea4",
+		wantAffinityAssistantName: "my-claim-a38e23bea4-affinity-assistant-0123456789-0",
+	}, {
+		name:                      "invalid characters",
+		workspaceName:             "ws",
+		claimName:                 "Cache.Volume_1",
+		want:                      "cache-volume-1-10914a53c4",
+		wantAffinityAssistantName: "cache-volume-1-10914a53c4-affinity-assistant-0123456789-0",
+	}, {
+		name:                      "only invalid characters",
+		workspaceName:             "ws",
+		claimName:                 "...",
+		want:                      "pvc-10914a53c4",
+		wantAffinityAssistantName: "pvc-10914a53c4-affinity-assistant-0123456789-0",
+	}, {
+		name:                      "100 chars claim name",
+		workspaceName:             "ws",
+		claimName:                 strings.Repeat("c", 100),
+		want:                      strings.Repeat("c", 100) + "-10914a53c4",
+		wantAffinityAssistantName: strings.Repeat("c", 52) + "-1e5bca2078-affinity-assistant-0123456789-0",
+	}, {
+		name:                      "250 chars claim name",
+		workspaceName:             "ws",
+		claimName:                 strings.Repeat("c", 250),
+		want:                      strings.Repeat("c", 242) + "-10914a53c4",
+		wantAffinityAssistantName: strings.Repeat("c", 52) + "-a389c5ce11-affinity-assistant-0123456789-0",
+	}, {
+		name:                      "250 chars uppercase claim name",
+		workspaceName:             "ws",
+		claimName:                 strings.Repeat("C", 250),
+		want:                      strings.Repeat("c", 242) + "-10914a53c4",
+		wantAffinityAssistantName: strings.Repeat("c", 52) + "-a389c5ce11-affinity-assistant-0123456789-0",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			wb := v1.WorkspaceBinding{
+				Name:                tc.workspaceName,
+				VolumeClaimTemplate: &corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: tc.claimName}},
+			}
+			got := PVCName(wb, owner)
+			if got != tc.want {
+				t.Errorf("PVCName() = %q, want %q", got, tc.want)
+			}
+			if errs := validation.IsDNS1123Subdomain(got); len(errs) != 0 {
+				t.Errorf("PVCName() = %q isn't a valid PVC name: %v", got, errs)
+			}
+			if !isPVCNameFromVolumeClaimTemplate(got) {
+				t.Errorf("PVCName() = %q isn't recognized as created from a volumeClaimTemplate", got)
+			}
+			gotAffinityAssistantName := AffinityAssistantPVCName(wb, owner, "affinity-assistant-0123456789")
+			if gotAffinityAssistantName != tc.wantAffinityAssistantName {
+				t.Errorf("AffinityAssistantPVCName() = %q, want %q", gotAffinityAssistantName, tc.wantAffinityAssistantName)
+			}
+			if !isPVCNameFromVolumeClaimTemplate(gotAffinityAssistantName) {
+				t.Errorf("AffinityAssistantPVCName() = %q isn't recognized as created from a volumeClaimTemplate", gotAffinityAssistantName)
+			}
+		})
+	}
+}
+
+// TestPVCName_Collisions tests that the PVCs of distinct workspaces or owners, including workspaces
+// whose names only differ by case or punctuation, are named differently.
+func TestPVCName_Collisions(t *testing.T) {
+	workspaceNames := []string{"ws", "WS", "w.s", "w-s", "w_s", strings.Repeat("w", 100), strings.Repeat("w", 99) + "W"}
+	names := map[string]string{}
+	for _, uid := range []types.UID{"uid-1", "uid-2"} {
+		owner := metav1.OwnerReference{Kind: "PipelineRun", Name: "pr", UID: uid}
+		for _, workspaceName := range workspaceNames {
+			wb := v1.WorkspaceBinding{
+				Name:                workspaceName,
+				VolumeClaimTemplate: &corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: "Data.Volume"}},
+			}
+			key := fmt.Sprintf("workspace %q of %s", workspaceName, uid)
+			for _, name := range []string{PVCName(wb, owner), AffinityAssistantPVCName(wb, owner, "affinity-assistant-0123456789")} {
+				if other, found := names[name]; found {
+					t.Errorf("the PVC of %s is named %q, like the PVC of %s", key, name, other)
+				}
+				names[name] = key
+			}
+		}
+	}
+}
+
+func TestSanitizeVolumeName(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		wantLen  int  // 0 means just check <= 63
+		wantSame bool // expect output == input
+	}{
+		{"short name unchanged", "my-pvc", 6, true},
+		{"exactly 63 chars unchanged", "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", 63, true},
+		{"64 chars gets truncated", "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", 63, false},
+		{"long name from issue 9739", "master-pipeline-700-generic-feature-execution-15943-error-handle-e46a3a1317", 63, false},
+		{"uppercase and dots get sanitized", "My.PVC", 6, false},
+		{"long uppercase name gets sanitized and truncated", strings.Repeat("A.", 50), 63, false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := SanitizeVolumeName(tc.input)
+			if errs := validation.IsDNS1123Label(got); len(errs) != 0 {
+				t.Errorf("SanitizeVolumeName(%q) = %q isn't a valid volume name: %v", tc.input, got, errs)
+			}
+			if len(got) > 63 {
+				t.Errorf("SanitizeVolumeName(%q) = %q (%d chars), want <= 63", tc.input, got, len(got))
+			}
+			if tc.wantSame && got != tc.input {
+				t.Errorf("SanitizeVolumeName(%q) = %q, want same as input", tc.input, got)
+			}
+			if tc.wantLen > 0 && len(got) != tc.wantLen {
+				t.Errorf("SanitizeVolumeName(%q) length = %d, want %d", tc.input, len(got), tc.wantLen)
+			}
+		})
+	}
+
+	// Verify determinism: same input always produces same output
+	long := "master-pipeline-700-generic-feature-execution-15943-error-handle-e46a3a1317"
+	a := SanitizeVolumeName(long)
+	b := SanitizeVolumeName(long)
+	if a != b {
+		t.Errorf("SanitizeVolumeName is not deterministic: %q != %q", a, b)
+	}
+
+	// Verify uniqueness: different inputs produce different outputs
+	c := SanitizeVolumeName(long + "-different")
+	if a == c {
+		t.Errorf("SanitizeVolumeName collision: %q and %q both produced %q", long, long+"-different", a)
+	}
+}
+
+func TestMatrixInstanceWorkspaceBinding(t *testing.T) {
+	wb := v1.WorkspaceBinding{
+		Name:                "cache",
+		VolumeClaimTemplate: &corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: "build-cache"}},
+	}
+	owner := metav1.OwnerReference{UID: types.UID("uid")}
+
+	names := map[string]bool{GeneratePVCNameFromWorkspaceBinding("build-cache", wb, owner): true}
+	for _, pipelineTask := range []string{"build", "test"} {
+		for ordinal := range 3 {
+			instance := MatrixInstanceWorkspaceBinding(wb, pipelineTask, ordinal)
+			name := GeneratePVCNameFromWorkspaceBinding(instance.VolumeClaimTemplate.Name, instance, owner)
+			if want := fmt.Sprintf("build-cache-%d-", ordinal); !strings.HasPrefix(name, want) {
+				t.Errorf("PVC of instance %d of %s is named %q, want the prefix %q", ordinal, pipelineTask, name, want)
+			}
+			if !isPVCNameFromVolumeClaimTemplate(name) {
+				t.Errorf("PVC of instance %d of %s named %q isn't recognized as created from a volumeClaimTemplate", ordinal, pipelineTask, name)
+			}
+			if names[name] {
+				t.Errorf("PVC of instance %d of %s is named %q, like another PVC", ordinal, pipelineTask, name)
+			}
+			names[name] = true
+		}
+	}
+	if wb.VolumeClaimTemplate.Name != "build-cache" {
+		t.Errorf("the volumeClaimTemplate of the workspace binding was modified to %q", wb.VolumeClaimTemplate.Name)
+	}
+}
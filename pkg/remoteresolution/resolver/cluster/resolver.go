@@ -23,11 +23,15 @@ import (
 	"github.com/tektoncd/pipeline/pkg/apis/resolution/v1beta1"
 	"github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
 	pipelineclient "github.com/tektoncd/pipeline/pkg/client/injection/client"
+	resolutionclientset "github.com/tektoncd/pipeline/pkg/client/resolution/clientset/versioned"
+	resolutionclient "github.com/tektoncd/pipeline/pkg/client/resolution/injection/client"
 	"github.com/tektoncd/pipeline/pkg/remoteresolution/resolver/framework"
 	"github.com/tektoncd/pipeline/pkg/remoteresolution/resolver/framework/cache"
 	resolutioncommon "github.com/tektoncd/pipeline/pkg/resolution/common"
 	clusterresolution "github.com/tektoncd/pipeline/pkg/resolution/resolver/cluster"
 	resolutionframework "github.com/tektoncd/pipeline/pkg/resolution/resolver/framework"
+	"k8s.io/client-go/kubernetes"
+	kubeclient "knative.dev/pkg/client/injection/kube/client"
 )
 
 const (
@@ -46,12 +50,16 @@ var _ cache.ImmutabilityChecker = (*Resolver)(nil)
 
 // Resolver implements a framework.Resolver that can fetch resources from the same cluster.
 type Resolver struct {
-	pipelineClientSet versioned.Interface
+	kubeClientSet       kubernetes.Interface
+	pipelineClientSet   versioned.Interface
+	resolutionClientSet resolutionclientset.Interface
 }
 
 // Initialize sets up any dependencies needed by the Resolver.
 func (r *Resolver) Initialize(ctx context.Context) error {
+	r.kubeClientSet = kubeclient.Get(ctx)
 	r.pipelineClientSet = pipelineclient.Get(ctx)
+	r.resolutionClientSet = resolutionclient.Get(ctx)
 	return nil
 }
 
@@ -87,7 +95,12 @@ func (r *Resolver) IsImmutable([]v1.Param) bool {
 }
 
 // Resolve uses the given params to resolve the requested file or resource.
+// The access of the requester to the namespace of the resource is checked before the cache is
+// looked up, so that cached resources aren't returned to requesters which can't access them.
 func (r *Resolver) Resolve(ctx context.Context, req *v1beta1.ResolutionRequestSpec) (resolutionframework.ResolvedResource, error) {
+	if err := clusterresolution.CheckServiceAccountAccess(ctx, req.Params, r.kubeClientSet, r.pipelineClientSet, r.resolutionClientSet); err != nil {
+		return nil, err
+	}
 	if cache.ShouldUse(ctx, r, req.Params) {
 		return cache.Get(ctx).GetCachedOrResolveFromRemote(
 			ctx,
@@ -34,6 +34,7 @@ import (
 	"github.com/tektoncd/pipeline/pkg/internal/resolution"
 	ttesting "github.com/tektoncd/pipeline/pkg/reconciler/testing"
 	cluster "github.com/tektoncd/pipeline/pkg/remoteresolution/resolver/cluster"
+	rrframework "github.com/tektoncd/pipeline/pkg/remoteresolution/resolver/framework"
 	"github.com/tektoncd/pipeline/pkg/remoteresolution/resolver/framework/cache"
 	frtesting "github.com/tektoncd/pipeline/pkg/remoteresolution/resolver/framework/testing"
 	resolutioncommon "github.com/tektoncd/pipeline/pkg/resolution/common"
@@ -42,9 +43,13 @@ import (
 	frameworktesting "github.com/tektoncd/pipeline/pkg/resolution/resolver/framework/testing"
 	"github.com/tektoncd/pipeline/test"
 	"github.com/tektoncd/pipeline/test/diff"
+	authorizationv1 "k8s.io/api/authorization/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ktesting "k8s.io/client-go/testing"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/kmeta"
 
 	"knative.dev/pkg/system"
 	_ "knative.dev/pkg/system/testing"
@@ -669,3 +674,198 @@ func TestResolveWithCacheHit(t *testing.T) {
 		t.Errorf("Expected data %q, got %q", string(mockResource.Data()), string(result.Data()))
 	}
 }
+
+func TestResolveWithServiceAccountAccessCheck(t *testing.T) {
+	exampleTask := &pipelinev1.Task{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "example-task",
+			Namespace:       "task-ns",
+			ResourceVersion: "00002",
+			UID:             "a123",
+		},
+		TypeMeta: metav1.TypeMeta{
+			Kind:       string(pipelinev1beta1.NamespacedTaskKind),
+			APIVersion: "tekton.dev/v1",
+		},
+		Spec: pipelinev1.TaskSpec{
+			Steps: []pipelinev1.Step{{
+				Name:    "some-step",
+				Image:   "some-image",
+				Command: []string{"something"},
+			}},
+		},
+	}
+	taskChecksum, err := exampleTask.Checksum()
+	if err != nil {
+		t.Fatalf("couldn't checksum task: %v", err)
+	}
+	taskAsYAML, err := yaml.Marshal(exampleTask)
+	if err != nil {
+		t.Fatalf("couldn't marshal task: %v", err)
+	}
+	taskRun := &pipelinev1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "tr", Namespace: "foo"},
+		Spec: pipelinev1.TaskRunSpec{
+			ServiceAccountName: "builder",
+			TaskRef:            &pipelinev1.TaskRef{ResolverRef: pipelinev1.ResolverRef{Resolver: "cluster"}},
+		},
+	}
+	deniedMessage := `access to specified namespace task-ns is denied to service account foo/builder: it can't get tasks.tekton.dev "example-task": no RBAC policy matched`
+
+	for _, tc := range []struct {
+		name           string
+		conf           map[string]string
+		allowed        bool
+		expectedReview bool
+		expectedStatus *v1beta1.ResolutionRequestStatus
+		expectedErr    error
+	}{{
+		name:           "service account allowed",
+		conf:           map[string]string{clusterresolution.CheckServiceAccountAccessKey: "true"},
+		allowed:        true,
+		expectedReview: true,
+	}, {
+		name:           "service account denied",
+		conf:           map[string]string{clusterresolution.CheckServiceAccountAccessKey: "true"},
+		expectedReview: true,
+		expectedStatus: &v1beta1.ResolutionRequestStatus{
+			Status: duckv1.Status{
+				Conditions: duckv1.Conditions{{
+					Type:    "Succeeded",
+					Status:  corev1.ConditionFalse,
+					Reason:  clusterresolution.ReasonNamespaceAccessDenied,
+					Message: deniedMessage,
+				}},
+			},
+		},
+		expectedErr: &resolutioncommon.GetResourceError{
+			ResolverName: cluster.ClusterResolverName,
+			Key:          "foo/rr",
+			Original:     errors.New(deniedMessage),
+		},
+	}, {
+		name: "check disabled",
+	}, {
+		name: "allowed namespaces overridden for the requesting namespace",
+		conf: map[string]string{
+			clusterresolution.AllowedNamespacesKey:                    "bar",
+			clusterresolution.AllowedNamespacesKeyPrefix + "foo":      "task-ns",
+			clusterresolution.AllowedNamespacesKeyPrefix + "other-ns": "bar",
+		},
+	}, {
+		name: "namespace not allowed by the override for the requesting namespace",
+		conf: map[string]string{
+			clusterresolution.AllowedNamespacesKey:               "task-ns",
+			clusterresolution.AllowedNamespacesKeyPrefix + "foo": "bar",
+		},
+		expectedStatus: resolution.CreateResolutionRequestFailureStatus(),
+		expectedErr: &resolutioncommon.InvalidRequestError{
+			ResolutionRequestKey: "foo/rr",
+			Message:              "access to specified namespace task-ns is not allowed",
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, _ := ttesting.SetupFakeContext(t)
+
+			request := createRequest("task", exampleTask.Name, exampleTask.Namespace)
+			request.OwnerReferences = []metav1.OwnerReference{*kmeta.NewControllerRef(taskRun)}
+
+			confMap := map[string]string{
+				clusterresolution.DefaultKindKey:      "task",
+				clusterresolution.DefaultNamespaceKey: "pipeline-ns",
+			}
+			for k, v := range tc.conf {
+				confMap[k] = v
+			}
+			d := test.Data{
+				ConfigMaps: []*corev1.ConfigMap{{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "cluster-resolver-config",
+						Namespace: resolverconfig.ResolversNamespace(system.Namespace()),
+					},
+					Data: confMap,
+				}, {
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: resolverconfig.ResolversNamespace(system.Namespace()),
+						Name:      resolverconfig.GetFeatureFlagsConfigName(),
+					},
+					Data: map[string]string{
+						"enable-cluster-resolver": "true",
+					},
+				}, {
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "resolver-cache-config",
+						Namespace: resolverconfig.ResolversNamespace(system.Namespace()),
+					},
+					Data: map[string]string{},
+				}},
+				ResolutionRequests: []*v1beta1.ResolutionRequest{request},
+				Tasks:              []*pipelinev1.Task{exampleTask},
+				TaskRuns:           []*pipelinev1.TaskRun{taskRun},
+			}
+
+			expectedStatus := tc.expectedStatus
+			if expectedStatus == nil {
+				expectedStatus = &v1beta1.ResolutionRequestStatus{
+					Status: duckv1.Status{
+						Annotations: map[string]string{
+							clusterresolution.ResourceNameAnnotation:      exampleTask.Name,
+							clusterresolution.ResourceNamespaceAnnotation: exampleTask.Namespace,
+						},
+					},
+					ResolutionRequestStatusFields: v1beta1.ResolutionRequestStatusFields{
+						Data: base64.StdEncoding.Strict().EncodeToString(taskAsYAML),
+						RefSource: &pipelinev1.RefSource{
+							URI: "/apis/tekton.dev/v1/namespaces/task-ns/task/example-task@a123",
+							Digest: map[string]string{
+								"sha256": hex.EncodeToString(taskChecksum),
+							},
+						},
+					},
+				}
+				expectedStatus.Source = expectedStatus.RefSource
+			} else if tc.expectedErr != nil && expectedStatus.Conditions[0].Message == "" {
+				expectedStatus.Conditions[0].Message = tc.expectedErr.Error()
+			}
+
+			var reviews []*authorizationv1.SubjectAccessReview
+			reviewAccess := func(_ rrframework.Resolver, testAssets test.Assets) {
+				testAssets.Clients.Kube.PrependReactor("create", "subjectaccessreviews", func(action ktesting.Action) (bool, runtime.Object, error) {
+					review := action.(ktesting.CreateAction).GetObject().(*authorizationv1.SubjectAccessReview).DeepCopy()
+					reviews = append(reviews, review)
+					review.Status.Allowed = tc.allowed
+					if !tc.allowed {
+						review.Status.Reason = "no RBAC policy matched"
+					}
+					return true, review, nil
+				})
+			}
+
+			frtesting.RunResolverReconcileTest(ctx, t, d, &cluster.Resolver{}, request, expectedStatus, tc.expectedErr, reviewAccess)
+
+			if !tc.expectedReview {
+				if len(reviews) != 0 {
+					t.Fatalf("expected no SubjectAccessReview, got %d", len(reviews))
+				}
+				return
+			}
+			if len(reviews) != 1 {
+				t.Fatalf("expected one SubjectAccessReview, got %d", len(reviews))
+			}
+			wantSpec := authorizationv1.SubjectAccessReviewSpec{
+				User:   "system:serviceaccount:foo:builder",
+				Groups: []string{"system:serviceaccounts", "system:serviceaccounts:foo"},
+				ResourceAttributes: &authorizationv1.ResourceAttributes{
+					Namespace: "task-ns",
+					Verb:      "get",
+					Group:     "tekton.dev",
+					Resource:  "tasks",
+					Name:      "example-task",
+				},
+			}
+			if d := cmp.Diff(wantSpec, reviews[0].Spec); d != "" {
+				t.Errorf("SubjectAccessReview spec didn't match %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	clientset "github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
+	resolutionclientset "github.com/tektoncd/pipeline/pkg/client/resolution/clientset/versioned"
+	common "github.com/tektoncd/pipeline/pkg/resolution/common"
+	"github.com/tektoncd/pipeline/pkg/resolution/resolver/framework"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"knative.dev/pkg/logging"
+)
+
+// ReasonNamespaceAccessDenied is the reason of the ResolutionRequests which failed because
+// the resource was requested from a namespace its requester isn't allowed to access.
+const ReasonNamespaceAccessDenied = "NamespaceAccessDenied"
+
+const defaultServiceAccount = "default"
+
+var kindResources = map[string]string{
+	"task":       "tasks",
+	"pipeline":   "pipelines",
+	"stepaction": "stepactions",
+}
+
+// NamespaceAccessError is returned when a resource is requested from a namespace the
+// resolver isn't allowed to access for the request.
+type NamespaceAccessError struct {
+	// Namespace is the namespace the resource was requested from.
+	Namespace string
+	// Reason describes why the access to the namespace was denied.
+	Reason string
+}
+
+var _ error = &NamespaceAccessError{}
+
+func (e *NamespaceAccessError) Error() string {
+	return fmt.Sprintf("access to specified namespace %s is %s", e.Namespace, e.Reason)
+}
+
+// CheckServiceAccountAccess checks, when enabled in the resolver's config, that the service
+// account of the TaskRun or PipelineRun owning the ResolutionRequest is allowed to get the
+// resource it requests from another namespace, by creating a SubjectAccessReview for it.
+// Requests which have no TaskRun or PipelineRun owner are checked for the default service
+// account of their namespace.
+func CheckServiceAccountAccess(ctx context.Context, origParams []pipelinev1.Param, kubeClientSet kubernetes.Interface, pipelineClientSet clientset.Interface, resolutionClientSet resolutionclientset.Interface) error {
+	conf := framework.GetResolverConfigFromContext(ctx)
+	if conf[CheckServiceAccountAccessKey] != "true" {
+		return nil
+	}
+
+	params, err := populateParamsWithDefaults(ctx, origParams)
+	if err != nil {
+		return err
+	}
+	requestNamespace := common.RequestNamespace(ctx)
+	if params[NamespaceParam] == requestNamespace {
+		return nil
+	}
+
+	serviceAccount, err := requesterServiceAccount(ctx, requestNamespace, common.RequestName(ctx), pipelineClientSet, resolutionClientSet)
+	if err != nil {
+		return fmt.Errorf("failed to find the service account of the resolution request %s/%s: %w", requestNamespace, common.RequestName(ctx), err)
+	}
+
+	resource := kindResources[params[KindParam]]
+	review, err := kubeClientSet.AuthorizationV1().SubjectAccessReviews().Create(ctx, &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			User:   fmt.Sprintf("system:serviceaccount:%s:%s", requestNamespace, serviceAccount),
+			Groups: []string{"system:serviceaccounts", "system:serviceaccounts:" + requestNamespace},
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace: params[NamespaceParam],
+				Verb:      "get",
+				Group:     pipeline.GroupName,
+				Resource:  resource,
+				Name:      params[NameParam],
+			},
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to check the access of service account %s/%s to namespace %s: %w", requestNamespace, serviceAccount, params[NamespaceParam], err)
+	}
+	if review.Status.Allowed {
+		return nil
+	}
+
+	reason := fmt.Sprintf("denied to service account %s/%s: it can't get %s.%s %q", requestNamespace, serviceAccount, resource, pipeline.GroupName, params[NameParam])
+	if review.Status.Reason != "" {
+		reason = fmt.Sprintf("%s: %s", reason, review.Status.Reason)
+	}
+	logging.FromContext(ctx).Infof("service account %s/%s isn't allowed to get %s %s from namespace %s", requestNamespace, serviceAccount, resource, params[NameParam], params[NamespaceParam])
+	return common.NewError(ReasonNamespaceAccessDenied, &NamespaceAccessError{Namespace: params[NamespaceParam], Reason: reason})
+}
+
+// requesterServiceAccount returns the service account of the TaskRun or PipelineRun owning
+// the ResolutionRequest.
+func requesterServiceAccount(ctx context.Context, namespace, name string, pipelineClientSet clientset.Interface, resolutionClientSet resolutionclientset.Interface) (string, error) {
+	rr, err := resolutionClientSet.ResolutionV1beta1().ResolutionRequests(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+
+	for _, owner := range rr.OwnerReferences {
+		if !strings.HasPrefix(owner.APIVersion, pipeline.GroupName+"/") {
+			continue
+		}
+		switch owner.Kind {
+		case pipeline.TaskRunControllerName:
+			tr, err := pipelineClientSet.TektonV1().TaskRuns(namespace).Get(ctx, owner.Name, metav1.GetOptions{})
+			if err != nil {
+				return "", err
+			}
+			return serviceAccountOrDefault(tr.Spec.ServiceAccountName), nil
+		case pipeline.PipelineRunControllerName:
+			pr, err := pipelineClientSet.TektonV1().PipelineRuns(namespace).Get(ctx, owner.Name, metav1.GetOptions{})
+			if err != nil {
+				return "", err
+			}
+			return serviceAccountOrDefault(pr.Spec.TaskRunTemplate.ServiceAccountName), nil
+		}
+	}
+	return defaultServiceAccount, nil
+}
+
+func serviceAccountOrDefault(serviceAccount string) string {
+	if serviceAccount == "" {
+		return defaultServiceAccount
+	}
+	return serviceAccount
+}
@@ -29,3 +29,13 @@ const (
 	// resolver is blocked from accessing. Defaults to empty, meaning no namespaces are blocked.
 	BlockedNamespacesKey = "blocked-namespaces"
 )
+
+const (
+	// AllowedNamespacesKeyPrefix is the prefix of the keys in the config map for an optional comma-separated list of
+	// namespaces which the resolver is allowed to access for the requests of a namespace, e.g.
+	// allowed-namespaces.team-a. It overrides the allowed-namespaces setting for the requests of that namespace.
+	AllowedNamespacesKeyPrefix = AllowedNamespacesKey + "."
+	// CheckServiceAccountAccessKey is the key in the config map for the setting checking that the service account of
+	// the TaskRun or PipelineRun requesting a resource from another namespace is allowed to get it. Defaults to false.
+	CheckServiceAccountAccessKey = "check-service-account-access"
+)
@@ -29,9 +29,13 @@ import (
 	pipelinev1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	clientset "github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
 	pipelineclient "github.com/tektoncd/pipeline/pkg/client/injection/client"
+	resolutionclientset "github.com/tektoncd/pipeline/pkg/client/resolution/clientset/versioned"
+	resolutionclient "github.com/tektoncd/pipeline/pkg/client/resolution/injection/client"
 	common "github.com/tektoncd/pipeline/pkg/resolution/common"
 	"github.com/tektoncd/pipeline/pkg/resolution/resolver/framework"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	kubeclient "knative.dev/pkg/client/injection/kube/client"
 	"knative.dev/pkg/logging"
 	"sigs.k8s.io/yaml"
 )
@@ -59,12 +63,16 @@ var _ framework.ConfigWatcher = (*Resolver)(nil)
 //
 // Deprecated: Use [github.com/tektoncd/pipeline/pkg/remoteresolution/resolver/cluster.Resolver] instead.
 type Resolver struct {
-	pipelineClientSet clientset.Interface
+	kubeClientSet       kubernetes.Interface
+	pipelineClientSet   clientset.Interface
+	resolutionClientSet resolutionclientset.Interface
 }
 
 // Initialize performs any setup required by the cluster resolver.
 func (r *Resolver) Initialize(ctx context.Context) error {
+	r.kubeClientSet = kubeclient.Get(ctx)
 	r.pipelineClientSet = pipelineclient.Get(ctx)
+	r.resolutionClientSet = resolutionclient.Get(ctx)
 	return nil
 }
 
@@ -96,6 +104,9 @@ func (r *Resolver) ValidateParams(ctx context.Context, params []pipelinev1.Param
 // Resolve performs the work of fetching a resource from a namespace with the given
 // parameters.
 func (r *Resolver) Resolve(ctx context.Context, origParams []pipelinev1.Param) (framework.ResolvedResource, error) {
+	if err := CheckServiceAccountAccess(ctx, origParams, r.kubeClientSet, r.pipelineClientSet, r.resolutionClientSet); err != nil {
+		return nil, err
+	}
 	return ResolveFromParams(ctx, origParams, r.pipelineClientSet)
 }
 
@@ -261,10 +272,15 @@ func populateParamsWithDefaults(ctx context.Context, origParams []pipelinev1.Par
 	}
 
 	if conf[BlockedNamespacesKey] != "" && isInCommaSeparatedList(params[NamespaceParam], conf[BlockedNamespacesKey]) {
-		return nil, fmt.Errorf("access to specified namespace %s is blocked", params[NamespaceParam])
+		return nil, &NamespaceAccessError{Namespace: params[NamespaceParam], Reason: "blocked"}
+	}
+
+	allowedNamespaces := conf[AllowedNamespacesKey]
+	if override, ok := conf[AllowedNamespacesKeyPrefix+common.RequestNamespace(ctx)]; ok {
+		allowedNamespaces = override
 	}
 
-	if conf[AllowedNamespacesKey] != "" && isInCommaSeparatedList(params[NamespaceParam], conf[AllowedNamespacesKey]) {
+	if allowedNamespaces != "" && isInCommaSeparatedList(params[NamespaceParam], allowedNamespaces) {
 		return params, nil
 	}
 
@@ -272,8 +288,8 @@ func populateParamsWithDefaults(ctx context.Context, origParams []pipelinev1.Par
 		return nil, errors.New("only explicit allowed access to namespaces is allowed")
 	}
 
-	if conf[AllowedNamespacesKey] != "" && !isInCommaSeparatedList(params[NamespaceParam], conf[AllowedNamespacesKey]) {
-		return nil, fmt.Errorf("access to specified namespace %s is not allowed", params[NamespaceParam])
+	if allowedNamespaces != "" && !isInCommaSeparatedList(params[NamespaceParam], allowedNamespaces) {
+		return nil, &NamespaceAccessError{Namespace: params[NamespaceParam], Reason: "not allowed"}
 	}
 
 	return params, nil
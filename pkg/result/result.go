@@ -40,6 +40,9 @@ const (
 
 	// TaskRunArtifactsResultType default taskRun artifacts result value
 	TaskRunArtifactsResultType ResultType = 6
+
+	// SidecarResultType default sidecar result value
+	SidecarResultType ResultType = 7
 )
 
 // RunResult is used to write key/value pairs to TaskRun pod termination messages.
@@ -97,6 +100,8 @@ func (r *ResultType) UnmarshalJSON(data []byte) error {
 		*r = StepArtifactsResultType
 	case "TaskRunArtifactsResult":
 		*r = TaskRunArtifactsResultType
+	case "SidecarResult":
+		*r = SidecarResultType
 	default:
 		*r = UnknownResultType
 	}
@@ -36,6 +36,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
 	"knative.dev/pkg/apis"
 	"knative.dev/pkg/logging"
 )
@@ -59,7 +60,18 @@ type Recorder struct {
 	runningTRsWaitingOnTaskResolutionGauge metric.Int64ObservableGauge
 	runningTRsThrottledByQuotaGauge        metric.Int64ObservableGauge
 	runningTRsThrottledByNodeGauge         metric.Int64ObservableGauge
+	throttledTRsGauge                      metric.Int64UpDownCounter
 	podLatencyHistogram                    metric.Float64Histogram
+	runningSlowCounter                     metric.Int64Counter
+	featureFlagUsedCounter                 metric.Int64Counter
+	reconcilePhaseHistogram                metric.Float64Histogram
+	trRetriesCounter                       metric.Int64Counter
+	trRetriesDurationHistogram             metric.Float64Histogram
+
+	// throttled holds the reason each TaskRun counted by throttledTRsGauge is throttled for.
+	throttled map[types.NamespacedName]string
+	// retried holds the number of archived attempts of each TaskRun, not done yet, counted by trRetriesCounter.
+	retried map[types.NamespacedName]int
 
 	insertTaskTag     func(task, taskrun string) []attribute.KeyValue
 	insertPipelineTag func(pipeline, pipelinerun string) []attribute.KeyValue
@@ -78,6 +90,8 @@ func NewRecorder(ctx context.Context) (*Recorder, error) {
 		r = &Recorder{
 			initialized: true,
 			cfg:         cfg.Metrics,
+			throttled:   map[types.NamespacedName]string{},
+			retried:     map[types.NamespacedName]int{},
 		}
 
 		errRegistering = r.configure(cfg.Metrics)
@@ -220,6 +234,15 @@ func (r *Recorder) configure(cfg *config.Metrics) error {
 	}
 	r.runningTRsThrottledByNodeGauge = runningTRsThrottledByNodeGauge
 
+	throttledTRsGauge, err := r.meter.Int64UpDownCounter(
+		"tekton_pipelines_controller_throttled_taskruns",
+		metric.WithDescription("Number of taskruns whose pods are pending, per namespace and reason, because of defined ResourceQuotas or of Node level constraints."),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create throttled taskruns gauge: %w", err)
+	}
+	r.throttledTRsGauge = throttledTRsGauge
+
 	podLatencyHistogram, err := r.meter.Float64Histogram(
 		"tekton_pipelines_controller_taskruns_pod_latency_milliseconds",
 		metric.WithDescription("scheduling latency for the taskrun pods"),
@@ -231,6 +254,55 @@ func (r *Recorder) configure(cfg *config.Metrics) error {
 	}
 	r.podLatencyHistogram = podLatencyHistogram
 
+	runningSlowCounter, err := r.meter.Int64Counter(
+		"tekton_pipelines_controller_taskruns_running_slow_total",
+		metric.WithDescription("Number of taskruns that ran for longer than the configured multiple of their expected duration"),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create taskruns running slow counter: %w", err)
+	}
+	r.runningSlowCounter = runningSlowCounter
+
+	featureFlagUsedCounter, err := r.meter.Int64Counter(
+		"tekton_pipelines_controller_taskrun_feature_flag_used_total",
+		metric.WithDescription("Number of taskruns which exercised the behavior gated by a feature flag"),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create taskrun feature flag used counter: %w", err)
+	}
+	r.featureFlagUsedCounter = featureFlagUsedCounter
+
+	reconcilePhaseHistogram, err := r.meter.Float64Histogram(
+		"tekton_pipelines_controller_taskrun_reconcile_phase_duration_seconds",
+		metric.WithDescription("The time spent in each phase of the reconciles of taskruns in seconds"),
+		metric.WithUnit("s"),
+		metric.WithExplicitBucketBoundaries(0.001, 0.005, 0.01, 0.05, 0.1, 0.5, 1, 5, 10, 30),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create taskrun reconcile phase duration histogram: %w", err)
+	}
+	r.reconcilePhaseHistogram = reconcilePhaseHistogram
+
+	trRetriesCounter, err := r.meter.Int64Counter(
+		"tekton_pipelines_controller_taskrun_retries_total",
+		metric.WithDescription("Number of attempts of taskruns which were retried"),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create taskrun retries counter: %w", err)
+	}
+	r.trRetriesCounter = trRetriesCounter
+
+	trRetriesDurationHistogram, err := r.meter.Float64Histogram(
+		"tekton_pipelines_controller_taskrun_retries_duration_seconds",
+		metric.WithDescription("The time the retried attempts of taskruns ran for in seconds"),
+		metric.WithUnit("s"),
+		metric.WithExplicitBucketBoundaries(10, 30, 60, 300, 900, 1800, 3600, 5400, 10800, 21600, 43200, 86400),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create taskrun retries duration histogram: %w", err)
+	}
+	r.trRetriesDurationHistogram = trRetriesDurationHistogram
+
 	return nil
 }
 
@@ -256,6 +328,10 @@ func (r *Recorder) DurationAndCount(ctx context.Context, tr *v1.TaskRun, beforeC
 			duration = tr.Status.CompletionTime.Sub(tr.Status.StartTime.Time)
 		}
 	}
+	// The completion time can precede the start time when the clocks of the nodes are skewed.
+	if duration < 0 {
+		duration = 0
+	}
 
 	taskName := getTaskTagName(tr)
 
@@ -377,6 +453,100 @@ func (r *Recorder) ReportRunningTaskRuns(ctx context.Context, lister listers.Tas
 	<-ctx.Done()
 }
 
+// ThrottledTaskRun counts the TaskRun as throttled, per namespace and reason, while its Succeeded condition
+// reports that its pod is pending because of a ResourceQuota or of the resources of the Nodes. It stops
+// counting it once it starts running, is done or is deleted.
+func (r *Recorder) ThrottledTaskRun(ctx context.Context, tr *v1.TaskRun) error {
+	if !r.initialized {
+		return fmt.Errorf("ignoring the metrics recording for %s , failed to initialize the metrics recorder", tr.Name)
+	}
+
+	reason := ""
+	if cond := tr.Status.GetCondition(apis.ConditionSucceeded); cond != nil && cond.IsUnknown() && tr.DeletionTimestamp == nil {
+		switch cond.Reason {
+		case pod.ReasonExceededResourceQuota, pod.ReasonExceededNodeResources:
+			reason = cond.Reason
+		}
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	key := tr.GetNamespacedName()
+	previous, ok := r.throttled[key]
+	if previous == reason {
+		return nil
+	}
+	if ok {
+		r.throttledTRsGauge.Add(ctx, -1, metric.WithAttributes(
+			attribute.String("namespace", tr.Namespace),
+			attribute.String("reason", previous),
+		))
+		delete(r.throttled, key)
+	}
+	if reason != "" {
+		r.throttledTRsGauge.Add(ctx, 1, metric.WithAttributes(
+			attribute.String("namespace", tr.Namespace),
+			attribute.String("reason", reason),
+		))
+		r.throttled[key] = reason
+	}
+
+	return nil
+}
+
+// Retries counts the attempts of the TaskRun archived into its RetriesStatus since the reconcile started, when
+// it had retriesBefore archived attempts, which weren't counted by a previous reconcile yet. Once the TaskRun is
+// done, it records the total time its archived attempts ran for, if any, and stops tracking it.
+func (r *Recorder) Retries(ctx context.Context, tr *v1.TaskRun, beforeCondition *apis.Condition, retriesBefore int) error {
+	if !r.initialized {
+		return fmt.Errorf("ignoring the metrics recording for %s , failed to initialize the metrics recorder", tr.Name)
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	attrs := []attribute.KeyValue{
+		attribute.String("namespace", tr.Namespace),
+	}
+	attrs = append(attrs, r.insertTaskTag(getTaskTagName(tr), tr.Name)...)
+
+	// The attempts archived by a reconcile whose status update failed are archived again by the next one.
+	key := tr.GetNamespacedName()
+	counted, ok := r.retried[key]
+	if !ok {
+		counted = retriesBefore
+	}
+	if retries := len(tr.Status.RetriesStatus); retries > counted {
+		r.trRetriesCounter.Add(ctx, int64(retries-counted), metric.WithAttributes(attrs...))
+		counted = retries
+	}
+	r.retried[key] = counted
+
+	if !tr.IsDone() && tr.DeletionTimestamp == nil {
+		return nil
+	}
+	delete(r.retried, key)
+
+	afterCondition := tr.Status.GetCondition(apis.ConditionSucceeded)
+	if !tr.IsDone() || len(tr.Status.RetriesStatus) == 0 || equality.Semantic.DeepEqual(beforeCondition, afterCondition) {
+		return nil
+	}
+	duration := time.Duration(0)
+	for _, attempt := range tr.Status.RetriesStatus {
+		if attempt.StartTime == nil || attempt.CompletionTime == nil {
+			continue
+		}
+		// The completion time can precede the start time when the clocks of the nodes are skewed.
+		if d := attempt.CompletionTime.Sub(attempt.StartTime.Time); d > 0 {
+			duration += d
+		}
+	}
+	r.trRetriesDurationHistogram.Record(ctx, duration.Seconds(), metric.WithAttributes(attrs...))
+
+	return nil
+}
+
 // RecordPodLatency logs the duration required to schedule the pod for TaskRun
 func (r *Recorder) RecordPodLatency(ctx context.Context, pod *corev1.Pod, tr *v1.TaskRun) error {
 	if !r.initialized {
@@ -392,6 +562,9 @@ func (r *Recorder) RecordPodLatency(ctx context.Context, pod *corev1.Pod, tr *v1
 	}
 
 	latency := scheduledTime.Sub(pod.CreationTimestamp.Time)
+	if latency < 0 {
+		latency = 0
+	}
 	taskName := getTaskTagName(tr)
 
 	attrs := []attribute.KeyValue{
@@ -404,6 +577,56 @@ func (r *Recorder) RecordPodLatency(ctx context.Context, pod *corev1.Pod, tr *v1
 	return nil
 }
 
+// RunningSlow counts a TaskRun that has been running for longer than the configured multiple of its expected duration
+func (r *Recorder) RunningSlow(ctx context.Context, tr *v1.TaskRun) error {
+	if !r.initialized {
+		return fmt.Errorf("ignoring the metrics recording for %s , failed to initialize the metrics recorder", tr.Name)
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	attrs := []attribute.KeyValue{
+		attribute.String("namespace", tr.Namespace),
+	}
+	attrs = append(attrs, r.insertTaskTag(getTaskTagName(tr), tr.Name)...)
+
+	r.runningSlowCounter.Add(ctx, 1, metric.WithAttributes(attrs...))
+
+	return nil
+}
+
+// FeatureFlagUsed counts a TaskRun which exercised the behavior gated by the feature flag
+func (r *Recorder) FeatureFlagUsed(ctx context.Context, namespace, flag string) error {
+	if !r.initialized {
+		return errors.New("ignoring the metrics recording, failed to initialize the metrics recorder")
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.featureFlagUsedCounter.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("namespace", namespace),
+		attribute.String("flag", flag),
+	))
+
+	return nil
+}
+
+// ReconcilePhaseDuration records the time spent in a phase of the reconcile of a TaskRun
+func (r *Recorder) ReconcilePhaseDuration(ctx context.Context, phase string, duration time.Duration) error {
+	if !r.initialized {
+		return errors.New("ignoring the metrics recording, failed to initialize the metrics recorder")
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.reconcilePhaseHistogram.Record(ctx, duration.Seconds(), metric.WithAttributes(attribute.String("phase", phase)))
+
+	return nil
+}
+
 // Helper functions for tag insertion
 
 func pipelinerunInsertTag(pipeline, pipelinerun string) []attribute.KeyValue {
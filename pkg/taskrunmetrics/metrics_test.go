@@ -89,6 +89,21 @@ func TestUninitializedMetrics(t *testing.T) {
 	if err := r.RecordPodLatency(ctx, &corev1.Pod{}, &v1.TaskRun{}); err == nil {
 		t.Error("Pod Latency recording expected to return error but got nil")
 	}
+	if err := r.RunningSlow(ctx, &v1.TaskRun{}); err == nil {
+		t.Error("Running slow recording expected to return error but got nil")
+	}
+	if err := r.FeatureFlagUsed(ctx, "foo", "results-from"); err == nil {
+		t.Error("Feature flag usage recording expected to return error but got nil")
+	}
+	if err := r.ReconcilePhaseDuration(ctx, "resolution", time.Second); err == nil {
+		t.Error("Reconcile phase duration recording expected to return error but got nil")
+	}
+	if err := r.ThrottledTaskRun(ctx, &v1.TaskRun{}); err == nil {
+		t.Error("Throttled TaskRun recording expected to return error but got nil")
+	}
+	if err := r.Retries(ctx, &v1.TaskRun{}, beforeCondition, 0); err == nil {
+		t.Error("Retries recording expected to return error but got nil")
+	}
 }
 
 func TestDurationAndCountNilStartTime(t *testing.T) {
@@ -382,6 +397,75 @@ func TestDurationAndCountGaugeDurationType(t *testing.T) {
 	t.Error("duration metric not found")
 }
 
+func TestDurationAndCountClockSkew(t *testing.T) {
+	resetMetrics()
+	ctx := context.Background()
+	cfg := &config.Config{
+		Metrics: &config.Metrics{
+			TaskrunLevel:            config.TaskrunLevelAtTaskrun,
+			PipelinerunLevel:        config.PipelinerunLevelAtPipelinerun,
+			DurationTaskrunType:     config.DurationTaskrunTypeLastValue,
+			DurationPipelinerunType: config.DefaultDurationPipelinerunType,
+		},
+	}
+	ctx = config.ToContext(ctx, cfg)
+
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	otel.SetMeterProvider(provider)
+
+	r, err := NewRecorder(ctx)
+	if err != nil {
+		t.Fatalf("NewRecorder: %v", err)
+	}
+
+	// The completion time precedes the start time when the clocks of the nodes are skewed.
+	skewedCompletionTime := metav1.NewTime(startTime.Add(-time.Minute))
+	tr := &v1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "taskrun-1", Namespace: "ns"},
+		Spec: v1.TaskRunSpec{
+			TaskRef: &v1.TaskRef{Name: "task-1"},
+		},
+		Status: v1.TaskRunStatus{
+			Status: duckv1.Status{
+				Conditions: duckv1.Conditions{{
+					Type:   apis.ConditionSucceeded,
+					Status: corev1.ConditionTrue,
+				}},
+			},
+			TaskRunStatusFields: v1.TaskRunStatusFields{
+				StartTime:      &startTime,
+				CompletionTime: &skewedCompletionTime,
+			},
+		},
+	}
+
+	if err := r.DurationAndCount(ctx, tr, nil); err != nil {
+		t.Fatalf("DurationAndCount: %v", err)
+	}
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(ctx, &rm); err != nil {
+		t.Fatalf("Collect error: %v", err)
+	}
+
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name == "tekton_pipelines_controller_taskrun_duration_seconds" {
+				gauge, ok := m.Data.(metricdata.Gauge[float64])
+				if !ok || len(gauge.DataPoints) != 1 {
+					t.Fatalf("Expected a single Gauge[float64] data point, got %v", m.Data)
+				}
+				if got := gauge.DataPoints[0].Value; got != 0 {
+					t.Errorf("Expected a duration of 0 for a completion time preceding the start time, got %v", got)
+				}
+				return
+			}
+		}
+	}
+	t.Error("duration metric not found")
+}
+
 func TestOnStoreInvalidConfig(t *testing.T) {
 	resetMetrics()
 	ctx := getConfigContext(false, false)
@@ -1388,6 +1472,67 @@ func TestRecordPodLatency(t *testing.T) {
 	}
 }
 
+func TestRunningSlow(t *testing.T) {
+	resetMetrics()
+	ctx := getConfigContext(false, false)
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	otel.SetMeterProvider(provider)
+
+	metrics, err := NewRecorder(ctx)
+	if err != nil {
+		t.Fatalf("NewRecorder: %v", err)
+	}
+
+	tr := &v1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-taskrun", Namespace: "foo"},
+		Spec: v1.TaskRunSpec{
+			TaskRef: &v1.TaskRef{Name: "task-1"},
+		},
+	}
+	if err := metrics.RunningSlow(ctx, tr); err != nil {
+		t.Fatalf("RunningSlow: %v", err)
+	}
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(ctx, &rm); err != nil {
+		t.Fatalf("Collect error: %v", err)
+	}
+
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name == "tekton_pipelines_controller_taskruns_running_slow_total" {
+				sum, ok := m.Data.(metricdata.Sum[int64])
+				if !ok {
+					t.Fatalf("Expected Sum[int64], got %T", m.Data)
+				}
+				if len(sum.DataPoints) != 1 {
+					t.Fatalf("Expected 1 data point, got %d", len(sum.DataPoints))
+				}
+				dp := sum.DataPoints[0]
+				if dp.Value != 1 {
+					t.Errorf("Expected running slow count 1, got %d", dp.Value)
+				}
+
+				gotAttrs := make(map[string]string)
+				for _, kv := range dp.Attributes.ToSlice() {
+					gotAttrs[string(kv.Key)] = kv.Value.AsString()
+				}
+				expectedAttrs := map[string]string{
+					"namespace": "foo",
+					"task":      "task-1",
+					"taskrun":   "test-taskrun",
+				}
+				if d := cmp.Diff(expectedAttrs, gotAttrs); d != "" {
+					t.Errorf("Attributes diff (-want, +got): %s", d)
+				}
+				return
+			}
+		}
+	}
+	t.Error("running slow metric not found")
+}
+
 func TestTaskRunIsOfPipelinerun(t *testing.T) {
 	tests := []struct {
 		name                  string
@@ -1571,3 +1716,372 @@ func TestInsertTags(t *testing.T) {
 		}
 	})
 }
+
+func TestFeatureFlagUsed(t *testing.T) {
+	resetMetrics()
+	ctx := getConfigContext(false, false)
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	otel.SetMeterProvider(provider)
+
+	metrics, err := NewRecorder(ctx)
+	if err != nil {
+		t.Fatalf("NewRecorder: %v", err)
+	}
+	for _, flag := range []string{"results-from", "results-from", "enforce-nonfalsifiability"} {
+		if err := metrics.FeatureFlagUsed(ctx, "foo", flag); err != nil {
+			t.Fatalf("FeatureFlagUsed: %v", err)
+		}
+	}
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(ctx, &rm); err != nil {
+		t.Fatalf("Collect error: %v", err)
+	}
+
+	got := map[string]int64{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name != "tekton_pipelines_controller_taskrun_feature_flag_used_total" {
+				continue
+			}
+			sum, ok := m.Data.(metricdata.Sum[int64])
+			if !ok {
+				t.Fatalf("Expected Sum[int64], got %T", m.Data)
+			}
+			for _, dp := range sum.DataPoints {
+				ns, _ := dp.Attributes.Value("namespace")
+				flag, _ := dp.Attributes.Value("flag")
+				got[ns.AsString()+"/"+flag.AsString()] = dp.Value
+			}
+		}
+	}
+	if d := cmp.Diff(map[string]int64{"foo/results-from": 2, "foo/enforce-nonfalsifiability": 1}, got); d != "" {
+		t.Errorf("Unexpected feature flag usage counts (-want +got): %s", d)
+	}
+}
+
+func TestReconcilePhaseDuration(t *testing.T) {
+	resetMetrics()
+	ctx := getConfigContext(false, false)
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	otel.SetMeterProvider(provider)
+
+	metrics, err := NewRecorder(ctx)
+	if err != nil {
+		t.Fatalf("NewRecorder: %v", err)
+	}
+	for _, d := range []time.Duration{time.Second, 500 * time.Millisecond} {
+		if err := metrics.ReconcilePhaseDuration(ctx, "resolution", d); err != nil {
+			t.Fatalf("ReconcilePhaseDuration: %v", err)
+		}
+	}
+	if err := metrics.ReconcilePhaseDuration(ctx, "resources", 2*time.Second); err != nil {
+		t.Fatalf("ReconcilePhaseDuration: %v", err)
+	}
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(ctx, &rm); err != nil {
+		t.Fatalf("Collect error: %v", err)
+	}
+
+	got := map[string]float64{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name != "tekton_pipelines_controller_taskrun_reconcile_phase_duration_seconds" {
+				continue
+			}
+			hist, ok := m.Data.(metricdata.Histogram[float64])
+			if !ok {
+				t.Fatalf("Expected Histogram[float64], got %T", m.Data)
+			}
+			for _, dp := range hist.DataPoints {
+				phase, _ := dp.Attributes.Value("phase")
+				got[phase.AsString()] = dp.Sum
+			}
+		}
+	}
+	if d := cmp.Diff(map[string]float64{"resolution": 1.5, "resources": 2}, got); d != "" {
+		t.Errorf("Unexpected reconcile phase durations (-want +got): %s", d)
+	}
+}
+
+func TestThrottledTaskRun(t *testing.T) {
+	resetMetrics()
+	ctx := getConfigContext(false, false)
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	otel.SetMeterProvider(provider)
+
+	metrics, err := NewRecorder(ctx)
+	if err != nil {
+		t.Fatalf("NewRecorder: %v", err)
+	}
+
+	taskRun := func(namespace, name string, status corev1.ConditionStatus, reason string) *v1.TaskRun {
+		return &v1.TaskRun{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Status: v1.TaskRunStatus{
+				Status: duckv1.Status{
+					Conditions: duckv1.Conditions{{
+						Type:   apis.ConditionSucceeded,
+						Status: status,
+						Reason: reason,
+					}},
+				},
+			},
+		}
+	}
+	deleted := taskRun("bar", "taskrun-4", corev1.ConditionUnknown, pod.ReasonExceededResourceQuota)
+	deleted.DeletionTimestamp = &startTime
+
+	for _, tc := range []struct {
+		desc string
+		trs  []*v1.TaskRun
+		want map[string]int64
+	}{{
+		desc: "pending pods throttled by quota and node resources",
+		trs: []*v1.TaskRun{
+			taskRun("foo", "taskrun-1", corev1.ConditionUnknown, pod.ReasonExceededResourceQuota),
+			taskRun("foo", "taskrun-2", corev1.ConditionUnknown, pod.ReasonExceededResourceQuota),
+			taskRun("foo", "taskrun-3", corev1.ConditionUnknown, pod.ReasonExceededNodeResources),
+			taskRun("bar", "taskrun-4", corev1.ConditionUnknown, pod.ReasonExceededResourceQuota),
+		},
+		want: map[string]int64{
+			"foo/" + pod.ReasonExceededResourceQuota: 2,
+			"foo/" + pod.ReasonExceededNodeResources: 1,
+			"bar/" + pod.ReasonExceededResourceQuota: 1,
+		},
+	}, {
+		desc: "reconciled again with the same reason",
+		trs: []*v1.TaskRun{
+			taskRun("foo", "taskrun-1", corev1.ConditionUnknown, pod.ReasonExceededResourceQuota),
+		},
+		want: map[string]int64{
+			"foo/" + pod.ReasonExceededResourceQuota: 2,
+			"foo/" + pod.ReasonExceededNodeResources: 1,
+			"bar/" + pod.ReasonExceededResourceQuota: 1,
+		},
+	}, {
+		desc: "throttled for another reason, started and done",
+		trs: []*v1.TaskRun{
+			taskRun("foo", "taskrun-1", corev1.ConditionUnknown, pod.ReasonExceededNodeResources),
+			taskRun("foo", "taskrun-2", corev1.ConditionUnknown, v1.TaskRunReasonRunning.String()),
+			taskRun("foo", "taskrun-3", corev1.ConditionTrue, v1.TaskRunReasonSuccessful.String()),
+		},
+		want: map[string]int64{
+			"foo/" + pod.ReasonExceededResourceQuota: 0,
+			"foo/" + pod.ReasonExceededNodeResources: 1,
+			"bar/" + pod.ReasonExceededResourceQuota: 1,
+		},
+	}, {
+		desc: "deleted",
+		trs:  []*v1.TaskRun{deleted},
+		want: map[string]int64{
+			"foo/" + pod.ReasonExceededResourceQuota: 0,
+			"foo/" + pod.ReasonExceededNodeResources: 1,
+			"bar/" + pod.ReasonExceededResourceQuota: 0,
+		},
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			for _, tr := range tc.trs {
+				if err := metrics.ThrottledTaskRun(ctx, tr); err != nil {
+					t.Fatalf("ThrottledTaskRun: %v", err)
+				}
+			}
+
+			var rm metricdata.ResourceMetrics
+			if err := reader.Collect(ctx, &rm); err != nil {
+				t.Fatalf("Collect error: %v", err)
+			}
+			got := map[string]int64{}
+			for _, sm := range rm.ScopeMetrics {
+				for _, m := range sm.Metrics {
+					if m.Name != "tekton_pipelines_controller_throttled_taskruns" {
+						continue
+					}
+					sum, ok := m.Data.(metricdata.Sum[int64])
+					if !ok {
+						t.Fatalf("Expected Sum[int64], got %T", m.Data)
+					}
+					for _, dp := range sum.DataPoints {
+						ns, _ := dp.Attributes.Value("namespace")
+						reason, _ := dp.Attributes.Value("reason")
+						got[ns.AsString()+"/"+reason.AsString()] = dp.Value
+					}
+				}
+			}
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("Unexpected throttled taskruns counts (-want +got): %s", d)
+			}
+		})
+	}
+}
+
+func TestRetries(t *testing.T) {
+	resetMetrics()
+	ctx := getConfigContext(false, false)
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	otel.SetMeterProvider(provider)
+
+	metrics, err := NewRecorder(ctx)
+	if err != nil {
+		t.Fatalf("NewRecorder: %v", err)
+	}
+
+	condition := func(status corev1.ConditionStatus, reason string) *apis.Condition {
+		return &apis.Condition{Type: apis.ConditionSucceeded, Status: status, Reason: reason}
+	}
+	attempt := func(start time.Time, duration time.Duration) v1.TaskRunStatus {
+		return v1.TaskRunStatus{
+			Status: duckv1.Status{Conditions: duckv1.Conditions{*condition(corev1.ConditionFalse, v1.TaskRunReasonFailed.String())}},
+			TaskRunStatusFields: v1.TaskRunStatusFields{
+				StartTime:      &metav1.Time{Time: start},
+				CompletionTime: &metav1.Time{Time: start.Add(duration)},
+			},
+		}
+	}
+	// The first attempt runs for a minute and the second one for two minutes before the third one succeeds.
+	retries := []v1.TaskRunStatus{
+		attempt(startTime.Time, time.Minute),
+		attempt(startTime.Add(time.Minute), 2*time.Minute),
+	}
+	running := condition(corev1.ConditionUnknown, v1.TaskRunReasonRunning.String())
+	toBeRetried := condition(corev1.ConditionUnknown, v1.TaskRunReasonToBeRetried.String())
+	succeeded := condition(corev1.ConditionTrue, v1.TaskRunReasonSuccessful.String())
+
+	for _, tc := range []struct {
+		desc          string
+		before        *apis.Condition
+		retriesBefore int
+		after         *apis.Condition
+		retries       int
+		wantCount     int64
+		wantDurations []float64
+	}{{
+		desc:    "first attempt running",
+		before:  running,
+		after:   running,
+		retries: 0,
+	}, {
+		desc:      "first attempt archived",
+		before:    running,
+		after:     toBeRetried,
+		retries:   1,
+		wantCount: 1,
+	}, {
+		desc:      "first attempt archived again after a failed status update",
+		before:    running,
+		after:     toBeRetried,
+		retries:   1,
+		wantCount: 1,
+	}, {
+		desc:          "second attempt archived",
+		before:        running,
+		retriesBefore: 1,
+		after:         toBeRetried,
+		retries:       2,
+		wantCount:     2,
+	}, {
+		desc:          "third attempt running",
+		before:        toBeRetried,
+		retriesBefore: 2,
+		after:         running,
+		retries:       2,
+		wantCount:     2,
+	}, {
+		desc:          "third attempt succeeded",
+		before:        running,
+		retriesBefore: 2,
+		after:         succeeded,
+		retries:       2,
+		wantCount:     2,
+		wantDurations: []float64{180},
+	}, {
+		desc:          "reconciled again once done",
+		before:        succeeded,
+		retriesBefore: 2,
+		after:         succeeded,
+		retries:       2,
+		wantCount:     2,
+		wantDurations: []float64{180},
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			tr := &v1.TaskRun{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-taskrun", Namespace: "foo"},
+				Spec: v1.TaskRunSpec{
+					TaskRef: &v1.TaskRef{Name: "task-1"},
+					Retries: 2,
+				},
+				Status: v1.TaskRunStatus{
+					Status: duckv1.Status{Conditions: duckv1.Conditions{*tc.after}},
+					TaskRunStatusFields: v1.TaskRunStatusFields{
+						RetriesStatus: retries[:tc.retries],
+					},
+				},
+			}
+			if err := metrics.Retries(ctx, tr, tc.before, tc.retriesBefore); err != nil {
+				t.Fatalf("Retries: %v", err)
+			}
+
+			var rm metricdata.ResourceMetrics
+			if err := reader.Collect(ctx, &rm); err != nil {
+				t.Fatalf("Collect error: %v", err)
+			}
+			wantAttrs := map[string]string{
+				"namespace": "foo",
+				"task":      "task-1",
+				"taskrun":   "test-taskrun",
+			}
+			var count int64
+			var durations []float64
+			for _, sm := range rm.ScopeMetrics {
+				for _, m := range sm.Metrics {
+					switch m.Name {
+					case "tekton_pipelines_controller_taskrun_retries_total":
+						sum, ok := m.Data.(metricdata.Sum[int64])
+						if !ok {
+							t.Fatalf("Expected Sum[int64], got %T", m.Data)
+						}
+						for _, dp := range sum.DataPoints {
+							if d := cmp.Diff(wantAttrs, attributesMap(dp.Attributes)); d != "" {
+								t.Errorf("Attributes diff (-want, +got): %s", d)
+							}
+							count += dp.Value
+						}
+					case "tekton_pipelines_controller_taskrun_retries_duration_seconds":
+						hist, ok := m.Data.(metricdata.Histogram[float64])
+						if !ok {
+							t.Fatalf("Expected Histogram[float64], got %T", m.Data)
+						}
+						for _, dp := range hist.DataPoints {
+							if d := cmp.Diff(wantAttrs, attributesMap(dp.Attributes)); d != "" {
+								t.Errorf("Attributes diff (-want, +got): %s", d)
+							}
+							if dp.Count != 1 {
+								t.Errorf("Expected a single retries duration, got %d", dp.Count)
+							}
+							durations = append(durations, dp.Sum)
+						}
+					}
+				}
+			}
+			if count != tc.wantCount {
+				t.Errorf("Expected %d retries, got %d", tc.wantCount, count)
+			}
+			if d := cmp.Diff(tc.wantDurations, durations); d != "" {
+				t.Errorf("Unexpected retries durations (-want +got): %s", d)
+			}
+		})
+	}
+}
+
+func attributesMap(set attribute.Set) map[string]string {
+	attrs := make(map[string]string)
+	for _, kv := range set.ToSlice() {
+		attrs[string(kv.Key)] = kv.Value.AsString()
+	}
+	return attrs
+}
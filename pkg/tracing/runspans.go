@@ -0,0 +1,172 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+const (
+	// PipelineRunSpanName is the name of the span of a PipelineRun, from its start to its completion.
+	PipelineRunSpanName = "PipelineRun:Run"
+	// TaskRunSpanName is the name of the span of a TaskRun, from its start to its completion.
+	TaskRunSpanName = "TaskRun:Run"
+	// StepSpanName is the name of the span of a step, from the start to the end of its container.
+	StepSpanName = "Step:Run"
+	// UnfinishedAttribute is set on the span of a run or step which didn't complete, ended along
+	// with its parent.
+	UnfinishedAttribute = "unfinished"
+
+	// TraceparentEnvVar is the environment variable propagating the trace context of a TaskRun to
+	// its steps, so that they can attach their own spans to its trace.
+	TraceparentEnvVar = "TRACEPARENT"
+	// TracestateEnvVar is the environment variable propagating the trace state of a TaskRun to its steps.
+	TracestateEnvVar = "TRACESTATE"
+)
+
+// RecordPipelineRunSpans records the span of the done PipelineRun pr, from its start time to its
+// completion time, as a child of the span of its SpanContext, along with the spans of its taskRuns
+// and of their steps as its descendants. The span of a run which started but didn't complete, like a
+// TaskRun cancelled along with its PipelineRun, ends with its parent, or at observedAt for the
+// PipelineRun itself.
+func RecordPipelineRunSpans(ctx context.Context, tracer trace.Tracer, pr *v1.PipelineRun, taskRuns []*v1.TaskRun, observedAt time.Time) {
+	ctx, span, ok := startRunSpan(parentContext(ctx, pr.Status.SpanContext), tracer, PipelineRunSpanName, pr.Status.StartTime,
+		attribute.String("pipelinerun", pr.Name), attribute.String("namespace", pr.Namespace))
+	if !ok {
+		return
+	}
+	end := runEnd(span, pr.Status.CompletionTime, observedAt)
+	for _, tr := range taskRuns {
+		recordTaskRunSpans(ctx, tracer, tr, end)
+	}
+	endRunSpan(span, pr.Status.GetCondition(apis.ConditionSucceeded), end)
+}
+
+// RecordTaskRunSpans records the span of the done TaskRun tr, from its start time to its completion
+// time, or to observedAt if it doesn't have one, as a child of the span of its SpanContext, along
+// with the spans of its steps as its children.
+func RecordTaskRunSpans(ctx context.Context, tracer trace.Tracer, tr *v1.TaskRun, observedAt time.Time) {
+	recordTaskRunSpans(parentContext(ctx, tr.Status.SpanContext), tracer, tr, observedAt)
+}
+
+// recordTaskRunSpans records the spans of tr and of its steps, ending them at parentEnd when they
+// didn't complete.
+func recordTaskRunSpans(ctx context.Context, tracer trace.Tracer, tr *v1.TaskRun, parentEnd time.Time) {
+	attrs := []attribute.KeyValue{attribute.String("taskrun", tr.Name), attribute.String("namespace", tr.Namespace)}
+	if pipelineTask := tr.Labels[pipeline.PipelineTaskLabelKey]; pipelineTask != "" {
+		attrs = append(attrs, attribute.String("pipelineTask", pipelineTask))
+	}
+	ctx, span, ok := startRunSpan(ctx, tracer, TaskRunSpanName, tr.Status.StartTime, attrs...)
+	if !ok {
+		return
+	}
+	end := runEnd(span, tr.Status.CompletionTime, parentEnd)
+	for _, step := range tr.Status.Steps {
+		recordStepSpan(ctx, tracer, step, end)
+	}
+	endRunSpan(span, tr.Status.GetCondition(apis.ConditionSucceeded), end)
+}
+
+// recordStepSpan records the span of the step, if its container started, ending it at parentEnd if
+// its container didn't terminate.
+func recordStepSpan(ctx context.Context, tracer trace.Tracer, step v1.StepState, parentEnd time.Time) {
+	var startedAt, finishedAt metav1.Time
+	switch {
+	case step.Terminated != nil:
+		startedAt, finishedAt = step.Terminated.StartedAt, step.Terminated.FinishedAt
+	case step.Running != nil:
+		startedAt = step.Running.StartedAt
+	}
+	if startedAt.IsZero() {
+		return
+	}
+	_, span := tracer.Start(ctx, StepSpanName, trace.WithTimestamp(startedAt.Time), trace.WithAttributes(attribute.String("step", step.Name)))
+	if terminated := step.Terminated; terminated != nil {
+		span.SetAttributes(attribute.Int("exitCode", int(terminated.ExitCode)))
+		if terminated.ExitCode != 0 {
+			span.SetStatus(codes.Error, fmt.Sprintf("%s: exit code %d", terminated.Reason, terminated.ExitCode))
+		}
+	}
+	var end *metav1.Time
+	if !finishedAt.IsZero() {
+		end = &finishedAt
+	}
+	span.End(trace.WithTimestamp(runEnd(span, end, parentEnd)))
+}
+
+// startRunSpan starts the span of a run at its start time, unless it didn't start.
+func startRunSpan(ctx context.Context, tracer trace.Tracer, name string, startTime *metav1.Time, attrs ...attribute.KeyValue) (context.Context, trace.Span, bool) {
+	if startTime == nil {
+		return ctx, nil, false
+	}
+	ctx, span := tracer.Start(ctx, name, trace.WithTimestamp(startTime.Time), trace.WithAttributes(attrs...))
+	return ctx, span, true
+}
+
+// runEnd returns the time at which the span of a run or step ends: its completion time, or the end
+// of its parent, marking the span as unfinished, if it didn't complete.
+func runEnd(span trace.Span, completionTime *metav1.Time, parentEnd time.Time) time.Time {
+	if completionTime != nil {
+		return completionTime.Time
+	}
+	span.SetAttributes(attribute.Bool(UnfinishedAttribute, true))
+	return parentEnd
+}
+
+// endRunSpan ends the span of a run at end, with an error status if it failed.
+func endRunSpan(span trace.Span, condition *apis.Condition, end time.Time) {
+	if condition != nil {
+		span.SetAttributes(attribute.String("reason", condition.Reason))
+		if condition.IsFalse() {
+			span.SetStatus(codes.Error, condition.Message)
+		}
+	}
+	span.End(trace.WithTimestamp(end))
+}
+
+// parentContext returns ctx with the span of spanContext, propagated by the reconcilers, as the
+// parent of the spans of a run, or without any parent if the run doesn't have a SpanContext.
+func parentContext(ctx context.Context, spanContext map[string]string) context.Context {
+	ctx = trace.ContextWithSpanContext(ctx, trace.SpanContext{})
+	return otel.GetTextMapPropagator().Extract(ctx, propagation.MapCarrier(spanContext))
+}
+
+// TraceContextEnvVars returns the environment variables propagating the trace context of
+// spanContext, the SpanContext of a TaskRun, to its steps.
+func TraceContextEnvVars(spanContext map[string]string) []corev1.EnvVar {
+	var env []corev1.EnvVar
+	if traceparent := spanContext["traceparent"]; traceparent != "" {
+		env = append(env, corev1.EnvVar{Name: TraceparentEnvVar, Value: traceparent})
+		if tracestate := spanContext["tracestate"]; tracestate != "" {
+			env = append(env, corev1.EnvVar{Name: TracestateEnvVar, Value: tracestate})
+		}
+	}
+	return env
+}
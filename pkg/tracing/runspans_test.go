@@ -0,0 +1,255 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/test/diff"
+	"go.opentelemetry.io/otel/codes"
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+const (
+	parentTraceID = "0f57e147e992b304d977436289d10628"
+	parentSpanID  = "73d5909e31793992"
+)
+
+var runStart = time.Date(2026, time.March, 1, 10, 0, 0, 0, time.UTC)
+
+func at(minutes int) *metav1.Time {
+	t := metav1.NewTime(runStart.Add(time.Duration(minutes) * time.Minute))
+	return &t
+}
+
+func succeeded(status corev1.ConditionStatus, reason string) duckv1.Status {
+	return duckv1.Status{Conditions: duckv1.Conditions{{Type: apis.ConditionSucceeded, Status: status, Reason: reason, Message: reason + " message"}}}
+}
+
+func terminatedStep(name string, start, end, exitCode int) v1.StepState {
+	return v1.StepState{Name: name, ContainerState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+		StartedAt: *at(start), FinishedAt: *at(end), ExitCode: int32(exitCode), Reason: "Completed",
+	}}}
+}
+
+// recordedSpan is a recorded span, identified by its name and the name of the run or step it is
+// the span of, along with the one of its parent.
+type recordedSpan struct {
+	Parent     string
+	Start, End time.Time
+	Error      bool
+	Unfinished bool
+}
+
+func recordedSpans(t *testing.T, exporter *tracetest.InMemoryExporter) map[string]recordedSpan {
+	t.Helper()
+	stubs := exporter.GetSpans()
+	labels := map[string]string{parentSpanID: "parent"}
+	for _, s := range stubs {
+		label := s.Name
+		for _, attr := range s.Attributes {
+			switch attr.Key {
+			case "pipelinerun", "taskrun", "step":
+				label += "/" + attr.Value.AsString()
+			}
+		}
+		labels[s.SpanContext.SpanID().String()] = label
+	}
+	spans := map[string]recordedSpan{}
+	for _, s := range stubs {
+		parent := ""
+		if s.Parent.IsValid() {
+			parent = labels[s.Parent.SpanID().String()]
+			if s.Parent.TraceID() != s.SpanContext.TraceID() {
+				t.Errorf("span %s isn't in the trace of its parent", labels[s.SpanContext.SpanID().String()])
+			}
+		}
+		unfinished := false
+		for _, attr := range s.Attributes {
+			if attr.Key == UnfinishedAttribute {
+				unfinished = attr.Value.AsBool()
+			}
+		}
+		spans[labels[s.SpanContext.SpanID().String()]] = recordedSpan{
+			Parent:     parent,
+			Start:      s.StartTime,
+			End:        s.EndTime,
+			Error:      s.Status.Code == codes.Error,
+			Unfinished: unfinished,
+		}
+	}
+	return spans
+}
+
+func TestRecordPipelineRunSpans(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := tracesdk.NewTracerProvider(tracesdk.WithSyncer(exporter))
+
+	pr := &v1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "release", Namespace: "ns"},
+		Status: v1.PipelineRunStatus{
+			Status: succeeded(corev1.ConditionFalse, "Failed"),
+			PipelineRunStatusFields: v1.PipelineRunStatusFields{
+				StartTime:      at(0),
+				CompletionTime: at(10),
+				SpanContext:    map[string]string{"traceparent": "00-" + parentTraceID + "-" + parentSpanID + "-01"},
+			},
+		},
+	}
+	taskRuns := []*v1.TaskRun{{
+		ObjectMeta: metav1.ObjectMeta{Name: "release-build", Namespace: "ns"},
+		Status: v1.TaskRunStatus{
+			Status: succeeded(corev1.ConditionTrue, "Succeeded"),
+			TaskRunStatusFields: v1.TaskRunStatusFields{
+				StartTime:      at(1),
+				CompletionTime: at(5),
+				Steps:          []v1.StepState{terminatedStep("compile", 2, 3, 0), terminatedStep("package", 3, 4, 0)},
+			},
+		},
+	}, {
+		ObjectMeta: metav1.ObjectMeta{Name: "release-test", Namespace: "ns"},
+		Status: v1.TaskRunStatus{
+			Status: succeeded(corev1.ConditionFalse, "Failed"),
+			TaskRunStatusFields: v1.TaskRunStatusFields{
+				StartTime:      at(5),
+				CompletionTime: at(9),
+				Steps: []v1.StepState{
+					terminatedStep("unit", 6, 8, 1),
+					// A step which didn't run doesn't have a span.
+					{Name: "report", ContainerState: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{}}},
+				},
+			},
+		},
+	}, {
+		// A TaskRun which didn't complete, cancelled along with the PipelineRun, ends with it.
+		ObjectMeta: metav1.ObjectMeta{Name: "release-scan", Namespace: "ns"},
+		Status: v1.TaskRunStatus{
+			Status: succeeded(corev1.ConditionUnknown, "Running"),
+			TaskRunStatusFields: v1.TaskRunStatusFields{
+				StartTime: at(8),
+				Steps: []v1.StepState{
+					terminatedStep("fetch", 8, 9, 0),
+					{Name: "scan", ContainerState: corev1.ContainerState{Running: &corev1.ContainerStateRunning{StartedAt: *at(9)}}},
+				},
+			},
+		},
+	}, {
+		// A TaskRun which didn't start doesn't have a span.
+		ObjectMeta: metav1.ObjectMeta{Name: "release-publish", Namespace: "ns"},
+	}}
+
+	RecordPipelineRunSpans(t.Context(), tp.Tracer("test"), pr, taskRuns, at(20).Time)
+
+	want := map[string]recordedSpan{
+		"PipelineRun:Run/release":   {Parent: "parent", Start: at(0).Time, End: at(10).Time, Error: true},
+		"TaskRun:Run/release-build": {Parent: "PipelineRun:Run/release", Start: at(1).Time, End: at(5).Time},
+		"Step:Run/compile":          {Parent: "TaskRun:Run/release-build", Start: at(2).Time, End: at(3).Time},
+		"Step:Run/package":          {Parent: "TaskRun:Run/release-build", Start: at(3).Time, End: at(4).Time},
+		"TaskRun:Run/release-test":  {Parent: "PipelineRun:Run/release", Start: at(5).Time, End: at(9).Time, Error: true},
+		"Step:Run/unit":             {Parent: "TaskRun:Run/release-test", Start: at(6).Time, End: at(8).Time, Error: true},
+		"TaskRun:Run/release-scan":  {Parent: "PipelineRun:Run/release", Start: at(8).Time, End: at(10).Time, Unfinished: true},
+		"Step:Run/fetch":            {Parent: "TaskRun:Run/release-scan", Start: at(8).Time, End: at(9).Time},
+		"Step:Run/scan":             {Parent: "TaskRun:Run/release-scan", Start: at(9).Time, End: at(10).Time, Unfinished: true},
+	}
+	if d := cmp.Diff(want, recordedSpans(t, exporter)); d != "" {
+		t.Errorf("recorded spans %s", diff.PrintWantGot(d))
+	}
+	for _, s := range exporter.GetSpans() {
+		if got := s.SpanContext.TraceID().String(); got != parentTraceID {
+			t.Errorf("span %s is in trace %s, want %s", s.Name, got, parentTraceID)
+		}
+	}
+}
+
+func TestRecordTaskRunSpans(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := tracesdk.NewTracerProvider(tracesdk.WithSyncer(exporter))
+
+	// A TaskRun without a SpanContext is the root of its own trace.
+	tr := &v1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "lint", Namespace: "ns"},
+		Status: v1.TaskRunStatus{
+			Status: succeeded(corev1.ConditionTrue, "Succeeded"),
+			TaskRunStatusFields: v1.TaskRunStatusFields{
+				StartTime:      at(0),
+				CompletionTime: at(2),
+				Steps:          []v1.StepState{terminatedStep("golangci-lint", 0, 2, 0)},
+			},
+		},
+	}
+	// A TaskRun done without a completion time, and its steps which didn't terminate, end when it
+	// was observed done.
+	unfinished := &v1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "vet", Namespace: "ns"},
+		Status: v1.TaskRunStatus{
+			Status: succeeded(corev1.ConditionFalse, "TaskRunTimeout"),
+			TaskRunStatusFields: v1.TaskRunStatusFields{
+				StartTime: at(0),
+				Steps: []v1.StepState{{Name: "go-vet", ContainerState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+					StartedAt: *at(1), ExitCode: 137, Reason: "Error",
+				}}}},
+			},
+		},
+	}
+	RecordTaskRunSpans(t.Context(), tp.Tracer("test"), tr, at(5).Time)
+	RecordTaskRunSpans(t.Context(), tp.Tracer("test"), unfinished, at(5).Time)
+
+	want := map[string]recordedSpan{
+		"TaskRun:Run/lint":       {Start: at(0).Time, End: at(2).Time},
+		"Step:Run/golangci-lint": {Parent: "TaskRun:Run/lint", Start: at(0).Time, End: at(2).Time},
+		"TaskRun:Run/vet":        {Start: at(0).Time, End: at(5).Time, Error: true, Unfinished: true},
+		"Step:Run/go-vet":        {Parent: "TaskRun:Run/vet", Start: at(1).Time, End: at(5).Time, Error: true, Unfinished: true},
+	}
+	if d := cmp.Diff(want, recordedSpans(t, exporter)); d != "" {
+		t.Errorf("recorded spans %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestTraceContextEnvVars(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		spanContext map[string]string
+		want        []corev1.EnvVar
+	}{{
+		name: "no span context",
+	}, {
+		name:        "traceparent",
+		spanContext: map[string]string{"traceparent": "00-" + parentTraceID + "-" + parentSpanID + "-01"},
+		want:        []corev1.EnvVar{{Name: "TRACEPARENT", Value: "00-" + parentTraceID + "-" + parentSpanID + "-01"}},
+	}, {
+		name:        "traceparent and tracestate",
+		spanContext: map[string]string{"traceparent": "00-" + parentTraceID + "-" + parentSpanID + "-01", "tracestate": "vendor=value"},
+		want: []corev1.EnvVar{
+			{Name: "TRACEPARENT", Value: "00-" + parentTraceID + "-" + parentSpanID + "-01"},
+			{Name: "TRACESTATE", Value: "vendor=value"},
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			if d := cmp.Diff(tc.want, TraceContextEnvVars(tc.spanContext)); d != "" {
+				t.Errorf("TraceContextEnvVars() %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
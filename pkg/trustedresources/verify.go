@@ -23,6 +23,7 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/sigstore/sigstore/pkg/signature"
@@ -70,6 +71,17 @@ type VerificationResult struct {
 	Err error
 }
 
+// Source identifies where a remote resource was resolved from, for the VerificationPolicies to be
+// matched against it.
+type Source struct {
+	// RefSource is the source of the resource, matched against the patterns of the policies.
+	RefSource *v1.RefSource
+	// Resolver is the name of the resolver which resolved the resource, e.g. "git" or "bundles".
+	Resolver string
+	// Params are the params the resource was resolved with.
+	Params v1.Params
+}
+
 // VerifyResource verifies the signature and public key against resource (v1beta1 and v1 task and pipeline).
 // VerificationResult is returned with different types for different cases:
 // 1) Return VerificationResult with VerificationSkip type, when no policies are found and no-match-policy is set to ignore
@@ -78,12 +90,14 @@ type VerificationResult struct {
 // 4) Return VerificationResult with VerificationError type when no policies are found and no-match-policy is set to fail, the resource fails to pass matched enforce verification policy, or there are errors during verification. Err is filled with the err.
 // refSource contains the source information of the resource.
 func VerifyResource(ctx context.Context, resource metav1.Object, k8s kubernetes.Interface, refSource *v1.RefSource, verificationpolicies []*v1alpha1.VerificationPolicy) VerificationResult {
-	var refSourceURI string
-	if refSource != nil {
-		refSourceURI = refSource.URI
-	}
+	return VerifyResolvedResource(ctx, resource, k8s, Source{RefSource: refSource}, verificationpolicies)
+}
 
-	matchedPolicies, err := getMatchedPolicies(resource.GetName(), refSourceURI, verificationpolicies)
+// VerifyResolvedResource verifies resource like VerifyResource, matching the VerificationPolicies
+// against the resolver and the params it was resolved with, in addition to its RefSource, so that
+// the resources of different resolvers can be verified with different policies.
+func VerifyResolvedResource(ctx context.Context, resource metav1.Object, k8s kubernetes.Interface, source Source, verificationpolicies []*v1alpha1.VerificationPolicy) VerificationResult {
+	matchedPolicies, err := getMatchedPolicies(resource.GetName(), source, verificationpolicies)
 	if err != nil {
 		if errors.Is(err, ErrNoMatchedPolicies) {
 			switch config.GetVerificationNoMatchPolicy(ctx) {
@@ -115,21 +129,35 @@ func VerifyPipeline(ctx context.Context, pipelineObj *v1beta1.Pipeline, k8s kube
 	return VerifyResource(ctx, pipelineObj, k8s, refSource, verificationpolicies)
 }
 
-// getMatchedPolicies filters out the policies by checking if the resource url (source) is matching any of the `patterns` in the `resources` list.
-func getMatchedPolicies(resourceName string, source string, policies []*v1alpha1.VerificationPolicy) ([]*v1alpha1.VerificationPolicy, error) {
+// getMatchedPolicies filters out the policies by checking if the resource url (source) is matching any of the `patterns` in the `resources` list,
+// restricted to the resolver and the params of the patterns which set them.
+func getMatchedPolicies(resourceName string, source Source, policies []*v1alpha1.VerificationPolicy) ([]*v1alpha1.VerificationPolicy, error) {
 	matchedPolicies := []*v1alpha1.VerificationPolicy{}
+	var uri string
+	if source.RefSource != nil {
+		uri = source.RefSource.URI
+	}
 	// Strip known resolver prefixes before matching so that patterns
 	// written without the prefix (e.g. "https://github.com/…") still
 	// work after we anchor them for full-string matching.
-	normalizedSource := stripResolverPrefix(source)
+	normalizedSource := stripResolverPrefix(uri)
 	for _, p := range policies {
 		for _, r := range p.Spec.Resources {
+			if r.Resolver != "" && r.Resolver != source.Resolver {
+				continue
+			}
 			pattern := anchorPattern(r.Pattern)
 			matching, err := regexp.MatchString(pattern, normalizedSource)
 			if err != nil {
 				// FixMe: changing %v to %w breaks integration tests.
 				return matchedPolicies, fmt.Errorf("%v: %w", err, ErrRegexMatch) //nolint:errorlint
 			}
+			if matching {
+				matching, err = paramsMatch(r.Params, source.Params)
+				if err != nil {
+					return matchedPolicies, fmt.Errorf("%v: %w", err, ErrRegexMatch) //nolint:errorlint
+				}
+			}
 			if matching {
 				matchedPolicies = append(matchedPolicies, p)
 				break
@@ -137,11 +165,27 @@ func getMatchedPolicies(resourceName string, source string, policies []*v1alpha1
 		}
 	}
 	if len(matchedPolicies) == 0 {
-		return matchedPolicies, fmt.Errorf("%w: no matching policies are found for resource: %s against source: %s", ErrNoMatchedPolicies, resourceName, source)
+		return matchedPolicies, fmt.Errorf("%w: no matching policies are found for resource: %s against source: %s", ErrNoMatchedPolicies, resourceName, uri)
 	}
 	return matchedPolicies, nil
 }
 
+// paramsMatch reports whether the string values of params match the patterns keyed by their names.
+// A param that is missing, or isn't a string, doesn't match its pattern.
+func paramsMatch(patterns map[string]string, params v1.Params) (bool, error) {
+	for name, pattern := range patterns {
+		i := slices.IndexFunc(params, func(p v1.Param) bool { return p.Name == name })
+		if i < 0 || params[i].Value.Type != v1.ParamTypeString {
+			return false, nil
+		}
+		matching, err := regexp.MatchString(anchorPattern(pattern), params[i].Value.StringVal)
+		if err != nil || !matching {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
 // anchorPattern wraps a pattern with ^(?:…)$ when it is not already
 // anchored.  This forces full-string matching so that unanchored
 // patterns cannot be bypassed by embedding the trusted URL as a
@@ -208,11 +252,11 @@ func verifyResource(ctx context.Context, resource metav1.Object, k8s kubernetes.
 	for _, p := range enforcePolicies {
 		verifiers, err := verifier.FromPolicy(ctx, k8s, p)
 		if err != nil {
-			return VerificationResult{VerificationResultType: VerificationError, Err: fmt.Errorf("failed to get verifiers from policy: %w", err)}
+			return VerificationResult{VerificationResultType: VerificationError, Err: fmt.Errorf("failed to get verifiers from policy %s: %w", p.Name, err)}
 		}
 		passVerification := doesAnyVerifierPass(ctx, checksumBytes, signature, verifiers)
 		if !passVerification {
-			return VerificationResult{VerificationResultType: VerificationError, Err: fmt.Errorf("%w: resource %s in namespace %s fails verification", ErrResourceVerificationFailed, resource.GetName(), resource.GetNamespace())}
+			return VerificationResult{VerificationResultType: VerificationError, Err: fmt.Errorf("%w: resource %s in namespace %s fails verification against policy %s", ErrResourceVerificationFailed, resource.GetName(), resource.GetNamespace(), p.Name)}
 		}
 	}
 
@@ -220,13 +264,13 @@ func verifyResource(ctx context.Context, resource metav1.Object, k8s kubernetes.
 	for _, p := range warnPolicies {
 		verifiers, err := verifier.FromPolicy(ctx, k8s, p)
 		if err != nil {
-			warn := fmt.Errorf("failed to get verifiers for resource %s from namespace %s: %w", resource.GetName(), resource.GetNamespace(), err)
+			warn := fmt.Errorf("failed to get verifiers for resource %s from namespace %s from policy %s: %w", resource.GetName(), resource.GetNamespace(), p.Name, err)
 			logger.Warnf(warn.Error())
 			return VerificationResult{VerificationResultType: VerificationWarn, Err: warn}
 		}
 		passVerification := doesAnyVerifierPass(ctx, checksumBytes, signature, verifiers)
 		if !passVerification {
-			warn := fmt.Errorf("%w: resource %s in namespace %s fails verification", ErrResourceVerificationFailed, resource.GetName(), resource.GetNamespace())
+			warn := fmt.Errorf("%w: resource %s in namespace %s fails verification against policy %s", ErrResourceVerificationFailed, resource.GetName(), resource.GetNamespace(), p.Name)
 			logger.Warnf(warn.Error())
 			return VerificationResult{VerificationResultType: VerificationWarn, Err: warn}
 		}
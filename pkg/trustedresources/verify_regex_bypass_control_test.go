@@ -4,6 +4,7 @@ import (
 	"errors"
 	"testing"
 
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 )
 
@@ -46,7 +47,7 @@ func TestGetMatchedPolicies_RegexBypass(t *testing.T) {
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			matched, err := getMatchedPolicies("test-resource", tc.source, policies)
+			matched, err := getMatchedPolicies("test-resource", Source{RefSource: &v1.RefSource{URI: tc.source}}, policies)
 			if tc.wantMatch {
 				if err != nil {
 					t.Fatalf("expected match for source %q, got err: %v", tc.source, err)
@@ -108,7 +109,7 @@ func TestGetMatchedPolicies_AlreadyAnchoredPatterns(t *testing.T) {
 					}},
 				},
 			}
-			matched, err := getMatchedPolicies("test-resource", tc.source, []*v1alpha1.VerificationPolicy{policy})
+			matched, err := getMatchedPolicies("test-resource", Source{RefSource: &v1.RefSource{URI: tc.source}}, []*v1alpha1.VerificationPolicy{policy})
 			if tc.wantMatch {
 				if err != nil {
 					t.Fatalf("expected match for pattern %q against source %q, got err: %v", tc.pattern, tc.source, err)
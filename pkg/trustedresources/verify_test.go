@@ -24,6 +24,7 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"errors"
+	"strings"
 	"testing"
 
 	"github.com/sigstore/sigstore/pkg/signature"
@@ -35,6 +36,7 @@ import (
 	test "github.com/tektoncd/pipeline/test"
 	"go.uber.org/zap/zaptest"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakek8s "k8s.io/client-go/kubernetes/fake"
 	"knative.dev/pkg/logging"
 )
 
@@ -543,6 +545,127 @@ func TestVerifyResource_V1Pipeline_Error(t *testing.T) {
 	}
 }
 
+// TestVerifyResolvedResource_PerResolverPolicies tests that the resources of different resolvers are
+// verified with the keys of the policies keyed to their resolver and params.
+func TestVerifyResolvedResource_PerResolverPolicies(t *testing.T) {
+	ctx := logging.WithLogger(t.Context(), zaptest.NewLogger(t).Sugar())
+	ctx = test.SetupTrustedResourceConfig(ctx, config.FailNoMatchPolicy)
+	gitSigner, _, gitPub, err := test.GenerateKeys(elliptic.P256(), crypto.SHA256)
+	if err != nil {
+		t.Fatalf("failed to generate keys: %v", err)
+	}
+	bundlesSigner, _, bundlesPub, err := test.GenerateKeys(elliptic.P256(), crypto.SHA256)
+	if err != nil {
+		t.Fatalf("failed to generate keys: %v", err)
+	}
+	policy := func(name string, pattern v1alpha1.ResourcePattern, pub []byte) *v1alpha1.VerificationPolicy {
+		return &v1alpha1.VerificationPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: v1alpha1.VerificationPolicySpec{
+				Resources:   []v1alpha1.ResourcePattern{pattern},
+				Authorities: []v1alpha1.Authority{{Name: "key", Key: &v1alpha1.KeyRef{Data: string(pub)}}},
+			},
+		}
+	}
+	vps := []*v1alpha1.VerificationPolicy{
+		policy("git-policy", v1alpha1.ResourcePattern{
+			Pattern:  ".*",
+			Resolver: "git",
+			Params:   map[string]string{"url": "https://github.com/my-org/.*"},
+		}, gitPub),
+		policy("bundles-policy", v1alpha1.ResourcePattern{Pattern: ".*", Resolver: "bundles"}, bundlesPub),
+	}
+	signedByGitKey, err := getSignedV1Task(unsignedV1Task.DeepCopy(), gitSigner, "signed-by-git-key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	signedByBundlesKey, err := getSignedV1Task(unsignedV1Task.DeepCopy(), bundlesSigner, "signed-by-bundles-key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	gitSource := Source{
+		RefSource: &v1.RefSource{URI: "git+https://github.com/my-org/catalog.git"},
+		Resolver:  "git",
+		Params:    v1.Params{{Name: "url", Value: *v1.NewStructuredValues("https://github.com/my-org/catalog.git")}},
+	}
+	bundlesSource := Source{
+		RefSource: &v1.RefSource{URI: "gcr.io/my-org/catalog/git-clone"},
+		Resolver:  "bundles",
+		Params:    v1.Params{{Name: "bundle", Value: *v1.NewStructuredValues("gcr.io/my-org/catalog/git-clone:0.1")}},
+	}
+
+	for _, tc := range []struct {
+		name       string
+		task       *v1.Task
+		source     Source
+		wantResult VerificationResultType
+		wantErr    error
+		wantPolicy string
+	}{{
+		name:       "git resource signed with the git key",
+		task:       signedByGitKey,
+		source:     gitSource,
+		wantResult: VerificationPass,
+	}, {
+		name:       "git resource signed with the bundles key",
+		task:       signedByBundlesKey,
+		source:     gitSource,
+		wantResult: VerificationError,
+		wantErr:    ErrResourceVerificationFailed,
+		wantPolicy: "git-policy",
+	}, {
+		name:       "bundle signed with the bundles key",
+		task:       signedByBundlesKey,
+		source:     bundlesSource,
+		wantResult: VerificationPass,
+	}, {
+		name:       "bundle signed with the git key",
+		task:       signedByGitKey,
+		source:     bundlesSource,
+		wantResult: VerificationError,
+		wantErr:    ErrResourceVerificationFailed,
+		wantPolicy: "bundles-policy",
+	}, {
+		name: "git resource of another org",
+		task: signedByGitKey,
+		source: Source{
+			RefSource: &v1.RefSource{URI: "git+https://github.com/other-org/catalog.git"},
+			Resolver:  "git",
+			Params:    v1.Params{{Name: "url", Value: *v1.NewStructuredValues("https://github.com/other-org/catalog.git")}},
+		},
+		wantResult: VerificationError,
+		wantErr:    ErrNoMatchedPolicies,
+	}, {
+		name: "git resource without the url param",
+		task: signedByGitKey,
+		source: Source{
+			RefSource: &v1.RefSource{URI: "git+https://github.com/my-org/catalog.git"},
+			Resolver:  "git",
+		},
+		wantResult: VerificationError,
+		wantErr:    ErrNoMatchedPolicies,
+	}, {
+		name:       "hub resource",
+		task:       signedByGitKey,
+		source:     Source{RefSource: &v1.RefSource{URI: "https://artifacthub.io/git-clone"}, Resolver: "hub"},
+		wantResult: VerificationError,
+		wantErr:    ErrNoMatchedPolicies,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			vr := VerifyResolvedResource(ctx, tc.task, fakek8s.NewSimpleClientset(), tc.source, vps)
+			if vr.VerificationResultType != tc.wantResult {
+				t.Errorf("VerificationResultType = %v, want %v: %v", vr.VerificationResultType, tc.wantResult, vr.Err)
+			}
+			if !errors.Is(vr.Err, tc.wantErr) {
+				t.Errorf("Err = %v, want %v", vr.Err, tc.wantErr)
+			}
+			if tc.wantPolicy != "" && !strings.Contains(vr.Err.Error(), "policy "+tc.wantPolicy) {
+				t.Errorf("Err = %v, want it to name the policy %s", vr.Err, tc.wantPolicy)
+			}
+		})
+	}
+}
+
 func signInterface(signer signature.Signer, i interface{}) ([]byte, error) {
 	if signer == nil {
 		return nil, errors.New("signer is nil")
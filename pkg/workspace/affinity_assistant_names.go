@@ -24,6 +24,8 @@ const (
 	LabelComponent = "app.kubernetes.io/component"
 	// ComponentNameAffinityAssistant is the component name for an Affinity Assistant
 	ComponentNameAffinityAssistant = "affinity-assistant"
+	// AffinityAssistantPodSelector is the label selector of the pods of the Affinity Assistants
+	AffinityAssistantPodSelector = LabelComponent + "=" + ComponentNameAffinityAssistant
 
 	// AnnotationAffinityAssistantName is used to pass the instance name of an Affinity Assistant to TaskRun pods
 	AnnotationAffinityAssistantName = "pipeline.tekton.dev/affinity-assistant"
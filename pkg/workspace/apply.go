@@ -93,6 +93,8 @@ func CreateVolumes(wb []v1.WorkspaceBinding) map[string]corev1.Volume {
 		case w.CSI != nil:
 			csi := *w.CSI
 			v.setVolumeSource(w.Name, name, corev1.VolumeSource{CSI: &csi})
+		case w.OCIImage != nil:
+			v.setVolumeSource(w.Name, name, corev1.VolumeSource{Image: &corev1.ImageVolumeSource{Reference: w.OCIImage.Reference}})
 		}
 	}
 	return v
@@ -160,7 +162,8 @@ func Apply(ctx context.Context, ts v1.TaskSpec, wb []v1.WorkspaceBinding, v map[
 			Name:      vv.Name,
 			MountPath: w.GetMountPath(),
 			SubPath:   wb[i].SubPath,
-			ReadOnly:  w.ReadOnly,
+			// The content of OCI images can't be written to, as image volumes are always read-only.
+			ReadOnly: w.ReadOnly || wb[i].OCIImage != nil,
 		}
 
 		if isolatedWorkspaces.Has(w.Name) {
@@ -349,6 +352,10 @@ func replaceWorkspaceBindingVars(wb *v1.WorkspaceBinding, replacements map[strin
 	if wb.CSI != nil {
 		wb.CSI = applyCSIVolumeSource(wb.CSI, replacements)
 	}
+	if wb.OCIImage != nil {
+		wb.OCIImage.Reference = substitution.ApplyReplacements(wb.OCIImage.Reference, replacements)
+		wb.OCIImage.PullSecret = substitution.ApplyReplacements(wb.OCIImage.PullSecret, replacements)
+	}
 	return wb
 }
 
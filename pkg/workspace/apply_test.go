@@ -54,6 +54,23 @@ func TestCreateVolumes(t *testing.T) {
 				},
 			},
 		},
+	}, {
+		name: "binding a single workspace with an OCI image",
+		workspaces: []v1.WorkspaceBinding{{
+			Name: "custom",
+			OCIImage: &v1.OCIImageWorkspaceSource{
+				Reference:  "registry.example.com/config:v1",
+				PullSecret: "registry-creds",
+			},
+		}},
+		expectedVolumes: map[string]corev1.Volume{
+			"custom": {
+				Name: "ws-20573",
+				VolumeSource: corev1.VolumeSource{
+					Image: &corev1.ImageVolumeSource{Reference: "registry.example.com/config:v1"},
+				},
+			},
+		},
 	}, {
 		name: "binding a single workspace with emptyDir",
 		workspaces: []v1.WorkspaceBinding{{
@@ -738,6 +755,35 @@ func TestApply(t *testing.T) {
 				ReadOnly:  true,
 			}},
 		},
+	}, {
+		name: "binding a workspace with an OCI image is read-only",
+		ts: v1.TaskSpec{
+			Workspaces: []v1.WorkspaceDeclaration{{
+				Name: "custom",
+			}},
+		},
+		workspaces: []v1.WorkspaceBinding{{
+			Name:     "custom",
+			OCIImage: &v1.OCIImageWorkspaceSource{Reference: "registry.example.com/config:v1"},
+		}},
+		expectedTaskSpec: v1.TaskSpec{
+			StepTemplate: &v1.StepTemplate{
+				VolumeMounts: []corev1.VolumeMount{{
+					Name:      "ws-20573",
+					MountPath: "/workspace/custom",
+					ReadOnly:  true,
+				}},
+			},
+			Volumes: []corev1.Volume{{
+				Name: "ws-20573",
+				VolumeSource: corev1.VolumeSource{
+					Image: &corev1.ImageVolumeSource{Reference: "registry.example.com/config:v1"},
+				},
+			}},
+			Workspaces: []v1.WorkspaceDeclaration{{
+				Name: "custom",
+			}},
+		},
 	}} {
 		t.Run(tc.name, func(t *testing.T) {
 			vols := workspace.CreateVolumes(tc.workspaces)
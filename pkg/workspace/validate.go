@@ -20,6 +20,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 
 	pipelineErrors "github.com/tektoncd/pipeline/pkg/apis/pipeline/errors"
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
@@ -39,8 +40,12 @@ func ValidateBindings(ctx context.Context, decls []v1.WorkspaceDeclaration, bind
 
 	declNames := sets.NewString()
 	bindNames := sets.NewString()
+	readOnlyDeclNames := sets.NewString()
 	for _, decl := range decls {
 		declNames.Insert(decl.Name)
+		if decl.ReadOnly {
+			readOnlyDeclNames.Insert(decl.Name)
+		}
 	}
 	for _, bind := range binds {
 		bindNames.Insert(bind.Name)
@@ -58,6 +63,9 @@ func ValidateBindings(ctx context.Context, decls []v1.WorkspaceDeclaration, bind
 		if !declNames.Has(bind.Name) {
 			return pipelineErrors.WrapUserError(fmt.Errorf("workspace binding %q does not match any declared workspace", bind.Name))
 		}
+		if bind.OCIImage != nil && !readOnlyDeclNames.Has(bind.Name) {
+			return pipelineErrors.WrapUserError(fmt.Errorf("workspace binding %q binds an OCI image to a workspace which isn't readOnly", bind.Name))
+		}
 	}
 
 	return nil
@@ -84,3 +92,23 @@ func ValidateOnlyOnePVCIsUsed(wb []v1.WorkspaceBinding) error {
 	}
 	return nil
 }
+
+// DedupeBindings returns the workspace bindings in wb with only the first binding of each workspace,
+// along with the names of the workspaces bound more than once, in the order of their first binding.
+func DedupeBindings(wb []v1.WorkspaceBinding) ([]v1.WorkspaceBinding, []string) {
+	var duplicates []string
+	bound := sets.NewString()
+	deduped := make([]v1.WorkspaceBinding, 0, len(wb))
+	for _, w := range wb {
+		if !bound.Has(w.Name) {
+			bound.Insert(w.Name)
+			deduped = append(deduped, w)
+		} else if !slices.Contains(duplicates, w.Name) {
+			duplicates = append(duplicates, w.Name)
+		}
+	}
+	if len(duplicates) == 0 {
+		return wb, nil
+	}
+	return deduped, duplicates
+}
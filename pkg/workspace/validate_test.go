@@ -20,8 +20,11 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
+	cfgtesting "github.com/tektoncd/pipeline/pkg/apis/config/testing"
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	workspace "github.com/tektoncd/pipeline/pkg/workspace"
+	"github.com/tektoncd/pipeline/test/diff"
 	corev1 "k8s.io/api/core/v1"
 )
 
@@ -160,6 +163,35 @@ func TestValidateBindingsInvalid(t *testing.T) {
 	}
 }
 
+func TestValidateBindings_OCIImage(t *testing.T) {
+	ociImage := []v1.WorkspaceBinding{{
+		Name:     "config",
+		OCIImage: &v1.OCIImageWorkspaceSource{Reference: "registry.example.com/config:v1"},
+	}}
+	for _, tc := range []struct {
+		name         string
+		declarations []v1.WorkspaceDeclaration
+		wantErr      string
+	}{{
+		name:         "bound to a readOnly workspace",
+		declarations: []v1.WorkspaceDeclaration{{Name: "config", ReadOnly: true}},
+	}, {
+		name:         "bound to a writable workspace",
+		declarations: []v1.WorkspaceDeclaration{{Name: "config"}},
+		wantErr:      `workspace binding "config" binds an OCI image to a workspace which isn't readOnly`,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			gotErr := ""
+			if err := workspace.ValidateBindings(cfgtesting.EnableAlphaAPIFields(t.Context()), tc.declarations, ociImage); err != nil {
+				gotErr = err.Error()
+			}
+			if gotErr != tc.wantErr {
+				t.Errorf("ValidateBindings() = %q, want %q", gotErr, tc.wantErr)
+			}
+		})
+	}
+}
+
 func TestValidateOnlyOnePVCIsUsed_Valid(t *testing.T) {
 	for _, tc := range []struct {
 		name     string
@@ -245,3 +277,46 @@ func TestValidateOnlyOnePVCIsUsed_Invalid(t *testing.T) {
 		})
 	}
 }
+
+func TestDedupeBindings(t *testing.T) {
+	for _, tc := range []struct {
+		name           string
+		bindings       []v1.WorkspaceBinding
+		wantBindings   []v1.WorkspaceBinding
+		wantDuplicates []string
+	}{{
+		name: "no duplicate bindings",
+		bindings: []v1.WorkspaceBinding{
+			{Name: "a", EmptyDir: &corev1.EmptyDirVolumeSource{}},
+			{Name: "b", EmptyDir: &corev1.EmptyDirVolumeSource{}},
+		},
+		wantBindings: []v1.WorkspaceBinding{
+			{Name: "a", EmptyDir: &corev1.EmptyDirVolumeSource{}},
+			{Name: "b", EmptyDir: &corev1.EmptyDirVolumeSource{}},
+		},
+	}, {
+		name: "first binding wins",
+		bindings: []v1.WorkspaceBinding{
+			{Name: "a", EmptyDir: &corev1.EmptyDirVolumeSource{}},
+			{Name: "b", EmptyDir: &corev1.EmptyDirVolumeSource{}},
+			{Name: "a", Secret: &corev1.SecretVolumeSource{SecretName: "foo"}},
+			{Name: "b", Secret: &corev1.SecretVolumeSource{SecretName: "foo"}},
+			{Name: "a", Secret: &corev1.SecretVolumeSource{SecretName: "bar"}},
+		},
+		wantBindings: []v1.WorkspaceBinding{
+			{Name: "a", EmptyDir: &corev1.EmptyDirVolumeSource{}},
+			{Name: "b", EmptyDir: &corev1.EmptyDirVolumeSource{}},
+		},
+		wantDuplicates: []string{"a", "b"},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			gotBindings, gotDuplicates := workspace.DedupeBindings(tc.bindings)
+			if d := cmp.Diff(tc.wantBindings, gotBindings); d != "" {
+				t.Errorf("Unexpected bindings %s", diff.PrintWantGot(d))
+			}
+			if d := cmp.Diff(tc.wantDuplicates, gotDuplicates); d != "" {
+				t.Errorf("Unexpected duplicates %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
@@ -45,6 +45,8 @@ import (
 	fakeresolutionrequestclient "github.com/tektoncd/pipeline/pkg/client/resolution/injection/client/fake"
 	fakeresolutionrequestinformer "github.com/tektoncd/pipeline/pkg/client/resolution/injection/informers/resolution/v1beta1/resolutionrequest/fake"
 	cloudeventclient "github.com/tektoncd/pipeline/pkg/reconciler/events/cloudevent"
+	"github.com/tektoncd/pipeline/pkg/reconciler/namespaceconfigmap"
+	fakenamespaceconfigmapinformer "github.com/tektoncd/pipeline/pkg/reconciler/namespaceconfigmap/fake"
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
@@ -59,6 +61,8 @@ import (
 	fakekubeclient "knative.dev/pkg/client/injection/kube/client/fake"
 	fakeconfigmapinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/configmap/fake"
 	fakelimitrangeinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/limitrange/fake"
+	fakenamespaceinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/namespace/fake"
+	fakefilteredpvcinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/persistentvolumeclaim/filtered/fake"
 	fakefilteredpodinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/pod/filtered/fake"
 	fakesecretinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/secret/fake"
 	fakeserviceaccountinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/serviceaccount/fake"
@@ -76,6 +80,7 @@ type Data struct {
 	StepActions             []*v1beta1.StepAction
 	CustomRuns              []*v1beta1.CustomRun
 	Pods                    []*corev1.Pod
+	PVCs                    []*corev1.PersistentVolumeClaim
 	Namespaces              []*corev1.Namespace
 	ConfigMaps              []*corev1.ConfigMap
 	ServiceAccounts         []*corev1.ServiceAccount
@@ -104,9 +109,11 @@ type Informers struct {
 	Task               informersv1.TaskInformer
 	StepAction         informersv1beta1.StepActionInformer
 	Pod                coreinformers.PodInformer
+	PVC                coreinformers.PersistentVolumeClaimInformer
 	ConfigMap          coreinformers.ConfigMapInformer
 	ServiceAccount     coreinformers.ServiceAccountInformer
 	LimitRange         coreinformers.LimitRangeInformer
+	Namespace          coreinformers.NamespaceInformer
 	ResolutionRequest  resolutioninformersv1alpha1.ResolutionRequestInformer
 	VerificationPolicy informersv1alpha1.VerificationPolicyInformer
 	Secret             coreinformers.SecretInformer
@@ -167,6 +174,23 @@ func AddToInformer(t *testing.T, store cache.Store) func(ktesting.Action) (bool,
 	}
 }
 
+// addNamedToInformer returns a function to add the ktesting.Actions on the objects named name to
+// the cache store, like AddToInformer.
+func addNamedToInformer(t *testing.T, store cache.Store, name string) func(ktesting.Action) (bool, runtime.Object, error) {
+	t.Helper()
+	addToInformer := AddToInformer(t, store)
+	return func(action ktesting.Action) (bool, runtime.Object, error) {
+		a, ok := action.(interface{ GetObject() runtime.Object })
+		if !ok {
+			return false, nil, nil
+		}
+		if objMeta, err := meta.Accessor(a.GetObject()); err != nil || objMeta.GetName() != name {
+			return false, nil, nil
+		}
+		return addToInformer(action)
+	}
+}
+
 // SeedTestData returns Clients and Informers populated with the
 // given Data.
 //
@@ -190,9 +214,11 @@ func SeedTestData(t *testing.T, ctx context.Context, d Data) (Clients, Informers
 		Task:               faketaskinformer.Get(ctx),
 		StepAction:         fakestepactioninformer.Get(ctx),
 		Pod:                fakefilteredpodinformer.Get(ctx, v1.ManagedByLabelKey),
+		PVC:                fakefilteredpvcinformer.Get(ctx, v1.ManagedByLabelKey),
 		ConfigMap:          fakeconfigmapinformer.Get(ctx),
 		ServiceAccount:     fakeserviceaccountinformer.Get(ctx),
 		LimitRange:         fakelimitrangeinformer.Get(ctx),
+		Namespace:          fakenamespaceinformer.Get(ctx),
 		ResolutionRequest:  fakeresolutionrequestinformer.Get(ctx),
 		VerificationPolicy: fakeverificationpolicyinformer.Get(ctx),
 		Secret:             fakesecretinformer.Get(ctx),
@@ -250,6 +276,14 @@ func SeedTestData(t *testing.T, ctx context.Context, d Data) (Clients, Informers
 			t.Fatal(err)
 		}
 	}
+	c.Kube.PrependReactor("*", "persistentvolumeclaims", AddToInformer(t, i.PVC.Informer().GetIndexer()))
+	for _, pvc := range d.PVCs {
+		pvc := pvc.DeepCopy() // Avoid assumptions that the informer's copy is modified.
+		if _, err := c.Kube.CoreV1().PersistentVolumeClaims(pvc.Namespace).Create(ctx, pvc, metav1.CreateOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	c.Kube.PrependReactor("*", "namespaces", AddToInformer(t, i.Namespace.Informer().GetIndexer()))
 	for _, n := range d.Namespaces {
 		n := n.DeepCopy() // Avoid assumptions that the informer's copy is modified.
 		if _, err := c.Kube.CoreV1().Namespaces().Create(ctx, n, metav1.CreateOptions{}); err != nil {
@@ -257,6 +291,10 @@ func SeedTestData(t *testing.T, ctx context.Context, d Data) (Clients, Informers
 		}
 	}
 	c.Kube.PrependReactor("*", "configmaps", AddToInformer(t, i.ConfigMap.Informer().GetIndexer()))
+	// The ConfigMaps configuring the runs of the namespaces are also added to the informers of their names.
+	for _, name := range namespaceconfigmap.Names {
+		c.Kube.PrependReactor("*", "configmaps", addNamedToInformer(t, fakenamespaceconfigmapinformer.Get(ctx, name).Informer().GetIndexer(), name))
+	}
 	for _, cm := range d.ConfigMaps {
 		cm := cm.DeepCopy() // Avoid assumptions that the informer's copy is modified.
 		if _, err := c.Kube.CoreV1().ConfigMaps(cm.Namespace).Create(ctx, cm, metav1.CreateOptions{}); err != nil {
@@ -0,0 +1,98 @@
+//go:build e2e
+
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/test/parse"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/system"
+	knativetest "knative.dev/pkg/test"
+	"knative.dev/pkg/test/helpers"
+)
+
+// TestNamespaceFeatureFlags tests that a feature flag turned off in the cluster is turned on for the
+// TaskRuns of a namespace overriding it in its "tekton-feature-flags" ConfigMap.
+// @test:execution=parallel
+func TestNamespaceFeatureFlags(t *testing.T) {
+	ctx := t.Context()
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	c, namespace := setup(ctx, t, requireClusterFlagOff(config.KeepPodOnCancel))
+	t.Parallel()
+	knativetest.CleanupOnInterrupt(func() { tearDown(ctx, t, c, namespace) }, t.Logf)
+	defer tearDown(ctx, t, c, namespace)
+
+	t.Logf("Overriding the feature flags of namespace %q", namespace)
+	if _, err := c.KubeClient.CoreV1().ConfigMaps(namespace).Create(ctx, &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: config.NamespaceFeatureFlagsConfigMapName, Namespace: namespace},
+		Data: map[string]string{
+			config.KeepPodOnCancel: "true",
+		},
+	}, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("Failed to create ConfigMap %s: %s", config.NamespaceFeatureFlagsConfigMapName, err)
+	}
+
+	tr, err := c.V1TaskRunClient.Create(ctx, parse.MustParseV1TaskRun(t, fmt.Sprintf(`
+metadata:
+  name: %s
+  namespace: %s
+spec:
+  taskSpec:
+    steps:
+    - image: mirror.gcr.io/busybox
+      script: echo hello
+`, helpers.ObjectNameForTest(t), namespace)), metav1.CreateOptions{})
+	if err != nil {
+		t.Fatalf("Error creating TaskRun: %v", err)
+	}
+	if err := WaitForTaskRunState(ctx, c, tr.Name, TaskRunSucceed(tr.Name), "TaskRunSuccess", v1Version); err != nil {
+		t.Fatalf("Error waiting for TaskRun to succeed: %v", err)
+	}
+	tr, err = c.V1TaskRunClient.Get(ctx, tr.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Error getting TaskRun: %v", err)
+	}
+	if tr.Status.Provenance == nil || tr.Status.Provenance.FeatureFlags == nil {
+		t.Fatalf("Expected the TaskRun provenance to record its feature flags, got %v", tr.Status.Provenance)
+	}
+	if !tr.Status.Provenance.FeatureFlags.EnableKeepPodOnCancel {
+		t.Errorf("Expected the TaskRun to be reconciled with %s overridden by its namespace", config.KeepPodOnCancel)
+	}
+}
+
+// requireClusterFlagOff returns a setup func that will skip the current test if the boolean
+// feature flag named flag is turned on in the feature-flags ConfigMap.
+func requireClusterFlagOff(flag string) func(context.Context, *testing.T, *clients, string) {
+	return func(ctx context.Context, t *testing.T, c *clients, namespace string) {
+		t.Helper()
+		featureFlagsCM, err := c.KubeClient.CoreV1().ConfigMaps(system.Namespace()).Get(ctx, config.GetFeatureFlagsConfigName(), metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("Failed to get ConfigMap `%s`: %s", config.GetFeatureFlagsConfigName(), err)
+		}
+		if value := featureFlagsCM.Data[flag]; value == "true" {
+			t.Skipf("Feature flag %q is turned on in the cluster", flag)
+		}
+	}
+}